@@ -66,3 +66,20 @@ func IsRepoOwner(
 
 	return err == nil, nil
 }
+
+// RepoPermissionChecker returns a function reporting whether the principal of the session
+// is granted the permission on the repository.
+func RepoPermissionChecker(
+	authorizer authz.Authorizer,
+	session *auth.Session,
+	repo *types.Repository,
+) func(ctx context.Context, permission enum.Permission) (bool, error) {
+	return func(ctx context.Context, permission enum.Permission) (bool, error) {
+		err := CheckRepo(ctx, authorizer, session, repo, permission)
+		if err != nil && !errors.Is(err, ErrNotAuthorized) {
+			return false, fmt.Errorf("failed to check user access: %w", err)
+		}
+
+		return err == nil, nil
+	}
+}
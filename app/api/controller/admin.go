@@ -0,0 +1,40 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/gotidy/ptr"
+)
+
+// IsLastActiveAdmin returns true if the user is the only admin that isn't blocked.
+// It's used to prevent removing the last admin, e.g. by blocking, demoting or deleting it.
+func IsLastActiveAdmin(ctx context.Context, principalStore store.PrincipalStore, user *types.User) (bool, error) {
+	if !user.Admin || user.Blocked {
+		return false, nil
+	}
+
+	admUsrCount, err := principalStore.CountUsers(ctx, &types.UserFilter{Admin: true, Blocked: ptr.Bool(false)})
+	if err != nil {
+		return false, fmt.Errorf("failed to check admin user count: %w", err)
+	}
+
+	return admUsrCount <= 1, nil
+}
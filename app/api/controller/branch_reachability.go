@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+)
+
+// BranchAncestryReader is the subset of git operations required for checking reachability of commits from branches.
+type BranchAncestryReader interface {
+	ListBranches(ctx context.Context, params *git.ListBranchesParams) (*git.ListBranchesOutput, error)
+	AreAncestors(ctx context.Context, params git.AreAncestorsParams) (git.AreAncestorsOutput, error)
+}
+
+// ReachableFromBranches returns a function reporting whether a commit is reachable
+// from any of the repository branches accepted by the filter.
+func ReachableFromBranches(
+	gitReader BranchAncestryReader,
+	readParams git.ReadParams,
+) func(ctx context.Context, commitSHA string, filter func(branch string) bool) (bool, error) {
+	return func(ctx context.Context, commitSHA string, filter func(branch string) bool) (bool, error) {
+		commit, err := sha.New(commitSHA)
+		if err != nil {
+			return false, fmt.Errorf("invalid commit sha: %w", err)
+		}
+
+		branchesOut, err := gitReader.ListBranches(ctx, &git.ListBranchesParams{
+			ReadParams: readParams,
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to list branches: %w", err)
+		}
+
+		checks := make([]git.AncestorCheck, 0, len(branchesOut.Branches))
+		for _, branch := range branchesOut.Branches {
+			if !filter(branch.Name) {
+				continue
+			}
+
+			checks = append(checks, git.AncestorCheck{
+				AncestorCommitSHA:   commit,
+				DescendantCommitSHA: branch.SHA,
+			})
+		}
+
+		if len(checks) == 0 {
+			return false, nil
+		}
+
+		ancestryOut, err := gitReader.AreAncestors(ctx, git.AreAncestorsParams{
+			ReadParams: readParams,
+			Checks:     checks,
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to check commit ancestry: %w", err)
+		}
+
+		for _, isAncestor := range ancestryOut.Ancestors {
+			if isAncestor {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// CombinedStatus returns the combined status of the latest status check results for a commit in a repository.
+// Optional status checks are taken from the protection rules of the default branch.
+func (c *Controller) CombinedStatus(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	commitSHA string,
+) (types.CombinedCheckStatus, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return types.CombinedCheckStatus{}, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	return c.combinedStatus(ctx, session, repo, commitSHA, "", repo.DefaultBranch)
+}
+
+// RefStatus returns the combined status of the latest status check results for the tip of a git reference.
+// References without the "refs/" prefix are considered to be branches.
+// Optional status checks are taken from the protection rules of the branch,
+// or of the default branch in case the reference isn't a branch.
+func (c *Controller) RefStatus(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	gitRef string,
+) (types.CombinedCheckStatus, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return types.CombinedCheckStatus{}, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if !strings.HasPrefix(gitRef, "refs/") {
+		gitRef, err = git.GetRefPath(gitRef, gitenum.RefTypeBranch)
+		if err != nil {
+			return types.CombinedCheckStatus{}, err
+		}
+	}
+
+	branch, ok := strings.CutPrefix(gitRef, "refs/heads/")
+	if !ok {
+		branch = repo.DefaultBranch
+	}
+
+	return c.combinedStatus(ctx, session, repo, gitRef, gitRef, branch)
+}
+
+func (c *Controller) combinedStatus(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	rev string,
+	gitRef string,
+	branch string,
+) (types.CombinedCheckStatus, error) {
+	commit, err := c.git.GetCommit(ctx, &git.GetCommitParams{
+		ReadParams: git.CreateReadParams(repo),
+		Revision:   rev,
+	})
+	if err != nil {
+		return types.CombinedCheckStatus{}, fmt.Errorf("failed to get commit: %w", err)
+	}
+
+	commitSHA := commit.Commit.SHA.String()
+
+	isRepoOwner, err := apiauth.IsRepoOwner(ctx, c.authorizer, session, repo)
+	if err != nil {
+		return types.CombinedCheckStatus{}, fmt.Errorf("failed to determine if user is repo owner: %w", err)
+	}
+
+	protectionRules, err := c.protectionManager.ForRepository(ctx, repo.ID)
+	if err != nil {
+		return types.CombinedCheckStatus{},
+			fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	reqChecks, err := protectionRules.RequiredChecks(ctx, protection.RequiredChecksInput{
+		Actor:       &session.Principal,
+		IsRepoOwner: isRepoOwner,
+		Repo:        repo,
+		Branch:      branch,
+	})
+	if err != nil {
+		return types.CombinedCheckStatus{}, fmt.Errorf("failed to get identifiers of optional checks: %w", err)
+	}
+
+	checks, err := c.checkStore.List(ctx, repo.ID, commitSHA, types.CheckListOptions{})
+	if err != nil {
+		return types.CombinedCheckStatus{}, fmt.Errorf("failed to list status check results for repo: %w", err)
+	}
+
+	result := combineChecks(checks, reqChecks.OptionalIdentifiers)
+	result.CommitSHA = commitSHA
+	result.Ref = gitRef
+
+	return result, nil
+}
+
+// combineChecks rolls up the status of the provided checks.
+// The status is success only if all non-optional checks succeeded, pending if any of them is pending or running
+// (or if there are none) and failure otherwise. The counts include the optional checks.
+func combineChecks(checks []types.Check, optional map[string]struct{}) types.CombinedCheckStatus {
+	result := types.CombinedCheckStatus{
+		Checks: make([]types.CombinedCheck, 0, len(checks)),
+	}
+
+	var total, succeeded, pending int
+	for _, check := range checks {
+		_, isOptional := optional[check.Identifier]
+
+		result.Checks = append(result.Checks, types.CombinedCheck{
+			Optional: isOptional,
+			Check:    check,
+		})
+
+		switch check.Status {
+		case enum.CheckStatusPending:
+			result.Counts.Pending++
+		case enum.CheckStatusRunning:
+			result.Counts.Running++
+		case enum.CheckStatusSuccess:
+			result.Counts.Success++
+		case enum.CheckStatusFailure:
+			result.Counts.Failure++
+		case enum.CheckStatusError:
+			result.Counts.Error++
+		}
+
+		if isOptional {
+			continue
+		}
+
+		total++
+		switch {
+		case check.Status == enum.CheckStatusSuccess:
+			succeeded++
+		case !check.Status.IsCompleted():
+			pending++
+		}
+	}
+
+	switch {
+	case total == 0 || pending > 0:
+		result.Status = enum.CheckStatusPending
+	case succeeded == total:
+		result.Status = enum.CheckStatusSuccess
+	default:
+		result.Status = enum.CheckStatusFailure
+	}
+
+	return result
+}
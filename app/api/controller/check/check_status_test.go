@@ -0,0 +1,110 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func Test_combineChecks(t *testing.T) {
+	check := func(identifier string, status enum.CheckStatus) types.Check {
+		return types.Check{Identifier: identifier, Status: status}
+	}
+
+	tests := []struct {
+		name       string
+		checks     []types.Check
+		optional   map[string]struct{}
+		wantStatus enum.CheckStatus
+		wantCounts types.CheckStatusCounts
+	}{
+		{
+			name:       "no checks",
+			checks:     nil,
+			wantStatus: enum.CheckStatusPending,
+		},
+		{
+			name: "all succeeded",
+			checks: []types.Check{
+				check("a", enum.CheckStatusSuccess),
+				check("b", enum.CheckStatusSuccess),
+			},
+			wantStatus: enum.CheckStatusSuccess,
+			wantCounts: types.CheckStatusCounts{Success: 2},
+		},
+		{
+			name: "pending wins over failure",
+			checks: []types.Check{
+				check("a", enum.CheckStatusFailure),
+				check("b", enum.CheckStatusRunning),
+				check("c", enum.CheckStatusSuccess),
+			},
+			wantStatus: enum.CheckStatusPending,
+			wantCounts: types.CheckStatusCounts{Running: 1, Failure: 1, Success: 1},
+		},
+		{
+			name: "error is failure",
+			checks: []types.Check{
+				check("a", enum.CheckStatusError),
+				check("b", enum.CheckStatusSuccess),
+			},
+			wantStatus: enum.CheckStatusFailure,
+			wantCounts: types.CheckStatusCounts{Error: 1, Success: 1},
+		},
+		{
+			name: "optional ignored",
+			checks: []types.Check{
+				check("a", enum.CheckStatusFailure),
+				check("b", enum.CheckStatusPending),
+				check("c", enum.CheckStatusSuccess),
+			},
+			optional:   map[string]struct{}{"a": {}, "b": {}},
+			wantStatus: enum.CheckStatusSuccess,
+			wantCounts: types.CheckStatusCounts{Pending: 1, Failure: 1, Success: 1},
+		},
+		{
+			name: "only optional",
+			checks: []types.Check{
+				check("a", enum.CheckStatusSuccess),
+			},
+			optional:   map[string]struct{}{"a": {}},
+			wantStatus: enum.CheckStatusPending,
+			wantCounts: types.CheckStatusCounts{Success: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := combineChecks(tt.checks, tt.optional)
+			if got.Status != tt.wantStatus {
+				t.Errorf("status: want=%s got=%s", tt.wantStatus, got.Status)
+			}
+			if got.Counts != tt.wantCounts {
+				t.Errorf("counts: want=%+v got=%+v", tt.wantCounts, got.Counts)
+			}
+			if len(got.Checks) != len(tt.checks) {
+				t.Errorf("checks: want=%d got=%d", len(tt.checks), len(got.Checks))
+			}
+			for _, c := range got.Checks {
+				if _, want := tt.optional[c.Check.Identifier]; c.Optional != want {
+					t.Errorf("optional %s: want=%t got=%t", c.Check.Identifier, want, c.Optional)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+)
+
+// Delete unsubscribes the current user from activity digests.
+func (c *Controller) Delete(
+	ctx context.Context,
+	session *auth.Session,
+) error {
+	if err := c.subscriptionStore.Delete(ctx, session.Principal.ID); err != nil {
+		return fmt.Errorf("failed to delete digest subscription: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+)
+
+// Preview renders the activity digest of the current user for the current period,
+// regardless of whether it would be sent.
+func (c *Controller) Preview(
+	ctx context.Context,
+	session *auth.Session,
+) ([]byte, error) {
+	sub, err := c.subscriptionStore.Find(ctx, session.Principal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find digest subscription: %w", err)
+	}
+
+	now := time.Now()
+	digest, err := c.digestService.Generate(ctx, &session.Principal, sub, now.Add(-sub.Frequency.Period()), now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate digest: %w", err)
+	}
+
+	body, err := c.digestService.Render(digest, sub.Frequency)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render digest: %w", err)
+	}
+
+	return body, nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const maxSubscribedResources = 100
+
+type UpdateInput struct {
+	Frequency enum.DigestFrequency `json:"frequency"`
+	SpaceIDs  []int64              `json:"space_ids"`
+	RepoIDs   []int64              `json:"repo_ids"`
+}
+
+func (in *UpdateInput) sanitize() error {
+	frequency, ok := in.Frequency.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("Invalid digest frequency: %s", in.Frequency)
+	}
+	in.Frequency = frequency
+
+	in.SpaceIDs = deduplicate(in.SpaceIDs)
+	in.RepoIDs = deduplicate(in.RepoIDs)
+
+	if len(in.SpaceIDs)+len(in.RepoIDs) == 0 {
+		return usererror.BadRequest("At least one space or repository is required")
+	}
+
+	if len(in.SpaceIDs) > maxSubscribedResources || len(in.RepoIDs) > maxSubscribedResources {
+		return usererror.BadRequestf("At most %d spaces and %d repositories can be subscribed to",
+			maxSubscribedResources, maxSubscribedResources)
+	}
+
+	return nil
+}
+
+// Update subscribes the current user to activity digests of the provided spaces and repositories,
+// or replaces the existing subscription.
+func (c *Controller) Update(
+	ctx context.Context,
+	session *auth.Session,
+	in *UpdateInput,
+) (*types.DigestSubscription, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	for _, spaceID := range in.SpaceIDs {
+		space, err := c.spaceStore.Find(ctx, spaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space %d: %w", spaceID, err)
+		}
+
+		if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, repoID := range in.RepoIDs {
+		repo, err := c.repoStore.Find(ctx, repoID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find repo %d: %w", repoID, err)
+		}
+
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now().UnixMilli()
+	sub := &types.DigestSubscription{
+		PrincipalID: session.Principal.ID,
+		Frequency:   in.Frequency,
+		SpaceIDs:    in.SpaceIDs,
+		RepoIDs:     in.RepoIDs,
+		LastSent:    now,
+		Created:     now,
+		Updated:     now,
+	}
+
+	if err := c.subscriptionStore.Upsert(ctx, sub); err != nil {
+		return nil, fmt.Errorf("failed to upsert digest subscription: %w", err)
+	}
+
+	return sub, nil
+}
+
+func deduplicate(ids []int64) []int64 {
+	seen := make(map[int64]struct{}, len(ids))
+	result := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		result = append(result, id)
+	}
+
+	return result
+}
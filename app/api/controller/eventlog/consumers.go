@@ -0,0 +1,90 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventlog
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/stream"
+)
+
+var errEventLogDisabled = usererror.BadRequest("Events are not stored in the durable event log.")
+
+type ReplayInput struct {
+	GroupName string `json:"group_name"`
+	StreamID  string `json:"stream_id"`
+	Offset    int64  `json:"offset"`
+}
+
+func (in *ReplayInput) sanitize() error {
+	in.GroupName = strings.TrimSpace(in.GroupName)
+	in.StreamID = strings.TrimSpace(in.StreamID)
+
+	if in.GroupName == "" {
+		return usererror.BadRequest("Consumer group name is required.")
+	}
+	if in.StreamID == "" {
+		return usererror.BadRequest("Stream ID is required.")
+	}
+	if in.Offset < 0 {
+		return usererror.BadRequest("Offset can't be negative.")
+	}
+
+	return nil
+}
+
+// ListConsumers returns the offsets of all consumer groups and how far they are behind their streams.
+func (c *Controller) ListConsumers(ctx context.Context, session *auth.Session) ([]stream.ConsumerLag, error) {
+	if !session.Principal.Admin {
+		return nil, usererror.ErrForbidden
+	}
+
+	if !c.enabled {
+		return []stream.ConsumerLag{}, nil
+	}
+
+	lags, err := c.logStore.ListLag(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list event consumer lag: %w", err)
+	}
+
+	return lags, nil
+}
+
+// Replay resets the offset of a consumer group on a stream,
+// all events after the offset are processed again by the consumer group.
+func (c *Controller) Replay(ctx context.Context, session *auth.Session, in *ReplayInput) error {
+	if !session.Principal.Admin {
+		return usererror.ErrForbidden
+	}
+
+	if !c.enabled {
+		return errEventLogDisabled
+	}
+
+	if err := in.sanitize(); err != nil {
+		return err
+	}
+
+	if err := c.logStore.Reset(ctx, in.GroupName, in.StreamID, in.Offset); err != nil {
+		return fmt.Errorf("failed to reset event consumer offset: %w", err)
+	}
+
+	return nil
+}
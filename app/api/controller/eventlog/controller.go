@@ -0,0 +1,35 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventlog
+
+import (
+	"github.com/harness/gitness/stream"
+)
+
+type Controller struct {
+	logStore stream.LogStore
+	// enabled is true if events are stored in the durable event log.
+	enabled bool
+}
+
+func NewController(
+	logStore stream.LogStore,
+	enabled bool,
+) *Controller {
+	return &Controller{
+		logStore: logStore,
+		enabled:  enabled,
+	}
+}
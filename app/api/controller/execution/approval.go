@@ -0,0 +1,153 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// StageApprovalInput is the input for approving or rejecting a stage.
+type StageApprovalInput struct {
+	Comment string `json:"comment"`
+}
+
+func (in *StageApprovalInput) sanitize() {
+	in.Comment = strings.TrimSpace(in.Comment)
+}
+
+// Approve approves a stage that is waiting for approval and schedules it for execution.
+func (c *Controller) Approve(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	stageNum int64,
+	in *StageApprovalInput,
+) (*types.StageApproval, error) {
+	return c.decide(ctx, session, repoRef, pipelineIdentifier, executionNum, stageNum,
+		enum.StageApprovalDecisionApproved, in)
+}
+
+// Reject rejects a stage that is waiting for approval and fails it.
+func (c *Controller) Reject(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	stageNum int64,
+	in *StageApprovalInput,
+) (*types.StageApproval, error) {
+	return c.decide(ctx, session, repoRef, pipelineIdentifier, executionNum, stageNum,
+		enum.StageApprovalDecisionRejected, in)
+}
+
+// ListApprovals lists all approval decisions made for stages of an execution.
+func (c *Controller) ListApprovals(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+) ([]*types.StageApproval, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+
+	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path, pipelineIdentifier, enum.PermissionPipelineView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize: %w", err)
+	}
+
+	pipeline, err := c.pipelineStore.FindByIdentifier(ctx, repo.ID, pipelineIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	execution, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find execution %d: %w", executionNum, err)
+	}
+
+	approvals, err := c.stageApprovalStore.List(ctx, execution.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stage approvals: %w", err)
+	}
+
+	return approvals, nil
+}
+
+func (c *Controller) decide(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	stageNum int64,
+	decision enum.StageApprovalDecision,
+	in *StageApprovalInput,
+) (*types.StageApproval, error) {
+	in.sanitize()
+
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+
+	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path, pipelineIdentifier, enum.PermissionPipelineExecute)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize: %w", err)
+	}
+
+	pipeline, err := c.pipelineStore.FindByIdentifier(ctx, repo.ID, pipelineIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	execution, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find execution %d: %w", executionNum, err)
+	}
+
+	stage, err := c.stageStore.FindByNumber(ctx, execution.ID, int(stageNum))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stage %d: %w", stageNum, err)
+	}
+
+	canApprove, err := c.approver.CanApprove(ctx, &session.Principal, repo, stage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check stage approvers: %w", err)
+	}
+	if !canApprove {
+		return nil, usererror.Forbidden("Not allowed to decide on the approval of this stage")
+	}
+
+	approval, err := c.approver.Decide(ctx, &session.Principal, repo, stage, decision, in.Comment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decide on stage approval: %w", err)
+	}
+
+	return approval, nil
+}
@@ -0,0 +1,261 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/blob"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	artifactBlobPathFmt   = "artifacts/%d/%s"
+	artifactPeekBytes     = 512
+	artifactMaxNameLength = 255
+)
+
+var (
+	errArtifactExecutionFinished = usererror.Conflict(
+		"Artifacts can't be uploaded after the execution finished.")
+	errArtifactExists = usererror.Conflict(
+		"An artifact with the same name already exists for the execution.")
+)
+
+// UploadArtifact stores the provided file as an artifact of the execution.
+// Artifacts can only be uploaded while the execution is pending or running.
+func (c *Controller) UploadArtifact(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	name string,
+	file io.Reader,
+) (*types.Artifact, error) {
+	name, err := sanitizeArtifactName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	repo, execution, err := c.findExecutionCheckAccess(ctx, session, repoRef, pipelineIdentifier, executionNum,
+		enum.PermissionPipelineExecute)
+	if err != nil {
+		return nil, err
+	}
+
+	if execution.Status.IsDone() {
+		return nil, errArtifactExecutionFinished
+	}
+
+	_, err = c.artifactStore.FindByName(ctx, execution.ID, name)
+	if err == nil {
+		return nil, errArtifactExists
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to check for existing artifact: %w", err)
+	}
+
+	if file == nil {
+		return nil, usererror.BadRequest("No file provided.")
+	}
+
+	// read at most one byte more than allowed to detect files exceeding the limit.
+	reader := bufio.NewReader(io.LimitReader(file, c.artifactMaxSize+1))
+	head, err := reader.Peek(artifactPeekBytes)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	hash := sha256.New()
+	var size byteCounter
+	blobPath := fmt.Sprintf(artifactBlobPathFmt, execution.RepoID, uuid.New().String())
+
+	err = c.blobStore.Upload(ctx, io.TeeReader(reader, io.MultiWriter(hash, &size)), blobPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload artifact: %w", err)
+	}
+
+	if int64(size) > c.artifactMaxSize {
+		c.deleteArtifactBlob(ctx, blobPath)
+		return nil, usererror.BadRequestf("Artifact exceeds the maximum size of %d bytes.", c.artifactMaxSize)
+	}
+
+	artifact := &types.Artifact{
+		ExecutionID: execution.ID,
+		RepoID:      execution.RepoID,
+		Name:        name,
+		Size:        int64(size),
+		Checksum:    hex.EncodeToString(hash.Sum(nil)),
+		ContentType: mimetype.Detect(head).String(),
+		BlobPath:    blobPath,
+		CreatedBy:   session.Principal.ID,
+		Created:     time.Now().UnixMilli(),
+	}
+
+	err = c.artifactStore.Create(ctx, artifact)
+	if err != nil {
+		c.deleteArtifactBlob(ctx, blobPath)
+		if errors.Is(err, gitness_store.ErrDuplicate) {
+			return nil, errArtifactExists
+		}
+		return nil, fmt.Errorf("failed to create artifact: %w", err)
+	}
+
+	c.quotaWarner.Record(ctx, repo.ParentID)
+
+	return artifact, nil
+}
+
+// ListArtifacts lists all artifacts of the execution.
+func (c *Controller) ListArtifacts(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+) ([]*types.Artifact, error) {
+	_, execution, err := c.findExecutionCheckAccess(ctx, session, repoRef, pipelineIdentifier, executionNum,
+		enum.PermissionPipelineView)
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts, err := c.artifactStore.List(ctx, execution.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list artifacts: %w", err)
+	}
+
+	return artifacts, nil
+}
+
+// DownloadArtifact returns either a signed URL of the artifact (if supported by the blob store)
+// or a reader of the artifact content.
+func (c *Controller) DownloadArtifact(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	name string,
+) (*types.Artifact, string, io.ReadCloser, error) {
+	_, execution, err := c.findExecutionCheckAccess(ctx, session, repoRef, pipelineIdentifier, executionNum,
+		enum.PermissionPipelineView)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	artifact, err := c.artifactStore.FindByName(ctx, execution.ID, name)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to find artifact: %w", err)
+	}
+
+	signedURL, err := c.blobStore.GetSignedURL(ctx, artifact.BlobPath)
+	if err != nil && !errors.Is(err, blob.ErrNotSupported) {
+		return nil, "", nil, fmt.Errorf("failed to get signed URL: %w", err)
+	}
+
+	if signedURL != "" {
+		return artifact, signedURL, nil, nil
+	}
+
+	file, err := c.blobStore.Download(ctx, artifact.BlobPath)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to download artifact from blobstore: %w", err)
+	}
+
+	return artifact, "", file, nil
+}
+
+func (c *Controller) findExecutionCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	permission enum.Permission,
+) (*types.Repository, *types.Execution, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+
+	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path, pipelineIdentifier, permission)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to authorize: %w", err)
+	}
+
+	pipeline, err := c.pipelineStore.FindByIdentifier(ctx, repo.ID, pipelineIdentifier)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	execution, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find execution %d: %w", executionNum, err)
+	}
+
+	return repo, execution, nil
+}
+
+func (c *Controller) deleteArtifactBlob(ctx context.Context, blobPath string) {
+	if err := c.blobStore.Delete(ctx, blobPath); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("blob_path", blobPath).Msg("failed to delete artifact blob")
+	}
+}
+
+// sanitizeArtifactName validates the artifact name, which is a relative slash separated path.
+func sanitizeArtifactName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+
+	if name == "" {
+		return "", usererror.BadRequest("Artifact name is required.")
+	}
+	if len(name) > artifactMaxNameLength {
+		return "", usererror.BadRequestf("Artifact name can't be longer than %d characters.", artifactMaxNameLength)
+	}
+	if strings.Contains(name, "\\") || path.IsAbs(name) || path.Clean(name) != name ||
+		name == "." || name == ".." || strings.HasPrefix(name, "../") {
+		return "", usererror.BadRequest("Artifact name has to be a clean relative path.")
+	}
+
+	return name, nil
+}
+
+// byteCounter is an io.Writer that counts the number of bytes written to it.
+type byteCounter int64
+
+func (c *byteCounter) Write(p []byte) (int, error) {
+	*c += byteCounter(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import "testing"
+
+func TestSanitizeArtifactName(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{name: "coverage.out", want: "coverage.out"},
+		{name: " dist/app.tar.gz ", want: "dist/app.tar.gz"},
+		{name: "", wantErr: true},
+		{name: ".", wantErr: true},
+		{name: "..", wantErr: true},
+		{name: "../secret", wantErr: true},
+		{name: "dist/../../secret", wantErr: true},
+		{name: "/etc/passwd", wantErr: true},
+		{name: "dist//app", wantErr: true},
+		{name: "dist\\app", wantErr: true},
+	}
+
+	for _, test := range tests {
+		got, err := sanitizeArtifactName(test.name)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("%q: expected an error, got %q", test.name, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", test.name, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("%q: got %q, want %q", test.name, got, test.want)
+		}
+	}
+}
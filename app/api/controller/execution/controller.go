@@ -15,25 +15,34 @@
 package execution
 
 import (
+	"github.com/harness/gitness/app/api/controller/limiter"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/pipeline/approver"
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/triggerer"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/store/database/dbtx"
 )
 
 type Controller struct {
-	tx             dbtx.Transactor
-	authorizer     authz.Authorizer
-	executionStore store.ExecutionStore
-	checkStore     store.CheckStore
-	canceler       canceler.Canceler
-	commitService  commit.Service
-	triggerer      triggerer.Triggerer
-	repoStore      store.RepoStore
-	stageStore     store.StageStore
-	pipelineStore  store.PipelineStore
+	tx                 dbtx.Transactor
+	authorizer         authz.Authorizer
+	executionStore     store.ExecutionStore
+	checkStore         store.CheckStore
+	canceler           canceler.Canceler
+	commitService      commit.Service
+	triggerer          triggerer.Triggerer
+	repoStore          store.RepoStore
+	stageStore         store.StageStore
+	pipelineStore      store.PipelineStore
+	approver           approver.Approver
+	stageApprovalStore store.StageApprovalStore
+	artifactStore      store.ArtifactStore
+	blobStore          blob.Store
+	artifactMaxSize    int64
+	quotaWarner        *limiter.QuotaWarner
 }
 
 func NewController(
@@ -47,17 +56,29 @@ func NewController(
 	repoStore store.RepoStore,
 	stageStore store.StageStore,
 	pipelineStore store.PipelineStore,
+	approver approver.Approver,
+	stageApprovalStore store.StageApprovalStore,
+	artifactStore store.ArtifactStore,
+	blobStore blob.Store,
+	artifactMaxSize int64,
+	quotaWarner *limiter.QuotaWarner,
 ) *Controller {
 	return &Controller{
-		tx:             tx,
-		authorizer:     authorizer,
-		executionStore: executionStore,
-		checkStore:     checkStore,
-		canceler:       canceler,
-		commitService:  commitService,
-		triggerer:      triggerer,
-		repoStore:      repoStore,
-		stageStore:     stageStore,
-		pipelineStore:  pipelineStore,
+		tx:                 tx,
+		authorizer:         authorizer,
+		executionStore:     executionStore,
+		checkStore:         checkStore,
+		canceler:           canceler,
+		commitService:      commitService,
+		triggerer:          triggerer,
+		repoStore:          repoStore,
+		stageStore:         stageStore,
+		pipelineStore:      pipelineStore,
+		approver:           approver,
+		stageApprovalStore: stageApprovalStore,
+		artifactStore:      artifactStore,
+		blobStore:          blobStore,
+		artifactMaxSize:    artifactMaxSize,
+		quotaWarner:        quotaWarner,
 	}
 }
@@ -30,6 +30,7 @@ func (c *Controller) Find(
 	repoRef string,
 	pipelineIdentifier string,
 	executionNum int64,
+	axes map[string]string,
 ) (*types.Execution, error) {
 	repo, err := c.repoStore.FindByRef(ctx, repoRef)
 	if err != nil {
@@ -56,8 +57,9 @@ func (c *Controller) Find(
 			executionNum, err)
 	}
 
-	// Add stages information to the execution
-	execution.Stages = stages
+	// Add stages information to the execution, matrix cells can be filtered by their axis values.
+	execution.Stages = filterStagesByAxes(stages, axes)
+	execution.Matrices = groupMatrices(stages)
 
 	return execution, nil
 }
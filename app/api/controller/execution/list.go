@@ -23,6 +23,7 @@ import (
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+	"github.com/harness/gitness/types/listfilter"
 )
 
 func (c *Controller) List(
@@ -30,7 +31,7 @@ func (c *Controller) List(
 	session *auth.Session,
 	repoRef string,
 	pipelineIdentifier string,
-	pagination types.Pagination,
+	filter *listfilter.Filter,
 ) ([]*types.Execution, int64, error) {
 	repo, err := c.repoStore.FindByRef(ctx, repoRef)
 	if err != nil {
@@ -51,12 +52,12 @@ func (c *Controller) List(
 	var executions []*types.Execution
 
 	err = c.tx.WithTx(ctx, func(ctx context.Context) (err error) {
-		count, err = c.executionStore.Count(ctx, pipeline.ID)
+		count, err = c.executionStore.Count(ctx, pipeline.ID, filter)
 		if err != nil {
 			return fmt.Errorf("failed to count child executions: %w", err)
 		}
 
-		executions, err = c.executionStore.List(ctx, pipeline.ID, pagination)
+		executions, err = c.executionStore.List(ctx, pipeline.ID, filter)
 		if err != nil {
 			return fmt.Errorf("failed to list child executions: %w", err)
 		}
@@ -0,0 +1,99 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/harness/gitness/app/pipeline/converter/matrix"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// groupMatrices groups the stages that were expanded from the same matrix.
+func groupMatrices(stages []*types.Stage) []*types.ExecutionMatrix {
+	var matrices []*types.ExecutionMatrix
+	groups := map[string]*types.ExecutionMatrix{}
+	cells := map[string][]*types.Stage{}
+
+	for _, stage := range stages {
+		if stage.Matrix == nil {
+			continue
+		}
+
+		group, ok := groups[stage.Matrix.Group]
+		if !ok {
+			group = &types.ExecutionMatrix{
+				Group: stage.Matrix.Group,
+				Axes:  map[string][]string{},
+			}
+			groups[stage.Matrix.Group] = group
+			matrices = append(matrices, group)
+		}
+
+		for axis, value := range stage.Matrix.Axes {
+			if !slices.Contains(group.Axes[axis], value) {
+				group.Axes[axis] = append(group.Axes[axis], value)
+			}
+		}
+
+		group.Stages = append(group.Stages, stage.Number)
+		cells[group.Group] = append(cells[group.Group], stage)
+	}
+
+	for _, group := range matrices {
+		for _, values := range group.Axes {
+			sort.Strings(values)
+		}
+		group.Status = matrixStatus(cells[group.Group])
+	}
+
+	return matrices
+}
+
+// matrixStatus aggregates the status of the cells of a matrix.
+// Failed cells that are allowed to fail don't fail the matrix.
+func matrixStatus(cells []*types.Stage) enum.CIStatus {
+	status := enum.CIStatusSuccess
+	for _, cell := range cells {
+		switch {
+		case cell.Status == enum.CIStatusRunning:
+			return enum.CIStatusRunning
+		case !cell.Status.IsDone():
+			status = enum.CIStatusPending
+		case status == enum.CIStatusSuccess && cell.Status.IsFailed() && !cell.Matrix.AllowFailure:
+			status = cell.Status
+		}
+	}
+
+	return status
+}
+
+// filterStagesByAxes returns the matrix cells whose axis values match all the provided ones.
+func filterStagesByAxes(stages []*types.Stage, axes map[string]string) []*types.Stage {
+	if len(axes) == 0 {
+		return stages
+	}
+
+	filtered := make([]*types.Stage, 0, len(stages))
+	for _, stage := range stages {
+		if stage.Matrix != nil && matrix.Matches(stage.Matrix.Axes, axes) {
+			filtered = append(filtered, stage)
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,90 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type RetryInput struct {
+	// FailedOnly retries only the stages that didn't succeed, if the pipeline structure allows it.
+	FailedOnly bool `json:"failed_only"`
+}
+
+// Retry creates a new execution for the same commit, ref, trigger payload and parameters
+// as the original execution, linked to it via the retried_from field.
+func (c *Controller) Retry(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineIdentifier string,
+	executionNum int64,
+	in *RetryInput,
+) (*types.Execution, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path,
+		pipelineIdentifier, enum.PermissionPipelineExecute)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize: %w", err)
+	}
+
+	pipeline, err := c.pipelineStore.FindByIdentifier(ctx, repo.ID, pipelineIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	original, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find execution %d: %w", executionNum, err)
+	}
+
+	if !original.Status.IsDone() {
+		return nil, usererror.BadRequest("Only finished executions can be retried.")
+	}
+	if in.FailedOnly && original.Status == enum.CIStatusSuccess {
+		// matrix cells that are allowed to fail don't fail the execution, but can still be retried.
+		var stages []*types.Stage
+		stages, err = c.stageStore.List(ctx, original.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list stages of execution %d: %w", executionNum, err)
+		}
+		if !slices.ContainsFunc(stages, func(stage *types.Stage) bool { return stage.Status.IsFailed() }) {
+			return nil, usererror.BadRequest("The execution has no failed stages to retry.")
+		}
+	}
+
+	_, err = c.commitService.FindCommit(ctx, repo, original.After)
+	if errors.IsNotFound(err) {
+		return nil, usererror.BadRequestf(
+			"Commit %s of execution #%d no longer exists in the repository.", original.After, original.Number)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch commit: %w", err)
+	}
+
+	return c.triggerer.Retry(ctx, pipeline, original, &session.Principal, in.FailedOnly)
+}
@@ -18,12 +18,15 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/controller/limiter"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
 	eventsgit "github.com/harness/gitness/app/events/git"
 	eventsrepo "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/commitpolicy"
+	"github.com/harness/gitness/app/services/featureflag"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
@@ -45,10 +48,15 @@ type Controller struct {
 	repoReporter        *eventsrepo.Reporter
 	git                 git.Interface
 	pullreqStore        store.PullReqStore
+	pushStore           store.RepoPushStore
 	urlProvider         url.Provider
 	protectionManager   *protection.Manager
 	limiter             limiter.ResourceLimiter
+	quotaWarner         *limiter.QuotaWarner
 	settings            *settings.Service
+	commitPolicy        *commitpolicy.Service
+	featureFlags        *featureflag.Service
+	config              *types.Config
 	preReceiveExtender  PreReceiveExtender
 	updateExtender      UpdateExtender
 	postReceiveExtender PostReceiveExtender
@@ -62,10 +70,15 @@ func NewController(
 	repoReporter *eventsrepo.Reporter,
 	git git.Interface,
 	pullreqStore store.PullReqStore,
+	pushStore store.RepoPushStore,
 	urlProvider url.Provider,
 	protectionManager *protection.Manager,
 	limiter limiter.ResourceLimiter,
+	quotaWarner *limiter.QuotaWarner,
 	settings *settings.Service,
+	commitPolicy *commitpolicy.Service,
+	featureFlags *featureflag.Service,
+	config *types.Config,
 	preReceiveExtender PreReceiveExtender,
 	updateExtender UpdateExtender,
 	postReceiveExtender PostReceiveExtender,
@@ -78,10 +91,15 @@ func NewController(
 		repoReporter:        repoReporter,
 		git:                 git,
 		pullreqStore:        pullreqStore,
+		pushStore:           pushStore,
 		urlProvider:         urlProvider,
 		protectionManager:   protectionManager,
 		limiter:             limiter,
+		quotaWarner:         quotaWarner,
 		settings:            settings,
+		commitPolicy:        commitPolicy,
+		featureFlags:        featureFlags,
+		config:              config,
 		preReceiveExtender:  preReceiveExtender,
 		updateExtender:      updateExtender,
 		postReceiveExtender: postReceiveExtender,
@@ -99,6 +117,8 @@ func (c *Controller) getRepoCheckAccess(ctx context.Context,
 		return nil, fmt.Errorf("failed to find repo with id %d: %w", repoID, err)
 	}
 
+	controller.WithRepo(ctx, repo)
+
 	// TODO: execute permission check. block anything but gitness service?
 
 	return repo, nil
@@ -28,6 +28,13 @@ import (
 // to "soft enforce" no write operations being executed as part of githooks.
 type RestrictedGIT interface {
 	IsAncestor(ctx context.Context, params git.IsAncestorParams) (git.IsAncestorOutput, error)
+	AreAncestors(ctx context.Context, params git.AreAncestorsParams) (git.AreAncestorsOutput, error)
+	GetTagTargets(ctx context.Context, params *git.GetTagTargetsParams) (*git.GetTagTargetsOutput, error)
+	ListBranches(ctx context.Context, params *git.ListBranchesParams) (*git.ListBranchesOutput, error)
+	ListNewCommitMessages(
+		ctx context.Context,
+		params *git.ListNewCommitMessagesParams,
+	) (*git.ListNewCommitMessagesOutput, error)
 	ScanSecrets(ctx context.Context, param *git.ScanSecretsParams) (*git.ScanSecretsOutput, error)
 	GetBranch(ctx context.Context, params *git.GetBranchParams) (*git.GetBranchOutput, error)
 	Diff(ctx context.Context, in *git.DiffParams, files ...api.FileDiffRequest) (<-chan *git.FileDiff, <-chan error)
@@ -24,6 +24,7 @@ import (
 	"github.com/harness/gitness/app/bootstrap"
 	events "github.com/harness/gitness/app/events/git"
 	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/git/hook"
 	"github.com/harness/gitness/types"
@@ -65,6 +66,7 @@ func (c *Controller) PostReceive(
 	// report ref events if repo is in an active state (best effort)
 	if repo.State == enum.RepoStateActive {
 		c.reportReferenceEvents(ctx, rgit, repo, in.PrincipalID, in.PostReceiveInput)
+		c.recordPushes(ctx, rgit, repo, in.PrincipalID, in.PostReceiveInput)
 	}
 
 	// handle branch updates related to PRs - best effort
@@ -261,6 +263,8 @@ func (c *Controller) suggestPullRequest(
 }
 
 // handleEmptyRepoPush updates repo default branch on empty repos if push contains branches.
+// If the configured default branch isn't pushed, the first pushed branch becomes the default branch,
+// unless the auto detection was disabled in the repo settings.
 func (c *Controller) handleEmptyRepoPush(
 	ctx context.Context,
 	repo *types.Repository,
@@ -291,8 +295,26 @@ func (c *Controller) handleEmptyRepoPush(
 		return
 	}
 
-	oldName := repo.DefaultBranch
 	var err error
+	if newDefaultBranch != repo.DefaultBranch {
+		var autoDetect bool
+		autoDetect, err = settings.RepoGet(
+			ctx,
+			c.settings,
+			repo.ID,
+			settings.KeyDefaultBranchAutoDetect,
+			settings.DefaultDefaultBranchAutoDetect,
+		)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to check settings whether default branch auto detection is enabled")
+			autoDetect = settings.DefaultDefaultBranchAutoDetect
+		}
+		if !autoDetect {
+			newDefaultBranch = repo.DefaultBranch
+		}
+	}
+
+	oldName := repo.DefaultBranch
 	repo, err = c.repoStore.UpdateOptLock(ctx, repo, func(r *types.Repository) error {
 		r.IsEmpty = false
 		r.DefaultBranch = newDefaultBranch
@@ -305,11 +327,17 @@ func (c *Controller) handleEmptyRepoPush(
 	}
 
 	if repo.DefaultBranch != oldName {
+		// the event handler updates HEAD of the git repository and records the change in the repo activities.
 		c.repoReporter.DefaultBranchUpdated(ctx, &repoevents.DefaultBranchUpdatedPayload{
 			RepoID:      repo.ID,
 			PrincipalID: bootstrap.NewSystemServiceSession().Principal.ID,
 			OldName:     oldName,
 			NewName:     repo.DefaultBranch,
+			Automatic:   true,
 		})
+
+		out.Messages = append(out.Messages,
+			fmt.Sprintf("The default branch %q wasn't pushed, the default branch was changed to %q.",
+				oldName, repo.DefaultBranch))
 	}
 }
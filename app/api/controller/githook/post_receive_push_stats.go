@@ -0,0 +1,127 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// pushStatsMaxCommitCount limits the number of commits counted per reference update,
+// to avoid expensive history walks for pushes of large imported histories.
+const pushStatsMaxCommitCount = 1000
+
+// recordPushes records the branch and tag updates of the push for the repository statistics.
+// NOTE: keep best effort as it doesn't change the outcome of the git operation.
+func (c *Controller) recordPushes(
+	ctx context.Context,
+	rgit RestrictedGIT,
+	repo *types.Repository,
+	principalID int64,
+	in hook.PostReceiveInput,
+) {
+	now := time.Now().UnixMilli()
+
+	for _, refUpdate := range in.RefUpdates {
+		isBranch := strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixBranch)
+		if !isBranch && !strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixTag) {
+			continue
+		}
+
+		var commitCount int64
+		if isBranch && !refUpdate.New.IsNil() {
+			var err error
+			commitCount, err = c.countPushedCommits(ctx, rgit, repo, in, refUpdate)
+			if err != nil {
+				log.Ctx(ctx).Warn().Err(err).
+					Str("ref", refUpdate.Ref).
+					Msg("failed to count pushed commits")
+			}
+		}
+
+		err := c.pushStore.Create(ctx, &types.RepoPush{
+			RepoID:      repo.ID,
+			PrincipalID: principalID,
+			Ref:         refUpdate.Ref,
+			CommitCount: commitCount,
+			Created:     now,
+		})
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Str("ref", refUpdate.Ref).
+				Msg("failed to record push")
+		}
+	}
+}
+
+// countPushedCommits returns the number of commits a branch update introduced to the repository.
+// New branches are compared against the default branch.
+func (c *Controller) countPushedCommits(
+	ctx context.Context,
+	rgit RestrictedGIT,
+	repo *types.Repository,
+	in hook.PostReceiveInput,
+	refUpdate hook.ReferenceUpdate,
+) (int64, error) {
+	readParams := git.ReadParams{
+		RepoUID:             repo.GitUID,
+		AlternateObjectDirs: in.Environment.AlternateObjectDirs,
+	}
+
+	baseSHA, ok, err := GetBaseSHAForScanningChanges(ctx, rgit, repo, in.Environment, in.RefUpdates, refUpdate)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get base sha: %w", err)
+	}
+
+	if !ok {
+		// first push to an empty repository - all commits reachable from the branch are new.
+		out, err := c.git.ListCommits(ctx, &git.ListCommitsParams{
+			ReadParams: readParams,
+			GitREF:     refUpdate.New.String(),
+			Page:       1,
+			Limit:      pushStatsMaxCommitCount,
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to list commits: %w", err)
+		}
+
+		return int64(len(out.Commits)), nil
+	}
+
+	out, err := c.git.GetCommitDivergences(ctx, &git.GetCommitDivergencesParams{
+		ReadParams: readParams,
+		MaxCount:   pushStatsMaxCommitCount,
+		Requests: []git.CommitDivergenceRequest{
+			{From: baseSHA.String(), To: refUpdate.New.String()},
+		},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get commit divergence: %w", err)
+	}
+
+	if len(out.Divergences) == 0 || out.Divergences[0].Behind < 0 {
+		return 0, nil
+	}
+
+	return int64(out.Divergences[0].Behind), nil
+}
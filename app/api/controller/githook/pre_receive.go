@@ -20,11 +20,14 @@ import (
 	"strings"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/controller/limiter"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/sha"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -59,6 +62,7 @@ func (c *Controller) PreReceive(
 	}
 
 	refUpdates := groupRefsByAction(in.RefUpdates)
+	var rejected rejectedRefs
 
 	if slices.Contains(refUpdates.branches.deleted, repo.DefaultBranch) {
 		// Default branch mustn't be deleted.
@@ -66,6 +70,15 @@ func (c *Controller) PreReceive(
 		return output, nil
 	}
 
+	// References in hidden namespaces can't be updated by anyone - including internal calls.
+	err = c.checkHiddenRefs(ctx, repo, in.RefUpdates, &output)
+	if err != nil {
+		return hook.Output{}, err
+	}
+	if output.Error != nil {
+		return output, nil
+	}
+
 	// For external calls (git pushes) block modification of pullreq references.
 	if !in.Internal && c.blockPullReqRefUpdate(refUpdates, repo.State) {
 		output.Error = ptr.String(usererror.ErrPullReqRefsCantBeModified.Error())
@@ -82,13 +95,18 @@ func (c *Controller) PreReceive(
 
 		dummySession := &auth.Session{Principal: *principal, Metadata: nil}
 
-		err = c.checkProtectionRules(ctx, dummySession, repo, refUpdates, &output)
+		err = c.checkProtectionRules(ctx, rgit, dummySession, repo, in, refUpdates, &output, &rejected)
 		if err != nil {
 			return hook.Output{}, fmt.Errorf("failed to check protection rules: %w", err)
 		}
+
+		err = c.checkCommitMessages(ctx, rgit, repo, in, &output, &rejected)
+		if err != nil {
+			return hook.Output{}, fmt.Errorf("failed to check commit messages: %w", err)
+		}
 	}
 
-	err = c.scanSecrets(ctx, rgit, repo, in, &output)
+	err = c.scanSecrets(ctx, rgit, repo, in, &output, &rejected)
 	if err != nil {
 		return hook.Output{}, err
 	}
@@ -100,15 +118,30 @@ func (c *Controller) PreReceive(
 
 	err = c.checkFileSizeLimit(ctx, rgit, repo, in, &output)
 	if output.Error != nil {
+		// git rejects all references of the push if pre-receive fails - not only the ones causing the failure.
+		printPushRejection(&output, in.RefUpdates, rejected)
 		return output, nil
 	}
 	if err != nil {
 		return hook.Output{}, err
 	}
 
+	if !in.Internal {
+		c.warnStorageQuota(ctx, repo, &output)
+	}
+
 	return output, nil
 }
 
+// warnStorageQuota informs the pusher if the storage quota of the space is close to its limit.
+func (c *Controller) warnStorageQuota(ctx context.Context, repo *types.Repository, output *hook.Output) {
+	for _, usage := range c.quotaWarner.Warnings(ctx, repo.ParentID) {
+		if usage.Resource == limiter.QuotaResourceStorage && usage.Remaining() > 0 {
+			printStorageQuotaWarning(output, usage)
+		}
+	}
+}
+
 func (c *Controller) blockPullReqRefUpdate(refUpdates changedRefs, state enum.RepoState) bool {
 	if state == enum.RepoStateMigrateGitPush {
 		return false
@@ -125,10 +158,13 @@ func (c *Controller) blockPullReqRefUpdate(refUpdates changedRefs, state enum.Re
 
 func (c *Controller) checkProtectionRules(
 	ctx context.Context,
+	rgit RestrictedGIT,
 	session *auth.Session,
 	repo *types.Repository,
+	in types.GithookPreReceiveInput,
 	refUpdates changedRefs,
 	output *hook.Output,
+	rejectedRefs *rejectedRefs,
 ) error {
 	isRepoOwner, err := apiauth.IsRepoOwner(ctx, c.authorizer, session, repo)
 	if err != nil {
@@ -140,34 +176,62 @@ func (c *Controller) checkProtectionRules(
 		return fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
 	}
 
+	readParams := git.ReadParams{
+		RepoUID:             repo.GitUID,
+		AlternateObjectDirs: in.Environment.AlternateObjectDirs,
+	}
+
+	tagTargets, err := getCreatedTagTargets(ctx, rgit, readParams, in.RefUpdates)
+	if err != nil {
+		return err
+	}
+
 	var ruleViolations []types.RuleViolations
 	var errCheckAction error
 
-	checkAction := func(refAction protection.RefAction, refType protection.RefType, names []string) {
-		if errCheckAction != nil || len(names) == 0 {
-			return
-		}
+	// The references are verified one by one to be able to tell which of them caused the rejection of the push.
+	checkAction := func(refAction protection.RefAction, refType protection.RefType, refPrefix string, names []string) {
+		for _, name := range names {
+			if errCheckAction != nil {
+				return
+			}
 
-		violations, err := protectionRules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
-			Actor:       &session.Principal,
-			AllowBypass: true,
-			IsRepoOwner: isRepoOwner,
-			Repo:        repo,
-			RefAction:   refAction,
-			RefType:     refType,
-			RefNames:    names,
-		})
-		if err != nil {
-			errCheckAction = fmt.Errorf("failed to verify protection rules for git push: %w", err)
-			return
-		}
+			violations, err := protectionRules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+				Actor:       &session.Principal,
+				AllowBypass: true,
+				IsRepoOwner: isRepoOwner,
+				Repo:        repo,
+				RefAction:   refAction,
+				RefType:     refType,
+				RefNames:    []string{name},
+
+				TagTargets:            tagTargets,
+				HasPermission:         apiauth.RepoPermissionChecker(c.authorizer, session, repo),
+				ReachableFromBranches: controller.ReachableFromBranches(rgit, readParams),
+			})
+			if err != nil {
+				errCheckAction = fmt.Errorf("failed to verify protection rules for git push: %w", err)
+				return
+			}
+
+			for i := range violations {
+				if violations[i].IsCritical() {
+					rejectedRefs.add(refPrefix + name)
+					break
+				}
+			}
 
-		ruleViolations = append(ruleViolations, violations...)
+			ruleViolations = append(ruleViolations, violations...)
+		}
 	}
 
-	checkAction(protection.RefActionCreate, protection.RefTypeBranch, refUpdates.branches.created)
-	checkAction(protection.RefActionDelete, protection.RefTypeBranch, refUpdates.branches.deleted)
-	checkAction(protection.RefActionUpdate, protection.RefTypeBranch, refUpdates.branches.updated)
+	branches, tags := refUpdates.branches, refUpdates.tags
+	checkAction(protection.RefActionCreate, protection.RefTypeBranch, gitReferenceNamePrefixBranch, branches.created)
+	checkAction(protection.RefActionDelete, protection.RefTypeBranch, gitReferenceNamePrefixBranch, branches.deleted)
+	checkAction(protection.RefActionUpdate, protection.RefTypeBranch, gitReferenceNamePrefixBranch, branches.updated)
+	checkAction(protection.RefActionCreate, protection.RefTypeTag, gitReferenceNamePrefixTag, tags.created)
+	checkAction(protection.RefActionDelete, protection.RefTypeTag, gitReferenceNamePrefixTag, tags.deleted)
+	checkAction(protection.RefActionUpdate, protection.RefTypeTag, gitReferenceNamePrefixTag, tags.updated)
 
 	if errCheckAction != nil {
 		return errCheckAction
@@ -195,6 +259,59 @@ func (c *Controller) checkProtectionRules(
 	return nil
 }
 
+// getCreatedTagTargets returns the objects the tags created by the push are pointing to, by the tag name.
+func getCreatedTagTargets(
+	ctx context.Context,
+	rgit RestrictedGIT,
+	readParams git.ReadParams,
+	refUpdates []hook.ReferenceUpdate,
+) (map[string]protection.TagTarget, error) {
+	var (
+		names []string
+		shas  []sha.SHA
+	)
+
+	for _, refUpdate := range refUpdates {
+		if !strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixTag) || !refUpdate.Old.IsNil() {
+			continue
+		}
+
+		names = append(names, refUpdate.Ref[len(gitReferenceNamePrefixTag):])
+		shas = append(shas, refUpdate.New)
+	}
+
+	if len(names) == 0 {
+		return map[string]protection.TagTarget{}, nil
+	}
+
+	out, err := rgit.GetTagTargets(ctx, &git.GetTagTargetsParams{
+		ReadParams: readParams,
+		SHAs:       shas,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get targets of created tags: %w", err)
+	}
+
+	tagTargets := make(map[string]protection.TagTarget, len(names))
+	for i, name := range names {
+		tagTargets[name] = protection.TagTarget{
+			Annotated: out.Targets[i].Annotated,
+			CommitSHA: out.Targets[i].CommitSHA.String(),
+		}
+	}
+
+	return tagTargets, nil
+}
+
+// rejectedRefs collects the references that caused the rejection of a push.
+type rejectedRefs []string
+
+func (r *rejectedRefs) add(ref string) {
+	if !slices.Contains(*r, ref) {
+		*r = append(*r, ref)
+	}
+}
+
 type changes struct {
 	created []string
 	deleted []string
@@ -0,0 +1,110 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/services/commitpolicy"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+
+	"github.com/gotidy/ptr"
+)
+
+const (
+	// commitMessageMaxCheckedCommits is the maximum number of commits checked per reference update.
+	commitMessageMaxCheckedCommits = 1000
+	// commitMessageMaxPrintedViolations is the maximum number of violating commits listed in the output.
+	commitMessageMaxPrintedViolations = 5
+)
+
+type commitMessageViolation struct {
+	SHA     sha.SHA
+	Subject string
+	Reasons []string
+}
+
+// checkCommitMessages verifies that the messages of the commits introduced by the pushed branches
+// comply with the commit message policy of the repository.
+func (c *Controller) checkCommitMessages(
+	ctx context.Context,
+	rgit RestrictedGIT,
+	repo *types.Repository,
+	in types.GithookPreReceiveInput,
+	output *hook.Output,
+	rejectedRefs *rejectedRefs,
+) error {
+	checker, err := c.commitPolicy.RepoChecker(ctx, repo)
+	if err != nil {
+		return fmt.Errorf("failed to get commit message policy: %w", err)
+	}
+	if !checker.Active() {
+		return nil
+	}
+
+	var violations []commitMessageViolation
+	checked := map[sha.SHA]struct{}{}
+
+	for _, refUpdate := range in.RefUpdates {
+		branchName, isBranch := strings.CutPrefix(refUpdate.Ref, gitReferenceNamePrefixBranch)
+		if !isBranch || refUpdate.New.IsNil() || checker.ExcludesBranch(branchName) {
+			continue
+		}
+
+		var out *git.ListNewCommitMessagesOutput
+		out, err = rgit.ListNewCommitMessages(ctx, &git.ListNewCommitMessagesParams{
+			ReadParams: git.ReadParams{
+				RepoUID:             repo.GitUID,
+				AlternateObjectDirs: in.Environment.AlternateObjectDirs,
+			},
+			Rev:   refUpdate.New.String(),
+			Limit: commitMessageMaxCheckedCommits,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list new commits of %q: %w", refUpdate.Ref, err)
+		}
+
+		for _, commit := range out.Commits {
+			if _, ok := checked[commit.SHA]; ok {
+				continue
+			}
+			checked[commit.SHA] = struct{}{}
+
+			reasons := checker.Check(commit.Message, len(commit.ParentSHAs))
+			if len(reasons) == 0 {
+				continue
+			}
+
+			rejectedRefs.add(refUpdate.Ref)
+			violations = append(violations, commitMessageViolation{
+				SHA:     commit.SHA,
+				Subject: commitpolicy.Subject(commit.Message),
+				Reasons: reasons,
+			})
+		}
+	}
+
+	if len(violations) > 0 {
+		output.Error = ptr.String("Push rejected by the commit message policy of the repository")
+		printCommitMessageViolations(output, violations)
+	}
+
+	return nil
+}
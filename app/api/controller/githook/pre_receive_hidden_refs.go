@@ -0,0 +1,62 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/types"
+
+	"github.com/gotidy/ptr"
+)
+
+// checkHiddenRefs blocks updates of references in hidden namespaces (configured for the instance and the repo).
+// Hidden references are maintained exclusively by the server, which updates them without executing the git hooks,
+// hence the updates are blocked for all callers - irrespective of their permissions.
+func (c *Controller) checkHiddenRefs(
+	ctx context.Context,
+	repo *types.Repository,
+	refUpdates []hook.ReferenceUpdate,
+	output *hook.Output,
+) error {
+	repoHiddenRefs, err := settings.RepoGet(
+		ctx,
+		c.settings,
+		repo.ID,
+		settings.KeyHiddenRefs,
+		settings.DefaultHiddenRefs,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to check settings for hidden references: %w", err)
+	}
+
+	hiddenRefs := make([]string, 0, len(c.config.Git.HiddenRefs)+len(repoHiddenRefs))
+	hiddenRefs = append(hiddenRefs, c.config.Git.HiddenRefs...)
+	hiddenRefs = append(hiddenRefs, repoHiddenRefs...)
+
+	for _, refUpdate := range refUpdates {
+		if namespace, ok := api.MatchHiddenRef(refUpdate.Ref, hiddenRefs); ok {
+			output.Error = ptr.String(fmt.Sprintf(
+				"Reference %q is part of the hidden namespace %q and can't be updated.", refUpdate.Ref, namespace))
+			return nil
+		}
+	}
+
+	return nil
+}
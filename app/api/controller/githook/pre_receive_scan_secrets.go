@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/harness/gitness/app/services/featureflag"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/git/hook"
@@ -40,7 +41,13 @@ func (c *Controller) scanSecrets(
 	repo *types.Repository,
 	in types.GithookPreReceiveInput,
 	output *hook.Output,
+	rejectedRefs *rejectedRefs,
 ) error {
+	// the feature flag is evaluated in memory, so it's checked before the repo settings.
+	if !c.featureFlags.IsEnabledForRepo(featureflag.FlagSecretScanning, repo) {
+		return nil
+	}
+
 	// check if scanning is enabled on the repo
 	scanningEnabled, err := settings.RepoGet(
 		ctx,
@@ -74,6 +81,9 @@ func (c *Controller) scanSecrets(
 	// block the push if any secrets were found
 	if len(findings) > 0 {
 		output.Error = ptr.String("Changes blocked by security scan results")
+		for _, finding := range findings {
+			rejectedRefs.add(finding.Ref)
+		}
 	}
 
 	return nil
@@ -16,18 +16,22 @@ package githook
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/harness/gitness/app/api/controller/limiter"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/git/hook"
 
 	"github.com/fatih/color"
+	"golang.org/x/exp/slices"
 )
 
 var (
 	colorScanHeader            = color.New(color.FgHiWhite, color.Underline)
 	colorScanSummary           = color.New(color.FgHiRed, color.Bold)
 	colorScanSummaryNoFindings = color.New(color.FgHiGreen, color.Bold)
+	colorQuotaWarning          = color.New(color.FgHiYellow, color.Bold)
 )
 
 func printScanSecretsFindings(
@@ -137,6 +141,103 @@ func printOversizeFiles(
 	)
 }
 
+func printStorageQuotaWarning(
+	output *hook.Output,
+	usage limiter.QuotaUsage,
+) {
+	output.Messages = append(
+		output.Messages,
+		colorQuotaWarning.Sprintf(
+			"The storage quota of the space is %d%% used, %dB remaining",
+			usage.Used*100/usage.Limit, usage.Remaining(),
+		),
+		"", "", // add two empty lines for making it visually more consumable
+	)
+}
+
+func printCommitMessageViolations(
+	output *hook.Output,
+	violations []commitMessageViolation,
+) {
+	output.Messages = append(
+		output.Messages,
+		colorScanHeader.Sprintf(
+			"Push contains commits violating the commit message policy:",
+		),
+		"", // add empty line for making it visually more consumable
+	)
+
+	for i, violation := range violations {
+		if i == commitMessageMaxPrintedViolations {
+			break
+		}
+
+		output.Messages = append(
+			output.Messages,
+			fmt.Sprintf("  %s %s", violation.SHA, violation.Subject),
+		)
+		for _, reason := range violation.Reasons {
+			output.Messages = append(output.Messages, fmt.Sprintf("      - %s", reason))
+		}
+		output.Messages = append(output.Messages, "") // add empty line for making it visually more consumable
+	}
+
+	total := len(violations)
+	if total > commitMessageMaxPrintedViolations {
+		output.Messages = append(
+			output.Messages,
+			fmt.Sprintf("  ... and %d more", total-commitMessageMaxPrintedViolations),
+			"",
+		)
+	}
+
+	output.Messages = append(
+		output.Messages,
+		colorScanSummary.Sprintf(
+			"%d %s violating the commit message policy",
+			total, singularOrPlural("commit", total > 1),
+		),
+		"", "", // add two empty lines for making it visually more consumable
+	)
+}
+
+// printPushRejection lists the references of the push that caused the rejection, and the ones that
+// weren't updated even though they didn't violate anything, as git rejects all references if pre-receive fails.
+func printPushRejection(
+	output *hook.Output,
+	refUpdates []hook.ReferenceUpdate,
+	rejected rejectedRefs,
+) {
+	if len(refUpdates) < 2 {
+		return
+	}
+
+	output.Messages = append(
+		output.Messages,
+		colorScanHeader.Sprintf("None of the %d references of the push were updated:", len(refUpdates)),
+		"", // add empty line for making it visually more consumable
+	)
+
+	for _, ref := range rejected {
+		output.Messages = append(output.Messages, fmt.Sprintf("  %s (rejected)", ref))
+	}
+
+	for _, refUpdate := range refUpdates {
+		if slices.Contains(rejected, refUpdate.Ref) {
+			continue
+		}
+
+		reason := "rejected together with the other references"
+		if len(rejected) > 0 {
+			reason = "rejected because of " + strings.Join(rejected, ", ")
+		}
+
+		output.Messages = append(output.Messages, fmt.Sprintf("  %s (%s)", refUpdate.Ref, reason))
+	}
+
+	output.Messages = append(output.Messages, "", "") // add two empty lines for making it visually more consumable
+}
+
 func singularOrPlural(noun string, plural bool) string {
 	if plural {
 		return noun + "s"
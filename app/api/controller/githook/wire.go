@@ -19,12 +19,15 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	eventsgit "github.com/harness/gitness/app/events/git"
 	eventsrepo "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/commitpolicy"
+	"github.com/harness/gitness/app/services/featureflag"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
 )
@@ -48,11 +51,16 @@ func ProvideController(
 	repoReporter *eventsrepo.Reporter,
 	git git.Interface,
 	pullreqStore store.PullReqStore,
+	pushStore store.RepoPushStore,
 	urlProvider url.Provider,
 	protectionManager *protection.Manager,
 	githookFactory hook.ClientFactory,
 	limiter limiter.ResourceLimiter,
+	quotaWarner *limiter.QuotaWarner,
 	settings *settings.Service,
+	commitPolicy *commitpolicy.Service,
+	featureFlags *featureflag.Service,
+	config *types.Config,
 	preReceiveExtender PreReceiveExtender,
 	updateExtender UpdateExtender,
 	postReceiveExtender PostReceiveExtender,
@@ -65,10 +73,15 @@ func ProvideController(
 		repoReporter,
 		git,
 		pullreqStore,
+		pushStore,
 		urlProvider,
 		protectionManager,
 		limiter,
+		quotaWarner,
 		settings,
+		commitPolicy,
+		featureFlags,
+		config,
 		preReceiveExtender,
 		updateExtender,
 		postReceiveExtender,
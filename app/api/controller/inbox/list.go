@@ -0,0 +1,55 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inbox
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+)
+
+// List returns the notifications of the current user, newest first.
+func (c *Controller) List(
+	ctx context.Context,
+	session *auth.Session,
+	filter *types.NotificationFilter,
+) ([]*types.Notification, int64, error) {
+	count, err := c.notificationStore.Count(ctx, session.Principal.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count notifications: %w", err)
+	}
+
+	notifications, err := c.notificationStore.List(ctx, session.Principal.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	return notifications, count, nil
+}
+
+// Count returns the number of unread notifications of the current user.
+func (c *Controller) Count(
+	ctx context.Context,
+	session *auth.Session,
+) (*types.NotificationCount, error) {
+	unread, err := c.notificationStore.Count(ctx, session.Principal.ID, &types.NotificationFilter{OnlyUnread: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+
+	return &types.NotificationCount{Unread: unread}, nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inbox
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+)
+
+const maxMarkReadIDs = 100
+
+type MarkReadInput struct {
+	IDs []int64 `json:"ids"`
+	// All marks all notifications of the user as read.
+	All bool `json:"all"`
+}
+
+func (in *MarkReadInput) sanitize() error {
+	if in.All && len(in.IDs) > 0 {
+		return usererror.BadRequest("Either notification IDs or all can be provided, not both")
+	}
+
+	if !in.All && len(in.IDs) == 0 {
+		return usererror.BadRequest("At least one notification ID is required")
+	}
+
+	if len(in.IDs) > maxMarkReadIDs {
+		return usererror.BadRequestf("At most %d notifications can be marked as read at once", maxMarkReadIDs)
+	}
+
+	return nil
+}
+
+// MarkRead marks a single notification of the current user as read.
+func (c *Controller) MarkRead(
+	ctx context.Context,
+	session *auth.Session,
+	notificationID int64,
+) (*types.NotificationCount, error) {
+	return c.MarkReadBulk(ctx, session, &MarkReadInput{IDs: []int64{notificationID}})
+}
+
+// MarkReadBulk marks the provided notifications, or all notifications, of the current user as read.
+// It returns the remaining number of unread notifications.
+func (c *Controller) MarkReadBulk(
+	ctx context.Context,
+	session *auth.Session,
+	in *MarkReadInput,
+) (*types.NotificationCount, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	// the store marks all notifications as read if no IDs are provided.
+	_, err := c.notificationStore.MarkRead(ctx, session.Principal.ID, in.IDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+
+	return c.Count(ctx, session)
+}
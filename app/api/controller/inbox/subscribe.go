@@ -0,0 +1,86 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inbox
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// SubscribeRepo watches or unwatches the repository for the current user.
+func (c *Controller) SubscribeRepo(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	subscribed bool,
+) (*types.NotificationSubscription, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.inboxService.Subscribe(ctx, session.Principal.ID, enum.SubscriptionResourceTypeRepo, repo.ID, subscribed)
+}
+
+// SubscribePullReq watches or unwatches the pull request for the current user.
+func (c *Controller) SubscribePullReq(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	subscribed bool,
+) (*types.NotificationSubscription, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, err := c.pullReqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	return c.inboxService.Subscribe(ctx, session.Principal.ID, enum.SubscriptionResourceTypePullReq, pr.ID, subscribed)
+}
+
+func (c *Controller) getRepoCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.Repository, error) {
+	if auth.IsAnonymousSession(session) {
+		return nil, usererror.ErrUnauthorized
+	}
+
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	controller.WithRepo(ctx, repo)
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView); err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
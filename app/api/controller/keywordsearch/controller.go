@@ -19,6 +19,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/space"
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/store"
 )
 
 type Controller struct {
@@ -26,6 +27,8 @@ type Controller struct {
 	repoCtrl   *repo.Controller
 	searcher   keywordsearch.Searcher
 	spaceCtrl  *space.Controller
+
+	repoTopicStore store.RepoTopicStore
 }
 
 func NewController(
@@ -33,11 +36,14 @@ func NewController(
 	searcher keywordsearch.Searcher,
 	repoCtrl *repo.Controller,
 	spaceCtrl *space.Controller,
+	repoTopicStore store.RepoTopicStore,
 ) *Controller {
 	return &Controller{
 		authorizer: authorizer,
 		searcher:   searcher,
 		repoCtrl:   repoCtrl,
 		spaceCtrl:  spaceCtrl,
+
+		repoTopicStore: repoTopicStore,
 	}
 }
@@ -18,6 +18,9 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"slices"
+	"sort"
+	"strings"
 
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
@@ -69,6 +72,10 @@ func (c *Controller) Search(
 		return types.SearchResult{}, fmt.Errorf("failed to search: %w", err)
 	}
 
+	if !in.EnableRegex {
+		c.boostTopicMatches(ctx, in.Query, result.FileMatches)
+	}
+
 	for idx, fileMatch := range result.FileMatches {
 		repoPath, ok := repoIDToPathMap[fileMatch.RepoID]
 		if !ok {
@@ -155,3 +162,37 @@ func (c *Controller) getReposBySpacePath(
 	}
 	return repoIDToPathMap, nil
 }
+
+// boostTopicMatches moves the file matches of repos with a topic matching a word of the query to the front,
+// preserving the order of the search results otherwise.
+// Failures are only logged, as they shouldn't prevent searching.
+func (c *Controller) boostTopicMatches(ctx context.Context, query string, fileMatches []types.FileMatch) {
+	words := strings.Fields(strings.ToLower(query))
+	if len(words) == 0 || len(fileMatches) == 0 {
+		return
+	}
+
+	repoIDs := make([]int64, 0)
+	for _, fileMatch := range fileMatches {
+		if !slices.Contains(repoIDs, fileMatch.RepoID) {
+			repoIDs = append(repoIDs, fileMatch.RepoID)
+		}
+	}
+
+	topics, err := c.repoTopicStore.Map(ctx, repoIDs)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to list repo topics for boosting search results")
+		return
+	}
+
+	boosted := make(map[int64]bool, len(topics))
+	for repoID, repoTopics := range topics {
+		boosted[repoID] = slices.ContainsFunc(repoTopics, func(topic string) bool {
+			return slices.Contains(words, topic)
+		})
+	}
+
+	sort.SliceStable(fileMatches, func(i, j int) bool {
+		return boosted[fileMatches[i].RepoID] && !boosted[fileMatches[j].RepoID]
+	})
+}
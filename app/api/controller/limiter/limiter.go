@@ -30,6 +30,10 @@ type ResourceLimiter interface {
 
 	// RepoSize allows repository growth up to a limit for the given repoID.
 	RepoSize(ctx context.Context, repoID int64) error
+
+	// QuotaUsage returns the usage of the quotas that apply to the space.
+	// It's called on mutating requests, so implementations are expected to serve it from cached usage counters.
+	QuotaUsage(ctx context.Context, spaceID int64) ([]QuotaUsage, error)
 }
 
 var _ ResourceLimiter = Unlimited{}
@@ -49,3 +53,7 @@ func (Unlimited) RepoCount(context.Context, int64, int) error {
 func (Unlimited) RepoSize(context.Context, int64) error {
 	return nil
 }
+
+func (Unlimited) QuotaUsage(context.Context, int64) ([]QuotaUsage, error) {
+	return nil, nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package limiter
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+// QuotaResource identifies a resource limited by a space quota.
+type QuotaResource string
+
+const (
+	QuotaResourceRepos   QuotaResource = "repos"
+	QuotaResourceStorage QuotaResource = "storage"
+)
+
+// QuotaUsage is the consumption of a space quota. A limit of 0 means unlimited.
+type QuotaUsage struct {
+	Resource QuotaResource
+	Limit    int64
+	Used     int64
+}
+
+// Remaining returns how much of the quota is left.
+func (u QuotaUsage) Remaining() int64 {
+	return max(u.Limit-u.Used, 0)
+}
+
+// Exceeds returns true if the usage is at or above the provided percentage of the limit.
+func (u QuotaUsage) Exceeds(percent int) bool {
+	return u.Limit > 0 && u.Used*100 >= u.Limit*int64(percent)
+}
+
+// QuotaWarner finds the quotas of spaces whose usage is above the warning threshold.
+type QuotaWarner struct {
+	limiter   ResourceLimiter
+	threshold int
+}
+
+func NewQuotaWarner(limiter ResourceLimiter, threshold int) *QuotaWarner {
+	return &QuotaWarner{
+		limiter:   limiter,
+		threshold: threshold,
+	}
+}
+
+// Warnings returns the quotas of the space that are above the warning threshold.
+// Warnings are informational only, so failures are logged and never fail the request.
+func (w *QuotaWarner) Warnings(ctx context.Context, spaceID int64) []QuotaUsage {
+	if w.threshold <= 0 {
+		return nil
+	}
+
+	usages, err := w.limiter.QuotaUsage(ctx, spaceID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("space_id", spaceID).Msg("failed to get quota usage")
+		return nil
+	}
+
+	var warnings []QuotaUsage
+	for _, usage := range usages {
+		if usage.Exceeds(w.threshold) {
+			warnings = append(warnings, usage)
+		}
+	}
+
+	return warnings
+}
+
+// Record adds the warnings of the space to the quota warnings of the context, if there are any.
+func (w *QuotaWarner) Record(ctx context.Context, spaceID int64) {
+	collected, ok := ctx.Value(quotaWarningsKey{}).(*QuotaWarnings)
+	if !ok {
+		return
+	}
+
+	collected.add(w.Warnings(ctx, spaceID))
+}
+
+type quotaWarningsKey struct{}
+
+// QuotaWarnings collects the quota warnings recorded while processing a request.
+type QuotaWarnings struct {
+	mx       sync.Mutex
+	warnings map[QuotaResource]QuotaUsage
+}
+
+// WithQuotaWarnings returns a context that collects the quota warnings recorded with it.
+func WithQuotaWarnings(ctx context.Context) (context.Context, *QuotaWarnings) {
+	collected := &QuotaWarnings{}
+	return context.WithValue(ctx, quotaWarningsKey{}, collected), collected
+}
+
+func (q *QuotaWarnings) add(warnings []QuotaUsage) {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	for _, warning := range warnings {
+		if q.warnings == nil {
+			q.warnings = make(map[QuotaResource]QuotaUsage)
+		}
+		q.warnings[warning.Resource] = warning
+	}
+}
+
+// Get returns the recorded warning of the resource.
+func (q *QuotaWarnings) Get(resource QuotaResource) (QuotaUsage, bool) {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	warning, ok := q.warnings[resource]
+	return warning, ok
+}
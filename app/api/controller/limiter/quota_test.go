@@ -0,0 +1,103 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package limiter
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type quotaLimiter struct {
+	Unlimited
+	usages []QuotaUsage
+	err    error
+}
+
+func (l quotaLimiter) QuotaUsage(context.Context, int64) ([]QuotaUsage, error) {
+	return l.usages, l.err
+}
+
+func TestQuotaWarner_Warnings(t *testing.T) {
+	repos := QuotaUsage{Resource: QuotaResourceRepos, Limit: 10, Used: 8}
+	storage := QuotaUsage{Resource: QuotaResourceStorage, Limit: 1000, Used: 799}
+	unlimited := QuotaUsage{Resource: QuotaResourceStorage, Used: 1 << 40}
+
+	tests := []struct {
+		name      string
+		limiter   ResourceLimiter
+		threshold int
+		want      []QuotaUsage
+	}{
+		{
+			name:      "unlimited limiter",
+			limiter:   Unlimited{},
+			threshold: 80,
+		},
+		{
+			name:      "above and below threshold",
+			limiter:   quotaLimiter{usages: []QuotaUsage{repos, storage, unlimited}},
+			threshold: 80,
+			want:      []QuotaUsage{repos},
+		},
+		{
+			name:      "lower threshold",
+			limiter:   quotaLimiter{usages: []QuotaUsage{repos, storage}},
+			threshold: 70,
+			want:      []QuotaUsage{repos, storage},
+		},
+		{
+			name:      "disabled",
+			limiter:   quotaLimiter{usages: []QuotaUsage{repos, storage}},
+			threshold: 0,
+		},
+		{
+			name:      "failure is ignored",
+			limiter:   quotaLimiter{usages: []QuotaUsage{repos}, err: errors.New("boom")},
+			threshold: 80,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := NewQuotaWarner(test.limiter, test.threshold).Warnings(context.Background(), 1)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestQuotaWarner_Record(t *testing.T) {
+	storage := QuotaUsage{Resource: QuotaResourceStorage, Limit: 100, Used: 95}
+	warner := NewQuotaWarner(quotaLimiter{usages: []QuotaUsage{storage}}, 80)
+
+	// recording without a collecting context is a no-op.
+	warner.Record(context.Background(), 1)
+
+	ctx, warnings := WithQuotaWarnings(context.Background())
+	warner.Record(ctx, 1)
+
+	if got, ok := warnings.Get(QuotaResourceStorage); !ok || got != storage {
+		t.Errorf("got %+v (%t), want %+v", got, ok, storage)
+	}
+	if got := storage.Remaining(); got != 5 {
+		t.Errorf("got remaining %d, want 5", got)
+	}
+	if _, ok := warnings.Get(QuotaResourceRepos); ok {
+		t.Error("unexpected repos warning")
+	}
+}
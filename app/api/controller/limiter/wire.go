@@ -15,13 +15,20 @@
 package limiter
 
 import (
+	"github.com/harness/gitness/types"
+
 	"github.com/google/wire"
 )
 
 var WireSet = wire.NewSet(
 	ProvideLimiter,
+	ProvideQuotaWarner,
 )
 
 func ProvideLimiter() (ResourceLimiter, error) {
 	return NewResourceLimiter(), nil
 }
+
+func ProvideQuotaWarner(limiter ResourceLimiter, config *types.Config) *QuotaWarner {
+	return NewQuotaWarner(limiter, config.Quota.WarningThreshold)
+}
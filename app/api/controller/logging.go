@@ -0,0 +1,32 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+
+	"github.com/harness/gitness/logging"
+	"github.com/harness/gitness/types"
+)
+
+// WithRepo annotates all future logs of the request with the resolved repository.
+// The repo uid allows correlating the logs with the ones of the git layer.
+func WithRepo(ctx context.Context, repo *types.Repository) {
+	logging.UpdateContext(ctx,
+		logging.WithRepoID(repo.ID),
+		logging.WithRepoPath(repo.Path),
+		logging.WithRepoUID(repo.GitUID),
+	)
+}
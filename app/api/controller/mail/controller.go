@@ -0,0 +1,39 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mail
+
+import (
+	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/app/store"
+)
+
+type Controller struct {
+	transport    mailer.Transport
+	failureStore store.MailFailureStore
+	// enabled is true if email delivery is configured.
+	enabled bool
+}
+
+func NewController(
+	transport mailer.Transport,
+	failureStore store.MailFailureStore,
+	enabled bool,
+) *Controller {
+	return &Controller{
+		transport:    transport,
+		failureStore: failureStore,
+		enabled:      enabled,
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mail
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+)
+
+// ListFailures lists failed email deliveries, most recent first.
+func (c *Controller) ListFailures(
+	ctx context.Context,
+	session *auth.Session,
+	filter *types.MailFailureFilter,
+) ([]*types.MailFailure, int64, error) {
+	if !session.Principal.Admin {
+		return nil, 0, usererror.ErrForbidden
+	}
+
+	count, err := c.failureStore.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count mail failures: %w", err)
+	}
+
+	failures, err := c.failureStore.List(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list mail failures: %w", err)
+	}
+
+	return failures, count, nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mail
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/notification"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+)
+
+const subjectMailTest = "Test email"
+
+type SendTestInput struct {
+	Email string `json:"email"`
+}
+
+type SendTestOutput struct {
+	Email string `json:"email"`
+	Sent  bool   `json:"sent"`
+	Error string `json:"error,omitempty"`
+}
+
+type mailTestTemplatePayload struct {
+	SentBy string
+}
+
+// SendTest synchronously sends a probe email to verify the email configuration.
+// A failed delivery is reported in the output and recorded like any other delivery failure.
+func (c *Controller) SendTest(
+	ctx context.Context,
+	session *auth.Session,
+	in *SendTestInput,
+) (*SendTestOutput, error) {
+	if !session.Principal.Admin {
+		return nil, usererror.ErrForbidden
+	}
+
+	if !c.enabled {
+		return nil, usererror.BadRequest("Email delivery is not configured.")
+	}
+
+	in.Email = strings.TrimSpace(in.Email)
+	if in.Email == "" {
+		in.Email = session.Principal.Email
+	}
+	if err := check.Email(in.Email); err != nil {
+		return nil, err
+	}
+
+	email, err := notification.GenerateBody(notification.TemplateMailTest, &mailTestTemplatePayload{
+		SentBy: session.Principal.DisplayName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render test email: %w", err)
+	}
+
+	email.ToRecipients = []string{in.Email}
+	email.Subject = subjectMailTest
+
+	errSend := c.transport.Send(ctx, *email)
+	if errSend == nil {
+		return &SendTestOutput{Email: in.Email, Sent: true}, nil
+	}
+
+	now := time.Now().UnixMilli()
+	err = c.failureStore.Record(ctx, &types.MailFailure{
+		JobUID:    fmt.Sprintf("test-%d-%d", session.Principal.ID, now),
+		Recipient: in.Email,
+		Subject:   subjectMailTest,
+		Error:     errSend.Error(),
+		Created:   now,
+		Updated:   now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record test email failure: %w", err)
+	}
+
+	return &SendTestOutput{
+		Email: in.Email,
+		Sent:  false,
+		Error: errSend.Error(),
+	}, nil
+}
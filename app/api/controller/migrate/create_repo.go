@@ -116,6 +116,7 @@ func (c *Controller) CreateRepo(
 			DefaultBranch: in.DefaultBranch,
 			IsEmpty:       true,
 			State:         enum.RepoStateMigrateGitPush,
+			ShowReadme:    true,
 		}
 
 		return c.repoStore.Create(ctx, repo)
@@ -162,10 +163,7 @@ func (c *Controller) CreateRepo(
 		log.Warn().Msgf("failed to insert audit log for import repository operation: %s", err)
 	}
 
-	return &repoCtrl.RepositoryOutput{
-		Repository: *repo,
-		IsPublic:   isRepoPublic,
-	}, nil
+	return repoCtrl.GetRepoOutputWithAccess(ctx, isRepoPublic, repo), nil
 }
 
 func (c *Controller) spaceCheckAuth(
@@ -24,12 +24,6 @@ import (
 	"github.com/harness/gitness/types/enum"
 )
 
-var validTransitions = map[enum.RepoState][]enum.RepoState{
-	enum.RepoStateActive:            {enum.RepoStateMigrateDataImport},
-	enum.RepoStateMigrateDataImport: {enum.RepoStateActive},
-	enum.RepoStateMigrateGitPush:    {enum.RepoStateActive, enum.RepoStateMigrateDataImport},
-}
-
 type UpdateStateInput struct {
 	State enum.RepoState `json:"state"`
 }
@@ -45,14 +39,12 @@ func (c *Controller) UpdateRepoState(
 		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
 	}
 
-	if !stateTransitionValid(repo, in.State) {
+	// the migrate API can only move repositories into and out of the migration states.
+	if !isMigrateState(repo.State) && !isMigrateState(in.State) {
 		return nil, usererror.BadRequestf("Changing repo state from %s to %s is not allowed.", repo.State, in.State)
 	}
 
-	repo, err = c.repoStore.UpdateOptLock(ctx, repo, func(r *types.Repository) error {
-		r.State = in.State
-		return nil
-	})
+	err = c.repoStateSvc.Transition(ctx, &session.Principal, repo, in.State, 0)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update the repo state: %w", err)
 	}
@@ -60,12 +52,6 @@ func (c *Controller) UpdateRepoState(
 	return repo, nil
 }
 
-func stateTransitionValid(repo *types.Repository, newState enum.RepoState) bool {
-	for _, validState := range validTransitions[repo.State] {
-		if validState == newState {
-			return true
-		}
-	}
-
-	return false
+func isMigrateState(state enum.RepoState) bool {
+	return state == enum.RepoStateMigrateGitPush || state == enum.RepoStateMigrateDataImport
 }
@@ -0,0 +1,38 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Cancel requests cancellation of an operation. Cancellation of a completed operation has no effect.
+func (c *Controller) Cancel(ctx context.Context, session *auth.Session, operationID string) error {
+	op, _, _, err := c.getOperationCheckAccess(ctx, session, operationID,
+		enum.PermissionRepoEdit, enum.PermissionSpaceEdit)
+	if err != nil {
+		return err
+	}
+
+	if err = c.operationSvc.Cancel(ctx, op); err != nil {
+		return fmt.Errorf("failed to cancel operation: %w", err)
+	}
+
+	return nil
+}
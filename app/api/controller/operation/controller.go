@@ -0,0 +1,166 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/config"
+	"github.com/harness/gitness/app/services/operation"
+	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type Controller struct {
+	authorizer   authz.Authorizer
+	spaceStore   store.SpaceStore
+	repoStore    store.RepoStore
+	publicAccess publicaccess.Service
+	operationSvc *operation.Service
+}
+
+func NewController(
+	authorizer authz.Authorizer,
+	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
+	publicAccess publicaccess.Service,
+	operationSvc *operation.Service,
+) *Controller {
+	return &Controller{
+		authorizer:   authorizer,
+		spaceStore:   spaceStore,
+		repoStore:    repoStore,
+		publicAccess: publicAccess,
+		operationSvc: operationSvc,
+	}
+}
+
+// Output is the status of a long-running operation.
+type Output struct {
+	ID       string              `json:"id"`
+	Type     enum.OperationType  `json:"type"`
+	State    enum.OperationState `json:"state"`
+	Progress int                 `json:"progress"`
+	// Result is set once the operation succeeded. Its type depends on the type of the operation:
+	// repository for repo_import, SpaceExportResult for space_export
+	// and SpaceArchiveExportResult for space_archive_export.
+	Result    any                   `json:"result,omitempty"`
+	Error     *types.OperationError `json:"error,omitempty"`
+	CreatedBy int64                 `json:"created_by"`
+	Created   int64                 `json:"created"`
+}
+
+// SpaceExportResult is the result of an export of all repositories of a space to harness code.
+type SpaceExportResult struct {
+	Repos int `json:"repos"`
+}
+
+// SpaceArchiveExportResult is the result of an export of a space into an archive.
+type SpaceArchiveExportResult struct {
+	DownloadPath string `json:"download_path"`
+}
+
+// getOperationCheckAccess returns the operation and checks that the principal has the requested permission
+// on the resource the operation was started for. The repository or space is returned alongside the operation.
+func (c *Controller) getOperationCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	operationID string,
+	repoPermission enum.Permission,
+	spacePermission enum.Permission,
+) (*types.Operation, *types.Repository, *types.Space, error) {
+	op, err := c.operationSvc.Find(ctx, operationID)
+	if errors.Is(err, operation.ErrNotFound) {
+		return nil, nil, nil, usererror.NotFound("Operation not found.")
+	}
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	switch op.ResourceType {
+	case enum.ParentResourceTypeRepo:
+		repo, err := c.repoStore.Find(ctx, op.ResourceID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return nil, nil, nil, usererror.NotFound("Operation not found.")
+		}
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to find repository of operation: %w", err)
+		}
+
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, repoPermission); err != nil {
+			return nil, nil, nil, err
+		}
+
+		return op, repo, nil, nil
+
+	case enum.ParentResourceTypeSpace:
+		space, err := c.spaceStore.Find(ctx, op.ResourceID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return nil, nil, nil, usererror.NotFound("Operation not found.")
+		}
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to find space of operation: %w", err)
+		}
+
+		if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, spacePermission); err != nil {
+			return nil, nil, nil, err
+		}
+
+		return op, nil, space, nil
+	}
+
+	return nil, nil, nil, fmt.Errorf("unsupported operation resource type %q", op.ResourceType)
+}
+
+// getResult returns the typed result of a succeeded operation.
+// The repository or the space of the operation is expected to be set depending on the operation resource type.
+func (c *Controller) getResult(
+	ctx context.Context,
+	op *types.Operation,
+	repository *types.Repository,
+	space *types.Space,
+	status operation.Status,
+) (any, error) {
+	switch op.Type {
+	case enum.OperationTypeRepoImport:
+		repoOut, err := repo.GetRepoOutput(ctx, c.publicAccess, repository)
+		if err != nil {
+			return nil, err
+		}
+
+		return repoOut, nil
+
+	case enum.OperationTypeSpaceExport:
+		return SpaceExportResult{Repos: status.Jobs}, nil
+
+	case enum.OperationTypeSpaceArchiveExport:
+		return SpaceArchiveExportResult{
+			DownloadPath: config.APIURL + "/spaces/" + url.PathEscape(space.Path) + "/export/archive",
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported operation type %q", op.Type)
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/operation"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Find returns the state, the progress and, once completed, the result or the error of an operation.
+func (c *Controller) Find(ctx context.Context, session *auth.Session, operationID string) (*Output, error) {
+	op, repo, space, err := c.getOperationCheckAccess(ctx, session, operationID,
+		enum.PermissionRepoView, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := c.operationSvc.GetStatus(ctx, op)
+	if errors.Is(err, operation.ErrNotFound) {
+		return nil, usererror.NotFound("Operation not found.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operation status: %w", err)
+	}
+
+	out := &Output{
+		ID:        op.UID,
+		Type:      op.Type,
+		State:     status.State,
+		Progress:  status.Progress,
+		CreatedBy: op.CreatedBy,
+		Created:   op.Created,
+	}
+
+	switch status.State {
+	case enum.OperationStateSucceeded:
+		out.Result, err = c.getResult(ctx, op, repo, space, status)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get operation result: %w", err)
+		}
+	case enum.OperationStateFailed:
+		out.Error = &types.OperationError{Message: status.Failure}
+	case enum.OperationStatePending, enum.OperationStateRunning, enum.OperationStateCancelled:
+	}
+
+	return out, nil
+}
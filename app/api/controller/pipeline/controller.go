@@ -17,6 +17,8 @@ package pipeline
 import (
 	"github.com/harness/gitness/app/auth/authz"
 	events "github.com/harness/gitness/app/events/pipeline"
+	"github.com/harness/gitness/app/pipeline/file"
+	"github.com/harness/gitness/app/pipeline/validator"
 	"github.com/harness/gitness/app/store"
 )
 
@@ -27,6 +29,8 @@ type Controller struct {
 	authorizer    authz.Authorizer
 	pipelineStore store.PipelineStore
 	reporter      events.Reporter
+	fileService   file.Service
+	validator     validator.Service
 }
 
 func NewController(
@@ -35,6 +39,8 @@ func NewController(
 	triggerStore store.TriggerStore,
 	pipelineStore store.PipelineStore,
 	reporter events.Reporter,
+	fileService file.Service,
+	validatorService validator.Service,
 ) *Controller {
 	return &Controller{
 		repoStore:     repoStore,
@@ -42,5 +48,7 @@ func NewController(
 		authorizer:    authorizer,
 		pipelineStore: pipelineStore,
 		reporter:      reporter,
+		fileService:   fileService,
+		validator:     validatorService,
 	}
 }
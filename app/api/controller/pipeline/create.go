@@ -35,6 +35,19 @@ var (
 	// errPipelineRequiresConfigPath is returned if the user tries to create a pipeline with an empty config path.
 	errPipelineRequiresConfigPath = usererror.BadRequest(
 		"Pipeline requires a config path.")
+
+	// errPipelineNegativeConcurrencyLimit is returned if the user provides a negative concurrency limit.
+	errPipelineNegativeConcurrencyLimit = usererror.BadRequest(
+		"Pipeline concurrency limit can't be negative.")
+
+	// errPipelineNegativeTimeout is returned if the user provides a negative timeout.
+	errPipelineNegativeTimeout = usererror.BadRequest(
+		"Pipeline timeout can't be negative.")
+
+	// errPipelineIdentifierExists is returned if the identifier is already used by another pipeline of the repo.
+	// Identifiers are compared case-insensitively.
+	errPipelineIdentifierExists = usererror.Conflict(
+		"A pipeline with the same identifier already exists in the repository.")
 )
 
 type CreateInput struct {
@@ -45,6 +58,11 @@ type CreateInput struct {
 	Disabled      bool   `json:"disabled"`
 	DefaultBranch string `json:"default_branch"`
 	ConfigPath    string `json:"config_path"`
+	// ConcurrencyLimit is the max number of executions running at once, 0 means unlimited.
+	ConcurrencyLimit int  `json:"concurrency_limit"`
+	CancelSuperseded bool `json:"cancel_superseded"`
+	// Timeout is the max duration (in ms) an execution may run, 0 means the instance wide maximum.
+	Timeout int64 `json:"timeout"`
 }
 
 func (c *Controller) Create(
@@ -67,20 +85,36 @@ func (c *Controller) Create(
 		return nil, fmt.Errorf("failed to authorize pipeline: %w", err)
 	}
 
+	exists, err := c.pipelineStore.IdentifierExists(ctx, repo.ID, in.Identifier, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if pipeline identifier exists: %w", err)
+	}
+	if exists {
+		return nil, errPipelineIdentifierExists
+	}
+
+	err = c.validateConfig(ctx, repo, in.DefaultBranch, in.ConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
 	var pipeline *types.Pipeline
 	now := time.Now().UnixMilli()
 	pipeline = &types.Pipeline{
-		Description:   in.Description,
-		RepoID:        repo.ID,
-		Identifier:    in.Identifier,
-		Disabled:      in.Disabled,
-		CreatedBy:     session.Principal.ID,
-		Seq:           0,
-		DefaultBranch: in.DefaultBranch,
-		ConfigPath:    in.ConfigPath,
-		Created:       now,
-		Updated:       now,
-		Version:       0,
+		Description:      in.Description,
+		RepoID:           repo.ID,
+		Identifier:       in.Identifier,
+		Disabled:         in.Disabled,
+		CreatedBy:        session.Principal.ID,
+		Seq:              0,
+		DefaultBranch:    in.DefaultBranch,
+		ConfigPath:       in.ConfigPath,
+		ConcurrencyLimit: in.ConcurrencyLimit,
+		CancelSuperseded: in.CancelSuperseded,
+		Timeout:          in.Timeout,
+		Created:          now,
+		Updated:          now,
+		Version:          0,
 	}
 	err = c.pipelineStore.Create(ctx, pipeline)
 	if err != nil {
@@ -120,7 +154,7 @@ func (c *Controller) sanitizeCreateInput(in *CreateInput) error {
 		in.Identifier = in.UID
 	}
 
-	if err := check.Identifier(in.Identifier); err != nil {
+	if err := check.UID(in.Identifier); err != nil {
 		return err
 	}
 
@@ -137,5 +171,13 @@ func (c *Controller) sanitizeCreateInput(in *CreateInput) error {
 		return errPipelineRequiresConfigPath
 	}
 
+	if in.ConcurrencyLimit < 0 {
+		return errPipelineNegativeConcurrencyLimit
+	}
+
+	if in.Timeout < 0 {
+		return errPipelineNegativeTimeout
+	}
+
 	return nil
 }
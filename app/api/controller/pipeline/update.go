@@ -29,11 +29,14 @@ import (
 
 type UpdateInput struct {
 	// TODO [CODE-1363]: remove after identifier migration.
-	UID         *string `json:"uid" deprecated:"true"`
-	Identifier  *string `json:"identifier"`
-	Description *string `json:"description"`
-	Disabled    *bool   `json:"disabled"`
-	ConfigPath  *string `json:"config_path"`
+	UID              *string `json:"uid" deprecated:"true"`
+	Identifier       *string `json:"identifier"`
+	Description      *string `json:"description"`
+	Disabled         *bool   `json:"disabled"`
+	ConfigPath       *string `json:"config_path"`
+	ConcurrencyLimit *int    `json:"concurrency_limit"`
+	CancelSuperseded *bool   `json:"cancel_superseded"`
+	Timeout          *int64  `json:"timeout"`
 }
 
 func (c *Controller) Update(
@@ -61,6 +64,23 @@ func (c *Controller) Update(
 		return nil, fmt.Errorf("failed to find pipeline: %w", err)
 	}
 
+	if in.Identifier != nil {
+		exists, err := c.pipelineStore.IdentifierExists(ctx, repo.ID, *in.Identifier, pipeline.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check if pipeline identifier exists: %w", err)
+		}
+		if exists {
+			return nil, errPipelineIdentifierExists
+		}
+	}
+
+	if in.ConfigPath != nil {
+		err = c.validateConfig(ctx, repo, pipeline.DefaultBranch, *in.ConfigPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	updated, err := c.pipelineStore.UpdateOptLock(ctx, pipeline, func(pipeline *types.Pipeline) error {
 		if in.Identifier != nil {
 			pipeline.Identifier = *in.Identifier
@@ -74,6 +94,15 @@ func (c *Controller) Update(
 		if in.Disabled != nil {
 			pipeline.Disabled = *in.Disabled
 		}
+		if in.ConcurrencyLimit != nil {
+			pipeline.ConcurrencyLimit = *in.ConcurrencyLimit
+		}
+		if in.CancelSuperseded != nil {
+			pipeline.CancelSuperseded = *in.CancelSuperseded
+		}
+		if in.Timeout != nil {
+			pipeline.Timeout = *in.Timeout
+		}
 
 		return nil
 	})
@@ -91,7 +120,7 @@ func (c *Controller) sanitizeUpdateInput(in *UpdateInput) error {
 	}
 
 	if in.Identifier != nil {
-		if err := check.Identifier(*in.Identifier); err != nil {
+		if err := check.UID(*in.Identifier); err != nil {
 			return err
 		}
 	}
@@ -109,5 +138,13 @@ func (c *Controller) sanitizeUpdateInput(in *UpdateInput) error {
 		}
 	}
 
+	if in.ConcurrencyLimit != nil && *in.ConcurrencyLimit < 0 {
+		return errPipelineNegativeConcurrencyLimit
+	}
+
+	if in.Timeout != nil && *in.Timeout < 0 {
+		return errPipelineNegativeTimeout
+	}
+
 	return nil
 }
@@ -0,0 +1,136 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ValidateInput is the input of a pipeline yaml validation.
+// If Yaml is empty, the yaml is read from ConfigPath at Ref of the repository.
+type ValidateInput struct {
+	Yaml       string `json:"yaml"`
+	ConfigPath string `json:"config_path"`
+	Ref        string `json:"ref"`
+}
+
+// Validate validates a pipeline yaml without saving or executing it.
+// Problems found in the yaml are part of the result and not returned as error.
+func (c *Controller) Validate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *ValidateInput,
+) (*types.PipelineValidation, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+
+	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path, "", enum.PermissionPipelineEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize pipeline: %w", err)
+	}
+
+	data := []byte(in.Yaml)
+	if strings.TrimSpace(in.Yaml) == "" {
+		if in.ConfigPath == "" {
+			return nil, usererror.BadRequest("Either the pipeline yaml or a config path is required.")
+		}
+
+		data, err = c.readConfig(ctx, repo, in.ConfigPath, in.Ref)
+		if errors.IsNotFound(err) {
+			return nil, usererror.NotFoundf("Pipeline config %q doesn't exist.", in.ConfigPath)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := c.validator.Validate(ctx, repo, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate pipeline yaml: %w", err)
+	}
+
+	return result, nil
+}
+
+// validateConfig validates the pipeline config stored in the repository before a pipeline is saved.
+// Configs that don't exist yet or need to be converted (jsonnet, starlark) are skipped.
+// Errors reject the pipeline, warnings don't.
+func (c *Controller) validateConfig(
+	ctx context.Context,
+	repo *types.Repository,
+	branch string,
+	configPath string,
+) error {
+	if !isYamlConfig(configPath) {
+		return nil
+	}
+
+	data, err := c.readConfig(ctx, repo, configPath, branch)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	result, err := c.validator.Validate(ctx, repo, data)
+	if err != nil {
+		return fmt.Errorf("failed to validate pipeline yaml: %w", err)
+	}
+
+	if !result.Valid {
+		return usererror.BadRequestWithPayload(
+			fmt.Sprintf("Pipeline config %q is invalid.", configPath),
+			map[string]any{"problems": result.Problems},
+		)
+	}
+
+	return nil
+}
+
+// readConfig reads the pipeline config from the repository, ref defaults to the default branch of the repository.
+func (c *Controller) readConfig(
+	ctx context.Context,
+	repo *types.Repository,
+	configPath string,
+	ref string,
+) ([]byte, error) {
+	if ref == "" {
+		ref = repo.DefaultBranch
+	}
+
+	file, err := c.fileService.Get(ctx, repo, configPath, ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pipeline config: %w", err)
+	}
+
+	return file.Data, nil
+}
+
+func isYamlConfig(path string) bool {
+	return strings.HasSuffix(path, ".yaml") || strings.HasSuffix(path, ".yml")
+}
@@ -17,6 +17,8 @@ package pipeline
 import (
 	"github.com/harness/gitness/app/auth/authz"
 	events "github.com/harness/gitness/app/events/pipeline"
+	"github.com/harness/gitness/app/pipeline/file"
+	"github.com/harness/gitness/app/pipeline/validator"
 	"github.com/harness/gitness/app/store"
 
 	"github.com/google/wire"
@@ -33,6 +35,8 @@ func ProvideController(
 	authorizer authz.Authorizer,
 	pipelineStore store.PipelineStore,
 	reporter *events.Reporter,
+	fileService file.Service,
+	validatorService validator.Service,
 ) *Controller {
 	return NewController(
 		authorizer,
@@ -40,5 +44,7 @@ func ProvideController(
 		triggerStore,
 		pipelineStore,
 		*reporter,
+		fileService,
+		validatorService,
 	)
 }
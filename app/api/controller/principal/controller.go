@@ -16,17 +16,24 @@ package principal
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/avatar"
 	"github.com/harness/gitness/app/store"
 )
 
 type controller struct {
 	principalStore store.PrincipalStore
 	authorizer     authz.Authorizer
+	avatars        *avatar.Service
 }
 
-func newController(principalStore store.PrincipalStore, authorizer authz.Authorizer) *controller {
+func newController(
+	principalStore store.PrincipalStore,
+	authorizer authz.Authorizer,
+	avatars *avatar.Service,
+) *controller {
 	return &controller{
 		principalStore: principalStore,
 		authorizer:     authorizer,
+		avatars:        avatars,
 	}
 }
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/avatar"
 	"github.com/harness/gitness/types"
 )
 
@@ -28,4 +29,6 @@ type Controller interface {
 	List(ctx context.Context, session *auth.Session, opts *types.PrincipalFilter) ([]*types.PrincipalInfo, error)
 	Find(ctx context.Context, session *auth.Session, principalID int64) (*types.PrincipalInfo, error)
 	CheckExistenceByEmails(ctx context.Context, session *auth.Session, input *CheckUsersInput) (*CheckUsersOutput, error)
+	// Avatar returns the avatar of the principal in the requested size.
+	Avatar(ctx context.Context, session *auth.Session, principalUID string, size int) (*avatar.Image, error)
 }
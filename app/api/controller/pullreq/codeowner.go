@@ -78,6 +78,7 @@ func mapCodeOwnerEvaluation(ownerEvaluation *codeowners.Evaluation) []types.Code
 		codeOwnerEvaluationEntries[i] = types.CodeOwnerEvaluationEntry{
 			LineNumber:                entry.LineNumber,
 			Pattern:                   entry.Pattern,
+			Paths:                     entry.Paths,
 			OwnerEvaluations:          ownerEvaluations,
 			UserGroupOwnerEvaluations: userGroupOwnerEvaluations,
 		}
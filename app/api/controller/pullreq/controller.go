@@ -20,25 +20,27 @@ import (
 	"unicode/utf8"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/commitpolicy"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/label"
 	locker "github.com/harness/gitness/app/services/locker"
 	"github.com/harness/gitness/app/services/migrate"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
-	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
-	gitenum "github.com/harness/gitness/git/enum"
+	gitapi "github.com/harness/gitness/git/api"
 	"github.com/harness/gitness/git/sha"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
@@ -75,6 +77,8 @@ type Controller struct {
 	labelSvc               *label.Service
 	instrumentation        instrument.Service
 	userGroupService       usergroup.SearchService
+	settings               *settings.Service
+	commitPolicy           *commitpolicy.Service
 }
 
 func NewController(
@@ -107,6 +111,8 @@ func NewController(
 	labelSvc *label.Service,
 	instrumentation instrument.Service,
 	userGroupService usergroup.SearchService,
+	settings *settings.Service,
+	commitPolicy *commitpolicy.Service,
 ) *Controller {
 	return &Controller{
 		tx:                     tx,
@@ -138,33 +144,74 @@ func NewController(
 		labelSvc:               labelSvc,
 		instrumentation:        instrumentation,
 		userGroupService:       userGroupService,
+		settings:               settings,
+		commitPolicy:           commitPolicy,
 	}
 }
 
-func (c *Controller) verifyBranchExistence(ctx context.Context,
-	repo *types.Repository, branch string,
+// verifySourceTargetBranches verifies that the source and the target branch exist and returns the source branch SHA.
+// Branches of the same repository are resolved with a single git call.
+func (c *Controller) verifySourceTargetBranches(
+	ctx context.Context,
+	sourceRepo *types.Repository,
+	targetRepo *types.Repository,
+	sourceBranch string,
+	targetBranch string,
 ) (sha.SHA, error) {
-	if branch == "" {
-		return sha.SHA{}, usererror.BadRequest("branch name can't be empty")
+	if sourceRepo.ID == targetRepo.ID {
+		shas, err := c.verifyBranchesExistence(ctx, sourceRepo, sourceBranch, targetBranch)
+		if err != nil {
+			return sha.None, err
+		}
+
+		return shas[0], nil
+	}
+
+	shas, err := c.verifyBranchesExistence(ctx, sourceRepo, sourceBranch)
+	if err != nil {
+		return sha.None, err
+	}
+
+	if _, err = c.verifyBranchesExistence(ctx, targetRepo, targetBranch); err != nil {
+		return sha.None, err
 	}
 
-	ref, err := c.git.GetRef(ctx,
-		git.GetRefParams{
-			ReadParams: git.ReadParams{RepoUID: repo.GitUID},
-			Name:       branch,
-			Type:       gitenum.RefTypeBranch,
-		})
-	if errors.AsStatus(err) == errors.StatusNotFound {
-		return sha.SHA{}, usererror.BadRequest(
-			fmt.Sprintf("branch %q does not exist in the repository %q", branch, repo.Identifier))
+	return shas[0], nil
+}
+
+// verifyBranchesExistence verifies that all provided branches exist in the repository
+// and returns their SHAs in the same order.
+func (c *Controller) verifyBranchesExistence(ctx context.Context,
+	repo *types.Repository, branches ...string,
+) ([]sha.SHA, error) {
+	refs := make([]string, len(branches))
+	for i, branch := range branches {
+		if branch == "" {
+			return nil, usererror.BadRequest("branch name can't be empty")
+		}
+		refs[i] = gitapi.BranchPrefix + branch
 	}
+
+	out, err := c.git.GetRefs(ctx, &git.GetRefsParams{
+		ReadParams: git.ReadParams{RepoUID: repo.GitUID},
+		Refs:       refs,
+	})
 	if err != nil {
-		return sha.SHA{}, fmt.Errorf(
-			"failed to check existence of the branch %q in the repository %q: %w",
-			branch, repo.Identifier, err)
+		return nil, fmt.Errorf(
+			"failed to check existence of the branches %v in the repository %q: %w",
+			branches, repo.Identifier, err)
 	}
 
-	return ref.SHA, nil
+	shas := make([]sha.SHA, len(branches))
+	for i, ref := range out.Refs {
+		if !ref.Exists {
+			return nil, usererror.BadRequest(
+				fmt.Sprintf("branch %q does not exist in the repository %q", branches[i], repo.Identifier))
+		}
+		shas[i] = ref.SHA
+	}
+
+	return shas, nil
 }
 
 func (c *Controller) getRepo(ctx context.Context, repoRef string) (*types.Repository, error) {
@@ -178,7 +225,7 @@ func (c *Controller) getRepo(ctx context.Context, repoRef string) (*types.Reposi
 	}
 
 	if repo.State != enum.RepoStateActive {
-		return nil, usererror.BadRequest("Repository is not ready to use.")
+		return nil, usererror.RepoUnavailable(repo.State, repo.StateUntil)
 	}
 
 	return repo, nil
@@ -192,6 +239,8 @@ func (c *Controller) getRepoCheckAccess(ctx context.Context,
 		return nil, err
 	}
 
+	controller.WithRepo(ctx, repo)
+
 	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, reqPermission); err != nil {
 		return nil, fmt.Errorf("access check failed: %w", err)
 	}
@@ -48,6 +48,9 @@ type MergeInput struct {
 	Message     string           `json:"message"`
 	BypassRules bool             `json:"bypass_rules"`
 	DryRun      bool             `json:"dry_run"`
+
+	// DeleteSourceBranch overrides the delete source branch on merge setting of the repository.
+	DeleteSourceBranch *bool `json:"delete_source_branch,omitempty"`
 }
 
 func (in *MergeInput) sanitize() error {
@@ -171,14 +174,14 @@ func (c *Controller) Merge(
 	sourceRepo := targetRepo
 	sourceWriteParams := targetWriteParams
 	if pr.SourceRepoID != pr.TargetRepoID {
-		sourceWriteParams, err = controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, sourceRepo)
+		sourceRepo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+			return nil, nil, fmt.Errorf("failed to get source repository: %w", err)
 		}
 
-		sourceRepo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
+		sourceWriteParams, err = controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, sourceRepo)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to get source repository: %w", err)
+			return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
 		}
 	}
 
@@ -219,6 +222,12 @@ func (c *Controller) Merge(
 		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
 	}
 
+	deleteSourceBranch, warnings, err := c.sourceBranchDeletion(ctx, targetRepo, sourceRepo, pr,
+		in.DeleteSourceBranch, ruleOut.DeleteSourceBranch)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// we want to complete the merge independent of request cancel - start with new, time restricted context.
 	// TODO: This is a small change to reduce likelihood of dirty state.
 	// We still require a proper solution to handle an application crash or very slow execution times
@@ -291,8 +300,9 @@ func (c *Controller) Merge(
 
 		// With in.DryRun=true this function never returns types.MergeViolations
 		out := &types.MergeResponse{
-			BranchDeleted:  ruleOut.DeleteSourceBranch,
+			BranchDeleted:  deleteSourceBranch,
 			RuleViolations: violations,
+			Warnings:       warnings,
 
 			// values only returned by dry run
 			DryRun:                              true,
@@ -353,16 +363,31 @@ func (c *Controller) Merge(
 		committer = identityFromPrincipalInfo(*session.Principal.ToPrincipalInfo())
 	}
 
+	// use the commit message template of the repository if no message provided
+	if in.Message == "" {
+		var msg MergeMessage
+		var templated bool
+		msg, templated, err = c.renderMergeMessage(ctx, targetRepo, sourceRepo, pr, in.Method, author)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if templated {
+			if in.Title == "" {
+				in.Title = msg.Title
+			}
+			in.Message = msg.Message
+		}
+	}
+
 	// backfill commit title if none provided
 	if in.Title == "" {
-		switch in.Method {
-		case enum.MergeMethodMerge:
-			in.Title = fmt.Sprintf("Merge branch '%s' of %s (#%d)", pr.SourceBranch, sourceRepo.Path, pr.Number)
-		case enum.MergeMethodSquash:
-			in.Title = fmt.Sprintf("%s (#%d)", pr.Title, pr.Number)
-		case enum.MergeMethodRebase:
-			// Not used.
-		}
+		in.Title = defaultMergeTitle(in.Method, pr, sourceRepo)
+	}
+
+	err = c.applyCommitMessagePolicy(ctx, targetRepo, pr, *session.Principal.ToPrincipalInfo(), in)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// create merge commit(s)
@@ -461,7 +486,7 @@ func (c *Controller) Merge(
 		pr.ActivitySeq++
 		activitySeqMerge = pr.ActivitySeq
 
-		if ruleOut.DeleteSourceBranch {
+		if deleteSourceBranch {
 			pr.ActivitySeq++
 			activitySeqBranchDeleted = pr.ActivitySeq
 		}
@@ -494,7 +519,7 @@ func (c *Controller) Merge(
 	})
 
 	var branchDeleted bool
-	if ruleOut.DeleteSourceBranch {
+	if deleteSourceBranch {
 		errDelete := c.git.DeleteBranch(ctx, &git.DeleteBranchParams{
 			WriteParams: sourceWriteParams,
 			BranchName:  pr.SourceBranch,
@@ -502,6 +527,7 @@ func (c *Controller) Merge(
 		if errDelete != nil {
 			// non-critical error
 			log.Ctx(ctx).Err(errDelete).Msgf("failed to delete source branch after merging")
+			warnings = append(warnings, fmt.Sprintf("Failed to delete source branch %q.", pr.SourceBranch))
 		} else {
 			branchDeleted = true
 
@@ -539,5 +565,6 @@ func (c *Controller) Merge(
 		SHA:            mergeOutput.MergeSHA.String(),
 		BranchDeleted:  branchDeleted,
 		RuleViolations: violations,
+		Warnings:       warnings,
 	}, nil, nil
 }
@@ -0,0 +1,83 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// sourceBranchDeletion decides whether the source branch of the pull request should be deleted after merging.
+// The branch deletion is requested either by a protection rule, by the merge input or by the repository setting.
+// A requested deletion is skipped with a warning if the branch is the default branch of the source repository
+// or if other open pull requests use the same source branch.
+func (c *Controller) sourceBranchDeletion(
+	ctx context.Context,
+	targetRepo *types.Repository,
+	sourceRepo *types.Repository,
+	pr *types.PullReq,
+	requested *bool,
+	requiredByRules bool,
+) (bool, []string, error) {
+	shouldDelete := requiredByRules
+	if !shouldDelete && requested != nil {
+		shouldDelete = *requested
+	} else if !shouldDelete {
+		var err error
+		shouldDelete, err = settings.RepoGet(
+			ctx,
+			c.settings,
+			targetRepo.ID,
+			settings.KeyDeleteSourceBranchOnMerge,
+			settings.DefaultDeleteSourceBranchOnMerge,
+		)
+		if err != nil {
+			return false, nil, fmt.Errorf("failed to get delete source branch on merge setting: %w", err)
+		}
+	}
+
+	if !shouldDelete {
+		return false, nil, nil
+	}
+
+	if pr.SourceBranch == sourceRepo.DefaultBranch {
+		return false, []string{fmt.Sprintf(
+			"Source branch %q is the default branch of the repository and won't be deleted.", pr.SourceBranch,
+		)}, nil
+	}
+
+	openCount, err := c.pullreqStore.Count(ctx, &types.PullReqFilter{
+		SourceRepoID: pr.SourceRepoID,
+		SourceBranch: pr.SourceBranch,
+		States:       []enum.PullReqState{enum.PullReqStateOpen},
+	})
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to count open pull requests of the source branch: %w", err)
+	}
+
+	// the pull request that is being merged is still open and is included in the count.
+	if openCount > 1 {
+		return false, []string{fmt.Sprintf(
+			"Source branch %q is used by %d other open pull request(s) and won't be deleted.",
+			pr.SourceBranch, openCount-1,
+		)}, nil
+	}
+
+	return true, nil, nil
+}
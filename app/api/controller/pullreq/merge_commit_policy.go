@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/commitpolicy"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// trailerSignedOffBy is the only required trailer that gets added to merge commits automatically,
+// as the merging user signs off the commit created on their behalf.
+const trailerSignedOffBy = "Signed-off-by"
+
+// applyCommitMessagePolicy makes sure the title and message of the merge or squash commit comply
+// with the commit message policy of the target repository. A missing sign-off trailer is added for the merging user,
+// any other violation is returned as an error so the user can provide a compliant title and message.
+func (c *Controller) applyCommitMessagePolicy(
+	ctx context.Context,
+	targetRepo *types.Repository,
+	pr *types.PullReq,
+	merger types.PrincipalInfo,
+	in *MergeInput,
+) error {
+	if in.Method != enum.MergeMethodMerge && in.Method != enum.MergeMethodSquash {
+		return nil // other merge methods don't create new commit messages.
+	}
+
+	checker, err := c.commitPolicy.RepoChecker(ctx, targetRepo)
+	if err != nil {
+		return fmt.Errorf("failed to get commit message policy: %w", err)
+	}
+	if !checker.Active() || checker.ExcludesBranch(pr.TargetBranch) {
+		return nil
+	}
+
+	trailer := checker.RequiredTrailer()
+	if strings.EqualFold(trailer, trailerSignedOffBy) &&
+		!commitpolicy.HasTrailer(in.Title+"\n\n"+in.Message, trailer) {
+		in.Message = commitpolicy.AppendTrailer(in.Message, trailerSignedOffBy,
+			fmt.Sprintf("%s <%s>", merger.DisplayName, merger.Email))
+	}
+
+	parentCount := 1
+	if in.Method == enum.MergeMethodMerge {
+		parentCount = 2
+	}
+
+	if reasons := checker.Check(in.Title+"\n\n"+in.Message, parentCount); len(reasons) > 0 {
+		return usererror.BadRequestf(
+			"The commit message doesn't comply with the commit message policy of the repository: %s.",
+			strings.Join(reasons, "; "))
+	}
+
+	return nil
+}
@@ -0,0 +1,221 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/mergemessage"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// mergeMessageCommitsLimit is the maximum number of commits listed in a merge commit message.
+const mergeMessageCommitsLimit = 100
+
+// MergeMessage is the commit title and message used when merging a pull request
+// without providing a title and message.
+type MergeMessage struct {
+	Title   string `json:"title"`
+	Message string `json:"message"`
+	// Templated is true if the message is rendered from the commit message template of the repository.
+	Templated bool     `json:"templated"`
+	Warnings  []string `json:"warnings,omitempty"`
+}
+
+// MergeMessagePreview renders the commit title and message that would be used to merge the pull request
+// with the provided method in case the client doesn't provide them.
+func (c *Controller) MergeMessagePreview(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	method enum.MergeMethod,
+) (MergeMessage, error) {
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return MergeMessage{}, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, targetRepo.ID, pullreqNum)
+	if err != nil {
+		return MergeMessage{}, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	sourceRepo := targetRepo
+	if pr.SourceRepoID != pr.TargetRepoID {
+		sourceRepo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
+		if err != nil {
+			return MergeMessage{}, fmt.Errorf("failed to get source repository: %w", err)
+		}
+	}
+
+	var author *git.Identity
+	switch method {
+	case enum.MergeMethodMerge:
+		author = identityFromPrincipalInfo(*session.Principal.ToPrincipalInfo())
+	case enum.MergeMethodSquash:
+		author = identityFromPrincipalInfo(pr.Author)
+	case enum.MergeMethodRebase:
+		author = nil
+	}
+
+	msg, ok, err := c.renderMergeMessage(ctx, targetRepo, sourceRepo, pr, method, author)
+	if err != nil {
+		return MergeMessage{}, err
+	}
+
+	if msg.Title == "" {
+		msg.Title = defaultMergeTitle(method, pr, sourceRepo)
+	}
+
+	msg.Templated = ok
+
+	return msg, nil
+}
+
+// renderMergeMessage renders the commit message template of the target repository for the merge method.
+// It returns false if the repository doesn't have a template for the merge method.
+func (c *Controller) renderMergeMessage(
+	ctx context.Context,
+	targetRepo *types.Repository,
+	sourceRepo *types.Repository,
+	pr *types.PullReq,
+	method enum.MergeMethod,
+	author *git.Identity,
+) (MergeMessage, bool, error) {
+	var key settings.Key
+	switch method {
+	case enum.MergeMethodMerge:
+		key = settings.KeyMergeCommitTemplate
+	case enum.MergeMethodSquash:
+		key = settings.KeySquashCommitTemplate
+	case enum.MergeMethodRebase:
+		// rebase doesn't create any new commit messages.
+		return MergeMessage{}, false, nil
+	}
+
+	text, err := settings.RepoGet(ctx, c.settings, targetRepo.ID, key, "")
+	if err != nil {
+		return MergeMessage{}, false, fmt.Errorf("failed to get commit message template setting: %w", err)
+	}
+
+	if text == "" {
+		return MergeMessage{}, false, nil
+	}
+
+	tmpl, err := mergemessage.Parse(text)
+	if err != nil {
+		// templates are validated when saved, an invalid template mustn't block the merge.
+		log.Ctx(ctx).Warn().Err(err).Msgf("ignoring invalid %s setting of repo %d", key, targetRepo.ID)
+		return MergeMessage{}, false, nil
+	}
+
+	values := mergemessage.Values{
+		mergemessage.VarTitle:        pr.Title,
+		mergemessage.VarNumber:       strconv.FormatInt(pr.Number, 10),
+		mergemessage.VarDescription:  pr.Description,
+		mergemessage.VarSourceBranch: pr.SourceBranch,
+		mergemessage.VarTargetBranch: pr.TargetBranch,
+		mergemessage.VarAuthor:       pr.Author.DisplayName,
+	}
+
+	if tmpl.Uses(mergemessage.VarCommits) || tmpl.Uses(mergemessage.VarCoAuthors) {
+		var commits, coAuthors string
+		commits, coAuthors, err = c.mergeMessageCommits(ctx, targetRepo, pr, author)
+		if err != nil {
+			return MergeMessage{}, false, err
+		}
+
+		values[mergemessage.VarCommits] = commits
+		values[mergemessage.VarCoAuthors] = coAuthors
+	}
+
+	rendered, warnings := tmpl.Render(values)
+	for _, warning := range warnings {
+		log.Ctx(ctx).Warn().Msgf("%s template of repo %d: %s", key, targetRepo.ID, warning)
+	}
+
+	title, message := mergemessage.SplitMessage(rendered)
+
+	return MergeMessage{
+		Title:    title,
+		Message:  message,
+		Warnings: warnings,
+	}, true, nil
+}
+
+// mergeMessageCommits returns the list of the pull request commits, oldest first,
+// and the co-authored-by trailers of the authors of the commits other than the author of the merge commit.
+func (c *Controller) mergeMessageCommits(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+	author *git.Identity,
+) (string, string, error) {
+	output, err := c.git.ListCommits(ctx, &git.ListCommitsParams{
+		ReadParams: git.CreateReadParams(repo),
+		GitREF:     pr.SourceSHA,
+		After:      pr.MergeBaseSHA,
+		Limit:      mergeMessageCommitsLimit,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to list pull request commits: %w", err)
+	}
+
+	seen := map[string]struct{}{}
+	if author != nil {
+		seen[strings.ToLower(author.Email)] = struct{}{}
+	}
+
+	var commits, coAuthors []string
+	for i := len(output.Commits) - 1; i >= 0; i-- {
+		commit := output.Commits[i]
+		commits = append(commits, "* "+commit.Title)
+
+		identity := commit.Author.Identity
+		email := strings.ToLower(identity.Email)
+		if _, ok := seen[email]; ok {
+			continue
+		}
+		seen[email] = struct{}{}
+
+		coAuthors = append(coAuthors, fmt.Sprintf("Co-authored-by: %s <%s>", identity.Name, identity.Email))
+	}
+
+	return strings.Join(commits, "\n"), strings.Join(coAuthors, "\n"), nil
+}
+
+// defaultMergeTitle returns the commit title used if neither the client nor a template provide one.
+func defaultMergeTitle(method enum.MergeMethod, pr *types.PullReq, sourceRepo *types.Repository) string {
+	switch method {
+	case enum.MergeMethodMerge:
+		return fmt.Sprintf("Merge branch '%s' of %s (#%d)", pr.SourceBranch, sourceRepo.Path, pr.Number)
+	case enum.MergeMethodSquash:
+		return fmt.Sprintf("%s (#%d)", pr.Title, pr.Number)
+	case enum.MergeMethodRebase:
+		// Not used.
+	}
+
+	return ""
+}
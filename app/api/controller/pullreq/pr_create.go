@@ -86,13 +86,8 @@ func (c *Controller) Create(
 		return nil, usererror.BadRequest("target and source branch can't be the same")
 	}
 
-	var sourceSHA sha.SHA
-
-	if sourceSHA, err = c.verifyBranchExistence(ctx, sourceRepo, in.SourceBranch); err != nil {
-		return nil, err
-	}
-
-	if _, err = c.verifyBranchExistence(ctx, targetRepo, in.TargetBranch); err != nil {
+	sourceSHA, err := c.verifySourceTargetBranches(ctx, sourceRepo, targetRepo, in.SourceBranch, in.TargetBranch)
+	if err != nil {
 		return nil, err
 	}
 
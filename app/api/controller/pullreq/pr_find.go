@@ -20,6 +20,8 @@ import (
 
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -56,5 +58,25 @@ func (c *Controller) Find(
 		log.Ctx(ctx).Warn().Err(err).Msg("failed to backfill PR stats")
 	}
 
+	c.backfillCodeOwners(ctx, repo, pr)
+
 	return pr, nil
 }
+
+// backfillCodeOwners populates the owners of the files changed by the pull request - best effort.
+func (c *Controller) backfillCodeOwners(ctx context.Context, repo *types.Repository, pr *types.PullReq) {
+	if pr.State != enum.PullReqStateOpen {
+		return
+	}
+
+	ownership, err := c.codeOwners.GetOwnershipForPullReq(ctx, repo, pr)
+	if errors.Is(err, codeowners.ErrNotFound) {
+		return
+	}
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to backfill PR code owners")
+		return
+	}
+
+	pr.CodeOwners = ownership
+}
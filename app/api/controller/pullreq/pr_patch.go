@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Patch writes the pull request as mailbox of its commits (git format-patch) or as its full plain diff to w.
+// setSHAs is called with the source and merge base SHA of the pull request before anything is written.
+func (c *Controller) Patch(
+	ctx context.Context,
+	w io.Writer,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	format enum.PatchFormat,
+	setSHAs func(sourceSHA, mergeBaseSHA string),
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	setSHAs(pr.SourceSHA, pr.MergeBaseSHA)
+
+	switch format {
+	case enum.PatchFormatPatch:
+		return c.git.FormatPatch(ctx, w, &git.FormatPatchParams{
+			ReadParams: git.CreateReadParams(repo),
+			BaseRef:    pr.MergeBaseSHA,
+			HeadRef:    pr.SourceSHA,
+		})
+	case enum.PatchFormatDiff:
+		return c.git.RawDiff(ctx, w, &git.DiffParams{
+			ReadParams: git.CreateReadParams(repo),
+			BaseRef:    pr.MergeBaseSHA,
+			HeadRef:    pr.SourceSHA,
+			MergeBase:  true,
+		})
+	default:
+		return fmt.Errorf("unsupported patch format %q", format)
+	}
+}
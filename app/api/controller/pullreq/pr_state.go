@@ -108,11 +108,8 @@ func (c *Controller) State(ctx context.Context,
 
 	//nolint:nestif // refactor if needed
 	if pr.State != enum.PullReqStateOpen && in.State == enum.PullReqStateOpen {
-		if sourceSHA, err = c.verifyBranchExistence(ctx, sourceRepo, pr.SourceBranch); err != nil {
-			return nil, err
-		}
-
-		if _, err = c.verifyBranchExistence(ctx, targetRepo, pr.TargetBranch); err != nil {
+		sourceSHA, err = c.verifySourceTargetBranches(ctx, sourceRepo, targetRepo, pr.SourceBranch, pr.TargetBranch)
+		if err != nil {
 			return nil, err
 		}
 
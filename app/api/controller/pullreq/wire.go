@@ -19,12 +19,14 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/commitpolicy"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/label"
 	"github.com/harness/gitness/app/services/locker"
 	"github.com/harness/gitness/app/services/migrate"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
@@ -59,6 +61,8 @@ func ProvideController(tx dbtx.Transactor, urlProvider url.Provider, authorizer
 	labelSvc *label.Service,
 	instrumentation instrument.Service,
 	userGroupService usergroup.SearchService,
+	settings *settings.Service,
+	commitPolicy *commitpolicy.Service,
 ) *Controller {
 	return NewController(tx,
 		urlProvider,
@@ -89,5 +93,7 @@ func ProvideController(tx dbtx.Transactor, urlProvider url.Provider, authorizer
 		labelSvc,
 		instrumentation,
 		userGroupService,
+		settings,
+		commitPolicy,
 	)
 }
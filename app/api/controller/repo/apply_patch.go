@@ -0,0 +1,149 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ApplyPatchOptions holds the data for applying a patch.
+type ApplyPatchOptions struct {
+	Title     string `json:"title"`
+	Message   string `json:"message"`
+	Branch    string `json:"branch"`
+	NewBranch string `json:"new_branch"`
+
+	// Patch is a unified diff or a mailbox created by git format-patch.
+	Patch    string                   `json:"patch"`
+	Encoding enum.ContentEncodingType `json:"encoding"`
+
+	DryRunRules bool `json:"dry_run_rules"`
+	BypassRules bool `json:"bypass_rules"`
+}
+
+func (in *ApplyPatchOptions) sanitize() error {
+	in.Title = strings.TrimSpace(in.Title)
+	if in.Title == "" {
+		return usererror.BadRequest("Commit title must be provided.")
+	}
+
+	if in.Patch == "" {
+		return usererror.BadRequest("Patch must be provided.")
+	}
+
+	return nil
+}
+
+// ApplyPatch applies the patch to the branch and commits the result.
+// Files the patch can't be applied to are reported with their rejected hunks in a conflict error.
+func (c *Controller) ApplyPatch(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *ApplyPatchOptions,
+) (types.CommitFilesResponse, []types.RuleViolations, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return types.CommitFilesResponse{}, nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return types.CommitFilesResponse{}, nil, err
+	}
+
+	patch := []byte(in.Patch)
+	if in.Encoding == enum.ContentEncodingTypeBase64 {
+		patch, err = base64.StdEncoding.DecodeString(in.Patch)
+		if err != nil {
+			return types.CommitFilesResponse{}, nil, usererror.BadRequest("Patch isn't valid base64 encoded data.")
+		}
+	}
+
+	rules, isRepoOwner, err := c.fetchRules(ctx, session, repo)
+	if err != nil {
+		return types.CommitFilesResponse{}, nil, err
+	}
+
+	refAction := protection.RefActionUpdate
+	branchName := in.Branch
+	if in.NewBranch != "" {
+		refAction = protection.RefActionCreate
+		branchName = in.NewBranch
+	}
+
+	violations, err := rules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+		Actor:       &session.Principal,
+		AllowBypass: in.BypassRules,
+		IsRepoOwner: isRepoOwner,
+		Repo:        repo,
+		RefAction:   refAction,
+		RefType:     protection.RefTypeBranch,
+		RefNames:    []string{branchName},
+	})
+	if err != nil {
+		return types.CommitFilesResponse{}, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	if in.DryRunRules {
+		return types.CommitFilesResponse{
+			DryRunRules:    true,
+			RuleViolations: violations,
+		}, nil, nil
+	}
+
+	if protection.IsCritical(violations) {
+		return types.CommitFilesResponse{}, violations, nil
+	}
+
+	// Create internal write params. Note: This will skip the pre-commit protection rules check.
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return types.CommitFilesResponse{}, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	now := time.Now()
+	commit, err := c.git.CommitFiles(ctx, &git.CommitFilesParams{
+		WriteParams:   writeParams,
+		Title:         in.Title,
+		Message:       in.Message,
+		Branch:        in.Branch,
+		NewBranch:     in.NewBranch,
+		Patch:         patch,
+		Committer:     identityFromPrincipal(bootstrap.NewSystemServiceSession().Principal),
+		CommitterDate: &now,
+		Author:        identityFromPrincipal(session.Principal),
+		AuthorDate:    &now,
+	})
+	if err != nil {
+		return types.CommitFilesResponse{}, nil, err
+	}
+
+	return types.CommitFilesResponse{
+		CommitID:       commit.CommitID.String(),
+		RuleViolations: violations,
+	}, nil, nil
+}
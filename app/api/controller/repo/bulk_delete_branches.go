@@ -0,0 +1,167 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// maxBulkDeleteBranches is the max number of branches that can be deleted with a single request.
+const maxBulkDeleteBranches = 100
+
+// BulkDeleteBranchesInput is used for deleting multiple branches of a repository.
+type BulkDeleteBranchesInput struct {
+	Branches    []string `json:"branches"`
+	BypassRules bool     `json:"bypass_rules"`
+	DryRunRules bool     `json:"dry_run_rules"`
+}
+
+func (in *BulkDeleteBranchesInput) sanitize() error {
+	seen := make(map[string]struct{}, len(in.Branches))
+	branches := make([]string, 0, len(in.Branches))
+	for _, branch := range in.Branches {
+		branch = strings.TrimSpace(branch)
+		if branch == "" {
+			continue
+		}
+		if _, ok := seen[branch]; ok {
+			continue
+		}
+
+		seen[branch] = struct{}{}
+		branches = append(branches, branch)
+	}
+
+	if len(branches) == 0 {
+		return usererror.BadRequest("At least one branch name is required.")
+	}
+
+	if len(branches) > maxBulkDeleteBranches {
+		return usererror.BadRequestf("At most %d branches can be deleted at once.", maxBulkDeleteBranches)
+	}
+
+	in.Branches = branches
+
+	return nil
+}
+
+// BulkDeleteBranchResult is the outcome of the deletion of a single branch.
+type BulkDeleteBranchResult struct {
+	Name           string                 `json:"name"`
+	Deleted        bool                   `json:"deleted"`
+	Error          string                 `json:"error,omitempty"`
+	RuleViolations []types.RuleViolations `json:"rule_violations,omitempty"`
+	// DryRun is set if the branch would be deleted, but wasn't as the request was a dry run.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// BulkDeleteBranches deletes multiple branches of a repository.
+// Every branch is verified against the protection rules individually, a branch that can't be deleted
+// doesn't prevent deletion of the other branches. The outcome is reported for each of the branches.
+// With dryRun set, the outcome is reported without deleting any of the branches.
+func (c *Controller) BulkDeleteBranches(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *BulkDeleteBranchesInput,
+	dryRun bool,
+) ([]BulkDeleteBranchResult, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, isRepoOwner, err := c.fetchRules(ctx, session, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	results := make([]BulkDeleteBranchResult, len(in.Branches))
+	for i, branchName := range in.Branches {
+		results[i].Name = branchName
+
+		if branchName == repo.DefaultBranch {
+			results[i].Error = usererror.ErrDefaultBranchCantBeDeleted.Error()
+			continue
+		}
+
+		var violations []types.RuleViolations
+		violations, err = rules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+			Actor:       &session.Principal,
+			AllowBypass: in.BypassRules,
+			IsRepoOwner: isRepoOwner,
+			Repo:        repo,
+			RefAction:   protection.RefActionDelete,
+			RefType:     protection.RefTypeBranch,
+			RefNames:    []string{branchName},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify protection rules: %w", err)
+		}
+
+		results[i].RuleViolations = violations
+
+		if protection.IsCritical(violations) || in.DryRunRules {
+			continue
+		}
+
+		if dryRun {
+			_, err = c.git.GetBranch(ctx, &git.GetBranchParams{
+				ReadParams: git.CreateReadParams(repo),
+				BranchName: branchName,
+			})
+		} else {
+			err = c.git.DeleteBranch(ctx, &git.DeleteBranchParams{
+				WriteParams: writeParams,
+				BranchName:  branchName,
+			})
+		}
+		if errors.IsNotFound(err) {
+			results[i].Error = "Branch not found."
+			continue
+		}
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Str("branch", branchName).Msg("failed to delete branch")
+			results[i].Error = "Failed to delete branch."
+			continue
+		}
+
+		results[i].Deleted = true
+		results[i].DryRun = dryRun
+	}
+
+	return results, nil
+}
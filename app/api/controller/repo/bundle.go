@@ -0,0 +1,141 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// BackupBundle streams a git bundle containing all references of the repository to the writer.
+func (c *Controller) BackupBundle(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	w io.Writer,
+) error {
+	if !session.Principal.Admin {
+		return usererror.ErrForbidden
+	}
+
+	repo, err := GetRepo(ctx, c.repoStore, repoRef, ReadableRepoStates)
+	if err != nil {
+		return err
+	}
+
+	if repo.IsEmpty {
+		return usererror.BadRequest("Empty repositories can't be backed up.")
+	}
+
+	err = c.git.CreateBundle(ctx, &git.CreateBundleParams{
+		ReadParams: git.CreateReadParams(repo),
+	}, w)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle: %w", err)
+	}
+
+	return nil
+}
+
+// RestoreBundle replaces the git data of the repository with the content of the git bundle provided by the reader.
+// Restoring into a repository that isn't empty is rejected unless force is set.
+// The repository is kept in maintenance while the bundle is restored.
+func (c *Controller) RestoreBundle(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	r io.Reader,
+	force bool,
+) (*RepositoryOutput, error) {
+	if !session.Principal.Admin {
+		return nil, usererror.ErrForbidden
+	}
+
+	repo, err := GetRepo(ctx, c.repoStore, repoRef, ActiveRepoStates)
+	if err != nil {
+		return nil, err
+	}
+
+	if !repo.IsEmpty && !force {
+		return nil, usererror.Conflict("The repository isn't empty, restoring it requires the force flag.")
+	}
+
+	err = c.repoStateSvc.Transition(ctx, &session.Principal, repo, enum.RepoStateMaintenance, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to put repository into maintenance: %w", err)
+	}
+
+	errRestore := c.restoreBundle(ctx, session, repo, r, force)
+
+	// reactivate the repository independent of the outcome of the restore.
+	repo, err = c.repoStore.Find(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository after restore: %w", err)
+	}
+
+	err = c.repoStateSvc.Transition(ctx, &session.Principal, repo, enum.RepoStateActive, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reactivate repository after restore: %w", err)
+	}
+
+	if errRestore != nil {
+		return nil, errRestore
+	}
+
+	return GetRepoOutput(ctx, c.publicAccess, repo)
+}
+
+func (c *Controller) restoreBundle(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	r io.Reader,
+	force bool,
+) error {
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	restoreOut, err := c.git.RestoreBundle(ctx, &git.RestoreBundleParams{
+		WriteParams: writeParams,
+		Force:       force,
+	}, r)
+	if err != nil {
+		return fmt.Errorf("failed to restore repository from bundle: %w", err)
+	}
+
+	_, err = c.repoStore.UpdateOptLock(ctx, repo, func(repo *types.Repository) error {
+		repo.IsEmpty = false
+		// the default branch of the repository is kept if the bundle doesn't have one.
+		if restoreOut.DefaultBranch != "" {
+			repo.DefaultBranch = restoreOut.DefaultBranch
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update repository after restore: %w", err)
+	}
+
+	return nil
+}
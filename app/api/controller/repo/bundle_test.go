@@ -0,0 +1,215 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/services/repostate"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// bundleRepoStore is a store.RepoStore holding a single repository.
+type bundleRepoStore struct {
+	store.RepoStore
+	repo types.Repository
+}
+
+func (s *bundleRepoStore) FindByRef(context.Context, string) (*types.Repository, error) {
+	repo := s.repo.Clone()
+	return &repo, nil
+}
+
+func (s *bundleRepoStore) Find(context.Context, int64) (*types.Repository, error) {
+	repo := s.repo.Clone()
+	return &repo, nil
+}
+
+func (s *bundleRepoStore) UpdateState(
+	_ context.Context,
+	repo *types.Repository,
+	_, to enum.RepoState,
+	_ int64,
+) error {
+	s.repo.State = to
+	repo.State = to
+	return nil
+}
+
+func (s *bundleRepoStore) UpdateOptLock(
+	_ context.Context,
+	_ *types.Repository,
+	mutateFn func(repository *types.Repository) error,
+) (*types.Repository, error) {
+	repo := s.repo.Clone()
+	if err := mutateFn(&repo); err != nil {
+		return nil, err
+	}
+	s.repo = repo
+	return &repo, nil
+}
+
+// bundleGit is a git.Interface that only restores bundles.
+type bundleGit struct {
+	git.Interface
+	restored      bool
+	force         bool
+	defaultBranch string
+}
+
+func (g *bundleGit) CreateBundle(context.Context, *git.CreateBundleParams, io.Writer) error {
+	return nil
+}
+
+func (g *bundleGit) RestoreBundle(
+	_ context.Context,
+	params *git.RestoreBundleParams,
+	_ io.Reader,
+) (*git.RestoreBundleOutput, error) {
+	g.restored = true
+	g.force = params.Force
+	return &git.RestoreBundleOutput{DefaultBranch: g.defaultBranch}, nil
+}
+
+type bundleURLProvider struct {
+	url.Provider
+}
+
+func (bundleURLProvider) GetInternalAPIURL(context.Context) string {
+	return "http://localhost:3000/api"
+}
+
+type bundlePublicAccess struct {
+	publicaccess.Service
+}
+
+func (bundlePublicAccess) Get(context.Context, enum.PublicResourceType, string) (bool, error) {
+	return false, nil
+}
+
+func setupBundleController(repo types.Repository, defaultBranch string) (*Controller, *bundleRepoStore, *bundleGit) {
+	repoStore := &bundleRepoStore{repo: repo}
+	gitSvc := &bundleGit{defaultBranch: defaultBranch}
+
+	return &Controller{
+		urlProvider:  bundleURLProvider{},
+		repoStore:    repoStore,
+		git:          gitSvc,
+		publicAccess: bundlePublicAccess{},
+		repoStateSvc: repostate.NewService(repoStore, audit.New()),
+	}, repoStore, gitSvc
+}
+
+func bundleSession(admin bool) *auth.Session {
+	return &auth.Session{Principal: types.Principal{ID: 1, UID: "admin", Admin: admin}}
+}
+
+func requireUserError(t *testing.T, err error, status int) {
+	t.Helper()
+
+	var uErr *usererror.Error
+	if !errors.As(err, &uErr) || uErr.Status != status {
+		t.Fatalf("expected user error with status %d, got %v", status, err)
+	}
+}
+
+func TestController_BackupBundle(t *testing.T) {
+	ctx := context.Background()
+
+	c, _, _ := setupBundleController(types.Repository{ID: 1, State: enum.RepoStateActive}, "")
+	requireUserError(t, c.BackupBundle(ctx, bundleSession(false), "space/repo", io.Discard),
+		http.StatusForbidden)
+
+	c, _, _ = setupBundleController(types.Repository{ID: 1, State: enum.RepoStateActive, IsEmpty: true}, "")
+	requireUserError(t, c.BackupBundle(ctx, bundleSession(true), "space/repo", io.Discard),
+		http.StatusBadRequest)
+
+	c, _, _ = setupBundleController(types.Repository{ID: 1, State: enum.RepoStateActive}, "")
+	if err := c.BackupBundle(ctx, bundleSession(true), "space/repo", io.Discard); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestController_RestoreBundle(t *testing.T) {
+	tests := []struct {
+		name              string
+		admin             bool
+		isEmpty           bool
+		force             bool
+		bundleBranch      string
+		wantStatus        int
+		wantDefaultBranch string
+	}{
+		{name: "not admin", isEmpty: true, wantStatus: http.StatusForbidden},
+		{name: "not empty without force", admin: true, wantStatus: http.StatusConflict},
+		{name: "not empty with force", admin: true, force: true, bundleBranch: "develop",
+			wantDefaultBranch: "develop"},
+		{name: "empty", admin: true, isEmpty: true, bundleBranch: "develop", wantDefaultBranch: "develop"},
+		{name: "bundle without default branch", admin: true, isEmpty: true, wantDefaultBranch: "main"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, repoStore, gitSvc := setupBundleController(types.Repository{
+				ID:            1,
+				GitUID:        "repo-uid",
+				State:         enum.RepoStateActive,
+				IsEmpty:       test.isEmpty,
+				DefaultBranch: "main",
+			}, test.bundleBranch)
+
+			out, err := c.RestoreBundle(context.Background(), bundleSession(test.admin), "space/repo",
+				strings.NewReader("bundle"), test.force)
+
+			if test.wantStatus != 0 {
+				requireUserError(t, err, test.wantStatus)
+				if gitSvc.restored {
+					t.Errorf("expected repository not to be restored")
+				}
+				if repoStore.repo.State != enum.RepoStateActive {
+					t.Errorf("expected repository to stay active, got state %s", repoStore.repo.State)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if gitSvc.force != test.force {
+				t.Errorf("force: want=%t got=%t", test.force, gitSvc.force)
+			}
+			if out.IsEmpty || out.DefaultBranch != test.wantDefaultBranch {
+				t.Errorf("want non-empty repository with default branch %q, got empty=%t default branch %q",
+					test.wantDefaultBranch, out.IsEmpty, out.DefaultBranch)
+			}
+			if repoStore.repo.State != enum.RepoStateActive {
+				t.Errorf("expected repository to be active again, got state %s", repoStore.repo.State)
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@
 package repo
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"fmt"
@@ -130,6 +131,24 @@ func (c *Controller) CommitFiles(ctx context.Context,
 		}
 	}
 
+	var files []types.CommitFileStatus
+	if in.NewBranch == "" && !repo.IsEmpty {
+		var headSHA sha.SHA
+		actions, files, headSHA, err = c.skipUnchangedFiles(ctx, repo, in.Branch, actions)
+		if err != nil {
+			return types.CommitFilesResponse{}, nil, err
+		}
+
+		// nothing changed, return the existing commit instead of creating an empty one.
+		if len(actions) == 0 && !headSHA.IsEmpty() {
+			return types.CommitFilesResponse{
+				CommitID:       headSHA.String(),
+				RuleViolations: violations,
+				Files:          files,
+			}, nil, nil
+		}
+	}
+
 	// Create internal write params. Note: This will skip the pre-commit protection rules check.
 	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
 	if err != nil {
@@ -156,5 +175,97 @@ func (c *Controller) CommitFiles(ctx context.Context,
 	return types.CommitFilesResponse{
 		CommitID:       commit.CommitID.String(),
 		RuleViolations: violations,
+		Files:          files,
 	}, nil, nil
 }
+
+// skipUnchangedFiles removes all update actions from the list that wouldn't change the content of the file.
+// It returns the remaining actions, whether the content of each file changed and the current commit of the branch.
+func (c *Controller) skipUnchangedFiles(
+	ctx context.Context,
+	repo *types.Repository,
+	branch string,
+	actions []git.CommitFileAction,
+) ([]git.CommitFileAction, []types.CommitFileStatus, sha.SHA, error) {
+	if branch == "" {
+		branch = repo.DefaultBranch
+	}
+	readParams := git.ReadParams{RepoUID: repo.GitUID}
+
+	files := make([]types.CommitFileStatus, len(actions))
+	updates := make([]int, 0, len(actions))
+	blobSHAs := make([]sha.SHA, 0, len(actions))
+	for i, action := range actions {
+		files[i] = types.CommitFileStatus{Path: action.Path, Changed: true}
+		if action.Action != git.UpdateAction {
+			continue
+		}
+
+		hashOut, err := c.git.HashBlob(ctx, &git.HashBlobParams{
+			ReadParams: readParams,
+			Content:    bytes.NewReader(action.Payload),
+		})
+		if err != nil {
+			return nil, nil, sha.None, fmt.Errorf("failed to hash content of file %q: %w", action.Path, err)
+		}
+
+		updates = append(updates, i)
+		blobSHAs = append(blobSHAs, hashOut.SHA)
+	}
+
+	if len(updates) == 0 {
+		return actions, files, sha.None, nil
+	}
+
+	// content that doesn't exist in the repository yet can't be the current content of a file.
+	existsOut, err := c.git.ExistsBlobs(ctx, &git.ExistsBlobsParams{
+		ReadParams: readParams,
+		SHAs:       blobSHAs,
+	})
+	if err != nil {
+		return nil, nil, sha.None, fmt.Errorf("failed to check existence of blobs: %w", err)
+	}
+
+	branchOut, err := c.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: readParams,
+		BranchName: branch,
+	})
+	if err != nil {
+		return nil, nil, sha.None, fmt.Errorf("failed to get branch %q: %w", branch, err)
+	}
+	headSHA := branchOut.Branch.SHA
+
+	var nodeOut *git.GetTreeNodeOutput
+	for i, idx := range updates {
+		if !existsOut.Exists[i] {
+			continue
+		}
+
+		nodeOut, err = c.git.GetTreeNode(ctx, &git.GetTreeNodeParams{
+			ReadParams: readParams,
+			GitREF:     headSHA.String(),
+			Path:       actions[idx].Path,
+		})
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, sha.None, fmt.Errorf("failed to get file %q: %w", actions[idx].Path, err)
+		}
+
+		// a mismatching optimistic lock has to be reported by the commit, so the action is kept.
+		lockSHA := actions[idx].SHA
+		if nodeOut.Node.SHA == blobSHAs[i].String() && (lockSHA.IsEmpty() || lockSHA.String() == nodeOut.Node.SHA) {
+			files[idx].Changed = false
+		}
+	}
+
+	changed := make([]git.CommitFileAction, 0, len(actions))
+	for i := range actions {
+		if files[i].Changed {
+			changed = append(changed, actions[i])
+		}
+	}
+
+	return changed, files, headSHA, nil
+}
@@ -0,0 +1,140 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// CommitNote is the git note attached to a commit.
+type CommitNote struct {
+	CommitSHA sha.SHA `json:"commit_sha"`
+	NotesRef  string  `json:"notes_ref"`
+	Note      string  `json:"note"`
+}
+
+type SetCommitNoteInput struct {
+	Note string `json:"note"`
+}
+
+// GetCommitNote returns the git note attached to the commit.
+func (c *Controller) GetCommitNote(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	commitRef string,
+	notesRef string,
+) (*CommitNote, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	commitSHA, err := c.resolveCommitSHA(ctx, repo, commitRef)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := c.git.GetNote(ctx, &git.GetNoteParams{
+		ReadParams: git.CreateReadParams(repo),
+		NotesRef:   notesRef,
+		CommitSHA:  commitSHA,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get note: %w", err)
+	}
+
+	return &CommitNote{
+		CommitSHA: commitSHA,
+		NotesRef:  notesRefOrDefault(notesRef),
+		Note:      out.Note,
+	}, nil
+}
+
+// SetCommitNote creates or overwrites the git note attached to the commit.
+// The note is committed to the notes reference on behalf of the caller.
+func (c *Controller) SetCommitNote(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	commitRef string,
+	notesRef string,
+	in *SetCommitNoteInput,
+) (*CommitNote, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, err
+	}
+
+	commitSHA, err := c.resolveCommitSHA(ctx, repo, commitRef)
+	if err != nil {
+		return nil, err
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	_, err = c.git.SetNote(ctx, &git.SetNoteParams{
+		WriteParams: writeParams,
+		NotesRef:    notesRef,
+		CommitSHA:   commitSHA,
+		Note:        in.Note,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set note: %w", err)
+	}
+
+	return &CommitNote{
+		CommitSHA: commitSHA,
+		NotesRef:  notesRefOrDefault(notesRef),
+		Note:      in.Note,
+	}, nil
+}
+
+// resolveCommitSHA returns the full sha of the commit the provided reference (e.g. an abbreviated sha) points to.
+func (c *Controller) resolveCommitSHA(
+	ctx context.Context,
+	repo *types.Repository,
+	commitRef string,
+) (sha.SHA, error) {
+	out, err := c.git.GetCommit(ctx, &git.GetCommitParams{
+		ReadParams: git.CreateReadParams(repo),
+		Revision:   commitRef,
+	})
+	if err != nil {
+		return sha.None, fmt.Errorf("failed to get commit: %w", err)
+	}
+
+	return out.Commit.SHA, nil
+}
+
+func notesRefOrDefault(notesRef string) string {
+	if notesRef == "" {
+		return api.DefaultNotesRef
+	}
+
+	return notesRef
+}
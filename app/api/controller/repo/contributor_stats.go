@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ContributorStats returns the per author commit, addition and deletion counts bucketed by week
+// for the history of the provided git reference, in the provided time range (unix seconds, 0 means unbounded).
+// The stats are computed in the background the first time they are requested for the commit the reference
+// points to - in which case nil is returned and the caller is expected to poll.
+func (c *Controller) ContributorStats(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	gitRef string,
+	since int64,
+	until int64,
+) (*types.RepoContributorStats, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	// set gitRef to default branch in case an empty reference was provided
+	if gitRef == "" {
+		gitRef = repo.DefaultBranch
+	}
+
+	commit, err := c.git.GetCommit(ctx, &git.GetCommitParams{
+		ReadParams: git.CreateReadParams(repo),
+		Revision:   gitRef,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit of git ref: %w", err)
+	}
+
+	stats, err := c.contributorStats.Get(ctx, repo, commit.Commit.SHA, since, until)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contributor stats: %w", err)
+	}
+
+	return stats, nil
+}
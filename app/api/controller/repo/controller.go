@@ -27,14 +27,23 @@ import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
 	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/accesslog"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/contributorstats"
+	"github.com/harness/gitness/app/services/gitlimit"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/label"
 	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/maintenance"
+	"github.com/harness/gitness/app/services/operation"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/app/services/recentvisit"
+	"github.com/harness/gitness/app/services/repodocs"
+	"github.com/harness/gitness/app/services/repostate"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -51,8 +60,22 @@ var errPublicRepoCreationDisabled = usererror.BadRequestf("Public repository cre
 
 type RepositoryOutput struct {
 	types.Repository
-	IsPublic  bool `json:"is_public" yaml:"is_public"`
-	Importing bool `json:"importing" yaml:"-"`
+	IsPublic  bool   `json:"is_public" yaml:"is_public"`
+	Importing bool   `json:"importing" yaml:"-"`
+	StateName string `json:"state_name" yaml:"-"`
+
+	// Pinned is only set when the pinned repositories of a space are listed.
+	Pinned bool `json:"pinned,omitempty" yaml:"-"`
+
+	// Contact is the primary contact of the repository, only set when a single repository is fetched.
+	Contact *types.PrincipalInfo `json:"contact,omitempty" yaml:"-"`
+
+	// ReadmePath and License are only set when a single repository is fetched.
+	ReadmePath string             `json:"readme_path,omitempty" yaml:"-"`
+	License    *types.RepoLicense `json:"license,omitempty" yaml:"-"`
+
+	// OperationID is only set when the repository is imported. It's the ID of the import operation.
+	OperationID string `json:"operation_id,omitempty" yaml:"-"`
 }
 
 // TODO [CODE-1363]: remove after identifier migration.
@@ -88,6 +111,7 @@ type Controller struct {
 	eventReporter      *repoevents.Reporter
 	indexer            keywordsearch.Indexer
 	resourceLimiter    limiter.ResourceLimiter
+	quotaWarner        *limiter.QuotaWarner
 	locker             *locker.Locker
 	auditService       audit.Service
 	mtxManager         lock.MutexManager
@@ -96,6 +120,22 @@ type Controller struct {
 	publicAccess       publicaccess.Service
 	labelSvc           *label.Service
 	instrumentation    instrument.Service
+	repoActivityStore  store.RepoActivityStore
+	deployKeyStore     store.DeployKeyStore
+	publicKeyService   publickey.Service
+	repoStateSvc       *repostate.Service
+	pullReqStore       store.PullReqStore
+	repoStatsStore     store.RepoStatsStore
+	contributorStats   *contributorstats.Service
+	recentVisits       *recentvisit.Service
+	repoStarStore      store.RepoStarStore
+	repoDocs           *repodocs.Service
+	repoTopicStore     store.RepoTopicStore
+	operationSvc       *operation.Service
+	maintenanceSvc     *maintenance.Service
+	gitLimit           *gitlimit.Service
+	repoAccessLogStore store.RepoAccessLogStore
+	accessLog          *accesslog.Service
 }
 
 func NewController(
@@ -117,6 +157,7 @@ func NewController(
 	eventReporter *repoevents.Reporter,
 	indexer keywordsearch.Indexer,
 	limiter limiter.ResourceLimiter,
+	quotaWarner *limiter.QuotaWarner,
 	locker *locker.Locker,
 	auditService audit.Service,
 	mtxManager lock.MutexManager,
@@ -125,6 +166,22 @@ func NewController(
 	publicAccess publicaccess.Service,
 	labelSvc *label.Service,
 	instrumentation instrument.Service,
+	repoActivityStore store.RepoActivityStore,
+	deployKeyStore store.DeployKeyStore,
+	publicKeyService publickey.Service,
+	repoStateSvc *repostate.Service,
+	pullReqStore store.PullReqStore,
+	repoStatsStore store.RepoStatsStore,
+	contributorStats *contributorstats.Service,
+	recentVisits *recentvisit.Service,
+	repoStarStore store.RepoStarStore,
+	repoDocs *repodocs.Service,
+	repoTopicStore store.RepoTopicStore,
+	operationSvc *operation.Service,
+	maintenanceSvc *maintenance.Service,
+	gitLimit *gitlimit.Service,
+	repoAccessLogStore store.RepoAccessLogStore,
+	accessLog *accesslog.Service,
 ) *Controller {
 	return &Controller{
 		defaultBranch:      config.Git.DefaultBranch,
@@ -145,6 +202,7 @@ func NewController(
 		eventReporter:      eventReporter,
 		indexer:            indexer,
 		resourceLimiter:    limiter,
+		quotaWarner:        quotaWarner,
 		locker:             locker,
 		auditService:       auditService,
 		mtxManager:         mtxManager,
@@ -153,6 +211,22 @@ func NewController(
 		publicAccess:       publicAccess,
 		labelSvc:           labelSvc,
 		instrumentation:    instrumentation,
+		repoActivityStore:  repoActivityStore,
+		deployKeyStore:     deployKeyStore,
+		publicKeyService:   publicKeyService,
+		repoStateSvc:       repoStateSvc,
+		pullReqStore:       pullReqStore,
+		repoStatsStore:     repoStatsStore,
+		contributorStats:   contributorStats,
+		recentVisits:       recentVisits,
+		repoStarStore:      repoStarStore,
+		repoDocs:           repoDocs,
+		repoTopicStore:     repoTopicStore,
+		operationSvc:       operationSvc,
+		maintenanceSvc:     maintenanceSvc,
+		gitLimit:           gitLimit,
+		repoAccessLogStore: repoAccessLogStore,
+		accessLog:          accessLog,
 	}
 }
 
@@ -171,12 +245,18 @@ func (c *Controller) getRepo(
 
 // getRepoCheckAccess fetches an active repo (not one that is currently being imported)
 // and checks if the current user has permission to access it.
+// Repos that are temporarily read-only are returned as well if only view permission is required.
 func (c *Controller) getRepoCheckAccess(
 	ctx context.Context,
 	session *auth.Session,
 	repoRef string,
 	reqPermission enum.Permission,
 ) (*types.Repository, error) {
+	allowedStates := ActiveRepoStates
+	if reqPermission == enum.PermissionRepoView {
+		allowedStates = ReadableRepoStates
+	}
+
 	return GetRepoCheckAccess(
 		ctx,
 		c.repoStore,
@@ -184,7 +264,7 @@ func (c *Controller) getRepoCheckAccess(
 		session,
 		repoRef,
 		reqPermission,
-		ActiveRepoStates,
+		allowedStates,
 	)
 }
 
@@ -203,7 +283,7 @@ func (c *Controller) getRepoCheckAccessForGit(
 		session,
 		repoRef,
 		reqPermission,
-		nil, // Any state allowed - we'll block in the pre-receive hook.
+		GitRepoStates, // pushes are additionally blocked in the pre-receive hook.
 	)
 }
 
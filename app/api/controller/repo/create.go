@@ -23,10 +23,12 @@ import (
 	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/controller/limiter"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/bootstrap"
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/githook"
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/services/instrument"
@@ -90,7 +92,7 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 		return nil, err
 	}
 
-	gitResp, isEmpty, err := c.createGitRepository(ctx, session, in)
+	gitResp, err := c.createGitRepository(ctx, session, in)
 	if err != nil {
 		return nil, fmt.Errorf("error creating repository on git: %w", err)
 	}
@@ -119,7 +121,8 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 			Updated:       now,
 			ForkID:        in.ForkID,
 			DefaultBranch: in.DefaultBranch,
-			IsEmpty:       isEmpty,
+			IsEmpty:       true,
+			ShowReadme:    true,
 		}
 
 		return c.repoStore.Create(ctx, repo)
@@ -146,6 +149,15 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 		return nil, fmt.Errorf("failed to set repo public access (succesfull cleanup): %w", err)
 	}
 
+	repo, err = c.bootstrapRepository(ctx, session, repo, in)
+	if err != nil {
+		if dErr := c.PurgeNoAuth(ctx, session, repo); dErr != nil {
+			return nil, fmt.Errorf("failed to bootstrap repo (and repo purge: %w): %w", dErr, err)
+		}
+
+		return nil, fmt.Errorf("failed to bootstrap repo (succesfull cleanup): %w", err)
+	}
+
 	// backfil GitURL
 	repo.GitURL = c.urlProvider.GenerateGITCloneURL(ctx, repo.Path)
 	repo.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(ctx, repo.Path)
@@ -178,6 +190,11 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 	if err != nil {
 		log.Ctx(ctx).Warn().Msgf("failed to insert instrumentation record for create repository operation: %s", err)
 	}
+	c.eventReporter.Created(ctx, &repoevents.CreatedPayload{
+		RepoID:      repo.ID,
+		PrincipalID: session.Principal.ID,
+	})
+
 	// index repository if files are created
 	if !repo.IsEmpty {
 		err = c.indexer.Index(ctx, repo)
@@ -186,6 +203,8 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 		}
 	}
 
+	c.quotaWarner.Record(ctx, parentSpace.ID)
+
 	return repoOutput, nil
 }
 
@@ -238,75 +257,128 @@ func (c *Controller) sanitizeCreateInput(in *CreateInput) error {
 		in.DefaultBranch = c.defaultBranch
 	}
 
+	if in.License != "" && in.License != "none" {
+		in.License = strings.ToLower(in.License)
+		if !resources.LicenseExists(in.License) {
+			return usererror.BadRequestf("Unknown license %q.", in.License)
+		}
+	}
+
+	if in.GitIgnore != "" {
+		gitIgnore, ok := resources.FindGitIgnore(in.GitIgnore)
+		if !ok {
+			return usererror.BadRequestf("Unknown gitignore template %q.", in.GitIgnore)
+		}
+		in.GitIgnore = gitIgnore
+	}
+
 	return nil
 }
 
 func (c *Controller) createGitRepository(ctx context.Context, session *auth.Session,
-	in *CreateInput) (*git.CreateRepositoryOutput, bool, error) {
-	var (
-		err     error
-		content []byte
+	in *CreateInput) (*git.CreateRepositoryOutput, error) {
+	// generate envars (add everything githook CLI needs for execution)
+	envVars, err := githook.GenerateEnvironmentVariables(
+		ctx,
+		c.urlProvider.GetInternalAPIURL(ctx),
+		0,
+		session.Principal.ID,
+		true,
+		true,
 	)
-	files := make([]git.File, 0, 3) // readme, gitignore, licence
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate git hook environment variables: %w", err)
+	}
+
+	actor := identityFromPrincipal(session.Principal)
+	resp, err := c.git.CreateRepository(ctx, &git.CreateRepositoryParams{
+		Actor:         *actor,
+		EnvVars:       envVars,
+		DefaultBranch: in.DefaultBranch,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create repo on: %w", err)
+	}
+
+	return resp, nil
+}
+
+// bootstrapRepository creates the initial commit with the readme, license and gitignore files
+// selected in the input on the default branch of the repository.
+// The commit goes through the git hooks, which mark the repository as non-empty.
+func (c *Controller) bootstrapRepository(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	in *CreateInput,
+) (*types.Repository, error) {
+	actions := make([]git.CommitFileAction, 0, 3) // readme, gitignore, licence
 	if in.Readme {
-		content = createReadme(in.Identifier, in.Description)
-		files = append(files, git.File{
+		actions = append(actions, git.CommitFileAction{
+			Action:  git.CreateAction,
 			Path:    "README.md",
-			Content: content,
+			Payload: createReadme(in.Identifier, in.Description),
 		})
 	}
 	if in.License != "" && in.License != "none" {
-		content, err = resources.ReadLicense(in.License)
+		content, err := resources.RenderLicense(in.License, resources.LicenseData{
+			Year:    time.Now().Year(),
+			Owner:   session.Principal.DisplayName,
+			Program: in.Identifier,
+		})
 		if err != nil {
-			return nil, false, fmt.Errorf("failed to read license '%s': %w", in.License, err)
+			return repo, fmt.Errorf("failed to read license '%s': %w", in.License, err)
 		}
-		files = append(files, git.File{
+		actions = append(actions, git.CommitFileAction{
+			Action:  git.CreateAction,
 			Path:    "LICENSE",
-			Content: content,
+			Payload: content,
 		})
 	}
 	if in.GitIgnore != "" {
-		content, err = resources.ReadGitIgnore(in.GitIgnore)
+		content, err := resources.ReadGitIgnore(in.GitIgnore)
 		if err != nil {
-			return nil, false, fmt.Errorf("failed to read git ignore '%s': %w", in.GitIgnore, err)
+			return repo, fmt.Errorf("failed to read git ignore '%s': %w", in.GitIgnore, err)
 		}
-		files = append(files, git.File{
+		actions = append(actions, git.CommitFileAction{
+			Action:  git.CreateAction,
 			Path:    ".gitignore",
-			Content: content,
+			Payload: content,
 		})
 	}
 
-	// generate envars (add everything githook CLI needs for execution)
-	envVars, err := githook.GenerateEnvironmentVariables(
-		ctx,
-		c.urlProvider.GetInternalAPIURL(ctx),
-		0,
-		session.Principal.ID,
-		true,
-		true,
-	)
+	if len(actions) == 0 {
+		return repo, nil
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
 	if err != nil {
-		return nil, false, fmt.Errorf("failed to generate git hook environment variables: %w", err)
+		return repo, fmt.Errorf("failed to create RPC write params: %w", err)
 	}
 
-	actor := identityFromPrincipal(session.Principal)
-	committer := identityFromPrincipal(bootstrap.NewSystemServiceSession().Principal)
 	now := time.Now()
-	resp, err := c.git.CreateRepository(ctx, &git.CreateRepositoryParams{
-		Actor:         *actor,
-		EnvVars:       envVars,
-		DefaultBranch: in.DefaultBranch,
-		Files:         files,
-		Author:        actor,
-		AuthorDate:    &now,
-		Committer:     committer,
+	_, err = c.git.CommitFiles(ctx, &git.CommitFilesParams{
+		WriteParams:   writeParams,
+		Title:         "initial commit",
+		Branch:        repo.DefaultBranch,
+		NewBranch:     repo.DefaultBranch,
+		Actions:       actions,
+		Committer:     identityFromPrincipal(bootstrap.NewSystemServiceSession().Principal),
 		CommitterDate: &now,
+		Author:        identityFromPrincipal(session.Principal),
+		AuthorDate:    &now,
 	})
 	if err != nil {
-		return nil, false, fmt.Errorf("failed to create repo on: %w", err)
+		return repo, fmt.Errorf("failed to commit initial files: %w", err)
+	}
+
+	// the post-receive hook marked the repository as non-empty.
+	bootstrapped, err := c.repoStore.Find(ctx, repo.ID)
+	if err != nil {
+		return repo, fmt.Errorf("failed to find repo after the initial commit: %w", err)
 	}
 
-	return resp, len(files) == 0, nil
+	return bootstrapped, nil
 }
 
 func createReadme(name, description string) []byte {
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"time"
 
+	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/services/instrument"
@@ -65,6 +66,16 @@ func (c *Controller) CreateCommitTag(ctx context.Context,
 		return nil, nil, err
 	}
 
+	readParams := git.CreateReadParams(repo)
+
+	targetCommit, err := c.git.GetCommit(ctx, &git.GetCommitParams{
+		ReadParams: readParams,
+		Revision:   in.Target,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get tag target commit: %w", err)
+	}
+
 	violations, err := rules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
 		Actor:       &session.Principal,
 		AllowBypass: in.BypassRules,
@@ -73,6 +84,14 @@ func (c *Controller) CreateCommitTag(ctx context.Context,
 		RefAction:   protection.RefActionCreate,
 		RefType:     protection.RefTypeTag,
 		RefNames:    []string{in.Name},
+		TagTargets: map[string]protection.TagTarget{
+			in.Name: {
+				Annotated: in.Message != "",
+				CommitSHA: targetCommit.Commit.SHA.String(),
+			},
+		},
+		HasPermission:         apiauth.RepoPermissionChecker(c.authorizer, session, repo),
+		ReachableFromBranches: controller.ReachableFromBranches(c.git, readParams),
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
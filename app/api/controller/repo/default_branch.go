@@ -50,6 +50,46 @@ func (c *Controller) UpdateDefaultBranch(
 	}
 
 	repoClone := repo.Clone()
+
+	repo, err = c.updateDefaultBranch(ctx, session, repo, in.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	repoOutput, err := GetRepoOutput(ctx, c.publicAccess, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo output: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeRepositorySettings, repo.Identifier),
+		audit.ActionUpdated,
+		paths.Parent(repo.Path),
+		audit.WithOldObject(audit.RepositoryObject{
+			Repository: repoClone,
+			IsPublic:   repoOutput.IsPublic,
+		}),
+		audit.WithNewObject(audit.RepositoryObject{
+			Repository: *repo,
+			IsPublic:   repoOutput.IsPublic,
+		}),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update default branch operation: %s", err)
+	}
+
+	return repoOutput, nil
+}
+
+// updateDefaultBranch updates the default branch of the repository in git and in the database.
+// In case updating the database fails, the default branch in git is reverted.
+func (c *Controller) updateDefaultBranch(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	name string,
+) (*types.Repository, error) {
 	// the max time we give an update default branch to succeed
 	const timeout = 2 * time.Minute
 
@@ -58,7 +98,7 @@ func (c *Controller) UpdateDefaultBranch(
 	unlock, err := c.locker.LockDefaultBranch(
 		ctx,
 		repo.ID,
-		in.Name,                // branch name only used for logging (lock is on repo)
+		name,                   // branch name only used for logging (lock is on repo)
 		timeout+30*time.Second, // add 30s to the lock to give enough time for updating default branch
 	)
 	if err != nil {
@@ -81,7 +121,7 @@ func (c *Controller) UpdateDefaultBranch(
 
 	err = c.git.UpdateDefaultBranch(ctx, &git.UpdateDefaultBranchParams{
 		WriteParams: writeParams,
-		BranchName:  in.Name,
+		BranchName:  name,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to update the repo default branch: %w", err)
@@ -89,34 +129,20 @@ func (c *Controller) UpdateDefaultBranch(
 
 	oldName := repo.DefaultBranch
 	repo, err = c.repoStore.UpdateOptLock(ctx, repo, func(r *types.Repository) error {
-		r.DefaultBranch = in.Name
+		r.DefaultBranch = name
 		return nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update the repo default branch on db:%w", err)
-	}
+		// revert the default branch in git to keep git and the database consistent.
+		errRevert := c.git.UpdateDefaultBranch(ctx, &git.UpdateDefaultBranchParams{
+			WriteParams: writeParams,
+			BranchName:  oldName,
+		})
+		if errRevert != nil {
+			log.Ctx(ctx).Err(errRevert).Msgf("failed to revert the repo default branch to %q", oldName)
+		}
 
-	repoOutput, err := GetRepoOutput(ctx, c.publicAccess, repo)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get repo output: %w", err)
-	}
-
-	err = c.auditService.Log(ctx,
-		session.Principal,
-		audit.NewResource(audit.ResourceTypeRepositorySettings, repo.Identifier),
-		audit.ActionUpdated,
-		paths.Parent(repo.Path),
-		audit.WithOldObject(audit.RepositoryObject{
-			Repository: repoClone,
-			IsPublic:   repoOutput.IsPublic,
-		}),
-		audit.WithNewObject(audit.RepositoryObject{
-			Repository: *repo,
-			IsPublic:   repoOutput.IsPublic,
-		}),
-	)
-	if err != nil {
-		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update default branch operation: %s", err)
+		return nil, fmt.Errorf("failed to update the repo default branch on db:%w", err)
 	}
 
 	c.eventReporter.DefaultBranchUpdated(ctx, &repoevents.DefaultBranchUpdatedPayload{
@@ -126,5 +152,5 @@ func (c *Controller) UpdateDefaultBranch(
 		NewName:     repo.DefaultBranch,
 	})
 
-	return repoOutput, nil
+	return repo, nil
 }
@@ -0,0 +1,131 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/jwt"
+	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CreateDeployKeyInput struct {
+	Identifier string `json:"identifier"`
+	Title      string `json:"title"`
+	Content    string `json:"content"`
+	ReadOnly   bool   `json:"read_only"`
+}
+
+// CreateDeployKeyOutput is the created deploy key together with its access token.
+type CreateDeployKeyOutput struct {
+	types.DeployKey
+
+	// AccessToken is used as password for git operations over http with the deploy key.
+	// It's only returned on creation and stays valid until the deploy key is deleted.
+	AccessToken string `json:"access_token"`
+}
+
+func (in *CreateDeployKeyInput) sanitize() error {
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+
+	in.Title = strings.TrimSpace(in.Title)
+	if err := check.DisplayName(in.Title); err != nil {
+		return err
+	}
+
+	in.Content = strings.TrimSpace(in.Content)
+	if in.Content == "" {
+		return errors.InvalidArgument("public key not provided")
+	}
+
+	return nil
+}
+
+// CreateDeployKey adds a new deploy key to the repository.
+func (c *Controller) CreateDeployKey(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *CreateDeployKeyInput,
+) (*CreateDeployKeyOutput, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	key, comment, err := publickey.ParseString(in.Content)
+	if err != nil {
+		return nil, errors.InvalidArgument("could not parse public key")
+	}
+
+	k := &types.DeployKey{
+		RepoID:      repo.ID,
+		CreatedBy:   session.Principal.ID,
+		Created:     time.Now().UnixMilli(),
+		Verified:    nil, // the key is created as unverified
+		LastUsed:    nil,
+		Identifier:  in.Identifier,
+		Title:       in.Title,
+		ReadOnly:    in.ReadOnly,
+		Fingerprint: key.Fingerprint(),
+		Content:     in.Content,
+		Comment:     comment,
+		Type:        key.Type(),
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		err := c.publicKeyService.CheckKeyNotInUse(ctx, key)
+		if err != nil {
+			return err
+		}
+
+		err = c.deployKeyStore.Create(ctx, k)
+		if err != nil {
+			return fmt.Errorf("failed to insert deploy key: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the access token acts as the deploy key service, the same as ssh sessions of the deploy key.
+	principal := bootstrap.NewDeployKeyServiceSession(k).Principal
+	accessToken, err := jwt.GenerateForDeployKey(principal.ID, k, principal.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token for deploy key: %w", err)
+	}
+
+	return &CreateDeployKeyOutput{
+		DeployKey:   *k,
+		AccessToken: accessToken,
+	}, nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListDeployKeys lists the deploy keys of the repository.
+func (c *Controller) ListDeployKeys(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	filter *types.DeployKeyFilter,
+) ([]types.DeployKey, int, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var (
+		list  []types.DeployKey
+		count int
+	)
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		list, err = c.deployKeyStore.List(ctx, repo.ID, filter)
+		if err != nil {
+			return fmt.Errorf("failed to list deploy keys for repo: %w", err)
+		}
+
+		if filter.Page == 1 && len(list) < filter.Size {
+			count = len(list)
+			return nil
+		}
+
+		count, err = c.deployKeyStore.Count(ctx, repo.ID, filter)
+		if err != nil {
+			return fmt.Errorf("failed to count deploy keys for repo: %w", err)
+		}
+
+		return nil
+	}, dbtx.TxDefaultReadOnly)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return list, count, nil
+}
@@ -16,10 +16,13 @@ package repo
 
 import (
 	"context"
+	"fmt"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 // Find finds a repo.
@@ -34,9 +37,57 @@ func (c *Controller) Find(ctx context.Context, session *auth.Session, repoRef st
 		return nil, err
 	}
 
+	c.recentVisits.Record(session, enum.RecentVisitTypeRepo, repo.ID)
+
+	repo.Topics, err = c.repoTopicStore.List(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repo topics: %w", err)
+	}
+
 	// backfill clone url
 	repo.GitURL = c.urlProvider.GenerateGITCloneURL(ctx, repo.Path)
 	repo.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(ctx, repo.Path)
 
-	return GetRepoOutput(ctx, c.publicAccess, repo)
+	repoOut, err := GetRepoOutput(ctx, c.publicAccess, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	c.backfillDocs(ctx, repoOut)
+	c.backfillContact(ctx, repoOut)
+
+	return repoOut, nil
+}
+
+// backfillContact sets the principal info of the primary contact of the repository.
+// Failures are only logged, as they shouldn't prevent fetching the repository.
+func (c *Controller) backfillContact(ctx context.Context, repoOut *RepositoryOutput) {
+	if repoOut.ContactID == nil {
+		return
+	}
+
+	contact, err := c.principalInfoCache.Get(ctx, *repoOut.ContactID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to get repository contact")
+		return
+	}
+
+	repoOut.Contact = contact
+}
+
+// backfillDocs sets the README path and the license of the repository.
+// Failures are only logged, as they shouldn't prevent fetching the repository.
+func (c *Controller) backfillDocs(ctx context.Context, repoOut *RepositoryOutput) {
+	if repoOut.Importing {
+		return
+	}
+
+	docs, err := c.repoDocs.Get(ctx, &repoOut.Repository)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to get repository readme and license")
+		return
+	}
+
+	repoOut.ReadmePath = docs.ReadmePath
+	repoOut.License = docs.License
 }
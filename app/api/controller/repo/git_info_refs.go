@@ -20,6 +20,7 @@ import (
 	"io"
 
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types/enum"
 )
@@ -33,20 +34,49 @@ func (c *Controller) GitInfoRefs(
 	gitProtocol string,
 	w io.Writer,
 ) error {
+	// reject pushes with read-only deploy keys already during ref discovery.
+	if service == enum.GitServiceTypeReceivePack && isReadOnlyDeployKey(session) {
+		return errDeployKeyReadOnly
+	}
+
 	repo, err := c.getRepoCheckAccessForGit(ctx, session, repoRef, enum.PermissionRepoView)
 	if err != nil {
 		return fmt.Errorf("failed to verify repo access: %w", err)
 	}
 
+	// fail pushes while in maintenance mode already during ref discovery.
+	if service == enum.GitServiceTypeReceivePack {
+		if err = c.maintenanceSvc.CheckRepo(repo); err != nil {
+			return err
+		}
+	}
+
+	hiddenRefs, err := c.getHiddenRefs(ctx, repo.ID)
+	if err != nil {
+		return err
+	}
+
 	if err = c.git.GetInfoRefs(ctx, w, &git.InfoRefsParams{
 		ReadParams: git.CreateReadParams(repo),
 		// TODO: git shouldn't take a random string here, but instead have accepted enum values.
 		Service:     string(service),
 		Options:     nil,
 		GitProtocol: gitProtocol,
+		HiddenRefs:  hiddenRefs,
 	}); err != nil {
 		return fmt.Errorf("failed GetInfoRefs on git: %w", err)
 	}
 
 	return nil
 }
+
+// getHiddenRefs returns the reference prefixes hidden from git clients for the repository.
+// The instance wide hidden references are added by git.
+func (c *Controller) getHiddenRefs(ctx context.Context, repoID int64) ([]string, error) {
+	hiddenRefs, err := settings.RepoGet(ctx, c.settings, repoID, settings.KeyHiddenRefs, settings.DefaultHiddenRefs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hidden references of the repo: %w", err)
+	}
+
+	return hiddenRefs, nil
+}
@@ -17,14 +17,25 @@ package repo
 import (
 	"context"
 	"fmt"
+	"io"
+	"time"
 
 	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/gitlimit"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
+var errDeployKeyReadOnly = usererror.Forbidden("The deploy key is read-only and can't be used to push.")
+
 // GitServicePack executes the service pack part of git's smart http protocol (receive-/upload-pack).
 func (c *Controller) GitServicePack(
 	ctx context.Context,
@@ -40,11 +51,53 @@ func (c *Controller) GitServicePack(
 		permission = enum.PermissionRepoPush
 	}
 
+	// reject pushes with read-only deploy keys before any pack data is consumed.
+	if isWriteOperation && isReadOnlyDeployKey(session) {
+		return errDeployKeyReadOnly
+	}
+
 	repo, err := c.getRepoCheckAccessForGit(ctx, session, repoRef, permission)
 	if err != nil {
 		return fmt.Errorf("failed to verify repo access: %w", err)
 	}
 
+	// reject pushes while in maintenance mode before any pack data is consumed, fetches continue to work.
+	if isWriteOperation {
+		if err = c.maintenanceSvc.CheckRepo(repo); err != nil {
+			return err
+		}
+	}
+
+	// limit the concurrent git operations per principal before any expensive work is done.
+	release, err := c.gitLimit.Acquire(ctx, session, audit.GetRealIP(ctx))
+	if errors.Is(err, gitlimit.ErrTooManyOperations) {
+		// report the rejection via git's error channel, as git clients don't show error responses to users.
+		if errWrite := api.WriteErrorPacket(options.Stdout, err.Error()); errWrite != nil {
+			log.Ctx(ctx).Warn().Err(errWrite).Msg("failed to write git operation limit error to client")
+		}
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to acquire git operation: %w", err)
+	}
+	defer release()
+
+	// count the transferred bytes for the access log.
+	stdin := &countingReader{r: options.Stdin}
+	stdout := &countingWriter{w: options.Stdout}
+	options.Stdin = stdin
+	options.Stdout = stdout
+
+	started := time.Now()
+	defer func() {
+		c.recordAccess(ctx, session, repo.ID, options.Service, stdout.n, stdin.n, started, err == nil)
+	}()
+
+	options.HiddenRefs, err = c.getHiddenRefs(ctx, repo.ID)
+	if err != nil {
+		return err
+	}
+
 	params := &git.ServicePackParams{
 		// TODO: git shouldn't take a random string here, but instead have accepted enum values.
 		ServicePackOptions: options,
@@ -69,3 +122,64 @@ func (c *Controller) GitServicePack(
 
 	return nil
 }
+
+// isReadOnlyDeployKey returns true if the session was authenticated with a read-only deploy key.
+func isReadOnlyDeployKey(session *auth.Session) bool {
+	deployKeyMetadata, ok := session.Metadata.(*auth.DeployKeyMetadata)
+	return ok && deployKeyMetadata.ReadOnly
+}
+
+// recordAccess adds the completed git operation to the access log of the repository.
+// Anonymous access to public repositories is recorded without a principal.
+func (c *Controller) recordAccess(
+	ctx context.Context,
+	session *auth.Session,
+	repoID int64,
+	service enum.GitServiceType,
+	bytesSent int64,
+	bytesReceived int64,
+	started time.Time,
+	success bool,
+) {
+	var principalID *int64
+	if !auth.IsAnonymousSession(session) {
+		principalID = &session.Principal.ID
+	}
+
+	now := time.Now()
+	c.accessLog.Record(ctx, &types.RepoAccessLog{
+		RepoID:        repoID,
+		PrincipalID:   principalID,
+		Operation:     service,
+		ClientIP:      audit.GetRealIP(ctx),
+		BytesSent:     bytesSent,
+		BytesReceived: bytesReceived,
+		Duration:      now.Sub(started).Milliseconds(),
+		Success:       success,
+		Created:       now.UnixMilli(),
+	})
+}
+
+// countingReader counts the bytes read from the underlying reader.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+// countingWriter counts the bytes written to the underlying writer.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.n += int64(n)
+	return n, err
+}
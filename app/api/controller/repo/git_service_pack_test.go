@@ -0,0 +1,61 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/types/enum"
+)
+
+// failingReader fails the test if any pack data is consumed.
+type failingReader struct {
+	t *testing.T
+}
+
+func (r failingReader) Read([]byte) (int, error) {
+	r.t.Errorf("expected no pack data to be read")
+	return 0, io.EOF
+}
+
+func TestGitServicePack_ReadOnlyDeployKey(t *testing.T) {
+	session := &auth.Session{
+		Metadata: &auth.DeployKeyMetadata{DeployKeyID: 1, RepoID: 2, ReadOnly: true},
+	}
+
+	// the controller has no dependencies - rejection has to happen before any repo lookup.
+	c := &Controller{}
+
+	err := c.GitServicePack(context.Background(), session, "space/repo", api.ServicePackOptions{
+		Service: enum.GitServiceTypeReceivePack,
+		Stdin:   failingReader{t: t},
+		Stdout:  &bytes.Buffer{},
+	})
+	if !errors.Is(err, errDeployKeyReadOnly) {
+		t.Errorf("receive-pack: expected read-only error, got %v", err)
+	}
+
+	err = c.GitInfoRefs(context.Background(), session, "space/repo", enum.GitServiceTypeReceivePack, "",
+		&bytes.Buffer{})
+	if !errors.Is(err, errDeployKeyReadOnly) {
+		t.Errorf("info/refs for receive-pack: expected read-only error, got %v", err)
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// GitSettings represent the settings of a repository that are stored in its git config.
+type GitSettings struct {
+	DefaultBranch string            `json:"default_branch"`
+	Config        map[string]string `json:"config"`
+}
+
+type GitSettingsUpdateInput struct {
+	DefaultBranch *string `json:"default_branch"`
+	// Force allows changing the default branch while open pull requests target the current default branch.
+	Force bool `json:"force"`
+	// Config contains the git config keys to update. A null value unsets the key.
+	Config map[string]*string `json:"config"`
+}
+
+func (in *GitSettingsUpdateInput) sanitize() error {
+	if in.DefaultBranch != nil && *in.DefaultBranch == "" {
+		return usererror.BadRequest("Default branch can't be empty.")
+	}
+
+	for key := range in.Config {
+		if !git.IsRepoConfigKeyAllowed(key) {
+			return usererror.BadRequestf("Git config key %q can't be managed for a repository.", key)
+		}
+	}
+
+	return nil
+}
+
+// GitSettingsFind returns the git settings of the repository.
+func (c *Controller) GitSettingsFind(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*GitSettings, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.getGitSettings(ctx, repo)
+}
+
+// GitSettingsUpdate updates the git settings of the repository.
+// The default branch can't be changed while open pull requests target the current default branch, unless forced.
+func (c *Controller) GitSettingsUpdate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *GitSettingsUpdateInput,
+) (*GitSettings, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	old, err := c.getGitSettings(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.DefaultBranch != nil && *in.DefaultBranch != repo.DefaultBranch {
+		repo, err = c.changeDefaultBranch(ctx, session, repo, *in.DefaultBranch, in.Force)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(in.Config) > 0 {
+		writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create RPC write params: %w", err)
+		}
+
+		err = c.git.UpdateRepoConfig(ctx, &git.UpdateRepoConfigParams{
+			WriteParams: writeParams,
+			Values:      in.Config,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to update git config: %w", err)
+		}
+	}
+
+	out, err := c.getGitSettings(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeRepositorySettings, repo.Identifier),
+		audit.ActionUpdated,
+		paths.Parent(repo.Path),
+		audit.WithOldObject(old),
+		audit.WithNewObject(out),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update repository git settings operation: %s", err)
+	}
+
+	return out, nil
+}
+
+func (c *Controller) changeDefaultBranch(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	name string,
+	force bool,
+) (*types.Repository, error) {
+	_, err := c.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.CreateReadParams(repo),
+		BranchName: name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the new default branch: %w", err)
+	}
+
+	if !force {
+		count, err := c.pullReqStore.Count(ctx, &types.PullReqFilter{
+			TargetRepoID: repo.ID,
+			TargetBranch: repo.DefaultBranch,
+			States:       []enum.PullReqState{enum.PullReqStateOpen},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to count open pull requests: %w", err)
+		}
+
+		if count > 0 {
+			return nil, usererror.ConflictWithPayload(
+				fmt.Sprintf("There are %d open pull requests targeting the current default branch %q.",
+					count, repo.DefaultBranch),
+				map[string]any{"open_pull_requests": count},
+			)
+		}
+	}
+
+	return c.updateDefaultBranch(ctx, session, repo, name)
+}
+
+func (c *Controller) getGitSettings(ctx context.Context, repo *types.Repository) (*GitSettings, error) {
+	out, err := c.git.GetRepoConfig(ctx, &git.GetRepoConfigParams{
+		ReadParams: git.CreateReadParams(repo),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get git config: %w", err)
+	}
+
+	return &GitSettings{
+		DefaultBranch: repo.DefaultBranch,
+		Config:        out.Values,
+	}, nil
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
@@ -32,6 +33,12 @@ import (
 
 var ActiveRepoStates = []enum.RepoState{enum.RepoStateActive}
 
+// ReadableRepoStates contains all repo states that allow reading the repository via the API.
+var ReadableRepoStates = enum.RepoStatesAllowing(enum.RepoOperationRead)
+
+// GitRepoStates contains all repo states that allow accessing the repository via git.
+var GitRepoStates = enum.RepoStatesAllowing(enum.RepoOperationGit)
+
 // GetRepo fetches an repository.
 func GetRepo(
 	ctx context.Context,
@@ -49,7 +56,7 @@ func GetRepo(
 	}
 
 	if len(allowedStates) > 0 && !slices.Contains(allowedStates, repo.State) {
-		return nil, usererror.BadRequest("Repository is not ready to use.")
+		return nil, usererror.RepoUnavailable(repo.State, repo.StateUntil)
 	}
 
 	return repo, nil
@@ -71,6 +78,8 @@ func GetRepoCheckAccess(
 		return nil, fmt.Errorf("failed to find repo: %w", err)
 	}
 
+	controller.WithRepo(ctx, repo)
+
 	if err = apiauth.CheckRepo(ctx, authorizer, session, repo, reqPermission); err != nil {
 		return nil, fmt.Errorf("access check failed: %w", err)
 	}
@@ -91,7 +100,8 @@ func GetRepoOutput(
 	return &RepositoryOutput{
 		Repository: *repo,
 		IsPublic:   isPublic,
-		Importing:  repo.State != enum.RepoStateActive,
+		Importing:  repo.State.IsImporting(),
+		StateName:  repo.State.String(),
 	}, nil
 }
 
@@ -103,6 +113,7 @@ func GetRepoOutputWithAccess(
 	return &RepositoryOutput{
 		Repository: *repo,
 		IsPublic:   isPublic,
-		Importing:  repo.State != enum.RepoStateActive,
+		Importing:  repo.State.IsImporting(),
+		StateName:  repo.State.String(),
 	}
 }
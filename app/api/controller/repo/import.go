@@ -23,7 +23,10 @@ import (
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/instrument"
+	"github.com/harness/gitness/app/services/operation"
 	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 
 	"github.com/rs/zerolog/log"
 )
@@ -42,6 +45,7 @@ type ImportInput struct {
 }
 
 // Import creates a new empty repository and starts git import to it from a remote repository.
+// The returned repository contains the ID of the import operation that reports the progress of the import.
 func (c *Controller) Import(ctx context.Context, session *auth.Session, in *ImportInput) (*RepositoryOutput, error) {
 	if err := c.sanitizeImportInput(in); err != nil {
 		return nil, fmt.Errorf("failed to sanitize input: %w", err)
@@ -65,6 +69,7 @@ func (c *Controller) Import(ctx context.Context, session *auth.Session, in *Impo
 		&session.Principal,
 	)
 
+	var op *types.Operation
 	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
 		if err := c.resourceLimiter.RepoCount(ctx, parentSpace.ID, 1); err != nil {
 			return fmt.Errorf("resource limit exceeded: %w", limiter.ErrMaxNumReposReached)
@@ -92,6 +97,17 @@ func (c *Controller) Import(ctx context.Context, session *auth.Session, in *Impo
 			return fmt.Errorf("failed to start import repository job: %w", err)
 		}
 
+		op, err = c.operationSvc.Create(ctx, operation.CreateInput{
+			Type:         enum.OperationTypeRepoImport,
+			ResourceType: enum.ParentResourceTypeRepo,
+			ResourceID:   repo.ID,
+			JobUID:       importer.JobIDFromRepoID(repo.ID),
+			CreatedBy:    session.Principal.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create import repository operation: %w", err)
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -128,7 +144,11 @@ func (c *Controller) Import(ctx context.Context, session *auth.Session, in *Impo
 	if err != nil {
 		log.Ctx(ctx).Warn().Msgf("failed to insert instrumentation record for import repository operation: %s", err)
 	}
-	return GetRepoOutputWithAccess(ctx, false, repo), nil
+
+	repoOut := GetRepoOutputWithAccess(ctx, false, repo)
+	repoOut.OperationID = op.UID
+
+	return repoOut, nil
 }
 
 func (c *Controller) sanitizeImportInput(in *ImportInput) error {
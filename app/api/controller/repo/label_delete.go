@@ -19,24 +19,28 @@ import (
 	"fmt"
 
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
 
-// DeleteLabel deletes a label for the specified repository.
+// DeleteLabel deletes a label for the specified repository and returns the deleted resources.
+// With dryRun set, the resources that would be deleted are returned, but nothing is deleted.
 func (c *Controller) DeleteLabel(
 	ctx context.Context,
 	session *auth.Session,
 	repoRef string,
 	key string,
-) error {
+	dryRun bool,
+) ([]types.AffectedResource, error) {
 	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
 	if err != nil {
-		return fmt.Errorf("failed to acquire access to repo: %w", err)
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
 	}
 
-	if err := c.labelSvc.Delete(ctx, nil, &repo.ID, key); err != nil {
-		return fmt.Errorf("failed to delete repo label: %w", err)
+	affected, err := c.labelSvc.Delete(ctx, nil, &repo.ID, key, dryRun)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete repo label: %w", err)
 	}
 
-	return nil
+	return affected, nil
 }
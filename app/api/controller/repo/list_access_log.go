@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListAccessLog lists the git operations performed on a repository, most recent first.
+func (c *Controller) ListAccessLog(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	filter *types.RepoAccessLogFilter,
+) ([]*types.RepoAccessLog, int64, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var count int64
+	var entries []*types.RepoAccessLog
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) (err error) {
+		count, err = c.repoAccessLogStore.Count(ctx, repo.ID, filter)
+		if err != nil {
+			return fmt.Errorf("failed to count repo access log entries: %w", err)
+		}
+
+		entries, err = c.repoAccessLogStore.List(ctx, repo.ID, filter)
+		if err != nil {
+			return fmt.Errorf("failed to list repo access log entries: %w", err)
+		}
+
+		return nil
+	}, dbtx.TxDefaultReadOnly)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return entries, count, nil
+}
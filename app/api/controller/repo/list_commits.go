@@ -42,6 +42,10 @@ func (c *Controller) ListCommits(ctx context.Context,
 		gitRef = repo.DefaultBranch
 	}
 
+	if filter.Follow {
+		return c.listFileHistory(ctx, repo, gitRef, filter)
+	}
+
 	rpcOut, err := c.git.ListCommits(ctx, &git.ListCommitsParams{
 		ReadParams:   git.CreateReadParams(repo),
 		GitREF:       gitRef,
@@ -53,7 +57,15 @@ func (c *Controller) ListCommits(ctx context.Context,
 		Until:        filter.Until,
 		Committer:    filter.Committer,
 		IncludeStats: filter.IncludeStats,
+		IncludeNotes: filter.IncludeNotes,
+		NotesRef:     filter.NotesRef,
 	})
+	if git.IsErrRepositoryEmpty(err) {
+		return types.ListCommitResponse{
+			Commits:       []types.Commit{},
+			RenameDetails: []types.RenameDetails{},
+		}, nil
+	}
 	if err != nil {
 		return types.ListCommitResponse{}, err
 	}
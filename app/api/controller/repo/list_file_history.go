@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+)
+
+// listFileHistory lists the commits that changed the file at the filter path, following renames of the file.
+// Each commit is annotated with the path the file had at that commit.
+func (c *Controller) listFileHistory(
+	ctx context.Context,
+	repo *types.Repository,
+	gitRef string,
+	filter *types.CommitFilter,
+) (types.ListCommitResponse, error) {
+	if filter.Path == "" {
+		return types.ListCommitResponse{}, usererror.BadRequest("A file path is required to follow renames.")
+	}
+	if filter.IncludeStats {
+		return types.ListCommitResponse{}, usererror.BadRequest("Commit stats aren't supported when following renames.")
+	}
+
+	rpcOut, err := c.git.ListFileHistory(ctx, &git.ListFileHistoryParams{
+		ReadParams:   git.CreateReadParams(repo),
+		GitREF:       gitRef,
+		Path:         filter.Path,
+		After:        filter.After,
+		Limit:        filter.Limit,
+		Since:        filter.Since,
+		Until:        filter.Until,
+		Committer:    filter.Committer,
+		IncludeNotes: filter.IncludeNotes,
+		NotesRef:     filter.NotesRef,
+	})
+	if git.IsErrRepositoryEmpty(err) {
+		return types.ListCommitResponse{
+			Commits:       []types.Commit{},
+			RenameDetails: []types.RenameDetails{},
+		}, nil
+	}
+	if err != nil {
+		return types.ListCommitResponse{}, err
+	}
+
+	commits := make([]types.Commit, len(rpcOut.Commits))
+	for i := range rpcOut.Commits {
+		var commit *types.Commit
+		commit, err = controller.MapCommit(&rpcOut.Commits[i])
+		if err != nil {
+			return types.ListCommitResponse{}, fmt.Errorf("failed to map commit: %w", err)
+		}
+		commit.Path = rpcOut.Paths[i]
+		commits[i] = *commit
+	}
+
+	var nextAfter string
+	if rpcOut.HasMore && len(commits) > 0 {
+		nextAfter = commits[len(commits)-1].SHA
+	}
+
+	return types.ListCommitResponse{
+		Commits:       commits,
+		RenameDetails: []types.RenameDetails{},
+		NextAfter:     nextAfter,
+	}, nil
+}
@@ -50,6 +50,9 @@ func (c *Controller) ListPaths(ctx context.Context,
 		GitREF:             gitRef,
 		IncludeDirectories: includeDirectories,
 	})
+	if git.IsErrRepositoryEmpty(err) {
+		return ListPathsOutput{}, nil
+	}
 	if err != nil {
 		return ListPathsOutput{}, fmt.Errorf("failed to list git paths: %w", err)
 	}
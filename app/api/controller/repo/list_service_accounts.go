@@ -22,6 +22,7 @@ import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+	"github.com/harness/gitness/types/listfilter"
 )
 
 // ListServiceAccounts lists the service accounts of a repo.
@@ -29,6 +30,7 @@ func (c *Controller) ListServiceAccounts(
 	ctx context.Context,
 	session *auth.Session,
 	repoRef string,
+	filter *listfilter.Filter,
 ) ([]*types.ServiceAccount, error) {
 	repo, err := c.getRepo(ctx, repoRef)
 	if err != nil {
@@ -49,5 +51,5 @@ func (c *Controller) ListServiceAccounts(
 		return nil, fmt.Errorf("access check failed: %w", err)
 	}
 
-	return c.principalStore.ListServiceAccounts(ctx, enum.ParentResourceTypeRepo, repo.ID)
+	return c.principalStore.ListServiceAccounts(ctx, enum.ParentResourceTypeRepo, repo.ID, filter)
 }
@@ -0,0 +1,155 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// defaultStaleBranchInactiveDays is the number of days without commits
+	// after which a branch is considered inactive, if not provided by the caller.
+	defaultStaleBranchInactiveDays = 90
+
+	// maxStaleBranchCandidates is the max number of branches (oldest first) that are checked for staleness.
+	maxStaleBranchCandidates = 1000
+)
+
+// StaleBranch is a branch that is suggested for cleanup.
+type StaleBranch struct {
+	Branch
+	// Merged is true if the branch is fully merged into the default branch.
+	Merged bool `json:"merged"`
+	// Inactive is true if the branch didn't receive any commits in the inactivity period.
+	Inactive bool `json:"inactive"`
+}
+
+// ListStaleBranches lists the branches of the repository that are either fully merged into the default branch
+// or that haven't received any commits for the provided number of days. The default branch is never listed.
+func (c *Controller) ListStaleBranches(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	filter *types.StaleBranchFilter,
+) ([]StaleBranch, int64, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if repo.IsEmpty {
+		return []StaleBranch{}, 0, nil
+	}
+
+	inactiveDays := filter.InactiveDays
+	if inactiveDays <= 0 {
+		inactiveDays = defaultStaleBranchInactiveDays
+	}
+
+	readParams := git.CreateReadParams(repo)
+
+	defaultBranch, err := c.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: readParams,
+		BranchName: repo.DefaultBranch,
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get default branch: %w", err)
+	}
+
+	rpcOut, err := c.git.ListBranches(ctx, &git.ListBranchesParams{
+		ReadParams:    readParams,
+		IncludeCommit: true,
+		Query:         filter.Query,
+		Sort:          git.BranchSortOptionDate,
+		Order:         git.SortOrderAsc,
+		Page:          1,
+		PageSize:      maxStaleBranchCandidates,
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	candidates := make([]git.Branch, 0, len(rpcOut.Branches))
+	checks := make([]git.AncestorCheck, 0, len(rpcOut.Branches))
+	for _, branch := range rpcOut.Branches {
+		if branch.Name == repo.DefaultBranch {
+			continue
+		}
+
+		candidates = append(candidates, branch)
+		checks = append(checks, git.AncestorCheck{
+			AncestorCommitSHA:   branch.SHA,
+			DescendantCommitSHA: defaultBranch.Branch.SHA,
+		})
+	}
+
+	ancestryOut, err := c.git.AreAncestors(ctx, git.AreAncestorsParams{
+		ReadParams: readParams,
+		Checks:     checks,
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to check if branches are merged into the default branch: %w", err)
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -inactiveDays)
+
+	staleBranches := make([]StaleBranch, 0, len(candidates))
+	for i, branch := range candidates {
+		merged := ancestryOut.Ancestors[i]
+		inactive := branch.Commit != nil && branch.Commit.Committer.When.Before(cutoff)
+		if !merged && !inactive {
+			continue
+		}
+
+		var b Branch
+		b, err = mapBranch(branch)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to map branch: %w", err)
+		}
+
+		staleBranches = append(staleBranches, StaleBranch{
+			Branch:   b,
+			Merged:   merged,
+			Inactive: inactive,
+		})
+	}
+
+	count := int64(len(staleBranches))
+
+	return paginateStaleBranches(staleBranches, filter.Page, filter.Size), count, nil
+}
+
+func paginateStaleBranches(branches []StaleBranch, page, size int) []StaleBranch {
+	if size <= 0 {
+		return branches
+	}
+
+	if page < 1 {
+		page = 1
+	}
+
+	start := (page - 1) * size
+	if start >= len(branches) {
+		return []StaleBranch{}
+	}
+
+	return branches[start:min(start+size, len(branches))]
+}
@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types/enum"
+)
+
+// MergeBaseOutput contains the merge base of two refs.
+type MergeBaseOutput struct {
+	MergeBaseSHA sha.SHA `json:"merge_base_sha"`
+	// UnrelatedHistories is true if the two refs don't have a common ancestor.
+	UnrelatedHistories bool `json:"unrelated_histories"`
+}
+
+// MergeBase returns the best common ancestor of two refs.
+func (c *Controller) MergeBase(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	ref1 string,
+	ref2 string,
+) (*MergeBaseOutput, error) {
+	if ref1 == "" || ref2 == "" {
+		return nil, usererror.BadRequest("Both refs have to be provided.")
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.git.MergeBase(ctx, git.MergeBaseParams{
+		ReadParams: git.CreateReadParams(repo),
+		Ref1:       ref1,
+		Ref2:       ref2,
+	})
+	if git.IsErrUnrelatedHistories(err) {
+		return &MergeBaseOutput{UnrelatedHistories: true}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merge base: %w", err)
+	}
+
+	return &MergeBaseOutput{MergeBaseSHA: result.MergeBaseSHA}, nil
+}
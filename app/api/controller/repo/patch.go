@@ -0,0 +1,89 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types/enum"
+)
+
+// CommitPatch writes the commit as mailbox patch (git format-patch) or as plain diff to w.
+// The commit SHA can be abbreviated, but has to be unambiguous. setSHA is called with the full
+// commit SHA before anything is written.
+func (c *Controller) CommitPatch(
+	ctx context.Context,
+	w io.Writer,
+	session *auth.Session,
+	repoRef string,
+	commitSHA string,
+	format enum.PatchFormat,
+	setSHA func(commitSHA sha.SHA),
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return err
+	}
+
+	readParams := git.CreateReadParams(repo)
+
+	resolved, err := c.git.ResolveCommitSHA(ctx, &git.ResolveCommitSHAParams{
+		ReadParams: readParams,
+		SHA:        commitSHA,
+	})
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case enum.PatchFormatPatch:
+		setSHA(resolved.SHA)
+
+		return c.git.FormatPatch(ctx, w, &git.FormatPatchParams{
+			ReadParams: readParams,
+			HeadRef:    resolved.SHA.String(),
+		})
+	case enum.PatchFormatDiff:
+		var commit *git.GetCommitOutput
+		commit, err = c.git.GetCommit(ctx, &git.GetCommitParams{
+			ReadParams: readParams,
+			Revision:   resolved.SHA.String(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get commit: %w", err)
+		}
+
+		// the diff of a root commit is against the empty tree.
+		baseRef := sha.EmptyTree.String()
+		if len(commit.Commit.ParentSHAs) > 0 {
+			baseRef = commit.Commit.ParentSHAs[0].String()
+		}
+
+		setSHA(resolved.SHA)
+
+		return c.git.RawDiff(ctx, w, &git.DiffParams{
+			ReadParams: readParams,
+			BaseRef:    baseRef,
+			HeadRef:    resolved.SHA.String(),
+		})
+	default:
+		return fmt.Errorf("unsupported patch format %q", format)
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types/enum"
+)
+
+// PermalinkOutput is a link to a range of lines of a file that stays valid after the branch moves.
+type PermalinkOutput struct {
+	// SHA is the newest commit that changed any of the lines of the range.
+	SHA string `json:"sha"`
+	// Path is the path of the file in the commit, it differs from the requested path if the file was renamed since.
+	Path string `json:"path"`
+	// LineFrom and LineTo are the line numbers of the range in the commit.
+	LineFrom int `json:"line_from"`
+	LineTo   int `json:"line_to"`
+	// MultiCommit is true if the lines of the range were last changed by different commits.
+	MultiCommit bool `json:"multi_commit"`
+}
+
+// Permalink resolves a range of lines of a file at a git reference to the last commit that changed them.
+func (c *Controller) Permalink(ctx context.Context,
+	session *auth.Session,
+	repoRef, gitRef, path string,
+	lineFrom, lineTo int,
+) (*PermalinkOutput, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil, usererror.BadRequest("File path needs to specified.")
+	}
+
+	if lineFrom <= 0 {
+		return nil, usererror.BadRequest("Line from needs to be specified.")
+	}
+
+	if lineTo == 0 {
+		lineTo = lineFrom
+	}
+
+	if lineFrom > lineTo {
+		return nil, usererror.BadRequest("Line range must be valid.")
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	if gitRef == "" {
+		gitRef = repo.DefaultBranch
+	}
+
+	reader := git.NewStreamReader(
+		c.git.Blame(ctx, &git.BlameParams{
+			ReadParams: git.CreateReadParams(repo),
+			GitRef:     gitRef,
+			Path:       path,
+			LineFrom:   lineFrom,
+			LineTo:     lineTo,
+		}))
+
+	var newest *git.BlamePart
+	multiCommit := false
+	for {
+		var part *git.BlamePart
+		part, err = reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to blame file: %w", err)
+		}
+
+		if newest == nil {
+			newest = part
+			continue
+		}
+
+		if !part.Commit.SHA.Equal(newest.Commit.SHA) {
+			multiCommit = true
+			if part.Commit.Committer.When.After(newest.Commit.Committer.When) {
+				newest = part
+			}
+		}
+	}
+
+	if newest == nil {
+		return nil, usererror.BadRequest("Line range must be valid.")
+	}
+
+	// Lines can't be inserted into the range after the newest commit, hence the whole range
+	// is shifted by the same offset as the lines of the newest commit.
+	offset := newest.OrigLineNumber - newest.LineNumber
+
+	return &PermalinkOutput{
+		SHA:         newest.Commit.SHA.String(),
+		Path:        newest.Path,
+		LineFrom:    lineFrom + offset,
+		LineTo:      lineTo + offset,
+		MultiCommit: multiCommit,
+	}, nil
+}
@@ -72,6 +72,14 @@ func (c *Controller) PurgeNoAuth(
 		}
 	}
 
+	// a previous purge attempt might have failed after the state was already changed.
+	if repo.State != enum.RepoStatePurging {
+		err := c.repoStateSvc.Transition(ctx, &session.Principal, repo, enum.RepoStatePurging, 0)
+		if err != nil {
+			return fmt.Errorf("failed to mark repository for purging: %w", err)
+		}
+	}
+
 	if err := c.repoStore.Purge(ctx, repo.ID, repo.Deleted); err != nil {
 		return fmt.Errorf("failed to delete repo from db: %w", err)
 	}
@@ -0,0 +1,119 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// RefHistoryEntry is a single update of a git reference recorded in the reflog.
+type RefHistoryEntry struct {
+	OldSHA    sha.SHA        `json:"old_sha"`
+	NewSHA    sha.SHA        `json:"new_sha"`
+	Message   string         `json:"message"`
+	Identity  types.Identity `json:"identity"`
+	Timestamp int64          `json:"timestamp"`
+
+	// Pusher is the principal that performed the update, if it could be resolved.
+	Pusher *types.PrincipalInfo `json:"pusher,omitempty"`
+}
+
+// ListRefHistory lists the updates of a git reference, most recent first.
+// References without the "refs/" prefix are considered to be branches.
+func (c *Controller) ListRefHistory(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	gitRef string,
+	filter *types.Pagination,
+) ([]RefHistoryEntry, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.HasPrefix(gitRef, "refs/") {
+		gitRef, err = git.GetRefPath(gitRef, gitenum.RefTypeBranch)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rpcOut, err := c.git.ListReflog(ctx, &git.ListReflogParams{
+		ReadParams: git.CreateReadParams(repo),
+		Ref:        gitRef,
+		Page:       int32(filter.Page),
+		Limit:      int32(filter.Size),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reflog: %w", err)
+	}
+
+	pushers := map[string]*types.PrincipalInfo{}
+	entries := make([]RefHistoryEntry, len(rpcOut.Entries))
+	for i, entry := range rpcOut.Entries {
+		email := entry.Pusher.Identity.Email
+
+		pusher, ok := pushers[email]
+		if !ok {
+			pusher, err = c.findPusherByEmail(ctx, email)
+			if err != nil {
+				return nil, err
+			}
+			pushers[email] = pusher
+		}
+
+		entries[i] = RefHistoryEntry{
+			OldSHA:  entry.OldSHA,
+			NewSHA:  entry.NewSHA,
+			Message: entry.Message,
+			Identity: types.Identity{
+				Name:  entry.Pusher.Identity.Name,
+				Email: entry.Pusher.Identity.Email,
+			},
+			Timestamp: entry.Pusher.When.UnixMilli(),
+			Pusher:    pusher,
+		}
+	}
+
+	return entries, nil
+}
+
+func (c *Controller) findPusherByEmail(ctx context.Context, email string) (*types.PrincipalInfo, error) {
+	if email == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	principal, err := c.principalStore.FindByEmail(ctx, email)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, nil //nolint:nilnil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pusher by email: %w", err)
+	}
+
+	return principal.ToPrincipalInfo(), nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// restoreBranchSearchSize is the number of most recent push activities of a branch
+// that are searched for its deletion.
+const restoreBranchSearchSize = 100
+
+// RestoreBranchInput used for restoring a deleted branch.
+type RestoreBranchInput struct {
+	Name string `json:"name"`
+
+	// SHA is the commit the restored branch will be pointing to.
+	// If no SHA is provided, the branch is restored at the commit it pointed to when it got deleted.
+	SHA string `json:"sha"`
+
+	BypassRules bool `json:"bypass_rules"`
+}
+
+// RestoreBranch recreates a deleted branch at its last known commit.
+// The reflog of a branch is removed by git together with the branch,
+// hence the last known commit is taken from the push activity of the repository.
+func (c *Controller) RestoreBranch(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *RestoreBranchInput,
+) (*Branch, []types.RuleViolations, error) {
+	if in.Name == "" {
+		return nil, nil, usererror.BadRequest("Branch name is required")
+	}
+
+	if in.SHA == "" {
+		repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		in.SHA, err = c.findDeletedBranchSHA(ctx, repo, in.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return c.CreateBranch(ctx, session, repoRef, &CreateBranchInput{
+		Name:        in.Name,
+		Target:      in.SHA,
+		BypassRules: in.BypassRules,
+	})
+}
+
+func (c *Controller) findDeletedBranchSHA(
+	ctx context.Context,
+	repo *types.Repository,
+	branchName string,
+) (string, error) {
+	ref, err := git.GetRefPath(branchName, gitenum.RefTypeBranch)
+	if err != nil {
+		return "", err
+	}
+
+	activities, err := c.repoActivityStore.List(ctx, repo.ID, &types.RepoActivityFilter{
+		Page:  1,
+		Size:  restoreBranchSearchSize,
+		Types: []enum.RepoActivityType{enum.RepoActivityTypePush},
+		Ref:   ref,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list push activities of the branch: %w", err)
+	}
+
+	for _, activity := range activities {
+		payload := types.RepoActivityPushPayload{}
+		if err := json.Unmarshal(activity.Payload, &payload); err != nil {
+			return "", fmt.Errorf("failed to unmarshal push activity payload: %w", err)
+		}
+
+		if payload.NewSHA == types.NilSHA && payload.OldSHA != "" {
+			return payload.OldSHA, nil
+		}
+	}
+
+	return "", usererror.NotFoundf("No deletion of branch %q found", branchName)
+}
@@ -69,6 +69,10 @@ func (in *RuleCreateInput) sanitize() error {
 		in.Type = protection.TypeBranch
 	}
 
+	if in.Type == protection.TypeTag && in.Pattern.Default {
+		return usererror.BadRequest("tag rules can't use the default branch pattern")
+	}
+
 	if len(in.Definition) == 0 {
 		return usererror.BadRequest("rule definition missing")
 	}
@@ -76,6 +80,20 @@ func (in *RuleCreateInput) sanitize() error {
 	return nil
 }
 
+// RuleCreateValidate validates the input for creating a protection rule without creating it.
+// It's used to reject invalid input upfront when the same rule is created in many repositories.
+func (c *Controller) RuleCreateValidate(in *RuleCreateInput) error {
+	if err := in.sanitize(); err != nil {
+		return err
+	}
+
+	if _, err := c.protectionManager.SanitizeJSON(in.Type, in.Definition); err != nil {
+		return usererror.BadRequestf("invalid rule definition: %s", err.Error())
+	}
+
+	return nil
+}
+
 // RuleCreate creates a new protection rule for a repo.
 func (c *Controller) RuleCreate(ctx context.Context,
 	session *auth.Session,
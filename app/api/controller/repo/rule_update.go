@@ -119,6 +119,9 @@ func (c *Controller) RuleUpdate(ctx context.Context,
 		r.Description = *in.Description
 	}
 	if in.Pattern != nil {
+		if r.Type == protection.TypeTag && in.Pattern.Default {
+			return nil, usererror.BadRequest("tag rules can't use the default branch pattern")
+		}
 		r.Pattern = in.Pattern.JSON()
 	}
 	if in.Definition != nil {
@@ -31,14 +31,18 @@ import (
 )
 
 type SoftDeleteResponse struct {
-	DeletedAt int64 `json:"deleted_at"`
+	DeletedAt int64                    `json:"deleted_at"`
+	DryRun    bool                     `json:"dry_run,omitempty"`
+	Affected  []types.AffectedResource `json:"affected,omitempty"`
 }
 
 // SoftDelete soft deletes a repo and returns the deletedAt timestamp in epoch format.
+// With dryRun set, all checks are performed but the repository isn't deleted.
 func (c *Controller) SoftDelete(
 	ctx context.Context,
 	session *auth.Session,
 	repoRef string,
+	dryRun bool,
 ) (*SoftDeleteResponse, error) {
 	// note: can't use c.getRepoCheckAccess because import job for repositories being imported must be cancelled.
 	repo, err := c.repoStore.FindByRef(ctx, repoRef)
@@ -59,12 +63,26 @@ func (c *Controller) SoftDelete(
 		return nil, fmt.Errorf("failed to check current public access status: %w", err)
 	}
 
+	now := time.Now().UnixMilli()
+	out := &SoftDeleteResponse{
+		DeletedAt: now,
+		DryRun:    dryRun,
+		Affected: []types.AffectedResource{{
+			Type: enum.AffectedResourceTypeRepo,
+			ID:   repo.ID,
+			Name: repo.Path,
+		}},
+	}
+
+	if dryRun {
+		return out, nil
+	}
+
 	log.Ctx(ctx).Info().
 		Int64("repo.id", repo.ID).
 		Str("repo.path", repo.Path).
 		Msg("soft deleting repository")
 
-	now := time.Now().UnixMilli()
 	if err = c.SoftDeleteNoAuth(ctx, session, repo, now); err != nil {
 		return nil, fmt.Errorf("failed to soft delete repo: %w", err)
 	}
@@ -83,7 +101,7 @@ func (c *Controller) SoftDelete(
 		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for delete repository operation: %s", err)
 	}
 
-	return &SoftDeleteResponse{DeletedAt: now}, nil
+	return out, nil
 }
 
 func (c *Controller) SoftDeleteNoAuth(
@@ -97,7 +115,8 @@ func (c *Controller) SoftDeleteNoAuth(
 		return fmt.Errorf("failed to delete public access for repo: %w", err)
 	}
 
-	if repo.State != enum.RepoStateActive {
+	// repositories that are still being imported have no data worth restoring.
+	if repo.State.IsImporting() {
 		return c.PurgeNoAuth(ctx, session, repo)
 	}
 
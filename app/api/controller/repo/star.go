@@ -0,0 +1,143 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"github.com/harness/gitness/errors"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Star stars the repo for the current user. Starring an already starred repo is a no-op.
+// NOTE: Stars are a personal preference, they are neither recorded as repo activity nor in the audit log.
+func (c *Controller) Star(ctx context.Context, session *auth.Session, repoRef string) (*RepositoryOutput, error) {
+	return c.updateStar(ctx, session, repoRef, true)
+}
+
+// Unstar removes the star of the current user from the repo. Unstarring a repo that isn't starred is a no-op.
+func (c *Controller) Unstar(ctx context.Context, session *auth.Session, repoRef string) (*RepositoryOutput, error) {
+	return c.updateStar(ctx, session, repoRef, false)
+}
+
+func (c *Controller) updateStar(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	star bool,
+) (*RepositoryOutput, error) {
+	if auth.IsAnonymousSession(session) {
+		return nil, usererror.ErrUnauthorized
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		var changed bool
+		delta := 1
+		if star {
+			changed, err = c.repoStarStore.Create(ctx, session.Principal.ID, repo.ID)
+		} else {
+			changed, err = c.repoStarStore.Delete(ctx, session.Principal.ID, repo.ID)
+			delta = -1
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update repo star: %w", err)
+		}
+
+		if !changed {
+			return nil
+		}
+
+		if err = c.repoStore.UpdateNumStars(ctx, repo.ID, delta); err != nil {
+			return fmt.Errorf("failed to update repo star count: %w", err)
+		}
+
+		repo, err = c.repoStore.Find(ctx, repo.ID)
+		if err != nil {
+			return fmt.Errorf("failed to find repo: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// backfill clone url
+	repo.GitURL = c.urlProvider.GenerateGITCloneURL(ctx, repo.Path)
+	repo.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(ctx, repo.Path)
+
+	return GetRepoOutput(ctx, c.publicAccess, repo)
+}
+
+// ListStarred lists the repos starred by the current user that the user can still access.
+func (c *Controller) ListStarred(
+	ctx context.Context,
+	session *auth.Session,
+	pagination types.Pagination,
+) ([]*RepositoryOutput, int64, error) {
+	var (
+		repos []*types.Repository
+		count int64
+	)
+
+	err := c.tx.WithTx(ctx, func(ctx context.Context) (err error) {
+		count, err = c.repoStore.CountStarred(ctx, session.Principal.ID)
+		if err != nil {
+			return fmt.Errorf("failed to count starred repos: %w", err)
+		}
+
+		repos, err = c.repoStore.ListStarred(ctx, session.Principal.ID, pagination)
+		if err != nil {
+			return fmt.Errorf("failed to list starred repos: %w", err)
+		}
+
+		return nil
+	}, dbtx.TxDefaultReadOnly)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]*RepositoryOutput, 0, len(repos))
+	for _, repo := range repos {
+		// the user might have lost access to the repo since it was starred.
+		err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView)
+		if errors.Is(err, apiauth.ErrNotAuthorized) {
+			continue
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+
+		repoOut, err := GetRepoOutput(ctx, c.publicAccess, repo)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		result = append(result, repoOut)
+	}
+
+	return result, count, nil
+}
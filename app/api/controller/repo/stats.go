@@ -0,0 +1,93 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Stats returns the push frequency, the top contributors over the provided window
+// and the branch and tag count of a repository, as precomputed by the repo stats calculator.
+func (c *Controller) Stats(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	window enum.RepoStatsWindow,
+) (*types.RepoStatsOutput, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("access check failed: %w", err)
+	}
+
+	out := &types.RepoStatsOutput{
+		PushBuckets:     []types.RepoPushBucket{},
+		Window:          window,
+		TopContributors: []types.RepoContributorInfo{},
+	}
+
+	stats, err := c.repoStatsStore.Find(ctx, repo.ID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		// stats of the repository haven't been calculated yet.
+		return out, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo stats: %w", err)
+	}
+
+	out.Pushes7Days = stats.Pushes7Days
+	out.Pushes30Days = stats.Pushes30Days
+	out.Branches = stats.Branches
+	out.Tags = stats.Tags
+	out.Updated = stats.Updated
+	if stats.PushBuckets != nil {
+		out.PushBuckets = stats.PushBuckets
+	}
+
+	contributors := stats.Contributors[window]
+	if len(contributors) == 0 {
+		return out, nil
+	}
+
+	principalIDs := make([]int64, len(contributors))
+	for i, contributor := range contributors {
+		principalIDs[i] = contributor.PrincipalID
+	}
+
+	principalInfos, err := c.principalInfoCache.Map(ctx, principalIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load contributor principal infos: %w", err)
+	}
+
+	for _, contributor := range contributors {
+		principalInfo, ok := principalInfos[contributor.PrincipalID]
+		if !ok {
+			continue
+		}
+
+		out.TopContributors = append(out.TopContributors, types.RepoContributorInfo{
+			Principal: principalInfo,
+			Commits:   contributor.Commits,
+		})
+	}
+
+	return out, nil
+}
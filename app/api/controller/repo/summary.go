@@ -36,6 +36,10 @@ func (c *Controller) Summary(
 	}
 
 	summary, err := c.git.Summary(ctx, git.SummaryParams{ReadParams: git.CreateReadParams(repo)})
+	if git.IsErrRepositoryEmpty(err) {
+		// an empty repository has no commits, branches or tags to summarize.
+		summary, err = git.SummaryOutput{}, nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get repo summary: %w", err)
 	}
@@ -16,12 +16,17 @@ package repo
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/audit"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
@@ -30,12 +35,68 @@ import (
 )
 
 // UpdateInput is used for updating a repo.
+// Fields that aren't provided are left unchanged.
 type UpdateInput struct {
-	Description *string `json:"description"`
+	Description *string   `json:"description"`
+	Topics      *[]string `json:"topics"`
+
+	// Website is cleared if it's set to null or to an empty string.
+	Website types.Nullable[string] `json:"website"`
+	// ContactID is cleared if it's set to null.
+	ContactID  types.Nullable[int64] `json:"contact_id"`
+	ShowReadme *bool                 `json:"show_readme"`
 }
 
 func (in *UpdateInput) hasChanges(repo *types.Repository) bool {
-	return in.Description != nil && *in.Description != repo.Description
+	return (in.Description != nil && *in.Description != repo.Description) ||
+		(in.Topics != nil && !slices.Equal(*in.Topics, repo.Topics)) ||
+		in.hasMetadataChanges(repo) ||
+		(in.ShowReadme != nil && *in.ShowReadme != repo.ShowReadme)
+}
+
+// hasMetadataChanges returns true if any of the repository metadata included in the search index is changed.
+func (in *UpdateInput) hasMetadataChanges(repo *types.Repository) bool {
+	return (in.Website.Set && in.website() != repo.Website) ||
+		(in.ContactID.Set && !equalInt64Ptr(in.ContactID.Value, repo.ContactID))
+}
+
+func (in *UpdateInput) website() string {
+	if in.Website.Value == nil {
+		return ""
+	}
+
+	return *in.Website.Value
+}
+
+// apply updates the repository with the values that are provided.
+func (in *UpdateInput) apply(repo *types.Repository) {
+	if in.Description != nil {
+		repo.Description = *in.Description
+	}
+
+	if in.Website.Set {
+		repo.Website = in.website()
+	}
+
+	if in.ContactID.Set {
+		repo.ContactID = nil
+		if in.ContactID.Value != nil {
+			contactID := *in.ContactID.Value
+			repo.ContactID = &contactID
+		}
+	}
+
+	if in.ShowReadme != nil {
+		repo.ShowReadme = *in.ShowReadme
+	}
+}
+
+func equalInt64Ptr(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
 }
 
 // Update updates a repository.
@@ -49,20 +110,38 @@ func (c *Controller) Update(ctx context.Context,
 		return nil, err
 	}
 
+	repo.Topics, err = c.repoTopicStore.List(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repo topics: %w", err)
+	}
+
 	repoClone := repo.Clone()
 
-	if !in.hasChanges(repo) {
-		return GetRepoOutput(ctx, c.publicAccess, repo)
+	if err = c.sanitizeUpdateInput(ctx, in); err != nil {
+		return nil, fmt.Errorf("failed to sanitize input: %w", err)
 	}
 
-	if err = c.sanitizeUpdateInput(in); err != nil {
-		return nil, fmt.Errorf("failed to sanitize input: %w", err)
+	if !in.hasChanges(repo) {
+		return c.getUpdateOutput(ctx, repo)
 	}
 
-	repo, err = c.repoStore.UpdateOptLock(ctx, repo, func(repo *types.Repository) error {
-		// update values only if provided
-		if in.Description != nil {
-			repo.Description = *in.Description
+	reindex := in.hasMetadataChanges(repo)
+
+	topics := repo.Topics
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		repo, err = c.repoStore.UpdateOptLock(ctx, repo, func(repo *types.Repository) error {
+			in.apply(repo)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if in.Topics != nil && !slices.Equal(*in.Topics, topics) {
+			topics = *in.Topics
+			if err = c.repoTopicStore.Set(ctx, repo.ID, topics); err != nil {
+				return fmt.Errorf("failed to set repo topics: %w", err)
+			}
 		}
 
 		return nil
@@ -71,6 +150,8 @@ func (c *Controller) Update(ctx context.Context,
 		return nil, err
 	}
 
+	repo.Topics = topics
+
 	err = c.auditService.Log(ctx,
 		session.Principal,
 		audit.NewResource(audit.ResourceTypeRepositorySettings, repo.Identifier),
@@ -83,20 +164,81 @@ func (c *Controller) Update(ctx context.Context,
 		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update repository operation: %s", err)
 	}
 
+	c.eventReporter.Updated(ctx, &repoevents.UpdatedPayload{
+		RepoID:      repo.ID,
+		PrincipalID: session.Principal.ID,
+	})
+
+	if reindex {
+		if err = c.indexer.Index(ctx, repo); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to index repo %d after metadata update", repo.ID)
+		}
+	}
+
+	return c.getUpdateOutput(ctx, repo)
+}
+
+func (c *Controller) getUpdateOutput(ctx context.Context, repo *types.Repository) (*RepositoryOutput, error) {
 	// backfill repo url
 	repo.GitURL = c.urlProvider.GenerateGITCloneURL(ctx, repo.Path)
 	repo.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(ctx, repo.Path)
 
-	return GetRepoOutput(ctx, c.publicAccess, repo)
+	repoOut, err := GetRepoOutput(ctx, c.publicAccess, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	c.backfillContact(ctx, repoOut)
+
+	return repoOut, nil
 }
 
-func (c *Controller) sanitizeUpdateInput(in *UpdateInput) error {
+func (c *Controller) sanitizeUpdateInput(ctx context.Context, in *UpdateInput) error {
 	if in.Description != nil {
 		*in.Description = strings.TrimSpace(*in.Description)
 		if err := check.Description(*in.Description); err != nil {
-			return err
+			return fieldError("description", err.Error())
+		}
+	}
+
+	if in.Website.Value != nil {
+		*in.Website.Value = strings.TrimSpace(*in.Website.Value)
+		if err := check.RepoWebsite(*in.Website.Value); err != nil {
+			return fieldError("website", err.Error())
+		}
+	}
+
+	if in.ContactID.Value != nil {
+		_, err := c.principalStore.Find(ctx, *in.ContactID.Value)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return fieldError("contact_id", "Contact principal not found.")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to find contact principal: %w", err)
+		}
+	}
+
+	if in.Topics != nil {
+		topics := make([]string, 0, len(*in.Topics))
+		for _, topic := range *in.Topics {
+			topic = strings.ToLower(strings.TrimSpace(topic))
+			if !slices.Contains(topics, topic) {
+				topics = append(topics, topic)
+			}
+		}
+
+		slices.Sort(topics)
+		*in.Topics = topics
+
+		if err := check.RepoTopics(topics); err != nil {
+			return fieldError("topics", err.Error())
 		}
 	}
 
 	return nil
 }
+
+// fieldError returns a bad request error with the name of the invalid input field in the payload.
+func fieldError(field string, message string) error {
+	return usererror.BadRequestWithPayload(message, map[string]any{"field": field})
+}
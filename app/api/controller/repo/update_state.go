@@ -0,0 +1,99 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+type UpdateStateInput struct {
+	State string `json:"state"`
+	// ExpectedUntil is the time the repository is expected to leave the state (0 if unknown).
+	ExpectedUntil int64 `json:"expected_until"`
+}
+
+// manageableRepoStates contains the states that can be set via the API.
+// All other states are managed by the system.
+var manageableRepoStates = []enum.RepoState{
+	enum.RepoStateActive,
+	enum.RepoStateMaintenance,
+	enum.RepoStateLocked,
+	enum.RepoStateQuarantined,
+}
+
+func (in *UpdateStateInput) sanitize() (enum.RepoState, error) {
+	state, ok := enum.ParseRepoState(in.State)
+	if !ok {
+		return 0, usererror.BadRequestf("Unknown repository state %q.", in.State)
+	}
+
+	if !slices.Contains(manageableRepoStates, state) {
+		return 0, usererror.BadRequestf("Repository state %s can't be set manually.", state)
+	}
+
+	if in.ExpectedUntil < 0 || (in.ExpectedUntil > 0 && in.ExpectedUntil <= time.Now().UnixMilli()) {
+		return 0, usererror.BadRequest("Expected until has to be in the future.")
+	}
+
+	if state == enum.RepoStateActive && in.ExpectedUntil != 0 {
+		return 0, usererror.BadRequest("Expected until can't be set for active repositories.")
+	}
+
+	return state, nil
+}
+
+// UpdateState changes the state of the repository.
+// Only administrators can move repositories into or out of quarantine.
+func (c *Controller) UpdateState(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *UpdateStateInput,
+) (*RepositoryOutput, error) {
+	state, err := in.sanitize()
+	if err != nil {
+		return nil, err
+	}
+
+	repo, err := GetRepo(ctx, c.repoStore, repoRef, manageableRepoStates)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoEdit); err != nil {
+		return nil, fmt.Errorf("access check failed: %w", err)
+	}
+
+	if (repo.State == enum.RepoStateQuarantined || state == enum.RepoStateQuarantined) && !session.Principal.Admin {
+		return nil, apiauth.ErrNotAuthorized
+	}
+
+	if err = c.repoStateSvc.Transition(ctx, &session.Principal, repo, state, in.ExpectedUntil); err != nil {
+		return nil, err
+	}
+
+	repo.GitURL = c.urlProvider.GenerateGITCloneURL(ctx, repo.Path)
+	repo.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(ctx, repo.Path)
+
+	return GetRepoOutput(ctx, c.publicAccess, repo)
+}
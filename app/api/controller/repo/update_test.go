@@ -0,0 +1,169 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+)
+
+func TestUpdateInput_apply(t *testing.T) {
+	contactID := int64(7)
+	otherContactID := int64(8)
+	existing := types.Repository{
+		Description: "desc",
+		Website:     "https://example.com",
+		ContactID:   &contactID,
+		ShowReadme:  true,
+	}
+
+	tests := []struct {
+		name        string
+		data        string
+		wantChanges bool
+		want        types.Repository
+	}{
+		{
+			name:        "website absent",
+			data:        `{}`,
+			wantChanges: false,
+			want:        existing,
+		},
+		{
+			name:        "website null",
+			data:        `{"website":null}`,
+			wantChanges: true,
+			want:        types.Repository{Description: "desc", ContactID: &contactID, ShowReadme: true},
+		},
+		{
+			name:        "website value",
+			data:        `{"website":"https://example.org"}`,
+			wantChanges: true,
+			want: types.Repository{Description: "desc", Website: "https://example.org",
+				ContactID: &contactID, ShowReadme: true},
+		},
+		{
+			name:        "contact absent",
+			data:        `{"description":"desc"}`,
+			wantChanges: false,
+			want:        existing,
+		},
+		{
+			name:        "contact null",
+			data:        `{"contact_id":null}`,
+			wantChanges: true,
+			want:        types.Repository{Description: "desc", Website: "https://example.com", ShowReadme: true},
+		},
+		{
+			name:        "contact value",
+			data:        `{"contact_id":8}`,
+			wantChanges: true,
+			want: types.Repository{Description: "desc", Website: "https://example.com",
+				ContactID: &otherContactID, ShowReadme: true},
+		},
+		{
+			name:        "show readme absent",
+			data:        `{"website":"https://example.com"}`,
+			wantChanges: false,
+			want:        existing,
+		},
+		{
+			name:        "show readme unchanged",
+			data:        `{"show_readme":true}`,
+			wantChanges: false,
+			want:        existing,
+		},
+		{
+			name:        "show readme value",
+			data:        `{"show_readme":false}`,
+			wantChanges: true,
+			want: types.Repository{Description: "desc", Website: "https://example.com",
+				ContactID: &contactID, ShowReadme: false},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := &UpdateInput{}
+			if err := json.Unmarshal([]byte(test.data), in); err != nil {
+				t.Fatalf("failed to unmarshal input: %s", err)
+			}
+
+			repo := existing.Clone()
+
+			if got := in.hasChanges(&repo); got != test.wantChanges {
+				t.Errorf("hasChanges: want=%t got=%t", test.wantChanges, got)
+			}
+
+			in.apply(&repo)
+
+			if repo.Description != test.want.Description ||
+				repo.Website != test.want.Website ||
+				!equalInt64Ptr(repo.ContactID, test.want.ContactID) ||
+				repo.ShowReadme != test.want.ShowReadme {
+				t.Errorf("want=%+v got=%+v", test.want, repo)
+			}
+		})
+	}
+}
+
+func TestController_sanitizeUpdateInput(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		wantField string
+	}{
+		{name: "website absent", data: `{}`},
+		{name: "website null", data: `{"website":null}`},
+		{name: "website empty", data: `{"website":""}`},
+		{name: "website valid", data: `{"website":" https://example.com/docs "}`},
+		{name: "website no scheme", data: `{"website":"example.com"}`, wantField: "website"},
+		{name: "website invalid scheme", data: `{"website":"ftp://example.com"}`, wantField: "website"},
+		{name: "description too long", data: `{"description":"` + strings.Repeat("a", 2000) + `"}`,
+			wantField: "description"},
+	}
+
+	c := &Controller{}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := &UpdateInput{}
+			if err := json.Unmarshal([]byte(test.data), in); err != nil {
+				t.Fatalf("failed to unmarshal input: %s", err)
+			}
+
+			err := c.sanitizeUpdateInput(context.Background(), in)
+			if test.wantField == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+
+			var uErr *usererror.Error
+			if !errors.As(err, &uErr) {
+				t.Fatalf("expected a user error, got %v", err)
+			}
+			if uErr.Values["field"] != test.wantField {
+				t.Errorf("field: want=%s got=%v", test.wantField, uErr.Values["field"])
+			}
+		})
+	}
+}
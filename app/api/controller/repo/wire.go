@@ -18,14 +18,23 @@ import (
 	"github.com/harness/gitness/app/api/controller/limiter"
 	"github.com/harness/gitness/app/auth/authz"
 	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/accesslog"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/contributorstats"
+	"github.com/harness/gitness/app/services/gitlimit"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/label"
 	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/maintenance"
+	"github.com/harness/gitness/app/services/operation"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/app/services/recentvisit"
+	"github.com/harness/gitness/app/services/repodocs"
+	"github.com/harness/gitness/app/services/repostate"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -63,6 +72,7 @@ func ProvideController(
 	reporeporter *repoevents.Reporter,
 	indexer keywordsearch.Indexer,
 	limiter limiter.ResourceLimiter,
+	quotaWarner *limiter.QuotaWarner,
 	locker *locker.Locker,
 	auditService audit.Service,
 	mtxManager lock.MutexManager,
@@ -71,13 +81,31 @@ func ProvideController(
 	publicAccess publicaccess.Service,
 	labelSvc *label.Service,
 	instrumentation instrument.Service,
+	repoActivityStore store.RepoActivityStore,
+	deployKeyStore store.DeployKeyStore,
+	publicKeyService publickey.Service,
+	repoStateSvc *repostate.Service,
+	pullReqStore store.PullReqStore,
+	repoStatsStore store.RepoStatsStore,
+	contributorStats *contributorstats.Service,
+	recentVisits *recentvisit.Service,
+	repoStarStore store.RepoStarStore,
+	repoDocs *repodocs.Service,
+	repoTopicStore store.RepoTopicStore,
+	operationSvc *operation.Service,
+	maintenanceSvc *maintenance.Service,
+	gitLimit *gitlimit.Service,
+	repoAccessLogStore store.RepoAccessLogStore,
+	accessLog *accesslog.Service,
 ) *Controller {
 	return NewController(config, tx, urlProvider,
 		authorizer,
 		repoStore, spaceStore, pipelineStore,
 		principalStore, ruleStore, settings, principalInfoCache, protectionManager, rpcClient, importer,
-		codeOwners, reporeporter, indexer, limiter, locker, auditService, mtxManager, identifierCheck,
-		repoChecks, publicAccess, labelSvc, instrumentation)
+		codeOwners, reporeporter, indexer, limiter, quotaWarner, locker, auditService, mtxManager, identifierCheck,
+		repoChecks, publicAccess, labelSvc, instrumentation, repoActivityStore, deployKeyStore, publicKeyService,
+		repoStateSvc, pullReqStore, repoStatsStore, contributorStats, recentVisits, repoStarStore,
+		repoDocs, repoTopicStore, operationSvc, maintenanceSvc, gitLimit, repoAccessLogStore, accessLog)
 }
 
 func ProvideRepoCheck() Check {
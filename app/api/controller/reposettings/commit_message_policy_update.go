@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reposettings
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/services/commitpolicy"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// CommitMessagePolicyUpdate replaces the commit message policy of the repo.
+// An empty policy makes the repo inherit the commit message policy of its space.
+func (c *Controller) CommitMessagePolicyUpdate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *types.CommitMessagePolicy,
+) (*types.CommitMessagePolicyOutput, error) {
+	if err := commitpolicy.Sanitize(in); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	old, err := c.commitPolicy.RepoFind(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.commitPolicy.RepoSet(ctx, repo.ID, *in); err != nil {
+		return nil, err
+	}
+
+	out, err := c.commitPolicy.RepoFind(ctx, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find updated commit message policy: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeRepositorySettings, repo.Identifier),
+		audit.ActionUpdated,
+		paths.Parent(repo.Path),
+		audit.WithOldObject(old),
+		audit.WithNewObject(out),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for update commit message policy operation: %s", err)
+	}
+
+	return out, nil
+}
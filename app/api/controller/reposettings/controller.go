@@ -20,6 +20,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/commitpolicy"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/audit"
@@ -31,6 +32,7 @@ type Controller struct {
 	authorizer   authz.Authorizer
 	repoStore    store.RepoStore
 	settings     *settings.Service
+	commitPolicy *commitpolicy.Service
 	auditService audit.Service
 }
 
@@ -38,12 +40,14 @@ func NewController(
 	authorizer authz.Authorizer,
 	repoStore store.RepoStore,
 	settings *settings.Service,
+	commitPolicy *commitpolicy.Service,
 	auditService audit.Service,
 ) *Controller {
 	return &Controller{
 		authorizer:   authorizer,
 		repoStore:    repoStore,
 		settings:     settings,
+		commitPolicy: commitPolicy,
 		auditService: auditService,
 	}
 }
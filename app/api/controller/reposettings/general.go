@@ -15,6 +15,8 @@
 package reposettings
 
 import (
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/mergemessage"
 	"github.com/harness/gitness/app/services/settings"
 
 	"github.com/gotidy/ptr"
@@ -23,22 +25,39 @@ import (
 // GeneralSettings represent the general repository settings as exposed externally.
 type GeneralSettings struct {
 	FileSizeLimit *int64 `json:"file_size_limit" yaml:"file_size_limit"`
+	// DefaultBranchAutoDetect switches the default branch of an empty repository to the first pushed branch,
+	// in case the configured default branch isn't part of the first push.
+	DefaultBranchAutoDetect *bool `json:"default_branch_auto_detect" yaml:"default_branch_auto_detect"`
+	// MergeCommitTemplate and SquashCommitTemplate are the templates of the commit messages
+	// of merged pull requests, used in case no message is provided when merging.
+	MergeCommitTemplate  *string `json:"merge_commit_template" yaml:"merge_commit_template"`
+	SquashCommitTemplate *string `json:"squash_commit_template" yaml:"squash_commit_template"`
+	// DeleteSourceBranchOnMerge deletes the source branch of a pull request after it's merged.
+	DeleteSourceBranchOnMerge *bool `json:"delete_source_branch_on_merge" yaml:"delete_source_branch_on_merge"`
 }
 
 func GetDefaultGeneralSettings() *GeneralSettings {
 	return &GeneralSettings{
-		FileSizeLimit: ptr.Int64(settings.DefaultFileSizeLimit),
+		FileSizeLimit:             ptr.Int64(settings.DefaultFileSizeLimit),
+		DefaultBranchAutoDetect:   ptr.Bool(settings.DefaultDefaultBranchAutoDetect),
+		MergeCommitTemplate:       ptr.String(settings.DefaultMergeCommitTemplate),
+		SquashCommitTemplate:      ptr.String(settings.DefaultSquashCommitTemplate),
+		DeleteSourceBranchOnMerge: ptr.Bool(settings.DefaultDeleteSourceBranchOnMerge),
 	}
 }
 
 func GetGeneralSettingsMappings(s *GeneralSettings) []settings.SettingHandler {
 	return []settings.SettingHandler{
 		settings.Mapping(settings.KeyFileSizeLimit, s.FileSizeLimit),
+		settings.Mapping(settings.KeyDefaultBranchAutoDetect, s.DefaultBranchAutoDetect),
+		settings.Mapping(settings.KeyMergeCommitTemplate, s.MergeCommitTemplate),
+		settings.Mapping(settings.KeySquashCommitTemplate, s.SquashCommitTemplate),
+		settings.Mapping(settings.KeyDeleteSourceBranchOnMerge, s.DeleteSourceBranchOnMerge),
 	}
 }
 
 func GetGeneralSettingsAsKeyValues(s *GeneralSettings) []settings.KeyValue {
-	kvs := make([]settings.KeyValue, 0, 1)
+	kvs := make([]settings.KeyValue, 0, 5)
 
 	if s.FileSizeLimit != nil {
 		kvs = append(kvs, settings.KeyValue{
@@ -46,5 +65,49 @@ func GetGeneralSettingsAsKeyValues(s *GeneralSettings) []settings.KeyValue {
 			Value: s.FileSizeLimit,
 		})
 	}
+
+	if s.DefaultBranchAutoDetect != nil {
+		kvs = append(kvs, settings.KeyValue{
+			Key:   settings.KeyDefaultBranchAutoDetect,
+			Value: s.DefaultBranchAutoDetect,
+		})
+	}
+
+	if s.MergeCommitTemplate != nil {
+		kvs = append(kvs, settings.KeyValue{
+			Key:   settings.KeyMergeCommitTemplate,
+			Value: s.MergeCommitTemplate,
+		})
+	}
+
+	if s.SquashCommitTemplate != nil {
+		kvs = append(kvs, settings.KeyValue{
+			Key:   settings.KeySquashCommitTemplate,
+			Value: s.SquashCommitTemplate,
+		})
+	}
+
+	if s.DeleteSourceBranchOnMerge != nil {
+		kvs = append(kvs, settings.KeyValue{
+			Key:   settings.KeyDeleteSourceBranchOnMerge,
+			Value: s.DeleteSourceBranchOnMerge,
+		})
+	}
 	return kvs
 }
+
+func (s *GeneralSettings) sanitize() error {
+	if s.MergeCommitTemplate != nil {
+		if _, err := mergemessage.Parse(*s.MergeCommitTemplate); err != nil {
+			return usererror.BadRequestf("Invalid merge commit template: %s.", err)
+		}
+	}
+
+	if s.SquashCommitTemplate != nil {
+		if _, err := mergemessage.Parse(*s.SquashCommitTemplate); err != nil {
+			return usererror.BadRequestf("Invalid squash commit template: %s.", err)
+		}
+	}
+
+	return nil
+}
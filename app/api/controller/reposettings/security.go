@@ -15,7 +15,12 @@
 package reposettings
 
 import (
+	"slices"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/services/settings"
+	gitapi "github.com/harness/gitness/git/api"
 
 	"github.com/gotidy/ptr"
 )
@@ -23,24 +28,63 @@ import (
 // SecuritySettings represents the security related part of repository settings as exposed externally.
 type SecuritySettings struct {
 	SecretScanningEnabled *bool `json:"secret_scanning_enabled" yaml:"secret_scanning_enabled"`
+	// HiddenRefs are reference prefixes (e.g. "refs/internal") that are hidden from git clients
+	// in addition to the ones configured for the instance. References in hidden namespaces
+	// can neither be fetched nor updated via git.
+	HiddenRefs *[]string `json:"hidden_refs" yaml:"hidden_refs"`
 }
 
 func GetDefaultSecuritySettings() *SecuritySettings {
 	return &SecuritySettings{
 		SecretScanningEnabled: ptr.Bool(settings.DefaultSecretScanningEnabled),
+		HiddenRefs:            ptr.Of(settings.DefaultHiddenRefs),
 	}
 }
 
 func GetSecuritySettingsMappings(s *SecuritySettings) []settings.SettingHandler {
 	return []settings.SettingHandler{
 		settings.Mapping(settings.KeySecretScanningEnabled, s.SecretScanningEnabled),
+		settings.Mapping(settings.KeyHiddenRefs, s.HiddenRefs),
 	}
 }
 
 func GetSecuritySettingsAsKeyValues(s *SecuritySettings) []settings.KeyValue {
-	kvs := make([]settings.KeyValue, 0, 1)
+	kvs := make([]settings.KeyValue, 0, 2)
 	if s.SecretScanningEnabled != nil {
 		kvs = append(kvs, settings.KeyValue{Key: settings.KeySecretScanningEnabled, Value: *s.SecretScanningEnabled})
 	}
+	if s.HiddenRefs != nil {
+		kvs = append(kvs, settings.KeyValue{Key: settings.KeyHiddenRefs, Value: *s.HiddenRefs})
+	}
 	return kvs
 }
+
+func (s *SecuritySettings) sanitize() error {
+	if s.HiddenRefs == nil {
+		return nil
+	}
+
+	hiddenRefs := make([]string, 0, len(*s.HiddenRefs))
+	for _, ref := range *s.HiddenRefs {
+		ref = gitapi.NormalizeHiddenRef(ref)
+
+		if !strings.HasPrefix(ref, "refs/") {
+			return usererror.BadRequestf("Hidden reference %q has to be a namespace below \"refs/\".", ref)
+		}
+
+		// branches and tags are always visible, hiding them would break clones and the repository itself.
+		for _, prefix := range []string{gitapi.BranchPrefix, gitapi.TagPrefix} {
+			if strings.HasPrefix(ref+"/", prefix) {
+				return usererror.BadRequestf("Branches and tags can't be hidden (%q).", ref)
+			}
+		}
+
+		if !slices.Contains(hiddenRefs, ref) {
+			hiddenRefs = append(hiddenRefs, ref)
+		}
+	}
+
+	s.HiddenRefs = &hiddenRefs
+
+	return nil
+}
@@ -33,6 +33,10 @@ func (c *Controller) SecurityUpdate(
 	repoRef string,
 	in *SecuritySettings,
 ) (*SecuritySettings, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
 	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
 	if err != nil {
 		return nil, err
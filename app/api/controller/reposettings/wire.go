@@ -16,6 +16,7 @@ package reposettings
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/commitpolicy"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/audit"
@@ -32,7 +33,8 @@ func ProvideController(
 	authorizer authz.Authorizer,
 	repoStore store.RepoStore,
 	settings *settings.Service,
+	commitPolicy *commitpolicy.Service,
 	auditService audit.Service,
 ) *Controller {
-	return NewController(authorizer, repoStore, settings, auditService)
+	return NewController(authorizer, repoStore, settings, commitPolicy, auditService)
 }
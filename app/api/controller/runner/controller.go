@@ -0,0 +1,152 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Controller manages the runners that execute pipeline stages.
+type Controller struct {
+	authorizer  authz.Authorizer
+	runnerStore store.RunnerStore
+	stageStore  store.StageStore
+	spaceStore  store.SpaceStore
+
+	registrationToken string
+	heartbeatTimeout  time.Duration
+}
+
+func NewController(
+	authorizer authz.Authorizer,
+	runnerStore store.RunnerStore,
+	stageStore store.StageStore,
+	spaceStore store.SpaceStore,
+	registrationToken string,
+	heartbeatTimeout time.Duration,
+) *Controller {
+	return &Controller{
+		authorizer:        authorizer,
+		runnerStore:       runnerStore,
+		stageStore:        stageStore,
+		spaceStore:        spaceStore,
+		registrationToken: registrationToken,
+		heartbeatTimeout:  heartbeatTimeout,
+	}
+}
+
+func (c *Controller) findRunner(ctx context.Context, identifier string) (*types.Runner, error) {
+	runner, err := c.runnerStore.FindByIdentifier(ctx, identifier)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, usererror.NotFoundf("Runner %q not found", identifier)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find runner: %w", err)
+	}
+
+	return runner, nil
+}
+
+// checkAccess checks the permission on the space of the runner,
+// runners that serve the whole instance are managed by admins.
+func (c *Controller) checkAccess(
+	ctx context.Context,
+	session *auth.Session,
+	runner *types.Runner,
+	permission enum.Permission,
+) error {
+	if runner.SpaceID == 0 {
+		if !session.Principal.Admin {
+			return usererror.ErrForbidden
+		}
+		return nil
+	}
+
+	space, err := c.spaceStore.Find(ctx, runner.SpaceID)
+	if err != nil {
+		return fmt.Errorf("failed to find space of runner: %w", err)
+	}
+
+	return apiauth.CheckSpace(ctx, c.authorizer, session, space, permission)
+}
+
+// assignedStages returns the incomplete stages grouped by the runner they are assigned to.
+func (c *Controller) assignedStages(ctx context.Context) (map[string][]*types.Stage, error) {
+	stages, err := c.stageStore.ListIncomplete(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list incomplete stages: %w", err)
+	}
+
+	assigned := map[string][]*types.Stage{}
+	for _, stage := range stages {
+		if stage.Machine != "" {
+			assigned[stage.Machine] = append(assigned[stage.Machine], stage)
+		}
+	}
+
+	return assigned, nil
+}
+
+// setStatus sets the online status and the number of running stages of the runners.
+func (c *Controller) setStatus(ctx context.Context, runners ...*types.Runner) error {
+	assigned, err := c.assignedStages(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UnixMilli()
+	for _, runner := range runners {
+		runner.Online = runner.IsOnline(now, c.heartbeatTimeout)
+		runner.Running = len(assigned[runner.Identifier])
+	}
+
+	return nil
+}
+
+const runnerTokenLength = 32
+
+// generateToken generates the token a runner authenticates its heartbeats with.
+// Only the returned hash of the token is supposed to be stored.
+func generateToken() (string, string, error) {
+	tokenBytes := make([]byte, runnerTokenLength)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", "", fmt.Errorf("failed to read random bytes: %w", err)
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(tokenBytes)
+
+	return token, hashToken(token), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Delete deletes the runner. A runner that has stages in flight is drained first:
+// it doesn't receive new stages and is removed once its stages completed.
+// It returns true if the runner was deleted right away.
+func (c *Controller) Delete(ctx context.Context, session *auth.Session, identifier string) (bool, error) {
+	runner, err := c.findRunner(ctx, identifier)
+	if err != nil {
+		return false, err
+	}
+
+	if err = c.checkAccess(ctx, session, runner, enum.PermissionSpaceEdit); err != nil {
+		return false, err
+	}
+
+	if runner.Embedded {
+		return false, usererror.BadRequest("The runner embedded in the server can't be deleted.")
+	}
+
+	assigned, err := c.assignedStages(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if len(assigned[runner.Identifier]) == 0 {
+		err = c.runnerStore.Delete(ctx, runner.ID)
+		if err != nil {
+			return false, fmt.Errorf("failed to delete runner: %w", err)
+		}
+		return true, nil
+	}
+
+	if runner.Draining {
+		return false, nil
+	}
+
+	runner.Draining = true
+
+	err = c.runnerStore.Update(ctx, runner)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark runner as draining: %w", err)
+	}
+
+	return false, nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+)
+
+type HeartbeatInput struct {
+	// Token is the token the runner received when it registered.
+	Token string `json:"token"`
+}
+
+// Heartbeat records that the runner is online. The returned runner tells whether
+// it's draining, in which case it isn't assigned new stages anymore.
+func (c *Controller) Heartbeat(ctx context.Context, identifier string, in *HeartbeatInput) (*types.Runner, error) {
+	runner, err := c.runnerStore.FindByIdentifier(ctx, identifier)
+	if err != nil {
+		// don't reveal which runners exist.
+		return nil, usererror.ErrUnauthorized
+	}
+
+	if runner.TokenHash == "" ||
+		subtle.ConstantTimeCompare([]byte(hashToken(in.Token)), []byte(runner.TokenHash)) != 1 {
+		return nil, usererror.ErrUnauthorized
+	}
+
+	runner.LastHeartbeat = time.Now().UnixMilli()
+
+	err = c.runnerStore.UpdateHeartbeat(ctx, runner.ID, runner.LastHeartbeat)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update runner heartbeat: %w", err)
+	}
+
+	if err = c.setStatus(ctx, runner); err != nil {
+		return nil, err
+	}
+
+	return runner, nil
+}
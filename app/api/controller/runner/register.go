@@ -0,0 +1,176 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+)
+
+var (
+	errRegistrationDisabled = usererror.Forbidden("Runner registration is disabled.")
+	errInvalidCapacity      = usererror.BadRequest("Runner capacity can't be negative.")
+	errEmptyLabelKey        = usererror.BadRequest("Runner label keys can't be empty.")
+)
+
+type RegisterInput struct {
+	// Token is the registration token configured on the server.
+	Token       string `json:"token"`
+	Identifier  string `json:"identifier"`
+	Description string `json:"description"`
+	// SpaceRef is the space the runner executes stages for, empty to serve the whole instance.
+	SpaceRef string            `json:"space_ref"`
+	Labels   map[string]string `json:"labels"`
+	Capacity int               `json:"capacity"`
+	OS       string            `json:"os"`
+	Arch     string            `json:"arch"`
+}
+
+// RegisterOutput is the registered runner with the token it has to send its heartbeats with.
+type RegisterOutput struct {
+	types.Runner
+	Token string `json:"token"`
+}
+
+func (in *RegisterInput) sanitize() error {
+	in.Identifier = strings.TrimSpace(in.Identifier)
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+
+	in.Description = strings.TrimSpace(in.Description)
+	if err := check.Description(in.Description); err != nil {
+		return err
+	}
+
+	if in.Capacity < 0 {
+		return errInvalidCapacity
+	}
+	if in.Capacity == 0 {
+		in.Capacity = 1
+	}
+
+	for k := range in.Labels {
+		if strings.TrimSpace(k) == "" {
+			return errEmptyLabelKey
+		}
+	}
+
+	return nil
+}
+
+// Register registers a runner, or updates the registration of a runner with the same identifier.
+// The runner authenticates with the registration token and receives a new token for its heartbeats.
+func (c *Controller) Register(ctx context.Context, in *RegisterInput) (*RegisterOutput, error) {
+	if c.registrationToken == "" {
+		return nil, errRegistrationDisabled
+	}
+	if subtle.ConstantTimeCompare([]byte(in.Token), []byte(c.registrationToken)) != 1 {
+		return nil, usererror.ErrUnauthorized
+	}
+
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	var spaceID int64
+	if in.SpaceRef != "" {
+		space, err := c.spaceStore.FindByRef(ctx, in.SpaceRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space: %w", err)
+		}
+		spaceID = space.ID
+	}
+
+	token, tokenHash, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+
+	runner, err := c.runnerStore.FindByIdentifier(ctx, in.Identifier)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		runner = &types.Runner{
+			SpaceID:       spaceID,
+			Identifier:    in.Identifier,
+			Description:   in.Description,
+			Labels:        in.Labels,
+			Capacity:      in.Capacity,
+			OS:            in.OS,
+			Arch:          in.Arch,
+			TokenHash:     tokenHash,
+			LastHeartbeat: now,
+			Created:       now,
+			Updated:       now,
+		}
+
+		err = c.runnerStore.Create(ctx, runner)
+		if errors.Is(err, gitness_store.ErrDuplicate) {
+			return nil, usererror.Conflict("A runner with the same identifier is being registered.")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to create runner: %w", err)
+		}
+
+		return c.registered(ctx, runner, token)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find runner: %w", err)
+	}
+
+	if runner.Embedded {
+		return nil, usererror.Conflict("The identifier is used by the runner embedded in the server.")
+	}
+	if runner.SpaceID != spaceID {
+		return nil, usererror.Conflict("A runner with the same identifier is registered for a different space.")
+	}
+
+	// a restarted runner registers again, it keeps draining if it was deleted in the meantime.
+	runner.Description = in.Description
+	runner.Labels = in.Labels
+	runner.Capacity = in.Capacity
+	runner.OS = in.OS
+	runner.Arch = in.Arch
+	runner.TokenHash = tokenHash
+	runner.LastHeartbeat = now
+
+	err = c.runnerStore.Update(ctx, runner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update runner: %w", err)
+	}
+
+	return c.registered(ctx, runner, token)
+}
+
+func (c *Controller) registered(ctx context.Context, runner *types.Runner, token string) (*RegisterOutput, error) {
+	if err := c.setStatus(ctx, runner); err != nil {
+		return nil, err
+	}
+
+	return &RegisterOutput{
+		Runner: *runner,
+		Token:  token,
+	}, nil
+}
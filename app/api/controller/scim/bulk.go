@@ -0,0 +1,130 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	maxBulkOperations = 100
+	usersPath         = "/Users"
+)
+
+// Bulk executes many user operations at once, which allows identity providers to sync all users
+// with a few requests. Every operation is executed on its own, a failed operation doesn't roll back
+// the previous ones.
+func (c *Controller) Bulk(ctx context.Context, session *auth.Session, in *BulkInput) (*BulkOutput, error) {
+	if err := c.checkAccess(ctx, session, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	if len(in.Operations) > maxBulkOperations {
+		return nil, usererror.RequestTooLargef("A bulk request can contain at most %d operations", maxBulkOperations)
+	}
+
+	out := &BulkOutput{
+		Schemas:    []string{SchemaBulkResponse},
+		Operations: make([]BulkOperationResult, 0, len(in.Operations)),
+	}
+
+	failed := 0
+	for _, op := range in.Operations {
+		if in.FailOnErrors > 0 && failed >= in.FailOnErrors {
+			break
+		}
+
+		result := c.bulkOperation(ctx, session, op)
+		if status, _ := strconv.Atoi(result.Status); status >= http.StatusBadRequest {
+			failed++
+		}
+
+		out.Operations = append(out.Operations, result)
+	}
+
+	return out, nil
+}
+
+func (c *Controller) bulkOperation(ctx context.Context, session *auth.Session, op BulkOperation) BulkOperationResult {
+	result := BulkOperationResult{
+		Method: op.Method,
+		BulkID: op.BulkID,
+	}
+
+	status, user, err := c.executeBulkOperation(ctx, session, op)
+	if err != nil {
+		uErr := usererror.Translate(ctx, err)
+		result.Status = strconv.Itoa(uErr.Status)
+		result.Response = uErr
+
+		return result
+	}
+
+	result.Status = strconv.Itoa(status)
+	if user != nil {
+		result.Location = usersPath + "/" + user.ID
+	}
+
+	return result
+}
+
+func (c *Controller) executeBulkOperation(ctx context.Context, session *auth.Session,
+	op BulkOperation) (int, *User, error) {
+	id, hasID := strings.CutPrefix(op.Path, usersPath+"/")
+	if (!hasID && op.Path != usersPath) || (hasID && (id == "" || strings.Contains(id, "/"))) {
+		return 0, nil, usererror.BadRequestf("Unsupported bulk operation path %q", op.Path)
+	}
+
+	method := strings.ToUpper(op.Method)
+	switch {
+	case method == http.MethodPost && !hasID:
+		in := &User{}
+		if err := json.Unmarshal(op.Data, in); err != nil {
+			return 0, nil, usererror.BadRequest("Invalid user in bulk operation data")
+		}
+		user, err := c.Create(ctx, session, in)
+		return http.StatusCreated, user, err
+
+	case method == http.MethodPut && hasID:
+		in := &User{}
+		if err := json.Unmarshal(op.Data, in); err != nil {
+			return 0, nil, usererror.BadRequest("Invalid user in bulk operation data")
+		}
+		user, err := c.Replace(ctx, session, id, in)
+		return http.StatusOK, user, err
+
+	case method == http.MethodPatch && hasID:
+		in := &PatchInput{}
+		if err := json.Unmarshal(op.Data, in); err != nil {
+			return 0, nil, usererror.BadRequest("Invalid patch in bulk operation data")
+		}
+		user, err := c.Patch(ctx, session, id, in)
+		return http.StatusOK, user, err
+
+	case method == http.MethodDelete && hasID:
+		return http.StatusNoContent, nil, c.Deactivate(ctx, session, id)
+
+	default:
+		return 0, nil, usererror.BadRequestf("Unsupported bulk operation %s %s", op.Method, op.Path)
+	}
+}
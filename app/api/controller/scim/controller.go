@@ -0,0 +1,107 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Controller implements a SCIM-like user provisioning API that allows identity providers
+// to keep the users of the system in sync.
+type Controller struct {
+	principalUIDCheck check.PrincipalUID
+	authorizer        authz.Authorizer
+	principalStore    store.PrincipalStore
+}
+
+func NewController(
+	principalUIDCheck check.PrincipalUID,
+	authorizer authz.Authorizer,
+	principalStore store.PrincipalStore,
+) *Controller {
+	return &Controller{
+		principalUIDCheck: principalUIDCheck,
+		authorizer:        authorizer,
+		principalStore:    principalStore,
+	}
+}
+
+func (c *Controller) checkAccess(ctx context.Context, session *auth.Session, permission enum.Permission) error {
+	// users are global, there's no explicit resource.
+	scope := &types.Scope{}
+	resource := &types.Resource{
+		Type: enum.ResourceTypeUser,
+	}
+
+	return apiauth.Check(ctx, c.authorizer, session, scope, resource, permission)
+}
+
+func (c *Controller) findUser(ctx context.Context, id string) (*types.User, error) {
+	user, err := c.principalStore.FindUserByUID(ctx, id)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, usererror.NotFoundf("User %q not found", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	return user, nil
+}
+
+// checkUserNameAvailable returns a conflict error if any principal already uses the uid.
+func (c *Controller) checkUserNameAvailable(ctx context.Context, uid string) error {
+	_, err := c.principalStore.FindByUID(ctx, uid)
+	if err == nil {
+		return errConflict(attributeUserName, "A user with the same userName already exists")
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to find principal by uid: %w", err)
+	}
+
+	return nil
+}
+
+// checkEmailAvailable returns a conflict error if a principal other than the one with the provided id
+// already uses the email.
+func (c *Controller) checkEmailAvailable(ctx context.Context, email string, principalID int64) error {
+	existing, err := c.principalStore.FindByEmail(ctx, email)
+	if err == nil && existing.ID != principalID {
+		return errConflict(attributeEmails, "A user with the same email already exists")
+	}
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to find principal by email: %w", err)
+	}
+
+	return nil
+}
+
+func errConflict(attribute string, message string) error {
+	return usererror.ConflictWithPayload(message, map[string]any{
+		"scim_type": scimTypeUniqueness,
+		"attribute": attribute,
+	})
+}
@@ -0,0 +1,291 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+// TestSyncCycle simulates an identity provider that creates, updates, deactivates
+// and re-activates users over several sync runs.
+func TestSyncCycle(t *testing.T) {
+	ctx := context.Background()
+	principals := newFakePrincipalStore()
+	admin := &types.User{UID: "admin", Email: "admin@example.com", DisplayName: "Admin", Admin: true}
+	principals.add(admin)
+	session := &auth.Session{Principal: *admin.ToPrincipal()}
+
+	// memberships reference users by id, they must survive a deactivation.
+	memberships := map[int64][]string{}
+
+	ctrl := NewController(check.PrincipalUIDDefault, allowAll{}, principals)
+
+	// initial sync: provision all users in bulk.
+	out, err := ctrl.Bulk(ctx, session, &BulkInput{Operations: []BulkOperation{
+		bulkOp(http.MethodPost, "/Users", "1", `{"userName":"jane","displayName":"Jane Doe",
+			"emails":[{"value":"jane@example.com","primary":true}]}`),
+		bulkOp(http.MethodPost, "/Users", "2", `{"userName":"john","name":{"givenName":"John","familyName":"Doe"},
+			"emails":[{"value":"john@example.com"}]}`),
+		bulkOp(http.MethodPost, "/Users", "3", `{"userName":"jack","emails":[{"value":"JOHN@example.com"}]}`),
+	}})
+	if err != nil {
+		t.Fatalf("bulk create failed: %s", err)
+	}
+	expectStatuses(t, out, "201", "201", "409")
+	expectConflict(t, out.Operations[2].Response, attributeEmails)
+
+	jane := principals.byUID["jane"]
+	memberships[jane.ID] = []string{"space-a", "space-b"}
+
+	if john := principals.byUID["john"]; john.DisplayName != "John Doe" || john.Blocked {
+		t.Errorf("unexpected provisioned user %+v", john)
+	}
+
+	_, err = ctrl.Create(ctx, session, &User{UserName: "Jane", Emails: []Email{{Value: "jane2@example.com"}}})
+	expectConflict(t, err, attributeUserName)
+
+	// lookups by filter.
+	list, err := ctrl.List(ctx, session, `userName eq "jane"`, 1, 10)
+	if err != nil {
+		t.Fatalf("filter failed: %s", err)
+	}
+	if list.TotalResults != 1 || list.Resources[0].ID != "jane" {
+		t.Errorf("expected jane to be found, got %+v", list)
+	}
+
+	list, err = ctrl.List(ctx, session, `emails.value eq "nobody@example.com"`, 1, 10)
+	if err != nil || list.TotalResults != 0 || len(list.Resources) != 0 {
+		t.Errorf("expected no users to be found, got %+v, %v", list, err)
+	}
+
+	if _, err = ctrl.List(ctx, session, `userName sw "j"`, 1, 10); err == nil {
+		t.Errorf("expected an error for an unsupported filter")
+	}
+
+	// second sync: update attributes and deactivate a user, identity providers send strings for booleans.
+	out, err = ctrl.Bulk(ctx, session, &BulkInput{Operations: []BulkOperation{
+		bulkOp(http.MethodPatch, "/Users/jane", "", `{"Operations":[
+			{"op":"Replace","path":"displayName","value":"Jane Smith"},
+			{"op":"Replace","path":"emails[type eq \"work\"].value","value":"jane.smith@example.com"},
+			{"op":"Replace","path":"active","value":"False"}]}`),
+		bulkOp(http.MethodPut, "/Users/john", "", `{"userName":"john","displayName":"John Doe",
+			"emails":[{"value":"jane.smith@example.com"}]}`),
+		bulkOp(http.MethodPut, "/Users/john", "", `{"userName":"johnny","emails":[{"value":"john@example.com"}]}`),
+		bulkOp(http.MethodDelete, "/Users/unknown", "", ``),
+	}})
+	if err != nil {
+		t.Fatalf("bulk update failed: %s", err)
+	}
+	expectStatuses(t, out, "200", "409", "400", "404")
+	expectConflict(t, out.Operations[1].Response, attributeEmails)
+
+	if jane = principals.byUID["jane"]; !jane.Blocked || jane.DisplayName != "Jane Smith" ||
+		jane.Email != "jane.smith@example.com" {
+		t.Errorf("unexpected updated user %+v", jane)
+	}
+
+	list, err = ctrl.List(ctx, session, "", 1, 10)
+	if err != nil {
+		t.Fatalf("list failed: %s", err)
+	}
+	if list.TotalResults != 3 || list.ItemsPerPage != 3 || *list.Resources[1].Active {
+		t.Errorf("expected all users with jane inactive, got %+v", list)
+	}
+
+	// third sync: re-activate the user, it keeps its identity and memberships.
+	janeID := jane.ID
+	user, err := ctrl.Patch(ctx, session, "jane", &PatchInput{Operations: []PatchOperation{
+		{Op: "replace", Value: json.RawMessage(`{"active":true}`)},
+	}})
+	if err != nil {
+		t.Fatalf("re-activation failed: %s", err)
+	}
+	if !*user.Active || principals.byUID["jane"].ID != janeID || len(memberships[janeID]) != 2 {
+		t.Errorf("expected jane to be re-activated with the same identity, got %+v", user)
+	}
+
+	// admins can't deactivate themselves.
+	if err = ctrl.Deactivate(ctx, session, "admin"); err == nil {
+		t.Errorf("expected the deactivation of the acting admin to fail")
+	}
+}
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		filter    string
+		attribute string
+		value     string
+		wantErr   bool
+	}{
+		{filter: `userName eq "jane"`, attribute: "userName", value: "jane"},
+		{filter: `  emails.value EQ "a\"b@example.com" `, attribute: "emails.value", value: `a"b@example.com`},
+		{filter: `userName eq jane`, wantErr: true},
+		{filter: `userName eq "jane" and active eq true`, wantErr: true},
+		{filter: `userName co "jane"`, wantErr: true},
+	}
+
+	for _, test := range tests {
+		attribute, value, err := parseFilter(test.filter)
+		if (err != nil) != test.wantErr {
+			t.Errorf("filter %q: got error %v, want error: %t", test.filter, err, test.wantErr)
+			continue
+		}
+		if attribute != test.attribute || value != test.value {
+			t.Errorf("filter %q: got (%q, %q), want (%q, %q)",
+				test.filter, attribute, value, test.attribute, test.value)
+		}
+	}
+}
+
+func bulkOp(method, path, bulkID, data string) BulkOperation {
+	op := BulkOperation{Method: method, Path: path, BulkID: bulkID}
+	if data != "" {
+		op.Data = json.RawMessage(data)
+	}
+	return op
+}
+
+func expectStatuses(t *testing.T, out *BulkOutput, statuses ...string) {
+	t.Helper()
+	if len(out.Operations) != len(statuses) {
+		t.Fatalf("got %d results, want %d", len(out.Operations), len(statuses))
+	}
+	for i, status := range statuses {
+		if out.Operations[i].Status != status {
+			t.Errorf("operation %d: got status %s, want %s (%+v)", i, out.Operations[i].Status, status,
+				out.Operations[i].Response)
+		}
+	}
+}
+
+func expectConflict(t *testing.T, v any, attribute string) {
+	t.Helper()
+	var uErr *usererror.Error
+	if err, ok := v.(error); !ok || !errors.As(err, &uErr) {
+		t.Fatalf("expected a user error, got %v", v)
+	}
+	if uErr.Status != http.StatusConflict || uErr.Values["attribute"] != attribute {
+		t.Errorf("expected a conflict on %q, got %d %v", attribute, uErr.Status, uErr.Values)
+	}
+}
+
+type allowAll struct {
+	authz.Authorizer
+}
+
+func (allowAll) Check(context.Context, *auth.Session, *types.Scope, *types.Resource, enum.Permission) (bool, error) {
+	return true, nil
+}
+
+type fakePrincipalStore struct {
+	store.PrincipalStore
+	byUID map[string]*types.User
+}
+
+func newFakePrincipalStore() *fakePrincipalStore {
+	return &fakePrincipalStore{byUID: map[string]*types.User{}}
+}
+
+func (s *fakePrincipalStore) add(user *types.User) {
+	user.ID = int64(len(s.byUID) + 1)
+	user.Created = user.ID
+	s.byUID[strings.ToLower(user.UID)] = user
+}
+
+func (s *fakePrincipalStore) find(match func(u *types.User) bool) (*types.User, error) {
+	for _, user := range s.byUID {
+		if match(user) {
+			clone := *user
+			return &clone, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *fakePrincipalStore) FindUserByUID(_ context.Context, uid string) (*types.User, error) {
+	return s.find(func(u *types.User) bool { return strings.EqualFold(u.UID, uid) })
+}
+
+func (s *fakePrincipalStore) FindUserByEmail(_ context.Context, email string) (*types.User, error) {
+	return s.find(func(u *types.User) bool { return strings.EqualFold(u.Email, email) })
+}
+
+func (s *fakePrincipalStore) FindByUID(ctx context.Context, uid string) (*types.Principal, error) {
+	user, err := s.FindUserByUID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	return user.ToPrincipal(), nil
+}
+
+func (s *fakePrincipalStore) FindByEmail(ctx context.Context, email string) (*types.Principal, error) {
+	user, err := s.FindUserByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	return user.ToPrincipal(), nil
+}
+
+func (s *fakePrincipalStore) CreateUser(_ context.Context, user *types.User) error {
+	clone := *user
+	s.add(&clone)
+	user.ID = clone.ID
+	return nil
+}
+
+func (s *fakePrincipalStore) UpdateUser(_ context.Context, user *types.User) error {
+	clone := *user
+	s.byUID[strings.ToLower(user.UID)] = &clone
+	return nil
+}
+
+func (s *fakePrincipalStore) CountUsers(_ context.Context, filter *types.UserFilter) (int64, error) {
+	users, _ := s.ListUsers(context.Background(), &types.UserFilter{Admin: filter.Admin, Blocked: filter.Blocked})
+	return int64(len(users)), nil
+}
+
+func (s *fakePrincipalStore) ListUsers(_ context.Context, filter *types.UserFilter) ([]*types.User, error) {
+	users := make([]*types.User, 0, len(s.byUID))
+	for _, user := range s.byUID {
+		if (filter.Admin && !user.Admin) || (filter.Blocked != nil && user.Blocked != *filter.Blocked) {
+			continue
+		}
+		clone := *user
+		users = append(users, &clone)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+
+	if filter.Size > 0 {
+		start := min((filter.Page-1)*filter.Size, len(users))
+		users = users[start:min(start+filter.Size, len(users))]
+	}
+
+	return users, nil
+}
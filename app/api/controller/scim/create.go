@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/dchest/uniuri"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var hashPassword = bcrypt.GenerateFromPassword
+
+// Create provisions a new user.
+// Provisioned users get a random password, they are expected to log in via single sign-on or reset it.
+func (c *Controller) Create(ctx context.Context, session *auth.Session, in *User) (*User, error) {
+	if err := c.checkAccess(ctx, session, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	uid, email, displayName, err := c.sanitizeCreateInput(in)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.checkUserNameAvailable(ctx, uid); err != nil {
+		return nil, err
+	}
+	if err = c.checkEmailAvailable(ctx, email, 0); err != nil {
+		return nil, err
+	}
+
+	password, err := randomPassword()
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := hashPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create hash: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	user := &types.User{
+		UID:         uid,
+		DisplayName: displayName,
+		Email:       email,
+		Password:    string(hash),
+		Salt:        uniuri.NewLen(uniuri.UUIDLen),
+		Blocked:     in.Active != nil && !*in.Active,
+		Created:     now,
+		Updated:     now,
+	}
+
+	err = c.principalStore.CreateUser(ctx, user)
+	if errors.Is(err, store.ErrDuplicate) {
+		// lost a race against a concurrent request, we can't tell which attribute conflicted.
+		return nil, usererror.ConflictWithPayload("A user with the same userName or email already exists",
+			map[string]any{"scim_type": scimTypeUniqueness})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return toUser(user), nil
+}
+
+func (c *Controller) sanitizeCreateInput(in *User) (string, string, string, error) {
+	uid := strings.TrimSpace(in.UserName)
+	if err := c.principalUIDCheck(uid); err != nil {
+		return "", "", "", err
+	}
+
+	email := strings.TrimSpace(in.primaryEmail())
+	if err := check.Email(email); err != nil {
+		return "", "", "", err
+	}
+
+	displayName := strings.TrimSpace(in.displayName())
+	if displayName == "" {
+		displayName = uid
+	}
+	if err := check.DisplayName(displayName); err != nil {
+		return "", "", "", err
+	}
+
+	return uid, email, displayName, nil
+}
+
+func randomPassword() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random password: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// filterRegex matches the only supported kind of filter expression: `<attribute> eq "<value>"`.
+var filterRegex = regexp.MustCompile(`(?i)^\s*([a-z.]+)\s+eq\s+("(?:[^"\\]|\\.)*")\s*$`)
+
+// List lists the users of the system page by page.
+// If a filter is provided the matching user is returned instead (at most one, as both userName and email are unique).
+func (c *Controller) List(ctx context.Context, session *auth.Session,
+	filter string, page int, size int) (*ListResponse, error) {
+	if err := c.checkAccess(ctx, session, enum.PermissionUserView); err != nil {
+		return nil, err
+	}
+
+	if filter != "" {
+		return c.listFiltered(ctx, filter)
+	}
+
+	userFilter := &types.UserFilter{
+		Page:  page,
+		Size:  size,
+		Sort:  enum.UserAttrCreated,
+		Order: enum.OrderAsc,
+	}
+
+	count, err := c.principalStore.CountUsers(ctx, userFilter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	users, err := c.principalStore.ListUsers(ctx, userFilter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	resources := make([]*User, len(users))
+	for i, user := range users {
+		resources[i] = toUser(user)
+	}
+
+	return &ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: count,
+		StartIndex:   (page-1)*size + 1,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}, nil
+}
+
+func (c *Controller) listFiltered(ctx context.Context, filter string) (*ListResponse, error) {
+	attribute, value, err := parseFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var user *types.User
+	switch strings.ToLower(attribute) {
+	case "username":
+		user, err = c.principalStore.FindUserByUID(ctx, value)
+	case "emails", "emails.value":
+		user, err = c.principalStore.FindUserByEmail(ctx, value)
+	default:
+		return nil, errInvalidFilter(fmt.Sprintf("Filtering by attribute %q is not supported", attribute))
+	}
+	if err != nil && !errors.Is(err, store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	resources := []*User{}
+	if user != nil {
+		resources = append(resources, toUser(user))
+	}
+
+	return &ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: int64(len(resources)),
+		StartIndex:   1,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}, nil
+}
+
+// parseFilter parses a filter of the form `userName eq "jane"` and returns the attribute and the value.
+func parseFilter(filter string) (string, string, error) {
+	matches := filterRegex.FindStringSubmatch(filter)
+	if matches == nil {
+		return "", "", errInvalidFilter(`Only filters of the form 'attribute eq "value"' are supported`)
+	}
+
+	var value string
+	if err := json.Unmarshal([]byte(matches[2]), &value); err != nil {
+		return "", "", errInvalidFilter("Filter value is not a valid string")
+	}
+
+	return matches[1], value, nil
+}
+
+func errInvalidFilter(message string) error {
+	return usererror.BadRequestWithPayload(message, map[string]any{"scim_type": scimTypeInvalidFilter})
+}
@@ -0,0 +1,171 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/harness/gitness/types"
+)
+
+const (
+	SchemaUser         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaListResponse = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp      = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaBulkRequest  = "urn:ietf:params:scim:api:messages:2.0:BulkRequest"
+	SchemaBulkResponse = "urn:ietf:params:scim:api:messages:2.0:BulkResponse"
+
+	resourceTypeUser = "User"
+
+	attributeUserName = "userName"
+	attributeEmails   = "emails"
+
+	scimTypeUniqueness    = "uniqueness"
+	scimTypeInvalidFilter = "invalidFilter"
+	scimTypeInvalidPath   = "invalidPath"
+	scimTypeMutability    = "mutability"
+)
+
+type (
+	// User is the SCIM representation of a user.
+	// The id of the user is its UID, which is also used as the userName.
+	User struct {
+		Schemas     []string `json:"schemas"`
+		ID          string   `json:"id,omitempty"`
+		UserName    string   `json:"userName"`
+		DisplayName string   `json:"displayName,omitempty"`
+		Name        *Name    `json:"name,omitempty"`
+		Emails      []Email  `json:"emails,omitempty"`
+		// Active maps to the blocked flag of the user - inactive users are blocked.
+		Active *bool `json:"active,omitempty"`
+		Meta   *Meta `json:"meta,omitempty"`
+	}
+
+	Name struct {
+		Formatted  string `json:"formatted,omitempty"`
+		GivenName  string `json:"givenName,omitempty"`
+		FamilyName string `json:"familyName,omitempty"`
+	}
+
+	Email struct {
+		Value   string `json:"value"`
+		Type    string `json:"type,omitempty"`
+		Primary bool   `json:"primary,omitempty"`
+	}
+
+	Meta struct {
+		ResourceType string `json:"resourceType"`
+		Created      string `json:"created"`
+		LastModified string `json:"lastModified"`
+	}
+
+	// ListResponse is the paginated result of a user list or filter request.
+	ListResponse struct {
+		Schemas      []string `json:"schemas"`
+		TotalResults int64    `json:"totalResults"`
+		StartIndex   int      `json:"startIndex"`
+		ItemsPerPage int      `json:"itemsPerPage"`
+		Resources    []*User  `json:"Resources"`
+	}
+
+	// PatchInput is a SCIM PatchOp request.
+	PatchInput struct {
+		Schemas    []string         `json:"schemas"`
+		Operations []PatchOperation `json:"Operations"`
+	}
+
+	PatchOperation struct {
+		Op    string          `json:"op"`
+		Path  string          `json:"path,omitempty"`
+		Value json.RawMessage `json:"value,omitempty"`
+	}
+
+	// BulkInput is a SCIM bulk request that creates, updates or deactivates many users at once.
+	BulkInput struct {
+		Schemas []string `json:"schemas"`
+		// FailOnErrors is the number of failed operations after which the remaining operations are skipped.
+		FailOnErrors int             `json:"failOnErrors,omitempty"`
+		Operations   []BulkOperation `json:"Operations"`
+	}
+
+	BulkOperation struct {
+		Method string          `json:"method"`
+		BulkID string          `json:"bulkId,omitempty"`
+		Path   string          `json:"path"`
+		Data   json.RawMessage `json:"data,omitempty"`
+	}
+
+	BulkOutput struct {
+		Schemas    []string              `json:"schemas"`
+		Operations []BulkOperationResult `json:"Operations"`
+	}
+
+	BulkOperationResult struct {
+		Method   string `json:"method"`
+		BulkID   string `json:"bulkId,omitempty"`
+		Location string `json:"location,omitempty"`
+		Status   string `json:"status"`
+		Response any    `json:"response,omitempty"`
+	}
+)
+
+func toUser(user *types.User) *User {
+	active := !user.Blocked
+	return &User{
+		Schemas:     []string{SchemaUser},
+		ID:          user.UID,
+		UserName:    user.UID,
+		DisplayName: user.DisplayName,
+		Name:        &Name{Formatted: user.DisplayName},
+		Emails:      []Email{{Value: user.Email, Primary: true}},
+		Active:      &active,
+		Meta: &Meta{
+			ResourceType: resourceTypeUser,
+			Created:      time.UnixMilli(user.Created).UTC().Format(time.RFC3339),
+			LastModified: time.UnixMilli(user.Updated).UTC().Format(time.RFC3339),
+		},
+	}
+}
+
+// primaryEmail returns the primary email of the user, or the first one if none is marked as primary.
+func (u *User) primaryEmail() string {
+	for _, email := range u.Emails {
+		if email.Primary {
+			return email.Value
+		}
+	}
+	if len(u.Emails) > 0 {
+		return u.Emails[0].Value
+	}
+	return ""
+}
+
+// displayName returns the display name of the user, falling back to the name attributes.
+func (u *User) displayName() string {
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
+	if u.Name == nil {
+		return ""
+	}
+	if u.Name.Formatted != "" {
+		return u.Name.Formatted
+	}
+	if u.Name.GivenName != "" && u.Name.FamilyName != "" {
+		return u.Name.GivenName + " " + u.Name.FamilyName
+	}
+	return u.Name.GivenName + u.Name.FamilyName
+}
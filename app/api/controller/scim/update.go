@@ -0,0 +1,253 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+// userUpdate contains the changes to apply to a user, nil fields are left unchanged.
+type userUpdate struct {
+	userName    *string
+	displayName *string
+	email       *string
+	active      *bool
+}
+
+// Replace updates the user with the attributes of the provided resource.
+// Attributes that are missing in the resource are left unchanged.
+func (c *Controller) Replace(ctx context.Context, session *auth.Session, id string, in *User) (*User, error) {
+	upd := &userUpdate{
+		userName: &in.UserName,
+		active:   in.Active,
+	}
+	if displayName := in.displayName(); displayName != "" {
+		upd.displayName = &displayName
+	}
+	if email := in.primaryEmail(); email != "" {
+		upd.email = &email
+	}
+
+	return c.update(ctx, session, id, upd)
+}
+
+// Patch applies a SCIM PatchOp to the user.
+// Only add and replace operations are supported, attributes that can't be mapped to a user are ignored.
+func (c *Controller) Patch(ctx context.Context, session *auth.Session, id string, in *PatchInput) (*User, error) {
+	upd := &userUpdate{}
+	for _, op := range in.Operations {
+		switch strings.ToLower(op.Op) {
+		case "add", "replace":
+		case "remove":
+			return nil, usererror.BadRequestWithPayload("Removing user attributes is not supported",
+				map[string]any{"scim_type": scimTypeMutability})
+		default:
+			return nil, usererror.BadRequestf("Unknown patch operation %q", op.Op)
+		}
+
+		if err := upd.apply(op.Path, op.Value); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.update(ctx, session, id, upd)
+}
+
+// Deactivate blocks the user. The user isn't deleted, so memberships and tokens are kept
+// and become usable again once the user is re-activated.
+func (c *Controller) Deactivate(ctx context.Context, session *auth.Session, id string) error {
+	active := false
+	_, err := c.update(ctx, session, id, &userUpdate{active: &active})
+	return err
+}
+
+func (c *Controller) update(ctx context.Context, session *auth.Session, id string, upd *userUpdate) (*User, error) {
+	if err := c.checkAccess(ctx, session, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	user, err := c.findUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	changed, err := c.applyUpdate(ctx, session, user, upd)
+	if err != nil {
+		return nil, err
+	}
+	if !changed {
+		return toUser(user), nil
+	}
+
+	user.Updated = time.Now().UnixMilli()
+
+	err = c.principalStore.UpdateUser(ctx, user)
+	if errors.Is(err, store.ErrDuplicate) {
+		return nil, errConflict(attributeEmails, "A user with the same email already exists")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	return toUser(user), nil
+}
+
+func (c *Controller) applyUpdate(ctx context.Context, session *auth.Session,
+	user *types.User, upd *userUpdate) (bool, error) {
+	if upd.userName != nil && strings.TrimSpace(*upd.userName) != user.UID {
+		return false, usererror.BadRequestWithPayload("The userName of a user can't be changed",
+			map[string]any{"scim_type": scimTypeMutability, "attribute": attributeUserName})
+	}
+
+	changed := false
+
+	if upd.displayName != nil {
+		displayName := strings.TrimSpace(*upd.displayName)
+		if err := check.DisplayName(displayName); err != nil {
+			return false, err
+		}
+		if displayName != user.DisplayName {
+			user.DisplayName = displayName
+			changed = true
+		}
+	}
+
+	if upd.email != nil {
+		email := strings.TrimSpace(*upd.email)
+		if err := check.Email(email); err != nil {
+			return false, err
+		}
+		if email != user.Email {
+			if err := c.checkEmailAvailable(ctx, email, user.ID); err != nil {
+				return false, err
+			}
+			user.Email = email
+			changed = true
+		}
+	}
+
+	if upd.active != nil && *upd.active == user.Blocked {
+		if !*upd.active {
+			if user.ID == session.Principal.ID {
+				return false, usererror.BadRequest("users can't deactivate themselves")
+			}
+
+			isLastAdmin, err := controller.IsLastActiveAdmin(ctx, c.principalStore, user)
+			if err != nil {
+				return false, err
+			}
+			if isLastAdmin {
+				return false, usererror.BadRequest("system requires at least one active admin user")
+			}
+		}
+
+		user.Blocked = !*upd.active
+		changed = true
+	}
+
+	return changed, nil
+}
+
+// apply adds the value of a patch operation to the update.
+// Operations without a path contain an object with the attributes to update.
+func (upd *userUpdate) apply(path string, value json.RawMessage) error {
+	if path == "" {
+		var attributes map[string]json.RawMessage
+		if err := json.Unmarshal(value, &attributes); err != nil {
+			return usererror.BadRequest("Patch operations without a path require an object value")
+		}
+
+		for attribute, attributeValue := range attributes {
+			if err := upd.apply(attribute, attributeValue); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	path = strings.ToLower(path)
+	switch {
+	case path == "username":
+		return unmarshalPatchValue(path, value, &upd.userName)
+	case path == "displayname" || path == "name.formatted":
+		return unmarshalPatchValue(path, value, &upd.displayName)
+	case path == "name":
+		name := &Name{}
+		if err := unmarshalPatchValue(path, value, &name); err != nil {
+			return err
+		}
+		if displayName := (&User{Name: name}).displayName(); displayName != "" {
+			upd.displayName = &displayName
+		}
+	case path == "emails":
+		var emails []Email
+		if err := unmarshalPatchValue(path, value, &emails); err != nil {
+			return err
+		}
+		if email := (&User{Emails: emails}).primaryEmail(); email != "" {
+			upd.email = &email
+		}
+	case strings.HasPrefix(path, "emails[") && strings.HasSuffix(path, "].value"):
+		// e.g. `emails[type eq "work"].value`, there's only a single email per user.
+		return unmarshalPatchValue(path, value, &upd.email)
+	case path == "active":
+		active, err := parseActive(value)
+		if err != nil {
+			return err
+		}
+		upd.active = &active
+	}
+
+	return nil
+}
+
+func unmarshalPatchValue(path string, value json.RawMessage, v any) error {
+	if err := json.Unmarshal(value, v); err != nil {
+		return usererror.BadRequestf("Invalid value for attribute %q", path)
+	}
+	return nil
+}
+
+// parseActive parses the active flag, some identity providers send booleans as strings.
+func parseActive(value json.RawMessage) (bool, error) {
+	var active bool
+	if err := json.Unmarshal(value, &active); err == nil {
+		return active, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		if active, err := strconv.ParseBool(s); err == nil {
+			return active, nil
+		}
+	}
+
+	return false, usererror.BadRequest(`Invalid value for attribute "active"`)
+}
@@ -0,0 +1,265 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serviceaccount
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestCreate_TokenFailureRollsBackServiceAccount(t *testing.T) {
+	ctx := context.Background()
+	principals := &memPrincipalStore{serviceAccounts: map[int64]*types.ServiceAccount{}}
+	tokens := &memTokenStore{tokens: map[int64]*types.Token{}, createErr: errors.New("db failure")}
+	ctrl := newTestController(principals, tokens, &fakeTokenCache{})
+
+	_, err := ctrl.Create(ctx, testSession(), &CreateInput{
+		Email:       "sa@example.com",
+		DisplayName: "CI",
+		ParentType:  enum.ParentResourceTypeSpace,
+		ParentID:    1,
+		Token:       &CreateTokenInput{Identifier: "ci"},
+	})
+	if err == nil {
+		t.Fatal("expected the creation to fail")
+	}
+
+	if len(principals.serviceAccounts) != 0 {
+		t.Errorf("expected the service account to be rolled back, got %d service accounts",
+			len(principals.serviceAccounts))
+	}
+}
+
+func TestCreate_WithInitialToken(t *testing.T) {
+	ctx := context.Background()
+	principals := &memPrincipalStore{serviceAccounts: map[int64]*types.ServiceAccount{}}
+	tokens := &memTokenStore{tokens: map[int64]*types.Token{}}
+	ctrl := newTestController(principals, tokens, &fakeTokenCache{})
+
+	out, err := ctrl.Create(ctx, testSession(), &CreateInput{
+		Email:       "sa@example.com",
+		DisplayName: "CI",
+		ParentType:  enum.ParentResourceTypeSpace,
+		ParentID:    1,
+		Token:       &CreateTokenInput{Identifier: "ci"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create service account: %v", err)
+	}
+
+	if out.InitialToken == nil || out.InitialToken.AccessToken == "" || !out.InitialToken.ShownOnce {
+		t.Fatalf("expected the initial token to be returned once, got %+v", out.InitialToken)
+	}
+	if out.InitialToken.Token.PrincipalID != out.ID || out.InitialToken.Token.Type != enum.TokenTypeSAT {
+		t.Errorf("expected a service account token of the service account, got %+v", out.InitialToken.Token)
+	}
+	if len(principals.serviceAccounts) != 1 || len(tokens.tokens) != 1 {
+		t.Errorf("expected one service account and one token, got %d and %d",
+			len(principals.serviceAccounts), len(tokens.tokens))
+	}
+}
+
+func TestDelete_RevokesAllTokens(t *testing.T) {
+	ctx := context.Background()
+	principals := &memPrincipalStore{serviceAccounts: map[int64]*types.ServiceAccount{}}
+	tokens := &memTokenStore{tokens: map[int64]*types.Token{}}
+	tokenCache := &fakeTokenCache{}
+	ctrl := newTestController(principals, tokens, tokenCache)
+
+	out, err := ctrl.Create(ctx, testSession(), &CreateInput{
+		Email:       "sa@example.com",
+		DisplayName: "CI",
+		ParentType:  enum.ParentResourceTypeSpace,
+		ParentID:    1,
+		Token:       &CreateTokenInput{Identifier: "ci"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create service account: %v", err)
+	}
+
+	if _, err = ctrl.CreateToken(ctx, testSession(), out.UID, &CreateTokenInput{Identifier: "deploy"}); err != nil {
+		t.Fatalf("failed to create second token: %v", err)
+	}
+
+	// tokens of other principals are kept.
+	tokens.tokens[100] = &types.Token{ID: 100, PrincipalID: out.ID + 1, Identifier: "other"}
+
+	if err := ctrl.Delete(ctx, testSession(), out.UID); err != nil {
+		t.Fatalf("failed to delete service account: %v", err)
+	}
+
+	if len(principals.serviceAccounts) != 0 {
+		t.Error("expected the service account to be deleted")
+	}
+	for _, tkn := range tokens.tokens {
+		if tkn.PrincipalID == out.ID {
+			t.Errorf("expected token %q of the service account to be revoked", tkn.Identifier)
+		}
+	}
+	if len(tokens.tokens) != 1 {
+		t.Errorf("expected the token of the other principal to be kept, got %d tokens", len(tokens.tokens))
+	}
+	if len(tokenCache.evicted) != 1 || tokenCache.evicted[0] != out.ID {
+		t.Errorf("expected the token cache of the service account to be evicted, got %v", tokenCache.evicted)
+	}
+}
+
+func newTestController(
+	principals *memPrincipalStore,
+	tokens *memTokenStore,
+	tokenCache *fakeTokenCache,
+) *Controller {
+	return NewController(check.PrincipalUIDDefault, allowAll{}, principals, fakeSpaceStore{}, nil,
+		tokens, tokenCache, &memTx{stores: []snapshotter{principals, tokens}})
+}
+
+func testSession() *auth.Session {
+	return &auth.Session{Principal: types.Principal{ID: 1, UID: "admin", Type: enum.PrincipalTypeUser}}
+}
+
+type allowAll struct {
+	authz.Authorizer
+}
+
+func (allowAll) Check(context.Context, *auth.Session, *types.Scope, *types.Resource, enum.Permission) (bool, error) {
+	return true, nil
+}
+
+type fakeSpaceStore struct {
+	store.SpaceStore
+}
+
+func (fakeSpaceStore) Find(_ context.Context, id int64) (*types.Space, error) {
+	return &types.Space{ID: id, Path: "space"}, nil
+}
+
+type fakeTokenCache struct {
+	store.PrincipalTokenCache
+	evicted []int64
+}
+
+func (c *fakeTokenCache) Evict(_ context.Context, principalID int64) {
+	c.evicted = append(c.evicted, principalID)
+}
+
+// snapshotter is implemented by the in-memory stores to support rolling back transactions.
+type snapshotter interface {
+	snapshot() func()
+}
+
+// memTx runs the transaction functions directly and restores the state of the stores if they fail.
+type memTx struct {
+	stores []snapshotter
+}
+
+func (tx *memTx) WithTx(ctx context.Context, txFn func(ctx context.Context) error, _ ...interface{}) error {
+	restores := make([]func(), len(tx.stores))
+	for i, s := range tx.stores {
+		restores[i] = s.snapshot()
+	}
+
+	err := txFn(ctx)
+	if err != nil {
+		for _, restore := range restores {
+			restore()
+		}
+	}
+
+	return err
+}
+
+type memPrincipalStore struct {
+	store.PrincipalStore
+	serviceAccounts map[int64]*types.ServiceAccount
+	lastID          int64
+}
+
+func (s *memPrincipalStore) snapshot() func() {
+	serviceAccounts := maps.Clone(s.serviceAccounts)
+	return func() { s.serviceAccounts = serviceAccounts }
+}
+
+func (s *memPrincipalStore) CreateServiceAccount(_ context.Context, sa *types.ServiceAccount) error {
+	s.lastID++
+	sa.ID = s.lastID
+	s.serviceAccounts[sa.ID] = sa
+	return nil
+}
+
+func (s *memPrincipalStore) FindServiceAccountByUID(_ context.Context, uid string) (*types.ServiceAccount, error) {
+	for _, sa := range s.serviceAccounts {
+		if sa.UID == uid {
+			return sa, nil
+		}
+	}
+	return nil, errors.New("service account not found")
+}
+
+func (s *memPrincipalStore) DeleteServiceAccount(_ context.Context, id int64) error {
+	delete(s.serviceAccounts, id)
+	return nil
+}
+
+type memTokenStore struct {
+	store.TokenStore
+	tokens    map[int64]*types.Token
+	lastID    int64
+	createErr error
+}
+
+func (s *memTokenStore) snapshot() func() {
+	tokens := maps.Clone(s.tokens)
+	return func() { s.tokens = tokens }
+}
+
+func (s *memTokenStore) Create(_ context.Context, token *types.Token) error {
+	if s.createErr != nil {
+		return s.createErr
+	}
+
+	s.lastID++
+	token.ID = s.lastID
+	s.tokens[token.ID] = token
+	return nil
+}
+
+func (s *memTokenStore) DeleteForPrincipal(
+	_ context.Context,
+	principalID int64,
+	tknTypes []enum.TokenType,
+) (int64, error) {
+	var count int64
+	for id, token := range s.tokens {
+		if token.PrincipalID != principalID {
+			continue
+		}
+		if len(tknTypes) > 0 && !slices.Contains(tknTypes, token.Type) {
+			continue
+		}
+		delete(s.tokens, id)
+		count++
+	}
+	return count, nil
+}
@@ -22,6 +22,7 @@ import (
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/token"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
@@ -40,11 +41,32 @@ type CreateInput struct {
 	DisplayName string                  `json:"display_name"`
 	ParentType  enum.ParentResourceType `json:"parent_type"`
 	ParentID    int64                   `json:"parent_id"`
+
+	// Token is optional - if provided, a token is created together with the service account.
+	Token *CreateTokenInput `json:"token,omitempty"`
+}
+
+// CreateOutput is the service account returned on creation.
+type CreateOutput struct {
+	types.ServiceAccount
+
+	// InitialToken is the token created together with the service account (if requested).
+	InitialToken *InitialTokenOutput `json:"initial_token,omitempty"`
+}
+
+// InitialTokenOutput contains the access token of the initial service account token.
+// The access token is shown only once and can't be retrieved again.
+type InitialTokenOutput struct {
+	types.TokenResponse
+
+	// ShownOnce is always true and is a reminder for clients that the access token has to be stored.
+	ShownOnce bool `json:"shown_once"`
 }
 
 // Create creates a new service account.
+// If requested, the initial token of the service account is created in the same transaction.
 func (c *Controller) Create(ctx context.Context, session *auth.Session,
-	in *CreateInput) (*types.ServiceAccount, error) {
+	in *CreateInput) (*CreateOutput, error) {
 	// Ensure principal has required permissions on parent (ensures that parent exists)
 	// since it's a create, we use don't pass a resource name.
 	if err := apiauth.CheckServiceAccount(ctx, c.authorizer, session, c.spaceStore, c.repoStore,
@@ -52,13 +74,55 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session,
 		return nil, err
 	}
 
+	if in.Token != nil {
+		if err := c.sanitizeCreateTokenInput(in.Token); err != nil {
+			return nil, fmt.Errorf("invalid token input: %w", err)
+		}
+	}
+
 	uid, err := generateServiceAccountUID(in.ParentType, in.ParentID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate service account UID: %w", err)
 	}
 
-	// TODO: There's a chance of duplicate error - we should retry?
-	return c.CreateNoAuth(ctx, in, uid)
+	out := &CreateOutput{}
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		// TODO: There's a chance of duplicate error - we should retry?
+		sa, err := c.CreateNoAuth(ctx, in, uid)
+		if err != nil {
+			return err
+		}
+
+		out.ServiceAccount = *sa
+
+		if in.Token == nil {
+			return nil
+		}
+
+		tkn, jwtToken, err := token.CreateSAT(
+			ctx,
+			c.tokenStore,
+			&session.Principal,
+			sa,
+			in.Token.Identifier,
+			in.Token.Lifetime,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create initial token: %w", err)
+		}
+
+		out.InitialToken = &InitialTokenOutput{
+			TokenResponse: types.TokenResponse{Token: *tkn, AccessToken: jwtToken},
+			ShownOnce:     true,
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
 }
 
 /*
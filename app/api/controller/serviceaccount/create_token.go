@@ -77,7 +77,7 @@ func (c *Controller) sanitizeCreateTokenInput(in *CreateTokenInput) error {
 		in.Identifier = in.UID
 	}
 
-	if err := check.Identifier(in.Identifier); err != nil {
+	if err := check.UID(in.Identifier); err != nil {
 		return err
 	}
 
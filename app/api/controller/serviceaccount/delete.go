@@ -16,6 +16,7 @@ package serviceaccount
 
 import (
 	"context"
+	"fmt"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
@@ -36,5 +37,23 @@ func (c *Controller) Delete(ctx context.Context, session *auth.Session,
 		return err
 	}
 
-	return c.principalStore.DeleteServiceAccount(ctx, sa.ID)
+	// revoke all tokens of the service account together with its deletion.
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := c.tokenStore.DeleteForPrincipal(ctx, sa.ID, nil); err != nil {
+			return fmt.Errorf("failed to revoke tokens of service account: %w", err)
+		}
+
+		if err := c.principalStore.DeleteServiceAccount(ctx, sa.ID); err != nil {
+			return fmt.Errorf("failed to delete service account: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	c.tokenCache.Evict(ctx, sa.ID)
+
+	return nil
 }
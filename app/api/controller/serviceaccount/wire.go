@@ -17,6 +17,7 @@ package serviceaccount
 import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types/check"
 
 	"github.com/google/wire"
@@ -29,6 +30,7 @@ var WireSet = wire.NewSet(
 
 func ProvideController(principalUIDCheck check.PrincipalUID, authorizer authz.Authorizer,
 	principalStore store.PrincipalStore, spaceStore store.SpaceStore, repoStore store.RepoStore,
-	tokenStore store.TokenStore) *Controller {
-	return NewController(principalUIDCheck, authorizer, principalStore, spaceStore, repoStore, tokenStore)
+	tokenStore store.TokenStore, tokenCache store.PrincipalTokenCache, tx dbtx.Transactor) *Controller {
+	return NewController(principalUIDCheck, authorizer, principalStore, spaceStore, repoStore,
+		tokenStore, tokenCache, tx)
 }
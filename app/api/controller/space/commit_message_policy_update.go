@@ -0,0 +1,55 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/commitpolicy"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// CommitMessagePolicyUpdate replaces the commit message policy of the space.
+// The policy applies to all repositories and spaces within the space that don't define a policy of their own.
+// An empty policy makes the space inherit the commit message policy of its parent.
+func (c *Controller) CommitMessagePolicyUpdate(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *types.CommitMessagePolicy,
+) (*types.CommitMessagePolicyOutput, error) {
+	if err := commitpolicy.Sanitize(in); err != nil {
+		return nil, err
+	}
+
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space: %w", err)
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit); err != nil {
+		return nil, fmt.Errorf("access check failed: %w", err)
+	}
+
+	if err = c.commitPolicy.SpaceSet(ctx, space.ID, *in); err != nil {
+		return nil, err
+	}
+
+	return c.commitPolicy.SpaceFind(ctx, space)
+}
@@ -21,13 +21,21 @@ import (
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/commitpolicy"
 	"github.com/harness/gitness/app/services/exporter"
 	"github.com/harness/gitness/app/services/gitspace"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/operation"
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/recentvisit"
+	"github.com/harness/gitness/app/services/repobulk"
+	"github.com/harness/gitness/app/services/spacearchive"
+	"github.com/harness/gitness/app/services/spacefeed"
+	"github.com/harness/gitness/app/services/usage"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -64,6 +72,7 @@ func (s SpaceOutput) MarshalJSON() ([]byte, error) {
 
 type Controller struct {
 	nestedSpacesEnabled bool
+	maxPinnedRepos      int
 
 	tx              dbtx.Transactor
 	urlProvider     url.Provider
@@ -83,12 +92,23 @@ type Controller struct {
 	prListService   *pullreq.ListService
 	importer        *importer.Repository
 	exporter        *exporter.Repository
+	spaceArchive    *spacearchive.Service
 	resourceLimiter limiter.ResourceLimiter
 	publicAccess    publicaccess.Service
 	auditService    audit.Service
 	gitspaceSvc     *gitspace.Service
 	labelSvc        *label.Service
 	instrumentation instrument.Service
+	recentVisits    *recentvisit.Service
+	feed            *spacefeed.Service
+	usage           *usage.Service
+	commitPolicy    *commitpolicy.Service
+	repoTopicStore  store.RepoTopicStore
+	pinnedRepoStore store.SpacePinnedRepoStore
+	locker          *locker.Locker
+	repoBulk        *repobulk.Service
+	operationSvc    *operation.Service
+	streamLimiter   *streamLimiter
 }
 
 func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Provider,
@@ -97,13 +117,23 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 	connectorStore store.ConnectorStore, templateStore store.TemplateStore, spaceStore store.SpaceStore,
 	repoStore store.RepoStore, principalStore store.PrincipalStore, repoCtrl *repo.Controller,
 	membershipStore store.MembershipStore, prListService *pullreq.ListService,
-	importer *importer.Repository, exporter *exporter.Repository,
+	importer *importer.Repository, exporter *exporter.Repository, spaceArchive *spacearchive.Service,
 	limiter limiter.ResourceLimiter, publicAccess publicaccess.Service, auditService audit.Service,
 	gitspaceSvc *gitspace.Service, labelSvc *label.Service,
 	instrumentation instrument.Service,
+	recentVisits *recentvisit.Service,
+	feed *spacefeed.Service,
+	usage *usage.Service,
+	commitPolicy *commitpolicy.Service,
+	repoTopicStore store.RepoTopicStore,
+	pinnedRepoStore store.SpacePinnedRepoStore,
+	locker *locker.Locker,
+	repoBulk *repobulk.Service,
+	operationSvc *operation.Service,
 ) *Controller {
 	return &Controller{
 		nestedSpacesEnabled: config.NestedSpacesEnabled,
+		maxPinnedRepos:      config.MaxPinnedRepos,
 		tx:                  tx,
 		urlProvider:         urlProvider,
 		sseStreamer:         sseStreamer,
@@ -122,11 +152,22 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 		prListService:       prListService,
 		importer:            importer,
 		exporter:            exporter,
+		spaceArchive:        spaceArchive,
 		resourceLimiter:     limiter,
 		publicAccess:        publicAccess,
 		auditService:        auditService,
 		gitspaceSvc:         gitspaceSvc,
 		labelSvc:            labelSvc,
 		instrumentation:     instrumentation,
+		recentVisits:        recentVisits,
+		feed:                feed,
+		usage:               usage,
+		commitPolicy:        commitPolicy,
+		repoTopicStore:      repoTopicStore,
+		pinnedRepoStore:     pinnedRepoStore,
+		locker:              locker,
+		repoBulk:            repoBulk,
+		operationSvc:        operationSvc,
+		streamLimiter:       newStreamLimiter(config.SpaceFeed.MaxStreamsPerUser),
 	}
 }
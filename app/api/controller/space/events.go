@@ -16,18 +16,33 @@ package space
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"sync"
+	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/types/enum"
 )
 
+// repoAccessTTL is the time for which the result of a repository access check is reused by a stream.
+const repoAccessTTL = 30 * time.Second
+
+var errSessionExpired = errors.New("auth session expired")
+
+// Events streams the live updates of the space. Events about repositories are only forwarded
+// if the principal can view the repository at the time of the event.
+// If lastEventID is provided, the events published after it are replayed before any live events.
+// The stream is terminated once the auth session expires.
 func (c *Controller) Events(
 	ctx context.Context,
 	session *auth.Session,
 	spaceRef string,
+	lastEventID string,
 ) (<-chan *sse.Event, <-chan error, func(context.Context) error, error) {
 	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
 	if err != nil {
@@ -38,7 +53,164 @@ func (c *Controller) Events(
 		return nil, nil, nil, fmt.Errorf("failed to authorize stream: %w", err)
 	}
 
-	chEvents, chErr, sseCancel := c.sseStreamer.Stream(ctx, space.ID)
+	var afterID int64
+	if lastEventID != "" {
+		afterID, err = strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil || afterID < 0 {
+			return nil, nil, nil, usererror.BadRequestf("Invalid last event ID '%s'.", lastEventID)
+		}
+	}
+
+	if !c.streamLimiter.acquire(session.Principal.ID) {
+		return nil, nil, nil, usererror.ErrTooManyStreams
+	}
+
+	// subscribe before replaying, to not miss any events published in between.
+	chLive, chLiveErr, sseCancel := c.sseStreamer.Stream(ctx, space.ID)
+
+	var replayed []*sse.Event
+	var replayedUpTo int64
+	if lastEventID != "" {
+		replayed, replayedUpTo, err = c.feed.Replay(ctx, space.ID, afterID)
+		if err != nil {
+			c.streamLimiter.release(session.Principal.ID)
+			_ = sseCancel(ctx)
+			return nil, nil, nil, fmt.Errorf("failed to replay space events: %w", err)
+		}
+	}
+
+	streamCtx, streamCancel := context.WithCancel(ctx)
+
+	chEvents := make(chan *sse.Event)
+	chErr := make(chan error, 1)
+
+	s := &eventStream{
+		ctrl:         c,
+		session:      session,
+		replayedUpTo: replayedUpTo,
+		access:       make(map[int64]repoAccess),
+		chEvents:     chEvents,
+		chErr:        chErr,
+	}
+	go s.forward(streamCtx, replayed, chLive, chLiveErr)
+
+	var once sync.Once
+	cancel := func(ctx context.Context) error {
+		streamCancel()
+		once.Do(func() { c.streamLimiter.release(session.Principal.ID) })
+		return sseCancel(ctx)
+	}
+
+	return chEvents, chErr, cancel, nil
+}
+
+type repoAccess struct {
+	allowed bool
+	checked time.Time
+}
+
+// eventStream filters and forwards the events of a single space event stream.
+type eventStream struct {
+	ctrl         *Controller
+	session      *auth.Session
+	replayedUpTo int64
+	access       map[int64]repoAccess
+	chEvents     chan<- *sse.Event
+	chErr        chan<- error
+}
+
+func (s *eventStream) forward(
+	ctx context.Context,
+	replayed []*sse.Event,
+	chLive <-chan *sse.Event,
+	chLiveErr <-chan error,
+) {
+	var chExpired <-chan time.Time
+	if s.session.ExpiresAt > 0 {
+		timer := time.NewTimer(time.Until(time.UnixMilli(s.session.ExpiresAt)))
+		defer timer.Stop()
+		chExpired = timer.C
+	}
+
+	for _, event := range replayed {
+		if !s.canView(ctx, event) {
+			continue
+		}
+		if !s.send(ctx, event) {
+			return
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-chExpired:
+			s.chErr <- errSessionExpired
+			return
+
+		case err := <-chLiveErr:
+			s.chErr <- err
+			return
+
+		case event, ok := <-chLive:
+			if !ok {
+				close(s.chEvents)
+				return
+			}
+			if s.isReplayed(event) || !s.canView(ctx, event) {
+				continue
+			}
+			if !s.send(ctx, event) {
+				return
+			}
+		}
+	}
+}
+
+func (s *eventStream) send(ctx context.Context, event *sse.Event) bool {
+	select {
+	case s.chEvents <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// isReplayed returns true if the live event has been sent already as part of the replay.
+func (s *eventStream) isReplayed(event *sse.Event) bool {
+	if s.replayedUpTo == 0 || event.ID == "" {
+		return false
+	}
+
+	id, err := strconv.ParseInt(event.ID, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	return id <= s.replayedUpTo
+}
+
+// canView returns true if the principal can view the repository the event is about.
+// The result is cached for a short time, so access revoked mid-stream is picked up quickly.
+func (s *eventStream) canView(ctx context.Context, event *sse.Event) bool {
+	if event.RepoID == 0 {
+		return true
+	}
+
+	if access, ok := s.access[event.RepoID]; ok && time.Since(access.checked) < repoAccessTTL {
+		return access.allowed
+	}
+
+	allowed := false
+	repo, err := s.ctrl.repoStore.Find(ctx, event.RepoID)
+	if err == nil {
+		err = apiauth.CheckRepo(ctx, s.ctrl.authorizer, s.session, repo, enum.PermissionRepoView)
+		allowed = err == nil
+	}
+
+	s.access[event.RepoID] = repoAccess{allowed: allowed, checked: time.Now()}
 
-	return chEvents, chErr, sseCancel, nil
+	return allowed
 }
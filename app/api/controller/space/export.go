@@ -23,31 +23,63 @@ import (
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/services/exporter"
+	"github.com/harness/gitness/app/services/operation"
+	"github.com/harness/gitness/app/services/spacearchive"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
 
+// ExportType defines the supported targets of a space export.
+type ExportType string
+
+func (ExportType) Enum() []any {
+	return []any{ExportTypeHarnessCode, ExportTypeArchive}
+}
+
+const (
+	// ExportTypeHarnessCode exports all repositories of the space to harness code.
+	ExportTypeHarnessCode ExportType = "harness_code"
+	// ExportTypeArchive exports the space into a downloadable archive (metadata and git bundles).
+	ExportTypeArchive ExportType = "archive"
+)
+
 type ExportInput struct {
-	AccountID         string `json:"account_id"`
-	OrgIdentifier     string `json:"org_identifier"`
-	ProjectIdentifier string `json:"project_identifier"`
-	Token             string `json:"token"`
+	Type              ExportType `json:"type"`
+	AccountID         string     `json:"account_id"`
+	OrgIdentifier     string     `json:"org_identifier"`
+	ProjectIdentifier string     `json:"project_identifier"`
+	Token             string     `json:"token"`
+}
+
+type ExportOutput struct {
+	OperationID string `json:"operation_id"`
 }
 
-// Export creates a new empty repository in harness code and does git push to it.
-func (c *Controller) Export(ctx context.Context, session *auth.Session, spaceRef string, in *ExportInput) error {
+// Export creates a new empty repository in harness code and does git push to it,
+// or, in case of an archive export, starts a background job that exports the space into an archive.
+// The returned operation reports the progress of the export.
+func (c *Controller) Export(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *ExportInput,
+) (*ExportOutput, error) {
 	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit); err != nil {
-		return err
+		return nil, err
+	}
+
+	if in.Type == ExportTypeArchive {
+		return c.exportArchive(ctx, session, space)
 	}
 
 	err = c.sanitizeExportInput(in)
 	if err != nil {
-		return fmt.Errorf("failed to sanitize input: %w", err)
+		return nil, fmt.Errorf("failed to sanitize input: %w", err)
 	}
 
 	providerInfo := &exporter.HarnessCodeInfo{
@@ -63,7 +95,7 @@ func (c *Controller) Export(ctx context.Context, session *auth.Session, spaceRef
 		reposInPage, err := c.repoStore.List(
 			ctx, space.ID, &types.RepoFilter{Size: 200, Page: page, Order: enum.OrderDesc})
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if len(reposInPage) == 0 {
 			break
@@ -72,6 +104,7 @@ func (c *Controller) Export(ctx context.Context, session *auth.Session, spaceRef
 		repos = append(repos, reposInPage...)
 	}
 
+	var op *types.Operation
 	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
 		err = c.exporter.RunManyForSpace(ctx, space.ID, repos, providerInfo)
 		if errors.Is(err, exporter.ErrJobRunning) {
@@ -80,16 +113,59 @@ func (c *Controller) Export(ctx context.Context, session *auth.Session, spaceRef
 		if err != nil {
 			return fmt.Errorf("failed to start export repository job: %w", err)
 		}
+
+		op, err = c.operationSvc.Create(ctx, operation.CreateInput{
+			Type:         enum.OperationTypeSpaceExport,
+			ResourceType: enum.ParentResourceTypeSpace,
+			ResourceID:   space.ID,
+			JobGroupID:   exporter.JobGroupIDFromSpaceID(space.ID),
+			CreatedBy:    session.Principal.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create export operation: %w", err)
+		}
+
 		return nil
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return &ExportOutput{OperationID: op.UID}, nil
+}
+
+func (c *Controller) exportArchive(
+	ctx context.Context,
+	session *auth.Session,
+	space *types.Space,
+) (*ExportOutput, error) {
+	err := c.spaceArchive.RunExport(ctx, space.ID)
+	if errors.Is(err, spacearchive.ErrJobRunning) {
+		return nil, usererror.ConflictWithPayload("export already in progress")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to start space archive export job: %w", err)
+	}
+
+	op, err := c.operationSvc.Create(ctx, operation.CreateInput{
+		Type:         enum.OperationTypeSpaceArchiveExport,
+		ResourceType: enum.ParentResourceTypeSpace,
+		ResourceID:   space.ID,
+		JobUID:       spacearchive.ExportJobUIDFromSpaceID(space.ID),
+		CreatedBy:    session.Principal.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create space archive export operation: %w", err)
+	}
+
+	return &ExportOutput{OperationID: op.UID}, nil
 }
 
 func (c *Controller) sanitizeExportInput(in *ExportInput) error {
+	if in.Type != "" && in.Type != ExportTypeHarnessCode {
+		return usererror.BadRequestf("unknown export type %q", in.Type)
+	}
+
 	if in.AccountID == "" {
 		return usererror.BadRequest("account id must be provided")
 	}
@@ -0,0 +1,54 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/spacearchive"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ExportDownload returns either a signed URL or a reader for the latest archive export of the space.
+func (c *Controller) ExportDownload(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) (string, io.ReadCloser, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit); err != nil {
+		return "", nil, err
+	}
+
+	signedURL, file, err := c.spaceArchive.DownloadExport(ctx, space.ID)
+	if errors.Is(err, spacearchive.ErrNotFound) {
+		return "", nil, usererror.NotFound("No completed archive export found for space.")
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to download space archive: %w", err)
+	}
+
+	return signedURL, file, nil
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/services/exporter"
+	"github.com/harness/gitness/app/services/spacearchive"
 	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/types/enum"
 
@@ -29,7 +30,8 @@ import (
 )
 
 type ExportProgressOutput struct {
-	Repos []job.Progress `json:"repos"`
+	Repos   []job.Progress `json:"repos"`
+	Archive *job.Progress  `json:"archive,omitempty"`
 }
 
 // ExportProgress returns progress of the export job.
@@ -47,12 +49,23 @@ func (c *Controller) ExportProgress(ctx context.Context,
 	}
 
 	progress, err := c.exporter.GetProgressForSpace(ctx, space.ID)
-	if errors.Is(err, exporter.ErrNotFound) {
+	if err != nil && !errors.Is(err, exporter.ErrNotFound) {
+		return ExportProgressOutput{}, fmt.Errorf("failed to retrieve export progress: %w", err)
+	}
+
+	archiveProgress, errArchive := c.spaceArchive.GetExportProgress(ctx, space.ID)
+	if errArchive != nil && !errors.Is(errArchive, spacearchive.ErrNotFound) {
+		return ExportProgressOutput{}, fmt.Errorf("failed to retrieve archive export progress: %w", errArchive)
+	}
+
+	if err != nil && errArchive != nil {
 		return ExportProgressOutput{}, usererror.NotFound("No recent or ongoing export found for space.")
 	}
-	if err != nil {
-		return ExportProgressOutput{}, fmt.Errorf("failed to retrieve export progress: %w", err)
+
+	out := ExportProgressOutput{Repos: progress}
+	if errArchive == nil {
+		out.Archive = &archiveProgress
 	}
 
-	return ExportProgressOutput{Repos: progress}, nil
+	return out, nil
 }
@@ -35,5 +35,7 @@ func (c *Controller) Find(ctx context.Context, session *auth.Session, spaceRef s
 		return nil, err
 	}
 
+	c.recentVisits.Record(session, enum.RecentVisitTypeSpace, space.ID)
+
 	return GetSpaceOutput(ctx, c.publicAccess, space)
 }
@@ -0,0 +1,311 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller/limiter"
+	repoctrl "github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/services/importer"
+	"github.com/harness/gitness/app/services/spacearchive"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ImportArchiveRepoStatus describes the outcome of importing a single repository of a space archive.
+type ImportArchiveRepoStatus string
+
+func (ImportArchiveRepoStatus) Enum() []any {
+	return []any{ImportArchiveRepoStatusImporting, ImportArchiveRepoStatusSkipped, ImportArchiveRepoStatusFailed}
+}
+
+const (
+	ImportArchiveRepoStatusImporting ImportArchiveRepoStatus = "importing"
+	ImportArchiveRepoStatusSkipped   ImportArchiveRepoStatus = "skipped"
+	ImportArchiveRepoStatusFailed    ImportArchiveRepoStatus = "failed"
+)
+
+type ImportArchiveRepoResult struct {
+	Path   string                     `json:"path"`
+	Status ImportArchiveRepoStatus    `json:"status"`
+	Reason string                     `json:"reason,omitempty"`
+	Repo   *repoctrl.RepositoryOutput `json:"repo,omitempty"`
+}
+
+type ImportArchiveOutput struct {
+	CreatedSpaces        []string                  `json:"created_spaces"`
+	Repos                []ImportArchiveRepoResult `json:"repos"`
+	UnresolvedPrincipals []string                  `json:"unresolved_principals"`
+}
+
+// ImportArchive recreates the spaces and repositories of a space archive inside the space.
+// Already existing spaces are reused and already existing repositories are skipped, which allows
+// re-importing the same archive to pick up repositories that failed to import previously.
+// The git data of each repository is imported by a separate background job,
+// its progress is available via the import progress of the repository.
+// Sub spaces are owned by the importing principal, all other principal references are remapped by UID.
+//
+//nolint:gocognit,gocyclo,cyclop // refactor if needed.
+func (c *Controller) ImportArchive(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	archive io.Reader,
+) (*ImportArchiveOutput, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit); err != nil {
+		return nil, err
+	}
+
+	archivePath, manifest, err := c.spaceArchive.UploadImportArchive(ctx, space.ID, archive)
+	if errors.Is(err, spacearchive.ErrInvalidArchive) {
+		return nil, usererror.BadRequestf("Invalid space archive: %s", err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload space archive: %w", err)
+	}
+
+	if len(manifest.Spaces) > 0 && !c.nestedSpacesEnabled {
+		return nil, errNestedSpacesNotSupported
+	}
+
+	isPublicAccessSupported, err := c.publicAccess.IsPublicAccessSupported(ctx, space.Path)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to check if public access is supported for space %q: %w",
+			space.Path,
+			err,
+		)
+	}
+
+	resolver := c.spaceArchive.NewPrincipalResolver(&session.Principal)
+
+	out := &ImportArchiveOutput{
+		CreatedSpaces: []string{},
+		Repos:         make([]ImportArchiveRepoResult, 0, len(manifest.Repos)),
+	}
+	var createdSpaces []*types.Space
+	var createdRepos []*types.Repository
+	var sources []importer.ArchiveSource
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		// lock the space for update during repo creation to prevent racing conditions with space soft delete.
+		space, err = c.spaceStore.FindForUpdate(ctx, space.ID)
+		if err != nil {
+			return fmt.Errorf("failed to find the target space: %w", err)
+		}
+
+		if err := c.resourceLimiter.RepoCount(ctx, space.ID, len(manifest.Repos)); err != nil {
+			return fmt.Errorf("resource limit exceeded: %w", limiter.ErrMaxNumReposReached)
+		}
+
+		spaces := map[string]*types.Space{"": space}
+		for _, entry := range manifest.Spaces {
+			parent, ok := spaces[paths.Parent(entry.Path)]
+			if !ok {
+				return usererror.BadRequestf("Invalid space archive: parent of space %q is missing.", entry.Path)
+			}
+
+			subSpace, err := c.spaceStore.FindByRef(ctx, paths.Concatenate(space.Path, entry.Path))
+			if err != nil && !errors.Is(err, store.ErrResourceNotFound) {
+				return fmt.Errorf("failed to find space %q: %w", entry.Path, err)
+			}
+			if err == nil {
+				spaces[entry.Path] = subSpace
+				continue
+			}
+
+			_, identifier, err := paths.DisectLeaf(entry.Path)
+			if err != nil {
+				return usererror.BadRequestf("Invalid space archive: invalid space path %q.", entry.Path)
+			}
+
+			in := &CreateInput{
+				Identifier:  identifier,
+				Description: entry.Description,
+				IsPublic:    entry.IsPublic && isPublicAccessSupported,
+			}
+			if err = c.identifierCheck(in.Identifier, false); err != nil {
+				return usererror.BadRequestf("Invalid space archive: %s", err)
+			}
+
+			subSpace, err = c.createSpaceInnerInTX(ctx, session, parent.ID, in)
+			if err != nil {
+				return fmt.Errorf("failed to create space %q: %w", entry.Path, err)
+			}
+
+			spaces[entry.Path] = subSpace
+			createdSpaces = append(createdSpaces, subSpace)
+		}
+
+		for _, entry := range manifest.Repos {
+			parent := spaces[entry.ParentPath]
+			repoPath := paths.Concatenate(paths.Concatenate(space.Path, entry.ParentPath), entry.Identifier)
+
+			if parent == nil {
+				out.Repos = append(out.Repos, ImportArchiveRepoResult{
+					Path:   repoPath,
+					Status: ImportArchiveRepoStatusFailed,
+					Reason: "parent space is missing in the archive",
+				})
+				continue
+			}
+
+			if err = check.RepoIdentifierDefault(entry.Identifier); err != nil {
+				out.Repos = append(out.Repos, ImportArchiveRepoResult{
+					Path:   repoPath,
+					Status: ImportArchiveRepoStatusFailed,
+					Reason: err.Error(),
+				})
+				continue
+			}
+
+			if entry.ExportError != "" {
+				out.Repos = append(out.Repos, ImportArchiveRepoResult{
+					Path:   repoPath,
+					Status: ImportArchiveRepoStatusFailed,
+					Reason: "git data wasn't exported: " + entry.ExportError,
+				})
+				continue
+			}
+
+			_, err = c.repoStore.FindByRef(ctx, repoPath)
+			if err != nil && !errors.Is(err, store.ErrResourceNotFound) {
+				return fmt.Errorf("failed to find repository %q: %w", repoPath, err)
+			}
+			if err == nil {
+				out.Repos = append(out.Repos, ImportArchiveRepoResult{
+					Path:   repoPath,
+					Status: ImportArchiveRepoStatusSkipped,
+					Reason: "repository already exists",
+				})
+				continue
+			}
+
+			createdBy, err := resolver.Resolve(ctx, entry.CreatedBy)
+			if err != nil {
+				return err
+			}
+
+			repoInfo := importer.RepositoryInfo{
+				Identifier:    entry.Identifier,
+				IsPublic:      entry.IsPublic,
+				DefaultBranch: entry.DefaultBranch,
+			}
+			repo, isPublic := repoInfo.ToRepo(
+				parent.ID,
+				parent.Path,
+				entry.Identifier,
+				entry.Description,
+				&session.Principal,
+			)
+			repo.CreatedBy = createdBy
+
+			err = c.repoStore.Create(ctx, repo)
+			if err != nil {
+				return fmt.Errorf("failed to create repository %q in storage: %w", repoPath, err)
+			}
+
+			err = c.spaceArchive.CreateWebhooks(ctx, repo, entry.Webhooks, resolver)
+			if err != nil {
+				return fmt.Errorf("failed to create webhooks of repository %q: %w", repoPath, err)
+			}
+
+			createdRepos = append(createdRepos, repo)
+			sources = append(sources, importer.ArchiveSource{
+				RepoID:        repo.ID,
+				Public:        isPublic,
+				Bundle:        entry.Bundle,
+				DefaultBranch: entry.DefaultBranch,
+			})
+			out.Repos = append(out.Repos, ImportArchiveRepoResult{
+				Path:   repo.Path,
+				Status: ImportArchiveRepoStatusImporting,
+				Repo:   repoctrl.GetRepoOutputWithAccess(ctx, false, repo),
+			})
+		}
+
+		if len(sources) == 0 {
+			return nil
+		}
+
+		jobGroupID := fmt.Sprintf("space-import-%d", space.ID)
+		err = c.importer.RunManyFromArchive(ctx, jobGroupID, archivePath, sources)
+		if err != nil {
+			return fmt.Errorf("failed to start import repository jobs: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, subSpace := range createdSpaces {
+		out.CreatedSpaces = append(out.CreatedSpaces, subSpace.Path)
+
+		if !isPublicAccessSupported {
+			continue
+		}
+
+		for _, entry := range manifest.Spaces {
+			if !entry.IsPublic || paths.Concatenate(space.Path, entry.Path) != subSpace.Path {
+				continue
+			}
+
+			err = c.publicAccess.Set(ctx, enum.PublicResourceTypeSpace, subSpace.Path, true)
+			if err != nil {
+				log.Ctx(ctx).Warn().Err(err).Msgf("failed to make imported space %q public", subSpace.Path)
+			}
+		}
+	}
+
+	for _, repo := range createdRepos {
+		err = c.auditService.Log(ctx,
+			session.Principal,
+			audit.NewResource(audit.ResourceTypeRepository, repo.Identifier),
+			audit.ActionCreated,
+			paths.Parent(repo.Path),
+			audit.WithNewObject(audit.RepositoryObject{
+				Repository: *repo,
+				IsPublic:   false, // in import we configure public access and create a new audit log.
+			}),
+		)
+		if err != nil {
+			log.Ctx(ctx).Warn().Msgf("failed to insert audit log for import repository operation: %s", err)
+		}
+	}
+
+	out.UnresolvedPrincipals = resolver.Unresolved()
+
+	return out, nil
+}
@@ -19,24 +19,28 @@ import (
 	"fmt"
 
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
 
-// DeleteLabel deletes a label for the specified space.
+// DeleteLabel deletes a label for the specified space and returns the deleted resources.
+// With dryRun set, the resources that would be deleted are returned, but nothing is deleted.
 func (c *Controller) DeleteLabel(
 	ctx context.Context,
 	session *auth.Session,
 	spaceRef string,
 	key string,
-) error {
+	dryRun bool,
+) ([]types.AffectedResource, error) {
 	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionRepoEdit)
 	if err != nil {
-		return fmt.Errorf("failed to acquire access to space: %w", err)
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
 	}
 
-	if err := c.labelSvc.Delete(ctx, &space.ID, nil, key); err != nil {
-		return fmt.Errorf("failed to delete space label: %w", err)
+	affected, err := c.labelSvc.Delete(ctx, &space.ID, nil, key, dryRun)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete space label: %w", err)
 	}
 
-	return nil
+	return affected, nil
 }
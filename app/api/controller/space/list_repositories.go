@@ -49,19 +49,37 @@ func (c *Controller) ListRepositories(
 		return nil, 0, err
 	}
 
+	// anonymous users are granted access through public access only, don't list private repositories.
+	filter.OnlyPublic = auth.IsAnonymousSession(session)
+
 	return c.ListRepositoriesNoAuth(ctx, space.ID, filter)
 }
 
 // ListRepositoriesNoAuth list repositories WITHOUT checking for PermissionRepoView.
+// With the IncludePinned filter, the first page starts with the pinned repos of the space in their order,
+// which are excluded from the remaining list.
 func (c *Controller) ListRepositoriesNoAuth(
 	ctx context.Context,
 	spaceID int64,
 	filter *types.RepoFilter,
 ) ([]*repoCtrl.RepositoryOutput, int64, error) {
 	var repos []*types.Repository
+	var pinned []*types.Repository
 	var count int64
 
 	err := c.tx.WithTx(ctx, func(ctx context.Context) (err error) {
+		if filter.IncludePinned {
+			pinned, err = c.listPinnedRepos(ctx, spaceID, filter)
+			if err != nil {
+				return err
+			}
+
+			filter.ExcludedRepoIDs = make([]int64, len(pinned))
+			for i, repo := range pinned {
+				filter.ExcludedRepoIDs[i] = repo.ID
+			}
+		}
+
 		count, err = c.repoStore.Count(ctx, spaceID, filter)
 		if err != nil {
 			return fmt.Errorf("failed to count child repos: %w", err)
@@ -78,19 +96,58 @@ func (c *Controller) ListRepositoriesNoAuth(
 		return nil, 0, err
 	}
 
+	count += int64(len(pinned))
+	if filter.Page > 1 {
+		pinned = nil
+	}
+
+	pinnedOut, err := c.getRepoOutputs(ctx, pinned)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for _, repoOut := range pinnedOut {
+		repoOut.Pinned = true
+	}
+
+	reposOut, err := c.getRepoOutputs(ctx, repos)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return append(pinnedOut, reposOut...), count, nil
+}
+
+// getRepoOutputs backfills the topics and URLs of the repos and returns their output.
+func (c *Controller) getRepoOutputs(
+	ctx context.Context,
+	repos []*types.Repository,
+) ([]*repoCtrl.RepositoryOutput, error) {
+	repoIDs := make([]int64, len(repos))
+	for i, repo := range repos {
+		repoIDs[i] = repo.ID
+	}
+
+	topics, err := c.repoTopicStore.Map(ctx, repoIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list topics of child repos: %w", err)
+	}
+
 	reposOut := []*repoCtrl.RepositoryOutput{}
 	for _, repo := range repos {
+		repo.Topics = topics[repo.ID]
+
 		// backfill URLs
 		repo.GitURL = c.urlProvider.GenerateGITCloneURL(ctx, repo.Path)
 		repo.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(ctx, repo.Path)
 
 		repoOut, err := repoCtrl.GetRepoOutput(ctx, c.publicAccess, repo)
 		if err != nil {
-			return nil, 0, fmt.Errorf("failed to get repo %q output: %w", repo.Path, err)
+			return nil, fmt.Errorf("failed to get repo %q output: %w", repo.Path, err)
 		}
 
 		reposOut = append(reposOut, repoOut)
 	}
 
-	return reposOut, count, nil
+	return reposOut, nil
 }
@@ -21,13 +21,14 @@ import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+	"github.com/harness/gitness/types/listfilter"
 )
 
 /*
 * ListServiceAccounts lists the service accounts of a space.
  */
 func (c *Controller) ListServiceAccounts(ctx context.Context, session *auth.Session,
-	spaceRef string) ([]*types.ServiceAccount, error) {
+	spaceRef string, filter *listfilter.Filter) ([]*types.ServiceAccount, error) {
 	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
 	if err != nil {
 		return nil, err
@@ -47,5 +48,5 @@ func (c *Controller) ListServiceAccounts(ctx context.Context, session *auth.Sess
 		return nil, err
 	}
 
-	return c.principalStore.ListServiceAccounts(ctx, enum.ParentResourceTypeSpace, space.ID)
+	return c.principalStore.ListServiceAccounts(ctx, enum.ParentResourceTypeSpace, space.ID, filter)
 }
@@ -47,6 +47,9 @@ func (c *Controller) ListSpaces(ctx context.Context,
 		return nil, 0, err
 	}
 
+	// anonymous users are granted access through public access only, don't list private spaces.
+	filter.OnlyPublic = auth.IsAnonymousSession(session)
+
 	return c.ListSpacesNoAuth(ctx, space.ID, filter)
 }
 
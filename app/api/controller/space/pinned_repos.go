@@ -0,0 +1,296 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	repoCtrl "github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const pinnedReposLockExpiry = 30 * time.Second
+
+type PinRepoInput struct {
+	RepoID int64 `json:"repo_id"`
+}
+
+type ReorderPinnedReposInput struct {
+	// RepoIDs are the IDs of all pinned repositories of the space in their new order.
+	RepoIDs []int64 `json:"repo_ids"`
+}
+
+// ListPinnedRepos lists the pinned repositories of the space in their order.
+func (c *Controller) ListPinnedRepos(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) ([]*repoCtrl.RepositoryOutput, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpaceScope(
+		ctx,
+		c.authorizer,
+		session,
+		space,
+		enum.ResourceTypeRepo,
+		enum.PermissionRepoView,
+	); err != nil {
+		return nil, err
+	}
+
+	return c.getPinnedRepoOutputs(ctx, space.ID, &types.RepoFilter{
+		// anonymous users are granted access through public access only, don't list private repositories.
+		OnlyPublic: auth.IsAnonymousSession(session),
+	})
+}
+
+// PinRepo pins a repository of the space after the already pinned ones. Pinning a pinned repository is a no-op.
+func (c *Controller) PinRepo(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *PinRepoInput,
+) ([]*repoCtrl.RepositoryOutput, error) {
+	space, err := c.getSpaceCheckAuthPinnedRepos(ctx, session, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	repo, err := c.repoStore.Find(ctx, in.RepoID)
+	if errors.Is(err, store.ErrResourceNotFound) || (err == nil && repo.ParentID != space.ID) {
+		return nil, usererror.BadRequest("Only repositories of the space can be pinned.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	err = c.updatePinnedRepos(ctx, space.ID, func(pins []types.SpacePinnedRepo) ([]types.SpacePinnedRepo, error) {
+		if slices.ContainsFunc(pins, func(pin types.SpacePinnedRepo) bool { return pin.RepoID == repo.ID }) {
+			return pins, nil
+		}
+
+		if len(pins) >= c.maxPinnedRepos {
+			return nil, usererror.BadRequestf("A space can have at most %d pinned repositories.", c.maxPinnedRepos)
+		}
+
+		return append(pins, types.SpacePinnedRepo{
+			SpaceID:   space.ID,
+			RepoID:    repo.ID,
+			Created:   time.Now().UnixMilli(),
+			CreatedBy: session.Principal.ID,
+		}), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c.getPinnedRepoOutputs(ctx, space.ID, &types.RepoFilter{})
+}
+
+// UnpinRepo unpins a repository of the space. Unpinning a repository that isn't pinned is a no-op.
+func (c *Controller) UnpinRepo(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	repoID int64,
+) ([]*repoCtrl.RepositoryOutput, error) {
+	space, err := c.getSpaceCheckAuthPinnedRepos(ctx, session, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.updatePinnedRepos(ctx, space.ID, func(pins []types.SpacePinnedRepo) ([]types.SpacePinnedRepo, error) {
+		return slices.DeleteFunc(pins, func(pin types.SpacePinnedRepo) bool { return pin.RepoID == repoID }), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c.getPinnedRepoOutputs(ctx, space.ID, &types.RepoFilter{})
+}
+
+// ReorderPinnedRepos changes the order of the pinned repositories of the space.
+// The input has to contain all pinned repositories of the space.
+func (c *Controller) ReorderPinnedRepos(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *ReorderPinnedReposInput,
+) ([]*repoCtrl.RepositoryOutput, error) {
+	space, err := c.getSpaceCheckAuthPinnedRepos(ctx, session, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.updatePinnedRepos(ctx, space.ID, func(pins []types.SpacePinnedRepo) ([]types.SpacePinnedRepo, error) {
+		if len(in.RepoIDs) != len(pins) {
+			return nil, usererror.BadRequest("The new order has to contain all pinned repositories exactly once.")
+		}
+
+		reordered := make([]types.SpacePinnedRepo, 0, len(pins))
+		for _, repoID := range in.RepoIDs {
+			idx := slices.IndexFunc(pins, func(pin types.SpacePinnedRepo) bool { return pin.RepoID == repoID })
+			if idx < 0 || slices.ContainsFunc(reordered, func(pin types.SpacePinnedRepo) bool {
+				return pin.RepoID == repoID
+			}) {
+				return nil, usererror.BadRequest("The new order has to contain all pinned repositories exactly once.")
+			}
+
+			reordered = append(reordered, pins[idx])
+		}
+
+		return reordered, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c.getPinnedRepoOutputs(ctx, space.ID, &types.RepoFilter{})
+}
+
+func (c *Controller) getSpaceCheckAuthPinnedRepos(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) (*types.Space, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space: %w", err)
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit); err != nil {
+		return nil, fmt.Errorf("access check failed: %w", err)
+	}
+
+	return space, nil
+}
+
+// updatePinnedRepos replaces the pinned repos of the space with the ones returned by the provided function.
+// The pins are locked for the duration of the update, so concurrent updates can't interleave.
+func (c *Controller) updatePinnedRepos(
+	ctx context.Context,
+	spaceID int64,
+	fn func(pins []types.SpacePinnedRepo) ([]types.SpacePinnedRepo, error),
+) error {
+	unlock, err := c.locker.LockPinnedRepos(ctx, spaceID, pinnedReposLockExpiry)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return c.tx.WithTx(ctx, func(ctx context.Context) error {
+		// stale pins are omitted, replacing the pins removes them.
+		pins, err := c.pinnedRepoStore.List(ctx, spaceID)
+		if err != nil {
+			return fmt.Errorf("failed to list pinned repos: %w", err)
+		}
+
+		pins, err = fn(pins)
+		if err != nil {
+			return err
+		}
+
+		if err = c.pinnedRepoStore.Replace(ctx, spaceID, pins); err != nil {
+			return fmt.Errorf("failed to update pinned repos: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (c *Controller) getPinnedRepoOutputs(
+	ctx context.Context,
+	spaceID int64,
+	filter *types.RepoFilter,
+) ([]*repoCtrl.RepositoryOutput, error) {
+	var pinned []*types.Repository
+	err := c.tx.WithTx(ctx, func(ctx context.Context) (err error) {
+		pinned, err = c.listPinnedRepos(ctx, spaceID, filter)
+		return err
+	}, dbtx.TxDefaultReadOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	reposOut, err := c.getRepoOutputs(ctx, pinned)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, repoOut := range reposOut {
+		repoOut.Pinned = true
+	}
+
+	return reposOut, nil
+}
+
+// listPinnedRepos returns the pinned repos of the space matching the filter, in the order they're pinned.
+// The pagination and sorting of the filter are ignored, and deleted repos are never pinned.
+func (c *Controller) listPinnedRepos(
+	ctx context.Context,
+	spaceID int64,
+	filter *types.RepoFilter,
+) ([]*types.Repository, error) {
+	if filter.DeletedAt != nil || filter.DeletedBeforeOrAt != nil {
+		return nil, nil
+	}
+
+	pins, err := c.pinnedRepoStore.List(ctx, spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pinned repos: %w", err)
+	}
+
+	if len(pins) == 0 {
+		return nil, nil
+	}
+
+	repoIDs := make([]int64, len(pins))
+	for i, pin := range pins {
+		repoIDs[i] = pin.RepoID
+	}
+
+	repos, err := c.repoStore.List(ctx, spaceID, &types.RepoFilter{
+		Page:           1,
+		Size:           len(repoIDs),
+		Query:          filter.Query,
+		OnlyPublic:     filter.OnlyPublic,
+		Topics:         filter.Topics,
+		TopicsMatchAll: filter.TopicsMatchAll,
+		RepoIDs:        repoIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pinned repos: %w", err)
+	}
+
+	slices.SortFunc(repos, func(a, b *types.Repository) int {
+		return slices.Index(repoIDs, a.ID) - slices.Index(repoIDs, b.ID)
+	})
+
+	return repos, nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/repobulk"
+	"github.com/harness/gitness/types/enum"
+)
+
+// BulkRepos starts a background job that applies the operation to the selected repositories of the space.
+// The permission to edit is checked for every repository, repositories without access are skipped.
+// With dryRun set, no job is started and the report of what the job would do is returned instead.
+func (c *Controller) BulkRepos(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *repobulk.Input,
+	dryRun bool,
+) (*repobulk.Progress, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView); err != nil {
+		return nil, err
+	}
+
+	if dryRun {
+		return c.repoBulk.DryRun(ctx, space.ID, session.Principal.ID, in)
+	}
+
+	jobUID, err := c.repoBulk.Run(ctx, space.ID, session.Principal.ID, in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start repository bulk operation: %w", err)
+	}
+
+	return c.repoBulk.GetProgress(ctx, space.ID, session.Principal.ID, jobUID)
+}
+
+// BulkReposProgress returns the progress and the per repository report of a bulk operation.
+// Only the principal that started the bulk operation has access to it.
+func (c *Controller) BulkReposProgress(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	jobUID string,
+) (*repobulk.Progress, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView); err != nil {
+		return nil, err
+	}
+
+	progress, err := c.repoBulk.GetProgress(ctx, space.ID, session.Principal.ID, jobUID)
+	if errors.Is(err, repobulk.ErrNotFound) {
+		return nil, usererror.NotFound("Repository bulk operation not found.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository bulk operation progress: %w", err)
+	}
+
+	return progress, nil
+}
@@ -27,14 +27,18 @@ import (
 )
 
 type SoftDeleteResponse struct {
-	DeletedAt int64 `json:"deleted_at"`
+	DeletedAt int64                    `json:"deleted_at"`
+	DryRun    bool                     `json:"dry_run,omitempty"`
+	Affected  []types.AffectedResource `json:"affected,omitempty"`
 }
 
 // SoftDelete marks deleted timestamp for the space and all its subspaces and repositories inside.
+// With dryRun set, all checks are performed and the affected resources are reported, but nothing is deleted.
 func (c *Controller) SoftDelete(
 	ctx context.Context,
 	session *auth.Session,
 	spaceRef string,
+	dryRun bool,
 ) (*SoftDeleteResponse, error) {
 	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
 	if err != nil {
@@ -51,6 +55,19 @@ func (c *Controller) SoftDelete(
 		return nil, fmt.Errorf("failed to check access: %w", err)
 	}
 
+	if dryRun {
+		subSpaces, repos, errList := c.listSoftDeleteTargets(ctx, space.ID)
+		if errList != nil {
+			return nil, errList
+		}
+
+		return &SoftDeleteResponse{
+			DeletedAt: time.Now().UnixMilli(),
+			DryRun:    true,
+			Affected:  softDeleteAffected(space, subSpaces, repos),
+		}, nil
+	}
+
 	return c.SoftDeleteNoAuth(ctx, session, space)
 }
 
@@ -87,18 +104,10 @@ func (c *Controller) softDeleteInnerInTx(
 	if err != nil {
 		return nil, fmt.Errorf("failed to lock the space for update: %w", err)
 	}
-	filter := &types.SpaceFilter{
-		Page:              1,
-		Size:              math.MaxInt,
-		Query:             "",
-		Order:             enum.OrderAsc,
-		Sort:              enum.SpaceAttrCreated,
-		DeletedBeforeOrAt: nil, // only filter active subspaces
-		Recursive:         true,
-	}
-	subSpaces, err := c.spaceStore.List(ctx, space.ID, filter)
+
+	subSpaces, repos, err := c.listSoftDeleteTargets(ctx, space.ID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list space %d sub spaces recursively: %w", space.ID, err)
+		return nil, err
 	}
 
 	now := time.Now().UnixMilli()
@@ -114,7 +123,7 @@ func (c *Controller) softDeleteInnerInTx(
 		}
 	}
 
-	err = c.softDeleteRepositoriesNoAuth(ctx, session, space.ID, now)
+	err = c.softDeleteRepositoriesNoAuth(ctx, session, repos, now)
 	if err != nil {
 		return nil, fmt.Errorf("failed to soft delete repositories of space %d: %w", space.ID, err)
 	}
@@ -128,18 +137,33 @@ func (c *Controller) softDeleteInnerInTx(
 		return nil, fmt.Errorf("spacePathStore failed to delete descendant paths of %d: %w", space.ID, err)
 	}
 
-	return &SoftDeleteResponse{DeletedAt: now}, nil
+	return &SoftDeleteResponse{
+		DeletedAt: now,
+		Affected:  softDeleteAffected(space, subSpaces, repos),
+	}, nil
 }
 
-// softDeleteRepositoriesNoAuth soft deletes all repositories in a space - no authorization is verified.
-// WARNING For internal calls only.
-func (c *Controller) softDeleteRepositoriesNoAuth(
+// listSoftDeleteTargets returns the active sub spaces (recursively) and repositories of the space,
+// which are soft deleted together with the space.
+func (c *Controller) listSoftDeleteTargets(
 	ctx context.Context,
-	session *auth.Session,
 	spaceID int64,
-	deletedAt int64,
-) error {
-	filter := &types.RepoFilter{
+) ([]*types.Space, []*types.Repository, error) {
+	spaceFilter := &types.SpaceFilter{
+		Page:              1,
+		Size:              math.MaxInt,
+		Query:             "",
+		Order:             enum.OrderAsc,
+		Sort:              enum.SpaceAttrCreated,
+		DeletedBeforeOrAt: nil, // only filter active subspaces
+		Recursive:         true,
+	}
+	subSpaces, err := c.spaceStore.List(ctx, spaceID, spaceFilter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list space %d sub spaces recursively: %w", spaceID, err)
+	}
+
+	repoFilter := &types.RepoFilter{
 		Page:              1,
 		Size:              int(math.MaxInt),
 		Query:             "",
@@ -148,16 +172,58 @@ func (c *Controller) softDeleteRepositoriesNoAuth(
 		DeletedBeforeOrAt: nil, // only filter active repos
 		Recursive:         true,
 	}
-	repos, err := c.repoStore.List(ctx, spaceID, filter)
+	repos, err := c.repoStore.List(ctx, spaceID, repoFilter)
 	if err != nil {
-		return fmt.Errorf("failed to list space repositories: %w", err)
+		return nil, nil, fmt.Errorf("failed to list space repositories: %w", err)
 	}
 
+	return subSpaces, repos, nil
+}
+
+// softDeleteRepositoriesNoAuth soft deletes the repositories of a space - no authorization is verified.
+// WARNING For internal calls only.
+func (c *Controller) softDeleteRepositoriesNoAuth(
+	ctx context.Context,
+	session *auth.Session,
+	repos []*types.Repository,
+	deletedAt int64,
+) error {
 	for _, repo := range repos {
-		err = c.repoCtrl.SoftDeleteNoAuth(ctx, session, repo, deletedAt)
+		err := c.repoCtrl.SoftDeleteNoAuth(ctx, session, repo, deletedAt)
 		if err != nil {
 			return fmt.Errorf("failed to soft delete repository: %w", err)
 		}
 	}
 	return nil
 }
+
+func softDeleteAffected(
+	space *types.Space,
+	subSpaces []*types.Space,
+	repos []*types.Repository,
+) []types.AffectedResource {
+	affected := make([]types.AffectedResource, 0, 1+len(subSpaces)+len(repos))
+	affected = append(affected, types.AffectedResource{
+		Type: enum.AffectedResourceTypeSpace,
+		ID:   space.ID,
+		Name: space.Path,
+	})
+
+	for _, subSpace := range subSpaces {
+		affected = append(affected, types.AffectedResource{
+			Type: enum.AffectedResourceTypeSpace,
+			ID:   subSpace.ID,
+			Name: subSpace.Path,
+		})
+	}
+
+	for _, repo := range repos {
+		affected = append(affected, types.AffectedResource{
+			Type: enum.AffectedResourceTypeRepo,
+			ID:   repo.ID,
+			Name: repo.Path,
+		})
+	}
+
+	return affected
+}
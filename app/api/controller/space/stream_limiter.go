@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"sync"
+)
+
+// streamLimiter limits the number of concurrent event streams per principal.
+type streamLimiter struct {
+	max int
+
+	mx      sync.Mutex
+	streams map[int64]int
+}
+
+func newStreamLimiter(maxStreams int) *streamLimiter {
+	return &streamLimiter{
+		max:     maxStreams,
+		streams: make(map[int64]int),
+	}
+}
+
+// acquire reserves a stream for the principal, it returns false if the principal has no streams left.
+// A non-positive maximum disables the limit.
+func (l *streamLimiter) acquire(principalID int64) bool {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	if l.max > 0 && l.streams[principalID] >= l.max {
+		return false
+	}
+
+	l.streams[principalID]++
+	return true
+}
+
+// release frees a stream previously reserved for the principal.
+func (l *streamLimiter) release(principalID int64) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	l.streams[principalID]--
+	if l.streams[principalID] <= 0 {
+		delete(l.streams, principalID)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type TopicRenameInput struct {
+	Topic    string `json:"topic"`
+	NewTopic string `json:"new_topic"`
+}
+
+func (in *TopicRenameInput) sanitize() error {
+	in.Topic = strings.ToLower(strings.TrimSpace(in.Topic))
+	in.NewTopic = strings.ToLower(strings.TrimSpace(in.NewTopic))
+
+	if in.Topic == "" {
+		return usererror.BadRequest("Topic is required.")
+	}
+
+	if err := check.RepoTopic(in.NewTopic); err != nil {
+		return err
+	}
+
+	if in.Topic == in.NewTopic {
+		return usererror.BadRequest("The new topic must be different from the current one.")
+	}
+
+	return nil
+}
+
+type TopicRenameOutput struct {
+	// RepoCount is the number of repositories the topic got renamed for.
+	RepoCount int `json:"repo_count"`
+}
+
+// RenameTopic renames the topic of all repositories in the space and its subspaces.
+// Repositories that already have the new topic just lose the old one.
+func (c *Controller) RenameTopic(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *TopicRenameInput,
+) (*TopicRenameOutput, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space: %w", err)
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit); err != nil {
+		return nil, fmt.Errorf("access check failed: %w", err)
+	}
+
+	var repoIDs []int64
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		repoIDs, err = c.repoTopicStore.Rename(ctx, space.ID, in.Topic, in.NewTopic)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to rename repo topic: %w", err)
+	}
+
+	for _, repoID := range repoIDs {
+		c.auditTopicRename(ctx, session, repoID, in)
+	}
+
+	return &TopicRenameOutput{RepoCount: len(repoIDs)}, nil
+}
+
+func (c *Controller) auditTopicRename(
+	ctx context.Context,
+	session *auth.Session,
+	repoID int64,
+	in *TopicRenameInput,
+) {
+	repo, err := c.repoStore.Find(ctx, repoID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to find repo %d for topic rename audit log", repoID)
+		return
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeRepositorySettings, repo.Identifier),
+		audit.ActionUpdated,
+		paths.Parent(repo.Path),
+		audit.WithData("topic", in.Topic, "new_topic", in.NewTopic),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for rename repo topic operation: %s", err)
+	}
+}
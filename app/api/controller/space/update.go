@@ -20,6 +20,7 @@ import (
 	"strings"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
@@ -28,11 +29,19 @@ import (
 
 // UpdateInput is used for updating a space.
 type UpdateInput struct {
-	Description *string `json:"description"`
+	Description    *string `json:"description"`
+	ExecutionLimit *int    `json:"execution_limit"`
 }
 
 func (in *UpdateInput) hasChanges(space *types.Space) bool {
-	return in.Description != nil && *in.Description != space.Description
+	if in.Description != nil && *in.Description != space.Description {
+		return true
+	}
+	if in.ExecutionLimit != nil && *in.ExecutionLimit != space.ExecutionLimit {
+		return true
+	}
+
+	return false
 }
 
 // Update updates a space.
@@ -64,6 +73,9 @@ func (c *Controller) Update(
 		if in.Description != nil {
 			space.Description = *in.Description
 		}
+		if in.ExecutionLimit != nil {
+			space.ExecutionLimit = *in.ExecutionLimit
+		}
 
 		return nil
 	})
@@ -82,5 +94,9 @@ func (c *Controller) sanitizeUpdateInput(in *UpdateInput) error {
 		}
 	}
 
+	if in.ExecutionLimit != nil && *in.ExecutionLimit < 0 {
+		return usererror.BadRequest("Execution limit can't be negative.")
+	}
+
 	return nil
 }
@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// defaultUsageReportDays is the number of days covered by a usage report if no start is provided.
+const defaultUsageReportDays = 30
+
+// Usage returns the daily CI minutes and storage consumption of the child spaces and repositories of a space
+// for the time range [from, to] (unix millis). If to is zero, the report ends today,
+// if from is zero, the report covers the last 30 days.
+func (c *Controller) Usage(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	from int64,
+	to int64,
+) (*types.UsageReport, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space: %w", err)
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit); err != nil {
+		return nil, fmt.Errorf("access check failed: %w", err)
+	}
+
+	if to == 0 {
+		to = time.Now().UnixMilli()
+	}
+	if from == 0 {
+		from = time.UnixMilli(to).AddDate(0, 0, -(defaultUsageReportDays - 1)).UnixMilli()
+	}
+
+	report, err := c.usage.Report(ctx, space, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create usage report: %w", err)
+	}
+
+	return report, nil
+}
@@ -18,13 +18,21 @@ import (
 	"github.com/harness/gitness/app/api/controller/limiter"
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/commitpolicy"
 	"github.com/harness/gitness/app/services/exporter"
 	"github.com/harness/gitness/app/services/gitspace"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/operation"
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/recentvisit"
+	"github.com/harness/gitness/app/services/repobulk"
+	"github.com/harness/gitness/app/services/spacearchive"
+	"github.com/harness/gitness/app/services/spacefeed"
+	"github.com/harness/gitness/app/services/usage"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -48,19 +56,37 @@ func ProvideController(config *types.Config, tx dbtx.Transactor, urlProvider url
 	spaceStore store.SpaceStore, repoStore store.RepoStore, principalStore store.PrincipalStore,
 	repoCtrl *repo.Controller, membershipStore store.MembershipStore, prListService *pullreq.ListService,
 	importer *importer.Repository,
-	exporter *exporter.Repository, limiter limiter.ResourceLimiter, publicAccess publicaccess.Service,
+	exporter *exporter.Repository, spaceArchive *spacearchive.Service, limiter limiter.ResourceLimiter, publicAccess publicaccess.Service,
 	auditService audit.Service, gitspaceService *gitspace.Service,
 	labelSvc *label.Service,
 	instrumentation instrument.Service,
+	recentVisits *recentvisit.Service,
+	feed *spacefeed.Service,
+	usage *usage.Service,
+	commitPolicy *commitpolicy.Service,
+	repoTopicStore store.RepoTopicStore,
+	pinnedRepoStore store.SpacePinnedRepoStore,
+	locker *locker.Locker,
+	repoBulk *repobulk.Service,
+	operationSvc *operation.Service,
 ) *Controller {
 	return NewController(config, tx, urlProvider, sseStreamer, identifierCheck, authorizer,
 		spacePathStore, pipelineStore, secretStore,
 		connectorStore, templateStore,
 		spaceStore, repoStore, principalStore,
 		repoCtrl, membershipStore, prListService, importer,
-		exporter, limiter, publicAccess,
+		exporter, spaceArchive, limiter, publicAccess,
 		auditService, gitspaceService,
 		labelSvc,
 		instrumentation,
+		recentVisits,
+		feed,
+		usage,
+		commitPolicy,
+		repoTopicStore,
+		pinnedRepoStore,
+		locker,
+		repoBulk,
+		operationSvc,
 	)
 }
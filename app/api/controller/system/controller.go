@@ -17,19 +17,47 @@ package system
 import (
 	"context"
 
+	"github.com/harness/gitness/app/services/counters"
+	"github.com/harness/gitness/app/services/featureflag"
+	"github.com/harness/gitness/app/services/gitlimit"
+	"github.com/harness/gitness/app/services/instancesettings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/types"
 )
 
 type Controller struct {
-	principalStore store.PrincipalStore
-	config         *types.Config
+	principalStore    store.PrincipalStore
+	config            *types.Config
+	instanceSettings  *instancesettings.Service
+	schemaStore       store.SchemaStore
+	counterReconciler *counters.Reconciler
+	installationStore store.InstallationStore
+	spaceStore        store.SpaceStore
+	featureFlags      *featureflag.Service
+	gitLimit          *gitlimit.Service
 }
 
-func NewController(principalStore store.PrincipalStore, config *types.Config) *Controller {
+func NewController(
+	principalStore store.PrincipalStore,
+	config *types.Config,
+	instanceSettings *instancesettings.Service,
+	schemaStore store.SchemaStore,
+	counterReconciler *counters.Reconciler,
+	installationStore store.InstallationStore,
+	spaceStore store.SpaceStore,
+	featureFlags *featureflag.Service,
+	gitLimit *gitlimit.Service,
+) *Controller {
 	return &Controller{
-		principalStore: principalStore,
-		config:         config,
+		principalStore:    principalStore,
+		config:            config,
+		instanceSettings:  instanceSettings,
+		schemaStore:       schemaStore,
+		counterReconciler: counterReconciler,
+		installationStore: installationStore,
+		spaceStore:        spaceStore,
+		featureFlags:      featureFlags,
+		gitLimit:          gitLimit,
 	}
 }
 
@@ -41,3 +69,8 @@ func (c *Controller) IsUserSignupAllowed(ctx context.Context) (bool, error) {
 
 	return usrCount == 0 || c.config.UserSignupEnabled, nil
 }
+
+// IsPublicResourceCreationEnabled returns whether users are allowed to create publicly accessible resources.
+func (c *Controller) IsPublicResourceCreationEnabled() bool {
+	return c.instanceSettings.PublicResourceCreationEnabled()
+}
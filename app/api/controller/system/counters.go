@@ -0,0 +1,91 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/counters"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types/enum"
+)
+
+type ReconcileCountersInput struct {
+	// Counters are the counters to reconcile. All counters are reconciled if empty.
+	Counters []enum.CounterType `json:"counters"`
+}
+
+func (in *ReconcileCountersInput) sanitize() error {
+	for i, counter := range in.Counters {
+		var ok bool
+		if in.Counters[i], ok = counter.Sanitize(); !ok {
+			return usererror.BadRequestf("Unknown counter type %q.", counter)
+		}
+	}
+
+	return nil
+}
+
+// ReconcileCounters starts a background job that recomputes the denormalized counters
+// and corrects the ones that drifted from their source of truth.
+func (c *Controller) ReconcileCounters(
+	ctx context.Context,
+	session *auth.Session,
+	in *ReconcileCountersInput,
+) error {
+	if !session.Principal.Admin {
+		return usererror.ErrForbidden
+	}
+
+	if err := in.sanitize(); err != nil {
+		return err
+	}
+
+	err := c.counterReconciler.Trigger(ctx, in.Counters)
+	if errors.Is(err, counters.ErrJobRunning) {
+		return usererror.ConflictWithPayload("counter reconciliation already in progress")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to start counter reconciliation job: %w", err)
+	}
+
+	return nil
+}
+
+// ReconcileCountersProgress returns the progress of the latest counter reconciliation.
+// The result of a completed reconciliation contains the drift found for each counter.
+func (c *Controller) ReconcileCountersProgress(
+	ctx context.Context,
+	session *auth.Session,
+) (job.Progress, error) {
+	if !session.Principal.Admin {
+		return job.Progress{}, usererror.ErrForbidden
+	}
+
+	progress, err := c.counterReconciler.GetProgress(ctx)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return job.Progress{}, usererror.NotFound("No counter reconciliation found")
+	}
+	if err != nil {
+		return job.Progress{}, err
+	}
+
+	return progress, nil
+}
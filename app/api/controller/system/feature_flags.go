@@ -0,0 +1,172 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// UpdateFeatureFlagInput is the input for overriding the state of a feature flag.
+type UpdateFeatureFlagInput struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// ListFeatureFlags lists all feature flags with their state.
+func (c *Controller) ListFeatureFlags(_ context.Context, session *auth.Session) ([]*types.FeatureFlag, error) {
+	if !session.Principal.Admin {
+		return nil, usererror.ErrForbidden
+	}
+
+	return c.featureFlags.List(), nil
+}
+
+// FindFeatureFlag returns a feature flag with its state.
+func (c *Controller) FindFeatureFlag(
+	_ context.Context,
+	session *auth.Session,
+	name string,
+) (*types.FeatureFlag, error) {
+	if !session.Principal.Admin {
+		return nil, usererror.ErrForbidden
+	}
+
+	return c.featureFlags.Find(name)
+}
+
+// UpdateFeatureFlag overrides the state of a feature flag for the whole instance.
+// The new state is picked up by all instances within seconds.
+func (c *Controller) UpdateFeatureFlag(
+	ctx context.Context,
+	session *auth.Session,
+	name string,
+	in *UpdateFeatureFlagInput,
+) (*types.FeatureFlag, error) {
+	return c.updateFeatureFlag(ctx, session, name, "", in)
+}
+
+// UpdateSpaceFeatureFlag overrides the state of a feature flag for a space, its subspaces and repositories.
+func (c *Controller) UpdateSpaceFeatureFlag(
+	ctx context.Context,
+	session *auth.Session,
+	name string,
+	spaceRef string,
+	in *UpdateFeatureFlagInput,
+) (*types.FeatureFlag, error) {
+	return c.updateFeatureFlag(ctx, session, name, spaceRef, in)
+}
+
+// ResetFeatureFlag removes the instance-wide override of a feature flag, which restores its default.
+func (c *Controller) ResetFeatureFlag(
+	ctx context.Context,
+	session *auth.Session,
+	name string,
+) (*types.FeatureFlag, error) {
+	return c.resetFeatureFlag(ctx, session, name, "")
+}
+
+// ResetSpaceFeatureFlag removes the override of a feature flag for a space.
+func (c *Controller) ResetSpaceFeatureFlag(
+	ctx context.Context,
+	session *auth.Session,
+	name string,
+	spaceRef string,
+) (*types.FeatureFlag, error) {
+	return c.resetFeatureFlag(ctx, session, name, spaceRef)
+}
+
+func (c *Controller) updateFeatureFlag(
+	ctx context.Context,
+	session *auth.Session,
+	name string,
+	spaceRef string,
+	in *UpdateFeatureFlagInput,
+) (*types.FeatureFlag, error) {
+	if !session.Principal.Admin {
+		return nil, usererror.ErrForbidden
+	}
+
+	if in.Enabled == nil {
+		return nil, usererror.BadRequest("The state of the feature flag is required.")
+	}
+
+	spaceID, err := c.findFeatureFlagSpaceID(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	flag, err := c.featureFlags.Set(ctx, name, spaceID, *in.Enabled, session.Principal.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Ctx(ctx).Info().
+		Str("feature_flag", flag.Name).
+		Bool("feature_flag_enabled", *in.Enabled).
+		Str("space_ref", spaceRef).
+		Int64("principal_id", session.Principal.ID).
+		Msg("feature flag updated")
+
+	return flag, nil
+}
+
+func (c *Controller) resetFeatureFlag(
+	ctx context.Context,
+	session *auth.Session,
+	name string,
+	spaceRef string,
+) (*types.FeatureFlag, error) {
+	if !session.Principal.Admin {
+		return nil, usererror.ErrForbidden
+	}
+
+	spaceID, err := c.findFeatureFlagSpaceID(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	flag, err := c.featureFlags.Reset(ctx, name, spaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Ctx(ctx).Info().
+		Str("feature_flag", flag.Name).
+		Str("space_ref", spaceRef).
+		Int64("principal_id", session.Principal.ID).
+		Msg("feature flag override removed")
+
+	return flag, nil
+}
+
+// findFeatureFlagSpaceID returns the id of the space with the provided ref, or nil for instance-wide overrides.
+func (c *Controller) findFeatureFlagSpaceID(ctx context.Context, spaceRef string) (*int64, error) {
+	if spaceRef == "" {
+		return nil, nil //nolint:nilnil // no space means the override is instance-wide
+	}
+
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space: %w", err)
+	}
+
+	return &space.ID, nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// UpdateGitOperationLimitInput is the input for overriding the git operation limit of a principal.
+type UpdateGitOperationLimitInput struct {
+	MaxConcurrent *int `json:"max_concurrent"`
+}
+
+// ListGitOperations lists the principals and anonymous client IPs with in-flight git operations
+// on this instance, ordered by the number of in-flight operations.
+func (c *Controller) ListGitOperations(
+	ctx context.Context,
+	session *auth.Session,
+) ([]*types.GitOperationsInFlight, error) {
+	if !session.Principal.Admin {
+		return nil, usererror.ErrForbidden
+	}
+
+	return c.gitLimit.InFlight(ctx)
+}
+
+// ListGitOperationLimits lists the principals with an overridden git operation limit.
+func (c *Controller) ListGitOperationLimits(
+	ctx context.Context,
+	session *auth.Session,
+) ([]*types.GitOperationLimit, error) {
+	if !session.Principal.Admin {
+		return nil, usererror.ErrForbidden
+	}
+
+	return c.gitLimit.ListLimits(ctx)
+}
+
+// UpdateGitOperationLimit overrides the maximum number of concurrent git operations of a principal.
+func (c *Controller) UpdateGitOperationLimit(
+	ctx context.Context,
+	session *auth.Session,
+	principalID int64,
+	in *UpdateGitOperationLimitInput,
+) (*types.GitOperationLimit, error) {
+	if !session.Principal.Admin {
+		return nil, usererror.ErrForbidden
+	}
+
+	if in.MaxConcurrent == nil {
+		return nil, usererror.BadRequest("The maximum number of concurrent git operations is required.")
+	}
+
+	principal, err := c.principalStore.Find(ctx, principalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find principal: %w", err)
+	}
+
+	limit, err := c.gitLimit.SetLimit(ctx, principal.ID, *in.MaxConcurrent, session.Principal.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	limit.Principal = principal.ToPrincipalInfo()
+
+	log.Ctx(ctx).Info().
+		Int64("git_operation_limit_principal_id", principal.ID).
+		Int("git_operation_limit_max_concurrent", limit.MaxConcurrent).
+		Int64("principal_id", session.Principal.ID).
+		Msg("git operation limit updated")
+
+	return limit, nil
+}
+
+// ResetGitOperationLimit removes the git operation limit override of a principal,
+// which restores the instance-wide limit.
+func (c *Controller) ResetGitOperationLimit(
+	ctx context.Context,
+	session *auth.Session,
+	principalID int64,
+) error {
+	if !session.Principal.Admin {
+		return usererror.ErrForbidden
+	}
+
+	if err := c.gitLimit.ResetLimit(ctx, principalID); err != nil {
+		return err
+	}
+
+	log.Ctx(ctx).Info().
+		Int64("git_operation_limit_principal_id", principalID).
+		Int64("principal_id", session.Principal.ID).
+		Msg("git operation limit removed")
+
+	return nil
+}
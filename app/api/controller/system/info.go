@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/version"
+)
+
+// SystemInfo returns the installation record, version, database driver, enabled features
+// and feature flags of the instance.
+func (c *Controller) SystemInfo(
+	ctx context.Context,
+	session *auth.Session,
+) (*types.SystemInfo, error) {
+	if !session.Principal.Admin {
+		return nil, usererror.ErrForbidden
+	}
+
+	installation, err := c.installationStore.Find(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find installation record: %w", err)
+	}
+
+	return &types.SystemInfo{
+		Installation:   installation,
+		Version:        version.Version.String(),
+		DatabaseDriver: c.config.Database.Driver,
+		Features: map[string]bool{
+			"ssh":                      c.config.SSH.Enable,
+			"gitspace":                 c.config.Gitspace.Enable,
+			"artifact_registry":        c.config.Registry.Enable,
+			"oidc":                     c.config.OIDC.Enabled,
+			"user_signup":              c.config.UserSignupEnabled,
+			"public_resource_creation": c.instanceSettings.PublicResourceCreationEnabled(),
+		},
+		FeatureFlags: c.featureFlags.List(),
+	}, nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// UpdateSettingInput is the input for changing the value of an instance setting.
+type UpdateSettingInput struct {
+	Value json.RawMessage `json:"value"`
+}
+
+// ListSettings lists all instance settings with their current and default values.
+func (c *Controller) ListSettings(_ context.Context, session *auth.Session) ([]*types.InstanceSetting, error) {
+	if !session.Principal.Admin {
+		return nil, usererror.ErrForbidden
+	}
+
+	return c.instanceSettings.List(), nil
+}
+
+// FindSetting returns an instance setting with its current and default value.
+func (c *Controller) FindSetting(
+	_ context.Context,
+	session *auth.Session,
+	key string,
+) (*types.InstanceSetting, error) {
+	if !session.Principal.Admin {
+		return nil, usererror.ErrForbidden
+	}
+
+	return c.instanceSettings.Find(key)
+}
+
+// UpdateSetting changes the value of an instance setting at runtime.
+// The new value is picked up by all instances within seconds.
+func (c *Controller) UpdateSetting(
+	ctx context.Context,
+	session *auth.Session,
+	key string,
+	in *UpdateSettingInput,
+) (*types.InstanceSetting, error) {
+	if !session.Principal.Admin {
+		return nil, usererror.ErrForbidden
+	}
+
+	if len(in.Value) == 0 || string(in.Value) == "null" {
+		return nil, usererror.BadRequest("A value is required.")
+	}
+
+	setting, err := c.instanceSettings.Update(ctx, key, in.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Ctx(ctx).Info().
+		Str("setting_key", setting.Key).
+		Interface("setting_value", setting.Value).
+		Int64("principal_id", session.Principal.ID).
+		Msg("instance setting updated")
+
+	return setting, nil
+}
+
+// ResetSetting removes the runtime value of an instance setting,
+// which restores the default value from the environment configuration.
+func (c *Controller) ResetSetting(
+	ctx context.Context,
+	session *auth.Session,
+	key string,
+) (*types.InstanceSetting, error) {
+	if !session.Principal.Admin {
+		return nil, usererror.ErrForbidden
+	}
+
+	setting, err := c.instanceSettings.Reset(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Ctx(ctx).Info().
+		Str("setting_key", setting.Key).
+		Int64("principal_id", session.Principal.ID).
+		Msg("instance setting reset to default")
+
+	return setting, nil
+}
@@ -15,6 +15,10 @@
 package system
 
 import (
+	"github.com/harness/gitness/app/services/counters"
+	"github.com/harness/gitness/app/services/featureflag"
+	"github.com/harness/gitness/app/services/gitlimit"
+	"github.com/harness/gitness/app/services/instancesettings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/types"
 
@@ -26,6 +30,17 @@ var WireSet = wire.NewSet(
 	NewController,
 )
 
-func ProvideController(principalStore store.PrincipalStore, config *types.Config) *Controller {
-	return NewController(principalStore, config)
+func ProvideController(
+	principalStore store.PrincipalStore,
+	config *types.Config,
+	instanceSettings *instancesettings.Service,
+	schemaStore store.SchemaStore,
+	counterReconciler *counters.Reconciler,
+	installationStore store.InstallationStore,
+	spaceStore store.SpaceStore,
+	featureFlags *featureflag.Service,
+	gitLimit *gitlimit.Service,
+) *Controller {
+	return NewController(principalStore, config, instanceSettings, schemaStore, counterReconciler,
+		installationStore, spaceStore, featureFlags, gitLimit)
 }
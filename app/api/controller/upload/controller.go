@@ -23,9 +23,11 @@ import (
 	"strings"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/instancesettings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/types"
@@ -35,7 +37,6 @@ import (
 )
 
 const (
-	MaxFileSize       = 10 << 20 // 10 MB file limit set in Handler
 	fileBucketPathFmt = "uploads/%d/%s"
 	peekBytes         = 512
 )
@@ -46,21 +47,29 @@ var supportedFileTypes = map[string]struct{}{
 }
 
 type Controller struct {
-	authorizer authz.Authorizer
-	repoStore  store.RepoStore
-	blobStore  blob.Store
+	authorizer       authz.Authorizer
+	repoStore        store.RepoStore
+	blobStore        blob.Store
+	instanceSettings *instancesettings.Service
 }
 
 func NewController(authorizer authz.Authorizer,
 	repoStore store.RepoStore,
 	blobStore blob.Store,
+	instanceSettings *instancesettings.Service,
 ) *Controller {
 	return &Controller{
-		authorizer: authorizer,
-		repoStore:  repoStore,
-		blobStore:  blobStore,
+		authorizer:       authorizer,
+		repoStore:        repoStore,
+		blobStore:        blobStore,
+		instanceSettings: instanceSettings,
 	}
 }
+
+// MaxFileSize returns the maximum size of an uploaded file in bytes, which is enforced by the handler.
+func (c *Controller) MaxFileSize() int64 {
+	return c.instanceSettings.UploadMaxFileSize()
+}
 func (c *Controller) getRepoCheckAccess(ctx context.Context,
 	session *auth.Session,
 	repoRef string,
@@ -75,6 +84,8 @@ func (c *Controller) getRepoCheckAccess(ctx context.Context,
 		return nil, fmt.Errorf("failed to find repo: %w", err)
 	}
 
+	controller.WithRepo(ctx, repo)
+
 	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, permission); err != nil {
 		return nil, fmt.Errorf("failed to verify authorization: %w", err)
 	}
@@ -16,8 +16,20 @@ package user
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
 
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/auth/oidc"
+	"github.com/harness/gitness/app/services/avatar"
+	"github.com/harness/gitness/app/services/loginprotection"
+	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/app/services/recentvisit"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
@@ -28,13 +40,30 @@ import (
 )
 
 type Controller struct {
-	tx                dbtx.Transactor
-	principalUIDCheck check.PrincipalUID
-	authorizer        authz.Authorizer
-	principalStore    store.PrincipalStore
-	tokenStore        store.TokenStore
-	membershipStore   store.MembershipStore
-	publicKeyStore    store.PublicKeyStore
+	tx                 dbtx.Transactor
+	principalUIDCheck  check.PrincipalUID
+	authorizer         authz.Authorizer
+	principalStore     store.PrincipalStore
+	tokenStore         store.TokenStore
+	tokenCache         store.PrincipalTokenCache
+	membershipStore    store.MembershipStore
+	publicKeyStore     store.PublicKeyStore
+	publicKeyService   publickey.Service
+	passwordResetStore store.PasswordResetStore
+	emailChangeStore   store.EmailChangeStore
+	mailer             mailer.Mailer
+	// mailEnabled is true if emails can be sent (required for verifying email changes).
+	mailEnabled     bool
+	preferenceStore store.UserPreferenceStore
+	recentVisits    *recentvisit.Service
+	avatars         *avatar.Service
+	loginProtection *loginprotection.Service
+	// oidcProvider is the identity provider used for single sign-on, if enabled.
+	oidcProvider      *oidc.Provider
+	userIdentityStore store.UserIdentityStore
+	installationStore store.InstallationStore
+	// sessionLifetime is the duration for which login sessions are valid.
+	sessionLifetime time.Duration
 }
 
 func NewController(
@@ -43,17 +72,45 @@ func NewController(
 	authorizer authz.Authorizer,
 	principalStore store.PrincipalStore,
 	tokenStore store.TokenStore,
+	tokenCache store.PrincipalTokenCache,
 	membershipStore store.MembershipStore,
 	publicKeyStore store.PublicKeyStore,
+	publicKeyService publickey.Service,
+	passwordResetStore store.PasswordResetStore,
+	emailChangeStore store.EmailChangeStore,
+	mailer mailer.Mailer,
+	mailEnabled bool,
+	preferenceStore store.UserPreferenceStore,
+	recentVisits *recentvisit.Service,
+	avatars *avatar.Service,
+	loginProtection *loginprotection.Service,
+	oidcProvider *oidc.Provider,
+	userIdentityStore store.UserIdentityStore,
+	installationStore store.InstallationStore,
+	sessionLifetime time.Duration,
 ) *Controller {
 	return &Controller{
-		tx:                tx,
-		principalUIDCheck: principalUIDCheck,
-		authorizer:        authorizer,
-		principalStore:    principalStore,
-		tokenStore:        tokenStore,
-		membershipStore:   membershipStore,
-		publicKeyStore:    publicKeyStore,
+		tx:                 tx,
+		principalUIDCheck:  principalUIDCheck,
+		authorizer:         authorizer,
+		principalStore:     principalStore,
+		tokenStore:         tokenStore,
+		tokenCache:         tokenCache,
+		membershipStore:    membershipStore,
+		publicKeyStore:     publicKeyStore,
+		publicKeyService:   publicKeyService,
+		passwordResetStore: passwordResetStore,
+		emailChangeStore:   emailChangeStore,
+		mailer:             mailer,
+		mailEnabled:        mailEnabled,
+		preferenceStore:    preferenceStore,
+		recentVisits:       recentVisits,
+		avatars:            avatars,
+		loginProtection:    loginProtection,
+		oidcProvider:       oidcProvider,
+		userIdentityStore:  userIdentityStore,
+		installationStore:  installationStore,
+		sessionLifetime:    sessionLifetime,
 	}
 }
 
@@ -74,3 +131,23 @@ func findUserFromEmail(ctx context.Context,
 func isUserTokenType(tokenType enum.TokenType) bool {
 	return tokenType == enum.TokenTypePAT || tokenType == enum.TokenTypeSession
 }
+
+const oneTimeTokenLength = 32
+
+// generateOneTimeToken generates a random token that can be handed out to the user.
+// Only the returned hash of the token is supposed to be stored.
+func generateOneTimeToken() (string, string, error) {
+	tokenBytes := make([]byte, oneTimeTokenLength)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", "", fmt.Errorf("failed to read random bytes: %w", err)
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(tokenBytes)
+
+	return token, hashOneTimeToken(token), nil
+}
+
+func hashOneTimeToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/dchest/uniuri"
+	"github.com/rs/zerolog/log"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -97,6 +98,12 @@ func (c *Controller) CreateNoAuth(ctx context.Context, in *CreateInput, admin bo
 		if err != nil {
 			return nil, err
 		}
+
+		// the first user is the admin that installed the instance.
+		err = c.installationStore.SetInstaller(ctx, user.ID, user.Email)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to set user %d as installer of the instance", user.ID)
+		}
 	}
 
 	return user, nil
@@ -16,12 +16,11 @@ package user
 
 import (
 	"context"
-	"fmt"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
-	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
 
@@ -33,16 +32,13 @@ func (c *Controller) Delete(ctx context.Context, session *auth.Session,
 		return err
 	}
 
-	// Fail if the user being deleted is the only admin in DB
-	if user.Admin {
-		admUsrCount, err := c.principalStore.CountUsers(ctx, &types.UserFilter{Admin: true})
-		if err != nil {
-			return fmt.Errorf("failed to check admin user count: %w", err)
-		}
-
-		if admUsrCount == 1 {
-			return usererror.BadRequest("cannot delete the only admin user")
-		}
+	// Fail if the user being deleted is the only active admin in DB
+	isLastAdmin, err := controller.IsLastActiveAdmin(ctx, c.principalStore, user)
+	if err != nil {
+		return err
+	}
+	if isLastAdmin {
+		return usererror.BadRequest("cannot delete the only admin user")
 	}
 
 	// Ensure principal has required permissions on parent
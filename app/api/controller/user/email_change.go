@@ -0,0 +1,199 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/notification"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	emailChangeLifetime = 24 * time.Hour
+	subjectEmailChange  = "Confirm your new email address"
+)
+
+var (
+	errEmailChangeTokenInvalid = usererror.BadRequest("The email verification token is invalid or has expired.")
+	errEmailInUse              = usererror.Conflict("The email address is already in use.")
+)
+
+type EmailChangeInput struct {
+	Email string `json:"email"`
+}
+
+// EmailChangeOutput describes the pending email change.
+// The verification token is sent to the new email address.
+type EmailChangeOutput struct {
+	Email     string `json:"email"`
+	ExpiresAt int64  `json:"expires_at"`
+}
+
+type ConfirmEmailChangeInput struct {
+	Token string `json:"token"`
+}
+
+type emailChangeTemplatePayload struct {
+	DisplayName string
+	Email       string
+	Token       string
+	Expires     string
+}
+
+// RequestEmailChange records a pending change of the email address of the user
+// and sends a verification token to the new email address.
+// Any outstanding email change of the user is invalidated.
+func (c *Controller) RequestEmailChange(ctx context.Context, session *auth.Session,
+	userUID string, in *EmailChangeInput) (*EmailChangeOutput, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	if !c.mailEnabled {
+		return nil, usererror.BadRequest("Changing the email address requires email delivery to be configured.")
+	}
+
+	in.Email = strings.TrimSpace(in.Email)
+	if err = check.Email(in.Email); err != nil {
+		return nil, err
+	}
+
+	if strings.EqualFold(in.Email, user.Email) {
+		return nil, usererror.BadRequest("The new email address has to be different from the current one.")
+	}
+
+	// fail early, the email address is checked again once the change is confirmed.
+	_, err = findUserFromEmail(ctx, c.principalStore, in.Email)
+	if err == nil {
+		return nil, errEmailInUse
+	}
+	if !errors.Is(err, store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to check if email is in use: %w", err)
+	}
+
+	token, tokenHash, err := generateOneTimeToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate email verification token: %w", err)
+	}
+
+	now := time.Now()
+	change := &types.EmailChange{
+		PrincipalID: user.ID,
+		Email:       in.Email,
+		TokenHash:   tokenHash,
+		Expires:     now.Add(emailChangeLifetime).UnixMilli(),
+		Created:     now.UnixMilli(),
+	}
+
+	email, err := notification.GenerateBody(notification.TemplateEmailChange, &emailChangeTemplatePayload{
+		DisplayName: user.DisplayName,
+		Email:       change.Email,
+		Token:       token,
+		Expires:     time.UnixMilli(change.Expires).UTC().Format(time.RFC1123),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render email verification mail: %w", err)
+	}
+
+	// replaces any outstanding email change of the user.
+	err = c.emailChangeStore.Upsert(ctx, change)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store pending email change: %w", err)
+	}
+
+	email.ToRecipients = []string{change.Email}
+	email.Subject = subjectEmailChange
+
+	err = c.mailer.Send(ctx, *email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send email verification mail: %w", err)
+	}
+
+	return &EmailChangeOutput{
+		Email:     change.Email,
+		ExpiresAt: change.Expires,
+	}, nil
+}
+
+// ConfirmEmailChange swaps the email address of the user with the pending one.
+// It fails with a conflict if the email address was claimed by another principal in the meantime.
+func (c *Controller) ConfirmEmailChange(ctx context.Context, session *auth.Session,
+	userUID string, in *ConfirmEmailChangeInput) (*types.User, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	if in.Token == "" {
+		return nil, errEmailChangeTokenInvalid
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		change, err := c.emailChangeStore.FindByTokenHash(ctx, hashOneTimeToken(in.Token))
+		if errors.Is(err, store.ErrResourceNotFound) {
+			return errEmailChangeTokenInvalid
+		}
+		if err != nil {
+			return fmt.Errorf("failed to find pending email change: %w", err)
+		}
+
+		// tokens of other users are treated as unknown.
+		if change.PrincipalID != user.ID || change.Expires <= time.Now().UnixMilli() {
+			return errEmailChangeTokenInvalid
+		}
+
+		if err = c.emailChangeStore.Delete(ctx, user.ID); err != nil {
+			return fmt.Errorf("failed to delete pending email change: %w", err)
+		}
+
+		user.Email = change.Email
+		user.Updated = time.Now().UnixMilli()
+
+		err = c.principalStore.UpdateUser(ctx, user)
+		if errors.Is(err, store.ErrDuplicate) {
+			return errEmailInUse
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update email of user: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
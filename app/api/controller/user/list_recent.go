@@ -0,0 +1,38 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+)
+
+// ListRecent lists the repositories and spaces the current user has visited most recently.
+func (c *Controller) ListRecent(
+	ctx context.Context,
+	session *auth.Session,
+	filter *types.RecentVisitFilter,
+) ([]types.RecentVisitOutput, error) {
+	visitType, ok := filter.Type.Sanitize()
+	if !ok {
+		return nil, usererror.BadRequestf("Invalid recent visit type: %s", filter.Type)
+	}
+	filter.Type = visitType
+
+	return c.recentVisits.List(ctx, session, filter)
+}
@@ -23,7 +23,9 @@ import (
 	"time"
 
 	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/loginprotection"
 	"github.com/harness/gitness/app/token"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 
@@ -50,30 +52,74 @@ func (c *Controller) Login(
 		user, err = findUserFromEmail(ctx, c.principalStore, in.LoginIdentifier)
 	}
 
+	// failed logins for unknown identifiers are tracked like the ones of existing accounts,
+	// so lockouts don't reveal whether an account exists.
+	accountKey := loginprotection.IdentifierKey(in.LoginIdentifier)
+	if err == nil {
+		accountKey = loginprotection.AccountKey(user.ID)
+	}
+	ipKey := loginprotection.IPKey(audit.GetRealIP(ctx))
+
+	lockedFor, lockErr := c.loginProtection.LockedFor(ctx, accountKey, ipKey)
+	if lockErr != nil {
+		return nil, fmt.Errorf("failed to check login lockout: %w", lockErr)
+	}
+
 	// always return not found for security reasons.
 	if err != nil {
 		log.Ctx(ctx).Debug().Err(err).
+			Bool("locked", lockedFor > 0).
 			Msgf("failed to retrieve user %q during login (returning ErrNotFound).", in.LoginIdentifier)
-		return nil, usererror.ErrNotFound
+
+		if lockedFor > 0 {
+			return nil, usererror.LoginLocked(lockedFor)
+		}
+
+		return nil, c.loginFailed(ctx, accountKey, ipKey)
 	}
 
 	err = bcrypt.CompareHashAndPassword(
 		[]byte(user.Password),
 		[]byte(in.Password),
 	)
+
+	// while locked, the response doesn't depend on the password - only the logs tell them apart.
+	if lockedFor > 0 {
+		log.Ctx(ctx).Info().
+			Str("user_uid", user.UID).
+			Bool("password_valid", err == nil).
+			Dur("locked_for", lockedFor).
+			Msg("login attempt while locked")
+
+		return nil, usererror.LoginLocked(lockedFor)
+	}
+
 	if err != nil {
-		log.Debug().Err(err).
+		log.Ctx(ctx).Debug().Err(err).
 			Str("user_uid", user.UID).
 			Msg("invalid password")
 
-		return nil, usererror.ErrNotFound
+		return nil, c.loginFailed(ctx, accountKey, ipKey)
+	}
+
+	// only reveal the blocked state to callers that know the password.
+	if user.Blocked {
+		return nil, usererror.ErrPrincipalBlocked
+	}
+
+	// failed logins of the source IP aren't reset - they'd otherwise be cleared by logging into any account
+	// in between guesses, so they only age out of the failure window.
+	if err = c.loginProtection.Reset(ctx, accountKey); err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Str("user_uid", user.UID).
+			Msg("failed to reset failed logins after successful login")
 	}
 
 	tokenIdentifier, err := GenerateSessionTokenIdentifier()
 	if err != nil {
 		return nil, err
 	}
-	token, jwtToken, err := token.CreateUserSession(ctx, c.tokenStore, user, tokenIdentifier)
+	token, jwtToken, err := token.CreateUserSession(ctx, c.tokenStore, user, tokenIdentifier, c.sessionLifetime)
 	if err != nil {
 		return nil, err
 	}
@@ -81,6 +127,18 @@ func (c *Controller) Login(
 	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
 }
 
+// loginFailed records the failed login, delays the response as required and returns the error for the caller.
+func (c *Controller) loginFailed(ctx context.Context, accountKey, ipKey string) error {
+	delay, err := c.loginProtection.Fail(ctx, accountKey, ipKey)
+	if err != nil {
+		return fmt.Errorf("failed to record failed login: %w", err)
+	}
+
+	c.loginProtection.Wait(ctx, delay)
+
+	return usererror.ErrNotFound
+}
+
 func GenerateSessionTokenIdentifier() (string, error) {
 	r, err := rand.Int(rand.Reader, big.NewInt(10000))
 	if err != nil {
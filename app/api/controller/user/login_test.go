@@ -0,0 +1,167 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/loginprotection"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// loginPrincipalStore is a store.PrincipalStore that only finds users by uid.
+type loginPrincipalStore struct {
+	store.PrincipalStore
+	users map[string]*types.User
+}
+
+func (s *loginPrincipalStore) FindUserByUID(_ context.Context, uid string) (*types.User, error) {
+	if user, ok := s.users[uid]; ok {
+		return user, nil
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *loginPrincipalStore) FindUserByEmail(context.Context, string) (*types.User, error) {
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+// loginTokenStore is a store.TokenStore that only creates tokens.
+type loginTokenStore struct {
+	store.TokenStore
+}
+
+func (s *loginTokenStore) Create(_ context.Context, token *types.Token) error {
+	token.ID = 1
+	return nil
+}
+
+// loginAttemptStore is an in-memory store.LoginAttemptStore.
+type loginAttemptStore struct {
+	failures map[string][]int64
+	lockouts map[string]int64
+}
+
+func (s *loginAttemptStore) RecordFailure(_ context.Context, key string, created int64) error {
+	s.failures[key] = append(s.failures[key], created)
+	return nil
+}
+
+func (s *loginAttemptStore) CountFailures(_ context.Context, key string, since int64) (int64, error) {
+	var n int64
+	for _, created := range s.failures[key] {
+		if created > since {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (s *loginAttemptStore) DeleteFailures(_ context.Context, keys ...string) error {
+	for _, key := range keys {
+		delete(s.failures, key)
+	}
+	return nil
+}
+
+func (s *loginAttemptStore) PurgeFailures(context.Context, int64) (int64, error) { return 0, nil }
+
+func (s *loginAttemptStore) Lock(_ context.Context, key string, until int64) error {
+	s.lockouts[key] = until
+	return nil
+}
+
+func (s *loginAttemptStore) LockedUntil(_ context.Context, now int64, keys ...string) (int64, error) {
+	var until int64
+	for _, key := range keys {
+		if u := s.lockouts[key]; u > now {
+			until = max(until, u)
+		}
+	}
+	return until, nil
+}
+
+func (s *loginAttemptStore) Unlock(_ context.Context, key string) error {
+	delete(s.lockouts, key)
+	return nil
+}
+
+func (s *loginAttemptStore) PurgeLockouts(context.Context, int64) (int64, error) { return 0, nil }
+
+func TestLogin_SuccessDoesntResetIPFailures(t *testing.T) {
+	const ipLockoutThreshold = 4
+
+	ctx := audit.WithRealIP(context.Background(), "10.0.0.1")
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("attacker-password"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("failed to hash password: %s", err)
+	}
+
+	loginProtection, err := loginprotection.NewService(loginprotection.Config{
+		Enabled:            true,
+		FailureWindow:      time.Minute,
+		DelayAfter:         100,
+		LockoutThreshold:   100,
+		IPLockoutThreshold: ipLockoutThreshold,
+		LockoutDuration:    time.Hour,
+	}, &loginAttemptStore{failures: map[string][]int64{}, lockouts: map[string]int64{}})
+	if err != nil {
+		t.Fatalf("failed to create login protection: %s", err)
+	}
+
+	c := &Controller{
+		principalStore: &loginPrincipalStore{users: map[string]*types.User{
+			"attacker": {ID: 1, UID: "attacker", Password: string(hash), Salt: "salt"},
+			"victim":   {ID: 2, UID: "victim", Password: "unknown", Salt: "salt"},
+		}},
+		tokenStore:      &loginTokenStore{},
+		loginProtection: loginProtection,
+		sessionLifetime: time.Hour,
+	}
+
+	// logging into an own account in between guesses doesn't clear the failures of the source IP.
+	for i := range ipLockoutThreshold {
+		_, err = c.Login(ctx, &LoginInput{LoginIdentifier: "victim", Password: "guess"})
+		if !errors.Is(err, usererror.ErrNotFound) {
+			t.Fatalf("guess %d: got error %v, want not found", i, err)
+		}
+
+		if i == ipLockoutThreshold-1 {
+			break
+		}
+
+		if _, err = c.Login(ctx, &LoginInput{LoginIdentifier: "attacker", Password: "attacker-password"}); err != nil {
+			t.Fatalf("login %d of own account failed: %s", i, err)
+		}
+	}
+
+	_, err = c.Login(ctx, &LoginInput{LoginIdentifier: "attacker", Password: "attacker-password"})
+
+	var userErr *usererror.Error
+	if !errors.As(err, &userErr) || userErr.Status != http.StatusTooManyRequests {
+		t.Fatalf("got error %v, want the source IP to be locked", err)
+	}
+}
@@ -55,5 +55,7 @@ func (c *Controller) Logout(ctx context.Context, session *auth.Session) error {
 		return fmt.Errorf("failed to delete token from store: %w", err)
 	}
 
+	c.tokenCache.Evict(ctx, session.Principal.ID)
+
 	return nil
 }
@@ -0,0 +1,252 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth/oidc"
+	"github.com/harness/gitness/app/token"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	errOIDCUnavailable = usererror.New(http.StatusBadGateway,
+		"Login via the identity provider is currently not possible, please contact your administrator")
+	errOIDCStateInvalid = usererror.BadRequest("The login request is invalid or expired, please try again")
+	errOIDCLoginFailed  = usererror.New(http.StatusUnauthorized, "Login via the identity provider failed")
+)
+
+// OIDCLoginOutput contains the details of the login at the identity provider.
+// State and nonce have to be stored by the client and passed to OIDCCallback.
+type OIDCLoginOutput struct {
+	RedirectURL string
+	State       string
+	Nonce       string
+}
+
+// OIDCLogin starts a login via the OIDC identity provider.
+func (c *Controller) OIDCLogin(ctx context.Context) (*OIDCLoginOutput, error) {
+	if !c.oidcProvider.Enabled() {
+		return nil, usererror.ErrNotFound
+	}
+
+	state, err := randomOIDCValue()
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := randomOIDCValue()
+	if err != nil {
+		return nil, err
+	}
+
+	redirectURL, err := c.oidcProvider.AuthCodeURL(ctx, state, nonce)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).
+			Msg("failed to start oidc login, the identity provider is misconfigured or unavailable")
+		return nil, errOIDCUnavailable
+	}
+
+	return &OIDCLoginOutput{
+		RedirectURL: redirectURL,
+		State:       state,
+		Nonce:       nonce,
+	}, nil
+}
+
+// OIDCCallbackInput contains the parameters of the callback from the identity provider,
+// together with the state and nonce returned by OIDCLogin.
+type OIDCCallbackInput struct {
+	Code          string
+	State         string
+	ExpectedState string
+	Nonce         string
+}
+
+// OIDCCallback completes a login via the OIDC identity provider and returns a session token on success.
+// Users logging in for the first time are provisioned if enabled.
+func (c *Controller) OIDCCallback(ctx context.Context, in *OIDCCallbackInput) (*types.TokenResponse, error) {
+	if !c.oidcProvider.Enabled() {
+		return nil, usererror.ErrNotFound
+	}
+
+	if in.ExpectedState == "" || subtle.ConstantTimeCompare([]byte(in.State), []byte(in.ExpectedState)) != 1 {
+		return nil, errOIDCStateInvalid
+	}
+
+	if in.Code == "" {
+		return nil, usererror.BadRequest("The authorization code is missing")
+	}
+
+	identity, err := c.oidcProvider.Exchange(ctx, in.Code, in.Nonce)
+	if errors.Is(err, oidc.ErrInvalidToken) {
+		log.Ctx(ctx).Warn().Err(err).Msg("oidc login failed")
+		return nil, errOIDCLoginFailed
+	}
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).
+			Msg("failed to complete oidc login, the identity provider is misconfigured or unavailable")
+		return nil, errOIDCUnavailable
+	}
+
+	user, err := c.findOrProvisionOIDCUser(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Blocked {
+		return nil, usererror.ErrPrincipalBlocked
+	}
+
+	tokenIdentifier, err := GenerateSessionTokenIdentifier()
+	if err != nil {
+		return nil, err
+	}
+	token, jwtToken, err := token.CreateUserSession(ctx, c.tokenStore, user, tokenIdentifier, c.sessionLifetime)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
+}
+
+// findOrProvisionOIDCUser returns the user linked to the identity.
+// On the first login, the identity is linked to a new user, or to an existing user with the same email if allowed.
+func (c *Controller) findOrProvisionOIDCUser(ctx context.Context, identity *oidc.Identity) (*types.User, error) {
+	link, err := c.userIdentityStore.Find(ctx, identity.Issuer, identity.Subject)
+	if err == nil {
+		return c.principalStore.FindUser(ctx, link.PrincipalID)
+	}
+	if !errors.Is(err, store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find user identity: %w", err)
+	}
+
+	config := c.oidcProvider.Config()
+	logger := log.Ctx(ctx).With().
+		Str("oidc_subject", identity.Subject).
+		Str("oidc_email", identity.Email).
+		Logger()
+
+	existing, err := c.principalStore.FindUserByEmail(ctx, identity.Email)
+	if err != nil && !errors.Is(err, store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find user by email: %w", err)
+	}
+
+	if existing != nil {
+		if !config.LinkExistingByEmail || !identity.EmailVerified {
+			logger.Warn().
+				Str("user_uid", existing.UID).
+				Bool("email_verified", identity.EmailVerified).
+				Msg("oidc login rejected, a local user with the same email already exists " +
+					"(set GITNESS_OIDC_LINK_EXISTING_BY_EMAIL to link users with verified emails)")
+			return nil, usererror.Conflict("A user with the email of this login already exists")
+		}
+
+		if err = c.createUserIdentity(ctx, existing.ID, identity); err != nil {
+			return nil, err
+		}
+
+		logger.Info().Str("user_uid", existing.UID).Msg("linked oidc identity to existing user")
+
+		return existing, nil
+	}
+
+	if !config.AutoProvision {
+		logger.Warn().Msg("oidc login rejected, no user exists and GITNESS_OIDC_AUTO_PROVISION is disabled")
+		return nil, usererror.Forbidden("No user exists for this login, please contact your administrator")
+	}
+
+	uid := identity.Username
+	if uid == "" {
+		uid, _, _ = strings.Cut(identity.Email, "@")
+	}
+
+	displayName := identity.Name
+	if displayName == "" {
+		displayName = uid
+	}
+
+	// the user can't log in with a password until it's reset.
+	password, err := randomOIDCValue()
+	if err != nil {
+		return nil, err
+	}
+
+	var user *types.User
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		created, err := c.CreateNoAuth(ctx, &CreateInput{
+			UID:         uid,
+			Email:       identity.Email,
+			DisplayName: displayName,
+			Password:    password,
+		}, false)
+		if err != nil {
+			return err
+		}
+
+		user = created
+
+		return c.createUserIdentity(ctx, user.ID, identity)
+	})
+	if errors.Is(err, store.ErrDuplicate) {
+		logger.Warn().Str("user_uid", uid).
+			Msgf("oidc login rejected, a user with the uid already exists (check the %q claim mapping)",
+				config.ClaimUsername)
+		return nil, usererror.Conflict("A user with the username of this login already exists")
+	}
+	if err != nil {
+		logger.Warn().Err(err).Str("user_uid", uid).Msg("failed to provision user for oidc login")
+		return nil, fmt.Errorf("failed to provision user: %w", err)
+	}
+
+	logger.Info().Str("user_uid", user.UID).Msg("provisioned user on first oidc login")
+
+	return user, nil
+}
+
+func (c *Controller) createUserIdentity(ctx context.Context, principalID int64, identity *oidc.Identity) error {
+	err := c.userIdentityStore.Create(ctx, &types.UserIdentity{
+		PrincipalID: principalID,
+		Provider:    identity.Issuer,
+		Subject:     identity.Subject,
+		Created:     time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to link user identity: %w", err)
+	}
+
+	return nil
+}
+
+func randomOIDCValue() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random value: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,207 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/notification"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	passwordResetLifetime = 24 * time.Hour
+	subjectPasswordReset  = "Reset your password"
+)
+
+var errPasswordResetTokenInvalid = usererror.BadRequest("The password reset token is invalid or has expired.")
+
+// PasswordResetOutput contains the one-time password reset token.
+// The token is shown only once and can't be retrieved again.
+type PasswordResetOutput struct {
+	Token     string `json:"token"`
+	ExpiresAt int64  `json:"expires_at"`
+	// Emailed is true if the token was also sent to the email address of the user.
+	Emailed bool `json:"emailed"`
+}
+
+type passwordResetTemplatePayload struct {
+	DisplayName string
+	Token       string
+	Expires     string
+}
+
+type ResetPasswordInput struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+// InitiatePasswordReset invalidates all sessions of the user and issues a one-time password reset token.
+// Any previously issued reset token of the user is replaced.
+func (c *Controller) InitiatePasswordReset(ctx context.Context, session *auth.Session,
+	userUID string) (*PasswordResetOutput, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure principal has required permissions on parent.
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEditAdmin); err != nil {
+		return nil, err
+	}
+
+	token, tokenHash, err := generateOneTimeToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate password reset token: %w", err)
+	}
+
+	now := time.Now()
+	reset := &types.PasswordReset{
+		PrincipalID: user.ID,
+		TokenHash:   tokenHash,
+		Expires:     now.Add(passwordResetLifetime).UnixMilli(),
+		CreatedBy:   session.Principal.ID,
+		Created:     now.UnixMilli(),
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := c.passwordResetStore.Upsert(ctx, reset); err != nil {
+			return fmt.Errorf("failed to store password reset: %w", err)
+		}
+
+		if _, err := c.tokenStore.DeleteForPrincipal(ctx, user.ID,
+			[]enum.TokenType{enum.TokenTypeSession}); err != nil {
+			return fmt.Errorf("failed to invalidate sessions of user: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.tokenCache.Evict(ctx, user.ID)
+
+	return &PasswordResetOutput{
+		Token:     token,
+		ExpiresAt: reset.Expires,
+		Emailed:   c.sendPasswordResetMail(ctx, user, token, reset.Expires),
+	}, nil
+}
+
+// sendPasswordResetMail sends the password reset token to the user, if email delivery is configured.
+// It's best effort, the token is returned to the admin either way.
+func (c *Controller) sendPasswordResetMail(ctx context.Context, user *types.User, token string, expires int64) bool {
+	if !c.mailEnabled {
+		return false
+	}
+
+	email, err := notification.GenerateBody(notification.TemplatePasswordReset, &passwordResetTemplatePayload{
+		DisplayName: user.DisplayName,
+		Token:       token,
+		Expires:     time.UnixMilli(expires).UTC().Format(time.RFC1123),
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to render password reset mail")
+		return false
+	}
+
+	email.ToRecipients = []string{user.Email}
+	email.Subject = subjectPasswordReset
+
+	if err = c.mailer.Send(ctx, *email); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to send password reset mail")
+		return false
+	}
+
+	return true
+}
+
+// ResetPassword sets a new password using a one-time password reset token.
+// No auth check is required, the reset token is used for it.
+func (c *Controller) ResetPassword(ctx context.Context, in *ResetPasswordInput) error {
+	if in.Token == "" {
+		return errPasswordResetTokenInvalid
+	}
+
+	if err := check.Password(in.Password); err != nil {
+		return err
+	}
+
+	hash, err := hashPassword([]byte(in.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	var principalID int64
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		reset, err := c.passwordResetStore.FindByTokenHash(ctx, hashOneTimeToken(in.Token))
+		if errors.Is(err, store.ErrResourceNotFound) {
+			return errPasswordResetTokenInvalid
+		}
+		if err != nil {
+			return fmt.Errorf("failed to find password reset: %w", err)
+		}
+
+		if reset.Expires <= time.Now().UnixMilli() {
+			return errPasswordResetTokenInvalid
+		}
+
+		// the token can only be used once.
+		if err = c.passwordResetStore.Delete(ctx, reset.PrincipalID); err != nil {
+			return fmt.Errorf("failed to delete password reset: %w", err)
+		}
+
+		user, err := c.principalStore.FindUser(ctx, reset.PrincipalID)
+		if err != nil {
+			return fmt.Errorf("failed to find user: %w", err)
+		}
+
+		user.Password = string(hash)
+		user.Updated = time.Now().UnixMilli()
+
+		if err = c.principalStore.UpdateUser(ctx, user); err != nil {
+			return fmt.Errorf("failed to update password of user: %w", err)
+		}
+
+		if _, err = c.tokenStore.DeleteForPrincipal(ctx, user.ID,
+			[]enum.TokenType{enum.TokenTypeSession}); err != nil {
+			return fmt.Errorf("failed to invalidate sessions of user: %w", err)
+		}
+
+		principalID = user.ID
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	c.tokenCache.Evict(ctx, principalID)
+
+	return nil
+}
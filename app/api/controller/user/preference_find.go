@@ -0,0 +1,54 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// FindPreference returns the list view preference of the current user.
+// If the user hasn't stored a preference for the view yet, an empty preference is returned.
+func (c *Controller) FindPreference(
+	ctx context.Context,
+	session *auth.Session,
+	view enum.UserPreferenceView,
+) (*types.UserPreference, error) {
+	view, ok := view.Sanitize()
+	if !ok || view == "" {
+		return nil, usererror.BadRequestf("Invalid preference view: %s", view)
+	}
+
+	pref, err := c.preferenceStore.Find(ctx, session.Principal.ID, view)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return &types.UserPreference{
+			PrincipalID: session.Principal.ID,
+			View:        view,
+			Params:      map[string][]string{},
+		}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user preference: %w", err)
+	}
+
+	return pref, nil
+}
@@ -0,0 +1,178 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// maxPreferenceSize is the maximum size of the JSON encoded preference params.
+	maxPreferenceSize = 4096
+	// maxPreferenceParamValues is the maximum number of values a single preference param can have.
+	maxPreferenceParamValues = 20
+	// maxPreferenceQueryLength is the maximum length of the search query stored in a preference.
+	maxPreferenceQueryLength = 256
+)
+
+type preferenceParamValidator func(value string) bool
+
+// preferenceParams contains the query parameters that can be stored for each of the list views.
+var preferenceParams = map[enum.UserPreferenceView]map[string]preferenceParamValidator{
+	enum.UserPreferenceViewRepoList: {
+		request.QueryParamSort:      isValidRepoSort,
+		request.QueryParamOrder:     isValidOrder,
+		request.QueryParamQuery:     isValidQuery,
+		request.QueryParamRecursive: isValidBool,
+		request.QueryParamLimit:     isValidPositiveInt,
+	},
+	enum.UserPreferenceViewPullReqList: {
+		request.QueryParamState:              isValidPullReqState,
+		request.QueryParamSort:               isValidPullReqSort,
+		request.QueryParamOrder:              isValidOrder,
+		request.QueryParamQuery:              isValidQuery,
+		request.QueryParamCreatedBy:          isValidPositiveInt,
+		request.QueryParamAuthorID:           isValidPositiveInt,
+		request.QueryParamCommenterID:        isValidPositiveInt,
+		request.QueryParamReviewerID:         isValidPositiveInt,
+		request.QueryParamReviewDecision:     isValidReviewDecision,
+		request.QueryParamLabelID:            isValidPositiveInt,
+		request.QueryParamValueID:            isValidPositiveInt,
+		request.QueryParamIncludeDescription: isValidBool,
+		request.QueryParamLimit:              isValidPositiveInt,
+	},
+}
+
+type UpdatePreferenceInput struct {
+	Params map[string][]string `json:"params"`
+}
+
+func (in *UpdatePreferenceInput) sanitize(view enum.UserPreferenceView) error {
+	if in.Params == nil {
+		in.Params = map[string][]string{}
+	}
+
+	knownParams := preferenceParams[view]
+	for param, values := range in.Params {
+		isValid, ok := knownParams[param]
+		if !ok {
+			return usererror.BadRequestf("Parameter %q can't be stored for view %s", param, view)
+		}
+
+		if len(values) == 0 {
+			delete(in.Params, param)
+			continue
+		}
+
+		if len(values) > maxPreferenceParamValues {
+			return usererror.BadRequestf("Parameter %q can have at most %d values", param, maxPreferenceParamValues)
+		}
+
+		for _, value := range values {
+			if !isValid(value) {
+				return usererror.BadRequestf("Invalid value %q for parameter %q", value, param)
+			}
+		}
+	}
+
+	raw, err := json.Marshal(in.Params)
+	if err != nil {
+		return fmt.Errorf("failed to marshal preference params: %w", err)
+	}
+	if len(raw) > maxPreferenceSize {
+		return usererror.BadRequestf("Preference can't be larger than %d bytes", maxPreferenceSize)
+	}
+
+	return nil
+}
+
+// UpdatePreference stores the list view preference of the current user, replacing any existing one.
+func (c *Controller) UpdatePreference(
+	ctx context.Context,
+	session *auth.Session,
+	view enum.UserPreferenceView,
+	in *UpdatePreferenceInput,
+) (*types.UserPreference, error) {
+	view, ok := view.Sanitize()
+	if !ok || view == "" {
+		return nil, usererror.BadRequestf("Invalid preference view: %s", view)
+	}
+
+	if err := in.sanitize(view); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	pref := &types.UserPreference{
+		PrincipalID: session.Principal.ID,
+		View:        view,
+		Params:      in.Params,
+		Created:     now,
+		Updated:     now,
+	}
+
+	if err := c.preferenceStore.Upsert(ctx, pref); err != nil {
+		return nil, fmt.Errorf("failed to upsert user preference: %w", err)
+	}
+
+	return pref, nil
+}
+
+func isValidRepoSort(value string) bool {
+	return enum.ParseRepoAttr(value) != enum.RepoAttrNone
+}
+
+func isValidPullReqSort(value string) bool {
+	_, ok := enum.PullReqSort(value).Sanitize()
+	return ok && value != ""
+}
+
+func isValidPullReqState(value string) bool {
+	_, ok := enum.PullReqState(value).Sanitize()
+	return ok && value != ""
+}
+
+func isValidReviewDecision(value string) bool {
+	_, ok := enum.PullReqReviewDecision(value).Sanitize()
+	return ok && value != ""
+}
+
+func isValidOrder(value string) bool {
+	return enum.ParseOrder(value) != enum.OrderDefault
+}
+
+func isValidQuery(value string) bool {
+	return len(value) <= maxPreferenceQueryLength
+}
+
+func isValidBool(value string) bool {
+	_, err := strconv.ParseBool(value)
+	return err == nil
+}
+
+func isValidPositiveInt(value string) bool {
+	i, err := strconv.ParseInt(value, 10, 64)
+	return err == nil && i > 0
+}
@@ -33,6 +33,7 @@ type CreatePublicKeyInput struct {
 	Identifier string              `json:"identifier"`
 	Usage      enum.PublicKeyUsage `json:"usage"`
 	Content    string              `json:"content"`
+	ValidUntil *int64              `json:"valid_until"`
 }
 
 func (c *Controller) CreatePublicKey(
@@ -50,7 +51,9 @@ func (c *Controller) CreatePublicKey(
 		return nil, err
 	}
 
-	if err := sanitizeCreatePublicKeyInput(in); err != nil {
+	now := time.Now().UnixMilli()
+
+	if err := sanitizeCreatePublicKeyInput(in, now); err != nil {
 		return nil, err
 	}
 
@@ -59,12 +62,12 @@ func (c *Controller) CreatePublicKey(
 		return nil, errors.InvalidArgument("could not parse public key")
 	}
 
-	now := time.Now().UnixMilli()
-
 	k := &types.PublicKey{
 		PrincipalID: user.ID,
 		Created:     now,
 		Verified:    nil, // the key is created as unverified
+		LastUsed:    nil,
+		ValidUntil:  in.ValidUntil,
 		Identifier:  in.Identifier,
 		Usage:       in.Usage,
 		Fingerprint: key.Fingerprint(),
@@ -74,15 +77,9 @@ func (c *Controller) CreatePublicKey(
 	}
 
 	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
-		existingKeys, err := c.publicKeyStore.ListByFingerprint(ctx, k.Fingerprint)
+		err := c.publicKeyService.CheckKeyNotInUse(ctx, key)
 		if err != nil {
-			return fmt.Errorf("failed to read keys by fingerprint: %w", err)
-		}
-
-		for _, existingKey := range existingKeys {
-			if key.Matches(existingKey.Content) {
-				return errors.InvalidArgument("Key is already in use")
-			}
+			return err
 		}
 
 		err = c.publicKeyStore.Create(ctx, k)
@@ -99,7 +96,7 @@ func (c *Controller) CreatePublicKey(
 	return k, nil
 }
 
-func sanitizeCreatePublicKeyInput(in *CreatePublicKeyInput) error {
+func sanitizeCreatePublicKeyInput(in *CreatePublicKeyInput, now int64) error {
 	if err := check.Identifier(in.Identifier); err != nil {
 		return err
 	}
@@ -115,5 +112,9 @@ func sanitizeCreatePublicKeyInput(in *CreatePublicKeyInput) error {
 		return errors.InvalidArgument("public key not provided")
 	}
 
+	if in.ValidUntil != nil && *in.ValidUntil <= now {
+		return errors.InvalidArgument("public key expiration time must be in the future")
+	}
+
 	return nil
 }
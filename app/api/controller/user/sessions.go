@@ -0,0 +1,112 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListSessions lists the active login sessions of a user.
+// The session used for the request is flagged as the current session.
+func (c *Controller) ListSessions(ctx context.Context, session *auth.Session,
+	userUID string) ([]*types.UserSession, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure principal has required permissions on parent.
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserView); err != nil {
+		return nil, err
+	}
+
+	tokens, err := c.tokenStore.List(ctx, user.ID, enum.TokenTypeSession)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list session tokens: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	currentID := currentSessionID(session, user.ID)
+
+	sessions := make([]*types.UserSession, 0, len(tokens))
+	for _, token := range tokens {
+		// expired sessions are kept for a while before they're cleaned up.
+		if token.ExpiresAt != nil && *token.ExpiresAt <= now {
+			continue
+		}
+
+		sessions = append(sessions, &types.UserSession{
+			Identifier: token.Identifier,
+			IssuedAt:   token.IssuedAt,
+			ExpiresAt:  token.ExpiresAt,
+			LastUsedAt: token.LastUsedAt,
+			UserAgent:  token.UserAgent,
+			IPAddress:  token.IPAddress,
+			Current:    token.ID == currentID,
+		})
+	}
+
+	return sessions, nil
+}
+
+// RevokeOtherSessions revokes all login sessions of a user except the session used for the request.
+func (c *Controller) RevokeOtherSessions(ctx context.Context, session *auth.Session, userUID string) error {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return err
+	}
+
+	// Ensure principal has required permissions on parent.
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return err
+	}
+
+	return c.revokeOtherSessions(ctx, session, user.ID)
+}
+
+// revokeOtherSessions revokes all login sessions of the principal except the session used for the request.
+func (c *Controller) revokeOtherSessions(ctx context.Context, session *auth.Session, principalID int64) error {
+	_, err := c.tokenStore.DeleteForPrincipalExcept(ctx, principalID, enum.TokenTypeSession,
+		currentSessionID(session, principalID))
+	if err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	c.tokenCache.Evict(ctx, principalID)
+
+	return nil
+}
+
+// currentSessionID returns the id of the session token used for the request,
+// or 0 if the request wasn't made with a session of the principal.
+func currentSessionID(session *auth.Session, principalID int64) int64 {
+	if session == nil || session.Principal.ID != principalID {
+		return 0
+	}
+
+	metadata, ok := session.Metadata.(*auth.TokenMetadata)
+	if !ok || metadata.TokenType != enum.TokenTypeSession {
+		return 0
+	}
+
+	return metadata.TokenID
+}
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
@@ -53,6 +54,11 @@ func (c *Controller) Update(ctx context.Context, session *auth.Session,
 		return nil, fmt.Errorf("invalid input: %w", err)
 	}
 
+	// users have to verify a new email address, only admins can change it directly.
+	if in.Email != nil && !strings.EqualFold(*in.Email, user.Email) && !session.Principal.Admin {
+		return nil, usererror.BadRequest("Changing the email address requires verification, use /user/email instead.")
+	}
+
 	if in.DisplayName != nil {
 		user.DisplayName = *in.DisplayName
 	}
@@ -74,6 +80,13 @@ func (c *Controller) Update(ctx context.Context, session *auth.Session,
 		return nil, err
 	}
 
+	// a changed password invalidates all other sessions, which might have been opened with the old password.
+	if in.Password != nil {
+		if err = c.revokeOtherSessions(ctx, session, user.ID); err != nil {
+			return nil, err
+		}
+	}
+
 	return user, nil
 }
 
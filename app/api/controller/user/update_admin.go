@@ -16,10 +16,10 @@ package user
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/types"
@@ -43,14 +43,13 @@ func (c *Controller) UpdateAdmin(ctx context.Context, session *auth.Session,
 		return nil, err
 	}
 
-	// Fail if the user being updated is the only admin in DB.
-	if user.Admin && !request.Admin {
-		admUsrCount, err := c.principalStore.CountUsers(ctx, &types.UserFilter{Admin: true})
+	// Fail if the user being updated is the only active admin in DB.
+	if !request.Admin {
+		isLastAdmin, err := controller.IsLastActiveAdmin(ctx, c.principalStore, user)
 		if err != nil {
-			return nil, fmt.Errorf("failed to check admin user count: %w", err)
+			return nil, err
 		}
-
-		if admUsrCount <= 1 {
+		if isLastAdmin {
 			return nil, usererror.BadRequest("system requires at least one admin user")
 		}
 	}
@@ -0,0 +1,76 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type UpdateBlockedInput struct {
+	Blocked bool `json:"blocked"`
+}
+
+// UpdateBlocked blocks or unblocks a user.
+// Blocked users can't authenticate, but their tokens are kept and work again once the user is unblocked.
+func (c *Controller) UpdateBlocked(ctx context.Context, session *auth.Session,
+	userUID string, request *UpdateBlockedInput) (*types.User, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure principal has required permissions on parent.
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEditAdmin); err != nil {
+		return nil, err
+	}
+
+	if user.Blocked == request.Blocked {
+		return user, nil
+	}
+
+	if request.Blocked {
+		if user.ID == session.Principal.ID {
+			return nil, usererror.BadRequest("users can't block themselves")
+		}
+
+		// Fail if the user being blocked is the only active admin in DB.
+		isLastAdmin, err := controller.IsLastActiveAdmin(ctx, c.principalStore, user)
+		if err != nil {
+			return nil, err
+		}
+		if isLastAdmin {
+			return nil, usererror.BadRequest("system requires at least one active admin user")
+		}
+	}
+
+	user.Blocked = request.Blocked
+	user.Updated = time.Now().UnixMilli()
+
+	err = c.principalStore.UpdateUser(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update blocked state of user: %w", err)
+	}
+
+	return user, nil
+}
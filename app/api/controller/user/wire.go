@@ -16,8 +16,15 @@ package user
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/auth/oidc"
+	"github.com/harness/gitness/app/services/avatar"
+	"github.com/harness/gitness/app/services/loginprotection"
+	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/app/services/recentvisit"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 
 	"github.com/google/wire"
@@ -29,13 +36,26 @@ var WireSet = wire.NewSet(
 )
 
 func ProvideController(
+	config *types.Config,
 	tx dbtx.Transactor,
 	principalUIDCheck check.PrincipalUID,
 	authorizer authz.Authorizer,
 	principalStore store.PrincipalStore,
 	tokenStore store.TokenStore,
+	tokenCache store.PrincipalTokenCache,
 	membershipStore store.MembershipStore,
 	publicKeyStore store.PublicKeyStore,
+	publicKeyService publickey.Service,
+	passwordResetStore store.PasswordResetStore,
+	emailChangeStore store.EmailChangeStore,
+	mailer mailer.Mailer,
+	preferenceStore store.UserPreferenceStore,
+	recentVisits *recentvisit.Service,
+	avatars *avatar.Service,
+	loginProtection *loginprotection.Service,
+	oidcProvider *oidc.Provider,
+	userIdentityStore store.UserIdentityStore,
+	installationStore store.InstallationStore,
 ) *Controller {
 	return NewController(
 		tx,
@@ -43,6 +63,20 @@ func ProvideController(
 		authorizer,
 		principalStore,
 		tokenStore,
+		tokenCache,
 		membershipStore,
-		publicKeyStore)
+		publicKeyStore,
+		publicKeyService,
+		passwordResetStore,
+		emailChangeStore,
+		mailer,
+		config.SMTP.Host != "",
+		preferenceStore,
+		recentVisits,
+		avatars,
+		loginProtection,
+		oidcProvider,
+		userIdentityStore,
+		installationStore,
+		config.Token.Expire)
 }
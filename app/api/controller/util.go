@@ -47,11 +47,18 @@ func createRPCWriteParams(
 		return git.WriteParams{}, fmt.Errorf("failed to generate git hook environment variables: %w", err)
 	}
 
+	actor := git.Identity{
+		Name:  session.Principal.DisplayName,
+		Email: session.Principal.Email,
+	}
+
+	// attribute reference updates recorded in the reflog to the principal.
+	for key, value := range git.CreateEnvironmentForReflog(actor) {
+		envVars[key] = value
+	}
+
 	return git.WriteParams{
-		Actor: git.Identity{
-			Name:  session.Principal.DisplayName,
-			Email: session.Principal.Email,
-		},
+		Actor:   actor,
 		RepoUID: repo.GitUID,
 		EnvVars: envVars,
 	}, nil
@@ -107,6 +114,7 @@ func MapCommit(c *git.Commit) (*types.Commit, error) {
 			Author:     *author,
 			Committer:  *committer,
 			Stats:      mapStats(c),
+			Note:       c.Note,
 		},
 		nil
 }
@@ -19,9 +19,11 @@ import (
 	"fmt"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/instancesettings"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/encrypt"
@@ -30,8 +32,7 @@ import (
 )
 
 type Controller struct {
-	allowLoopback       bool
-	allowPrivateNetwork bool
+	instanceSettings *instancesettings.Service
 
 	authorizer            authz.Authorizer
 	webhookStore          store.WebhookStore
@@ -42,8 +43,7 @@ type Controller struct {
 }
 
 func NewController(
-	allowLoopback bool,
-	allowPrivateNetwork bool,
+	instanceSettings *instancesettings.Service,
 	authorizer authz.Authorizer,
 	webhookStore store.WebhookStore,
 	webhookExecutionStore store.WebhookExecutionStore,
@@ -52,8 +52,7 @@ func NewController(
 	encrypter encrypt.Encrypter,
 ) *Controller {
 	return &Controller{
-		allowLoopback:         allowLoopback,
-		allowPrivateNetwork:   allowPrivateNetwork,
+		instanceSettings:      instanceSettings,
 		authorizer:            authorizer,
 		webhookStore:          webhookStore,
 		webhookExecutionStore: webhookExecutionStore,
@@ -74,6 +73,8 @@ func (c *Controller) getRepoCheckAccess(ctx context.Context,
 		return nil, fmt.Errorf("failed to find repo: %w", err)
 	}
 
+	controller.WithRepo(ctx, repo)
+
 	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, reqPermission); err != nil {
 		return nil, fmt.Errorf("failed to verify authorization: %w", err)
 	}
@@ -56,7 +56,8 @@ func (c *Controller) Create(
 	internal bool,
 ) (*types.Webhook, error) {
 	// validate input
-	err := sanitizeCreateInput(in, c.allowLoopback, c.allowPrivateNetwork || internal)
+	err := sanitizeCreateInput(in, c.instanceSettings.WebhookAllowLoopback(),
+		c.instanceSettings.WebhookAllowPrivateNetwork() || internal)
 	if err != nil {
 		return nil, err
 	}
@@ -121,6 +122,13 @@ func (c *Controller) Create(
 	return hook, nil
 }
 
+// CreateValidate validates the input for creating a webhook without creating it.
+// It's used to reject invalid input upfront when the same webhook is created for many repositories.
+func (c *Controller) CreateValidate(in *CreateInput, internal bool) error {
+	return sanitizeCreateInput(in, c.instanceSettings.WebhookAllowLoopback(),
+		c.instanceSettings.WebhookAllowPrivateNetwork() || internal)
+}
+
 func sanitizeCreateInput(in *CreateInput, allowLoopback bool, allowPrivateNetwork bool) error {
 	// TODO [CODE-1363]: remove after identifier migration.
 	if in.Identifier == "" {
@@ -47,7 +47,8 @@ func (c *Controller) Update(
 	in *UpdateInput,
 	allowModifyingInternal bool,
 ) (*types.Webhook, error) {
-	if err := sanitizeUpdateInput(in, c.allowLoopback, c.allowPrivateNetwork); err != nil {
+	if err := sanitizeUpdateInput(in, c.instanceSettings.WebhookAllowLoopback(),
+		c.instanceSettings.WebhookAllowPrivateNetwork()); err != nil {
 		return nil, err
 	}
 
@@ -16,6 +16,7 @@ package webhook
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/instancesettings"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/encrypt"
@@ -28,12 +29,12 @@ var WireSet = wire.NewSet(
 	ProvideController,
 )
 
-func ProvideController(config webhook.Config, authorizer authz.Authorizer,
+func ProvideController(instanceSettings *instancesettings.Service, authorizer authz.Authorizer,
 	webhookStore store.WebhookStore, webhookExecutionStore store.WebhookExecutionStore,
 	repoStore store.RepoStore, webhookService *webhook.Service, encrypter encrypt.Encrypter,
 ) *Controller {
 	return NewController(
-		config.AllowLoopback, config.AllowPrivateNetwork, authorizer,
+		instanceSettings, authorizer,
 		webhookStore, webhookExecutionStore,
 		repoStore, webhookService, encrypter)
 }
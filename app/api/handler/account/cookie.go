@@ -19,6 +19,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/types"
 )
 
@@ -34,6 +35,11 @@ func includeTokenCookie(
 	}
 
 	http.SetCookie(w, cookie)
+
+	// a new session always gets a new csrf token.
+	csrfCookie := auth.NewCSRFCookie(r, cookieName, tokenResponse.AccessToken)
+	csrfCookie.Expires = cookie.Expires
+	http.SetCookie(w, csrfCookie)
 }
 
 func deleteTokenCookieIfPresent(r *http.Request, w http.ResponseWriter, cookieName string) {
@@ -49,6 +55,12 @@ func deleteTokenCookieIfPresent(r *http.Request, w http.ResponseWriter, cookieNa
 	cookie.Expires = time.UnixMilli(0) // this effectively tells the browser to delete the cookie
 
 	http.SetCookie(w, cookie)
+
+	csrfCookie := auth.NewCSRFCookie(r, cookieName, "")
+	csrfCookie.Value = ""
+	csrfCookie.Expires = time.UnixMilli(0)
+
+	http.SetCookie(w, csrfCookie)
 }
 
 func newEmptyTokenCookie(r *http.Request, cookieName string) *http.Cookie {
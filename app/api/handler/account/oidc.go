@@ -0,0 +1,111 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package account
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/usererror"
+
+	"github.com/rs/zerolog/log"
+)
+
+// oidcStateMaxAge is the time the user has to complete the login at the identity provider.
+const oidcStateMaxAge = 10 * time.Minute
+
+// HandleOIDCLogin returns an http.HandlerFunc that redirects the user to the OIDC identity provider for login.
+func HandleOIDCLogin(userCtrl *user.Controller, cookieName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		out, err := userCtrl.OIDCLogin(ctx)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		cookie := newOIDCStateCookie(r, cookieName)
+		cookie.Value = out.State + "." + out.Nonce
+		cookie.MaxAge = int(oidcStateMaxAge.Seconds())
+		http.SetCookie(w, cookie)
+
+		http.Redirect(w, r, out.RedirectURL, http.StatusFound)
+	}
+}
+
+// HandleOIDCCallback returns an http.HandlerFunc that completes the login via the OIDC identity provider.
+// On success, the session cookie is set and the user is redirected to the UI.
+func HandleOIDCCallback(userCtrl *user.Controller, cookieName string, uiURL string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		// the state can only be used once.
+		var expectedState, nonce string
+		if stateCookie, err := r.Cookie(oidcStateCookieName(cookieName)); err == nil {
+			expectedState, nonce, _ = strings.Cut(stateCookie.Value, ".")
+
+			cookie := newOIDCStateCookie(r, cookieName)
+			cookie.MaxAge = -1
+			http.SetCookie(w, cookie)
+		}
+
+		query := r.URL.Query()
+		if errCode := query.Get("error"); errCode != "" {
+			log.Ctx(ctx).Warn().
+				Str("oidc_error", errCode).
+				Str("oidc_error_description", query.Get("error_description")).
+				Msg("identity provider returned an error for the oidc login")
+
+			render.UserError(ctx, w, usererror.New(http.StatusUnauthorized,
+				"The identity provider rejected the login: "+errCode))
+			return
+		}
+
+		tokenResponse, err := userCtrl.OIDCCallback(ctx, &user.OIDCCallbackInput{
+			Code:          query.Get("code"),
+			State:         query.Get("state"),
+			ExpectedState: expectedState,
+			Nonce:         nonce,
+		})
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		includeTokenCookie(r, w, tokenResponse, cookieName)
+
+		http.Redirect(w, r, uiURL, http.StatusFound)
+	}
+}
+
+func oidcStateCookieName(cookieName string) string {
+	return cookieName + "_oidc"
+}
+
+func newOIDCStateCookie(r *http.Request, cookieName string) *http.Cookie {
+	return &http.Cookie{
+		Name: oidcStateCookieName(cookieName),
+		// the callback is a cross-site navigation from the identity provider, strict cookies wouldn't be sent.
+		SameSite: http.SameSiteLaxMode,
+		HttpOnly: true,
+		Path:     "/",
+		Domain:   r.URL.Hostname(),
+		Secure:   r.URL.Scheme == "https",
+	}
+}
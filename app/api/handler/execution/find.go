@@ -42,7 +42,13 @@ func HandleFind(executionCtrl *execution.Controller) http.HandlerFunc {
 			return
 		}
 
-		execution, err := executionCtrl.Find(ctx, session, repoRef, pipelineIdentifier, n)
+		axes, err := request.ParseMatrixAxesFromQuery(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		execution, err := executionCtrl.Find(ctx, session, repoRef, pipelineIdentifier, n, axes)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
@@ -37,15 +37,19 @@ func HandleList(executionCtrl *execution.Controller) http.HandlerFunc {
 			return
 		}
 
-		pagination := request.ParsePaginationFromRequest(r)
+		filter, err := request.ParseExecutionFilter(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
 
-		repos, totalCount, err := executionCtrl.List(ctx, session, repoRef, pipelineIdentifier, pagination)
+		repos, totalCount, err := executionCtrl.List(ctx, session, repoRef, pipelineIdentifier, filter)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
 		}
 
-		render.Pagination(r, w, pagination.Page, pagination.Size, int(totalCount))
+		render.Pagination(r, w, filter.Page, filter.Size, int(totalCount))
 		render.JSON(w, http.StatusOK, repos)
 	}
 }
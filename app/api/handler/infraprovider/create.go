@@ -15,7 +15,6 @@
 package infraprovider
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"github.com/harness/gitness/app/api/controller/infraprovider"
@@ -30,9 +29,9 @@ func HandleCreateConfig(infraProviderCtrl *infraprovider.Controller) http.Handle
 		session, _ := request.AuthSessionFrom(ctx)
 
 		in := new(infraprovider.CreateInput)
-		err := json.NewDecoder(r.Body).Decode(in)
+		err := request.DecodeJSON(r, request.MaxJSONBodySize, in)
 		if err != nil {
-			render.BadRequestf(ctx, w, "Invalid Request Body: %s.", err)
+			render.TranslatedUserError(ctx, w, err)
 			return
 		}
 
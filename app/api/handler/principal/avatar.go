@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serviceaccount
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/principal"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/services/avatar"
+
+	"github.com/rs/zerolog/log"
+)
+
+// HandleAvatar returns the avatar of the principal as PNG image.
+// Requests that reference the current avatar version can be cached indefinitely.
+func HandleAvatar(principalCtrl principal.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+		principalUID, err := request.GetPrincipalUIDFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		size, err := request.ParseAvatarSize(r, avatar.DefaultSize)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		img, err := principalCtrl.Avatar(ctx, session, principalUID, size)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		if img.Hash != "" && request.GetAvatarVersionFromQuery(r) == img.Hash {
+			w.Header().Set("Cache-Control", "private, max-age=31536000, immutable")
+		} else {
+			w.Header().Set("Cache-Control", "private, no-cache")
+		}
+		w.Header().Set(request.HeaderETag, img.ETag)
+
+		ifNoneMatch, ok := request.GetIfNoneMatchFromHeader(r)
+		if ok && ifNoneMatch == img.ETag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("Content-Type", "image/png")
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(img.Data); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to write avatar")
+		}
+	}
+}
@@ -15,7 +15,6 @@
 package pullreq
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"github.com/harness/gitness/app/api/controller/pullreq"
@@ -48,9 +47,9 @@ func HandleCommentUpdate(pullreqCtrl *pullreq.Controller) http.HandlerFunc {
 		}
 
 		in := new(pullreq.CommentUpdateInput)
-		err = json.NewDecoder(r.Body).Decode(in)
+		err = request.DecodeJSON(r, request.MaxJSONBodySize, in)
 		if err != nil {
-			render.BadRequestf(ctx, w, "Invalid Request Body: %s.", err)
+			render.TranslatedUserError(ctx, w, err)
 			return
 		}
 
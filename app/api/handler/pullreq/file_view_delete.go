@@ -40,7 +40,7 @@ func HandleFileViewDelete(pullreqCtrl *pullreq.Controller) http.HandlerFunc {
 			return
 		}
 
-		filePath, err := request.GetRemainderFromPath(r)
+		filePath, err := request.GetSanitizedPathFromRemainder(r)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
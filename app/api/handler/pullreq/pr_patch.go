@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/types/enum"
+)
+
+// HandlePatch returns a http.HandlerFunc that streams a pull request as .patch or .diff file.
+func HandlePatch(pullreqCtrl *pullreq.Controller, format enum.PatchFormat, maxSize int64) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		pullreqNumber, err := request.GetPullReqNumberFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		setSHAs := func(sourceSHA, mergeBaseSHA string) {
+			w.Header().Set("X-Source-Sha", sourceSHA)
+			w.Header().Set("X-Merge-Base-Sha", mergeBaseSHA)
+			render.PatchHeaders(w, strconv.FormatInt(pullreqNumber, 10)+"."+string(format), false)
+		}
+
+		render.Patch(ctx, w, maxSize, func(ctx context.Context, pw io.Writer) error {
+			return pullreqCtrl.Patch(ctx, pw, session, repoRef, pullreqNumber, format, setSHAs)
+		})
+	}
+}
@@ -35,7 +35,11 @@ func HandleBlame(repoCtrl *repo.Controller) http.HandlerFunc {
 			return
 		}
 
-		path := request.GetOptionalRemainderFromPath(r)
+		path, err := request.GetSanitizedPathFromRemainder(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
 
 		// line_from is optional, skipped if set to 0
 		lineFrom, err := request.QueryParamAsPositiveInt64OrDefault(r, request.QueryParamLineFrom, 0)
@@ -41,7 +41,11 @@ func HandleGetContent(repoCtrl *repo.Controller) http.HandlerFunc {
 			return
 		}
 
-		repoPath := request.GetOptionalRemainderFromPath(r)
+		repoPath, err := request.GetSanitizedPathFromRemainder(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
 
 		resp, err := repoCtrl.GetContent(ctx, session, repoRef, gitRef, repoPath, includeCommit)
 		if err != nil {
@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+)
+
+// HandleContributorStats writes json-encoded contributor statistics to the http response body.
+// In case the statistics are still being computed, 202 is returned with the location to poll.
+func HandleContributorStats(repoCtrl *repo.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		gitRef := request.GetGitRefFromQueryOrDefault(r, "")
+
+		// since is optional, skipped if set to 0
+		since, err := request.QueryParamAsPositiveInt64OrDefault(r, request.QueryParamSince, 0)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		// until is optional, skipped if set to 0
+		until, err := request.QueryParamAsPositiveInt64OrDefault(r, request.QueryParamUntil, 0)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		stats, err := repoCtrl.ContributorStats(ctx, session, repoRef, gitRef, since, until)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		if stats == nil {
+			w.Header().Set("Location", r.URL.RequestURI())
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, stats)
+	}
+}
@@ -15,9 +15,9 @@
 package repo
 
 import (
-	"encoding/json"
 	"net/http"
 
+	"github.com/harness/gitness/app/api/controller/limiter"
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/render"
 	"github.com/harness/gitness/app/api/request"
@@ -26,13 +26,13 @@ import (
 // HandleCreate returns a http.HandlerFunc that creates a new repository.
 func HandleCreate(repoCtrl *repo.Controller) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
+		ctx, quotaWarnings := limiter.WithQuotaWarnings(r.Context())
 		session, _ := request.AuthSessionFrom(ctx)
 
 		in := new(repo.CreateInput)
-		err := json.NewDecoder(r.Body).Decode(in)
+		err := request.DecodeJSON(r, request.MaxJSONBodySize, in)
 		if err != nil {
-			render.BadRequestf(ctx, w, "Invalid Request Body: %s.", err)
+			render.TranslatedUserError(ctx, w, err)
 			return
 		}
 
@@ -42,6 +42,7 @@ func HandleCreate(repoCtrl *repo.Controller) http.HandlerFunc {
 			return
 		}
 
+		render.QuotaWarnings(w, quotaWarnings)
 		render.JSON(w, http.StatusCreated, repo)
 	}
 }
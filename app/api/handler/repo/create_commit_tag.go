@@ -15,7 +15,6 @@
 package repo
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"github.com/harness/gitness/app/api/controller/repo"
@@ -34,9 +33,9 @@ func HandleCreateCommitTag(repoCtrl *repo.Controller) http.HandlerFunc {
 		}
 
 		in := new(repo.CreateCommitTagInput)
-		err = json.NewDecoder(r.Body).Decode(in)
+		err = request.DecodeJSON(r, request.MaxJSONBodySize, in)
 		if err != nil {
-			render.BadRequestf(ctx, w, "Invalid request body: %s.", err)
+			render.TranslatedUserError(ctx, w, err)
 			return
 		}
 
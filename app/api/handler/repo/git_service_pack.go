@@ -25,6 +25,7 @@ import (
 	"github.com/harness/gitness/app/api/render"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/gitlimit"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/git/api"
 	"github.com/harness/gitness/types/enum"
@@ -83,6 +84,10 @@ func HandleGitServicePack(
 			renderBasicAuth(ctx, w, urlProvider)
 			return
 		}
+		if errors.Is(err, gitlimit.ErrTooManyOperations) {
+			// the rejection was already reported to the client via git's error channel.
+			return
+		}
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
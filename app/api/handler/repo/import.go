@@ -41,6 +41,6 @@ func HandleImport(repoCtrl *repo.Controller) http.HandlerFunc {
 			return
 		}
 
-		render.JSON(w, http.StatusCreated, repo)
+		render.OperationAccepted(w, repo.OperationID, repo)
 	}
 }
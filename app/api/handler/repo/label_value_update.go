@@ -15,7 +15,6 @@
 package repo
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"github.com/harness/gitness/app/api/controller/repo"
@@ -48,9 +47,9 @@ func HandleUpdateLabelValue(repoCtrl *repo.Controller) http.HandlerFunc {
 		}
 
 		in := new(types.UpdateValueInput)
-		err = json.NewDecoder(r.Body).Decode(in)
+		err = request.DecodeJSON(r, request.MaxJSONBodySize, in)
 		if err != nil {
-			render.BadRequestf(ctx, w, "Invalid request body: %s.", err)
+			render.TranslatedUserError(ctx, w, err)
 			return
 		}
 
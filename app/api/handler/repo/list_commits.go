@@ -49,9 +49,12 @@ func HandleListCommits(repoCtrl *repo.Controller) http.HandlerFunc {
 			return
 		}
 
-		// TODO: get last page indicator explicitly - current check is wrong in case len % limit == 0
-		isLastPage := len(list.Commits) < filter.Limit
-		render.PaginationNoTotal(r, w, filter.Page, filter.Limit, isLastPage)
+		// the history of a file following renames is paginated with the next_after cursor instead of pages.
+		if !filter.Follow {
+			// TODO: get last page indicator explicitly - current check is wrong in case len % limit == 0
+			isLastPage := len(list.Commits) < filter.Limit
+			render.PaginationNoTotal(r, w, filter.Page, filter.Limit, isLastPage)
+		}
 		render.JSON(w, http.StatusOK, list)
 	}
 }
@@ -35,7 +35,13 @@ func HandleListServiceAccounts(repoCtrl *repo.Controller) http.HandlerFunc {
 			return
 		}
 
-		sas, err := repoCtrl.ListServiceAccounts(ctx, session, repoRef)
+		filter, err := request.ParseServiceAccountFilter(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		sas, err := repoCtrl.ListServiceAccounts(ctx, session, repoRef, filter)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
@@ -0,0 +1,55 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types/enum"
+)
+
+// HandleCommitPatch returns a http.HandlerFunc that streams a commit as .patch or .diff file.
+func HandleCommitPatch(repoCtrl *repo.Controller, format enum.PatchFormat, maxSize int64) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		commitSHA, err := request.GetCommitSHAFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.Patch(ctx, w, maxSize, func(ctx context.Context, pw io.Writer) error {
+			return repoCtrl.CommitPatch(ctx, pw, session, repoRef, commitSHA, format, func(resolved sha.SHA) {
+				// abbreviated SHAs could become ambiguous later on, only full SHAs are immutable.
+				immutable := resolved.String() == strings.ToLower(commitSHA)
+				render.PatchHeaders(w, resolved.String()+"."+string(format), immutable)
+			})
+		})
+	}
+}
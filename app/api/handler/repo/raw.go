@@ -39,7 +39,11 @@ func HandleRaw(repoCtrl *repo.Controller) http.HandlerFunc {
 		}
 
 		gitRef := request.GetGitRefFromQueryOrDefault(r, "")
-		path := request.GetOptionalRemainderFromPath(r)
+		path, err := request.GetSanitizedPathFromRemainder(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
 
 		dataReader, dataLength, sha, err := repoCtrl.Raw(ctx, session, repoRef, gitRef, path)
 		if err != nil {
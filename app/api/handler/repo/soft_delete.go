@@ -37,7 +37,13 @@ func HandleSoftDelete(repoCtrl *repo.Controller) http.HandlerFunc {
 			return
 		}
 
-		softDeleteResponse, err := repoCtrl.SoftDelete(ctx, session, repoRef)
+		dryRun, err := request.ParseDryRunFromQuery(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		softDeleteResponse, err := repoCtrl.SoftDelete(ctx, session, repoRef, dryRun)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
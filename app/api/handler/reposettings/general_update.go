@@ -15,7 +15,6 @@
 package reposettings
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"github.com/harness/gitness/app/api/controller/reposettings"
@@ -34,9 +33,9 @@ func HandleGeneralUpdate(repoSettingCtrl *reposettings.Controller) http.HandlerF
 		}
 
 		in := new(reposettings.GeneralSettings)
-		err = json.NewDecoder(r.Body).Decode(in)
+		err = request.DecodeJSON(r, request.MaxJSONBodySize, in)
 		if err != nil {
-			render.BadRequestf(ctx, w, "Invalid request body: %s.", err)
+			render.TranslatedUserError(ctx, w, err)
 			return
 		}
 
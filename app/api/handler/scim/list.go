@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scim
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/harness/gitness/app/api/controller/scim"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+)
+
+const (
+	queryParamFilter     = "filter"
+	queryParamStartIndex = "startIndex"
+	queryParamCount      = "count"
+)
+
+// HandleList returns an http.HandlerFunc that writes a json-encoded page of users.
+// Besides the standard page and limit parameters, the SCIM startIndex and count parameters are supported.
+func HandleList(scimCtrl *scim.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		filter := request.QueryParamOrDefault(r, queryParamFilter, "")
+		page, size := parsePagination(r)
+
+		out, err := scimCtrl.List(ctx, session, filter, page, size)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.Pagination(r, w, page, size, int(out.TotalResults))
+		render.JSON(w, http.StatusOK, out)
+	}
+}
+
+// parsePagination returns the requested page and page size.
+// The 1-based SCIM startIndex is rounded down to the start of the page containing it.
+func parsePagination(r *http.Request) (int, int) {
+	page := request.ParsePage(r)
+	size := request.ParseLimit(r)
+
+	if count, err := strconv.Atoi(r.URL.Query().Get(queryParamCount)); err == nil && count > 0 {
+		size = min(count, request.PerPageMax)
+	}
+	if startIndex, err := strconv.Atoi(r.URL.Query().Get(queryParamStartIndex)); err == nil && startIndex > 0 {
+		page = (startIndex-1)/size + 1
+	}
+
+	return page, size
+}
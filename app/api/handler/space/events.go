@@ -37,7 +37,9 @@ func HandleEvents(appCtx context.Context, spaceCtrl *space.Controller) http.Hand
 			return
 		}
 
-		chEvents, chErr, sseCancel, err := spaceCtrl.Events(ctx, session, spaceRef)
+		lastEventID := request.GetLastEventIDFromHeader(r)
+
+		chEvents, chErr, sseCancel, err := spaceCtrl.Events(ctx, session, spaceRef, lastEventID)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
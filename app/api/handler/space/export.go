@@ -41,11 +41,12 @@ func HandleExport(spaceCtrl *space.Controller) http.HandlerFunc {
 			return
 		}
 
-		err = spaceCtrl.Export(ctx, session, spaceRef, in)
+		out, err := spaceCtrl.Export(ctx, session, spaceRef, in)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
 		}
-		w.WriteHeader(http.StatusAccepted)
+
+		render.OperationAccepted(w, out.OperationID, out)
 	}
 }
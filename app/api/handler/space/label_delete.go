@@ -20,6 +20,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/space"
 	"github.com/harness/gitness/app/api/render"
 	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/types"
 )
 
 func HandleDeleteLabel(labelCtrl *space.Controller) http.HandlerFunc {
@@ -39,12 +40,23 @@ func HandleDeleteLabel(labelCtrl *space.Controller) http.HandlerFunc {
 			return
 		}
 
-		err = labelCtrl.DeleteLabel(ctx, session, spaceRef, identifier)
+		dryRun, err := request.ParseDryRunFromQuery(r)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
 		}
 
+		affected, err := labelCtrl.DeleteLabel(ctx, session, spaceRef, identifier, dryRun)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		if dryRun {
+			render.JSON(w, http.StatusOK, types.DryRunOutput{DryRun: true, Affected: affected})
+			return
+		}
+
 		render.DeleteSuccessful(w)
 	}
 }
@@ -20,7 +20,6 @@ import (
 	"github.com/harness/gitness/app/api/controller/space"
 	"github.com/harness/gitness/app/api/render"
 	"github.com/harness/gitness/app/api/request"
-	"github.com/harness/gitness/types/enum"
 )
 
 // HandleListRepos writes json-encoded list of repos in the request body.
@@ -40,10 +39,6 @@ func HandleListRepos(spaceCtrl *space.Controller) http.HandlerFunc {
 			return
 		}
 
-		if filter.Order == enum.OrderDefault {
-			filter.Order = enum.OrderAsc
-		}
-
 		repos, count, err := spaceCtrl.ListRepositories(
 			ctx, session, spaceRef, filter)
 		if err != nil {
@@ -33,7 +33,13 @@ func HandleListServiceAccounts(spaceCtrl *space.Controller) http.HandlerFunc {
 			return
 		}
 
-		sas, err := spaceCtrl.ListServiceAccounts(ctx, session, spaceRef)
+		filter, err := request.ParseServiceAccountFilter(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		sas, err := spaceCtrl.ListServiceAccounts(ctx, session, spaceRef, filter)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
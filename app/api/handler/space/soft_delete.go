@@ -35,7 +35,13 @@ func HandleSoftDelete(spaceCtrl *space.Controller) http.HandlerFunc {
 			return
 		}
 
-		res, err := spaceCtrl.SoftDelete(ctx, session, spaceRef)
+		dryRun, err := request.ParseDryRunFromQuery(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		res, err := spaceCtrl.SoftDelete(ctx, session, spaceRef, dryRun)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/space"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/types"
+)
+
+// HandleUsage returns the usage report of a space, as json or, if requested through the Accept header, as csv.
+func HandleUsage(spaceCtrl *space.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		spaceRef, err := request.GetSpaceRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		from, to, err := request.GetUsageRangeFromQuery(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		report, err := spaceCtrl.Usage(ctx, session, spaceRef, from, to)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		if request.NegotiateContentType(r, request.ContentTypeJSON, request.ContentTypeCSV) == request.ContentTypeCSV {
+			render.CSV(ctx, w, http.StatusOK, "usage.csv", usageRecords(report))
+			return
+		}
+
+		render.JSON(w, http.StatusOK, report)
+	}
+}
+
+// usageRecords flattens the usage report into csv records, one per entry and day.
+func usageRecords(report *types.UsageReport) [][]string {
+	records := [][]string{{"type", "path", "day", "ci_minutes", "git_size", "artifacts_size"}}
+	for _, entry := range report.Entries {
+		for _, bucket := range entry.Buckets {
+			records = append(records, []string{
+				string(entry.Type),
+				entry.Path,
+				time.UnixMilli(bucket.Day).UTC().Format(time.DateOnly),
+				strconv.FormatFloat(bucket.CIMinutes, 'f', 2, 64),
+				strconv.FormatInt(bucket.GitSize, 10),
+				strconv.FormatInt(bucket.ArtifactsSize, 10),
+			})
+		}
+	}
+
+	return records
+}
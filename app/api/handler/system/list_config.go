@@ -28,6 +28,9 @@ type ConfigOutput struct {
 	SSHEnabled                    bool `json:"ssh_enabled"`
 	GitspaceEnabled               bool `json:"gitspace_enabled"`
 	ArtifactRegistryEnabled       bool `json:"artifact_registry_enabled"`
+
+	OIDCEnabled     bool   `json:"oidc_enabled"`
+	OIDCDisplayName string `json:"oidc_display_name,omitempty"`
 }
 
 // HandleGetConfig returns an http.HandlerFunc that processes an http.Request
@@ -45,9 +48,11 @@ func HandleGetConfig(config *types.Config, sysCtrl *system.Controller) http.Hand
 		render.JSON(w, http.StatusOK, ConfigOutput{
 			SSHEnabled:                    config.SSH.Enable,
 			UserSignupAllowed:             userSignupAllowed,
-			PublicResourceCreationEnabled: config.PublicResourceCreationEnabled,
+			PublicResourceCreationEnabled: sysCtrl.IsPublicResourceCreationEnabled(),
 			GitspaceEnabled:               config.Gitspace.Enable,
 			ArtifactRegistryEnabled:       config.Registry.Enable,
+			OIDCEnabled:                   config.OIDC.Enabled,
+			OIDCDisplayName:               config.OIDC.DisplayName,
 		})
 	}
 }
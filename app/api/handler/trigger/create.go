@@ -15,7 +15,6 @@
 package trigger
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"github.com/harness/gitness/app/api/controller/trigger"
@@ -39,9 +38,9 @@ func HandleCreate(triggerCtrl *trigger.Controller) http.HandlerFunc {
 		}
 
 		in := new(trigger.CreateInput)
-		err = json.NewDecoder(r.Body).Decode(in)
+		err = request.DecodeJSON(r, request.MaxJSONBodySize, in)
 		if err != nil {
-			render.BadRequestf(ctx, w, "Invalid Request Body: %s.", err)
+			render.TranslatedUserError(ctx, w, err)
 			return
 		}
 
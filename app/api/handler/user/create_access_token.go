@@ -15,7 +15,6 @@
 package user
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"github.com/harness/gitness/app/api/controller/user"
@@ -32,9 +31,9 @@ func HandleCreateAccessToken(userCtrl *user.Controller) http.HandlerFunc {
 		userUID := session.Principal.UID
 
 		in := new(user.CreateTokenInput)
-		err := json.NewDecoder(r.Body).Decode(in)
+		err := request.DecodeJSON(r, request.MaxJSONBodySize, in)
 		if err != nil {
-			render.BadRequestf(ctx, w, "Invalid request body: %s.", err)
+			render.TranslatedUserError(ctx, w, err)
 			return
 		}
 
@@ -15,7 +15,6 @@
 package user
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"github.com/harness/gitness/app/api/controller/user"
@@ -30,9 +29,9 @@ func HandleCreatePublicKey(userCtrl *user.Controller) http.HandlerFunc {
 		userUID := session.Principal.UID
 
 		in := new(user.CreatePublicKeyInput)
-		err := json.NewDecoder(r.Body).Decode(in)
+		err := request.DecodeJSON(r, request.MaxJSONBodySize, in)
 		if err != nil {
-			render.BadRequestf(ctx, w, "Invalid Request Body: %s.", err)
+			render.TranslatedUserError(ctx, w, err)
 			return
 		}
 
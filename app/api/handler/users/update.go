@@ -15,7 +15,6 @@
 package users
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"github.com/harness/gitness/app/api/controller/user"
@@ -36,8 +35,8 @@ func HandleUpdate(userCtrl *user.Controller) http.HandlerFunc {
 		}
 
 		in := new(user.UpdateInput)
-		if err = json.NewDecoder(r.Body).Decode(in); err != nil {
-			render.BadRequestf(ctx, w, "Invalid request body: %s.", err)
+		if err = request.DecodeJSON(r, request.MaxJSONBodySize, in); err != nil {
+			render.TranslatedUserError(ctx, w, err)
 			return
 		}
 
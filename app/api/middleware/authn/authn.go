@@ -20,6 +20,7 @@ import (
 
 	"github.com/harness/gitness/app/api/render"
 	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authn"
 
@@ -37,6 +38,13 @@ func Attempt(authenticator authn.Authenticator) func(http.Handler) http.Handler
 			log := hlog.FromRequest(r)
 
 			session, err := authenticator.Authenticate(r)
+			if errors.Is(err, authn.ErrPrincipalBlocked) {
+				log.Debug().Err(err).Msg("authentication failed")
+
+				render.UserError(ctx, w, usererror.ErrPrincipalBlocked)
+				return
+			}
+
 			if err != nil && !errors.Is(err, authn.ErrNoAuthData) {
 				log.Debug().Err(err).Msg("authentication failed")
 
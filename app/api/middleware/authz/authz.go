@@ -48,3 +48,24 @@ func BlockSessionToken(next http.Handler) http.Handler {
 		},
 	)
 }
+
+// BlockDeployKey blocks any request that uses a deploy key for authentication.
+// NOTE: Deploy keys are restricted to git operations and can't be used with the api.
+func BlockDeployKey(next http.Handler) http.Handler {
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			if session, oks := request.AuthSessionFrom(ctx); oks {
+				if _, ok := session.Metadata.(*auth.DeployKeyMetadata); ok {
+					log.Ctx(ctx).Warn().Msg("blocking api call - deploy keys are only allowed for usage with git")
+
+					render.Unauthorized(ctx, w)
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		},
+	)
+}
@@ -0,0 +1,274 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cors
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	headerOrigin           = "Origin"
+	headerVary             = "Vary"
+	headerRequestMethod    = "Access-Control-Request-Method"
+	headerRequestHeaders   = "Access-Control-Request-Headers"
+	headerAllowOrigin      = "Access-Control-Allow-Origin"
+	headerAllowMethods     = "Access-Control-Allow-Methods"
+	headerAllowHeaders     = "Access-Control-Allow-Headers"
+	headerAllowCredentials = "Access-Control-Allow-Credentials"
+	headerExposeHeaders    = "Access-Control-Expose-Headers"
+	headerMaxAge           = "Access-Control-Max-Age"
+
+	varyPreflight = "Origin, Access-Control-Request-Method, Access-Control-Request-Headers"
+	varyActual    = "Origin"
+
+	wildcard = "*"
+)
+
+// Options configures the cors middleware.
+type Options struct {
+	// AllowedOrigins is the list of origins a cross-domain request can be executed from.
+	// An entry can be an exact origin (e.g. "https://app.example.com"), a pattern with a single
+	// wildcard (e.g. "https://*.example.com") or "*" to allow all origins.
+	AllowedOrigins []string
+
+	// AllowedMethods is the list of methods the client is allowed to use with cross-domain requests.
+	AllowedMethods []string
+
+	// AllowedHeaders is the list of non-simple headers the client is allowed to use with
+	// cross-domain requests. "*" allows all headers.
+	AllowedHeaders []string
+
+	// ExposedHeaders is the list of response headers that are made available to the client.
+	ExposedHeaders []string
+
+	// AllowCredentials indicates whether the request can include user credentials.
+	// Credentials are never allowed for origins that only match the "*" wildcard.
+	AllowCredentials bool
+
+	// MaxAge is the number of seconds the result of a preflight request can be cached.
+	MaxAge int
+}
+
+type originPattern struct {
+	prefix string
+	suffix string
+}
+
+func (p originPattern) match(origin string) bool {
+	return len(origin) > len(p.prefix)+len(p.suffix) &&
+		strings.HasPrefix(origin, p.prefix) &&
+		strings.HasSuffix(origin, p.suffix)
+}
+
+type cors struct {
+	allowAllOrigins  bool
+	origins          map[string]struct{}
+	originPatterns   []originPattern
+	methods          map[string]struct{}
+	allowAllHeaders  bool
+	headers          map[string]struct{}
+	allowMethods     string
+	exposeHeaders    string
+	allowCredentials bool
+	maxAge           string
+}
+
+// New returns a middleware that handles cross-origin requests as configured by the options.
+// Preflight requests are answered directly and are never passed on to the next handler.
+func New(opts Options) func(http.Handler) http.Handler {
+	c := &cors{
+		origins:          map[string]struct{}{},
+		methods:          map[string]struct{}{},
+		headers:          map[string]struct{}{},
+		allowCredentials: opts.AllowCredentials,
+	}
+
+	for _, origin := range opts.AllowedOrigins {
+		origin = strings.ToLower(strings.TrimSpace(origin))
+		switch {
+		case origin == "":
+			continue
+		case origin == wildcard:
+			c.allowAllOrigins = true
+		case strings.Contains(origin, wildcard):
+			prefix, suffix, _ := strings.Cut(origin, wildcard)
+			c.originPatterns = append(c.originPatterns, originPattern{prefix: prefix, suffix: suffix})
+		default:
+			c.origins[origin] = struct{}{}
+		}
+	}
+
+	methods := make([]string, 0, len(opts.AllowedMethods))
+	for _, method := range opts.AllowedMethods {
+		method = strings.ToUpper(strings.TrimSpace(method))
+		if method == "" {
+			continue
+		}
+		c.methods[method] = struct{}{}
+		methods = append(methods, method)
+	}
+	c.allowMethods = strings.Join(methods, ", ")
+
+	for _, header := range opts.AllowedHeaders {
+		header = http.CanonicalHeaderKey(strings.TrimSpace(header))
+		switch header {
+		case "":
+			continue
+		case wildcard:
+			c.allowAllHeaders = true
+		default:
+			c.headers[header] = struct{}{}
+		}
+	}
+
+	exposed := make([]string, 0, len(opts.ExposedHeaders))
+	for _, header := range opts.ExposedHeaders {
+		header = http.CanonicalHeaderKey(strings.TrimSpace(header))
+		if header != "" {
+			exposed = append(exposed, header)
+		}
+	}
+	c.exposeHeaders = strings.Join(exposed, ", ")
+
+	if opts.MaxAge > 0 {
+		c.maxAge = strconv.Itoa(opts.MaxAge)
+	}
+
+	return c.handler
+}
+
+func (c *cors) handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions && r.Header.Get(headerRequestMethod) != "" {
+			c.handlePreflight(w, r)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		c.handleActual(w, r)
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (c *cors) handlePreflight(w http.ResponseWriter, r *http.Request) {
+	h := w.Header()
+	h.Add(headerVary, varyPreflight)
+
+	origin := r.Header.Get(headerOrigin)
+	allowOrigin, credentials, ok := c.matchOrigin(origin)
+	if !ok {
+		return
+	}
+
+	method := strings.ToUpper(r.Header.Get(headerRequestMethod))
+	if _, ok := c.methods[method]; !ok {
+		return
+	}
+
+	requested := parseHeaderList(r.Header.Get(headerRequestHeaders))
+	if !c.headersAllowed(requested) {
+		return
+	}
+
+	h.Set(headerAllowOrigin, allowOrigin)
+	h.Set(headerAllowMethods, c.allowMethods)
+	if len(requested) > 0 {
+		h.Set(headerAllowHeaders, strings.Join(requested, ", "))
+	}
+	if credentials {
+		h.Set(headerAllowCredentials, "true")
+	}
+	if c.maxAge != "" {
+		h.Set(headerMaxAge, c.maxAge)
+	}
+}
+
+func (c *cors) handleActual(w http.ResponseWriter, r *http.Request) {
+	h := w.Header()
+	h.Add(headerVary, varyActual)
+
+	origin := r.Header.Get(headerOrigin)
+	allowOrigin, credentials, ok := c.matchOrigin(origin)
+	if !ok {
+		return
+	}
+
+	h.Set(headerAllowOrigin, allowOrigin)
+	if c.exposeHeaders != "" {
+		h.Set(headerExposeHeaders, c.exposeHeaders)
+	}
+	if credentials {
+		h.Set(headerAllowCredentials, "true")
+	}
+}
+
+// matchOrigin returns the value of the Access-Control-Allow-Origin header for the origin,
+// and whether credentials can be allowed for it.
+// Origins that only match the "*" wildcard are answered with "*" and never get credentials.
+func (c *cors) matchOrigin(origin string) (string, bool, bool) {
+	if origin == "" {
+		return "", false, false
+	}
+
+	lower := strings.ToLower(origin)
+	if _, ok := c.origins[lower]; ok {
+		return origin, c.allowCredentials, true
+	}
+
+	for _, p := range c.originPatterns {
+		if p.match(lower) {
+			return origin, c.allowCredentials, true
+		}
+	}
+
+	if c.allowAllOrigins {
+		return wildcard, false, true
+	}
+
+	return "", false, false
+}
+
+func (c *cors) headersAllowed(requested []string) bool {
+	if c.allowAllHeaders {
+		return true
+	}
+
+	for _, header := range requested {
+		if _, ok := c.headers[header]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func parseHeaderList(value string) []string {
+	if value == "" {
+		return nil
+	}
+
+	parts := strings.Split(value, ",")
+	headers := make([]string, 0, len(parts))
+	for _, part := range parts {
+		part = http.CanonicalHeaderKey(strings.TrimSpace(part))
+		if part != "" {
+			headers = append(headers, part)
+		}
+	}
+
+	return headers
+}
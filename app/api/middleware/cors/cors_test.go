@@ -0,0 +1,242 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCors(t *testing.T) {
+	opts := Options{
+		AllowedOrigins:   []string{"https://app.example.com", "https://*.gitness.dev"},
+		AllowedMethods:   []string{"GET", "POST", "DELETE"},
+		AllowedHeaders:   []string{"Authorization", "Content-Type"},
+		ExposedHeaders:   []string{"Link", "X-Total"},
+		AllowCredentials: true,
+		MaxAge:           300,
+	}
+	wildcardOpts := opts
+	wildcardOpts.AllowedOrigins = []string{"*"}
+
+	tests := []struct {
+		name        string
+		opts        Options
+		method      string
+		header      map[string]string
+		wantStatus  int
+		wantNext    bool
+		wantOrigin  string
+		wantCreds   string
+		wantMethods string
+		wantHeaders string
+		wantExposed string
+		wantMaxAge  string
+		wantVary    string
+	}{
+		{
+			name:   "preflight exact origin",
+			opts:   opts,
+			method: http.MethodOptions,
+			header: map[string]string{
+				headerOrigin:         "https://app.example.com",
+				headerRequestMethod:  "DELETE",
+				headerRequestHeaders: "authorization, content-type",
+			},
+			wantStatus:  http.StatusNoContent,
+			wantOrigin:  "https://app.example.com",
+			wantCreds:   "true",
+			wantMethods: "GET, POST, DELETE",
+			wantHeaders: "Authorization, Content-Type",
+			wantMaxAge:  "300",
+			wantVary:    varyPreflight,
+		},
+		{
+			name:   "preflight subdomain pattern",
+			opts:   opts,
+			method: http.MethodOptions,
+			header: map[string]string{
+				headerOrigin:        "https://ci.gitness.dev",
+				headerRequestMethod: "POST",
+			},
+			wantStatus:  http.StatusNoContent,
+			wantOrigin:  "https://ci.gitness.dev",
+			wantCreds:   "true",
+			wantMethods: "GET, POST, DELETE",
+			wantMaxAge:  "300",
+			wantVary:    varyPreflight,
+		},
+		{
+			name:   "preflight pattern doesn't match bare domain",
+			opts:   opts,
+			method: http.MethodOptions,
+			header: map[string]string{
+				headerOrigin:        "https://.gitness.dev",
+				headerRequestMethod: "POST",
+			},
+			wantStatus: http.StatusNoContent,
+			wantVary:   varyPreflight,
+		},
+		{
+			name:   "preflight disallowed origin",
+			opts:   opts,
+			method: http.MethodOptions,
+			header: map[string]string{
+				headerOrigin:        "https://evil.example.com",
+				headerRequestMethod: "GET",
+			},
+			wantStatus: http.StatusNoContent,
+			wantVary:   varyPreflight,
+		},
+		{
+			name:   "preflight disallowed method",
+			opts:   opts,
+			method: http.MethodOptions,
+			header: map[string]string{
+				headerOrigin:        "https://app.example.com",
+				headerRequestMethod: "PUT",
+			},
+			wantStatus: http.StatusNoContent,
+			wantVary:   varyPreflight,
+		},
+		{
+			name:   "preflight disallowed header",
+			opts:   opts,
+			method: http.MethodOptions,
+			header: map[string]string{
+				headerOrigin:         "https://app.example.com",
+				headerRequestMethod:  "GET",
+				headerRequestHeaders: "X-Custom",
+			},
+			wantStatus: http.StatusNoContent,
+			wantVary:   varyPreflight,
+		},
+		{
+			name:   "preflight wildcard origin never allows credentials",
+			opts:   wildcardOpts,
+			method: http.MethodOptions,
+			header: map[string]string{
+				headerOrigin:        "https://any.example.org",
+				headerRequestMethod: "GET",
+			},
+			wantStatus:  http.StatusNoContent,
+			wantOrigin:  "*",
+			wantMethods: "GET, POST, DELETE",
+			wantMaxAge:  "300",
+			wantVary:    varyPreflight,
+		},
+		{
+			name:   "options without request method is passed on",
+			opts:   opts,
+			method: http.MethodOptions,
+			header: map[string]string{
+				headerOrigin: "https://app.example.com",
+			},
+			wantStatus:  http.StatusOK,
+			wantNext:    true,
+			wantOrigin:  "https://app.example.com",
+			wantCreds:   "true",
+			wantExposed: "Link, X-Total",
+			wantVary:    varyActual,
+		},
+		{
+			name:   "actual request exact origin",
+			opts:   opts,
+			method: http.MethodGet,
+			header: map[string]string{
+				headerOrigin: "https://APP.example.com",
+			},
+			wantStatus:  http.StatusOK,
+			wantNext:    true,
+			wantOrigin:  "https://APP.example.com",
+			wantCreds:   "true",
+			wantExposed: "Link, X-Total",
+			wantVary:    varyActual,
+		},
+		{
+			name:   "actual request disallowed origin",
+			opts:   opts,
+			method: http.MethodGet,
+			header: map[string]string{
+				headerOrigin: "https://gitness.dev.evil.com",
+			},
+			wantStatus: http.StatusOK,
+			wantNext:   true,
+			wantVary:   varyActual,
+		},
+		{
+			name:   "actual request wildcard origin never allows credentials",
+			opts:   wildcardOpts,
+			method: http.MethodPost,
+			header: map[string]string{
+				headerOrigin: "https://any.example.org",
+			},
+			wantStatus:  http.StatusOK,
+			wantNext:    true,
+			wantOrigin:  "*",
+			wantExposed: "Link, X-Total",
+			wantVary:    varyActual,
+		},
+		{
+			name:       "actual request without origin",
+			opts:       opts,
+			method:     http.MethodGet,
+			wantStatus: http.StatusOK,
+			wantNext:   true,
+			wantVary:   varyActual,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			calledNext := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				calledNext = true
+				w.WriteHeader(http.StatusOK)
+			})
+
+			r := httptest.NewRequest(test.method, "/api/v1/repos", nil)
+			for k, v := range test.header {
+				r.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+
+			New(test.opts)(next).ServeHTTP(w, r)
+
+			if w.Code != test.wantStatus {
+				t.Errorf("status: got %d, want %d", w.Code, test.wantStatus)
+			}
+			if calledNext != test.wantNext {
+				t.Errorf("next handler called: got %t, want %t", calledNext, test.wantNext)
+			}
+
+			h := w.Header()
+			for header, want := range map[string]string{
+				headerAllowOrigin:      test.wantOrigin,
+				headerAllowCredentials: test.wantCreds,
+				headerAllowMethods:     test.wantMethods,
+				headerAllowHeaders:     test.wantHeaders,
+				headerExposeHeaders:    test.wantExposed,
+				headerMaxAge:           test.wantMaxAge,
+				headerVary:             test.wantVary,
+			} {
+				if got := h.Get(header); got != want {
+					t.Errorf("%s: got %q, want %q", header, got, want)
+				}
+			}
+		})
+	}
+}
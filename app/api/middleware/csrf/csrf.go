@@ -0,0 +1,89 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csrf
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+
+	"github.com/rs/zerolog/hlog"
+)
+
+// HeaderCSRFToken is the request header that has to carry the CSRF token.
+const HeaderCSRFToken = "X-CSRF-Token"
+
+// Protect returns an http.HandlerFunc middleware that requires a valid CSRF token in the request header
+// for state changing requests of sessions that were authenticated using the session cookie.
+// Requests authenticated by other means (e.g. bearer token) are not affected.
+//
+// The middleware has to run after the authentication middleware.
+// Whenever the client doesn't have the CSRF cookie of its current session, the cookie is (re)issued,
+// which allows clients to refresh the token and retry after a failed request.
+func Protect(cookieName string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			session, ok := request.AuthSessionFrom(ctx)
+			if !ok || !session.FromCookie {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			sessionToken, ok := request.GetTokenFromCookie(r, cookieName)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			expected := auth.CSRFToken(sessionToken)
+
+			if current, ok := request.GetCookie(r, auth.CSRFCookieName(cookieName)); !ok || current != expected {
+				http.SetCookie(w, auth.NewCSRFCookie(r, cookieName, sessionToken))
+			}
+
+			if isSafeMethod(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			actual := r.Header.Get(HeaderCSRFToken)
+			if subtle.ConstantTimeCompare([]byte(actual), []byte(expected)) != 1 {
+				hlog.FromRequest(r).Debug().
+					Bool("csrf_token_present", actual != "").
+					Msg("csrf token validation failed")
+
+				render.UserError(ctx, w, usererror.ErrCSRFTokenInvalid)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csrf
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/auth"
+)
+
+const (
+	testCookieName   = "token"
+	testSessionToken = "session-jwt"
+)
+
+func TestProtect(t *testing.T) {
+	validToken := auth.CSRFToken(testSessionToken)
+	csrfCookieName := auth.CSRFCookieName(testCookieName)
+
+	tests := []struct {
+		name            string
+		method          string
+		session         *auth.Session
+		sessionCookie   bool
+		csrfCookie      string
+		csrfHeader      string
+		wantStatus      int
+		wantNext        bool
+		wantCookieIssue bool
+	}{
+		{
+			name:            "cookie session safe method without token",
+			method:          http.MethodGet,
+			session:         &auth.Session{FromCookie: true},
+			sessionCookie:   true,
+			wantStatus:      http.StatusOK,
+			wantNext:        true,
+			wantCookieIssue: true,
+		},
+		{
+			name:          "cookie session post with valid token",
+			method:        http.MethodPost,
+			session:       &auth.Session{FromCookie: true},
+			sessionCookie: true,
+			csrfCookie:    validToken,
+			csrfHeader:    validToken,
+			wantStatus:    http.StatusOK,
+			wantNext:      true,
+		},
+		{
+			name:            "cookie session post without token",
+			method:          http.MethodPost,
+			session:         &auth.Session{FromCookie: true},
+			sessionCookie:   true,
+			wantStatus:      http.StatusForbidden,
+			wantCookieIssue: true,
+		},
+		{
+			name:          "cookie session delete with mismatched token",
+			method:        http.MethodDelete,
+			session:       &auth.Session{FromCookie: true},
+			sessionCookie: true,
+			csrfCookie:    validToken,
+			csrfHeader:    auth.CSRFToken("other-session"),
+			wantStatus:    http.StatusForbidden,
+		},
+		{
+			name:            "cookie session patch with token of previous session",
+			method:          http.MethodPatch,
+			session:         &auth.Session{FromCookie: true},
+			sessionCookie:   true,
+			csrfCookie:      auth.CSRFToken("previous-session"),
+			csrfHeader:      auth.CSRFToken("previous-session"),
+			wantStatus:      http.StatusForbidden,
+			wantCookieIssue: true,
+		},
+		{
+			name:       "bearer session post without token",
+			method:     http.MethodPost,
+			session:    &auth.Session{FromCookie: false},
+			wantStatus: http.StatusOK,
+			wantNext:   true,
+		},
+		{
+			name:          "bearer session post with stale session cookie",
+			method:        http.MethodPut,
+			session:       &auth.Session{FromCookie: false},
+			sessionCookie: true,
+			wantStatus:    http.StatusOK,
+			wantNext:      true,
+		},
+		{
+			name:       "anonymous post",
+			method:     http.MethodPost,
+			wantStatus: http.StatusOK,
+			wantNext:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			calledNext := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				calledNext = true
+				w.WriteHeader(http.StatusOK)
+			})
+
+			r := httptest.NewRequest(test.method, "http://localhost/api/v1/repos", nil)
+			if test.session != nil {
+				r = r.WithContext(request.WithAuthSession(r.Context(), test.session))
+			}
+			if test.sessionCookie {
+				r.AddCookie(&http.Cookie{Name: testCookieName, Value: testSessionToken})
+			}
+			if test.csrfCookie != "" {
+				r.AddCookie(&http.Cookie{Name: csrfCookieName, Value: test.csrfCookie})
+			}
+			if test.csrfHeader != "" {
+				r.Header.Set(HeaderCSRFToken, test.csrfHeader)
+			}
+			w := httptest.NewRecorder()
+
+			Protect(testCookieName)(next).ServeHTTP(w, r)
+
+			if w.Code != test.wantStatus {
+				t.Errorf("status: got %d, want %d", w.Code, test.wantStatus)
+			}
+			if calledNext != test.wantNext {
+				t.Errorf("next handler called: got %t, want %t", calledNext, test.wantNext)
+			}
+
+			var issued *http.Cookie
+			for _, c := range w.Result().Cookies() {
+				if c.Name == csrfCookieName {
+					issued = c
+				}
+			}
+			if (issued != nil) != test.wantCookieIssue {
+				t.Fatalf("csrf cookie issued: got %t, want %t", issued != nil, test.wantCookieIssue)
+			}
+			if issued != nil && issued.Value != validToken {
+				t.Errorf("issued csrf cookie: got %q, want %q", issued.Value, validToken)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/idempotency"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+/*
+ * Handle returns an http.HandlerFunc middleware that makes requests with an Idempotency-Key header idempotent.
+ * The first request with a key is executed and its response is stored with the key and the request fingerprint.
+ * Retries with the same key and fingerprint get the stored response, retries with a different fingerprint fail.
+ * Requests without the header are executed as usual.
+ */
+func Handle(idempotencySvc *idempotency.Service) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			key := strings.TrimSpace(r.Header.Get(request.HeaderIdempotencyKey))
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			session, ok := request.AuthSessionFrom(ctx)
+			if !ok || auth.IsAnonymousSession(session) {
+				// the request will be rejected anyway - keys are only stored for authenticated principals.
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if len(key) > idempotency.MaxKeyLength {
+				render.UserError(ctx, w, usererror.BadRequestf(
+					"The idempotency key can't be longer than %d characters.", idempotency.MaxKeyLength))
+				return
+			}
+
+			// the body is read before the handler decodes it, so the handler's limit has to be enforced here.
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, request.MaxJSONBodySize))
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				render.UserError(ctx, w, usererror.RequestTooLargef(
+					"The request body is too large, the maximum allowed size is %d bytes.", maxBytesErr.Limit))
+				return
+			}
+			if err != nil {
+				render.UserError(ctx, w, usererror.BadRequest("Failed to read the request body."))
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			fingerprint := idempotency.Fingerprint(r.Method, r.URL.RequestURI(), body)
+
+			record, acquired, err := idempotencySvc.Acquire(ctx, session.Principal.ID, key, fingerprint)
+			if err != nil {
+				render.TranslatedUserError(ctx, w, err)
+				return
+			}
+
+			if !acquired {
+				replay(w, record)
+				return
+			}
+
+			serve(idempotencySvc, record, next, w, r)
+		})
+	}
+}
+
+// serve executes the request and stores its response with the key.
+// Server errors and rate limited responses aren't stored,
+// the key is released instead so that the request can be retried.
+func serve(
+	idempotencySvc *idempotency.Service,
+	record *types.IdempotencyKey,
+	next http.Handler,
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	// the response is stored even if the client went away in the meantime.
+	ctx := context.WithoutCancel(r.Context())
+
+	completed := false
+	defer func() {
+		// also executed if the handler panics.
+		if completed {
+			return
+		}
+		if err := idempotencySvc.Release(ctx, record); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to release idempotency key")
+		}
+	}()
+
+	rec := &recorder{ResponseWriter: w}
+
+	next.ServeHTTP(rec, r)
+
+	if rec.status >= http.StatusInternalServerError || rec.status == http.StatusTooManyRequests || rec.overflow {
+		return
+	}
+
+	status := rec.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	err := idempotencySvc.Complete(ctx, record, status, rec.Header().Get("Content-Type"), rec.body.Bytes())
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to store response with idempotency key")
+		return
+	}
+
+	completed = true
+}
+
+// replay writes the response stored with the key.
+func replay(w http.ResponseWriter, record *types.IdempotencyKey) {
+	if record.ResponseContentType != "" {
+		w.Header().Set("Content-Type", record.ResponseContentType)
+	}
+	w.Header().Set(request.HeaderIdempotentReplayed, "true")
+	w.WriteHeader(record.ResponseStatus)
+	_, _ = w.Write(record.ResponseBody)
+}
+
+// recorder is an http.ResponseWriter that keeps a copy of the status and body of the response.
+type recorder struct {
+	http.ResponseWriter
+	status   int
+	body     bytes.Buffer
+	overflow bool
+}
+
+func (r *recorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *recorder) Write(p []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+
+	if !r.overflow {
+		if r.body.Len()+len(p) > idempotency.MaxResponseSize {
+			r.overflow = true
+			r.body.Reset()
+		} else {
+			r.body.Write(p)
+		}
+	}
+
+	return r.ResponseWriter.Write(p)
+}
@@ -0,0 +1,266 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package idempotency
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/idempotency"
+	"github.com/harness/gitness/encrypt"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+type keyID struct {
+	principalID int64
+	key         string
+}
+
+// memKeyStore is an in-memory store.IdempotencyKeyStore.
+type memKeyStore struct {
+	mx   sync.Mutex
+	keys map[keyID]types.IdempotencyKey
+}
+
+func (s *memKeyStore) Create(_ context.Context, key *types.IdempotencyKey, now int64) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	id := keyID{key.PrincipalID, key.Key}
+	if existing, ok := s.keys[id]; ok && existing.Expires > now {
+		return gitness_store.ErrDuplicate
+	}
+	s.keys[id] = *key
+	return nil
+}
+
+func (s *memKeyStore) Find(_ context.Context, principalID int64, key string) (*types.IdempotencyKey, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	existing, ok := s.keys[keyID{principalID, key}]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return &existing, nil
+}
+
+func (s *memKeyStore) Complete(_ context.Context, key *types.IdempotencyKey) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.keys[keyID{key.PrincipalID, key.Key}] = *key
+	return nil
+}
+
+func (s *memKeyStore) DeletePending(_ context.Context, principalID int64, key string) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	id := keyID{principalID, key}
+	if existing, ok := s.keys[id]; ok && !existing.IsCompleted() {
+		delete(s.keys, id)
+	}
+	return nil
+}
+
+func (s *memKeyStore) Purge(context.Context, int64) (int64, error) {
+	return 0, nil
+}
+
+func setupHandler(t *testing.T, status int, delay time.Duration) (http.Handler, *atomic.Int32) {
+	t.Helper()
+
+	h, calls, _ := setupHandlerWithStore(t, status, delay)
+	return h, calls
+}
+
+func setupHandlerWithStore(
+	t *testing.T,
+	status int,
+	delay time.Duration,
+) (http.Handler, *atomic.Int32, *memKeyStore) {
+	t.Helper()
+
+	encrypter, err := encrypt.New("01234567890123456789012345678901", false)
+	if err != nil {
+		t.Fatalf("failed to create encrypter: %v", err)
+	}
+
+	keyStore := &memKeyStore{keys: map[keyID]types.IdempotencyKey{}}
+	svc, err := idempotency.NewService(idempotency.Config{
+		TTL:         time.Hour,
+		LockTimeout: time.Minute,
+		WaitTimeout: 5 * time.Second,
+	}, keyStore, encrypter)
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+
+	calls := &atomic.Int32{}
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		n := calls.Add(1)
+		time.Sleep(delay)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"call":` + strconv.Itoa(int(n)) + `}`))
+	})
+
+	return Handle(svc)(next), calls, keyStore
+}
+
+func doRequest(h http.Handler, key, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodPost, "/repos", strings.NewReader(body))
+	if key != "" {
+		r.Header.Set(request.HeaderIdempotencyKey, key)
+	}
+	r = r.WithContext(request.WithAuthSession(r.Context(), &auth.Session{
+		Principal: types.Principal{ID: 1, UID: "user"},
+	}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestHandle_Replay(t *testing.T) {
+	h, calls := setupHandler(t, http.StatusCreated, 0)
+
+	first := doRequest(h, "key", `{"identifier":"repo"}`)
+	second := doRequest(h, "key", `{"identifier":"repo"}`)
+
+	if calls.Load() != 1 {
+		t.Fatalf("expected handler to be called once, got %d", calls.Load())
+	}
+	if second.Code != http.StatusCreated || second.Body.String() != first.Body.String() {
+		t.Errorf("expected replay of %d %q, got %d %q",
+			first.Code, first.Body.String(), second.Code, second.Body.String())
+	}
+	if second.Header().Get(request.HeaderIdempotentReplayed) != "true" {
+		t.Errorf("expected replayed response to be marked")
+	}
+	if second.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("expected content type to be replayed, got %q", second.Header().Get("Content-Type"))
+	}
+}
+
+func TestHandle_StoresEncryptedResponse(t *testing.T) {
+	h, _, keyStore := setupHandlerWithStore(t, http.StatusCreated, 0)
+
+	first := doRequest(h, "key", `{"identifier":"token"}`)
+
+	stored, err := keyStore.Find(context.Background(), 1, "key")
+	if err != nil {
+		t.Fatalf("failed to find stored key: %v", err)
+	}
+	if len(stored.ResponseBody) == 0 || strings.Contains(string(stored.ResponseBody), first.Body.String()) {
+		t.Errorf("expected stored response to be encrypted, got %q", stored.ResponseBody)
+	}
+
+	second := doRequest(h, "key", `{"identifier":"token"}`)
+	if second.Body.String() != first.Body.String() {
+		t.Errorf("expected replay of %q, got %q", first.Body.String(), second.Body.String())
+	}
+}
+
+func TestHandle_BodyTooLarge(t *testing.T) {
+	h, calls := setupHandler(t, http.StatusCreated, 0)
+
+	body := `{"identifier":"` + strings.Repeat("a", request.MaxJSONBodySize) + `"}`
+	w := doRequest(h, "key", body)
+
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected status %d, got %d", http.StatusRequestEntityTooLarge, w.Code)
+	}
+	if calls.Load() != 0 {
+		t.Errorf("expected handler not to be called, got %d calls", calls.Load())
+	}
+}
+
+func TestHandle_KeyReused(t *testing.T) {
+	h, calls := setupHandler(t, http.StatusCreated, 0)
+
+	doRequest(h, "key", `{"identifier":"repo"}`)
+	w := doRequest(h, "key", `{"identifier":"other"}`)
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected status %d, got %d", http.StatusUnprocessableEntity, w.Code)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected handler to be called once, got %d", calls.Load())
+	}
+}
+
+func TestHandle_WithoutKey(t *testing.T) {
+	h, calls := setupHandler(t, http.StatusCreated, 0)
+
+	doRequest(h, "", `{}`)
+	doRequest(h, "", `{}`)
+
+	if calls.Load() != 2 {
+		t.Errorf("expected handler to be called twice, got %d", calls.Load())
+	}
+}
+
+func TestHandle_ServerErrorReleasesKey(t *testing.T) {
+	h, calls := setupHandler(t, http.StatusInternalServerError, 0)
+
+	doRequest(h, "key", `{}`)
+	w := doRequest(h, "key", `{}`)
+
+	if calls.Load() != 2 {
+		t.Errorf("expected handler to be called twice, got %d", calls.Load())
+	}
+	if w.Header().Get(request.HeaderIdempotentReplayed) != "" {
+		t.Errorf("expected server error not to be replayed")
+	}
+}
+
+func TestHandle_ConcurrentRequests(t *testing.T) {
+	h, calls := setupHandler(t, http.StatusCreated, 200*time.Millisecond)
+
+	const n = 5
+	responses := make([]*httptest.ResponseRecorder, n)
+
+	wg := sync.WaitGroup{}
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			responses[i] = doRequest(h, "key", `{}`)
+		}()
+	}
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Fatalf("expected handler to be called once, got %d", calls.Load())
+	}
+	for i, w := range responses {
+		if w.Code != http.StatusCreated || w.Body.String() != responses[0].Body.String() {
+			t.Errorf("response %d: expected %d %q, got %d %q",
+				i, http.StatusCreated, responses[0].Body.String(), w.Code, w.Body.String())
+		}
+	}
+}
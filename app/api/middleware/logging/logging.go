@@ -63,6 +63,53 @@ func HLogRequestIDHandler() func(http.Handler) http.Handler {
 	}
 }
 
+// HLogPrincipalHandler provides a middleware that injects the authenticated principal
+// into the logging context. It has to be used after the authentication middleware.
+func HLogPrincipalHandler() func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			if session, ok := request.AuthSessionFrom(ctx); ok {
+				logging.UpdateContext(ctx,
+					logging.WithPrincipalID(session.Principal.ID),
+					logging.WithPrincipalUID(session.Principal.UID),
+				)
+			}
+
+			h.ServeHTTP(w, r)
+		})
+	}
+}
+
+// HLogRepoRefHandler provides a middleware that injects the repo reference of the request path
+// into the logging context. Controllers add the resolved repository once it's known.
+func HLogRepoRefHandler() func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if repoRef, err := request.GetRepoRefFromPath(r); err == nil {
+				logging.UpdateContext(r.Context(), logging.WithRepoRef(repoRef))
+			}
+
+			h.ServeHTTP(w, r)
+		})
+	}
+}
+
+// HLogSpaceRefHandler provides a middleware that injects the space reference of the request path
+// into the logging context.
+func HLogSpaceRefHandler() func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if spaceRef, err := request.GetSpaceRefFromPath(r); err == nil {
+				logging.UpdateContext(r.Context(), logging.WithSpaceRef(spaceRef))
+			}
+
+			h.ServeHTTP(w, r)
+		})
+	}
+}
+
 // HLogAccessLogHandler provides an hlog based middleware that logs access logs.
 func HLogAccessLogHandler() func(http.Handler) http.Handler {
 	return hlog.AccessHandler(
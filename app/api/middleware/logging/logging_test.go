@@ -0,0 +1,134 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/api"
+	gittypes "github.com/harness/gitness/git/types"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// TestFailedPushLogsAreCorrelated verifies that the logs of the API and the git layer
+// for a failed push carry the same request id, principal and repository.
+func TestFailedPushLogsAreCorrelated(t *testing.T) {
+	gitAdapter, err := api.New(gittypes.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create git adapter: %v", err)
+	}
+
+	gitService, err := git.New(gittypes.Config{Root: t.TempDir()}, gitAdapter, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create git service: %v", err)
+	}
+
+	principal := types.Principal{ID: 42, UID: "pusher", DisplayName: "Pusher", Email: "pusher@example.com"}
+	repo := &types.Repository{ID: 7, Path: "space/repo", GitUID: "missing-repo"}
+
+	push := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		controller.WithRepo(ctx, repo)
+
+		err := gitService.ServicePack(ctx, &git.ServicePackParams{
+			WriteParams: &git.WriteParams{
+				RepoUID: repo.GitUID,
+				Actor:   git.Identity{Name: principal.DisplayName, Email: principal.Email},
+			},
+			ServicePackOptions: api.ServicePackOptions{
+				Service:      enum.GitServiceTypeReceivePack,
+				StatelessRPC: true,
+				Stdin:        strings.NewReader(""),
+				Stdout:       io.Discard,
+			},
+		})
+		if err == nil {
+			t.Error("expected push to a missing repository to fail")
+			return
+		}
+
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to serve git push")
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	withSession := func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := request.WithAuthSession(r.Context(), &auth.Session{Principal: principal})
+			h.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+
+	handler := HLogRequestIDHandler()(withSession(HLogPrincipalHandler()(push)))
+
+	buf := &bytes.Buffer{}
+	logger := zerolog.New(buf)
+
+	req := httptest.NewRequest(http.MethodPost, "/space/repo.git/git-receive-pack", nil)
+	req.Header.Set(requestIDHeader, "test-request-id")
+	req = req.WithContext(logger.WithContext(req.Context()))
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	lines := map[string]map[string]any{}
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		line := map[string]any{}
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("failed to parse log line %q: %v", scanner.Text(), err)
+		}
+		msg, _ := line[zerolog.MessageFieldName].(string)
+		lines[msg] = line
+	}
+
+	apiLine, ok := lines["failed to serve git push"]
+	if !ok {
+		t.Fatalf("missing API log line, got: %v", lines)
+	}
+	gitLine, ok := lines["failed to serve git rpc"]
+	if !ok {
+		t.Fatalf("missing git log line, got: %v", lines)
+	}
+
+	for _, line := range []map[string]any{apiLine, gitLine} {
+		for field, want := range map[string]any{
+			"request_id":   "test-request-id",
+			"principal_id": float64(principal.ID),
+			"repo_uid":     repo.GitUID,
+		} {
+			if got := line[field]; got != want {
+				t.Errorf("log line %q: field %q is %v, want %v", line[zerolog.MessageFieldName], field, got, want)
+			}
+		}
+	}
+
+	if got, want := gitLine["git_rpc"], string(enum.GitServiceTypeReceivePack); got != want {
+		t.Errorf("git log line: field %q is %v, want %v", "git_rpc", got, want)
+	}
+}
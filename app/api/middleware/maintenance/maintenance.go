@@ -0,0 +1,122 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/services/maintenance"
+)
+
+/*
+ * BlockWrites returns an http.HandlerFunc middleware that rejects mutating requests
+ * while maintenance mode is enabled for the whole instance.
+ * Requests with a path starting with any of the exempt prefixes are never rejected,
+ * which keeps the endpoints required to disable maintenance mode accessible.
+ */
+func BlockWrites(maintenanceSvc *maintenance.Service, exemptPathPrefixes ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isSafeMethod(r.Method) || hasAnyPrefix(r.URL.Path, exemptPathPrefixes) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if err := maintenanceSvc.CheckInstance(); err != nil {
+				render.TranslatedUserError(r.Context(), w, err)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+/*
+ * BlockSpaceWrites returns an http.HandlerFunc middleware that rejects mutating requests
+ * while maintenance mode is enabled for the space referenced in the request path.
+ */
+func BlockSpaceWrites(maintenanceSvc *maintenance.Service) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isSafeMethod(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := r.Context()
+
+			spaceRef, err := request.GetSpaceRefFromPath(r)
+			if err != nil {
+				render.TranslatedUserError(ctx, w, err)
+				return
+			}
+
+			if err = maintenanceSvc.CheckSpaceRef(ctx, spaceRef); err != nil {
+				render.TranslatedUserError(ctx, w, err)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+/*
+ * BlockRepoWrites returns an http.HandlerFunc middleware that rejects mutating requests
+ * while maintenance mode is enabled for the repository referenced in the request path.
+ */
+func BlockRepoWrites(maintenanceSvc *maintenance.Service) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isSafeMethod(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := r.Context()
+
+			repoRef, err := request.GetRepoRefFromPath(r)
+			if err != nil {
+				render.TranslatedUserError(ctx, w, err)
+				return
+			}
+
+			if err = maintenanceSvc.CheckRepoRef(ctx, repoRef); err != nil {
+				render.TranslatedUserError(ctx, w, err)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// isSafeMethod returns true if the http method doesn't modify any resources.
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+func hasAnyPrefix(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
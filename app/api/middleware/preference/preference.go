@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preference
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+/*
+ * ApplyDefaults returns an http.HandlerFunc middleware that adds the query parameters
+ * stored in the user's preference for the provided list view to the request.
+ * Query parameters that are provided explicitly in the request always take precedence.
+ */
+func ApplyDefaults(userCtrl *user.Controller, view enum.UserPreferenceView) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			session, ok := request.AuthSessionFrom(ctx)
+			if !ok || session.Principal.Type != enum.PrincipalTypeUser {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			pref, err := userCtrl.FindPreference(ctx, session, view)
+			if err != nil {
+				// preferences are a convenience - don't fail the request.
+				log.Ctx(ctx).Warn().Err(err).Msgf("failed to find user preference for view %s", view)
+
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if len(pref.Params) == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			query := r.URL.Query()
+			for param, values := range pref.Params {
+				if query.Has(param) {
+					continue
+				}
+				query[param] = values
+			}
+
+			r2 := r.Clone(ctx)
+			r2.URL.RawQuery = query.Encode()
+
+			next.ServeHTTP(w, r2)
+		})
+	}
+}
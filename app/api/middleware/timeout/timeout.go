@@ -0,0 +1,105 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timeout
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrTimeout is the cause of the cancellation of requests that exceeded their timeout.
+var ErrTimeout = errors.New("request exceeded its timeout")
+
+type deadlineKey struct{}
+
+// deadline cancels the context of a request once its timeout, measured from the start of the request, expired.
+type deadline struct {
+	start  time.Time
+	cancel context.CancelCauseFunc
+
+	mx    sync.Mutex
+	timer *time.Timer
+}
+
+// set replaces the timeout of the request. A non-positive timeout doesn't limit the request.
+func (d *deadline) set(timeout time.Duration) {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+
+	if timeout <= 0 {
+		return
+	}
+
+	d.timer = time.AfterFunc(time.Until(d.start.Add(timeout)), func() { d.cancel(ErrTimeout) })
+}
+
+/*
+ * Handle returns an http.HandlerFunc middleware that cancels the request context with ErrTimeout as cause
+ * once the request exceeded the timeout, which stops git operations and store queries of the request.
+ * Routes can replace the timeout with Override (e.g. for expensive operations) or remove it with Disable.
+ * A non-positive timeout doesn't limit the requests.
+ */
+func Handle(timeout time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithCancelCause(r.Context())
+			defer cancel(nil)
+
+			d := &deadline{
+				start:  time.Now(),
+				cancel: cancel,
+			}
+			d.set(timeout)
+			defer d.set(0)
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, deadlineKey{}, d)))
+		})
+	}
+}
+
+/*
+ * Override returns an http.HandlerFunc middleware that replaces the timeout of the request set by Handle.
+ * The timeout is still measured from the start of the request, a non-positive timeout removes the limit.
+ */
+func Override(timeout time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if d, ok := r.Context().Value(deadlineKey{}).(*deadline); ok {
+				d.set(timeout)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Disable is an http.HandlerFunc middleware that removes the timeout of the request set by Handle.
+// It's meant for streaming endpoints (events, logs, archives and uploads) which are expected to be long-lived.
+func Disable(next http.Handler) http.Handler {
+	return Override(0)(next)
+}
+
+// Exceeded returns true if the context got canceled because the request exceeded its timeout.
+func Exceeded(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), ErrTimeout)
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timeout
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandle(t *testing.T) {
+	tests := []struct {
+		name         string
+		timeout      time.Duration
+		route        func(http.Handler) http.Handler
+		wantExceeded bool
+	}{
+		{
+			name:         "default timeout",
+			timeout:      20 * time.Millisecond,
+			wantExceeded: true,
+		},
+		{
+			name:    "no timeout",
+			timeout: 0,
+		},
+		{
+			name:         "shorter route timeout",
+			timeout:      time.Hour,
+			route:        Override(20 * time.Millisecond),
+			wantExceeded: true,
+		},
+		{
+			name:    "longer route timeout",
+			timeout: 20 * time.Millisecond,
+			route:   Override(time.Hour),
+		},
+		{
+			name:    "disabled for route",
+			timeout: 20 * time.Millisecond,
+			route:   Disable,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var exceeded bool
+
+			var handler http.Handler = http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				select {
+				case <-r.Context().Done():
+				case <-time.After(200 * time.Millisecond):
+				}
+				exceeded = Exceeded(r.Context())
+			})
+			if test.route != nil {
+				handler = test.route(handler)
+			}
+
+			Handle(test.timeout)(handler).ServeHTTP(httptest.NewRecorder(),
+				httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if exceeded != test.wantExceeded {
+				t.Errorf("got exceeded %t, want %t", exceeded, test.wantExceeded)
+			}
+		})
+	}
+}
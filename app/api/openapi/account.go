@@ -51,6 +51,17 @@ type registerRequest struct {
 	user.RegisterInput
 }
 
+// request to reset the password of an account.
+type resetPasswordRequest struct {
+	user.ResetPasswordInput
+}
+
+// request to complete the login via the OIDC identity provider.
+type oidcCallbackRequest struct {
+	Code  string `query:"code"`
+	State string `query:"state"`
+}
+
 // helper function that constructs the openapi specification
 // for the account registration and login endpoints.
 func buildAccount(reflector *openapi3.Reflector) {
@@ -63,6 +74,7 @@ func buildAccount(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&onLogin, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.SetJSONResponse(&onLogin, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&onLogin, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&onLogin, new(usererror.Error), http.StatusTooManyRequests)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/login", onLogin)
 
 	opLogout := openapi3.Operation{}
@@ -84,4 +96,36 @@ func buildAccount(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&onRegister, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&onRegister, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/register", onRegister)
+
+	onResetPassword := openapi3.Operation{}
+	onResetPassword.WithTags("account")
+	onResetPassword.WithMapOfAnything(map[string]interface{}{"operationId": "onResetPassword"})
+	_ = reflector.SetRequest(&onResetPassword, new(resetPasswordRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&onResetPassword, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&onResetPassword, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&onResetPassword, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/reset-password", onResetPassword)
+
+	opOIDCLogin := openapi3.Operation{}
+	opOIDCLogin.WithTags("account")
+	opOIDCLogin.WithSummary("Redirect to the OpenID Connect identity provider for login")
+	opOIDCLogin.WithMapOfAnything(map[string]interface{}{"operationId": "opOIDCLogin"})
+	_ = reflector.SetRequest(&opOIDCLogin, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opOIDCLogin, nil, http.StatusFound)
+	_ = reflector.SetJSONResponse(&opOIDCLogin, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opOIDCLogin, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/auth/oidc/login", opOIDCLogin)
+
+	opOIDCCallback := openapi3.Operation{}
+	opOIDCCallback.WithTags("account")
+	opOIDCCallback.WithSummary("Complete the login via the OpenID Connect identity provider")
+	opOIDCCallback.WithMapOfAnything(map[string]interface{}{"operationId": "opOIDCCallback"})
+	_ = reflector.SetRequest(&opOIDCCallback, new(oidcCallbackRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opOIDCCallback, nil, http.StatusFound)
+	_ = reflector.SetJSONResponse(&opOIDCCallback, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opOIDCCallback, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opOIDCCallback, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opOIDCCallback, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opOIDCCallback, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/auth/oidc/callback", opOIDCCallback)
 }
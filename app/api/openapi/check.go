@@ -54,6 +54,7 @@ var queryParameterStatusCheckSince = openapi3.ParameterOrRef{
 	},
 }
 
+//nolint:funlen
 func checkOperations(reflector *openapi3.Reflector) {
 	const tag = "status_checks"
 
@@ -106,4 +107,35 @@ func checkOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&listStatusCheckRecent, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/checks/recent",
 		listStatusCheckRecent)
+
+	getCombinedStatus := openapi3.Operation{}
+	getCombinedStatus.WithTags(tag)
+	getCombinedStatus.WithMapOfAnything(map[string]interface{}{"operationId": "getCombinedStatus"})
+	_ = reflector.SetRequest(&getCombinedStatus, struct {
+		repoRequest
+		CommitSHA string `path:"commit_sha"`
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&getCombinedStatus, new(types.CombinedCheckStatus), http.StatusOK)
+	_ = reflector.SetJSONResponse(&getCombinedStatus, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&getCombinedStatus, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&getCombinedStatus, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&getCombinedStatus, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&getCombinedStatus, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/commits/{commit_sha}/status/combined",
+		getCombinedStatus)
+
+	getRefStatus := openapi3.Operation{}
+	getRefStatus.WithTags(tag)
+	getRefStatus.WithMapOfAnything(map[string]interface{}{"operationId": "getRefStatus"})
+	_ = reflector.SetRequest(&getRefStatus, struct {
+		repoRequest
+		Ref string `path:"ref"`
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&getRefStatus, new(types.CombinedCheckStatus), http.StatusOK)
+	_ = reflector.SetJSONResponse(&getRefStatus, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&getRefStatus, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&getRefStatus, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&getRefStatus, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&getRefStatus, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/refs/{ref}/status", getRefStatus)
 }
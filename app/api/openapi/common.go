@@ -30,6 +30,42 @@ func ptrptr(i interface{}) *interface{} {
 	return &i
 }
 
+// pathParameter returns a path parameter, the name should be one of the path parameter constants
+// of the request package which are used by the handlers to read the parameter.
+func pathParameter(name string, description string) openapi3.ParameterOrRef {
+	return openapi3.ParameterOrRef{
+		Parameter: &openapi3.Parameter{
+			Name:        name,
+			In:          openapi3.ParameterInPath,
+			Description: ptr.String(description),
+			Required:    ptr.Bool(true),
+			Schema: &openapi3.SchemaOrRef{
+				Schema: &openapi3.Schema{
+					Type: ptrSchemaType(openapi3.SchemaTypeString),
+				},
+			},
+		},
+	}
+}
+
+// queryParameter returns an optional query parameter, the name should be one of the query parameter constants
+// of the request package which are used by the handlers to read the parameter.
+func queryParameter(name string, description string, t openapi3.SchemaType) openapi3.ParameterOrRef {
+	return openapi3.ParameterOrRef{
+		Parameter: &openapi3.Parameter{
+			Name:        name,
+			In:          openapi3.ParameterInQuery,
+			Description: ptr.String(description),
+			Required:    ptr.Bool(false),
+			Schema: &openapi3.SchemaOrRef{
+				Schema: &openapi3.Schema{
+					Type: ptrSchemaType(t),
+				},
+			},
+		},
+	}
+}
+
 var QueryParameterPage = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamPage,
@@ -171,3 +207,34 @@ var queryParameterIncludeSubspaces = openapi3.ParameterOrRef{
 		},
 	},
 }
+
+var queryParameterDryRun = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamDryRun,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Validate the request and report the affected resources without changing anything."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
+var headerParameterIdempotencyKey = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name: request.HeaderIdempotencyKey,
+		In:   openapi3.ParameterInHeader,
+		Description: ptr.String("A unique key to safely retry the request. Retries with the same key and body " +
+			"return the original response, reusing the key for a different request fails."),
+		Required: ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:      ptrSchemaType(openapi3.SchemaTypeString),
+				MaxLength: ptr.Int64(255),
+			},
+		},
+	},
+}
@@ -0,0 +1,46 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/eventlog"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/stream"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func eventLogOperations(reflector *openapi3.Reflector) {
+	opListConsumers := openapi3.Operation{}
+	opListConsumers.WithTags("admin")
+	opListConsumers.WithMapOfAnything(map[string]interface{}{"operationId": "adminListEventConsumers"})
+	_ = reflector.SetRequest(&opListConsumers, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListConsumers, new([]stream.ConsumerLag), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListConsumers, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListConsumers, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/events/consumers", opListConsumers)
+
+	opReplay := openapi3.Operation{}
+	opReplay.WithTags("admin")
+	opReplay.WithMapOfAnything(map[string]interface{}{"operationId": "adminReplayEvents"})
+	_ = reflector.SetRequest(&opReplay, new(eventlog.ReplayInput), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opReplay, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opReplay, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opReplay, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opReplay, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/events/consumers/replay", opReplay)
+}
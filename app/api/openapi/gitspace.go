@@ -40,6 +40,7 @@ type actionGitspaceRequest struct {
 }
 
 type updateGitspaceRequest struct {
+	gitspaceRequest
 }
 
 type gitspaceRequest struct {
@@ -83,7 +84,7 @@ func gitspaceOperations(reflector *openapi3.Reflector) {
 	opUpdate.WithTags("gitspaces")
 	opUpdate.WithSummary("Update gitspace config")
 	opUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateGitspace"})
-	_ = reflector.SetRequest(&opUpdate, new(updateGitspaceRequest), http.MethodPut)
+	_ = reflector.SetRequest(&opUpdate, new(updateGitspaceRequest), http.MethodPatch)
 	_ = reflector.SetJSONResponse(&opUpdate, new(types.GitspaceConfig), http.StatusOK)
 	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusInternalServerError)
@@ -184,5 +185,5 @@ func gitspaceOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opAction, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.SetJSONResponse(&opAction, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opAction, new(usererror.Error), http.StatusNotFound)
-	_ = reflector.Spec.AddOperation(http.MethodPost, "/gitspaces/{gitspace_identifier}/action", opAction)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/gitspaces/{gitspace_identifier}/actions", opAction)
 }
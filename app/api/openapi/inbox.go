@@ -0,0 +1,131 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/inbox"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type notificationRequest struct {
+	ID int64 `path:"notification_id"`
+}
+
+var queryParameterOnlyUnread = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamOnlyUnread,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Only list unread notifications."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
+//nolint:funlen
+func inboxOperations(reflector *openapi3.Reflector) {
+	opList := openapi3.Operation{}
+	opList.WithTags("user")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "listNotifications"})
+	opList.WithParameters(queryParameterOnlyUnread, QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opList, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, new([]types.Notification), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/notifications", opList)
+
+	opCount := openapi3.Operation{}
+	opCount.WithTags("user")
+	opCount.WithMapOfAnything(map[string]interface{}{"operationId": "countNotifications"})
+	_ = reflector.SetRequest(&opCount, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opCount, new(types.NotificationCount), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCount, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/notifications/count", opCount)
+
+	opMarkReadBulk := openapi3.Operation{}
+	opMarkReadBulk.WithTags("user")
+	opMarkReadBulk.WithMapOfAnything(map[string]interface{}{"operationId": "markNotificationsRead"})
+	_ = reflector.SetRequest(&opMarkReadBulk, new(inbox.MarkReadInput), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opMarkReadBulk, new(types.NotificationCount), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMarkReadBulk, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opMarkReadBulk, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/notifications/read", opMarkReadBulk)
+
+	opMarkRead := openapi3.Operation{}
+	opMarkRead.WithTags("user")
+	opMarkRead.WithMapOfAnything(map[string]interface{}{"operationId": "markNotificationRead"})
+	_ = reflector.SetRequest(&opMarkRead, new(notificationRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opMarkRead, new(types.NotificationCount), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMarkRead, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opMarkRead, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/notifications/{notification_id}/read", opMarkRead)
+
+	opWatchRepo := openapi3.Operation{}
+	opWatchRepo.WithTags("repository")
+	opWatchRepo.WithMapOfAnything(map[string]interface{}{"operationId": "watchRepository"})
+	_ = reflector.SetRequest(&opWatchRepo, new(repoRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opWatchRepo, new(types.NotificationSubscription), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opWatchRepo, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opWatchRepo, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opWatchRepo, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opWatchRepo, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/repos/{repo_ref}/subscription", opWatchRepo)
+
+	opUnwatchRepo := openapi3.Operation{}
+	opUnwatchRepo.WithTags("repository")
+	opUnwatchRepo.WithMapOfAnything(map[string]interface{}{"operationId": "unwatchRepository"})
+	_ = reflector.SetRequest(&opUnwatchRepo, new(repoRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opUnwatchRepo, new(types.NotificationSubscription), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUnwatchRepo, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUnwatchRepo, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUnwatchRepo, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUnwatchRepo, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/subscription", opUnwatchRepo)
+
+	opWatchPullReq := openapi3.Operation{}
+	opWatchPullReq.WithTags("pullreq")
+	opWatchPullReq.WithMapOfAnything(map[string]interface{}{"operationId": "watchPullReq"})
+	_ = reflector.SetRequest(&opWatchPullReq, new(pullReqRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opWatchPullReq, new(types.NotificationSubscription), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opWatchPullReq, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opWatchPullReq, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opWatchPullReq, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opWatchPullReq, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/subscription", opWatchPullReq)
+
+	opUnwatchPullReq := openapi3.Operation{}
+	opUnwatchPullReq.WithTags("pullreq")
+	opUnwatchPullReq.WithMapOfAnything(map[string]interface{}{"operationId": "unwatchPullReq"})
+	_ = reflector.SetRequest(&opUnwatchPullReq, new(pullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opUnwatchPullReq, new(types.NotificationSubscription), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUnwatchPullReq, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUnwatchPullReq, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUnwatchPullReq, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUnwatchPullReq, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/subscription", opUnwatchPullReq)
+}
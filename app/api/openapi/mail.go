@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/mail"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+var queryParameterRecipient = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamRecipient,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Only list failures of the provided recipient email address."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+func mailOperations(reflector *openapi3.Reflector) {
+	opSendTest := openapi3.Operation{}
+	opSendTest.WithTags("admin")
+	opSendTest.WithMapOfAnything(map[string]interface{}{"operationId": "adminSendTestMail"})
+	_ = reflector.SetRequest(&opSendTest, new(mail.SendTestInput), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opSendTest, new(mail.SendTestOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSendTest, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSendTest, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSendTest, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/mail/test", opSendTest)
+
+	opListFailures := openapi3.Operation{}
+	opListFailures.WithTags("admin")
+	opListFailures.WithMapOfAnything(map[string]interface{}{"operationId": "adminListMailFailures"})
+	opListFailures.WithParameters(queryParameterRecipient, QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opListFailures, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListFailures, new([]types.MailFailure), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListFailures, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListFailures, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/mail/failures", opListFailures)
+}
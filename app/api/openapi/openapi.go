@@ -54,6 +54,13 @@ func (*OpenAPI) Generate() *openapi3.Spec {
 	//
 
 	buildSystem(&reflector)
+	buildInstanceSettings(&reflector)
+	buildFeatureFlags(&reflector)
+	buildGitOperations(&reflector)
+	buildMigrationStatus(&reflector)
+	buildSystemInfo(&reflector)
+	buildAdminMetrics(&reflector)
+	buildReconcileCounters(&reflector)
 	buildAccount(&reflector)
 	buildUser(&reflector)
 	buildAdmin(&reflector)
@@ -72,6 +79,12 @@ func (*OpenAPI) Generate() *openapi3.Spec {
 	uploadOperations(&reflector)
 	gitspaceOperations(&reflector)
 	infraProviderOperations(&reflector)
+	inboxOperations(&reflector)
+	mailOperations(&reflector)
+	eventLogOperations(&reflector)
+	scimOperations(&reflector)
+	runnerOperations(&reflector)
+	operationOperations(&reflector)
 
 	//
 	// define security scheme
@@ -0,0 +1,52 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/operation"
+	"github.com/harness/gitness/app/api/usererror"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type operationRequest struct {
+	ID string `path:"operation_id"`
+}
+
+func operationOperations(reflector *openapi3.Reflector) {
+	opFind := openapi3.Operation{}
+	opFind.WithTags("operation")
+	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "findOperation"})
+	_ = reflector.SetRequest(&opFind, new(operationRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(operation.Output), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/operations/{operation_id}", opFind)
+
+	opCancel := openapi3.Operation{}
+	opCancel.WithTags("operation")
+	opCancel.WithMapOfAnything(map[string]interface{}{"operationId": "cancelOperation"})
+	_ = reflector.SetRequest(&opCancel, new(operationRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opCancel, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opCancel, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCancel, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCancel, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCancel, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/operations/{operation_id}", opCancel)
+}
@@ -17,12 +17,14 @@ package openapi
 import (
 	"net/http"
 
+	"github.com/harness/gitness/app/api/controller/execution"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/trigger"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/livelog"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 
 	"github.com/gotidy/ptr"
 	"github.com/swaggest/openapi-go/openapi3"
@@ -49,6 +51,12 @@ type logRequest struct {
 	StepNum  string `path:"step_number"`
 }
 
+type stageApprovalRequest struct {
+	executionRequest
+	StageNum string `path:"stage_number"`
+	execution.StageApprovalInput
+}
+
 type createExecutionRequest struct {
 	pipelineRequest
 }
@@ -63,10 +71,30 @@ type createPipelineRequest struct {
 	pipeline.CreateInput
 }
 
+type validatePipelineRequest struct {
+	repoRequest
+	pipeline.ValidateInput
+}
+
 type getExecutionRequest struct {
 	executionRequest
 }
 
+type artifactRequest struct {
+	executionRequest
+	Name string `path:"artifact_name"`
+}
+
+type uploadArtifactRequest struct {
+	artifactRequest
+	Content string `json:"-" format:"binary" description:"Binary file to upload"`
+}
+
+type retryExecutionRequest struct {
+	executionRequest
+	execution.RetryInput
+}
+
 type getTriggerRequest struct {
 	triggerRequest
 }
@@ -99,6 +127,27 @@ var queryParameterLatest = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterMatrix = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamMatrix,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Only include the matrix stages with the provided axis values (in the form axis:value)."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+					},
+				},
+			},
+		},
+		Style:   ptr.String(string(openapi3.EncodingStyleForm)),
+		Explode: ptr.Bool(true),
+	},
+}
+
 var queryParameterBranch = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamBranch,
@@ -113,6 +162,47 @@ var queryParameterBranch = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterSortExecution = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamSort,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The data by which the executions are sorted."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeString),
+				Default: ptrptr(string(types.ExecutionFieldNumber)),
+				Enum: []interface{}{
+					ptr.String(string(types.ExecutionFieldNumber)),
+					ptr.String(string(types.ExecutionFieldCreated)),
+				},
+			},
+		},
+	},
+}
+
+var queryParameterStatusExecution = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamStatus,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The status of the executions to include in the result."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+						Enum: enum.CIStatus("").Enum(),
+					},
+				},
+			},
+		},
+		Style:   ptr.String(string(openapi3.EncodingStyleForm)),
+		Explode: ptr.Bool(true),
+	},
+}
+
 func pipelineOperations(reflector *openapi3.Reflector) {
 	opCreate := openapi3.Operation{}
 	opCreate.WithTags("pipeline")
@@ -125,6 +215,18 @@ func pipelineOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/pipelines", opCreate)
 
+	opValidate := openapi3.Operation{}
+	opValidate.WithTags("pipeline")
+	opValidate.WithMapOfAnything(map[string]interface{}{"operationId": "validatePipeline"})
+	_ = reflector.SetRequest(&opValidate, new(validatePipelineRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opValidate, new(types.PipelineValidation), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opValidate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opValidate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opValidate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opValidate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opValidate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/pipelines/validate", opValidate)
+
 	opPipelines := openapi3.Operation{}
 	opPipelines.WithTags("pipeline")
 	opPipelines.WithMapOfAnything(map[string]interface{}{"operationId": "listPipelines"})
@@ -188,6 +290,7 @@ func pipelineOperations(reflector *openapi3.Reflector) {
 	executionFind := openapi3.Operation{}
 	executionFind.WithTags("pipeline")
 	executionFind.WithMapOfAnything(map[string]interface{}{"operationId": "findExecution"})
+	executionFind.WithParameters(queryParameterMatrix)
 	_ = reflector.SetRequest(&executionFind, new(getExecutionRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&executionFind, new(types.Execution), http.StatusOK)
 	_ = reflector.SetJSONResponse(&executionFind, new(usererror.Error), http.StatusInternalServerError)
@@ -209,6 +312,104 @@ func pipelineOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/cancel", executionCancel)
 
+	executionRetry := openapi3.Operation{}
+	executionRetry.WithTags("pipeline")
+	executionRetry.WithMapOfAnything(map[string]interface{}{"operationId": "retryExecution"})
+	_ = reflector.SetRequest(&executionRetry, new(retryExecutionRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&executionRetry, new(types.Execution), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&executionRetry, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&executionRetry, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&executionRetry, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&executionRetry, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&executionRetry, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/retry", executionRetry)
+
+	executionListArtifacts := openapi3.Operation{}
+	executionListArtifacts.WithTags("pipeline")
+	executionListArtifacts.WithMapOfAnything(map[string]interface{}{"operationId": "listExecutionArtifacts"})
+	_ = reflector.SetRequest(&executionListArtifacts, new(getExecutionRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&executionListArtifacts, []types.Artifact{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&executionListArtifacts, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&executionListArtifacts, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&executionListArtifacts, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&executionListArtifacts, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/artifacts",
+		executionListArtifacts)
+
+	executionUploadArtifact := openapi3.Operation{}
+	executionUploadArtifact.WithTags("pipeline")
+	executionUploadArtifact.WithMapOfAnything(map[string]interface{}{"operationId": "uploadExecutionArtifact"})
+	_ = reflector.SetRequest(&executionUploadArtifact, new(uploadArtifactRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&executionUploadArtifact, new(types.Artifact), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&executionUploadArtifact, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&executionUploadArtifact, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&executionUploadArtifact, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&executionUploadArtifact, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&executionUploadArtifact, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&executionUploadArtifact, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/artifacts/{artifact_name}",
+		executionUploadArtifact)
+
+	executionDownloadArtifact := openapi3.Operation{}
+	executionDownloadArtifact.WithTags("pipeline")
+	executionDownloadArtifact.WithMapOfAnything(map[string]interface{}{"operationId": "downloadExecutionArtifact"})
+	_ = reflector.SetRequest(&executionDownloadArtifact, new(artifactRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&executionDownloadArtifact, http.StatusOK, "application/octet-stream")
+	_ = reflector.SetJSONResponse(&executionDownloadArtifact, nil, http.StatusTemporaryRedirect)
+	_ = reflector.SetJSONResponse(&executionDownloadArtifact, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&executionDownloadArtifact, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&executionDownloadArtifact, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&executionDownloadArtifact, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/artifacts/{artifact_name}",
+		executionDownloadArtifact)
+
+	executionListApprovals := openapi3.Operation{}
+	executionListApprovals.WithTags("pipeline")
+	executionListApprovals.WithMapOfAnything(map[string]interface{}{"operationId": "listExecutionApprovals"})
+	_ = reflector.SetRequest(&executionListApprovals, new(getExecutionRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&executionListApprovals, []types.StageApproval{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&executionListApprovals, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&executionListApprovals, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&executionListApprovals, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&executionListApprovals, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/approvals",
+		executionListApprovals)
+
+	stageApprove := openapi3.Operation{}
+	stageApprove.WithTags("pipeline")
+	stageApprove.WithMapOfAnything(map[string]interface{}{"operationId": "approveExecutionStage"})
+	_ = reflector.SetRequest(&stageApprove, new(stageApprovalRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&stageApprove, new(types.StageApproval), http.StatusOK)
+	_ = reflector.SetJSONResponse(&stageApprove, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&stageApprove, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&stageApprove, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&stageApprove, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&stageApprove, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&stageApprove, new(usererror.Error), http.StatusPreconditionFailed)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/stages/{stage_number}/approve",
+		stageApprove)
+
+	stageReject := openapi3.Operation{}
+	stageReject.WithTags("pipeline")
+	stageReject.WithMapOfAnything(map[string]interface{}{"operationId": "rejectExecutionStage"})
+	_ = reflector.SetRequest(&stageReject, new(stageApprovalRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&stageReject, new(types.StageApproval), http.StatusOK)
+	_ = reflector.SetJSONResponse(&stageReject, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&stageReject, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&stageReject, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&stageReject, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&stageReject, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&stageReject, new(usererror.Error), http.StatusPreconditionFailed)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}/stages/{stage_number}/reject",
+		stageReject)
+
 	executionDelete := openapi3.Operation{}
 	executionDelete.WithTags("pipeline")
 	executionDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteExecution"})
@@ -224,9 +425,11 @@ func pipelineOperations(reflector *openapi3.Reflector) {
 	executionList := openapi3.Operation{}
 	executionList.WithTags("pipeline")
 	executionList.WithMapOfAnything(map[string]interface{}{"operationId": "listExecutions"})
-	executionList.WithParameters(QueryParameterPage, QueryParameterLimit)
+	executionList.WithParameters(QueryParameterPage, QueryParameterLimit,
+		queryParameterSortExecution, queryParameterOrder, queryParameterStatusExecution)
 	_ = reflector.SetRequest(&executionList, new(pipelineRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&executionList, []types.Execution{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&executionList, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.SetJSONResponse(&executionList, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&executionList, new(usererror.Error), http.StatusUnauthorized)
 	_ = reflector.SetJSONResponse(&executionList, new(usererror.Error), http.StatusForbidden)
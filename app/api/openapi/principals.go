@@ -17,6 +17,7 @@ package openapi
 import (
 	"net/http"
 
+	"github.com/harness/gitness/app/api/controller/principal"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/types"
@@ -77,4 +78,41 @@ func buildPrincipals(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/principals", opList)
+
+	opFind := openapi3.Operation{}
+	opFind.WithTags("principals")
+	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "getPrincipal"})
+	opFind.WithParameters(pathParameter(request.PathParamPrincipalID, "The id of the principal."))
+	_ = reflector.SetRequest(&opFind, struct{}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(types.PrincipalInfo), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/principals/{principal_id}", opFind)
+
+	opCheckEmails := openapi3.Operation{}
+	opCheckEmails.WithTags("principals")
+	opCheckEmails.WithMapOfAnything(map[string]interface{}{"operationId": "checkPrincipalEmails"})
+	_ = reflector.SetRequest(&opCheckEmails, new(principal.CheckUsersInput), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCheckEmails, new(principal.CheckUsersOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCheckEmails, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCheckEmails, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/principals/check-emails", opCheckEmails)
+
+	opAvatar := openapi3.Operation{}
+	opAvatar.WithTags("principals")
+	opAvatar.WithMapOfAnything(map[string]interface{}{"operationId": "getPrincipalAvatar"})
+	opAvatar.WithParameters(
+		pathParameter(request.PathParamPrincipalUID, "The uid of the principal."),
+		queryParameter(request.QueryParamAvatarSize, "The width and height of the avatar in pixels.",
+			openapi3.SchemaTypeInteger),
+		queryParameter(request.QueryParamAvatarVersion,
+			"The hash of the avatar, allows caching the response indefinitely.", openapi3.SchemaTypeString),
+	)
+	_ = reflector.SetRequest(&opAvatar, struct{}{}, http.MethodGet)
+	_ = reflector.SetStringResponse(&opAvatar, http.StatusOK, "image/png")
+	_ = reflector.SetJSONResponse(&opAvatar, nil, http.StatusNotModified)
+	_ = reflector.SetJSONResponse(&opAvatar, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opAvatar, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAvatar, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/principals/{principal_uid}/avatar", opAvatar)
 }
@@ -264,6 +264,22 @@ var queryParameterSortPullRequest = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterMergeMethod = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamMergeMethod,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The merge method for which the commit message is rendered."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeString),
+				Default: ptrptr(enum.MergeMethodMerge),
+				Enum:    enum.MergeMethod("").Enum(),
+			},
+		},
+	},
+}
+
 var queryParameterKindPullRequestActivity = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamKind,
@@ -463,12 +479,15 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	createPullReq := openapi3.Operation{}
 	createPullReq.WithTags("pullreq")
 	createPullReq.WithMapOfAnything(map[string]interface{}{"operationId": "createPullReq"})
+	createPullReq.WithParameters(headerParameterIdempotencyKey)
 	_ = reflector.SetRequest(&createPullReq, new(createPullReqRequest), http.MethodPost)
 	_ = reflector.SetJSONResponse(&createPullReq, new(types.PullReq), http.StatusCreated)
 	_ = reflector.SetJSONResponse(&createPullReq, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.SetJSONResponse(&createPullReq, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&createPullReq, new(usererror.Error), http.StatusUnauthorized)
 	_ = reflector.SetJSONResponse(&createPullReq, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&createPullReq, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&createPullReq, new(usererror.Error), http.StatusUnprocessableEntity)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/pullreq", createPullReq)
 
 	listPullReq := openapi3.Operation{}
@@ -664,6 +683,19 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge", mergePullReqOp)
 
+	mergeMessagePreviewOp := openapi3.Operation{}
+	mergeMessagePreviewOp.WithTags("pullreq")
+	mergeMessagePreviewOp.WithMapOfAnything(map[string]interface{}{"operationId": "mergeMessagePreview"})
+	mergeMessagePreviewOp.WithParameters(queryParameterMergeMethod)
+	_ = reflector.SetRequest(&mergeMessagePreviewOp, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&mergeMessagePreviewOp, new(pullreq.MergeMessage), http.StatusOK)
+	_ = reflector.SetJSONResponse(&mergeMessagePreviewOp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&mergeMessagePreviewOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&mergeMessagePreviewOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&mergeMessagePreviewOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge-message", mergeMessagePreviewOp)
+
 	opListCommits := openapi3.Operation{}
 	opListCommits.WithTags("pullreq")
 	opListCommits.WithMapOfAnything(map[string]interface{}{"operationId": "listPullReqCommits"})
@@ -749,6 +781,32 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	panicOnErr(reflector.SetJSONResponse(&opDiff, new(usererror.Error), http.StatusNotFound))
 	panicOnErr(reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pullreq/{pullreq_number}/diff", opDiff))
 
+	opPatchFile := openapi3.Operation{}
+	opPatchFile.WithTags("pullreq")
+	opPatchFile.WithMapOfAnything(map[string]interface{}{"operationId": "getPullReqPatchFile"})
+	panicOnErr(reflector.SetRequest(&opPatchFile, new(getPullReqRequest), http.MethodGet))
+	panicOnErr(reflector.SetStringResponse(&opPatchFile, http.StatusOK, "text/plain"))
+	panicOnErr(reflector.SetJSONResponse(&opPatchFile, new(usererror.Error), http.StatusBadRequest))
+	panicOnErr(reflector.SetJSONResponse(&opPatchFile, new(usererror.Error), http.StatusInternalServerError))
+	panicOnErr(reflector.SetJSONResponse(&opPatchFile, new(usererror.Error), http.StatusUnauthorized))
+	panicOnErr(reflector.SetJSONResponse(&opPatchFile, new(usererror.Error), http.StatusForbidden))
+	panicOnErr(reflector.SetJSONResponse(&opPatchFile, new(usererror.Error), http.StatusNotFound))
+	panicOnErr(reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}.patch", opPatchFile))
+
+	opDiffFile := openapi3.Operation{}
+	opDiffFile.WithTags("pullreq")
+	opDiffFile.WithMapOfAnything(map[string]interface{}{"operationId": "getPullReqDiffFile"})
+	panicOnErr(reflector.SetRequest(&opDiffFile, new(getPullReqRequest), http.MethodGet))
+	panicOnErr(reflector.SetStringResponse(&opDiffFile, http.StatusOK, "text/plain"))
+	panicOnErr(reflector.SetJSONResponse(&opDiffFile, new(usererror.Error), http.StatusBadRequest))
+	panicOnErr(reflector.SetJSONResponse(&opDiffFile, new(usererror.Error), http.StatusInternalServerError))
+	panicOnErr(reflector.SetJSONResponse(&opDiffFile, new(usererror.Error), http.StatusUnauthorized))
+	panicOnErr(reflector.SetJSONResponse(&opDiffFile, new(usererror.Error), http.StatusForbidden))
+	panicOnErr(reflector.SetJSONResponse(&opDiffFile, new(usererror.Error), http.StatusNotFound))
+	panicOnErr(reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}.diff", opDiffFile))
+
 	opPostDiff := openapi3.Operation{}
 	opPostDiff.WithTags("pullreq")
 	opPostDiff.WithMapOfAnything(map[string]interface{}{"operationId": "diffPullReqPost"})
@@ -48,6 +48,10 @@ type repoRequest struct {
 type updateRepoRequest struct {
 	repoRequest
 	repo.UpdateInput
+
+	// override the nullable input fields to document them with their value types.
+	Website   *string `json:"website" nullable:"true"`
+	ContactID *int64  `json:"contact_id" nullable:"true"`
 }
 
 type updateDefaultBranchRequest struct {
@@ -80,6 +84,11 @@ type commitFilesRequest struct {
 	repo.CommitFilesOptions
 }
 
+type applyPatchRequest struct {
+	repoRequest
+	repo.ApplyPatchOptions
+}
+
 // contentType is a plugin for repo.ContentType to allow using oneof.
 type contentType string
 
@@ -122,6 +131,11 @@ type GetCommitRequest struct {
 	CommitSHA string `path:"commit_sha"`
 }
 
+type setCommitNoteRequest struct {
+	GetCommitRequest
+	repo.SetCommitNoteInput
+}
+
 type calculateCommitDivergenceRequest struct {
 	repoRequest
 	repo.GetCommitDivergencesInput
@@ -145,6 +159,21 @@ type deleteBranchRequest struct {
 	BranchName string `path:"branch_name"`
 }
 
+type restoreBranchRequest struct {
+	repoRequest
+	repo.RestoreBranchInput
+}
+
+type bulkDeleteBranchesRequest struct {
+	repoRequest
+	repo.BulkDeleteBranchesInput
+}
+
+type listRefHistoryRequest struct {
+	repoRequest
+	Ref string `path:"ref"`
+}
+
 type createTagRequest struct {
 	repoRequest
 	repo.CreateCommitTagInput
@@ -179,14 +208,14 @@ type codeOwnersValidate struct {
 type ruleType string
 
 func (ruleType) Enum() []interface{} {
-	return []interface{}{protection.TypeBranch}
+	return []interface{}{protection.TypeBranch, protection.TypeTag}
 }
 
 // ruleDefinition is a plugin for types.Rule Definition to allow using oneof.
 type ruleDefinition struct{}
 
 func (ruleDefinition) JSONSchemaOneOf() []interface{} {
-	return []interface{}{protection.Branch{}}
+	return []interface{}{protection.Branch{}, protection.Tag{}}
 }
 
 type rule struct {
@@ -210,6 +239,11 @@ type updateRepoPublicAccessRequest struct {
 	repo.UpdatePublicAccessInput
 }
 
+type updateRepoStateRequest struct {
+	repoRequest
+	repo.UpdateStateInput
+}
+
 type securitySettingsRequest struct {
 	repoRequest
 	reposettings.SecuritySettings
@@ -220,6 +254,16 @@ type generalSettingsRequest struct {
 	reposettings.GeneralSettings
 }
 
+type commitMessagePolicyRequest struct {
+	repoRequest
+	types.CommitMessagePolicy
+}
+
+type gitSettingsRequest struct {
+	repoRequest
+	repo.GitSettingsUpdateInput
+}
+
 type archiveRequest struct {
 	repoRequest
 	GitRef string `path:"git_ref" required:"true"`
@@ -238,6 +282,57 @@ type LabelValueRequest struct {
 	Color enum.LabelColor `json:"color"`
 }
 
+var queryParameterTypeRepoActivity = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamType,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The type of the repository activity to include in the result."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+						Enum: enum.RepoActivityType("").Enum(),
+					},
+				},
+			},
+		},
+	},
+}
+
+var queryParameterRepoStatsWindow = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamWindow,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The time window over which the top contributors are returned."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeString),
+				Default: ptrptr(string(enum.RepoStatsWindow30Days)),
+				Enum:    enum.RepoStatsWindow("").Enum(),
+			},
+		},
+	},
+}
+
+var queryParameterForce = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamForce,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Allows to overwrite a repository that isn't empty."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
 var queryParameterGitRef = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name: request.QueryParamGitRef,
@@ -254,6 +349,34 @@ var queryParameterGitRef = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterRef1 = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamRef1,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The first git reference (branch / tag / commitID)."),
+		Required:    ptr.Bool(true),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterRef2 = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamRef2,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The second git reference (branch / tag / commitID)."),
+		Required:    ptr.Bool(true),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
 var queryParameterPath = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamPath,
@@ -297,6 +420,48 @@ var queryParameterUntil = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterPrincipalIDAccessLog = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamPrincipalID,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The ID of the principal whose git operations should be returned."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterSinceAccessLog = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamSince,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Epoch (in milliseconds) since when git operations should be returned."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterUntilAccessLog = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamUntil,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Epoch (in milliseconds) until when git operations should be returned."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
 var queryParameterIncludeCommit = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamIncludeCommit,
@@ -342,6 +507,51 @@ var QueryParamIncludeStats = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterIncludeNotes = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamIncludeNotes,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Indicates whether the git notes of the commits should be included in the response."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
+var queryParameterFollow = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamFollow,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Indicates whether the history of the file at the provided path should follow renames."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
+var queryParameterNotesRef = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamNotesRef,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The reference the git notes are stored in."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeString),
+				Default: ptrptr(gittypes.DefaultNotesRef),
+			},
+		},
+	},
+}
+
 var queryParameterLineFrom = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamLineFrom,
@@ -421,6 +631,22 @@ var queryParameterQueryBranches = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterInactiveDays = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamInactiveDays,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The number of days without commits after which a branch is considered inactive."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeInteger),
+				Default: ptrptr(90),
+				Minimum: ptr.Float64(1),
+			},
+		},
+	},
+}
+
 var queryParameterSortTags = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamSort,
@@ -456,10 +682,11 @@ var queryParameterQueryTags = openapi3.ParameterOrRef{
 
 var queryParameterAfterCommits = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
-		Name:        request.QueryParamAfter,
-		In:          openapi3.ParameterInQuery,
-		Description: ptr.String("The result should only contain commits that occurred after the provided reference."),
-		Required:    ptr.Bool(false),
+		Name: request.QueryParamAfter,
+		In:   openapi3.ParameterInQuery,
+		Description: ptr.String("The result should only contain commits that occurred after the provided reference " +
+			"(the next_after commit SHA of the previous page if follow is set)."),
+		Required: ptr.Bool(false),
 		Schema: &openapi3.SchemaOrRef{
 			Schema: &openapi3.Schema{
 				Type: ptrSchemaType(openapi3.SchemaTypeString),
@@ -496,6 +723,28 @@ var queryParameterQueryRuleList = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterTypeRuleList = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamType,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The types of the protection rules to list."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+						Enum: ruleType("").Enum(),
+					},
+				},
+			},
+		},
+		Style:   ptr.String(string(openapi3.EncodingStyleForm)),
+		Explode: ptr.Bool(true),
+	},
+}
+
 var queryParameterSortRuleList = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamSort,
@@ -669,6 +918,7 @@ func repoOperations(reflector *openapi3.Reflector) {
 	createRepository := openapi3.Operation{}
 	createRepository.WithTags("repository")
 	createRepository.WithMapOfAnything(map[string]interface{}{"operationId": "createRepository"})
+	createRepository.WithParameters(headerParameterIdempotencyKey)
 	createRepository.WithParameters(queryParameterSpacePath)
 	_ = reflector.SetRequest(&createRepository, new(createRepositoryRequest), http.MethodPost)
 	_ = reflector.SetJSONResponse(&createRepository, new(repo.RepositoryOutput), http.StatusCreated)
@@ -676,18 +926,23 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&createRepository, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&createRepository, new(usererror.Error), http.StatusUnauthorized)
 	_ = reflector.SetJSONResponse(&createRepository, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&createRepository, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&createRepository, new(usererror.Error), http.StatusUnprocessableEntity)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos", createRepository)
 
 	importRepository := openapi3.Operation{}
 	importRepository.WithTags("repository")
 	importRepository.WithMapOfAnything(map[string]interface{}{"operationId": "importRepository"})
+	importRepository.WithParameters(headerParameterIdempotencyKey)
 	importRepository.WithParameters(queryParameterSpacePath)
 	_ = reflector.SetRequest(&importRepository, &struct{ repo.ImportInput }{}, http.MethodPost)
-	_ = reflector.SetJSONResponse(&importRepository, new(repo.RepositoryOutput), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&importRepository, new(repo.RepositoryOutput), http.StatusAccepted)
 	_ = reflector.SetJSONResponse(&importRepository, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.SetJSONResponse(&importRepository, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&importRepository, new(usererror.Error), http.StatusUnauthorized)
 	_ = reflector.SetJSONResponse(&importRepository, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&importRepository, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&importRepository, new(usererror.Error), http.StatusUnprocessableEntity)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/import", importRepository)
 
 	opFind := openapi3.Operation{}
@@ -727,6 +982,7 @@ func repoOperations(reflector *openapi3.Reflector) {
 	opDelete := openapi3.Operation{}
 	opDelete.WithTags("repository")
 	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteRepository"})
+	opDelete.WithParameters(queryParameterDryRun)
 	_ = reflector.SetRequest(&opDelete, new(repoRequest), http.MethodDelete)
 	_ = reflector.SetJSONResponse(&opDelete, new(repo.SoftDeleteResponse), http.StatusOK)
 	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
@@ -786,9 +1042,48 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(
 		http.MethodPost, "/repos/{repo_ref}/public-access", opUpdatePublicAccess)
 
+	opStar := openapi3.Operation{}
+	opStar.WithTags("repository")
+	opStar.WithMapOfAnything(map[string]interface{}{"operationId": "starRepository"})
+	_ = reflector.SetRequest(&opStar, new(repoRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opStar, new(repo.RepositoryOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opStar, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opStar, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opStar, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opStar, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/repos/{repo_ref}/star", opStar)
+
+	opUnstar := openapi3.Operation{}
+	opUnstar.WithTags("repository")
+	opUnstar.WithMapOfAnything(map[string]interface{}{"operationId": "unstarRepository"})
+	_ = reflector.SetRequest(&opUnstar, new(repoRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opUnstar, new(repo.RepositoryOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUnstar, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUnstar, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUnstar, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUnstar, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/star", opUnstar)
+
+	opUpdateState := openapi3.Operation{}
+	opUpdateState.WithTags("repository")
+	opUpdateState.WithMapOfAnything(
+		map[string]interface{}{"operationId": "updateRepoState"})
+	_ = reflector.SetRequest(&opUpdateState, new(updateRepoStateRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opUpdateState, new(repo.RepositoryOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateState, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateState, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdateState, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdateState, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdateState, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opUpdateState, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opUpdateState, new(usererror.Error), http.StatusLocked)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/state", opUpdateState)
+
 	opServiceAccounts := openapi3.Operation{}
 	opServiceAccounts.WithTags("repository")
 	opServiceAccounts.WithMapOfAnything(map[string]interface{}{"operationId": "listRepositoryServiceAccounts"})
+	opServiceAccounts.WithParameters(queryParameterQueryServiceAccount,
+		queryParameterSortServiceAccount, queryParameterOrder)
 	_ = reflector.SetRequest(&opServiceAccounts, new(repoRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&opServiceAccounts, []types.ServiceAccount{}, http.StatusOK)
 	_ = reflector.SetJSONResponse(&opServiceAccounts, new(usererror.Error), http.StatusInternalServerError)
@@ -859,14 +1154,30 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opGetBlame, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/blame/{path}", opGetBlame)
 
+	opGetPermalink := openapi3.Operation{}
+	opGetPermalink.WithTags("repository")
+	opGetPermalink.WithMapOfAnything(map[string]interface{}{"operationId": "getPermalink"})
+	opGetPermalink.WithParameters(queryParameterGitRef, queryParameterPath,
+		queryParameterLineFrom, queryParameterLineTo)
+	_ = reflector.SetRequest(&opGetPermalink, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opGetPermalink, new(repo.PermalinkOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opGetPermalink, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opGetPermalink, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opGetPermalink, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opGetPermalink, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opGetPermalink, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/permalink", opGetPermalink)
+
 	opListCommits := openapi3.Operation{}
 	opListCommits.WithTags("repository")
 	opListCommits.WithMapOfAnything(map[string]interface{}{"operationId": "listCommits"})
 	opListCommits.WithParameters(queryParameterGitRef, queryParameterAfterCommits, queryParameterPath,
 		queryParameterSince, queryParameterUntil, queryParameterCommitter,
-		QueryParameterPage, QueryParameterLimit, QueryParamIncludeStats)
+		QueryParameterPage, QueryParameterLimit, QueryParamIncludeStats,
+		queryParameterIncludeNotes, queryParameterNotesRef, queryParameterFollow)
 	_ = reflector.SetRequest(&opListCommits, new(listCommitsRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&opListCommits, []types.ListCommitResponse{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListCommits, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.SetJSONResponse(&opListCommits, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opListCommits, new(usererror.Error), http.StatusUnauthorized)
 	_ = reflector.SetJSONResponse(&opListCommits, new(usererror.Error), http.StatusForbidden)
@@ -884,6 +1195,32 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opGetCommit, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/commits/{commit_sha}", opGetCommit)
 
+	opGetCommitNote := openapi3.Operation{}
+	opGetCommitNote.WithTags("repository")
+	opGetCommitNote.WithMapOfAnything(map[string]interface{}{"operationId": "getCommitNote"})
+	opGetCommitNote.WithParameters(queryParameterNotesRef)
+	_ = reflector.SetRequest(&opGetCommitNote, new(GetCommitRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opGetCommitNote, repo.CommitNote{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opGetCommitNote, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opGetCommitNote, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opGetCommitNote, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opGetCommitNote, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opGetCommitNote, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/commits/{commit_sha}/notes", opGetCommitNote)
+
+	opSetCommitNote := openapi3.Operation{}
+	opSetCommitNote.WithTags("repository")
+	opSetCommitNote.WithMapOfAnything(map[string]interface{}{"operationId": "setCommitNote"})
+	opSetCommitNote.WithParameters(queryParameterNotesRef)
+	_ = reflector.SetRequest(&opSetCommitNote, new(setCommitNoteRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opSetCommitNote, repo.CommitNote{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSetCommitNote, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSetCommitNote, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSetCommitNote, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSetCommitNote, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSetCommitNote, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/repos/{repo_ref}/commits/{commit_sha}/notes", opSetCommitNote)
+
 	opCalulateCommitDivergence := openapi3.Operation{}
 	opCalulateCommitDivergence.WithTags("repository")
 	opCalulateCommitDivergence.WithMapOfAnything(map[string]interface{}{"operationId": "calculateCommitDivergence"})
@@ -932,6 +1269,59 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opDeleteBranch, new(types.RulesViolations), http.StatusUnprocessableEntity)
 	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/branches/{branch_name}", opDeleteBranch)
 
+	opRestoreBranch := openapi3.Operation{}
+	opRestoreBranch.WithTags("repository")
+	opRestoreBranch.WithMapOfAnything(map[string]interface{}{"operationId": "restoreBranch"})
+	_ = reflector.SetRequest(&opRestoreBranch, new(restoreBranchRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRestoreBranch, new(repo.Branch), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opRestoreBranch, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRestoreBranch, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRestoreBranch, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRestoreBranch, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRestoreBranch, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opRestoreBranch, new(types.RulesViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/branches/restore", opRestoreBranch)
+
+	opBulkDeleteBranches := openapi3.Operation{}
+	opBulkDeleteBranches.WithTags("repository")
+	opBulkDeleteBranches.WithMapOfAnything(map[string]interface{}{"operationId": "bulkDeleteBranches"})
+	opBulkDeleteBranches.WithParameters(queryParameterDryRun)
+	_ = reflector.SetRequest(&opBulkDeleteBranches, new(bulkDeleteBranchesRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opBulkDeleteBranches, []repo.BulkDeleteBranchResult{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opBulkDeleteBranches, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opBulkDeleteBranches, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opBulkDeleteBranches, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opBulkDeleteBranches, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opBulkDeleteBranches, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/branches/bulk-delete", opBulkDeleteBranches)
+
+	opListStaleBranches := openapi3.Operation{}
+	opListStaleBranches.WithTags("repository")
+	opListStaleBranches.WithMapOfAnything(map[string]interface{}{"operationId": "listStaleBranches"})
+	opListStaleBranches.WithParameters(queryParameterQueryBranches, queryParameterInactiveDays,
+		QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opListStaleBranches, new(listBranchesRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListStaleBranches, []repo.StaleBranch{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListStaleBranches, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opListStaleBranches, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListStaleBranches, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListStaleBranches, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListStaleBranches, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/stale-branches", opListStaleBranches)
+
+	opListRefHistory := openapi3.Operation{}
+	opListRefHistory.WithTags("repository")
+	opListRefHistory.WithMapOfAnything(map[string]interface{}{"operationId": "listRefHistory"})
+	opListRefHistory.WithParameters(QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opListRefHistory, new(listRefHistoryRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListRefHistory, []repo.RefHistoryEntry{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListRefHistory, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opListRefHistory, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListRefHistory, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListRefHistory, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListRefHistory, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/refs/{ref}/history", opListRefHistory)
+
 	opListBranches := openapi3.Operation{}
 	opListBranches.WithTags("repository")
 	opListBranches.WithMapOfAnything(map[string]interface{}{"operationId": "listBranches"})
@@ -1001,6 +1391,21 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opCommitFiles, new(types.RulesViolations), http.StatusUnprocessableEntity)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/commits", opCommitFiles)
 
+	opApplyPatch := openapi3.Operation{}
+	opApplyPatch.WithTags("repository")
+	opApplyPatch.WithMapOfAnything(map[string]interface{}{"operationId": "applyPatch"})
+	_ = reflector.SetRequest(&opApplyPatch, new(applyPatchRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opApplyPatch, types.CommitFilesResponse{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opApplyPatch, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opApplyPatch, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opApplyPatch, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opApplyPatch, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opApplyPatch, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opApplyPatch, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opApplyPatch, new(usererror.Error), http.StatusRequestEntityTooLarge)
+	_ = reflector.SetJSONResponse(&opApplyPatch, new(types.RulesViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/patches", opApplyPatch)
+
 	opDiff := openapi3.Operation{}
 	opDiff.WithTags("repository")
 	opDiff.WithMapOfAnything(map[string]interface{}{"operationId": "rawDiff"})
@@ -1034,6 +1439,30 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opCommitDiff, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/commits/{commit_sha}/diff", opCommitDiff)
 
+	opCommitPatchFile := openapi3.Operation{}
+	opCommitPatchFile.WithTags("repository")
+	opCommitPatchFile.WithMapOfAnything(map[string]interface{}{"operationId": "getCommitPatchFile"})
+	_ = reflector.SetRequest(&opCommitPatchFile, new(GetCommitRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&opCommitPatchFile, http.StatusOK, "text/plain")
+	_ = reflector.SetJSONResponse(&opCommitPatchFile, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCommitPatchFile, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCommitPatchFile, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCommitPatchFile, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCommitPatchFile, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/commits/{commit_sha}.patch", opCommitPatchFile)
+
+	opCommitDiffFile := openapi3.Operation{}
+	opCommitDiffFile.WithTags("repository")
+	opCommitDiffFile.WithMapOfAnything(map[string]interface{}{"operationId": "getCommitDiffFile"})
+	_ = reflector.SetRequest(&opCommitDiffFile, new(GetCommitRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&opCommitDiffFile, http.StatusOK, "text/plain")
+	_ = reflector.SetJSONResponse(&opCommitDiffFile, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCommitDiffFile, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCommitDiffFile, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCommitDiffFile, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCommitDiffFile, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/commits/{commit_sha}.diff", opCommitDiffFile)
+
 	opDiffStats := openapi3.Operation{}
 	opDiffStats.WithTags("repository")
 	opDiffStats.WithMapOfAnything(map[string]interface{}{"operationId": "diffStats"})
@@ -1057,6 +1486,7 @@ func repoOperations(reflector *openapi3.Reflector) {
 	opRuleAdd := openapi3.Operation{}
 	opRuleAdd.WithTags("repository")
 	opRuleAdd.WithMapOfAnything(map[string]interface{}{"operationId": "ruleAdd"})
+	opRuleAdd.WithParameters(headerParameterIdempotencyKey)
 	_ = reflector.SetRequest(&opRuleAdd, struct {
 		repoRequest
 		repo.RuleCreateInput
@@ -1070,6 +1500,8 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opRuleAdd, new(usererror.Error), http.StatusUnauthorized)
 	_ = reflector.SetJSONResponse(&opRuleAdd, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.SetJSONResponse(&opRuleAdd, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opRuleAdd, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opRuleAdd, new(usererror.Error), http.StatusUnprocessableEntity)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/rules", opRuleAdd)
 
 	opRuleDelete := openapi3.Operation{}
@@ -1109,7 +1541,7 @@ func repoOperations(reflector *openapi3.Reflector) {
 	opRuleList.WithTags("repository")
 	opRuleList.WithMapOfAnything(map[string]interface{}{"operationId": "ruleList"})
 	opRuleList.WithParameters(
-		queryParameterQueryRuleList,
+		queryParameterQueryRuleList, queryParameterTypeRuleList,
 		queryParameterOrder, queryParameterSortRuleList,
 		QueryParameterPage, QueryParameterLimit)
 	_ = reflector.SetRequest(&opRuleList, &struct {
@@ -1207,6 +1639,69 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(
 		http.MethodGet, "/repos/{repo_ref}/settings/general", opSettingsGeneralFind)
 
+	opCommitMessagePolicyUpdate := openapi3.Operation{}
+	opCommitMessagePolicyUpdate.WithTags("repository")
+	opCommitMessagePolicyUpdate.WithSummary("Set the commit message policy of the repository")
+	opCommitMessagePolicyUpdate.WithMapOfAnything(
+		map[string]interface{}{"operationId": "updateRepoCommitMessagePolicy"})
+	_ = reflector.SetRequest(
+		&opCommitMessagePolicyUpdate, new(commitMessagePolicyRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(
+		&opCommitMessagePolicyUpdate, new(types.CommitMessagePolicyOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCommitMessagePolicyUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCommitMessagePolicyUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCommitMessagePolicyUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCommitMessagePolicyUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCommitMessagePolicyUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodPut, "/repos/{repo_ref}/settings/commit-message-policy", opCommitMessagePolicyUpdate)
+
+	opCommitMessagePolicyFind := openapi3.Operation{}
+	opCommitMessagePolicyFind.WithTags("repository")
+	opCommitMessagePolicyFind.WithSummary("Get the commit message policy in effect for the repository")
+	opCommitMessagePolicyFind.WithMapOfAnything(
+		map[string]interface{}{"operationId": "findRepoCommitMessagePolicy"})
+	_ = reflector.SetRequest(&opCommitMessagePolicyFind, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(
+		&opCommitMessagePolicyFind, new(types.CommitMessagePolicyOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCommitMessagePolicyFind, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCommitMessagePolicyFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCommitMessagePolicyFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCommitMessagePolicyFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCommitMessagePolicyFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodGet, "/repos/{repo_ref}/settings/commit-message-policy", opCommitMessagePolicyFind)
+
+	opSettingsGitUpdate := openapi3.Operation{}
+	opSettingsGitUpdate.WithTags("repository")
+	opSettingsGitUpdate.WithMapOfAnything(
+		map[string]interface{}{"operationId": "updateGitSettings"})
+	_ = reflector.SetRequest(
+		&opSettingsGitUpdate, new(gitSettingsRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opSettingsGitUpdate, new(repo.GitSettings), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSettingsGitUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSettingsGitUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSettingsGitUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSettingsGitUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSettingsGitUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opSettingsGitUpdate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(
+		http.MethodPatch, "/repos/{repo_ref}/settings/git", opSettingsGitUpdate)
+
+	opSettingsGitFind := openapi3.Operation{}
+	opSettingsGitFind.WithTags("repository")
+	opSettingsGitFind.WithMapOfAnything(
+		map[string]interface{}{"operationId": "findGitSettings"})
+	_ = reflector.SetRequest(&opSettingsGitFind, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opSettingsGitFind, new(repo.GitSettings), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSettingsGitFind, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSettingsGitFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSettingsGitFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSettingsGitFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSettingsGitFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodGet, "/repos/{repo_ref}/settings/git", opSettingsGitFind)
+
 	opArchive := openapi3.Operation{}
 	opArchive.WithTags("repository")
 	opArchive.WithMapOfAnything(map[string]interface{}{"operationId": "archive"})
@@ -1228,6 +1723,32 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opArchive, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/archive/{git_ref}.{format}", opArchive)
 
+	opBackupBundle := openapi3.Operation{}
+	opBackupBundle.WithTags("admin")
+	opBackupBundle.WithMapOfAnything(map[string]interface{}{"operationId": "adminBackupRepository"})
+	_ = reflector.SetRequest(&opBackupBundle, new(repoRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&opBackupBundle, http.StatusOK, "application/octet-stream")
+	_ = reflector.SetJSONResponse(&opBackupBundle, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opBackupBundle, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opBackupBundle, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opBackupBundle, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opBackupBundle, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/repos/{repo_ref}/bundle", opBackupBundle)
+
+	opRestoreBundle := openapi3.Operation{}
+	opRestoreBundle.WithTags("admin")
+	opRestoreBundle.WithMapOfAnything(map[string]interface{}{"operationId": "adminRestoreRepository"})
+	opRestoreBundle.WithParameters(queryParameterForce)
+	_ = reflector.SetRequest(&opRestoreBundle, new(repoRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opRestoreBundle, new(repo.RepositoryOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRestoreBundle, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRestoreBundle, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRestoreBundle, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRestoreBundle, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRestoreBundle, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opRestoreBundle, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/admin/repos/{repo_ref}/bundle", opRestoreBundle)
+
 	opSummary := openapi3.Operation{}
 	opSummary.WithTags("repository")
 	opSummary.WithMapOfAnything(
@@ -1241,6 +1762,121 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opSummary, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/summary", opSummary)
 
+	opMergeBase := openapi3.Operation{}
+	opMergeBase.WithTags("repository")
+	opMergeBase.WithMapOfAnything(
+		map[string]interface{}{"operationId": "mergeBase"})
+	opMergeBase.WithParameters(queryParameterRef1, queryParameterRef2)
+	_ = reflector.SetRequest(&opMergeBase, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opMergeBase, new(repo.MergeBaseOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMergeBase, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opMergeBase, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMergeBase, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMergeBase, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMergeBase, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/merge-base", opMergeBase)
+
+	opStats := openapi3.Operation{}
+	opStats.WithTags("repository")
+	opStats.WithMapOfAnything(
+		map[string]interface{}{"operationId": "repoStats"})
+	opStats.WithParameters(queryParameterRepoStatsWindow)
+	_ = reflector.SetRequest(&opStats, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opStats, new(types.RepoStatsOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opStats, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opStats, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opStats, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opStats, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opStats, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/stats", opStats)
+
+	opContributorStats := openapi3.Operation{}
+	opContributorStats.WithTags("repository")
+	opContributorStats.WithMapOfAnything(
+		map[string]interface{}{"operationId": "repoContributorStats"})
+	opContributorStats.WithParameters(queryParameterGitRef, queryParameterSince, queryParameterUntil)
+	_ = reflector.SetRequest(&opContributorStats, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opContributorStats, new(types.RepoContributorStats), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opContributorStats, nil, http.StatusAccepted)
+	_ = reflector.SetJSONResponse(&opContributorStats, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opContributorStats, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opContributorStats, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opContributorStats, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opContributorStats, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/stats/contributors", opContributorStats)
+
+	opListActivities := openapi3.Operation{}
+	opListActivities.WithTags("repository")
+	opListActivities.WithMapOfAnything(
+		map[string]interface{}{"operationId": "listRepoActivities"})
+	opListActivities.WithParameters(queryParameterTypeRepoActivity, QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opListActivities, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListActivities, []types.RepoActivity{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListActivities, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListActivities, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListActivities, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListActivities, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/activity", opListActivities)
+
+	opListAccessLog := openapi3.Operation{}
+	opListAccessLog.WithTags("repository")
+	opListAccessLog.WithMapOfAnything(
+		map[string]interface{}{"operationId": "listRepoAccessLog"})
+	opListAccessLog.WithParameters(queryParameterPrincipalIDAccessLog, queryParameterSinceAccessLog,
+		queryParameterUntilAccessLog, QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opListAccessLog, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListAccessLog, []types.RepoAccessLog{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListAccessLog, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opListAccessLog, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListAccessLog, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListAccessLog, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListAccessLog, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/access-log", opListAccessLog)
+
+	opCreateDeployKey := openapi3.Operation{}
+	opCreateDeployKey.WithTags("repository")
+	opCreateDeployKey.WithMapOfAnything(
+		map[string]interface{}{"operationId": "createDeployKey"})
+	_ = reflector.SetRequest(&opCreateDeployKey, struct {
+		repoRequest
+		repo.CreateDeployKeyInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreateDeployKey, new(repo.CreateDeployKeyOutput), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreateDeployKey, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreateDeployKey, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreateDeployKey, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreateDeployKey, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/keys", opCreateDeployKey)
+
+	opListDeployKeys := openapi3.Operation{}
+	opListDeployKeys.WithTags("repository")
+	opListDeployKeys.WithMapOfAnything(
+		map[string]interface{}{"operationId": "listDeployKeys"})
+	opListDeployKeys.WithParameters(QueryParameterPage, QueryParameterLimit,
+		queryParameterQueryPublicKey, queryParameterSortPublicKey, queryParameterOrder)
+	_ = reflector.SetRequest(&opListDeployKeys, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListDeployKeys, []types.DeployKey{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListDeployKeys, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListDeployKeys, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListDeployKeys, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListDeployKeys, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/keys", opListDeployKeys)
+
+	opDeleteDeployKey := openapi3.Operation{}
+	opDeleteDeployKey.WithTags("repository")
+	opDeleteDeployKey.WithMapOfAnything(
+		map[string]interface{}{"operationId": "deleteDeployKey"})
+	_ = reflector.SetRequest(&opDeleteDeployKey, struct {
+		repoRequest
+		ID string `path:"deploy_key_identifier"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteDeployKey, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteDeployKey, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeleteDeployKey, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeleteDeployKey, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeleteDeployKey, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/keys/{deploy_key_identifier}", opDeleteDeployKey)
+
 	opDefineLabel := openapi3.Operation{}
 	opDefineLabel.WithTags("repository")
 	opDefineLabel.WithMapOfAnything(
@@ -1292,11 +1928,13 @@ func repoOperations(reflector *openapi3.Reflector) {
 	opDeleteLabel.WithTags("repository")
 	opDeleteLabel.WithMapOfAnything(
 		map[string]interface{}{"operationId": "deleteRepoLabel"})
+	opDeleteLabel.WithParameters(queryParameterDryRun)
 	_ = reflector.SetRequest(&opDeleteLabel, &struct {
 		repoRequest
 		Key string `path:"key"`
 	}{}, http.MethodDelete)
 	_ = reflector.SetJSONResponse(&opDeleteLabel, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteLabel, new(types.DryRunOutput), http.StatusOK)
 	_ = reflector.SetJSONResponse(&opDeleteLabel, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.SetJSONResponse(&opDeleteLabel, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opDeleteLabel, new(usererror.Error), http.StatusUnauthorized)
@@ -0,0 +1,90 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/runner"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type runnerRequest struct {
+	Identifier string `path:"runner_identifier"`
+}
+
+type heartbeatRunnerRequest struct {
+	runnerRequest
+	runner.HeartbeatInput
+}
+
+func runnerOperations(reflector *openapi3.Reflector) {
+	opRegister := openapi3.Operation{}
+	opRegister.WithTags("runner")
+	opRegister.WithMapOfAnything(map[string]interface{}{"operationId": "registerRunner"})
+	_ = reflector.SetRequest(&opRegister, new(runner.RegisterInput), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRegister, new(runner.RegisterOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRegister, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRegister, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRegister, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRegister, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opRegister, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/runners/register", opRegister)
+
+	opHeartbeat := openapi3.Operation{}
+	opHeartbeat.WithTags("runner")
+	opHeartbeat.WithMapOfAnything(map[string]interface{}{"operationId": "heartbeatRunner"})
+	_ = reflector.SetRequest(&opHeartbeat, new(heartbeatRunnerRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opHeartbeat, new(types.Runner), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opHeartbeat, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opHeartbeat, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/runners/{runner_identifier}/heartbeat", opHeartbeat)
+
+	opDelete := openapi3.Operation{}
+	opDelete.WithTags("runner")
+	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteRunner"})
+	_ = reflector.SetRequest(&opDelete, new(runnerRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDelete, nil, http.StatusAccepted)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/runners/{runner_identifier}", opDelete)
+
+	opList := openapi3.Operation{}
+	opList.WithTags("admin")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "adminListRunners"})
+	_ = reflector.SetRequest(&opList, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, new([]*types.Runner), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/runners", opList)
+
+	opListSpace := openapi3.Operation{}
+	opListSpace.WithTags("space")
+	opListSpace.WithMapOfAnything(map[string]interface{}{"operationId": "listSpaceRunners"})
+	_ = reflector.SetRequest(&opListSpace, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListSpace, new([]*types.Runner), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListSpace, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListSpace, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListSpace, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opListSpace, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/runners", opListSpace)
+}
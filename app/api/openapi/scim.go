@@ -0,0 +1,128 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/scim"
+	"github.com/harness/gitness/app/api/usererror"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type (
+	scimUserRequest struct {
+		UserUID string `path:"user_uid"`
+	}
+
+	scimUserListRequest struct {
+		Filter     string `query:"filter"     description:"Filter of the form 'userName eq \"jane\"'."`
+		StartIndex int    `query:"startIndex" description:"The 1-based index of the first user to return." minimum:"1"`
+		Count      int    `query:"count"      description:"The number of users per page." minimum:"1" maximum:"100"`
+
+		// include pagination request
+		paginationRequest
+	}
+
+	scimUserReplaceRequest struct {
+		scimUserRequest
+		scim.User
+	}
+
+	scimUserPatchRequest struct {
+		scimUserRequest
+		scim.PatchInput
+	}
+)
+
+func scimOperations(reflector *openapi3.Reflector) {
+	opList := openapi3.Operation{}
+	opList.WithTags("scim")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "scimListUsers"})
+	_ = reflector.SetRequest(&opList, new(scimUserListRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, new(scim.ListResponse), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/scim/v2/Users", opList)
+
+	opCreate := openapi3.Operation{}
+	opCreate.WithTags("scim")
+	opCreate.WithMapOfAnything(map[string]interface{}{"operationId": "scimCreateUser"})
+	_ = reflector.SetRequest(&opCreate, new(scim.User), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreate, new(scim.User), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/scim/v2/Users", opCreate)
+
+	opFind := openapi3.Operation{}
+	opFind.WithTags("scim")
+	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "scimGetUser"})
+	_ = reflector.SetRequest(&opFind, new(scimUserRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(scim.User), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/scim/v2/Users/{user_uid}", opFind)
+
+	opReplace := openapi3.Operation{}
+	opReplace.WithTags("scim")
+	opReplace.WithMapOfAnything(map[string]interface{}{"operationId": "scimReplaceUser"})
+	_ = reflector.SetRequest(&opReplace, new(scimUserReplaceRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opReplace, new(scim.User), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opReplace, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opReplace, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opReplace, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opReplace, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opReplace, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/scim/v2/Users/{user_uid}", opReplace)
+
+	opPatch := openapi3.Operation{}
+	opPatch.WithTags("scim")
+	opPatch.WithMapOfAnything(map[string]interface{}{"operationId": "scimPatchUser"})
+	_ = reflector.SetRequest(&opPatch, new(scimUserPatchRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opPatch, new(scim.User), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opPatch, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opPatch, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPatch, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opPatch, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opPatch, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/scim/v2/Users/{user_uid}", opPatch)
+
+	opDeactivate := openapi3.Operation{}
+	opDeactivate.WithTags("scim")
+	opDeactivate.WithMapOfAnything(map[string]interface{}{"operationId": "scimDeactivateUser"})
+	_ = reflector.SetRequest(&opDeactivate, new(scimUserRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeactivate, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeactivate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opDeactivate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeactivate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opDeactivate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/scim/v2/Users/{user_uid}", opDeactivate)
+
+	opBulk := openapi3.Operation{}
+	opBulk.WithTags("scim")
+	opBulk.WithMapOfAnything(map[string]interface{}{"operationId": "scimBulk"})
+	_ = reflector.SetRequest(&opBulk, new(scim.BulkInput), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opBulk, new(scim.BulkOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opBulk, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opBulk, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opBulk, new(usererror.Error), http.StatusRequestEntityTooLarge)
+	_ = reflector.SetJSONResponse(&opBulk, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/scim/v2/Bulk", opBulk)
+}
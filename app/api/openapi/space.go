@@ -17,9 +17,11 @@ package openapi
 import (
 	"net/http"
 
+	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/controller/space"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/repobulk"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -35,6 +37,43 @@ type spaceRequest struct {
 	Ref string `path:"space_ref"`
 }
 
+type spaceCommitMessagePolicyRequest struct {
+	spaceRequest
+	types.CommitMessagePolicy
+}
+
+type renameSpaceTopicRequest struct {
+	spaceRequest
+	space.TopicRenameInput
+}
+
+type pinRepoRequest struct {
+	spaceRequest
+	space.PinRepoInput
+}
+
+type unpinRepoRequest struct {
+	spaceRequest
+	RepoID int64 `path:"pinned_repo_id"`
+}
+
+type reorderPinnedReposRequest struct {
+	spaceRequest
+	space.ReorderPinnedReposInput
+}
+
+type spaceEventsRequest struct {
+	spaceRequest
+	LastEventID string `header:"Last-Event-ID"`
+}
+
+type spaceUsageRequest struct {
+	spaceRequest
+	From   int64  `query:"from" description:"The start of the report (unix millis), defaults to 30 days before the end."`
+	To     int64  `query:"to"   description:"The end of the report (unix millis), defaults to now."`
+	Accept string `header:"Accept" enum:"application/json,text/csv"`
+}
+
 type updateSpaceRequest struct {
 	spaceRequest
 	space.UpdateInput
@@ -54,6 +93,16 @@ type exportSpaceRequest struct {
 	space.ExportInput
 }
 
+type bulkSpaceReposRequest struct {
+	spaceRequest
+	repobulk.Input
+}
+
+type bulkSpaceReposProgressRequest struct {
+	spaceRequest
+	JobUID string `path:"bulk_job_uid"`
+}
+
 type restoreSpaceRequest struct {
 	spaceRequest
 	space.RestoreInput
@@ -73,6 +122,8 @@ var queryParameterSortRepo = openapi3.ParameterOrRef{
 					ptr.String(enum.RepoAttrIdentifier.String()),
 					ptr.String(enum.RepoAttrCreated.String()),
 					ptr.String(enum.RepoAttrUpdated.String()),
+					ptr.String(enum.RepoAttrLastActivity.String()),
+					ptr.String(enum.RepoAttrStars.String()),
 				},
 			},
 		},
@@ -93,10 +144,76 @@ var queryParameterQueryRepo = openapi3.ParameterOrRef{
 	},
 }
 
-var queryParameterRecursive = openapi3.ParameterOrRef{
+var queryParameterTopicRepo = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamTopic,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The topics which are used to filter the repositories."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+					},
+				},
+			},
+		},
+		Style:   ptr.String(string(openapi3.EncodingStyleForm)),
+		Explode: ptr.Bool(true),
+	},
+}
+
+var queryParameterTopicMatchRepo = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamTopicMatch,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Whether the repositories need to have all of the topics or any of them."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeString),
+				Default: ptrptr("any"),
+				Enum:    []interface{}{"any", "all"},
+			},
+		},
+	},
+}
+
+var queryParameterIncludePinnedRepo = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamIncludePinned,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The boolean used to list the pinned repositories of the space first."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
+var queryParameterQueryTopic = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamQuery,
 		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The prefix which is used to filter the topics."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterRecursive = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamRecursive,
+		In:          openapi3.ParameterInQuery,
 		Description: ptr.String("The boolean used to do space recursive op on repos."),
 		Required:    ptr.Bool(false),
 		Schema: &openapi3.SchemaOrRef{
@@ -141,6 +258,39 @@ var queryParameterQuerySpace = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterQueryServiceAccount = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The substring which is used to filter the service accounts by their uid or display name."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterSortServiceAccount = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamSort,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The data by which the service accounts are sorted."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeString),
+				Default: ptrptr(string(types.ServiceAccountFieldUID)),
+				Enum: []interface{}{
+					ptr.String(string(types.ServiceAccountFieldUID)),
+					ptr.String(string(types.ServiceAccountFieldCreated)),
+				},
+			},
+		},
+	},
+}
+
 var queryParameterMembershipUsers = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamQuery,
@@ -176,12 +326,15 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	opCreate := openapi3.Operation{}
 	opCreate.WithTags("space")
 	opCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createSpace"})
+	opCreate.WithParameters(headerParameterIdempotencyKey)
 	_ = reflector.SetRequest(&opCreate, new(createSpaceRequest), http.MethodPost)
 	_ = reflector.SetJSONResponse(&opCreate, new(space.SpaceOutput), http.StatusCreated)
 	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusUnauthorized)
 	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusUnprocessableEntity)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces", opCreate)
 
 	opImport := openapi3.Operation{}
@@ -198,7 +351,10 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	opImportRepositories := openapi3.Operation{}
 	opImportRepositories.WithTags("space")
 	opImportRepositories.WithMapOfAnything(map[string]interface{}{"operationId": "importSpaceRepositories"})
-	_ = reflector.SetRequest(&opImportRepositories, &struct{ space.ImportRepositoriesInput }{}, http.MethodPost)
+	_ = reflector.SetRequest(&opImportRepositories, &struct {
+		spaceRequest
+		space.ImportRepositoriesInput
+	}{}, http.MethodPost)
 	_ = reflector.SetJSONResponse(&opImportRepositories, new(space.ImportRepositoriesOutput), http.StatusOK)
 	_ = reflector.SetJSONResponse(&opImportRepositories, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.SetJSONResponse(&opImportRepositories, new(usererror.Error), http.StatusInternalServerError)
@@ -210,13 +366,38 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	opExport.WithTags("space")
 	opExport.WithMapOfAnything(map[string]interface{}{"operationId": "exportSpace"})
 	_ = reflector.SetRequest(&opExport, new(exportSpaceRequest), http.MethodPost)
-	_ = reflector.SetJSONResponse(&opExport, nil, http.StatusAccepted)
+	_ = reflector.SetJSONResponse(&opExport, new(space.ExportOutput), http.StatusAccepted)
 	_ = reflector.SetJSONResponse(&opExport, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.SetJSONResponse(&opExport, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opExport, new(usererror.Error), http.StatusUnauthorized)
 	_ = reflector.SetJSONResponse(&opExport, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/export", opExport)
 
+	opBulkRepos := openapi3.Operation{}
+	opBulkRepos.WithTags("space")
+	opBulkRepos.WithMapOfAnything(map[string]interface{}{"operationId": "bulkSpaceRepos"})
+	opBulkRepos.WithParameters(queryParameterDryRun)
+	_ = reflector.SetRequest(&opBulkRepos, new(bulkSpaceReposRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opBulkRepos, new(repobulk.Progress), http.StatusAccepted)
+	_ = reflector.SetJSONResponse(&opBulkRepos, new(repobulk.Progress), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opBulkRepos, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opBulkRepos, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opBulkRepos, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opBulkRepos, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/repos/bulk", opBulkRepos)
+
+	opBulkReposProgress := openapi3.Operation{}
+	opBulkReposProgress.WithTags("space")
+	opBulkReposProgress.WithMapOfAnything(map[string]interface{}{"operationId": "bulkSpaceReposProgress"})
+	_ = reflector.SetRequest(&opBulkReposProgress, new(bulkSpaceReposProgressRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opBulkReposProgress, new(repobulk.Progress), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opBulkReposProgress, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opBulkReposProgress, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opBulkReposProgress, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opBulkReposProgress, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/spaces/{space_ref}/repos/bulk/{bulk_job_uid}", opBulkReposProgress)
+
 	opExportProgress := openapi3.Operation{}
 	opExportProgress.WithTags("space")
 	opExportProgress.WithMapOfAnything(map[string]interface{}{"operationId": "exportProgressSpace"})
@@ -228,6 +409,29 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opExportProgress, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/export-progress", opExportProgress)
 
+	opExportDownload := openapi3.Operation{}
+	opExportDownload.WithTags("space")
+	opExportDownload.WithMapOfAnything(map[string]interface{}{"operationId": "exportDownloadSpace"})
+	_ = reflector.SetRequest(&opExportDownload, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&opExportDownload, http.StatusOK, "application/gzip")
+	_ = reflector.SetJSONResponse(&opExportDownload, nil, http.StatusTemporaryRedirect)
+	_ = reflector.SetJSONResponse(&opExportDownload, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opExportDownload, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opExportDownload, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opExportDownload, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/export/archive", opExportDownload)
+
+	opImportArchive := openapi3.Operation{}
+	opImportArchive.WithTags("space")
+	opImportArchive.WithMapOfAnything(map[string]interface{}{"operationId": "importArchiveSpace"})
+	_ = reflector.SetRequest(&opImportArchive, new(spaceRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opImportArchive, new(space.ImportArchiveOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opImportArchive, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opImportArchive, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opImportArchive, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opImportArchive, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/import-archive", opImportArchive)
+
 	opGet := openapi3.Operation{}
 	opGet.WithTags("space")
 	opGet.WithMapOfAnything(map[string]interface{}{"operationId": "getSpace"})
@@ -269,6 +473,7 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	opDelete := openapi3.Operation{}
 	opDelete.WithTags("space")
 	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteSpace"})
+	opDelete.WithParameters(queryParameterDryRun)
 	_ = reflector.SetRequest(&opDelete, new(spaceRequest), http.MethodDelete)
 	_ = reflector.SetJSONResponse(&opDelete, new(space.SoftDeleteResponse), http.StatusOK)
 	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
@@ -331,7 +536,8 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	opRepos.WithTags("space")
 	opRepos.WithMapOfAnything(map[string]interface{}{"operationId": "listRepos"})
 	opRepos.WithParameters(queryParameterQueryRepo, queryParameterSortRepo, queryParameterOrder,
-		QueryParameterPage, QueryParameterLimit, queryParameterRecursive)
+		QueryParameterPage, QueryParameterLimit, queryParameterRecursive,
+		queryParameterTopicRepo, queryParameterTopicMatchRepo, queryParameterIncludePinnedRepo)
 	_ = reflector.SetRequest(&opRepos, new(spaceRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&opRepos, []types.Repository{}, http.StatusOK)
 	_ = reflector.SetJSONResponse(&opRepos, new(usererror.Error), http.StatusInternalServerError)
@@ -340,6 +546,82 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opRepos, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/repos", opRepos)
 
+	opListTopics := openapi3.Operation{}
+	opListTopics.WithTags("space")
+	opListTopics.WithSummary("List the repository topics used in the space")
+	opListTopics.WithMapOfAnything(map[string]interface{}{"operationId": "listSpaceTopics"})
+	opListTopics.WithParameters(queryParameterQueryTopic, queryParameterRecursive)
+	_ = reflector.SetRequest(&opListTopics, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListTopics, []types.RepoTopicCount{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListTopics, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListTopics, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListTopics, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListTopics, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/topics", opListTopics)
+
+	opListPinnedRepos := openapi3.Operation{}
+	opListPinnedRepos.WithTags("space")
+	opListPinnedRepos.WithSummary("List the pinned repositories of the space")
+	opListPinnedRepos.WithMapOfAnything(map[string]interface{}{"operationId": "listPinnedRepos"})
+	_ = reflector.SetRequest(&opListPinnedRepos, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListPinnedRepos, []repo.RepositoryOutput{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListPinnedRepos, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListPinnedRepos, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListPinnedRepos, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListPinnedRepos, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/pinned-repos", opListPinnedRepos)
+
+	opPinRepo := openapi3.Operation{}
+	opPinRepo.WithTags("space")
+	opPinRepo.WithSummary("Pin a repository of the space")
+	opPinRepo.WithMapOfAnything(map[string]interface{}{"operationId": "pinRepo"})
+	_ = reflector.SetRequest(&opPinRepo, new(pinRepoRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opPinRepo, []repo.RepositoryOutput{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opPinRepo, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opPinRepo, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPinRepo, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPinRepo, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPinRepo, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/pinned-repos", opPinRepo)
+
+	opUnpinRepo := openapi3.Operation{}
+	opUnpinRepo.WithTags("space")
+	opUnpinRepo.WithSummary("Unpin a repository of the space")
+	opUnpinRepo.WithMapOfAnything(map[string]interface{}{"operationId": "unpinRepo"})
+	_ = reflector.SetRequest(&opUnpinRepo, new(unpinRepoRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opUnpinRepo, []repo.RepositoryOutput{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUnpinRepo, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUnpinRepo, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUnpinRepo, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUnpinRepo, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/spaces/{space_ref}/pinned-repos/{pinned_repo_id}", opUnpinRepo)
+
+	opReorderPinnedRepos := openapi3.Operation{}
+	opReorderPinnedRepos.WithTags("space")
+	opReorderPinnedRepos.WithSummary("Change the order of the pinned repositories of the space")
+	opReorderPinnedRepos.WithMapOfAnything(map[string]interface{}{"operationId": "reorderPinnedRepos"})
+	_ = reflector.SetRequest(&opReorderPinnedRepos, new(reorderPinnedReposRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opReorderPinnedRepos, []repo.RepositoryOutput{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opReorderPinnedRepos, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opReorderPinnedRepos, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opReorderPinnedRepos, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opReorderPinnedRepos, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opReorderPinnedRepos, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/spaces/{space_ref}/pinned-repos/order", opReorderPinnedRepos)
+
+	opRenameTopic := openapi3.Operation{}
+	opRenameTopic.WithTags("space")
+	opRenameTopic.WithSummary("Rename a repository topic in the space and its subspaces")
+	opRenameTopic.WithMapOfAnything(map[string]interface{}{"operationId": "renameSpaceTopic"})
+	_ = reflector.SetRequest(&opRenameTopic, new(renameSpaceTopicRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRenameTopic, new(space.TopicRenameOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRenameTopic, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRenameTopic, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRenameTopic, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRenameTopic, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRenameTopic, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/topics/rename", opRenameTopic)
+
 	opTemplates := openapi3.Operation{}
 	opTemplates.WithTags("space")
 	opTemplates.WithMapOfAnything(map[string]interface{}{"operationId": "listTemplates"})
@@ -379,6 +661,8 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	opServiceAccounts := openapi3.Operation{}
 	opServiceAccounts.WithTags("space")
 	opServiceAccounts.WithMapOfAnything(map[string]interface{}{"operationId": "listServiceAccounts"})
+	opServiceAccounts.WithParameters(queryParameterQueryServiceAccount,
+		queryParameterSortServiceAccount, queryParameterOrder)
 	_ = reflector.SetRequest(&opServiceAccounts, new(spaceRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&opServiceAccounts, []types.ServiceAccount{}, http.StatusOK)
 	_ = reflector.SetJSONResponse(&opServiceAccounts, new(usererror.Error), http.StatusInternalServerError)
@@ -498,11 +782,13 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	opDeleteLabel.WithTags("space")
 	opDeleteLabel.WithMapOfAnything(
 		map[string]interface{}{"operationId": "deleteSpaceLabel"})
+	opDeleteLabel.WithParameters(queryParameterDryRun)
 	_ = reflector.SetRequest(&opDeleteLabel, &struct {
 		spaceRequest
 		Key string `path:"key"`
 	}{}, http.MethodDelete)
 	_ = reflector.SetJSONResponse(&opDeleteLabel, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteLabel, new(types.DryRunOutput), http.StatusOK)
 	_ = reflector.SetJSONResponse(&opDeleteLabel, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.SetJSONResponse(&opDeleteLabel, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opDeleteLabel, new(usererror.Error), http.StatusUnauthorized)
@@ -620,4 +906,64 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&listPullReq, new(usererror.Error), http.StatusUnauthorized)
 	_ = reflector.SetJSONResponse(&listPullReq, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{repo_ref}/pullreq", listPullReq)
+
+	opEvents := openapi3.Operation{}
+	opEvents.WithTags("space")
+	opEvents.WithSummary("Stream live updates of resources in the space")
+	opEvents.WithMapOfAnything(map[string]interface{}{"operationId": "streamSpaceEvents"})
+	_ = reflector.SetRequest(&opEvents, new(spaceEventsRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&opEvents, http.StatusOK, "text/event-stream")
+	_ = reflector.SetJSONResponse(&opEvents, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opEvents, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opEvents, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opEvents, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opEvents, new(usererror.Error), http.StatusTooManyRequests)
+	_ = reflector.SetJSONResponse(&opEvents, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/events", opEvents)
+
+	opUsage := openapi3.Operation{}
+	opUsage.WithTags("space")
+	opUsage.WithSummary("Daily CI minutes and storage usage of the child spaces and repositories")
+	opUsage.WithMapOfAnything(map[string]interface{}{"operationId": "getSpaceUsage"})
+	_ = reflector.SetRequest(&opUsage, new(spaceUsageRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opUsage, new(types.UsageReport), http.StatusOK)
+	_ = reflector.SetStringResponse(&opUsage, http.StatusOK, "text/csv")
+	_ = reflector.SetJSONResponse(&opUsage, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUsage, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUsage, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUsage, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opUsage, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/usage", opUsage)
+
+	opCommitMessagePolicyUpdate := openapi3.Operation{}
+	opCommitMessagePolicyUpdate.WithTags("space")
+	opCommitMessagePolicyUpdate.WithSummary("Set the commit message policy of the space")
+	opCommitMessagePolicyUpdate.WithMapOfAnything(
+		map[string]interface{}{"operationId": "updateSpaceCommitMessagePolicy"})
+	_ = reflector.SetRequest(
+		&opCommitMessagePolicyUpdate, new(spaceCommitMessagePolicyRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(
+		&opCommitMessagePolicyUpdate, new(types.CommitMessagePolicyOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCommitMessagePolicyUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCommitMessagePolicyUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCommitMessagePolicyUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCommitMessagePolicyUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opCommitMessagePolicyUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(
+		http.MethodPut, "/spaces/{space_ref}/settings/commit-message-policy", opCommitMessagePolicyUpdate)
+
+	opCommitMessagePolicyFind := openapi3.Operation{}
+	opCommitMessagePolicyFind.WithTags("space")
+	opCommitMessagePolicyFind.WithSummary("Get the commit message policy in effect for the space")
+	opCommitMessagePolicyFind.WithMapOfAnything(
+		map[string]interface{}{"operationId": "findSpaceCommitMessagePolicy"})
+	_ = reflector.SetRequest(&opCommitMessagePolicyFind, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(
+		&opCommitMessagePolicyFind, new(types.CommitMessagePolicyOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCommitMessagePolicyFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCommitMessagePolicyFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCommitMessagePolicyFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opCommitMessagePolicyFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(
+		http.MethodGet, "/spaces/{space_ref}/settings/commit-message-policy", opCommitMessagePolicyFind)
 }
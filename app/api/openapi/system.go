@@ -17,12 +17,54 @@ package openapi
 import (
 	"net/http"
 
+	controllersystem "github.com/harness/gitness/app/api/controller/system"
 	"github.com/harness/gitness/app/api/handler/system"
 	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
 
 	"github.com/swaggest/openapi-go/openapi3"
 )
 
+type (
+	instanceSettingRequest struct {
+		Key string `path:"instance_setting_key"`
+	}
+
+	instanceSettingUpdateRequest struct {
+		instanceSettingRequest
+		controllersystem.UpdateSettingInput
+	}
+
+	featureFlagRequest struct {
+		Name string `path:"feature_flag"`
+	}
+
+	featureFlagUpdateRequest struct {
+		featureFlagRequest
+		controllersystem.UpdateFeatureFlagInput
+	}
+
+	featureFlagSpaceRequest struct {
+		featureFlagRequest
+		SpaceRef string `path:"space_ref"`
+	}
+
+	featureFlagSpaceUpdateRequest struct {
+		featureFlagSpaceRequest
+		controllersystem.UpdateFeatureFlagInput
+	}
+
+	gitOperationLimitRequest struct {
+		PrincipalID int64 `path:"principal_id"`
+	}
+
+	gitOperationLimitUpdateRequest struct {
+		gitOperationLimitRequest
+		controllersystem.UpdateGitOperationLimitInput
+	}
+)
+
 // helper function that constructs the openapi specification
 // for the system registration config endpoints.
 func buildSystem(reflector *openapi3.Reflector) {
@@ -34,4 +76,235 @@ func buildSystem(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opGetConfig, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opGetConfig, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/system/config", opGetConfig)
+
+	opHealth := openapi3.Operation{}
+	opHealth.WithTags("system")
+	opHealth.WithMapOfAnything(map[string]interface{}{"operationId": "getSystemHealth"})
+	_ = reflector.SetRequest(&opHealth, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opHealth, nil, http.StatusOK)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/system/health", opHealth)
+
+	opVersion := openapi3.Operation{}
+	opVersion.WithTags("system")
+	opVersion.WithMapOfAnything(map[string]interface{}{"operationId": "getSystemVersion"})
+	_ = reflector.SetRequest(&opVersion, nil, http.MethodGet)
+	_ = reflector.SetStringResponse(&opVersion, http.StatusOK, "text/plain")
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/system/version", opVersion)
+}
+
+// helper function that constructs the openapi specification
+// for the instance settings admin endpoints.
+func buildInstanceSettings(reflector *openapi3.Reflector) {
+	opList := openapi3.Operation{}
+	opList.WithTags("admin")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "adminListInstanceSettings"})
+	_ = reflector.SetRequest(&opList, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, new([]types.InstanceSetting), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/settings", opList)
+
+	opFind := openapi3.Operation{}
+	opFind.WithTags("admin")
+	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "adminFindInstanceSetting"})
+	_ = reflector.SetRequest(&opFind, new(instanceSettingRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(types.InstanceSetting), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/settings/{instance_setting_key}", opFind)
+
+	opUpdate := openapi3.Operation{}
+	opUpdate.WithTags("admin")
+	opUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "adminUpdateInstanceSetting"})
+	_ = reflector.SetRequest(&opUpdate, new(instanceSettingUpdateRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opUpdate, new(types.InstanceSetting), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/admin/settings/{instance_setting_key}", opUpdate)
+
+	opReset := openapi3.Operation{}
+	opReset.WithTags("admin")
+	opReset.WithMapOfAnything(map[string]interface{}{"operationId": "adminResetInstanceSetting"})
+	_ = reflector.SetRequest(&opReset, new(instanceSettingRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opReset, new(types.InstanceSetting), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opReset, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opReset, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opReset, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/admin/settings/{instance_setting_key}", opReset)
+}
+
+// helper function that constructs the openapi specification
+// for the feature flag admin endpoints.
+func buildFeatureFlags(reflector *openapi3.Reflector) {
+	opList := openapi3.Operation{}
+	opList.WithTags("admin")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "adminListFeatureFlags"})
+	_ = reflector.SetRequest(&opList, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, new([]types.FeatureFlag), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/feature-flags", opList)
+
+	opFind := openapi3.Operation{}
+	opFind.WithTags("admin")
+	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "adminFindFeatureFlag"})
+	_ = reflector.SetRequest(&opFind, new(featureFlagRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(types.FeatureFlag), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/feature-flags/{feature_flag}", opFind)
+
+	opUpdate := openapi3.Operation{}
+	opUpdate.WithTags("admin")
+	opUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "adminUpdateFeatureFlag"})
+	_ = reflector.SetRequest(&opUpdate, new(featureFlagUpdateRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opUpdate, new(types.FeatureFlag), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/admin/feature-flags/{feature_flag}", opUpdate)
+
+	opReset := openapi3.Operation{}
+	opReset.WithTags("admin")
+	opReset.WithMapOfAnything(map[string]interface{}{"operationId": "adminResetFeatureFlag"})
+	_ = reflector.SetRequest(&opReset, new(featureFlagRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opReset, new(types.FeatureFlag), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opReset, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opReset, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opReset, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/admin/feature-flags/{feature_flag}", opReset)
+
+	opUpdateSpace := openapi3.Operation{}
+	opUpdateSpace.WithTags("admin")
+	opUpdateSpace.WithMapOfAnything(map[string]interface{}{"operationId": "adminUpdateSpaceFeatureFlag"})
+	_ = reflector.SetRequest(&opUpdateSpace, new(featureFlagSpaceUpdateRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opUpdateSpace, new(types.FeatureFlag), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateSpace, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateSpace, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdateSpace, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opUpdateSpace, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/admin/feature-flags/{feature_flag}/spaces/{space_ref}", opUpdateSpace)
+
+	opResetSpace := openapi3.Operation{}
+	opResetSpace.WithTags("admin")
+	opResetSpace.WithMapOfAnything(map[string]interface{}{"operationId": "adminResetSpaceFeatureFlag"})
+	_ = reflector.SetRequest(&opResetSpace, new(featureFlagSpaceRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opResetSpace, new(types.FeatureFlag), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opResetSpace, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opResetSpace, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opResetSpace, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opResetSpace, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/admin/feature-flags/{feature_flag}/spaces/{space_ref}", opResetSpace)
+}
+
+// helper function that constructs the openapi specification
+// for the git operation admin endpoints.
+func buildGitOperations(reflector *openapi3.Reflector) {
+	opList := openapi3.Operation{}
+	opList.WithTags("admin")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "adminListGitOperations"})
+	_ = reflector.SetRequest(&opList, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, new([]types.GitOperationsInFlight), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/git-operations", opList)
+
+	opListLimits := openapi3.Operation{}
+	opListLimits.WithTags("admin")
+	opListLimits.WithMapOfAnything(map[string]interface{}{"operationId": "adminListGitOperationLimits"})
+	_ = reflector.SetRequest(&opListLimits, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListLimits, new([]types.GitOperationLimit), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListLimits, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListLimits, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/git-operations/limits", opListLimits)
+
+	opUpdateLimit := openapi3.Operation{}
+	opUpdateLimit.WithTags("admin")
+	opUpdateLimit.WithMapOfAnything(map[string]interface{}{"operationId": "adminUpdateGitOperationLimit"})
+	_ = reflector.SetRequest(&opUpdateLimit, new(gitOperationLimitUpdateRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opUpdateLimit, new(types.GitOperationLimit), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateLimit, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateLimit, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdateLimit, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opUpdateLimit, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/admin/git-operations/limits/{principal_id}", opUpdateLimit)
+
+	opResetLimit := openapi3.Operation{}
+	opResetLimit.WithTags("admin")
+	opResetLimit.WithMapOfAnything(map[string]interface{}{"operationId": "adminResetGitOperationLimit"})
+	_ = reflector.SetRequest(&opResetLimit, new(gitOperationLimitRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opResetLimit, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opResetLimit, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opResetLimit, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opResetLimit, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/admin/git-operations/limits/{principal_id}", opResetLimit)
+}
+
+// helper function that constructs the openapi specification
+// for the database migration status admin endpoint.
+func buildMigrationStatus(reflector *openapi3.Reflector) {
+	opStatus := openapi3.Operation{}
+	opStatus.WithTags("admin")
+	opStatus.WithMapOfAnything(map[string]interface{}{"operationId": "adminMigrationStatus"})
+	_ = reflector.SetRequest(&opStatus, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opStatus, new(types.MigrationStatus), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opStatus, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opStatus, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/migrations", opStatus)
+}
+
+// helper function that constructs the openapi specification
+// for the system info admin endpoint.
+func buildSystemInfo(reflector *openapi3.Reflector) {
+	opInfo := openapi3.Operation{}
+	opInfo.WithTags("admin")
+	opInfo.WithMapOfAnything(map[string]interface{}{"operationId": "adminSystemInfo"})
+	_ = reflector.SetRequest(&opInfo, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opInfo, new(types.SystemInfo), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opInfo, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opInfo, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/system/info", opInfo)
+}
+
+// helper function that constructs the openapi specification
+// for the prometheus metrics admin endpoint.
+func buildAdminMetrics(reflector *openapi3.Reflector) {
+	opMetrics := openapi3.Operation{}
+	opMetrics.WithTags("admin")
+	opMetrics.WithMapOfAnything(map[string]interface{}{"operationId": "adminMetrics"})
+	_ = reflector.SetRequest(&opMetrics, nil, http.MethodGet)
+	_ = reflector.SetStringResponse(&opMetrics, http.StatusOK, "text/plain")
+	_ = reflector.SetJSONResponse(&opMetrics, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/metrics", opMetrics)
+}
+
+// helper function that constructs the openapi specification
+// for the counter reconciliation admin endpoints.
+func buildReconcileCounters(reflector *openapi3.Reflector) {
+	opReconcile := openapi3.Operation{}
+	opReconcile.WithTags("admin")
+	opReconcile.WithMapOfAnything(map[string]interface{}{"operationId": "adminReconcileCounters"})
+	_ = reflector.SetRequest(&opReconcile, new(controllersystem.ReconcileCountersInput), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opReconcile, nil, http.StatusAccepted)
+	_ = reflector.SetJSONResponse(&opReconcile, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opReconcile, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opReconcile, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opReconcile, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/counters/reconcile", opReconcile)
+
+	opProgress := openapi3.Operation{}
+	opProgress.WithTags("admin")
+	opProgress.WithMapOfAnything(map[string]interface{}{"operationId": "adminReconcileCountersProgress"})
+	_ = reflector.SetRequest(&opProgress, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opProgress, new(job.Progress), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opProgress, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opProgress, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opProgress, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/counters/reconcile", opProgress)
 }
@@ -29,7 +29,8 @@ type createTemplateRequest struct {
 }
 
 type templateRequest struct {
-	Ref string `path:"template_ref"`
+	Type string `path:"template_type"`
+	Ref  string `path:"template_ref"`
 }
 
 type getTemplateRequest struct {
@@ -62,7 +63,7 @@ func templateOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusUnauthorized)
 	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
-	_ = reflector.Spec.AddOperation(http.MethodGet, "/templates/{template_ref}", opFind)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/templates/{template_type}/{template_ref}", opFind)
 
 	opDelete := openapi3.Operation{}
 	opDelete.WithTags("template")
@@ -73,7 +74,7 @@ func templateOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusUnauthorized)
 	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
-	_ = reflector.Spec.AddOperation(http.MethodDelete, "/templates/{template_ref}", opDelete)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/templates/{template_type}/{template_ref}", opDelete)
 
 	opUpdate := openapi3.Operation{}
 	opUpdate.WithTags("template")
@@ -85,5 +86,5 @@ func templateOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusUnauthorized)
 	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusNotFound)
-	_ = reflector.Spec.AddOperation(http.MethodPatch, "/templates/{template_ref}", opUpdate)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/templates/{template_type}/{template_ref}", opUpdate)
 }
@@ -17,6 +17,8 @@ package openapi
 import (
 	"net/http"
 
+	"github.com/harness/gitness/app/api/controller/digest"
+	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/controller/user"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/api/usererror"
@@ -61,6 +63,21 @@ var queryParameterSortMembershipSpaces = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterRecentVisitType = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamType,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The type of the recently visited resources to list."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+				Enum: enum.RecentVisitType("").Enum(),
+			},
+		},
+	},
+}
+
 var queryParameterQueryPublicKey = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamQuery,
@@ -110,13 +127,80 @@ func buildUser(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.Spec.AddOperation(http.MethodPatch, "/user", opUpdate)
 
+	opRequestEmailChange := openapi3.Operation{}
+	opRequestEmailChange.WithTags("user")
+	opRequestEmailChange.WithMapOfAnything(map[string]interface{}{"operationId": "requestEmailChange"})
+	_ = reflector.SetRequest(&opRequestEmailChange, new(user.EmailChangeInput), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opRequestEmailChange, new(user.EmailChangeOutput), http.StatusAccepted)
+	_ = reflector.SetJSONResponse(&opRequestEmailChange, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRequestEmailChange, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opRequestEmailChange, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/user/email", opRequestEmailChange)
+
+	opConfirmEmailChange := openapi3.Operation{}
+	opConfirmEmailChange.WithTags("user")
+	opConfirmEmailChange.WithMapOfAnything(map[string]interface{}{"operationId": "confirmEmailChange"})
+	_ = reflector.SetRequest(&opConfirmEmailChange, new(user.ConfirmEmailChangeInput), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opConfirmEmailChange, new(types.User), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opConfirmEmailChange, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opConfirmEmailChange, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opConfirmEmailChange, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/email/confirm", opConfirmEmailChange)
+
 	opToken := openapi3.Operation{}
 	opToken.WithTags("user")
 	opToken.WithMapOfAnything(map[string]interface{}{"operationId": "createToken"})
+	opToken.WithParameters(headerParameterIdempotencyKey)
 	_ = reflector.SetRequest(&opToken, new(createTokenRequest), http.MethodPost)
 	_ = reflector.SetJSONResponse(&opToken, new(types.TokenResponse), http.StatusCreated)
 	_ = reflector.SetJSONResponse(&opToken, new(usererror.Error), http.StatusInternalServerError)
-	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/token", opToken)
+	_ = reflector.SetJSONResponse(&opToken, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opToken, new(usererror.Error), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/tokens", opToken)
+
+	opListTokens := openapi3.Operation{}
+	opListTokens.WithTags("user")
+	opListTokens.WithMapOfAnything(map[string]interface{}{"operationId": "listTokens"})
+	_ = reflector.SetRequest(&opListTokens, struct{}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListTokens, new([]types.Token), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListTokens, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/tokens", opListTokens)
+
+	opDeleteToken := openapi3.Operation{}
+	opDeleteToken.WithTags("user")
+	opDeleteToken.WithMapOfAnything(map[string]interface{}{"operationId": "deleteToken"})
+	opDeleteToken.WithParameters(pathParameter(request.PathParamTokenIdentifier, "The identifier of the token."))
+	_ = reflector.SetRequest(&opDeleteToken, struct{}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteToken, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteToken, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeleteToken, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/tokens/{token_identifier}", opDeleteToken)
+
+	opListSessions := openapi3.Operation{}
+	opListSessions.WithTags("user")
+	opListSessions.WithMapOfAnything(map[string]interface{}{"operationId": "listSessions"})
+	_ = reflector.SetRequest(&opListSessions, struct{}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListSessions, new([]types.UserSession), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListSessions, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/sessions", opListSessions)
+
+	opRevokeOtherSessions := openapi3.Operation{}
+	opRevokeOtherSessions.WithTags("user")
+	opRevokeOtherSessions.WithMapOfAnything(map[string]interface{}{"operationId": "revokeOtherSessions"})
+	_ = reflector.SetRequest(&opRevokeOtherSessions, struct{}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opRevokeOtherSessions, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opRevokeOtherSessions, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/sessions", opRevokeOtherSessions)
+
+	opDeleteSession := openapi3.Operation{}
+	opDeleteSession.WithTags("user")
+	opDeleteSession.WithMapOfAnything(map[string]interface{}{"operationId": "deleteSession"})
+	opDeleteSession.WithParameters(pathParameter(request.PathParamTokenIdentifier, "The identifier of the session."))
+	_ = reflector.SetRequest(&opDeleteSession, struct{}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteSession, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteSession, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeleteSession, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/sessions/{token_identifier}", opDeleteSession)
 
 	opMemberSpaces := openapi3.Operation{}
 	opMemberSpaces.WithTags("user")
@@ -160,4 +244,106 @@ func buildUser(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opKeyList, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.SetJSONResponse(&opKeyList, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/keys", opKeyList)
+
+	opDigestFind := openapi3.Operation{}
+	opDigestFind.WithTags("user")
+	opDigestFind.WithMapOfAnything(map[string]interface{}{"operationId": "getDigestSubscription"})
+	_ = reflector.SetRequest(&opDigestFind, struct{}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opDigestFind, new(types.DigestSubscription), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opDigestFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opDigestFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/digest", opDigestFind)
+
+	opDigestUpdate := openapi3.Operation{}
+	opDigestUpdate.WithTags("user")
+	opDigestUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateDigestSubscription"})
+	_ = reflector.SetRequest(&opDigestUpdate, new(digest.UpdateInput), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opDigestUpdate, new(types.DigestSubscription), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opDigestUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opDigestUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDigestUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opDigestUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/user/digest", opDigestUpdate)
+
+	opDigestDelete := openapi3.Operation{}
+	opDigestDelete.WithTags("user")
+	opDigestDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteDigestSubscription"})
+	_ = reflector.SetRequest(&opDigestDelete, struct{}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDigestDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDigestDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/digest", opDigestDelete)
+
+	type avatarRequest struct {
+		Content string `json:"-" format:"binary" description:"PNG or JPEG image to upload"`
+	}
+
+	opUpdateAvatar := openapi3.Operation{}
+	opUpdateAvatar.WithTags("user")
+	opUpdateAvatar.WithMapOfAnything(map[string]interface{}{"operationId": "updateUserAvatar"})
+	_ = reflector.SetRequest(&opUpdateAvatar, new(avatarRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opUpdateAvatar, new(types.Avatar), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateAvatar, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateAvatar, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/user/avatar", opUpdateAvatar)
+
+	opDeleteAvatar := openapi3.Operation{}
+	opDeleteAvatar.WithTags("user")
+	opDeleteAvatar.WithMapOfAnything(map[string]interface{}{"operationId": "deleteUserAvatar"})
+	_ = reflector.SetRequest(&opDeleteAvatar, struct{}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteAvatar, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteAvatar, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/avatar", opDeleteAvatar)
+
+	opDigestPreview := openapi3.Operation{}
+	opDigestPreview.WithTags("user")
+	opDigestPreview.WithMapOfAnything(map[string]interface{}{"operationId": "previewDigest"})
+	_ = reflector.SetRequest(&opDigestPreview, struct{}{}, http.MethodGet)
+	_ = reflector.SetStringResponse(&opDigestPreview, http.StatusOK, "text/html")
+	_ = reflector.SetJSONResponse(&opDigestPreview, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opDigestPreview, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/digest/preview", opDigestPreview)
+
+	type preferenceRequest struct {
+		View enum.UserPreferenceView `path:"preference_view"`
+	}
+
+	opPreferenceFind := openapi3.Operation{}
+	opPreferenceFind.WithTags("user")
+	opPreferenceFind.WithMapOfAnything(map[string]interface{}{"operationId": "getUserPreference"})
+	_ = reflector.SetRequest(&opPreferenceFind, new(preferenceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opPreferenceFind, new(types.UserPreference), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opPreferenceFind, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opPreferenceFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/preferences/{preference_view}", opPreferenceFind)
+
+	opPreferenceUpdate := openapi3.Operation{}
+	opPreferenceUpdate.WithTags("user")
+	opPreferenceUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateUserPreference"})
+	_ = reflector.SetRequest(&opPreferenceUpdate, struct {
+		preferenceRequest
+		user.UpdatePreferenceInput
+	}{}, http.MethodPut)
+	_ = reflector.SetJSONResponse(&opPreferenceUpdate, new(types.UserPreference), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opPreferenceUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opPreferenceUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/user/preferences/{preference_view}", opPreferenceUpdate)
+
+	opListRecent := openapi3.Operation{}
+	opListRecent.WithTags("user")
+	opListRecent.WithMapOfAnything(map[string]interface{}{"operationId": "listRecentVisits"})
+	opListRecent.WithParameters(queryParameterRecentVisitType)
+	_ = reflector.SetRequest(&opListRecent, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListRecent, new([]types.RecentVisitOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListRecent, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opListRecent, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/recent", opListRecent)
+
+	opListStarred := openapi3.Operation{}
+	opListStarred.WithTags("user")
+	opListStarred.WithMapOfAnything(map[string]interface{}{"operationId": "listStarredRepos"})
+	opListStarred.WithParameters(QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opListStarred, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListStarred, new([]repo.RepositoryOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListStarred, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/starred", opListStarred)
 }
@@ -55,6 +55,12 @@ type (
 		adminUsersRequest
 		user.UpdateAdminInput
 	}
+
+	// updateBlockedRequest is the request for blocking or unblocking the user.
+	updateBlockedRequest struct {
+		adminUsersRequest
+		user.UpdateBlockedInput
+	}
 )
 
 // helper function that constructs the openapi specification
@@ -109,6 +115,25 @@ func buildAdmin(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opUpdateAdmin, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.Spec.AddOperation(http.MethodPatch, "/admin/users/{user_uid}/admin", opUpdateAdmin)
 
+	opUpdateBlocked := openapi3.Operation{}
+	opUpdateBlocked.WithTags("admin")
+	opUpdateBlocked.WithMapOfAnything(map[string]interface{}{"operationId": "updateUserBlocked"})
+	_ = reflector.SetRequest(&opUpdateBlocked, new(updateBlockedRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdateBlocked, new(types.User), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateBlocked, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateBlocked, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opUpdateBlocked, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/admin/users/{user_uid}/blocked", opUpdateBlocked)
+
+	opPasswordReset := openapi3.Operation{}
+	opPasswordReset.WithTags("admin")
+	opPasswordReset.WithMapOfAnything(map[string]interface{}{"operationId": "adminInitiatePasswordReset"})
+	_ = reflector.SetRequest(&opPasswordReset, new(adminUsersRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opPasswordReset, new(user.PasswordResetOutput), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opPasswordReset, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opPasswordReset, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/users/{user_uid}/password-reset", opPasswordReset)
+
 	opDelete := openapi3.Operation{}
 	opDelete.WithTags("admin")
 	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "adminDeleteUser"})
@@ -117,4 +142,13 @@ func buildAdmin(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodDelete, "/admin/users/{user_uid}", opDelete)
+
+	opClearLoginLockout := openapi3.Operation{}
+	opClearLoginLockout.WithTags("admin")
+	opClearLoginLockout.WithMapOfAnything(map[string]interface{}{"operationId": "adminClearUserLoginLockout"})
+	_ = reflector.SetRequest(&opClearLoginLockout, new(adminUsersRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opClearLoginLockout, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opClearLoginLockout, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opClearLoginLockout, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/admin/users/{user_uid}/login-lockout", opClearLoginLockout)
 }
@@ -111,17 +111,40 @@ var queryParameterQueryWebhook = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterTriggerWebhookExecution = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamTrigger,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The trigger types of the webhook executions to include in the result."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+						Enum: enum.WebhookTrigger("").Enum(),
+					},
+				},
+			},
+		},
+	},
+}
+
 //nolint:funlen
 func webhookOperations(reflector *openapi3.Reflector) {
 	createWebhook := openapi3.Operation{}
 	createWebhook.WithTags("webhook")
 	createWebhook.WithMapOfAnything(map[string]interface{}{"operationId": "createWebhook"})
+	createWebhook.WithParameters(headerParameterIdempotencyKey)
 	_ = reflector.SetRequest(&createWebhook, new(createWebhookRequest), http.MethodPost)
 	_ = reflector.SetJSONResponse(&createWebhook, new(webhookType), http.StatusCreated)
 	_ = reflector.SetJSONResponse(&createWebhook, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.SetJSONResponse(&createWebhook, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&createWebhook, new(usererror.Error), http.StatusUnauthorized)
 	_ = reflector.SetJSONResponse(&createWebhook, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&createWebhook, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&createWebhook, new(usererror.Error), http.StatusUnprocessableEntity)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/webhooks", createWebhook)
 
 	listWebhooks := openapi3.Operation{}
@@ -173,7 +196,7 @@ func webhookOperations(reflector *openapi3.Reflector) {
 	listWebhookExecutions := openapi3.Operation{}
 	listWebhookExecutions.WithTags("webhook")
 	listWebhookExecutions.WithMapOfAnything(map[string]interface{}{"operationId": "listWebhookExecutions"})
-	listWebhookExecutions.WithParameters(QueryParameterPage, QueryParameterLimit)
+	listWebhookExecutions.WithParameters(queryParameterTriggerWebhookExecution, QueryParameterPage, QueryParameterLimit)
 	_ = reflector.SetRequest(&listWebhookExecutions, new(listWebhookExecutionsRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&listWebhookExecutions, new([]types.WebhookExecution), http.StatusOK)
 	_ = reflector.SetJSONResponse(&listWebhookExecutions, new(usererror.Error), http.StatusBadRequest)
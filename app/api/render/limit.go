@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"io"
+)
+
+// LimitWriter caps the number of bytes streamed to the underlying writer.
+// Once the limit is exceeded, the trailer is written instead of the remaining data,
+// cancel is called to stop the producer of the data and all further writes are discarded.
+type LimitWriter struct {
+	w        io.Writer
+	limit    int64
+	trailer  string
+	cancel   func()
+	written  int64
+	exceeded bool
+}
+
+// NewLimitWriter returns a new LimitWriter. A limit of zero or less disables the limit.
+func NewLimitWriter(w io.Writer, limit int64, trailer string, cancel func()) *LimitWriter {
+	return &LimitWriter{
+		w:       w,
+		limit:   limit,
+		trailer: trailer,
+		cancel:  cancel,
+	}
+}
+
+func (l *LimitWriter) Write(p []byte) (int, error) {
+	if l.exceeded {
+		return len(p), nil
+	}
+
+	if l.limit <= 0 || l.written+int64(len(p)) <= l.limit {
+		n, err := l.w.Write(p)
+		l.written += int64(n)
+		return n, err
+	}
+
+	n, err := l.w.Write(p[:l.limit-l.written])
+	l.written += int64(n)
+	if err != nil {
+		return n, err
+	}
+
+	l.exceeded = true
+	l.cancel()
+
+	if _, err = io.WriteString(l.w, l.trailer); err != nil {
+		return n, err
+	}
+
+	return len(p), nil
+}
+
+// Written returns the number of bytes of the data written to the underlying writer, excluding the trailer.
+func (l *LimitWriter) Written() int64 {
+	return l.written
+}
+
+// Exceeded returns true if the data was truncated because it exceeded the limit.
+func (l *LimitWriter) Exceeded() bool {
+	return l.exceeded
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestLimitWriter(t *testing.T) {
+	tests := []struct {
+		name         string
+		limit        int64
+		writes       []string
+		wantOutput   string
+		wantExceeded bool
+	}{
+		{name: "unlimited", limit: 0, writes: []string{"abc", "def"}, wantOutput: "abcdef"},
+		{name: "within limit", limit: 6, writes: []string{"abc", "def"}, wantOutput: "abcdef"},
+		{name: "exceeded", limit: 4, writes: []string{"abc", "def", "ghi"}, wantOutput: "abcd[cut]", wantExceeded: true},
+		{name: "exceeded at boundary", limit: 3, writes: []string{"abc", "def"}, wantOutput: "abc[cut]", wantExceeded: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			canceled := 0
+			w := NewLimitWriter(buf, test.limit, "[cut]", func() { canceled++ })
+
+			for _, data := range test.writes {
+				n, err := io.WriteString(w, data)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if n != len(data) {
+					t.Fatalf("expected %d bytes to be written, got %d", len(data), n)
+				}
+			}
+
+			if got := buf.String(); got != test.wantOutput {
+				t.Errorf("expected output %q, got %q", test.wantOutput, got)
+			}
+			if w.Exceeded() != test.wantExceeded {
+				t.Errorf("expected exceeded=%t, got %t", test.wantExceeded, w.Exceeded())
+			}
+			if wantCanceled := map[bool]int{true: 1}[test.wantExceeded]; canceled != wantCanceled {
+				t.Errorf("expected cancel to be called %d times, got %d", wantCanceled, canceled)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/harness/gitness/app/config"
+)
+
+// OperationAccepted writes the response of a request that started a long-running operation.
+// The Location header points to the endpoint that reports the status of the operation.
+func OperationAccepted(w http.ResponseWriter, operationID string, v any) {
+	w.Header().Set("Location", OperationLocation(operationID))
+	JSON(w, http.StatusAccepted, v)
+}
+
+// OperationLocation returns the path of the endpoint that reports the status of the operation.
+func OperationLocation(operationID string) string {
+	return config.APIURL + "/operations/" + url.PathEscape(operationID)
+}
@@ -0,0 +1,35 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOperationAccepted(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	OperationAccepted(w, "abc123", map[string]string{"operation_id": "abc123"})
+
+	if got, want := w.Code, http.StatusAccepted; got != want {
+		t.Errorf("Want response code %d, got %d", want, got)
+	}
+
+	if got, want := w.Header().Get("Location"), "/api/v1/operations/abc123"; got != want {
+		t.Errorf("Want Location header %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+// PatchHeaders sets the headers of a downloadable patch file.
+// Immutable patches (addressed by a full commit SHA) can be cached indefinitely.
+func PatchHeaders(w http.ResponseWriter, filename string, immutable bool) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", filename))
+	if immutable {
+		w.Header().Set("Cache-Control", "private, max-age=31536000, immutable")
+	}
+}
+
+// Patch streams the patch produced by write to the response. Patches larger than maxSize bytes
+// are truncated and end with a trailer explaining the truncation. Errors are rendered as long
+// as no data has been written yet.
+func Patch(
+	ctx context.Context,
+	w http.ResponseWriter,
+	maxSize int64,
+	write func(ctx context.Context, w io.Writer) error,
+) {
+	writeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	trailer := fmt.Sprintf("\n[truncated: the patch exceeds the maximum size of %d bytes]\n", maxSize)
+	lw := NewLimitWriter(w, maxSize, trailer, cancel)
+
+	err := write(writeCtx, lw)
+	switch {
+	case err == nil, lw.Exceeded():
+		return
+	case lw.Written() == 0:
+		w.Header().Del("Content-Disposition")
+		w.Header().Del("Cache-Control")
+		TranslatedUserError(ctx, w, err)
+	default:
+		// the patch is partially streamed already, it's too late to report the error.
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to stream patch")
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/harness/gitness/app/api/controller/limiter"
+)
+
+const (
+	HeaderQuotaReposRemaining   = "X-Quota-Repos-Remaining"
+	HeaderQuotaStorageRemaining = "X-Quota-Storage-Remaining"
+)
+
+// QuotaWarnings sets the remaining quota headers for the quotas close to their limits.
+// It has to be called before the response body is written.
+func QuotaWarnings(w http.ResponseWriter, warnings *limiter.QuotaWarnings) {
+	headers := map[limiter.QuotaResource]string{
+		limiter.QuotaResourceRepos:   HeaderQuotaReposRemaining,
+		limiter.QuotaResourceStorage: HeaderQuotaStorageRemaining,
+	}
+
+	for resource, header := range headers {
+		if warning, ok := warnings.Get(resource); ok {
+			w.Header().Set(header, strconv.FormatInt(warning.Remaining(), 10))
+		}
+	}
+}
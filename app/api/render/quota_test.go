@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/harness/gitness/app/api/controller/limiter"
+)
+
+type storageQuotaLimiter struct {
+	limiter.Unlimited
+}
+
+func (storageQuotaLimiter) QuotaUsage(context.Context, int64) ([]limiter.QuotaUsage, error) {
+	return []limiter.QuotaUsage{
+		{Resource: limiter.QuotaResourceRepos, Limit: 10, Used: 1},
+		{Resource: limiter.QuotaResourceStorage, Limit: 1000, Used: 900},
+	}, nil
+}
+
+func TestQuotaWarnings(t *testing.T) {
+	ctx, warnings := limiter.WithQuotaWarnings(context.Background())
+	limiter.NewQuotaWarner(storageQuotaLimiter{}, 80).Record(ctx, 1)
+
+	w := httptest.NewRecorder()
+	QuotaWarnings(w, warnings)
+
+	if got := w.Header().Get(HeaderQuotaStorageRemaining); got != "100" {
+		t.Errorf("expected storage remaining 100, got %q", got)
+	}
+	if got := w.Header().Get(HeaderQuotaReposRemaining); got != "" {
+		t.Errorf("expected no repos remaining header, got %q", got)
+	}
+}
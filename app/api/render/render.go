@@ -16,12 +16,15 @@ package render
 
 import (
 	"context"
+	"encoding/csv"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"strconv"
 
+	"github.com/harness/gitness/app/api/middleware/timeout"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/types"
 
@@ -61,6 +64,22 @@ func Reader(ctx context.Context, w http.ResponseWriter, code int, reader io.Read
 	}
 }
 
+// CSV writes the csv-encoded records to the response with the provided status.
+// If a filename is provided, the response is marked as an attachment with that name.
+func CSV(ctx context.Context, w http.ResponseWriter, code int, filename string, records [][]string) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if filename != "" {
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	}
+	w.WriteHeader(code)
+
+	enc := csv.NewWriter(w)
+	if err := enc.WriteAll(records); err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to write csv encoding to response body")
+	}
+}
+
 // JSONArrayDynamic outputs an JSON array whose elements are streamed from a channel.
 // Due to the dynamic nature (unknown number of elements) the function will use
 // chunked transfer encoding for large files.
@@ -76,7 +95,7 @@ func JSONArrayDynamic[T comparable](ctx context.Context, w http.ResponseWriter,
 
 		if err != nil {
 			// User canceled the request - no need to do anything
-			if errors.Is(err, context.Canceled) {
+			if errors.Is(err, context.Canceled) && !timeout.Exceeded(ctx) {
 				return
 			}
 
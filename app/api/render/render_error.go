@@ -16,7 +16,9 @@ package render
 
 import (
 	"context"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/harness/gitness/app/api/usererror"
 
@@ -67,5 +69,9 @@ func InternalError(ctx context.Context, w http.ResponseWriter) {
 func UserError(ctx context.Context, w http.ResponseWriter, err *usererror.Error) {
 	log.Ctx(ctx).Debug().Err(err).Msgf("operation resulted in user facing error")
 
+	if err.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.FormatInt(int64(math.Ceil(err.RetryAfter.Seconds())), 10))
+	}
+
 	JSON(w, err.Status, err)
 }
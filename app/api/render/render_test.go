@@ -21,6 +21,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/git"
@@ -65,6 +66,31 @@ func TestWriteErrorCode(t *testing.T) {
 	}
 }
 
+func TestWriteErrorRetryAfter(t *testing.T) {
+	ctx := context.TODO()
+	w := httptest.NewRecorder()
+
+	UserError(ctx, w, usererror.MaintenanceMode("down for maintenance", 1500*time.Millisecond))
+
+	if got, want := w.Code, 503; want != got {
+		t.Errorf("Want response code %d, got %d", want, got)
+	}
+	if got, want := w.Header().Get("Retry-After"), "2"; got != want {
+		t.Errorf("Want Retry-After header %s, got %s", want, got)
+	}
+
+	errjson := &usererror.Error{}
+	if err := json.NewDecoder(w.Body).Decode(errjson); err != nil {
+		t.Error(err)
+	}
+	if got, want := errjson.Message, "down for maintenance"; got != want {
+		t.Errorf("Want error message %s, got %s", want, got)
+	}
+	if got, want := errjson.Values["code"], usererror.ErrCodeMaintenanceMode; got != want {
+		t.Errorf("Want error code %s, got %v", want, got)
+	}
+}
+
 func TestWriteNotFound(t *testing.T) {
 	ctx := context.TODO()
 	w := httptest.NewRecorder()
@@ -280,3 +306,26 @@ func TestJSONArrayDynamic(t *testing.T) {
 		})
 	}
 }
+
+func TestCSV(t *testing.T) {
+	ctx := context.TODO()
+	w := httptest.NewRecorder()
+
+	CSV(ctx, w, http.StatusOK, "usage.csv", [][]string{
+		{"path", "value"},
+		{"acme/web", "1,5"},
+	})
+
+	if got, want := w.Code, http.StatusOK; want != got {
+		t.Errorf("Want response code %d, got %d", want, got)
+	}
+	if got, want := w.Header().Get("Content-Type"), "text/csv; charset=utf-8"; want != got {
+		t.Errorf("Want content type %s, got %s", want, got)
+	}
+	if got, want := w.Header().Get("Content-Disposition"), `attachment; filename="usage.csv"`; want != got {
+		t.Errorf("Want content disposition %s, got %s", want, got)
+	}
+	if got, want := w.Body.String(), "path,value\nacme/web,\"1,5\"\n"; want != got {
+		t.Errorf("Want body %q, got %q", want, got)
+	}
+}
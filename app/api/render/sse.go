@@ -129,6 +129,13 @@ type sseStream struct {
 }
 
 func (r sseStream) event(event *sse.Event) error {
+	if event.ID != "" {
+		_, err := io.WriteString(r.writer, fmt.Sprintf("id: %s\n", event.ID))
+		if err != nil {
+			return fmt.Errorf("failed to send event id: %w", err)
+		}
+	}
+
 	_, err := io.WriteString(r.writer, fmt.Sprintf("event: %s\n", event.Type))
 	if err != nil {
 		return fmt.Errorf("failed to send event header: %w", err)
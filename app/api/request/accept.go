@@ -0,0 +1,106 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	HeaderParamAccept = "Accept"
+
+	ContentTypeJSON = "application/json"
+	ContentTypeCSV  = "text/csv"
+)
+
+// NegotiateContentType returns the offered content type the client prefers according to the Accept header.
+// More specific media ranges take precedence over wildcards, and among equally preferred offers
+// the first one wins. The first offer is returned if the request doesn't accept any of the offers.
+func NegotiateContentType(r *http.Request, offers ...string) string {
+	if len(offers) == 0 {
+		return ""
+	}
+
+	best := offers[0]
+	bestQ := -1.0
+	bestSpecificity := -1
+
+	for _, offer := range offers {
+		q, specificity := acceptQuality(r.Header.Values(HeaderParamAccept), offer)
+		if q <= 0 {
+			continue
+		}
+
+		if q > bestQ || (q == bestQ && specificity > bestSpecificity) {
+			best, bestQ, bestSpecificity = offer, q, specificity
+		}
+	}
+
+	return best
+}
+
+// acceptQuality returns the quality of the offer according to the most specific matching media range
+// of the Accept header values, together with the specificity of the match:
+// 2 for an exact match, 1 for a type wildcard and 0 for the full wildcard.
+// A missing Accept header accepts everything.
+func acceptQuality(accept []string, offer string) (float64, int) {
+	if len(accept) == 0 {
+		return 1, 0
+	}
+
+	offerType, offerSubtype, _ := strings.Cut(offer, "/")
+
+	q, specificity := 0.0, -1
+	for _, value := range accept {
+		for _, mediaRange := range strings.Split(value, ",") {
+			mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+			if err != nil {
+				continue
+			}
+
+			rangeType, rangeSubtype, _ := strings.Cut(mediaType, "/")
+
+			var s int
+			switch {
+			case rangeType == offerType && rangeSubtype == offerSubtype:
+				s = 2
+			case rangeType == offerType && rangeSubtype == "*":
+				s = 1
+			case rangeType == "*" && rangeSubtype == "*":
+				s = 0
+			default:
+				continue
+			}
+
+			if s <= specificity {
+				continue
+			}
+
+			rangeQ := 1.0
+			if qValue, ok := params["q"]; ok {
+				if rangeQ, err = strconv.ParseFloat(qValue, 64); err != nil {
+					continue
+				}
+			}
+
+			q, specificity = rangeQ, s
+		}
+	}
+
+	return q, specificity
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestNegotiateContentType(t *testing.T) {
+	tests := []struct {
+		name   string
+		accept []string
+		want   string
+	}{
+		{name: "no header", accept: nil, want: ContentTypeJSON},
+		{name: "wildcard", accept: []string{"*/*"}, want: ContentTypeJSON},
+		{name: "csv", accept: []string{"text/csv"}, want: ContentTypeCSV},
+		{name: "csv with charset", accept: []string{"text/csv; charset=utf-8"}, want: ContentTypeCSV},
+		{name: "type wildcard", accept: []string{"text/*"}, want: ContentTypeCSV},
+		{name: "quality", accept: []string{"application/json;q=0.5, text/csv"}, want: ContentTypeCSV},
+		{name: "specific over wildcard", accept: []string{"text/csv;q=0.9, */*;q=0.1"}, want: ContentTypeCSV},
+		{name: "equal preference", accept: []string{"text/csv, application/json"}, want: ContentTypeJSON},
+		{name: "multiple headers", accept: []string{"text/html", "text/csv"}, want: ContentTypeCSV},
+		{name: "rejected", accept: []string{"text/csv;q=0, */*"}, want: ContentTypeJSON},
+		{name: "unsupported", accept: []string{"image/png"}, want: ContentTypeJSON},
+		{name: "invalid", accept: []string{"text/csv;q=abc"}, want: ContentTypeJSON},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r, _ := http.NewRequest(http.MethodGet, "/", nil)
+			for _, value := range test.accept {
+				r.Header.Add(HeaderParamAccept, value)
+			}
+
+			if got := NegotiateContentType(r, ContentTypeJSON, ContentTypeCSV); got != test.want {
+				t.Errorf("expected %q, got %q", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+)
+
+const (
+	// MaxJSONBodySize is the default maximum size of json request bodies in bytes.
+	MaxJSONBodySize = 1 << 20 // 1 MiB
+
+	// prefixUnknownField is the prefix of the error returned by the json decoder for unknown fields.
+	prefixUnknownField = "json: unknown field "
+)
+
+var (
+	// ErrEmptyBody is returned if a request body is required but the request doesn't have one.
+	ErrEmptyBody = usererror.BadRequest("The request body is empty.")
+)
+
+// DecodeJSON decodes the json body of the request into v.
+// The body must not exceed maxBytes, must contain a single json value
+// and must not contain fields that don't exist in v.
+// All returned errors are user facing errors.
+func DecodeJSON(r *http.Request, maxBytes int64, v any) error {
+	if r.Body == nil || r.Body == http.NoBody {
+		return ErrEmptyBody
+	}
+
+	dec := json.NewDecoder(http.MaxBytesReader(nil, r.Body, maxBytes))
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(v); err != nil {
+		return translateJSONError(err)
+	}
+
+	// the body must not contain anything but whitespace after the decoded value.
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return translateJSONError(err)
+		}
+		return usererror.BadRequestf("Malformed JSON at offset %d: "+
+			"the request body must only contain a single JSON value.", dec.InputOffset())
+	}
+
+	return nil
+}
+
+// translateJSONError converts an error returned by the json decoder into a user facing error.
+func translateJSONError(err error) error {
+	var (
+		maxBytesErr  *http.MaxBytesError
+		syntaxErr    *json.SyntaxError
+		typeErr      *json.UnmarshalTypeError
+		userErr      *usererror.Error
+		unmarshalErr *json.InvalidUnmarshalError
+	)
+
+	switch {
+	case errors.Is(err, io.EOF):
+		return ErrEmptyBody
+	case errors.As(err, &maxBytesErr):
+		return usererror.RequestTooLargef("The request body is too large, the maximum allowed size is %d bytes.",
+			maxBytesErr.Limit)
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return usererror.BadRequest("Malformed JSON: the request body ended unexpectedly.")
+	case errors.As(err, &syntaxErr):
+		return usererror.BadRequestf("Malformed JSON at offset %d: %s.", syntaxErr.Offset, syntaxErr)
+	case errors.As(err, &typeErr):
+		if typeErr.Field == "" {
+			return usererror.BadRequestf("Invalid JSON value at offset %d: expected %s but got %s.",
+				typeErr.Offset, typeErr.Type, typeErr.Value)
+		}
+		return usererror.BadRequestf("Invalid value for field %q at offset %d: expected %s but got %s.",
+			typeErr.Field, typeErr.Offset, typeErr.Type, typeErr.Value)
+	case strings.HasPrefix(err.Error(), prefixUnknownField):
+		// the json package doesn't provide a typed error for unknown fields.
+		return usererror.BadRequestf("Unknown field %s.", strings.TrimPrefix(err.Error(), prefixUnknownField))
+	case errors.As(err, &userErr):
+		// returned by custom unmarshalers.
+		return userErr
+	case errors.As(err, &unmarshalErr):
+		// caused by the caller, not the request.
+		return err
+	default:
+		return usererror.BadRequestf("Invalid request body: %s.", err)
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+)
+
+func TestDecodeJSON(t *testing.T) {
+	type input struct {
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+	}
+
+	tests := []struct {
+		name       string
+		body       string
+		maxBytes   int64
+		wantStatus int
+		wantMsg    string
+		want       input
+	}{
+		{
+			name:     "valid",
+			body:     `{"name":"test","count":3}`,
+			maxBytes: MaxJSONBodySize,
+			want:     input{Name: "test", Count: 3},
+		},
+		{
+			name:     "valid with trailing whitespace",
+			body:     "{\"name\":\"test\"}\n",
+			maxBytes: MaxJSONBodySize,
+			want:     input{Name: "test"},
+		},
+		{
+			name:       "empty body",
+			body:       "",
+			maxBytes:   MaxJSONBodySize,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    "The request body is empty.",
+		},
+		{
+			name:       "body too large",
+			body:       `{"name":"` + strings.Repeat("a", 64) + `"}`,
+			maxBytes:   32,
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantMsg:    "The request body is too large, the maximum allowed size is 32 bytes.",
+		},
+		{
+			name:       "malformed json",
+			body:       `{"name":"test",}`,
+			maxBytes:   MaxJSONBodySize,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    "Malformed JSON at offset 16: invalid character '}' looking for beginning of object key string.",
+		},
+		{
+			name:       "truncated json",
+			body:       `{"name":"test"`,
+			maxBytes:   MaxJSONBodySize,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    "Malformed JSON: the request body ended unexpectedly.",
+		},
+		{
+			name:       "unknown field",
+			body:       `{"nmae":"test"}`,
+			maxBytes:   MaxJSONBodySize,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    `Unknown field "nmae".`,
+		},
+		{
+			name:       "invalid field type",
+			body:       `{"count":"three"}`,
+			maxBytes:   MaxJSONBodySize,
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    `Invalid value for field "count" at offset 16: expected int but got string.`,
+		},
+		{
+			name:       "multiple values",
+			body:       `{"name":"a"}{"name":"b"}`,
+			maxBytes:   MaxJSONBodySize,
+			wantStatus: http.StatusBadRequest,
+			wantMsg: "Malformed JSON at offset 13: " +
+				"the request body must only contain a single JSON value.",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(test.body))
+
+			got := input{}
+			err := DecodeJSON(r, test.maxBytes, &got)
+
+			if test.wantStatus == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if got != test.want {
+					t.Errorf("expected %+v, got %+v", test.want, got)
+				}
+				return
+			}
+
+			var userErr *usererror.Error
+			if !errors.As(err, &userErr) {
+				t.Fatalf("expected user error, got %v", err)
+			}
+			if userErr.Status != test.wantStatus {
+				t.Errorf("expected status %d, got %d", test.wantStatus, userErr.Status)
+			}
+			if userErr.Message != test.wantMsg {
+				t.Errorf("expected message %q, got %q", test.wantMsg, userErr.Message)
+			}
+		})
+	}
+}
@@ -56,6 +56,8 @@ const (
 
 	QueryParamInherited  = "inherited"
 	QueryParamAssignable = "assignable"
+	QueryParamForce      = "force"
+	QueryParamDryRun     = "dry_run"
 
 	// TODO: have shared constants across all services?
 	HeaderRequestID       = "X-Request-Id"
@@ -65,6 +67,9 @@ const (
 
 	HeaderIfNoneMatch = "If-None-Match"
 	HeaderETag        = "ETag"
+
+	HeaderIdempotencyKey     = "Idempotency-Key"
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
 )
 
 // GetOptionalRemainderFromPath returns the remainder ("*") from the path or an empty string if it doesn't exist.
@@ -192,6 +197,16 @@ func ParseAssignableFromQuery(r *http.Request) (bool, error) {
 	return QueryParamAsBoolOrDefault(r, QueryParamAssignable, false)
 }
 
+// ParseForceFromQuery extracts the force option from the URL query.
+func ParseForceFromQuery(r *http.Request) (bool, error) {
+	return QueryParamAsBoolOrDefault(r, QueryParamForce, false)
+}
+
+// ParseDryRunFromQuery extracts the dry run option of destructive operations from the URL query.
+func ParseDryRunFromQuery(r *http.Request) (bool, error) {
+	return QueryParamAsBoolOrDefault(r, QueryParamDryRun, false)
+}
+
 // GetDeletedAtFromQueryOrError gets the exact resource deletion timestamp from the query.
 func GetDeletedAtFromQueryOrError(r *http.Request) (int64, error) {
 	return QueryParamAsPositiveInt64OrError(r, QueryParamDeletedAt)
@@ -38,7 +38,13 @@ const (
 	QueryParamUntil              = "until"
 	QueryParamCommitter          = "committer"
 	QueryParamIncludeStats       = "include_stats"
+	QueryParamIncludeNotes       = "include_notes"
+	QueryParamNotesRef           = "notes_ref"
+	QueryParamFollow             = "follow"
+	QueryParamRef1               = "ref1"
+	QueryParamRef2               = "ref2"
 	QueryParamInternal           = "internal"
+	QueryParamInactiveDays       = "inactive_days"
 	QueryParamService            = "service"
 	HeaderParamGitProtocol       = "Git-Protocol"
 )
@@ -47,6 +53,11 @@ func GetGitRefFromQueryOrDefault(r *http.Request, deflt string) string {
 	return QueryParamOrDefault(r, QueryParamGitRef, deflt)
 }
 
+// GetNotesRefFromQuery returns the git notes reference from the query (empty if not provided).
+func GetNotesRefFromQuery(r *http.Request) string {
+	return QueryParamOrDefault(r, QueryParamNotesRef, "")
+}
+
 func GetIncludeCommitFromQueryOrDefault(r *http.Request, deflt bool) (bool, error) {
 	return QueryParamAsBoolOrDefault(r, QueryParamIncludeCommit, deflt)
 }
@@ -77,6 +88,21 @@ func ParseBranchFilter(r *http.Request) *types.BranchFilter {
 	}
 }
 
+// ParseStaleBranchFilter extracts the stale branch filter from the url.
+func ParseStaleBranchFilter(r *http.Request) (*types.StaleBranchFilter, error) {
+	inactiveDays, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamInactiveDays, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.StaleBranchFilter{
+		Query:        ParseQuery(r),
+		InactiveDays: int(inactiveDays),
+		Page:         ParsePage(r),
+		Size:         ParseLimit(r),
+	}, nil
+}
+
 // ParseSortTag extracts the tag sort parameter from the url.
 func ParseSortTag(r *http.Request) enum.TagSortOption {
 	return enum.ParseTagSortOption(
@@ -111,6 +137,19 @@ func ParseCommitFilter(r *http.Request) (*types.CommitFilter, error) {
 	if err != nil {
 		return nil, err
 	}
+	includeNotes, err := QueryParamAsBoolOrDefault(r, QueryParamIncludeNotes, false)
+	if err != nil {
+		return nil, err
+	}
+	follow, err := QueryParamAsBoolOrDefault(r, QueryParamFollow, false)
+	if err != nil {
+		return nil, err
+	}
+
+	path, err := SanitizeRepoPath(QueryParamOrDefault(r, QueryParamPath, ""))
+	if err != nil {
+		return nil, err
+	}
 
 	return &types.CommitFilter{
 		After: QueryParamOrDefault(r, QueryParamAfter, ""),
@@ -118,11 +157,14 @@ func ParseCommitFilter(r *http.Request) (*types.CommitFilter, error) {
 			Page:  ParsePage(r),
 			Limit: ParseLimit(r),
 		},
-		Path:         QueryParamOrDefault(r, QueryParamPath, ""),
+		Path:         path,
 		Since:        since,
 		Until:        until,
 		Committer:    QueryParamOrDefault(r, QueryParamCommitter, ""),
 		IncludeStats: includeStats,
+		IncludeNotes: includeNotes,
+		NotesRef:     GetNotesRefFromQuery(r),
+		Follow:       follow,
 	}, nil
 }
 
@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types/enum"
+	"github.com/harness/gitness/types/listfilter"
+)
+
+// ParseListFilter extracts the list filter declared by the spec from the url.
+// Unsupported sort and order values fall back to the defaults of the spec,
+// unsupported enum values are rejected.
+func ParseListFilter(r *http.Request, spec listfilter.Spec) (*listfilter.Filter, error) {
+	filter := &listfilter.Filter{
+		Sort:        spec.DefaultSort,
+		Order:       ParseOrder(r),
+		Unpaginated: spec.Unpaginated,
+	}
+
+	if !spec.Unpaginated {
+		filter.Page = ParsePage(r)
+		filter.Size = ParseLimit(r)
+	}
+
+	if len(spec.Search) > 0 {
+		filter.Query = strings.ToLower(ParseQuery(r))
+		filter.Search = spec.Search
+	}
+
+	if field, ok := spec.Sort[strings.ToLower(ParseSort(r))]; ok {
+		filter.Sort = field
+	}
+
+	if filter.Order == enum.OrderDefault {
+		filter.Order = spec.DefaultOrder
+	}
+
+	for param, enumField := range spec.Enums {
+		values, ok := QueryParamList(r, param)
+		if !ok {
+			continue
+		}
+
+		for _, value := range values {
+			if !enumField.Supports(value) {
+				return nil, usererror.BadRequestf("Parameter %q doesn't support value %q.", param, value)
+			}
+		}
+
+		if filter.Enums == nil {
+			filter.Enums = make(map[listfilter.Field][]string)
+		}
+		filter.Enums[enumField.Field] = append(filter.Enums[enumField.Field], values...)
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,140 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+	"github.com/harness/gitness/types/listfilter"
+)
+
+func newListRequest(rawQuery string) *http.Request {
+	return &http.Request{URL: &url.URL{Path: "/list", RawQuery: rawQuery}}
+}
+
+// TestParseRepoFilter_MatchesLegacyParsing verifies the repository list filter, as consumed by the store,
+// is identical to the one produced by the parsing before the list filters were introduced.
+func TestParseRepoFilter_MatchesLegacyParsing(t *testing.T) {
+	queries := []string{
+		"",
+		"query=MyRepo",
+		"sort=uid",
+		"sort=identifier&order=desc",
+		"sort=created_at&order=ASC&page=2&limit=5",
+		"sort=UPDATED&order=descending",
+		"sort=deleted&page=-1&limit=1000",
+		"sort=last_activity&order=unknown",
+		"sort=stars&query=a%20b",
+		"sort=repo_id;drop&order=asc",
+	}
+
+	for _, query := range queries {
+		r := newListRequest(query)
+
+		legacy := &types.RepoFilter{
+			Query: ParseQuery(r),
+			Order: ParseOrder(r),
+			Page:  ParsePage(r),
+			Sort:  enum.ParseRepoAttr(ParseSort(r)),
+			Size:  ParseLimit(r),
+		}
+		if legacy.Order == enum.OrderDefault {
+			legacy.Order = enum.OrderAsc
+		}
+
+		filter, err := ParseRepoFilter(r)
+		if err != nil {
+			t.Fatalf("query %q: unexpected error: %v", query, err)
+		}
+
+		if got, want := filter.ListFilter(), legacy.ListFilter(); !reflect.DeepEqual(got, want) {
+			t.Errorf("query %q: got %+v, want %+v", query, got, want)
+		}
+	}
+}
+
+func TestParseExecutionFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    *listfilter.Filter
+		wantErr bool
+	}{
+		{
+			name:  "defaults",
+			query: "",
+			want: &listfilter.Filter{
+				Page: 1, Size: PerPageDefault, Sort: types.ExecutionFieldNumber, Order: enum.OrderDesc,
+			},
+		},
+		{
+			name:  "sort and status",
+			query: "sort=Created&order=asc&page=2&limit=10&status=running&status=failure",
+			want: &listfilter.Filter{
+				Page: 2, Size: 10, Sort: types.ExecutionFieldCreated, Order: enum.OrderAsc,
+				Enums: map[listfilter.Field][]string{
+					types.ExecutionFieldStatus: {string(enum.CIStatusRunning), string(enum.CIStatusFailure)},
+				},
+			},
+		},
+		{
+			name:  "unsupported sort falls back to default",
+			query: "sort=execution_id",
+			want: &listfilter.Filter{
+				Page: 1, Size: PerPageDefault, Sort: types.ExecutionFieldNumber, Order: enum.OrderDesc,
+			},
+		},
+		{
+			name:    "unsupported status",
+			query:   "status=done",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseExecutionFilter(newListRequest(test.query))
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got error %v, want error %t", err, test.wantErr)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseServiceAccountFilter(t *testing.T) {
+	got, err := ParseServiceAccountFilter(newListRequest("query=Bot&page=2&limit=1"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := &listfilter.Filter{
+		Unpaginated: true,
+		Query:       "bot",
+		Search:      []listfilter.Field{types.ServiceAccountFieldUID, types.ServiceAccountFieldDisplayName},
+		Sort:        types.ServiceAccountFieldUID,
+		Order:       enum.OrderAsc,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/harness/gitness/app/api/usererror"
+)
+
+const (
+	// maxRepoPathLength is the maximum length of a file path in a repository.
+	maxRepoPathLength = 4096
+
+	// maxRepoPathDepth is the maximum number of segments of a file path in a repository.
+	maxRepoPathDepth = 256
+)
+
+var (
+	errRepoPathTraversal = usererror.BadRequest(
+		"The path must not contain '.' or '..' segments.")
+	errRepoPathAbsolute = usererror.BadRequest(
+		"The path must be relative to the root of the repository and must not start with '/'.")
+	errRepoPathBackslash = usererror.BadRequest(
+		"The path must not contain backslashes.")
+	errRepoPathInvalidCharacters = usererror.BadRequest(
+		"The path must be valid UTF-8 and must not contain control characters.")
+	errRepoPathTooLong = usererror.BadRequestf(
+		"The path must not be longer than %d characters.", maxRepoPathLength)
+	errRepoPathTooDeep = usererror.BadRequestf(
+		"The path must not have more than %d segments.", maxRepoPathDepth)
+)
+
+// GetSanitizedPathFromRemainder returns the file path in a repository from the remainder ("*") of the path.
+// An empty string is returned for the root of the repository.
+func GetSanitizedPathFromRemainder(r *http.Request) (string, error) {
+	path, err := PathParam(r, PathParamRemainder)
+	if err != nil {
+		return "", err
+	}
+
+	return SanitizeRepoPath(path)
+}
+
+// SanitizeRepoPath normalizes the file path in a repository by collapsing duplicate slashes
+// and removing the trailing slash. It returns a user facing error if the path
+// tries to traverse the repository, contains control characters or backslashes,
+// or exceeds the maximum length or depth.
+func SanitizeRepoPath(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+
+	if len(path) > maxRepoPathLength {
+		return "", errRepoPathTooLong
+	}
+
+	if !utf8.ValidString(path) {
+		return "", errRepoPathInvalidCharacters
+	}
+	for _, c := range path {
+		if c < 32 || c == 127 {
+			return "", errRepoPathInvalidCharacters
+		}
+	}
+
+	if strings.ContainsRune(path, '\\') {
+		return "", errRepoPathBackslash
+	}
+
+	if path[0] == '/' {
+		return "", errRepoPathAbsolute
+	}
+
+	segments := strings.Split(path, "/")
+	sanitized := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		switch segment {
+		case "":
+			// duplicate or trailing slash.
+			continue
+		case ".", "..":
+			return "", errRepoPathTraversal
+		}
+
+		sanitized = append(sanitized, segment)
+	}
+
+	if len(sanitized) > maxRepoPathDepth {
+		return "", errRepoPathTooDeep
+	}
+
+	return strings.Join(sanitized, "/"), nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+)
+
+func TestGetSanitizedPathFromRemainder(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		want    string
+		wantErr error
+	}{
+		{name: "root", target: "/content/", want: ""},
+		{name: "file", target: "/content/docs/README.md", want: "docs/README.md"},
+		{name: "trailing slash", target: "/content/docs/", want: "docs"},
+		{name: "duplicate slashes", target: "/content/docs//api///README.md", want: "docs/api/README.md"},
+		{name: "encoded slash", target: "/content/docs%2FREADME.md", want: "docs/README.md"},
+		{name: "dots in name", target: "/content/..docs/file..md", want: "..docs/file..md"},
+		{name: "unicode", target: "/content/d%C3%B6cs/%E6%96%87.md", want: "döcs/文.md"},
+		{name: "traversal", target: "/content/docs/../../etc/passwd", wantErr: errRepoPathTraversal},
+		{name: "encoded traversal", target: "/content/%2e%2e%2f%2e%2e%2fetc%2fpasswd", wantErr: errRepoPathTraversal},
+		{name: "encoded traversal in segment", target: "/content/docs/%2e%2e/secret", wantErr: errRepoPathTraversal},
+		{name: "mixed encoded traversal", target: "/content/docs/..%2f..%2fsecret", wantErr: errRepoPathTraversal},
+		{name: "current directory", target: "/content/./docs", wantErr: errRepoPathTraversal},
+		{name: "encoded leading slash", target: "/content/%2fetc%2fpasswd", wantErr: errRepoPathAbsolute},
+		{name: "leading slash", target: "/content//etc/passwd", wantErr: errRepoPathAbsolute},
+		{name: "encoded nul byte", target: "/content/docs%00.md", wantErr: errRepoPathInvalidCharacters},
+		{name: "encoded newline", target: "/content/docs%0A.md", wantErr: errRepoPathInvalidCharacters},
+		{name: "invalid utf8", target: "/content/docs%ff.md", wantErr: errRepoPathInvalidCharacters},
+		{name: "encoded backslash", target: "/content/docs%5C..%5Csecret", wantErr: errRepoPathBackslash},
+		{name: "too long", target: "/content/" + strings.Repeat("a", maxRepoPathLength+1), wantErr: errRepoPathTooLong},
+		{name: "too deep", target: "/content/" + strings.Repeat("a/", maxRepoPathDepth+1), wantErr: errRepoPathTooDeep},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				got string
+				err error
+			)
+
+			r := chi.NewRouter()
+			r.Get("/content/*", func(_ http.ResponseWriter, r *http.Request) {
+				got, err = GetSanitizedPathFromRemainder(r)
+			})
+			r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, test.target, nil))
+
+			if err != test.wantErr { //nolint:errorlint // the exact error instance is expected.
+				t.Fatalf("expected error %v, got %v", test.wantErr, err)
+			}
+			if got != test.want {
+				t.Errorf("expected path %q, got %q", test.want, got)
+			}
+		})
+	}
+}
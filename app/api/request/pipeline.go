@@ -16,6 +16,12 @@ package request
 
 import (
 	"net/http"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+	"github.com/harness/gitness/types/listfilter"
 )
 
 const (
@@ -26,6 +32,8 @@ const (
 	PathParamTriggerIdentifier  = "trigger_identifier"
 	QueryParamLatest            = "latest"
 	QueryParamBranch            = "branch"
+	QueryParamMatrix            = "matrix"
+	QueryParamStatus            = "status"
 )
 
 func GetPipelineIdentifierFromPath(r *http.Request) (string, error) {
@@ -36,6 +44,27 @@ func GetBranchFromQuery(r *http.Request) string {
 	return QueryParamOrDefault(r, QueryParamBranch, "")
 }
 
+// ParseExecutionFilter extracts the execution list filter from the url.
+func ParseExecutionFilter(r *http.Request) (*listfilter.Filter, error) {
+	statuses, _ := enum.GetAllCIStatuses()
+	values := make([]string, len(statuses))
+	for i, status := range statuses {
+		values[i] = string(status)
+	}
+
+	return ParseListFilter(r, listfilter.Spec{
+		Sort: map[string]listfilter.Field{
+			"number":  types.ExecutionFieldNumber,
+			"created": types.ExecutionFieldCreated,
+		},
+		DefaultSort:  types.ExecutionFieldNumber,
+		DefaultOrder: enum.OrderDesc,
+		Enums: map[string]listfilter.EnumField{
+			QueryParamStatus: {Field: types.ExecutionFieldStatus, Values: values},
+		},
+	})
+}
+
 func GetExecutionNumberFromPath(r *http.Request) (int64, error) {
 	return PathParamAsPositiveInt64(r, PathParamExecutionNumber)
 }
@@ -56,3 +85,18 @@ func GetLatestFromPath(r *http.Request) bool {
 func GetTriggerIdentifierFromPath(r *http.Request) (string, error) {
 	return PathParamOrError(r, PathParamTriggerIdentifier)
 }
+
+// ParseMatrixAxesFromQuery extracts the matrix axis values (in the form axis:value) from the url.
+func ParseMatrixAxesFromQuery(r *http.Request) (map[string]string, error) {
+	values := r.URL.Query()[QueryParamMatrix]
+	axes := make(map[string]string, len(values))
+	for _, v := range values {
+		axis, value, ok := strings.Cut(v, ":")
+		if !ok || axis == "" {
+			return nil, usererror.BadRequestf("Invalid matrix filter %q, expected axis:value.", v)
+		}
+		axes[axis] = value
+	}
+
+	return axes, nil
+}
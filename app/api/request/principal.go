@@ -19,6 +19,7 @@ import (
 
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+	"github.com/harness/gitness/types/listfilter"
 )
 
 const (
@@ -28,6 +29,9 @@ const (
 	PathParamServiceAccountUID = "sa_uid"
 
 	PathParamPrincipalID = "principal_id"
+
+	QueryParamAvatarSize    = "size"
+	QueryParamAvatarVersion = "v"
 )
 
 // GetUserIDFromPath returns the user id from the request path.
@@ -52,6 +56,39 @@ func GetServiceAccountUIDFromPath(r *http.Request) (string, error) {
 	return PathParamOrError(r, PathParamServiceAccountUID)
 }
 
+// serviceAccountListSpec declares the sort and search parameters of service account lists.
+// Service accounts aren't paginated, their number per parent is expected to be small.
+var serviceAccountListSpec = listfilter.Spec{
+	Sort: map[string]listfilter.Field{
+		"uid":     types.ServiceAccountFieldUID,
+		"created": types.ServiceAccountFieldCreated,
+	},
+	DefaultSort:  types.ServiceAccountFieldUID,
+	DefaultOrder: enum.OrderAsc,
+	Search:       []listfilter.Field{types.ServiceAccountFieldUID, types.ServiceAccountFieldDisplayName},
+	Unpaginated:  true,
+}
+
+// ParseServiceAccountFilter extracts the service account list filter from the url.
+func ParseServiceAccountFilter(r *http.Request) (*listfilter.Filter, error) {
+	return ParseListFilter(r, serviceAccountListSpec)
+}
+
+// ParseAvatarSize extracts the requested avatar size from the url.
+func ParseAvatarSize(r *http.Request, deflt int) (int, error) {
+	size, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamAvatarSize, int64(deflt))
+	if err != nil {
+		return 0, err
+	}
+
+	return int(size), nil
+}
+
+// GetAvatarVersionFromQuery returns the avatar version from the url.
+func GetAvatarVersionFromQuery(r *http.Request) string {
+	return QueryParamOrDefault(r, QueryParamAvatarVersion, "")
+}
+
 // ParseSortUser extracts the user sort parameter from the url.
 func ParseSortUser(r *http.Request) enum.UserAttr {
 	return enum.ParseUserAttr(
@@ -44,3 +44,26 @@ func ParseListPublicKeyQueryFilterFromRequest(r *http.Request) (types.PublicKeyF
 		Order:           ParseOrder(r),
 	}, nil
 }
+
+const (
+	PathParamDeployKeyIdentifier = "deploy_key_identifier"
+)
+
+func GetDeployKeyIdentifierFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamDeployKeyIdentifier)
+}
+
+// ParseListDeployKeyQueryFilterFromRequest parses query filter for deploy keys from the url.
+func ParseListDeployKeyQueryFilterFromRequest(r *http.Request) (types.DeployKeyFilter, error) {
+	sort := enum.PublicKeySort(ParseSort(r))
+	sort, ok := sort.Sanitize()
+	if !ok {
+		return types.DeployKeyFilter{}, usererror.BadRequest("Invalid value for the sort query parameter.")
+	}
+
+	return types.DeployKeyFilter{
+		ListQueryFilter: ParseListQueryFilterFromRequest(r),
+		Sort:            sort,
+		Order:           ParseOrder(r),
+	}, nil
+}
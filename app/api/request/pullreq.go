@@ -34,6 +34,7 @@ const (
 	QueryParamReviewerID         = "reviewer_id"
 	QueryParamReviewDecision     = "review_decision"
 	QueryParamIncludeDescription = "include_description"
+	QueryParamMergeMethod        = "method"
 )
 
 func GetPullReqNumberFromPath(r *http.Request) (int64, error) {
@@ -193,6 +194,21 @@ func ParsePullReqActivityFilter(r *http.Request) (*types.PullReqActivityFilter,
 	}, nil
 }
 
+// ParseMergeMethod extracts the merge method from the url, defaults to merge.
+func ParseMergeMethod(r *http.Request) (enum.MergeMethod, error) {
+	str := r.URL.Query().Get(QueryParamMergeMethod)
+	if str == "" {
+		return enum.MergeMethodMerge, nil
+	}
+
+	method, ok := enum.MergeMethod(str).Sanitize()
+	if !ok {
+		return "", errors.InvalidArgument("Unsupported merge method: %q.", str)
+	}
+
+	return method, nil
+}
+
 // parsePullReqActivityKinds extracts the pull request activity kinds from the url.
 func parsePullReqActivityKinds(r *http.Request) []enum.PullReqActivityKind {
 	strKinds := r.URL.Query()[QueryParamKind]
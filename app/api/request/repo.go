@@ -16,25 +16,51 @@ package request
 
 import (
 	"net/http"
+	"slices"
+	"strings"
 
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+	"github.com/harness/gitness/types/listfilter"
 )
 
 const (
 	PathParamRepoRef = "repo_ref"
 	QueryParamRepoID = "repo_id"
+	QueryParamWindow = "window"
+
+	QueryParamPrincipalID = "principal_id"
+
+	QueryParamTopic         = "topic"
+	QueryParamTopicMatch    = "topic_match"
+	QueryParamIncludePinned = "include_pinned"
+
+	topicMatchAny = "any"
+	topicMatchAll = "all"
 )
 
 func GetRepoRefFromPath(r *http.Request) (string, error) {
 	return PathParamOrError(r, PathParamRepoRef)
 }
 
-// ParseSortRepo extracts the repo sort parameter from the url.
-func ParseSortRepo(r *http.Request) enum.RepoAttr {
-	return enum.ParseRepoAttr(
-		r.URL.Query().Get(QueryParamSort),
-	)
+// repoListSpec declares the sort and search parameters of repository lists.
+var repoListSpec = listfilter.Spec{
+	Sort: map[string]listfilter.Field{
+		// TODO [CODE-1363]: remove after identifier migration.
+		"uid":           types.RepoFieldIdentifier,
+		"identifier":    types.RepoFieldIdentifier,
+		"created":       types.RepoFieldCreated,
+		"created_at":    types.RepoFieldCreated,
+		"updated":       types.RepoFieldUpdated,
+		"updated_at":    types.RepoFieldUpdated,
+		"deleted":       types.RepoFieldDeleted,
+		"deleted_at":    types.RepoFieldDeleted,
+		"last_activity": types.RepoFieldLastActivity,
+		"stars":         types.RepoFieldStars,
+	},
+	DefaultOrder: enum.OrderAsc,
+	Search:       []listfilter.Field{types.RepoFieldIdentifier},
 }
 
 // ParseRepoFilter extracts the repository filter from the url.
@@ -65,14 +91,135 @@ func ParseRepoFilter(r *http.Request) (*types.RepoFilter, error) {
 		deletedAt = &deletedAtVal
 	}
 
+	topics, topicsMatchAll, err := parseRepoTopics(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// includePinned is optional to list the pinned repos of the space first.
+	includePinned, err := QueryParamAsBoolOrDefault(r, QueryParamIncludePinned, false)
+	if err != nil {
+		return nil, err
+	}
+
+	listFilter, err := ParseListFilter(r, repoListSpec)
+	if err != nil {
+		return nil, err
+	}
+
 	return &types.RepoFilter{
-		Query:             ParseQuery(r),
-		Order:             ParseOrder(r),
-		Page:              ParsePage(r),
-		Sort:              ParseSortRepo(r),
-		Size:              ParseLimit(r),
+		Query:             listFilter.Query,
+		Order:             listFilter.Order,
+		Page:              listFilter.Page,
+		Sort:              enum.ParseRepoAttr(string(listFilter.Sort)),
+		Size:              listFilter.Size,
 		Recursive:         recursive,
 		DeletedAt:         deletedAt,
 		DeletedBeforeOrAt: deletedBeforeOrAt,
+		Topics:            topics,
+		TopicsMatchAll:    topicsMatchAll,
+		IncludePinned:     includePinned,
+	}, nil
+}
+
+// parseRepoTopics extracts the repository topics from the url,
+// and whether the repositories need to have all of them or any of them.
+func parseRepoTopics(r *http.Request) ([]string, bool, error) {
+	var topics []string
+	for _, topic := range r.URL.Query()[QueryParamTopic] {
+		topic = strings.ToLower(strings.TrimSpace(topic))
+		if topic != "" && !slices.Contains(topics, topic) {
+			topics = append(topics, topic)
+		}
+	}
+
+	switch match := r.URL.Query().Get(QueryParamTopicMatch); match {
+	case "", topicMatchAny:
+		return topics, false, nil
+	case topicMatchAll:
+		return topics, true, nil
+	default:
+		return nil, false, usererror.BadRequestf("Invalid value %q for parameter %q, expected %q or %q.",
+			match, QueryParamTopicMatch, topicMatchAny, topicMatchAll)
+	}
+}
+
+// ParseRepoTopicFilter extracts the repository topic filter from the url.
+func ParseRepoTopicFilter(r *http.Request) (*types.RepoTopicFilter, error) {
+	recursive, err := ParseRecursiveFromQuery(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.RepoTopicFilter{
+		Query:     ParseQuery(r),
+		Recursive: recursive,
+	}, nil
+}
+
+// ParseRepoActivityFilter extracts the repository activity filter from the url.
+func ParseRepoActivityFilter(r *http.Request) *types.RepoActivityFilter {
+	return &types.RepoActivityFilter{
+		Page:  ParsePage(r),
+		Size:  ParseLimit(r),
+		Types: parseRepoActivityTypes(r),
+	}
+}
+
+// ParseRepoAccessLogFilter extracts the repository access log filter from the url.
+func ParseRepoAccessLogFilter(r *http.Request) (*types.RepoAccessLogFilter, error) {
+	principalID, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamPrincipalID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	since, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamSince, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	until, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamUntil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.RepoAccessLogFilter{
+		Page:        ParsePage(r),
+		Size:        ParseLimit(r),
+		PrincipalID: principalID,
+		Since:       since,
+		Until:       until,
 	}, nil
 }
+
+// parseRepoActivityTypes extracts the repository activity types from the url.
+func parseRepoActivityTypes(r *http.Request) []enum.RepoActivityType {
+	strTypes := r.URL.Query()[QueryParamType]
+	m := make(map[enum.RepoActivityType]struct{}) // use map to eliminate duplicates
+	for _, s := range strTypes {
+		if t, ok := enum.RepoActivityType(s).Sanitize(); ok && t != "" {
+			m[t] = struct{}{}
+		}
+	}
+
+	if len(m) == 0 {
+		return nil
+	}
+
+	activityTypes := make([]enum.RepoActivityType, 0, len(m))
+	for t := range m {
+		activityTypes = append(activityTypes, t)
+	}
+
+	return activityTypes
+}
+
+// ParseRepoStatsWindow extracts the repository statistics window from the url.
+func ParseRepoStatsWindow(r *http.Request) (enum.RepoStatsWindow, error) {
+	window, ok := enum.RepoStatsWindow(r.URL.Query().Get(QueryParamWindow)).Sanitize()
+	if !ok {
+		return "", usererror.BadRequest("Invalid value for the window query parameter.")
+	}
+
+	return window, nil
+}
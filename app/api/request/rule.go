@@ -33,6 +33,7 @@ func ParseRuleFilter(r *http.Request) *types.RuleFilter {
 	return &types.RuleFilter{
 		ListQueryFilter: ParseListQueryFilterFromRequest(r),
 		States:          parseRuleStates(r),
+		Types:           parseRuleTypes(r),
 		Sort:            parseRuleSort(r),
 		Order:           ParseOrder(r),
 	}
@@ -56,6 +57,24 @@ func parseRuleStates(r *http.Request) []enum.RuleState {
 	return states
 }
 
+// parseRuleTypes extracts the protection rule types from the url.
+func parseRuleTypes(r *http.Request) []types.RuleType {
+	strTypes, _ := QueryParamList(r, QueryParamType)
+	m := make(map[types.RuleType]struct{}) // use map to eliminate duplicates
+	for _, s := range strTypes {
+		if s != "" {
+			m[types.RuleType(s)] = struct{}{}
+		}
+	}
+
+	ruleTypes := make([]types.RuleType, 0, len(m))
+	for t := range m {
+		ruleTypes = append(ruleTypes, t)
+	}
+
+	return ruleTypes
+}
+
 // GetRuleIdentifierFromPath extracts the protection rule identifier from the URL.
 func GetRuleIdentifierFromPath(r *http.Request) (string, error) {
 	return PathParamOrError(r, PathParamRuleIdentifier)
@@ -23,15 +23,48 @@ import (
 )
 
 const (
-	PathParamSpaceRef = "space_ref"
+	PathParamSpaceRef     = "space_ref"
+	PathParamPinnedRepoID = "pinned_repo_id"
+	PathParamBulkJobUID   = "bulk_job_uid"
 
 	QueryParamIncludeSubspaces = "include_subspaces"
+
+	QueryParamFrom = "from"
+	QueryParamTo   = "to"
+
+	// HeaderParamLastEventID is the header used by SSE clients to resume a stream.
+	HeaderParamLastEventID = "Last-Event-ID"
 )
 
 func GetSpaceRefFromPath(r *http.Request) (string, error) {
 	return PathParamOrError(r, PathParamSpaceRef)
 }
 
+// GetPinnedRepoIDFromPath extracts the ID of the pinned repository from the url.
+func GetPinnedRepoIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamPinnedRepoID)
+}
+
+// GetBulkJobUIDFromPath extracts the job UID of a repository bulk operation from the url.
+func GetBulkJobUIDFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamBulkJobUID)
+}
+
+// GetUsageRangeFromQuery extracts the optional start and end (unix millis) of a usage report from the url.
+func GetUsageRangeFromQuery(r *http.Request) (int64, int64, error) {
+	from, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamFrom, 0)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	to, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamTo, 0)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return from, to, nil
+}
+
 // ParseSortSpace extracts the space sort parameter from the url.
 func ParseSortSpace(r *http.Request) enum.SpaceAttr {
 	return enum.ParseSpaceAttr(
@@ -87,3 +120,8 @@ func GetIncludeSubspacesFromQuery(r *http.Request) (bool, error) {
 
 	return v, nil
 }
+
+// GetLastEventIDFromHeader returns the ID of the last event a resuming SSE client has received.
+func GetLastEventIDFromHeader(r *http.Request) string {
+	return GetHeaderOrDefault(r, HeaderParamLastEventID, "")
+}
@@ -24,6 +24,8 @@ import (
 const (
 	PathParamWebhookIdentifier  = "webhook_identifier"
 	PathParamWebhookExecutionID = "webhook_execution_id"
+
+	QueryParamTrigger = "trigger"
 )
 
 func GetWebhookIdentifierFromPath(r *http.Request) (string, error) {
@@ -48,11 +50,34 @@ func ParseWebhookFilter(r *http.Request) *types.WebhookFilter {
 // ParseWebhookExecutionFilter extracts the WebhookExecution query parameters for listing from the url.
 func ParseWebhookExecutionFilter(r *http.Request) *types.WebhookExecutionFilter {
 	return &types.WebhookExecutionFilter{
-		Page: ParsePage(r),
-		Size: ParseLimit(r),
+		Page:     ParsePage(r),
+		Size:     ParseLimit(r),
+		Triggers: parseWebhookTriggers(r),
 	}
 }
 
+// parseWebhookTriggers extracts the webhook triggers from the url.
+func parseWebhookTriggers(r *http.Request) []enum.WebhookTrigger {
+	strTriggers := r.URL.Query()[QueryParamTrigger]
+	m := make(map[enum.WebhookTrigger]struct{}) // use map to eliminate duplicates
+	for _, s := range strTriggers {
+		if trigger, ok := enum.WebhookTrigger(s).Sanitize(); ok {
+			m[trigger] = struct{}{}
+		}
+	}
+
+	if len(m) == 0 {
+		return nil
+	}
+
+	triggers := make([]enum.WebhookTrigger, 0, len(m))
+	for t := range m {
+		triggers = append(triggers, t)
+	}
+
+	return triggers
+}
+
 // ParseSortWebhook extracts the webhook sort parameter from the url.
 func ParseSortWebhook(r *http.Request) enum.WebhookAttr {
 	return enum.ParseWebhookAttr(
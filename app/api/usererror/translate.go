@@ -20,7 +20,9 @@ import (
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/controller/limiter"
+	"github.com/harness/gitness/app/api/middleware/timeout"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/maintenance"
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/blob"
@@ -41,6 +43,7 @@ func Translate(ctx context.Context, err error) *Error {
 		codeOwnersTooLargeError  *codeowners.TooLargeError
 		codeOwnersFileParseError *codeowners.FileParseError
 		lockError                *lock.Error
+		maintenanceError         *maintenance.Error
 	)
 
 	// print original error for debugging purposes
@@ -53,6 +56,10 @@ func Translate(ctx context.Context, err error) *Error {
 	case errors.As(err, &rError):
 		return rError
 
+	// the request exceeded its timeout, the error could be any failure caused by the cancellation.
+	case timeout.Exceeded(ctx):
+		return ErrTimeout
+
 	// api auth errors
 	case errors.Is(err, apiauth.ErrNotAuthorized):
 		return ErrForbidden
@@ -120,6 +127,10 @@ func Translate(ctx context.Context, err error) *Error {
 	case errors.As(err, &lockError):
 		return errorFromLockError(lockError)
 
+	// maintenance mode errors
+	case errors.As(err, &maintenanceError):
+		return MaintenanceMode(maintenanceError.Message, maintenanceError.RetryAfter)
+
 	// public access errors
 	case errors.Is(err, publicaccess.ErrPublicAccessNotAllowed):
 		return BadRequestf("Public access on resources is not allowed.")
@@ -151,6 +162,7 @@ var codes = map[errors.Status]int{
 	errors.StatusPreconditionFailed: http.StatusPreconditionFailed,
 	errors.StatusUnauthorized:       http.StatusUnauthorized,
 	errors.StatusInternal:           http.StatusInternalServerError,
+	errors.StatusUnavailable:        http.StatusServiceUnavailable,
 }
 
 // httpStatusCode returns the associated HTTP status code for a git error code.
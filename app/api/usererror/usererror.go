@@ -16,7 +16,25 @@ package usererror
 
 import (
 	"fmt"
+	"math"
 	"net/http"
+	"time"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// ErrCodeCSRFTokenInvalid is the code of ErrCSRFTokenInvalid.
+	ErrCodeCSRFTokenInvalid = "csrf_token_invalid"
+
+	// ErrCodeLoginLocked is the code of errors returned by LoginLocked.
+	ErrCodeLoginLocked = "login_locked"
+
+	// ErrCodeMaintenanceMode is the code of errors returned by MaintenanceMode.
+	ErrCodeMaintenanceMode = "maintenance_mode"
+
+	// ErrCodeTimeout is the code of ErrTimeout.
+	ErrCodeTimeout = "timeout"
 )
 
 var (
@@ -89,6 +107,21 @@ var (
 		"The requested resource is temporarily locked, please retry the operation.",
 	)
 
+	// ErrPrincipalBlocked is returned if the principal has been blocked by an administrator.
+	ErrPrincipalBlocked = New(http.StatusForbidden, "The account has been blocked")
+
+	// ErrTooManyStreams is returned if the principal has too many concurrent event streams open.
+	ErrTooManyStreams = New(http.StatusTooManyRequests, "Too many concurrent event streams")
+
+	// ErrCSRFTokenInvalid is returned if a cookie authenticated request is missing a valid CSRF token.
+	// The code allows clients to tell it apart from other forbidden errors, refresh the token and retry.
+	ErrCSRFTokenInvalid = NewWithPayload(http.StatusForbidden, "Missing or invalid CSRF token",
+		map[string]any{"code": ErrCodeCSRFTokenInvalid})
+
+	// ErrTimeout is returned if the request got canceled because it exceeded its timeout.
+	ErrTimeout = NewWithPayload(http.StatusGatewayTimeout, "The request took too long to complete",
+		map[string]any{"code": ErrCodeTimeout})
+
 	// ErrEmptyRepoNeedsBranch is returned if no branch found on the githook post receieve for empty repositories.
 	ErrEmptyRepoNeedsBranch = New(http.StatusBadRequest,
 		"Pushing to an empty repository requires at least one branch with commits.")
@@ -99,6 +132,9 @@ type Error struct {
 	Status  int            `json:"-"`
 	Message string         `json:"message"`
 	Values  map[string]any `json:"values,omitempty"`
+
+	// RetryAfter is returned to the client in the Retry-After header if set.
+	RetryAfter time.Duration `json:"-"`
 }
 
 func (e *Error) Error() string {
@@ -130,6 +166,28 @@ func NewWithPayload(status int, message string, valueMaps ...map[string]any) *Er
 	return &Error{Status: status, Message: message, Values: values}
 }
 
+// LoginLocked returns a new user facing error for logins that are temporarily locked
+// after too many failed attempts. The payload contains the remaining lockout duration.
+func LoginLocked(retryAfter time.Duration) *Error {
+	return NewWithPayload(http.StatusTooManyRequests,
+		"Too many failed login attempts, try again later",
+		map[string]any{
+			"code":                ErrCodeLoginLocked,
+			"retry_after_seconds": int64(math.Ceil(retryAfter.Seconds())),
+		})
+}
+
+// MaintenanceMode returns a new user facing error for writes that are blocked while maintenance mode is enabled.
+func MaintenanceMode(message string, retryAfter time.Duration) *Error {
+	err := NewWithPayload(http.StatusServiceUnavailable, message,
+		map[string]any{
+			"code":                ErrCodeMaintenanceMode,
+			"retry_after_seconds": int64(math.Ceil(retryAfter.Seconds())),
+		})
+	err.RetryAfter = retryAfter
+	return err
+}
+
 // BadRequest returns a new user facing bad request error.
 func BadRequest(message string) *Error {
 	return New(http.StatusBadRequest, message)
@@ -179,3 +237,15 @@ func ConflictWithPayload(message string, values ...map[string]any) *Error {
 func Conflict(message string) *Error {
 	return NewWithPayload(http.StatusConflict, message)
 }
+
+// RepoUnavailable returns a new user facing error for repositories in a state that doesn't allow the operation.
+func RepoUnavailable(state enum.RepoState, until int64) *Error {
+	return NewWithPayload(
+		http.StatusLocked,
+		fmt.Sprintf("Repository is unavailable: %s.", state),
+		map[string]any{
+			"state":          state.String(),
+			"expected_until": until,
+		},
+	)
+}
@@ -24,6 +24,9 @@ import (
 var (
 	// ErrNoAuthData that is returned if the authorizer doesn't find any data in the request that can be used for auth.
 	ErrNoAuthData = errors.New("the request doesn't contain any auth data that can be used by the Authorizer")
+
+	// ErrPrincipalBlocked is returned if the auth data is valid, but the principal has been blocked.
+	ErrPrincipalBlocked = errors.New("the principal has been blocked")
 )
 
 // Authenticator is an abstraction of an entity that's responsible for authenticating principals
@@ -20,40 +20,53 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/jwt"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 
 	gojwt "github.com/golang-jwt/jwt"
+	"github.com/rs/zerolog/log"
 )
 
 var _ Authenticator = (*JWTAuthenticator)(nil)
 
+// sessionLastUsedInterval is the minimum time between updates of the last used time of a session,
+// so not every request results in a db write.
+const sessionLastUsedInterval = 5 * time.Minute
+
 // JWTAuthenticator uses the provided JWT to authenticate the caller.
 type JWTAuthenticator struct {
 	cookieName     string
 	principalStore store.PrincipalStore
 	tokenStore     store.TokenStore
+	tokenCache     store.PrincipalTokenCache
+	deployKeyStore store.DeployKeyStore
 }
 
 func NewTokenAuthenticator(
 	principalStore store.PrincipalStore,
 	tokenStore store.TokenStore,
+	tokenCache store.PrincipalTokenCache,
+	deployKeyStore store.DeployKeyStore,
 	cookieName string,
 ) *JWTAuthenticator {
 	return &JWTAuthenticator{
 		cookieName:     cookieName,
 		principalStore: principalStore,
 		tokenStore:     tokenStore,
+		tokenCache:     tokenCache,
+		deployKeyStore: deployKeyStore,
 	}
 }
 
 func (a *JWTAuthenticator) Authenticate(r *http.Request) (*auth.Session, error) {
 	ctx := r.Context()
-	str := extractToken(r, a.cookieName)
+	str, fromCookie := extractToken(r, a.cookieName)
 
 	if len(str) == 0 {
 		return nil, ErrNoAuthData
@@ -83,6 +96,11 @@ func (a *JWTAuthenticator) Authenticate(r *http.Request) (*auth.Session, error)
 		return nil, errors.New("invalid HMAC signature for JWT")
 	}
 
+	// tokens of blocked principals stay in the db, but can't be used until the principal is unblocked.
+	if principal.Blocked {
+		return nil, ErrPrincipalBlocked
+	}
+
 	var metadata auth.Metadata
 	switch {
 	case claims.Token != nil:
@@ -94,13 +112,25 @@ func (a *JWTAuthenticator) Authenticate(r *http.Request) (*auth.Session, error)
 		metadata = a.metadataFromMembershipClaims(claims.Membership)
 	case claims.AccessPermissions != nil:
 		metadata = a.metadataFromAccessPermissions(claims.AccessPermissions)
+	case claims.DeployKey != nil:
+		metadata, err = a.metadataFromDeployKeyClaims(ctx, claims.DeployKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get metadata from deploy key claims: %w", err)
+		}
 	default:
 		return nil, fmt.Errorf("jwt is missing sub-claims")
 	}
 
+	var expiresAt int64
+	if claims.ExpiresAt > 0 {
+		expiresAt = time.Unix(claims.ExpiresAt, 0).UnixMilli()
+	}
+
 	return &auth.Session{
-		Principal: *principal,
-		Metadata:  metadata,
+		Principal:  *principal,
+		Metadata:   metadata,
+		ExpiresAt:  expiresAt,
+		FromCookie: fromCookie,
 	}, nil
 }
 
@@ -109,10 +139,21 @@ func (a *JWTAuthenticator) metadataFromTokenClaims(
 	principal *types.Principal,
 	tknClaims *jwt.SubClaimsToken,
 ) (auth.Metadata, error) {
-	// ensure tkn exists
-	tkn, err := a.tokenStore.Find(ctx, tknClaims.ID)
+	// ensure tkn exists (wasn't revoked) - the tokens of the principal are cached and evicted on revocation.
+	tokens, err := a.tokenCache.Get(ctx, principal.ID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find token in db: %w", err)
+		return nil, fmt.Errorf("failed to get tokens of principal: %w", err)
+	}
+
+	tkn, ok := tokens.Tokens[tknClaims.ID]
+	if !ok {
+		// the token could have been created after the tokens of the principal were cached.
+		tkn, err = a.tokenStore.Find(ctx, tknClaims.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find token in db: %w", err)
+		}
+
+		a.tokenCache.Evict(ctx, principal.ID)
 	}
 
 	// protect against faked JWTs for other principals in case of single salt leak
@@ -123,12 +164,32 @@ func (a *JWTAuthenticator) metadataFromTokenClaims(
 		)
 	}
 
+	if tkn.Type == enum.TokenTypeSession {
+		a.updateSessionLastUsed(ctx, tkn)
+	}
+
 	return &auth.TokenMetadata{
 		TokenType: tkn.Type,
 		TokenID:   tkn.ID,
 	}, nil
 }
 
+// updateSessionLastUsed updates the last used time of the session, if it wasn't updated recently.
+func (a *JWTAuthenticator) updateSessionLastUsed(ctx context.Context, tkn *types.Token) {
+	now := time.Now()
+	if tkn.LastUsedAt != nil && now.Sub(time.UnixMilli(*tkn.LastUsedAt)) < sessionLastUsedInterval {
+		return
+	}
+
+	// failing to track the usage of a session shouldn't fail the request.
+	if err := a.tokenStore.UpdateLastUsed(ctx, tkn.ID, now.UnixMilli()); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("token_id", tkn.ID).Msg("failed to update last used time of session")
+		return
+	}
+
+	a.tokenCache.Evict(ctx, tkn.PrincipalID)
+}
+
 func (a *JWTAuthenticator) metadataFromMembershipClaims(
 	mbsClaims *jwt.SubClaimsMembership,
 ) auth.Metadata {
@@ -139,6 +200,28 @@ func (a *JWTAuthenticator) metadataFromMembershipClaims(
 	}
 }
 
+// metadataFromDeployKeyClaims ensures the deploy key exists (wasn't deleted) and returns its metadata.
+func (a *JWTAuthenticator) metadataFromDeployKeyClaims(
+	ctx context.Context,
+	dkClaims *jwt.SubClaimsDeployKey,
+) (auth.Metadata, error) {
+	deployKey, err := a.deployKeyStore.Find(ctx, dkClaims.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find deploy key in db: %w", err)
+	}
+
+	// failing to track the usage of a deploy key shouldn't fail the request.
+	if err := a.deployKeyStore.MarkAsUsed(ctx, deployKey.ID, time.Now().UnixMilli()); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("deploy_key_id", deployKey.ID).Msg("failed to mark deploy key as used")
+	}
+
+	return &auth.DeployKeyMetadata{
+		DeployKeyID: deployKey.ID,
+		RepoID:      deployKey.RepoID,
+		ReadOnly:    deployKey.ReadOnly,
+	}, nil
+}
+
 func (a *JWTAuthenticator) metadataFromAccessPermissions(
 	s *jwt.SubClaimsAccessPermissions,
 ) auth.Metadata {
@@ -147,10 +230,11 @@ func (a *JWTAuthenticator) metadataFromAccessPermissions(
 	}
 }
 
-func extractToken(r *http.Request, cookieName string) string {
+// extractToken returns the token of the request and whether it was taken from the session cookie.
+func extractToken(r *http.Request, cookieName string) (string, bool) {
 	// Check query param first (as that's most immediately visible to caller)
 	if queryToken, ok := request.GetAccessTokenFromQuery(r); ok {
-		return queryToken
+		return queryToken, false
 	}
 
 	// check authorization header next
@@ -160,20 +244,20 @@ func extractToken(r *http.Request, cookieName string) string {
 	case strings.HasPrefix(headerToken, "Basic "):
 		// return pwd either way - if it's invalid pwd is empty string which we'd return anyway
 		_, pwd, _ := r.BasicAuth()
-		return pwd
+		return pwd, false
 	// strip bearer prefix if present
 	case strings.HasPrefix(headerToken, "Bearer "):
-		return headerToken[7:]
+		return headerToken[7:], false
 	// otherwise use value as is
 	case headerToken != "":
-		return headerToken
+		return headerToken, false
 	}
 
 	// check cookies last (as that's least visible to caller)
 	if cookieToken, ok := request.GetTokenFromCookie(r, cookieName); ok {
-		return cookieToken
+		return cookieToken, true
 	}
 
 	// no token found
-	return ""
+	return "", false
 }
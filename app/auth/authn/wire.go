@@ -30,6 +30,8 @@ func ProvideAuthenticator(
 	config *types.Config,
 	principalStore store.PrincipalStore,
 	tokenStore store.TokenStore,
+	tokenCache store.PrincipalTokenCache,
+	deployKeyStore store.DeployKeyStore,
 ) Authenticator {
-	return NewTokenAuthenticator(principalStore, tokenStore, config.Token.CookieName)
+	return NewTokenAuthenticator(principalStore, tokenStore, tokenCache, deployKeyStore, config.Token.CookieName)
 }
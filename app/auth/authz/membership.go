@@ -16,12 +16,14 @@ package authz
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -34,17 +36,20 @@ var _ Authorizer = (*MembershipAuthorizer)(nil)
 type MembershipAuthorizer struct {
 	permissionCache PermissionCache
 	spaceStore      store.SpaceStore
+	repoStore       store.RepoStore
 	publicAccess    publicaccess.Service
 }
 
 func NewMembershipAuthorizer(
 	permissionCache PermissionCache,
 	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
 	publicAccess publicaccess.Service,
 ) *MembershipAuthorizer {
 	return &MembershipAuthorizer{
 		permissionCache: permissionCache,
 		spaceStore:      spaceStore,
+		repoStore:       repoStore,
 		publicAccess:    publicAccess,
 	}
 }
@@ -76,6 +81,11 @@ func (a *MembershipAuthorizer) Check(
 		session.Metadata,
 	)
 
+	// deploy keys are authorized by the key only, independent of the principal of the session.
+	if deployKeyMetadata, ok := session.Metadata.(*auth.DeployKeyMetadata); ok {
+		return a.checkWithDeployKeyMetadata(ctx, deployKeyMetadata, scope, resource, permission)
+	}
+
 	if session.Principal.Admin {
 		return true, nil // system admin can call any API
 	}
@@ -175,6 +185,41 @@ func (a *MembershipAuthorizer) CheckAll(
 	return true, nil
 }
 
+// checkWithDeployKeyMetadata checks access using the deploy key provided in the metadata.
+// Deploy keys grant git access to the repository they belong to, pushes are only allowed for read-write keys.
+func (a *MembershipAuthorizer) checkWithDeployKeyMetadata(
+	ctx context.Context,
+	deployKeyMetadata *auth.DeployKeyMetadata,
+	scope *types.Scope,
+	resource *types.Resource,
+	permission enum.Permission,
+) (bool, error) {
+	if resource.Type != enum.ResourceTypeRepo {
+		return false, nil
+	}
+
+	switch permission {
+	case enum.PermissionRepoView:
+		// fetches are allowed with any deploy key of the repository.
+	case enum.PermissionRepoPush:
+		if deployKeyMetadata.ReadOnly {
+			return false, nil
+		}
+	default:
+		return false, nil
+	}
+
+	repo, err := a.repoStore.FindByRef(ctx, paths.Concatenate(scope.SpacePath, resource.Identifier))
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+
+	return repo.ID == deployKeyMetadata.RepoID, nil
+}
+
 // checkWithMembershipMetadata checks access using the ephemeral membership provided in the metadata.
 func (a *MembershipAuthorizer) checkWithMembershipMetadata(
 	ctx context.Context,
@@ -0,0 +1,139 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// grantAllPermissions is a permission cache granting every permission, as if the principal owned all spaces.
+type grantAllPermissions struct {
+	PermissionCache
+}
+
+func (grantAllPermissions) Get(context.Context, PermissionCacheKey) (bool, error) {
+	return true, nil
+}
+
+// repoPaths is a repo store that finds the repos by their path.
+type repoPaths struct {
+	store.RepoStore
+	repos map[string]*types.Repository
+}
+
+func (s repoPaths) FindByRef(_ context.Context, repoRef string) (*types.Repository, error) {
+	repo, ok := s.repos[repoRef]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return repo, nil
+}
+
+func TestMembershipAuthorizer_DeployKey(t *testing.T) {
+	authorizer := NewMembershipAuthorizer(
+		grantAllPermissions{},
+		nil,
+		repoPaths{repos: map[string]*types.Repository{
+			"space/repo":  {ID: 1, Path: "space/repo"},
+			"space/other": {ID: 2, Path: "space/other"},
+		}},
+		publicResources{paths: map[string]bool{}},
+	)
+
+	scope := &types.Scope{SpacePath: "space"}
+
+	tests := []struct {
+		name       string
+		readOnly   bool
+		resource   *types.Resource
+		permission enum.Permission
+		want       bool
+	}{
+		{
+			name:       "view own repo",
+			resource:   &types.Resource{Type: enum.ResourceTypeRepo, Identifier: "repo"},
+			permission: enum.PermissionRepoView,
+			want:       true,
+		},
+		{
+			name:       "push own repo",
+			resource:   &types.Resource{Type: enum.ResourceTypeRepo, Identifier: "repo"},
+			permission: enum.PermissionRepoPush,
+			want:       true,
+		},
+		{
+			name:       "push own repo with read-only key",
+			readOnly:   true,
+			resource:   &types.Resource{Type: enum.ResourceTypeRepo, Identifier: "repo"},
+			permission: enum.PermissionRepoPush,
+			want:       false,
+		},
+		{
+			name:       "view other repo",
+			resource:   &types.Resource{Type: enum.ResourceTypeRepo, Identifier: "other"},
+			permission: enum.PermissionRepoView,
+			want:       false,
+		},
+		{
+			name:       "push other repo",
+			resource:   &types.Resource{Type: enum.ResourceTypeRepo, Identifier: "other"},
+			permission: enum.PermissionRepoPush,
+			want:       false,
+		},
+		{
+			name:       "view unknown repo",
+			resource:   &types.Resource{Type: enum.ResourceTypeRepo, Identifier: "unknown"},
+			permission: enum.PermissionRepoView,
+			want:       false,
+		},
+		{
+			name:       "edit own repo",
+			resource:   &types.Resource{Type: enum.ResourceTypeRepo, Identifier: "repo"},
+			permission: enum.PermissionRepoEdit,
+			want:       false,
+		},
+		{
+			name:       "view space",
+			resource:   &types.Resource{Type: enum.ResourceTypeSpace, Identifier: "space"},
+			permission: enum.PermissionSpaceView,
+			want:       false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// the principal is an admin with access to everything, the deploy key restricts the session regardless.
+			session := &auth.Session{
+				Principal: types.Principal{ID: 42, Admin: true},
+				Metadata:  &auth.DeployKeyMetadata{DeployKeyID: 7, RepoID: 1, ReadOnly: test.readOnly},
+			}
+
+			got, err := authorizer.Check(context.Background(), session, scope, test.resource, test.permission)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != test.want {
+				t.Errorf("expected %t, got %t", test.want, got)
+			}
+		})
+	}
+}
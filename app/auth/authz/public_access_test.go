@@ -0,0 +1,116 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// publicResources is a public access service that reports the configured resource paths as public.
+type publicResources struct {
+	publicaccess.Service
+	paths map[string]bool
+}
+
+func (s publicResources) Get(_ context.Context, _ enum.PublicResourceType, path string) (bool, error) {
+	return s.paths[path], nil
+}
+
+func TestCheckPublicAccess(t *testing.T) {
+	ctx := context.Background()
+	scope := &types.Scope{SpacePath: "space", Repo: "repo"}
+
+	tests := []struct {
+		name       string
+		svc        publicaccess.Service
+		resource   *types.Resource
+		permission enum.Permission
+		want       bool
+	}{
+		{
+			name:       "public repo view",
+			svc:        publicResources{paths: map[string]bool{"space/repo": true}},
+			resource:   &types.Resource{Type: enum.ResourceTypeRepo, Identifier: "repo"},
+			permission: enum.PermissionRepoView,
+			want:       true,
+		},
+		{
+			name:       "public repo push",
+			svc:        publicResources{paths: map[string]bool{"space/repo": true}},
+			resource:   &types.Resource{Type: enum.ResourceTypeRepo, Identifier: "repo"},
+			permission: enum.PermissionRepoPush,
+			want:       false,
+		},
+		{
+			name:       "public space view",
+			svc:        publicResources{paths: map[string]bool{"space/child": true}},
+			resource:   &types.Resource{Type: enum.ResourceTypeSpace, Identifier: "child"},
+			permission: enum.PermissionSpaceView,
+			want:       true,
+		},
+		{
+			name:       "private repo view",
+			svc:        publicResources{paths: map[string]bool{}},
+			resource:   &types.Resource{Type: enum.ResourceTypeRepo, Identifier: "repo"},
+			permission: enum.PermissionRepoView,
+			want:       false,
+		},
+		{
+			name:       "repo view with public access disabled",
+			svc:        publicaccess.NewService(false, nil, nil, nil, nil),
+			resource:   &types.Resource{Type: enum.ResourceTypeRepo, Identifier: "repo"},
+			permission: enum.PermissionRepoView,
+			want:       false,
+		},
+		{
+			name:       "repo listing with public access disabled",
+			svc:        publicaccess.NewService(false, nil, nil, nil, nil),
+			resource:   &types.Resource{Type: enum.ResourceTypeRepo},
+			permission: enum.PermissionRepoView,
+			want:       false,
+		},
+		{
+			name:       "space view with public access disabled",
+			svc:        publicaccess.NewService(false, nil, nil, nil, nil),
+			resource:   &types.Resource{Type: enum.ResourceTypeSpace, Identifier: "child"},
+			permission: enum.PermissionSpaceView,
+			want:       false,
+		},
+		{
+			name:       "pipeline view with public access disabled",
+			svc:        publicaccess.NewService(false, nil, nil, nil, nil),
+			resource:   &types.Resource{Type: enum.ResourceTypePipeline, Identifier: "pipeline"},
+			permission: enum.PermissionPipelineView,
+			want:       false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := CheckPublicAccess(ctx, test.svc, scope, test.resource, test.permission)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != test.want {
+				t.Errorf("want=%t got=%t", test.want, got)
+			}
+		})
+	}
+}
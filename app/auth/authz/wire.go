@@ -32,9 +32,10 @@ var WireSet = wire.NewSet(
 func ProvideAuthorizer(
 	pCache PermissionCache,
 	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
 	publicAccess publicaccess.Service,
 ) Authorizer {
-	return NewMembershipAuthorizer(pCache, spaceStore, publicAccess)
+	return NewMembershipAuthorizer(pCache, spaceStore, repoStore, publicAccess)
 }
 
 func ProvidePermissionCache(
@@ -0,0 +1,52 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+)
+
+// csrfTokenLabel is mixed into the CSRF token to keep it distinct from other values derived from the session.
+const csrfTokenLabel = "gitness-csrf"
+
+// CSRFToken returns the CSRF token bound to the provided session token.
+// The token is derived from the session token, so it changes whenever a new session is issued.
+func CSRFToken(sessionToken string) string {
+	mac := hmac.New(sha256.New, []byte(sessionToken))
+	mac.Write([]byte(csrfTokenLabel))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// CSRFCookieName returns the name of the cookie that carries the CSRF token for the session cookie.
+func CSRFCookieName(sessionCookieName string) string {
+	return sessionCookieName + "_csrf"
+}
+
+// NewCSRFCookie returns the cookie carrying the CSRF token of the provided session token.
+// Unlike the session cookie, it's readable by scripts so the UI can echo it in the request header.
+func NewCSRFCookie(r *http.Request, sessionCookieName string, sessionToken string) *http.Cookie {
+	return &http.Cookie{
+		Name:     CSRFCookieName(sessionCookieName),
+		Value:    CSRFToken(sessionToken),
+		SameSite: http.SameSiteStrictMode,
+		HttpOnly: false,
+		Path:     "/",
+		Domain:   r.URL.Hostname(),
+		Secure:   r.URL.Scheme == "https",
+	}
+}
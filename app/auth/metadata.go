@@ -58,3 +58,15 @@ type AccessPermissionMetadata struct {
 func (m *AccessPermissionMetadata) ImpactsAuthorization() bool {
 	return true
 }
+
+// DeployKeyMetadata contains information about the deploy key that was used during auth.
+// Access is limited to git operations on the repository the key belongs to.
+type DeployKeyMetadata struct {
+	DeployKeyID int64
+	RepoID      int64
+	ReadOnly    bool
+}
+
+func (m *DeployKeyMetadata) ImpactsAuthorization() bool {
+	return true
+}
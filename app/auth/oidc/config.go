@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"errors"
+	"net/url"
+	"slices"
+	"time"
+)
+
+type Config struct {
+	Enabled     bool
+	DisplayName string
+
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	RedirectURL  string
+
+	ClaimEmail    string
+	ClaimName     string
+	ClaimUsername string
+
+	AutoProvision       bool
+	LinkExistingByEmail bool
+
+	DiscoveryTimeout  time.Duration
+	DiscoveryCacheTTL time.Duration
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if !c.Enabled {
+		return nil
+	}
+	if _, err := url.ParseRequestURI(c.IssuerURL); err != nil {
+		return errors.New("config.IssuerURL has to be a valid url (GITNESS_OIDC_ISSUER_URL)")
+	}
+	if c.ClientID == "" {
+		return errors.New("config.ClientID is required (GITNESS_OIDC_CLIENT_ID)")
+	}
+	if _, err := url.ParseRequestURI(c.RedirectURL); err != nil {
+		return errors.New("config.RedirectURL has to be a valid url (GITNESS_OIDC_REDIRECT_URL)")
+	}
+	if !slices.Contains(c.Scopes, scopeOpenID) {
+		c.Scopes = append([]string{scopeOpenID}, c.Scopes...)
+	}
+	if c.ClaimEmail == "" {
+		return errors.New("config.ClaimEmail is required (GITNESS_OIDC_CLAIM_EMAIL)")
+	}
+	if c.DiscoveryTimeout <= 0 {
+		return errors.New("config.DiscoveryTimeout has to be a positive duration")
+	}
+	if c.DiscoveryCacheTTL <= 0 {
+		return errors.New("config.DiscoveryCacheTTL has to be a positive duration")
+	}
+
+	return nil
+}
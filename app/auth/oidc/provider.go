@@ -0,0 +1,398 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/oauth2"
+)
+
+const (
+	scopeOpenID = "openid"
+
+	discoveryPath = "/.well-known/openid-configuration"
+
+	// maxMetadataSize is the maximum size of the discovery document and key set of the identity provider.
+	maxMetadataSize = 1 << 20
+
+	// minKeyRefreshInterval is the minimum time between two fetches of the key set
+	// that are triggered by ID tokens signed with an unknown key.
+	minKeyRefreshInterval = time.Minute
+)
+
+var (
+	// ErrDisabled is returned if the OIDC login is used while it's disabled.
+	ErrDisabled = errors.New("oidc login is disabled")
+
+	// ErrInvalidToken is returned if the ID token returned by the identity provider is invalid.
+	ErrInvalidToken = errors.New("invalid id token")
+)
+
+// supportedSigningMethods are the asymmetric signing methods accepted for ID tokens.
+var supportedSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+// Identity contains the details of a user authenticated by the identity provider.
+type Identity struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Username      string
+}
+
+type discoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Provider implements the authorization code flow against an OpenID Connect identity provider.
+// The discovery document and signing keys of the identity provider are cached.
+type Provider struct {
+	config Config
+	client *http.Client
+
+	mx             sync.Mutex
+	discovery      *discoveryDocument
+	discoveryTime  time.Time
+	keys           map[string]crypto.PublicKey
+	keysTime       time.Time
+	keysRefreshTry time.Time
+}
+
+func NewProvider(config Config) (*Provider, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided oidc config is invalid: %w", err)
+	}
+
+	return &Provider{
+		config: config,
+		client: &http.Client{Timeout: config.DiscoveryTimeout},
+	}, nil
+}
+
+// Enabled returns true if users can log in via the identity provider.
+func (p *Provider) Enabled() bool {
+	return p.config.Enabled
+}
+
+// Config returns the config of the provider.
+func (p *Provider) Config() Config {
+	return p.config
+}
+
+// AuthCodeURL returns the URL of the identity provider the user has to be redirected to for logging in.
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce string) (string, error) {
+	if !p.config.Enabled {
+		return "", ErrDisabled
+	}
+
+	oauthConfig, err := p.oauthConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return oauthConfig.AuthCodeURL(state, oauth2.SetAuthURLParam("nonce", nonce)), nil
+}
+
+// Exchange exchanges the authorization code for an ID token, verifies it and returns the identity it contains.
+func (p *Provider) Exchange(ctx context.Context, code, nonce string) (*Identity, error) {
+	if !p.config.Enabled {
+		return nil, ErrDisabled
+	}
+
+	oauthConfig, err := p.oauthConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := oauthConfig.Exchange(context.WithValue(ctx, oauth2.HTTPClient, p.client), code)
+	if err != nil {
+		var retrieveErr *oauth2.RetrieveError
+		if errors.As(err, &retrieveErr) {
+			return nil, fmt.Errorf("token endpoint rejected the authorization code (error=%q, description=%q), "+
+				"check GITNESS_OIDC_CLIENT_ID, GITNESS_OIDC_CLIENT_SECRET and GITNESS_OIDC_REDIRECT_URL: %w",
+				retrieveErr.ErrorCode, retrieveErr.ErrorDescription, err)
+		}
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, fmt.Errorf("token response doesn't contain an id_token, "+
+			"check that GITNESS_OIDC_SCOPES contains %q: %w", scopeOpenID, ErrInvalidToken)
+	}
+
+	return p.verify(ctx, rawIDToken, nonce)
+}
+
+func (p *Provider) oauthConfig(ctx context.Context) (*oauth2.Config, error) {
+	discovery, err := p.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  discovery.AuthorizationEndpoint,
+			TokenURL: discovery.TokenEndpoint,
+		},
+		RedirectURL: p.config.RedirectURL,
+		Scopes:      p.config.Scopes,
+	}, nil
+}
+
+// verify verifies the signature and the claims of the ID token and returns the identity it contains.
+func (p *Provider) verify(ctx context.Context, rawIDToken, nonce string) (*Identity, error) {
+	discovery, err := p.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := &jwt.Parser{ValidMethods: supportedSigningMethods}
+	claims := jwt.MapClaims{}
+	_, err = parser.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.getKey(ctx, discovery, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify id token: %s: %w", err, ErrInvalidToken)
+	}
+
+	if iss, _ := claims["iss"].(string); iss != discovery.Issuer {
+		return nil, fmt.Errorf("id token issuer %q doesn't match %q: %w", iss, discovery.Issuer, ErrInvalidToken)
+	}
+
+	audiences := stringsClaim(claims, "aud")
+	if !slices.Contains(audiences, p.config.ClientID) {
+		return nil, fmt.Errorf("id token audience %v doesn't contain the client id: %w", audiences, ErrInvalidToken)
+	}
+	if azp, ok := claims["azp"].(string); (ok || len(audiences) > 1) && azp != p.config.ClientID {
+		return nil, fmt.Errorf("id token authorized party %q isn't the client id: %w", azp, ErrInvalidToken)
+	}
+
+	if claimNonce, _ := claims["nonce"].(string); nonce == "" || claimNonce != nonce {
+		return nil, fmt.Errorf("id token nonce doesn't match the nonce of the login: %w", ErrInvalidToken)
+	}
+
+	identity := &Identity{
+		Issuer:        discovery.Issuer,
+		EmailVerified: boolClaim(claims, "email_verified"),
+	}
+	identity.Subject, _ = claims["sub"].(string)
+	identity.Email, _ = claims[p.config.ClaimEmail].(string)
+	identity.Name, _ = claims[p.config.ClaimName].(string)
+	identity.Username, _ = claims[p.config.ClaimUsername].(string)
+
+	if identity.Subject == "" {
+		return nil, fmt.Errorf("id token is missing the sub claim: %w", ErrInvalidToken)
+	}
+	if identity.Email == "" {
+		return nil, fmt.Errorf("id token is missing the %q claim, "+
+			"check GITNESS_OIDC_CLAIM_EMAIL and that GITNESS_OIDC_SCOPES requests it: %w",
+			p.config.ClaimEmail, ErrInvalidToken)
+	}
+
+	return identity, nil
+}
+
+// getDiscovery returns the discovery document of the identity provider.
+// A cached document is used while it's fresh, or if the identity provider is temporarily unavailable.
+func (p *Provider) getDiscovery(ctx context.Context) (*discoveryDocument, error) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	if p.discovery != nil && time.Since(p.discoveryTime) < p.config.DiscoveryCacheTTL {
+		return p.discovery, nil
+	}
+
+	discovery, err := p.fetchDiscovery(ctx)
+	if err != nil && p.discovery != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to refresh oidc discovery document, using cached document")
+		return p.discovery, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	p.discovery = discovery
+	p.discoveryTime = time.Now()
+
+	return discovery, nil
+}
+
+func (p *Provider) fetchDiscovery(ctx context.Context) (*discoveryDocument, error) {
+	discoveryURL := strings.TrimSuffix(p.config.IssuerURL, "/") + discoveryPath
+
+	discovery := &discoveryDocument{}
+	if err := p.fetchJSON(ctx, discoveryURL, discovery); err != nil {
+		return nil, fmt.Errorf("failed to fetch oidc discovery document, check GITNESS_OIDC_ISSUER_URL: %w", err)
+	}
+
+	// the issuer has to match exactly, otherwise ID tokens would fail verification later on.
+	if discovery.Issuer != p.config.IssuerURL {
+		return nil, fmt.Errorf("issuer %q of the oidc discovery document doesn't match the configured issuer %q, "+
+			"set GITNESS_OIDC_ISSUER_URL to the exact issuer", discovery.Issuer, p.config.IssuerURL)
+	}
+
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, fmt.Errorf("oidc discovery document of %q is missing the authorization, token or jwks endpoint",
+			discoveryURL)
+	}
+
+	return discovery, nil
+}
+
+// getKey returns the public key with the provided key id used by the identity provider to sign ID tokens.
+// If the key isn't known, the key set is refreshed, as the identity provider might have rotated its keys.
+func (p *Provider) getKey(ctx context.Context, discovery *discoveryDocument, kid string) (crypto.PublicKey, error) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	now := time.Now()
+	fresh := p.keys != nil && now.Sub(p.keysTime) < p.config.DiscoveryCacheTTL
+
+	if key, ok := p.findKey(kid); ok && fresh {
+		return key, nil
+	}
+
+	if !fresh || now.Sub(p.keysRefreshTry) >= minKeyRefreshInterval {
+		p.keysRefreshTry = now
+
+		keys, err := p.fetchKeys(ctx, discovery.JWKSURI)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to refresh oidc signing keys")
+		} else {
+			p.keys = keys
+			p.keysTime = now
+		}
+	}
+
+	if key, ok := p.findKey(kid); ok {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("signing key %q not found in the key set of the identity provider", kid)
+}
+
+// findKey finds the key with the provided id. Tokens without key id are accepted if there's only one key.
+func (p *Provider) findKey(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+
+	key, ok := p.keys[kid]
+	return key, ok
+}
+
+func (p *Provider) fetchKeys(ctx context.Context, jwksURI string) (map[string]crypto.PublicKey, error) {
+	set := &jsonWebKeySet{}
+	if err := p.fetchJSON(ctx, jwksURI, set); err != nil {
+		return nil, fmt.Errorf("failed to fetch oidc key set: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		key, err := k.publicKey()
+		if err != nil {
+			log.Ctx(ctx).Debug().Err(err).Str("kid", k.Kid).Msg("skipping unsupported oidc signing key")
+			continue
+		}
+
+		keys[k.Kid] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("key set %q doesn't contain any supported signing keys", jwksURI)
+	}
+
+	return keys, nil
+}
+
+func (p *Provider) fetchJSON(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request to %q failed: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request to %q failed with status %d", url, resp.StatusCode)
+	}
+
+	if err = json.NewDecoder(io.LimitReader(resp.Body, maxMetadataSize)).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response of %q: %w", url, err)
+	}
+
+	return nil
+}
+
+func stringsClaim(claims jwt.MapClaims, name string) []string {
+	switch v := claims[name].(type) {
+	case string:
+		return []string{v}
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, e := range v {
+			if s, ok := e.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// boolClaim returns the value of a boolean claim. Some identity providers encode booleans as strings.
+func boolClaim(claims jwt.MapClaims, name string) bool {
+	switch v := claims[name].(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	default:
+		return false
+	}
+}
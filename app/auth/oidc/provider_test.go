@@ -0,0 +1,281 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	testClientID = "gitness"
+	testKeyID    = "key-1"
+)
+
+type testIdP struct {
+	server    *httptest.Server
+	key       *rsa.PrivateKey
+	issuer    string
+	claims    jwt.MapClaims
+	discovery int
+}
+
+func newTestIdP(t *testing.T) *testIdP {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %s", err)
+	}
+
+	idp := &testIdP{key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(discoveryPath, func(w http.ResponseWriter, _ *http.Request) {
+		idp.discovery++
+		_ = json.NewEncoder(w).Encode(discoveryDocument{
+			Issuer:                idp.issuer,
+			AuthorizationEndpoint: idp.server.URL + "/authorize",
+			TokenEndpoint:         idp.server.URL + "/token",
+			JWKSURI:               idp.server.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(jsonWebKeySet{Keys: []jsonWebKey{{
+			Kty: "RSA",
+			Kid: testKeyID,
+			Use: "sig",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.Form.Get("code") != "valid-code" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, idp.claims)
+		token.Header["kid"] = testKeyID
+		idToken, err := token.SignedString(key)
+		if err != nil {
+			t.Errorf("failed to sign id token: %s", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"id_token":     idToken,
+		})
+	})
+
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+
+	idp.issuer = idp.server.URL
+
+	return idp
+}
+
+func (idp *testIdP) provider(t *testing.T) *Provider {
+	t.Helper()
+
+	p, err := NewProvider(Config{
+		Enabled:           true,
+		IssuerURL:         idp.server.URL,
+		ClientID:          testClientID,
+		ClientSecret:      "secret",
+		Scopes:            []string{"email"},
+		RedirectURL:       "http://localhost:3000/api/v1/auth/oidc/callback",
+		ClaimEmail:        "email",
+		ClaimName:         "name",
+		ClaimUsername:     "preferred_username",
+		DiscoveryTimeout:  time.Second,
+		DiscoveryCacheTTL: time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("failed to create provider: %s", err)
+	}
+
+	return p
+}
+
+func validClaims(issuer, nonce string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":                issuer,
+		"sub":                "subject-1",
+		"aud":                testClientID,
+		"exp":                time.Now().Add(time.Minute).Unix(),
+		"iat":                time.Now().Add(-time.Minute).Unix(),
+		"nonce":              nonce,
+		"email":              "jane@example.com",
+		"email_verified":     true,
+		"name":               "Jane Doe",
+		"preferred_username": "jane",
+	}
+}
+
+func TestProvider_AuthCodeURL(t *testing.T) {
+	idp := newTestIdP(t)
+	p := idp.provider(t)
+
+	for range 3 {
+		raw, err := p.AuthCodeURL(context.Background(), "state-1", "nonce-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		u, err := url.Parse(raw)
+		if err != nil {
+			t.Fatalf("invalid url: %s", err)
+		}
+
+		q := u.Query()
+		if q.Get("state") != "state-1" || q.Get("nonce") != "nonce-1" || q.Get("client_id") != testClientID {
+			t.Errorf("unexpected auth code url %q", raw)
+		}
+		if q.Get("scope") != "openid email" {
+			t.Errorf("got scope %q, want the openid scope added", q.Get("scope"))
+		}
+	}
+
+	if idp.discovery != 1 {
+		t.Errorf("discovery document was fetched %d times, want it to be cached", idp.discovery)
+	}
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	idp := newTestIdP(t)
+
+	tests := []struct {
+		name       string
+		code       string
+		nonce      string
+		mutate     func(c jwt.MapClaims)
+		wantErr    bool
+		wantTokErr bool
+	}{
+		{
+			name:  "valid",
+			code:  "valid-code",
+			nonce: "nonce-1",
+		},
+		{
+			name:       "nonce mismatch",
+			code:       "valid-code",
+			nonce:      "other-nonce",
+			wantErr:    true,
+			wantTokErr: true,
+		},
+		{
+			name:       "wrong audience",
+			code:       "valid-code",
+			nonce:      "nonce-1",
+			mutate:     func(c jwt.MapClaims) { c["aud"] = "other-client" },
+			wantErr:    true,
+			wantTokErr: true,
+		},
+		{
+			name:       "wrong issuer",
+			code:       "valid-code",
+			nonce:      "nonce-1",
+			mutate:     func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" },
+			wantErr:    true,
+			wantTokErr: true,
+		},
+		{
+			name:       "expired",
+			code:       "valid-code",
+			nonce:      "nonce-1",
+			mutate:     func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Minute).Unix() },
+			wantErr:    true,
+			wantTokErr: true,
+		},
+		{
+			name:       "missing email",
+			code:       "valid-code",
+			nonce:      "nonce-1",
+			mutate:     func(c jwt.MapClaims) { delete(c, "email") },
+			wantErr:    true,
+			wantTokErr: true,
+		},
+		{
+			name:    "invalid code",
+			code:    "invalid-code",
+			nonce:   "nonce-1",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			idp.claims = validClaims(idp.issuer, "nonce-1")
+			if test.mutate != nil {
+				test.mutate(idp.claims)
+			}
+
+			identity, err := idp.provider(t).Exchange(context.Background(), test.code, test.nonce)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+				if errors.Is(err, ErrInvalidToken) != test.wantTokErr {
+					t.Errorf("got error %q, want invalid token error: %t", err, test.wantTokErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			want := Identity{
+				Issuer:        idp.issuer,
+				Subject:       "subject-1",
+				Email:         "jane@example.com",
+				EmailVerified: true,
+				Name:          "Jane Doe",
+				Username:      "jane",
+			}
+			if *identity != want {
+				t.Errorf("got identity %+v, want %+v", *identity, want)
+			}
+		})
+	}
+}
+
+func TestProvider_IssuerMismatch(t *testing.T) {
+	idp := newTestIdP(t)
+	p := idp.provider(t)
+	idp.issuer = "https://other.example.com"
+
+	if _, err := p.AuthCodeURL(context.Background(), "state", "nonce"); err == nil {
+		t.Errorf("expected an error for a discovery document of another issuer")
+	}
+}
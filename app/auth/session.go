@@ -25,4 +25,11 @@ type Session struct {
 
 	// Metadata contains auth related information (access grants, tokenId, sshKeyId, ...)
 	Metadata Metadata
+
+	// ExpiresAt is the unix time (in milliseconds) at which the session expires, 0 if it doesn't expire.
+	ExpiresAt int64
+
+	// FromCookie is true if the session was authenticated using the session cookie.
+	// Such sessions are subject to CSRF protection.
+	FromCookie bool
 }
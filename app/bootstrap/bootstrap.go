@@ -18,13 +18,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/harness/gitness/app/api/controller/service"
 	"github.com/harness/gitness/app/api/controller/user"
 	"github.com/harness/gitness/app/auth"
+	appstore "github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 
+	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 )
 
@@ -63,12 +66,33 @@ func NewGitspaceServiceSession() *auth.Session {
 	}
 }
 
+// deployKeyServicePrincipal is the principal that is used for git operations
+// authenticated with a repository deploy key.
+var deployKeyServicePrincipal *types.Principal
+
+// NewDeployKeyServiceSession returns the session of git operations authenticated with the provided deploy key.
+// Access of the session is restricted to the repository of the deploy key.
+func NewDeployKeyServiceSession(deployKey *types.DeployKey) *auth.Session {
+	return &auth.Session{
+		Principal: *deployKeyServicePrincipal,
+		Metadata: &auth.DeployKeyMetadata{
+			DeployKeyID: deployKey.ID,
+			RepoID:      deployKey.RepoID,
+			ReadOnly:    deployKey.ReadOnly,
+		},
+	}
+}
+
 // Bootstrap is an abstraction of a function that bootstraps a system.
 type Bootstrap func(context.Context) error
 
 func System(config *types.Config, userCtrl *user.Controller,
-	serviceCtrl *service.Controller) func(context.Context) error {
+	serviceCtrl *service.Controller, installationStore appstore.InstallationStore) func(context.Context) error {
 	return func(ctx context.Context) error {
+		if err := Installation(ctx, installationStore); err != nil {
+			return fmt.Errorf("failed to setup installation record: %w", err)
+		}
+
 		if err := SystemService(ctx, config, serviceCtrl); err != nil {
 			return fmt.Errorf("failed to setup system service: %w", err)
 		}
@@ -80,6 +104,10 @@ func System(config *types.Config, userCtrl *user.Controller,
 			return fmt.Errorf("failed to setup gitspace service: %w", err)
 		}
 
+		if err := DeployKeyService(ctx, config, serviceCtrl); err != nil {
+			return fmt.Errorf("failed to setup deploy key service: %w", err)
+		}
+
 		if err := AdminUser(ctx, config, userCtrl); err != nil {
 			return fmt.Errorf("failed to setup admin user: %w", err)
 		}
@@ -88,6 +116,36 @@ func System(config *types.Config, userCtrl *user.Controller,
 	}
 }
 
+// Installation creates the installation record of the instance on first startup.
+// The installer is set once the first user is created.
+func Installation(ctx context.Context, installationStore appstore.InstallationStore) error {
+	_, err := installationStore.Find(ctx)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to find installation record: %w", err)
+	}
+
+	installation := &types.Installation{
+		UUID:      uuid.NewString(),
+		Installed: time.Now().UnixMilli(),
+	}
+
+	err = installationStore.Create(ctx, installation)
+	if errors.Is(err, store.ErrDuplicate) {
+		// record might've been created by another instance in the meantime.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create installation record: %w", err)
+	}
+
+	log.Ctx(ctx).Info().Msgf("Created installation record of instance '%s'.", installation.UUID)
+
+	return nil
+}
+
 // AdminUser sets up the admin user based on the config (if provided).
 func AdminUser(ctx context.Context, config *types.Config, userCtrl *user.Controller) error {
 	if config.Principal.Admin.Password == "" {
@@ -240,6 +298,40 @@ func GitspaceService(
 	return nil
 }
 
+// DeployKeyService sets up the deploy key service principal that is used for
+// git operations authenticated with a repository deploy key.
+// The service is no admin and has no memberships, deploy keys are authorized by their repository only.
+func DeployKeyService(
+	ctx context.Context,
+	config *types.Config,
+	serviceCtrl *service.Controller,
+) error {
+	svc, err := serviceCtrl.FindNoAuth(ctx, config.Principal.DeployKey.UID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		svc, err = createServicePrincipal(
+			ctx,
+			serviceCtrl,
+			config.Principal.DeployKey.UID,
+			config.Principal.DeployKey.Email,
+			config.Principal.DeployKey.DisplayName,
+			false,
+		)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to setup deploy key service: %w", err)
+	}
+	if svc.Admin {
+		return fmt.Errorf("service with uid '%s' exists but is an admin (ID: %d)", svc.UID, svc.ID)
+	}
+
+	deployKeyServicePrincipal = svc.ToPrincipal()
+
+	log.Ctx(ctx).Info().Msgf("Completed setup of deploy key service '%s' (id: %d).", svc.UID, svc.ID)
+
+	return nil
+}
+
 func createServicePrincipal(
 	ctx context.Context,
 	serviceCtrl *service.Controller,
@@ -17,6 +17,7 @@ package bootstrap
 import (
 	"github.com/harness/gitness/app/api/controller/service"
 	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
@@ -26,6 +27,6 @@ import (
 var WireSet = wire.NewSet(ProvideBootstrap)
 
 func ProvideBootstrap(config *types.Config, userCtrl *user.Controller,
-	serviceCtrl *service.Controller) Bootstrap {
-	return System(config, userCtrl, serviceCtrl)
+	serviceCtrl *service.Controller, installationStore store.InstallationStore) Bootstrap {
+	return System(config, userCtrl, serviceCtrl, installationStore)
 }
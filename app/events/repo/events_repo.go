@@ -53,6 +53,8 @@ type DefaultBranchUpdatedPayload struct {
 	PrincipalID int64  `json:"principal_id"`
 	OldName     string `json:"old_name"`
 	NewName     string `json:"new_name"`
+	// Automatic is true if the default branch was detected from the first push to the repository.
+	Automatic bool `json:"automatic,omitempty"`
 }
 
 func (r *Reporter) DefaultBranchUpdated(ctx context.Context, payload *DefaultBranchUpdatedPayload) {
@@ -69,3 +71,53 @@ func (r *Reader) RegisterDefaultBranchUpdated(fn events.HandlerFunc[*DefaultBran
 	opts ...events.HandlerOption) error {
 	return events.ReaderRegisterEvent(r.innerReader, DefaultBranchUpdatedEvent, fn, opts...)
 }
+
+const CreatedEvent events.EventType = "created"
+
+type CreatedPayload struct {
+	RepoID      int64 `json:"repo_id"`
+	PrincipalID int64 `json:"principal_id"`
+}
+
+func (r *Reporter) Created(ctx context.Context, payload *CreatedPayload) {
+	if payload == nil {
+		return
+	}
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, CreatedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send repo created event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported repo created event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterCreated(fn events.HandlerFunc[*CreatedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, CreatedEvent, fn, opts...)
+}
+
+const UpdatedEvent events.EventType = "updated"
+
+type UpdatedPayload struct {
+	RepoID      int64 `json:"repo_id"`
+	PrincipalID int64 `json:"principal_id"`
+}
+
+func (r *Reporter) Updated(ctx context.Context, payload *UpdatedPayload) {
+	if payload == nil {
+		return
+	}
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, UpdatedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send repo updated event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported repo updated event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterUpdated(fn events.HandlerFunc[*UpdatedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, UpdatedEvent, fn, opts...)
+}
@@ -44,6 +44,7 @@ type Claims struct {
 	Token             *SubClaimsToken             `json:"tkn,omitempty"`
 	Membership        *SubClaimsMembership        `json:"ms,omitempty"`
 	AccessPermissions *SubClaimsAccessPermissions `json:"ap,omitempty"`
+	DeployKey         *SubClaimsDeployKey         `json:"dk,omitempty"`
 }
 
 // SubClaimsToken contains information about the token the JWT was created for.
@@ -58,6 +59,11 @@ type SubClaimsMembership struct {
 	SpaceID int64               `json:"sid,omitempty"`
 }
 
+// SubClaimsDeployKey contains information about the deploy key the JWT was created for.
+type SubClaimsDeployKey struct {
+	ID int64 `json:"id,omitempty"`
+}
+
 // SubClaimsAccessPermissions stores allowed actions on a resource.
 type SubClaimsAccessPermissions struct {
 	Source      Source              `json:"src,omitempty"`
@@ -161,3 +167,26 @@ func GenerateForTokenWithAccessPermissions(
 
 	return res, nil
 }
+
+// GenerateForDeployKey generates a jwt for git operations over http with the given deploy key.
+// The jwt doesn't expire, it's valid as long as the deploy key exists.
+func GenerateForDeployKey(principalID int64, deployKey *types.DeployKey, secret string) (string, error) {
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		StandardClaims: jwt.StandardClaims{
+			Issuer: issuer,
+			// times required to be in sec not millisec
+			IssuedAt: deployKey.Created / 1000,
+		},
+		PrincipalID: principalID,
+		DeployKey: &SubClaimsDeployKey{
+			ID: deployKey.ID,
+		},
+	})
+
+	res, err := jwtToken.SignedString([]byte(secret))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,247 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package approver
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/pipeline/manager"
+	"github.com/harness/gitness/app/pipeline/scheduler"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/errors"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Approver acts on stages that are blocked by an approval gate.
+type Approver interface {
+	// CanApprove returns true if the principal is allowed to decide on the approval gate of the stage.
+	CanApprove(
+		ctx context.Context,
+		principal *types.Principal,
+		repo *types.Repository,
+		stage *types.Stage,
+	) (bool, error)
+
+	// Decide applies the decision to the blocked stage: approved stages are scheduled for execution,
+	// rejected stages are failed and timed out stages are failed or skipped as declared by the gate.
+	Decide(
+		ctx context.Context,
+		principal *types.Principal,
+		repo *types.Repository,
+		stage *types.Stage,
+		decision enum.StageApprovalDecision,
+		comment string,
+	) (*types.StageApproval, error)
+}
+
+type service struct {
+	stageStore         store.StageStore
+	stageApprovalStore store.StageApprovalStore
+	spaceStore         store.SpaceStore
+	membershipStore    store.MembershipStore
+	scheduler          scheduler.Scheduler
+	manager            manager.ExecutionManager
+	sseStreamer        sse.Streamer
+	auditService       audit.Service
+}
+
+// New returns an approver that handles all approval gate decisions.
+func New(
+	stageStore store.StageStore,
+	stageApprovalStore store.StageApprovalStore,
+	spaceStore store.SpaceStore,
+	membershipStore store.MembershipStore,
+	scheduler scheduler.Scheduler,
+	manager manager.ExecutionManager,
+	sseStreamer sse.Streamer,
+	auditService audit.Service,
+) Approver {
+	return &service{
+		stageStore:         stageStore,
+		stageApprovalStore: stageApprovalStore,
+		spaceStore:         spaceStore,
+		membershipStore:    membershipStore,
+		scheduler:          scheduler,
+		manager:            manager,
+		sseStreamer:        sseStreamer,
+		auditService:       auditService,
+	}
+}
+
+func (s *service) CanApprove(
+	ctx context.Context,
+	principal *types.Principal,
+	repo *types.Repository,
+	stage *types.Stage,
+) (bool, error) {
+	gate := stage.Approval
+	if gate == nil {
+		return false, nil
+	}
+
+	if principal.Admin {
+		return true, nil
+	}
+
+	// a gate without restrictions can be approved by anyone allowed to execute the pipeline.
+	if len(gate.Roles) == 0 && len(gate.Approvers) == 0 {
+		return true, nil
+	}
+
+	if slices.Contains(gate.Approvers, principal.UID) {
+		return true, nil
+	}
+
+	if len(gate.Roles) == 0 {
+		return false, nil
+	}
+
+	// memberships are inherited, so check the repo's space and all of its ancestors.
+	spaceIDs, err := s.spaceStore.GetAncestorIDs(ctx, repo.ParentID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get ancestors of space %d: %w", repo.ParentID, err)
+	}
+
+	for _, spaceID := range spaceIDs {
+		membership, err := s.membershipStore.Find(ctx, types.MembershipKey{
+			SpaceID:     spaceID,
+			PrincipalID: principal.ID,
+		})
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to find membership: %w", err)
+		}
+
+		if slices.Contains(gate.Roles, membership.Role) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+//nolint:gocognit // refactor if needed
+func (s *service) Decide(
+	ctx context.Context,
+	principal *types.Principal,
+	repo *types.Repository,
+	stage *types.Stage,
+	decision enum.StageApprovalDecision,
+	comment string,
+) (*types.StageApproval, error) {
+	if stage.Status != enum.CIStatusBlocked || stage.Approval == nil {
+		return nil, errors.PreconditionFailed("Stage %q is not waiting for approval", stage.Name)
+	}
+
+	log := log.Ctx(ctx).With().
+		Int64("stage.id", stage.ID).
+		Str("stage.name", stage.Name).
+		Str("decision", string(decision)).
+		Logger()
+
+	now := time.Now().UnixMilli()
+
+	var err error
+	switch {
+	case decision == enum.StageApprovalDecisionApproved:
+		stage.Status = enum.CIStatusPending
+		err = s.stageStore.Update(ctx, stage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update stage: %w", err)
+		}
+
+		err = s.scheduler.Schedule(ctx, stage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to schedule stage: %w", err)
+		}
+
+	case decision == enum.StageApprovalDecisionTimedOut &&
+		stage.Approval.OnTimeout == enum.StageApprovalTimeoutActionSkip:
+		stage.Status = enum.CIStatusSkipped
+		stage.Started = now
+		stage.Stopped = now
+
+		// tear down the stage to let the execution continue with the downstream stages.
+		err = s.manager.AfterStage(ctx, stage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to skip stage: %w", err)
+		}
+
+	default:
+		stage.Status = enum.CIStatusFailure
+		stage.Error = fmt.Sprintf("stage approval %s", decision)
+		stage.Started = now
+		stage.Stopped = now
+
+		err = s.manager.AfterStage(ctx, stage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fail stage: %w", err)
+		}
+	}
+
+	approval := &types.StageApproval{
+		ExecutionID: stage.ExecutionID,
+		StageID:     stage.ID,
+		StageNumber: stage.Number,
+		RepoID:      repo.ID,
+		Decision:    decision,
+		Comment:     comment,
+		CreatedBy:   principal.ID,
+		Created:     now,
+		Author:      principal.ToPrincipalInfo(),
+	}
+
+	err = s.stageApprovalStore.Create(ctx, approval)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record stage approval: %w", err)
+	}
+
+	if decision != enum.StageApprovalDecisionTimedOut {
+		action := audit.ActionApproved
+		if decision == enum.StageApprovalDecisionRejected {
+			action = audit.ActionRejected
+		}
+
+		err = s.auditService.Log(ctx,
+			*principal,
+			audit.NewResource(audit.ResourceTypePipelineStage, stage.Name, audit.RepoName, repo.Identifier),
+			action,
+			paths.Parent(repo.Path),
+			audit.WithNewObject(approval),
+		)
+		if err != nil {
+			log.Warn().Err(err).Msg("failed to insert audit log for stage approval operation")
+		}
+	}
+
+	err = s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeStageApprovalDecided, approval)
+	if err != nil {
+		log.Warn().Err(err).Msg("failed to publish stage approval decided event")
+	}
+
+	return approval, nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package approver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeApprovalTimeouts        = "gitness:pipeline:approval-timeouts"
+	jobCronApprovalTimeouts        = "* * * * *" // Every minute.
+	jobMaxDurationApprovalTimeouts = 1 * time.Minute
+)
+
+// Expirer periodically resolves approval gates that weren't decided before their deadline.
+type Expirer struct {
+	scheduler  *job.Scheduler
+	executor   *job.Executor
+	approver   Approver
+	stageStore store.StageStore
+	repoStore  store.RepoStore
+}
+
+func NewExpirer(
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	approver Approver,
+	stageStore store.StageStore,
+	repoStore store.RepoStore,
+) *Expirer {
+	return &Expirer{
+		scheduler:  scheduler,
+		executor:   executor,
+		approver:   approver,
+		stageStore: stageStore,
+		repoStore:  repoStore,
+	}
+}
+
+func (e *Expirer) Register(ctx context.Context) error {
+	err := e.executor.Register(jobTypeApprovalTimeouts, e)
+	if err != nil {
+		return fmt.Errorf("failed to register job handler for stage approval timeouts: %w", err)
+	}
+
+	err = e.scheduler.AddRecurring(
+		ctx,
+		jobTypeApprovalTimeouts,
+		jobTypeApprovalTimeouts,
+		jobCronApprovalTimeouts,
+		jobMaxDurationApprovalTimeouts,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule stage approval timeouts job: %w", err)
+	}
+
+	return nil
+}
+
+// Handle times out all blocked stages with an expired approval deadline.
+func (e *Expirer) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	stages, err := e.stageStore.ListBlocked(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list blocked stages: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	principal := bootstrap.NewSystemServiceSession().Principal
+
+	var count int
+	for _, stage := range stages {
+		if stage.Approval == nil || stage.Approval.Deadline == 0 || stage.Approval.Deadline > now {
+			continue
+		}
+
+		repo, err := e.repoStore.Find(ctx, stage.RepoID)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("stage.id", stage.ID).
+				Msg("failed to find repository of blocked stage")
+			continue
+		}
+
+		_, err = e.approver.Decide(ctx, &principal, repo, stage, enum.StageApprovalDecisionTimedOut, "")
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("stage.id", stage.ID).
+				Msg("failed to time out stage approval")
+			continue
+		}
+
+		count++
+	}
+
+	result := "no stage approvals timed out"
+	if count > 0 {
+		result = fmt.Sprintf("timed out %d stage approvals", count)
+	}
+
+	return result, nil
+}
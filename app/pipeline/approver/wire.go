@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package approver
+
+import (
+	"github.com/harness/gitness/app/pipeline/manager"
+	"github.com/harness/gitness/app/pipeline/scheduler"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/job"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideApprover,
+	ProvideExpirer,
+)
+
+// ProvideApprover provides an approver for stages blocked by an approval gate.
+func ProvideApprover(
+	stageStore store.StageStore,
+	stageApprovalStore store.StageApprovalStore,
+	spaceStore store.SpaceStore,
+	membershipStore store.MembershipStore,
+	scheduler scheduler.Scheduler,
+	manager manager.ExecutionManager,
+	sseStreamer sse.Streamer,
+	auditService audit.Service,
+) Approver {
+	return New(stageStore, stageApprovalStore, spaceStore, membershipStore,
+		scheduler, manager, sseStreamer, auditService)
+}
+
+// ProvideExpirer provides the job that times out undecided approval gates.
+func ProvideExpirer(
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	approver Approver,
+	stageStore store.StageStore,
+	repoStore store.RepoStore,
+) *Expirer {
+	return NewExpirer(scheduler, executor, approver, stageStore, repoStore)
+}
@@ -0,0 +1,167 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package canceler
+
+import (
+	"context"
+	"fmt"
+
+	events "github.com/harness/gitness/app/events/pipeline"
+	"github.com/harness/gitness/app/pipeline/scheduler"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// failer fails running executions on behalf of the server, e.g. because they ran too long
+// or because their runner went offline.
+type failer struct {
+	executionStore store.ExecutionStore
+	stageStore     store.StageStore
+	stepStore      store.StepStore
+	repoStore      store.RepoStore
+	scheduler      scheduler.Scheduler
+	sseStreamer    sse.Streamer
+	reporter       *events.Reporter
+}
+
+// fail persists the failed execution with its updated stages and steps
+// and signals the runner to stop the running steps.
+func (f *failer) fail(
+	ctx context.Context,
+	execution *types.Execution,
+	stages []*types.Stage,
+	updatedStages []*types.Stage,
+	updatedSteps []*types.Step,
+) error {
+	log := log.Ctx(ctx).With().
+		Int64("execution.id", execution.ID).
+		Logger()
+
+	// if the update fails due to an optimistic lock error the execution
+	// has been updated in the meantime and is checked again on the next run.
+	err := f.executionStore.Update(ctx, execution)
+	if err != nil {
+		return fmt.Errorf("could not update execution status to failed: %w", err)
+	}
+
+	for _, stage := range updatedStages {
+		err = f.stageStore.Update(ctx, stage)
+		if err != nil {
+			log.Debug().Err(err).
+				Int64("stage.number", stage.Number).
+				Msg("canceler: cannot update stage status")
+		}
+	}
+
+	for _, step := range updatedSteps {
+		err = f.stepStore.Update(ctx, step)
+		if err != nil {
+			log.Debug().Err(err).
+				Int64("step.id", step.ID).
+				Msg("canceler: cannot update step status")
+		}
+	}
+
+	// signal the runner to stop the running steps.
+	err = f.scheduler.Cancel(ctx, execution.ID)
+	if err != nil {
+		log.Warn().Err(err).Msg("canceler: failed to signal execution cancellation")
+	}
+
+	execution.Stages = stages
+
+	repo, err := f.repoStore.Find(ctx, execution.RepoID)
+	if err != nil {
+		log.Warn().Err(err).Msg("canceler: failed to find repository")
+		return nil
+	}
+
+	err = f.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeExecutionCompleted, execution)
+	if err != nil {
+		log.Debug().Err(err).Msg("canceler: failed to publish server-sent event")
+	}
+
+	reportExecutionCompleted(ctx, f.reporter, execution)
+
+	return nil
+}
+
+// reportExecutionCompleted sends the event of an execution that reached its final status.
+func reportExecutionCompleted(ctx context.Context, reporter *events.Reporter, execution *types.Execution) {
+	reporter.Executed(ctx, &events.ExecutedPayload{
+		PipelineID:   execution.PipelineID,
+		RepoID:       execution.RepoID,
+		ExecutionNum: execution.Number,
+		Status:       execution.Status,
+	})
+}
+
+// applyFailure fails the execution and all started stages with the provided error.
+// The failed step is failed as well, other running steps are killed and steps that haven't started are skipped.
+// It returns the stages and steps that were updated.
+func applyFailure(
+	execution *types.Execution,
+	stages []*types.Stage,
+	failedStep *types.Step,
+	reason string,
+	now int64,
+) ([]*types.Stage, []*types.Step) {
+	var updatedStages []*types.Stage
+	var updatedSteps []*types.Step
+
+	execution.Status = enum.CIStatusFailure
+	execution.Error = reason
+	execution.Finished = now
+
+	for _, stage := range stages {
+		if stage.Status.IsDone() {
+			continue
+		}
+		if stage.Started != 0 {
+			stage.Status = enum.CIStatusFailure
+			stage.Error = reason
+		} else {
+			stage.Status = enum.CIStatusSkipped
+			stage.Started = now
+		}
+		stage.Stopped = now
+		updatedStages = append(updatedStages, stage)
+
+		for _, step := range stage.Steps {
+			if step.Status.IsDone() {
+				continue
+			}
+			switch {
+			case failedStep != nil && step.ID == failedStep.ID:
+				step.Status = enum.CIStatusFailure
+				step.Error = reason
+			case step.Started != 0:
+				step.Status = enum.CIStatusKilled
+			default:
+				step.Status = enum.CIStatusSkipped
+				step.Started = now
+			}
+			step.Stopped = now
+			step.ExitCode = 130
+			updatedSteps = append(updatedSteps, step)
+		}
+	}
+
+	return updatedStages, updatedSteps
+}
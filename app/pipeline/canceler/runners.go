@@ -0,0 +1,251 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package canceler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	events "github.com/harness/gitness/app/events/pipeline"
+	"github.com/harness/gitness/app/pipeline/scheduler"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeRunnerMonitor        = "gitness:pipeline:runner-monitor"
+	jobCronRunnerMonitor        = "* * * * *" // Every minute.
+	jobMaxDurationRunnerMonitor = 1 * time.Minute
+
+	// runnerOfflineError is the error of executions that failed because their runner went offline.
+	runnerOfflineError = "runner offline"
+)
+
+// RunnerMonitor periodically handles the stages of runners that went offline according to the stale policy
+// and removes draining runners once they have no stages in flight anymore.
+type RunnerMonitor struct {
+	failer
+	jobScheduler *job.Scheduler
+	executor     *job.Executor
+	tx           dbtx.Transactor
+	runnerStore  store.RunnerStore
+
+	heartbeatTimeout time.Duration
+	stalePolicy      enum.RunnerStalePolicy
+}
+
+func NewRunnerMonitor(
+	jobScheduler *job.Scheduler,
+	executor *job.Executor,
+	tx dbtx.Transactor,
+	executionStore store.ExecutionStore,
+	stageStore store.StageStore,
+	stepStore store.StepStore,
+	repoStore store.RepoStore,
+	runnerStore store.RunnerStore,
+	scheduler scheduler.Scheduler,
+	sseStreamer sse.Streamer,
+	reporter *events.Reporter,
+	heartbeatTimeout time.Duration,
+	stalePolicy enum.RunnerStalePolicy,
+) *RunnerMonitor {
+	return &RunnerMonitor{
+		failer: failer{
+			executionStore: executionStore,
+			stageStore:     stageStore,
+			stepStore:      stepStore,
+			repoStore:      repoStore,
+			scheduler:      scheduler,
+			sseStreamer:    sseStreamer,
+			reporter:       reporter,
+		},
+		jobScheduler:     jobScheduler,
+		executor:         executor,
+		tx:               tx,
+		runnerStore:      runnerStore,
+		heartbeatTimeout: heartbeatTimeout,
+		stalePolicy:      stalePolicy,
+	}
+}
+
+func (m *RunnerMonitor) Register(ctx context.Context) error {
+	err := m.executor.Register(jobTypeRunnerMonitor, m)
+	if err != nil {
+		return fmt.Errorf("failed to register job handler for runner monitor: %w", err)
+	}
+
+	err = m.jobScheduler.AddRecurring(
+		ctx,
+		jobTypeRunnerMonitor,
+		jobTypeRunnerMonitor,
+		jobCronRunnerMonitor,
+		jobMaxDurationRunnerMonitor,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule runner monitor job: %w", err)
+	}
+
+	return nil
+}
+
+// Handle handles the stages of offline runners and removes drained runners.
+func (m *RunnerMonitor) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	runners, err := m.runnerStore.List(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list runners: %w", err)
+	}
+
+	stages, err := m.stageStore.ListIncomplete(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list incomplete stages: %w", err)
+	}
+
+	assigned := map[string][]*types.Stage{}
+	for _, stage := range stages {
+		if stage.Machine != "" {
+			assigned[stage.Machine] = append(assigned[stage.Machine], stage)
+		}
+	}
+
+	now := time.Now().UnixMilli()
+
+	var handled, removed int
+	for _, runner := range runners {
+		runnerStages := assigned[runner.Identifier]
+
+		if len(runnerStages) > 0 && !runner.IsOnline(now, m.heartbeatTimeout) {
+			for _, stage := range runnerStages {
+				err = m.handleStale(ctx, stage, now)
+				if err != nil {
+					log.Ctx(ctx).Warn().Err(err).
+						Str("runner", runner.Identifier).
+						Int64("stage.id", stage.ID).
+						Msg("failed to handle stage of offline runner")
+					continue
+				}
+				handled++
+			}
+			continue
+		}
+
+		if runner.Draining && len(runnerStages) == 0 {
+			err = m.runnerStore.Delete(ctx, runner.ID)
+			if err != nil {
+				log.Ctx(ctx).Warn().Err(err).
+					Str("runner", runner.Identifier).
+					Msg("failed to delete drained runner")
+				continue
+			}
+			removed++
+		}
+	}
+
+	return fmt.Sprintf("handled %d stages of offline runners, removed %d drained runners", handled, removed), nil
+}
+
+// handleStale re-queues the stage or fails its execution, depending on the stale policy.
+func (m *RunnerMonitor) handleStale(ctx context.Context, stage *types.Stage, now int64) error {
+	if m.stalePolicy == enum.RunnerStalePolicyFail {
+		return m.failStale(ctx, stage, now)
+	}
+	return m.requeue(ctx, stage)
+}
+
+// requeue puts the stage back into the queue, the steps created by the offline runner are removed
+// as the stage is set up again by the runner picking it up.
+func (m *RunnerMonitor) requeue(ctx context.Context, stage *types.Stage) error {
+	machine := stage.Machine
+
+	err := m.tx.WithTx(ctx, func(ctx context.Context) error {
+		// if the update fails due to an optimistic lock error the runner
+		// came back and updated the stage in the meantime.
+		resetStage(stage)
+		if err := m.stageStore.Update(ctx, stage); err != nil {
+			return fmt.Errorf("failed to reset stage: %w", err)
+		}
+
+		if err := m.stepStore.DeleteByStageID(ctx, stage.ID); err != nil {
+			return fmt.Errorf("failed to delete steps of stage: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = m.scheduler.Schedule(ctx, stage)
+	if err != nil {
+		return fmt.Errorf("failed to schedule stage: %w", err)
+	}
+
+	log.Ctx(ctx).Info().
+		Str("runner", machine).
+		Int64("execution.id", stage.ExecutionID).
+		Int64("stage.id", stage.ID).
+		Msg("runner monitor: re-queued stage of offline runner")
+
+	return nil
+}
+
+// failStale fails the execution of the stage.
+func (m *RunnerMonitor) failStale(ctx context.Context, stage *types.Stage, now int64) error {
+	execution, err := m.executionStore.Find(ctx, stage.ExecutionID)
+	if err != nil {
+		return fmt.Errorf("failed to find execution: %w", err)
+	}
+
+	// the execution has already been failed because of another stage of the runner.
+	if execution.Status.IsDone() {
+		return nil
+	}
+
+	stages, err := m.stageStore.ListWithSteps(ctx, execution.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list stages of execution: %w", err)
+	}
+
+	updatedStages, updatedSteps := applyFailure(execution, stages, nil, runnerOfflineError, now)
+
+	err = m.fail(ctx, execution, stages, updatedStages, updatedSteps)
+	if err != nil {
+		return err
+	}
+
+	log.Ctx(ctx).Info().
+		Str("runner", stage.Machine).
+		Int64("execution.id", execution.ID).
+		Msg("runner monitor: failed execution of offline runner")
+
+	return nil
+}
+
+// resetStage resets the stage to the state it had before it was assigned to a runner.
+func resetStage(stage *types.Stage) {
+	stage.Status = enum.CIStatusPending
+	stage.Machine = ""
+	stage.Error = ""
+	stage.ExitCode = 0
+	stage.Started = 0
+	stage.Stopped = 0
+	stage.Steps = nil
+}
@@ -0,0 +1,210 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package canceler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	events "github.com/harness/gitness/app/events/pipeline"
+	"github.com/harness/gitness/app/pipeline/scheduler"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeExecutionTimeouts        = "gitness:pipeline:execution-timeouts"
+	jobCronExecutionTimeouts        = "* * * * *" // Every minute.
+	jobMaxDurationExecutionTimeouts = 1 * time.Minute
+
+	// timeoutError is the error of executions, stages and steps that ran longer than allowed.
+	timeoutError = "timeout"
+)
+
+// TimeoutEnforcer periodically fails running executions that exceeded their timeout
+// or that have a running step which exceeded its timeout.
+type TimeoutEnforcer struct {
+	failer
+	jobScheduler *job.Scheduler
+	executor     *job.Executor
+
+	maxExecutionTimeout time.Duration
+	maxStepTimeout      time.Duration
+}
+
+func NewTimeoutEnforcer(
+	jobScheduler *job.Scheduler,
+	executor *job.Executor,
+	executionStore store.ExecutionStore,
+	stageStore store.StageStore,
+	stepStore store.StepStore,
+	repoStore store.RepoStore,
+	scheduler scheduler.Scheduler,
+	sseStreamer sse.Streamer,
+	reporter *events.Reporter,
+	maxExecutionTimeout time.Duration,
+	maxStepTimeout time.Duration,
+) *TimeoutEnforcer {
+	return &TimeoutEnforcer{
+		failer: failer{
+			executionStore: executionStore,
+			stageStore:     stageStore,
+			stepStore:      stepStore,
+			repoStore:      repoStore,
+			scheduler:      scheduler,
+			sseStreamer:    sseStreamer,
+			reporter:       reporter,
+		},
+		jobScheduler:        jobScheduler,
+		executor:            executor,
+		maxExecutionTimeout: maxExecutionTimeout,
+		maxStepTimeout:      maxStepTimeout,
+	}
+}
+
+func (e *TimeoutEnforcer) Register(ctx context.Context) error {
+	err := e.executor.Register(jobTypeExecutionTimeouts, e)
+	if err != nil {
+		return fmt.Errorf("failed to register job handler for execution timeouts: %w", err)
+	}
+
+	err = e.jobScheduler.AddRecurring(
+		ctx,
+		jobTypeExecutionTimeouts,
+		jobTypeExecutionTimeouts,
+		jobCronExecutionTimeouts,
+		jobMaxDurationExecutionTimeouts,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule execution timeouts job: %w", err)
+	}
+
+	return nil
+}
+
+// Handle fails all running executions that ran longer than allowed.
+func (e *TimeoutEnforcer) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	executions, err := e.executionStore.ListIncomplete(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list incomplete executions: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+
+	var count int
+	for _, execution := range executions {
+		if execution.Status != enum.CIStatusRunning {
+			continue
+		}
+
+		var stages []*types.Stage
+		stages, err = e.stageStore.ListWithSteps(ctx, execution.ID)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("execution.id", execution.ID).
+				Msg("failed to list stages of running execution")
+			continue
+		}
+
+		timedOut, step := findTimeout(execution, stages, now, e.maxExecutionTimeout, e.maxStepTimeout)
+		if !timedOut {
+			continue
+		}
+
+		err = e.timeout(ctx, execution, stages, step, now)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("execution.id", execution.ID).
+				Msg("failed to time out execution")
+			continue
+		}
+
+		count++
+	}
+
+	result := "no executions timed out"
+	if count > 0 {
+		result = fmt.Sprintf("timed out %d executions", count)
+	}
+
+	return result, nil
+}
+
+// timeout fails the execution and signals the runner to stop the running steps.
+func (e *TimeoutEnforcer) timeout(
+	ctx context.Context,
+	execution *types.Execution,
+	stages []*types.Stage,
+	timedOutStep *types.Step,
+	now int64,
+) error {
+	updatedStages, updatedSteps := applyTimeout(execution, stages, timedOutStep, now)
+
+	err := e.fail(ctx, execution, stages, updatedStages, updatedSteps)
+	if err != nil {
+		return err
+	}
+
+	log.Ctx(ctx).Info().Int64("execution.id", execution.ID).Msg("timeout enforcer: execution timed out")
+
+	return nil
+}
+
+// findTimeout checks whether the running execution exceeded its timeout or has a running step
+// that exceeded its timeout. The returned step is the one that timed out, if any.
+// Timeouts are measured from the actual start, a timeout of 0 falls back to the instance wide maximum.
+func findTimeout(
+	execution *types.Execution,
+	stages []*types.Stage,
+	now int64,
+	maxExecutionTimeout time.Duration,
+	maxStepTimeout time.Duration,
+) (bool, *types.Step) {
+	for _, stage := range stages {
+		for _, step := range stage.Steps {
+			if step.Status == enum.CIStatusRunning &&
+				exceeded(step.Started, step.Timeout, maxStepTimeout, now) {
+				return true, step
+			}
+		}
+	}
+
+	return exceeded(execution.Started, execution.Timeout, maxExecutionTimeout, now), nil
+}
+
+func exceeded(started int64, timeout int64, maxTimeout time.Duration, now int64) bool {
+	if timeout <= 0 {
+		timeout = maxTimeout.Milliseconds()
+	}
+	return started > 0 && timeout > 0 && now-started > timeout
+}
+
+// applyTimeout fails the execution and all started stages with a timeout error and flags the step that timed out.
+// It returns the stages and steps that were updated.
+func applyTimeout(
+	execution *types.Execution,
+	stages []*types.Stage,
+	timedOutStep *types.Step,
+	now int64,
+) ([]*types.Stage, []*types.Step) {
+	if timedOutStep != nil {
+		timedOutStep.TimedOut = true
+	}
+	return applyFailure(execution, stages, timedOutStep, timeoutError, now)
+}
@@ -0,0 +1,143 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package canceler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestFindTimeout(t *testing.T) {
+	now := time.Now().UnixMilli()
+	hour := time.Hour.Milliseconds()
+
+	tests := []struct {
+		name         string
+		execution    *types.Execution
+		steps        []*types.Step
+		wantTimedOut bool
+		wantStep     int64
+	}{
+		{
+			name:      "slow step",
+			execution: &types.Execution{Started: now - 3*hour, Timeout: 10 * hour},
+			steps: []*types.Step{
+				{ID: 1, Status: enum.CIStatusSuccess, Started: now - 3*hour, Timeout: hour},
+				{ID: 2, Status: enum.CIStatusRunning, Started: now - 2*hour, Timeout: hour},
+			},
+			wantTimedOut: true,
+			wantStep:     2,
+		},
+		{
+			name:      "step within timeout",
+			execution: &types.Execution{Started: now - 3*hour, Timeout: 10 * hour},
+			steps: []*types.Step{
+				{ID: 1, Status: enum.CIStatusRunning, Started: now - hour/2, Timeout: hour},
+			},
+		},
+		{
+			name:      "step without timeout falls back to maximum",
+			execution: &types.Execution{Started: now - 3*hour},
+			steps: []*types.Step{
+				{ID: 1, Status: enum.CIStatusRunning, Started: now - 3*hour},
+			},
+			wantTimedOut: true,
+			wantStep:     1,
+		},
+		{
+			name:      "execution timeout",
+			execution: &types.Execution{Started: now - 2*hour, Timeout: hour},
+			steps: []*types.Step{
+				{ID: 1, Status: enum.CIStatusRunning, Started: now - hour/2, Timeout: hour},
+			},
+			wantTimedOut: true,
+		},
+		{
+			name:      "timeout is measured from start",
+			execution: &types.Execution{Started: 0, Timeout: hour},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stages := []*types.Stage{{Steps: test.steps}}
+
+			timedOut, step := findTimeout(test.execution, stages, now, 10*time.Hour, 2*time.Hour)
+			if timedOut != test.wantTimedOut {
+				t.Errorf("want timed out %t, got %t", test.wantTimedOut, timedOut)
+			}
+
+			var stepID int64
+			if step != nil {
+				stepID = step.ID
+			}
+			if stepID != test.wantStep {
+				t.Errorf("want step %d, got %d", test.wantStep, stepID)
+			}
+		})
+	}
+}
+
+func TestApplyTimeout(t *testing.T) {
+	now := time.Now().UnixMilli()
+
+	execution := &types.Execution{Status: enum.CIStatusRunning, Started: now - 1000}
+	slow := &types.Step{ID: 2, Status: enum.CIStatusRunning, Started: now - 1000}
+	stages := []*types.Stage{
+		{
+			Status:  enum.CIStatusRunning,
+			Started: now - 1000,
+			Steps: []*types.Step{
+				{ID: 1, Status: enum.CIStatusSuccess, Started: now - 1000, Stopped: now - 500},
+				slow,
+				{ID: 3, Status: enum.CIStatusPending},
+			},
+		},
+		{
+			Status: enum.CIStatusWaitingOnDeps,
+		},
+	}
+
+	updatedStages, updatedSteps := applyTimeout(execution, stages, slow, now)
+
+	if execution.Status != enum.CIStatusFailure || execution.Error != timeoutError || execution.Finished != now {
+		t.Errorf("unexpected execution: %+v", execution)
+	}
+
+	if len(updatedStages) != 2 || len(updatedSteps) != 2 {
+		t.Fatalf("want 2 updated stages and 2 updated steps, got %d and %d", len(updatedStages), len(updatedSteps))
+	}
+
+	if stages[0].Status != enum.CIStatusFailure || stages[0].Error != timeoutError {
+		t.Errorf("want running stage to fail with timeout, got %s %q", stages[0].Status, stages[0].Error)
+	}
+	if stages[1].Status != enum.CIStatusSkipped {
+		t.Errorf("want waiting stage to be skipped, got %s", stages[1].Status)
+	}
+
+	steps := stages[0].Steps
+	if steps[0].Status != enum.CIStatusSuccess || steps[0].TimedOut {
+		t.Errorf("want completed step to be left untouched, got %+v", steps[0])
+	}
+	if steps[1].Status != enum.CIStatusFailure || !steps[1].TimedOut || steps[1].Error != timeoutError {
+		t.Errorf("want slow step to be flagged as timed out, got %+v", steps[1])
+	}
+	if steps[2].Status != enum.CIStatusSkipped || steps[2].TimedOut {
+		t.Errorf("want pending step to be skipped, got %+v", steps[2])
+	}
+}
@@ -15,9 +15,13 @@
 package canceler
 
 import (
+	events "github.com/harness/gitness/app/events/pipeline"
 	"github.com/harness/gitness/app/pipeline/scheduler"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
 )
@@ -25,6 +29,8 @@ import (
 // WireSet provides a wire set for this package.
 var WireSet = wire.NewSet(
 	ProvideCanceler,
+	ProvideTimeoutEnforcer,
+	ProvideRunnerMonitor,
 )
 
 // ProvideExecutionManager provides an execution manager.
@@ -34,6 +40,45 @@ func ProvideCanceler(
 	repoStore store.RepoStore,
 	scheduler scheduler.Scheduler,
 	stageStore store.StageStore,
-	stepStore store.StepStore) Canceler {
-	return New(executionStore, sseStreamer, repoStore, scheduler, stageStore, stepStore)
+	stepStore store.StepStore,
+	reporter *events.Reporter,
+) Canceler {
+	return New(executionStore, sseStreamer, repoStore, scheduler, stageStore, stepStore, reporter)
+}
+
+// ProvideTimeoutEnforcer provides the job that fails executions which ran longer than allowed.
+func ProvideTimeoutEnforcer(
+	jobScheduler *job.Scheduler,
+	executor *job.Executor,
+	executionStore store.ExecutionStore,
+	stageStore store.StageStore,
+	stepStore store.StepStore,
+	repoStore store.RepoStore,
+	scheduler scheduler.Scheduler,
+	sseStreamer sse.Streamer,
+	reporter *events.Reporter,
+	config *types.Config,
+) *TimeoutEnforcer {
+	return NewTimeoutEnforcer(jobScheduler, executor, executionStore, stageStore, stepStore, repoStore,
+		scheduler, sseStreamer, reporter, config.CI.MaxExecutionTimeout, config.CI.MaxStepTimeout)
+}
+
+// ProvideRunnerMonitor provides the job that handles the stages of offline runners and removes drained runners.
+func ProvideRunnerMonitor(
+	jobScheduler *job.Scheduler,
+	executor *job.Executor,
+	tx dbtx.Transactor,
+	executionStore store.ExecutionStore,
+	stageStore store.StageStore,
+	stepStore store.StepStore,
+	repoStore store.RepoStore,
+	runnerStore store.RunnerStore,
+	scheduler scheduler.Scheduler,
+	sseStreamer sse.Streamer,
+	reporter *events.Reporter,
+	config *types.Config,
+) *RunnerMonitor {
+	return NewRunnerMonitor(jobScheduler, executor, tx, executionStore, stageStore, stepStore, repoStore,
+		runnerStore, scheduler, sseStreamer, reporter, config.CI.Runners.HeartbeatTimeout,
+		config.CI.Runners.StalePolicy)
 }
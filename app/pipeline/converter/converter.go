@@ -16,9 +16,11 @@ package converter
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/harness/gitness/app/pipeline/converter/jsonnet"
+	"github.com/harness/gitness/app/pipeline/converter/matrix"
 	"github.com/harness/gitness/app/pipeline/converter/starlark"
 	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/services/publicaccess"
@@ -65,7 +67,7 @@ func (c *converter) Convert(ctx context.Context, args *ConvertArgs) (*file.File,
 		if err != nil {
 			return nil, err
 		}
-		return &file.File{Data: []byte(str)}, nil
+		return expandMatrix(&file.File{Data: []byte(str)})
 	} else if isStarlark(path) {
 		str, err := starlark.Parse(
 			args.Repo,
@@ -79,9 +81,18 @@ func (c *converter) Convert(ctx context.Context, args *ConvertArgs) (*file.File,
 		if err != nil {
 			return nil, err
 		}
-		return &file.File{Data: []byte(str)}, nil
+		return expandMatrix(&file.File{Data: []byte(str)})
 	}
-	return args.File, nil
+	return expandMatrix(args.File)
+}
+
+// expandMatrix expands pipelines that declare a matrix into one pipeline per matrix cell.
+func expandMatrix(f *file.File) (*file.File, error) {
+	data, err := matrix.Expand(f.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand matrix: %w", err)
+	}
+	return &file.File{Data: data}, nil
 }
 
 func isJSONNet(path string) bool {
@@ -0,0 +1,421 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/harness/gitness/types"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// maxCells is the max number of cells a single matrix can be expanded to.
+	maxCells = 64
+
+	keyName        = "name"
+	keyKind        = "kind"
+	keyMatrix      = "matrix"
+	keyCell        = "matrix_cell"
+	keyDependsOn   = "depends_on"
+	keyEnvironment = "environment"
+)
+
+// declaration is the matrix declared on a pipeline document of the drone yaml.
+// Each cell of the matrix is expanded into a separate pipeline document.
+//
+//	kind: pipeline
+//	name: test
+//	matrix:
+//	  axes:
+//	    go: ["1.21", "1.22"]
+//	    os: [linux, windows]
+//	  exclude:
+//	  - {go: "1.21", os: windows}
+//	  allow_failure:
+//	  - {os: windows}
+//	steps:
+//	- name: test
+//	  image: golang:${GO}
+type declaration struct {
+	Axes         map[string][]string `yaml:"axes"`
+	Exclude      []map[string]string `yaml:"exclude"`
+	AllowFailure []map[string]string `yaml:"allow_failure"`
+}
+
+// cell is the metadata added to the pipeline document of an expanded matrix cell.
+type cell struct {
+	Group        string            `yaml:"group"`
+	Axes         map[string]string `yaml:"axes"`
+	AllowFailure bool              `yaml:"allow_failure,omitempty"`
+}
+
+// Expand expands all pipeline documents of the drone yaml that declare a matrix into one document per cell.
+// Cell documents are named after the pipeline and the axis values of the cell, the axis values are exposed
+// as pipeline environment variables and substituted for ${AXIS} in the document.
+// Dependencies on a pipeline with a matrix are replaced with dependencies on all of its cells.
+// The data is returned unchanged if no pipeline declares a matrix.
+func Expand(data []byte) ([]byte, error) {
+	docs, err := decode(data)
+	if err != nil {
+		// syntax errors are reported by the yaml parser of the pipeline.
+		return data, nil //nolint:nilerr
+	}
+
+	expanded := make([]*yaml.Node, 0, len(docs))
+	groups := map[string][]string{}
+	for _, doc := range docs {
+		root := documentRoot(doc)
+		if root == nil || scalar(root, keyKind) != "pipeline" || lookup(root, keyMatrix) == nil {
+			expanded = append(expanded, doc)
+			continue
+		}
+
+		name := scalar(root, keyName)
+		if name == "" {
+			name = "default"
+		}
+
+		var cells []*yaml.Node
+		cells, err = expandDocument(doc, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to expand matrix of pipeline %q: %w", name, err)
+		}
+
+		for _, c := range cells {
+			groups[name] = append(groups[name], scalar(documentRoot(c), keyName))
+		}
+		expanded = append(expanded, cells...)
+	}
+
+	if len(groups) == 0 {
+		return data, nil
+	}
+
+	for _, doc := range expanded {
+		root := documentRoot(doc)
+		if root != nil {
+			replaceDependencies(root, groups)
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	encoder := yaml.NewEncoder(buf)
+	encoder.SetIndent(2)
+	for _, doc := range expanded {
+		if err = encoder.Encode(doc); err != nil {
+			return nil, fmt.Errorf("failed to encode yaml document: %w", err)
+		}
+	}
+	if err = encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode yaml: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ParseCells returns the matrix metadata of the expanded drone yaml, keyed by pipeline (stage) name.
+func ParseCells(data []byte) (map[string]*types.StageMatrix, error) {
+	cells := map[string]*types.StageMatrix{}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		doc := struct {
+			Kind string `yaml:"kind"`
+			Name string `yaml:"name"`
+			Cell *cell  `yaml:"matrix_cell"`
+		}{}
+		err := decoder.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode yaml document: %w", err)
+		}
+
+		if doc.Kind != "pipeline" || doc.Cell == nil {
+			continue
+		}
+
+		cells[doc.Name] = &types.StageMatrix{
+			Group:        doc.Cell.Group,
+			Axes:         doc.Cell.Axes,
+			AllowFailure: doc.Cell.AllowFailure,
+		}
+	}
+
+	return cells, nil
+}
+
+func decode(data []byte) ([]*yaml.Node, error) {
+	var docs []*yaml.Node
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		doc := &yaml.Node{}
+		err := decoder.Decode(doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode yaml document: %w", err)
+		}
+
+		docs = append(docs, doc)
+	}
+
+	return docs, nil
+}
+
+func expandDocument(doc *yaml.Node, name string) ([]*yaml.Node, error) {
+	root := documentRoot(doc)
+
+	decl := declaration{}
+	if err := lookup(root, keyMatrix).Decode(&decl); err != nil {
+		return nil, fmt.Errorf("failed to decode matrix: %w", err)
+	}
+
+	combinations, err := decl.combinations()
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]*yaml.Node, 0, len(combinations))
+	for _, axes := range combinations {
+		c := &cell{
+			Group:        name,
+			Axes:         axes,
+			AllowFailure: matchesAny(axes, decl.AllowFailure),
+		}
+
+		cellNode := &yaml.Node{}
+		if err = cellNode.Encode(c); err != nil {
+			return nil, fmt.Errorf("failed to encode matrix cell: %w", err)
+		}
+
+		cellDoc := clone(doc)
+		cellRoot := documentRoot(cellDoc)
+		substitute(cellRoot, axes)
+		remove(cellRoot, keyMatrix)
+		set(cellRoot, keyName, &yaml.Node{Kind: yaml.ScalarNode, Value: CellName(name, axes)})
+		set(cellRoot, keyCell, cellNode)
+		addEnvironment(cellRoot, axes)
+
+		docs = append(docs, cellDoc)
+	}
+
+	return docs, nil
+}
+
+// CellName returns the name of the pipeline (stage) of a matrix cell.
+func CellName(group string, axes map[string]string) string {
+	names := sortedKeys(axes)
+	pairs := make([]string, len(names))
+	for i, axis := range names {
+		pairs[i] = axis + "=" + axes[axis]
+	}
+	return fmt.Sprintf("%s (%s)", group, strings.Join(pairs, ", "))
+}
+
+// combinations returns the cartesian product of the axes without the excluded combinations.
+func (d *declaration) combinations() ([]map[string]string, error) {
+	if len(d.Axes) == 0 {
+		return nil, errors.New("matrix requires at least one axis")
+	}
+
+	combinations := []map[string]string{{}}
+	for _, axis := range sortedKeys(d.Axes) {
+		values := d.Axes[axis]
+		if len(values) == 0 {
+			return nil, fmt.Errorf("axis %q requires at least one value", axis)
+		}
+
+		next := make([]map[string]string, 0, len(combinations)*len(values))
+		for _, combination := range combinations {
+			for _, value := range values {
+				c := make(map[string]string, len(combination)+1)
+				for k, v := range combination {
+					c[k] = v
+				}
+				c[axis] = value
+				next = append(next, c)
+			}
+		}
+		combinations = next
+	}
+
+	result := make([]map[string]string, 0, len(combinations))
+	for _, combination := range combinations {
+		if !matchesAny(combination, d.Exclude) {
+			result = append(result, combination)
+		}
+	}
+
+	if len(result) == 0 {
+		return nil, errors.New("all matrix cells are excluded")
+	}
+	if len(result) > maxCells {
+		return nil, fmt.Errorf("matrix has %d cells, at most %d are allowed", len(result), maxCells)
+	}
+
+	return result, nil
+}
+
+// matchesAny returns true if the axes contain all axis values of any of the patterns.
+func matchesAny(axes map[string]string, patterns []map[string]string) bool {
+	for _, pattern := range patterns {
+		if Matches(axes, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches returns true if the axes contain all axis values of the pattern.
+func Matches(axes map[string]string, pattern map[string]string) bool {
+	for axis, value := range pattern {
+		if v, ok := axes[axis]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
+// EnvName returns the name of the environment variable of an axis.
+func EnvName(axis string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, strings.ToUpper(axis))
+}
+
+func addEnvironment(root *yaml.Node, axes map[string]string) {
+	env := lookup(root, keyEnvironment)
+	if env == nil || env.Kind != yaml.MappingNode {
+		env = &yaml.Node{Kind: yaml.MappingNode}
+		set(root, keyEnvironment, env)
+	}
+
+	for _, axis := range sortedKeys(axes) {
+		name := EnvName(axis)
+		if lookup(env, name) != nil {
+			continue
+		}
+		set(env, name, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: axes[axis]})
+	}
+}
+
+// substitute replaces ${AXIS} with the axis value in all scalars of the node.
+func substitute(node *yaml.Node, axes map[string]string) {
+	if node.Kind == yaml.ScalarNode {
+		for axis, value := range axes {
+			node.Value = strings.ReplaceAll(node.Value, "${"+EnvName(axis)+"}", value)
+		}
+		return
+	}
+	for _, child := range node.Content {
+		substitute(child, axes)
+	}
+}
+
+// replaceDependencies replaces dependencies on a matrix pipeline with dependencies on all of its cells.
+func replaceDependencies(root *yaml.Node, groups map[string][]string) {
+	dependsOn := lookup(root, keyDependsOn)
+	if dependsOn == nil || dependsOn.Kind != yaml.SequenceNode {
+		return
+	}
+
+	content := make([]*yaml.Node, 0, len(dependsOn.Content))
+	for _, dependency := range dependsOn.Content {
+		cells, ok := groups[dependency.Value]
+		if !ok {
+			content = append(content, dependency)
+			continue
+		}
+		for _, name := range cells {
+			content = append(content, &yaml.Node{Kind: yaml.ScalarNode, Value: name})
+		}
+	}
+	dependsOn.Content = content
+}
+
+func documentRoot(doc *yaml.Node) *yaml.Node {
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil
+	}
+	return doc.Content[0]
+}
+
+func lookup(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func scalar(mapping *yaml.Node, key string) string {
+	node := lookup(mapping, key)
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return node.Value
+}
+
+func set(mapping *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1] = value
+			return
+		}
+	}
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+}
+
+func remove(mapping *yaml.Node, key string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+func clone(node *yaml.Node) *yaml.Node {
+	c := *node
+	c.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		c.Content[i] = clone(child)
+	}
+	return &c
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
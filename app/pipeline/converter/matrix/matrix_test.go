@@ -0,0 +1,157 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/types"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestExpand(t *testing.T) {
+	data := []byte(`
+kind: pipeline
+name: test
+matrix:
+  axes:
+    go: [1.21, 1.22]
+    os: [linux, windows]
+  exclude:
+  - {go: 1.21, os: windows}
+  allow_failure:
+  - {os: windows}
+steps:
+- name: test
+  image: golang:${GO}
+---
+kind: pipeline
+name: publish
+depends_on: [test]
+steps:
+- name: publish
+  image: alpine
+`)
+
+	expanded, err := Expand(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	type document struct {
+		Name        string            `yaml:"name"`
+		DependsOn   []string          `yaml:"depends_on"`
+		Environment map[string]string `yaml:"environment"`
+		Matrix      any               `yaml:"matrix"`
+		Steps       []struct {
+			Image string `yaml:"image"`
+		} `yaml:"steps"`
+	}
+
+	var docs []document
+	decoder := yaml.NewDecoder(strings.NewReader(string(expanded)))
+	for {
+		doc := document{}
+		if err = decoder.Decode(&doc); err != nil {
+			break
+		}
+		docs = append(docs, doc)
+	}
+
+	wantNames := []string{
+		"test (go=1.21, os=linux)",
+		"test (go=1.22, os=linux)",
+		"test (go=1.22, os=windows)",
+		"publish",
+	}
+	if len(docs) != len(wantNames) {
+		t.Fatalf("want %d documents, got %d:\n%s", len(wantNames), len(docs), expanded)
+	}
+	for i, name := range wantNames {
+		if docs[i].Name != name {
+			t.Errorf("want document %d to be named %q, got %q", i, name, docs[i].Name)
+		}
+	}
+
+	if docs[0].Matrix != nil {
+		t.Errorf("want matrix declaration to be removed from cells")
+	}
+	if docs[1].Steps[0].Image != "golang:1.22" {
+		t.Errorf("want axis value to be substituted, got %q", docs[1].Steps[0].Image)
+	}
+	if want := map[string]string{"GO": "1.22", "OS": "windows"}; !reflect.DeepEqual(docs[2].Environment, want) {
+		t.Errorf("want axis values in environment, got %v", docs[2].Environment)
+	}
+	if !reflect.DeepEqual(docs[3].DependsOn, wantNames[:3]) {
+		t.Errorf("want dependency on all cells, got %v", docs[3].DependsOn)
+	}
+
+	cells, err := ParseCells(expanded)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	wantCells := map[string]*types.StageMatrix{
+		"test (go=1.21, os=linux)": {
+			Group: "test",
+			Axes:  map[string]string{"go": "1.21", "os": "linux"},
+		},
+		"test (go=1.22, os=linux)": {
+			Group: "test",
+			Axes:  map[string]string{"go": "1.22", "os": "linux"},
+		},
+		"test (go=1.22, os=windows)": {
+			Group:        "test",
+			Axes:         map[string]string{"go": "1.22", "os": "windows"},
+			AllowFailure: true,
+		},
+	}
+	if !reflect.DeepEqual(cells, wantCells) {
+		t.Errorf("unexpected cells: %+v", cells)
+	}
+}
+
+func TestExpandWithoutMatrix(t *testing.T) {
+	data := []byte("kind: pipeline\nname: build\nsteps:\n- name: test\n  image: golang\n")
+
+	expanded, err := Expand(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(expanded) != string(data) {
+		t.Errorf("want data to be unchanged, got:\n%s", expanded)
+	}
+}
+
+func TestExpandInvalid(t *testing.T) {
+	tests := map[string]string{
+		"no axes":      "kind: pipeline\nmatrix:\n  axes: {}\n",
+		"empty axis":   "kind: pipeline\nmatrix:\n  axes:\n    go: []\n",
+		"all excluded": "kind: pipeline\nmatrix:\n  axes:\n    go: [a]\n  exclude:\n  - {go: a}\n",
+		"too many cells": "kind: pipeline\nmatrix:\n  axes:\n" +
+			"    a: [1, 2, 3, 4, 5]\n    b: [1, 2, 3, 4, 5]\n    c: [1, 2, 3]\n",
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Expand([]byte(data)); err == nil {
+				t.Errorf("expected error")
+			}
+		})
+	}
+}
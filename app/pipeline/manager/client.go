@@ -76,6 +76,8 @@ func (e *embedded) Request(ctx context.Context, args *client.Filter) (*drone.Sta
 		Variant: args.Variant,
 		Kernel:  args.Kernel,
 		Labels:  args.Labels,
+		// the embedded runner is registered under the instance ID, see runner.Heartbeat.
+		Machine: e.config.InstanceID,
 	}
 	stage, err := e.manager.Request(ctx, request)
 	if err != nil {
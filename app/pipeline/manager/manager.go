@@ -63,6 +63,8 @@ type (
 		Variant string            `json:"variant"`
 		Kernel  string            `json:"kernel"`
 		Labels  map[string]string `json:"labels,omitempty"`
+		// Machine is the identifier of the registered runner requesting the build.
+		Machine string `json:"machine"`
 	}
 
 	// Config represents a pipeline config file.
@@ -208,6 +210,7 @@ func (m *Manager) Request(ctx context.Context, args *Request) (*types.Stage, err
 		Str("arch", args.Arch).
 		Str("kernel", args.Kernel).
 		Str("variant", args.Variant).
+		Str("machine", args.Machine).
 		Logger()
 	log.Debug().Msg("manager: request queue item")
 
@@ -219,6 +222,7 @@ func (m *Manager) Request(ctx context.Context, args *Request) (*types.Stage, err
 		Kernel:  args.Kernel,
 		Variant: args.Variant,
 		Labels:  args.Labels,
+		Machine: args.Machine,
 	})
 	if err != nil && ctx.Err() != nil {
 		log.Debug().Err(err).Msg("manager: context canceled")
@@ -469,6 +473,10 @@ func (m *Manager) BeforeStage(_ context.Context, stage *types.Stage) error {
 		Steps:       m.Steps,
 		Stages:      m.Stages,
 		Users:       m.Users,
+		Reporter:    m.reporter,
+
+		MaxExecutionTimeout: m.Config.CI.MaxExecutionTimeout,
+		MaxStepTimeout:      m.Config.CI.MaxStepTimeout,
 	}
 
 	return s.do(noContext, stage)
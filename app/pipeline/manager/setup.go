@@ -19,6 +19,7 @@ import (
 	"errors"
 	"time"
 
+	events "github.com/harness/gitness/app/events/pipeline"
 	"github.com/harness/gitness/app/pipeline/checks"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
@@ -38,6 +39,10 @@ type setup struct {
 	Steps       store.StepStore
 	Stages      store.StageStore
 	Users       store.PrincipalStore
+	Reporter    events.Reporter
+
+	MaxExecutionTimeout time.Duration
+	MaxStepTimeout      time.Duration
 }
 
 func (s *setup) do(ctx context.Context, stage *types.Stage) error {
@@ -60,6 +65,13 @@ func (s *setup) do(ctx context.Context, stage *types.Stage) error {
 		return err
 	}
 
+	// the step timeouts are declared in the yaml and aren't known to the runner.
+	stored, err := s.Stages.Find(noContext, stage.ID)
+	if err != nil {
+		log.Error().Err(err).Msg("manager: cannot find the stage")
+		return err
+	}
+
 	if len(stage.Error) > 500 {
 		stage.Error = stage.Error[:500]
 	}
@@ -76,6 +88,7 @@ func (s *setup) do(ctx context.Context, stage *types.Stage) error {
 		if len(step.Error) > 500 {
 			step.Error = step.Error[:500]
 		}
+		step.Timeout = effectiveTimeout(stored.StepTimeouts[step.Name], s.MaxStepTimeout)
 		err := s.Steps.Create(noContext, step)
 		if err != nil {
 			log.Error().Err(err).
@@ -87,16 +100,23 @@ func (s *setup) do(ctx context.Context, stage *types.Stage) error {
 		}
 	}
 
-	_, err = s.updateExecution(noContext, execution)
+	pipeline, err := s.Pipelines.Find(ctx, execution.PipelineID)
 	if err != nil {
-		log.Error().Err(err).Msg("manager: cannot update the execution")
+		log.Error().Err(err).Msg("manager: cannot find pipeline")
 		return err
 	}
-	pipeline, err := s.Pipelines.Find(ctx, execution.PipelineID)
+	started, err := s.updateExecution(noContext, execution, pipeline)
 	if err != nil {
-		log.Error().Err(err).Msg("manager: cannot find pipeline")
+		log.Error().Err(err).Msg("manager: cannot update the execution")
 		return err
 	}
+	if started {
+		s.Reporter.Started(ctx, &events.StartedPayload{
+			PipelineID:   execution.PipelineID,
+			RepoID:       execution.RepoID,
+			ExecutionNum: execution.Number,
+		})
+	}
 	// try to write to the checks store - if not, log an error and continue
 	err = checks.Write(ctx, s.Checks, execution, pipeline)
 	if err != nil {
@@ -119,12 +139,18 @@ func (s *setup) do(ctx context.Context, stage *types.Stage) error {
 // helper function that updates the execution status from pending to running.
 // This accounts for the fact that another agent may have already updated
 // the execution status, which may happen if two stages execute concurrently.
-func (s *setup) updateExecution(ctx context.Context, execution *types.Execution) (bool, error) {
+// The execution timeout is fixed at this point, as it's measured from the start of the execution.
+func (s *setup) updateExecution(
+	ctx context.Context,
+	execution *types.Execution,
+	pipeline *types.Pipeline,
+) (bool, error) {
 	if execution.Status != enum.CIStatusPending {
 		return false, nil
 	}
 	execution.Started = time.Now().UnixMilli()
 	execution.Status = enum.CIStatusRunning
+	execution.Timeout = effectiveTimeout(pipeline.Timeout, s.MaxExecutionTimeout)
 	err := s.Executions.Update(ctx, execution)
 	if errors.Is(err, gitness_store.ErrVersionConflict) {
 		return false, nil
@@ -134,3 +160,13 @@ func (s *setup) updateExecution(ctx context.Context, execution *types.Execution)
 	}
 	return true, nil
 }
+
+// helper function that caps the timeout (in ms) at the instance wide maximum.
+// If no timeout is configured, the maximum is used.
+func effectiveTimeout(timeout int64, maxTimeout time.Duration) int64 {
+	limit := maxTimeout.Milliseconds()
+	if limit > 0 && (timeout <= 0 || timeout > limit) {
+		return limit
+	}
+	return timeout
+}
@@ -60,6 +60,9 @@ func (t *teardown) do(ctx context.Context, stage *types.Stage) error {
 		return err
 	}
 
+	// executions canceled or failed by the server have been reported as completed already.
+	reported := execution.Status.IsDone()
+
 	log = log.With().
 		Int64("execution.number", execution.Number).
 		Int64("execution.id", execution.ID).
@@ -122,7 +125,7 @@ func (t *teardown) do(ctx context.Context, stage *types.Stage) error {
 		return err
 	}
 
-	err = t.scheduleDownstream(ctx, stages)
+	err = t.scheduleDownstream(ctx, repo, stages)
 	if err != nil {
 		log.Error().Err(err).
 			Msg("manager: cannot schedule downstream builds")
@@ -144,6 +147,9 @@ func (t *teardown) do(ctx context.Context, stage *types.Stage) error {
 			execution.Status = enum.CIStatusKilled
 			break
 		}
+		if isFailureAllowed(sibling) {
+			continue
+		}
 		if sibling.Status == enum.CIStatusFailure {
 			execution.Status = enum.CIStatusFailure
 			break
@@ -177,7 +183,9 @@ func (t *teardown) do(ctx context.Context, stage *types.Stage) error {
 	}
 
 	// send pipeline execution status
-	t.reportExecutionCompleted(ctx, execution)
+	if !reported {
+		t.reportExecutionCompleted(ctx, execution)
+	}
 
 	pipeline, err := t.Pipelines.Find(ctx, execution.PipelineID)
 	if err != nil {
@@ -205,7 +213,7 @@ func (t *teardown) cancelDownstream(
 	failed := false
 	for _, s := range stages {
 		// check pipeline state
-		if s.Status.IsFailed() {
+		if s.Status.IsFailed() && !isFailureAllowed(s) {
 			failed = true
 		}
 	}
@@ -261,6 +269,11 @@ func (t *teardown) cancelDownstream(
 	return errs
 }
 
+// isFailureAllowed returns true if the stage is a matrix cell that is allowed to fail.
+func isFailureAllowed(stage *types.Stage) bool {
+	return stage.Matrix != nil && stage.Matrix.AllowFailure
+}
+
 func isexecutionComplete(stages []*types.Stage) bool {
 	for _, stage := range stages {
 		if stage.Status == enum.CIStatusPending ||
@@ -292,9 +305,13 @@ func areDepsComplete(stage *types.Stage, stages []*types.Stage) bool {
 
 // scheduleDownstream is a helper function that tests for
 // downstream stages and schedules stages if all dependencies
-// and execution requirements are met.
+// and execution requirements are met. Stages guarded by an
+// approval gate are blocked until they get approved instead.
+//
+//nolint:gocognit // refactor if needed
 func (t *teardown) scheduleDownstream(
 	ctx context.Context,
+	repo *types.Repository,
 	stages []*types.Stage,
 ) error {
 	var errs error
@@ -325,9 +342,18 @@ func (t *teardown) scheduleDownstream(
 			Str("stage.depends_on", strings.Join(sibling.DependsOn, ",")).
 			Logger()
 
-		log.Debug().Msg("manager: schedule next stage")
+		blocked := sibling.Approval != nil
+		if blocked {
+			log.Debug().Msg("manager: block next stage until approved")
+
+			sibling.Status = enum.CIStatusBlocked
+			sibling.Approval.Deadline = time.Now().UnixMilli() + sibling.Approval.Timeout
+		} else {
+			log.Debug().Msg("manager: schedule next stage")
+
+			sibling.Status = enum.CIStatusPending
+		}
 
-		sibling.Status = enum.CIStatusPending
 		err := t.Stages.Update(noContext, sibling)
 		if errors.Is(err, gitness_store.ErrVersionConflict) {
 			rErr := t.resync(ctx, sibling)
@@ -342,6 +368,15 @@ func (t *teardown) scheduleDownstream(
 			errs = multierror.Append(errs, err)
 		}
 
+		if blocked {
+			err = t.SSEStreamer.Publish(noContext, repo.ParentID, enum.SSETypeStageApprovalRequested, sibling)
+			if err != nil {
+				log.Warn().Err(err).
+					Msg("manager: could not publish stage approval requested event")
+			}
+			continue
+		}
+
 		err = t.Scheduler.Schedule(noContext, sibling)
 		if err != nil {
 			log.Error().Err(err).
@@ -367,6 +402,7 @@ func (t *teardown) resync(ctx context.Context, stage *types.Stage) error {
 	stage.Machine = updated.Machine
 	stage.Started = updated.Started
 	stage.Stopped = updated.Stopped
+	stage.Approval = updated.Approval
 	return nil
 }
 
@@ -0,0 +1,152 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	goruntime "runtime"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const defaultHeartbeatInterval = 20 * time.Second
+
+// Heartbeat registers the runner embedded in the server and keeps it online,
+// as stages are only dispatched to registered runners.
+type Heartbeat struct {
+	runnerStore store.RunnerStore
+	identifier  string
+	labels      map[string]string
+	capacity    int
+	interval    time.Duration
+}
+
+func NewHeartbeat(
+	runnerStore store.RunnerStore,
+	config *types.Config,
+) *Heartbeat {
+	// send multiple heartbeats within the timeout so a single missed one doesn't take the runner offline.
+	interval := config.CI.Runners.HeartbeatTimeout / 3
+	if interval <= 0 {
+		interval = defaultHeartbeatInterval
+	}
+
+	return &Heartbeat{
+		runnerStore: runnerStore,
+		identifier:  config.InstanceID,
+		labels:      config.CI.Runners.EmbeddedLabels,
+		capacity:    config.CI.ParallelWorkers,
+		interval:    interval,
+	}
+}
+
+// Run registers the embedded runner and sends heartbeats until the context is canceled.
+func (h *Heartbeat) Run(ctx context.Context) {
+	if err := h.register(ctx); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("failed to register embedded runner")
+	}
+
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := h.beat(ctx); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to send heartbeat of embedded runner")
+		}
+	}
+}
+
+// register creates the embedded runner or updates its registration with the current configuration.
+func (h *Heartbeat) register(ctx context.Context) error {
+	now := time.Now().UnixMilli()
+
+	runner, err := h.runnerStore.FindByIdentifier(ctx, h.identifier)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		runner = &types.Runner{
+			Identifier:    h.identifier,
+			Description:   "Runner embedded in the server",
+			Labels:        h.labels,
+			Capacity:      h.capacity,
+			OS:            goruntime.GOOS,
+			Arch:          goruntime.GOARCH,
+			Embedded:      true,
+			LastHeartbeat: now,
+			Created:       now,
+			Updated:       now,
+		}
+
+		err = h.runnerStore.Create(ctx, runner)
+		if err != nil {
+			return fmt.Errorf("failed to create runner: %w", err)
+		}
+
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find runner: %w", err)
+	}
+
+	if !runner.Embedded {
+		return fmt.Errorf("runner %q is already registered as remote runner", h.identifier)
+	}
+
+	runner.Labels = h.labels
+	runner.Capacity = h.capacity
+	runner.OS = goruntime.GOOS
+	runner.Arch = goruntime.GOARCH
+	runner.LastHeartbeat = now
+
+	err = h.runnerStore.Update(ctx, runner)
+	if err != nil {
+		return fmt.Errorf("failed to update runner: %w", err)
+	}
+
+	return nil
+}
+
+func (h *Heartbeat) beat(ctx context.Context) error {
+	runner, err := h.runnerStore.FindByIdentifier(ctx, h.identifier)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		// the runner was deleted, register it again.
+		return h.register(ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find runner: %w", err)
+	}
+
+	if !runner.Embedded {
+		return fmt.Errorf("runner %q is already registered as remote runner", h.identifier)
+	}
+
+	err = h.runnerStore.UpdateHeartbeat(ctx, runner.ID, time.Now().UnixMilli())
+	if err != nil {
+		return fmt.Errorf("failed to update heartbeat: %w", err)
+	}
+
+	return nil
+}
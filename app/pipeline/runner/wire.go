@@ -16,6 +16,7 @@ package runner
 
 import (
 	"github.com/harness/gitness/app/pipeline/resolver"
+	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/types"
 
 	runtime2 "github.com/drone-runners/drone-runner-docker/engine2/runtime"
@@ -28,6 +29,7 @@ import (
 var WireSet = wire.NewSet(
 	ProvideExecutionRunner,
 	ProvideExecutionPoller,
+	ProvideHeartbeat,
 )
 
 // ProvideExecutionRunner provides an execution runner.
@@ -47,3 +49,11 @@ func ProvideExecutionPoller(
 ) *poller.Poller {
 	return NewExecutionPoller(runner, client)
 }
+
+// ProvideHeartbeat provides the registration and heartbeat of the embedded runner.
+func ProvideHeartbeat(
+	runnerStore store.RunnerStore,
+	config *types.Config,
+) *Heartbeat {
+	return NewHeartbeat(runnerStore, config)
+}
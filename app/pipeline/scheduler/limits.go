@@ -0,0 +1,220 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// limitScope is a concurrency limit an execution is subject to.
+// Executions sharing the same key compete for the same slots.
+type limitScope struct {
+	key    string
+	limit  int
+	reason string
+}
+
+// limitResolver resolves the concurrency limits of executions.
+// It caches pipelines and spaces, so a new resolver should be used for every scheduling round.
+type limitResolver struct {
+	pipelineStore store.PipelineStore
+	repoStore     store.RepoStore
+	spaceStore    store.SpaceStore
+
+	pipelines map[int64]*types.Pipeline
+	spaces    map[int64]*types.Space
+	repoScope map[int64][]limitScope
+}
+
+func newLimitResolver(
+	pipelineStore store.PipelineStore,
+	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
+) *limitResolver {
+	return &limitResolver{
+		pipelineStore: pipelineStore,
+		repoStore:     repoStore,
+		spaceStore:    spaceStore,
+		pipelines:     map[int64]*types.Pipeline{},
+		spaces:        map[int64]*types.Space{},
+		repoScope:     map[int64][]limitScope{},
+	}
+}
+
+// scopes returns all concurrency limits the execution is subject to:
+// the limit of its pipeline and the limits of all spaces the repository is part of.
+func (r *limitResolver) scopes(ctx context.Context, execution *types.Execution) ([]limitScope, error) {
+	pipeline, ok := r.pipelines[execution.PipelineID]
+	if !ok {
+		var err error
+		pipeline, err = r.pipelineStore.Find(ctx, execution.PipelineID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find pipeline %d: %w", execution.PipelineID, err)
+		}
+		r.pipelines[execution.PipelineID] = pipeline
+	}
+
+	var scopes []limitScope
+	if pipeline.ConcurrencyLimit > 0 {
+		scopes = append(scopes, limitScope{
+			key:   fmt.Sprintf("pipeline:%d", pipeline.ID),
+			limit: pipeline.ConcurrencyLimit,
+			reason: fmt.Sprintf("Waiting for a free slot: pipeline %q allows %d concurrent execution(s).",
+				pipeline.Identifier, pipeline.ConcurrencyLimit),
+		})
+	}
+
+	spaceScopes, err := r.spaceScopes(ctx, execution.RepoID)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(scopes, spaceScopes...), nil
+}
+
+func (r *limitResolver) spaceScopes(ctx context.Context, repoID int64) ([]limitScope, error) {
+	if scopes, ok := r.repoScope[repoID]; ok {
+		return scopes, nil
+	}
+
+	repo, err := r.repoStore.Find(ctx, repoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository %d: %w", repoID, err)
+	}
+
+	var scopes []limitScope
+	for spaceID := repo.ParentID; spaceID != 0; {
+		space, ok := r.spaces[spaceID]
+		if !ok {
+			space, err = r.spaceStore.Find(ctx, spaceID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find space %d: %w", spaceID, err)
+			}
+			r.spaces[spaceID] = space
+		}
+
+		if space.ExecutionLimit > 0 {
+			scopes = append(scopes, limitScope{
+				key:   fmt.Sprintf("space:%d", space.ID),
+				limit: space.ExecutionLimit,
+				reason: fmt.Sprintf("Waiting for a free slot: space %q allows %d concurrent execution(s).",
+					space.Path, space.ExecutionLimit),
+			})
+		}
+
+		spaceID = space.ParentID
+	}
+
+	r.repoScope[repoID] = scopes
+
+	return scopes, nil
+}
+
+// spaceIDs returns the IDs of all spaces the repository is part of, starting with its parent space.
+func (r *limitResolver) spaceIDs(ctx context.Context, repoID int64) ([]int64, error) {
+	repo, err := r.repoStore.Find(ctx, repoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository %d: %w", repoID, err)
+	}
+
+	var spaceIDs []int64
+	for spaceID := repo.ParentID; spaceID != 0; {
+		space, ok := r.spaces[spaceID]
+		if !ok {
+			space, err = r.spaceStore.Find(ctx, spaceID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find space %d: %w", spaceID, err)
+			}
+			r.spaces[spaceID] = space
+		}
+
+		spaceIDs = append(spaceIDs, space.ID)
+		spaceID = space.ParentID
+	}
+
+	return spaceIDs, nil
+}
+
+// blockedExecutions returns the queue reason of every waiting execution that can't start
+// without exceeding one of its concurrency limits. Started executions always hold their slots,
+// waiting executions are admitted in the order they are provided (oldest first).
+func blockedExecutions(
+	started []*types.Execution,
+	waiting []*types.Execution,
+	scopes map[int64][]limitScope,
+) map[int64]string {
+	used := map[string]int{}
+	for _, execution := range started {
+		for _, scope := range scopes[execution.ID] {
+			used[scope.key]++
+		}
+	}
+
+	blocked := map[int64]string{}
+	for _, execution := range waiting {
+		admit := true
+		for _, scope := range scopes[execution.ID] {
+			if used[scope.key] >= scope.limit {
+				blocked[execution.ID] = scope.reason
+				admit = false
+				break
+			}
+		}
+		if !admit {
+			continue
+		}
+
+		for _, scope := range scopes[execution.ID] {
+			used[scope.key]++
+		}
+	}
+
+	return blocked
+}
+
+// splitExecutions splits the incomplete executions into the ones that already started
+// and the ones that are waiting to be scheduled. An execution counts as started once it's running
+// or any of its stages got picked up; it's waiting if any of its stages is ready to be scheduled.
+// Executions without schedulable stages (e.g. waiting for approval) are in neither group.
+func splitExecutions(
+	executions []*types.Execution,
+	stages []*types.Stage,
+) (started []*types.Execution, waiting []*types.Execution) {
+	pickedUp := map[int64]bool{}
+	schedulable := map[int64]bool{}
+	for _, stage := range stages {
+		if stage.Status == enum.CIStatusRunning || stage.Machine != "" {
+			pickedUp[stage.ExecutionID] = true
+			continue
+		}
+		schedulable[stage.ExecutionID] = true
+	}
+
+	for _, execution := range executions {
+		switch {
+		case execution.Status == enum.CIStatusRunning || pickedUp[execution.ID]:
+			started = append(started, execution)
+		case schedulable[execution.ID]:
+			waiting = append(waiting, execution)
+		}
+	}
+
+	return started, waiting
+}
@@ -0,0 +1,137 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestBlockedExecutions(t *testing.T) {
+	pipelineScope := limitScope{key: "pipeline:1", limit: 1, reason: "pipeline"}
+	spaceScope := limitScope{key: "space:1", limit: 2, reason: "space"}
+
+	tests := []struct {
+		name    string
+		started []int64
+		waiting []int64
+		scopes  map[int64][]limitScope
+		want    map[int64]string
+	}{
+		{
+			name:    "no limits",
+			started: []int64{1},
+			waiting: []int64{2, 3},
+			scopes:  map[int64][]limitScope{},
+			want:    map[int64]string{},
+		},
+		{
+			name:    "pipeline limit reached by started execution",
+			started: []int64{1},
+			waiting: []int64{2},
+			scopes: map[int64][]limitScope{
+				1: {pipelineScope},
+				2: {pipelineScope},
+			},
+			want: map[int64]string{2: "pipeline"},
+		},
+		{
+			name:    "oldest waiting execution is admitted first",
+			waiting: []int64{2, 3},
+			scopes: map[int64][]limitScope{
+				2: {pipelineScope},
+				3: {pipelineScope},
+			},
+			want: map[int64]string{3: "pipeline"},
+		},
+		{
+			name:    "space limit shared across pipelines",
+			started: []int64{1},
+			waiting: []int64{2, 3},
+			scopes: map[int64][]limitScope{
+				1: {spaceScope},
+				2: {spaceScope},
+				3: {spaceScope},
+			},
+			want: map[int64]string{3: "space"},
+		},
+		{
+			name:    "blocked execution doesn't take a slot",
+			started: []int64{1},
+			waiting: []int64{2, 3},
+			scopes: map[int64][]limitScope{
+				1: {pipelineScope},
+				2: {pipelineScope, spaceScope},
+				3: {spaceScope},
+			},
+			want: map[int64]string{2: "pipeline"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := blockedExecutions(executionsWithIDs(test.started), executionsWithIDs(test.waiting), test.scopes)
+			if len(got) != len(test.want) {
+				t.Fatalf("got %v, want %v", got, test.want)
+			}
+			for id, reason := range test.want {
+				if got[id] != reason {
+					t.Errorf("execution %d: got reason %q, want %q", id, got[id], reason)
+				}
+			}
+		})
+	}
+}
+
+func TestSplitExecutions(t *testing.T) {
+	executions := []*types.Execution{
+		{ID: 1, Status: enum.CIStatusRunning},
+		{ID: 2, Status: enum.CIStatusPending},
+		{ID: 3, Status: enum.CIStatusPending},
+		{ID: 4, Status: enum.CIStatusPending},
+	}
+	stages := []*types.Stage{
+		{ExecutionID: 1, Status: enum.CIStatusPending},
+		{ExecutionID: 2, Status: enum.CIStatusPending, Machine: "runner-1"},
+		{ExecutionID: 3, Status: enum.CIStatusPending},
+	}
+
+	started, waiting := splitExecutions(executions, stages)
+
+	if ids := idsOf(started); len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Errorf("got started executions %v, want [1 2]", ids)
+	}
+	if ids := idsOf(waiting); len(ids) != 1 || ids[0] != 3 {
+		t.Errorf("got waiting executions %v, want [3]", ids)
+	}
+}
+
+func executionsWithIDs(ids []int64) []*types.Execution {
+	executions := make([]*types.Execution, len(ids))
+	for i, id := range ids {
+		executions[i] = &types.Execution{ID: id, Status: enum.CIStatusPending}
+	}
+	return executions
+}
+
+func idsOf(executions []*types.Execution) []int64 {
+	ids := make([]int64, len(executions))
+	for i, execution := range executions {
+		ids[i] = execution.ID
+	}
+	return ids
+}
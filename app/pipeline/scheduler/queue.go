@@ -16,6 +16,7 @@ package scheduler
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
@@ -37,10 +38,27 @@ type queue struct {
 	store    store.StageStore
 	workers  map[*worker]struct{}
 	ctx      context.Context
+
+	executionStore store.ExecutionStore
+	pipelineStore  store.PipelineStore
+	repoStore      store.RepoStore
+	spaceStore     store.SpaceStore
+	runnerStore    store.RunnerStore
+
+	heartbeatTimeout time.Duration
 }
 
 // newQueue returns a new Queue backed by the build datastore.
-func newQueue(store store.StageStore, lock lock.MutexManager) (*queue, error) {
+func newQueue(
+	store store.StageStore,
+	executionStore store.ExecutionStore,
+	pipelineStore store.PipelineStore,
+	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
+	runnerStore store.RunnerStore,
+	lock lock.MutexManager,
+	heartbeatTimeout time.Duration,
+) (*queue, error) {
 	const lockKey = "build_queue"
 	mx, err := lock.NewMutex(lockKey)
 	if err != nil {
@@ -53,6 +71,14 @@ func newQueue(store store.StageStore, lock lock.MutexManager) (*queue, error) {
 		workers:  map[*worker]struct{}{},
 		interval: time.Minute,
 		ctx:      context.Background(),
+
+		executionStore: executionStore,
+		pipelineStore:  pipelineStore,
+		repoStore:      repoStore,
+		spaceStore:     spaceStore,
+		runnerStore:    runnerStore,
+
+		heartbeatTimeout: heartbeatTimeout,
 	}
 	go func() {
 		if err := q.start(); err != nil {
@@ -87,6 +113,7 @@ func (q *queue) Request(ctx context.Context, params Filter) (*types.Stage, error
 		kernel:  params.Kernel,
 		variant: params.Variant,
 		labels:  params.Labels,
+		machine: params.Machine,
 		channel: make(chan *types.Stage),
 		done:    ctx.Done(),
 	}
@@ -133,6 +160,19 @@ func (q *queue) signal(ctx context.Context) error {
 		return err
 	}
 
+	resolver := newLimitResolver(q.pipelineStore, q.repoStore, q.spaceStore)
+
+	blocked, err := q.blockedExecutions(ctx, items, resolver)
+	if err != nil {
+		return err
+	}
+
+	// stages are only dispatched to registered runners that are online and have free capacity.
+	runners, err := newRunnerPool(ctx, q.runnerStore, resolver, items, q.heartbeatTimeout)
+	if err != nil {
+		return err
+	}
+
 	q.Lock()
 	defer q.Unlock()
 	for _, item := range items {
@@ -143,6 +183,13 @@ func (q *queue) signal(ctx context.Context) error {
 			continue
 		}
 
+		// if the pipeline or any of the spaces of the repository
+		// define execution concurrency limits, the stage has to wait
+		// until its execution is allowed to start.
+		if _, ok := blocked[item.ExecutionID]; ok {
+			continue
+		}
+
 		// if the stage defines concurrency limits we
 		// need to make sure those limits are not exceeded
 		// before proceeding.
@@ -164,6 +211,11 @@ func (q *queue) signal(ctx context.Context) error {
 				continue
 			}
 
+			runner, ok := runners.available(w.machine)
+			if !ok || !runners.serves(runner, item) {
+				continue
+			}
+
 			if w.os != "" || w.arch != "" || w.variant != "" || w.kernel != "" {
 				// the worker is platform-specific. check to ensure
 				// the queue item matches the worker platform.
@@ -185,14 +237,14 @@ func (q *queue) signal(ctx context.Context) error {
 				}
 			}
 
-			if len(item.Labels) > 0 || len(w.labels) > 0 {
-				if !checkLabels(item.Labels, w.labels) {
-					continue
-				}
+			// the runner must provide all labels the pipeline requires.
+			if !matchLabels(item.Labels, runner.Labels, w.labels) {
+				continue
 			}
 
 			select {
 			case w.channel <- item:
+				runners.assign(w.machine)
 			case <-w.done:
 			}
 
@@ -203,6 +255,58 @@ func (q *queue) signal(ctx context.Context) error {
 	return nil
 }
 
+// blockedExecutions returns the executions that can't be started yet because of pipeline
+// or space concurrency limits, mapped to the reason, and stores the queue reason of every waiting execution.
+func (q *queue) blockedExecutions(
+	ctx context.Context,
+	stages []*types.Stage,
+	resolver *limitResolver,
+) (map[int64]string, error) {
+	executions, err := q.executionStore.ListIncomplete(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list incomplete executions: %w", err)
+	}
+
+	started, waiting := splitExecutions(executions, stages)
+	if len(waiting) == 0 {
+		return nil, nil
+	}
+
+	scopes := make(map[int64][]limitScope, len(started)+len(waiting))
+	for _, group := range [][]*types.Execution{started, waiting} {
+		for _, execution := range group {
+			var executionScopes []limitScope
+			executionScopes, err = resolver.scopes(ctx, execution)
+			if err != nil {
+				// don't block scheduling, treat the execution as unlimited.
+				log.Ctx(ctx).Warn().Err(err).
+					Int64("execution_id", execution.ID).
+					Msg("failed to resolve execution concurrency limits")
+				continue
+			}
+			scopes[execution.ID] = executionScopes
+		}
+	}
+
+	blocked := blockedExecutions(started, waiting, scopes)
+
+	for _, execution := range waiting {
+		reason := blocked[execution.ID]
+		if reason == execution.QueueReason {
+			continue
+		}
+
+		err = q.executionStore.UpdateQueueReason(ctx, execution.ID, reason)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("execution_id", execution.ID).
+				Msg("failed to update execution queue reason")
+		}
+	}
+
+	return blocked, nil
+}
+
 func (q *queue) start() error {
 	for {
 		select {
@@ -230,22 +334,11 @@ type worker struct {
 	kernel  string
 	variant string
 	labels  map[string]string
+	machine string
 	channel chan *types.Stage
 	done    <-chan struct{}
 }
 
-func checkLabels(a, b map[string]string) bool {
-	if len(a) != len(b) {
-		return false
-	}
-	for k, v := range a {
-		if w, ok := b[k]; !ok || v != w {
-			return false
-		}
-	}
-	return true
-}
-
 func withinLimits(stage *types.Stage, siblings []*types.Stage) bool {
 	if stage.Limit == 0 {
 		return true
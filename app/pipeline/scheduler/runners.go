@@ -0,0 +1,141 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// runnerPool tracks the registered runners and the stages assigned to them during a scheduling round.
+type runnerPool struct {
+	runners map[string]*types.Runner
+	running map[string]int
+	// repoSpaces holds the IDs of all spaces a repository is part of,
+	// it's only populated if there are runners registered for a space.
+	repoSpaces map[int64][]int64
+}
+
+func newRunnerPool(
+	ctx context.Context,
+	runnerStore store.RunnerStore,
+	resolver *limitResolver,
+	stages []*types.Stage,
+	heartbeatTimeout time.Duration,
+) (*runnerPool, error) {
+	runners, err := runnerStore.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list runners: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	p := &runnerPool{
+		runners:    make(map[string]*types.Runner, len(runners)),
+		running:    make(map[string]int, len(runners)),
+		repoSpaces: map[int64][]int64{},
+	}
+
+	spaceScoped := false
+	for _, runner := range runners {
+		if runner.Draining || !runner.IsOnline(now, heartbeatTimeout) {
+			continue
+		}
+		p.runners[runner.Identifier] = runner
+		spaceScoped = spaceScoped || runner.SpaceID != 0
+	}
+
+	for _, stage := range stages {
+		if stage.Machine != "" {
+			p.running[stage.Machine]++
+		}
+	}
+
+	if !spaceScoped {
+		return p, nil
+	}
+
+	for _, stage := range stages {
+		if stage.Machine != "" {
+			continue
+		}
+		if _, ok := p.repoSpaces[stage.RepoID]; ok {
+			continue
+		}
+
+		var spaceIDs []int64
+		spaceIDs, err = resolver.spaceIDs(ctx, stage.RepoID)
+		if err != nil {
+			// the stage can still be executed by instance wide runners.
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("stage.id", stage.ID).
+				Msg("failed to resolve spaces of stage repository")
+		}
+		p.repoSpaces[stage.RepoID] = spaceIDs
+	}
+
+	return p, nil
+}
+
+// available returns the runner of the worker if it's online, not draining and has free capacity.
+func (p *runnerPool) available(machine string) (*types.Runner, bool) {
+	runner, ok := p.runners[machine]
+	if !ok {
+		return nil, false
+	}
+	if runner.Capacity > 0 && p.running[machine] >= runner.Capacity {
+		return nil, false
+	}
+	return runner, true
+}
+
+// serves returns true if the runner is allowed to execute the stage,
+// runners registered for a space only execute stages of repositories in that space or its subspaces.
+func (p *runnerPool) serves(runner *types.Runner, stage *types.Stage) bool {
+	if runner.SpaceID == 0 {
+		return true
+	}
+	for _, spaceID := range p.repoSpaces[stage.RepoID] {
+		if spaceID == runner.SpaceID {
+			return true
+		}
+	}
+	return false
+}
+
+// assign takes a slot of the runner's capacity.
+func (p *runnerPool) assign(machine string) {
+	p.running[machine]++
+}
+
+// matchLabels returns true if every label required by the stage is provided
+// either by the registered runner or by the worker requesting the stage.
+func matchLabels(required map[string]string, runnerLabels map[string]string, workerLabels map[string]string) bool {
+	for k, v := range required {
+		if w, ok := runnerLabels[k]; ok && w == v {
+			continue
+		}
+		if w, ok := workerLabels[k]; ok && w == v {
+			continue
+		}
+		return false
+	}
+	return true
+}
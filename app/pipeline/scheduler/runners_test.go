@@ -0,0 +1,112 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestMatchLabels(t *testing.T) {
+	tests := []struct {
+		name         string
+		required     map[string]string
+		runnerLabels map[string]string
+		workerLabels map[string]string
+		want         bool
+	}{
+		{
+			name: "no requirements",
+			runnerLabels: map[string]string{
+				"gpu": "true",
+			},
+			want: true,
+		},
+		{
+			name:         "provided by runner",
+			required:     map[string]string{"gpu": "true"},
+			runnerLabels: map[string]string{"gpu": "true", "zone": "eu"},
+			want:         true,
+		},
+		{
+			name:         "provided by runner and worker",
+			required:     map[string]string{"gpu": "true", "zone": "eu"},
+			runnerLabels: map[string]string{"gpu": "true"},
+			workerLabels: map[string]string{"zone": "eu"},
+			want:         true,
+		},
+		{
+			name:         "different value",
+			required:     map[string]string{"zone": "us"},
+			runnerLabels: map[string]string{"zone": "eu"},
+			want:         false,
+		},
+		{
+			name:     "missing label",
+			required: map[string]string{"gpu": "true"},
+			want:     false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := matchLabels(test.required, test.runnerLabels, test.workerLabels); got != test.want {
+				t.Errorf("want %t, got %t", test.want, got)
+			}
+		})
+	}
+}
+
+func TestRunnerPool(t *testing.T) {
+	pool := &runnerPool{
+		runners: map[string]*types.Runner{
+			"global": {Identifier: "global", Capacity: 2},
+			"space":  {Identifier: "space", SpaceID: 2, Capacity: 1},
+		},
+		running: map[string]int{
+			"global": 1,
+		},
+		repoSpaces: map[int64][]int64{
+			10: {2, 1},
+			11: {3, 1},
+		},
+	}
+
+	if _, ok := pool.available("offline"); ok {
+		t.Errorf("unknown runner must not be available")
+	}
+
+	runner, ok := pool.available("global")
+	if !ok {
+		t.Fatalf("runner with free capacity must be available")
+	}
+	if !pool.serves(runner, &types.Stage{RepoID: 11}) {
+		t.Errorf("instance wide runner must serve all repositories")
+	}
+
+	pool.assign("global")
+	if _, ok = pool.available("global"); ok {
+		t.Errorf("runner at capacity must not be available")
+	}
+
+	runner, _ = pool.available("space")
+	if !pool.serves(runner, &types.Stage{RepoID: 10}) {
+		t.Errorf("space runner must serve repositories of the space")
+	}
+	if pool.serves(runner, &types.Stage{RepoID: 11}) {
+		t.Errorf("space runner must not serve repositories of other spaces")
+	}
+}
@@ -16,6 +16,7 @@ package scheduler
 
 import (
 	"context"
+	"time"
 
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/lock"
@@ -32,6 +33,8 @@ type Filter struct {
 	Kernel  string
 	Variant string
 	Labels  map[string]string
+	// Machine identifies the registered runner requesting the stage.
+	Machine string
 }
 
 // Scheduler schedules Build stages for execution.
@@ -57,8 +60,18 @@ type scheduler struct {
 }
 
 // newScheduler provides an instance of a scheduler with cancel abilities.
-func newScheduler(stageStore store.StageStore, lock lock.MutexManager) (Scheduler, error) {
-	q, err := newQueue(stageStore, lock)
+func newScheduler(
+	stageStore store.StageStore,
+	executionStore store.ExecutionStore,
+	pipelineStore store.PipelineStore,
+	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
+	runnerStore store.RunnerStore,
+	lock lock.MutexManager,
+	heartbeatTimeout time.Duration,
+) (Scheduler, error) {
+	q, err := newQueue(stageStore, executionStore, pipelineStore, repoStore, spaceStore, runnerStore,
+		lock, heartbeatTimeout)
 	if err != nil {
 		return nil, err
 	}
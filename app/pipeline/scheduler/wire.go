@@ -17,6 +17,7 @@ package scheduler
 import (
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/lock"
+	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
 )
@@ -29,7 +30,14 @@ var WireSet = wire.NewSet(
 // ProvideScheduler provides a scheduler which can be used to schedule and request builds.
 func ProvideScheduler(
 	stageStore store.StageStore,
+	executionStore store.ExecutionStore,
+	pipelineStore store.PipelineStore,
+	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
+	runnerStore store.RunnerStore,
 	lock lock.MutexManager,
+	config *types.Config,
 ) (Scheduler, error) {
-	return newScheduler(stageStore, lock)
+	return newScheduler(stageStore, executionStore, pipelineStore, repoStore, spaceStore, runnerStore,
+		lock, config.CI.Runners.HeartbeatTimeout)
 }
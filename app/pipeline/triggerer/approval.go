@@ -0,0 +1,123 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultApprovalTimeout is used for approval gates that don't declare a timeout.
+const defaultApprovalTimeout = 24 * time.Hour
+
+// approvalDocument is the subset of a drone yaml document that is relevant for approval gates.
+// The drone yaml parser doesn't know about approvals, hence the documents are decoded separately.
+//
+//	kind: pipeline
+//	name: deploy
+//	approval:
+//	  roles: [space_owner]
+//	  approvers: [jane.doe]
+//	  timeout: 12h
+//	  on_timeout: skip
+type approvalDocument struct {
+	Kind     string               `yaml:"kind"`
+	Name     string               `yaml:"name"`
+	Approval *approvalDeclaration `yaml:"approval"`
+}
+
+type approvalDeclaration struct {
+	Roles     []string `yaml:"roles"`
+	Approvers []string `yaml:"approvers"`
+	Timeout   string   `yaml:"timeout"`
+	OnTimeout string   `yaml:"on_timeout"`
+}
+
+// parseApprovalGates returns the approval gates declared in the drone yaml, keyed by pipeline (stage) name.
+func parseApprovalGates(data []byte) (map[string]*types.StageApprovalGate, error) {
+	gates := map[string]*types.StageApprovalGate{}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		doc := approvalDocument{}
+		err := decoder.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode yaml document: %w", err)
+		}
+
+		if doc.Kind != "pipeline" || doc.Approval == nil {
+			continue
+		}
+
+		name := doc.Name
+		if name == "" {
+			name = "default"
+		}
+
+		gate, err := doc.Approval.toGate()
+		if err != nil {
+			return nil, fmt.Errorf("invalid approval declaration of pipeline %q: %w", name, err)
+		}
+
+		gates[name] = gate
+	}
+
+	return gates, nil
+}
+
+func (d *approvalDeclaration) toGate() (*types.StageApprovalGate, error) {
+	gate := &types.StageApprovalGate{
+		Approvers: d.Approvers,
+		Timeout:   defaultApprovalTimeout.Milliseconds(),
+	}
+
+	for _, r := range d.Roles {
+		role, ok := enum.MembershipRole(r).Sanitize()
+		if !ok {
+			return nil, fmt.Errorf("unknown role %q", r)
+		}
+		gate.Roles = append(gate.Roles, role)
+	}
+
+	if d.Timeout != "" {
+		timeout, err := time.ParseDuration(d.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse timeout: %w", err)
+		}
+		if timeout <= 0 {
+			return nil, errors.New("timeout must be positive")
+		}
+		gate.Timeout = timeout.Milliseconds()
+	}
+
+	onTimeout, ok := enum.StageApprovalTimeoutAction(d.OnTimeout).Sanitize()
+	if !ok {
+		return nil, fmt.Errorf("unknown on_timeout action %q", d.OnTimeout)
+	}
+	gate.OnTimeout = onTimeout
+
+	return gate, nil
+}
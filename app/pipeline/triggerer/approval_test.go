@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestParseApprovalGates(t *testing.T) {
+	data := []byte(`
+kind: pipeline
+name: build
+steps:
+- name: test
+  image: golang
+---
+kind: pipeline
+name: deploy
+approval:
+  roles: [space_owner]
+  approvers: [jane]
+  timeout: 1h
+  on_timeout: skip
+steps:
+- name: deploy
+  image: alpine
+---
+kind: pipeline
+approval: {}
+`)
+
+	gates, err := parseApprovalGates(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := map[string]*types.StageApprovalGate{
+		"deploy": {
+			Roles:     []enum.MembershipRole{enum.MembershipRoleSpaceOwner},
+			Approvers: []string{"jane"},
+			Timeout:   3600000,
+			OnTimeout: enum.StageApprovalTimeoutActionSkip,
+		},
+		"default": {
+			Timeout:   defaultApprovalTimeout.Milliseconds(),
+			OnTimeout: enum.StageApprovalTimeoutActionFail,
+		},
+	}
+	if !reflect.DeepEqual(gates, want) {
+		t.Errorf("unexpected gates: %+v", gates)
+	}
+
+	_, err = parseApprovalGates([]byte("kind: pipeline\napproval:\n  on_timeout: retry\n"))
+	if err == nil {
+		t.Errorf("expected error for unknown on_timeout action")
+	}
+}
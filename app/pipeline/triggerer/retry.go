@@ -0,0 +1,127 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func (t *triggerer) Retry(
+	ctx context.Context,
+	pipeline *types.Pipeline,
+	original *types.Execution,
+	triggeredBy *types.Principal,
+	failedOnly bool,
+) (*types.Execution, error) {
+	// The hook is rebuilt from the original execution, so the new execution runs for the same commit
+	// with the same trigger payload and parameters, even if the branch moved in the meantime.
+	hook := &Hook{
+		Parent:       original.Parent,
+		Trigger:      triggeredBy.UID,
+		TriggeredBy:  triggeredBy.ID,
+		Action:       original.Action,
+		Link:         original.Link,
+		Timestamp:    original.Timestamp,
+		Title:        original.Title,
+		Message:      original.Message,
+		Before:       original.Before,
+		After:        original.After,
+		Ref:          original.Ref,
+		Fork:         original.Fork,
+		Source:       original.Source,
+		Target:       original.Target,
+		AuthorLogin:  original.Author,
+		AuthorName:   original.AuthorName,
+		AuthorEmail:  original.AuthorEmail,
+		AuthorAvatar: original.AuthorAvatar,
+		Debug:        original.Debug,
+		Cron:         original.Cron,
+		Sender:       original.Sender,
+		Params:       maps.Clone(original.Params),
+		RetriedFrom:  original.Number,
+	}
+
+	var reuse map[string]*types.Stage
+	if failedOnly {
+		stages, err := t.stageStore.List(ctx, original.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list stages of the original execution: %w", err)
+		}
+
+		reuse = make(map[string]*types.Stage, len(stages))
+		for _, stage := range stages {
+			if stage.Status == enum.CIStatusSuccess {
+				reuse[stage.Name] = stage
+			}
+		}
+	}
+
+	return t.trigger(ctx, pipeline, hook, reuse)
+}
+
+// reuseStages carries over the successful stages of the original execution to the stages of a retry.
+// Outputs of stages aren't persisted and can't be restored, hence successful stages can only be
+// carried over if none of the stages that run again depend on them. The stages are left untouched
+// and false is returned if that's not the case or the pipeline structure changed.
+func reuseStages(stages []*types.Stage, successful map[string]*types.Stage) bool {
+	names := make(map[string]struct{}, len(stages))
+	for _, stage := range stages {
+		names[stage.Name] = struct{}{}
+	}
+	for name := range successful {
+		if _, ok := names[name]; !ok {
+			return false
+		}
+	}
+
+	rerun := 0
+	for _, stage := range stages {
+		if _, ok := successful[stage.Name]; ok {
+			continue
+		}
+
+		rerun++
+		for _, dependency := range stage.DependsOn {
+			if _, ok := successful[dependency]; ok {
+				return false
+			}
+		}
+	}
+
+	if rerun == 0 {
+		return false
+	}
+
+	for _, stage := range stages {
+		original, ok := successful[stage.Name]
+		if !ok {
+			continue
+		}
+
+		stage.Status = original.Status
+		stage.ExitCode = original.ExitCode
+		stage.Machine = original.Machine
+		stage.Started = original.Started
+		stage.Stopped = original.Stopped
+		stage.Approval = nil
+	}
+
+	return true
+}
@@ -0,0 +1,91 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestReuseStages(t *testing.T) {
+	successful := map[string]*types.Stage{
+		"lint":  {Name: "lint", Status: enum.CIStatusSuccess, Started: 1, Stopped: 2},
+		"build": {Name: "build", Status: enum.CIStatusSuccess, Started: 3, Stopped: 4},
+	}
+
+	tests := []struct {
+		name   string
+		stages []*types.Stage
+		reused bool
+	}{
+		{
+			name: "independent failed stage",
+			stages: []*types.Stage{
+				{Name: "lint", Status: enum.CIStatusPending},
+				{Name: "build", Status: enum.CIStatusPending},
+				{Name: "test", Status: enum.CIStatusPending},
+			},
+			reused: true,
+		},
+		{
+			name: "failed stage depends on successful stage",
+			stages: []*types.Stage{
+				{Name: "lint", Status: enum.CIStatusPending},
+				{Name: "build", Status: enum.CIStatusPending},
+				{Name: "deploy", Status: enum.CIStatusWaitingOnDeps, DependsOn: []string{"build"}},
+			},
+			reused: false,
+		},
+		{
+			name: "pipeline structure changed",
+			stages: []*types.Stage{
+				{Name: "lint", Status: enum.CIStatusPending},
+				{Name: "test", Status: enum.CIStatusPending},
+			},
+			reused: false,
+		},
+		{
+			name: "nothing to rerun",
+			stages: []*types.Stage{
+				{Name: "lint", Status: enum.CIStatusPending},
+				{Name: "build", Status: enum.CIStatusPending},
+			},
+			reused: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reused := reuseStages(test.stages, successful)
+			if reused != test.reused {
+				t.Fatalf("expected reused=%t, got %t", test.reused, reused)
+			}
+
+			for _, stage := range test.stages {
+				original, ok := successful[stage.Name]
+				switch {
+				case reused && ok:
+					if stage.Status != enum.CIStatusSuccess || stage.Started != original.Started {
+						t.Errorf("stage %q wasn't carried over: %+v", stage.Name, stage)
+					}
+				case stage.Status == enum.CIStatusSuccess:
+					t.Errorf("stage %q must not be carried over", stage.Name)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// cancelSuperseded cancels all pending or running executions of the pipeline
+// that were triggered for the same ref before the provided execution.
+func (t *triggerer) cancelSuperseded(
+	ctx context.Context,
+	repo *types.Repository,
+	execution *types.Execution,
+) error {
+	if execution.Ref == "" {
+		return nil
+	}
+
+	executions, err := t.executionStore.ListIncomplete(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list incomplete executions: %w", err)
+	}
+
+	for _, superseded := range executions {
+		if superseded.PipelineID != execution.PipelineID ||
+			superseded.Ref != execution.Ref ||
+			superseded.Number >= execution.Number {
+			continue
+		}
+
+		superseded.SupersededBy = execution.Number
+		err = t.canceler.Cancel(ctx, repo, superseded)
+		if err != nil {
+			// continue with the remaining executions, the superseded one might have finished in the meantime.
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("execution.number", superseded.Number).
+				Msg("trigger: failed to cancel superseded execution")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	v1yaml "github.com/drone/spec/dist/go"
+	"gopkg.in/yaml.v3"
+)
+
+// stepTimeoutDocument is the subset of a drone yaml document that is relevant for step timeouts.
+// The drone yaml parser doesn't know about step timeouts, hence the documents are decoded separately.
+//
+//	kind: pipeline
+//	name: build
+//	steps:
+//	- name: test
+//	  timeout: 30m
+type stepTimeoutDocument struct {
+	Kind  string `yaml:"kind"`
+	Name  string `yaml:"name"`
+	Steps []struct {
+		Name    string `yaml:"name"`
+		Timeout string `yaml:"timeout"`
+	} `yaml:"steps"`
+}
+
+// parseStepTimeouts returns the step timeouts (in ms) declared in the drone yaml,
+// keyed by pipeline (stage) name and step name.
+func parseStepTimeouts(data []byte) (map[string]map[string]int64, error) {
+	timeouts := map[string]map[string]int64{}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		doc := stepTimeoutDocument{}
+		err := decoder.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode yaml document: %w", err)
+		}
+
+		if doc.Kind != "pipeline" {
+			continue
+		}
+
+		name := doc.Name
+		if name == "" {
+			name = "default"
+		}
+
+		for _, step := range doc.Steps {
+			if step.Timeout == "" {
+				continue
+			}
+
+			var timeout int64
+			timeout, err = parseTimeout(step.Timeout)
+			if err != nil {
+				return nil, fmt.Errorf("invalid timeout of step %q of pipeline %q: %w", step.Name, name, err)
+			}
+
+			if timeouts[name] == nil {
+				timeouts[name] = map[string]int64{}
+			}
+			timeouts[name][step.Name] = timeout
+		}
+	}
+
+	return timeouts, nil
+}
+
+// parseV1StepTimeouts returns the step timeouts (in ms) declared in a v1 yaml stage, keyed by step identifier.
+func parseV1StepTimeouts(stage *v1yaml.StageCI) (map[string]int64, error) {
+	var timeouts map[string]int64
+	for _, step := range stage.Steps {
+		if step.Timeout == "" {
+			continue
+		}
+
+		timeout, err := parseTimeout(step.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout of step %q: %w", step.Id, err)
+		}
+
+		if timeouts == nil {
+			timeouts = map[string]int64{}
+		}
+		timeouts[step.Id] = timeout
+	}
+
+	return timeouts, nil
+}
+
+func parseTimeout(s string) (int64, error) {
+	timeout, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse timeout: %w", err)
+	}
+	if timeout <= 0 {
+		return 0, errors.New("timeout must be positive")
+	}
+	return timeout.Milliseconds(), nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseStepTimeouts(t *testing.T) {
+	data := []byte(`
+kind: pipeline
+name: build
+steps:
+- name: test
+  image: golang
+  timeout: 30m
+- name: lint
+  image: golang
+---
+kind: pipeline
+steps:
+- name: deploy
+  image: alpine
+  timeout: 1h
+---
+kind: secret
+name: token
+`)
+
+	timeouts, err := parseStepTimeouts(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := map[string]map[string]int64{
+		"build":   {"test": 1800000},
+		"default": {"deploy": 3600000},
+	}
+	if !reflect.DeepEqual(timeouts, want) {
+		t.Errorf("unexpected timeouts: %+v", timeouts)
+	}
+
+	_, err = parseStepTimeouts([]byte("kind: pipeline\nsteps:\n- name: test\n  timeout: -5m\n"))
+	if err == nil {
+		t.Errorf("expected error for negative timeout")
+	}
+}
@@ -21,14 +21,17 @@ import (
 	"runtime/debug"
 	"time"
 
+	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/checks"
 	"github.com/harness/gitness/app/pipeline/converter"
+	"github.com/harness/gitness/app/pipeline/converter/matrix"
 	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/pipeline/manager"
 	"github.com/harness/gitness/app/pipeline/resolver"
 	"github.com/harness/gitness/app/pipeline/scheduler"
 	"github.com/harness/gitness/app/pipeline/triggerer/dag"
 	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/store/database/dbtx"
@@ -71,6 +74,7 @@ type Hook struct {
 	Cron         string             `json:"cron"`
 	Sender       string             `json:"sender"`
 	Params       map[string]string  `json:"params"`
+	RetriedFrom  int64              `json:"retried_from"`
 }
 
 // Triggerer is responsible for triggering a Execution from an
@@ -78,6 +82,17 @@ type Hook struct {
 // returned.
 type Triggerer interface {
 	Trigger(ctx context.Context, pipeline *types.Pipeline, hook *Hook) (*types.Execution, error)
+
+	// Retry triggers a new execution with the trigger context of the original execution.
+	// If failedOnly is set, successful stages of the original execution are carried over
+	// if the pipeline structure allows it, otherwise the whole execution runs again.
+	Retry(
+		ctx context.Context,
+		pipeline *types.Pipeline,
+		original *types.Execution,
+		triggeredBy *types.Principal,
+		failedOnly bool,
+	) (*types.Execution, error)
 }
 
 type triggerer struct {
@@ -94,6 +109,8 @@ type triggerer struct {
 	templateStore    store.TemplateStore
 	pluginStore      store.PluginStore
 	publicAccess     publicaccess.Service
+	sseStreamer      sse.Streamer
+	canceler         canceler.Canceler
 }
 
 func New(
@@ -110,6 +127,8 @@ func New(
 	templateStore store.TemplateStore,
 	pluginStore store.PluginStore,
 	publicAccess publicaccess.Service,
+	sseStreamer sse.Streamer,
+	canceler canceler.Canceler,
 ) Triggerer {
 	return &triggerer{
 		executionStore:   executionStore,
@@ -125,14 +144,28 @@ func New(
 		templateStore:    templateStore,
 		pluginStore:      pluginStore,
 		publicAccess:     publicAccess,
+		sseStreamer:      sseStreamer,
+		canceler:         canceler,
 	}
 }
 
-//nolint:gocognit,gocyclo,cyclop //TODO: Refactor @Vistaar
 func (t *triggerer) Trigger(
 	ctx context.Context,
 	pipeline *types.Pipeline,
 	base *Hook,
+) (*types.Execution, error) {
+	return t.trigger(ctx, pipeline, base, nil)
+}
+
+// trigger creates the execution for the hook. Stages of the execution with a matching
+// successful stage in reuse are carried over instead of running again, if possible.
+//
+//nolint:gocognit,gocyclo,cyclop //TODO: Refactor @Vistaar
+func (t *triggerer) trigger(
+	ctx context.Context,
+	pipeline *types.Pipeline,
+	base *Hook,
+	reuse map[string]*types.Stage,
 ) (*types.Execution, error) {
 	log := log.With().
 		Int64("pipeline.id", pipeline.ID).
@@ -197,6 +230,7 @@ func (t *triggerer) Trigger(
 		Debug:        base.Debug,
 		Sender:       base.Sender,
 		Cron:         base.Cron,
+		RetriedFrom:  base.RetriedFrom,
 		Created:      now,
 		Updated:      now,
 	}
@@ -233,6 +267,24 @@ func (t *triggerer) Trigger(
 			return t.createExecutionWithError(ctx, pipeline, base, err.Error())
 		}
 
+		gates, err := parseApprovalGates(file.Data)
+		if err != nil {
+			log.Warn().Err(err).Msg("trigger: cannot parse approval gates")
+			return t.createExecutionWithError(ctx, pipeline, base, err.Error())
+		}
+
+		stepTimeouts, err := parseStepTimeouts(file.Data)
+		if err != nil {
+			log.Warn().Err(err).Msg("trigger: cannot parse step timeouts")
+			return t.createExecutionWithError(ctx, pipeline, base, err.Error())
+		}
+
+		cells, err := matrix.ParseCells(file.Data)
+		if err != nil {
+			log.Warn().Err(err).Msg("trigger: cannot parse matrix cells")
+			return t.createExecutionWithError(ctx, pipeline, base, err.Error())
+		}
+
 		var matched []*yaml.Pipeline
 		var dag = dag.New()
 		for _, document := range manifest.Resources {
@@ -319,6 +371,9 @@ func (t *triggerer) Trigger(
 			if stage.Name == "" {
 				stage.Name = "default"
 			}
+			stage.Approval = gates[stage.Name]
+			stage.StepTimeouts = stepTimeouts[stage.Name]
+			stage.Matrix = cells[stage.Name]
 			if len(stage.DependsOn) == 0 {
 				stage.Status = enum.CIStatusPending
 			}
@@ -347,6 +402,18 @@ func (t *triggerer) Trigger(
 		}
 	}
 
+	if len(reuse) > 0 && !reuseStages(stages, reuse) {
+		log.Info().Msg("trigger: successful stages can't be reused, retrying the whole execution")
+	}
+
+	// stages that are ready to run but are guarded by an approval gate have to wait for approval.
+	for _, stage := range stages {
+		if stage.Status == enum.CIStatusPending && stage.Approval != nil {
+			stage.Status = enum.CIStatusBlocked
+			stage.Approval.Deadline = now + stage.Approval.Timeout
+		}
+	}
+
 	// Increment pipeline number using optimistic locking.
 	pipeline, err = t.pipelineStore.IncrementSeqNum(ctx, pipeline)
 	if err != nil {
@@ -370,7 +437,23 @@ func (t *triggerer) Trigger(
 		log.Error().Err(err).Msg("trigger: could not write to check store")
 	}
 
+	// cancel older executions of the same ref, log on failure but don't error out the execution
+	if pipeline.CancelSuperseded {
+		err = t.cancelSuperseded(ctx, repo, execution)
+		if err != nil {
+			log.Warn().Err(err).Msg("trigger: could not cancel superseded executions")
+		}
+	}
+
 	for _, stage := range stages {
+		if stage.Status == enum.CIStatusBlocked {
+			// notify potential approvers, log on failure but don't error out the execution
+			err = t.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeStageApprovalRequested, stage)
+			if err != nil {
+				log.Warn().Err(err).Msg("trigger: failed to publish stage approval requested event")
+			}
+			continue
+		}
 		if stage.Status != enum.CIStatusPending {
 			continue
 		}
@@ -453,7 +536,7 @@ func parseV1Stages(
 
 		for idx, stage := range v.Stages {
 			// Only parse CI stages for now
-			switch stage.Spec.(type) {
+			switch spec := stage.Spec.(type) {
 			case *v1yaml.StageCI:
 				now := time.Now().UnixMilli()
 				var onSuccess, onFailure bool
@@ -468,6 +551,12 @@ func parseV1Stages(
 					}
 				}
 
+				var stepTimeouts map[string]int64
+				stepTimeouts, err = parseV1StepTimeouts(spec)
+				if err != nil {
+					return nil, fmt.Errorf("could not parse step timeouts of stage %q: %w", stage.Id, err)
+				}
+
 				dependsOn := []string{}
 				if prevStage != "" {
 					dependsOn = append(dependsOn, prevStage)
@@ -488,6 +577,7 @@ func parseV1Stages(
 					OnFailure: onFailure,
 					DependsOn: dependsOn,
 				}
+				temp.StepTimeouts = stepTimeouts
 				prevStage = temp.Name
 				stages = append(stages, temp)
 			default:
@@ -575,6 +665,7 @@ func (t *triggerer) createExecutionWithError(
 		AuthorAvatar: base.AuthorAvatar,
 		Debug:        base.Debug,
 		Sender:       base.Sender,
+		RetriedFrom:  base.RetriedFrom,
 		Created:      now,
 		Updated:      now,
 		Started:      now,
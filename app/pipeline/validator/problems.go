@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"gopkg.in/yaml.v3"
+)
+
+// problems collects the problems found while validating a pipeline yaml.
+type problems []types.PipelineProblem
+
+func (p *problems) add(severity enum.PipelineProblemSeverity, node *yaml.Node, format string, args ...any) {
+	problem := types.PipelineProblem{
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+	}
+	if node != nil {
+		problem.Line = node.Line
+		problem.Column = node.Column
+	}
+
+	*p = append(*p, problem)
+}
+
+func (p *problems) errorf(node *yaml.Node, format string, args ...any) {
+	p.add(enum.PipelineProblemSeverityError, node, format, args...)
+}
+
+func (p *problems) warnf(node *yaml.Node, format string, args ...any) {
+	p.add(enum.PipelineProblemSeverityWarning, node, format, args...)
+}
+
+func (p problems) hasErrors() bool {
+	for _, problem := range p {
+		if problem.Severity == enum.PipelineProblemSeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// result returns the validation result with problems ordered by their position in the yaml.
+// Problems without a position are listed first.
+func (p problems) result() *types.PipelineValidation {
+	list := make([]types.PipelineProblem, len(p))
+	copy(list, p)
+
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Line != list[j].Line {
+			return list[i].Line < list[j].Line
+		}
+		return list[i].Column < list[j].Column
+	})
+
+	return &types.PipelineValidation{
+		Valid:    !p.hasErrors(),
+		Problems: list,
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+)
+
+// v1SecretExpression matches secret references in expressions of the v1 yaml, e.g. ${{ secrets.get("token") }}.
+var v1SecretExpression = regexp.MustCompile(`secrets\.get\(\s*["']([^"']+)["']\s*\)`)
+
+// reference is a named reference to a resource that has to exist for the pipeline to execute.
+type reference struct {
+	name string
+	node *yaml.Node
+}
+
+// references holds the resources referenced by a pipeline yaml.
+type references struct {
+	secrets        []reference
+	connectors     []reference
+	stageTemplates []reference
+	stepTemplates  []reference
+	plugins        []reference
+}
+
+// collectDroneReferences returns the secrets referenced by the pipelines of a drone yaml.
+// Secrets declared by documents of kind secret are resolved by the runner and aren't included.
+func collectDroneReferences(docs []*yaml.Node) *references {
+	refs := &references{}
+	declared := map[string]struct{}{}
+
+	for _, doc := range docs {
+		root := documentRoot(doc)
+		kind := mappingValue(root, "kind")
+		if kind == nil {
+			continue
+		}
+
+		switch kind.Value {
+		case "secret":
+			if name := mappingValue(root, "name"); name != nil {
+				declared[name.Value] = struct{}{}
+			}
+		case "pipeline":
+			if secrets := mappingValue(root, "image_pull_secrets"); secrets != nil {
+				refs.secrets = appendScalars(refs.secrets, secrets)
+			}
+
+			walk(root, "", func(node *yaml.Node, _ string) {
+				if secret := mappingValue(node, "from_secret"); secret != nil && secret.Kind == yaml.ScalarNode {
+					refs.secrets = append(refs.secrets, reference{name: secret.Value, node: secret})
+				}
+			})
+		}
+	}
+
+	secrets := refs.secrets[:0]
+	for _, secret := range refs.secrets {
+		if _, ok := declared[secret.name]; !ok {
+			secrets = append(secrets, secret)
+		}
+	}
+	refs.secrets = secrets
+
+	return refs
+}
+
+// collectV1References returns the secrets, connectors, templates and plugins referenced by a v1 yaml.
+func collectV1References(docs []*yaml.Node) *references {
+	refs := &references{}
+
+	for _, doc := range docs {
+		walk(documentRoot(doc), "", func(node *yaml.Node, parentKey string) {
+			switch node.Kind {
+			case yaml.ScalarNode:
+				for _, match := range v1SecretExpression.FindAllStringSubmatch(node.Value, -1) {
+					refs.secrets = append(refs.secrets, reference{name: match[1], node: node})
+				}
+			case yaml.MappingNode:
+				collectV1MappingReferences(refs, node, parentKey)
+			case yaml.DocumentNode, yaml.SequenceNode, yaml.AliasNode:
+			}
+		})
+	}
+
+	return refs
+}
+
+func collectV1MappingReferences(refs *references, node *yaml.Node, parentKey string) {
+	if connector := mappingValue(node, "connector"); connector != nil {
+		refs.connectors = appendScalars(refs.connectors, connector)
+	}
+
+	if parentKey != "stages" && parentKey != "steps" {
+		return
+	}
+
+	typ := mappingValue(node, "type")
+	spec := mappingValue(node, "spec")
+	if typ == nil || spec == nil {
+		return
+	}
+
+	name := mappingValue(spec, "name")
+	switch {
+	case typ.Value == "template" && name != nil && parentKey == "stages":
+		refs.stageTemplates = append(refs.stageTemplates, reference{name: name.Value, node: name})
+	case typ.Value == "template" && name != nil:
+		refs.stepTemplates = append(refs.stepTemplates, reference{name: name.Value, node: name})
+	case typ.Value == "plugin" && parentKey == "steps":
+		if uses := mappingValue(spec, "uses"); uses != nil && uses.Value != "" {
+			name = uses
+		}
+		if name != nil {
+			refs.plugins = append(refs.plugins, reference{name: name.Value, node: name})
+		}
+	}
+}
+
+// appendScalars appends the scalar, the list of scalars or the list of mappings with a name key as references.
+func appendScalars(refs []reference, node *yaml.Node) []reference {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.Value != "" {
+			refs = append(refs, reference{name: node.Value, node: node})
+		}
+	case yaml.MappingNode:
+		if name := mappingValue(node, "name"); name != nil && name.Kind == yaml.ScalarNode {
+			refs = append(refs, reference{name: name.Value, node: name})
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			refs = appendScalars(refs, resolveAlias(item))
+		}
+	case yaml.DocumentNode, yaml.AliasNode:
+	}
+
+	return refs
+}
+
+// walk calls fn for the node and all its descendants.
+// parentKey is the key of the closest mapping the node is (an item of) a value of.
+func walk(node *yaml.Node, parentKey string, fn func(node *yaml.Node, parentKey string)) {
+	node = resolveAlias(node)
+	if node == nil {
+		return
+	}
+
+	fn(node, parentKey)
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			walk(node.Content[i+1], node.Content[i].Value, fn)
+		}
+	case yaml.SequenceNode, yaml.DocumentNode:
+		for _, item := range node.Content {
+			walk(item, parentKey, fn)
+		}
+	case yaml.ScalarNode, yaml.AliasNode:
+	}
+}
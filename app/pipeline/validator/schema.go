@@ -0,0 +1,478 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// valueKind is the kind of value expected for a key of the pipeline yaml.
+type valueKind int
+
+const (
+	// kindAny accepts any value.
+	kindAny valueKind = iota
+	// kindString accepts any scalar, the drone yaml parser converts numbers and booleans to strings.
+	kindString
+	kindBool
+	kindInt
+	// kindStringList accepts a list of scalars or a single scalar.
+	kindStringList
+	// kindList accepts a list of values matching the item schema.
+	kindList
+	// kindMap accepts a mapping with arbitrary keys.
+	kindMap
+	// kindObject accepts a mapping with the keys defined by the schema.
+	kindObject
+	// kindConstraint accepts a condition constraint, either a list of patterns
+	// or a mapping with include and exclude patterns.
+	kindConstraint
+)
+
+// maxSuggestionDistance is the maximum edit distance of a known key to be suggested for an unknown key.
+const maxSuggestionDistance = 2
+
+// schema describes the expected structure of a yaml value.
+type schema struct {
+	kind   valueKind
+	fields map[string]*schema
+	items  *schema
+}
+
+func object(fields map[string]*schema) *schema { return &schema{kind: kindObject, fields: fields} }
+func list(items *schema) *schema               { return &schema{kind: kindList, items: items} }
+
+var (
+	anyValue    = &schema{kind: kindAny}
+	stringValue = &schema{kind: kindString}
+	boolValue   = &schema{kind: kindBool}
+	intValue    = &schema{kind: kindInt}
+	stringList  = &schema{kind: kindStringList}
+	mapValue    = &schema{kind: kindMap}
+	constraint  = &schema{kind: kindConstraint}
+)
+
+// droneConditions describes the when and trigger sections of the drone yaml.
+var droneConditions = object(map[string]*schema{
+	"action":   constraint,
+	"branch":   constraint,
+	"cron":     constraint,
+	"event":    constraint,
+	"instance": constraint,
+	"paths":    constraint,
+	"ref":      constraint,
+	"repo":     constraint,
+	"status":   constraint,
+	"target":   constraint,
+})
+
+// droneStep describes a step or service of a drone pipeline.
+var droneStep = object(map[string]*schema{
+	"name":        stringValue,
+	"image":       stringValue,
+	"pull":        stringValue,
+	"commands":    stringList,
+	"command":     stringList,
+	"entrypoint":  stringList,
+	"detach":      boolValue,
+	"privileged":  boolValue,
+	"environment": mapValue,
+	"settings":    mapValue,
+	"failure":     stringValue,
+	"when":        droneConditions,
+	"depends_on":  stringList,
+	"volumes": list(object(map[string]*schema{
+		"name": stringValue,
+		"path": stringValue,
+	})),
+	"network_mode": stringValue,
+	"user":         stringValue,
+	"shell":        stringValue,
+	"working_dir":  stringValue,
+	"devices":      list(mapValue),
+	"dns":          stringList,
+	"dns_search":   stringList,
+	"extra_hosts":  stringList,
+	"ports":        list(anyValue),
+	"resources":    mapValue,
+	"build":        anyValue,
+	"push":         anyValue,
+	"timeout":      stringValue,
+})
+
+// dronePipeline describes a document of kind pipeline of the drone yaml.
+var dronePipeline = object(map[string]*schema{
+	"kind":    stringValue,
+	"type":    stringValue,
+	"name":    stringValue,
+	"version": stringValue,
+	"platform": object(map[string]*schema{
+		"os":      stringValue,
+		"arch":    stringValue,
+		"variant": stringValue,
+		"version": stringValue,
+	}),
+	"workspace": object(map[string]*schema{
+		"base": stringValue,
+		"path": stringValue,
+	}),
+	"clone": object(map[string]*schema{
+		"disable":     boolValue,
+		"depth":       intValue,
+		"retries":     intValue,
+		"skip_verify": boolValue,
+	}),
+	"concurrency": object(map[string]*schema{
+		"limit": intValue,
+	}),
+	"steps":              list(droneStep),
+	"services":           list(droneStep),
+	"trigger":            droneConditions,
+	"depends_on":         stringList,
+	"environment":        mapValue,
+	"image_pull_secrets": stringList,
+	"node":               mapValue,
+	"volumes": list(object(map[string]*schema{
+		"name":  stringValue,
+		"temp":  mapValue,
+		"host":  mapValue,
+		"claim": mapValue,
+	})),
+	"approval": object(map[string]*schema{
+		"roles":      stringList,
+		"approvers":  stringList,
+		"timeout":    stringValue,
+		"on_timeout": stringValue,
+	}),
+	"matrix": object(map[string]*schema{
+		"axes":          mapValue,
+		"exclude":       list(mapValue),
+		"allow_failure": list(mapValue),
+	}),
+	// runner specific keys
+	"metadata":             mapValue,
+	"node_selector":        mapValue,
+	"tolerations":          list(mapValue),
+	"service_account_name": stringValue,
+	"host_aliases":         list(mapValue),
+	"dns_config":           mapValue,
+	"pool":                 mapValue,
+	"server":               mapValue,
+})
+
+// droneSecret describes a document of kind secret of the drone yaml.
+var droneSecret = object(map[string]*schema{
+	"kind": stringValue,
+	"type": stringValue,
+	"name": stringValue,
+	"data": stringValue,
+	"get": object(map[string]*schema{
+		"path": stringValue,
+		"name": stringValue,
+		"key":  stringValue,
+	}),
+})
+
+// droneSignature describes a document of kind signature of the drone yaml.
+var droneSignature = object(map[string]*schema{
+	"kind": stringValue,
+	"hmac": stringValue,
+})
+
+// droneKinds maps the document kinds of the drone yaml to their schema.
+// Documents of other kinds are left to the drone yaml parser.
+var droneKinds = map[string]*schema{
+	"pipeline":  dronePipeline,
+	"secret":    droneSecret,
+	"signature": droneSignature,
+}
+
+// v1Config describes the top level keys of a v1 yaml.
+// The spec itself is validated by the v1 yaml parser.
+var v1Config = object(map[string]*schema{
+	"version": anyValue,
+	"kind":    stringValue,
+	"type":    stringValue,
+	"name":    stringValue,
+	"spec":    mapValue,
+	"inputs":  mapValue,
+})
+
+// checkDroneSchema checks all documents of a drone yaml for unknown keys and values of the wrong type.
+func checkDroneSchema(p *problems, docs []*yaml.Node) {
+	for _, doc := range docs {
+		root := documentRoot(doc)
+		if root == nil {
+			continue
+		}
+		if root.Kind != yaml.MappingNode {
+			p.errorf(root, "expected a mapping, got %s", describeNode(root))
+			continue
+		}
+
+		kind := mappingValue(root, "kind")
+		if kind == nil || kind.Kind != yaml.ScalarNode {
+			continue
+		}
+
+		s, ok := droneKinds[kind.Value]
+		if !ok {
+			continue
+		}
+
+		checkSchema(p, root, s, "")
+	}
+}
+
+// checkV1Schema checks the top level keys of a v1 yaml.
+func checkV1Schema(p *problems, docs []*yaml.Node) {
+	for _, doc := range docs {
+		root := documentRoot(doc)
+		if root == nil {
+			continue
+		}
+
+		checkSchema(p, root, v1Config, "")
+	}
+}
+
+// checkSchema checks the node against the schema.
+// Unknown keys are reported as warnings, as runners ignore keys they don't know about.
+func checkSchema(p *problems, node *yaml.Node, s *schema, path string) {
+	node = resolveAlias(node)
+	if isNull(node) {
+		return
+	}
+
+	switch s.kind {
+	case kindAny:
+	case kindString:
+		expectNodeKind(p, node, yaml.ScalarNode, path)
+	case kindBool:
+		if expectNodeKind(p, node, yaml.ScalarNode, path) && node.Tag != "!!bool" {
+			p.errorf(node, "%s: expected a boolean, got %q", describePath(path), node.Value)
+		}
+	case kindInt:
+		if expectNodeKind(p, node, yaml.ScalarNode, path) && node.Tag != "!!int" {
+			p.errorf(node, "%s: expected an integer, got %q", describePath(path), node.Value)
+		}
+	case kindStringList:
+		checkStringList(p, node, path)
+	case kindList:
+		if !expectNodeKind(p, node, yaml.SequenceNode, path) {
+			return
+		}
+		for i, item := range node.Content {
+			checkSchema(p, item, s.items, fmt.Sprintf("%s[%d]", path, i))
+		}
+	case kindMap:
+		expectNodeKind(p, node, yaml.MappingNode, path)
+	case kindObject:
+		if !expectNodeKind(p, node, yaml.MappingNode, path) {
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Tag == "!!merge" {
+				continue
+			}
+
+			field, ok := s.fields[key.Value]
+			if !ok {
+				p.warnf(key, "unknown key %q in %s%s", key.Value, describePath(path), suggestKey(key.Value, s.fields))
+				continue
+			}
+
+			checkSchema(p, value, field, joinPath(path, key.Value))
+		}
+	case kindConstraint:
+		if node.Kind != yaml.MappingNode {
+			checkStringList(p, node, path)
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Value != "include" && key.Value != "exclude" {
+				p.warnf(key, "unknown key %q in %s, expected include or exclude", key.Value, describePath(path))
+				continue
+			}
+
+			checkStringList(p, value, joinPath(path, key.Value))
+		}
+	}
+}
+
+func checkStringList(p *problems, node *yaml.Node, path string) {
+	node = resolveAlias(node)
+	if isNull(node) || node.Kind == yaml.ScalarNode {
+		return
+	}
+	if !expectNodeKind(p, node, yaml.SequenceNode, path) {
+		return
+	}
+	for i, item := range node.Content {
+		item = resolveAlias(item)
+		if item.Kind != yaml.ScalarNode {
+			p.errorf(item, "%s[%d]: expected a string, got %s", describePath(path), i, describeNode(item))
+		}
+	}
+}
+
+func expectNodeKind(p *problems, node *yaml.Node, kind yaml.Kind, path string) bool {
+	if node.Kind == kind {
+		return true
+	}
+
+	expected := describeNode(&yaml.Node{Kind: kind})
+	p.errorf(node, "%s: expected %s, got %s", describePath(path), expected, describeNode(node))
+
+	return false
+}
+
+// suggestKey returns a hint for a misspelled key, if a known key is close enough.
+func suggestKey(key string, fields map[string]*schema) string {
+	known := make([]string, 0, len(fields))
+	for k := range fields {
+		known = append(known, k)
+	}
+	sort.Strings(known)
+
+	suggestion := ""
+	best := maxSuggestionDistance + 1
+	for _, k := range known {
+		if d := levenshtein(strings.ToLower(key), k); d < best {
+			suggestion, best = k, d
+		}
+	}
+
+	if suggestion == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(", did you mean %q?", suggestion)
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func describePath(path string) string {
+	if path == "" {
+		return "document"
+	}
+	return path
+}
+
+func describeNode(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "a mapping"
+	case yaml.SequenceNode:
+		return "a list"
+	case yaml.ScalarNode:
+		return "a scalar"
+	case yaml.DocumentNode:
+		return "a document"
+	case yaml.AliasNode:
+		return "an alias"
+	default:
+		return "an unknown value"
+	}
+}
+
+// decodeDocuments decodes all documents of the yaml keeping the position of each node.
+func decodeDocuments(data []byte) ([]*yaml.Node, error) {
+	var docs []*yaml.Node
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		doc := &yaml.Node{}
+		err := decoder.Decode(doc)
+		if errors.Is(err, io.EOF) {
+			return docs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if documentRoot(doc) != nil {
+			docs = append(docs, doc)
+		}
+	}
+}
+
+// documentRoot returns the root node of the document or nil if the document is empty.
+func documentRoot(doc *yaml.Node) *yaml.Node {
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil
+	}
+	return resolveAlias(doc.Content[0])
+}
+
+// mappingValue returns the value of the key in the mapping node or nil if the key doesn't exist.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return resolveAlias(node.Content[i+1])
+		}
+	}
+	return nil
+}
+
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	for node != nil && node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	return node
+}
+
+func isNull(node *yaml.Node) bool {
+	return node == nil || (node.Kind == yaml.ScalarNode && node.Tag == "!!null")
+}
@@ -0,0 +1,268 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	droneyaml "github.com/drone/drone-yaml/yaml"
+	"github.com/drone/drone-yaml/yaml/linter"
+	v1yaml "github.com/drone/spec/dist/go"
+	"github.com/drone/spec/dist/go/parse/normalize"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// v1YamlPattern detects v1 yaml the same way the triggerer does.
+	v1YamlPattern = regexp.MustCompilePOSIX(`^spec:`)
+
+	// yamlErrorPattern extracts the line from errors of the yaml parsers.
+	yamlErrorPattern = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+)
+
+// Service validates pipeline yaml without executing it.
+type Service interface {
+	// Validate parses the pipeline yaml, checks it against the schema and verifies that the secrets,
+	// connectors, templates and plugins it references exist. References are resolved the same way as
+	// during execution, secrets, connectors and templates are looked up in the space of the repository.
+	Validate(ctx context.Context, repo *types.Repository, data []byte) (*types.PipelineValidation, error)
+}
+
+type service struct {
+	secretStore    store.SecretStore
+	connectorStore store.ConnectorStore
+	templateStore  store.TemplateStore
+	pluginStore    store.PluginStore
+}
+
+func newService(
+	secretStore store.SecretStore,
+	connectorStore store.ConnectorStore,
+	templateStore store.TemplateStore,
+	pluginStore store.PluginStore,
+) Service {
+	return &service{
+		secretStore:    secretStore,
+		connectorStore: connectorStore,
+		templateStore:  templateStore,
+		pluginStore:    pluginStore,
+	}
+}
+
+func (s *service) Validate(
+	ctx context.Context,
+	repo *types.Repository,
+	data []byte,
+) (*types.PipelineValidation, error) {
+	p := &problems{}
+
+	docs, err := decodeDocuments(data)
+	if err != nil {
+		addParseError(p, err)
+		return p.result(), nil
+	}
+	if len(docs) == 0 {
+		p.errorf(nil, "pipeline yaml is empty")
+		return p.result(), nil
+	}
+
+	var refs *references
+	if v1YamlPattern.Match(data) {
+		checkV1Schema(p, docs)
+		if !p.hasErrors() {
+			checkV1Config(p, data)
+		}
+		refs = collectV1References(docs)
+	} else {
+		checkDroneSchema(p, docs)
+		if !p.hasErrors() {
+			checkDroneManifest(p, data)
+		}
+		refs = collectDroneReferences(docs)
+	}
+
+	if err = s.checkReferences(ctx, p, repo.ParentID, refs); err != nil {
+		return nil, err
+	}
+
+	return p.result(), nil
+}
+
+// addParseError adds the error of a yaml parser as problem, using the line of the error if available.
+func addParseError(p *problems, err error) {
+	match := yamlErrorPattern.FindStringSubmatch(err.Error())
+	if match == nil {
+		p.errorf(nil, "%s", err.Error())
+		return
+	}
+
+	line, _ := strconv.Atoi(match[1])
+	p.errorf(&yaml.Node{Line: line}, "%s", match[2])
+}
+
+// checkDroneManifest parses and lints the drone yaml the same way the triggerer does.
+func checkDroneManifest(p *problems, data []byte) {
+	manifest, err := droneyaml.ParseString(string(data))
+	if err != nil {
+		addParseError(p, err)
+		return
+	}
+
+	if err = linter.Manifest(manifest, true); err != nil {
+		p.errorf(nil, "%s", err.Error())
+	}
+}
+
+// checkV1Config parses and normalizes the v1 yaml the same way the triggerer does.
+func checkV1Config(p *problems, data []byte) {
+	config, err := v1yaml.ParseBytes(data)
+	if err != nil {
+		p.errorf(nil, "invalid v1 yaml: %s", err.Error())
+		return
+	}
+
+	if err = normalize.Normalize(config); err != nil {
+		p.errorf(nil, "invalid v1 yaml: %s", err.Error())
+		return
+	}
+
+	if config.Kind != "pipeline" {
+		p.errorf(nil, "unsupported kind %q, only pipelines are supported", config.Kind)
+	}
+}
+
+func (s *service) checkReferences(ctx context.Context, p *problems, spaceID int64, refs *references) error {
+	checks := []struct {
+		refs   []reference
+		exists func(ref reference) (bool, error)
+		what   string
+	}{
+		{
+			refs: refs.secrets,
+			exists: func(ref reference) (bool, error) {
+				_, err := s.secretStore.FindByIdentifier(ctx, spaceID, ref.name)
+				return found(err)
+			},
+			what: "secret",
+		},
+		{
+			refs: refs.connectors,
+			exists: func(ref reference) (bool, error) {
+				_, err := s.connectorStore.FindByIdentifier(ctx, spaceID, ref.name)
+				return found(err)
+			},
+			what: "connector",
+		},
+		{
+			refs: refs.stageTemplates,
+			exists: func(ref reference) (bool, error) {
+				return s.templateExists(ctx, p, spaceID, ref, enum.ResolverTypeStage)
+			},
+			what: "stage template",
+		},
+		{
+			refs: refs.stepTemplates,
+			exists: func(ref reference) (bool, error) {
+				return s.templateExists(ctx, p, spaceID, ref, enum.ResolverTypeStep)
+			},
+			what: "step template",
+		},
+		{
+			refs: refs.plugins,
+			exists: func(ref reference) (bool, error) {
+				_, err := s.pluginStore.Find(ctx, ref.name, "")
+				return found(err)
+			},
+			what: "plugin",
+		},
+	}
+
+	for _, check := range checks {
+		known := map[string]bool{}
+		for _, ref := range check.refs {
+			exists, ok := known[ref.name]
+			if !ok {
+				var err error
+				exists, err = check.exists(ref)
+				if err != nil {
+					return fmt.Errorf("failed to look up %s %q: %w", check.what, ref.name, err)
+				}
+				known[ref.name] = exists
+			}
+
+			if !exists {
+				p.errorf(ref.node, "%s %q doesn't exist", check.what, ref.name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// templateExists checks that the template exists and is a valid template of the expected type.
+// Invalid templates are reported as problems at the position of their first reference.
+func (s *service) templateExists(
+	ctx context.Context,
+	p *problems,
+	spaceID int64,
+	ref reference,
+	typ enum.ResolverType,
+) (bool, error) {
+	template, err := s.templateStore.FindByIdentifierAndType(ctx, spaceID, ref.name, typ)
+	if exists, errFound := found(err); !exists || errFound != nil {
+		return exists, errFound
+	}
+
+	config, err := v1yaml.ParseString(template.Data)
+	if err != nil {
+		p.errorf(ref.node, "%s template %q is invalid: %s", typ, ref.name, err.Error())
+		return true, nil
+	}
+
+	switch config.Spec.(type) {
+	case *v1yaml.TemplateStage:
+		if typ != enum.ResolverTypeStage {
+			p.errorf(ref.node, "template %q is a stage template, expected a %s template", ref.name, typ)
+		}
+	case *v1yaml.TemplateStep:
+		if typ != enum.ResolverTypeStep {
+			p.errorf(ref.node, "template %q is a step template, expected a %s template", ref.name, typ)
+		}
+	default:
+		p.errorf(ref.node, "%s template %q doesn't contain a template", typ, ref.name)
+	}
+
+	return true, nil
+}
+
+// found converts the error of a store lookup to whether the resource exists.
+func found(err error) (bool, error) {
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,192 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestCheckDroneSchema(t *testing.T) {
+	data := []byte(`kind: pipeline
+name: build
+clone:
+  depth: shallow
+steps:
+- name: test
+  image: golang
+  enviroment:
+    CGO_ENABLED: 0
+  when:
+    branch:
+      include: [main]
+      exlude: [dev]
+- name: build
+  image: [golang]
+  privileged: yes please
+---
+kind: secret
+name: token
+get:
+  path: secrets/token
+`)
+
+	docs, err := decodeDocuments(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	p := &problems{}
+	checkDroneSchema(p, docs)
+
+	want := &types.PipelineValidation{
+		Valid: false,
+		Problems: []types.PipelineProblem{
+			{
+				Severity: enum.PipelineProblemSeverityError,
+				Message:  `clone.depth: expected an integer, got "shallow"`,
+				Line:     4,
+				Column:   10,
+			},
+			{
+				Severity: enum.PipelineProblemSeverityWarning,
+				Message:  `unknown key "enviroment" in steps[0], did you mean "environment"?`,
+				Line:     8,
+				Column:   3,
+			},
+			{
+				Severity: enum.PipelineProblemSeverityWarning,
+				Message:  `unknown key "exlude" in steps[0].when.branch, expected include or exclude`,
+				Line:     13,
+				Column:   7,
+			},
+			{
+				Severity: enum.PipelineProblemSeverityError,
+				Message:  "steps[1].image: expected a scalar, got a list",
+				Line:     15,
+				Column:   10,
+			},
+			{
+				Severity: enum.PipelineProblemSeverityError,
+				Message:  `steps[1].privileged: expected a boolean, got "yes please"`,
+				Line:     16,
+				Column:   15,
+			},
+		},
+	}
+	if got := p.result(); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected result: %+v", got)
+	}
+}
+
+func TestCollectReferences(t *testing.T) {
+	drone := []byte(`kind: pipeline
+image_pull_secrets: [dockerconfig]
+steps:
+- name: publish
+  image: plugins/docker
+  settings:
+    password:
+      from_secret: docker_password
+    username:
+      from_secret: inline
+---
+kind: secret
+name: inline
+get:
+  path: secrets/docker
+`)
+
+	v1 := []byte(`kind: pipeline
+spec:
+  stages:
+  - type: template
+    spec:
+      name: deploy
+  - type: ci
+    spec:
+      steps:
+      - type: run
+        spec:
+          container:
+            image: golang
+            connector: dockerhub
+          script: echo ${{ secrets.get("token") }}
+      - type: template
+        spec:
+          name: notify
+      - type: plugin
+        spec:
+          uses: slack
+`)
+
+	tests := []struct {
+		name    string
+		data    []byte
+		collect func(docs []*yaml.Node) *references
+		want    map[string][]string
+	}{
+		{
+			name:    "drone",
+			data:    drone,
+			collect: collectDroneReferences,
+			want:    map[string][]string{"secrets": {"dockerconfig", "docker_password"}},
+		},
+		{
+			name:    "v1",
+			data:    v1,
+			collect: collectV1References,
+			want: map[string][]string{
+				"secrets":         {"token"},
+				"connectors":      {"dockerhub"},
+				"stage templates": {"deploy"},
+				"step templates":  {"notify"},
+				"plugins":         {"slack"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			docs, err := decodeDocuments(test.data)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			refs := test.collect(docs)
+			got := map[string][]string{}
+			for what, list := range map[string][]reference{
+				"secrets":         refs.secrets,
+				"connectors":      refs.connectors,
+				"stage templates": refs.stageTemplates,
+				"step templates":  refs.stepTemplates,
+				"plugins":         refs.plugins,
+			} {
+				for _, ref := range list {
+					got[what] = append(got[what], ref.name)
+				}
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("unexpected references: %v", got)
+			}
+		})
+	}
+}
@@ -18,24 +18,32 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/harness/gitness/app/api/controller/aiagent"
 	"github.com/harness/gitness/app/api/controller/capabilities"
 	"github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/controller/connector"
+	"github.com/harness/gitness/app/api/controller/digest"
+	"github.com/harness/gitness/app/api/controller/eventlog"
 	"github.com/harness/gitness/app/api/controller/execution"
 	controllergithook "github.com/harness/gitness/app/api/controller/githook"
 	"github.com/harness/gitness/app/api/controller/gitspace"
+	"github.com/harness/gitness/app/api/controller/inbox"
 	"github.com/harness/gitness/app/api/controller/infraprovider"
 	"github.com/harness/gitness/app/api/controller/keywordsearch"
 	"github.com/harness/gitness/app/api/controller/logs"
+	"github.com/harness/gitness/app/api/controller/mail"
 	"github.com/harness/gitness/app/api/controller/migrate"
+	"github.com/harness/gitness/app/api/controller/operation"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
 	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/controller/reposettings"
+	"github.com/harness/gitness/app/api/controller/runner"
+	"github.com/harness/gitness/app/api/controller/scim"
 	"github.com/harness/gitness/app/api/controller/secret"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
 	"github.com/harness/gitness/app/api/controller/space"
@@ -51,13 +59,18 @@ import (
 	handlercapabilities "github.com/harness/gitness/app/api/handler/capabilities"
 	handlercheck "github.com/harness/gitness/app/api/handler/check"
 	handlerconnector "github.com/harness/gitness/app/api/handler/connector"
+	handlerdigest "github.com/harness/gitness/app/api/handler/digest"
+	handlereventlog "github.com/harness/gitness/app/api/handler/eventlog"
 	handlerexecution "github.com/harness/gitness/app/api/handler/execution"
 	handlergithook "github.com/harness/gitness/app/api/handler/githook"
 	handlergitspace "github.com/harness/gitness/app/api/handler/gitspace"
+	handlerinbox "github.com/harness/gitness/app/api/handler/inbox"
 	handlerinfraProvider "github.com/harness/gitness/app/api/handler/infraprovider"
 	handlerkeywordsearch "github.com/harness/gitness/app/api/handler/keywordsearch"
 	handlerlogs "github.com/harness/gitness/app/api/handler/logs"
+	handlermail "github.com/harness/gitness/app/api/handler/mail"
 	handlermigrate "github.com/harness/gitness/app/api/handler/migrate"
+	handleroperation "github.com/harness/gitness/app/api/handler/operation"
 	handlerpipeline "github.com/harness/gitness/app/api/handler/pipeline"
 	handlerplugin "github.com/harness/gitness/app/api/handler/plugin"
 	handlerprincipal "github.com/harness/gitness/app/api/handler/principal"
@@ -65,6 +78,8 @@ import (
 	handlerrepo "github.com/harness/gitness/app/api/handler/repo"
 	handlerreposettings "github.com/harness/gitness/app/api/handler/reposettings"
 	"github.com/harness/gitness/app/api/handler/resource"
+	handlerrunner "github.com/harness/gitness/app/api/handler/runner"
+	handlerscim "github.com/harness/gitness/app/api/handler/scim"
 	handlersecret "github.com/harness/gitness/app/api/handler/secret"
 	handlerserviceaccount "github.com/harness/gitness/app/api/handler/serviceaccount"
 	handlerspace "github.com/harness/gitness/app/api/handler/space"
@@ -78,13 +93,23 @@ import (
 	handlerwebhook "github.com/harness/gitness/app/api/handler/webhook"
 	"github.com/harness/gitness/app/api/middleware/address"
 	middlewareauthn "github.com/harness/gitness/app/api/middleware/authn"
+	middlewareauthz "github.com/harness/gitness/app/api/middleware/authz"
+	"github.com/harness/gitness/app/api/middleware/consistency"
+	"github.com/harness/gitness/app/api/middleware/cors"
+	"github.com/harness/gitness/app/api/middleware/csrf"
 	"github.com/harness/gitness/app/api/middleware/encode"
+	middlewareidempotency "github.com/harness/gitness/app/api/middleware/idempotency"
 	"github.com/harness/gitness/app/api/middleware/logging"
+	middlewaremaintenance "github.com/harness/gitness/app/api/middleware/maintenance"
 	"github.com/harness/gitness/app/api/middleware/nocache"
+	"github.com/harness/gitness/app/api/middleware/preference"
 	middlewareprincipal "github.com/harness/gitness/app/api/middleware/principal"
+	middlewaretimeout "github.com/harness/gitness/app/api/middleware/timeout"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/app/services/idempotency"
+	"github.com/harness/gitness/app/services/maintenance"
 	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
@@ -92,7 +117,7 @@ import (
 
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
-	"github.com/go-chi/cors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/zerolog/hlog"
 )
 
@@ -100,7 +125,11 @@ var (
 	// terminatedPathPrefixesAPI is the list of prefixes that will require resolving terminated paths.
 	terminatedPathPrefixesAPI = []string{"/v1/spaces/", "/v1/repos/",
 		"/v1/secrets/", "/v1/connectors", "/v1/templates/step", "/v1/templates/stage", "/v1/gitspaces", "/v1/infraproviders",
-		"/v1/migrate/repos", "/v1/pipelines"}
+		"/v1/migrate/repos", "/v1/pipelines", "/v1/admin/repos/"}
+
+	// maintenanceExemptPathPrefixesAPI is the list of prefixes that are never blocked by maintenance mode.
+	// It keeps the endpoints required to disable maintenance mode and the git hooks accessible.
+	maintenanceExemptPathPrefixesAPI = []string{"/v1/admin/", "/v1/login", "/v1/logout", "/v1/internal/"}
 )
 
 // NewAPIHandler returns a new APIHandler.
@@ -136,6 +165,15 @@ func NewAPIHandler(
 	gitspaceCtrl *gitspace.Controller,
 	aiagentCtrl *aiagent.Controller,
 	capabilitiesCtrl *capabilities.Controller,
+	digestCtrl *digest.Controller,
+	inboxCtrl *inbox.Controller,
+	mailCtrl *mail.Controller,
+	eventLogCtrl *eventlog.Controller,
+	scimCtrl *scim.Controller,
+	runnerCtrl *runner.Controller,
+	idempotencySvc *idempotency.Service,
+	operationCtrl *operation.Controller,
+	maintenanceSvc *maintenance.Service,
 ) http.Handler {
 	// Use go-chi router for inner routing.
 	r := chi.NewRouter()
@@ -155,20 +193,29 @@ func NewAPIHandler(
 	r.Use(corsHandler(config))
 
 	r.Use(audit.Middleware())
+	r.Use(consistency.ReadYourWrites)
+	r.Use(middlewaretimeout.Handle(config.HTTP.Timeouts.Default))
+	r.Use(middlewaremaintenance.BlockWrites(maintenanceSvc, maintenanceExemptPathPrefixesAPI...))
 
 	r.Route("/v1", func(r chi.Router) {
 		// special methods that don't require authentication
 		setupAccountWithoutAuth(r, userCtrl, sysCtrl, config)
+		setupRunnersWithoutAuth(r, runnerCtrl)
 		setupSystem(r, config, sysCtrl)
 		setupResources(r)
 
 		r.Group(func(r chi.Router) {
 			r.Use(middlewareauthn.Attempt(authenticator))
+			r.Use(middlewareauthz.BlockDeployKey)
+			r.Use(logging.HLogPrincipalHandler())
+			r.Use(csrf.Protect(config.Token.CookieName))
 
 			setupRoutesV1WithAuth(r, appCtx, config, repoCtrl, repoSettingsCtrl, executionCtrl, triggerCtrl, logCtrl,
 				pipelineCtrl, connectorCtrl, templateCtrl, pluginCtrl, secretCtrl, spaceCtrl, pullreqCtrl,
 				webhookCtrl, githookCtrl, git, saCtrl, userCtrl, principalCtrl, userGroupCtrl, checkCtrl, uploadCtrl,
-				searchCtrl, gitspaceCtrl, infraProviderCtrl, migrateCtrl, aiagentCtrl, capabilitiesCtrl)
+				searchCtrl, gitspaceCtrl, infraProviderCtrl, migrateCtrl, aiagentCtrl, capabilitiesCtrl, digestCtrl,
+				inboxCtrl, mailCtrl, eventLogCtrl, scimCtrl, sysCtrl, runnerCtrl, idempotencySvc, operationCtrl,
+				maintenanceSvc)
 		})
 	})
 
@@ -186,7 +233,7 @@ func corsHandler(config *types.Config) func(http.Handler) http.Handler {
 			AllowCredentials: config.Cors.AllowCredentials,
 			MaxAge:           config.Cors.MaxAge,
 		},
-	).Handler
+	)
 }
 
 // nolint: revive // it's the app context, it shouldn't be the first argument
@@ -220,25 +267,38 @@ func setupRoutesV1WithAuth(r chi.Router,
 	migrateCtrl *migrate.Controller,
 	aiagentCtrl *aiagent.Controller,
 	capabilitiesCtrl *capabilities.Controller,
+	digestCtrl *digest.Controller,
+	inboxCtrl *inbox.Controller,
+	mailCtrl *mail.Controller,
+	eventLogCtrl *eventlog.Controller,
+	scimCtrl *scim.Controller,
+	sysCtrl *system.Controller,
+	runnerCtrl *runner.Controller,
+	idempotencySvc *idempotency.Service,
+	operationCtrl *operation.Controller,
+	maintenanceSvc *maintenance.Service,
 ) {
 	setupAccountWithAuth(r, userCtrl, config)
-	setupSpaces(r, appCtx, spaceCtrl, userGroupCtrl)
-	setupRepos(r, repoCtrl, repoSettingsCtrl, pipelineCtrl, executionCtrl, triggerCtrl,
-		logCtrl, pullreqCtrl, webhookCtrl, checkCtrl, uploadCtrl)
+	setupSpaces(r, appCtx, spaceCtrl, userGroupCtrl, userCtrl, runnerCtrl, idempotencySvc, maintenanceSvc)
+	setupRepos(r, config, repoCtrl, repoSettingsCtrl, pipelineCtrl, executionCtrl, triggerCtrl,
+		logCtrl, pullreqCtrl, webhookCtrl, checkCtrl, uploadCtrl, userCtrl, inboxCtrl, idempotencySvc, maintenanceSvc)
 	setupConnectors(r, connectorCtrl)
 	setupTemplates(r, templateCtrl)
 	setupSecrets(r, secretCtrl)
 	setupAiAgent(r, aiagentCtrl, capabilitiesCtrl)
-	setupUser(r, userCtrl)
-	setupServiceAccounts(r, saCtrl)
+	setupUser(r, userCtrl, repoCtrl, digestCtrl, inboxCtrl, idempotencySvc)
+	setupServiceAccounts(r, saCtrl, idempotencySvc)
 	setupPrincipals(r, principalCtrl)
 	setupInternal(r, githookCtrl, git)
-	setupAdmin(r, userCtrl)
+	setupAdmin(r, userCtrl, repoCtrl, mailCtrl, eventLogCtrl, sysCtrl, runnerCtrl)
+	setupSCIM(r, scimCtrl)
+	setupRunners(r, runnerCtrl)
 	setupPlugins(r, pluginCtrl)
-	setupKeywordSearch(r, searchCtrl)
+	setupKeywordSearch(r, config, searchCtrl)
 	setupInfraProviders(r, infraProviderCtrl)
 	setupGitspaces(r, gitspaceCtrl)
 	setupMigrate(r, migrateCtrl)
+	setupOperations(r, operationCtrl)
 }
 
 // nolint: revive // it's the app context, it shouldn't be the first argument
@@ -247,14 +307,21 @@ func setupSpaces(
 	appCtx context.Context,
 	spaceCtrl *space.Controller,
 	userGroupCtrl *usergroup.Controller,
-
+	userCtrl *user.Controller,
+	runnerCtrl *runner.Controller,
+	idempotencySvc *idempotency.Service,
+	maintenanceSvc *maintenance.Service,
 ) {
 	r.Route("/spaces", func(r chi.Router) {
 		// Create takes path and parentId via body, not uri
-		r.Post("/", handlerspace.HandleCreate(spaceCtrl))
+		r.With(middlewareidempotency.Handle(idempotencySvc)).
+			Post("/", handlerspace.HandleCreate(spaceCtrl))
 		r.Post("/import", handlerspace.HandleImport(spaceCtrl))
 
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamSpaceRef), func(r chi.Router) {
+			r.Use(logging.HLogSpaceRefHandler())
+			r.Use(middlewaremaintenance.BlockSpaceWrites(maintenanceSvc))
+
 			// space operations
 			r.Get("/", handlerspace.HandleFind(spaceCtrl))
 			r.Patch("/", handlerspace.HandleUpdate(spaceCtrl))
@@ -262,13 +329,34 @@ func setupSpaces(
 			r.Post("/restore", handlerspace.HandleRestore(spaceCtrl))
 			r.Post("/purge", handlerspace.HandlePurge(spaceCtrl))
 
-			r.Get("/events", handlerspace.HandleEvents(appCtx, spaceCtrl))
+			r.With(middlewaretimeout.Disable).Get("/events", handlerspace.HandleEvents(appCtx, spaceCtrl))
+			r.Get("/usage", handlerspace.HandleUsage(spaceCtrl))
+
+			r.Route("/settings", func(r chi.Router) {
+				r.Get("/commit-message-policy", handlerspace.HandleCommitMessagePolicyFind(spaceCtrl))
+				r.Put("/commit-message-policy", handlerspace.HandleCommitMessagePolicyUpdate(spaceCtrl))
+			})
 
 			r.Post("/import", handlerspace.HandleImportRepositories(spaceCtrl))
 			r.Post("/move", handlerspace.HandleMove(spaceCtrl))
 			r.Get("/spaces", handlerspace.HandleListSpaces(spaceCtrl))
 			r.Get("/pipelines", handlerspace.HandleListPipelines(spaceCtrl))
-			r.Get("/repos", handlerspace.HandleListRepos(spaceCtrl))
+			r.Get("/runners", handlerrunner.HandleListSpace(runnerCtrl))
+			r.With(preference.ApplyDefaults(userCtrl, enum.UserPreferenceViewRepoList)).
+				Get("/repos", handlerspace.HandleListRepos(spaceCtrl))
+			r.Post("/repos/bulk", handlerspace.HandleBulkRepos(spaceCtrl))
+			r.Get(fmt.Sprintf("/repos/bulk/{%s}", request.PathParamBulkJobUID),
+				handlerspace.HandleBulkReposProgress(spaceCtrl))
+			r.Get("/topics", handlerspace.HandleListTopics(spaceCtrl))
+			r.Post("/topics/rename", handlerspace.HandleRenameTopic(spaceCtrl))
+
+			r.Route("/pinned-repos", func(r chi.Router) {
+				r.Get("/", handlerspace.HandleListPinnedRepos(spaceCtrl))
+				r.Post("/", handlerspace.HandlePinRepo(spaceCtrl))
+				r.Put("/order", handlerspace.HandleReorderPinnedRepos(spaceCtrl))
+				r.Delete(fmt.Sprintf("/{%s}", request.PathParamPinnedRepoID), handlerspace.HandleUnpinRepo(spaceCtrl))
+			})
+
 			r.Get("/usergroups", handlerUserGroup.HandleList(userGroupCtrl))
 			r.Get("/service-accounts", handlerspace.HandleListServiceAccounts(spaceCtrl))
 			r.Get("/secrets", handlerspace.HandleListSecrets(spaceCtrl))
@@ -277,8 +365,11 @@ func setupSpaces(
 			r.Get("/gitspaces", handlerspace.HandleListGitspaces(spaceCtrl))
 			r.Post("/export", handlerspace.HandleExport(spaceCtrl))
 			r.Get("/export-progress", handlerspace.HandleExportProgress(spaceCtrl))
+			r.With(middlewaretimeout.Disable).Get("/export/archive", handlerspace.HandleExportDownload(spaceCtrl))
+			r.With(middlewaretimeout.Disable).Post("/import-archive", handlerspace.HandleImportArchive(spaceCtrl))
 			r.Post("/public-access", handlerspace.HandleUpdatePublicAccess(spaceCtrl))
-			r.Get("/pullreq", handlerspace.HandleListPullReqs(spaceCtrl))
+			r.With(preference.ApplyDefaults(userCtrl, enum.UserPreferenceViewPullReqList)).
+				Get("/pullreq", handlerspace.HandleListPullReqs(spaceCtrl))
 
 			r.Route("/members", func(r chi.Router) {
 				r.Get("/", handlerspace.HandleMembershipList(spaceCtrl))
@@ -317,6 +408,7 @@ func SetupSpaceLabels(r chi.Router, spaceCtrl *space.Controller) {
 }
 
 func setupRepos(r chi.Router,
+	config *types.Config,
 	repoCtrl *repo.Controller,
 	repoSettingsCtrl *reposettings.Controller,
 	pipelineCtrl *pipeline.Controller,
@@ -327,12 +419,22 @@ func setupRepos(r chi.Router,
 	webhookCtrl *webhook.Controller,
 	checkCtrl *check.Controller,
 	uploadCtrl *upload.Controller,
+	userCtrl *user.Controller,
+	inboxCtrl *inbox.Controller,
+	idempotencySvc *idempotency.Service,
+	maintenanceSvc *maintenance.Service,
 ) {
+	idempotent := middlewareidempotency.Handle(idempotencySvc)
+	long := middlewaretimeout.Override(config.HTTP.Timeouts.Long)
+
 	r.Route("/repos", func(r chi.Router) {
 		// Create takes path and parentId via body, not uri
-		r.Post("/", handlerrepo.HandleCreate(repoCtrl))
-		r.Post("/import", handlerrepo.HandleImport(repoCtrl))
+		r.With(idempotent).Post("/", handlerrepo.HandleCreate(repoCtrl))
+		r.With(idempotent).Post("/import", handlerrepo.HandleImport(repoCtrl))
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamRepoRef), func(r chi.Router) {
+			r.Use(logging.HLogRepoRefHandler())
+			r.Use(middlewaremaintenance.BlockRepoWrites(maintenanceSvc))
+
 			// repo level operations
 			r.Get("/", handlerrepo.HandleFind(repoCtrl))
 			r.Patch("/", handlerrepo.HandleUpdate(repoCtrl))
@@ -340,15 +442,37 @@ func setupRepos(r chi.Router,
 			r.Post("/purge", handlerrepo.HandlePurge(repoCtrl))
 			r.Post("/restore", handlerrepo.HandleRestore(repoCtrl))
 			r.Post("/public-access", handlerrepo.HandleUpdatePublicAccess(repoCtrl))
+			r.Post("/state", handlerrepo.HandleUpdateState(repoCtrl))
+			r.Put("/star", handlerrepo.HandleStar(repoCtrl))
+			r.Delete("/star", handlerrepo.HandleUnstar(repoCtrl))
+			r.Put("/subscription", handlerinbox.HandleSubscribeRepo(inboxCtrl, true))
+			r.Delete("/subscription", handlerinbox.HandleSubscribeRepo(inboxCtrl, false))
 
 			r.Route("/settings", func(r chi.Router) {
 				r.Get("/security", handlerreposettings.HandleSecurityFind(repoSettingsCtrl))
 				r.Patch("/security", handlerreposettings.HandleSecurityUpdate(repoSettingsCtrl))
 				r.Get("/general", handlerreposettings.HandleGeneralFind(repoSettingsCtrl))
 				r.Patch("/general", handlerreposettings.HandleGeneralUpdate(repoSettingsCtrl))
+				r.Get("/commit-message-policy", handlerreposettings.HandleCommitMessagePolicyFind(repoSettingsCtrl))
+				r.Put("/commit-message-policy", handlerreposettings.HandleCommitMessagePolicyUpdate(repoSettingsCtrl))
+				r.Get("/git", handlerrepo.HandleGitSettingsFind(repoCtrl))
+				r.Patch("/git", handlerrepo.HandleGitSettingsUpdate(repoCtrl))
 			})
 
 			r.Get("/summary", handlerrepo.HandleSummary(repoCtrl))
+			r.Route("/stats", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandleStats(repoCtrl))
+				r.With(long).Get("/contributors", handlerrepo.HandleContributorStats(repoCtrl))
+			})
+			r.Get("/activity", handlerrepo.HandleListActivities(repoCtrl))
+			r.Get("/access-log", handlerrepo.HandleListAccessLog(repoCtrl))
+
+			r.Route("/keys", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandleListDeployKeys(repoCtrl))
+				r.Post("/", handlerrepo.HandleCreateDeployKey(repoCtrl))
+				r.Delete(fmt.Sprintf("/{%s}", request.PathParamDeployKeyIdentifier),
+					handlerrepo.HandleDeleteDeployKey(repoCtrl))
+			})
 
 			r.Post("/move", handlerrepo.HandleMove(repoCtrl))
 			r.Get("/service-accounts", handlerrepo.HandleListServiceAccounts(repoCtrl))
@@ -368,31 +492,48 @@ func setupRepos(r chi.Router,
 			r.Post("/path-details", handlerrepo.HandlePathsDetails(repoCtrl))
 
 			r.Route("/blame", func(r chi.Router) {
-				r.Get("/*", handlerrepo.HandleBlame(repoCtrl))
+				r.With(long).Get("/*", handlerrepo.HandleBlame(repoCtrl))
 			})
 
+			r.Get("/permalink", handlerrepo.HandlePermalink(repoCtrl))
+
 			r.Route("/raw", func(r chi.Router) {
-				r.Get("/*", handlerrepo.HandleRaw(repoCtrl))
+				r.With(middlewaretimeout.Disable).Get("/*", handlerrepo.HandleRaw(repoCtrl))
 			})
 
+			r.With(middlewaretimeout.Disable).
+				Post("/patches", handlerrepo.HandleApplyPatch(repoCtrl, config.Git.PatchUploadMaxSize))
+
 			// commit operations
 			r.Route("/commits", func(r chi.Router) {
 				r.Get("/", handlerrepo.HandleListCommits(repoCtrl))
 
-				r.Post("/calculate-divergence", handlerrepo.HandleCalculateCommitDivergence(repoCtrl))
+				r.With(long).Post("/calculate-divergence", handlerrepo.HandleCalculateCommitDivergence(repoCtrl))
 				r.Post("/", handlerrepo.HandleCommitFiles(repoCtrl))
 
+				r.With(long).Get(fmt.Sprintf("/{%s}.patch", request.PathParamCommitSHA),
+					handlerrepo.HandleCommitPatch(repoCtrl, enum.PatchFormatPatch, config.Git.PatchMaxSize))
+				r.With(long).Get(fmt.Sprintf("/{%s}.diff", request.PathParamCommitSHA),
+					handlerrepo.HandleCommitPatch(repoCtrl, enum.PatchFormatDiff, config.Git.PatchMaxSize))
+
 				// per commit operations
 				r.Route(fmt.Sprintf("/{%s}", request.PathParamCommitSHA), func(r chi.Router) {
 					r.Get("/", handlerrepo.HandleGetCommit(repoCtrl))
-					r.Get("/diff", handlerrepo.HandleCommitDiff(repoCtrl))
+					r.With(long).Get("/diff", handlerrepo.HandleCommitDiff(repoCtrl))
+					r.Get("/notes", handlerrepo.HandleGetCommitNote(repoCtrl))
+					r.Put("/notes", handlerrepo.HandleSetCommitNote(repoCtrl))
+					r.Get("/status/combined", handlercheck.HandleCheckCombinedStatus(checkCtrl))
 				})
 			})
 
+			r.Get("/stale-branches", handlerrepo.HandleListStaleBranches(repoCtrl))
+
 			// branch operations
 			r.Route("/branches", func(r chi.Router) {
 				r.Get("/", handlerrepo.HandleListBranches(repoCtrl))
 				r.Post("/", handlerrepo.HandleCreateBranch(repoCtrl))
+				r.Post("/restore", handlerrepo.HandleRestoreBranch(repoCtrl))
+				r.Post("/bulk-delete", handlerrepo.HandleBulkDeleteBranches(repoCtrl))
 
 				// per branch operations (can't be grouped in single route)
 				r.Get("/*", handlerrepo.HandleGetBranch(repoCtrl))
@@ -406,25 +547,38 @@ func setupRepos(r chi.Router,
 				r.Delete("/*", handlerrepo.HandleDeleteCommitTag(repoCtrl))
 			})
 
+			// reference history and status
+			r.Route("/refs", func(r chi.Router) {
+				r.Get("/*", routeRefSuffix(handlerrepo.HandleListRefHistory(repoCtrl),
+					map[string]http.HandlerFunc{
+						handlercheck.RefStatusSuffix: handlercheck.HandleCheckRefStatus(checkCtrl),
+					}))
+			})
+
 			// diffs
 			r.Route("/diff", func(r chi.Router) {
+				r.Use(long)
 				r.Get("/*", handlerrepo.HandleDiff(repoCtrl))
 				r.Post("/*", handlerrepo.HandleDiff(repoCtrl))
 			})
 			r.Route("/diff-stats", func(r chi.Router) {
+				r.Use(long)
 				r.Get("/*", handlerrepo.HandleDiffStats(repoCtrl))
 			})
+			r.Get("/merge-base", handlerrepo.HandleMergeBase(repoCtrl))
 			r.Route("/merge-check", func(r chi.Router) {
+				r.Use(long)
 				r.Post("/*", handlerrepo.HandleMergeCheck(repoCtrl))
 			})
 
 			r.Get("/codeowners/validate", handlerrepo.HandleCodeOwnersValidate(repoCtrl))
 
-			r.Get(fmt.Sprintf("/archive/%s", request.PathParamArchiveGitRef), handlerrepo.HandleArchive(repoCtrl))
+			r.With(middlewaretimeout.Disable).
+				Get(fmt.Sprintf("/archive/%s", request.PathParamArchiveGitRef), handlerrepo.HandleArchive(repoCtrl))
 
-			SetupPullReq(r, pullreqCtrl)
+			SetupPullReq(r, config, pullreqCtrl, userCtrl, inboxCtrl, idempotent)
 
-			SetupWebhook(r, webhookCtrl)
+			SetupWebhook(r, webhookCtrl, idempotent)
 
 			setupPipelines(r, repoCtrl, pipelineCtrl, executionCtrl, triggerCtrl, logCtrl)
 
@@ -432,7 +586,7 @@ func setupRepos(r chi.Router,
 
 			SetupUploads(r, uploadCtrl)
 
-			SetupRules(r, repoCtrl)
+			SetupRules(r, repoCtrl, idempotent)
 
 			SetupRepoLabels(r, repoCtrl)
 		})
@@ -463,6 +617,7 @@ func SetupRepoLabels(r chi.Router, repoCtrl *repo.Controller) {
 
 func SetupUploads(r chi.Router, uploadCtrl *upload.Controller) {
 	r.Route("/uploads", func(r chi.Router) {
+		r.Use(middlewaretimeout.Disable)
 		r.Post("/", handlerupload.HandleUpload(uploadCtrl))
 		r.Get("/*", handlerupload.HandleDownoad(uploadCtrl))
 	})
@@ -479,6 +634,7 @@ func setupPipelines(
 		r.Get("/", handlerrepo.HandleListPipelines(repoCtrl))
 		// Create takes path and parentId via body, not uri
 		r.Post("/", handlerpipeline.HandleCreate(pipelineCtrl))
+		r.Post("/validate", handlerpipeline.HandleValidate(pipelineCtrl))
 		r.Get("/generate", handlerrepo.HandlePipelineGenerate(repoCtrl))
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamPipelineIdentifier), func(r chi.Router) {
 			r.Get("/", handlerpipeline.HandleFind(pipelineCtrl))
@@ -560,14 +716,25 @@ func setupExecutions(
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamExecutionNumber), func(r chi.Router) {
 			r.Get("/", handlerexecution.HandleFind(executionCtrl))
 			r.Post("/cancel", handlerexecution.HandleCancel(executionCtrl))
+			r.Post("/retry", handlerexecution.HandleRetry(executionCtrl))
 			r.Delete("/", handlerexecution.HandleDelete(executionCtrl))
+			r.Get("/approvals", handlerexecution.HandleListApprovals(executionCtrl))
+			r.Route("/artifacts", func(r chi.Router) {
+				r.Get("/", handlerexecution.HandleListArtifacts(executionCtrl))
+				r.With(middlewaretimeout.Disable).Get("/*", handlerexecution.HandleDownloadArtifact(executionCtrl))
+				r.With(middlewaretimeout.Disable).Post("/*", handlerexecution.HandleUploadArtifact(executionCtrl))
+			})
+			r.Route(fmt.Sprintf("/stages/{%s}", request.PathParamStageNumber), func(r chi.Router) {
+				r.Post("/approve", handlerexecution.HandleApprove(executionCtrl))
+				r.Post("/reject", handlerexecution.HandleReject(executionCtrl))
+			})
 			r.Get(
 				fmt.Sprintf("/logs/{%s}/{%s}",
 					request.PathParamStageNumber,
 					request.PathParamStepNumber,
 				), handlerlogs.HandleFind(logCtrl))
 			// TODO: Decide whether API should be /stream/logs/{}/{} or /logs/{}/{}/stream
-			r.Get(
+			r.With(middlewaretimeout.Disable).Get(
 				fmt.Sprintf("/logs/{%s}/{%s}/stream",
 					request.PathParamStageNumber,
 					request.PathParamStepNumber,
@@ -593,6 +760,8 @@ func setupTriggers(
 
 func setupInternal(r chi.Router, githookCtrl *controllergithook.Controller, git git.Interface) {
 	r.Route("/internal", func(r chi.Router) {
+		// git hooks are bound by the timeouts of the git operations that trigger them.
+		r.Use(middlewaretimeout.Disable)
 		SetupGitHooks(r, githookCtrl, git)
 	})
 }
@@ -605,16 +774,33 @@ func SetupGitHooks(r chi.Router, githookCtrl *controllergithook.Controller, git
 	})
 }
 
-func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
+func SetupPullReq(
+	r chi.Router,
+	config *types.Config,
+	pullreqCtrl *pullreq.Controller,
+	userCtrl *user.Controller,
+	inboxCtrl *inbox.Controller,
+	idempotent func(http.Handler) http.Handler,
+) {
+	long := middlewaretimeout.Override(config.HTTP.Timeouts.Long)
+
 	r.Route("/pullreq", func(r chi.Router) {
-		r.Post("/", handlerpullreq.HandleCreate(pullreqCtrl))
-		r.Get("/", handlerpullreq.HandleList(pullreqCtrl))
+		r.With(idempotent).Post("/", handlerpullreq.HandleCreate(pullreqCtrl))
+		r.With(preference.ApplyDefaults(userCtrl, enum.UserPreferenceViewPullReqList)).
+			Get("/", handlerpullreq.HandleList(pullreqCtrl))
+
+		r.With(long).Get(fmt.Sprintf("/{%s}.patch", request.PathParamPullReqNumber),
+			handlerpullreq.HandlePatch(pullreqCtrl, enum.PatchFormatPatch, config.Git.PatchMaxSize))
+		r.With(long).Get(fmt.Sprintf("/{%s}.diff", request.PathParamPullReqNumber),
+			handlerpullreq.HandlePatch(pullreqCtrl, enum.PatchFormatDiff, config.Git.PatchMaxSize))
 
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamPullReqNumber), func(r chi.Router) {
 			r.Get("/", handlerpullreq.HandleFind(pullreqCtrl))
 			r.Patch("/", handlerpullreq.HandleUpdate(pullreqCtrl))
 			r.Post("/state", handlerpullreq.HandleState(pullreqCtrl))
 			r.Get("/activities", handlerpullreq.HandleListActivities(pullreqCtrl))
+			r.Put("/subscription", handlerinbox.HandleSubscribePullReq(inboxCtrl, true))
+			r.Delete("/subscription", handlerinbox.HandleSubscribePullReq(inboxCtrl, false))
 			r.Route("/comments", func(r chi.Router) {
 				r.Post("/", handlerpullreq.HandleCommentCreate(pullreqCtrl))
 				r.Post("/apply-suggestions", handlerpullreq.HandleCommentApplySuggestions(pullreqCtrl))
@@ -644,6 +830,7 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 				r.Post("/", handlerpullreq.HandleReviewSubmit(pullreqCtrl))
 			})
 			r.Post("/merge", handlerpullreq.HandleMerge(pullreqCtrl))
+			r.Get("/merge-message", handlerpullreq.HandleMergeMessagePreview(pullreqCtrl))
 			r.Get("/commits", handlerpullreq.HandleCommits(pullreqCtrl))
 			r.Get("/metadata", handlerpullreq.HandleMetadata(pullreqCtrl))
 
@@ -653,8 +840,8 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 				r.Delete("/*", handlerpullreq.HandleFileViewDelete(pullreqCtrl))
 			})
 			r.Get("/codeowners", handlerpullreq.HandleCodeOwner(pullreqCtrl))
-			r.Get("/diff", handlerpullreq.HandleDiff(pullreqCtrl))
-			r.Post("/diff", handlerpullreq.HandleDiff(pullreqCtrl))
+			r.With(long).Get("/diff", handlerpullreq.HandleDiff(pullreqCtrl))
+			r.With(long).Post("/diff", handlerpullreq.HandleDiff(pullreqCtrl))
 			r.Get("/checks", handlerpullreq.HandleCheckList(pullreqCtrl))
 
 			setupPullReqLabels(r, pullreqCtrl)
@@ -672,9 +859,9 @@ func setupPullReqLabels(r chi.Router, pullreqCtrl *pullreq.Controller) {
 	})
 }
 
-func SetupWebhook(r chi.Router, webhookCtrl *webhook.Controller) {
+func SetupWebhook(r chi.Router, webhookCtrl *webhook.Controller, idempotent func(http.Handler) http.Handler) {
 	r.Route("/webhooks", func(r chi.Router) {
-		r.Post("/", handlerwebhook.HandleCreate(webhookCtrl))
+		r.With(idempotent).Post("/", handlerwebhook.HandleCreate(webhookCtrl))
 		r.Get("/", handlerwebhook.HandleList(webhookCtrl))
 
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamWebhookIdentifier), func(r chi.Router) {
@@ -694,6 +881,23 @@ func SetupWebhook(r chi.Router, webhookCtrl *webhook.Controller) {
 	})
 }
 
+// routeRefSuffix routes the requests for a git reference by the suffix of the path,
+// as references can contain slashes and the suffix can't be matched by the router itself.
+// Requests that don't end with any of the suffixes are handled by the fallback handler.
+func routeRefSuffix(fallback http.HandlerFunc, handlers map[string]http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		remainder := chi.URLParam(r, "*")
+		for suffix, h := range handlers {
+			if strings.HasSuffix(remainder, suffix) {
+				h(w, r)
+				return
+			}
+		}
+
+		fallback(w, r)
+	}
+}
+
 func SetupChecks(r chi.Router, checkCtrl *check.Controller) {
 	r.Route("/checks", func(r chi.Router) {
 		r.Get("/recent", handlercheck.HandleCheckListRecent(checkCtrl))
@@ -704,9 +908,9 @@ func SetupChecks(r chi.Router, checkCtrl *check.Controller) {
 	})
 }
 
-func SetupRules(r chi.Router, repoCtrl *repo.Controller) {
+func SetupRules(r chi.Router, repoCtrl *repo.Controller, idempotent func(http.Handler) http.Handler) {
 	r.Route("/rules", func(r chi.Router) {
-		r.Post("/", handlerrepo.HandleRuleCreate(repoCtrl))
+		r.With(idempotent).Post("/", handlerrepo.HandleRuleCreate(repoCtrl))
 		r.Get("/", handlerrepo.HandleRuleList(repoCtrl))
 
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamRuleIdentifier), func(r chi.Router) {
@@ -717,18 +921,30 @@ func SetupRules(r chi.Router, repoCtrl *repo.Controller) {
 	})
 }
 
-func setupUser(r chi.Router, userCtrl *user.Controller) {
+func setupUser(
+	r chi.Router,
+	userCtrl *user.Controller,
+	repoCtrl *repo.Controller,
+	digestCtrl *digest.Controller,
+	inboxCtrl *inbox.Controller,
+	idempotencySvc *idempotency.Service,
+) {
 	r.Route("/user", func(r chi.Router) {
 		// enforce principal authenticated and it's a user
 		r.Use(middlewareprincipal.RestrictTo(enum.PrincipalTypeUser))
 		r.Get("/", handleruser.HandleFind(userCtrl))
 		r.Patch("/", handleruser.HandleUpdate(userCtrl))
+		r.Patch("/email", handleruser.HandleRequestEmailChange(userCtrl))
+		r.With(middlewaretimeout.Disable).Put("/avatar", handleruser.HandleUpdateAvatar(userCtrl))
+		r.Delete("/avatar", handleruser.HandleDeleteAvatar(userCtrl))
+		r.Post("/email/confirm", handleruser.HandleConfirmEmailChange(userCtrl))
 		r.Get("/memberships", handleruser.HandleMembershipSpaces(userCtrl))
 
 		// PAT
 		r.Route("/tokens", func(r chi.Router) {
 			r.Get("/", handleruser.HandleListTokens(userCtrl, enum.TokenTypePAT))
-			r.Post("/", handleruser.HandleCreateAccessToken(userCtrl))
+			r.With(middlewareidempotency.Handle(idempotencySvc)).
+				Post("/", handleruser.HandleCreateAccessToken(userCtrl))
 
 			// per token operations
 			r.Route(fmt.Sprintf("/{%s}", request.PathParamTokenIdentifier), func(r chi.Router) {
@@ -738,7 +954,8 @@ func setupUser(r chi.Router, userCtrl *user.Controller) {
 
 		// SESSION TOKENS
 		r.Route("/sessions", func(r chi.Router) {
-			r.Get("/", handleruser.HandleListTokens(userCtrl, enum.TokenTypeSession))
+			r.Get("/", handleruser.HandleListSessions(userCtrl))
+			r.Delete("/", handleruser.HandleRevokeOtherSessions(userCtrl))
 
 			// per token operations
 			r.Route(fmt.Sprintf("/{%s}", request.PathParamTokenIdentifier), func(r chi.Router) {
@@ -753,13 +970,43 @@ func setupUser(r chi.Router, userCtrl *user.Controller) {
 			r.Delete(fmt.Sprintf("/{%s}", request.PathParamPublicKeyIdentifier),
 				handleruser.HandleDeletePublicKey(userCtrl))
 		})
+
+		// List view preferences
+		r.Route(fmt.Sprintf("/preferences/{%s}", request.PathParamPreferenceView), func(r chi.Router) {
+			r.Get("/", handleruser.HandleFindPreference(userCtrl))
+			r.Put("/", handleruser.HandleUpdatePreference(userCtrl))
+		})
+
+		// Recently visited repositories and spaces
+		r.Get("/recent", handleruser.HandleListRecent(userCtrl))
+
+		// Starred repositories
+		r.Get("/starred", handlerrepo.HandleListStarred(repoCtrl))
+
+		// In-app notifications
+		r.Route("/notifications", func(r chi.Router) {
+			r.Get("/", handlerinbox.HandleList(inboxCtrl))
+			r.Get("/count", handlerinbox.HandleCount(inboxCtrl))
+			r.Post("/read", handlerinbox.HandleMarkReadBulk(inboxCtrl))
+			r.Post(fmt.Sprintf("/{%s}/read", request.PathParamNotificationID), handlerinbox.HandleMarkRead(inboxCtrl))
+		})
+
+		// Activity digest
+		r.Route("/digest", func(r chi.Router) {
+			r.Get("/", handlerdigest.HandleFind(digestCtrl))
+			r.Put("/", handlerdigest.HandleUpdate(digestCtrl))
+			r.Delete("/", handlerdigest.HandleDelete(digestCtrl))
+			r.Get("/preview", handlerdigest.HandlePreview(digestCtrl))
+		})
 	})
 }
 
-func setupServiceAccounts(r chi.Router, saCtrl *serviceaccount.Controller) {
+func setupServiceAccounts(r chi.Router, saCtrl *serviceaccount.Controller, idempotencySvc *idempotency.Service) {
+	idempotent := middlewareidempotency.Handle(idempotencySvc)
+
 	r.Route("/service-accounts", func(r chi.Router) {
 		// create takes parent information via body
-		r.Post("/", handlerserviceaccount.HandleCreate(saCtrl))
+		r.With(idempotent).Post("/", handlerserviceaccount.HandleCreate(saCtrl))
 
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamServiceAccountUID), func(r chi.Router) {
 			r.Get("/", handlerserviceaccount.HandleFind(saCtrl))
@@ -768,7 +1015,7 @@ func setupServiceAccounts(r chi.Router, saCtrl *serviceaccount.Controller) {
 			// SAT
 			r.Route("/tokens", func(r chi.Router) {
 				r.Get("/", handlerserviceaccount.HandleListTokens(saCtrl))
-				r.Post("/", handlerserviceaccount.HandleCreateToken(saCtrl))
+				r.With(idempotent).Post("/", handlerserviceaccount.HandleCreateToken(saCtrl))
 
 				// per token operations
 				r.Route(fmt.Sprintf("/{%s}", request.PathParamTokenIdentifier), func(r chi.Router) {
@@ -798,12 +1045,14 @@ func setupPrincipals(r chi.Router, principalCtrl principal.Controller) {
 	r.Route("/principals", func(r chi.Router) {
 		r.Get("/", handlerprincipal.HandleList(principalCtrl))
 		r.Get(fmt.Sprintf("/{%s}", request.PathParamPrincipalID), handlerprincipal.HandleFind(principalCtrl))
+		r.Get(fmt.Sprintf("/{%s}/avatar", request.PathParamPrincipalUID), handlerprincipal.HandleAvatar(principalCtrl))
 		r.Post("/check-emails", handlerprincipal.HandleCheckExistenceByEmail(principalCtrl))
 	})
 }
 
-func setupKeywordSearch(r chi.Router, searchCtrl *keywordsearch.Controller) {
-	r.Post("/search", handlerkeywordsearch.HandleSearch(searchCtrl))
+func setupKeywordSearch(r chi.Router, config *types.Config, searchCtrl *keywordsearch.Controller) {
+	r.With(middlewaretimeout.Override(config.HTTP.Timeouts.Long)).
+		Post("/search", handlerkeywordsearch.HandleSearch(searchCtrl))
 }
 
 func setupGitspaces(r chi.Router, gitspacesCtrl *gitspace.Controller) {
@@ -817,7 +1066,7 @@ func setupGitspaces(r chi.Router, gitspacesCtrl *gitspace.Controller) {
 			r.Delete("/", handlergitspace.HandleDeleteConfig(gitspacesCtrl))
 			r.Patch("/", handlergitspace.HandleUpdateConfig(gitspacesCtrl))
 			r.Get("/events", handlergitspace.HandleEvents(gitspacesCtrl))
-			r.Get("/logs/stream", handlergitspace.HandleLogsStream(gitspacesCtrl))
+			r.With(middlewaretimeout.Disable).Get("/logs/stream", handlergitspace.HandleLogsStream(gitspacesCtrl))
 		})
 	})
 }
@@ -831,7 +1080,15 @@ func setupInfraProviders(r chi.Router, infraProviderCtrl *infraprovider.Controll
 	})
 }
 
-func setupAdmin(r chi.Router, userCtrl *user.Controller) {
+func setupAdmin(
+	r chi.Router,
+	userCtrl *user.Controller,
+	repoCtrl *repo.Controller,
+	mailCtrl *mail.Controller,
+	eventLogCtrl *eventlog.Controller,
+	sysCtrl *system.Controller,
+	runnerCtrl *runner.Controller,
+) {
 	r.Route("/admin", func(r chi.Router) {
 		r.Use(middlewareprincipal.RestrictToAdmin())
 		r.Route("/users", func(r chi.Router) {
@@ -843,6 +1100,83 @@ func setupAdmin(r chi.Router, userCtrl *user.Controller) {
 				r.Patch("/", users.HandleUpdate(userCtrl))
 				r.Delete("/", users.HandleDelete(userCtrl))
 				r.Patch("/admin", handleruser.HandleUpdateAdmin(userCtrl))
+				r.Patch("/blocked", handleruser.HandleUpdateBlocked(userCtrl))
+				r.Delete("/login-lockout", handleruser.HandleClearLoginLockout(userCtrl))
+				r.Post("/password-reset", handleruser.HandleInitiatePasswordReset(userCtrl))
+			})
+		})
+		r.Route(fmt.Sprintf("/repos/{%s}", request.PathParamRepoRef), func(r chi.Router) {
+			r.Get("/bundle", handlerrepo.HandleBackupBundle(repoCtrl))
+			r.Put("/bundle", handlerrepo.HandleRestoreBundle(repoCtrl))
+		})
+		r.Route("/mail", func(r chi.Router) {
+			r.Post("/test", handlermail.HandleSendTest(mailCtrl))
+			r.Get("/failures", handlermail.HandleListFailures(mailCtrl))
+		})
+		r.Route("/events/consumers", func(r chi.Router) {
+			r.Get("/", handlereventlog.HandleListConsumers(eventLogCtrl))
+			r.Post("/replay", handlereventlog.HandleReplay(eventLogCtrl))
+		})
+		r.Route("/settings", func(r chi.Router) {
+			r.Get("/", handlersystem.HandleListSettings(sysCtrl))
+
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamInstanceSettingKey), func(r chi.Router) {
+				r.Get("/", handlersystem.HandleFindSetting(sysCtrl))
+				r.Put("/", handlersystem.HandleUpdateSetting(sysCtrl))
+				r.Delete("/", handlersystem.HandleResetSetting(sysCtrl))
+			})
+		})
+		r.Route("/feature-flags", func(r chi.Router) {
+			r.Get("/", handlersystem.HandleListFeatureFlags(sysCtrl))
+
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamFeatureFlag), func(r chi.Router) {
+				r.Get("/", handlersystem.HandleFindFeatureFlag(sysCtrl))
+				r.Put("/", handlersystem.HandleUpdateFeatureFlag(sysCtrl))
+				r.Delete("/", handlersystem.HandleResetFeatureFlag(sysCtrl))
+
+				r.Route(fmt.Sprintf("/spaces/{%s}", request.PathParamSpaceRef), func(r chi.Router) {
+					r.Put("/", handlersystem.HandleUpdateSpaceFeatureFlag(sysCtrl))
+					r.Delete("/", handlersystem.HandleResetSpaceFeatureFlag(sysCtrl))
+				})
+			})
+		})
+		r.Route("/git-operations", func(r chi.Router) {
+			r.Get("/", handlersystem.HandleListGitOperations(sysCtrl))
+
+			r.Route("/limits", func(r chi.Router) {
+				r.Get("/", handlersystem.HandleListGitOperationLimits(sysCtrl))
+
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamPrincipalID), func(r chi.Router) {
+					r.Put("/", handlersystem.HandleUpdateGitOperationLimit(sysCtrl))
+					r.Delete("/", handlersystem.HandleResetGitOperationLimit(sysCtrl))
+				})
+			})
+		})
+		r.Get("/migrations", handlersystem.HandleMigrationStatus(sysCtrl))
+		r.Get("/system/info", handlersystem.HandleSystemInfo(sysCtrl))
+		r.Get("/runners", handlerrunner.HandleList(runnerCtrl))
+		r.Route("/counters/reconcile", func(r chi.Router) {
+			r.Post("/", handlersystem.HandleReconcileCounters(sysCtrl))
+			r.Get("/", handlersystem.HandleReconcileCountersProgress(sysCtrl))
+		})
+		r.Method(http.MethodGet, "/metrics", promhttp.Handler())
+	})
+}
+
+// setupSCIM sets up the SCIM-like user provisioning api used by identity providers.
+func setupSCIM(r chi.Router, scimCtrl *scim.Controller) {
+	r.Route("/scim/v2", func(r chi.Router) {
+		r.Use(middlewareprincipal.RestrictToAdmin())
+		r.Post("/Bulk", handlerscim.HandleBulk(scimCtrl))
+		r.Route("/Users", func(r chi.Router) {
+			r.Get("/", handlerscim.HandleList(scimCtrl))
+			r.Post("/", handlerscim.HandleCreate(scimCtrl))
+
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamUserUID), func(r chi.Router) {
+				r.Get("/", handlerscim.HandleFind(scimCtrl))
+				r.Put("/", handlerscim.HandleReplace(scimCtrl))
+				r.Patch("/", handlerscim.HandlePatch(scimCtrl))
+				r.Delete("/", handlerscim.HandleDeactivate(scimCtrl))
 			})
 		})
 	})
@@ -857,6 +1191,24 @@ func setupAccountWithoutAuth(
 	cookieName := config.Token.CookieName
 	r.Post("/login", account.HandleLogin(userCtrl, cookieName))
 	r.Post("/register", account.HandleRegister(userCtrl, sysCtrl, cookieName))
+	r.Post("/reset-password", account.HandleResetPassword(userCtrl))
+
+	r.Route("/auth/oidc", func(r chi.Router) {
+		r.Get("/login", account.HandleOIDCLogin(userCtrl, cookieName))
+		r.Get("/callback", account.HandleOIDCCallback(userCtrl, cookieName, config.URL.UI))
+	})
+}
+
+// setupRunnersWithoutAuth sets up the runner api used by the runners themselves,
+// they authenticate with the registration token and their runner token.
+func setupRunnersWithoutAuth(r chi.Router, runnerCtrl *runner.Controller) {
+	r.Post("/runners/register", handlerrunner.HandleRegister(runnerCtrl))
+	r.Post(fmt.Sprintf("/runners/{%s}/heartbeat", request.PathParamRunnerIdentifier),
+		handlerrunner.HandleHeartbeat(runnerCtrl))
+}
+
+func setupRunners(r chi.Router, runnerCtrl *runner.Controller) {
+	r.Delete(fmt.Sprintf("/runners/{%s}", request.PathParamRunnerIdentifier), handlerrunner.HandleDelete(runnerCtrl))
 }
 
 func setupAccountWithAuth(r chi.Router, userCtrl *user.Controller, config *types.Config) {
@@ -877,3 +1229,12 @@ func setupMigrate(r chi.Router, migCtrl *migrate.Controller) {
 		})
 	})
 }
+
+func setupOperations(r chi.Router, operationCtrl *operation.Controller) {
+	r.Route("/operations", func(r chi.Router) {
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamOperationID), func(r chi.Router) {
+			r.Get("/", handleroperation.HandleFind(operationCtrl))
+			r.Delete("/", handleroperation.HandleCancel(operationCtrl))
+		})
+	})
+}
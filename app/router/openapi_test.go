@@ -0,0 +1,127 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/api/openapi"
+	"github.com/harness/gitness/types"
+
+	"github.com/go-chi/chi"
+)
+
+// undocumentedRoutes are the API routes that are intentionally not part of the openapi spec
+// (internal and migration endpoints) or that aren't documented yet.
+// Don't add new routes here, add the operation to the openapi spec instead.
+var undocumentedRoutes = map[string]bool{
+	"POST /harness-intelligence/analyse-execution":                                                     true,
+	"POST /harness-intelligence/capabilities":                                                          true,
+	"POST /harness-intelligence/generate-pipeline":                                                     true,
+	"POST /harness-intelligence/suggest-pipeline":                                                      true,
+	"POST /harness-intelligence/update-pipeline":                                                       true,
+	"POST /internal/git-hooks/post-receive":                                                            true,
+	"POST /internal/git-hooks/pre-receive":                                                             true,
+	"POST /internal/git-hooks/update":                                                                  true,
+	"POST /migrate/repos":                                                                              true,
+	"POST /migrate/repos/{repo_ref}/pullreqs":                                                          true,
+	"POST /migrate/repos/{repo_ref}/rules":                                                             true,
+	"PATCH /migrate/repos/{repo_ref}/update-state":                                                     true,
+	"POST /migrate/repos/{repo_ref}/webhooks":                                                          true,
+	"GET /repos/{repo_ref}/import-progress":                                                            true,
+	"GET /repos/{repo_ref}/pipelines/generate":                                                         true,
+	"GET /repos/{repo_ref}/pullreq/{pullreq_number}/reviewers/combined":                                true,
+	"PUT /repos/{repo_ref}/pullreq/{pullreq_number}/reviewers/usergroups":                              true,
+	"DELETE /repos/{repo_ref}/pullreq/{pullreq_number}/reviewers/usergroups/{user_group_id}":           true,
+	"POST /repos/{repo_ref}/webhooks/{webhook_identifier}/executions/{webhook_execution_id}/retrigger": true,
+	"POST /search":                                                true,
+	"POST /service-accounts":                                      true,
+	"DELETE /service-accounts/{sa_uid}":                           true,
+	"GET /service-accounts/{sa_uid}":                              true,
+	"GET /service-accounts/{sa_uid}/tokens":                       true,
+	"POST /service-accounts/{sa_uid}/tokens":                      true,
+	"DELETE /service-accounts/{sa_uid}/tokens/{token_identifier}": true,
+	"GET /spaces/{space_ref}/gitspaces":                           true,
+	"GET /spaces/{space_ref}/pipelines":                           true,
+	"GET /spaces/{space_ref}/usergroups":                          true,
+
+	"GET /repos/{repo_ref}/pipelines/{pipeline_identifier}/executions/{execution_number}" +
+		"/logs/{stage_number}/{step_number}/stream": true,
+}
+
+var pathParamRegex = regexp.MustCompile(`\{[^}]*\}`)
+
+// normalizeRoutePath removes trailing slashes and the names of path parameters,
+// as the handlers and the spec don't always use the same names.
+func normalizeRoutePath(path string) string {
+	if len(path) > 1 {
+		path = strings.TrimRight(path, "/")
+	}
+	return pathParamRegex.ReplaceAllString(path, "{}")
+}
+
+// TestRoutesHaveOpenAPISpec ensures every API route has a corresponding operation in the openapi spec.
+func TestRoutesHaveOpenAPISpec(t *testing.T) {
+	config := &types.Config{}
+
+	r := chi.NewRouter()
+	setupAccountWithoutAuth(r, nil, nil, config)
+	setupSystem(r, config, nil)
+	setupResources(r)
+	setupRoutesV1WithAuth(r, context.Background(), config, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil)
+
+	spec := openapi.NewOpenAPIService().Generate()
+	documented := map[string][]string{}
+	for path, item := range spec.Paths.MapOfPathItemValues {
+		for method := range item.MapOfOperationValues {
+			method = strings.ToUpper(method)
+			documented[method] = append(documented[method], normalizeRoutePath(path))
+		}
+	}
+
+	isDocumented := func(method string, route string) bool {
+		// wildcard routes match all operations that document the remainder of the path as parameters.
+		prefix, isWildcard := strings.CutSuffix(normalizeRoutePath(route), "/*")
+		for _, path := range documented[method] {
+			if path == prefix || (isWildcard && strings.HasPrefix(path, prefix+"/{")) {
+				return true
+			}
+		}
+		return false
+	}
+
+	err := chi.Walk(r, func(method string, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		if method == http.MethodHead || method == http.MethodOptions {
+			return nil
+		}
+
+		key := method + " " + strings.TrimRight(route, "/")
+		if isDocumented(method, route) || undocumentedRoutes[key] {
+			return nil
+		}
+
+		t.Errorf("route %q has no operation in the openapi spec", key)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to walk routes: %s", err)
+	}
+}
@@ -14,7 +14,19 @@
 
 package router
 
-import "testing"
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	middlewaretimeout "github.com/harness/gitness/app/api/middleware/timeout"
+	"github.com/harness/gitness/types"
+
+	"github.com/go-chi/chi"
+)
 
 // this unit test ensures routes that require authorization
 // return a 401 unauthorized if no token, or an invalid token
@@ -36,3 +48,62 @@ func TestPipelineGate(t *testing.T) {
 func TestSystemGate(t *testing.T) {
 	t.Skip()
 }
+
+// this unit test ensures streaming and upload routes aren't limited by the request timeout,
+// while regular routes are.
+func TestRouteTimeouts(t *testing.T) {
+	config := &types.Config{}
+
+	r := chi.NewRouter()
+	setupRoutesV1WithAuth(r, context.Background(), config, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil)
+
+	expected := map[string]bool{
+		"GET /user":                                      false,
+		"PUT /user/avatar":                               true,
+		"DELETE /user/avatar":                            false,
+		"POST /repos/{repo_ref}/uploads":                 true,
+		"GET /repos/{repo_ref}/uploads/*":                true,
+		"GET /repos/{repo_ref}/raw/*":                    true,
+		"POST /repos/{repo_ref}/patches":                 true,
+		"GET /spaces/{space_ref}/events":                 true,
+		"POST /internal/git-hooks/pre-receive":           true,
+		"GET /repos/{repo_ref}/archive/*":                true,
+		"GET /repos/{repo_ref}/commits":                  false,
+		"POST /repos/{repo_ref}/commits":                 false,
+		"POST /spaces/{space_ref}/import-archive":        true,
+		"GET /spaces/{space_ref}/export/archive":         true,
+		"GET /repos/{repo_ref}/pullreq/{pullreq_number}": false,
+	}
+
+	disable := reflect.ValueOf(middlewaretimeout.Disable).Pointer()
+
+	found := map[string]bool{}
+	err := chi.Walk(r, func(method string, route string, _ http.Handler, mws ...func(http.Handler) http.Handler) error {
+		key := method + " " + strings.TrimRight(route, "/")
+		want, ok := expected[key]
+		if !ok {
+			return nil
+		}
+		found[key] = true
+
+		disabled := slices.ContainsFunc(mws, func(mw func(http.Handler) http.Handler) bool {
+			return reflect.ValueOf(mw).Pointer() == disable
+		})
+		if disabled != want {
+			t.Errorf("route %q: expected timeout disabled=%t, got %t", key, want, disabled)
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to walk routes: %s", err)
+	}
+
+	for key := range expected {
+		if !found[key] {
+			t.Errorf("route %q not found", key)
+		}
+	}
+}
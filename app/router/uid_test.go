@@ -0,0 +1,111 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/api/middleware/encode"
+	"github.com/harness/gitness/app/api/openapi"
+	"github.com/harness/gitness/app/api/request"
+	registryrouter "github.com/harness/gitness/registry/app/api/router"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+
+	"github.com/go-chi/chi"
+)
+
+// TestSpaceIdentifiersDontCollideWithRoutes ensures space identifiers that would be routed
+// to something else than the space are rejected: root spaces can't be any of the first path segments
+// of the routers, and spaces in general can't be any of the suffixes claimed by the registry router.
+func TestSpaceIdentifiersDontCollideWithRoutes(t *testing.T) {
+	config := &types.Config{}
+	web := NewWebHandler(config, nil, openapi.NewOpenAPIService()).(chi.Routes)
+	registryRouter := registryrouter.NewRegistryRouter(nil)
+
+	rootSegments := map[string]struct{}{
+		strings.TrimPrefix(APIMount, "/"): {},
+		strings.TrimPrefix(GitMount, "/"): {},
+	}
+
+	err := chi.Walk(web, func(_ string, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		segment, _, _ := strings.Cut(strings.TrimPrefix(route, "/"), "/")
+		if segment != "" && segment != "*" && !strings.HasPrefix(segment, "{") {
+			rootSegments[segment] = struct{}{}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to walk web routes: %s", err)
+	}
+
+	for _, segment := range []string{"registry", "v2"} {
+		if !registryRouter.IsEligibleTraffic(httptest.NewRequest(http.MethodGet, "/"+segment+"/x", nil)) {
+			t.Errorf("expected %q to be routed to the registry", segment)
+		}
+		rootSegments[segment] = struct{}{}
+	}
+
+	for segment := range rootSegments {
+		if err := check.SpaceIdentifierDefault(segment, true); err == nil {
+			t.Errorf("root space identifier %q collides with the routes but isn't rejected", segment)
+		}
+		if err := check.SpaceIdentifierDefault(segment, false); err != nil {
+			t.Errorf("space identifier %q is only reserved for root spaces: %s", segment, err)
+		}
+	}
+
+	for _, identifier := range []string{"artifacts", "registries"} {
+		req := httptest.NewRequest(http.MethodGet, APIMount+"/v1/spaces/space/"+identifier, nil)
+		if !registryRouter.IsEligibleTraffic(req) {
+			t.Errorf("expected space %q to be routed to the registry", identifier)
+		}
+		if err := check.SpaceIdentifierDefault(identifier, false); err == nil {
+			t.Errorf("space identifier %q collides with the registry routes but isn't rejected", identifier)
+		}
+	}
+
+	// path segments of the api routes don't collide with space and repository identifiers.
+	for _, identifier := range []string{"settings", "pullreq", "branches"} {
+		if err := check.SpaceIdentifierDefault(identifier, true); err != nil {
+			t.Errorf("unexpected error for space identifier %q: %s", identifier, err)
+		}
+		if err := check.RepoIdentifierDefault(identifier); err != nil {
+			t.Errorf("unexpected error for repo identifier %q: %s", identifier, err)
+		}
+	}
+}
+
+// TestRouteSegmentRepoRefsResolve ensures repositories with identifiers that are also path segments
+// of the api routes can be accessed via the API.
+func TestRouteSegmentRepoRefsResolve(t *testing.T) {
+	var got string
+	r := chi.NewRouter()
+	r.Get("/v1/repos/{repo_ref}/branches", func(_ http.ResponseWriter, r *http.Request) {
+		got, _ = request.GetRepoRefFromPath(r)
+	})
+	h := encode.TerminatedPathBefore(terminatedPathPrefixesAPI, r)
+
+	for _, ref := range []string{"space/settings", "space/Branches", "settings/repo", "space/1repo"} {
+		got = ""
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/repos/"+ref+"/+/branches", nil))
+		if got != ref {
+			t.Errorf("expected repo ref %q, got %q", ref, got)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// maxPendingBatches is the number of batches kept in memory before new entries are dropped,
+// which can only happen if the database can't keep up or is unavailable.
+const maxPendingBatches = 20
+
+// shutdownFlushTimeout is the maximum time spent writing the remaining entries on shutdown.
+const shutdownFlushTimeout = 10 * time.Second
+
+type Config struct {
+	// FlushInterval is the interval in which the recorded entries are written to the database.
+	FlushInterval time.Duration
+	// BatchSize is the maximum number of entries written to the database at once.
+	BatchSize int
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.FlushInterval <= 0 {
+		return errors.New("config.FlushInterval has to be a positive duration")
+	}
+	if c.BatchSize < 1 {
+		return errors.New("config.BatchSize has to be a positive number")
+	}
+
+	return nil
+}
+
+// Service records the git operations performed on repositories.
+// Entries are collected in memory and written to the database in batches,
+// either periodically or as soon as a full batch is available.
+type Service struct {
+	config         Config
+	accessLogStore store.RepoAccessLogStore
+
+	mx sync.Mutex
+	// pending contains the entries that haven't been written to the database yet.
+	pending []*types.RepoAccessLog
+	// flushCh signals that a full batch is pending.
+	flushCh chan struct{}
+}
+
+func NewService(
+	config Config,
+	accessLogStore store.RepoAccessLogStore,
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided repo access log service config is invalid: %w", err)
+	}
+
+	return &Service{
+		config:         config,
+		accessLogStore: accessLogStore,
+		flushCh:        make(chan struct{}, 1),
+	}, nil
+}
+
+// Record queues the entry to be written to the access log. It never blocks on the database.
+func (s *Service) Record(ctx context.Context, entry *types.RepoAccessLog) {
+	s.mx.Lock()
+
+	if len(s.pending) >= maxPendingBatches*s.config.BatchSize {
+		s.mx.Unlock()
+		log.Ctx(ctx).Warn().Msgf("dropping access log entry of repo %d, too many pending entries", entry.RepoID)
+		return
+	}
+
+	s.pending = append(s.pending, entry)
+	full := len(s.pending) >= s.config.BatchSize
+
+	s.mx.Unlock()
+
+	if full {
+		select {
+		case s.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Run writes the recorded entries to the database until the context is canceled.
+func (s *Service) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.config.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// write the remaining entries before shutting down.
+			flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownFlushTimeout)
+			s.flush(flushCtx)
+			cancel()
+			return nil
+		case <-ticker.C:
+			s.flush(ctx)
+		case <-s.flushCh:
+			s.flush(ctx)
+		}
+	}
+}
+
+// flush writes the pending entries to the database in batches.
+// If a batch can't be written, it and all remaining entries are queued again for the next flush,
+// as long as they fit into the pending limit.
+func (s *Service) flush(ctx context.Context) {
+	s.mx.Lock()
+	pending := s.pending
+	s.pending = nil
+	s.mx.Unlock()
+
+	for len(pending) > 0 {
+		batch := pending[:min(len(pending), s.config.BatchSize)]
+
+		if err := s.accessLogStore.CreateMany(ctx, batch); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to store %d repo access log entries", len(batch))
+			s.requeue(ctx, pending)
+			return
+		}
+
+		pending = pending[len(batch):]
+	}
+}
+
+// requeue puts the entries that failed to be written in front of the entries recorded in the meantime.
+// Entries beyond the pending limit are dropped, newest first.
+func (s *Service) requeue(ctx context.Context, entries []*types.RepoAccessLog) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.pending = append(entries, s.pending...)
+
+	if limit := maxPendingBatches * s.config.BatchSize; len(s.pending) > limit {
+		log.Ctx(ctx).Warn().Msgf("dropping %d repo access log entries, too many pending entries",
+			len(s.pending)-limit)
+		s.pending = s.pending[:limit]
+	}
+}
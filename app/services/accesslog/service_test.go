@@ -0,0 +1,198 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+)
+
+// memAccessLogStore is an in-memory repo access log store that records the written batches.
+type memAccessLogStore struct {
+	store.RepoAccessLogStore
+
+	mx      sync.Mutex
+	batches [][]*types.RepoAccessLog
+	// failures is the number of upcoming CreateMany calls that fail.
+	failures int
+	// onCreate is called on every CreateMany call, if set.
+	onCreate func()
+}
+
+func (s *memAccessLogStore) CreateMany(_ context.Context, entries []*types.RepoAccessLog) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if s.onCreate != nil {
+		s.onCreate()
+	}
+
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("database unavailable")
+	}
+
+	s.batches = append(s.batches, entries)
+	return nil
+}
+
+func (s *memAccessLogStore) batchSizes() []int {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	sizes := make([]int, len(s.batches))
+	for i, batch := range s.batches {
+		sizes[i] = len(batch)
+	}
+	return sizes
+}
+
+func TestServiceFlushesInBatches(t *testing.T) {
+	accessLogStore := &memAccessLogStore{}
+	s, err := NewService(Config{FlushInterval: time.Hour, BatchSize: 2}, accessLogStore)
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		_ = s.Run(ctx)
+		close(done)
+	}()
+
+	// a full batch is written without waiting for the flush interval.
+	s.Record(ctx, &types.RepoAccessLog{RepoID: 1})
+	s.Record(ctx, &types.RepoAccessLog{RepoID: 1})
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(accessLogStore.batchSizes()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("full batch wasn't written")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// the remaining entries are written on shutdown.
+	s.Record(ctx, &types.RepoAccessLog{RepoID: 1})
+	cancel()
+	<-done
+
+	sizes := accessLogStore.batchSizes()
+	if len(sizes) != 2 || sizes[0] != 2 || sizes[1] != 1 {
+		t.Errorf("expected batches of sizes [2 1], got %v", sizes)
+	}
+}
+
+func TestServiceDropsWhenFull(t *testing.T) {
+	accessLogStore := &memAccessLogStore{}
+	s, err := NewService(Config{FlushInterval: time.Hour, BatchSize: 3}, accessLogStore)
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+
+	ctx := context.Background()
+	for range maxPendingBatches*3 + 5 {
+		s.Record(ctx, &types.RepoAccessLog{RepoID: 1})
+	}
+
+	s.flush(ctx)
+
+	sizes := accessLogStore.batchSizes()
+	if len(sizes) != maxPendingBatches {
+		t.Fatalf("expected %d batches, got %d", maxPendingBatches, len(sizes))
+	}
+	for _, size := range sizes {
+		if size != 3 {
+			t.Errorf("expected batch size 3, got %d", size)
+		}
+	}
+}
+
+func TestServiceRequeuesFailedBatches(t *testing.T) {
+	accessLogStore := &memAccessLogStore{failures: 1}
+	s, err := NewService(Config{FlushInterval: time.Hour, BatchSize: 2}, accessLogStore)
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+
+	ctx := context.Background()
+	for i := range 5 {
+		s.Record(ctx, &types.RepoAccessLog{RepoID: int64(i)})
+	}
+
+	// the first batch fails, nothing is lost.
+	s.flush(ctx)
+	if sizes := accessLogStore.batchSizes(); len(sizes) != 0 {
+		t.Fatalf("expected no batches after failed flush, got %v", sizes)
+	}
+
+	s.Record(ctx, &types.RepoAccessLog{RepoID: 5})
+	s.flush(ctx)
+
+	var repoIDs []int64
+	for _, batch := range accessLogStore.batches {
+		for _, entry := range batch {
+			repoIDs = append(repoIDs, entry.RepoID)
+		}
+	}
+	if len(repoIDs) != 6 {
+		t.Fatalf("expected 6 entries to be written, got %v", repoIDs)
+	}
+	for i, repoID := range repoIDs {
+		if repoID != int64(i) {
+			t.Errorf("expected entries in recording order, got %v", repoIDs)
+			break
+		}
+	}
+}
+
+func TestServiceRequeueIsBounded(t *testing.T) {
+	accessLogStore := &memAccessLogStore{failures: 1}
+	s, err := NewService(Config{FlushInterval: time.Hour, BatchSize: 3}, accessLogStore)
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+
+	ctx := context.Background()
+	limit := maxPendingBatches * 3
+	for range limit {
+		s.Record(ctx, &types.RepoAccessLog{RepoID: 1})
+	}
+
+	// entries recorded while the failing batch is written don't fit anymore once it's queued again.
+	accessLogStore.onCreate = func() {
+		for range 4 {
+			s.Record(ctx, &types.RepoAccessLog{RepoID: 2})
+		}
+	}
+
+	s.flush(ctx)
+
+	if len(s.pending) != limit {
+		t.Fatalf("expected %d pending entries, got %d", limit, len(s.pending))
+	}
+	for _, entry := range s.pending {
+		if entry.RepoID != 1 {
+			t.Fatal("expected the newest entries to be dropped")
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avatar
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"image"
+	"image/color"
+)
+
+const identiconGridSize = 5
+
+// downscale crops the centered square of the image and scales it down to the size by averaging
+// the source pixels of every target pixel. Smaller images are cropped only.
+func downscale(src image.Image, size int) *image.RGBA {
+	b := src.Bounds()
+	side := min(b.Dx(), b.Dy())
+	crop := image.Rect(0, 0, side, side).Add(image.Pt(b.Min.X+(b.Dx()-side)/2, b.Min.Y+(b.Dy()-side)/2))
+
+	if side < size {
+		size = side
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	for dy := 0; dy < size; dy++ {
+		y0 := crop.Min.Y + dy*side/size
+		y1 := max(crop.Min.Y+(dy+1)*side/size, y0+1)
+
+		for dx := 0; dx < size; dx++ {
+			x0 := crop.Min.X + dx*side/size
+			x1 := max(crop.Min.X+(dx+1)*side/size, x0+1)
+
+			var r, g, bl, a, n uint64
+			for y := y0; y < y1; y++ {
+				for x := x0; x < x1; x++ {
+					pr, pg, pb, pa := src.At(x, y).RGBA()
+					r += uint64(pr)
+					g += uint64(pg)
+					bl += uint64(pb)
+					a += uint64(pa)
+					n++
+				}
+			}
+
+			// RGBA returns 16 bit values, the target has 8 bit values.
+			dst.SetRGBA(dx, dy, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(bl / n >> 8),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+
+	return dst
+}
+
+// identicon generates a deterministic, horizontally symmetric 5x5 pattern for the uid.
+func identicon(uid string, size int) *image.RGBA {
+	hash := sha256.Sum256([]byte(uid))
+
+	background := color.RGBA{R: 240, G: 240, B: 240, A: 255}
+	// keep the foreground dark enough to contrast with the background.
+	foreground := color.RGBA{R: hash[29] / 2, G: hash[30] / 2, B: hash[31] / 2, A: 255}
+
+	// the left half including the middle column is taken from the hash, the right half is mirrored.
+	var cells [identiconGridSize][identiconGridSize]bool
+	for row := 0; row < identiconGridSize; row++ {
+		for col := 0; col <= identiconGridSize/2; col++ {
+			filled := hash[row*identiconGridSize+col]%2 == 0
+			cells[row][col] = filled
+			cells[row][identiconGridSize-1-col] = filled
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			c := background
+			if cells[y*identiconGridSize/size][x*identiconGridSize/size] {
+				c = foreground
+			}
+			dst.SetRGBA(x, y, c)
+		}
+	}
+
+	return dst
+}
+
+// identiconHash returns a short hash identifying the identicon of the uid.
+func identiconHash(uid string) string {
+	hash := sha256.Sum256([]byte(uid))
+	return hex.EncodeToString(hash[:8])
+}
@@ -0,0 +1,107 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avatar
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/errors"
+)
+
+func TestDownscale(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 300, 200))
+	for y := 0; y < 200; y++ {
+		for x := 0; x < 300; x++ {
+			src.SetRGBA(x, y, color.RGBA{R: 200, G: 100, B: 50, A: 255})
+		}
+	}
+
+	tests := []struct {
+		name string
+		size int
+		want int
+	}{
+		{name: "downscaled", size: 64, want: 64},
+		{name: "cropped only", size: 512, want: 200},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dst := downscale(src, test.size)
+			if got := dst.Bounds().Dx(); got != test.want || dst.Bounds().Dy() != test.want {
+				t.Fatalf("expected %dx%d, got %dx%d", test.want, test.want, got, dst.Bounds().Dy())
+			}
+			if got := dst.RGBAAt(0, 0); got != (color.RGBA{R: 200, G: 100, B: 50, A: 255}) {
+				t.Errorf("unexpected color %v", got)
+			}
+		})
+	}
+}
+
+func TestIdenticonIsDeterministic(t *testing.T) {
+	a, err := encode(identicon("alice", 64))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := encode(identicon("alice", 64))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := encode(identicon("bob", 64))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(a, b) {
+		t.Error("expected the same identicon for the same uid")
+	}
+	if bytes.Equal(a, c) {
+		t.Error("expected different identicons for different uids")
+	}
+}
+
+func TestUploadRejectsUnsupportedTypes(t *testing.T) {
+	s := NewService(Config{MaxFileSize: 1024, MaxDimension: 512}, nil, nil)
+
+	svg := `<svg xmlns="http://www.w3.org/2000/svg" width="10" height="10"></svg>`
+
+	buf := &bytes.Buffer{}
+	if err := png.Encode(buf, image.NewRGBA(image.Rect(0, 0, 100, 100))); err != nil {
+		t.Fatal(err)
+	}
+	tooBig := append(buf.Bytes(), make([]byte, 1024)...)
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "svg", data: []byte(svg)},
+		{name: "text", data: []byte(strings.Repeat("a", 100))},
+		{name: "too big", data: tooBig},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := s.Upload(context.Background(), 1, bytes.NewReader(test.data))
+			if !errors.IsInvalidArgument(err) {
+				t.Errorf("expected invalid argument error, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,310 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avatar
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"time"
+
+	// register the jpeg format for decoding uploaded images.
+	_ "image/jpeg"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/errors"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// maxPixels limits the dimensions of uploaded images to protect against decompression bombs.
+	maxPixels = 25_000_000
+
+	// DefaultSize is the size of avatars that are requested without a size.
+	DefaultSize = 64
+
+	avatarPathFmt        = "avatars/%d/%s.png"
+	avatarVariantPathFmt = "avatars/%d/%s/%d.png"
+)
+
+// sizes are the sizes of the avatar variants, requested sizes are rounded up to the next one.
+var sizes = []int{16, 24, 32, 48, 64, 96, 128, 256, 512}
+
+type Config struct {
+	// MaxFileSize is the maximum size of uploaded avatar images in bytes.
+	MaxFileSize int64
+	// MaxDimension is the maximum width and height of stored avatars, bigger images are downscaled.
+	MaxDimension int
+}
+
+// Service stores the avatars of principals in the blob store and renders them in the requested size.
+// Principals without an avatar get a generated identicon.
+type Service struct {
+	config      Config
+	avatarStore store.AvatarStore
+	blobStore   blob.Store
+}
+
+func NewService(config Config, avatarStore store.AvatarStore, blobStore blob.Store) *Service {
+	return &Service{
+		config:      config,
+		avatarStore: avatarStore,
+		blobStore:   blobStore,
+	}
+}
+
+// Image is a rendered avatar image in PNG format.
+type Image struct {
+	Data []byte
+	// ETag identifies the content of the image.
+	ETag string
+	// Hash is the hash of the uploaded avatar, empty for identicons.
+	Hash string
+}
+
+// Upload validates the uploaded PNG or JPEG image and stores it as the avatar of the principal.
+// The type of the image is detected from its content, images are cropped to a square
+// and downscaled to the configured maximum dimension.
+func (s *Service) Upload(ctx context.Context, principalID int64, r io.Reader) (*types.Avatar, error) {
+	data, err := io.ReadAll(io.LimitReader(r, s.config.MaxFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read avatar: %w", err)
+	}
+	if int64(len(data)) > s.config.MaxFileSize {
+		return nil, errors.InvalidArgument("Avatar images can't be bigger than %d bytes.", s.config.MaxFileSize)
+	}
+
+	mType := mimetype.Detect(data)
+	if !mType.Is("image/png") && !mType.Is("image/jpeg") {
+		return nil, errors.InvalidArgument(
+			"Only PNG and JPEG avatar images are supported, uploaded file is of type %s.", mType.String())
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.InvalidArgument("Failed to read the avatar image.")
+	}
+	if cfg.Width*cfg.Height > maxPixels {
+		return nil, errors.InvalidArgument("Avatar images can't have more than %d pixels.", maxPixels)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.InvalidArgument("Failed to decode the avatar image.")
+	}
+
+	data, err = encode(downscale(img, s.config.MaxDimension))
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.Sum256(data)
+	avatar := &types.Avatar{
+		PrincipalID: principalID,
+		Hash:        hex.EncodeToString(hash[:]),
+	}
+
+	old, err := s.avatarStore.Find(ctx, principalID)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find current avatar: %w", err)
+	}
+
+	err = s.blobStore.Upload(ctx, bytes.NewReader(data), avatarPath(principalID, avatar.Hash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload avatar: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	avatar.Created = now
+	avatar.Updated = now
+
+	if err = s.avatarStore.Upsert(ctx, avatar); err != nil {
+		return nil, fmt.Errorf("failed to store avatar: %w", err)
+	}
+
+	if old != nil && old.Hash != avatar.Hash {
+		s.deleteFiles(ctx, old)
+	}
+
+	return avatar, nil
+}
+
+// Delete deletes the avatar of the principal, which falls back to the identicon afterwards.
+func (s *Service) Delete(ctx context.Context, principalID int64) error {
+	avatar, err := s.avatarStore.Find(ctx, principalID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find avatar: %w", err)
+	}
+
+	if err = s.avatarStore.Delete(ctx, principalID); err != nil {
+		return fmt.Errorf("failed to delete avatar: %w", err)
+	}
+
+	s.deleteFiles(ctx, avatar)
+
+	return nil
+}
+
+// Render returns the avatar of the principal in the requested size.
+// Resized variants of uploaded avatars are cached in the blob store.
+func (s *Service) Render(ctx context.Context, principal *types.Principal, size int) (*Image, error) {
+	size = s.normalizeSize(size)
+
+	avatar, err := s.avatarStore.Find(ctx, principal.ID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		data, err := encode(identicon(principal.UID, size))
+		if err != nil {
+			return nil, err
+		}
+
+		return &Image{
+			Data: data,
+			ETag: fmt.Sprintf("identicon-%s-%d", identiconHash(principal.UID), size),
+		}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find avatar: %w", err)
+	}
+
+	data, err := s.variant(ctx, avatar, size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Image{
+		Data: data,
+		ETag: fmt.Sprintf("%s-%d", avatar.Hash, size),
+		Hash: avatar.Hash,
+	}, nil
+}
+
+// variant returns the avatar downscaled to the size, it's generated and cached on first use.
+func (s *Service) variant(ctx context.Context, avatar *types.Avatar, size int) ([]byte, error) {
+	variantPath := avatarVariantPath(avatar.PrincipalID, avatar.Hash, size)
+
+	data, err := s.download(ctx, variantPath)
+	if err == nil {
+		return data, nil
+	}
+	if !errors.Is(err, blob.ErrNotFound) {
+		return nil, err
+	}
+
+	data, err = s.download(ctx, avatarPath(avatar.PrincipalID, avatar.Hash))
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode stored avatar: %w", err)
+	}
+
+	data, err = encode(downscale(img, size))
+	if err != nil {
+		return nil, err
+	}
+
+	// caching the variant is best effort, it's generated again on the next request otherwise.
+	if err = s.blobStore.Upload(ctx, bytes.NewReader(data), variantPath); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to cache avatar variant '%s'", variantPath)
+	}
+
+	return data, nil
+}
+
+func (s *Service) download(ctx context.Context, filePath string) ([]byte, error) {
+	rc, err := s.blobStore.Download(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if errClose := rc.Close(); errClose != nil {
+			log.Ctx(ctx).Warn().Err(errClose).Msgf("failed to close avatar file '%s'", filePath)
+		}
+	}()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read avatar file '%s': %w", filePath, err)
+	}
+
+	return data, nil
+}
+
+// deleteFiles deletes the stored avatar and its variants (best effort).
+func (s *Service) deleteFiles(ctx context.Context, avatar *types.Avatar) {
+	paths := []string{avatarPath(avatar.PrincipalID, avatar.Hash)}
+	for _, size := range sizes {
+		paths = append(paths, avatarVariantPath(avatar.PrincipalID, avatar.Hash, size))
+	}
+
+	for _, p := range paths {
+		if err := s.blobStore.Delete(ctx, p); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to delete avatar file '%s'", p)
+		}
+	}
+}
+
+// normalizeSize rounds the requested size up to the next variant size, capped at the maximum dimension.
+func (s *Service) normalizeSize(size int) int {
+	if size <= 0 {
+		size = DefaultSize
+	}
+
+	res := sizes[len(sizes)-1]
+	for _, v := range sizes {
+		if v >= size {
+			res = v
+			break
+		}
+	}
+
+	if res > s.config.MaxDimension {
+		res = s.config.MaxDimension
+	}
+
+	return res
+}
+
+func encode(img image.Image) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := png.Encode(buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode avatar: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func avatarPath(principalID int64, hash string) string {
+	return fmt.Sprintf(avatarPathFmt, principalID, hash)
+}
+
+func avatarVariantPath(principalID int64, hash string, size int) string {
+	return fmt.Sprintf(avatarVariantPathFmt, principalID, hash, size)
+}
@@ -0,0 +1,161 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeArtifacts        = "gitness:cleanup:artifacts"
+	jobCronArtifacts        = "42 */2 * * *" // At minute 42 past every 2nd hour.
+	jobMaxDurationArtifacts = 30 * time.Minute
+
+	artifactsBatchSize = 100
+)
+
+type artifactsCleanupJob struct {
+	retentionTime time.Duration
+	maxRepoSize   int64
+
+	artifactStore store.ArtifactStore
+	blobStore     blob.Store
+}
+
+func newArtifactsCleanupJob(
+	retentionTime time.Duration,
+	maxRepoSize int64,
+	artifactStore store.ArtifactStore,
+	blobStore blob.Store,
+) *artifactsCleanupJob {
+	return &artifactsCleanupJob{
+		retentionTime: retentionTime,
+		maxRepoSize:   maxRepoSize,
+
+		artifactStore: artifactStore,
+		blobStore:     blobStore,
+	}
+}
+
+// Handle purges execution artifacts that are past the retention time or belong to deleted executions,
+// and the oldest artifacts of repositories whose artifacts exceed the maximum total size.
+func (j *artifactsCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	olderThan := time.Now().Add(-j.retentionTime)
+
+	log.Ctx(ctx).Info().Msgf(
+		"start purging artifacts older than %s (aka created before %s)",
+		j.retentionTime,
+		olderThan.Format(time.RFC3339Nano))
+
+	expired, err := j.purgeExpired(ctx, olderThan.UnixMilli())
+	if err != nil {
+		return "", fmt.Errorf("failed to purge expired artifacts: %w", err)
+	}
+
+	exceeding, err := j.purgeExceedingRepoSize(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to purge artifacts exceeding repository size: %w", err)
+	}
+
+	result := "no artifacts to purge found"
+	if expired+exceeding > 0 {
+		result = fmt.Sprintf("deleted %d expired artifacts and %d artifacts exceeding the repository size",
+			expired, exceeding)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
+
+func (j *artifactsCleanupJob) purgeExpired(ctx context.Context, createdBefore int64) (int, error) {
+	count := 0
+	for {
+		artifacts, err := j.artifactStore.ListExpired(ctx, createdBefore, artifactsBatchSize)
+		if err != nil {
+			return count, fmt.Errorf("failed to list expired artifacts: %w", err)
+		}
+
+		for _, artifact := range artifacts {
+			if err = j.delete(ctx, artifact); err != nil {
+				return count, err
+			}
+			count++
+		}
+
+		if len(artifacts) < artifactsBatchSize {
+			return count, nil
+		}
+	}
+}
+
+func (j *artifactsCleanupJob) purgeExceedingRepoSize(ctx context.Context) (int, error) {
+	if j.maxRepoSize <= 0 {
+		return 0, nil
+	}
+
+	repoIDs, err := j.artifactStore.ListReposExceedingSize(ctx, j.maxRepoSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list repositories exceeding artifact size: %w", err)
+	}
+
+	count := 0
+	for _, repoID := range repoIDs {
+		var artifacts []*types.Artifact
+		artifacts, err = j.artifactStore.ListForRepo(ctx, repoID)
+		if err != nil {
+			return count, fmt.Errorf("failed to list artifacts of repository %d: %w", repoID, err)
+		}
+
+		// artifacts are ordered newest first, keep them until the size limit is reached.
+		var size int64
+		for _, artifact := range artifacts {
+			size += artifact.Size
+			if size <= j.maxRepoSize {
+				continue
+			}
+
+			if err = j.delete(ctx, artifact); err != nil {
+				return count, err
+			}
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// delete removes the artifact blob before the artifact record,
+// so a failure never leaves a blob without a record pointing to it.
+func (j *artifactsCleanupJob) delete(ctx context.Context, artifact *types.Artifact) error {
+	if err := j.blobStore.Delete(ctx, artifact.BlobPath); err != nil {
+		return fmt.Errorf("failed to delete blob of artifact %d: %w", artifact.ID, err)
+	}
+
+	if err := j.artifactStore.Delete(ctx, artifact.ID); err != nil {
+		return fmt.Errorf("failed to delete artifact %d: %w", artifact.ID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeIdempotencyKeys        = "gitness:cleanup:idempotency-keys"
+	jobCronIdempotencyKeys        = "17 * * * *" // At minute 17 past every hour.
+	jobMaxDurationIdempotencyKeys = 1 * time.Minute
+)
+
+type idempotencyKeysCleanupJob struct {
+	idempotencyKeyStore store.IdempotencyKeyStore
+}
+
+func newIdempotencyKeysCleanupJob(
+	idempotencyKeyStore store.IdempotencyKeyStore,
+) *idempotencyKeysCleanupJob {
+	return &idempotencyKeysCleanupJob{
+		idempotencyKeyStore: idempotencyKeyStore,
+	}
+}
+
+// Handle purges idempotency keys that expired, together with their stored responses.
+func (j *idempotencyKeysCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	n, err := j.idempotencyKeyStore.Purge(ctx, time.Now().UnixMilli())
+	if err != nil {
+		return "", fmt.Errorf("failed to purge expired idempotency keys: %w", err)
+	}
+
+	result := "no expired idempotency keys found"
+	if n > 0 {
+		result = fmt.Sprintf("deleted %d expired idempotency keys", n)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
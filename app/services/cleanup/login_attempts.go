@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeLoginAttempts        = "gitness:cleanup:login-attempts"
+	jobCronLoginAttempts        = "7 * * * *" // At minute 7 past every hour.
+	jobMaxDurationLoginAttempts = 1 * time.Minute
+)
+
+type loginAttemptsCleanupJob struct {
+	retentionTime     time.Duration
+	loginAttemptStore store.LoginAttemptStore
+}
+
+func newLoginAttemptsCleanupJob(
+	retentionTime time.Duration,
+	loginAttemptStore store.LoginAttemptStore,
+) *loginAttemptsCleanupJob {
+	return &loginAttemptsCleanupJob{
+		retentionTime:     retentionTime,
+		loginAttemptStore: loginAttemptStore,
+	}
+}
+
+// Handle purges failed logins that are outside the failure window and lockouts that ended.
+func (j *loginAttemptsCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	now := time.Now()
+	olderThan := now.Add(-j.retentionTime)
+
+	log.Ctx(ctx).Info().Msgf(
+		"start purging failed logins older than %s and ended lockouts",
+		olderThan.Format(time.RFC3339Nano),
+	)
+
+	nFailures, err := j.loginAttemptStore.PurgeFailures(ctx, olderThan.UnixMilli())
+	if err != nil {
+		return "", fmt.Errorf("failed to purge failed logins: %w", err)
+	}
+
+	nLockouts, err := j.loginAttemptStore.PurgeLockouts(ctx, now.UnixMilli())
+	if err != nil {
+		return "", fmt.Errorf("failed to purge ended login lockouts: %w", err)
+	}
+
+	result := fmt.Sprintf("deleted %d failed logins and %d lockouts", nFailures, nLockouts)
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeOperations        = "gitness:cleanup:operations"
+	jobCronOperations        = "27 * * * *" // At minute 27 past every hour.
+	jobMaxDurationOperations = 1 * time.Minute
+)
+
+type operationsCleanupJob struct {
+	retentionTime  time.Duration
+	operationStore store.OperationStore
+}
+
+func newOperationsCleanupJob(
+	retentionTime time.Duration,
+	operationStore store.OperationStore,
+) *operationsCleanupJob {
+	return &operationsCleanupJob{
+		retentionTime:  retentionTime,
+		operationStore: operationStore,
+	}
+}
+
+// Handle purges operations that are older than the retention time of the jobs executing them.
+func (j *operationsCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	olderThan := time.Now().Add(-j.retentionTime)
+
+	log.Ctx(ctx).Info().Msgf(
+		"start purging operations older than %s",
+		olderThan.Format(time.RFC3339Nano),
+	)
+
+	n, err := j.operationStore.Purge(ctx, olderThan.UnixMilli())
+	if err != nil {
+		return "", fmt.Errorf("failed to purge operations: %w", err)
+	}
+
+	result := "no old operations found"
+	if n > 0 {
+		result = fmt.Sprintf("deleted %d operations", n)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
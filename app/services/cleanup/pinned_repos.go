@@ -0,0 +1,61 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypePinnedRepos        = "gitness:cleanup:pinned-repos"
+	jobCronPinnedRepos        = "47 */2 * * *" // At minute 47 past every 2nd hour.
+	jobMaxDurationPinnedRepos = 1 * time.Minute
+)
+
+type pinnedReposCleanupJob struct {
+	pinnedRepoStore store.SpacePinnedRepoStore
+}
+
+func newPinnedReposCleanupJob(
+	pinnedRepoStore store.SpacePinnedRepoStore,
+) *pinnedReposCleanupJob {
+	return &pinnedReposCleanupJob{
+		pinnedRepoStore: pinnedRepoStore,
+	}
+}
+
+// Handle deletes the pins of repos that were deleted or moved out of the space they're pinned in.
+func (j *pinnedReposCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	n, err := j.pinnedRepoStore.DeleteStale(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to delete stale pinned repos: %w", err)
+	}
+
+	result := "no stale pinned repos found"
+	if n > 0 {
+		result = fmt.Sprintf("deleted %d stale pinned repos", n)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypePullReqRefs        = "gitness:cleanup:pullreq-refs"
+	jobCronPullReqRefs        = "43 */4 * * *" // At minute 43 past every 4th hour.
+	jobMaxDurationPullReqRefs = 30 * time.Minute
+
+	pullReqRefsPageSize = 100
+)
+
+type pullReqLister interface {
+	List(ctx context.Context, opts *types.PullReqFilter) ([]*types.PullReq, error)
+}
+
+type pullReqHeadRefsDeleter interface {
+	DeleteHeadRefs(ctx context.Context, repoID int64, prNums []int64) (int, error)
+}
+
+type pullReqRefsCleanupJob struct {
+	retentionTime time.Duration
+
+	pullReqStore pullReqLister
+	pullReqSvc   pullReqHeadRefsDeleter
+}
+
+func newPullReqRefsCleanupJob(
+	retentionTime time.Duration,
+	pullReqStore pullReqLister,
+	pullReqSvc pullReqHeadRefsDeleter,
+) *pullReqRefsCleanupJob {
+	return &pullReqRefsCleanupJob{
+		retentionTime: retentionTime,
+
+		pullReqStore: pullReqStore,
+		pullReqSvc:   pullReqSvc,
+	}
+}
+
+// Handle removes the head git references of pull requests that are closed for longer than the retention time.
+// The merge references are removed as soon as a pull request gets closed or merged,
+// and the head references of merged pull requests are kept, as they hold the original commits of squash merges.
+// All closed pull requests last edited before the retention time are checked on every run (the edit time
+// is never before the close time), so pull requests missed by failed runs or closed before the job existed
+// are cleaned up as well. Only head refs that still exist are deleted.
+func (j *pullReqRefsCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	closedBefore := time.Now().Add(-j.retentionTime)
+
+	log.Ctx(ctx).Info().Msgf(
+		"start removing head refs of pull requests closed for longer than %s (aka closed before %s)",
+		j.retentionTime,
+		closedBefore.Format(time.RFC3339Nano))
+
+	filter := &types.PullReqFilter{
+		Page:   1,
+		Size:   pullReqRefsPageSize,
+		States: []enum.PullReqState{enum.PullReqStateClosed},
+		EditedFilter: types.EditedFilter{
+			EditedLt: closedBefore.UnixMilli(),
+		},
+		Sort:  enum.PullReqSortCreated,
+		Order: enum.OrderAsc,
+	}
+
+	var n int
+	for {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+
+		page, err := j.pullReqStore.List(ctx, filter)
+		if err != nil {
+			return "", fmt.Errorf("failed to list closed pull requests: %w", err)
+		}
+
+		n += j.deleteHeadRefs(ctx, page)
+
+		if len(page) < filter.Size {
+			break
+		}
+
+		filter.Page++
+	}
+
+	result := fmt.Sprintf("removed head refs of %d closed pull requests", n)
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
+
+// deleteHeadRefs deletes the existing head refs of the provided pull requests grouped by repository,
+// and returns the number of deleted refs.
+func (j *pullReqRefsCleanupJob) deleteHeadRefs(ctx context.Context, prs []*types.PullReq) int {
+	var repoIDs []int64
+	prNumsPerRepo := make(map[int64][]int64)
+	for _, pr := range prs {
+		if _, ok := prNumsPerRepo[pr.TargetRepoID]; !ok {
+			repoIDs = append(repoIDs, pr.TargetRepoID)
+		}
+		prNumsPerRepo[pr.TargetRepoID] = append(prNumsPerRepo[pr.TargetRepoID], pr.Number)
+	}
+
+	var n int
+	for _, repoID := range repoIDs {
+		deleted, err := j.pullReqSvc.DeleteHeadRefs(ctx, repoID, prNumsPerRepo[repoID])
+		n += deleted
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("repo_id", repoID).
+				Msg("failed to remove head refs of closed pull requests")
+		}
+	}
+
+	return n
+}
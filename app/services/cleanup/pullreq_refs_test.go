@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// memPullReqs is an in-memory pull request store and head ref deleter.
+type memPullReqs struct {
+	prs      []*types.PullReq
+	headRefs map[int64]bool // keyed by pull request number, all pull requests are in the same repo.
+}
+
+func (m *memPullReqs) List(_ context.Context, opts *types.PullReqFilter) ([]*types.PullReq, error) {
+	var matching []*types.PullReq
+	for _, pr := range m.prs {
+		if len(opts.States) > 0 && pr.State != opts.States[0] {
+			continue
+		}
+		if opts.EditedLt > 0 && pr.Edited >= opts.EditedLt {
+			continue
+		}
+		if opts.EditedGt > 0 && pr.Edited <= opts.EditedGt {
+			continue
+		}
+		matching = append(matching, pr)
+	}
+
+	from := min((opts.Page-1)*opts.Size, len(matching))
+	to := min(from+opts.Size, len(matching))
+
+	return matching[from:to], nil
+}
+
+func (m *memPullReqs) DeleteHeadRefs(_ context.Context, _ int64, prNums []int64) (int, error) {
+	var n int
+	for _, prNum := range prNums {
+		if m.headRefs[prNum] {
+			delete(m.headRefs, prNum)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func TestPullReqRefsCleanupJob(t *testing.T) {
+	const retentionTime = 30 * 24 * time.Hour
+	now := time.Now()
+
+	m := &memPullReqs{headRefs: map[int64]bool{}}
+	addPR := func(state enum.PullReqState, edited time.Time) int64 {
+		num := int64(len(m.prs) + 1)
+		m.prs = append(m.prs, &types.PullReq{
+			Number:       num,
+			TargetRepoID: 1,
+			State:        state,
+			Edited:       edited.UnixMilli(),
+		})
+		m.headRefs[num] = true
+		return num
+	}
+
+	// pull requests closed long before the job got introduced, spanning multiple pages.
+	var expired []int64
+	for i := 0; i < 2*pullReqRefsPageSize+10; i++ {
+		expired = append(expired, addPR(enum.PullReqStateClosed, now.Add(-365*24*time.Hour)))
+	}
+	// a pull request closed right before the retention time.
+	expired = append(expired, addPR(enum.PullReqStateClosed, now.Add(-retentionTime-time.Minute)))
+
+	var kept []int64
+	kept = append(kept,
+		addPR(enum.PullReqStateClosed, now.Add(-retentionTime+time.Hour)),
+		addPR(enum.PullReqStateOpen, now.Add(-365*24*time.Hour)),
+		addPR(enum.PullReqStateMerged, now.Add(-365*24*time.Hour)),
+	)
+
+	j := newPullReqRefsCleanupJob(retentionTime, m, m)
+
+	if _, err := j.Handle(context.Background(), "", nil); err != nil {
+		t.Fatalf("failed to run job: %v", err)
+	}
+
+	for _, num := range expired {
+		if m.headRefs[num] {
+			t.Errorf("head ref of pull request %d wasn't removed", num)
+		}
+	}
+	for _, num := range kept {
+		if !m.headRefs[num] {
+			t.Errorf("head ref of pull request %d was removed", num)
+		}
+	}
+
+	// refs that are already removed aren't counted again.
+	result, err := j.Handle(context.Background(), "", nil)
+	if err != nil {
+		t.Fatalf("failed to rerun job: %v", err)
+	}
+	if want := "removed head refs of 0 closed pull requests"; result != want {
+		t.Errorf("unexpected result of rerun: got %q, want %q", result, want)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeQuarantineDirs        = "gitness:cleanup:quarantine-dirs"
+	jobCronQuarantineDirs        = "53 */6 * * *" // At minute 53 past every 6th hour.
+	jobMaxDurationQuarantineDirs = 30 * time.Minute
+)
+
+type repoSizeInfoLister interface {
+	ListSizeInfos(ctx context.Context) ([]*types.RepositorySizeInfo, error)
+}
+
+type quarantineDirsRemover interface {
+	RemoveStaleQuarantineDirs(ctx context.Context, params *git.RemoveStaleQuarantineDirsParams) error
+}
+
+type quarantineDirsCleanupJob struct {
+	repoStore repoSizeInfoLister
+	git       quarantineDirsRemover
+}
+
+func newQuarantineDirsCleanupJob(
+	repoStore repoSizeInfoLister,
+	git quarantineDirsRemover,
+) *quarantineDirsCleanupJob {
+	return &quarantineDirsCleanupJob{
+		repoStore: repoStore,
+		git:       git,
+	}
+}
+
+// Handle removes the quarantine directories of pushes that didn't complete from all repositories.
+// The quarantine directory of a failed push is removed right away, unless other pushes to the same
+// repository were in flight or the server got killed during the push.
+func (j *quarantineDirsCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	repos, err := j.repoStore.ListSizeInfos(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list repositories: %w", err)
+	}
+
+	log.Ctx(ctx).Info().Msgf("start removing stale quarantine directories of %d repositories", len(repos))
+
+	var failed int
+	for _, repo := range repos {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+
+		err = j.git.RemoveStaleQuarantineDirs(ctx, &git.RemoveStaleQuarantineDirsParams{
+			ReadParams: git.ReadParams{RepoUID: repo.GitUID},
+		})
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("repo_id", repo.ID).
+				Msg("failed to remove stale quarantine directories")
+			failed++
+		}
+	}
+
+	result := fmt.Sprintf("checked quarantine directories of %d repositories (%d failed)", len(repos), failed)
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
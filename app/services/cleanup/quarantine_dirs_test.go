@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+)
+
+type memRepoSizeInfos []*types.RepositorySizeInfo
+
+func (m memRepoSizeInfos) ListSizeInfos(context.Context) ([]*types.RepositorySizeInfo, error) {
+	return m, nil
+}
+
+// memQuarantineDirsRemover records the repositories of which stale quarantine directories got removed.
+type memQuarantineDirsRemover struct {
+	failing map[string]bool
+	removed []string
+}
+
+func (m *memQuarantineDirsRemover) RemoveStaleQuarantineDirs(
+	_ context.Context,
+	params *git.RemoveStaleQuarantineDirsParams,
+) error {
+	if m.failing[params.RepoUID] {
+		return errors.New("failed")
+	}
+	m.removed = append(m.removed, params.RepoUID)
+	return nil
+}
+
+func TestQuarantineDirsCleanupJob(t *testing.T) {
+	repos := memRepoSizeInfos{
+		{ID: 1, GitUID: "repo1"},
+		{ID: 2, GitUID: "repo2"},
+		{ID: 3, GitUID: "repo3"},
+	}
+	remover := &memQuarantineDirsRemover{failing: map[string]bool{"repo2": true}}
+
+	result, err := newQuarantineDirsCleanupJob(repos, remover).Handle(context.Background(), "", nil)
+	if err != nil {
+		t.Fatalf("failed to run job: %v", err)
+	}
+
+	// a failure for one repository doesn't stop the cleanup of the other repositories.
+	if len(remover.removed) != 2 || remover.removed[0] != "repo1" || remover.removed[1] != "repo3" {
+		t.Errorf("unexpected repositories cleaned up: %v", remover.removed)
+	}
+	if want := "checked quarantine directories of 3 repositories (1 failed)"; result != want {
+		t.Errorf("unexpected result: got %q, want %q", result, want)
+	}
+}
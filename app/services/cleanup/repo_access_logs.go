@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeRepoAccessLogs        = "gitness:cleanup:repo-access-logs"
+	jobCronRepoAccessLogs        = "37 */4 * * *" // At minute 37 past every 4th hour.
+	jobMaxDurationRepoAccessLogs = 1 * time.Minute
+)
+
+type repoAccessLogsCleanupJob struct {
+	retentionTime time.Duration
+
+	repoAccessLogStore store.RepoAccessLogStore
+}
+
+func newRepoAccessLogsCleanupJob(
+	retentionTime time.Duration,
+	repoAccessLogStore store.RepoAccessLogStore,
+) *repoAccessLogsCleanupJob {
+	return &repoAccessLogsCleanupJob{
+		retentionTime: retentionTime,
+
+		repoAccessLogStore: repoAccessLogStore,
+	}
+}
+
+// Handle purges old repo access logs that are past the retention time.
+func (j *repoAccessLogsCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	olderThan := time.Now().Add(-j.retentionTime)
+
+	log.Ctx(ctx).Info().Msgf(
+		"start purging repo access logs older than %s (aka created before %s)",
+		j.retentionTime,
+		olderThan.Format(time.RFC3339Nano))
+
+	n, err := j.repoAccessLogStore.DeleteOld(ctx, olderThan)
+	if err != nil {
+		return "", fmt.Errorf("failed to delete old repo access logs: %w", err)
+	}
+
+	result := "no old repo access logs found"
+	if n > 0 {
+		result = fmt.Sprintf("deleted %d repo access logs", n)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
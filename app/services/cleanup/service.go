@@ -21,13 +21,24 @@ import (
 	"time"
 
 	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/job"
 )
 
 type Config struct {
 	WebhookExecutionsRetentionTime   time.Duration
 	DeletedRepositoriesRetentionTime time.Duration
+	RepoActivitiesRetentionTime      time.Duration
+	RepoAccessLogsRetentionTime      time.Duration
+	PullReqClosedRefsRetentionTime   time.Duration
+	LoginFailuresRetentionTime       time.Duration
+	ArtifactsRetentionTime           time.Duration
+	OperationsRetentionTime          time.Duration
+	// ArtifactsMaxRepoSize is the max total size of artifacts per repository in bytes, 0 means unlimited.
+	ArtifactsMaxRepoSize int64
 }
 
 func (c *Config) Prepare() error {
@@ -41,6 +52,30 @@ func (c *Config) Prepare() error {
 	if c.DeletedRepositoriesRetentionTime <= 0 {
 		return errors.New("config.DeletedRepositoriesRetentionTime has to be provided")
 	}
+
+	if c.RepoActivitiesRetentionTime <= 0 {
+		return errors.New("config.RepoActivitiesRetentionTime has to be provided")
+	}
+
+	if c.RepoAccessLogsRetentionTime <= 0 {
+		return errors.New("config.RepoAccessLogsRetentionTime has to be provided")
+	}
+
+	if c.PullReqClosedRefsRetentionTime <= 0 {
+		return errors.New("config.PullReqClosedRefsRetentionTime has to be provided")
+	}
+
+	if c.LoginFailuresRetentionTime <= 0 {
+		return errors.New("config.LoginFailuresRetentionTime has to be provided")
+	}
+
+	if c.ArtifactsRetentionTime <= 0 {
+		return errors.New("config.ArtifactsRetentionTime has to be provided")
+	}
+
+	if c.OperationsRetentionTime <= 0 {
+		return errors.New("config.OperationsRetentionTime has to be provided")
+	}
 	return nil
 }
 
@@ -52,7 +87,18 @@ type Service struct {
 	webhookExecutionStore store.WebhookExecutionStore
 	tokenStore            store.TokenStore
 	repoStore             store.RepoStore
+	repoActivityStore     store.RepoActivityStore
+	repoAccessLogStore    store.RepoAccessLogStore
+	pullReqStore          store.PullReqStore
+	loginAttemptStore     store.LoginAttemptStore
+	artifactStore         store.ArtifactStore
+	pinnedRepoStore       store.SpacePinnedRepoStore
+	idempotencyKeyStore   store.IdempotencyKeyStore
+	operationStore        store.OperationStore
+	blobStore             blob.Store
+	git                   git.Interface
 	repoCtrl              *repo.Controller
+	pullReqSvc            *pullreq.Service
 }
 
 func NewService(
@@ -62,7 +108,18 @@ func NewService(
 	webhookExecutionStore store.WebhookExecutionStore,
 	tokenStore store.TokenStore,
 	repoStore store.RepoStore,
+	repoActivityStore store.RepoActivityStore,
+	repoAccessLogStore store.RepoAccessLogStore,
+	pullReqStore store.PullReqStore,
+	loginAttemptStore store.LoginAttemptStore,
+	artifactStore store.ArtifactStore,
+	pinnedRepoStore store.SpacePinnedRepoStore,
+	idempotencyKeyStore store.IdempotencyKeyStore,
+	operationStore store.OperationStore,
+	blobStore blob.Store,
+	git git.Interface,
 	repoCtrl *repo.Controller,
+	pullReqSvc *pullreq.Service,
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
 		return nil, fmt.Errorf("provided cleanup config is invalid: %w", err)
@@ -76,7 +133,18 @@ func NewService(
 		webhookExecutionStore: webhookExecutionStore,
 		tokenStore:            tokenStore,
 		repoStore:             repoStore,
+		repoActivityStore:     repoActivityStore,
+		repoAccessLogStore:    repoAccessLogStore,
+		pullReqStore:          pullReqStore,
+		loginAttemptStore:     loginAttemptStore,
+		artifactStore:         artifactStore,
+		pinnedRepoStore:       pinnedRepoStore,
+		idempotencyKeyStore:   idempotencyKeyStore,
+		operationStore:        operationStore,
+		blobStore:             blobStore,
+		git:                   git,
 		repoCtrl:              repoCtrl,
+		pullReqSvc:            pullReqSvc,
 	}, nil
 }
 
@@ -126,6 +194,105 @@ func (s *Service) scheduleRecurringCleanupJobs(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to schedule deleted repo cleanup job: %w", err)
 	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeRepoActivities,
+		jobTypeRepoActivities,
+		jobCronRepoActivities,
+		jobMaxDurationRepoActivities,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule repo activities cleanup job: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeRepoAccessLogs,
+		jobTypeRepoAccessLogs,
+		jobCronRepoAccessLogs,
+		jobMaxDurationRepoAccessLogs,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule repo access logs cleanup job: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypePullReqRefs,
+		jobTypePullReqRefs,
+		jobCronPullReqRefs,
+		jobMaxDurationPullReqRefs,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule pull request refs cleanup job: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeLoginAttempts,
+		jobTypeLoginAttempts,
+		jobCronLoginAttempts,
+		jobMaxDurationLoginAttempts,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule login attempts cleanup job: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeArtifacts,
+		jobTypeArtifacts,
+		jobCronArtifacts,
+		jobMaxDurationArtifacts,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule artifacts cleanup job: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypePinnedRepos,
+		jobTypePinnedRepos,
+		jobCronPinnedRepos,
+		jobMaxDurationPinnedRepos,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule pinned repos cleanup job: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeIdempotencyKeys,
+		jobTypeIdempotencyKeys,
+		jobCronIdempotencyKeys,
+		jobMaxDurationIdempotencyKeys,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule idempotency keys cleanup job: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeOperations,
+		jobTypeOperations,
+		jobCronOperations,
+		jobMaxDurationOperations,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule operations cleanup job: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeQuarantineDirs,
+		jobTypeQuarantineDirs,
+		jobCronQuarantineDirs,
+		jobMaxDurationQuarantineDirs,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule quarantine dirs cleanup job: %w", err)
+	}
 	return nil
 }
 
@@ -160,5 +327,96 @@ func (s *Service) registerJobHandlers() error {
 	); err != nil {
 		return fmt.Errorf("failed to register job handler for deleted repos cleanup: %w", err)
 	}
+
+	if err := s.executor.Register(
+		jobTypeRepoActivities,
+		newRepoActivitiesCleanupJob(
+			s.config.RepoActivitiesRetentionTime,
+			s.repoActivityStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for repo activities cleanup: %w", err)
+	}
+
+	if err := s.executor.Register(
+		jobTypeRepoAccessLogs,
+		newRepoAccessLogsCleanupJob(
+			s.config.RepoAccessLogsRetentionTime,
+			s.repoAccessLogStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for repo access logs cleanup: %w", err)
+	}
+
+	if err := s.executor.Register(
+		jobTypePullReqRefs,
+		newPullReqRefsCleanupJob(
+			s.config.PullReqClosedRefsRetentionTime,
+			s.pullReqStore,
+			s.pullReqSvc,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for pull request refs cleanup: %w", err)
+	}
+
+	if err := s.executor.Register(
+		jobTypeLoginAttempts,
+		newLoginAttemptsCleanupJob(
+			s.config.LoginFailuresRetentionTime,
+			s.loginAttemptStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for login attempts cleanup: %w", err)
+	}
+
+	if err := s.executor.Register(
+		jobTypeArtifacts,
+		newArtifactsCleanupJob(
+			s.config.ArtifactsRetentionTime,
+			s.config.ArtifactsMaxRepoSize,
+			s.artifactStore,
+			s.blobStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for artifacts cleanup: %w", err)
+	}
+
+	if err := s.executor.Register(
+		jobTypePinnedRepos,
+		newPinnedReposCleanupJob(
+			s.pinnedRepoStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for pinned repos cleanup: %w", err)
+	}
+
+	if err := s.executor.Register(
+		jobTypeIdempotencyKeys,
+		newIdempotencyKeysCleanupJob(
+			s.idempotencyKeyStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for idempotency keys cleanup: %w", err)
+	}
+
+	if err := s.executor.Register(
+		jobTypeOperations,
+		newOperationsCleanupJob(
+			s.config.OperationsRetentionTime,
+			s.operationStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for operations cleanup: %w", err)
+	}
+
+	if err := s.executor.Register(
+		jobTypeQuarantineDirs,
+		newQuarantineDirsCleanupJob(
+			s.repoStore,
+			s.git,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for quarantine dirs cleanup: %w", err)
+	}
 	return nil
 }
@@ -16,7 +16,10 @@ package cleanup
 
 import (
 	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/job"
 
 	"github.com/google/wire"
@@ -33,7 +36,18 @@ func ProvideService(
 	webhookExecutionStore store.WebhookExecutionStore,
 	tokenStore store.TokenStore,
 	repoStore store.RepoStore,
+	repoActivityStore store.RepoActivityStore,
+	repoAccessLogStore store.RepoAccessLogStore,
+	pullReqStore store.PullReqStore,
+	loginAttemptStore store.LoginAttemptStore,
+	artifactStore store.ArtifactStore,
+	pinnedRepoStore store.SpacePinnedRepoStore,
+	idempotencyKeyStore store.IdempotencyKeyStore,
+	operationStore store.OperationStore,
+	blobStore blob.Store,
+	git git.Interface,
 	repoCtrl *repo.Controller,
+	pullReqSvc *pullreq.Service,
 ) (*Service, error) {
 	return NewService(
 		config,
@@ -42,6 +56,17 @@ func ProvideService(
 		webhookExecutionStore,
 		tokenStore,
 		repoStore,
+		repoActivityStore,
+		repoAccessLogStore,
+		pullReqStore,
+		loginAttemptStore,
+		artifactStore,
+		pinnedRepoStore,
+		idempotencyKeyStore,
+		operationStore,
+		blobStore,
+		git,
 		repoCtrl,
+		pullReqSvc,
 	)
 }
@@ -21,9 +21,11 @@ import (
 	"io"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/cache"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
 	gitness_store "github.com/harness/gitness/store"
@@ -42,6 +44,9 @@ const (
 	maxGetContentFileSize = oneMegabyte * 4 // 4 MB
 	// userGroupPrefixMarker is a prefix which will be used to identify if a given codeowner is usergroup.
 	userGroupPrefixMarker = "@"
+	// pullReqOwnershipCacheDuration specifies for how long the owners of the files changed by a pull request
+	// are cached. Changes of the codeowners file on the target branch become visible after this period at the latest.
+	pullReqOwnershipCacheDuration = 10 * time.Minute
 )
 
 var (
@@ -112,6 +117,8 @@ type Service struct {
 	principalStore    store.PrincipalStore
 	config            Config
 	userGroupResolver usergroup.Resolver
+
+	pullReqOwnership cache.Cache[pullReqOwnershipKey, *types.CodeOwnersOwnership]
 }
 
 type File struct {
@@ -134,6 +141,10 @@ type Entry struct {
 	// Owners is the list of owners for the given pattern.
 	// NOTE: Could be empty in case of an entry that clears previously defined ownerships.
 	Owners []string
+
+	// Paths contains the changed paths the entry applies to.
+	// NOTE: Only populated for entries that are applicable to a pull request.
+	Paths []string
 }
 
 // IsOwnershipReset returns true iff the entry resets any previously defined ownerships.
@@ -149,6 +160,7 @@ type Evaluation struct {
 type EvaluationEntry struct {
 	LineNumber                int64
 	Pattern                   string
+	Paths                     []string
 	OwnerEvaluations          []OwnerEvaluation
 	UserGroupOwnerEvaluations []UserGroupOwnerEvaluation
 }
@@ -179,6 +191,8 @@ func New(
 		principalStore:    principalStore,
 		userGroupResolver: userGroupResolver,
 	}
+	service.pullReqOwnership = cache.New[pullReqOwnershipKey, *types.CodeOwnersOwnership](
+		pullReqOwnershipFinder{s: service}, pullReqOwnershipCacheDuration)
 	return service
 }
 
@@ -207,78 +221,105 @@ func (s *Service) get(
 }
 
 func (s *Service) parseCodeOwner(codeOwnersContent string) ([]Entry, error) {
+	codeOwners, parseErrs, err := parseCodeOwnerLines(codeOwnersContent)
+	if err != nil {
+		return nil, err
+	}
+	if len(parseErrs) > 0 {
+		return nil, parseErrs[0]
+	}
+
+	return codeOwners, nil
+}
+
+// parseCodeOwnerLines parses all lines of the codeowners file.
+// Lines that can't be parsed are skipped and reported as parse errors.
+func parseCodeOwnerLines(codeOwnersContent string) ([]Entry, []*FileParseError, error) {
 	var lineNumber int64
 	var codeOwners []Entry
+	var parseErrs []*FileParseError
 	scanner := bufio.NewScanner(strings.NewReader(codeOwnersContent))
 	for scanner.Scan() {
 		lineNumber++
-		originalLine := scanner.Text()
 
-		line := strings.TrimSpace(originalLine)
-		if line == "" || strings.HasPrefix(line, "#") {
+		entry, err := parseCodeOwnerLine(lineNumber, scanner.Text())
+		if err != nil {
+			parseErrs = append(parseErrs, err)
 			continue
 		}
+		if entry != nil {
+			codeOwners = append(codeOwners, *entry)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error reading input: %w", err)
+	}
 
-		isSeparator := func(r rune) bool { return r == ' ' || r == '\t' }
-		lineAsRunes := []rune(line)
-		pattern := strings.Builder{}
+	return codeOwners, parseErrs, nil
+}
 
-		// important to iterate over runes and not bytes to support utf-8 encoding.
-		for len(lineAsRunes) > 0 {
-			if isSeparator(lineAsRunes[0]) || lineAsRunes[0] == '#' {
-				break
-			}
+// parseCodeOwnerLine parses a single line of the codeowners file.
+// It returns nil in case the line is empty or a comment.
+func parseCodeOwnerLine(lineNumber int64, originalLine string) (*Entry, *FileParseError) {
+	line := strings.TrimSpace(originalLine)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return nil, nil
+	}
 
-			if lineAsRunes[0] == '\\' {
-				// ensure pattern doesn't end with trailing backslash.
-				if len(lineAsRunes) == 1 {
-					return nil, &FileParseError{
-						LineNumber: lineNumber,
-						Line:       originalLine,
-						Err:        ErrFileParseTrailingBackslashInPattern,
-					}
-				}
+	isSeparator := func(r rune) bool { return r == ' ' || r == '\t' }
+	lineAsRunes := []rune(line)
+	pattern := strings.Builder{}
+
+	// important to iterate over runes and not bytes to support utf-8 encoding.
+	for len(lineAsRunes) > 0 {
+		if isSeparator(lineAsRunes[0]) || lineAsRunes[0] == '#' {
+			break
+		}
 
-				switch {
-				// escape character and special characters need to stay escaped ("\\", "\*", ...)
-				case lineAsRunes[1] == '\\' || slices.Contains(escapableSpecialCharactersInPattern, lineAsRunes[1]):
-					pattern.WriteRune('\\')
-					lineAsRunes = lineAsRunes[1:]
-
-				// control characters aren't special characters in pattern syntax, so escaping should be removed.
-				case slices.Contains(escapableControlCharactersInPattern, lineAsRunes[1]):
-					lineAsRunes = lineAsRunes[1:]
-
-				default:
-					return nil, &FileParseError{
-						LineNumber: lineNumber,
-						Line:       originalLine,
-						Err:        ErrFileParseInvalidEscapingInPattern,
-					}
+		if lineAsRunes[0] == '\\' {
+			// ensure pattern doesn't end with trailing backslash.
+			if len(lineAsRunes) == 1 {
+				return nil, &FileParseError{
+					LineNumber: lineNumber,
+					Line:       originalLine,
+					Err:        ErrFileParseTrailingBackslashInPattern,
 				}
 			}
 
-			pattern.WriteRune(lineAsRunes[0])
-			lineAsRunes = lineAsRunes[1:]
-		}
-
-		// remove inline comment (can't be escaped in owners, only pattern supports escaping)
-		if i := slices.Index(lineAsRunes, '#'); i >= 0 {
-			lineAsRunes = lineAsRunes[:i]
+			switch {
+			// escape character and special characters need to stay escaped ("\\", "\*", ...)
+			case lineAsRunes[1] == '\\' || slices.Contains(escapableSpecialCharactersInPattern, lineAsRunes[1]):
+				pattern.WriteRune('\\')
+				lineAsRunes = lineAsRunes[1:]
+
+			// control characters aren't special characters in pattern syntax, so escaping should be removed.
+			case slices.Contains(escapableControlCharactersInPattern, lineAsRunes[1]):
+				lineAsRunes = lineAsRunes[1:]
+
+			default:
+				return nil, &FileParseError{
+					LineNumber: lineNumber,
+					Line:       originalLine,
+					Err:        ErrFileParseInvalidEscapingInPattern,
+				}
+			}
 		}
 
-		codeOwners = append(codeOwners, Entry{
-			LineNumber: lineNumber,
-			Pattern:    pattern.String(),
-			// could be empty list in case of removing ownership
-			Owners: strings.FieldsFunc(string(lineAsRunes), isSeparator),
-		})
+		pattern.WriteRune(lineAsRunes[0])
+		lineAsRunes = lineAsRunes[1:]
 	}
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading input: %w", err)
+
+	// remove inline comment (can't be escaped in owners, only pattern supports escaping)
+	if i := slices.Index(lineAsRunes, '#'); i >= 0 {
+		lineAsRunes = lineAsRunes[:i]
 	}
 
-	return codeOwners, nil
+	return &Entry{
+		LineNumber: lineNumber,
+		Pattern:    pattern.String(),
+		// could be empty list in case of removing ownership
+		Owners: strings.FieldsFunc(string(lineAsRunes), isSeparator),
+	}, nil
 }
 
 func (s *Service) getCodeOwnerFile(
@@ -375,24 +416,23 @@ func (s *Service) getApplicableCodeOwnersForPR(
 		return nil, fmt.Errorf("failed to get diff file stat: %w", err)
 	}
 
-	entryIDs := map[int]struct{}{}
+	entryPaths := map[int][]string{}
 	for _, file := range diffFileStats.Files {
-		// last rule that matches wins (hence simply go in reverse order)
-		for i := len(codeOwners.Entries) - 1; i >= 0; i-- {
-			pattern := codeOwners.Entries[i].Pattern
-			if ok, err := match(pattern, file); err != nil {
-				return nil, fmt.Errorf("failed to match pattern %q for file %q: %w", pattern, file, err)
-			} else if ok {
-				entryIDs[i] = struct{}{}
-				break
-			}
+		i, err := findMatchingEntry(codeOwners.Entries, file)
+		if err != nil {
+			return nil, err
+		}
+		if i >= 0 {
+			entryPaths[i] = append(entryPaths[i], file)
 		}
 	}
 
-	filteredEntries := make([]Entry, 0, len(entryIDs))
-	for i := range entryIDs {
+	filteredEntries := make([]Entry, 0, len(entryPaths))
+	for i, paths := range entryPaths {
 		if !codeOwners.Entries[i].IsOwnershipReset() {
-			filteredEntries = append(filteredEntries, codeOwners.Entries[i])
+			entry := codeOwners.Entries[i]
+			entry.Paths = paths
+			filteredEntries = append(filteredEntries, entry)
 		}
 	}
 
@@ -462,6 +502,7 @@ func (s *Service) Evaluate(
 			evaluationEntries = append(evaluationEntries, EvaluationEntry{
 				LineNumber:                entry.LineNumber,
 				Pattern:                   entry.Pattern,
+				Paths:                     entry.Paths,
 				OwnerEvaluations:          ownerEvaluations,
 				UserGroupOwnerEvaluations: userGroupOwnerEvaluations,
 			})
@@ -530,28 +571,55 @@ func (s *Service) resolveUserCodeOwnerByEmail(
 	}, nil
 }
 
+// Validate validates the codeowners file of the provided branch.
+// Syntax errors, unknown owners and invalid patterns are reported as violations with their line numbers.
+//
+//nolint:gocognit
 func (s *Service) Validate(
 	ctx context.Context,
 	repo *types.Repository,
 	branch string,
 ) (*types.CodeOwnersValidation, error) {
 	var codeOwnerValidation types.CodeOwnersValidation
-	// check file parsing, existence and size
-	codeowners, err := s.get(ctx, repo, branch)
+	// check file existence and size
+	codeOwnerFile, err := s.getCodeOwnerFile(ctx, repo, branch)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("unable to get codeowner file: %w", err)
+	}
+	if codeOwnerFile.TotalSize > maxGetContentFileSize {
+		return nil, &TooLargeError{FileSize: codeOwnerFile.TotalSize}
+	}
+
+	// check file parsing - unparsable lines are reported and skipped.
+	entries, parseErrs, err := parseCodeOwnerLines(codeOwnerFile.Content)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse codeowner: %w", err)
+	}
+
+	for _, parseErr := range parseErrs {
+		codeOwnerValidation.AddfAtLine(parseErr.LineNumber, enum.CodeOwnerViolationCodeSyntaxError,
+			"syntax error at line %d: %s", parseErr.LineNumber, parseErr.Err)
 	}
 
-	for _, entry := range codeowners.Entries {
-		// check for users in file
+	for _, entry := range entries {
+		// check for owners in file
 		for _, owner := range entry.Owners {
-			// todo: handle usergroup better
 			if strings.HasPrefix(owner, userGroupPrefixMarker) {
+				_, err := s.userGroupResolver.Resolve(ctx, owner[1:])
+				if errors.Is(err, usergroup.ErrNotFound) {
+					codeOwnerValidation.AddfAtLine(entry.LineNumber, enum.CodeOwnerViolationCodeUserGroupNotFound,
+						"user group %q not found", owner)
+					continue
+				}
+				if err != nil {
+					return nil, fmt.Errorf("error encountered resolving user group %q: %w", owner, err)
+				}
 				continue
 			}
+
 			_, err := s.principalStore.FindByEmail(ctx, owner)
 			if errors.Is(err, gitness_store.ErrResourceNotFound) {
-				codeOwnerValidation.Addf(enum.CodeOwnerViolationCodeUserNotFound,
+				codeOwnerValidation.AddfAtLine(entry.LineNumber, enum.CodeOwnerViolationCodeUserNotFound,
 					"user %q not found", owner)
 				continue
 			}
@@ -562,21 +630,234 @@ func (s *Service) Validate(
 
 		// check for pattern
 		if entry.Pattern == "" {
-			codeOwnerValidation.Add(enum.CodeOwnerViolationCodePatternEmpty,
+			codeOwnerValidation.AddfAtLine(entry.LineNumber, enum.CodeOwnerViolationCodePatternEmpty,
 				"empty pattern")
 			continue
 		}
 
 		ok := doublestar.ValidatePathPattern(entry.Pattern)
 		if !ok {
-			codeOwnerValidation.Addf(enum.CodeOwnerViolationCodePatternInvalid, "pattern %q is invalid",
-				entry.Pattern)
+			codeOwnerValidation.AddfAtLine(entry.LineNumber, enum.CodeOwnerViolationCodePatternInvalid,
+				"pattern %q is invalid", entry.Pattern)
 		}
 	}
 
 	return &codeOwnerValidation, nil
 }
 
+// GetOwnership returns the owners of the provided paths as defined by the codeowners file of the provided ref.
+// The last entry of the codeowners file matching a path wins.
+// Paths without owners are omitted, and owners that can't be resolved are skipped.
+func (s *Service) GetOwnership(
+	ctx context.Context,
+	repo *types.Repository,
+	ref string,
+	paths []string,
+) (*types.CodeOwnersOwnership, error) {
+	codeOwners, err := s.get(ctx, repo, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver := newOwnerResolver(s)
+	ownership := &types.CodeOwnersOwnership{
+		FileSHA:    codeOwners.FileSHA,
+		Paths:      make([]types.CodeOwnersPathOwners, 0, len(paths)),
+		Owners:     []types.PrincipalInfo{},
+		UserGroups: []types.UserGroupInfo{},
+	}
+	ownerIDs := map[int64]struct{}{}
+	userGroupIDs := map[string]struct{}{}
+
+	for _, path := range paths {
+		i, err := findMatchingEntry(codeOwners.Entries, path)
+		if err != nil {
+			return nil, err
+		}
+		if i < 0 || codeOwners.Entries[i].IsOwnershipReset() {
+			continue
+		}
+
+		entry := codeOwners.Entries[i]
+		pathOwners := types.CodeOwnersPathOwners{
+			Path:       path,
+			LineNumber: entry.LineNumber,
+			Pattern:    entry.Pattern,
+			Owners:     []types.PrincipalInfo{},
+			UserGroups: []types.UserGroupInfo{},
+		}
+
+		for _, owner := range entry.Owners {
+			principal, userGroup, err := resolver.resolve(ctx, owner)
+			if err != nil {
+				return nil, err
+			}
+
+			if principal != nil {
+				pathOwners.Owners = append(pathOwners.Owners, *principal)
+				if _, ok := ownerIDs[principal.ID]; !ok {
+					ownerIDs[principal.ID] = struct{}{}
+					ownership.Owners = append(ownership.Owners, *principal)
+				}
+			}
+
+			if userGroup != nil {
+				pathOwners.UserGroups = append(pathOwners.UserGroups, *userGroup)
+				if _, ok := userGroupIDs[userGroup.Identifier]; !ok {
+					userGroupIDs[userGroup.Identifier] = struct{}{}
+					ownership.UserGroups = append(ownership.UserGroups, *userGroup)
+				}
+			}
+		}
+
+		if len(pathOwners.Owners) == 0 && len(pathOwners.UserGroups) == 0 {
+			continue
+		}
+
+		ownership.Paths = append(ownership.Paths, pathOwners)
+	}
+
+	return ownership, nil
+}
+
+// GetOwnershipForPullReq returns the owners of all files changed by the pull request,
+// as defined by the codeowners file of the target branch.
+// The result is cached per source SHA of the pull request, so the diff is computed only once per push.
+func (s *Service) GetOwnershipForPullReq(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+) (*types.CodeOwnersOwnership, error) {
+	ownership, err := s.pullReqOwnership.Get(ctx, pullReqOwnershipKey{
+		RepoID:       repo.ID,
+		TargetBranch: pr.TargetBranch,
+		MergeBaseSHA: pr.MergeBaseSHA,
+		SourceSHA:    pr.SourceSHA,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if ownership == nil {
+		return nil, ErrNotFound
+	}
+
+	return ownership, nil
+}
+
+type pullReqOwnershipKey struct {
+	RepoID       int64
+	TargetBranch string
+	MergeBaseSHA string
+	SourceSHA    string
+}
+
+// pullReqOwnershipFinder computes the owners of the files changed between the merge base and the source SHA.
+// It returns nil if the target branch has no codeowners file.
+type pullReqOwnershipFinder struct {
+	s *Service
+}
+
+func (f pullReqOwnershipFinder) Find(
+	ctx context.Context,
+	key pullReqOwnershipKey,
+) (*types.CodeOwnersOwnership, error) {
+	repo, err := f.s.repoStore.Find(ctx, key.RepoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	diffFileNames, err := f.s.git.DiffFileNames(ctx, &git.DiffParams{
+		ReadParams: git.CreateReadParams(repo),
+		BaseRef:    key.MergeBaseSHA,
+		HeadRef:    key.SourceSHA,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get diff file names: %w", err)
+	}
+
+	ownership, err := f.s.GetOwnership(ctx, repo, key.TargetBranch, diffFileNames.Files)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil //nolint:nilnil // the absence of a codeowners file is cached as well
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return ownership, nil
+}
+
+// ownerResolver resolves the owners of a codeowners file and caches the results.
+type ownerResolver struct {
+	s          *Service
+	principals map[string]*types.PrincipalInfo
+	userGroups map[string]*types.UserGroupInfo
+}
+
+func newOwnerResolver(s *Service) *ownerResolver {
+	return &ownerResolver{
+		s:          s,
+		principals: map[string]*types.PrincipalInfo{},
+		userGroups: map[string]*types.UserGroupInfo{},
+	}
+}
+
+// resolve returns either the principal or the user group of the owner.
+// It returns nil for both in case the owner can't be found.
+func (r *ownerResolver) resolve(
+	ctx context.Context,
+	owner string,
+) (*types.PrincipalInfo, *types.UserGroupInfo, error) {
+	if strings.HasPrefix(owner, userGroupPrefixMarker) {
+		if userGroup, ok := r.userGroups[owner]; ok {
+			return nil, userGroup, nil
+		}
+
+		var userGroupInfo *types.UserGroupInfo
+		userGroup, err := r.s.userGroupResolver.Resolve(ctx, owner[1:])
+		if err != nil && !errors.Is(err, usergroup.ErrNotFound) {
+			return nil, nil, fmt.Errorf("error resolving user group %q: %w", owner, err)
+		}
+		if err == nil {
+			userGroupInfo = userGroup.ToUserGroupInfo()
+		}
+
+		r.userGroups[owner] = userGroupInfo
+		return nil, userGroupInfo, nil
+	}
+
+	if principal, ok := r.principals[owner]; ok {
+		return principal, nil, nil
+	}
+
+	var principalInfo *types.PrincipalInfo
+	principal, err := r.s.principalStore.FindByEmail(ctx, owner)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, nil, fmt.Errorf("error resolving user %q by email: %w", owner, err)
+	}
+	if err == nil {
+		principalInfo = principal.ToPrincipalInfo()
+	}
+
+	r.principals[owner] = principalInfo
+	return principalInfo, nil, nil
+}
+
+// findMatchingEntry returns the index of the entry that applies to the provided path, or -1 if none does.
+// The last entry that matches wins.
+func findMatchingEntry(entries []Entry, path string) (int, error) {
+	// last rule that matches wins (hence simply go in reverse order)
+	for i := len(entries) - 1; i >= 0; i-- {
+		pattern := entries[i].Pattern
+		if ok, err := match(pattern, path); err != nil {
+			return -1, fmt.Errorf("failed to match pattern %q for file %q: %w", pattern, path, err)
+		} else if ok {
+			return i, nil
+		}
+	}
+
+	return -1, nil
+}
+
 func findReviewerInList(email string, uid string, reviewers []*types.PullReqReviewer) *types.PullReqReviewer {
 	for _, reviewer := range reviewers {
 		if uid == reviewer.Reviewer.UID || email == reviewer.Reviewer.Email {
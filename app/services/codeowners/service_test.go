@@ -672,3 +672,70 @@ func Test_match(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseCodeOwnerLines(t *testing.T) {
+	content := `
+/docs/ user1@harness.io
+/src/\q user2@harness.io
+/src/ user3@harness.io # inline comment
+/src\`
+
+	entries, parseErrs, err := parseCodeOwnerLines(content)
+	if err != nil {
+		t.Fatalf("failed with error: %s", err)
+	}
+
+	wantEntries := []Entry{
+		{LineNumber: 2, Pattern: "/docs/", Owners: []string{"user1@harness.io"}},
+		{LineNumber: 4, Pattern: "/src/", Owners: []string{"user3@harness.io"}},
+	}
+	if !reflect.DeepEqual(entries, wantEntries) {
+		t.Errorf("parseCodeOwnerLines() entries = %v, want %v", entries, wantEntries)
+	}
+
+	if len(parseErrs) != 2 {
+		t.Fatalf("parseCodeOwnerLines() returned %d parse errors, want 2", len(parseErrs))
+	}
+	if parseErrs[0].LineNumber != 3 || parseErrs[0].Err != ErrFileParseInvalidEscapingInPattern {
+		t.Errorf("unexpected first parse error: %v", parseErrs[0])
+	}
+	if parseErrs[1].LineNumber != 5 || parseErrs[1].Err != ErrFileParseTrailingBackslashInPattern {
+		t.Errorf("unexpected second parse error: %v", parseErrs[1])
+	}
+}
+
+func Test_findMatchingEntry(t *testing.T) {
+	entries := []Entry{
+		{LineNumber: 1, Pattern: "*", Owners: []string{"user1@harness.io"}},
+		{LineNumber: 2, Pattern: "/docs/", Owners: []string{"user2@harness.io"}},
+		{LineNumber: 3, Pattern: "/docs/generated/"},
+	}
+
+	tests := []struct {
+		path string
+		want int
+	}{
+		{path: "main.go", want: 0},
+		{path: "docs/readme.md", want: 1},
+		{path: "docs/generated/api.md", want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := findMatchingEntry(entries, tt.path)
+			if err != nil {
+				t.Fatalf("failed with error: %s", err)
+			}
+			if got != tt.want {
+				t.Errorf("findMatchingEntry(%q) = %d, want %d", tt.path, got, tt.want)
+			}
+		})
+	}
+
+	got, err := findMatchingEntry(entries[1:], "main.go")
+	if err != nil {
+		t.Fatalf("failed with error: %s", err)
+	}
+	if got != -1 {
+		t.Errorf("findMatchingEntry() = %d for unowned path, want -1", got)
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commitpolicy
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+const (
+	maxSubjectPatternLength = 1024
+	maxExcludedBranches     = 50
+)
+
+var trailerTokenRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]*$`)
+
+// Sanitize validates and normalizes the commit message policy.
+func Sanitize(policy *types.CommitMessagePolicy) error {
+	policy.RequiredTrailer = strings.TrimSuffix(strings.TrimSpace(policy.RequiredTrailer), ":")
+
+	if len(policy.SubjectPattern) > maxSubjectPatternLength {
+		return usererror.BadRequestf("Subject pattern can't be longer than %d characters.", maxSubjectPatternLength)
+	}
+	if _, err := regexp.Compile(policy.SubjectPattern); err != nil {
+		return usererror.BadRequestf("Invalid subject pattern: %s", err)
+	}
+
+	if policy.MaxSubjectLength < 0 {
+		return usererror.BadRequest("Maximum subject length can't be negative.")
+	}
+
+	if policy.RequiredTrailer != "" && !trailerTokenRegex.MatchString(policy.RequiredTrailer) {
+		return usererror.BadRequestf("Invalid trailer %q.", policy.RequiredTrailer)
+	}
+
+	excluded := make([]string, 0, len(policy.ExcludedBranches))
+	for _, pattern := range policy.ExcludedBranches {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" || slices.Contains(excluded, pattern) {
+			continue
+		}
+		if !doublestar.ValidatePattern(pattern) {
+			return usererror.BadRequestf("Invalid excluded branch pattern %q.", pattern)
+		}
+		excluded = append(excluded, pattern)
+	}
+	if len(excluded) > maxExcludedBranches {
+		return usererror.BadRequestf("Policy can't exclude more than %d branch patterns.", maxExcludedBranches)
+	}
+	policy.ExcludedBranches = excluded
+
+	return nil
+}
+
+// Checker verifies commit messages against a commit message policy.
+type Checker struct {
+	policy  types.CommitMessagePolicy
+	subject *regexp.Regexp
+}
+
+// NewChecker returns a checker for the provided commit message policy.
+func NewChecker(policy types.CommitMessagePolicy) (*Checker, error) {
+	c := &Checker{policy: policy}
+
+	if policy.SubjectPattern != "" {
+		subject, err := regexp.Compile(policy.SubjectPattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile subject pattern: %w", err)
+		}
+		c.subject = subject
+	}
+
+	return c, nil
+}
+
+// Active returns true if the policy places any restriction on commit messages.
+func (c *Checker) Active() bool {
+	return !c.policy.IsEmpty()
+}
+
+// RequiredTrailer returns the token of the trailer required by the policy, or an empty string.
+func (c *Checker) RequiredTrailer() string {
+	return c.policy.RequiredTrailer
+}
+
+// ExcludesBranch returns true if the policy doesn't apply to commits pushed to the branch.
+func (c *Checker) ExcludesBranch(branch string) bool {
+	for _, pattern := range c.policy.ExcludedBranches {
+		if ok, _ := doublestar.Match(pattern, branch); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Check returns the reasons why the commit message doesn't comply with the policy.
+// The parent count of the commit is used to identify merge commits.
+func (c *Checker) Check(message string, parentCount int) []string {
+	if c.policy.SkipMergeCommits && parentCount > 1 {
+		return nil
+	}
+
+	var reasons []string
+
+	subject := Subject(message)
+
+	if c.subject != nil && !c.subject.MatchString(subject) {
+		reasons = append(reasons, fmt.Sprintf("subject doesn't match the pattern %q", c.policy.SubjectPattern))
+	}
+
+	if n := utf8.RuneCountInString(subject); c.policy.MaxSubjectLength > 0 && n > c.policy.MaxSubjectLength {
+		reasons = append(reasons,
+			fmt.Sprintf("subject is %d characters long, the limit is %d", n, c.policy.MaxSubjectLength))
+	}
+
+	if c.policy.RequiredTrailer != "" && !HasTrailer(message, c.policy.RequiredTrailer) {
+		reasons = append(reasons, fmt.Sprintf("the %q trailer is missing", c.policy.RequiredTrailer))
+	}
+
+	return reasons
+}
+
+// Subject returns the first line of the commit message.
+func Subject(message string) string {
+	message = strings.TrimLeft(message, "\r\n")
+	subject, _, _ := strings.Cut(message, "\n")
+	return strings.TrimRight(subject, "\r\t ")
+}
+
+// HasTrailer returns true if the last paragraph of the commit message contains the trailer with a value.
+// The subject of the message is never treated as a trailer and trailer tokens are case-insensitive.
+func HasTrailer(message, token string) bool {
+	paragraphs := paragraphs(message)
+	if len(paragraphs) < 2 {
+		return false
+	}
+
+	for _, line := range strings.Split(paragraphs[len(paragraphs)-1], "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if ok && strings.EqualFold(strings.TrimSpace(key), token) && strings.TrimSpace(value) != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AppendTrailer adds the trailer to the body of a commit message (the message without the subject).
+// The trailer is appended to the existing trailers, if the last paragraph of the body consists of trailers only.
+func AppendTrailer(body, token, value string) string {
+	trailer := token + ": " + value
+
+	body = strings.TrimRight(body, "\r\n\t ")
+	if body == "" {
+		return trailer
+	}
+
+	paragraphs := paragraphs(body)
+	if isTrailerBlock(paragraphs[len(paragraphs)-1]) {
+		return body + "\n" + trailer
+	}
+
+	return body + "\n\n" + trailer
+}
+
+func paragraphs(message string) []string {
+	message = strings.TrimSpace(strings.ReplaceAll(message, "\r\n", "\n"))
+
+	var result []string
+	for _, paragraph := range strings.Split(message, "\n\n") {
+		if paragraph = strings.Trim(paragraph, "\n"); paragraph != "" {
+			result = append(result, paragraph)
+		}
+	}
+
+	return result
+}
+
+func isTrailerBlock(paragraph string) bool {
+	for _, line := range strings.Split(paragraph, "\n") {
+		key, _, ok := strings.Cut(line, ":")
+		if !ok || !trailerTokenRegex.MatchString(key) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,201 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commitpolicy
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestChecker_Check(t *testing.T) {
+	policy := types.CommitMessagePolicy{
+		SubjectPattern:   `^(feat|fix)(\([a-z]+\))?: `,
+		MaxSubjectLength: 20,
+		RequiredTrailer:  "Signed-off-by",
+		SkipMergeCommits: true,
+	}
+
+	tests := []struct {
+		name        string
+		message     string
+		parentCount int
+		want        []string
+	}{
+		{
+			name:        "compliant",
+			message:     "fix: typo\n\nSigned-off-by: A <a@b.c>",
+			parentCount: 1,
+		},
+		{
+			name:        "case-insensitive-trailer",
+			message:     "fix: typo\n\nsigned-off-by: A <a@b.c>",
+			parentCount: 1,
+		},
+		{
+			name:        "merge-skipped",
+			message:     "Merge branch 'main' into feature",
+			parentCount: 2,
+		},
+		{
+			name:        "all-violations",
+			message:     "Update the documentation\n\nSome details.",
+			parentCount: 1,
+			want: []string{
+				`subject doesn't match the pattern "^(feat|fix)(\\([a-z]+\\))?: "`,
+				"subject is 24 characters long, the limit is 20",
+				`the "Signed-off-by" trailer is missing`,
+			},
+		},
+		{
+			name:        "trailer-in-subject",
+			message:     "Signed-off-by: A <a@b.c>",
+			parentCount: 1,
+			want: []string{
+				`subject doesn't match the pattern "^(feat|fix)(\\([a-z]+\\))?: "`,
+				"subject is 24 characters long, the limit is 20",
+				`the "Signed-off-by" trailer is missing`,
+			},
+		},
+		{
+			name:        "trailer-not-in-last-paragraph",
+			message:     "feat: a\n\nSigned-off-by: A <a@b.c>\n\nMore text.",
+			parentCount: 1,
+			want:        []string{`the "Signed-off-by" trailer is missing`},
+		},
+	}
+
+	checker, err := NewChecker(policy)
+	if err != nil {
+		t.Fatalf("failed to create checker: %v", err)
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := checker.Check(test.message, test.parentCount)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("want=%q, got=%q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestChecker_ExcludesBranch(t *testing.T) {
+	checker, err := NewChecker(types.CommitMessagePolicy{ExcludedBranches: []string{"wip/*", "release/**"}})
+	if err != nil {
+		t.Fatalf("failed to create checker: %v", err)
+	}
+
+	tests := map[string]bool{
+		"wip/feature":     true,
+		"wip/a/b":         false,
+		"release/1.0/fix": true,
+		"main":            false,
+	}
+
+	for branch, want := range tests {
+		if got := checker.ExcludesBranch(branch); got != want {
+			t.Errorf("branch %q: want=%t, got=%t", branch, want, got)
+		}
+	}
+}
+
+func TestAppendTrailer(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "empty",
+			body: "",
+			want: "Signed-off-by: A <a@b.c>",
+		},
+		{
+			name: "text",
+			body: "* commit 1\n* commit 2\n",
+			want: "* commit 1\n* commit 2\n\nSigned-off-by: A <a@b.c>",
+		},
+		{
+			name: "trailers",
+			body: "Details.\n\nCo-authored-by: B <b@b.c>",
+			want: "Details.\n\nCo-authored-by: B <b@b.c>\nSigned-off-by: A <a@b.c>",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := AppendTrailer(test.body, "Signed-off-by", "A <a@b.c>"); got != test.want {
+				t.Errorf("want=%q, got=%q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  types.CommitMessagePolicy
+		want    types.CommitMessagePolicy
+		wantErr bool
+	}{
+		{
+			name: "normalized",
+			policy: types.CommitMessagePolicy{
+				SubjectPattern:   "^feat: ",
+				RequiredTrailer:  "Signed-off-by: ",
+				ExcludedBranches: []string{"wip/*", " ", "wip/*"},
+			},
+			want: types.CommitMessagePolicy{
+				SubjectPattern:   "^feat: ",
+				RequiredTrailer:  "Signed-off-by",
+				ExcludedBranches: []string{"wip/*"},
+			},
+		},
+		{
+			name:    "invalid-pattern",
+			policy:  types.CommitMessagePolicy{SubjectPattern: "(feat"},
+			wantErr: true,
+		},
+		{
+			name:    "negative-length",
+			policy:  types.CommitMessagePolicy{MaxSubjectLength: -1},
+			wantErr: true,
+		},
+		{
+			name:    "invalid-trailer",
+			policy:  types.CommitMessagePolicy{RequiredTrailer: "Signed off by"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid-excluded-branch",
+			policy:  types.CommitMessagePolicy{ExcludedBranches: []string{"wip/["}},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := Sanitize(&test.policy)
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Fatalf("want error=%t, got err=%v", test.wantErr, err)
+			}
+			if !test.wantErr && !reflect.DeepEqual(test.policy, test.want) {
+				t.Errorf("want=%+v, got=%+v", test.want, test.policy)
+			}
+		})
+	}
+}
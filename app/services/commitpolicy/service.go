@@ -0,0 +1,158 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commitpolicy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Service manages the commit message policies of repositories and spaces.
+type Service struct {
+	settings   *settings.Service
+	spaceStore store.SpaceStore
+}
+
+func NewService(
+	settings *settings.Service,
+	spaceStore store.SpaceStore,
+) *Service {
+	return &Service{
+		settings:   settings,
+		spaceStore: spaceStore,
+	}
+}
+
+// RepoFind returns the commit message policy in effect for the repository.
+func (s *Service) RepoFind(ctx context.Context, repo *types.Repository) (*types.CommitMessagePolicyOutput, error) {
+	policy, found, err := s.get(ctx, enum.SettingsScopeRepo, repo.ID)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		return &types.CommitMessagePolicyOutput{CommitMessagePolicy: policy}, nil
+	}
+
+	return s.inherited(ctx, repo.ParentID)
+}
+
+// SpaceFind returns the commit message policy in effect for the space.
+func (s *Service) SpaceFind(ctx context.Context, space *types.Space) (*types.CommitMessagePolicyOutput, error) {
+	policy, found, err := s.get(ctx, enum.SettingsScopeSpace, space.ID)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		return &types.CommitMessagePolicyOutput{CommitMessagePolicy: policy}, nil
+	}
+
+	if space.ParentID == 0 {
+		return &types.CommitMessagePolicyOutput{}, nil
+	}
+
+	return s.inherited(ctx, space.ParentID)
+}
+
+// RepoSet sets the commit message policy of the repository.
+// An empty policy removes the policy of the repository, which then inherits the policy of its space.
+func (s *Service) RepoSet(ctx context.Context, repoID int64, policy types.CommitMessagePolicy) error {
+	return s.set(ctx, enum.SettingsScopeRepo, repoID, policy)
+}
+
+// SpaceSet sets the commit message policy of the space.
+// An empty policy removes the policy of the space, which then inherits the policy of its parent.
+func (s *Service) SpaceSet(ctx context.Context, spaceID int64, policy types.CommitMessagePolicy) error {
+	return s.set(ctx, enum.SettingsScopeSpace, spaceID, policy)
+}
+
+// RepoChecker returns the checker of the commit message policy in effect for the repository.
+func (s *Service) RepoChecker(ctx context.Context, repo *types.Repository) (*Checker, error) {
+	out, err := s.RepoFind(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewChecker(out.CommitMessagePolicy)
+}
+
+// inherited returns the policy of the closest space with a policy, starting with the provided space.
+func (s *Service) inherited(ctx context.Context, spaceID int64) (*types.CommitMessagePolicyOutput, error) {
+	ancestors, err := s.spaceStore.GetAncestors(ctx, spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get space ancestors: %w", err)
+	}
+
+	spaces := make(map[int64]*types.Space, len(ancestors))
+	for _, space := range ancestors {
+		spaces[space.ID] = space
+	}
+
+	// walk up the space hierarchy as the order of the ancestors isn't guaranteed.
+	for space := spaces[spaceID]; space != nil; space = spaces[space.ParentID] {
+		var policy types.CommitMessagePolicy
+		var found bool
+		policy, found, err = s.get(ctx, enum.SettingsScopeSpace, space.ID)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			return &types.CommitMessagePolicyOutput{
+				CommitMessagePolicy: policy,
+				InheritedFrom:       space.Path,
+			}, nil
+		}
+	}
+
+	return &types.CommitMessagePolicyOutput{}, nil
+}
+
+func (s *Service) get(
+	ctx context.Context,
+	scope enum.SettingsScope,
+	scopeID int64,
+) (types.CommitMessagePolicy, bool, error) {
+	var policy types.CommitMessagePolicy
+
+	found, err := s.settings.Get(ctx, scope, scopeID, settings.KeyCommitMessagePolicy, &policy)
+	if err != nil {
+		return types.CommitMessagePolicy{}, false, fmt.Errorf("failed to get commit message policy: %w", err)
+	}
+
+	return policy, found && !policy.IsEmpty(), nil
+}
+
+func (s *Service) set(
+	ctx context.Context,
+	scope enum.SettingsScope,
+	scopeID int64,
+	policy types.CommitMessagePolicy,
+) error {
+	var err error
+	if policy.IsEmpty() {
+		err = s.settings.Delete(ctx, scope, scopeID, settings.KeyCommitMessagePolicy)
+	} else {
+		err = s.settings.Set(ctx, scope, scopeID, settings.KeyCommitMessagePolicy, policy)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update commit message policy: %w", err)
+	}
+
+	return nil
+}
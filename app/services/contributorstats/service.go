@@ -0,0 +1,212 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contributorstats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+const (
+	jobType        = "repo-contributor-stats"
+	jobMaxRetries  = 0
+	jobMaxDuration = 15 * time.Minute
+)
+
+var _ job.Handler = (*Service)(nil)
+
+// ErrStatsFailed is returned if the calculation of the contributor stats failed.
+var ErrStatsFailed = errors.New("contributor stats calculation failed")
+
+type jobInput struct {
+	RepoID int64  `json:"repo_id"`
+	SHA    string `json:"sha"`
+	Since  int64  `json:"since,omitempty"`
+	Until  int64  `json:"until,omitempty"`
+}
+
+// Service computes the contributor statistics of repositories in background jobs.
+// The result of a job is kept as the cached stats for the commit and time range,
+// until the job gets purged by the job retention.
+type Service struct {
+	git       git.Interface
+	repoStore store.RepoStore
+	scheduler *job.Scheduler
+}
+
+func jobUID(input jobInput) string {
+	return fmt.Sprintf("repo-contributor-stats-%d-%s-%d-%d", input.RepoID, input.SHA, input.Since, input.Until)
+}
+
+// Get returns the contributor stats of the repository at the provided commit for the provided time range
+// (unix seconds, zero means unbounded). The time range is extended to full weeks.
+// If the stats aren't computed yet, a background job computing them is started and nil is returned.
+func (s *Service) Get(
+	ctx context.Context,
+	repo *types.Repository,
+	commitSHA sha.SHA,
+	since int64,
+	until int64,
+) (*types.RepoContributorStats, error) {
+	input := jobInput{
+		RepoID: repo.ID,
+		SHA:    commitSHA.String(),
+	}
+	if since > 0 {
+		input.Since = weekStart(time.Unix(since, 0)).Unix()
+	}
+	if until > 0 {
+		input.Until = weekStart(time.Unix(until, 0)).AddDate(0, 0, 7).Unix() - 1
+	}
+
+	uid := jobUID(input)
+
+	progress, err := s.scheduler.GetJobProgress(ctx, uid)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, s.run(ctx, uid, input)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contributor stats job progress: %w", err)
+	}
+
+	switch progress.State {
+	case job.JobStateFinished:
+		stats := &types.RepoContributorStats{}
+		if err := json.Unmarshal([]byte(progress.Result), stats); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal contributor stats: %w", err)
+		}
+
+		return stats, nil
+	case job.JobStateFailed, job.JobStateCanceled:
+		// purge the job so that the next request retries the calculation.
+		if err := s.scheduler.PurgeJobByUID(ctx, uid); err != nil {
+			return nil, fmt.Errorf("failed to purge failed contributor stats job: %w", err)
+		}
+
+		return nil, fmt.Errorf("%w: %s", ErrStatsFailed, progress.Failure)
+	case job.JobStateScheduled, job.JobStateRunning:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown contributor stats job state: %s", progress.State)
+	}
+}
+
+// weekStart returns the start of the week (Sunday 00:00 UTC) of the provided time.
+func weekStart(t time.Time) time.Time {
+	t = t.UTC().Truncate(24 * time.Hour)
+	return t.AddDate(0, 0, -int(t.Weekday()))
+}
+
+func (s *Service) run(ctx context.Context, uid string, input jobInput) error {
+	data, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Errorf("failed to marshal job input json: %w", err)
+	}
+
+	err = s.scheduler.RunJob(ctx, job.Definition{
+		UID:        uid,
+		Type:       jobType,
+		MaxRetries: jobMaxRetries,
+		Timeout:    jobMaxDuration,
+		Data:       string(data),
+	})
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		// the job was started by a concurrent request.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to run contributor stats job: %w", err)
+	}
+
+	return nil
+}
+
+// Handle is the contributor stats background job handler.
+func (s *Service) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	var input jobInput
+	if err := json.Unmarshal([]byte(data), &input); err != nil {
+		return "", fmt.Errorf("failed to unmarshal job input json: %w", err)
+	}
+
+	repo, err := s.repoStore.Find(ctx, input.RepoID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	commitSHA, err := sha.New(input.SHA)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse commit sha: %w", err)
+	}
+
+	out, err := s.git.ContributorStats(ctx, &git.ContributorStatsParams{
+		ReadParams: git.CreateReadParams(repo),
+		SHA:        commitSHA,
+		Since:      input.Since,
+		Until:      input.Until,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get contributor stats: %w", err)
+	}
+
+	stats := &types.RepoContributorStats{
+		SHA:          input.SHA,
+		Since:        input.Since,
+		Until:        input.Until,
+		Contributors: make([]types.ContributorStats, len(out.Contributors)),
+	}
+	for i, contributor := range out.Contributors {
+		stats.Contributors[i] = mapContributorStats(contributor)
+	}
+
+	result, err := json.Marshal(stats)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal contributor stats: %w", err)
+	}
+
+	return string(result), nil
+}
+
+func mapContributorStats(stats git.ContributorStats) types.ContributorStats {
+	weeks := make([]types.ContributorWeekStats, len(stats.Weeks))
+	for i, w := range stats.Weeks {
+		weeks[i] = types.ContributorWeekStats{
+			Week:      w.Week,
+			Commits:   w.Commits,
+			Additions: w.Additions,
+			Deletions: w.Deletions,
+		}
+	}
+
+	return types.ContributorStats{
+		Identity: types.Identity{
+			Name:  stats.Identity.Name,
+			Email: stats.Identity.Email,
+		},
+		Commits:   stats.Commits,
+		Additions: stats.Additions,
+		Deletions: stats.Deletions,
+		Weeks:     weeks,
+	}
+}
@@ -0,0 +1,378 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package counters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobType = "counter-reconciler"
+
+	// manualJobUID is the uid of the reconciliation job that is triggered through the API.
+	manualJobUID  = "counter-reconciler-manual"
+	jobMaxRetries = 0
+
+	// columnRepoSize is the pseudo counter column of the repository size, which is corrected using git.
+	columnRepoSize = "repo_size"
+)
+
+var ErrJobRunning = errors.New("a counter reconciliation job is already running")
+
+var (
+	correctionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gitness_counter_reconciler_corrections_total",
+		Help: "Number of denormalized counter values corrected by the counter reconciler.",
+	}, []string{"counter"})
+
+	maxDelta = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gitness_counter_reconciler_max_delta",
+		Help: "Largest absolute drift of a denormalized counter found by the last reconciliation.",
+	}, []string{"counter"})
+)
+
+// Reconciler is a background job that recomputes denormalized counters from their source of truth
+// and corrects the ones that drifted. It processes the rows in batches, pausing between them to limit
+// the load on the database, and stores a checkpoint after each batch so an interrupted run resumes
+// where it stopped.
+type Reconciler struct {
+	enabled       bool
+	cron          string
+	maxDur        time.Duration
+	batchSize     int
+	batchInterval time.Duration
+	git           git.Interface
+	repoStore     store.RepoStore
+	counterStore  store.CounterStore
+	settings      *settings.Service
+	scheduler     *job.Scheduler
+}
+
+type jobInput struct {
+	Counters []enum.CounterType `json:"counters"`
+}
+
+// checkpoint is the progress of the reconciliation of a single counter.
+type checkpoint struct {
+	AfterID int64              `json:"after_id"`
+	Drift   types.CounterDrift `json:"drift"`
+}
+
+func (r *Reconciler) Register(ctx context.Context) error {
+	if !r.enabled {
+		return nil
+	}
+
+	err := r.scheduler.AddRecurring(ctx, jobType, jobType, r.cron, r.maxDur)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for counter reconciler: %w", err)
+	}
+
+	return nil
+}
+
+// Trigger starts the reconciliation of the provided counters, or of all counters if none are provided.
+func (r *Reconciler) Trigger(ctx context.Context, counters []enum.CounterType) error {
+	progress, err := r.scheduler.GetJobProgress(ctx, manualJobUID)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to get counter reconciliation job progress: %w", err)
+	}
+	if err == nil {
+		if !progress.State.IsCompleted() {
+			return ErrJobRunning
+		}
+
+		if err = r.scheduler.PurgeJobByUID(ctx, manualJobUID); err != nil {
+			return fmt.Errorf("failed to purge previous counter reconciliation job: %w", err)
+		}
+	}
+
+	data, err := json.Marshal(jobInput{Counters: counters})
+	if err != nil {
+		return fmt.Errorf("failed to marshal job input json: %w", err)
+	}
+
+	err = r.scheduler.RunJob(ctx, job.Definition{
+		UID:        manualJobUID,
+		Type:       jobType,
+		MaxRetries: jobMaxRetries,
+		Timeout:    r.maxDur,
+		Data:       string(data),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to run counter reconciliation job: %w", err)
+	}
+
+	return nil
+}
+
+// GetProgress returns the progress of the latest reconciliation triggered through Trigger.
+// Once completed, the result contains the drift statistics of each reconciled counter.
+func (r *Reconciler) GetProgress(ctx context.Context) (job.Progress, error) {
+	progress, err := r.scheduler.GetJobProgress(ctx, manualJobUID)
+	if err != nil {
+		return job.Progress{}, fmt.Errorf("failed to get counter reconciliation job progress: %w", err)
+	}
+
+	return progress, nil
+}
+
+// Handle is the counter reconciliation background job handler.
+func (r *Reconciler) Handle(ctx context.Context, data string, reporter job.ProgressReporter) (string, error) {
+	var input jobInput
+	if data != "" {
+		if err := json.Unmarshal([]byte(data), &input); err != nil {
+			return "", fmt.Errorf("failed to unmarshal job input json: %w", err)
+		}
+	}
+
+	counters := input.Counters
+	if len(counters) == 0 {
+		counters, _ = enum.GetAllCounterTypes()
+	}
+
+	drifts := make([]types.CounterDrift, 0, len(counters))
+	for i, counter := range counters {
+		report := func(part int) {
+			// reporting progress is best effort, failure to do so shouldn't abort the reconciliation.
+			_ = reporter((100*i+part)/len(counters), "")
+		}
+
+		drift, err := r.reconcile(ctx, counter, report)
+		if err != nil {
+			return "", fmt.Errorf("failed to reconcile counter %s: %w", counter, err)
+		}
+
+		drifts = append(drifts, drift)
+
+		correctionsTotal.WithLabelValues(string(counter)).Add(float64(drift.Corrected))
+		maxDelta.WithLabelValues(string(counter)).Set(float64(drift.MaxDelta))
+
+		log.Ctx(ctx).Info().
+			Str("counter", string(counter)).
+			Int64("checked", drift.Checked).
+			Int64("corrected", drift.Corrected).
+			Int64("max_delta", drift.MaxDelta).
+			Msg("counter reconciled")
+	}
+
+	result, err := json.Marshal(drifts)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal job result: %w", err)
+	}
+
+	return string(result), nil
+}
+
+// reconcile recomputes all values of the counter, starting at its stored checkpoint.
+func (r *Reconciler) reconcile(
+	ctx context.Context,
+	counter enum.CounterType,
+	report func(part int),
+) (types.CounterDrift, error) {
+	key := checkpointKey(counter)
+
+	cp := checkpoint{Drift: types.CounterDrift{Counter: counter}}
+	found, err := r.settings.SystemGet(ctx, key, &cp)
+	if err != nil {
+		return types.CounterDrift{}, fmt.Errorf("failed to get checkpoint: %w", err)
+	}
+	if found {
+		log.Ctx(ctx).Info().
+			Str("counter", string(counter)).
+			Int64("after_id", cp.AfterID).
+			Msg("resuming counter reconciliation from checkpoint")
+	}
+
+	maxID, err := r.counterStore.MaxRepoID(ctx)
+	if err != nil {
+		return types.CounterDrift{}, fmt.Errorf("failed to get max repo id: %w", err)
+	}
+
+	for cp.AfterID < maxID {
+		var values []types.CounterValue
+		var lastID int64
+
+		values, lastID, err = r.nextBatch(ctx, counter, cp.AfterID)
+		if err != nil {
+			return types.CounterDrift{}, fmt.Errorf("failed to recompute counter values: %w", err)
+		}
+		if lastID <= cp.AfterID {
+			break
+		}
+
+		for _, value := range values {
+			if !track(&cp.Drift, value) {
+				continue
+			}
+
+			if err = r.correct(ctx, value); err != nil {
+				return types.CounterDrift{}, fmt.Errorf("failed to correct counter value: %w", err)
+			}
+
+			log.Ctx(ctx).Debug().
+				Str("counter", string(counter)).
+				Str("column", value.Column).
+				Int64("id", value.ID).
+				Int64("stored", value.Stored).
+				Int64("actual", value.Actual).
+				Msg("counter value corrected")
+		}
+
+		cp.AfterID = lastID
+		if err = r.settings.SystemSet(ctx, key, cp); err != nil {
+			return types.CounterDrift{}, fmt.Errorf("failed to store checkpoint: %w", err)
+		}
+
+		report(int(100 * min(cp.AfterID, maxID) / maxID))
+
+		select {
+		case <-ctx.Done():
+			return types.CounterDrift{}, ctx.Err()
+		case <-time.After(r.batchInterval):
+		}
+	}
+
+	if err = r.settings.SystemDelete(ctx, key); err != nil {
+		return types.CounterDrift{}, fmt.Errorf("failed to delete checkpoint: %w", err)
+	}
+
+	return cp.Drift, nil
+}
+
+// nextBatch returns the stored and recomputed counter values of the next batch of rows
+// and the id of the last row of the batch.
+func (r *Reconciler) nextBatch(
+	ctx context.Context,
+	counter enum.CounterType,
+	afterID int64,
+) ([]types.CounterValue, int64, error) {
+	var values []types.CounterValue
+	var err error
+
+	switch counter {
+	case enum.CounterTypeRepoStars:
+		values, err = r.counterStore.ListRepoStars(ctx, afterID, r.batchSize)
+	case enum.CounterTypeRepoPullReqs:
+		values, err = r.counterStore.ListRepoPullReqs(ctx, afterID, r.batchSize)
+	case enum.CounterTypeRepoPushes:
+		// the same windows are used by the repo stats calculator.
+		now := time.Now()
+		values, err = r.counterStore.ListRepoPushes(ctx, afterID, r.batchSize,
+			daysBefore(now, 7).UnixMilli(), daysBefore(now, 30).UnixMilli())
+	case enum.CounterTypeRepoSize:
+		return r.nextSizeBatch(ctx, afterID)
+	default:
+		return nil, 0, fmt.Errorf("unknown counter type %q", counter)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	lastID := afterID
+	if len(values) > 0 {
+		lastID = values[len(values)-1].ID
+	}
+
+	return values, lastID, nil
+}
+
+// nextSizeBatch returns the stored and the actual repository sizes of the next batch of repositories.
+func (r *Reconciler) nextSizeBatch(
+	ctx context.Context,
+	afterID int64,
+) ([]types.CounterValue, int64, error) {
+	sizeInfos, err := r.counterStore.ListRepoSizes(ctx, afterID, r.batchSize)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	lastID := afterID
+	values := make([]types.CounterValue, 0, len(sizeInfos))
+	for _, sizeInfo := range sizeInfos {
+		lastID = sizeInfo.ID
+
+		sizeOut, errSize := r.git.GetRepositorySize(
+			ctx,
+			&git.GetRepositorySizeParams{ReadParams: git.ReadParams{RepoUID: sizeInfo.GitUID}})
+		if errSize != nil {
+			log.Ctx(ctx).Warn().Err(errSize).
+				Str("repo_git_uid", sizeInfo.GitUID).
+				Int64("repo_id", sizeInfo.ID).
+				Msg("failed to get repo size")
+			continue
+		}
+
+		values = append(values, types.CounterValue{
+			ID:     sizeInfo.ID,
+			Column: columnRepoSize,
+			Stored: sizeInfo.Size,
+			Actual: sizeOut.Size,
+		})
+	}
+
+	return values, lastID, nil
+}
+
+func (r *Reconciler) correct(ctx context.Context, value types.CounterValue) error {
+	if value.Column == columnRepoSize {
+		return r.repoStore.UpdateSize(ctx, value.ID, value.Actual)
+	}
+
+	return r.counterStore.Correct(ctx, value)
+}
+
+// track adds the counter value to the drift statistics and returns whether the value has drifted.
+func track(drift *types.CounterDrift, value types.CounterValue) bool {
+	drift.Checked++
+
+	delta := value.Delta()
+	if delta < 0 {
+		delta = -delta
+	}
+	if delta == 0 {
+		return false
+	}
+
+	drift.Corrected++
+	drift.MaxDelta = max(drift.MaxDelta, delta)
+
+	return true
+}
+
+func checkpointKey(counter enum.CounterType) settings.Key {
+	return settings.Key("counter_reconciler_checkpoint_" + string(counter))
+}
+
+// daysBefore returns the start of the day (UTC) that lies the provided number of days before the provided time.
+func daysBefore(t time.Time, days int) time.Time {
+	return t.UTC().Truncate(24*time.Hour).AddDate(0, 0, -days)
+}
@@ -0,0 +1,45 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package counters
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestTrack(t *testing.T) {
+	drift := types.CounterDrift{Counter: enum.CounterTypeRepoStars}
+
+	values := []types.CounterValue{
+		{ID: 1, Column: "repo_num_stars", Stored: 3, Actual: 3},
+		{ID: 2, Column: "repo_num_stars", Stored: 5, Actual: 2},
+		{ID: 3, Column: "repo_num_stars", Stored: 0, Actual: 1},
+		{ID: 4, Column: "repo_num_stars", Stored: 7, Actual: 7},
+	}
+	expectDrifted := []bool{false, true, true, false}
+
+	for i, value := range values {
+		if got := track(&drift, value); got != expectDrifted[i] {
+			t.Errorf("value %d: expected drifted=%t, got %t", value.ID, expectDrifted[i], got)
+		}
+	}
+
+	want := types.CounterDrift{Counter: enum.CounterTypeRepoStars, Checked: 4, Corrected: 2, MaxDelta: 3}
+	if drift != want {
+		t.Errorf("expected drift %+v, got %+v", want, drift)
+	}
+}
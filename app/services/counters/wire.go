@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package counters
+
+import (
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideReconciler,
+)
+
+func ProvideReconciler(
+	config *types.Config,
+	git git.Interface,
+	repoStore store.RepoStore,
+	counterStore store.CounterStore,
+	settings *settings.Service,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+) (*Reconciler, error) {
+	r := &Reconciler{
+		enabled:       config.CounterReconciler.Enabled,
+		cron:          config.CounterReconciler.CRON,
+		maxDur:        config.CounterReconciler.MaxDuration,
+		batchSize:     config.CounterReconciler.BatchSize,
+		batchInterval: config.CounterReconciler.BatchInterval,
+		git:           git,
+		repoStore:     repoStore,
+		counterStore:  counterStore,
+		settings:      settings,
+		scheduler:     scheduler,
+	}
+
+	err := executor.Register(jobType, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
@@ -0,0 +1,160 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeDigest        = "gitness:digest:send"
+	jobCronDigest        = "0 * * * *" // At minute 0 of every hour.
+	jobMaxDurationDigest = 50 * time.Minute
+
+	dueTolerance = 10 * time.Minute
+)
+
+func (s *Service) Register(ctx context.Context) error {
+	if !s.config.Enabled {
+		return nil
+	}
+
+	err := s.executor.Register(jobTypeDigest, s)
+	if err != nil {
+		return fmt.Errorf("failed to register job handler for activity digests: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeDigest,
+		jobTypeDigest,
+		jobCronDigest,
+		jobMaxDurationDigest,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule activity digest job: %w", err)
+	}
+
+	return nil
+}
+
+// Handle sends the activity digests of all subscriptions whose period has elapsed.
+// Digests with nothing to report aren't sent, but still count as sent for the period.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	now := time.Now()
+
+	ticker := time.NewTicker(max(s.config.SendInterval, time.Millisecond))
+	defer ticker.Stop()
+
+	var sent, skipped int
+	frequencies, _ := enum.GetAllDigestFrequencies()
+	for _, frequency := range frequencies {
+		// the job runs on a fixed schedule, tolerate digests that were sent slightly later in the previous run.
+		sentBefore := now.Add(-frequency.Period()).Add(dueTolerance)
+
+		var afterPrincipalID int64
+		for {
+			subs, err := s.subscriptionStore.ListDue(ctx, frequency, sentBefore.UnixMilli(),
+				afterPrincipalID, s.config.BatchSize)
+			if err != nil {
+				return "", fmt.Errorf("failed to list due %s digest subscriptions: %w", frequency, err)
+			}
+
+			for _, sub := range subs {
+				afterPrincipalID = sub.PrincipalID
+
+				ok, err := s.process(ctx, sub, now, ticker)
+				if err != nil {
+					log.Ctx(ctx).Warn().Err(err).Int64("principal.id", sub.PrincipalID).
+						Msg("failed to send activity digest")
+					continue
+				}
+
+				if ok {
+					sent++
+				} else {
+					skipped++
+				}
+			}
+
+			if len(subs) < s.config.BatchSize {
+				break
+			}
+		}
+	}
+
+	return fmt.Sprintf("sent %d activity digests, skipped %d", sent, skipped), nil
+}
+
+// process builds and sends the digest of a single subscription and marks the period as sent.
+// The digest covers the time since the previous one, or a single period for new subscriptions.
+// It returns false if the digest wasn't sent.
+func (s *Service) process(
+	ctx context.Context,
+	sub *types.DigestSubscription,
+	now time.Time,
+	ticker *time.Ticker,
+) (bool, error) {
+	principal, err := s.principalStore.Find(ctx, sub.PrincipalID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find principal: %w", err)
+	}
+
+	from := now.Add(-sub.Frequency.Period())
+	if sub.LastSent > 0 {
+		from = time.UnixMilli(sub.LastSent)
+	}
+
+	var sent bool
+	if !principal.Blocked && principal.Email != "" {
+		digest, err := s.Generate(ctx, principal, sub, from, now)
+		if err != nil {
+			return false, fmt.Errorf("failed to generate digest: %w", err)
+		}
+
+		if !digest.IsEmpty() {
+			select {
+			case <-ctx.Done():
+				return false, ctx.Err()
+			case <-ticker.C:
+			}
+
+			if err = s.send(ctx, principal, digest, sub.Frequency); err != nil {
+				return false, fmt.Errorf("failed to send digest email: %w", err)
+			}
+
+			sent = true
+		}
+	}
+
+	if err = s.subscriptionStore.UpdateLastSent(ctx, sub.PrincipalID, now.UnixMilli()); err != nil {
+		return sent, fmt.Errorf("failed to update digest last sent time: %w", err)
+	}
+
+	return sent, nil
+}
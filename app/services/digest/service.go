@@ -0,0 +1,465 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/notification"
+	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/git"
+	gitapi "github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// activityPageSize is the number of repository activities loaded at once while building a digest.
+	activityPageSize = 100
+
+	subjectDigest = "Your %s activity digest"
+)
+
+type Config struct {
+	// Enabled is false if the digest emails can't be sent (e.g. SMTP isn't configured).
+	Enabled bool
+	// BatchSize is the number of subscriptions loaded at once by the digest job.
+	BatchSize int
+	// MaxRepos is the maximum number of repositories included in a single digest.
+	MaxRepos int
+	// SendInterval is the minimum time between two digest emails.
+	SendInterval time.Duration
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.BatchSize < 1 {
+		return errors.New("config.BatchSize has to be a positive number")
+	}
+	if c.MaxRepos < 1 {
+		return errors.New("config.MaxRepos has to be a positive number")
+	}
+	if c.SendInterval < 0 {
+		return errors.New("config.SendInterval can't be negative")
+	}
+
+	return nil
+}
+
+// Service builds activity digests out of the repository activity feeds and periodically emails them to subscribers.
+type Service struct {
+	config            Config
+	scheduler         *job.Scheduler
+	executor          *job.Executor
+	subscriptionStore store.DigestSubscriptionStore
+	repoActivityStore store.RepoActivityStore
+	repoStore         store.RepoStore
+	pipelineStore     store.PipelineStore
+	principalStore    store.PrincipalStore
+	pCache            store.PrincipalInfoCache
+	authorizer        authz.Authorizer
+	git               git.Interface
+	urlProvider       url.Provider
+	mailer            mailer.Mailer
+}
+
+func NewService(
+	config Config,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	subscriptionStore store.DigestSubscriptionStore,
+	repoActivityStore store.RepoActivityStore,
+	repoStore store.RepoStore,
+	pipelineStore store.PipelineStore,
+	principalStore store.PrincipalStore,
+	pCache store.PrincipalInfoCache,
+	authorizer authz.Authorizer,
+	git git.Interface,
+	urlProvider url.Provider,
+	mailer mailer.Mailer,
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided digest service config is invalid: %w", err)
+	}
+
+	return &Service{
+		config:            config,
+		scheduler:         scheduler,
+		executor:          executor,
+		subscriptionStore: subscriptionStore,
+		repoActivityStore: repoActivityStore,
+		repoStore:         repoStore,
+		pipelineStore:     pipelineStore,
+		principalStore:    principalStore,
+		pCache:            pCache,
+		authorizer:        authorizer,
+		git:               git,
+		urlProvider:       urlProvider,
+		mailer:            mailer,
+	}, nil
+}
+
+// Generate builds the digest of the subscribed repositories for the provided period.
+// Only repositories the principal can currently view are included.
+func (s *Service) Generate(
+	ctx context.Context,
+	principal *types.Principal,
+	sub *types.DigestSubscription,
+	from, to time.Time,
+) (*types.Digest, error) {
+	repos, err := s.listRepos(ctx, principal, sub)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := &types.Digest{
+		Recipient: principal.ToPrincipalInfo(),
+		From:      from.UnixMilli(),
+		To:        to.UnixMilli(),
+		Repos:     []types.DigestRepo{},
+	}
+
+	for _, repo := range repos {
+		digestRepo, err := s.summarizeRepo(ctx, repo, digest.From, digest.To)
+		if err != nil {
+			return nil, fmt.Errorf("failed to summarize activity of repo %s: %w", repo.Path, err)
+		}
+
+		if digestRepo.IsEmpty() {
+			continue
+		}
+
+		digest.Repos = append(digest.Repos, *digestRepo)
+	}
+
+	return digest, nil
+}
+
+// Render renders the digest as an HTML document.
+func (s *Service) Render(digest *types.Digest, frequency enum.DigestFrequency) ([]byte, error) {
+	return notification.GetHTMLBody(notification.TemplateDigest, newTemplatePayload(digest, frequency))
+}
+
+type templatePayload struct {
+	*types.Digest
+	Frequency string
+	From      string
+	To        string
+}
+
+func newTemplatePayload(digest *types.Digest, frequency enum.DigestFrequency) *templatePayload {
+	return &templatePayload{
+		Digest:    digest,
+		Frequency: string(frequency),
+		From:      time.UnixMilli(digest.From).UTC().Format(time.DateOnly),
+		To:        time.UnixMilli(digest.To).UTC().Format(time.DateOnly),
+	}
+}
+
+// listRepos returns the subscribed repositories and the repositories of the subscribed spaces
+// the principal has access to, up to the configured maximum.
+func (s *Service) listRepos(
+	ctx context.Context,
+	principal *types.Principal,
+	sub *types.DigestSubscription,
+) ([]*types.Repository, error) {
+	session := &auth.Session{Principal: *principal}
+
+	seen := make(map[int64]struct{})
+	repos := make([]*types.Repository, 0)
+
+	add := func(repo *types.Repository) error {
+		if _, ok := seen[repo.ID]; ok || len(repos) >= s.config.MaxRepos {
+			return nil
+		}
+		seen[repo.ID] = struct{}{}
+
+		err := apiauth.CheckRepo(ctx, s.authorizer, session, repo, enum.PermissionRepoView)
+		if errors.Is(err, apiauth.ErrNotAuthorized) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to check access to repo %s: %w", repo.Path, err)
+		}
+
+		repos = append(repos, repo)
+		return nil
+	}
+
+	for _, repoID := range sub.RepoIDs {
+		repo, err := s.repoStore.Find(ctx, repoID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find repo %d: %w", repoID, err)
+		}
+
+		if err = add(repo); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, spaceID := range sub.SpaceIDs {
+		if len(repos) >= s.config.MaxRepos {
+			break
+		}
+
+		spaceRepos, err := s.repoStore.List(ctx, spaceID, &types.RepoFilter{
+			Size:      s.config.MaxRepos,
+			Sort:      enum.RepoAttrIdentifier,
+			Order:     enum.OrderAsc,
+			Recursive: true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list repos of space %d: %w", spaceID, err)
+		}
+
+		for _, repo := range spaceRepos {
+			if err = add(repo); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return repos, nil
+}
+
+// summarizeRepo aggregates the activity feed of the repository in the provided period.
+func (s *Service) summarizeRepo(
+	ctx context.Context,
+	repo *types.Repository,
+	from, to int64,
+) (*types.DigestRepo, error) {
+	digestRepo := &types.DigestRepo{
+		Path:             repo.Path,
+		URL:              s.urlProvider.GenerateUIRepoURL(ctx, repo.Path),
+		PullReqsOpened:   []types.DigestPullReq{},
+		PullReqsMerged:   []types.DigestPullReq{},
+		NewContributors:  []*types.PrincipalInfo{},
+		FailedExecutions: []types.DigestPipelineExecution{},
+	}
+
+	var divergenceRequests []git.CommitDivergenceRequest
+	pipelineIdentifiers := make(map[int64]string)
+	contributors := make(map[int64]struct{})
+	defaultBranchRef := gitapi.BranchPrefix + repo.DefaultBranch
+
+	filter := &types.RepoActivityFilter{
+		Page:  1,
+		Size:  activityPageSize,
+		Since: from,
+		Until: to,
+	}
+
+	for {
+		activities, err := s.repoActivityStore.List(ctx, repo.ID, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list repo activities: %w", err)
+		}
+
+		for _, act := range activities {
+			if act.Type != enum.RepoActivityTypePipelineCompleted && act.Type != enum.RepoActivityTypeDefaultBranch {
+				contributors[act.PrincipalID] = struct{}{}
+			}
+
+			switch act.Type {
+			case enum.RepoActivityTypePush:
+				payload := &types.RepoActivityPushPayload{}
+				if err := json.Unmarshal(act.Payload, payload); err != nil {
+					return nil, fmt.Errorf("failed to unmarshal push activity payload: %w", err)
+				}
+
+				digestRepo.Pushes++
+
+				// deletions and new refs don't bring in any countable commits.
+				if payload.OldSHA != types.NilSHA && payload.NewSHA != types.NilSHA {
+					divergenceRequests = append(divergenceRequests, git.CommitDivergenceRequest{
+						From: payload.NewSHA,
+						To:   payload.OldSHA,
+					})
+				}
+
+			case enum.RepoActivityTypePullReqOpened, enum.RepoActivityTypePullReqMerged:
+				payload := &types.RepoActivityPullReqPayload{}
+				if err := json.Unmarshal(act.Payload, payload); err != nil {
+					return nil, fmt.Errorf("failed to unmarshal pull request activity payload: %w", err)
+				}
+
+				pr := types.DigestPullReq{
+					Number: payload.PullReqNumber,
+					URL:    s.urlProvider.GenerateUIPRURL(ctx, repo.Path, payload.PullReqNumber),
+				}
+
+				if act.Type == enum.RepoActivityTypePullReqOpened {
+					digestRepo.PullReqsOpened = append(digestRepo.PullReqsOpened, pr)
+				} else {
+					digestRepo.PullReqsMerged = append(digestRepo.PullReqsMerged, pr)
+				}
+
+			case enum.RepoActivityTypePipelineCompleted:
+				payload := &types.RepoActivityPipelinePayload{}
+				if err := json.Unmarshal(act.Payload, payload); err != nil {
+					return nil, fmt.Errorf("failed to unmarshal pipeline activity payload: %w", err)
+				}
+
+				if payload.Ref != defaultBranchRef || !payload.Status.IsFailed() {
+					continue
+				}
+
+				identifier, ok := pipelineIdentifiers[payload.PipelineID]
+				if !ok {
+					pipeline, err := s.pipelineStore.Find(ctx, payload.PipelineID)
+					if errors.Is(err, gitness_store.ErrResourceNotFound) {
+						continue
+					}
+					if err != nil {
+						return nil, fmt.Errorf("failed to find pipeline %d: %w", payload.PipelineID, err)
+					}
+
+					identifier = pipeline.Identifier
+					pipelineIdentifiers[payload.PipelineID] = identifier
+				}
+
+				digestRepo.FailedExecutions = append(digestRepo.FailedExecutions, types.DigestPipelineExecution{
+					Pipeline: identifier,
+					Number:   payload.ExecutionNumber,
+					URL:      s.urlProvider.GenerateUIBuildURL(ctx, repo.Path, identifier, payload.ExecutionNumber),
+				})
+			}
+		}
+
+		if len(activities) < activityPageSize {
+			break
+		}
+
+		filter.Page++
+	}
+
+	if digestRepo.IsEmpty() {
+		return digestRepo, nil
+	}
+
+	commits, err := s.countCommits(ctx, repo, divergenceRequests)
+	if err != nil {
+		return nil, err
+	}
+	digestRepo.CommitsPushed = commits
+
+	newContributors, err := s.listNewContributors(ctx, repo.ID, contributors, from)
+	if err != nil {
+		return nil, err
+	}
+	digestRepo.NewContributors = newContributors
+
+	return digestRepo, nil
+}
+
+// countCommits returns the number of commits the pushes brought into the repository.
+func (s *Service) countCommits(
+	ctx context.Context,
+	repo *types.Repository,
+	requests []git.CommitDivergenceRequest,
+) (int, error) {
+	if len(requests) == 0 {
+		return 0, nil
+	}
+
+	out, err := s.git.GetCommitDivergences(ctx, &git.GetCommitDivergencesParams{
+		ReadParams: git.ReadParams{RepoUID: repo.GitUID},
+		Requests:   requests,
+	})
+	if err != nil {
+		// history might have been rewritten since, the count is best effort.
+		log.Ctx(ctx).Warn().Err(err).Str("repo", repo.Path).Msg("failed to count pushed commits for digest")
+		return 0, nil
+	}
+
+	var count int
+	for _, divergence := range out.Divergences {
+		count += int(divergence.Ahead)
+	}
+
+	return count, nil
+}
+
+// listNewContributors returns the contributors without any activity in the repository before the provided time.
+func (s *Service) listNewContributors(
+	ctx context.Context,
+	repoID int64,
+	contributors map[int64]struct{},
+	before int64,
+) ([]*types.PrincipalInfo, error) {
+	ids := make([]int64, 0, len(contributors))
+	for id := range contributors {
+		ids = append(ids, id)
+	}
+
+	activeIDs, err := s.repoActivityStore.ListPrincipalsActiveBefore(ctx, repoID, ids, before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list previously active principals: %w", err)
+	}
+
+	for _, id := range activeIDs {
+		delete(contributors, id)
+	}
+
+	newIDs := make([]int64, 0, len(contributors))
+	for id := range contributors {
+		newIDs = append(newIDs, id)
+	}
+
+	infos, err := s.pCache.Map(ctx, newIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load new contributors: %w", err)
+	}
+
+	result := make([]*types.PrincipalInfo, 0, len(infos))
+	for _, id := range newIDs {
+		if info, ok := infos[id]; ok {
+			result = append(result, info)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *Service) send(ctx context.Context, principal *types.Principal, digest *types.Digest,
+	frequency enum.DigestFrequency) error {
+	email, err := notification.GenerateBody(notification.TemplateDigest, newTemplatePayload(digest, frequency))
+	if err != nil {
+		return fmt.Errorf("failed to render digest: %w", err)
+	}
+
+	email.ToRecipients = []string{principal.Email}
+	email.Subject = fmt.Sprintf(subjectDigest, frequency)
+
+	return s.mailer.Send(ctx, *email)
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	config Config,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	subscriptionStore store.DigestSubscriptionStore,
+	repoActivityStore store.RepoActivityStore,
+	repoStore store.RepoStore,
+	pipelineStore store.PipelineStore,
+	principalStore store.PrincipalStore,
+	pCache store.PrincipalInfoCache,
+	authorizer authz.Authorizer,
+	git git.Interface,
+	urlProvider url.Provider,
+	mailer mailer.Mailer,
+) (*Service, error) {
+	return NewService(
+		config,
+		scheduler,
+		executor,
+		subscriptionStore,
+		repoActivityStore,
+		repoStore,
+		pipelineStore,
+		principalStore,
+		pCache,
+		authorizer,
+		git,
+		urlProvider,
+		mailer,
+	)
+}
@@ -95,7 +95,7 @@ func (r *Repository) RunManyForSpace(
 	repos []*types.Repository,
 	harnessCodeInfo *HarnessCodeInfo,
 ) error {
-	jobGroupID := getJobGroupID(spaceID)
+	jobGroupID := JobGroupIDFromSpaceID(spaceID)
 	jobs, err := r.scheduler.GetJobProgressForGroup(ctx, jobGroupID)
 	if err != nil {
 		return fmt.Errorf("cannot get job progress before starting. %w", err)
@@ -165,7 +165,8 @@ func checkJobAlreadyRunning(jobs []job.Progress) error {
 	return nil
 }
 
-func getJobGroupID(spaceID int64) string {
+// JobGroupIDFromSpaceID returns the group ID of the jobs that export the repositories of the space.
+func JobGroupIDFromSpaceID(spaceID int64) string {
 	return fmt.Sprintf(exportSpaceJobUID, spaceID)
 }
 
@@ -260,7 +261,7 @@ func (r *Repository) getJobInput(data string) (Input, error) {
 }
 
 func (r *Repository) GetProgressForSpace(ctx context.Context, spaceID int64) ([]job.Progress, error) {
-	groupID := getJobGroupID(spaceID)
+	groupID := JobGroupIDFromSpaceID(spaceID)
 	progress, err := r.scheduler.GetJobProgressForGroup(ctx, groupID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get job progress for group: %w", err)
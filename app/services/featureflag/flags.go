@@ -0,0 +1,55 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package featureflag
+
+// Flag is the name of a feature flag.
+type Flag string
+
+const (
+	// FlagSSH allows git operations over ssh. It has no effect if the ssh server is disabled.
+	FlagSSH Flag = "ssh"
+	// FlagSecretScanning allows repositories to scan pushed changes for secrets.
+	FlagSecretScanning Flag = "secret_scanning"
+	// FlagMaintenanceMode blocks all writes via the API and git pushes while fetches continue to work.
+	FlagMaintenanceMode Flag = "maintenance_mode"
+)
+
+// definition describes a feature flag.
+type definition struct {
+	flag        Flag
+	description string
+	// dflt is the state of the flag if it's neither overridden for the instance nor for any space.
+	dflt bool
+}
+
+// definitions contains all feature flags in the order in which they are listed.
+var definitions = []*definition{
+	{
+		flag:        FlagSSH,
+		description: "Allow git operations over ssh (requires the ssh server to be enabled).",
+		dflt:        true,
+	},
+	{
+		flag:        FlagSecretScanning,
+		description: "Allow repositories to scan pushed changes for secrets.",
+		dflt:        true,
+	},
+	{
+		flag: FlagMaintenanceMode,
+		description: "Block all writes and git pushes, e.g. during migrations. Space overrides can only enable " +
+			"maintenance mode for a space, they can't exempt a space from instance-wide maintenance mode.",
+		dflt: false,
+	},
+}
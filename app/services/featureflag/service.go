@@ -0,0 +1,361 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package featureflag
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/pubsub"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// refreshInterval is the interval in which the overrides are reloaded from the database.
+	// It bounds the staleness of the flags in case a change notification gets lost or a space got moved.
+	refreshInterval = 30 * time.Second
+
+	pubsubNamespace    = "featureflag"
+	pubsubTopicChanged = "changed"
+)
+
+// Service provides the state of the feature flags, which are declared in code with a default
+// and can be overridden at runtime for the whole instance or for individual spaces.
+// The overrides are kept in memory, so evaluating a flag is cheap enough for hot paths like the pre-receive hook.
+// Every change is broadcast to all instances, which reload the overrides from the database.
+type Service struct {
+	featureFlagStore        store.FeatureFlagStore
+	spacePathStore          store.SpacePathStore
+	spacePathTransformation store.SpacePathTransformation
+	pubsub                  pubsub.PubSub
+
+	definitions map[Flag]*definition
+
+	state atomic.Pointer[state]
+
+	// reloadMx serializes reloads.
+	reloadMx sync.Mutex
+}
+
+// state is the snapshot of all overrides of known flags.
+type state struct {
+	instance map[Flag]*types.FeatureFlagOverride
+	// spaces contains the space overrides of the flags by the normalized path of the space.
+	spaces map[Flag]map[string]*types.FeatureFlagOverride
+}
+
+func NewService(
+	ctx context.Context,
+	featureFlagStore store.FeatureFlagStore,
+	spacePathStore store.SpacePathStore,
+	spacePathTransformation store.SpacePathTransformation,
+	bus pubsub.PubSub,
+) (*Service, error) {
+	s := &Service{
+		featureFlagStore:        featureFlagStore,
+		spacePathStore:          spacePathStore,
+		spacePathTransformation: spacePathTransformation,
+		pubsub:                  bus,
+		definitions:             make(map[Flag]*definition, len(definitions)),
+	}
+
+	for _, d := range definitions {
+		s.definitions[d.flag] = d
+	}
+
+	if err := s.reload(ctx); err != nil {
+		return nil, fmt.Errorf("failed to load feature flags: %w", err)
+	}
+
+	_ = bus.Subscribe(ctx, pubsubTopicChanged, func([]byte) error {
+		if err := s.reload(ctx); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to reload feature flags after change notification")
+		}
+		return nil
+	}, pubsub.WithChannelNamespace(pubsubNamespace))
+
+	return s, nil
+}
+
+// Run periodically reloads the overrides from the database until the context is canceled.
+func (s *Service) Run(ctx context.Context) error {
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := s.reload(ctx); err != nil {
+				log.Ctx(ctx).Warn().Err(err).Msg("failed to reload feature flags")
+			}
+		}
+	}
+}
+
+// IsEnabled returns the instance-wide state of the flag, ignoring any space overrides.
+func (s *Service) IsEnabled(flag Flag) bool {
+	return s.isEnabled(s.state.Load(), flag)
+}
+
+// IsEnabledForSpacePath returns the state of the flag for the space with the provided path.
+// The override of the closest space along the path wins, otherwise the instance-wide state applies.
+func (s *Service) IsEnabledForSpacePath(flag Flag, spacePath string) bool {
+	st := s.state.Load()
+
+	overrides := st.spaces[flag]
+	if len(overrides) == 0 {
+		return s.isEnabled(st, flag)
+	}
+
+	for path := s.normalizePath(spacePath); path != ""; {
+		if override, ok := overrides[path]; ok {
+			return override.Enabled
+		}
+
+		i := strings.LastIndex(path, types.PathSeparator)
+		if i < 0 {
+			break
+		}
+		path = path[:i]
+	}
+
+	return s.isEnabled(st, flag)
+}
+
+// IsEnabledForSpace returns the state of the flag for the space.
+func (s *Service) IsEnabledForSpace(flag Flag, space *types.Space) bool {
+	return s.IsEnabledForSpacePath(flag, space.Path)
+}
+
+// IsEnabledForRepo returns the state of the flag for the repository, as defined by its parent spaces.
+func (s *Service) IsEnabledForRepo(flag Flag, repo *types.Repository) bool {
+	return s.IsEnabledForRepoPath(flag, repo.Path)
+}
+
+// IsEnabledForRepoPath returns the state of the flag for the repository with the provided path.
+func (s *Service) IsEnabledForRepoPath(flag Flag, repoPath string) bool {
+	spacePath, _, err := paths.DisectLeaf(repoPath)
+	if err != nil {
+		return s.IsEnabled(flag)
+	}
+
+	return s.IsEnabledForSpacePath(flag, spacePath)
+}
+
+// List returns all feature flags with their state.
+func (s *Service) List() []*types.FeatureFlag {
+	st := s.state.Load()
+
+	out := make([]*types.FeatureFlag, len(definitions))
+	for i, d := range definitions {
+		out[i] = s.toFeatureFlag(st, d)
+	}
+
+	return out
+}
+
+// Find returns the feature flag with the provided name.
+func (s *Service) Find(name string) (*types.FeatureFlag, error) {
+	d, err := s.definition(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toFeatureFlag(s.state.Load(), d), nil
+}
+
+// Set overrides the state of the flag for the whole instance (spaceID is nil) or the space.
+func (s *Service) Set(
+	ctx context.Context,
+	name string,
+	spaceID *int64,
+	enabled bool,
+	principalID int64,
+) (*types.FeatureFlag, error) {
+	d, err := s.definition(name)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.featureFlagStore.Upsert(ctx, &types.FeatureFlagOverride{
+		Name:      string(d.flag),
+		SpaceID:   spaceID,
+		Enabled:   enabled,
+		UpdatedBy: principalID,
+		Updated:   time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store feature flag override: %w", err)
+	}
+
+	if err = s.changed(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.toFeatureFlag(s.state.Load(), d), nil
+}
+
+// Reset removes the override of the flag for the whole instance (spaceID is nil) or the space.
+func (s *Service) Reset(ctx context.Context, name string, spaceID *int64) (*types.FeatureFlag, error) {
+	d, err := s.definition(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.featureFlagStore.Delete(ctx, string(d.flag), spaceID); err != nil {
+		return nil, fmt.Errorf("failed to delete feature flag override: %w", err)
+	}
+
+	if err = s.changed(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.toFeatureFlag(s.state.Load(), d), nil
+}
+
+// changed reloads the overrides of this instance and notifies all other instances about the change.
+func (s *Service) changed(ctx context.Context) error {
+	if err := s.reload(ctx); err != nil {
+		return fmt.Errorf("failed to reload feature flags: %w", err)
+	}
+
+	err := s.pubsub.Publish(ctx, pubsubTopicChanged, nil, pubsub.WithPublishNamespace(pubsubNamespace))
+	if err != nil {
+		// other instances pick up the change with the next periodic reload.
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish feature flag change")
+	}
+
+	return nil
+}
+
+// reload loads all overrides from the database.
+// Overrides of flags that aren't declared (anymore) are ignored.
+func (s *Service) reload(ctx context.Context) error {
+	s.reloadMx.Lock()
+	defer s.reloadMx.Unlock()
+
+	overrides, err := s.featureFlagStore.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	st := &state{
+		instance: make(map[Flag]*types.FeatureFlagOverride),
+		spaces:   make(map[Flag]map[string]*types.FeatureFlagOverride),
+	}
+
+	for _, override := range overrides {
+		flag := Flag(override.Name)
+		if _, ok := s.definitions[flag]; !ok {
+			continue
+		}
+
+		if override.SpaceID == nil {
+			st.instance[flag] = override
+			continue
+		}
+
+		spacePath, err := s.spacePathStore.FindPrimaryBySpaceID(ctx, *override.SpaceID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			// the space got deleted in the meantime, which deletes the override as well.
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to find path of space %d: %w", *override.SpaceID, err)
+		}
+
+		override.SpacePath = spacePath.Value
+
+		if st.spaces[flag] == nil {
+			st.spaces[flag] = make(map[string]*types.FeatureFlagOverride)
+		}
+		st.spaces[flag][s.normalizePath(spacePath.Value)] = override
+	}
+
+	s.state.Store(st)
+
+	return nil
+}
+
+func (s *Service) isEnabled(st *state, flag Flag) bool {
+	if override, ok := st.instance[flag]; ok {
+		return override.Enabled
+	}
+
+	if d, ok := s.definitions[flag]; ok {
+		return d.dflt
+	}
+
+	return false
+}
+
+// normalizePath transforms the path the same way as the space path store to make lookups case-insensitive.
+func (s *Service) normalizePath(path string) string {
+	segments := paths.Segments(path)
+	for i, segment := range segments {
+		segments[i] = s.spacePathTransformation(segment, i == 0)
+	}
+
+	return strings.Join(segments, types.PathSeparator)
+}
+
+func (s *Service) definition(name string) (*definition, error) {
+	d, ok := s.definitions[Flag(name)]
+	if !ok {
+		known := make([]string, len(definitions))
+		for i, d := range definitions {
+			known[i] = string(d.flag)
+		}
+
+		return nil, errors.InvalidArgument("Unknown feature flag %q, known feature flags are: %s.",
+			name, strings.Join(known, ", "))
+	}
+
+	return d, nil
+}
+
+func (s *Service) toFeatureFlag(st *state, d *definition) *types.FeatureFlag {
+	_, overridden := st.instance[d.flag]
+
+	spaceOverrides := make([]*types.FeatureFlagOverride, 0, len(st.spaces[d.flag]))
+	for _, override := range st.spaces[d.flag] {
+		spaceOverrides = append(spaceOverrides, override)
+	}
+	slices.SortFunc(spaceOverrides, func(a, b *types.FeatureFlagOverride) int {
+		return strings.Compare(a.SpacePath, b.SpacePath)
+	})
+
+	return &types.FeatureFlag{
+		Name:           string(d.flag),
+		Description:    d.description,
+		Default:        d.dflt,
+		Enabled:        s.isEnabled(st, d.flag),
+		Overridden:     overridden,
+		SpaceOverrides: spaceOverrides,
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package featureflag
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/pubsub"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+var _ store.FeatureFlagStore = (*memStore)(nil)
+
+// memStore is an in-memory feature flag store.
+type memStore struct {
+	overrides []*types.FeatureFlagOverride
+}
+
+func (s *memStore) List(context.Context) ([]*types.FeatureFlagOverride, error) {
+	out := make([]*types.FeatureFlagOverride, len(s.overrides))
+	for i, o := range s.overrides {
+		c := *o
+		out[i] = &c
+	}
+	return out, nil
+}
+
+func (s *memStore) Upsert(ctx context.Context, override *types.FeatureFlagOverride) error {
+	_ = s.Delete(ctx, override.Name, override.SpaceID)
+	s.overrides = append(s.overrides, override)
+	return nil
+}
+
+func (s *memStore) Delete(_ context.Context, name string, spaceID *int64) error {
+	for i, o := range s.overrides {
+		if o.Name == name && ((o.SpaceID == nil && spaceID == nil) ||
+			(o.SpaceID != nil && spaceID != nil && *o.SpaceID == *spaceID)) {
+			s.overrides = append(s.overrides[:i], s.overrides[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+// memSpacePathStore resolves the primary paths of spaces, other methods aren't supported.
+type memSpacePathStore struct {
+	store.SpacePathStore
+	paths map[int64]string
+}
+
+func (s *memSpacePathStore) FindPrimaryBySpaceID(_ context.Context, spaceID int64) (*types.SpacePath, error) {
+	path, ok := s.paths[spaceID]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return &types.SpacePath{Value: path, IsPrimary: true, SpaceID: spaceID}, nil
+}
+
+func newTestService(t *testing.T) *Service {
+	t.Helper()
+
+	s, err := NewService(
+		context.Background(),
+		&memStore{},
+		&memSpacePathStore{paths: map[int64]string{1: "Root", 2: "Root/Sub", 3: "other"}},
+		func(original string, _ bool) string { return strings.ToLower(original) },
+		pubsub.NewInMemory(),
+	)
+	if err != nil {
+		t.Fatalf("failed to create service: %s", err)
+	}
+
+	return s
+}
+
+func TestService_Evaluation(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+
+	if !s.IsEnabledForRepoPath(FlagSecretScanning, "root/sub/repo") {
+		t.Fatalf("expected default to be used without overrides")
+	}
+
+	spaceRoot, spaceSub := int64(1), int64(2)
+	if _, err := s.Set(ctx, string(FlagSecretScanning), &spaceRoot, false, 1); err != nil {
+		t.Fatalf("failed to set flag for space: %s", err)
+	}
+	if _, err := s.Set(ctx, string(FlagSecretScanning), &spaceSub, true, 1); err != nil {
+		t.Fatalf("failed to set flag for space: %s", err)
+	}
+	if _, err := s.Set(ctx, string(FlagSecretScanning), nil, false, 1); err != nil {
+		t.Fatalf("failed to set flag for instance: %s", err)
+	}
+
+	tests := []struct {
+		repoPath string
+		want     bool
+	}{
+		// the closest space override wins, independent of the case of the path.
+		{repoPath: "ROOT/sub/repo", want: true},
+		{repoPath: "root/sub/nested/repo", want: true},
+		{repoPath: "root/repo", want: false},
+		{repoPath: "root/subspace/repo", want: false},
+		// spaces without override use the instance-wide state.
+		{repoPath: "other/repo", want: false},
+	}
+	for _, test := range tests {
+		if got := s.IsEnabledForRepoPath(FlagSecretScanning, test.repoPath); got != test.want {
+			t.Errorf("repo %q: got %t, want %t", test.repoPath, got, test.want)
+		}
+	}
+
+	flag, err := s.Reset(ctx, string(FlagSecretScanning), &spaceSub)
+	if err != nil {
+		t.Fatalf("failed to reset flag for space: %s", err)
+	}
+	if !flag.Overridden || flag.Enabled || len(flag.SpaceOverrides) != 1 || flag.SpaceOverrides[0].SpacePath != "Root" {
+		t.Errorf("unexpected flag state after reset: %+v", flag)
+	}
+	if s.IsEnabledForRepoPath(FlagSecretScanning, "root/sub/repo") {
+		t.Errorf("expected override of parent space to apply after reset")
+	}
+
+	// other flags aren't affected.
+	if !s.IsEnabledForRepoPath(FlagSSH, "root/repo") {
+		t.Errorf("expected flag without overrides to use its default")
+	}
+}
+
+func TestService_UnknownFlag(t *testing.T) {
+	s := newTestService(t)
+
+	_, err := s.Set(context.Background(), "unknown", nil, true, 1)
+
+	if !errors.IsInvalidArgument(err) {
+		t.Fatalf("expected invalid argument error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), string(FlagSSH)) || !strings.Contains(err.Error(), string(FlagSecretScanning)) {
+		t.Errorf("expected error to list the known flags, got %q", err.Error())
+	}
+}
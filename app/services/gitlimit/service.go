@@ -0,0 +1,417 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitlimit
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/instancesettings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/pubsub"
+	"github.com/harness/gitness/types"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// refreshInterval is the interval in which the limits are reloaded from the database.
+	// It bounds the staleness of the limits in case a change notification gets lost.
+	refreshInterval = 30 * time.Second
+
+	pubsubNamespace    = "gitlimit"
+	pubsubTopicChanged = "changed"
+
+	// metricLabelAnonymous is the principal label of the operations of anonymous clients.
+	// They are limited per client IP, but aggregated in the metrics to bound the cardinality.
+	metricLabelAnonymous = "anonymous"
+)
+
+// ErrTooManyOperations is returned if a git operation couldn't be started within the configured wait duration,
+// because the principal (or the client IP for anonymous access) reached its limit of concurrent git operations.
+var ErrTooManyOperations = errors.New("too many concurrent git operations, please retry later")
+
+var (
+	inFlightGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gitness_git_operations_in_flight",
+		Help: "Number of git operations (clone, fetch, push) currently executed per principal.",
+	}, []string{"principal"})
+
+	rejectedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gitness_git_operations_rejected_total",
+		Help: "Number of git operations rejected because the principal exceeded its concurrency limit.",
+	}, []string{"principal"})
+)
+
+// Service limits the number of concurrent git operations (upload-pack and receive-pack) per principal,
+// or per client IP for anonymous access, to prevent a single user from saturating the server.
+// The limit is an instance setting that can be overridden for individual principals.
+// The overrides are kept in memory and every change is broadcast to all instances, which reload them.
+// NOTE: The in-flight operations are tracked per instance, so the limit applies to each instance separately.
+type Service struct {
+	limitStore         store.GitOperationLimitStore
+	principalInfoCache store.PrincipalInfoCache
+	instanceSettings   *instancesettings.Service
+	pubsub             pubsub.PubSub
+
+	// limits contains the limit overrides by principal ID.
+	limits atomic.Pointer[map[int64]*types.GitOperationLimit]
+
+	// reloadMx serializes reloads.
+	reloadMx sync.Mutex
+
+	mx    sync.Mutex
+	slots map[slotKey]*slot
+	// metricInFlight contains the number of in-flight operations by metric label.
+	metricInFlight map[string]int
+}
+
+// slotKey identifies the principal or, for anonymous access, the client IP the operations are limited for.
+// Deploy keys share the deploy key service principal, so their operations are limited per deploy key.
+type slotKey struct {
+	principalID int64
+	deployKeyID int64
+	clientIP    string
+}
+
+// slot tracks the git operations of a principal or client IP.
+type slot struct {
+	label    string
+	inFlight int
+	waiting  int
+	// released is closed and replaced whenever an operation completes, to wake up the waiting operations.
+	released chan struct{}
+}
+
+func NewService(
+	ctx context.Context,
+	limitStore store.GitOperationLimitStore,
+	principalInfoCache store.PrincipalInfoCache,
+	instanceSettings *instancesettings.Service,
+	bus pubsub.PubSub,
+) (*Service, error) {
+	s := &Service{
+		limitStore:         limitStore,
+		principalInfoCache: principalInfoCache,
+		instanceSettings:   instanceSettings,
+		pubsub:             bus,
+		slots:              make(map[slotKey]*slot),
+		metricInFlight:     make(map[string]int),
+	}
+
+	if err := s.reload(ctx); err != nil {
+		return nil, fmt.Errorf("failed to load git operation limits: %w", err)
+	}
+
+	_ = bus.Subscribe(ctx, pubsubTopicChanged, func([]byte) error {
+		if err := s.reload(ctx); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to reload git operation limits after change notification")
+		}
+		return nil
+	}, pubsub.WithChannelNamespace(pubsubNamespace))
+
+	return s, nil
+}
+
+// Run periodically reloads the limits from the database until the context is canceled.
+func (s *Service) Run(ctx context.Context) error {
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := s.reload(ctx); err != nil {
+				log.Ctx(ctx).Warn().Err(err).Msg("failed to reload git operation limits")
+			}
+		}
+	}
+}
+
+// Acquire reserves a git operation for the principal of the session, or for the client IP in case of
+// anonymous sessions. If the limit is reached, it waits for another operation to complete for at most
+// the configured duration before it fails with ErrTooManyOperations.
+// The returned function has to be called once the operation completed.
+func (s *Service) Acquire(ctx context.Context, session *auth.Session, clientIP string) (func(), error) {
+	key, label := slotKey{principalID: session.Principal.ID}, session.Principal.UID
+	if auth.IsAnonymousSession(session) {
+		key, label = slotKey{clientIP: clientIP}, metricLabelAnonymous
+	}
+	if deployKeyMetadata, ok := session.Metadata.(*auth.DeployKeyMetadata); ok {
+		key.deployKeyID = deployKeyMetadata.DeployKeyID
+	}
+
+	limit := s.limit(key.principalID)
+
+	s.mx.Lock()
+
+	sl, ok := s.slots[key]
+	if !ok {
+		sl = &slot{label: label, released: make(chan struct{})}
+		s.slots[key] = sl
+	}
+
+	// the wait duration applies to the operation as a whole, not to every wake-up.
+	var timer *time.Timer
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+
+	for limit > 0 && sl.inFlight >= limit {
+		if timer == nil {
+			timer = time.NewTimer(s.instanceSettings.GitConcurrentOperationsWait())
+		}
+
+		released := sl.released
+		sl.waiting++
+		s.mx.Unlock()
+
+		var err error
+		select {
+		case <-released:
+		case <-timer.C:
+			err = ErrTooManyOperations
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+
+		s.mx.Lock()
+		sl.waiting--
+
+		if err != nil {
+			s.removeIfIdle(key, sl)
+			s.mx.Unlock()
+
+			if errors.Is(err, ErrTooManyOperations) {
+				rejectedTotal.WithLabelValues(label).Inc()
+			}
+
+			return nil, err
+		}
+	}
+
+	sl.inFlight++
+	s.updateMetric(label, 1)
+
+	s.mx.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { s.release(key) })
+	}, nil
+}
+
+// InFlight returns the principals and anonymous client IPs with in-flight or waiting git operations,
+// ordered by the number of in-flight operations.
+func (s *Service) InFlight(ctx context.Context) ([]*types.GitOperationsInFlight, error) {
+	s.mx.Lock()
+	out := make([]*types.GitOperationsInFlight, 0, len(s.slots))
+	keys := make([]slotKey, 0, len(s.slots))
+	principalIDs := make([]int64, 0, len(s.slots))
+	for key, sl := range s.slots {
+		out = append(out, &types.GitOperationsInFlight{
+			ClientIP: key.clientIP,
+			InFlight: sl.inFlight,
+			Waiting:  sl.waiting,
+			Limit:    s.limit(key.principalID),
+		})
+		keys = append(keys, key)
+		if key.clientIP == "" {
+			principalIDs = append(principalIDs, key.principalID)
+		}
+	}
+	s.mx.Unlock()
+
+	principals, err := s.principalInfoCache.Map(ctx, principalIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find principals: %w", err)
+	}
+
+	for i, key := range keys {
+		if key.clientIP == "" {
+			out[i].Principal = principals[key.principalID]
+		}
+	}
+
+	slices.SortFunc(out, func(a, b *types.GitOperationsInFlight) int {
+		if a.InFlight != b.InFlight {
+			return b.InFlight - a.InFlight
+		}
+		return b.Waiting - a.Waiting
+	})
+
+	return out, nil
+}
+
+// ListLimits returns the limit overrides of all principals.
+func (s *Service) ListLimits(ctx context.Context) ([]*types.GitOperationLimit, error) {
+	limits := *s.limits.Load()
+
+	out := make([]*types.GitOperationLimit, 0, len(limits))
+	principalIDs := make([]int64, 0, len(limits))
+	for _, limit := range limits {
+		c := *limit
+		out = append(out, &c)
+		principalIDs = append(principalIDs, limit.PrincipalID)
+	}
+
+	principals, err := s.principalInfoCache.Map(ctx, principalIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find principals: %w", err)
+	}
+
+	for _, limit := range out {
+		limit.Principal = principals[limit.PrincipalID]
+	}
+
+	slices.SortFunc(out, func(a, b *types.GitOperationLimit) int {
+		return cmp.Compare(a.PrincipalID, b.PrincipalID)
+	})
+
+	return out, nil
+}
+
+// SetLimit overrides the maximum number of concurrent git operations of the principal, zero means unlimited.
+// Operations that are already in-flight aren't affected by a lower limit.
+func (s *Service) SetLimit(
+	ctx context.Context,
+	principalID int64,
+	maxConcurrent int,
+	updatedBy int64,
+) (*types.GitOperationLimit, error) {
+	if maxConcurrent < 0 {
+		return nil, errors.InvalidArgument("The maximum number of concurrent git operations can't be negative.")
+	}
+
+	limit := &types.GitOperationLimit{
+		PrincipalID:   principalID,
+		MaxConcurrent: maxConcurrent,
+		UpdatedBy:     updatedBy,
+		Updated:       time.Now().UnixMilli(),
+	}
+
+	if err := s.limitStore.Upsert(ctx, limit); err != nil {
+		return nil, fmt.Errorf("failed to store git operation limit: %w", err)
+	}
+
+	if err := s.changed(ctx); err != nil {
+		return nil, err
+	}
+
+	return limit, nil
+}
+
+// ResetLimit removes the limit override of the principal, which restores the instance-wide limit.
+func (s *Service) ResetLimit(ctx context.Context, principalID int64) error {
+	if err := s.limitStore.Delete(ctx, principalID); err != nil {
+		return fmt.Errorf("failed to delete git operation limit: %w", err)
+	}
+
+	return s.changed(ctx)
+}
+
+func (s *Service) release(key slotKey) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	sl := s.slots[key]
+	sl.inFlight--
+	s.updateMetric(sl.label, -1)
+
+	close(sl.released)
+	sl.released = make(chan struct{})
+
+	s.removeIfIdle(key, sl)
+}
+
+// removeIfIdle removes the slot once it has neither in-flight nor waiting operations.
+// It has to be called with the mutex held.
+func (s *Service) removeIfIdle(key slotKey, sl *slot) {
+	if sl.inFlight <= 0 && sl.waiting <= 0 {
+		delete(s.slots, key)
+	}
+}
+
+// updateMetric updates the in-flight gauge of the label, which is removed once it drops to zero.
+// It has to be called with the mutex held.
+func (s *Service) updateMetric(label string, delta int) {
+	s.metricInFlight[label] += delta
+
+	n := s.metricInFlight[label]
+	if n <= 0 {
+		delete(s.metricInFlight, label)
+		inFlightGauge.DeleteLabelValues(label)
+		return
+	}
+
+	inFlightGauge.WithLabelValues(label).Set(float64(n))
+}
+
+// limit returns the maximum number of concurrent git operations of the principal (zero for anonymous clients).
+func (s *Service) limit(principalID int64) int {
+	if limit, ok := (*s.limits.Load())[principalID]; ok {
+		return limit.MaxConcurrent
+	}
+
+	return s.instanceSettings.GitMaxConcurrentOperations()
+}
+
+// changed reloads the limits of this instance and notifies all other instances about the change.
+func (s *Service) changed(ctx context.Context) error {
+	if err := s.reload(ctx); err != nil {
+		return fmt.Errorf("failed to reload git operation limits: %w", err)
+	}
+
+	err := s.pubsub.Publish(ctx, pubsubTopicChanged, nil, pubsub.WithPublishNamespace(pubsubNamespace))
+	if err != nil {
+		// other instances pick up the change with the next periodic reload.
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish git operation limit change")
+	}
+
+	return nil
+}
+
+// reload loads all limit overrides from the database.
+func (s *Service) reload(ctx context.Context) error {
+	s.reloadMx.Lock()
+	defer s.reloadMx.Unlock()
+
+	limits, err := s.limitStore.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	m := make(map[int64]*types.GitOperationLimit, len(limits))
+	for _, limit := range limits {
+		m[limit.PrincipalID] = limit
+	}
+
+	s.limits.Store(&m)
+
+	return nil
+}
@@ -0,0 +1,215 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitlimit
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/instancesettings"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/pubsub"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// memLimitStore is an in-memory git operation limit store.
+type memLimitStore struct {
+	mx     sync.Mutex
+	limits map[int64]*types.GitOperationLimit
+}
+
+func (s *memLimitStore) List(context.Context) ([]*types.GitOperationLimit, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	out := make([]*types.GitOperationLimit, 0, len(s.limits))
+	for _, limit := range s.limits {
+		c := *limit
+		out = append(out, &c)
+	}
+	return out, nil
+}
+
+func (s *memLimitStore) Upsert(_ context.Context, limit *types.GitOperationLimit) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.limits[limit.PrincipalID] = limit
+	return nil
+}
+
+func (s *memLimitStore) Delete(_ context.Context, principalID int64) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	delete(s.limits, principalID)
+	return nil
+}
+
+// emptySettingsStore is a settings store without any stored settings, other methods aren't supported.
+type emptySettingsStore struct {
+	store.SettingsStore
+}
+
+func (emptySettingsStore) FindMany(
+	context.Context,
+	enum.SettingsScope,
+	int64,
+	...string,
+) (map[string]json.RawMessage, error) {
+	return map[string]json.RawMessage{}, nil
+}
+
+// memPrincipalInfoCache resolves principal infos by ID, other methods aren't supported.
+type memPrincipalInfoCache struct {
+	store.PrincipalInfoCache
+}
+
+func (memPrincipalInfoCache) Map(_ context.Context, ids []int64) (map[int64]*types.PrincipalInfo, error) {
+	out := make(map[int64]*types.PrincipalInfo, len(ids))
+	for _, id := range ids {
+		out[id] = &types.PrincipalInfo{ID: id}
+	}
+	return out, nil
+}
+
+func newTestService(t *testing.T) *Service {
+	t.Helper()
+
+	ctx := context.Background()
+
+	config := &types.Config{}
+	config.Git.MaxConcurrentOperations = 2
+	config.Git.ConcurrentOperationsWait = 50 * time.Millisecond
+
+	instanceSettings, err := instancesettings.NewService(ctx, config,
+		settings.NewService(emptySettingsStore{}), pubsub.NewInMemory())
+	if err != nil {
+		t.Fatalf("failed to create instance settings: %s", err)
+	}
+
+	s, err := NewService(ctx, &memLimitStore{limits: map[int64]*types.GitOperationLimit{}},
+		memPrincipalInfoCache{}, instanceSettings, pubsub.NewInMemory())
+	if err != nil {
+		t.Fatalf("failed to create service: %s", err)
+	}
+
+	return s
+}
+
+func userSession(id int64) *auth.Session {
+	return &auth.Session{Principal: types.Principal{ID: id, UID: "user"}}
+}
+
+func TestService_Acquire(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+
+	release1, err := s.Acquire(ctx, userSession(1), "")
+	if err != nil {
+		t.Fatalf("failed to acquire first operation: %s", err)
+	}
+	if _, err = s.Acquire(ctx, userSession(1), ""); err != nil {
+		t.Fatalf("failed to acquire second operation: %s", err)
+	}
+
+	// other principals and anonymous clients aren't affected.
+	if _, err = s.Acquire(ctx, userSession(2), ""); err != nil {
+		t.Fatalf("failed to acquire operation of other principal: %s", err)
+	}
+	if _, err = s.Acquire(ctx, &auth.Session{Principal: auth.AnonymousPrincipal}, "10.0.0.1"); err != nil {
+		t.Fatalf("failed to acquire anonymous operation: %s", err)
+	}
+
+	if _, err = s.Acquire(ctx, userSession(1), ""); !errors.Is(err, ErrTooManyOperations) {
+		t.Fatalf("expected operation over the limit to be rejected, got: %v", err)
+	}
+
+	// waiting operations continue once another operation completes.
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		release1()
+		release1() // releasing twice has no effect.
+	}()
+	if _, err = s.Acquire(ctx, userSession(1), ""); err != nil {
+		t.Fatalf("expected waiting operation to continue after release, got: %s", err)
+	}
+	if _, err = s.Acquire(ctx, userSession(1), ""); !errors.Is(err, ErrTooManyOperations) {
+		t.Fatalf("expected operation over the limit to be rejected, got: %v", err)
+	}
+
+	inFlight, err := s.InFlight(ctx)
+	if err != nil {
+		t.Fatalf("failed to list in-flight operations: %s", err)
+	}
+	if len(inFlight) != 3 {
+		t.Fatalf("got %d in-flight entries, want 3", len(inFlight))
+	}
+	if inFlight[0].Principal == nil || inFlight[0].Principal.ID != 1 || inFlight[0].InFlight != 2 ||
+		inFlight[0].Waiting != 0 || inFlight[0].Limit != 2 {
+		t.Errorf("unexpected in-flight operations of principal: %+v", inFlight[0])
+	}
+}
+
+func TestService_SetLimit(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+
+	if _, err := s.SetLimit(ctx, 1, 1, 1); err != nil {
+		t.Fatalf("failed to set limit: %s", err)
+	}
+	if _, err := s.SetLimit(ctx, 2, 0, 1); err != nil {
+		t.Fatalf("failed to set limit: %s", err)
+	}
+	if _, err := s.SetLimit(ctx, 3, -1, 1); errors.AsStatus(err) != errors.StatusInvalidArgument {
+		t.Fatalf("expected negative limit to be rejected, got: %v", err)
+	}
+
+	if _, err := s.Acquire(ctx, userSession(1), ""); err != nil {
+		t.Fatalf("failed to acquire operation: %s", err)
+	}
+	if _, err := s.Acquire(ctx, userSession(1), ""); !errors.Is(err, ErrTooManyOperations) {
+		t.Fatalf("expected lower limit of principal to apply, got: %v", err)
+	}
+
+	// a limit of zero disables the limit for the principal.
+	for range 5 {
+		if _, err := s.Acquire(ctx, userSession(2), ""); err != nil {
+			t.Fatalf("expected unlimited operations, got: %s", err)
+		}
+	}
+
+	limits, err := s.ListLimits(ctx)
+	if err != nil {
+		t.Fatalf("failed to list limits: %s", err)
+	}
+	if len(limits) != 2 || limits[0].PrincipalID != 1 || limits[0].Principal == nil {
+		t.Errorf("unexpected limits: %+v", limits)
+	}
+
+	if err = s.ResetLimit(ctx, 1); err != nil {
+		t.Fatalf("failed to reset limit: %s", err)
+	}
+	if _, err = s.Acquire(ctx, userSession(1), ""); err != nil {
+		t.Fatalf("expected instance-wide limit to apply after reset, got: %s", err)
+	}
+}
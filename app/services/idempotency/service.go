@@ -0,0 +1,216 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+const (
+	// MaxKeyLength is the maximum length of an idempotency key.
+	MaxKeyLength = 255
+
+	// MaxResponseSize is the maximum size of a response body that is stored with an idempotency key.
+	// Requests with bigger responses can't be replayed and are executed again.
+	MaxResponseSize = 1 << 20 // 1 MiB
+
+	pollInterval = 100 * time.Millisecond
+)
+
+var (
+	// ErrKeyReused is returned if an idempotency key is reused for a different request.
+	ErrKeyReused = usererror.UnprocessableEntityf(
+		"The idempotency key was already used for a different request.")
+
+	// ErrInProgress is returned if the request of an idempotency key didn't complete in time.
+	ErrInProgress = usererror.Conflict(
+		"A request with the same idempotency key is still in progress.")
+)
+
+type Config struct {
+	// TTL is the duration for which the response of a completed request is stored with its key.
+	TTL time.Duration
+	// LockTimeout is the duration for which a key stays reserved by a request that didn't complete,
+	// e.g. because the server stopped while processing it.
+	LockTimeout time.Duration
+	// WaitTimeout is the maximum time a request waits for a concurrent request with the same key to complete.
+	WaitTimeout time.Duration
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.TTL <= 0 {
+		return errors.New("config.TTL has to be a positive duration")
+	}
+	if c.LockTimeout <= 0 {
+		return errors.New("config.LockTimeout has to be a positive duration")
+	}
+	if c.WaitTimeout < 0 {
+		return errors.New("config.WaitTimeout can't be negative")
+	}
+
+	return nil
+}
+
+// Service stores idempotency keys together with the fingerprint of their request and the produced response,
+// so that retried requests return the original response instead of being executed again.
+// Stored responses are encrypted, as they can contain secrets, e.g. newly created access tokens.
+type Service struct {
+	config    Config
+	keyStore  store.IdempotencyKeyStore
+	encrypter encrypt.Encrypter
+}
+
+func NewService(
+	config Config,
+	keyStore store.IdempotencyKeyStore,
+	encrypter encrypt.Encrypter,
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided idempotency config is invalid: %w", err)
+	}
+
+	return &Service{
+		config:    config,
+		keyStore:  keyStore,
+		encrypter: encrypter,
+	}, nil
+}
+
+// Fingerprint returns the fingerprint of a request, which is compared when a key is reused.
+func Fingerprint(method, uri string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{0})
+	h.Write([]byte(uri))
+	h.Write([]byte{0})
+	h.Write(body)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Acquire reserves the key of the principal for the request with the provided fingerprint.
+// If the key is reserved successfully, true is returned and the request has to be executed.
+// Otherwise, the key was used before and the completed key with the stored response is returned.
+// In case a concurrent request with the same key is still in progress, Acquire waits for it to complete.
+func (s *Service) Acquire(
+	ctx context.Context,
+	principalID int64,
+	key string,
+	fingerprint string,
+) (*types.IdempotencyKey, bool, error) {
+	deadline := time.Now().Add(s.config.WaitTimeout)
+
+	for {
+		now := time.Now()
+		record := &types.IdempotencyKey{
+			PrincipalID: principalID,
+			Key:         key,
+			Fingerprint: fingerprint,
+			Created:     now.UnixMilli(),
+			Expires:     now.Add(s.config.LockTimeout).UnixMilli(),
+		}
+
+		err := s.keyStore.Create(ctx, record, now.UnixMilli())
+		if err == nil {
+			return record, true, nil
+		}
+		if !errors.Is(err, gitness_store.ErrDuplicate) {
+			return nil, false, fmt.Errorf("failed to create idempotency key: %w", err)
+		}
+
+		record, err = s.keyStore.Find(ctx, principalID, key)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			// the request holding the key failed and released it - try again.
+			continue
+		}
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to find idempotency key: %w", err)
+		}
+
+		if record.Fingerprint != fingerprint {
+			return nil, false, ErrKeyReused
+		}
+
+		if record.IsCompleted() {
+			body, err := s.encrypter.Decrypt(record.ResponseBody)
+			if err != nil {
+				return nil, false, fmt.Errorf("failed to decrypt response stored with idempotency key: %w", err)
+			}
+			record.ResponseBody = []byte(body)
+
+			return record, false, nil
+		}
+
+		if time.Now().After(deadline) {
+			return nil, false, ErrInProgress
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// Complete stores the response of the request with the key, which is then kept for the configured TTL.
+func (s *Service) Complete(
+	ctx context.Context,
+	record *types.IdempotencyKey,
+	status int,
+	contentType string,
+	body []byte,
+) error {
+	encryptedBody, err := s.encrypter.Encrypt(string(body))
+	if err != nil {
+		return fmt.Errorf("failed to encrypt response of idempotency key: %w", err)
+	}
+
+	completed := *record
+	completed.ResponseStatus = status
+	completed.ResponseContentType = contentType
+	completed.ResponseBody = encryptedBody
+	completed.Expires = time.Now().Add(s.config.TTL).UnixMilli()
+
+	if err = s.keyStore.Complete(ctx, &completed); err != nil {
+		return fmt.Errorf("failed to store response with idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+// Release releases the key of a request that didn't produce a response that can be replayed,
+// so that the request can be retried with the same key.
+func (s *Service) Release(ctx context.Context, record *types.IdempotencyKey) error {
+	if err := s.keyStore.DeletePending(ctx, record.PrincipalID, record.Key); err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+
+	return nil
+}
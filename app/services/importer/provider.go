@@ -102,6 +102,7 @@ func (r *RepositoryInfo) ToRepo(
 		ForkID:        0,
 		DefaultBranch: r.DefaultBranch,
 		State:         enum.RepoStateGitImport,
+		ShowReadme:    true,
 		Path:          paths.Concatenate(spacePath, identifier),
 	}, r.IsPublic
 }
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"os"
 	"strings"
 	"time"
 
@@ -29,6 +30,8 @@ import (
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/services/repostate"
+	"github.com/harness/gitness/app/services/spacearchive"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	gitnessurl "github.com/harness/gitness/app/url"
@@ -68,6 +71,8 @@ type Repository struct {
 	indexer       keywordsearch.Indexer
 	publicAccess  publicaccess.Service
 	auditService  audit.Service
+	repoStateSvc  *repostate.Service
+	archive       *spacearchive.Service
 }
 
 var _ job.Handler = (*Repository)(nil)
@@ -91,6 +96,21 @@ type Input struct {
 	GitPass   string         `json:"git_pass"`
 	CloneURL  string         `json:"clone_url"`
 	Pipelines PipelineOption `json:"pipelines"`
+
+	// ArchivePath is set if the repository is imported from a space archive instead of a clone URL.
+	ArchivePath string `json:"archive_path,omitempty"`
+	// ArchiveBundle is the name of the git bundle inside the archive (empty for repos without git data).
+	ArchiveBundle string `json:"archive_bundle,omitempty"`
+	// DefaultBranch overrides the default branch of the imported repository.
+	DefaultBranch string `json:"default_branch,omitempty"`
+}
+
+// ArchiveSource describes a repository that is imported from a space archive.
+type ArchiveSource struct {
+	RepoID        int64
+	Public        bool
+	Bundle        string
+	DefaultBranch string
 }
 
 const jobType = "repository_import"
@@ -167,6 +187,38 @@ func (r *Repository) RunMany(ctx context.Context,
 	return nil
 }
 
+// RunManyFromArchive starts background jobs that import the provided repositories from a space archive.
+func (r *Repository) RunManyFromArchive(ctx context.Context,
+	groupID string,
+	archivePath string,
+	sources []ArchiveSource,
+) error {
+	defs := make([]job.Definition, len(sources))
+
+	for k, source := range sources {
+		jobDef, err := r.getJobDef(JobIDFromRepoID(source.RepoID), Input{
+			RepoID:        source.RepoID,
+			Public:        source.Public,
+			Pipelines:     PipelineOptionIgnore,
+			ArchivePath:   archivePath,
+			ArchiveBundle: source.Bundle,
+			DefaultBranch: source.DefaultBranch,
+		})
+		if err != nil {
+			return err
+		}
+
+		defs[k] = jobDef
+	}
+
+	err := r.scheduler.RunJobs(ctx, groupID, defs)
+	if err != nil {
+		return fmt.Errorf("failed to run jobs: %w", err)
+	}
+
+	return nil
+}
+
 func (r *Repository) getJobDef(jobUID string, input Input) (job.Definition, error) {
 	data, err := json.Marshal(input)
 	if err != nil {
@@ -221,17 +273,20 @@ func (r *Repository) Handle(ctx context.Context, data string, _ job.ProgressRepo
 		return "", err
 	}
 
-	if input.CloneURL == "" {
-		return "", errors.New("missing git repository clone URL")
-	}
+	var cloneURLWithAuth string
+	if input.ArchivePath == "" {
+		if input.CloneURL == "" {
+			return "", errors.New("missing git repository clone URL")
+		}
 
-	repoURL, err := url.Parse(input.CloneURL)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse git clone URL: %w", err)
-	}
+		repoURL, err := url.Parse(input.CloneURL)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse git clone URL: %w", err)
+		}
 
-	repoURL.User = url.UserPassword(input.GitUser, input.GitPass)
-	cloneURLWithAuth := repoURL.String()
+		repoURL.User = url.UserPassword(input.GitUser, input.GitPass)
+		cloneURLWithAuth = repoURL.String()
+	}
 
 	repo, err := r.repoStore.Find(ctx, input.RepoID)
 	if err != nil {
@@ -314,7 +369,12 @@ func (r *Repository) Handle(ctx context.Context, data string, _ job.ProgressRepo
 
 		log.Info().Msg("sync repository")
 
-		defaultBranch, err := r.syncGitRepository(ctx, &systemPrincipal, repo, cloneURLWithAuth)
+		var defaultBranch string
+		if input.ArchivePath != "" {
+			defaultBranch, err = r.syncGitRepositoryFromArchive(ctx, &systemPrincipal, repo, input)
+		} else {
+			defaultBranch, err = r.syncGitRepository(ctx, &systemPrincipal, repo, cloneURLWithAuth)
+		}
 		if err != nil {
 			return fmt.Errorf("failed to sync git repository from '%s': %w", input.CloneURL, err)
 		}
@@ -334,7 +394,6 @@ func (r *Repository) Handle(ctx context.Context, data string, _ job.ProgressRepo
 
 			repo.GitUID = gitUID
 			repo.DefaultBranch = defaultBranch
-			repo.State = enum.RepoStateActive
 
 			return nil
 		})
@@ -342,6 +401,11 @@ func (r *Repository) Handle(ctx context.Context, data string, _ job.ProgressRepo
 			return fmt.Errorf("failed to update repository after import: %w", err)
 		}
 
+		err = r.repoStateSvc.Transition(ctx, &systemPrincipal, repo, enum.RepoStateActive, 0)
+		if err != nil {
+			return fmt.Errorf("failed to activate repository after import: %w", err)
+		}
+
 		if input.Pipelines != PipelineOptionConvert {
 			return nil // assumes the value is enum.PipelineOptionIgnore
 		}
@@ -473,6 +537,61 @@ func (r *Repository) syncGitRepository(ctx context.Context,
 	return syncOut.DefaultBranch, nil
 }
 
+// syncGitRepositoryFromArchive syncs the repository from the git bundle stored in the space archive.
+// Bundles don't carry the symbolic HEAD, hence the default branch is taken from the archive metadata.
+func (r *Repository) syncGitRepositoryFromArchive(ctx context.Context,
+	principal *types.Principal,
+	repo *types.Repository,
+	input Input,
+) (string, error) {
+	if input.ArchiveBundle == "" {
+		// repository didn't contain any git data
+		return input.DefaultBranch, nil
+	}
+
+	bundleFile, err := os.CreateTemp("", "import-*.bundle")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary bundle file: %w", err)
+	}
+	defer func() {
+		if errRemove := os.Remove(bundleFile.Name()); errRemove != nil {
+			log.Ctx(ctx).Warn().Err(errRemove).Msg("failed to remove temporary bundle file")
+		}
+	}()
+
+	err = r.archive.ExtractBundle(ctx, input.ArchivePath, input.ArchiveBundle, bundleFile)
+	if errClose := bundleFile.Close(); err == nil && errClose != nil {
+		err = fmt.Errorf("failed to close temporary bundle file: %w", errClose)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	defaultBranch, err := r.syncGitRepository(ctx, principal, repo, bundleFile.Name())
+	if err != nil {
+		return "", err
+	}
+
+	if input.DefaultBranch == "" {
+		return defaultBranch, nil
+	}
+
+	writeParams, err := r.createRPCWriteParams(ctx, principal, repo)
+	if err != nil {
+		return "", err
+	}
+
+	err = r.git.UpdateDefaultBranch(ctx, &git.UpdateDefaultBranchParams{
+		WriteParams: writeParams,
+		BranchName:  input.DefaultBranch,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to set default branch: %w", err)
+	}
+
+	return input.DefaultBranch, nil
+}
+
 func (r *Repository) deleteGitRepository(ctx context.Context,
 	principal *types.Principal,
 	repo *types.Repository,
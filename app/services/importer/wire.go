@@ -17,6 +17,8 @@ package importer
 import (
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/services/repostate"
+	"github.com/harness/gitness/app/services/spacearchive"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -49,6 +51,8 @@ func ProvideRepoImporter(
 	indexer keywordsearch.Indexer,
 	publicAccess publicaccess.Service,
 	auditService audit.Service,
+	repoStateSvc *repostate.Service,
+	archive *spacearchive.Service,
 ) (*Repository, error) {
 	importer := &Repository{
 		defaultBranch: config.Git.DefaultBranch,
@@ -64,6 +68,8 @@ func ProvideRepoImporter(
 		indexer:       indexer,
 		publicAccess:  publicAccess,
 		auditService:  auditService,
+		repoStateSvc:  repoStateSvc,
+		archive:       archive,
 	}
 
 	err := executor.Register(jobType, importer)
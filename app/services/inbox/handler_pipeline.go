@@ -0,0 +1,53 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inbox
+
+import (
+	"context"
+	"fmt"
+
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// handleEventPipelineExecuted notifies whoever started a failed execution and the repository watchers.
+func (s *Service) handleEventPipelineExecuted(ctx context.Context,
+	event *events.Event[*pipelineevents.ExecutedPayload]) error {
+	// killed executions are stopped on purpose and aren't reported.
+	if event.Payload.Status != enum.CIStatusFailure && event.Payload.Status != enum.CIStatusError {
+		return nil
+	}
+
+	execution, err := s.executionStore.FindByNumber(ctx, event.Payload.PipelineID, event.Payload.ExecutionNum)
+	if err != nil {
+		return fmt.Errorf("failed to find execution %d: %w", event.Payload.ExecutionNum, err)
+	}
+
+	recipients, err := s.recipients(ctx, event.Payload.RepoID, 0, execution.CreatedBy)
+	if err != nil {
+		return err
+	}
+
+	// the failure isn't an action of a principal, hence there's no actor.
+	return s.notify(ctx, recipients, enum.NotificationTypeExecutionFailed, event.Payload.RepoID, execution.ID,
+		nil, event.Timestamp, &types.NotificationExecutionPayload{
+			PipelineID:      event.Payload.PipelineID,
+			ExecutionNumber: event.Payload.ExecutionNum,
+			Ref:             execution.Ref,
+			Status:          event.Payload.Status,
+		})
+}
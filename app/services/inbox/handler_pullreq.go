@@ -0,0 +1,94 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// handleEventPullReqCreated subscribes the author to the pull request and notifies the repository watchers.
+func (s *Service) handleEventPullReqCreated(ctx context.Context,
+	event *events.Event[*pullreqevents.CreatedPayload]) error {
+	if err := s.autoSubscribe(ctx, event.Payload.PrincipalID, event.Payload.PullReqID, event.Timestamp); err != nil {
+		return err
+	}
+
+	return s.notifyPullReq(ctx, event.Payload.Base, enum.NotificationTypePullReqCreated, event.Timestamp, "")
+}
+
+// handleEventPullReqReviewerAdded subscribes the reviewer to the pull request.
+func (s *Service) handleEventPullReqReviewerAdded(ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewerAddedPayload]) error {
+	return s.autoSubscribe(ctx, event.Payload.ReviewerID, event.Payload.PullReqID, event.Timestamp)
+}
+
+func (s *Service) handleEventPullReqReviewSubmitted(ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewSubmittedPayload]) error {
+	if err := s.autoSubscribe(ctx, event.Payload.ReviewerID, event.Payload.PullReqID, event.Timestamp); err != nil {
+		return err
+	}
+
+	return s.notifyPullReq(ctx, event.Payload.Base, enum.NotificationTypePullReqReviewSubmitted,
+		event.Timestamp, event.Payload.Decision)
+}
+
+func (s *Service) handleEventPullReqMerged(ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload]) error {
+	return s.notifyPullReq(ctx, event.Payload.Base, enum.NotificationTypePullReqMerged, event.Timestamp, "")
+}
+
+func (s *Service) handleEventPullReqClosed(ctx context.Context,
+	event *events.Event[*pullreqevents.ClosedPayload]) error {
+	return s.notifyPullReq(ctx, event.Payload.Base, enum.NotificationTypePullReqClosed, event.Timestamp, "")
+}
+
+func (s *Service) handleEventPullReqReopened(ctx context.Context,
+	event *events.Event[*pullreqevents.ReopenedPayload]) error {
+	return s.notifyPullReq(ctx, event.Payload.Base, enum.NotificationTypePullReqReopened, event.Timestamp, "")
+}
+
+func (s *Service) notifyPullReq(
+	ctx context.Context,
+	base pullreqevents.Base,
+	notificationType enum.NotificationType,
+	timestamp time.Time,
+	decision enum.PullReqReviewDecision,
+) error {
+	pr, err := s.pullReqStore.Find(ctx, base.PullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request %d: %w", base.PullReqID, err)
+	}
+
+	recipients, err := s.recipients(ctx, base.TargetRepoID, base.PullReqID)
+	if err != nil {
+		return err
+	}
+
+	actorID := base.PrincipalID
+
+	return s.notify(ctx, recipients, notificationType, base.TargetRepoID, base.PullReqID, &actorID, timestamp,
+		&types.NotificationPullReqPayload{
+			Number:   pr.Number,
+			Title:    pr.Title,
+			Decision: decision,
+		})
+}
@@ -0,0 +1,254 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	eventsReaderGroupName = "gitness:inbox"
+)
+
+type Config struct {
+	EventReaderName string
+	Concurrency     int
+	MaxRetries      int
+	// DedupWindow is the time in which repeated notifications of the same type
+	// about the same resource aren't delivered again to the same user.
+	DedupWindow time.Duration
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.EventReaderName == "" {
+		return errors.New("config.EventReaderName is required")
+	}
+	if c.Concurrency < 1 {
+		return errors.New("config.Concurrency has to be a positive number")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("config.MaxRetries can't be negative")
+	}
+	if c.DedupWindow < 0 {
+		return errors.New("config.DedupWindow can't be negative")
+	}
+
+	return nil
+}
+
+// Service generates in-app notifications from pull request and pipeline events
+// and manages the notification subscriptions of users.
+type Service struct {
+	config             Config
+	notificationStore  store.NotificationStore
+	subscriptionStore  store.NotificationSubscriptionStore
+	pullReqStore       store.PullReqStore
+	executionStore     store.ExecutionStore
+	principalInfoCache store.PrincipalInfoCache
+}
+
+func New(
+	ctx context.Context,
+	config Config,
+	notificationStore store.NotificationStore,
+	subscriptionStore store.NotificationSubscriptionStore,
+	pullReqStore store.PullReqStore,
+	executionStore store.ExecutionStore,
+	principalInfoCache store.PrincipalInfoCache,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	pipelineEvReaderFactory *events.ReaderFactory[*pipelineevents.Reader],
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided inbox service config is invalid: %w", err)
+	}
+
+	service := &Service{
+		config:             config,
+		notificationStore:  notificationStore,
+		subscriptionStore:  subscriptionStore,
+		pullReqStore:       pullReqStore,
+		executionStore:     executionStore,
+		principalInfoCache: principalInfoCache,
+	}
+
+	const idleTimeout = 1 * time.Minute
+
+	_, err := pullreqEvReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *pullreqevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterCreated(service.handleEventPullReqCreated)
+			_ = r.RegisterReviewerAdded(service.handleEventPullReqReviewerAdded)
+			_ = r.RegisterReviewSubmitted(service.handleEventPullReqReviewSubmitted)
+			_ = r.RegisterMerged(service.handleEventPullReqMerged)
+			_ = r.RegisterClosed(service.handleEventPullReqClosed)
+			_ = r.RegisterReopened(service.handleEventPullReqReopened)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pullreq events reader: %w", err)
+	}
+
+	_, err = pipelineEvReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *pipelineevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterExecuted(service.handleEventPipelineExecuted)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pipeline events reader: %w", err)
+	}
+
+	return service, nil
+}
+
+// notify creates the notification for all recipients.
+// The actor is never notified about their own action, neither are non-user principals.
+// Recipients that received a notification of the same type about the same resource
+// within the deduplication window are skipped.
+func (s *Service) notify(
+	ctx context.Context,
+	recipients []int64,
+	notificationType enum.NotificationType,
+	repoID int64,
+	resourceID int64,
+	actorID *int64,
+	timestamp time.Time,
+	payload any,
+) error {
+	rawPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification payload: %w", err)
+	}
+
+	dedupSince := timestamp.Add(-s.config.DedupWindow).UnixMilli()
+
+	for _, principalID := range recipients {
+		if actorID != nil && principalID == *actorID {
+			continue
+		}
+
+		principal, err := s.principalInfoCache.Get(ctx, principalID)
+		if err != nil {
+			return fmt.Errorf("failed to find notification recipient %d: %w", principalID, err)
+		}
+
+		if principal.Type != enum.PrincipalTypeUser {
+			continue
+		}
+
+		exists, err := s.notificationStore.ExistsSince(ctx, principalID, notificationType, resourceID, dedupSince)
+		if err != nil {
+			return fmt.Errorf("failed to check for duplicate notification: %w", err)
+		}
+
+		if exists {
+			log.Ctx(ctx).Debug().Msgf("skipping duplicate %s notification for principal %d",
+				notificationType, principalID)
+			continue
+		}
+
+		err = s.notificationStore.Create(ctx, &types.Notification{
+			PrincipalID: principalID,
+			Type:        notificationType,
+			RepoID:      repoID,
+			ResourceID:  resourceID,
+			ActorID:     actorID,
+			Payload:     rawPayload,
+			Read:        false,
+			Created:     timestamp.UnixMilli(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create notification: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// recipients returns the principals subscribed to the resource.
+// An explicit unsubscribe from a pull request takes precedence over watching its repository.
+func (s *Service) recipients(
+	ctx context.Context,
+	repoID int64,
+	pullReqID int64,
+	principalIDs ...int64,
+) ([]int64, error) {
+	subscribed := make(map[int64]bool)
+	for _, principalID := range principalIDs {
+		subscribed[principalID] = true
+	}
+
+	repoSubs, err := s.subscriptionStore.ListByResource(ctx, enum.SubscriptionResourceTypeRepo, repoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repo subscriptions: %w", err)
+	}
+
+	for _, sub := range repoSubs {
+		if sub.Subscribed {
+			subscribed[sub.PrincipalID] = true
+		}
+	}
+
+	if pullReqID != 0 {
+		prSubs, err := s.subscriptionStore.ListByResource(ctx, enum.SubscriptionResourceTypePullReq, pullReqID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pull request subscriptions: %w", err)
+		}
+
+		for _, sub := range prSubs {
+			subscribed[sub.PrincipalID] = sub.Subscribed
+		}
+	}
+
+	result := make([]int64, 0, len(subscribed))
+	for principalID, ok := range subscribed {
+		if ok {
+			result = append(result, principalID)
+		}
+	}
+
+	return result, nil
+}
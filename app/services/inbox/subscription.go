@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Subscribe subscribes or unsubscribes the principal to notifications about the resource.
+func (s *Service) Subscribe(
+	ctx context.Context,
+	principalID int64,
+	resourceType enum.SubscriptionResourceType,
+	resourceID int64,
+	subscribed bool,
+) (*types.NotificationSubscription, error) {
+	now := time.Now().UnixMilli()
+	sub := &types.NotificationSubscription{
+		PrincipalID:  principalID,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Subscribed:   subscribed,
+		Created:      now,
+		Updated:      now,
+	}
+
+	if err := s.subscriptionStore.Upsert(ctx, sub); err != nil {
+		return nil, fmt.Errorf("failed to update notification subscription: %w", err)
+	}
+
+	return sub, nil
+}
+
+// autoSubscribe subscribes the principal to notifications about the pull request,
+// unless the principal explicitly unsubscribed from it before.
+func (s *Service) autoSubscribe(ctx context.Context, principalID int64, pullReqID int64, timestamp time.Time) error {
+	err := s.subscriptionStore.CreateIfNotExists(ctx, &types.NotificationSubscription{
+		PrincipalID:  principalID,
+		ResourceType: enum.SubscriptionResourceTypePullReq,
+		ResourceID:   pullReqID,
+		Subscribed:   true,
+		Created:      timestamp.UnixMilli(),
+		Updated:      timestamp.UnixMilli(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to subscribe principal %d to pull request %d: %w", principalID, pullReqID, err)
+	}
+
+	return nil
+}
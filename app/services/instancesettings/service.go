@@ -0,0 +1,288 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instancesettings
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/pubsub"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// refreshInterval is the interval in which the settings are reloaded from the database.
+	// It bounds the staleness of the settings in case a change notification gets lost.
+	refreshInterval = 30 * time.Second
+
+	pubsubNamespace    = "instancesettings"
+	pubsubTopicChanged = "changed"
+)
+
+// Service provides the instance-level settings that can be changed by admins at runtime.
+// The values are kept in memory, so reading them is cheap enough for hot paths like middlewares.
+// Every change is broadcast to all instances, which reload the values from the database.
+type Service struct {
+	settings *settings.Service
+	pubsub   pubsub.PubSub
+
+	definitions map[settings.Key]*definition
+	defaults    map[settings.Key]any
+
+	// overrides contains the canonical values of all settings set at runtime.
+	overrides atomic.Pointer[map[settings.Key]any]
+
+	// reloadMx serializes reloads and the execution of the change listeners.
+	reloadMx  sync.Mutex
+	listeners []func()
+}
+
+func NewService(
+	ctx context.Context,
+	config *types.Config,
+	settingsService *settings.Service,
+	bus pubsub.PubSub,
+) (*Service, error) {
+	s := &Service{
+		settings:    settingsService,
+		pubsub:      bus,
+		definitions: make(map[settings.Key]*definition, len(definitions)),
+		defaults:    make(map[settings.Key]any, len(definitions)),
+	}
+
+	for _, d := range definitions {
+		s.definitions[d.key] = d
+		s.defaults[d.key] = d.dflt(config)
+	}
+
+	if err := s.reload(ctx); err != nil {
+		return nil, fmt.Errorf("failed to load instance settings: %w", err)
+	}
+
+	_ = bus.Subscribe(ctx, pubsubTopicChanged, func([]byte) error {
+		if err := s.reload(ctx); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to reload instance settings after change notification")
+		}
+		return nil
+	}, pubsub.WithChannelNamespace(pubsubNamespace))
+
+	return s, nil
+}
+
+// Run periodically reloads the settings from the database until the context is canceled.
+func (s *Service) Run(ctx context.Context) error {
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := s.reload(ctx); err != nil {
+				log.Ctx(ctx).Warn().Err(err).Msg("failed to reload instance settings")
+			}
+		}
+	}
+}
+
+// OnChange registers a function that's called whenever the value of any setting changed.
+// It's meant for components that derive state from the settings and can't read them on every use.
+func (s *Service) OnChange(fn func()) {
+	s.reloadMx.Lock()
+	defer s.reloadMx.Unlock()
+
+	s.listeners = append(s.listeners, fn)
+}
+
+// List returns all instance settings.
+func (s *Service) List() []*types.InstanceSetting {
+	overrides := *s.overrides.Load()
+
+	out := make([]*types.InstanceSetting, len(definitions))
+	for i, d := range definitions {
+		out[i] = s.toInstanceSetting(d, overrides)
+	}
+
+	return out
+}
+
+// Find returns the instance setting with the provided key.
+func (s *Service) Find(key string) (*types.InstanceSetting, error) {
+	d, err := s.definition(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toInstanceSetting(d, *s.overrides.Load()), nil
+}
+
+// Update validates the provided value and sets it as the runtime value of the setting.
+func (s *Service) Update(ctx context.Context, key string, raw json.RawMessage) (*types.InstanceSetting, error) {
+	d, err := s.definition(key)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := d.parse(raw)
+	if err != nil {
+		return nil, errors.InvalidArgument("Invalid value for setting %q: %s.", d.key, err)
+	}
+
+	if err = s.settings.SystemSet(ctx, d.key, d.export(value)); err != nil {
+		return nil, fmt.Errorf("failed to store instance setting: %w", err)
+	}
+
+	if err = s.changed(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.toInstanceSetting(d, *s.overrides.Load()), nil
+}
+
+// Reset removes the runtime value of the setting, which restores the default from the environment configuration.
+func (s *Service) Reset(ctx context.Context, key string) (*types.InstanceSetting, error) {
+	d, err := s.definition(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.settings.SystemDelete(ctx, d.key); err != nil {
+		return nil, fmt.Errorf("failed to delete instance setting: %w", err)
+	}
+
+	if err = s.changed(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.toInstanceSetting(d, *s.overrides.Load()), nil
+}
+
+// changed reloads the settings of this instance and notifies all other instances about the change.
+func (s *Service) changed(ctx context.Context) error {
+	if err := s.reload(ctx); err != nil {
+		return fmt.Errorf("failed to reload instance settings: %w", err)
+	}
+
+	err := s.pubsub.Publish(ctx, pubsubTopicChanged, nil, pubsub.WithPublishNamespace(pubsubNamespace))
+	if err != nil {
+		// other instances pick up the change with the next periodic reload.
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish instance settings change")
+	}
+
+	return nil
+}
+
+// reload loads the values of all settings from the database and calls the listeners if any value changed.
+func (s *Service) reload(ctx context.Context) error {
+	s.reloadMx.Lock()
+	defer s.reloadMx.Unlock()
+
+	overrides := make(map[settings.Key]any)
+	handlers := make([]settings.SettingHandler, len(definitions))
+	for i, d := range definitions {
+		handlers[i] = &loader{definition: d, values: overrides}
+	}
+
+	if err := s.settings.SystemMap(ctx, handlers...); err != nil {
+		return err
+	}
+
+	old := s.overrides.Swap(&overrides)
+	if old == nil || maps.Equal(*old, overrides) {
+		return nil
+	}
+
+	for _, fn := range s.listeners {
+		fn()
+	}
+
+	return nil
+}
+
+func (s *Service) definition(key string) (*definition, error) {
+	d, ok := s.definitions[settings.Key(key)]
+	if !ok {
+		return nil, errors.NotFound("Instance setting %q doesn't exist.", key)
+	}
+
+	return d, nil
+}
+
+func (s *Service) toInstanceSetting(d *definition, overrides map[settings.Key]any) *types.InstanceSetting {
+	dflt := s.defaults[d.key]
+	value, overridden := overrides[d.key]
+	if !overridden {
+		value = dflt
+	}
+
+	return &types.InstanceSetting{
+		Key:         string(d.key),
+		Type:        d.typ,
+		Description: d.description,
+		Value:       d.export(value),
+		Default:     d.export(dflt),
+		Overridden:  overridden,
+	}
+}
+
+// value returns the canonical value of the setting with the provided key.
+func value[T any](s *Service, key settings.Key) T {
+	if v, ok := (*s.overrides.Load())[key].(T); ok {
+		return v
+	}
+
+	// the type of the default values is ensured by the definitions.
+	v, _ := s.defaults[key].(T)
+
+	return v
+}
+
+// loader is a settings.SettingHandler that parses the stored value of a setting.
+// Invalid values are ignored, which means the default value is used for the setting.
+type loader struct {
+	definition *definition
+	values     map[settings.Key]any
+}
+
+func (l *loader) Key() settings.Key {
+	return l.definition.key
+}
+
+func (l *loader) Required() bool {
+	return false
+}
+
+func (l *loader) Handle(ctx context.Context, raw []byte) error {
+	v, err := l.definition.parse(raw)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Msgf("ignoring invalid value of instance setting %q, using the default", l.definition.key)
+		return nil
+	}
+
+	l.values[l.definition.key] = v
+
+	return nil
+}
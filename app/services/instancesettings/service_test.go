@@ -0,0 +1,213 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instancesettings
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/pubsub"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+var _ store.SettingsStore = (*memStore)(nil)
+
+// memStore is an in-memory settings store supporting only the system scope.
+type memStore struct {
+	values map[string]json.RawMessage
+}
+
+func (s *memStore) Find(_ context.Context, _ enum.SettingsScope, _ int64, key string) (json.RawMessage, error) {
+	v, ok := s.values[strings.ToLower(key)]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return v, nil
+}
+
+func (s *memStore) FindMany(
+	_ context.Context,
+	_ enum.SettingsScope,
+	_ int64,
+	keys ...string,
+) (map[string]json.RawMessage, error) {
+	out := map[string]json.RawMessage{}
+	for _, key := range keys {
+		if v, ok := s.values[strings.ToLower(key)]; ok {
+			out[key] = v
+		}
+	}
+	return out, nil
+}
+
+func (s *memStore) Upsert(_ context.Context, _ enum.SettingsScope, _ int64, key string, value json.RawMessage) error {
+	s.values[strings.ToLower(key)] = value
+	return nil
+}
+
+func (s *memStore) Delete(_ context.Context, _ enum.SettingsScope, _ int64, key string) error {
+	delete(s.values, strings.ToLower(key))
+	return nil
+}
+
+func newTestService(t *testing.T, values map[string]json.RawMessage) *Service {
+	t.Helper()
+
+	config := &types.Config{}
+	config.PublicResourceCreationEnabled = true
+	config.Upload.MaxFileSize = 10 << 20
+	config.Login.LockoutThreshold = 10
+	config.Login.IPLockoutThreshold = 50
+	config.Login.LockoutDuration = 15 * time.Minute
+
+	s, err := NewService(context.Background(), config,
+		settings.NewService(&memStore{values: values}), pubsub.NewInMemory())
+	if err != nil {
+		t.Fatalf("failed to create service: %s", err)
+	}
+
+	return s
+}
+
+func TestService_Defaults(t *testing.T) {
+	s := newTestService(t, map[string]json.RawMessage{
+		string(KeyLoginLockoutThreshold): json.RawMessage(`3`),
+		// invalid values are ignored and the default is used instead.
+		string(KeyUploadMaxFileSize): json.RawMessage(`"lots"`),
+	})
+
+	if !s.PublicResourceCreationEnabled() {
+		t.Errorf("expected default of public resource creation to be used")
+	}
+	if got := s.UploadMaxFileSize(); got != 10<<20 {
+		t.Errorf("got upload max file size %d, want default", got)
+	}
+	if got := s.LoginLockoutThreshold(); got != 3 {
+		t.Errorf("got lockout threshold %d, want stored value 3", got)
+	}
+	if got := s.LoginLockoutDuration(); got != 15*time.Minute {
+		t.Errorf("got lockout duration %s, want default", got)
+	}
+}
+
+func TestService_UpdateReset(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t, map[string]json.RawMessage{})
+
+	changes := 0
+	s.OnChange(func() { changes++ })
+
+	tests := []struct {
+		key   settings.Key
+		raw   string
+		check func() bool
+	}{
+		{key: KeyPublicResourceCreationEnabled, raw: `false`, check: func() bool {
+			return !s.PublicResourceCreationEnabled()
+		}},
+		{key: KeyUploadMaxFileSize, raw: `"100MB"`, check: func() bool {
+			return s.UploadMaxFileSize() == 100_000_000
+		}},
+		{key: KeyUploadMaxFileSize, raw: `"1MiB"`, check: func() bool {
+			return s.UploadMaxFileSize() == 1<<20
+		}},
+		{key: KeyUploadMaxFileSize, raw: `2048`, check: func() bool {
+			return s.UploadMaxFileSize() == 2048
+		}},
+		{key: KeyLoginLockoutDuration, raw: `"1h30m"`, check: func() bool {
+			return s.LoginLockoutDuration() == 90*time.Minute
+		}},
+		{key: KeyLoginIPLockoutThreshold, raw: `20`, check: func() bool {
+			return s.LoginIPLockoutThreshold() == 20
+		}},
+		{key: KeyMaintenanceMessage, raw: `"Back at noon."`, check: func() bool {
+			return s.MaintenanceMessage() == "Back at noon."
+		}},
+		{key: KeyGitMaxConcurrentOperations, raw: `0`, check: func() bool {
+			return s.GitMaxConcurrentOperations() == 0
+		}},
+	}
+	for _, test := range tests {
+		if _, err := s.Update(ctx, string(test.key), json.RawMessage(test.raw)); err != nil {
+			t.Fatalf("failed to update %s to %s: %s", test.key, test.raw, err)
+		}
+		if !test.check() {
+			t.Errorf("value of %s not applied after update to %s", test.key, test.raw)
+		}
+	}
+
+	if changes != len(tests) {
+		t.Errorf("got %d change notifications, want %d", changes, len(tests))
+	}
+
+	setting, err := s.Reset(ctx, string(KeyUploadMaxFileSize))
+	if err != nil {
+		t.Fatalf("failed to reset: %s", err)
+	}
+	if setting.Overridden || s.UploadMaxFileSize() != 10<<20 {
+		t.Errorf("setting wasn't reset to default: %+v", setting)
+	}
+
+	// durations are exposed as strings.
+	setting, err = s.Find(string(KeyLoginLockoutDuration))
+	if err != nil {
+		t.Fatalf("failed to find setting: %s", err)
+	}
+	if setting.Value != "1h30m0s" || setting.Default != "15m0s" || !setting.Overridden {
+		t.Errorf("unexpected setting: %+v", setting)
+	}
+}
+
+func TestService_UpdateInvalid(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t, map[string]json.RawMessage{})
+
+	tests := []struct {
+		key string
+		raw string
+	}{
+		{key: string(KeyPublicResourceCreationEnabled), raw: `"yes"`},
+		{key: string(KeyUploadMaxFileSize), raw: `"ten"`},
+		{key: string(KeyUploadMaxFileSize), raw: `0`},
+		{key: string(KeyLoginLockoutThreshold), raw: `1.5`},
+		{key: string(KeyLoginLockoutThreshold), raw: `0`},
+		{key: string(KeyLoginLockoutDuration), raw: `"100ms"`},
+		{key: string(KeyLoginLockoutDuration), raw: `900`},
+		{key: string(KeyMaintenanceMessage), raw: `"  "`},
+		{key: string(KeyMaintenanceMessage), raw: `true`},
+		{key: string(KeyGitMaxConcurrentOperations), raw: `-1`},
+	}
+	for _, test := range tests {
+		_, err := s.Update(ctx, test.key, json.RawMessage(test.raw))
+
+		if errors.AsStatus(err) != errors.StatusInvalidArgument {
+			t.Errorf("expected bad request for %s set to %s, got: %v", test.key, test.raw, err)
+		}
+	}
+
+	_, err := s.Update(ctx, "unknown", json.RawMessage(`true`))
+
+	if errors.AsStatus(err) != errors.StatusNotFound {
+		t.Errorf("expected not found for unknown setting, got: %v", err)
+	}
+}
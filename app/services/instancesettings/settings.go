@@ -0,0 +1,152 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instancesettings
+
+import (
+	"time"
+
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+var (
+	// KeyPublicResourceCreationEnabled [bool] allows users to create publicly accessible resources.
+	KeyPublicResourceCreationEnabled settings.Key = "public_resource_creation_enabled"
+	// KeyUploadMaxFileSize [bytes] is the maximum size of files uploaded via the API.
+	KeyUploadMaxFileSize settings.Key = "upload_max_file_size"
+	// KeyWebhookAllowPrivateNetwork [bool] allows webhooks to target private networks.
+	KeyWebhookAllowPrivateNetwork settings.Key = "webhook_allow_private_network"
+	// KeyWebhookAllowLoopback [bool] allows webhooks to target loopback addresses.
+	KeyWebhookAllowLoopback settings.Key = "webhook_allow_loopback"
+	// KeyLoginLockoutThreshold [int] is the number of failed logins of an account after which it's locked.
+	KeyLoginLockoutThreshold settings.Key = "login_lockout_threshold"
+	// KeyLoginIPLockoutThreshold [int] is the number of failed logins from a source IP after which it's locked.
+	KeyLoginIPLockoutThreshold settings.Key = "login_ip_lockout_threshold"
+	// KeyLoginLockoutDuration [duration] is the duration for which an account or source IP stays locked.
+	KeyLoginLockoutDuration settings.Key = "login_lockout_duration"
+	// KeyDatabaseSlowQueryThreshold [duration] is the duration after which store queries are logged as slow.
+	KeyDatabaseSlowQueryThreshold settings.Key = "database_slow_query_threshold"
+	// KeyGitSlowOperationThreshold [duration] is the duration after which git processes are logged as slow.
+	KeyGitSlowOperationThreshold settings.Key = "git_slow_operation_threshold"
+	// KeyGitMaxConcurrentOperations [int] is the maximum number of concurrent git operations per principal.
+	KeyGitMaxConcurrentOperations settings.Key = "git_max_concurrent_operations"
+	// KeyGitConcurrentOperationsWait [duration] is how long git operations wait for a slot before they're rejected.
+	KeyGitConcurrentOperationsWait settings.Key = "git_concurrent_operations_wait"
+	// KeyMaintenanceMessage [string] is the message returned for writes blocked by maintenance mode.
+	KeyMaintenanceMessage settings.Key = "maintenance_message"
+	// KeyMaintenanceRetryAfter [duration] is the delay clients are asked to wait before retrying blocked writes.
+	KeyMaintenanceRetryAfter settings.Key = "maintenance_retry_after"
+)
+
+// definition describes an instance setting.
+type definition struct {
+	key         settings.Key
+	typ         enum.InstanceSettingType
+	description string
+	// min is the minimum value of numeric settings (ints, byte sizes and durations)
+	// and the minimum length of strings, ignoring leading and trailing whitespace.
+	min int64
+	// dflt returns the default value of the setting derived from the environment configuration.
+	dflt func(config *types.Config) any
+}
+
+// definitions contains all instance settings in the order in which they are listed.
+var definitions = []*definition{
+	{
+		key:         KeyPublicResourceCreationEnabled,
+		typ:         enum.InstanceSettingTypeBool,
+		description: "Allow users to create publicly accessible resources.",
+		dflt:        func(config *types.Config) any { return config.PublicResourceCreationEnabled },
+	},
+	{
+		key:         KeyUploadMaxFileSize,
+		typ:         enum.InstanceSettingTypeBytes,
+		description: "Maximum size of files uploaded via the API.",
+		min:         1,
+		dflt:        func(config *types.Config) any { return config.Upload.MaxFileSize },
+	},
+	{
+		key:         KeyWebhookAllowPrivateNetwork,
+		typ:         enum.InstanceSettingTypeBool,
+		description: "Allow webhooks to target addresses in private networks.",
+		dflt:        func(config *types.Config) any { return config.Webhook.AllowPrivateNetwork },
+	},
+	{
+		key:         KeyWebhookAllowLoopback,
+		typ:         enum.InstanceSettingTypeBool,
+		description: "Allow webhooks to target loopback addresses.",
+		dflt:        func(config *types.Config) any { return config.Webhook.AllowLoopback },
+	},
+	{
+		key:         KeyLoginLockoutThreshold,
+		typ:         enum.InstanceSettingTypeInt,
+		description: "Number of failed logins of an account after which the account is temporarily locked.",
+		min:         1,
+		dflt:        func(config *types.Config) any { return int64(config.Login.LockoutThreshold) },
+	},
+	{
+		key:         KeyLoginIPLockoutThreshold,
+		typ:         enum.InstanceSettingTypeInt,
+		description: "Number of failed logins from a source IP after which the IP is temporarily locked.",
+		min:         1,
+		dflt:        func(config *types.Config) any { return int64(config.Login.IPLockoutThreshold) },
+	},
+	{
+		key:         KeyLoginLockoutDuration,
+		typ:         enum.InstanceSettingTypeDuration,
+		description: "Duration for which a locked account or source IP stays locked.",
+		min:         int64(time.Second),
+		dflt:        func(config *types.Config) any { return config.Login.LockoutDuration },
+	},
+	{
+		key:         KeyDatabaseSlowQueryThreshold,
+		typ:         enum.InstanceSettingTypeDuration,
+		description: "Duration after which store queries are logged as slow. Zero disables the slow query log.",
+		dflt:        func(config *types.Config) any { return config.Database.SlowQueryThreshold },
+	},
+	{
+		key:         KeyGitSlowOperationThreshold,
+		typ:         enum.InstanceSettingTypeDuration,
+		description: "Duration after which git processes are logged as slow. Zero disables the slow operation log.",
+		dflt:        func(config *types.Config) any { return config.Git.SlowOperationThreshold },
+	},
+	{
+		key:         KeyGitMaxConcurrentOperations,
+		typ:         enum.InstanceSettingTypeInt,
+		description: "Maximum concurrent git operations (clone, fetch, push) per principal. Zero disables the limit.",
+		dflt:        func(config *types.Config) any { return int64(config.Git.MaxConcurrentOperations) },
+	},
+	{
+		key:         KeyGitConcurrentOperationsWait,
+		typ:         enum.InstanceSettingTypeDuration,
+		description: "Duration git operations over the concurrency limit wait for a free slot before they fail.",
+		dflt:        func(config *types.Config) any { return config.Git.ConcurrentOperationsWait },
+	},
+	{
+		key:         KeyMaintenanceMessage,
+		typ:         enum.InstanceSettingTypeString,
+		description: "Message returned for writes that are blocked while maintenance mode is enabled.",
+		min:         1,
+		dflt:        func(config *types.Config) any { return config.Maintenance.Message },
+	},
+	{
+		key:         KeyMaintenanceRetryAfter,
+		typ:         enum.InstanceSettingTypeDuration,
+		description: "Delay clients are asked to wait before retrying writes blocked by maintenance mode.",
+		min:         int64(time.Second),
+		dflt:        func(config *types.Config) any { return config.Maintenance.RetryAfter },
+	},
+}
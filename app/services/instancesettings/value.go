@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instancesettings
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/dustin/go-humanize"
+)
+
+// parse parses and validates the raw json value of the setting and returns its canonical value.
+// Canonical values are bool, int64 for ints and byte sizes, time.Duration for durations and string for strings.
+func (d *definition) parse(raw json.RawMessage) (any, error) {
+	switch d.typ {
+	case enum.InstanceSettingTypeBool:
+		var v bool
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return nil, errors.New("value has to be a boolean")
+		}
+		return v, nil
+
+	case enum.InstanceSettingTypeInt:
+		var v int64
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return nil, errors.New("value has to be an integer")
+		}
+		return v, d.checkMin(v)
+
+	case enum.InstanceSettingTypeBytes:
+		v, err := parseBytes(raw)
+		if err != nil {
+			return nil, err
+		}
+		return v, d.checkMin(v)
+
+	case enum.InstanceSettingTypeDuration:
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, errors.New(`value has to be a duration string (e.g. "15m")`)
+		}
+		v, err := time.ParseDuration(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration %q", s)
+		}
+		return v, d.checkMin(int64(v))
+
+	case enum.InstanceSettingTypeString:
+		var v string
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return nil, errors.New("value has to be a string")
+		}
+		if int64(len(strings.TrimSpace(v))) < d.min {
+			return nil, fmt.Errorf("value has to be at least %d characters long", d.min)
+		}
+		return v, nil
+
+	default:
+		return nil, fmt.Errorf("setting type %q is not supported", d.typ)
+	}
+}
+
+// export returns the value in the representation used by the API and the settings store.
+func (d *definition) export(v any) any {
+	if duration, ok := v.(time.Duration); ok {
+		return duration.String()
+	}
+	return v
+}
+
+func (d *definition) checkMin(v int64) error {
+	if v >= d.min {
+		return nil
+	}
+
+	if d.typ == enum.InstanceSettingTypeDuration {
+		return fmt.Errorf("value has to be at least %s", time.Duration(d.min))
+	}
+	return fmt.Errorf("value has to be at least %d", d.min)
+}
+
+// parseBytes parses a byte size provided as number of bytes or as string (e.g. "100MB" or "1GiB").
+func parseBytes(raw json.RawMessage) (int64, error) {
+	var n int64
+	if err := json.Unmarshal(raw, &n); err == nil {
+		return n, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return 0, errors.New(`value has to be a number of bytes or a byte size string (e.g. "100MB")`)
+	}
+
+	size, err := humanize.ParseBytes(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid byte size %q", s)
+	}
+	if size > math.MaxInt64 {
+		return 0, fmt.Errorf("byte size %q is too big", s)
+	}
+
+	return int64(size), nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instancesettings
+
+import "time"
+
+// PublicResourceCreationEnabled returns whether users are allowed to create publicly accessible resources.
+func (s *Service) PublicResourceCreationEnabled() bool {
+	return value[bool](s, KeyPublicResourceCreationEnabled)
+}
+
+// UploadMaxFileSize returns the maximum size of files uploaded via the API in bytes.
+func (s *Service) UploadMaxFileSize() int64 {
+	return value[int64](s, KeyUploadMaxFileSize)
+}
+
+// WebhookAllowPrivateNetwork returns whether webhooks are allowed to target private networks.
+func (s *Service) WebhookAllowPrivateNetwork() bool {
+	return value[bool](s, KeyWebhookAllowPrivateNetwork)
+}
+
+// WebhookAllowLoopback returns whether webhooks are allowed to target loopback addresses.
+func (s *Service) WebhookAllowLoopback() bool {
+	return value[bool](s, KeyWebhookAllowLoopback)
+}
+
+// LoginLockoutThreshold returns the number of failed logins of an account after which it's locked.
+func (s *Service) LoginLockoutThreshold() int {
+	return int(value[int64](s, KeyLoginLockoutThreshold))
+}
+
+// LoginIPLockoutThreshold returns the number of failed logins from a source IP after which it's locked.
+func (s *Service) LoginIPLockoutThreshold() int {
+	return int(value[int64](s, KeyLoginIPLockoutThreshold))
+}
+
+// LoginLockoutDuration returns the duration for which an account or source IP stays locked.
+func (s *Service) LoginLockoutDuration() time.Duration {
+	return value[time.Duration](s, KeyLoginLockoutDuration)
+}
+
+// DatabaseSlowQueryThreshold returns the duration after which store queries are logged as slow.
+func (s *Service) DatabaseSlowQueryThreshold() time.Duration {
+	return value[time.Duration](s, KeyDatabaseSlowQueryThreshold)
+}
+
+// GitSlowOperationThreshold returns the duration after which git processes are logged as slow.
+func (s *Service) GitSlowOperationThreshold() time.Duration {
+	return value[time.Duration](s, KeyGitSlowOperationThreshold)
+}
+
+// GitMaxConcurrentOperations returns the maximum number of concurrent git operations per principal (0 = unlimited).
+func (s *Service) GitMaxConcurrentOperations() int {
+	return int(value[int64](s, KeyGitMaxConcurrentOperations))
+}
+
+// GitConcurrentOperationsWait returns how long git operations wait for a slot before they're rejected.
+func (s *Service) GitConcurrentOperationsWait() time.Duration {
+	return value[time.Duration](s, KeyGitConcurrentOperationsWait)
+}
+
+// MaintenanceMessage returns the message returned for writes blocked by maintenance mode.
+func (s *Service) MaintenanceMessage() string {
+	return value[string](s, KeyMaintenanceMessage)
+}
+
+// MaintenanceRetryAfter returns the delay clients are asked to wait before retrying writes blocked by maintenance mode.
+func (s *Service) MaintenanceRetryAfter() time.Duration {
+	return value[time.Duration](s, KeyMaintenanceRetryAfter)
+}
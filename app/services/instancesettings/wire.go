@@ -0,0 +1,54 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instancesettings
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/pubsub"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	ctx context.Context,
+	config *types.Config,
+	settingsService *settings.Service,
+	bus pubsub.PubSub,
+) (*Service, error) {
+	s, err := NewService(ctx, config, settingsService, bus)
+	if err != nil {
+		return nil, err
+	}
+
+	// the database and git layers can't depend on the service, the slow operation thresholds are pushed to them.
+	applySlowThresholds := func() {
+		dbtx.SetSlowQueryThreshold(s.DatabaseSlowQueryThreshold())
+		command.SetSlowThreshold(s.GitSlowOperationThreshold())
+	}
+
+	applySlowThresholds()
+	s.OnChange(applySlowThresholds)
+
+	return s, nil
+}
@@ -21,6 +21,7 @@ import (
 )
 
 type Indexer interface {
+	// Index indexes the content of the repository together with its metadata (description, website and contact).
 	Index(ctx context.Context, repo *types.Repository) error
 }
 
@@ -23,6 +23,7 @@ import (
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 func (s *Service) Define(
@@ -282,12 +283,67 @@ func (s *Service) listInScopes(
 	return labels, total, nil
 }
 
+// Delete deletes the label with all its values and returns the deleted resources.
+// With dryRun set, the resources that would be deleted are returned, but nothing is deleted.
 func (s *Service) Delete(
 	ctx context.Context,
 	spaceID, repoID *int64,
 	key string,
-) error {
-	return s.labelStore.Delete(ctx, spaceID, repoID, key)
+	dryRun bool,
+) ([]types.AffectedResource, error) {
+	affected, err := s.listDeleteAffected(ctx, spaceID, repoID, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if dryRun {
+		return affected, nil
+	}
+
+	if err = s.labelStore.Delete(ctx, spaceID, repoID, key); err != nil {
+		return nil, err
+	}
+
+	return affected, nil
+}
+
+// listDeleteAffected returns the label and its values, which get deleted together with the label.
+func (s *Service) listDeleteAffected(
+	ctx context.Context,
+	spaceID, repoID *int64,
+	key string,
+) ([]types.AffectedResource, error) {
+	label, err := s.labelStore.Find(ctx, spaceID, repoID, key)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		// deleting a label that doesn't exist isn't an error, nothing is affected by it.
+		return []types.AffectedResource{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := s.labelValueStore.List(ctx, label.ID, &types.ListQueryFilter{
+		Pagination: types.Pagination{Page: 1, Size: int(label.ValueCount)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	affected := make([]types.AffectedResource, 0, 1+len(values))
+	affected = append(affected, types.AffectedResource{
+		Type: enum.AffectedResourceTypeLabel,
+		ID:   label.ID,
+		Name: label.Key,
+	})
+	for _, value := range values {
+		affected = append(affected, types.AffectedResource{
+			Type: enum.AffectedResourceTypeLabelValue,
+			ID:   value.ID,
+			Name: value.Value,
+		})
+	}
+
+	return affected, nil
 }
 
 func newLabel(
@@ -49,7 +49,7 @@ func (l Locker) lock(
 	ctx = logging.NewContext(ctx, func(zc zerolog.Context) zerolog.Context {
 		return zc.
 			Str("key", key).
-			Str("namespace", namespaceRepo).
+			Str("namespace", namespace).
 			Str("expiry", expiry.String())
 	})
 
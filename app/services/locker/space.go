@@ -0,0 +1,39 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package locker
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const namespaceSpace = "space"
+
+// LockPinnedRepos locks the pinned repositories of the space, so they're modified by one request at a time.
+func (l Locker) LockPinnedRepos(
+	ctx context.Context,
+	spaceID int64,
+	expiry time.Duration,
+) (func(), error) {
+	key := fmt.Sprintf("%d/pinned-repos", spaceID)
+
+	unlockFn, err := l.lock(ctx, namespaceSpace, key, expiry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock mutex for pinned repos of space %d: %w", spaceID, err)
+	}
+
+	return unlockFn, nil
+}
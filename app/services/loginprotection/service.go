@@ -0,0 +1,289 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loginprotection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+
+	"github.com/rs/zerolog/log"
+)
+
+type Config struct {
+	// Enabled enables tracking of failed logins. If disabled, logins are never delayed or locked.
+	Enabled bool
+	// FailureWindow is the sliding window in which failed logins are counted.
+	FailureWindow time.Duration
+	// DelayAfter is the number of failed logins after which responses to failed logins are delayed.
+	DelayAfter int
+	// DelayStep is the delay added for every failed login beyond DelayAfter.
+	DelayStep time.Duration
+	// MaxDelay is the maximum delay of a response to a failed login.
+	MaxDelay time.Duration
+	// LockoutThreshold is the number of failed logins of an account after which it's locked.
+	LockoutThreshold int
+	// IPLockoutThreshold is the number of failed logins from a source IP after which it's locked.
+	IPLockoutThreshold int
+	// LockoutDuration is the duration for which an account or source IP stays locked.
+	LockoutDuration time.Duration
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if !c.Enabled {
+		return nil
+	}
+	if c.FailureWindow <= 0 {
+		return errors.New("config.FailureWindow has to be a positive duration")
+	}
+	if c.DelayAfter < 0 {
+		return errors.New("config.DelayAfter can't be negative")
+	}
+	if c.DelayStep < 0 || c.MaxDelay < 0 {
+		return errors.New("config.DelayStep and config.MaxDelay can't be negative")
+	}
+	if c.LockoutThreshold < 1 || c.IPLockoutThreshold < 1 {
+		return errors.New("config.LockoutThreshold and config.IPLockoutThreshold have to be positive numbers")
+	}
+	if c.LockoutDuration <= 0 {
+		return errors.New("config.LockoutDuration has to be a positive duration")
+	}
+
+	return nil
+}
+
+const (
+	keyPrefixAccount    = "account:"
+	keyPrefixIdentifier = "identifier:"
+	keyPrefixIP         = "ip:"
+)
+
+// AccountKey returns the key under which failed logins of an existing account are tracked.
+func AccountKey(principalID int64) string {
+	return keyPrefixAccount + strconv.FormatInt(principalID, 10)
+}
+
+// IdentifierKey returns the key under which failed logins for an unknown login identifier are tracked.
+// Tracking them the same way as existing accounts ensures lockouts don't reveal which accounts exist.
+func IdentifierKey(loginIdentifier string) string {
+	return keyPrefixIdentifier + strings.ToLower(loginIdentifier)
+}
+
+// IPKey returns the key under which failed logins from a source IP are tracked.
+// It returns an empty key if the IP is unknown, which is ignored by the service.
+func IPKey(ip string) string {
+	if ip == "" {
+		return ""
+	}
+	return keyPrefixIP + ip
+}
+
+// Service protects password logins against brute-force attacks.
+// It counts failed logins per account and per source IP in a sliding window,
+// delays the responses to failed logins progressively and temporarily locks
+// accounts and source IPs that exceed the configured thresholds.
+type Service struct {
+	// config is swapped as a whole if the lockout configuration is changed at runtime.
+	config            atomic.Pointer[Config]
+	loginAttemptStore store.LoginAttemptStore
+}
+
+func NewService(config Config, loginAttemptStore store.LoginAttemptStore) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided login protection config is invalid: %w", err)
+	}
+
+	s := &Service{
+		loginAttemptStore: loginAttemptStore,
+	}
+	s.config.Store(&config)
+
+	return s, nil
+}
+
+// UpdateLockout changes the lockout thresholds and duration at runtime.
+// Lockouts that are already in place keep their original duration.
+func (s *Service) UpdateLockout(threshold, ipThreshold int, duration time.Duration) error {
+	config := *s.config.Load()
+	config.LockoutThreshold = threshold
+	config.IPLockoutThreshold = ipThreshold
+	config.LockoutDuration = duration
+
+	if err := config.Prepare(); err != nil {
+		return fmt.Errorf("provided login lockout config is invalid: %w", err)
+	}
+
+	s.config.Store(&config)
+
+	return nil
+}
+
+// LockedFor returns the remaining duration for which any of the keys is locked, or 0 if none of them is.
+func (s *Service) LockedFor(ctx context.Context, keys ...string) (time.Duration, error) {
+	keys = nonEmpty(keys)
+	if !s.config.Load().Enabled || len(keys) == 0 {
+		return 0, nil
+	}
+
+	now := time.Now()
+
+	until, err := s.loginAttemptStore.LockedUntil(ctx, now.UnixMilli(), keys...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find login lockout: %w", err)
+	}
+
+	if until == 0 {
+		return 0, nil
+	}
+
+	return time.UnixMilli(until).Sub(now), nil
+}
+
+// Fail records a failed login for the account and source IP, locks them if they exceeded their threshold,
+// and returns the delay that should be applied before responding to the failed login.
+func (s *Service) Fail(ctx context.Context, accountKey, ipKey string) (time.Duration, error) {
+	config := s.config.Load()
+	if !config.Enabled {
+		return 0, nil
+	}
+
+	now := time.Now()
+	since := now.Add(-config.FailureWindow).UnixMilli()
+
+	var maxFailures int64
+	for _, k := range []struct {
+		key       string
+		threshold int
+	}{
+		{key: accountKey, threshold: config.LockoutThreshold},
+		{key: ipKey, threshold: config.IPLockoutThreshold},
+	} {
+		if k.key == "" {
+			continue
+		}
+
+		if err := s.loginAttemptStore.RecordFailure(ctx, k.key, now.UnixMilli()); err != nil {
+			return 0, fmt.Errorf("failed to record failed login: %w", err)
+		}
+
+		failures, err := s.loginAttemptStore.CountFailures(ctx, k.key, since)
+		if err != nil {
+			return 0, fmt.Errorf("failed to count failed logins: %w", err)
+		}
+
+		maxFailures = max(maxFailures, failures)
+
+		if failures < int64(k.threshold) {
+			continue
+		}
+
+		if err = s.lock(ctx, k.key, now.Add(config.LockoutDuration)); err != nil {
+			return 0, err
+		}
+
+		log.Ctx(ctx).Warn().
+			Str("login_key", k.key).
+			Int64("failures", failures).
+			Dur("lockout_duration", config.LockoutDuration).
+			Msg("too many failed logins, locked temporarily")
+	}
+
+	return config.delay(maxFailures), nil
+}
+
+// Reset resets the failed logins of the keys, e.g. after a successful login.
+func (s *Service) Reset(ctx context.Context, keys ...string) error {
+	keys = nonEmpty(keys)
+	if !s.config.Load().Enabled || len(keys) == 0 {
+		return nil
+	}
+
+	if err := s.loginAttemptStore.DeleteFailures(ctx, keys...); err != nil {
+		return fmt.Errorf("failed to reset failed logins: %w", err)
+	}
+
+	return nil
+}
+
+// Unlock removes the lockout and resets the failed logins of the key.
+// It's available even if the protection is disabled, so lockouts from before can be cleared.
+func (s *Service) Unlock(ctx context.Context, key string) error {
+	if err := s.loginAttemptStore.Unlock(ctx, key); err != nil {
+		return fmt.Errorf("failed to remove login lockout: %w", err)
+	}
+
+	if err := s.loginAttemptStore.DeleteFailures(ctx, key); err != nil {
+		return fmt.Errorf("failed to reset failed logins: %w", err)
+	}
+
+	return nil
+}
+
+// Wait blocks for the provided delay or until the context is done.
+func (s *Service) Wait(ctx context.Context, delay time.Duration) {
+	if delay <= 0 {
+		return
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
+
+func (s *Service) lock(ctx context.Context, key string, until time.Time) error {
+	if err := s.loginAttemptStore.Lock(ctx, key, until.UnixMilli()); err != nil {
+		return fmt.Errorf("failed to lock login: %w", err)
+	}
+
+	// the lockout takes over, counting starts from scratch once it ended.
+	if err := s.loginAttemptStore.DeleteFailures(ctx, key); err != nil {
+		return fmt.Errorf("failed to reset failed logins: %w", err)
+	}
+
+	return nil
+}
+
+// delay returns the delay of the response to a failed login, based on the number of failures in the window.
+func (c *Config) delay(failures int64) time.Duration {
+	excess := failures - int64(c.DelayAfter)
+	if excess <= 0 {
+		return 0
+	}
+
+	return min(time.Duration(excess)*c.DelayStep, c.MaxDelay)
+}
+
+func nonEmpty(keys []string) []string {
+	result := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if key != "" {
+			result = append(result, key)
+		}
+	}
+	return result
+}
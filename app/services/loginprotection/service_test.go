@@ -0,0 +1,269 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loginprotection
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+)
+
+var _ store.LoginAttemptStore = (*memStore)(nil)
+
+type memStore struct {
+	failures map[string][]int64
+	lockouts map[string]int64
+}
+
+func newMemStore() *memStore {
+	return &memStore{failures: map[string][]int64{}, lockouts: map[string]int64{}}
+}
+
+func (s *memStore) RecordFailure(_ context.Context, key string, created int64) error {
+	s.failures[key] = append(s.failures[key], created)
+	return nil
+}
+
+func (s *memStore) CountFailures(_ context.Context, key string, since int64) (int64, error) {
+	var n int64
+	for _, created := range s.failures[key] {
+		if created > since {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (s *memStore) DeleteFailures(_ context.Context, keys ...string) error {
+	for _, key := range keys {
+		delete(s.failures, key)
+	}
+	return nil
+}
+
+func (s *memStore) PurgeFailures(context.Context, int64) (int64, error) { return 0, nil }
+
+func (s *memStore) Lock(_ context.Context, key string, until int64) error {
+	s.lockouts[key] = until
+	return nil
+}
+
+func (s *memStore) LockedUntil(_ context.Context, now int64, keys ...string) (int64, error) {
+	var until int64
+	for _, key := range keys {
+		if u := s.lockouts[key]; u > now {
+			until = max(until, u)
+		}
+	}
+	return until, nil
+}
+
+func (s *memStore) Unlock(_ context.Context, key string) error {
+	delete(s.lockouts, key)
+	return nil
+}
+
+func (s *memStore) PurgeLockouts(context.Context, int64) (int64, error) { return 0, nil }
+
+func testConfig() Config {
+	return Config{
+		Enabled:            true,
+		FailureWindow:      time.Minute,
+		DelayAfter:         2,
+		DelayStep:          100 * time.Millisecond,
+		MaxDelay:           250 * time.Millisecond,
+		LockoutThreshold:   5,
+		IPLockoutThreshold: 8,
+		LockoutDuration:    time.Hour,
+	}
+}
+
+func TestService_Fail(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewService(testConfig(), newMemStore())
+	if err != nil {
+		t.Fatalf("failed to create service: %s", err)
+	}
+
+	account := AccountKey(1)
+	ip := IPKey("10.0.0.1")
+
+	wantDelays := []time.Duration{0, 0, 100 * time.Millisecond, 200 * time.Millisecond, 250 * time.Millisecond}
+	for i, want := range wantDelays {
+		lockedFor, err := s.LockedFor(ctx, account, ip)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if lockedFor != 0 {
+			t.Fatalf("attempt %d: account is locked before reaching the threshold", i+1)
+		}
+
+		delay, err := s.Fail(ctx, account, ip)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if delay != want {
+			t.Errorf("attempt %d: got delay %s, want %s", i+1, delay, want)
+		}
+	}
+
+	lockedFor, err := s.LockedFor(ctx, account)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if lockedFor <= 0 || lockedFor > time.Hour {
+		t.Errorf("account should be locked for up to an hour, got %s", lockedFor)
+	}
+
+	lockedFor, err = s.LockedFor(ctx, ip)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if lockedFor != 0 {
+		t.Errorf("ip shouldn't be locked below its threshold, got %s", lockedFor)
+	}
+
+	if err = s.Unlock(ctx, account); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	lockedFor, err = s.LockedFor(ctx, account)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if lockedFor != 0 {
+		t.Errorf("account should be unlocked, got %s", lockedFor)
+	}
+}
+
+func TestService_IPLockout(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewService(testConfig(), newMemStore())
+	if err != nil {
+		t.Fatalf("failed to create service: %s", err)
+	}
+
+	ip := IPKey("10.0.0.2")
+
+	// failures spread over many accounts still lock the source ip.
+	for i := range 8 {
+		if _, err = s.Fail(ctx, IdentifierKey("user"+string(rune('a'+i))), ip); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	lockedFor, err := s.LockedFor(ctx, AccountKey(42), ip)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if lockedFor <= 0 {
+		t.Errorf("ip should be locked")
+	}
+}
+
+func TestService_Reset(t *testing.T) {
+	ctx := context.Background()
+	mem := newMemStore()
+	s, err := NewService(testConfig(), mem)
+	if err != nil {
+		t.Fatalf("failed to create service: %s", err)
+	}
+
+	account := AccountKey(1)
+	ip := IPKey("")
+
+	for range 4 {
+		if _, err = s.Fail(ctx, account, ip); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	if err = s.Reset(ctx, account, ip); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// the counter starts from scratch after the reset, so the next failure doesn't lock the account.
+	delay, err := s.Fail(ctx, account, ip)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if delay != 0 {
+		t.Errorf("got delay %s after reset, want none", delay)
+	}
+
+	lockedFor, err := s.LockedFor(ctx, account, ip)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if lockedFor != 0 {
+		t.Errorf("account shouldn't be locked after reset, got %s", lockedFor)
+	}
+}
+
+func TestService_Disabled(t *testing.T) {
+	ctx := context.Background()
+	mem := newMemStore()
+	s, err := NewService(Config{Enabled: false}, mem)
+	if err != nil {
+		t.Fatalf("failed to create service: %s", err)
+	}
+
+	for range 20 {
+		delay, err := s.Fail(ctx, AccountKey(1), IPKey("10.0.0.3"))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if delay != 0 {
+			t.Fatalf("got delay %s while disabled", delay)
+		}
+	}
+
+	if len(mem.failures) != 0 {
+		t.Errorf("failures were recorded while disabled")
+	}
+}
+
+func TestService_UpdateLockout(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewService(testConfig(), newMemStore())
+	if err != nil {
+		t.Fatalf("failed to create service: %s", err)
+	}
+
+	if err = s.UpdateLockout(0, 8, time.Hour); err == nil {
+		t.Errorf("expected error for invalid lockout threshold")
+	}
+
+	if err = s.UpdateLockout(2, 8, time.Minute); err != nil {
+		t.Fatalf("failed to update lockout: %s", err)
+	}
+
+	account := AccountKey(7)
+	for range 2 {
+		if _, err = s.Fail(ctx, account, ""); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	lockedFor, err := s.LockedFor(ctx, account)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if lockedFor <= 0 || lockedFor > time.Minute {
+		t.Errorf("account should be locked for at most a minute, got %s", lockedFor)
+	}
+}
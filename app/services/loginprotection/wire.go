@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loginprotection
+
+import (
+	"github.com/harness/gitness/app/services/instancesettings"
+	"github.com/harness/gitness/app/store"
+
+	"github.com/google/wire"
+	"github.com/rs/zerolog/log"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	config Config,
+	loginAttemptStore store.LoginAttemptStore,
+	instanceSettings *instancesettings.Service,
+) (*Service, error) {
+	s, err := NewService(config, loginAttemptStore)
+	if err != nil {
+		return nil, err
+	}
+
+	// the lockout can be changed at runtime via the instance settings.
+	updateLockout := func() error {
+		return s.UpdateLockout(
+			instanceSettings.LoginLockoutThreshold(),
+			instanceSettings.LoginIPLockoutThreshold(),
+			instanceSettings.LoginLockoutDuration(),
+		)
+	}
+
+	if err = updateLockout(); err != nil {
+		return nil, err
+	}
+
+	instanceSettings.OnChange(func() {
+		if err := updateLockout(); err != nil {
+			log.Warn().Err(err).Msg("failed to apply changed login lockout settings")
+		}
+	})
+
+	return s, nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/harness/gitness/app/services/featureflag"
+	"github.com/harness/gitness/app/services/instancesettings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+)
+
+// Error is returned for writes that are blocked while maintenance mode is enabled.
+type Error struct {
+	Message string
+	// RetryAfter is the delay clients are asked to wait before retrying the write.
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Service decides whether writes are blocked by maintenance mode.
+// Maintenance mode is toggled via the maintenance_mode feature flag, either for the whole instance
+// or for individual spaces, and the changes are propagated to all instances by the feature flag service.
+// A space is in maintenance mode if the flag is enabled for the instance or for the space or any of its parents,
+// so space overrides can't exempt a space from instance-wide maintenance mode.
+type Service struct {
+	featureFlags     *featureflag.Service
+	instanceSettings *instancesettings.Service
+	spaceStore       store.SpaceStore
+	repoStore        store.RepoStore
+}
+
+func NewService(
+	featureFlags *featureflag.Service,
+	instanceSettings *instancesettings.Service,
+	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
+) *Service {
+	return &Service{
+		featureFlags:     featureFlags,
+		instanceSettings: instanceSettings,
+		spaceStore:       spaceStore,
+		repoStore:        repoStore,
+	}
+}
+
+// CheckInstance returns an Error if maintenance mode is enabled for the whole instance.
+func (s *Service) CheckInstance() error {
+	return s.check(s.featureFlags.IsEnabled(featureflag.FlagMaintenanceMode))
+}
+
+// CheckSpacePath returns an Error if maintenance mode is enabled for the space with the provided path.
+func (s *Service) CheckSpacePath(spacePath string) error {
+	return s.check(s.featureFlags.IsEnabled(featureflag.FlagMaintenanceMode) ||
+		s.featureFlags.IsEnabledForSpacePath(featureflag.FlagMaintenanceMode, spacePath))
+}
+
+// CheckRepo returns an Error if maintenance mode is enabled for the repository.
+func (s *Service) CheckRepo(repo *types.Repository) error {
+	return s.check(s.featureFlags.IsEnabled(featureflag.FlagMaintenanceMode) ||
+		s.featureFlags.IsEnabledForRepo(featureflag.FlagMaintenanceMode, repo))
+}
+
+// CheckSpaceRef returns an Error if maintenance mode is enabled for the space referenced by id or path.
+func (s *Service) CheckSpaceRef(ctx context.Context, spaceRef string) error {
+	if err := s.CheckInstance(); err != nil {
+		return err
+	}
+
+	// ASSUMPTION: digits only is not a valid space path
+	if _, err := strconv.ParseInt(spaceRef, 10, 64); err != nil {
+		return s.CheckSpacePath(spaceRef)
+	}
+
+	space, err := s.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return fmt.Errorf("failed to find space for maintenance mode check: %w", err)
+	}
+
+	return s.CheckSpacePath(space.Path)
+}
+
+// CheckRepoRef returns an Error if maintenance mode is enabled for the repository referenced by id or path.
+func (s *Service) CheckRepoRef(ctx context.Context, repoRef string) error {
+	if err := s.CheckInstance(); err != nil {
+		return err
+	}
+
+	// ASSUMPTION: digits only is not a valid repo path
+	if _, err := strconv.ParseInt(repoRef, 10, 64); err != nil {
+		return s.check(s.featureFlags.IsEnabledForRepoPath(featureflag.FlagMaintenanceMode, repoRef))
+	}
+
+	repo, err := s.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return fmt.Errorf("failed to find repo for maintenance mode check: %w", err)
+	}
+
+	return s.CheckRepo(repo)
+}
+
+func (s *Service) check(enabled bool) error {
+	if !enabled {
+		return nil
+	}
+
+	return &Error{
+		Message:    s.instanceSettings.MaintenanceMessage(),
+		RetryAfter: s.instanceSettings.MaintenanceRetryAfter(),
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergemessage
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Variables available in merge commit message templates.
+const (
+	VarTitle        = "title"
+	VarNumber       = "number"
+	VarDescription  = "description"
+	VarSourceBranch = "source_branch"
+	VarTargetBranch = "target_branch"
+	VarAuthor       = "author"
+	VarCommits      = "commits"
+	VarCoAuthors    = "co_authors"
+)
+
+const (
+	delimLeft  = "{{"
+	delimRight = "}}"
+)
+
+var variableNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// Values holds the values of the template variables.
+type Values map[string]string
+
+// Template is a parsed merge commit message template.
+// Variables are referenced by their name in double braces, e.g. "{{title}}".
+type Template struct {
+	parts []part
+}
+
+type part struct {
+	text     string
+	variable string
+}
+
+// Parse parses the provided merge commit message template.
+func Parse(text string) (*Template, error) {
+	t := &Template{}
+
+	offset := 0
+	for {
+		start := strings.Index(text[offset:], delimLeft)
+		if start < 0 {
+			break
+		}
+		start += offset
+
+		end := strings.Index(text[start+len(delimLeft):], delimRight)
+		if end < 0 {
+			return nil, fmt.Errorf("variable at position %d isn't closed", start)
+		}
+		end += start + len(delimLeft)
+
+		name := strings.TrimSpace(text[start+len(delimLeft) : end])
+		if !variableNameRegex.MatchString(name) {
+			return nil, fmt.Errorf("invalid variable name %q at position %d", name, start)
+		}
+
+		if start > offset {
+			t.parts = append(t.parts, part{text: text[offset:start]})
+		}
+		t.parts = append(t.parts, part{variable: name})
+
+		offset = end + len(delimRight)
+	}
+
+	if offset < len(text) {
+		t.parts = append(t.parts, part{text: text[offset:]})
+	}
+
+	return t, nil
+}
+
+// Uses returns true if the template references the variable.
+func (t *Template) Uses(variable string) bool {
+	for _, p := range t.parts {
+		if p.variable == variable {
+			return true
+		}
+	}
+	return false
+}
+
+// Render renders the template with the provided values.
+// Variables without a value are rendered as empty, a warning is returned for each of them.
+func (t *Template) Render(values Values) (string, []string) {
+	var warnings []string
+	sb := strings.Builder{}
+	for _, p := range t.parts {
+		if p.variable == "" {
+			sb.WriteString(p.text)
+			continue
+		}
+
+		value, ok := values[p.variable]
+		if !ok {
+			warning := fmt.Sprintf("Unknown variable %q is rendered as empty.", p.variable)
+			if !slices.Contains(warnings, warning) {
+				warnings = append(warnings, warning)
+			}
+			continue
+		}
+
+		sb.WriteString(value)
+	}
+
+	return sb.String(), warnings
+}
+
+// SplitMessage splits a rendered commit message into the title (the first line) and the rest of the message.
+func SplitMessage(message string) (string, string) {
+	message = strings.TrimSpace(message)
+	title, body, _ := strings.Cut(message, "\n")
+	return strings.TrimSpace(title), strings.TrimSpace(body)
+}
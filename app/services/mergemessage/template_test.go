@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergemessage
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{name: "empty", text: ""},
+		{name: "text-only", text: "Merge it"},
+		{name: "variables", text: "{{title}} (#{{ number }})\n\n{{commits}}"},
+		{name: "closing-only", text: "a }} b"},
+		{name: "unclosed", text: "{{title} (#{{number)", wantErr: true},
+		{name: "empty-variable", text: "{{ }}", wantErr: true},
+		{name: "invalid-variable", text: "{{source branch}}", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Parse(test.text)
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Errorf("want error=%t, got err=%v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestTemplate_Render(t *testing.T) {
+	tests := []struct {
+		name         string
+		text         string
+		values       Values
+		want         string
+		wantWarnings []string
+	}{
+		{
+			name:   "variables",
+			text:   "{{title}} (#{{ number }})\n\n{{co_authors}}",
+			values: Values{VarTitle: "Add feature", VarNumber: "12", VarCoAuthors: "Co-authored-by: A <a@b.c>"},
+			want:   "Add feature (#12)\n\nCo-authored-by: A <a@b.c>",
+		},
+		{
+			name:         "unknown-variable",
+			text:         "{{title}}{{unknown}} {{unknown}}!",
+			values:       Values{VarTitle: "Fix"},
+			want:         "Fix !",
+			wantWarnings: []string{`Unknown variable "unknown" is rendered as empty.`},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tmpl, err := Parse(test.text)
+			if err != nil {
+				t.Fatalf("failed to parse: %s", err)
+			}
+
+			got, warnings := tmpl.Render(test.values)
+			if got != test.want {
+				t.Errorf("want=%q got=%q", test.want, got)
+			}
+			if !reflect.DeepEqual(warnings, test.wantWarnings) {
+				t.Errorf("warnings: want=%v got=%v", test.wantWarnings, warnings)
+			}
+		})
+	}
+}
+
+func TestSplitMessage(t *testing.T) {
+	title, body := SplitMessage("\n Title \n\n  Body line 1\nBody line 2\n")
+	if title != "Title" {
+		t.Errorf("title: got=%q", title)
+	}
+	if body != "Body line 1\nBody line 2" {
+		t.Errorf("body: got=%q", body)
+	}
+}
@@ -23,15 +23,22 @@ import (
 	"time"
 
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/http/outbound"
 	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/version"
 )
 
-const jobType = "metric-collector"
+const (
+	jobType = "metric-collector"
+
+	// tokenHeader is the header used to authenticate against the metric endpoint.
+	tokenHeader = "X-Api-Key"
+)
 
 type metricData struct {
 	IP         string `json:"ip"`
+	InstanceID string `json:"instance_id"`
 	Hostname   string `json:"hostname"`
 	Installer  string `json:"installed_by"`
 	Installed  string `json:"installed_at"`
@@ -41,6 +48,9 @@ type metricData struct {
 	Pipelines  int64  `json:"pipeline_count"`
 	Executions int64  `json:"execution_count"`
 	Gitspaces  int64  `json:"gitspace_count"`
+	Pushes     int64  `json:"push_count_30d"`
+	Branches   int64  `json:"branch_count"`
+	Tags       int64  `json:"tag_count"`
 }
 
 type Collector struct {
@@ -48,12 +58,15 @@ type Collector struct {
 	enabled             bool
 	endpoint            string
 	token               string
+	httpClient          *outbound.Client
+	installationStore   store.InstallationStore
 	userStore           store.PrincipalStore
 	repoStore           store.RepoStore
 	pipelineStore       store.PipelineStore
 	executionStore      store.ExecutionStore
 	scheduler           *job.Scheduler
 	gitspaceConfigStore store.GitspaceConfigStore
+	repoStatsStore      store.RepoStatsStore
 }
 
 func (c *Collector) Register(ctx context.Context) error {
@@ -73,15 +86,13 @@ func (c *Collector) Handle(ctx context.Context, _ string, _ job.ProgressReporter
 		return "", nil
 	}
 
-	// get first available user
-	users, err := c.userStore.ListUsers(ctx, &types.UserFilter{
-		Page: 1,
-		Size: 1,
-	})
+	installation, err := c.installationStore.Find(ctx)
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("failed to find installation record: %w", err)
 	}
-	if len(users) == 0 {
+
+	// nothing to report as long as the instance wasn't set up by its first user.
+	if installation.InstalledBy == nil {
 		return "", nil
 	}
 
@@ -104,7 +115,7 @@ func (c *Collector) Handle(ctx context.Context, _ string, _ job.ProgressReporter
 	}
 
 	// total executions in the system
-	totalExecutions, err := c.executionStore.Count(ctx, 0)
+	totalExecutions, err := c.executionStore.Count(ctx, 0, nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to get executions total count: %w", err)
 	}
@@ -115,16 +126,26 @@ func (c *Collector) Handle(ctx context.Context, _ string, _ job.ProgressReporter
 		return "", fmt.Errorf("failed to get gitspace total count: %w", err)
 	}
 
+	// push and reference totals as precomputed by the repo stats calculator
+	repoStatsTotals, err := c.repoStatsStore.Totals(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get repo stats totals: %w", err)
+	}
+
 	data := metricData{
+		InstanceID: installation.UUID,
 		Hostname:   c.hostname,
-		Installer:  users[0].Email,
-		Installed:  time.UnixMilli(users[0].Created).Format("2006-01-02 15:04:05"),
+		Installer:  installation.InstallerEmail,
+		Installed:  time.UnixMilli(installation.Installed).Format("2006-01-02 15:04:05"),
 		Version:    version.Version.String(),
 		Users:      totalUsers,
 		Repos:      totalRepos,
 		Pipelines:  totalPipelines,
 		Executions: totalExecutions,
 		Gitspaces:  totalGitspaces,
+		Pushes:     repoStatsTotals.Pushes30Days,
+		Branches:   repoStatsTotals.Branches,
+		Tags:       repoStatsTotals.Tags,
 	}
 
 	buf := new(bytes.Buffer)
@@ -133,31 +154,21 @@ func (c *Collector) Handle(ctx context.Context, _ string, _ job.ProgressReporter
 		return "", fmt.Errorf("failed to encode metric data: %w", err)
 	}
 
-	endpoint := fmt.Sprintf("%s?api_key=%s", c.endpoint, c.token)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, buf)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, buf)
 	if err != nil {
-		return "", fmt.Errorf("failed to create a request for metric data to endpoint %s: %w", endpoint, err)
+		return "", fmt.Errorf("failed to create a request for metric data to endpoint %s: %w", c.endpoint, err)
 	}
 	req.Header.Add("Accept", "application/json")
 	req.Header.Add("Content-Type", "application/json")
+	// the token is sent as header to ensure it doesn't end up in any logs or error messages.
+	req.Header.Add(tokenHeader, c.token)
 
-	res, err := httpClient.Do(req)
+	res, err := c.httpClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to send metric data to endpoint %s: %w", endpoint, err)
+		return "", fmt.Errorf("failed to send metric data to endpoint %s: %w", c.endpoint, err)
 	}
 
 	res.Body.Close()
 
 	return res.Status, nil
 }
-
-// httpClient should be used for HTTP requests. It
-// is configured with a timeout for reliability.
-var httpClient = &http.Client{
-	Transport: &http.Transport{
-		Proxy:               http.ProxyFromEnvironment,
-		TLSHandshakeTimeout: 30 * time.Second,
-		DisableKeepAlives:   true,
-	},
-	Timeout: 1 * time.Minute,
-}
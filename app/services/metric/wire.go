@@ -15,7 +15,10 @@
 package metric
 
 import (
+	"fmt"
+
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/http/outbound"
 	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/types"
 
@@ -28,6 +31,8 @@ var WireSet = wire.NewSet(
 
 func ProvideCollector(
 	config *types.Config,
+	outboundConfig outbound.Config,
+	installationStore store.InstallationStore,
 	userStore store.PrincipalStore,
 	repoStore store.RepoStore,
 	pipelineStore store.PipelineStore,
@@ -35,21 +40,35 @@ func ProvideCollector(
 	scheduler *job.Scheduler,
 	executor *job.Executor,
 	gitspaceConfigStore store.GitspaceConfigStore,
+	repoStatsStore store.RepoStatsStore,
 ) (*Collector, error) {
+	// the metric endpoint is configured by the operator, no need to guard against internal destinations.
+	clientConfig := outboundConfig
+	clientConfig.Name = "metric"
+	clientConfig.AllowLoopback = outbound.AllowAlways
+	clientConfig.AllowPrivateNetwork = outbound.AllowAlways
+	httpClient, err := outbound.New(clientConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metric http client: %w", err)
+	}
+
 	job := &Collector{
 		hostname:            config.InstanceID,
 		enabled:             config.Metric.Enabled,
 		endpoint:            config.Metric.Endpoint,
 		token:               config.Metric.Token,
+		httpClient:          httpClient,
+		installationStore:   installationStore,
 		userStore:           userStore,
 		repoStore:           repoStore,
 		pipelineStore:       pipelineStore,
 		executionStore:      executionStore,
 		scheduler:           scheduler,
 		gitspaceConfigStore: gitspaceConfigStore,
+		repoStatsStore:      repoStatsStore,
 	}
 
-	err := executor.Register(jobType, job)
+	err = executor.Register(jobType, job)
 	if err != nil {
 		return nil, err
 	}
@@ -156,9 +156,11 @@ func mapToBranchRules(
 		},
 
 		PullReq: protection.DefPullReq{
-			Approvals:    protection.DefApprovals(rule.PullReq.Approvals),
-			Comments:     protection.DefComments(rule.PullReq.Comments),
-			StatusChecks: protection.DefStatusChecks(rule.PullReq.StatusChecks),
+			Approvals: protection.DefApprovals(rule.PullReq.Approvals),
+			Comments:  protection.DefComments(rule.PullReq.Comments),
+			StatusChecks: protection.DefStatusChecks{
+				RequireIdentifiers: rule.PullReq.StatusChecks.RequireIdentifiers,
+			},
 			Merge: protection.DefMerge{
 				StrategiesAllowed: convertMergeMethods(rule.PullReq.Merge.StrategiesAllowed),
 				DeleteBranch:      rule.PullReq.Merge.DeleteBranch,
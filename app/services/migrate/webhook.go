@@ -20,7 +20,7 @@ import (
 	"time"
 
 	webhookpkg "github.com/harness/gitness/app/api/controller/webhook"
-	"github.com/harness/gitness/app/services/webhook"
+	"github.com/harness/gitness/app/services/instancesettings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
@@ -30,24 +30,22 @@ import (
 
 // Webhook is webhook migrate.
 type Webhook struct {
-	// webhook configs
-	allowLoopback       bool
-	allowPrivateNetwork bool
+	// instanceSettings provide the webhook target restrictions.
+	instanceSettings *instancesettings.Service
 
 	tx           dbtx.Transactor
 	webhookStore store.WebhookStore
 }
 
 func NewWebhook(
-	config webhook.Config,
+	instanceSettings *instancesettings.Service,
 	tx dbtx.Transactor,
 	webhookStore store.WebhookStore,
 ) *Webhook {
 	return &Webhook{
-		allowLoopback:       config.AllowLoopback,
-		allowPrivateNetwork: config.AllowPrivateNetwork,
-		tx:                  tx,
-		webhookStore:        webhookStore,
+		instanceSettings: instanceSettings,
+		tx:               tx,
+		webhookStore:     webhookStore,
 	}
 }
 
@@ -60,10 +58,13 @@ func (migrate Webhook) Import(
 	now := time.Now().UnixMilli()
 	hooks := make([]*types.Webhook, len(extWebhooks))
 
+	allowLoopback := migrate.instanceSettings.WebhookAllowLoopback()
+	allowPrivateNetwork := migrate.instanceSettings.WebhookAllowPrivateNetwork()
+
 	// sanitize and convert webhooks
 	for i, whook := range extWebhooks {
 		triggers := webhookpkg.ConvertTriggers(whook.Events)
-		err := sanitizeWebhook(whook, triggers, migrate.allowLoopback, migrate.allowPrivateNetwork)
+		err := sanitizeWebhook(whook, triggers, allowLoopback, allowPrivateNetwork)
 		if err != nil {
 			return nil, fmt.Errorf("failed to sanitize external webhook input: %w", err)
 		}
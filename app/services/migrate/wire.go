@@ -15,7 +15,7 @@
 package migrate
 
 import (
-	"github.com/harness/gitness/app/services/webhook"
+	"github.com/harness/gitness/app/services/instancesettings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/git"
@@ -51,9 +51,9 @@ func ProvideRuleImporter(
 }
 
 func ProvideWebhookImporter(
-	config webhook.Config,
+	instanceSettings *instancesettings.Service,
 	tx dbtx.Transactor,
 	webhookStore store.WebhookStore,
 ) *Webhook {
-	return NewWebhook(config, tx, webhookStore)
+	return NewWebhook(instanceSettings, tx, webhookStore)
 }
@@ -32,6 +32,10 @@ const (
 	TemplatePullReqBranchUpdated = "pullreq_branch_updated.html"
 	TemplateNameReviewSubmitted  = "review_submitted.html"
 	TemplatePullReqStateChanged  = "pullreq_state_changed.html"
+	TemplateDigest               = "digest.html"
+	TemplateEmailChange          = "email_change.html"
+	TemplatePasswordReset        = "password_reset.html"
+	TemplateMailTest             = "mail_test.html"
 )
 
 type MailClient struct {
@@ -178,38 +182,71 @@ func GetSubjectPullRequest(
 }
 
 func GetHTMLBody(templateName string, data interface{}) ([]byte, error) {
-	tmpl := htmlTemplates[templateName]
+	tmpl, ok := htmlTemplates[templateName]
+	if !ok {
+		return nil, fmt.Errorf("unknown template %s", templateName)
+	}
+
+	tmplOutput := bytes.Buffer{}
+	err := tmpl.Execute(&tmplOutput, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute template %s: %w", templateName, err)
+	}
+
+	return tmplOutput.Bytes(), nil
+}
+
+// GetTextBody renders the plain-text alternative of the provided HTML template.
+func GetTextBody(templateName string, data interface{}) ([]byte, error) {
+	name := textTemplateName(templateName)
+	tmpl, ok := textTemplates[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown template %s", name)
+	}
+
 	tmplOutput := bytes.Buffer{}
 	err := tmpl.Execute(&tmplOutput, data)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute template %s", templateName)
+		return nil, fmt.Errorf("failed to execute template %s: %w", name, err)
 	}
 
 	return tmplOutput.Bytes(), nil
 }
 
+// GenerateBody renders the HTML body and its plain-text alternative of the provided template.
+func GenerateBody(templateName string, data interface{}) (*mailer.Payload, error) {
+	htmlBody, err := GetHTMLBody(templateName, data)
+	if err != nil {
+		return nil, err
+	}
+
+	textBody, err := GetTextBody(templateName, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mailer.Payload{
+		Body:      string(htmlBody),
+		PlainBody: string(textBody),
+	}, nil
+}
+
 func GenerateEmailFromPayload(
 	templateName string,
 	recipients []*types.PrincipalInfo,
 	base *BasePullReqPayload,
 	payload interface{},
 ) (*mailer.Payload, error) {
-	subject := GetSubjectPullRequest(base.Repo.Identifier, base.PullReq.Number,
-		base.PullReq.Title)
-
-	body, err := GetHTMLBody(templateName, payload)
+	email, err := GenerateBody(templateName, payload)
 	if err != nil {
 		return nil, err
 	}
 
-	var email mailer.Payload
-	email.Body = string(body)
-	email.Subject = subject
+	email.Subject = GetSubjectPullRequest(base.Repo.Identifier, base.PullReq.Number, base.PullReq.Title)
 	email.RepoRef = base.Repo.Path
+	email.ToRecipients = RetrieveEmailsFromPrincipals(recipients)
 
-	recipientEmails := RetrieveEmailsFromPrincipals(recipients)
-	email.ToRecipients = recipientEmails
-	return &email, nil
+	return email, nil
 }
 
 func RetrieveEmailsFromPrincipals(principals []*types.PrincipalInfo) []string {
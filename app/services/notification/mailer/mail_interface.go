@@ -21,20 +21,28 @@ import (
 )
 
 const (
-	mailContentType = "text/html"
+	mailContentType      = "text/html"
+	mailContentTypePlain = "text/plain"
 )
 
 type Mailer interface {
 	Send(ctx context.Context, mailPayload Payload) error
 }
 
+// Transport delivers emails synchronously, without going through the send queue.
+type Transport interface {
+	Mailer
+}
+
 type Payload struct {
 	CCRecipients []string
 	ToRecipients []string
 	Subject      string
 	Body         string
-	ContentType  string
-	RepoRef      string
+	// PlainBody is the plain-text alternative of the HTML body.
+	PlainBody   string
+	ContentType string
+	RepoRef     string
 }
 
 func ToGoMail(dto Payload) *gomail.Message {
@@ -42,6 +50,11 @@ func ToGoMail(dto Payload) *gomail.Message {
 	mail.SetHeader("To", dto.ToRecipients...)
 	mail.SetHeader("Cc", dto.CCRecipients...)
 	mail.SetHeader("Subject", dto.Subject)
+	if dto.PlainBody != "" {
+		mail.SetBody(mailContentTypePlain, dto.PlainBody)
+		mail.AddAlternative(mailContentType, dto.Body)
+		return mail
+	}
 	mail.SetBody(mailContentType, dto.Body)
 	return mail
 }
@@ -0,0 +1,31 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mailer
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+// NoopMailer is used when email delivery isn't configured. It drops all emails.
+type NoopMailer struct{}
+
+func (NoopMailer) Send(ctx context.Context, mailPayload Payload) error {
+	log.Ctx(ctx).Debug().
+		Str("subject", mailPayload.Subject).
+		Msg("email delivery is disabled, dropping email")
+	return nil
+}
@@ -0,0 +1,176 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mailer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const jobTypeMailSend = "mail-send"
+
+// Queue is a Mailer that sends emails asynchronously using the job system.
+// Every recipient gets a separate copy of the email, so the delivery to each of them
+// is retried independently and failures can be tracked per recipient.
+type Queue struct {
+	transport    Transport
+	scheduler    *job.Scheduler
+	failureStore store.MailFailureStore
+	maxRetries   int
+	timeout      time.Duration
+}
+
+type queuedMail struct {
+	JobUID    string `json:"job_uid"`
+	Recipient string `json:"recipient"`
+	Subject   string `json:"subject"`
+	Body      string `json:"body"`
+	PlainBody string `json:"plain_body"`
+	RepoRef   string `json:"repo_ref,omitempty"`
+}
+
+func NewQueue(
+	transport Transport,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	failureStore store.MailFailureStore,
+	maxRetries int,
+	timeout time.Duration,
+) (*Queue, error) {
+	q := &Queue{
+		transport:    transport,
+		scheduler:    scheduler,
+		failureStore: failureStore,
+		maxRetries:   maxRetries,
+		timeout:      timeout,
+	}
+
+	if err := executor.Register(jobTypeMailSend, q); err != nil {
+		return nil, fmt.Errorf("failed to register mail send job handler: %w", err)
+	}
+
+	return q, nil
+}
+
+// Send enqueues the email for delivery to all its recipients.
+func (q *Queue) Send(ctx context.Context, mailPayload Payload) error {
+	recipients := uniqueRecipients(mailPayload)
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	groupID, err := job.UID()
+	if err != nil {
+		return fmt.Errorf("failed to generate job group UID: %w", err)
+	}
+
+	defs := make([]job.Definition, len(recipients))
+	for i, recipient := range recipients {
+		jobUID, err := job.UID()
+		if err != nil {
+			return fmt.Errorf("failed to generate job UID: %w", err)
+		}
+
+		data, err := json.Marshal(queuedMail{
+			JobUID:    jobUID,
+			Recipient: recipient,
+			Subject:   mailPayload.Subject,
+			Body:      mailPayload.Body,
+			PlainBody: mailPayload.PlainBody,
+			RepoRef:   mailPayload.RepoRef,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to marshal mail send job data: %w", err)
+		}
+
+		defs[i] = job.Definition{
+			UID:        jobUID,
+			Type:       jobTypeMailSend,
+			MaxRetries: q.maxRetries,
+			Timeout:    q.timeout,
+			Data:       string(data),
+		}
+	}
+
+	if err = q.scheduler.RunJobs(ctx, groupID, defs); err != nil {
+		return fmt.Errorf("failed to enqueue email: %w", err)
+	}
+
+	return nil
+}
+
+// Handle delivers a single queued email. Failed attempts are recorded before the job is retried.
+func (q *Queue) Handle(ctx context.Context, input string, _ job.ProgressReporter) (string, error) {
+	mail := &queuedMail{}
+	if err := json.Unmarshal([]byte(input), mail); err != nil {
+		return "", fmt.Errorf("failed to unmarshal mail send job data: %w", err)
+	}
+
+	err := q.transport.Send(ctx, Payload{
+		ToRecipients: []string{mail.Recipient},
+		Subject:      mail.Subject,
+		Body:         mail.Body,
+		PlainBody:    mail.PlainBody,
+		RepoRef:      mail.RepoRef,
+	})
+	if err == nil {
+		return "", nil
+	}
+
+	now := time.Now().UnixMilli()
+	failure := &types.MailFailure{
+		JobUID:    mail.JobUID,
+		Recipient: mail.Recipient,
+		Subject:   mail.Subject,
+		Error:     err.Error(),
+		Created:   now,
+		Updated:   now,
+	}
+	if errRecord := q.failureStore.Record(ctx, failure); errRecord != nil {
+		log.Ctx(ctx).Warn().Err(errRecord).Msg("failed to record mail delivery failure")
+	}
+
+	return "", fmt.Errorf("failed to send email: %w", err)
+}
+
+// uniqueRecipients returns all recipients of the email, ignoring duplicates and empty addresses.
+func uniqueRecipients(mailPayload Payload) []string {
+	seen := make(map[string]struct{})
+	recipients := make([]string, 0, len(mailPayload.ToRecipients)+len(mailPayload.CCRecipients))
+	for _, list := range [][]string{mailPayload.ToRecipients, mailPayload.CCRecipients} {
+		for _, recipient := range list {
+			key := strings.ToLower(strings.TrimSpace(recipient))
+			if key == "" {
+				continue
+			}
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			recipients = append(recipients, recipient)
+		}
+	}
+
+	return recipients
+}
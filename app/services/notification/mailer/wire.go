@@ -15,6 +15,8 @@
 package mailer
 
 import (
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
@@ -22,9 +24,16 @@ import (
 
 var WireSet = wire.NewSet(
 	ProvideMailClient,
+	ProvideMailer,
 )
 
-func ProvideMailClient(config *types.Config) Mailer {
+// ProvideMailClient provides the transport that sends emails directly to the SMTP server.
+// Email delivery is disabled if no SMTP host is configured.
+func ProvideMailClient(config *types.Config) Transport {
+	if config.SMTP.Host == "" {
+		return NoopMailer{}
+	}
+
 	return NewMailClient(
 		config.SMTP.Host,
 		config.SMTP.Port,
@@ -34,3 +43,18 @@ func ProvideMailClient(config *types.Config) Mailer {
 		config.SMTP.Insecure, // #nosec G402 (insecure skipVerify configuration)
 	)
 }
+
+// ProvideMailer provides the mailer used by the application, which queues emails for asynchronous delivery.
+func ProvideMailer(
+	config *types.Config,
+	transport Transport,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	failureStore store.MailFailureStore,
+) (Mailer, error) {
+	if config.SMTP.Host == "" {
+		return NoopMailer{}, nil
+	}
+
+	return NewQueue(transport, scheduler, executor, failureStore, config.SMTP.MaxRetries, config.SMTP.SendTimeout)
+}
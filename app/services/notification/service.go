@@ -21,6 +21,8 @@ import (
 	"html/template"
 	"io/fs"
 	"path"
+	"strings"
+	texttemplate "text/template"
 
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/store"
@@ -33,6 +35,8 @@ import (
 const (
 	eventReaderGroupName = "gitness:notification"
 	templatesDir         = "templates"
+	htmlTemplateExt      = ".html"
+	textTemplateExt      = ".txt"
 	subjectPullReqEvent  = "[%s] %s (PR #%d)"
 )
 
@@ -40,6 +44,7 @@ var (
 	//go:embed  templates/*
 	files         embed.FS
 	htmlTemplates map[string]*template.Template
+	textTemplates map[string]*texttemplate.Template
 )
 
 func init() {
@@ -49,8 +54,11 @@ func init() {
 	}
 }
 
+// LoadTemplates parses all embedded templates. Every HTML template must be accompanied
+// by a plain-text template with the same name, which is used as the alternative body.
 func LoadTemplates() error {
 	htmlTemplates = make(map[string]*template.Template)
+	textTemplates = make(map[string]*texttemplate.Template)
 	tmplFiles, err := fs.ReadDir(files, templatesDir)
 	if err != nil {
 		return err
@@ -61,16 +69,39 @@ func LoadTemplates() error {
 			continue
 		}
 
-		pt, err := template.ParseFS(files, path.Join(templatesDir, tmpl.Name()))
-		if err != nil {
-			return err
+		switch path.Ext(tmpl.Name()) {
+		case htmlTemplateExt:
+			pt, err := template.ParseFS(files, path.Join(templatesDir, tmpl.Name()))
+			if err != nil {
+				return err
+			}
+
+			htmlTemplates[tmpl.Name()] = pt
+		case textTemplateExt:
+			pt, err := texttemplate.ParseFS(files, path.Join(templatesDir, tmpl.Name()))
+			if err != nil {
+				return err
+			}
+
+			textTemplates[tmpl.Name()] = pt
+		default:
+			return fmt.Errorf("unknown template file type %q", tmpl.Name())
 		}
+	}
 
-		htmlTemplates[tmpl.Name()] = pt
+	for name := range htmlTemplates {
+		if _, ok := textTemplates[textTemplateName(name)]; !ok {
+			return fmt.Errorf("template %s has no plain-text alternative", name)
+		}
 	}
+
 	return nil
 }
 
+func textTemplateName(htmlTemplateName string) string {
+	return strings.TrimSuffix(htmlTemplateName, htmlTemplateExt) + textTemplateExt
+}
+
 type BasePullReqPayload struct {
 	Repo       *types.Repository
 	PullReq    *types.PullReq
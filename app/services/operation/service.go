@@ -0,0 +1,216 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ErrNotFound is returned if the operation doesn't exist, or if the job executing it has already been purged.
+var ErrNotFound = errors.New("operation not found")
+
+// Service keeps track of long-running operations. An operation is executed by a single background job
+// or by a group of background jobs, and its state is derived from the state of the jobs.
+type Service struct {
+	operationStore store.OperationStore
+	scheduler      *job.Scheduler
+}
+
+func NewService(
+	operationStore store.OperationStore,
+	scheduler *job.Scheduler,
+) *Service {
+	return &Service{
+		operationStore: operationStore,
+		scheduler:      scheduler,
+	}
+}
+
+// CreateInput describes the operation that should be tracked.
+// Exactly one of JobUID and JobGroupID must be set.
+type CreateInput struct {
+	Type         enum.OperationType
+	ResourceType enum.ParentResourceType
+	ResourceID   int64
+	JobUID       string
+	JobGroupID   string
+	CreatedBy    int64
+}
+
+// Status is the current status of an operation.
+type Status struct {
+	State    enum.OperationState
+	Progress int
+	// Jobs is the number of jobs that execute the operation.
+	Jobs int
+	// Failure contains the failure message of a failed operation.
+	Failure string
+}
+
+// Create starts tracking of an operation whose jobs have already been scheduled.
+func (s *Service) Create(ctx context.Context, in CreateInput) (*types.Operation, error) {
+	if (in.JobUID == "") == (in.JobGroupID == "") {
+		return nil, errors.New("operation requires either a job uid or a job group id")
+	}
+
+	uid, err := job.UID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate operation uid: %w", err)
+	}
+
+	op := &types.Operation{
+		UID:          strings.ToLower(uid),
+		Type:         in.Type,
+		ResourceType: in.ResourceType,
+		ResourceID:   in.ResourceID,
+		JobUID:       in.JobUID,
+		JobGroupID:   in.JobGroupID,
+		CreatedBy:    in.CreatedBy,
+		Created:      time.Now().UnixMilli(),
+	}
+
+	if err = s.operationStore.Create(ctx, op); err != nil {
+		return nil, fmt.Errorf("failed to create operation: %w", err)
+	}
+
+	return op, nil
+}
+
+// Find returns the operation with the provided UID.
+func (s *Service) Find(ctx context.Context, uid string) (*types.Operation, error) {
+	op, err := s.operationStore.FindByUID(ctx, uid)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find operation: %w", err)
+	}
+
+	return op, nil
+}
+
+// GetStatus returns the current status of the operation.
+func (s *Service) GetStatus(ctx context.Context, op *types.Operation) (Status, error) {
+	var progress []job.Progress
+
+	if op.JobUID != "" {
+		p, err := s.scheduler.GetJobProgress(ctx, op.JobUID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return Status{}, ErrNotFound
+		}
+		if err != nil {
+			return Status{}, fmt.Errorf("failed to get job progress: %w", err)
+		}
+
+		progress = []job.Progress{p}
+	} else {
+		var err error
+		progress, err = s.scheduler.GetJobProgressForGroup(ctx, op.JobGroupID)
+		if err != nil {
+			return Status{}, fmt.Errorf("failed to get job progress for group: %w", err)
+		}
+	}
+
+	return statusFromProgress(progress), nil
+}
+
+// Cancel requests cancellation of all jobs of the operation that haven't completed yet.
+func (s *Service) Cancel(ctx context.Context, op *types.Operation) error {
+	if op.JobUID != "" {
+		if err := s.scheduler.CancelJob(ctx, op.JobUID); err != nil {
+			return fmt.Errorf("failed to cancel job: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := s.scheduler.CancelJobsByGroupID(ctx, op.JobGroupID); err != nil {
+		return fmt.Errorf("failed to cancel jobs of group: %w", err)
+	}
+
+	return nil
+}
+
+// statusFromProgress combines the progress of all jobs of an operation into the operation status.
+// The operation is pending until any of the jobs has started, and running until all jobs have completed.
+// A completed operation failed if any of its jobs failed, and is cancelled if any of its jobs got canceled.
+// An operation without any jobs (e.g. an export of a space without repositories) succeeded right away.
+func statusFromProgress(progress []job.Progress) Status {
+	var (
+		total     int
+		started   bool
+		completed = true
+		failed    bool
+		canceled  bool
+		failure   string
+	)
+
+	for _, p := range progress {
+		total += p.Progress
+
+		switch p.State {
+		case job.JobStateScheduled:
+			completed = false
+		case job.JobStateRunning:
+			completed = false
+			started = true
+		case job.JobStateFinished:
+			started = true
+		case job.JobStateFailed:
+			started = true
+			failed = true
+			if failure == "" {
+				failure = p.Failure
+			}
+		case job.JobStateCanceled:
+			started = true
+			canceled = true
+		}
+	}
+
+	status := Status{
+		Jobs: len(progress),
+	}
+	if len(progress) > 0 {
+		status.Progress = total / len(progress)
+	}
+
+	switch {
+	case !completed && !started:
+		status.State = enum.OperationStatePending
+	case !completed:
+		status.State = enum.OperationStateRunning
+	case failed:
+		status.State = enum.OperationStateFailed
+		status.Failure = failure
+	case canceled:
+		status.State = enum.OperationStateCancelled
+	default:
+		status.State = enum.OperationStateSucceeded
+		status.Progress = job.ProgressMax
+	}
+
+	return status
+}
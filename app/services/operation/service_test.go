@@ -0,0 +1,83 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operation
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestStatusFromProgress(t *testing.T) {
+	tests := []struct {
+		name     string
+		progress []job.Progress
+		want     Status
+	}{
+		{
+			name:     "pending",
+			progress: []job.Progress{{State: job.JobStateScheduled}},
+			want:     Status{State: enum.OperationStatePending, Jobs: 1},
+		},
+		{
+			name:     "running",
+			progress: []job.Progress{{State: job.JobStateRunning, Progress: 40}},
+			want:     Status{State: enum.OperationStateRunning, Progress: 40, Jobs: 1},
+		},
+		{
+			name: "running-group",
+			progress: []job.Progress{
+				{State: job.JobStateFinished, Progress: 100},
+				{State: job.JobStateScheduled},
+			},
+			want: Status{State: enum.OperationStateRunning, Progress: 50, Jobs: 2},
+		},
+		{
+			name: "succeeded-empty-group",
+			want: Status{State: enum.OperationStateSucceeded, Progress: 100},
+		},
+		{
+			name:     "succeeded",
+			progress: []job.Progress{{State: job.JobStateFinished, Progress: 90}},
+			want:     Status{State: enum.OperationStateSucceeded, Progress: 100, Jobs: 1},
+		},
+		{
+			name: "failed-group",
+			progress: []job.Progress{
+				{State: job.JobStateFinished, Progress: 100},
+				{State: job.JobStateCanceled, Progress: 20},
+				{State: job.JobStateFailed, Progress: 60, Failure: "clone failed"},
+			},
+			want: Status{State: enum.OperationStateFailed, Progress: 60, Jobs: 3, Failure: "clone failed"},
+		},
+		{
+			name: "cancelled-group",
+			progress: []job.Progress{
+				{State: job.JobStateFinished, Progress: 100},
+				{State: job.JobStateCanceled, Progress: 20},
+			},
+			want: Status{State: enum.OperationStateCancelled, Progress: 60, Jobs: 2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := statusFromProgress(test.progress); got != test.want {
+				t.Errorf("want %+v, got %+v", test.want, got)
+			}
+		})
+	}
+}
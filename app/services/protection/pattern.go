@@ -72,6 +72,18 @@ func (p *Pattern) Matches(branchName, defaultName string) bool {
 	return matches
 }
 
+// matchedBy returns the include pattern matching the name.
+// If the pattern doesn't have include patterns, "**" is returned as the pattern matches everything.
+func (p *Pattern) matchedBy(name string) string {
+	for _, include := range p.Include {
+		if patternMatches(include, name) {
+			return include
+		}
+	}
+
+	return "**"
+}
+
 func patternValidate(pattern string) error {
 	if pattern == "" {
 		return ErrPatternEmpty
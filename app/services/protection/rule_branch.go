@@ -65,7 +65,9 @@ func (v *Branch) RequiredChecks(
 
 	ids := out.RequiredIdentifiers
 	if len(ids) == 0 {
-		return RequiredChecksOutput{}, nil
+		return RequiredChecksOutput{
+			OptionalIdentifiers: out.OptionalIdentifiers,
+		}, nil
 	}
 
 	var (
@@ -82,6 +84,7 @@ func (v *Branch) RequiredChecks(
 	return RequiredChecksOutput{
 		RequiredIdentifiers:   requiredIDs,
 		BypassableIdentifiers: bypassableIDs,
+		OptionalIdentifiers:   out.OptionalIdentifiers,
 	}, nil
 }
 
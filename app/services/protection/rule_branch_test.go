@@ -362,6 +362,22 @@ func TestBranch_RequiredChecks(t *testing.T) {
 				BypassableIdentifiers: nil,
 			},
 		},
+		{
+			name: "optional-only",
+			branch: Branch{
+				PullReq: DefPullReq{
+					StatusChecks: DefStatusChecks{OptionalIdentifiers: []string{"abc"}},
+				},
+			},
+			in: RequiredChecksInput{
+				Actor: user,
+			},
+			expOut: RequiredChecksOutput{
+				RequiredIdentifiers:   nil,
+				BypassableIdentifiers: nil,
+				OptionalIdentifiers:   map[string]struct{}{"abc": {}},
+			},
+		},
 	}
 
 	ctx := context.Background()
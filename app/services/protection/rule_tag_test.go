@@ -0,0 +1,189 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// nolint:gocognit // it's a unit test
+func TestDefTagLifecycle_RefChangeVerify(t *testing.T) {
+	const (
+		tagName   = "v1.0.0"
+		commitSHA = "7e50b8d32da4a2ba8d3a3d0d6e2fd4b4f1ec7b71"
+	)
+
+	pattern := Pattern{Include: []string{"v*"}}
+
+	tests := []struct {
+		name        string
+		def         DefTagLifecycle
+		action      RefAction
+		target      TagTarget
+		permitted   bool
+		onProtected bool
+		expCodes    []string
+		expParams   [][]any
+	}{
+		{
+			name:   "create-empty",
+			action: RefActionCreate,
+		},
+		{
+			name:      "delete-fail",
+			action:    RefActionDelete,
+			expCodes:  []string{"tag.delete"},
+			expParams: [][]any{{tagName, "v*"}},
+		},
+		{
+			name:      "update-fail",
+			action:    RefActionUpdate,
+			expCodes:  []string{"tag.update"},
+			expParams: [][]any{{tagName, "v*"}},
+		},
+		{
+			name:      "create-permission-fail",
+			def:       DefTagLifecycle{CreatePermission: enum.PermissionRepoEdit},
+			action:    RefActionCreate,
+			expCodes:  []string{"tag.create.permission"},
+			expParams: [][]any{{tagName, "v*", enum.PermissionRepoEdit}},
+		},
+		{
+			name:      "create-permission-success",
+			def:       DefTagLifecycle{CreatePermission: enum.PermissionRepoEdit},
+			action:    RefActionCreate,
+			permitted: true,
+		},
+		{
+			name:      "create-lightweight-fail",
+			def:       DefTagLifecycle{RequireAnnotated: true},
+			action:    RefActionCreate,
+			target:    TagTarget{Annotated: false, CommitSHA: commitSHA},
+			expCodes:  []string{"tag.create.annotated"},
+			expParams: [][]any{{tagName, "v*"}},
+		},
+		{
+			name:   "create-annotated-success",
+			def:    DefTagLifecycle{RequireAnnotated: true},
+			action: RefActionCreate,
+			target: TagTarget{Annotated: true, CommitSHA: commitSHA},
+		},
+		{
+			name:      "create-protected-branch-fail",
+			def:       DefTagLifecycle{RequireProtectedBranch: true},
+			action:    RefActionCreate,
+			target:    TagTarget{CommitSHA: commitSHA},
+			expCodes:  []string{"tag.create.protected_branch"},
+			expParams: [][]any{{tagName, "v*"}},
+		},
+		{
+			name:        "create-protected-branch-success",
+			def:         DefTagLifecycle{RequireProtectedBranch: true},
+			action:      RefActionCreate,
+			target:      TagTarget{CommitSHA: commitSHA},
+			onProtected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := RefChangeVerifyInput{
+				RefNames:   []string{tagName},
+				RefAction:  test.action,
+				RefType:    RefTypeTag,
+				TagTargets: map[string]TagTarget{tagName: test.target},
+				HasPermission: func(context.Context, enum.Permission) (bool, error) {
+					return test.permitted, nil
+				},
+				ReachableFromBranches: func(_ context.Context, sha string, filter func(string) bool) (bool, error) {
+					return sha == commitSHA && filter("main"), nil
+				},
+				pattern: pattern,
+				isProtectedBranch: func(string) bool {
+					return test.onProtected
+				},
+			}
+
+			if err := test.def.Sanitize(); err != nil {
+				t.Errorf("def invalid: %s", err.Error())
+				return
+			}
+
+			violations, err := test.def.RefChangeVerify(context.Background(), in)
+			if err != nil {
+				t.Errorf("got an error: %s", err.Error())
+				return
+			}
+
+			inspectBranchViolations(t, test.expCodes, test.expParams, violations)
+		})
+	}
+}
+
+func TestTag_RefChangeVerify_Bypass(t *testing.T) {
+	const tagName = "v1.0.0"
+
+	actor := &types.Principal{ID: 42}
+	tag := Tag{
+		Bypass:    DefBypass{UserIDs: []int64{actor.ID}},
+		Lifecycle: DefTagLifecycle{CreatePermission: enum.PermissionRepoEdit},
+	}
+
+	tests := []struct {
+		name        string
+		action      RefAction
+		expBypassed bool
+	}{
+		{
+			name:        "create-bypassed",
+			action:      RefActionCreate,
+			expBypassed: true,
+		},
+		{
+			name:        "delete-not-bypassed",
+			action:      RefActionDelete,
+			expBypassed: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			violations, err := tag.RefChangeVerify(context.Background(), RefChangeVerifyInput{
+				Actor:       actor,
+				AllowBypass: true,
+				RefAction:   test.action,
+				RefType:     RefTypeTag,
+				RefNames:    []string{tagName},
+			})
+			if err != nil {
+				t.Errorf("got an error: %s", err.Error())
+				return
+			}
+
+			if len(violations) != 1 {
+				t.Errorf("expected one rule violation, got %d", len(violations))
+				return
+			}
+
+			if want, got := test.expBypassed, violations[0].Bypassed; want != got {
+				t.Errorf("bypassed: want=%t got=%t", want, got)
+			}
+		})
+	}
+}
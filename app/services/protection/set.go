@@ -16,6 +16,7 @@ package protection
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/harness/gitness/types"
@@ -74,9 +75,15 @@ func (s ruleSet) RequiredChecks(
 	ctx context.Context,
 	in RequiredChecksInput,
 ) (RequiredChecksOutput, error) {
+	branch := in.Branch
+	if in.PullReq != nil {
+		branch = in.PullReq.TargetBranch
+	}
+
 	requiredIDMap := map[string]struct{}{}
 	bypassableIDMap := map[string]struct{}{}
-	err := s.forEachRuleMatchBranch(in.Repo.DefaultBranch, in.PullReq.TargetBranch,
+	optionalIDMap := map[string]struct{}{}
+	err := s.forEachRuleMatchBranch(in.Repo.DefaultBranch, branch,
 		func(_ *types.RuleInfoInternal, p Protection) error {
 			out, err := p.RequiredChecks(ctx, in)
 			if err != nil {
@@ -93,6 +100,9 @@ func (s ruleSet) RequiredChecks(
 				}
 				bypassableIDMap[reqCheckID] = struct{}{}
 			}
+			for optCheckID := range out.OptionalIdentifiers {
+				optionalIDMap[optCheckID] = struct{}{}
+			}
 
 			return nil
 		})
@@ -100,9 +110,19 @@ func (s ruleSet) RequiredChecks(
 		return RequiredChecksOutput{}, err
 	}
 
+	// a status check required by any of the rules isn't optional.
+	for checkID := range optionalIDMap {
+		_, required := requiredIDMap[checkID]
+		_, bypassable := bypassableIDMap[checkID]
+		if required || bypassable {
+			delete(optionalIDMap, checkID)
+		}
+	}
+
 	return RequiredChecksOutput{
 		RequiredIdentifiers:   requiredIDMap,
 		BypassableIdentifiers: bypassableIDMap,
+		OptionalIdentifiers:   optionalIDMap,
 	}, nil
 }
 
@@ -113,6 +133,11 @@ func (s ruleSet) RefChangeVerify(ctx context.Context, in RefChangeVerifyInput) (
 		func(r *types.RuleInfoInternal, p Protection, matched []string) error {
 			ruleIn := in
 			ruleIn.RefNames = matched
+			ruleIn.isProtectedBranch = s.isProtectedBranch(in.Repo.DefaultBranch)
+
+			if err := json.Unmarshal(r.Pattern, &ruleIn.pattern); err != nil {
+				return fmt.Errorf("failed to parse rule pattern: %w", err)
+			}
 
 			rVs, err := p.RefChangeVerify(ctx, ruleIn)
 			if err != nil {
@@ -186,6 +211,11 @@ func (s ruleSet) forEachRuleMatchBranch(
 	for i := range s.rules {
 		r := s.rules[i]
 
+		// tag rules never apply to branches.
+		if r.Type == TypeTag {
+			continue
+		}
+
 		matches, err := matchesName(r.Pattern, defaultBranch, branchName)
 		if err != nil {
 			return err
@@ -242,6 +272,25 @@ func (s ruleSet) forEachRuleMatchRefs(
 	return nil
 }
 
+// isProtectedBranch returns a function reporting whether a branch is protected by any of the active branch rules.
+func (s ruleSet) isProtectedBranch(defaultBranch string) func(branch string) bool {
+	return func(branch string) bool {
+		for i := range s.rules {
+			r := &s.rules[i]
+			if r.Type != TypeBranch || r.State != enum.RuleStateActive {
+				continue
+			}
+
+			matches, err := matchesName(r.Pattern, defaultBranch, branch)
+			if err == nil && matches {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
 func backFillRule(vs []types.RuleViolations, rule types.RuleInfo) []types.RuleViolations {
 	for i := range vs {
 		vs[i].Rule = rule
@@ -390,6 +390,7 @@ func TestRuleSet_RequiredChecks(t *testing.T) {
 			expOut: RequiredChecksOutput{
 				RequiredIdentifiers:   map[string]struct{}{},
 				BypassableIdentifiers: map[string]struct{}{},
+				OptionalIdentifiers:   map[string]struct{}{},
 			},
 		},
 		{
@@ -432,6 +433,48 @@ func TestRuleSet_RequiredChecks(t *testing.T) {
 			expOut: RequiredChecksOutput{
 				RequiredIdentifiers:   map[string]struct{}{"b": {}, "c": {}},
 				BypassableIdentifiers: map[string]struct{}{"a": {}},
+				OptionalIdentifiers:   map[string]struct{}{},
+			},
+		},
+		{
+			name: "optional-branch",
+			rules: []types.RuleInfoInternal{
+				{
+					RuleInfo: types.RuleInfo{
+						SpacePath:  "",
+						RepoPath:   "space/repo",
+						ID:         1,
+						Identifier: "rule1",
+						Type:       TypeBranch,
+						State:      enum.RuleStateActive,
+					},
+					Pattern: []byte(`{"default":true}`),
+					Definition: []byte(`{
+						"pullreq":{"status_checks":{"require_identifiers":["a"],"optional_identifiers":["b","c"]}}
+					}`),
+				},
+				{
+					RuleInfo: types.RuleInfo{
+						SpacePath:  "space",
+						RepoPath:   "",
+						ID:         2,
+						Identifier: "rule2",
+						Type:       TypeBranch,
+						State:      enum.RuleStateActive,
+					},
+					Pattern:    []byte(`{"default":true}`),
+					Definition: []byte(`{"pullreq":{"status_checks":{"require_identifiers":["c"]}}}`),
+				},
+			},
+			input: RequiredChecksInput{
+				Actor:  &types.Principal{ID: 1},
+				Repo:   &types.Repository{ID: 1, DefaultBranch: "main"},
+				Branch: "main",
+			},
+			expOut: RequiredChecksOutput{
+				RequiredIdentifiers:   map[string]struct{}{"a": {}, "c": {}},
+				BypassableIdentifiers: map[string]struct{}{},
+				OptionalIdentifiers:   map[string]struct{}{"b": {}},
 			},
 		},
 	}
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 type (
@@ -33,6 +34,28 @@ type (
 		RefAction   RefAction
 		RefType     RefType
 		RefNames    []string
+
+		// TagTargets describes the objects the created tags point to, by the tag name.
+		TagTargets map[string]TagTarget
+		// HasPermission reports whether the actor has the permission on the repository.
+		HasPermission func(ctx context.Context, permission enum.Permission) (bool, error)
+		// ReachableFromBranches reports whether the commit is reachable from any of the repository branches
+		// accepted by the filter.
+		ReachableFromBranches func(ctx context.Context, commitSHA string, filter func(branch string) bool) (bool, error)
+
+		// pattern is the name pattern of the rule that is verified, populated by the rule set.
+		pattern Pattern
+		// isProtectedBranch reports whether a branch is protected by any of the branch rules,
+		// populated by the rule set.
+		isProtectedBranch func(branch string) bool
+	}
+
+	// TagTarget describes the object a tag points to.
+	TagTarget struct {
+		// Annotated is true for annotated tags and false for lightweight tags.
+		Annotated bool
+		// CommitSHA is the commit the tag points to, it's empty if the tag doesn't point to a commit.
+		CommitSHA string
 	}
 
 	RefType int
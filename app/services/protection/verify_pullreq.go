@@ -63,11 +63,15 @@ type (
 		IsRepoOwner bool
 		Repo        *types.Repository
 		PullReq     *types.PullReq
+		// Branch is used to match the rules if no pull request is provided.
+		Branch string
 	}
 
 	RequiredChecksOutput struct {
 		RequiredIdentifiers   map[string]struct{}
 		BypassableIdentifiers map[string]struct{}
+		// OptionalIdentifiers are the status checks that are ignored when rolling up the status of a commit.
+		OptionalIdentifiers map[string]struct{}
 	}
 )
 
@@ -77,6 +81,9 @@ var (
 	_ MergeVerifier = (*DefPullReq)(nil)
 )
 
+// maxCodeOwnerViolationItems is the maximum number of paths and owners included in a code owners violation.
+const maxCodeOwnerViolationItems = 10
+
 const (
 	codePullReqApprovalReqMinCount              = "pullreq.approvals.require_minimum_count"
 	codePullReqApprovalReqMinCountLatest        = "pullreq.approvals.require_minimum_count:latest_commit"
@@ -165,14 +172,16 @@ func (v *DefPullReq) MergeVerify(
 			reviewDecision, approvers := getCodeOwnerApprovalStatus(entry)
 
 			if reviewDecision == enum.PullReqReviewDecisionPending {
-				violations.Addf(codePullReqApprovalReqCodeOwnersNoApproval,
-					"Code owners approval pending for %q", entry.Pattern)
+				violations.AddWithParams(codePullReqApprovalReqCodeOwnersNoApproval,
+					fmt.Sprintf("Code owners approval pending for %q", entry.Pattern),
+					entry.Pattern, getCodeOwnerPaths(entry), getCodeOwnerNames(entry))
 				continue
 			}
 
 			if reviewDecision == enum.PullReqReviewDecisionChangeReq {
-				violations.Addf(codePullReqApprovalReqCodeOwnersChangeRequested,
-					"Code owners requested changes for %q", entry.Pattern)
+				violations.AddWithParams(codePullReqApprovalReqCodeOwnersChangeRequested,
+					fmt.Sprintf("Code owners requested changes for %q", entry.Pattern),
+					entry.Pattern, getCodeOwnerPaths(entry), getCodeOwnerNames(entry))
 				continue
 			}
 
@@ -184,8 +193,9 @@ func (v *DefPullReq) MergeVerify(
 				return ev.ReviewSHA == in.PullReq.SourceSHA
 			})
 			if !latestSHAApproved {
-				violations.Addf(codePullReqApprovalReqCodeOwnersNoLatestApproval,
-					"Code owners approval pending on latest commit for %q", entry.Pattern)
+				violations.AddWithParams(codePullReqApprovalReqCodeOwnersNoLatestApproval,
+					fmt.Sprintf("Code owners approval pending on latest commit for %q", entry.Pattern),
+					entry.Pattern, getCodeOwnerPaths(entry), getCodeOwnerNames(entry))
 			}
 		}
 	}
@@ -259,8 +269,18 @@ func (v *DefPullReq) RequiredChecks(
 	for _, id := range v.StatusChecks.RequireIdentifiers {
 		m[id] = struct{}{}
 	}
+
+	var optional map[string]struct{}
+	if len(v.StatusChecks.OptionalIdentifiers) > 0 {
+		optional = make(map[string]struct{}, len(v.StatusChecks.OptionalIdentifiers))
+		for _, id := range v.StatusChecks.OptionalIdentifiers {
+			optional[id] = struct{}{}
+		}
+	}
+
 	return RequiredChecksOutput{
 		RequiredIdentifiers: m,
+		OptionalIdentifiers: optional,
 	}, nil
 }
 
@@ -293,6 +313,8 @@ func (DefComments) Sanitize() error {
 
 type DefStatusChecks struct {
 	RequireIdentifiers []string `json:"require_identifiers,omitempty"`
+	// OptionalIdentifiers are status checks that don't affect the combined status of a commit.
+	OptionalIdentifiers []string `json:"optional_identifiers,omitempty"`
 }
 
 // TODO [CODE-1363]: remove after identifier migration.
@@ -335,6 +357,16 @@ func (c *DefStatusChecks) Sanitize() error {
 		return fmt.Errorf("required identifiers error: %w", err)
 	}
 
+	if err := validateIdentifierSlice(c.OptionalIdentifiers); err != nil {
+		return fmt.Errorf("optional identifiers error: %w", err)
+	}
+
+	for _, id := range c.OptionalIdentifiers {
+		if slices.Contains(c.RequireIdentifiers, id) {
+			return fmt.Errorf("status check %q can't be both required and optional", id)
+		}
+	}
+
 	return nil
 }
 
@@ -429,3 +461,26 @@ func getCodeOwnerApprovalStatus(
 	}
 	return enum.PullReqReviewDecisionPending, nil
 }
+
+// getCodeOwnerPaths returns the changed paths owned by the code owners entry,
+// limited to maxCodeOwnerViolationItems entries.
+func getCodeOwnerPaths(entry codeowners.EvaluationEntry) []string {
+	paths := make([]string, min(len(entry.Paths), maxCodeOwnerViolationItems))
+	copy(paths, entry.Paths)
+
+	return paths
+}
+
+// getCodeOwnerNames returns the names of the owners of the code owners entry,
+// limited to maxCodeOwnerViolationItems entries.
+func getCodeOwnerNames(entry codeowners.EvaluationEntry) []string {
+	names := make([]string, 0, len(entry.OwnerEvaluations)+len(entry.UserGroupOwnerEvaluations))
+	for _, o := range entry.OwnerEvaluations {
+		names = append(names, o.Owner.DisplayName)
+	}
+	for _, u := range entry.UserGroupOwnerEvaluations {
+		names = append(names, "@"+u.Identifier)
+	}
+
+	return names[:min(len(names), maxCodeOwnerViolationItems)]
+}
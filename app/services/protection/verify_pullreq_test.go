@@ -16,6 +16,7 @@ package protection
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -117,8 +118,13 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 					EvaluationEntries: []codeowners.EvaluationEntry{
 						{
 							Pattern: "app",
+							Paths:   []string{"app/main.go", "app/app.go"},
 							OwnerEvaluations: []codeowners.OwnerEvaluation{
-								{ReviewDecision: enum.PullReqReviewDecisionPending, ReviewSHA: "abc"},
+								{
+									Owner:          types.PrincipalInfo{DisplayName: "John"},
+									ReviewDecision: enum.PullReqReviewDecisionPending,
+									ReviewSHA:      "abc",
+								},
 							},
 						},
 						{
@@ -140,8 +146,11 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 				codePullReqApprovalReqCodeOwnersNoApproval,
 				codePullReqApprovalReqCodeOwnersNoApproval,
 			},
-			expParams: [][]any{{"app"}, {"data"}},
-			expOut:    MergeVerifyOutput{RequiresCodeOwnersApproval: true},
+			expParams: [][]any{
+				{"app", []string{"app/main.go", "app/app.go"}, []string{"John"}},
+				{"data", []string{}, []string{}},
+			},
+			expOut: MergeVerifyOutput{RequiresCodeOwnersApproval: true},
 		},
 		{
 			name: codePullReqApprovalReqCodeOwnersNoApproval + "-success",
@@ -179,9 +188,19 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 						{
 							Pattern: "app",
 							OwnerEvaluations: []codeowners.OwnerEvaluation{
-								{ReviewDecision: enum.PullReqReviewDecisionApproved, ReviewSHA: "abc"},
-								{ReviewDecision: enum.PullReqReviewDecisionChangeReq, ReviewSHA: "abc"},
-								{ReviewDecision: enum.PullReqReviewDecisionPending, ReviewSHA: "abc"},
+								{
+									Owner:          types.PrincipalInfo{DisplayName: "John"},
+									ReviewDecision: enum.PullReqReviewDecisionApproved,
+									ReviewSHA:      "abc",
+								},
+								{
+									Owner:          types.PrincipalInfo{DisplayName: "Jane"},
+									ReviewDecision: enum.PullReqReviewDecisionChangeReq,
+									ReviewSHA:      "abc",
+								},
+							},
+							UserGroupOwnerEvaluations: []codeowners.UserGroupOwnerEvaluation{
+								{Identifier: "devs"},
 							},
 						},
 						{
@@ -196,7 +215,7 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 				Method: enum.MergeMethodMerge,
 			},
 			expCodes:  []string{codePullReqApprovalReqCodeOwnersChangeRequested},
-			expParams: [][]any{{"app"}},
+			expParams: [][]any{{"app", []string{}, []string{"John", "Jane", "@devs"}}},
 			expOut:    MergeVerifyOutput{RequiresCodeOwnersApproval: true},
 		},
 		{
@@ -208,8 +227,13 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 					EvaluationEntries: []codeowners.EvaluationEntry{
 						{
 							Pattern: "data",
+							Paths:   []string{"data/db.go"},
 							OwnerEvaluations: []codeowners.OwnerEvaluation{
-								{ReviewDecision: enum.PullReqReviewDecisionApproved, ReviewSHA: "old"},
+								{
+									Owner:          types.PrincipalInfo{DisplayName: "John"},
+									ReviewDecision: enum.PullReqReviewDecisionApproved,
+									ReviewSHA:      "old",
+								},
 							},
 						},
 						{
@@ -225,7 +249,7 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 				Method: enum.MergeMethodMerge,
 			},
 			expCodes:  []string{codePullReqApprovalReqCodeOwnersNoLatestApproval},
-			expParams: [][]any{{"data"}},
+			expParams: [][]any{{"data", []string{"data/db.go"}, []string{"John"}}},
 			expOut:    MergeVerifyOutput{RequiresCodeOwnersApprovalLatest: true},
 		},
 		{
@@ -416,3 +440,23 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCodeOwnerPathsAndNames_Capped(t *testing.T) {
+	entry := codeowners.EvaluationEntry{Pattern: "*"}
+	for i := range maxCodeOwnerViolationItems + 5 {
+		entry.Paths = append(entry.Paths, fmt.Sprintf("file%d.go", i))
+		entry.OwnerEvaluations = append(entry.OwnerEvaluations, codeowners.OwnerEvaluation{
+			Owner: types.PrincipalInfo{DisplayName: fmt.Sprintf("user%d", i)},
+		})
+	}
+
+	paths := getCodeOwnerPaths(entry)
+	if len(paths) != maxCodeOwnerViolationItems || paths[0] != "file0.go" {
+		t.Errorf("expected the first %d paths, got %v", maxCodeOwnerViolationItems, paths)
+	}
+
+	names := getCodeOwnerNames(entry)
+	if len(names) != maxCodeOwnerViolationItems || names[0] != "user0" {
+		t.Errorf("expected the first %d owners, got %v", maxCodeOwnerViolationItems, names)
+	}
+}
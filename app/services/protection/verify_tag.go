@@ -0,0 +1,150 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+// DefTagLifecycle defines the lifecycle of protected tags.
+// Protected tags can never be deleted or moved to another object.
+type DefTagLifecycle struct {
+	// CreatePermission is the repository permission required for creating the tags.
+	// If not set, the tags can be created by anyone allowed to push to the repository.
+	CreatePermission enum.Permission `json:"create_permission,omitempty"`
+	// RequireAnnotated forbids creation of lightweight tags.
+	RequireAnnotated bool `json:"require_annotated,omitempty"`
+	// RequireProtectedBranch requires the tags to point to commits reachable from a protected branch.
+	RequireProtectedBranch bool `json:"require_protected_branch,omitempty"`
+}
+
+// ensures that the DefTagLifecycle type implements Sanitizer and RefChangeVerifier interfaces.
+var (
+	_ Sanitizer         = (*DefTagLifecycle)(nil)
+	_ RefChangeVerifier = (*DefTagLifecycle)(nil)
+)
+
+// tagCreatePermissions are the repository permissions that can be required for creating protected tags.
+var tagCreatePermissions = []enum.Permission{
+	enum.PermissionRepoPush,
+	enum.PermissionRepoEdit,
+	enum.PermissionRepoDelete,
+}
+
+const (
+	codeTagCreatePermission = "tag.create.permission"
+	codeTagAnnotated        = "tag.create.annotated"
+	codeTagProtectedBranch  = "tag.create.protected_branch"
+	codeTagDelete           = "tag.delete"
+	codeTagUpdate           = "tag.update"
+)
+
+func (v *DefTagLifecycle) RefChangeVerify(
+	ctx context.Context,
+	in RefChangeVerifyInput,
+) ([]types.RuleViolations, error) {
+	var violations types.RuleViolations
+
+	for _, tagName := range in.RefNames {
+		pattern := in.pattern.matchedBy(tagName)
+
+		switch in.RefAction {
+		case RefActionCreate:
+			if err := v.verifyCreate(ctx, in, tagName, pattern, &violations); err != nil {
+				return nil, err
+			}
+		case RefActionDelete:
+			violations.Addf(codeTagDelete,
+				"Tag %q matches protected pattern %q and can't be deleted.", tagName, pattern)
+		case RefActionUpdate:
+			violations.Addf(codeTagUpdate,
+				"Tag %q matches protected pattern %q and can't be updated.", tagName, pattern)
+		}
+	}
+
+	if len(violations.Violations) > 0 {
+		return []types.RuleViolations{violations}, nil
+	}
+
+	return nil, nil
+}
+
+func (v *DefTagLifecycle) verifyCreate(
+	ctx context.Context,
+	in RefChangeVerifyInput,
+	tagName string,
+	pattern string,
+	violations *types.RuleViolations,
+) error {
+	if v.CreatePermission != "" {
+		var permitted bool
+		if in.HasPermission != nil {
+			var err error
+			permitted, err = in.HasPermission(ctx, v.CreatePermission)
+			if err != nil {
+				return fmt.Errorf("failed to check tag creation permission: %w", err)
+			}
+		}
+
+		if !permitted {
+			violations.Addf(codeTagCreatePermission,
+				"Tag %q matches protected pattern %q and requires the %q permission to be created.",
+				tagName, pattern, v.CreatePermission)
+		}
+	}
+
+	target, ok := in.TagTargets[tagName]
+	if !ok {
+		return nil
+	}
+
+	if v.RequireAnnotated && !target.Annotated {
+		violations.Addf(codeTagAnnotated,
+			"Tag %q matches protected pattern %q and must be an annotated tag.", tagName, pattern)
+	}
+
+	if v.RequireProtectedBranch {
+		var reachable bool
+		if target.CommitSHA != "" && in.ReachableFromBranches != nil && in.isProtectedBranch != nil {
+			var err error
+			reachable, err = in.ReachableFromBranches(ctx, target.CommitSHA, in.isProtectedBranch)
+			if err != nil {
+				return fmt.Errorf("failed to check if tagged commit is on a protected branch: %w", err)
+			}
+		}
+
+		if !reachable {
+			violations.Addf(codeTagProtectedBranch,
+				"Tag %q matches protected pattern %q and must point to a commit of a protected branch.",
+				tagName, pattern)
+		}
+	}
+
+	return nil
+}
+
+func (v *DefTagLifecycle) Sanitize() error {
+	if v.CreatePermission != "" && !slices.Contains(tagCreatePermissions, v.CreatePermission) {
+		return fmt.Errorf("unsupported create permission: %q", v.CreatePermission)
+	}
+
+	return nil
+}
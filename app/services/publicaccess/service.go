@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/harness/gitness/app/services/instancesettings"
 	"github.com/harness/gitness/app/store"
 	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types/enum"
@@ -29,20 +30,23 @@ import (
 var _ Service = (*service)(nil)
 
 type service struct {
-	publicResourceCreationEnabled bool
-	publicAccessStore             store.PublicAccessStore
-	repoStore                     store.RepoStore
-	spaceStore                    store.SpaceStore
+	publicAccessEnabled bool
+	instanceSettings    *instancesettings.Service
+	publicAccessStore   store.PublicAccessStore
+	repoStore           store.RepoStore
+	spaceStore          store.SpaceStore
 }
 
 func NewService(
-	publicResourceCreationEnabled bool,
+	publicAccessEnabled bool,
+	instanceSettings *instancesettings.Service,
 	publicAccessStore store.PublicAccessStore,
 	repoStore store.RepoStore,
 	spaceStore store.SpaceStore,
 ) Service {
 	return &service{
-		publicResourceCreationEnabled: publicResourceCreationEnabled,
+		publicAccessEnabled: publicAccessEnabled,
+		instanceSettings:    instanceSettings,
 
 		publicAccessStore: publicAccessStore,
 		repoStore:         repoStore,
@@ -55,6 +59,11 @@ func (s *service) Get(
 	resourceType enum.PublicResourceType,
 	resourcePath string,
 ) (bool, error) {
+	// with public access disabled on instance level, every resource is private.
+	if !s.publicAccessEnabled {
+		return false, nil
+	}
+
 	pubResID, err := s.getResourceID(ctx, resourceType, resourcePath)
 	if err != nil {
 		return false, fmt.Errorf("failed to get resource id: %w", err)
@@ -74,7 +83,7 @@ func (s *service) Set(
 	resourcePath string,
 	enable bool,
 ) error {
-	if enable && !s.publicResourceCreationEnabled {
+	if enable && !s.publicResourceCreationAllowed() {
 		return ErrPublicAccessNotAllowed
 	}
 
@@ -117,5 +126,11 @@ func (s *service) Delete(
 }
 
 func (s *service) IsPublicAccessSupported(context.Context, string) (bool, error) {
-	return s.publicResourceCreationEnabled, nil
+	return s.publicResourceCreationAllowed(), nil
+}
+
+// publicResourceCreationAllowed returns whether resources can be made public.
+// NOTE: Creation of public resources can be disabled at runtime via the instance settings.
+func (s *service) publicResourceCreationAllowed() bool {
+	return s.publicAccessEnabled && s.instanceSettings.PublicResourceCreationEnabled()
 }
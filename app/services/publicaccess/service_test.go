@@ -0,0 +1,132 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publicaccess
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type publicResource struct {
+	typ enum.PublicResourceType
+	id  int64
+}
+
+// memPublicAccessStore is an in-memory public access store.
+type memPublicAccessStore map[publicResource]bool
+
+func (s memPublicAccessStore) Find(_ context.Context, typ enum.PublicResourceType, id int64) (bool, error) {
+	return s[publicResource{typ: typ, id: id}], nil
+}
+
+func (s memPublicAccessStore) Create(_ context.Context, typ enum.PublicResourceType, id int64) error {
+	s[publicResource{typ: typ, id: id}] = true
+	return nil
+}
+
+func (s memPublicAccessStore) Delete(_ context.Context, typ enum.PublicResourceType, id int64) error {
+	delete(s, publicResource{typ: typ, id: id})
+	return nil
+}
+
+type fakeRepoStore struct {
+	store.RepoStore
+}
+
+func (fakeRepoStore) FindByRef(context.Context, string) (*types.Repository, error) {
+	return &types.Repository{ID: 1}, nil
+}
+
+type fakeSpaceStore struct {
+	store.SpaceStore
+}
+
+func (fakeSpaceStore) FindByRef(context.Context, string) (*types.Space, error) {
+	return &types.Space{ID: 1}, nil
+}
+
+func TestService_PublicAccessDisabled(t *testing.T) {
+	ctx := context.Background()
+
+	// resources that were made public before public access got disabled.
+	publicAccessStore := memPublicAccessStore{
+		{typ: enum.PublicResourceTypeRepo, id: 1}:  true,
+		{typ: enum.PublicResourceTypeSpace, id: 1}: true,
+	}
+
+	s := NewService(false, nil, publicAccessStore, fakeRepoStore{}, fakeSpaceStore{})
+
+	for _, typ := range []enum.PublicResourceType{enum.PublicResourceTypeRepo, enum.PublicResourceTypeSpace} {
+		isPublic, err := s.Get(ctx, typ, "space/resource")
+		if err != nil {
+			t.Fatalf("failed to get public access of %s: %v", typ, err)
+		}
+		if isPublic {
+			t.Errorf("expected %s to be private with public access disabled", typ)
+		}
+	}
+
+	err := s.Set(ctx, enum.PublicResourceTypeRepo, "space/repo", true)
+	if !errors.Is(err, ErrPublicAccessNotAllowed) {
+		t.Errorf("expected ErrPublicAccessNotAllowed when making a repo public, got %v", err)
+	}
+
+	supported, err := s.IsPublicAccessSupported(ctx, "space")
+	if err != nil {
+		t.Fatalf("failed to check public access support: %v", err)
+	}
+	if supported {
+		t.Error("expected public access to be unsupported")
+	}
+
+	// making resources private is still possible.
+	if err := s.Set(ctx, enum.PublicResourceTypeRepo, "space/repo", false); err != nil {
+		t.Fatalf("failed to make repo private: %v", err)
+	}
+	if publicAccessStore[publicResource{typ: enum.PublicResourceTypeRepo, id: 1}] {
+		t.Error("expected repo to be removed from the public access store")
+	}
+}
+
+func TestService_PublicAccessEnabled(t *testing.T) {
+	ctx := context.Background()
+
+	publicAccessStore := memPublicAccessStore{
+		{typ: enum.PublicResourceTypeRepo, id: 1}: true,
+	}
+
+	s := NewService(true, nil, publicAccessStore, fakeRepoStore{}, fakeSpaceStore{})
+
+	isPublic, err := s.Get(ctx, enum.PublicResourceTypeRepo, "space/repo")
+	if err != nil {
+		t.Fatalf("failed to get public access of repo: %v", err)
+	}
+	if !isPublic {
+		t.Error("expected repo to be public")
+	}
+
+	isPublic, err = s.Get(ctx, enum.PublicResourceTypeSpace, "space")
+	if err != nil {
+		t.Fatalf("failed to get public access of space: %v", err)
+	}
+	if isPublic {
+		t.Error("expected space to be private")
+	}
+}
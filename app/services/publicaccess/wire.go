@@ -15,6 +15,7 @@
 package publicaccess
 
 import (
+	"github.com/harness/gitness/app/services/instancesettings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/types"
 
@@ -27,9 +28,16 @@ var WireSet = wire.NewSet(
 
 func ProvidePublicAccess(
 	config *types.Config,
+	instanceSettings *instancesettings.Service,
 	publicAccessStore store.PublicAccessStore,
 	repoStore store.RepoStore,
 	spaceStore store.SpaceStore,
 ) Service {
-	return NewService(config.PublicResourceCreationEnabled, publicAccessStore, repoStore, spaceStore)
+	return NewService(
+		config.PublicAccessEnabled,
+		instanceSettings,
+		publicAccessStore,
+		repoStore,
+		spaceStore,
+	)
 }
@@ -29,25 +29,41 @@ import (
 
 type Service interface {
 	ValidateKey(ctx context.Context, publicKey ssh.PublicKey, usage enum.PublicKeyUsage) (*types.PrincipalInfo, error)
+
+	// ValidateDeployKey tries to match the provided key to one of the deploy keys.
+	// It returns the deploy key and the principal that created it.
+	ValidateDeployKey(ctx context.Context, publicKey ssh.PublicKey) (*types.DeployKey, error)
+
+	// CheckKeyNotInUse returns an error if the key is already used as user key or as deploy key.
+	CheckKeyNotInUse(ctx context.Context, key KeyInfo) error
 }
 
 func NewService(
 	publicKeyStore store.PublicKeyStore,
+	deployKeyStore store.DeployKeyStore,
+	principalStore store.PrincipalStore,
 	pCache store.PrincipalInfoCache,
 ) LocalService {
 	return LocalService{
 		publicKeyStore: publicKeyStore,
+		deployKeyStore: deployKeyStore,
+		principalStore: principalStore,
 		pCache:         pCache,
 	}
 }
 
 type LocalService struct {
 	publicKeyStore store.PublicKeyStore
+	deployKeyStore store.DeployKeyStore
+	principalStore store.PrincipalStore
 	pCache         store.PrincipalInfoCache
 }
 
+// errKeyInUse is intentionally generic to not reveal who owns the conflicting key.
+var errKeyInUse = errors.InvalidArgument("Key is already in use")
+
 // ValidateKey tries to match the provided key to one of the keys in the database.
-// It updates the verified timestamp of the matched key to mark it as used.
+// Expired keys and keys of blocked principals are ignored. It updates the last used timestamp of the matched key.
 func (s LocalService) ValidateKey(
 	ctx context.Context,
 	publicKey ssh.PublicKey,
@@ -64,11 +80,17 @@ func (s LocalService) ValidateKey(
 	var keyID int64
 	var principalID int64
 
+	now := time.Now().UnixMilli()
+
 	for _, existingKey := range existingKeys {
 		if !key.Matches(existingKey.Content) || existingKey.Usage != usage {
 			continue
 		}
 
+		if existingKey.ValidUntil != nil && *existingKey.ValidUntil <= now {
+			continue
+		}
+
 		keyID = existingKey.ID
 		principalID = existingKey.PrincipalID
 	}
@@ -77,15 +99,85 @@ func (s LocalService) ValidateKey(
 		return nil, errors.NotFound("Unrecognized key")
 	}
 
-	pInfo, err := s.pCache.Get(ctx, principalID)
+	principal, err := s.principalStore.Find(ctx, principalID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to pull principal info by public key's principal ID: %w", err)
+		return nil, fmt.Errorf("failed to find principal by public key's principal ID: %w", err)
+	}
+
+	if principal.Blocked {
+		return nil, errors.Format(errors.StatusUnauthorized, "Principal has been blocked")
 	}
 
-	err = s.publicKeyStore.MarkAsVerified(ctx, keyID, time.Now().UnixMilli())
+	pInfo := principal.ToPrincipalInfo()
+
+	err = s.publicKeyStore.MarkAsUsed(ctx, keyID, now)
 	if err != nil {
-		return nil, fmt.Errorf("failed mark key as verified: %w", err)
+		return nil, fmt.Errorf("failed mark key as used: %w", err)
 	}
 
 	return pInfo, nil
 }
+
+// ValidateDeployKey tries to match the provided key to one of the deploy keys in the database.
+// It updates the last used timestamp of the matched key.
+// NOTE: Deploy keys aren't bound to a principal, the creator of the key is only kept for auditing.
+func (s LocalService) ValidateDeployKey(
+	ctx context.Context,
+	publicKey ssh.PublicKey,
+) (*types.DeployKey, error) {
+	key := From(publicKey)
+	fingerprint := key.Fingerprint()
+
+	existingKeys, err := s.deployKeyStore.ListByFingerprint(ctx, fingerprint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read deploy keys by fingerprint: %w", err)
+	}
+
+	var deployKey *types.DeployKey
+	for i := range existingKeys {
+		if key.Matches(existingKeys[i].Content) {
+			deployKey = &existingKeys[i]
+			break
+		}
+	}
+
+	if deployKey == nil {
+		return nil, errors.NotFound("Unrecognized key")
+	}
+
+	err = s.deployKeyStore.MarkAsUsed(ctx, deployKey.ID, time.Now().UnixMilli())
+	if err != nil {
+		return nil, fmt.Errorf("failed mark deploy key as used: %w", err)
+	}
+
+	return deployKey, nil
+}
+
+// CheckKeyNotInUse returns an error if the key is already registered, either as user key or as deploy key.
+func (s LocalService) CheckKeyNotInUse(ctx context.Context, key KeyInfo) error {
+	fingerprint := key.Fingerprint()
+
+	publicKeys, err := s.publicKeyStore.ListByFingerprint(ctx, fingerprint)
+	if err != nil {
+		return fmt.Errorf("failed to read keys by fingerprint: %w", err)
+	}
+
+	for _, existingKey := range publicKeys {
+		if key.Matches(existingKey.Content) {
+			return errKeyInUse
+		}
+	}
+
+	deployKeys, err := s.deployKeyStore.ListByFingerprint(ctx, fingerprint)
+	if err != nil {
+		return fmt.Errorf("failed to read deploy keys by fingerprint: %w", err)
+	}
+
+	for _, existingKey := range deployKeys {
+		if key.Matches(existingKey.Content) {
+			return errKeyInUse
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,266 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publickey
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// memPublicKeyStore is a store.PublicKeyStore that lists keys by fingerprint and records their usage.
+type memPublicKeyStore struct {
+	store.PublicKeyStore
+	keys []types.PublicKey
+	used map[int64]int64
+}
+
+func (s *memPublicKeyStore) ListByFingerprint(_ context.Context, fingerprint string) ([]types.PublicKey, error) {
+	var keys []types.PublicKey
+	for _, key := range s.keys {
+		if key.Fingerprint == fingerprint {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (s *memPublicKeyStore) MarkAsUsed(_ context.Context, id int64, used int64) error {
+	s.used[id] = used
+	return nil
+}
+
+// memDeployKeyStore is a store.DeployKeyStore that lists keys by fingerprint and records their usage.
+type memDeployKeyStore struct {
+	store.DeployKeyStore
+	keys []types.DeployKey
+	used map[int64]int64
+}
+
+func (s *memDeployKeyStore) ListByFingerprint(_ context.Context, fingerprint string) ([]types.DeployKey, error) {
+	var keys []types.DeployKey
+	for _, key := range s.keys {
+		if key.Fingerprint == fingerprint {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (s *memDeployKeyStore) MarkAsUsed(_ context.Context, id int64, used int64) error {
+	s.used[id] = used
+	return nil
+}
+
+// memPrincipalStore is a store.PrincipalStore that only finds principals by id.
+type memPrincipalStore struct {
+	store.PrincipalStore
+	principals map[int64]*types.Principal
+}
+
+func (s *memPrincipalStore) Find(_ context.Context, id int64) (*types.Principal, error) {
+	if principal, ok := s.principals[id]; ok {
+		return principal, nil
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func generateKey(t *testing.T) (gossh.PublicKey, string) {
+	t.Helper()
+
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %s", err)
+	}
+
+	sshKey, err := gossh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatalf("failed to convert key: %s", err)
+	}
+
+	return sshKey, string(gossh.MarshalAuthorizedKey(sshKey))
+}
+
+type testStores struct {
+	publicKeys *memPublicKeyStore
+	deployKeys *memDeployKeyStore
+	principals *memPrincipalStore
+}
+
+func newTestService() (LocalService, testStores) {
+	stores := testStores{
+		publicKeys: &memPublicKeyStore{used: map[int64]int64{}},
+		deployKeys: &memDeployKeyStore{used: map[int64]int64{}},
+		principals: &memPrincipalStore{principals: map[int64]*types.Principal{
+			1: {ID: 1, UID: "user", Type: enum.PrincipalTypeUser},
+			2: {ID: 2, UID: "blocked", Type: enum.PrincipalTypeUser, Blocked: true},
+		}},
+	}
+
+	return NewService(stores.publicKeys, stores.deployKeys, stores.principals, nil), stores
+}
+
+func TestLocalService_ValidateKey(t *testing.T) {
+	now := time.Now().UnixMilli()
+	past := now - time.Hour.Milliseconds()
+	future := now + time.Hour.Milliseconds()
+
+	tests := []struct {
+		name        string
+		principalID int64
+		usage       enum.PublicKeyUsage
+		validUntil  *int64
+		wantErr     errors.Status
+	}{
+		{name: "valid", principalID: 1, usage: enum.PublicKeyUsageAuth},
+		{name: "not expired yet", principalID: 1, usage: enum.PublicKeyUsageAuth, validUntil: &future},
+		{name: "expired", principalID: 1, usage: enum.PublicKeyUsageAuth, validUntil: &past,
+			wantErr: errors.StatusNotFound},
+		{name: "other usage", principalID: 1, usage: enum.PublicKeyUsageSign, wantErr: errors.StatusNotFound},
+		{name: "blocked principal", principalID: 2, usage: enum.PublicKeyUsageAuth,
+			wantErr: errors.StatusUnauthorized},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, stores := newTestService()
+
+			sshKey, content := generateKey(t)
+			stores.publicKeys.keys = []types.PublicKey{{
+				ID:          10,
+				PrincipalID: test.principalID,
+				ValidUntil:  test.validUntil,
+				Usage:       test.usage,
+				Fingerprint: From(sshKey).Fingerprint(),
+				Content:     content,
+			}}
+
+			principal, err := s.ValidateKey(context.Background(), sshKey, enum.PublicKeyUsageAuth)
+
+			if test.wantErr != "" {
+				if errors.AsStatus(err) != test.wantErr {
+					t.Fatalf("expected error with status %s, got %v", test.wantErr, err)
+				}
+				if _, ok := stores.publicKeys.used[10]; ok {
+					t.Errorf("expected rejected key not to be marked as used")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if principal.ID != test.principalID {
+				t.Errorf("principal: want=%d got=%d", test.principalID, principal.ID)
+			}
+			if used := stores.publicKeys.used[10]; used < now {
+				t.Errorf("expected key to be marked as used at login, got last used %d", used)
+			}
+		})
+	}
+}
+
+func TestLocalService_ValidateDeployKey(t *testing.T) {
+	s, stores := newTestService()
+
+	sshKey, content := generateKey(t)
+	stores.deployKeys.keys = []types.DeployKey{{
+		ID:          20,
+		RepoID:      5,
+		CreatedBy:   1,
+		ReadOnly:    true,
+		Fingerprint: From(sshKey).Fingerprint(),
+		Content:     content,
+	}}
+
+	deployKey, err := s.ValidateDeployKey(context.Background(), sshKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if deployKey.ID != 20 || deployKey.RepoID != 5 || !deployKey.ReadOnly {
+		t.Errorf("unexpected deploy key: %+v", deployKey)
+	}
+	if used := stores.deployKeys.used[20]; used == 0 {
+		t.Errorf("expected deploy key to be marked as used at login")
+	}
+
+	otherKey, _ := generateKey(t)
+	if _, err = s.ValidateDeployKey(context.Background(), otherKey); errors.AsStatus(err) != errors.StatusNotFound {
+		t.Errorf("expected unknown deploy key to be rejected with not found, got %v", err)
+	}
+}
+
+func TestLocalService_CheckKeyNotInUse(t *testing.T) {
+	tests := []struct {
+		name      string
+		userKey   bool
+		deployKey bool
+		wantInUse bool
+	}{
+		{name: "unused key"},
+		{name: "used as user key", userKey: true, wantInUse: true},
+		{name: "used as deploy key", deployKey: true, wantInUse: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, stores := newTestService()
+
+			sshKey, content := generateKey(t)
+			fingerprint := From(sshKey).Fingerprint()
+			if test.userKey {
+				stores.publicKeys.keys = []types.PublicKey{{
+					ID:          10,
+					PrincipalID: 1,
+					Usage:       enum.PublicKeyUsageAuth,
+					Fingerprint: fingerprint,
+					Content:     content,
+				}}
+			}
+			if test.deployKey {
+				stores.deployKeys.keys = []types.DeployKey{{
+					ID:          20,
+					RepoID:      5,
+					Fingerprint: fingerprint,
+					Content:     content,
+				}}
+			}
+
+			err := s.CheckKeyNotInUse(context.Background(), From(sshKey))
+
+			if !test.wantInUse {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+
+			// the error must be the same for user and deploy keys to not reveal who owns the key.
+			if !errors.Is(err, errKeyInUse) {
+				t.Fatalf("expected generic key in use error, got %v", err)
+			}
+		})
+	}
+}
@@ -26,7 +26,9 @@ var WireSet = wire.NewSet(
 
 func ProvidePublicKey(
 	publicKeyStore store.PublicKeyStore,
+	deployKeyStore store.DeployKeyStore,
+	principalStore store.PrincipalStore,
 	pCache store.PrincipalInfoCache,
 ) Service {
-	return NewService(publicKeyStore, pCache)
+	return NewService(publicKeyStore, deployKeyStore, principalStore, pCache)
 }
@@ -118,3 +118,63 @@ func (s *Service) updateHeadRefOnReopen(ctx context.Context,
 
 	return nil
 }
+
+// DeleteHeadRefs deletes the head git refs of the provided pull requests of a repository, if they exist.
+// The refs are recreated in case a pull request gets reopened.
+// It returns the number of deleted refs.
+func (s *Service) DeleteHeadRefs(ctx context.Context, repoID int64, prNums []int64) (int, error) {
+	if len(prNums) == 0 {
+		return 0, nil
+	}
+
+	repoGit, err := s.repoGitInfoCache.Get(ctx, repoID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get repo git info: %w", err)
+	}
+
+	refs := make([]string, len(prNums))
+	for i, prNum := range prNums {
+		refs[i], err = git.GetRefPath(strconv.FormatInt(prNum, 10), gitenum.RefTypePullReqHead)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get PR head ref path: %w", err)
+		}
+	}
+
+	resolved, err := s.git.GetRefs(ctx, &git.GetRefsParams{
+		ReadParams: git.ReadParams{RepoUID: repoGit.GitUID},
+		Refs:       refs,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get PR head refs: %w", err)
+	}
+
+	var writeParams git.WriteParams
+	var n int
+	for i, ref := range resolved.Refs {
+		if !ref.Exists {
+			continue
+		}
+
+		if n == 0 {
+			writeParams, err = createSystemRPCWriteParams(ctx, s.urlProvider, repoGit.ID, repoGit.GitUID)
+			if err != nil {
+				return 0, fmt.Errorf("failed to generate rpc write params: %w", err)
+			}
+		}
+
+		err = s.git.UpdateRef(ctx, git.UpdateRefParams{
+			WriteParams: writeParams,
+			Name:        strconv.FormatInt(prNums[i], 10),
+			Type:        gitenum.RefTypePullReqHead,
+			NewValue:    sha.None, // when NewValue is empty will delete the ref.
+			OldValue:    ref.SHA,
+		})
+		if err != nil {
+			return n, fmt.Errorf("failed to delete head ref of PR %d: %w", prNums[i], err)
+		}
+
+		n++
+	}
+
+	return n, nil
+}
@@ -0,0 +1,241 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recentvisit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type Config struct {
+	// Debounce is the minimum time between two recorded visits of the same resource by the same user.
+	Debounce time.Duration
+	// FlushInterval is the interval in which the recorded visits are written to the database.
+	FlushInterval time.Duration
+	// MaxEntries is the number of most recent visits kept per user.
+	MaxEntries int
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.Debounce < 0 {
+		return errors.New("config.Debounce can't be negative")
+	}
+	if c.FlushInterval <= 0 {
+		return errors.New("config.FlushInterval has to be a positive duration")
+	}
+	if c.MaxEntries < 1 {
+		return errors.New("config.MaxEntries has to be a positive number")
+	}
+
+	return nil
+}
+
+type visitKey struct {
+	principalID int64
+	visitType   enum.RecentVisitType
+	resourceID  int64
+}
+
+// Service records the repositories and spaces visited by users.
+// Visits are collected in memory and periodically written to the database in a single batch.
+type Service struct {
+	config     Config
+	visitStore store.RecentVisitStore
+	repoStore  store.RepoStore
+	spaceStore store.SpaceStore
+	authorizer authz.Authorizer
+
+	mx sync.Mutex
+	// pending contains the visits that haven't been written to the database yet.
+	pending map[visitKey]int64
+	// recorded contains the time of the last recorded visit, used to debounce visits.
+	recorded map[visitKey]int64
+}
+
+func NewService(
+	config Config,
+	visitStore store.RecentVisitStore,
+	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
+	authorizer authz.Authorizer,
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided recent visit service config is invalid: %w", err)
+	}
+
+	return &Service{
+		config:     config,
+		visitStore: visitStore,
+		repoStore:  repoStore,
+		spaceStore: spaceStore,
+		authorizer: authorizer,
+		pending:    map[visitKey]int64{},
+		recorded:   map[visitKey]int64{},
+	}, nil
+}
+
+// Record records a visit of the resource by the principal of the session.
+// Only visits of users are recorded, and repeated visits within the debounce interval are ignored.
+func (s *Service) Record(session *auth.Session, visitType enum.RecentVisitType, resourceID int64) {
+	if session == nil ||
+		session.Principal.Type != enum.PrincipalTypeUser ||
+		session.Principal.UID == types.AnonymousPrincipalUID {
+		return
+	}
+
+	key := visitKey{
+		principalID: session.Principal.ID,
+		visitType:   visitType,
+		resourceID:  resourceID,
+	}
+	now := time.Now().UnixMilli()
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if last, ok := s.recorded[key]; ok && now-last < s.config.Debounce.Milliseconds() {
+		return
+	}
+
+	s.recorded[key] = now
+	s.pending[key] = now
+}
+
+// Run periodically writes the recorded visits to the database until the context is canceled.
+func (s *Service) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.config.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// write the remaining visits before shutting down.
+			s.flush(context.WithoutCancel(ctx))
+			return nil
+		case <-ticker.C:
+			s.flush(ctx)
+		}
+	}
+}
+
+func (s *Service) flush(ctx context.Context) {
+	now := time.Now().UnixMilli()
+
+	s.mx.Lock()
+	pending := s.pending
+	s.pending = map[visitKey]int64{}
+	for key, recorded := range s.recorded {
+		if now-recorded >= s.config.Debounce.Milliseconds() {
+			delete(s.recorded, key)
+		}
+	}
+	s.mx.Unlock()
+
+	if len(pending) == 0 {
+		return
+	}
+
+	visits := make([]types.RecentVisit, 0, len(pending))
+	principalIDs := map[int64]struct{}{}
+	for key, visited := range pending {
+		visits = append(visits, types.RecentVisit{
+			PrincipalID: key.principalID,
+			Type:        key.visitType,
+			ResourceID:  key.resourceID,
+			Visited:     visited,
+		})
+		principalIDs[key.principalID] = struct{}{}
+	}
+
+	if err := s.visitStore.UpsertMany(ctx, visits); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to store %d recent visits", len(visits))
+		return
+	}
+
+	for principalID := range principalIDs {
+		if err := s.visitStore.Trim(ctx, principalID, s.config.MaxEntries); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to trim recent visits of principal %d", principalID)
+		}
+	}
+}
+
+// List returns the resources the principal of the session has visited most recently.
+// Resources the principal can't access anymore are skipped, and visits of deleted resources are removed.
+func (s *Service) List(
+	ctx context.Context,
+	session *auth.Session,
+	filter *types.RecentVisitFilter,
+) ([]types.RecentVisitOutput, error) {
+	visits, err := s.visitStore.List(ctx, session.Principal.ID, filter, s.config.MaxEntries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recent visits: %w", err)
+	}
+
+	result := make([]types.RecentVisitOutput, 0, len(visits))
+	for _, visit := range visits {
+		out := types.RecentVisitOutput{RecentVisit: visit}
+
+		switch visit.Type {
+		case enum.RecentVisitTypeRepo:
+			out.Repo, err = s.repoStore.Find(ctx, visit.ResourceID)
+			if err == nil {
+				err = apiauth.CheckRepo(ctx, s.authorizer, session, out.Repo, enum.PermissionRepoView)
+			}
+		case enum.RecentVisitTypeSpace:
+			out.Space, err = s.spaceStore.Find(ctx, visit.ResourceID)
+			if err == nil {
+				err = apiauth.CheckSpace(ctx, s.authorizer, session, out.Space, enum.PermissionSpaceView)
+			}
+		default:
+			continue
+		}
+
+		switch {
+		case err == nil:
+			result = append(result, out)
+		case errors.Is(err, gitness_store.ErrResourceNotFound):
+			s.deleteDangling(ctx, visit)
+		case errors.Is(err, apiauth.ErrNotAuthorized):
+		default:
+			return nil, fmt.Errorf("failed to resolve recently visited %s %d: %w", visit.Type, visit.ResourceID, err)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *Service) deleteDangling(ctx context.Context, visit types.RecentVisit) {
+	err := s.visitStore.Delete(ctx, visit.PrincipalID, visit.Type, visit.ResourceID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to delete recent visit of deleted %s %d",
+			visit.Type, visit.ResourceID)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const jobTypeMaintenance = "repo-maintenance"
+
+// Maintenance is a recurring job that writes pack bitmaps and commit-graph files of all repositories,
+// which speeds up clones, commit counting (e.g. ahead/behind) and merge-base lookups.
+type Maintenance struct {
+	enabled    bool
+	cron       string
+	maxDur     time.Duration
+	numWorkers int
+	git        git.Interface
+	repoStore  store.RepoStore
+	scheduler  *job.Scheduler
+}
+
+func (m *Maintenance) Register(ctx context.Context) error {
+	if !m.enabled {
+		return nil
+	}
+
+	err := m.scheduler.AddRecurring(ctx, jobTypeMaintenance, jobTypeMaintenance, m.cron, m.maxDur)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for repo maintenance: %w", err)
+	}
+
+	return nil
+}
+
+func (m *Maintenance) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	if !m.enabled {
+		return "", nil
+	}
+
+	repos, err := m.repoStore.ListSizeInfos(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list repositories: %w", err)
+	}
+
+	log.Ctx(ctx).Info().Msgf("start maintenance of %d repositories", len(repos))
+
+	var wg sync.WaitGroup
+	taskCh := make(chan *types.RepositorySizeInfo)
+	for i := 0; i < m.numWorkers; i++ {
+		wg.Add(1)
+		go m.worker(ctx, &wg, taskCh)
+	}
+loop:
+	for _, repo := range repos {
+		select {
+		case <-ctx.Done():
+			break loop
+		case taskCh <- repo:
+		}
+	}
+	close(taskCh)
+	wg.Wait()
+
+	return "", nil
+}
+
+func (m *Maintenance) worker(ctx context.Context, wg *sync.WaitGroup, taskCh <-chan *types.RepositorySizeInfo) {
+	defer wg.Done()
+
+	for repo := range taskCh {
+		log := log.Ctx(ctx).With().Str("repo_git_uid", repo.GitUID).Int64("repo_id", repo.ID).Logger()
+
+		err := m.git.OptimizeRepository(ctx, &git.OptimizeRepositoryParams{
+			ReadParams: git.ReadParams{RepoUID: repo.GitUID},
+		})
+		if err != nil {
+			log.Warn().Err(err).Msg("failed to run repository maintenance")
+			continue
+		}
+
+		log.Debug().Msg("repository maintenance completed")
+	}
+}
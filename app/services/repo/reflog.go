@@ -0,0 +1,113 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeReflogEnabler   = "repo-reflog-enabler"
+	jobUIDReflogEnabler    = "repo-reflog-enabler-v1"
+	jobMaxDurReflogEnabler = 30 * time.Minute
+)
+
+// ReflogEnabler is a one-time job that enables the reflog on repositories
+// that were created before the reflog got enabled by default for new repositories.
+type ReflogEnabler struct {
+	git       git.Interface
+	repoStore store.RepoStore
+	scheduler *job.Scheduler
+}
+
+func (e *ReflogEnabler) Register(ctx context.Context) error {
+	err := e.scheduler.RunJob(ctx, job.Definition{
+		UID:     jobUIDReflogEnabler,
+		Type:    jobTypeReflogEnabler,
+		Timeout: jobMaxDurReflogEnabler,
+	})
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		// the job has already been scheduled (or executed) before.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to run reflog enabler job: %w", err)
+	}
+
+	return nil
+}
+
+func (e *ReflogEnabler) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	repos, err := e.repoStore.ListSizeInfos(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list repositories: %w", err)
+	}
+
+	principal := bootstrap.NewSystemServiceSession().Principal
+	actor := git.Identity{
+		Name:  principal.DisplayName,
+		Email: principal.Email,
+	}
+	enabled := "true"
+
+	var count int
+	for _, repo := range repos {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+
+		log := log.Ctx(ctx).With().Str("repo_git_uid", repo.GitUID).Int64("repo_id", repo.ID).Logger()
+
+		configOut, err := e.git.GetRepoConfig(ctx, &git.GetRepoConfigParams{
+			ReadParams: git.ReadParams{RepoUID: repo.GitUID},
+		})
+		if err != nil {
+			log.Warn().Err(err).Msg("failed to get git config of repository")
+			continue
+		}
+
+		// don't override an explicit configuration of the repository.
+		if _, ok := configOut.Values[strings.ToLower(git.RepoConfigKeyLogAllRefUpdates)]; ok {
+			continue
+		}
+
+		err = e.git.UpdateRepoConfig(ctx, &git.UpdateRepoConfigParams{
+			WriteParams: git.WriteParams{RepoUID: repo.GitUID, Actor: actor},
+			Values:      map[string]*string{git.RepoConfigKeyLogAllRefUpdates: &enabled},
+		})
+		if err != nil {
+			log.Warn().Err(err).Msg("failed to enable reflog of repository")
+			continue
+		}
+
+		count++
+	}
+
+	log.Ctx(ctx).Info().Msgf("enabled reflog on %d repositories", count)
+
+	return "", nil
+}
@@ -0,0 +1,191 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeStats = "repo-stats-calculator"
+
+	// statsBucketDays is the number of days covered by the push frequency buckets.
+	statsBucketDays = 30
+)
+
+// StatsCalculator is a recurring job that precomputes the push and reference statistics of all repositories,
+// and removes recorded pushes that are older than the largest statistics window.
+type StatsCalculator struct {
+	enabled         bool
+	cron            string
+	maxDur          time.Duration
+	numWorkers      int
+	topContributors int
+	git             git.Interface
+	repoStore       store.RepoStore
+	pushStore       store.RepoPushStore
+	statsStore      store.RepoStatsStore
+	scheduler       *job.Scheduler
+}
+
+func (s *StatsCalculator) Register(ctx context.Context) error {
+	if !s.enabled {
+		return nil
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobTypeStats, jobTypeStats, s.cron, s.maxDur)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for repo stats calculator: %w", err)
+	}
+
+	return nil
+}
+
+func (s *StatsCalculator) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	if !s.enabled {
+		return "", nil
+	}
+
+	now := time.Now()
+
+	windows, _ := enum.GetAllRepoStatsWindows()
+	maxDays := 0
+	for _, window := range windows {
+		maxDays = max(maxDays, window.Days())
+	}
+
+	n, err := s.pushStore.DeleteOld(ctx, daysBefore(now, maxDays))
+	if err != nil {
+		return "", fmt.Errorf("failed to delete old repo pushes: %w", err)
+	}
+
+	repos, err := s.repoStore.ListSizeInfos(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list repositories: %w", err)
+	}
+
+	log.Ctx(ctx).Info().Msgf("deleted %d old repo pushes, start stats calculation of %d repositories",
+		n, len(repos))
+
+	var wg sync.WaitGroup
+	taskCh := make(chan *types.RepositorySizeInfo)
+	for i := 0; i < s.numWorkers; i++ {
+		wg.Add(1)
+		go s.worker(ctx, &wg, now, taskCh)
+	}
+loop:
+	for _, repo := range repos {
+		select {
+		case <-ctx.Done():
+			break loop
+		case taskCh <- repo:
+		}
+	}
+	close(taskCh)
+	wg.Wait()
+
+	return "", nil
+}
+
+func (s *StatsCalculator) worker(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	now time.Time,
+	taskCh <-chan *types.RepositorySizeInfo,
+) {
+	defer wg.Done()
+
+	for repo := range taskCh {
+		log := log.Ctx(ctx).With().Str("repo_git_uid", repo.GitUID).Int64("repo_id", repo.ID).Logger()
+
+		stats, err := s.calculate(ctx, repo, now)
+		if err != nil {
+			log.Warn().Err(err).Msg("failed to calculate repo stats")
+			continue
+		}
+
+		if err := s.statsStore.Upsert(ctx, stats); err != nil {
+			log.Warn().Err(err).Msg("failed to store repo stats")
+			continue
+		}
+
+		log.Debug().Msg("repo stats calculated")
+	}
+}
+
+func (s *StatsCalculator) calculate(
+	ctx context.Context,
+	repo *types.RepositorySizeInfo,
+	now time.Time,
+) (*types.RepoStats, error) {
+	stats := &types.RepoStats{
+		RepoID:       repo.ID,
+		Contributors: map[enum.RepoStatsWindow][]types.RepoContributor{},
+		Updated:      now.UnixMilli(),
+	}
+
+	var err error
+
+	stats.Pushes7Days, err = s.pushStore.Count(ctx, repo.ID, daysBefore(now, 7).UnixMilli())
+	if err != nil {
+		return nil, fmt.Errorf("failed to count pushes of last 7 days: %w", err)
+	}
+
+	stats.Pushes30Days, err = s.pushStore.Count(ctx, repo.ID, daysBefore(now, 30).UnixMilli())
+	if err != nil {
+		return nil, fmt.Errorf("failed to count pushes of last 30 days: %w", err)
+	}
+
+	stats.PushBuckets, err = s.pushStore.ListBuckets(ctx, repo.ID, daysBefore(now, statsBucketDays).UnixMilli())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list push buckets: %w", err)
+	}
+
+	windows, _ := enum.GetAllRepoStatsWindows()
+	for _, window := range windows {
+		since := daysBefore(now, window.Days()).UnixMilli()
+		stats.Contributors[window], err = s.pushStore.ListTopContributors(ctx, repo.ID, since, s.topContributors)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list top contributors of window %s: %w", window, err)
+		}
+	}
+
+	summary, err := s.git.Summary(ctx, git.SummaryParams{ReadParams: git.ReadParams{RepoUID: repo.GitUID}})
+	if err != nil {
+		// empty repositories don't have a default branch yet, so they can't be summarized.
+		log.Ctx(ctx).Debug().Err(err).Int64("repo_id", repo.ID).Msg("failed to get repo summary")
+	} else {
+		stats.Branches = int64(summary.BranchCount)
+		stats.Tags = int64(summary.TagCount)
+	}
+
+	return stats, nil
+}
+
+// daysBefore returns the start of the day (UTC) that lies the provided number of days before the provided time.
+func daysBefore(t time.Time, days int) time.Time {
+	return t.UTC().Truncate(24*time.Hour).AddDate(0, 0, -days)
+}
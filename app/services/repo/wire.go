@@ -31,6 +31,9 @@ import (
 
 var WireSet = wire.NewSet(
 	ProvideCalculator,
+	ProvideReflogEnabler,
+	ProvideMaintenance,
+	ProvideStatsCalculator,
 	ProvideService,
 )
 
@@ -59,6 +62,81 @@ func ProvideCalculator(
 	return job, nil
 }
 
+func ProvideReflogEnabler(
+	git git.Interface,
+	repoStore store.RepoStore,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+) (*ReflogEnabler, error) {
+	job := &ReflogEnabler{
+		git:       git,
+		repoStore: repoStore,
+		scheduler: scheduler,
+	}
+
+	err := executor.Register(jobTypeReflogEnabler, job)
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func ProvideMaintenance(
+	config *types.Config,
+	git git.Interface,
+	repoStore store.RepoStore,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+) (*Maintenance, error) {
+	job := &Maintenance{
+		enabled:    config.RepoMaintenance.Enabled,
+		cron:       config.RepoMaintenance.CRON,
+		maxDur:     config.RepoMaintenance.MaxDuration,
+		numWorkers: config.RepoMaintenance.NumWorkers,
+		git:        git,
+		repoStore:  repoStore,
+		scheduler:  scheduler,
+	}
+
+	err := executor.Register(jobTypeMaintenance, job)
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func ProvideStatsCalculator(
+	config *types.Config,
+	git git.Interface,
+	repoStore store.RepoStore,
+	pushStore store.RepoPushStore,
+	statsStore store.RepoStatsStore,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+) (*StatsCalculator, error) {
+	job := &StatsCalculator{
+		enabled:         config.RepoStats.Enabled,
+		cron:            config.RepoStats.CRON,
+		maxDur:          config.RepoStats.MaxDuration,
+		numWorkers:      config.RepoStats.NumWorkers,
+		topContributors: config.RepoStats.TopContributors,
+		git:             git,
+		repoStore:       repoStore,
+		pushStore:       pushStore,
+		statsStore:      statsStore,
+		scheduler:       scheduler,
+	}
+
+	err := executor.Register(jobTypeStats, job)
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
 func ProvideService(ctx context.Context,
 	config *types.Config,
 	repoEvReporter *repoevents.Reporter,
@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repoactivity
+
+import (
+	"context"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func (s *Service) handleEventPullReqCreated(ctx context.Context,
+	event *events.Event[*pullreqevents.CreatedPayload]) error {
+	return s.record(ctx, event.Payload.TargetRepoID, event.Payload.PrincipalID,
+		enum.RepoActivityTypePullReqOpened, event.Timestamp, &types.RepoActivityPullReqPayload{
+			PullReqID:     event.Payload.PullReqID,
+			PullReqNumber: event.Payload.Number,
+			SHA:           event.Payload.SourceSHA,
+		})
+}
+
+func (s *Service) handleEventPullReqMerged(ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload]) error {
+	return s.record(ctx, event.Payload.TargetRepoID, event.Payload.PrincipalID,
+		enum.RepoActivityTypePullReqMerged, event.Timestamp, &types.RepoActivityPullReqPayload{
+			PullReqID:     event.Payload.PullReqID,
+			PullReqNumber: event.Payload.Number,
+			SHA:           event.Payload.MergeSHA,
+		})
+}
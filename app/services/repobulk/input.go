@@ -0,0 +1,210 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repobulk
+
+import (
+	"slices"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/controller/webhook"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types/check"
+)
+
+// maxSelectedRepos is the maximum number of repositories that can be listed explicitly.
+const maxSelectedRepos = 1000
+
+// Operation defines the change applied to all selected repositories.
+type Operation string
+
+func (Operation) Enum() []any {
+	return []any{OperationCreateRule, OperationCreateWebhook, OperationSetVisibility}
+}
+
+const (
+	// OperationCreateRule creates a branch protection rule in the repositories.
+	OperationCreateRule Operation = "create_rule"
+	// OperationCreateWebhook creates a webhook in the repositories.
+	OperationCreateWebhook Operation = "create_webhook"
+	// OperationSetVisibility makes the repositories public or private.
+	OperationSetVisibility Operation = "set_visibility"
+)
+
+// SelectorType defines how the repositories of a bulk operation are selected.
+type SelectorType string
+
+func (SelectorType) Enum() []any {
+	return []any{SelectorTypeAll, SelectorTypeTopic, SelectorTypeList}
+}
+
+const (
+	// SelectorTypeAll selects all repositories of the space and its sub spaces.
+	SelectorTypeAll SelectorType = "all"
+	// SelectorTypeTopic selects all repositories of the space and its sub spaces with any of the topics.
+	SelectorTypeTopic SelectorType = "topic"
+	// SelectorTypeList selects the explicitly listed repositories.
+	SelectorTypeList SelectorType = "list"
+)
+
+// Selector selects the repositories a bulk operation is applied to.
+type Selector struct {
+	Type   SelectorType `json:"type"`
+	Topics []string     `json:"topics,omitempty"`
+	// Repos are the paths of the repositories relative to the space.
+	Repos []string `json:"repos,omitempty"`
+}
+
+// Input is the input of a repository bulk operation.
+// Only the field matching the operation is used.
+type Input struct {
+	Operation Operation `json:"operation"`
+	Selector  Selector  `json:"selector"`
+
+	Rule     *repo.RuleCreateInput `json:"rule,omitempty"`
+	Webhook  *webhook.CreateInput  `json:"webhook,omitempty"`
+	IsPublic *bool                 `json:"is_public,omitempty"`
+}
+
+func (in *Input) sanitize() error {
+	switch in.Operation {
+	case OperationCreateRule:
+		if in.Rule == nil {
+			return usererror.BadRequest("Rule is required for the create rule operation.")
+		}
+		// TODO [CODE-1363]: remove after identifier migration.
+		if in.Rule.Identifier == "" {
+			in.Rule.Identifier = in.Rule.UID
+		}
+		// the identifier is required upfront as it's used to detect repositories that already have the rule.
+		if err := check.Identifier(in.Rule.Identifier); err != nil {
+			return err
+		}
+	case OperationCreateWebhook:
+		if in.Webhook == nil {
+			return usererror.BadRequest("Webhook is required for the create webhook operation.")
+		}
+		// TODO [CODE-1363]: remove after identifier migration.
+		if in.Webhook.Identifier == "" {
+			in.Webhook.Identifier = in.Webhook.UID
+		}
+		// the identifier is required upfront as it's used to detect repositories that already have the webhook.
+		if err := check.Identifier(in.Webhook.Identifier); err != nil {
+			return err
+		}
+	case OperationSetVisibility:
+		if in.IsPublic == nil {
+			return usererror.BadRequest("Visibility is required for the set visibility operation.")
+		}
+	default:
+		return usererror.BadRequestf("Unknown operation %q.", in.Operation)
+	}
+
+	return in.Selector.sanitize()
+}
+
+func (s *Selector) sanitize() error {
+	switch s.Type {
+	case SelectorTypeAll:
+		s.Topics = nil
+		s.Repos = nil
+	case SelectorTypeTopic:
+		if len(s.Topics) == 0 {
+			return usererror.BadRequest("At least one topic is required to select repositories by topic.")
+		}
+		if err := check.RepoTopics(s.Topics); err != nil {
+			return err
+		}
+		s.Repos = nil
+	case SelectorTypeList:
+		if len(s.Repos) == 0 {
+			return usererror.BadRequest("At least one repository is required to select repositories by list.")
+		}
+		if len(s.Repos) > maxSelectedRepos {
+			return usererror.BadRequestf("At most %d repositories can be selected by list.", maxSelectedRepos)
+		}
+		slices.Sort(s.Repos)
+		s.Repos = slices.Compact(s.Repos)
+		s.Topics = nil
+	default:
+		return usererror.BadRequestf("Unknown repository selector %q.", s.Type)
+	}
+
+	return nil
+}
+
+// ResultStatus is the outcome of a bulk operation for a single repository.
+type ResultStatus string
+
+func (ResultStatus) Enum() []any {
+	return []any{ResultStatusSucceeded, ResultStatusUnchanged, ResultStatusSkipped, ResultStatusFailed}
+}
+
+const (
+	// ResultStatusSucceeded means the change was applied to the repository.
+	ResultStatusSucceeded ResultStatus = "succeeded"
+	// ResultStatusUnchanged means the repository already was in the desired state.
+	ResultStatusUnchanged ResultStatus = "unchanged"
+	// ResultStatusSkipped means the repository wasn't changed, e.g. due to missing permissions.
+	ResultStatusSkipped ResultStatus = "skipped"
+	// ResultStatusFailed means applying the change to the repository failed.
+	ResultStatusFailed ResultStatus = "failed"
+)
+
+// RepoResult is the result of a bulk operation for a single repository.
+type RepoResult struct {
+	RepoID   int64        `json:"repo_id,omitempty"`
+	RepoPath string       `json:"repo_path"`
+	Status   ResultStatus `json:"status"`
+	Message  string       `json:"message,omitempty"`
+}
+
+// Report is the per repository report of a bulk operation.
+type Report struct {
+	Operation Operation    `json:"operation"`
+	DryRun    bool         `json:"dry_run,omitempty"`
+	Total     int          `json:"total"`
+	Succeeded int          `json:"succeeded"`
+	Unchanged int          `json:"unchanged"`
+	Skipped   int          `json:"skipped"`
+	Failed    int          `json:"failed"`
+	Repos     []RepoResult `json:"repos"`
+}
+
+func (r *Report) add(result RepoResult) {
+	switch result.Status {
+	case ResultStatusSucceeded:
+		r.Succeeded++
+	case ResultStatusUnchanged:
+		r.Unchanged++
+	case ResultStatusSkipped:
+		r.Skipped++
+	case ResultStatusFailed:
+		r.Failed++
+	}
+
+	r.Repos = append(r.Repos, result)
+}
+
+// Progress is the progress of a bulk operation, including the report of all already processed repositories.
+// Dry runs aren't executed as a job, hence they have no job UID.
+type Progress struct {
+	JobUID   string    `json:"job_uid,omitempty"`
+	DryRun   bool      `json:"dry_run,omitempty"`
+	State    job.State `json:"state"`
+	Progress int       `json:"progress"`
+	Failure  string    `json:"failure,omitempty"`
+	Report   *Report   `json:"report,omitempty"`
+}
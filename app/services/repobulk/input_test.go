@@ -0,0 +1,185 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repobulk
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/controller/webhook"
+	"github.com/harness/gitness/app/api/usererror"
+)
+
+func TestInputSanitize(t *testing.T) {
+	isPublic := true
+
+	tests := []struct {
+		name    string
+		in      Input
+		wantErr bool
+	}{
+		{
+			name: "rule",
+			in: Input{
+				Operation: OperationCreateRule,
+				Selector:  Selector{Type: SelectorTypeAll},
+				Rule:      &repo.RuleCreateInput{Identifier: "protect-main"},
+			},
+		},
+		{
+			name: "rule-without-identifier",
+			in: Input{
+				Operation: OperationCreateRule,
+				Selector:  Selector{Type: SelectorTypeAll},
+				Rule:      &repo.RuleCreateInput{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rule-missing",
+			in: Input{
+				Operation: OperationCreateRule,
+				Selector:  Selector{Type: SelectorTypeAll},
+			},
+			wantErr: true,
+		},
+		{
+			name: "webhook-deprecated-uid",
+			in: Input{
+				Operation: OperationCreateWebhook,
+				Selector:  Selector{Type: SelectorTypeTopic, Topics: []string{"go"}},
+				Webhook:   &webhook.CreateInput{UID: "ci"},
+			},
+		},
+		{
+			name: "visibility-missing",
+			in: Input{
+				Operation: OperationSetVisibility,
+				Selector:  Selector{Type: SelectorTypeAll},
+			},
+			wantErr: true,
+		},
+		{
+			name: "visibility",
+			in: Input{
+				Operation: OperationSetVisibility,
+				Selector:  Selector{Type: SelectorTypeList, Repos: []string{"b", "a", "b"}},
+				IsPublic:  &isPublic,
+			},
+		},
+		{
+			name: "unknown-operation",
+			in: Input{
+				Operation: "enable_lfs",
+				Selector:  Selector{Type: SelectorTypeAll},
+			},
+			wantErr: true,
+		},
+		{
+			name: "topic-selector-without-topics",
+			in: Input{
+				Operation: OperationSetVisibility,
+				Selector:  Selector{Type: SelectorTypeTopic},
+				IsPublic:  &isPublic,
+			},
+			wantErr: true,
+		},
+		{
+			name: "list-selector-without-repos",
+			in: Input{
+				Operation: OperationSetVisibility,
+				Selector:  Selector{Type: SelectorTypeList},
+				IsPublic:  &isPublic,
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown-selector",
+			in: Input{
+				Operation: OperationSetVisibility,
+				Selector:  Selector{Type: "label"},
+				IsPublic:  &isPublic,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.in.sanitize()
+			if test.wantErr {
+				var uErr *usererror.Error
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				if errors.As(err, &uErr) && uErr.Status != http.StatusBadRequest {
+					t.Errorf("expected a bad request error, got %d", uErr.Status)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestSelectorSanitizeDeduplicatesRepos(t *testing.T) {
+	s := Selector{Type: SelectorTypeList, Repos: []string{"b", "a", "b"}, Topics: []string{"go"}}
+	if err := s.sanitize(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if want := []string{"a", "b"}; !slices.Equal(s.Repos, want) {
+		t.Errorf("expected repos %v, got %v", want, s.Repos)
+	}
+	if s.Topics != nil {
+		t.Errorf("expected topics to be cleared, got %v", s.Topics)
+	}
+}
+
+func TestReportAdd(t *testing.T) {
+	report := &Report{}
+	for _, status := range []ResultStatus{
+		ResultStatusSucceeded, ResultStatusUnchanged, ResultStatusSkipped, ResultStatusFailed, ResultStatusSkipped,
+	} {
+		report.add(RepoResult{Status: status})
+	}
+
+	if report.Succeeded != 1 || report.Unchanged != 1 || report.Skipped != 2 || report.Failed != 1 {
+		t.Errorf("unexpected counts: %+v", report)
+	}
+	if len(report.Repos) != 5 {
+		t.Errorf("expected 5 results, got %d", len(report.Repos))
+	}
+}
+
+func TestJobUIDIsScopedToSpaceAndPrincipal(t *testing.T) {
+	uid, err := newJobUID(12, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !strings.HasPrefix(uid, jobUIDPrefix(12, 3)) {
+		t.Errorf("expected job uid %q to have the prefix of space and principal", uid)
+	}
+	if strings.HasPrefix(uid, jobUIDPrefix(1, 3)) || strings.HasPrefix(uid, jobUIDPrefix(12, 33)) {
+		t.Errorf("job uid %q unexpectedly matches the prefix of another space or principal", uid)
+	}
+}
@@ -0,0 +1,494 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repobulk
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobType        = "repo_bulk_operation"
+	jobMaxRetries  = 0
+	jobMaxDuration = time.Hour
+
+	listPageSize = 100
+)
+
+var _ job.Handler = (*Service)(nil)
+
+type jobInput struct {
+	SpaceID     int64 `json:"space_id"`
+	PrincipalID int64 `json:"principal_id"`
+	Input       Input `json:"input"`
+}
+
+// Run validates the input and starts a background job that applies the operation
+// to all selected repositories of the space on behalf of the principal.
+func (s *Service) Run(
+	ctx context.Context,
+	spaceID int64,
+	principalID int64,
+	in *Input,
+) (string, error) {
+	if err := s.validate(in); err != nil {
+		return "", err
+	}
+
+	jobUID, err := newJobUID(spaceID, principalID)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(jobInput{
+		SpaceID:     spaceID,
+		PrincipalID: principalID,
+		Input:       *in,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal job input json: %w", err)
+	}
+
+	// the input can contain secrets (e.g. of a webhook), hence it's stored encrypted.
+	encryptedData, err := s.encrypter.Encrypt(string(data))
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt job input: %w", err)
+	}
+
+	err = s.scheduler.RunJob(ctx, job.Definition{
+		UID:        jobUID,
+		Type:       jobType,
+		MaxRetries: jobMaxRetries,
+		Timeout:    jobMaxDuration,
+		Data:       base64.StdEncoding.EncodeToString(encryptedData),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to run repository bulk operation job: %w", err)
+	}
+
+	return jobUID, nil
+}
+
+// DryRun validates the input and reports the outcome of the operation for all selected repositories
+// of the space, exactly as the job would, but without starting a job or changing any of the repositories.
+func (s *Service) DryRun(
+	ctx context.Context,
+	spaceID int64,
+	principalID int64,
+	in *Input,
+) (*Progress, error) {
+	if err := s.validate(in); err != nil {
+		return nil, err
+	}
+
+	space, err := s.spaceStore.Find(ctx, spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space: %w", err)
+	}
+
+	principal, err := s.principalStore.Find(ctx, principalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find principal: %w", err)
+	}
+
+	report, err := s.process(ctx, space, principal, in, true, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Progress{
+		DryRun:   true,
+		State:    job.JobStateFinished,
+		Progress: job.ProgressMax,
+		Report:   report,
+	}, nil
+}
+
+// GetProgress returns the progress of a bulk operation the principal started in the space.
+func (s *Service) GetProgress(
+	ctx context.Context,
+	spaceID int64,
+	principalID int64,
+	jobUID string,
+) (*Progress, error) {
+	if !strings.HasPrefix(jobUID, jobUIDPrefix(spaceID, principalID)) {
+		return nil, ErrNotFound
+	}
+
+	progress, err := s.scheduler.GetJobProgress(ctx, jobUID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository bulk operation job progress: %w", err)
+	}
+
+	out := &Progress{
+		JobUID:   jobUID,
+		State:    progress.State,
+		Progress: progress.Progress,
+		Failure:  progress.Failure,
+	}
+
+	if progress.Result != "" {
+		out.Report = &Report{}
+		if err = json.Unmarshal([]byte(progress.Result), out.Report); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal repository bulk operation report: %w", err)
+		}
+	}
+
+	return out, nil
+}
+
+// Handle is the repository bulk operation background job handler.
+func (s *Service) Handle(ctx context.Context, data string, fn job.ProgressReporter) (string, error) {
+	input, err := s.getJobInput(data)
+	if err != nil {
+		return "", err
+	}
+
+	space, err := s.spaceStore.Find(ctx, input.SpaceID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find space: %w", err)
+	}
+
+	principal, err := s.principalStore.Find(ctx, input.PrincipalID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find principal: %w", err)
+	}
+
+	log := log.Ctx(ctx).With().
+		Int64("space.id", space.ID).
+		Str("space.path", space.Path).
+		Str("operation", string(input.Input.Operation)).
+		Logger()
+
+	report, err := s.process(ctx, space, principal, &input.Input, false, fn)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := json.Marshal(report)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal repository bulk operation report: %w", err)
+	}
+
+	log.Info().Msgf("completed repository bulk operation: %d succeeded, %d unchanged, %d skipped, %d failed",
+		report.Succeeded, report.Unchanged, report.Skipped, report.Failed)
+
+	return string(result), nil
+}
+
+// validate checks the input the same way it's checked when the operation is applied to a single repository.
+func (s *Service) validate(in *Input) error {
+	if err := in.sanitize(); err != nil {
+		return err
+	}
+
+	// the controllers sanitize the input in place, hence only copies of it are validated.
+	if in.Rule != nil && in.Operation == OperationCreateRule {
+		rule := *in.Rule
+		return s.repoCtrl.RuleCreateValidate(&rule)
+	}
+
+	if in.Webhook != nil && in.Operation == OperationCreateWebhook {
+		hook := *in.Webhook
+		return s.webhookCtrl.CreateValidate(&hook, false)
+	}
+
+	return nil
+}
+
+// process applies the operation to all selected repositories and reports the outcome for each of them.
+// With dryRun set, only the outcome is determined and none of the repositories is changed.
+// The progress reporter is optional.
+func (s *Service) process(
+	ctx context.Context,
+	space *types.Space,
+	principal *types.Principal,
+	in *Input,
+	dryRun bool,
+	fn job.ProgressReporter,
+) (*Report, error) {
+	session := &auth.Session{Principal: *principal}
+
+	repos, report, err := s.selectRepos(ctx, space, &in.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	report.Operation = in.Operation
+	report.DryRun = dryRun
+	report.Total = len(report.Repos) + len(repos)
+
+	for i, r := range repos {
+		report.add(s.applyToRepo(ctx, session, r, in, dryRun))
+
+		if fn == nil {
+			continue
+		}
+
+		if err = reportProgress(fn, job.ProgressMax*(i+1)/len(repos), report); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to report repository bulk operation progress")
+		}
+	}
+
+	return report, nil
+}
+
+// selectRepos returns the active repositories matching the selector.
+// Explicitly selected repositories that can't be used are returned as part of the report.
+func (s *Service) selectRepos(
+	ctx context.Context,
+	space *types.Space,
+	selector *Selector,
+) ([]*types.Repository, *Report, error) {
+	report := &Report{Repos: []RepoResult{}}
+
+	if selector.Type != SelectorTypeList {
+		repos, err := s.listRepos(ctx, space.ID, selector.Topics)
+		return repos, report, err
+	}
+
+	repos := make([]*types.Repository, 0, len(selector.Repos))
+	for _, repoPath := range selector.Repos {
+		repoPath = paths.Concatenate(space.Path, repoPath)
+
+		r, err := s.repoStore.FindByRef(ctx, repoPath)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			report.add(RepoResult{RepoPath: repoPath, Status: ResultStatusFailed, Message: "Repository not found."})
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to find repository %q: %w", repoPath, err)
+		}
+
+		if r.State != enum.RepoStateActive {
+			report.add(RepoResult{
+				RepoID:   r.ID,
+				RepoPath: r.Path,
+				Status:   ResultStatusSkipped,
+				Message:  "Repository isn't active.",
+			})
+			continue
+		}
+
+		repos = append(repos, r)
+	}
+
+	return repos, report, nil
+}
+
+// listRepos returns all active repositories of the space and its sub spaces in a stable order,
+// optionally limited to the repositories with any of the provided topics.
+func (s *Service) listRepos(ctx context.Context, spaceID int64, topics []string) ([]*types.Repository, error) {
+	var repos []*types.Repository
+	for page := 1; ; page++ {
+		reposInPage, err := s.repoStore.List(ctx, spaceID, &types.RepoFilter{
+			Page:      page,
+			Size:      listPageSize,
+			Sort:      enum.RepoAttrCreated,
+			Order:     enum.OrderAsc,
+			Recursive: true,
+			Topics:    topics,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list repositories: %w", err)
+		}
+
+		for _, r := range reposInPage {
+			if r.State == enum.RepoStateActive {
+				repos = append(repos, r)
+			}
+		}
+
+		if len(reposInPage) < listPageSize {
+			return repos, nil
+		}
+	}
+}
+
+// applyToRepo applies the operation to the repository unless it's already in the desired state.
+// Repositories the principal isn't allowed to edit are skipped.
+func (s *Service) applyToRepo(
+	ctx context.Context,
+	session *auth.Session,
+	r *types.Repository,
+	in *Input,
+	dryRun bool,
+) RepoResult {
+	result := RepoResult{RepoID: r.ID, RepoPath: r.Path}
+
+	err := apiauth.CheckRepo(ctx, s.authorizer, session, r, enum.PermissionRepoEdit)
+	if errors.Is(err, apiauth.ErrNotAuthorized) {
+		result.Status = ResultStatusSkipped
+		result.Message = "Not authorized to edit the repository."
+		return result
+	}
+
+	if err == nil {
+		result.Status, err = s.apply(ctx, session, r, in, dryRun)
+	}
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to apply bulk operation to repository %q", r.Path)
+
+		result.Status = ResultStatusFailed
+		result.Message = usererror.Translate(ctx, err).Message
+	}
+
+	return result
+}
+
+func (s *Service) apply(
+	ctx context.Context,
+	session *auth.Session,
+	r *types.Repository,
+	in *Input,
+	dryRun bool,
+) (ResultStatus, error) {
+	applied, err := s.isApplied(ctx, r, in)
+	if err != nil {
+		return "", err
+	}
+	if applied {
+		return ResultStatusUnchanged, nil
+	}
+
+	if dryRun {
+		err = s.checkApplicable(ctx, r, in)
+	} else {
+		err = s.applyChange(ctx, session, r, in)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return ResultStatusSucceeded, nil
+}
+
+// isApplied returns true if the repository already is in the state the operation would put it in.
+func (s *Service) isApplied(ctx context.Context, r *types.Repository, in *Input) (bool, error) {
+	var err error
+	switch in.Operation {
+	case OperationCreateRule:
+		_, err = s.ruleStore.FindByIdentifier(ctx, nil, &r.ID, in.Rule.Identifier)
+	case OperationCreateWebhook:
+		_, err = s.webhookStore.FindByIdentifier(ctx, enum.WebhookParentRepo, r.ID, in.Webhook.Identifier)
+	case OperationSetVisibility:
+		isPublic, errGet := s.publicAccess.Get(ctx, enum.PublicResourceTypeRepo, r.Path)
+		if errGet != nil {
+			return false, fmt.Errorf("failed to check repository public access: %w", errGet)
+		}
+		return isPublic == *in.IsPublic, nil
+	}
+
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check if %s is already applied: %w", in.Operation, err)
+	}
+
+	return true, nil
+}
+
+// checkApplicable returns the error applying the operation to the repository would fail with,
+// for the cases that aren't covered by the input validation.
+func (s *Service) checkApplicable(ctx context.Context, r *types.Repository, in *Input) error {
+	if in.Operation != OperationSetVisibility || !*in.IsPublic {
+		return nil
+	}
+
+	isPublicAccessSupported, err := s.publicAccess.IsPublicAccessSupported(ctx, paths.Parent(r.Path))
+	if err != nil {
+		return fmt.Errorf("failed to check if public access is supported: %w", err)
+	}
+	if !isPublicAccessSupported {
+		return errPublicRepoCreationDisabled
+	}
+
+	return nil
+}
+
+func (s *Service) applyChange(
+	ctx context.Context,
+	session *auth.Session,
+	r *types.Repository,
+	in *Input,
+) error {
+	var err error
+	switch in.Operation {
+	case OperationCreateRule:
+		// the controller sanitizes the input in place, hence every repository gets its own copy.
+		rule := *in.Rule
+		_, err = s.repoCtrl.RuleCreate(ctx, session, r.Path, &rule)
+	case OperationCreateWebhook:
+		hook := *in.Webhook
+		_, err = s.webhookCtrl.Create(ctx, session, r.Path, &hook, false)
+	case OperationSetVisibility:
+		_, err = s.repoCtrl.UpdatePublicAccess(ctx, session, r.Path, &repo.UpdatePublicAccessInput{
+			IsPublic: *in.IsPublic,
+		})
+	}
+
+	return err
+}
+
+// reportProgress reports the progress together with the report of all already processed repositories,
+// which allows to poll the per repository results while the job is still running.
+func reportProgress(fn job.ProgressReporter, progress int, report *Report) error {
+	result, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to marshal repository bulk operation report: %w", err)
+	}
+
+	return fn(progress, string(result))
+}
+
+func (s *Service) getJobInput(data string) (jobInput, error) {
+	encrypted, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return jobInput{}, fmt.Errorf("failed to base64 decode job input: %w", err)
+	}
+
+	decrypted, err := s.encrypter.Decrypt(encrypted)
+	if err != nil {
+		return jobInput{}, fmt.Errorf("failed to decrypt job input: %w", err)
+	}
+
+	var input jobInput
+	if err = json.Unmarshal([]byte(decrypted), &input); err != nil {
+		return jobInput{}, fmt.Errorf("failed to unmarshal job input json: %w", err)
+	}
+
+	return input, nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repobulk
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/controller/webhook"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/job"
+)
+
+var (
+	// ErrNotFound is returned if the bulk operation job doesn't exist.
+	ErrNotFound = errors.New("repository bulk operation not found")
+
+	errPublicRepoCreationDisabled = usererror.BadRequest("Public repository creation is disabled.")
+)
+
+// Service applies a settings change to many repositories of a space in a background job.
+// The changes are applied through the repository and webhook controllers,
+// which makes sure the same validation and permission checks are used as for single repositories.
+type Service struct {
+	authorizer     authz.Authorizer
+	principalStore store.PrincipalStore
+	spaceStore     store.SpaceStore
+	repoStore      store.RepoStore
+	ruleStore      store.RuleStore
+	webhookStore   store.WebhookStore
+	publicAccess   publicaccess.Service
+	repoCtrl       *repo.Controller
+	webhookCtrl    *webhook.Controller
+	encrypter      encrypt.Encrypter
+	scheduler      *job.Scheduler
+}
+
+// jobUIDPrefix returns the prefix of all bulk operation jobs started by the principal in the space.
+// It's used to make sure that a job can only be polled through the space it was started in.
+func jobUIDPrefix(spaceID, principalID int64) string {
+	return "repo-bulk-" + strconv.FormatInt(spaceID, 10) + "-" + strconv.FormatInt(principalID, 10) + "-"
+}
+
+func newJobUID(spaceID, principalID int64) (string, error) {
+	uid, err := job.UID()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate job uid: %w", err)
+	}
+
+	return jobUIDPrefix(spaceID, principalID) + strings.ToLower(uid), nil
+}
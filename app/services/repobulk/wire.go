@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repobulk
+
+import (
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/controller/webhook"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/job"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	authorizer authz.Authorizer,
+	principalStore store.PrincipalStore,
+	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
+	ruleStore store.RuleStore,
+	webhookStore store.WebhookStore,
+	publicAccess publicaccess.Service,
+	repoCtrl *repo.Controller,
+	webhookCtrl *webhook.Controller,
+	encrypter encrypt.Encrypter,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+) (*Service, error) {
+	s := &Service{
+		authorizer:     authorizer,
+		principalStore: principalStore,
+		spaceStore:     spaceStore,
+		repoStore:      repoStore,
+		ruleStore:      ruleStore,
+		webhookStore:   webhookStore,
+		publicAccess:   publicAccess,
+		repoCtrl:       repoCtrl,
+		webhookCtrl:    webhookCtrl,
+		encrypter:      encrypter,
+		scheduler:      scheduler,
+	}
+
+	err := executor.Register(jobType, s)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repodocs
+
+import (
+	"path"
+	"strings"
+
+	"github.com/harness/gitness/git"
+)
+
+// readmeDirs are the directories searched for a README, in order of priority.
+var readmeDirs = []string{"", ".github", "docs"}
+
+// readmeExtensions are the extensions of README files, in order of priority.
+// READMEs with other extensions are only used if none of these exist.
+var readmeExtensions = []string{".md", ".markdown", ".mdown", ".rst", ".adoc", ".asciidoc", ".txt", ""}
+
+// licenseNames are the names of license files (without extension).
+var licenseNames = []string{"license", "licence", "copying", "unlicense"}
+
+// licenseExtensions are the extensions of license files.
+var licenseExtensions = []string{"", ".md", ".txt", ".markdown", ".rst"}
+
+// findReadme returns the README with the highest priority among the nodes of a directory.
+func findReadme(nodes []git.TreeNode) (git.TreeNode, bool) {
+	return findFile(nodes, func(name string) (int, bool) {
+		ext := path.Ext(name)
+		if strings.TrimSuffix(name, ext) != "readme" {
+			return 0, false
+		}
+
+		return priority(readmeExtensions, ext), true
+	})
+}
+
+// findLicense returns the license file with the highest priority among the nodes of a directory.
+func findLicense(nodes []git.TreeNode) (git.TreeNode, bool) {
+	return findFile(nodes, func(name string) (int, bool) {
+		ext := path.Ext(name)
+		namePriority := priority(licenseNames, strings.TrimSuffix(name, ext))
+		extPriority := priority(licenseExtensions, ext)
+		if namePriority == len(licenseNames) || extPriority == len(licenseExtensions) {
+			return 0, false
+		}
+
+		return namePriority*len(licenseExtensions) + extPriority, true
+	})
+}
+
+// findFile returns the blob with the lowest rank, ties are broken by the name.
+func findFile(nodes []git.TreeNode, rank func(name string) (int, bool)) (git.TreeNode, bool) {
+	var (
+		found     git.TreeNode
+		foundRank int
+		ok        bool
+	)
+
+	for _, node := range nodes {
+		if node.Type != git.TreeNodeTypeBlob {
+			continue
+		}
+
+		r, match := rank(strings.ToLower(node.Name))
+		if !match {
+			continue
+		}
+
+		if !ok || r < foundRank || (r == foundRank && node.Name < found.Name) {
+			found, foundRank, ok = node, r, true
+		}
+	}
+
+	return found, ok
+}
+
+// priority returns the index of the value in the list, or the length of the list if it's not included.
+func priority(list []string, value string) int {
+	for i, v := range list {
+		if v == value {
+			return i
+		}
+	}
+
+	return len(list)
+}
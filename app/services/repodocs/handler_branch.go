@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repodocs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+)
+
+func (s *Service) handleEventBranchCreated(
+	ctx context.Context,
+	event *events.Event[*gitevents.BranchCreatedPayload],
+) error {
+	return s.handleDefaultBranchPush(ctx, event.Payload.RepoID, event.Payload.Ref, "", event.Payload.SHA)
+}
+
+func (s *Service) handleEventBranchUpdated(
+	ctx context.Context,
+	event *events.Event[*gitevents.BranchUpdatedPayload],
+) error {
+	return s.handleDefaultBranchPush(ctx, event.Payload.RepoID, event.Payload.Ref,
+		event.Payload.OldSHA, event.Payload.NewSHA)
+}
+
+// handleDefaultBranchPush drops the detected license of the previous tip of the default branch
+// and starts the license detection for the new tip, so it's ready when the repository is viewed.
+func (s *Service) handleDefaultBranchPush(
+	ctx context.Context,
+	repoID int64,
+	ref string,
+	oldSHA string,
+	newSHA string,
+) error {
+	repo, err := s.repoStore.Find(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository in db: %w", err)
+	}
+
+	if strings.TrimPrefix(ref, "refs/heads/") != repo.DefaultBranch {
+		return nil
+	}
+
+	if oldSHA != "" {
+		err = s.scheduler.PurgeJobByUID(ctx, jobUID(jobInput{RepoID: repoID, SHA: oldSHA}))
+		if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return fmt.Errorf("failed to purge license detection job of previous tip: %w", err)
+		}
+	}
+
+	return s.detectLicense(ctx, jobInput{RepoID: repoID, SHA: newSHA})
+}
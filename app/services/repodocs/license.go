@@ -0,0 +1,132 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repodocs
+
+import (
+	"embed"
+	"math"
+	"path"
+	"strings"
+	"unicode"
+
+	"github.com/harness/gitness/types"
+)
+
+// licenseMatchThreshold is the minimum similarity of a license file to a license template
+// for the license to be reported.
+const licenseMatchThreshold = 0.9
+
+// licenseTemplates contains the texts of the detected licenses, the file names are the SPDX identifiers.
+// For long licenses the template only contains the distinctive beginning of the license text.
+//
+//go:embed licenses/*.txt
+var licenseTemplates embed.FS
+
+type licenseTemplate struct {
+	spdxID   string
+	shingles map[string]struct{}
+}
+
+var templates = mustLoadTemplates()
+
+func mustLoadTemplates() []licenseTemplate {
+	entries, err := licenseTemplates.ReadDir("licenses")
+	if err != nil {
+		panic(err)
+	}
+
+	result := make([]licenseTemplate, 0, len(entries))
+	for _, entry := range entries {
+		data, err := licenseTemplates.ReadFile(path.Join("licenses", entry.Name()))
+		if err != nil {
+			panic(err)
+		}
+
+		result = append(result, licenseTemplate{
+			spdxID:   strings.TrimSuffix(entry.Name(), ".txt"),
+			shingles: shingles(string(data)),
+		})
+	}
+
+	return result
+}
+
+// matchLicense compares the text with all license templates and returns the best match.
+// The confidence is the fraction of the template that is found in the text. If multiple templates
+// are contained in the text equally well (e.g. BSD-2-Clause in BSD-3-Clause), the longer template wins.
+// Returns nil if no template reaches the threshold.
+func matchLicense(text string) *types.RepoLicense {
+	const epsilon = 0.02
+
+	textShingles := shingles(text)
+	if len(textShingles) == 0 {
+		return nil
+	}
+
+	var (
+		best           *licenseTemplate
+		bestConfidence float64
+		bestCommon     int
+	)
+
+	for i := range templates {
+		common := 0
+		for s := range templates[i].shingles {
+			if _, ok := textShingles[s]; ok {
+				common++
+			}
+		}
+
+		confidence := float64(common) / float64(len(templates[i].shingles))
+		if confidence > bestConfidence+epsilon ||
+			(confidence > bestConfidence-epsilon && common > bestCommon) {
+			best = &templates[i]
+			bestConfidence = confidence
+			bestCommon = common
+		}
+	}
+
+	if best == nil || bestConfidence < licenseMatchThreshold {
+		return nil
+	}
+
+	return &types.RepoLicense{
+		SPDXID:     best.spdxID,
+		Confidence: math.Round(bestConfidence*100) / 100,
+	}
+}
+
+// shingles returns the set of word trigrams of the normalized text.
+// Copyright lines are ignored because they differ between projects.
+func shingles(text string) map[string]struct{} {
+	words := make([]string, 0, len(text)/5)
+	for _, line := range strings.Split(strings.ToLower(text), "\n") {
+		trimmed := strings.TrimLeft(line, " \t#*/-=")
+		if strings.HasPrefix(trimmed, "copyright") {
+			continue
+		}
+
+		words = append(words, strings.FieldsFunc(trimmed, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})...)
+	}
+
+	result := make(map[string]struct{}, len(words))
+	for i := 0; i+2 < len(words); i++ {
+		result[words[i]+" "+words[i+1]+" "+words[i+2]] = struct{}{}
+	}
+
+	return result
+}
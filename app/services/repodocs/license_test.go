@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repodocs
+
+import (
+	"strings"
+	"testing"
+)
+
+func readTemplate(t *testing.T, spdxID string) string {
+	t.Helper()
+	data, err := licenseTemplates.ReadFile("licenses/" + spdxID + ".txt")
+	if err != nil {
+		t.Fatalf("failed to read template: %s", err)
+	}
+	return string(data)
+}
+
+func TestMatchLicense(t *testing.T) {
+	mit := readTemplate(t, "MIT")
+	bsd3 := readTemplate(t, "BSD-3-Clause")
+	bsd2 := readTemplate(t, "BSD-2-Clause")
+
+	tests := []struct {
+		name   string
+		text   string
+		spdxID string
+	}{
+		{
+			name:   "mit with copyright line",
+			text:   strings.Replace(mit, "MIT License\n", "MIT License\n\nCopyright (c) 2024 Jane Doe\n", 1),
+			spdxID: "MIT",
+		},
+		{
+			name:   "bsd-3 wins over contained bsd-2",
+			text:   bsd3,
+			spdxID: "BSD-3-Clause",
+		},
+		{
+			name:   "bsd-2",
+			text:   bsd2,
+			spdxID: "BSD-2-Clause",
+		},
+		{
+			name:   "reformatted apache",
+			text:   strings.Join(strings.Fields(readTemplate(t, "Apache-2.0")), " "),
+			spdxID: "Apache-2.0",
+		},
+		{
+			name: "unknown",
+			text: "All rights reserved. Do not copy.",
+		},
+		{
+			name: "partial mit",
+			text: mit[:len(mit)/2],
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			license := matchLicense(test.text)
+			if test.spdxID == "" {
+				if license != nil {
+					t.Errorf("expected no license, got %s (%v)", license.SPDXID, license.Confidence)
+				}
+				return
+			}
+
+			if license == nil {
+				t.Fatalf("expected %s, got no license", test.spdxID)
+			}
+			if license.SPDXID != test.spdxID {
+				t.Errorf("expected %s, got %s", test.spdxID, license.SPDXID)
+			}
+		})
+	}
+}
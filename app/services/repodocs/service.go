@@ -0,0 +1,326 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repodocs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/cache"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	groupGitEvents = "gitness:repodocs"
+
+	jobType        = "repo-license-detection"
+	jobMaxRetries  = 0
+	jobMaxDuration = 5 * time.Minute
+
+	// licenseMaxSize is the maximum number of bytes of a license file that are compared with the templates.
+	licenseMaxSize = 128 * 1024
+
+	readmeCacheDuration = 10 * time.Minute
+)
+
+var _ job.Handler = (*Service)(nil)
+
+type Config struct {
+	EventReaderName string
+	Concurrency     int
+	MaxRetries      int
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.EventReaderName == "" {
+		return errors.New("config.EventReaderName is required")
+	}
+	if c.Concurrency < 1 {
+		return errors.New("config.Concurrency has to be a positive number")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("config.MaxRetries can't be negative")
+	}
+	return nil
+}
+
+// Docs are the README and license of a repository at the tip of its default branch.
+type Docs struct {
+	ReadmePath string
+	// License is nil if the repository has no known license, or the detection is still in progress.
+	License *types.RepoLicense
+}
+
+type jobInput struct {
+	RepoID int64  `json:"repo_id"`
+	SHA    string `json:"sha"`
+}
+
+// Service detects the README and the license of repositories.
+// The README is looked up directly and cached in memory, the license is detected by matching the
+// license file against license templates in a background job. Both are keyed by the commit SHA
+// of the default branch tip, pushes to the default branch trigger the detection for the new tip.
+type Service struct {
+	git       git.Interface
+	repoStore store.RepoStore
+	scheduler *job.Scheduler
+	readmes   cache.Cache[readmeKey, string]
+}
+
+func NewService(
+	ctx context.Context,
+	config Config,
+	gitInterface git.Interface,
+	repoStore store.RepoStore,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided repo docs service config is invalid: %w", err)
+	}
+
+	s := &Service{
+		git:       gitInterface,
+		repoStore: repoStore,
+		scheduler: scheduler,
+		readmes:   cache.New[readmeKey, string](readmeFinder{git: gitInterface}, readmeCacheDuration),
+	}
+
+	if err := executor.Register(jobType, s); err != nil {
+		return nil, err
+	}
+
+	_, err := gitReaderFactory.Launch(ctx, groupGitEvents, config.EventReaderName,
+		func(r *gitevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterBranchCreated(s.handleEventBranchCreated)
+			_ = r.RegisterBranchUpdated(s.handleEventBranchUpdated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch git event reader for repo docs: %w", err)
+	}
+
+	return s, nil
+}
+
+// Get returns the README and the license of the repository at the tip of its default branch.
+// If the license of the tip wasn't detected yet, the detection is started in the background.
+func (s *Service) Get(ctx context.Context, repo *types.Repository) (*Docs, error) {
+	if repo.IsEmpty {
+		return &Docs{}, nil
+	}
+
+	branch, err := s.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.CreateReadParams(repo),
+		BranchName: repo.DefaultBranch,
+	})
+	if errors.IsNotFound(err) {
+		return &Docs{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get default branch: %w", err)
+	}
+
+	tipSHA := branch.Branch.SHA.String()
+
+	readmePath, err := s.readmes.Get(ctx, readmeKey{GitUID: repo.GitUID, SHA: tipSHA})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find readme: %w", err)
+	}
+
+	license, err := s.license(ctx, jobInput{RepoID: repo.ID, SHA: tipSHA})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Docs{
+		ReadmePath: readmePath,
+		License:    license,
+	}, nil
+}
+
+func jobUID(input jobInput) string {
+	return fmt.Sprintf("repo-license-%d-%s", input.RepoID, input.SHA)
+}
+
+// license returns the detected license of the commit, or nil if the detection is still running.
+func (s *Service) license(ctx context.Context, input jobInput) (*types.RepoLicense, error) {
+	uid := jobUID(input)
+
+	progress, err := s.scheduler.GetJobProgress(ctx, uid)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, s.detectLicense(ctx, input)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get license detection job progress: %w", err)
+	}
+
+	switch progress.State {
+	case job.JobStateFinished:
+		var license *types.RepoLicense
+		if err := json.Unmarshal([]byte(progress.Result), &license); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal license: %w", err)
+		}
+
+		return license, nil
+	case job.JobStateFailed, job.JobStateCanceled:
+		// purge the job so that the next request retries the detection.
+		log.Ctx(ctx).Warn().Str("failure", progress.Failure).Msg("license detection failed")
+		if err := s.scheduler.PurgeJobByUID(ctx, uid); err != nil {
+			return nil, fmt.Errorf("failed to purge failed license detection job: %w", err)
+		}
+
+		return nil, nil
+	case job.JobStateScheduled, job.JobStateRunning:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown license detection job state: %s", progress.State)
+	}
+}
+
+// detectLicense starts the background job detecting the license of the commit.
+func (s *Service) detectLicense(ctx context.Context, input jobInput) error {
+	data, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Errorf("failed to marshal job input json: %w", err)
+	}
+
+	err = s.scheduler.RunJob(ctx, job.Definition{
+		UID:        jobUID(input),
+		Type:       jobType,
+		MaxRetries: jobMaxRetries,
+		Timeout:    jobMaxDuration,
+		Data:       string(data),
+	})
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		// the job was started by a concurrent request.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to run license detection job: %w", err)
+	}
+
+	return nil
+}
+
+// Handle is the license detection background job handler.
+func (s *Service) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	var input jobInput
+	if err := json.Unmarshal([]byte(data), &input); err != nil {
+		return "", fmt.Errorf("failed to unmarshal job input json: %w", err)
+	}
+
+	repo, err := s.repoStore.Find(ctx, input.RepoID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	readParams := git.CreateReadParams(repo)
+
+	tree, err := s.git.ListTreeNodes(ctx, &git.ListTreeNodeParams{
+		ReadParams: readParams,
+		GitREF:     input.SHA,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list files: %w", err)
+	}
+
+	var license *types.RepoLicense
+
+	if node, ok := findLicense(tree.Nodes); ok {
+		blob, err := s.git.GetBlob(ctx, &git.GetBlobParams{
+			ReadParams: readParams,
+			SHA:        node.SHA,
+			SizeLimit:  licenseMaxSize,
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to get license file: %w", err)
+		}
+
+		content, err := io.ReadAll(blob.Content)
+		_ = blob.Content.Close()
+		if err != nil {
+			return "", fmt.Errorf("failed to read license file: %w", err)
+		}
+
+		license = matchLicense(string(content))
+	}
+
+	result, err := json.Marshal(license)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal license: %w", err)
+	}
+
+	return string(result), nil
+}
+
+type readmeKey struct {
+	GitUID string
+	SHA    string
+}
+
+// readmeFinder looks up the README of a commit, it returns an empty path if there's none.
+type readmeFinder struct {
+	git git.Interface
+}
+
+func (f readmeFinder) Find(ctx context.Context, key readmeKey) (string, error) {
+	for _, dir := range readmeDirs {
+		tree, err := f.git.ListTreeNodes(ctx, &git.ListTreeNodeParams{
+			ReadParams: git.ReadParams{RepoUID: key.GitUID},
+			GitREF:     key.SHA,
+			Path:       dir,
+		})
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to list files of %q: %w", dir, err)
+		}
+
+		if node, ok := findReadme(tree.Nodes); ok {
+			return path.Join(dir, node.Name), nil
+		}
+	}
+
+	return "", nil
+}
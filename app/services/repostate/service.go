@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repostate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Service is the only place where the state of a repository is changed.
+// It validates the transition and records it in the audit log.
+type Service struct {
+	repoStore    store.RepoStore
+	auditService audit.Service
+}
+
+func NewService(
+	repoStore store.RepoStore,
+	auditService audit.Service,
+) *Service {
+	return &Service{
+		repoStore:    repoStore,
+		auditService: auditService,
+	}
+}
+
+// Transition moves the repository to the provided state.
+// The until parameter is the time the repository is expected to leave the new state (0 if unknown).
+func (s *Service) Transition(
+	ctx context.Context,
+	principal *types.Principal,
+	repo *types.Repository,
+	to enum.RepoState,
+	until int64,
+) error {
+	from := repo.State
+	if !from.CanTransitionTo(to) {
+		return usererror.BadRequestf("Changing repository state from %s to %s is not allowed.", from, to)
+	}
+
+	repoClone := repo.Clone()
+
+	err := s.repoStore.UpdateState(ctx, repo, from, to, until)
+	if errors.Is(err, gitness_store.ErrVersionConflict) {
+		return usererror.Conflict("Repository state has been changed concurrently.")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update repository state from %s to %s: %w", from, to, err)
+	}
+
+	log.Ctx(ctx).Info().
+		Int64("repo.id", repo.ID).
+		Str("repo.path", repo.Path).
+		Msgf("repository state changed from %s to %s", from, to)
+
+	err = s.auditService.Log(ctx,
+		*principal,
+		audit.NewResource(audit.ResourceTypeRepository, repo.Identifier),
+		audit.ActionUpdated,
+		paths.Parent(repo.Path),
+		audit.WithOldObject(repoClone),
+		audit.WithNewObject(repo),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for repository state change: %s", err)
+	}
+
+	return nil
+}
@@ -125,6 +125,26 @@ func (s *Service) Get(
 	return true, nil
 }
 
+// Delete deletes the setting with the given key for the given scope.
+func (s *Service) Delete(
+	ctx context.Context,
+	scope enum.SettingsScope,
+	scopeID int64,
+	key Key,
+) error {
+	err := s.settingsStore.Delete(
+		ctx,
+		scope,
+		scopeID,
+		string(key),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete setting from store: %w", err)
+	}
+
+	return nil
+}
+
 // Map maps all available settings using the provided handlers for the given scope.
 func (s *Service) Map(
 	ctx context.Context,
@@ -22,4 +22,27 @@ var (
 	DefaultSecretScanningEnabled     = false
 	KeyFileSizeLimit             Key = "file_size_limit"
 	DefaultFileSizeLimit             = int64(1e+8) // 100 MB
+	// KeyDefaultBranchAutoDetect [bool] enables switching the default branch of an empty repository
+	// to the first pushed branch, in case the configured default branch isn't pushed.
+	KeyDefaultBranchAutoDetect     Key = "default_branch_auto_detect"
+	DefaultDefaultBranchAutoDetect     = true
+	// KeyHiddenRefs [[]string] lists reference prefixes hidden from git clients
+	// in addition to the ones configured for the instance.
+	KeyHiddenRefs     Key = "hidden_refs"
+	DefaultHiddenRefs     = []string{}
+	// KeyMergeCommitTemplate [string] is the template of the message of merge commits created by pull requests.
+	// If empty, the default message is used.
+	KeyMergeCommitTemplate     Key = "merge_commit_template"
+	DefaultMergeCommitTemplate     = ""
+	// KeySquashCommitTemplate [string] is the template of the message of squash commits created by pull requests.
+	// If empty, the default message is used.
+	KeySquashCommitTemplate     Key = "squash_commit_template"
+	DefaultSquashCommitTemplate     = ""
+	// KeyDeleteSourceBranchOnMerge [bool] deletes the source branch of a pull request after it's merged,
+	// unless the merge request explicitly says otherwise.
+	KeyDeleteSourceBranchOnMerge     Key = "delete_source_branch_on_merge"
+	DefaultDeleteSourceBranchOnMerge     = false
+	// KeyCommitMessagePolicy [types.CommitMessagePolicy] is the commit message policy of a repo or a space.
+	// Repos without a policy inherit the policy of the closest ancestor space that has one.
+	KeyCommitMessagePolicy Key = "commit_message_policy"
 )
@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spacearchive
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/harness/gitness/blob"
+)
+
+func TestArchiveRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+
+	bundleRoot := filepath.Join(tmpDir, "bundles")
+	bundle := bundleDir + "/1.bundle"
+	bundleContent := []byte("# v2 git bundle\n")
+
+	if err := os.MkdirAll(filepath.Join(bundleRoot, bundleDir), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(bundleRoot, filepath.FromSlash(bundle)), bundleContent, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	manifest := &Manifest{
+		Version:   ManifestVersion,
+		SpacePath: "root",
+		Spaces: []SpaceEntry{
+			{Path: "child/grandchild"},
+			{Path: "child"},
+		},
+		Repos: []RepoEntry{
+			{ParentPath: "child", Identifier: "repo", Bundle: bundle},
+			{ParentPath: "", Identifier: "empty", IsEmpty: true},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	if err := writeArchive(buf, bundleRoot, manifest); err != nil {
+		t.Fatalf("failed to write archive: %s", err)
+	}
+
+	blobStore, _ := blob.NewFileSystemStore(blob.Config{Bucket: filepath.Join(tmpDir, "blobs")})
+	s := &Service{blobStore: blobStore}
+
+	archivePath, got, err := s.UploadImportArchive(ctx, 1, buf)
+	if err != nil {
+		t.Fatalf("failed to upload archive: %s", err)
+	}
+
+	if len(got.Repos) != 2 || got.Repos[0].Bundle != bundle {
+		t.Errorf("unexpected repos in manifest: %+v", got.Repos)
+	}
+
+	if got.Spaces[0].Path != "child" || got.Spaces[1].Path != "child/grandchild" {
+		t.Errorf("expected parent spaces to be listed first, got: %+v", got.Spaces)
+	}
+
+	extracted := &bytes.Buffer{}
+	if err = s.ExtractBundle(ctx, archivePath, bundle, extracted); err != nil {
+		t.Fatalf("failed to extract bundle: %s", err)
+	}
+
+	if !bytes.Equal(extracted.Bytes(), bundleContent) {
+		t.Errorf("unexpected bundle content: %q", extracted.String())
+	}
+
+	if err = s.ExtractBundle(ctx, archivePath, bundleDir+"/2.bundle", extracted); err == nil {
+		t.Error("expected error for missing bundle")
+	}
+}
@@ -0,0 +1,474 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spacearchive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	exportJobType        = "space_archive_export"
+	exportJobMaxRetries  = 0
+	exportJobMaxDuration = 2 * time.Hour
+
+	// exportBundleProgressMax is the progress reported once all bundles are created,
+	// the remainder is reserved for uploading the archive.
+	exportBundleProgressMax = 90
+
+	listPageSize = 100
+)
+
+var _ job.Handler = (*Service)(nil)
+
+type exportInput struct {
+	SpaceID int64 `json:"space_id"`
+}
+
+// ExportJobUIDFromSpaceID returns the UID of the job that exports the space into an archive.
+func ExportJobUIDFromSpaceID(spaceID int64) string {
+	return "space-archive-export-" + strconv.FormatInt(spaceID, 10)
+}
+
+// RunExport starts a background job that exports the space (including all sub spaces and repositories)
+// into an archive. Any previously exported archive of the space gets replaced.
+func (s *Service) RunExport(ctx context.Context, spaceID int64) error {
+	jobUID := ExportJobUIDFromSpaceID(spaceID)
+
+	progress, err := s.scheduler.GetJobProgress(ctx, jobUID)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to get export job progress: %w", err)
+	}
+	if err == nil {
+		if !progress.State.IsCompleted() {
+			return ErrJobRunning
+		}
+
+		if err = s.scheduler.PurgeJobByUID(ctx, jobUID); err != nil {
+			return fmt.Errorf("failed to purge previous export job: %w", err)
+		}
+	}
+
+	data, err := json.Marshal(exportInput{SpaceID: spaceID})
+	if err != nil {
+		return fmt.Errorf("failed to marshal job input json: %w", err)
+	}
+
+	err = s.scheduler.RunJob(ctx, job.Definition{
+		UID:        jobUID,
+		Type:       exportJobType,
+		MaxRetries: exportJobMaxRetries,
+		Timeout:    exportJobMaxDuration,
+		Data:       string(data),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to run export job: %w", err)
+	}
+
+	return nil
+}
+
+// GetExportProgress returns the progress of the latest archive export of the space.
+func (s *Service) GetExportProgress(ctx context.Context, spaceID int64) (job.Progress, error) {
+	progress, err := s.scheduler.GetJobProgress(ctx, ExportJobUIDFromSpaceID(spaceID))
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return job.Progress{}, ErrNotFound
+	}
+	if err != nil {
+		return job.Progress{}, fmt.Errorf("failed to get export job progress: %w", err)
+	}
+
+	return progress, nil
+}
+
+// DownloadExport returns either a signed URL or a reader for the latest exported archive of the space.
+func (s *Service) DownloadExport(ctx context.Context, spaceID int64) (string, io.ReadCloser, error) {
+	progress, err := s.GetExportProgress(ctx, spaceID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if progress.State != job.JobStateFinished {
+		return "", nil, ErrNotFound
+	}
+
+	archivePath := exportArchivePath(spaceID)
+
+	signedURL, err := s.blobStore.GetSignedURL(ctx, archivePath)
+	if err != nil && !errors.Is(err, blob.ErrNotSupported) {
+		return "", nil, fmt.Errorf("failed to get signed URL: %w", err)
+	}
+
+	if signedURL != "" {
+		return signedURL, nil, nil
+	}
+
+	file, err := s.blobStore.Download(ctx, archivePath)
+	if errors.Is(err, blob.ErrNotFound) {
+		return "", nil, ErrNotFound
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to download archive from blobstore: %w", err)
+	}
+
+	return "", file, nil
+}
+
+// Handle is the space archive export background job handler.
+func (s *Service) Handle(ctx context.Context, data string, fn job.ProgressReporter) (string, error) {
+	var input exportInput
+	if err := json.Unmarshal([]byte(data), &input); err != nil {
+		return "", fmt.Errorf("failed to unmarshal job input json: %w", err)
+	}
+
+	space, err := s.spaceStore.Find(ctx, input.SpaceID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find space: %w", err)
+	}
+
+	log := log.Ctx(ctx).With().
+		Int64("space.id", space.ID).
+		Str("space.path", space.Path).
+		Logger()
+
+	tmpDir, err := os.MkdirTemp(s.tmpDir, "space-archive-export-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer func() {
+		if errRemove := os.RemoveAll(tmpDir); errRemove != nil {
+			log.Warn().Err(errRemove).Msg("failed to remove temporary export directory")
+		}
+	}()
+
+	repos, err := s.listRepos(ctx, space.ID)
+	if err != nil {
+		return "", err
+	}
+
+	manifest, err := s.buildManifest(ctx, space, repos)
+	if err != nil {
+		return "", err
+	}
+
+	log.Info().Msgf("export %d spaces and %d repositories", len(manifest.Spaces), len(manifest.Repos))
+
+	for i, repo := range repos {
+		entry := &manifest.Repos[i]
+
+		if !repo.IsEmpty {
+			bundle := path.Join(bundleDir, strconv.FormatInt(repo.ID, 10)+".bundle")
+			err = s.createBundle(ctx, repo, filepath.Join(tmpDir, filepath.FromSlash(bundle)))
+			if err != nil {
+				// don't fail the whole export, the failure is reported per repo as part of the manifest.
+				log.Warn().Err(err).Msgf("failed to create bundle for repo %q", repo.Path)
+				entry.ExportError = err.Error()
+			} else {
+				entry.Bundle = bundle
+			}
+		}
+
+		if err = fn(exportBundleProgressMax*(i+1)/len(repos), ""); err != nil {
+			log.Warn().Err(err).Msg("failed to report export progress")
+		}
+	}
+
+	pr, pw := io.Pipe()
+	writeErrCh := make(chan error, 1)
+	go func() {
+		errWrite := writeArchive(pw, tmpDir, manifest)
+		_ = pw.CloseWithError(errWrite)
+		writeErrCh <- errWrite
+	}()
+
+	err = s.blobStore.Upload(ctx, pr, exportArchivePath(space.ID))
+	_ = pr.CloseWithError(err)
+	errWrite := <-writeErrCh
+	if err != nil {
+		return "", fmt.Errorf("failed to upload archive: %w", err)
+	}
+	if errWrite != nil {
+		return "", fmt.Errorf("failed to write archive: %w", errWrite)
+	}
+
+	log.Info().Msg("completed space archive export")
+
+	return "", nil
+}
+
+func (s *Service) buildManifest(
+	ctx context.Context,
+	space *types.Space,
+	repos []*types.Repository,
+) (*Manifest, error) {
+	manifest := &Manifest{
+		Version:   ManifestVersion,
+		Exported:  time.Now().UnixMilli(),
+		SpacePath: space.Path,
+	}
+
+	descendants, err := s.spaceStore.GetDescendantsData(ctx, space.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get descendants of space: %w", err)
+	}
+
+	for _, descendant := range descendants {
+		if descendant.ID == space.ID {
+			continue
+		}
+
+		subSpace, err := s.spaceStore.Find(ctx, descendant.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space %d: %w", descendant.ID, err)
+		}
+
+		if subSpace.Deleted != nil {
+			continue
+		}
+
+		isPublic, err := s.publicAccess.Get(ctx, enum.PublicResourceTypeSpace, subSpace.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check space public access: %w", err)
+		}
+
+		manifest.Spaces = append(manifest.Spaces, SpaceEntry{
+			Path:        relativePath(space.Path, subSpace.Path),
+			Description: subSpace.Description,
+			IsPublic:    isPublic,
+			CreatedBy:   s.principalUID(ctx, subSpace.CreatedBy),
+		})
+	}
+
+	manifest.Repos = make([]RepoEntry, len(repos))
+	for i, repo := range repos {
+		isPublic, err := s.publicAccess.Get(ctx, enum.PublicResourceTypeRepo, repo.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check repo public access: %w", err)
+		}
+
+		webhooks, err := s.listWebhooks(ctx, repo.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		manifest.Repos[i] = RepoEntry{
+			ParentPath:    relativePath(space.Path, paths.Parent(repo.Path)),
+			Identifier:    repo.Identifier,
+			Description:   repo.Description,
+			DefaultBranch: repo.DefaultBranch,
+			IsPublic:      isPublic,
+			IsEmpty:       repo.IsEmpty,
+			CreatedBy:     s.principalUID(ctx, repo.CreatedBy),
+			Webhooks:      webhooks,
+		}
+	}
+
+	return manifest, nil
+}
+
+// listRepos returns all active repositories of the space and its sub spaces in a stable order.
+func (s *Service) listRepos(ctx context.Context, spaceID int64) ([]*types.Repository, error) {
+	var repos []*types.Repository
+	for page := 1; ; page++ {
+		reposInPage, err := s.repoStore.List(ctx, spaceID, &types.RepoFilter{
+			Page:      page,
+			Size:      listPageSize,
+			Sort:      enum.RepoAttrCreated,
+			Order:     enum.OrderAsc,
+			Recursive: true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list repositories: %w", err)
+		}
+
+		for _, repo := range reposInPage {
+			if repo.State == enum.RepoStateActive {
+				repos = append(repos, repo)
+			}
+		}
+
+		if len(reposInPage) < listPageSize {
+			return repos, nil
+		}
+	}
+}
+
+func (s *Service) listWebhooks(ctx context.Context, repoID int64) ([]WebhookEntry, error) {
+	var entries []WebhookEntry
+	for page := 1; ; page++ {
+		webhooks, err := s.webhookStore.List(ctx, enum.WebhookParentRepo, repoID, &types.WebhookFilter{
+			Page:         page,
+			Size:         listPageSize,
+			SkipInternal: true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list webhooks: %w", err)
+		}
+
+		for _, webhook := range webhooks {
+			entries = append(entries, WebhookEntry{
+				Identifier:  webhook.Identifier,
+				DisplayName: webhook.DisplayName,
+				Description: webhook.Description,
+				URL:         webhook.URL,
+				Enabled:     webhook.Enabled,
+				Insecure:    webhook.Insecure,
+				Triggers:    webhook.Triggers,
+				CreatedBy:   s.principalUID(ctx, webhook.CreatedBy),
+			})
+		}
+
+		if len(webhooks) < listPageSize {
+			return entries, nil
+		}
+	}
+}
+
+// principalUID returns the UID of the principal, or an empty string in case the principal can't be found.
+func (s *Service) principalUID(ctx context.Context, principalID int64) string {
+	principal, err := s.principalInfoCache.Get(ctx, principalID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to find principal %d", principalID)
+		return ""
+	}
+
+	return principal.UID
+}
+
+func (s *Service) createBundle(ctx context.Context, repo *types.Repository, filePath string) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o700); err != nil {
+		return fmt.Errorf("failed to create bundle directory: %w", err)
+	}
+
+	f, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle file: %w", err)
+	}
+
+	err = s.git.CreateBundle(ctx, &git.CreateBundleParams{
+		ReadParams: git.ReadParams{RepoUID: repo.GitUID},
+	}, f)
+	if errClose := f.Close(); err == nil && errClose != nil {
+		err = fmt.Errorf("failed to close bundle file: %w", errClose)
+	}
+
+	return err
+}
+
+// writeArchive writes a gzip compressed tar archive containing the manifest followed by all bundles.
+// The manifest is always the first entry to allow reading it without reading the whole archive.
+func writeArchive(w io.Writer, bundleRoot string, manifest *Manifest) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+
+	err = tw.WriteHeader(&tar.Header{
+		Name:    manifestFileName,
+		Mode:    0o600,
+		Size:    int64(len(manifestData)),
+		ModTime: time.UnixMilli(manifest.Exported),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write manifest header: %w", err)
+	}
+
+	if _, err = tw.Write(manifestData); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	for _, repo := range manifest.Repos {
+		if repo.Bundle == "" {
+			continue
+		}
+
+		bundlePath := filepath.Join(bundleRoot, filepath.FromSlash(repo.Bundle))
+		if err = writeArchiveFile(tw, bundlePath, repo.Bundle); err != nil {
+			return err
+		}
+	}
+
+	if err = tw.Close(); err != nil {
+		return fmt.Errorf("failed to close tar writer: %w", err)
+	}
+
+	if err = gw.Close(); err != nil {
+		return fmt.Errorf("failed to close gzip writer: %w", err)
+	}
+
+	return nil
+}
+
+func writeArchiveFile(tw *tar.Writer, filePath string, name string) error {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open %q: %w", name, err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat %q: %w", name, err)
+	}
+
+	err = tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0o600,
+		Size:    info.Size(),
+		ModTime: info.ModTime(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write header of %q: %w", name, err)
+	}
+
+	if _, err = io.Copy(tw, f); err != nil {
+		return fmt.Errorf("failed to write %q: %w", name, err)
+	}
+
+	return nil
+}
+
+// relativePath returns the path relative to the provided root path.
+func relativePath(rootPath string, fullPath string) string {
+	if fullPath == rootPath {
+		return ""
+	}
+
+	return strings.TrimPrefix(fullPath, rootPath+"/")
+}
@@ -0,0 +1,226 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spacearchive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// UploadImportArchive stores the provided archive for importing it into the space
+// and returns the archive path together with the manifest of the archive.
+func (s *Service) UploadImportArchive(
+	ctx context.Context,
+	spaceID int64,
+	r io.Reader,
+) (string, *Manifest, error) {
+	archivePath := importArchivePath(spaceID, time.Now().UnixMilli())
+
+	err := s.blobStore.Upload(ctx, r, archivePath)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to upload archive: %w", err)
+	}
+
+	manifest, err := s.ReadManifest(ctx, archivePath)
+	if err != nil {
+		// the archive can't be imported, there's no point in keeping it.
+		if errDelete := s.blobStore.Delete(ctx, archivePath); errDelete != nil {
+			log.Ctx(ctx).Warn().Err(errDelete).Msgf("failed to delete invalid import archive '%s'", archivePath)
+		}
+		return "", nil, err
+	}
+
+	return archivePath, manifest, nil
+}
+
+// ReadManifest reads the manifest of a previously uploaded archive.
+func (s *Service) ReadManifest(ctx context.Context, archivePath string) (*Manifest, error) {
+	var manifest *Manifest
+	err := s.readArchiveFile(ctx, archivePath, manifestFileName, func(r io.Reader) error {
+		manifest = &Manifest{}
+		if err := json.NewDecoder(r).Decode(manifest); err != nil {
+			return fmt.Errorf("%w: failed to decode manifest: %w", ErrInvalidArchive, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if manifest.Version != ManifestVersion {
+		return nil, fmt.Errorf("%w: unsupported manifest version %d", ErrInvalidArchive, manifest.Version)
+	}
+
+	// ensure parent spaces are always listed before their children.
+	sort.SliceStable(manifest.Spaces, func(i, j int) bool {
+		return len(manifest.Spaces[i].Path) < len(manifest.Spaces[j].Path)
+	})
+
+	return manifest, nil
+}
+
+// ExtractBundle writes the git bundle with the provided name from a previously uploaded archive to the writer.
+func (s *Service) ExtractBundle(ctx context.Context, archivePath string, bundle string, w io.Writer) error {
+	return s.readArchiveFile(ctx, archivePath, bundle, func(r io.Reader) error {
+		if _, err := io.Copy(w, r); err != nil {
+			return fmt.Errorf("failed to extract bundle %q: %w", bundle, err)
+		}
+		return nil
+	})
+}
+
+// readArchiveFile streams the archive until the file with the provided name is found
+// and calls the provided function with a reader for the file content.
+func (s *Service) readArchiveFile(
+	ctx context.Context,
+	archivePath string,
+	name string,
+	fn func(r io.Reader) error,
+) error {
+	file, err := s.blobStore.Download(ctx, archivePath)
+	if err != nil {
+		return fmt.Errorf("failed to download archive from blobstore: %w", err)
+	}
+	defer func() {
+		if errClose := file.Close(); errClose != nil {
+			log.Ctx(ctx).Warn().Err(errClose).Msg("failed to close archive")
+		}
+	}()
+
+	gr, err := gzip.NewReader(file)
+	if err != nil {
+		return fmt.Errorf("%w: failed to open gzip stream: %w", ErrInvalidArchive, err)
+	}
+
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return fmt.Errorf("%w: file %q not found in archive", ErrInvalidArchive, name)
+		}
+		if err != nil {
+			return fmt.Errorf("%w: failed to read archive: %w", ErrInvalidArchive, err)
+		}
+
+		if header.Typeflag == tar.TypeReg && header.Name == name {
+			return fn(tr)
+		}
+	}
+}
+
+// PrincipalResolver maps principal UIDs of an archive to principals of this instance.
+// Principals that can't be found are replaced with the fallback principal and remembered as unresolved.
+type PrincipalResolver struct {
+	service    *Service
+	fallback   int64
+	resolved   map[string]int64
+	unresolved map[string]struct{}
+}
+
+func (s *Service) NewPrincipalResolver(fallback *types.Principal) *PrincipalResolver {
+	return &PrincipalResolver{
+		service:    s,
+		fallback:   fallback.ID,
+		resolved:   map[string]int64{},
+		unresolved: map[string]struct{}{},
+	}
+}
+
+// Resolve returns the ID of the principal with the provided UID, or the fallback principal ID.
+func (r *PrincipalResolver) Resolve(ctx context.Context, uid string) (int64, error) {
+	if id, ok := r.resolved[uid]; ok {
+		return id, nil
+	}
+
+	if uid == "" {
+		return r.fallback, nil
+	}
+
+	principal, err := r.service.principalStore.FindByUID(ctx, uid)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		r.unresolved[uid] = struct{}{}
+		r.resolved[uid] = r.fallback
+		return r.fallback, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to find principal %q: %w", uid, err)
+	}
+
+	r.resolved[uid] = principal.ID
+
+	return principal.ID, nil
+}
+
+// Unresolved returns the UIDs of all principals that couldn't be resolved.
+func (r *PrincipalResolver) Unresolved() []string {
+	uids := make([]string, 0, len(r.unresolved))
+	for uid := range r.unresolved {
+		uids = append(uids, uid)
+	}
+
+	sort.Strings(uids)
+
+	return uids
+}
+
+// CreateWebhooks recreates the webhooks of an archived repository.
+// As secrets are not part of the archive, the webhooks are created disabled.
+func (s *Service) CreateWebhooks(
+	ctx context.Context,
+	repo *types.Repository,
+	entries []WebhookEntry,
+	resolver *PrincipalResolver,
+) error {
+	now := time.Now().UnixMilli()
+	for _, entry := range entries {
+		createdBy, err := resolver.Resolve(ctx, entry.CreatedBy)
+		if err != nil {
+			return err
+		}
+
+		err = s.webhookStore.Create(ctx, &types.Webhook{
+			ParentID:    repo.ID,
+			ParentType:  enum.WebhookParentRepo,
+			CreatedBy:   createdBy,
+			Created:     now,
+			Updated:     now,
+			Identifier:  entry.Identifier,
+			DisplayName: entry.DisplayName,
+			Description: entry.Description,
+			URL:         entry.URL,
+			Enabled:     false,
+			Insecure:    entry.Insecure,
+			Triggers:    entry.Triggers,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create webhook %q: %w", entry.Identifier, err)
+		}
+	}
+
+	return nil
+}
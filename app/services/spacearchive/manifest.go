@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spacearchive
+
+import (
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// ManifestVersion is the version of the archive layout written by the exporter.
+	ManifestVersion = 1
+
+	manifestFileName = "manifest.json"
+	bundleDir        = "bundles"
+)
+
+// Manifest describes the content of a space archive.
+// All principal references are stored as principal UIDs, as IDs aren't portable between instances.
+type Manifest struct {
+	Version   int          `json:"version"`
+	Exported  int64        `json:"exported"`
+	SpacePath string       `json:"space_path"`
+	Spaces    []SpaceEntry `json:"spaces"`
+	Repos     []RepoEntry  `json:"repos"`
+}
+
+// SpaceEntry describes a space contained in the archive.
+// The path is relative to the exported space (which itself isn't part of the list).
+type SpaceEntry struct {
+	Path        string `json:"path"`
+	Description string `json:"description"`
+	IsPublic    bool   `json:"is_public"`
+	CreatedBy   string `json:"created_by"`
+}
+
+// RepoEntry describes a repository contained in the archive.
+// The parent path is relative to the exported space (empty for repos directly inside the exported space).
+type RepoEntry struct {
+	ParentPath    string         `json:"parent_path"`
+	Identifier    string         `json:"identifier"`
+	Description   string         `json:"description"`
+	DefaultBranch string         `json:"default_branch"`
+	IsPublic      bool           `json:"is_public"`
+	IsEmpty       bool           `json:"is_empty"`
+	CreatedBy     string         `json:"created_by"`
+	Webhooks      []WebhookEntry `json:"webhooks"`
+
+	// Bundle is the name of the git bundle inside the archive (empty if the repo has no git data).
+	Bundle string `json:"bundle,omitempty"`
+	// ExportError contains the reason why the git data of the repository couldn't be exported.
+	ExportError string `json:"export_error,omitempty"`
+}
+
+// WebhookEntry describes a webhook of a repository contained in the archive.
+// NOTE: Webhook secrets are never exported.
+type WebhookEntry struct {
+	Identifier  string                `json:"identifier"`
+	DisplayName string                `json:"display_name"`
+	Description string                `json:"description"`
+	URL         string                `json:"url"`
+	Enabled     bool                  `json:"enabled"`
+	Insecure    bool                  `json:"insecure"`
+	Triggers    []enum.WebhookTrigger `json:"triggers"`
+	CreatedBy   string                `json:"created_by"`
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spacearchive
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+)
+
+var (
+	// ErrNotFound is returned if no archive was found.
+	ErrNotFound = errors.New("space archive not found")
+
+	// ErrJobRunning is returned if an export of the space is already in progress.
+	ErrJobRunning = errors.New("an archive export job is already running")
+
+	// ErrInvalidArchive is returned if the provided archive can't be read.
+	ErrInvalidArchive = errors.New("invalid space archive")
+)
+
+// Service exports spaces into portable archives (metadata as JSON and git bundles of all repositories)
+// and provides access to the content of such archives for importing them again.
+type Service struct {
+	tmpDir             string
+	git                git.Interface
+	spaceStore         store.SpaceStore
+	repoStore          store.RepoStore
+	webhookStore       store.WebhookStore
+	principalStore     store.PrincipalStore
+	principalInfoCache store.PrincipalInfoCache
+	publicAccess       publicaccess.Service
+	blobStore          blob.Store
+	scheduler          *job.Scheduler
+}
+
+func exportArchivePath(spaceID int64) string {
+	return fmt.Sprintf("spaces/%d/archive/export.tar.gz", spaceID)
+}
+
+func importArchivePath(spaceID int64, timestamp int64) string {
+	return fmt.Sprintf("spaces/%d/archive/import-%d.tar.gz", spaceID, timestamp)
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spacearchive
+
+import (
+	"github.com/harness/gitness/app/services/publicaccess"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	config *types.Config,
+	git git.Interface,
+	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
+	webhookStore store.WebhookStore,
+	principalStore store.PrincipalStore,
+	principalInfoCache store.PrincipalInfoCache,
+	publicAccess publicaccess.Service,
+	blobStore blob.Store,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+) (*Service, error) {
+	s := &Service{
+		tmpDir:             config.Git.TmpDir,
+		git:                git,
+		spaceStore:         spaceStore,
+		repoStore:          repoStore,
+		webhookStore:       webhookStore,
+		principalStore:     principalStore,
+		principalInfoCache: principalInfoCache,
+		publicAccess:       publicAccess,
+		blobStore:          blobStore,
+		scheduler:          scheduler,
+	}
+
+	err := executor.Register(exportJobType, s)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
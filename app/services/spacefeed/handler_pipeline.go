@@ -0,0 +1,41 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spacefeed
+
+import (
+	"context"
+
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ExecutionData is the data of the execution feed events.
+type ExecutionData struct {
+	RepoID          int64         `json:"repo_id"`
+	PipelineID      int64         `json:"pipeline_id"`
+	ExecutionNumber int64         `json:"execution_number"`
+	Status          enum.CIStatus `json:"status"`
+}
+
+func (s *Service) handleEventPipelineExecuted(ctx context.Context,
+	event *events.Event[*pipelineevents.ExecutedPayload]) error {
+	return s.publish(ctx, event.Payload.RepoID, enum.SSETypeExecutionStateChanged, ExecutionData{
+		RepoID:          event.Payload.RepoID,
+		PipelineID:      event.Payload.PipelineID,
+		ExecutionNumber: event.Payload.ExecutionNum,
+		Status:          event.Payload.Status,
+	})
+}
@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spacefeed
+
+import (
+	"context"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types/enum"
+)
+
+// PullReqData is the data of the pull request feed events.
+type PullReqData struct {
+	RepoID      int64  `json:"repo_id"`
+	PullReqID   int64  `json:"pullreq_id"`
+	Number      int64  `json:"number"`
+	PrincipalID int64  `json:"principal_id"`
+	Change      string `json:"change"`
+}
+
+func (s *Service) publishPullReq(ctx context.Context, base pullreqevents.Base, change string) error {
+	return s.publish(ctx, base.TargetRepoID, enum.SSETypePullReqChanged, PullReqData{
+		RepoID:      base.TargetRepoID,
+		PullReqID:   base.PullReqID,
+		Number:      base.Number,
+		PrincipalID: base.PrincipalID,
+		Change:      change,
+	})
+}
+
+func (s *Service) handleEventPullReqCreated(ctx context.Context,
+	event *events.Event[*pullreqevents.CreatedPayload]) error {
+	return s.publishPullReq(ctx, event.Payload.Base, string(pullreqevents.CreatedEvent))
+}
+
+func (s *Service) handleEventPullReqUpdated(ctx context.Context,
+	event *events.Event[*pullreqevents.UpdatedPayload]) error {
+	return s.publishPullReq(ctx, event.Payload.Base, string(pullreqevents.UpdatedEvent))
+}
+
+func (s *Service) handleEventPullReqBranchUpdated(ctx context.Context,
+	event *events.Event[*pullreqevents.BranchUpdatedPayload]) error {
+	return s.publishPullReq(ctx, event.Payload.Base, string(pullreqevents.BranchUpdatedEvent))
+}
+
+func (s *Service) handleEventPullReqClosed(ctx context.Context,
+	event *events.Event[*pullreqevents.ClosedPayload]) error {
+	return s.publishPullReq(ctx, event.Payload.Base, string(pullreqevents.ClosedEvent))
+}
+
+func (s *Service) handleEventPullReqReopened(ctx context.Context,
+	event *events.Event[*pullreqevents.ReopenedPayload]) error {
+	return s.publishPullReq(ctx, event.Payload.Base, string(pullreqevents.ReopenedEvent))
+}
+
+func (s *Service) handleEventPullReqMerged(ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload]) error {
+	return s.publishPullReq(ctx, event.Payload.Base, string(pullreqevents.MergedEvent))
+}
@@ -0,0 +1,45 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spacefeed
+
+import (
+	"context"
+
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types/enum"
+)
+
+// RepoData is the data of the repository feed events.
+type RepoData struct {
+	RepoID      int64 `json:"repo_id"`
+	PrincipalID int64 `json:"principal_id"`
+}
+
+func (s *Service) handleEventRepoCreated(ctx context.Context,
+	event *events.Event[*repoevents.CreatedPayload]) error {
+	return s.publish(ctx, event.Payload.RepoID, enum.SSETypeRepositoryCreated, RepoData{
+		RepoID:      event.Payload.RepoID,
+		PrincipalID: event.Payload.PrincipalID,
+	})
+}
+
+func (s *Service) handleEventRepoUpdated(ctx context.Context,
+	event *events.Event[*repoevents.UpdatedPayload]) error {
+	return s.publish(ctx, event.Payload.RepoID, enum.SSETypeRepositoryUpdated, RepoData{
+		RepoID:      event.Payload.RepoID,
+		PrincipalID: event.Payload.PrincipalID,
+	})
+}
@@ -0,0 +1,320 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spacefeed
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	eventsReaderGroupName = "gitness:spacefeed"
+
+	// feedStreamID is the ID of the event log stream that keeps the feed events for resuming streams.
+	feedStreamID = "spacefeed"
+
+	// trimInterval is the number of appended feed events after which the feed is trimmed.
+	trimInterval = 100
+
+	// replayPageSize is the number of feed events read at once during a replay.
+	replayPageSize = 100
+
+	payloadKeyType     = "type"
+	payloadKeyRepoID   = "repo_id"
+	payloadKeySpaceIDs = "space_ids"
+	payloadKeyData     = "data"
+)
+
+type Config struct {
+	EventReaderName string
+	Concurrency     int
+	MaxRetries      int
+	// MaxLength is the number of feed events kept for resuming streams.
+	MaxLength int64
+	// ReplayLimit is the maximum number of feed events replayed when a stream is resumed.
+	ReplayLimit int
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.EventReaderName == "" {
+		return errors.New("config.EventReaderName is required")
+	}
+	if c.Concurrency < 1 {
+		return errors.New("config.Concurrency has to be a positive number")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("config.MaxRetries can't be negative")
+	}
+	if c.MaxLength < 1 {
+		return errors.New("config.MaxLength has to be a positive number")
+	}
+	if c.ReplayLimit < 0 {
+		return errors.New("config.ReplayLimit can't be negative")
+	}
+
+	return nil
+}
+
+// Service turns repository, pipeline and pull request events into lightweight live updates
+// that are published to the event streams of all spaces above the affected repository.
+// Every update is also appended to the event log, its offset is used as the ID of the event,
+// which allows clients to resume a stream after a disconnect.
+type Service struct {
+	config      Config
+	logStore    stream.LogStore
+	repoStore   store.RepoStore
+	spaceStore  store.SpaceStore
+	sseStreamer sse.Streamer
+
+	mx       sync.Mutex
+	appended int
+}
+
+func New(
+	ctx context.Context,
+	config Config,
+	logStore stream.LogStore,
+	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
+	sseStreamer sse.Streamer,
+	repoEvReaderFactory *events.ReaderFactory[*repoevents.Reader],
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	pipelineEvReaderFactory *events.ReaderFactory[*pipelineevents.Reader],
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided space feed service config is invalid: %w", err)
+	}
+
+	service := &Service{
+		config:      config,
+		logStore:    logStore,
+		repoStore:   repoStore,
+		spaceStore:  spaceStore,
+		sseStreamer: sseStreamer,
+	}
+
+	const idleTimeout = 1 * time.Minute
+
+	_, err := repoEvReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *repoevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterCreated(service.handleEventRepoCreated)
+			_ = r.RegisterUpdated(service.handleEventRepoUpdated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch repo events reader: %w", err)
+	}
+
+	_, err = pullreqEvReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *pullreqevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterCreated(service.handleEventPullReqCreated)
+			_ = r.RegisterUpdated(service.handleEventPullReqUpdated)
+			_ = r.RegisterBranchUpdated(service.handleEventPullReqBranchUpdated)
+			_ = r.RegisterClosed(service.handleEventPullReqClosed)
+			_ = r.RegisterReopened(service.handleEventPullReqReopened)
+			_ = r.RegisterMerged(service.handleEventPullReqMerged)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pullreq events reader: %w", err)
+	}
+
+	_, err = pipelineEvReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *pipelineevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterExecuted(service.handleEventPipelineExecuted)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pipeline events reader: %w", err)
+	}
+
+	return service, nil
+}
+
+// publish appends the feed event to the event log and publishes it to all spaces above the repository.
+func (s *Service) publish(ctx context.Context, repoID int64, eventType enum.SSEType, data any) error {
+	repo, err := s.repoStore.Find(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repo %d: %w", repoID, err)
+	}
+
+	spaceIDs, err := s.spaceStore.GetAncestorIDs(ctx, repo.ParentID)
+	if err != nil {
+		return fmt.Errorf("failed to get ancestors of space %d: %w", repo.ParentID, err)
+	}
+
+	rawData, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal feed event data: %w", err)
+	}
+
+	rawSpaceIDs := make([]string, len(spaceIDs))
+	for i, spaceID := range spaceIDs {
+		rawSpaceIDs[i] = strconv.FormatInt(spaceID, 10)
+	}
+
+	id, err := s.logStore.Append(ctx, feedStreamID, map[string][]byte{
+		payloadKeyType:     []byte(eventType),
+		payloadKeyRepoID:   []byte(strconv.FormatInt(repoID, 10)),
+		payloadKeySpaceIDs: []byte(strings.Join(rawSpaceIDs, ",")),
+		payloadKeyData:     rawData,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to append feed event to the event log: %w", err)
+	}
+
+	if s.shouldTrim() {
+		if err = s.logStore.Trim(ctx, feedStreamID, s.config.MaxLength); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to trim space feed")
+		}
+	}
+
+	event := &sse.Event{
+		ID:     strconv.FormatInt(id, 10),
+		Type:   eventType,
+		Data:   rawData,
+		RepoID: repoID,
+	}
+
+	// the event is in the event log already, clients that miss it will get it when resuming the stream.
+	for _, spaceID := range spaceIDs {
+		if err = s.sseStreamer.PublishEvent(ctx, spaceID, event); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to publish feed event to space %d", spaceID)
+		}
+	}
+
+	return nil
+}
+
+// shouldTrim returns true every trimInterval appended feed events.
+func (s *Service) shouldTrim() bool {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.appended++
+	if s.appended < trimInterval {
+		return false
+	}
+
+	s.appended = 0
+	return true
+}
+
+// Replay returns the feed events of the space with an ID greater than afterID, ordered by ID.
+// At most ReplayLimit events are returned. It also returns the ID of the last feed event
+// that has been scanned, live events with an ID up to that one have been replayed already.
+func (s *Service) Replay(ctx context.Context, spaceID int64, afterID int64) ([]*sse.Event, int64, error) {
+	var res []*sse.Event
+
+	lastID := afterID
+	for len(res) < s.config.ReplayLimit {
+		msgs, err := s.logStore.ListAfter(ctx, feedStreamID, lastID, replayPageSize)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to list feed events after %d: %w", lastID, err)
+		}
+
+		for _, msg := range msgs {
+			lastID = msg.ID
+
+			event, ok := toSpaceEvent(msg, spaceID)
+			if !ok {
+				continue
+			}
+
+			res = append(res, event)
+			if len(res) >= s.config.ReplayLimit {
+				break
+			}
+		}
+
+		if len(msgs) < replayPageSize {
+			break
+		}
+	}
+
+	return res, lastID, nil
+}
+
+// toSpaceEvent converts the feed event into an SSE event if it was published to the space.
+func toSpaceEvent(msg stream.LogMessage, spaceID int64) (*sse.Event, bool) {
+	space := strconv.FormatInt(spaceID, 10)
+
+	found := false
+	for _, id := range strings.Split(string(msg.Payload[payloadKeySpaceIDs]), ",") {
+		if id == space {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, false
+	}
+
+	repoID, err := strconv.ParseInt(string(msg.Payload[payloadKeyRepoID]), 10, 64)
+	if err != nil {
+		return nil, false
+	}
+
+	return &sse.Event{
+		ID:     strconv.FormatInt(msg.ID, 10),
+		Type:   enum.SSEType(msg.Payload[payloadKeyType]),
+		Data:   msg.Payload[payloadKeyData],
+		RepoID: repoID,
+	}, true
+}
@@ -0,0 +1,175 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// dayMillis is the length of a day in milliseconds, the size of a report bucket.
+	dayMillis = int64(24 * time.Hour / time.Millisecond)
+
+	listPageSize = 100
+)
+
+// Report returns the daily usage of the child spaces and repositories of the space
+// for the UTC days containing from and to (unix millis). Days without recorded usage are reported as zero.
+// The storage sizes of a child space are the sum of the daily high-water marks of its repositories.
+func (s *Service) Report(
+	ctx context.Context,
+	space *types.Space,
+	from int64,
+	to int64,
+) (*types.UsageReport, error) {
+	fromDay := dayStart(time.UnixMilli(from)).UnixMilli()
+	toDay := dayStart(time.UnixMilli(to)).UnixMilli()
+	if toDay < fromDay {
+		return nil, usererror.BadRequest("The start of the usage report must not be after its end.")
+	}
+
+	days := int((toDay-fromDay)/dayMillis) + 1
+	if days > s.maxReportDays {
+		return nil, usererror.BadRequestf("A usage report can't span more than %d days.", s.maxReportDays)
+	}
+
+	repos, err := s.listRepos(ctx, space.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, repoEntries := groupRepos(space.Path, repos, days, fromDay)
+
+	repoIDs := make([]int64, 0, len(repoEntries))
+	for repoID := range repoEntries {
+		repoIDs = append(repoIDs, repoID)
+	}
+
+	rollups, err := s.usageStore.ListByRepos(ctx, repoIDs, fromDay, toDay)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list usage rollups: %w", err)
+	}
+
+	for _, rollup := range rollups {
+		entry, ok := repoEntries[rollup.RepoID]
+		if !ok {
+			continue
+		}
+
+		bucket := &entry.Buckets[(rollup.Day-fromDay)/dayMillis]
+		bucket.CIMinutes += float64(rollup.CIDuration) / float64(time.Minute/time.Millisecond)
+		bucket.GitSize += rollup.GitSize
+		bucket.ArtifactsSize += rollup.ArtifactsSize
+	}
+
+	report := &types.UsageReport{
+		SpacePath: space.Path,
+		From:      fromDay,
+		To:        toDay,
+		Entries:   make([]types.UsageEntry, len(entries)),
+	}
+
+	for i, entry := range entries {
+		for _, bucket := range entry.Buckets {
+			entry.CIMinutes += bucket.CIMinutes
+			entry.GitSize = max(entry.GitSize, bucket.GitSize)
+			entry.ArtifactsSize = max(entry.ArtifactsSize, bucket.ArtifactsSize)
+		}
+
+		report.Entries[i] = *entry
+	}
+
+	return report, nil
+}
+
+// groupRepos groups the repositories by the direct child of the space they belong to.
+// Repositories that are direct children of the space get an entry of their own.
+// It returns the entries with zeroed buckets, ordered by path, and the entry of each repository.
+func groupRepos(
+	spacePath string,
+	repos []*types.Repository,
+	days int,
+	fromDay int64,
+) ([]*types.UsageEntry, map[int64]*types.UsageEntry) {
+	entries := make([]*types.UsageEntry, 0)
+	entriesByPath := make(map[string]*types.UsageEntry)
+	repoEntries := make(map[int64]*types.UsageEntry, len(repos))
+
+	for _, repo := range repos {
+		relPath := strings.TrimPrefix(repo.Path, spacePath+types.PathSeparator)
+
+		entryType := enum.ResourceTypeRepo
+		identifier, _, nested := strings.Cut(relPath, types.PathSeparator)
+		if nested {
+			entryType = enum.ResourceTypeSpace
+		}
+
+		path := spacePath + types.PathSeparator + identifier
+
+		entry, ok := entriesByPath[path]
+		if !ok {
+			entry = &types.UsageEntry{
+				Type:       entryType,
+				Identifier: identifier,
+				Path:       path,
+				Buckets:    make([]types.UsageBucket, days),
+			}
+			for i := range entry.Buckets {
+				entry.Buckets[i].Day = fromDay + int64(i)*dayMillis
+			}
+
+			entriesByPath[path] = entry
+			entries = append(entries, entry)
+		}
+
+		repoEntries[repo.ID] = entry
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Path < entries[j].Path
+	})
+
+	return entries, repoEntries
+}
+
+func (s *Service) listRepos(ctx context.Context, spaceID int64) ([]*types.Repository, error) {
+	var repos []*types.Repository
+	for page := 1; ; page++ {
+		reposInPage, err := s.repoStore.List(ctx, spaceID, &types.RepoFilter{
+			Page:      page,
+			Size:      listPageSize,
+			Sort:      enum.RepoAttrCreated,
+			Order:     enum.OrderAsc,
+			Recursive: true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list repositories: %w", err)
+		}
+
+		repos = append(repos, reposInPage...)
+
+		if len(reposInPage) < listPageSize {
+			return repos, nil
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDayStart(t *testing.T) {
+	// 2024-03-10 23:30 in UTC-5 is already the 11th in UTC.
+	local := time.Date(2024, 3, 10, 23, 30, 0, 0, time.FixedZone("", -5*60*60))
+	want := time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)
+
+	if got := dayStart(local); !got.Equal(want) {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestGroupRepos(t *testing.T) {
+	repos := []*types.Repository{
+		{ID: 1, Identifier: "web", Path: "acme/web"},
+		{ID: 2, Identifier: "api", Path: "acme/backend/api"},
+		{ID: 3, Identifier: "db", Path: "acme/backend/infra/db"},
+		{ID: 4, Identifier: "app", Path: "acme/android/app"},
+	}
+
+	fromDay := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+
+	entries, repoEntries := groupRepos("acme", repos, 3, fromDay)
+
+	want := []struct {
+		typ        enum.ResourceType
+		identifier string
+		path       string
+	}{
+		{typ: enum.ResourceTypeSpace, identifier: "android", path: "acme/android"},
+		{typ: enum.ResourceTypeSpace, identifier: "backend", path: "acme/backend"},
+		{typ: enum.ResourceTypeRepo, identifier: "web", path: "acme/web"},
+	}
+
+	if len(entries) != len(want) {
+		t.Fatalf("expected %d entries, got %d", len(want), len(entries))
+	}
+
+	for i, w := range want {
+		entry := entries[i]
+		if entry.Type != w.typ || entry.Identifier != w.identifier || entry.Path != w.path {
+			t.Errorf("entry %d: expected %s %s %s, got %s %s %s",
+				i, w.typ, w.identifier, w.path, entry.Type, entry.Identifier, entry.Path)
+		}
+
+		if len(entry.Buckets) != 3 {
+			t.Fatalf("entry %d: expected 3 buckets, got %d", i, len(entry.Buckets))
+		}
+		for j, bucket := range entry.Buckets {
+			if wantDay := fromDay + int64(j)*dayMillis; bucket.Day != wantDay {
+				t.Errorf("entry %d bucket %d: expected day %d, got %d", i, j, wantDay, bucket.Day)
+			}
+		}
+	}
+
+	if repoEntries[2] != repoEntries[3] || repoEntries[2].Path != "acme/backend" {
+		t.Errorf("expected repos 2 and 3 to be grouped into acme/backend")
+	}
+	if repoEntries[1].Path != "acme/web" {
+		t.Errorf("expected repo 1 to have its own entry, got %s", repoEntries[1].Path)
+	}
+}
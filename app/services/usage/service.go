@@ -0,0 +1,141 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const jobType = "gitness:usage:rollup"
+
+var _ job.Handler = (*Service)(nil)
+
+// Service aggregates the CI minutes and storage consumption of repositories into daily rollups
+// and builds the usage reports of spaces out of them.
+type Service struct {
+	enabled       bool
+	cron          string
+	maxDur        time.Duration
+	maxReportDays int
+	repoStore     store.RepoStore
+	artifactStore store.ArtifactStore
+	usageStore    store.UsageStore
+	scheduler     *job.Scheduler
+}
+
+func (s *Service) Register(ctx context.Context) error {
+	if !s.enabled {
+		return nil
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobType, jobType, s.cron, s.maxDur)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for usage rollups: %w", err)
+	}
+
+	return nil
+}
+
+// Handle is the usage rollup background job handler.
+// It recomputes the CI durations of yesterday and today, to include steps that finished
+// after the last run of yesterday, and samples the current storage sizes into today's rollups.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	now := time.Now()
+	today := dayStart(now)
+	yesterday := today.AddDate(0, 0, -1)
+
+	durationsYesterday, err := s.usageStore.SumCIDurations(ctx, yesterday.UnixMilli(), today.UnixMilli())
+	if err != nil {
+		return "", fmt.Errorf("failed to sum CI durations of yesterday: %w", err)
+	}
+
+	durationsToday, err := s.usageStore.SumCIDurations(ctx, today.UnixMilli(), today.AddDate(0, 0, 1).UnixMilli())
+	if err != nil {
+		return "", fmt.Errorf("failed to sum CI durations of today: %w", err)
+	}
+
+	sizeInfos, err := s.repoStore.ListSizeInfos(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list repository sizes: %w", err)
+	}
+
+	artifactSizes, err := s.artifactStore.SumSizes(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to sum artifact sizes: %w", err)
+	}
+
+	// the storage sizes of yesterday are left untouched, the upsert only ever raises them.
+	for repoID, duration := range durationsYesterday {
+		err = s.usageStore.Upsert(ctx, &types.UsageRollup{
+			RepoID:     repoID,
+			Day:        yesterday.UnixMilli(),
+			CIDuration: duration,
+			Updated:    now.UnixMilli(),
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to update usage rollup of yesterday for repo %d: %w", repoID, err)
+		}
+	}
+
+	rollups := make(map[int64]*types.UsageRollup, len(sizeInfos))
+	rollup := func(repoID int64) *types.UsageRollup {
+		r, ok := rollups[repoID]
+		if !ok {
+			r = &types.UsageRollup{
+				RepoID:  repoID,
+				Day:     today.UnixMilli(),
+				Updated: now.UnixMilli(),
+			}
+			rollups[repoID] = r
+		}
+		return r
+	}
+
+	for _, info := range sizeInfos {
+		// the repository size is stored in KiB.
+		rollup(info.ID).GitSize = info.Size * 1024
+	}
+	for repoID, size := range artifactSizes {
+		rollup(repoID).ArtifactsSize = size
+	}
+	for repoID, duration := range durationsToday {
+		rollup(repoID).CIDuration = duration
+	}
+
+	for _, r := range rollups {
+		if err = s.usageStore.Upsert(ctx, r); err != nil {
+			return "", fmt.Errorf("failed to update usage rollup for repo %d: %w", r.RepoID, err)
+		}
+	}
+
+	log.Ctx(ctx).Info().
+		Int("repos", len(rollups)).
+		Msg("usage rollups updated")
+
+	return "", nil
+}
+
+// dayStart returns the start of the UTC day of the provided time.
+func dayStart(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
@@ -131,6 +131,33 @@ func (s *Service) findPullReqForEvent(ctx context.Context, prID int64) (*types.P
 	return pr, nil
 }
 
+// findExecutionForEvent finds the pipeline and the execution for the provided pipelineID and execution number.
+func (s *Service) findExecutionForEvent(ctx context.Context, pipelineID int64, num int64,
+) (*types.Pipeline, *types.Execution, error) {
+	pipeline, err := s.pipelineStore.Find(ctx, pipelineID)
+	if err != nil && errors.Is(err, store.ErrResourceNotFound) {
+		// not found error is unrecoverable - most likely a racing condition of pipeline being deleted by now
+		return nil, nil, events.NewDiscardEventErrorf("pipeline with id '%d' doesn't exist anymore", pipelineID)
+	}
+	if err != nil {
+		// all other errors we return and force the event to be reprocessed
+		return nil, nil, fmt.Errorf("failed to get pipeline for id '%d': %w", pipelineID, err)
+	}
+
+	execution, err := s.executionStore.FindByNumber(ctx, pipelineID, num)
+	if err != nil && errors.Is(err, store.ErrResourceNotFound) {
+		// not found error is unrecoverable - most likely a racing condition of pipeline being deleted by now
+		return nil, nil, events.NewDiscardEventErrorf("execution %d of pipeline with id '%d' doesn't exist anymore",
+			num, pipelineID)
+	}
+	if err != nil {
+		// all other errors we return and force the event to be reprocessed
+		return nil, nil, fmt.Errorf("failed to get execution %d of pipeline with id '%d': %w", num, pipelineID, err)
+	}
+
+	return pipeline, execution, nil
+}
+
 // findPrincipalForEvent finds the principal for the provided principalID.
 func (s *Service) findPrincipalForEvent(ctx context.Context, principalID int64) (*types.Principal, error) {
 	principal, err := s.principalStore.Find(ctx, principalID)
@@ -0,0 +1,84 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ExecutionPayload describes the payload of pipeline execution related webhook triggers.
+// Note: Use same payload for all execution operations to make it easier for consumers.
+type ExecutionPayload struct {
+	BaseSegment
+	ExecutionSegment
+}
+
+// handleEventExecutionStarted handles pipeline execution started events
+// and triggers execution started webhooks for the repo of the pipeline.
+func (s *Service) handleEventExecutionStarted(ctx context.Context,
+	event *events.Event[*pipelineevents.StartedPayload]) error {
+	return s.triggerForExecution(ctx, enum.WebhookTriggerExecutionStarted, event.ID,
+		event.Payload.PipelineID, event.Payload.ExecutionNum)
+}
+
+// handleEventExecutionCompleted handles pipeline executed events
+// and triggers execution completed webhooks for the repo of the pipeline.
+// In case the execution didn't succeed, execution failed webhooks are triggered as well.
+func (s *Service) handleEventExecutionCompleted(ctx context.Context,
+	event *events.Event[*pipelineevents.ExecutedPayload]) error {
+	err := s.triggerForExecution(ctx, enum.WebhookTriggerExecutionCompleted, event.ID,
+		event.Payload.PipelineID, event.Payload.ExecutionNum)
+	if err != nil {
+		return err
+	}
+
+	if !event.Payload.Status.IsFailed() {
+		return nil
+	}
+
+	// use a separate event ID to get a distinct trigger ID, as executions are deduplicated by trigger ID.
+	return s.triggerForExecution(ctx, enum.WebhookTriggerExecutionFailed, event.ID+"-failed",
+		event.Payload.PipelineID, event.Payload.ExecutionNum)
+}
+
+// triggerForExecution triggers all webhooks for the given triggerType
+// with the current state of the execution as payload.
+func (s *Service) triggerForExecution(ctx context.Context, triggerType enum.WebhookTrigger, eventID string,
+	pipelineID int64, executionNum int64) error {
+	pipeline, execution, err := s.findExecutionForEvent(ctx, pipelineID, executionNum)
+	if err != nil {
+		return err
+	}
+
+	return s.triggerForEventWithRepo(ctx, triggerType,
+		eventID, execution.CreatedBy, execution.RepoID,
+		func(principal *types.Principal, repo *types.Repository) (any, error) {
+			return &ExecutionPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   triggerType,
+					Repo:      repositoryInfoFrom(ctx, repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				ExecutionSegment: ExecutionSegment{
+					Execution: executionInfoFrom(ctx, execution, pipeline, repo, s.urlProvider),
+				},
+			}, nil
+		})
+}
@@ -18,16 +18,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net/http"
 	"time"
 
 	gitevents "github.com/harness/gitness/app/events/git"
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/instancesettings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/http/outbound"
 	"github.com/harness/gitness/stream"
 )
 
@@ -41,12 +43,12 @@ type Config struct {
 	UserAgentIdentity string
 	// HeaderIdentity specifies the identity used for headers in webhook calls (e.g. X-Gitness-Trigger, ...).
 	// NOTE: If no value is provided, the UserAgentIdentity will be used.
-	HeaderIdentity      string
-	EventReaderName     string
-	Concurrency         int
-	MaxRetries          int
-	AllowPrivateNetwork bool
-	AllowLoopback       bool
+	HeaderIdentity  string
+	EventReaderName string
+	Concurrency     int
+	MaxRetries      int
+	// Outbound is the configuration of the http clients used to execute the webhooks.
+	Outbound outbound.Config
 }
 
 func (c *Config) Prepare() error {
@@ -85,12 +87,11 @@ type Service struct {
 	git                   git.Interface
 	activityStore         store.PullReqActivityStore
 	encrypter             encrypt.Encrypter
+	pipelineStore         store.PipelineStore
+	executionStore        store.ExecutionStore
 
-	secureHTTPClient   *http.Client
-	insecureHTTPClient *http.Client
-
-	secureHTTPClientInternal   *http.Client
-	insecureHTTPClientInternal *http.Client
+	httpClient         *outbound.Client
+	httpClientInternal *outbound.Client
 
 	config Config
 }
@@ -100,6 +101,7 @@ func NewService(
 	config Config,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	pipelineReaderFactory *events.ReaderFactory[*pipelineevents.Reader],
 	webhookStore store.WebhookStore,
 	webhookExecutionStore store.WebhookExecutionStore,
 	repoStore store.RepoStore,
@@ -109,10 +111,31 @@ func NewService(
 	principalStore store.PrincipalStore,
 	git git.Interface,
 	encrypter encrypt.Encrypter,
+	instanceSettings *instancesettings.Service,
+	pipelineStore store.PipelineStore,
+	executionStore store.ExecutionStore,
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
 		return nil, fmt.Errorf("provided webhook service config is invalid: %w", err)
 	}
+	// the restrictions of user webhooks can be changed at runtime via the instance settings.
+	clientConfig := config.Outbound
+	clientConfig.Name = "webhook"
+	clientConfig.AllowLoopback = instanceSettings.WebhookAllowLoopback
+	clientConfig.AllowPrivateNetwork = instanceSettings.WebhookAllowPrivateNetwork
+	httpClient, err := outbound.New(clientConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook http client: %w", err)
+	}
+
+	// internal webhooks are allowed to target private networks.
+	clientConfig.Name = "webhook-internal"
+	clientConfig.AllowPrivateNetwork = outbound.AllowAlways
+	httpClientInternal, err := outbound.New(clientConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create internal webhook http client: %w", err)
+	}
+
 	service := &Service{
 		webhookStore:          webhookStore,
 		webhookExecutionStore: webhookExecutionStore,
@@ -123,17 +146,16 @@ func NewService(
 		principalStore:        principalStore,
 		git:                   git,
 		encrypter:             encrypter,
+		pipelineStore:         pipelineStore,
+		executionStore:        executionStore,
 
-		secureHTTPClient:   newHTTPClient(config.AllowLoopback, config.AllowPrivateNetwork, false),
-		insecureHTTPClient: newHTTPClient(config.AllowLoopback, config.AllowPrivateNetwork, true),
-
-		secureHTTPClientInternal:   newHTTPClient(config.AllowLoopback, true, false),
-		insecureHTTPClientInternal: newHTTPClient(config.AllowLoopback, true, true),
+		httpClient:         httpClient,
+		httpClientInternal: httpClientInternal,
 
 		config: config,
 	}
 
-	_, err := gitReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+	_, err = gitReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
 		func(r *gitevents.Reader) error {
 			const idleTimeout = 1 * time.Minute
 			r.Configure(
@@ -183,5 +205,25 @@ func NewService(
 		return nil, fmt.Errorf("failed to launch pr event reader for webhooks: %w", err)
 	}
 
+	_, err = pipelineReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *pipelineevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			// register events
+			_ = r.RegisterStarted(service.handleEventExecutionStarted)
+			_ = r.RegisterExecuted(service.handleEventExecutionCompleted)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pipeline event reader for webhooks: %w", err)
+	}
+
 	return service, nil
 }
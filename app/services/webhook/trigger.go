@@ -28,6 +28,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/harness/gitness/http/outbound"
 	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -121,8 +122,8 @@ func (s *Service) triggerWebhooks(ctx context.Context, webhooks []*types.Webhook
 			continue
 		}
 
-		// check if webhook is registered for trigger (empty list => all triggers are registered)
-		triggerRegistered := len(webhook.Triggers) == 0
+		// check if webhook is registered for trigger (empty list => all triggers except opt-in ones are registered)
+		triggerRegistered := len(webhook.Triggers) == 0 && !triggerType.IsOptIn()
 		for _, trigger := range webhook.Triggers {
 			if trigger == triggerType {
 				triggerRegistered = true
@@ -228,17 +229,11 @@ func (s *Service) executeWebhook(ctx context.Context, webhook *types.Webhook, tr
 	}
 
 	// Execute HTTP Request (insecure if requested)
-	var resp *http.Response
-	switch {
-	case webhook.Internal && webhook.Insecure:
-		resp, err = s.insecureHTTPClientInternal.Do(req)
-	case webhook.Internal:
-		resp, err = s.secureHTTPClientInternal.Do(req)
-	case webhook.Insecure:
-		resp, err = s.insecureHTTPClient.Do(req)
-	default:
-		resp, err = s.secureHTTPClient.Do(req)
+	client := s.httpClient
+	if webhook.Internal {
+		client = s.httpClientInternal
 	}
+	resp, err := client.Do(req, outbound.SkipTLSVerify(webhook.Insecure))
 
 	// always close the body!
 	if resp != nil && resp.Body != nil {
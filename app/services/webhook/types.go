@@ -80,6 +80,11 @@ type PullReqCommentSegment struct {
 	CommentInfo CommentInfo `json:"comment"`
 }
 
+// ExecutionSegment contains details for all pipeline execution related payloads for webhooks.
+type ExecutionSegment struct {
+	Execution ExecutionInfo `json:"execution"`
+}
+
 // PullReqUpdateSegment contains details what has been updated in the pull request.
 type PullReqUpdateSegment struct {
 	TitleChanged       bool   `json:"title_changed"`
@@ -130,6 +135,53 @@ func repositoryInfoFrom(ctx context.Context, repo *types.Repository, urlProvider
 	}
 }
 
+// ExecutionInfo describes the pipeline execution related info for a webhook payload.
+// NOTE: don't use types package as we want webhook payload to be independent from API calls.
+type ExecutionInfo struct {
+	PipelineID         int64             `json:"pipeline_id"`
+	PipelineIdentifier string            `json:"pipeline_identifier"`
+	Number             int64             `json:"number"`
+	Status             enum.CIStatus     `json:"status"`
+	Error              string            `json:"error,omitempty"`
+	Event              enum.TriggerEvent `json:"event,omitempty"`
+	Ref                string            `json:"ref"`
+	SHA                string            `json:"sha"`
+	Started            int64             `json:"started,omitempty"`
+	Finished           int64             `json:"finished,omitempty"`
+	// Duration is the duration of the execution in milliseconds (only set once the execution is done).
+	Duration int64  `json:"duration,omitempty"`
+	URL      string `json:"url"`
+}
+
+// executionInfoFrom gets the ExecutionInfo from a types.Execution.
+func executionInfoFrom(
+	ctx context.Context,
+	execution *types.Execution,
+	pipeline *types.Pipeline,
+	repo *types.Repository,
+	urlProvider url.Provider,
+) ExecutionInfo {
+	var duration int64
+	if execution.Status.IsDone() && execution.Started > 0 && execution.Finished >= execution.Started {
+		duration = execution.Finished - execution.Started
+	}
+
+	return ExecutionInfo{
+		PipelineID:         pipeline.ID,
+		PipelineIdentifier: pipeline.Identifier,
+		Number:             execution.Number,
+		Status:             execution.Status,
+		Error:              execution.Error,
+		Event:              execution.Event,
+		Ref:                execution.Ref,
+		SHA:                execution.After,
+		Started:            execution.Started,
+		Finished:           execution.Finished,
+		Duration:           duration,
+		URL:                urlProvider.GenerateUIBuildURL(ctx, repo.Path, pipeline.Identifier, execution.Number),
+	}
+}
+
 // PullReqInfo describes the pullreq related info for a webhook payload.
 // NOTE: don't use types package as we want pullreq payload to be independent from API calls.
 type PullReqInfo struct {
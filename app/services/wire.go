@@ -15,18 +15,31 @@
 package services
 
 import (
+	"github.com/harness/gitness/app/pipeline/approver"
+	"github.com/harness/gitness/app/pipeline/canceler"
+	"github.com/harness/gitness/app/services/accesslog"
 	"github.com/harness/gitness/app/services/cleanup"
+	"github.com/harness/gitness/app/services/counters"
+	"github.com/harness/gitness/app/services/digest"
+	"github.com/harness/gitness/app/services/featureflag"
+	"github.com/harness/gitness/app/services/gitlimit"
 	"github.com/harness/gitness/app/services/gitspace"
 	"github.com/harness/gitness/app/services/gitspaceevent"
 	"github.com/harness/gitness/app/services/gitspaceinfraevent"
+	"github.com/harness/gitness/app/services/inbox"
 	"github.com/harness/gitness/app/services/infraprovider"
+	"github.com/harness/gitness/app/services/instancesettings"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/metric"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/recentvisit"
 	"github.com/harness/gitness/app/services/repo"
+	"github.com/harness/gitness/app/services/repoactivity"
+	"github.com/harness/gitness/app/services/spacefeed"
 	"github.com/harness/gitness/app/services/trigger"
+	"github.com/harness/gitness/app/services/usage"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/job"
 
@@ -44,6 +57,10 @@ type Services struct {
 	JobScheduler          *job.Scheduler
 	MetricCollector       *metric.Collector
 	RepoSizeCalculator    *repo.SizeCalculator
+	RepoReflogEnabler     *repo.ReflogEnabler
+	RepoMaintenance       *repo.Maintenance
+	RepoStatsCalculator   *repo.StatsCalculator
+	CounterReconciler     *counters.Reconciler
 	Repo                  *repo.Service
 	Cleanup               *cleanup.Service
 	Notification          *notification.Service
@@ -52,6 +69,19 @@ type Services struct {
 	Instrumentation       instrument.Service
 	instrumentConsumer    instrument.Consumer
 	instrumentRepoCounter *instrument.RepositoryCount
+	StageApprovalExpirer  *approver.Expirer
+	TimeoutEnforcer       *canceler.TimeoutEnforcer
+	RunnerMonitor         *canceler.RunnerMonitor
+	RepoActivity          *repoactivity.Service
+	Digest                *digest.Service
+	RecentVisit           *recentvisit.Service
+	Inbox                 *inbox.Service
+	SpaceFeed             *spacefeed.Service
+	InstanceSettings      *instancesettings.Service
+	FeatureFlags          *featureflag.Service
+	GitLimit              *gitlimit.Service
+	AccessLog             *accesslog.Service
+	Usage                 *usage.Service
 }
 
 type GitspaceServices struct {
@@ -82,6 +112,10 @@ func ProvideServices(
 	jobScheduler *job.Scheduler,
 	metricCollector *metric.Collector,
 	repoSizeCalculator *repo.SizeCalculator,
+	repoReflogEnabler *repo.ReflogEnabler,
+	repoMaintenance *repo.Maintenance,
+	repoStatsCalculator *repo.StatsCalculator,
+	counterReconciler *counters.Reconciler,
 	repo *repo.Service,
 	cleanupSvc *cleanup.Service,
 	notificationSvc *notification.Service,
@@ -90,6 +124,19 @@ func ProvideServices(
 	instrumentation instrument.Service,
 	instrumentConsumer instrument.Consumer,
 	instrumentRepoCounter *instrument.RepositoryCount,
+	stageApprovalExpirer *approver.Expirer,
+	timeoutEnforcer *canceler.TimeoutEnforcer,
+	runnerMonitor *canceler.RunnerMonitor,
+	repoActivitySvc *repoactivity.Service,
+	digestSvc *digest.Service,
+	recentVisitSvc *recentvisit.Service,
+	inboxSvc *inbox.Service,
+	spaceFeedSvc *spacefeed.Service,
+	instanceSettingsSvc *instancesettings.Service,
+	featureFlagsSvc *featureflag.Service,
+	gitLimitSvc *gitlimit.Service,
+	accessLogSvc *accesslog.Service,
+	usageSvc *usage.Service,
 ) Services {
 	return Services{
 		Webhook:               webhooksSvc,
@@ -98,6 +145,10 @@ func ProvideServices(
 		JobScheduler:          jobScheduler,
 		MetricCollector:       metricCollector,
 		RepoSizeCalculator:    repoSizeCalculator,
+		RepoReflogEnabler:     repoReflogEnabler,
+		RepoMaintenance:       repoMaintenance,
+		RepoStatsCalculator:   repoStatsCalculator,
+		CounterReconciler:     counterReconciler,
 		Repo:                  repo,
 		Cleanup:               cleanupSvc,
 		Notification:          notificationSvc,
@@ -106,5 +157,18 @@ func ProvideServices(
 		Instrumentation:       instrumentation,
 		instrumentConsumer:    instrumentConsumer,
 		instrumentRepoCounter: instrumentRepoCounter,
+		StageApprovalExpirer:  stageApprovalExpirer,
+		TimeoutEnforcer:       timeoutEnforcer,
+		RunnerMonitor:         runnerMonitor,
+		RepoActivity:          repoActivitySvc,
+		Digest:                digestSvc,
+		RecentVisit:           recentVisitSvc,
+		Inbox:                 inboxSvc,
+		SpaceFeed:             spaceFeedSvc,
+		InstanceSettings:      instanceSettingsSvc,
+		FeatureFlags:          featureFlagsSvc,
+		GitLimit:              gitLimitSvc,
+		AccessLog:             accessLogSvc,
+		Usage:                 usageSvc,
 	}
 }
@@ -26,14 +26,22 @@ import (
 
 // Event is a server sent event.
 type Event struct {
+	// ID is the optional ID of the event, used by clients to resume a stream.
+	ID   string          `json:"id,omitempty"`
 	Type enum.SSEType    `json:"type"`
 	Data json.RawMessage `json:"data"`
+	// RepoID is the optional ID of the repository the event is about.
+	// It's not sent to clients, but used to filter events by repository access.
+	RepoID int64 `json:"repo_id,omitempty"`
 }
 
 type Streamer interface {
 	// Publish publishes an event to a given space ID.
 	Publish(ctx context.Context, spaceID int64, eventType enum.SSEType, data any) error
 
+	// PublishEvent publishes an already serialized event to a given space ID.
+	PublishEvent(ctx context.Context, spaceID int64, event *Event) error
+
 	// Stream streams the events on a space ID.
 	Stream(ctx context.Context, spaceID int64) (<-chan *Event, <-chan error, func(context.Context) error)
 }
@@ -55,10 +63,14 @@ func (e *pubsubStreamer) Publish(ctx context.Context, spaceID int64, eventType e
 	if err != nil {
 		return fmt.Errorf("failed to serialize data: %w", err)
 	}
-	event := Event{
+
+	return e.PublishEvent(ctx, spaceID, &Event{
 		Type: eventType,
 		Data: dataSerialized,
-	}
+	})
+}
+
+func (e *pubsubStreamer) PublishEvent(ctx context.Context, spaceID int64, event *Event) error {
 	serializedEvent, err := json.Marshal(event)
 	if err != nil {
 		return fmt.Errorf("failed to serialize event: %w", err)
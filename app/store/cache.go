@@ -26,6 +26,10 @@ type (
 	// SpacePathCache caches a raw path to a space path.
 	SpacePathCache cache.Cache[string, *types.SpacePath]
 
+	// PrincipalTokenCache caches principal IDs to the tokens of the principal that haven't been revoked.
+	// It has to be evicted whenever tokens of the principal are revoked.
+	PrincipalTokenCache cache.Cache[int64, *types.PrincipalTokens]
+
 	// RepoGitInfoCache caches repository IDs to values GitUID.
 	RepoGitInfoCache cache.Cache[int64, *types.RepositoryGitInfo]
 )
@@ -60,14 +60,22 @@ type pathCache struct {
 }
 
 func (c *pathCache) Get(ctx context.Context, key string) (*types.SpacePath, error) {
-	// build unique key from provided value
+	return c.inner.Get(ctx, c.uniqueKey(key))
+}
+
+func (c *pathCache) Evict(ctx context.Context, key string) {
+	c.inner.Evict(ctx, c.uniqueKey(key))
+}
+
+// uniqueKey builds the unique key from the provided value.
+func (c *pathCache) uniqueKey(key string) string {
 	segments := paths.Segments(key)
 	uniqueKey := ""
 	for i, segment := range segments {
 		uniqueKey = paths.Concatenate(uniqueKey, c.spacePathTransformation(segment, i == 0))
 	}
 
-	return c.inner.Get(ctx, uniqueKey)
+	return uniqueKey
 }
 
 func (c *pathCache) Stats() (int64, int64) {
@@ -0,0 +1,45 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+)
+
+// principalTokensGetter is used to hook a TokenStore as source of a PrincipalTokenCache.
+type principalTokensGetter struct {
+	tokenStore store.TokenStore
+}
+
+func (g *principalTokensGetter) Find(ctx context.Context, principalID int64) (*types.PrincipalTokens, error) {
+	tokens, err := g.tokenStore.ListForPrincipal(ctx, principalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tokens of principal: %w", err)
+	}
+
+	result := &types.PrincipalTokens{
+		PrincipalID: principalID,
+		Tokens:      make(map[int64]*types.Token, len(tokens)),
+	}
+	for _, token := range tokens {
+		result.Tokens[token.ID] = token
+	}
+
+	return result, nil
+}
@@ -27,6 +27,7 @@ import (
 // WireSet provides a wire set for this package.
 var WireSet = wire.NewSet(
 	ProvidePrincipalInfoCache,
+	ProvidePrincipalTokenCache,
 	ProvidePathCache,
 	ProvideRepoGitInfoCache,
 )
@@ -36,6 +37,13 @@ func ProvidePrincipalInfoCache(getter store.PrincipalInfoView) store.PrincipalIn
 	return cache.NewExtended[int64, *types.PrincipalInfo](getter, 30*time.Second)
 }
 
+// ProvidePrincipalTokenCache provides a cache for storing the tokens of principals.
+// Revocations are only evicted from the cache of the local instance, so the maximum age bounds
+// how long a revoked token stays usable on other instances.
+func ProvidePrincipalTokenCache(tokenStore store.TokenStore) store.PrincipalTokenCache {
+	return cache.New[int64, *types.PrincipalTokens](&principalTokensGetter{tokenStore: tokenStore}, 30*time.Second)
+}
+
 // ProvidePathCache provides a cache for storing routing paths and their types.SpacePath objects.
 func ProvidePathCache(
 	pathStore store.SpacePathStore,
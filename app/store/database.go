@@ -22,6 +22,7 @@ import (
 
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+	"github.com/harness/gitness/types/listfilter"
 )
 
 type (
@@ -94,8 +95,8 @@ type (
 		DeleteServiceAccount(ctx context.Context, id int64) error
 
 		// ListServiceAccounts returns a list of service accounts for a specific parent.
-		ListServiceAccounts(ctx context.Context,
-			parentType enum.ParentResourceType, parentID int64) ([]*types.ServiceAccount, error)
+		ListServiceAccounts(ctx context.Context, parentType enum.ParentResourceType, parentID int64,
+			filter *listfilter.Filter) ([]*types.ServiceAccount, error)
 
 		// CountServiceAccounts returns a count of service accounts for a specific parent.
 		CountServiceAccounts(ctx context.Context,
@@ -228,6 +229,16 @@ type (
 		// UpdateSize updates the size of a specific repository in the database (size is in KiB).
 		UpdateSize(ctx context.Context, id int64, sizeInKiB int64) error
 
+		// UpdateState moves the repository from one state to another.
+		// It returns ErrVersionConflict if the repository isn't in the expected state anymore.
+		UpdateState(ctx context.Context, repo *types.Repository, from, to enum.RepoState, until int64) error
+
+		// UpdateLastActivity updates the last activity time of the repository if it's newer.
+		UpdateLastActivity(ctx context.Context, id int64, lastActivity int64) error
+
+		// UpdateNumStars changes the star counter of the repository by the provided delta.
+		UpdateNumStars(ctx context.Context, id int64, delta int) error
+
 		// Get the repo size.
 		GetSize(ctx context.Context, id int64) (int64, error)
 
@@ -255,6 +266,385 @@ type (
 
 		// ListSizeInfos returns a list of all active repo sizes.
 		ListSizeInfos(ctx context.Context) ([]*types.RepositorySizeInfo, error)
+
+		// CountStarred returns the number of active repos starred by the principal.
+		CountStarred(ctx context.Context, principalID int64) (int64, error)
+
+		// ListStarred returns the active repos starred by the principal, most recently starred first.
+		ListStarred(ctx context.Context, principalID int64, pagination types.Pagination) ([]*types.Repository, error)
+	}
+
+	// RepoStarStore defines the storage of repositories starred by users.
+	RepoStarStore interface {
+		// Create stars the repo for the principal. It returns false if the repo was already starred.
+		Create(ctx context.Context, principalID, repoID int64) (bool, error)
+
+		// Delete removes the star of the principal from the repo. It returns false if the repo wasn't starred.
+		Delete(ctx context.Context, principalID, repoID int64) (bool, error)
+	}
+
+	// RepoTopicStore defines the storage of the topics of repositories.
+	RepoTopicStore interface {
+		// List returns the topics of the repo, sorted alphabetically.
+		List(ctx context.Context, repoID int64) ([]string, error)
+
+		// Map returns the topics of the repos, sorted alphabetically, indexed by the repo ID.
+		Map(ctx context.Context, repoIDs []int64) (map[int64][]string, error)
+
+		// Set replaces the topics of the repo.
+		Set(ctx context.Context, repoID int64, topics []string) error
+
+		// Count returns the number of active repos per topic in the space, most used topics first.
+		Count(ctx context.Context, spaceID int64, filter *types.RepoTopicFilter) ([]types.RepoTopicCount, error)
+
+		// Rename renames the topic of all repos in the space and its subspaces.
+		// It returns the IDs of the repos that had the topic.
+		Rename(ctx context.Context, spaceID int64, oldTopic, newTopic string) ([]int64, error)
+	}
+
+	// SpacePinnedRepoStore defines the storage of the repositories pinned in spaces.
+	SpacePinnedRepoStore interface {
+		// List returns the pins of the space in their order.
+		// Pins of repos that were deleted or aren't in the space anymore are omitted.
+		List(ctx context.Context, spaceID int64) ([]types.SpacePinnedRepo, error)
+
+		// Replace replaces all pins of the space with the provided ones, ordered as provided.
+		Replace(ctx context.Context, spaceID int64, pins []types.SpacePinnedRepo) error
+
+		// DeleteStale deletes the pins of repos that were deleted or aren't in the space anymore.
+		DeleteStale(ctx context.Context) (int64, error)
+	}
+
+	// AvatarStore defines the storage of the uploaded avatars of principals.
+	AvatarStore interface {
+		// Find returns the avatar of the principal.
+		Find(ctx context.Context, principalID int64) (*types.Avatar, error)
+
+		// Upsert creates or replaces the avatar of the principal.
+		Upsert(ctx context.Context, avatar *types.Avatar) error
+
+		// Delete deletes the avatar of the principal.
+		Delete(ctx context.Context, principalID int64) error
+	}
+
+	RepoActivityStore interface {
+		// Create records a new repository activity.
+		Create(ctx context.Context, act *types.RepoActivity) error
+
+		// Count returns the number of activities of a repository matching the filter.
+		Count(ctx context.Context, repoID int64, filter *types.RepoActivityFilter) (int64, error)
+
+		// List returns the activities of a repository matching the filter, most recent first.
+		List(ctx context.Context, repoID int64, filter *types.RepoActivityFilter) ([]*types.RepoActivity, error)
+
+		// ListPrincipalsActiveBefore returns those of the provided principals
+		// that have any activity in the repository before the provided time.
+		ListPrincipalsActiveBefore(
+			ctx context.Context,
+			repoID int64,
+			principalIDs []int64,
+			before int64,
+		) ([]int64, error)
+
+		// DeleteOld removes all repository activities that are older than the provided time.
+		DeleteOld(ctx context.Context, olderThan time.Time) (int64, error)
+	}
+
+	// RepoPushStore defines the repository push storage.
+	RepoPushStore interface {
+		// Create records a new push to a repository.
+		Create(ctx context.Context, push *types.RepoPush) error
+
+		// Count returns the number of pushes to the repository since the provided time.
+		Count(ctx context.Context, repoID int64, since int64) (int64, error)
+
+		// ListBuckets returns the number of pushes to the repository per day since the provided time.
+		// Days without any pushes are omitted.
+		ListBuckets(ctx context.Context, repoID int64, since int64) ([]types.RepoPushBucket, error)
+
+		// ListTopContributors returns up to limit principals that pushed the most commits
+		// to the repository since the provided time.
+		ListTopContributors(
+			ctx context.Context,
+			repoID int64,
+			since int64,
+			limit int,
+		) ([]types.RepoContributor, error)
+
+		// DeleteOld removes all pushes that are older than the provided time.
+		DeleteOld(ctx context.Context, olderThan time.Time) (int64, error)
+	}
+
+	// RepoStatsStore defines the precomputed repository statistics storage.
+	RepoStatsStore interface {
+		// Find returns the statistics of the repository.
+		Find(ctx context.Context, repoID int64) (*types.RepoStats, error)
+
+		// Upsert creates or replaces the statistics of the repository.
+		Upsert(ctx context.Context, stats *types.RepoStats) error
+
+		// Totals returns the statistics summed up over all repositories.
+		Totals(ctx context.Context) (*types.RepoStatsTotals, error)
+	}
+
+	// RecentVisitStore defines the storage of repositories and spaces recently visited by users.
+	RecentVisitStore interface {
+		// UpsertMany creates the provided visits or updates the visited timestamp of the existing ones.
+		UpsertMany(ctx context.Context, visits []types.RecentVisit) error
+
+		// List returns the most recent visits of the principal, optionally filtered by type.
+		List(
+			ctx context.Context,
+			principalID int64,
+			filter *types.RecentVisitFilter,
+			limit int,
+		) ([]types.RecentVisit, error)
+
+		// Delete removes the visit of the resource by the principal.
+		Delete(ctx context.Context, principalID int64, visitType enum.RecentVisitType, resourceID int64) error
+
+		// Trim removes all but the provided number of most recent visits of the principal.
+		Trim(ctx context.Context, principalID int64, keep int) error
+	}
+
+	// NotificationStore defines the in-app notification storage.
+	NotificationStore interface {
+		// Create creates a new notification.
+		Create(ctx context.Context, n *types.Notification) error
+
+		// ExistsSince returns true if the principal received a notification
+		// of the same type about the same resource since the provided time.
+		ExistsSince(
+			ctx context.Context,
+			principalID int64,
+			notificationType enum.NotificationType,
+			resourceID int64,
+			since int64,
+		) (bool, error)
+
+		// Count returns the number of notifications of the principal matching the filter.
+		Count(ctx context.Context, principalID int64, filter *types.NotificationFilter) (int64, error)
+
+		// List returns the notifications of the principal matching the filter, newest first.
+		List(ctx context.Context, principalID int64, filter *types.NotificationFilter) ([]*types.Notification, error)
+
+		// MarkRead marks the provided notifications of the principal as read.
+		// If no notification IDs are provided, all notifications of the principal are marked as read.
+		MarkRead(ctx context.Context, principalID int64, ids []int64) (int64, error)
+	}
+
+	// NotificationSubscriptionStore defines the storage of notification subscriptions.
+	NotificationSubscriptionStore interface {
+		// Find returns the subscription of the principal to the resource.
+		Find(
+			ctx context.Context,
+			principalID int64,
+			resourceType enum.SubscriptionResourceType,
+			resourceID int64,
+		) (*types.NotificationSubscription, error)
+
+		// Upsert creates the subscription or updates the subscribed flag of the existing one.
+		Upsert(ctx context.Context, sub *types.NotificationSubscription) error
+
+		// CreateIfNotExists creates the subscription unless the principal already has one for the resource.
+		CreateIfNotExists(ctx context.Context, sub *types.NotificationSubscription) error
+
+		// ListByResource returns all subscriptions to the resource, including explicit unsubscribes.
+		ListByResource(
+			ctx context.Context,
+			resourceType enum.SubscriptionResourceType,
+			resourceID int64,
+		) ([]*types.NotificationSubscription, error)
+	}
+
+	// MailFailureStore defines the storage of failed email deliveries.
+	MailFailureStore interface {
+		// Record stores a failed delivery attempt, or increases the attempts of an already recorded delivery.
+		Record(ctx context.Context, failure *types.MailFailure) error
+
+		// Count returns the number of mail failures matching the filter.
+		Count(ctx context.Context, filter *types.MailFailureFilter) (int64, error)
+
+		// List returns the mail failures matching the filter.
+		List(ctx context.Context, filter *types.MailFailureFilter) ([]*types.MailFailure, error)
+	}
+
+	// UserIdentityStore defines the storage of the links between users and external identities.
+	UserIdentityStore interface {
+		// Find finds the identity of the provider with the subject.
+		Find(ctx context.Context, provider, subject string) (*types.UserIdentity, error)
+
+		// Create links a user to an external identity.
+		Create(ctx context.Context, identity *types.UserIdentity) error
+	}
+
+	// LoginAttemptStore defines the storage of failed logins and temporary login lockouts.
+	// Failures and lockouts are tracked per key, e.g. per account or per source IP.
+	LoginAttemptStore interface {
+		// RecordFailure stores a failed login for the key.
+		RecordFailure(ctx context.Context, key string, created int64) error
+
+		// CountFailures returns the number of failed logins for the key since the provided time.
+		CountFailures(ctx context.Context, key string, since int64) (int64, error)
+
+		// DeleteFailures deletes all failed logins of the keys.
+		DeleteFailures(ctx context.Context, keys ...string) error
+
+		// PurgeFailures deletes all failed logins older than the provided time.
+		PurgeFailures(ctx context.Context, before int64) (int64, error)
+
+		// Lock locks the key until the provided time.
+		Lock(ctx context.Context, key string, until int64) error
+
+		// LockedUntil returns the latest time any of the keys is locked until, or 0 if none is locked.
+		LockedUntil(ctx context.Context, now int64, keys ...string) (int64, error)
+
+		// Unlock removes the lockout of the key, if any.
+		Unlock(ctx context.Context, key string) error
+
+		// PurgeLockouts deletes all lockouts that ended before the provided time.
+		PurgeLockouts(ctx context.Context, before int64) (int64, error)
+	}
+
+	// IdempotencyKeyStore defines the storage of idempotency keys and the responses of their requests.
+	IdempotencyKeyStore interface {
+		// Create reserves the key for the principal. Keys that expired before now are replaced.
+		// Returns store.ErrDuplicate if the key is already reserved.
+		Create(ctx context.Context, key *types.IdempotencyKey, now int64) error
+
+		// Find finds the key of the principal.
+		Find(ctx context.Context, principalID int64, key string) (*types.IdempotencyKey, error)
+
+		// Complete stores the response with the key and extends the key's expiry.
+		Complete(ctx context.Context, key *types.IdempotencyKey) error
+
+		// DeletePending deletes the key of the principal unless a response is stored with it.
+		DeletePending(ctx context.Context, principalID int64, key string) error
+
+		// Purge deletes all keys that expired before the provided time.
+		Purge(ctx context.Context, before int64) (int64, error)
+	}
+
+	// OperationStore defines the storage of long-running operations.
+	OperationStore interface {
+		// Create creates the operation. An existing operation that references the same jobs is replaced,
+		// as the jobs of a new operation supersede the ones of the previous operation.
+		Create(ctx context.Context, op *types.Operation) error
+
+		// FindByUID finds the operation by its UID.
+		FindByUID(ctx context.Context, uid string) (*types.Operation, error)
+
+		// Purge deletes all operations created before the provided time.
+		Purge(ctx context.Context, before int64) (int64, error)
+	}
+
+	// InstallationStore defines the storage of the installation record of the instance.
+	InstallationStore interface {
+		// Find returns the installation record.
+		Find(ctx context.Context) (*types.Installation, error)
+
+		// Create creates the installation record. Returns store.ErrDuplicate if it exists already.
+		Create(ctx context.Context, installation *types.Installation) error
+
+		// SetInstaller sets the installer of the instance, unless it is known already.
+		SetInstaller(ctx context.Context, principalID int64, email string) error
+	}
+
+	// FeatureFlagStore defines the storage of the feature flag overrides.
+	FeatureFlagStore interface {
+		// List returns all feature flag overrides.
+		List(ctx context.Context) ([]*types.FeatureFlagOverride, error)
+
+		// Upsert creates or updates the override of the feature flag for the instance (SpaceID is nil) or the space.
+		Upsert(ctx context.Context, override *types.FeatureFlagOverride) error
+
+		// Delete deletes the override of the feature flag for the instance (spaceID is nil) or the space.
+		Delete(ctx context.Context, name string, spaceID *int64) error
+	}
+
+	// RepoAccessLogStore defines the storage of the access log of git operations on repositories.
+	RepoAccessLogStore interface {
+		// CreateMany records the provided access log entries.
+		CreateMany(ctx context.Context, entries []*types.RepoAccessLog) error
+
+		// Count returns the number of access log entries of a repository matching the filter.
+		Count(ctx context.Context, repoID int64, filter *types.RepoAccessLogFilter) (int64, error)
+
+		// List returns the access log entries of a repository matching the filter, most recent first.
+		List(ctx context.Context, repoID int64, filter *types.RepoAccessLogFilter) ([]*types.RepoAccessLog, error)
+
+		// DeleteOld removes all access log entries that are older than the provided time.
+		DeleteOld(ctx context.Context, olderThan time.Time) (int64, error)
+	}
+
+	// GitOperationLimitStore defines the storage of the per-principal git operation limits.
+	GitOperationLimitStore interface {
+		// List returns the git operation limits of all principals.
+		List(ctx context.Context) ([]*types.GitOperationLimit, error)
+
+		// Upsert creates or updates the git operation limit of the principal.
+		Upsert(ctx context.Context, limit *types.GitOperationLimit) error
+
+		// Delete deletes the git operation limit of the principal.
+		Delete(ctx context.Context, principalID int64) error
+	}
+
+	// UserPreferenceStore defines the user list view preference storage.
+	UserPreferenceStore interface {
+		// Find returns the preference of the principal for the provided view.
+		Find(ctx context.Context, principalID int64, view enum.UserPreferenceView) (*types.UserPreference, error)
+
+		// Upsert creates or replaces the preference of the principal for the view.
+		Upsert(ctx context.Context, pref *types.UserPreference) error
+	}
+
+	// DigestSubscriptionStore defines the activity digest subscription storage.
+	DigestSubscriptionStore interface {
+		// Find returns the digest subscription of the principal.
+		Find(ctx context.Context, principalID int64) (*types.DigestSubscription, error)
+
+		// Upsert creates or updates the digest subscription of the principal.
+		Upsert(ctx context.Context, sub *types.DigestSubscription) error
+
+		// Delete removes the digest subscription of the principal.
+		Delete(ctx context.Context, principalID int64) error
+
+		// ListDue returns up to limit subscriptions with the provided frequency that haven't been sent since sentBefore.
+		// Subscriptions are ordered by principal ID, only principals with an ID greater than afterPrincipalID are returned.
+		ListDue(
+			ctx context.Context,
+			frequency enum.DigestFrequency,
+			sentBefore int64,
+			afterPrincipalID int64,
+			limit int,
+		) ([]*types.DigestSubscription, error)
+
+		// UpdateLastSent updates the time the last digest was sent to the principal.
+		UpdateLastSent(ctx context.Context, principalID int64, lastSent int64) error
+	}
+
+	// PasswordResetStore defines the password reset storage.
+	PasswordResetStore interface {
+		// FindByTokenHash finds the password reset by the hash of its token.
+		FindByTokenHash(ctx context.Context, tokenHash string) (*types.PasswordReset, error)
+
+		// Upsert creates the password reset of the principal or replaces the existing one.
+		Upsert(ctx context.Context, reset *types.PasswordReset) error
+
+		// Delete deletes the password reset of the principal.
+		Delete(ctx context.Context, principalID int64) error
+	}
+
+	// EmailChangeStore defines the pending email change storage.
+	EmailChangeStore interface {
+		// FindByTokenHash finds the pending email change by the hash of its verification token.
+		FindByTokenHash(ctx context.Context, tokenHash string) (*types.EmailChange, error)
+
+		// Upsert creates the pending email change of the principal or replaces the existing one.
+		Upsert(ctx context.Context, change *types.EmailChange) error
+
+		// Delete deletes the pending email change of the principal.
+		Delete(ctx context.Context, principalID int64) error
 	}
 
 	// SettingsStore defines the settings storage.
@@ -284,6 +674,15 @@ type (
 			key string,
 			value json.RawMessage,
 		) error
+
+		// Delete deletes the setting with the given key for the provided scope.
+		// NOTE: Deleting a setting that doesn't exist is not considered an error.
+		Delete(
+			ctx context.Context,
+			scope enum.SettingsScope,
+			scopeID int64,
+			key string,
+		) error
 	}
 
 	// RepoGitInfoView defines the repository GitUID view.
@@ -325,13 +724,32 @@ type (
 		// Delete deletes the token with the given id.
 		Delete(ctx context.Context, id int64) error
 
+		// DeleteForPrincipal deletes all tokens of the principal and returns the number of deleted tokens.
+		// If tokenTypes are provided, then only tokens of that type are deleted.
+		DeleteForPrincipal(ctx context.Context, principalID int64, tknTypes []enum.TokenType) (int64, error)
+
+		// DeleteForPrincipalExcept deletes all tokens of the given type of the principal except the token
+		// with the provided id and returns the number of deleted tokens.
+		DeleteForPrincipalExcept(
+			ctx context.Context,
+			principalID int64,
+			tknType enum.TokenType,
+			exceptID int64,
+		) (int64, error)
+
 		// DeleteExpiredBefore deletes all tokens that expired before the provided time.
 		// If tokenTypes are provided, then only tokens of that type are deleted.
 		DeleteExpiredBefore(ctx context.Context, before time.Time, tknTypes []enum.TokenType) (int64, error)
 
+		// UpdateLastUsed updates the time the token was last used.
+		UpdateLastUsed(ctx context.Context, id int64, lastUsedAt int64) error
+
 		// List returns a list of tokens of a specific type for a specific principal.
 		List(ctx context.Context, principalID int64, tokenType enum.TokenType) ([]*types.Token, error)
 
+		// ListForPrincipal returns all tokens of the principal.
+		ListForPrincipal(ctx context.Context, principalID int64) ([]*types.Token, error)
+
 		// Count returns a count of tokens of a specifc type for a specific principal.
 		Count(ctx context.Context, principalID int64, tokenType enum.TokenType) (int64, error)
 	}
@@ -687,6 +1105,10 @@ type (
 		// FindByIdentifier returns a pipeline with a given Identifier in a space
 		FindByIdentifier(ctx context.Context, id int64, identifier string) (*types.Pipeline, error)
 
+		// IdentifierExists returns true if a pipeline of the repo, other than the pipeline with the excluded ID,
+		// has the provided identifier (compared case-insensitively).
+		IdentifierExists(ctx context.Context, repoID int64, identifier string, excludeID int64) (bool, error)
+
 		// Create creates a new pipeline in the datastore.
 		Create(ctx context.Context, pipeline *types.Pipeline) error
 
@@ -770,13 +1192,19 @@ type (
 		Update(ctx context.Context, execution *types.Execution) error
 
 		// List lists the executions for a given pipeline ID
-		List(ctx context.Context, pipelineID int64, pagination types.Pagination) ([]*types.Execution, error)
+		List(ctx context.Context, pipelineID int64, filter *listfilter.Filter) ([]*types.Execution, error)
+
+		// ListIncomplete returns a list of executions that are pending or running.
+		ListIncomplete(ctx context.Context) ([]*types.Execution, error)
+
+		// UpdateQueueReason updates the reason why an execution is waiting in the queue.
+		UpdateQueueReason(ctx context.Context, executionID int64, reason string) error
 
 		// Delete deletes an execution given a pipeline ID and an execution number
 		Delete(ctx context.Context, pipelineID int64, num int64) error
 
-		// Count the number of executions in a space
-		Count(ctx context.Context, parentID int64) (int64, error)
+		// Count the number of executions in a pipeline matching the optional filter.
+		Count(ctx context.Context, parentID int64, filter *listfilter.Filter) (int64, error)
 	}
 
 	StageStore interface {
@@ -784,6 +1212,10 @@ type (
 		// where the stage is incomplete (pending or running).
 		ListIncomplete(ctx context.Context) ([]*types.Stage, error)
 
+		// ListBlocked returns a build stage list from the datastore
+		// where the stage is waiting for approval.
+		ListBlocked(ctx context.Context) ([]*types.Stage, error)
+
 		// List returns a list of stages corresponding to an execution ID.
 		List(ctx context.Context, executionID int64) ([]*types.Stage, error)
 
@@ -805,6 +1237,41 @@ type (
 		Create(ctx context.Context, stage *types.Stage) error
 	}
 
+	StageApprovalStore interface {
+		// Create records a new stage approval decision.
+		Create(ctx context.Context, approval *types.StageApproval) error
+
+		// List returns all approval decisions made for stages of an execution.
+		List(ctx context.Context, executionID int64) ([]*types.StageApproval, error)
+	}
+
+	ArtifactStore interface {
+		// Create creates a new artifact.
+		Create(ctx context.Context, artifact *types.Artifact) error
+
+		// FindByName finds the artifact of an execution by its name.
+		FindByName(ctx context.Context, executionID int64, name string) (*types.Artifact, error)
+
+		// List returns all artifacts of an execution.
+		List(ctx context.Context, executionID int64) ([]*types.Artifact, error)
+
+		// ListExpired returns artifacts that were created before the provided time (unix millis)
+		// or whose execution no longer exists.
+		ListExpired(ctx context.Context, createdBefore int64, limit int) ([]*types.Artifact, error)
+
+		// ListReposExceedingSize returns IDs of repositories whose artifacts take up more than maxSize bytes.
+		ListReposExceedingSize(ctx context.Context, maxSize int64) ([]int64, error)
+
+		// ListForRepo returns all artifacts of a repository, newest first.
+		ListForRepo(ctx context.Context, repoID int64) ([]*types.Artifact, error)
+
+		// SumSizes returns the total size (bytes) of the artifacts per repository.
+		SumSizes(ctx context.Context) (map[int64]int64, error)
+
+		// Delete deletes an artifact.
+		Delete(ctx context.Context, id int64) error
+	}
+
 	StepStore interface {
 		// FindByNumber returns a step from the datastore by number.
 		FindByNumber(ctx context.Context, stageID int64, stepNum int) (*types.Step, error)
@@ -815,6 +1282,46 @@ type (
 		// Update tries to update a step and returns an optimistic locking error if it was
 		// unable to do so.
 		Update(ctx context.Context, e *types.Step) error
+
+		// DeleteByStageID deletes all steps of a stage, together with their logs.
+		DeleteByStageID(ctx context.Context, stageID int64) error
+	}
+
+	RunnerStore interface {
+		// Create creates a new runner.
+		Create(ctx context.Context, runner *types.Runner) error
+
+		// FindByIdentifier finds the runner by its identifier.
+		FindByIdentifier(ctx context.Context, identifier string) (*types.Runner, error)
+
+		// List returns all runners.
+		List(ctx context.Context) ([]*types.Runner, error)
+
+		// ListBySpace returns the runners registered for a space.
+		ListBySpace(ctx context.Context, spaceID int64) ([]*types.Runner, error)
+
+		// Update tries to update a runner and returns an optimistic locking error if it was
+		// unable to do so.
+		Update(ctx context.Context, runner *types.Runner) error
+
+		// UpdateHeartbeat records a heartbeat of the runner.
+		UpdateHeartbeat(ctx context.Context, id int64, heartbeat int64) error
+
+		// Delete deletes a runner.
+		Delete(ctx context.Context, id int64) error
+	}
+
+	UsageStore interface {
+		// Upsert creates or updates the rollup of a repository for a day.
+		// The CI duration gets replaced, while the storage sizes only ever grow within a day.
+		Upsert(ctx context.Context, rollup *types.UsageRollup) error
+
+		// ListByRepos returns the rollups of the repositories for the days within [from, to] (unix millis).
+		ListByRepos(ctx context.Context, repoIDs []int64, from int64, to int64) ([]*types.UsageRollup, error)
+
+		// SumCIDurations returns the sum of the runtimes (millis) of the steps that finished
+		// within [from, to) (unix millis), per repository.
+		SumCIDurations(ctx context.Context, from int64, to int64) (map[int64]int64, error)
 	}
 
 	ConnectorStore interface {
@@ -966,8 +1473,8 @@ type (
 		// DeleteByIdentifier deletes a public key.
 		DeleteByIdentifier(ctx context.Context, principalID int64, identifier string) error
 
-		// MarkAsVerified updates the public key to mark it as verified.
-		MarkAsVerified(ctx context.Context, id int64, verified int64) error
+		// MarkAsUsed updates the last used timestamp of the public key and marks it as verified.
+		MarkAsUsed(ctx context.Context, id int64, used int64) error
 
 		// Count returns the number of public keys for the principal that match provided the filter.
 		Count(ctx context.Context, principalID int64, filter *types.PublicKeyFilter) (int, error)
@@ -979,6 +1486,32 @@ type (
 		ListByFingerprint(ctx context.Context, fingerprint string) ([]types.PublicKey, error)
 	}
 
+	DeployKeyStore interface {
+		// Find returns a deploy key given its ID.
+		Find(ctx context.Context, id int64) (*types.DeployKey, error)
+
+		// FindByIdentifier returns a deploy key given a repo ID and an identifier.
+		FindByIdentifier(ctx context.Context, repoID int64, identifier string) (*types.DeployKey, error)
+
+		// Create creates a new deploy key.
+		Create(ctx context.Context, deployKey *types.DeployKey) error
+
+		// DeleteByIdentifier deletes a deploy key.
+		DeleteByIdentifier(ctx context.Context, repoID int64, identifier string) error
+
+		// MarkAsUsed updates the last used timestamp of the deploy key and marks it as verified.
+		MarkAsUsed(ctx context.Context, id int64, used int64) error
+
+		// Count returns the number of deploy keys of the repo that match the provided filter.
+		Count(ctx context.Context, repoID int64, filter *types.DeployKeyFilter) (int, error)
+
+		// List returns the deploy keys of the repo that match the provided filter.
+		List(ctx context.Context, repoID int64, filter *types.DeployKeyFilter) ([]types.DeployKey, error)
+
+		// ListByFingerprint returns deploy keys given a fingerprint.
+		ListByFingerprint(ctx context.Context, fingerprint string) ([]types.DeployKey, error)
+	}
+
 	GitspaceEventStore interface {
 		// Create creates a new record for the given gitspace event.
 		Create(ctx context.Context, gitspaceEvent *types.GitspaceEvent) error
@@ -1147,4 +1680,39 @@ type (
 		Delete(ctx context.Context, id int64) error
 		Update(ctx context.Context, infraProvisioned *types.InfraProvisioned) error
 	}
+
+	// SchemaStore provides information about the database schema.
+	SchemaStore interface {
+		// MigrationStatus returns the migration status of the database schema.
+		MigrationStatus(ctx context.Context) (*types.MigrationStatus, error)
+	}
+
+	// CounterStore recomputes denormalized counters from their source of truth.
+	CounterStore interface {
+		// MaxRepoID returns the highest repository id.
+		MaxRepoID(ctx context.Context) (int64, error)
+
+		// ListRepoStars returns the star counters of the repositories with an id greater than afterID.
+		ListRepoStars(ctx context.Context, afterID int64, limit int) ([]types.CounterValue, error)
+
+		// ListRepoPullReqs returns the pull request counters of the repositories with an id greater than afterID.
+		ListRepoPullReqs(ctx context.Context, afterID int64, limit int) ([]types.CounterValue, error)
+
+		// ListRepoPushes returns the rolling push counters of the repositories with an id greater than afterID.
+		// Pushes are counted since the provided times (unix milliseconds) for the 7 and 30 day counters.
+		ListRepoPushes(
+			ctx context.Context,
+			afterID int64,
+			limit int,
+			since7Days int64,
+			since30Days int64,
+		) ([]types.CounterValue, error)
+
+		// ListRepoSizes returns the size infos of the repositories with an id greater than afterID.
+		ListRepoSizes(ctx context.Context, afterID int64, limit int) ([]*types.RepositorySizeInfo, error)
+
+		// Correct adjusts the counter by the difference between its recomputed and its stored value,
+		// which keeps concurrent changes of the counter intact.
+		Correct(ctx context.Context, value types.CounterValue) error
+	}
 )
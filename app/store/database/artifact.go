@@ -0,0 +1,276 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.ArtifactStore = (*ArtifactStore)(nil)
+
+// NewArtifactStore returns a new ArtifactStore.
+func NewArtifactStore(db *sqlx.DB) *ArtifactStore {
+	return &ArtifactStore{
+		db: db,
+	}
+}
+
+// ArtifactStore implements store.ArtifactStore backed by a relational database.
+type ArtifactStore struct {
+	db *sqlx.DB
+}
+
+const (
+	artifactColumns = `
+		 artifact_id
+		,artifact_execution_id
+		,artifact_repo_id
+		,artifact_name
+		,artifact_size
+		,artifact_checksum
+		,artifact_content_type
+		,artifact_blob_path
+		,artifact_created_by
+		,artifact_created`
+)
+
+type artifact struct {
+	ID          int64  `db:"artifact_id"`
+	ExecutionID int64  `db:"artifact_execution_id"`
+	RepoID      int64  `db:"artifact_repo_id"`
+	Name        string `db:"artifact_name"`
+	Size        int64  `db:"artifact_size"`
+	Checksum    string `db:"artifact_checksum"`
+	ContentType string `db:"artifact_content_type"`
+	BlobPath    string `db:"artifact_blob_path"`
+	CreatedBy   int64  `db:"artifact_created_by"`
+	Created     int64  `db:"artifact_created"`
+}
+
+// Create creates a new artifact.
+func (s *ArtifactStore) Create(ctx context.Context, artifact *types.Artifact) error {
+	const sqlQuery = `
+	INSERT INTO execution_artifacts (
+		 artifact_execution_id
+		,artifact_repo_id
+		,artifact_name
+		,artifact_size
+		,artifact_checksum
+		,artifact_content_type
+		,artifact_blob_path
+		,artifact_created_by
+		,artifact_created
+	) VALUES (
+		 :artifact_execution_id
+		,:artifact_repo_id
+		,:artifact_name
+		,:artifact_size
+		,:artifact_checksum
+		,:artifact_content_type
+		,:artifact_blob_path
+		,:artifact_created_by
+		,:artifact_created
+	) RETURNING artifact_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalArtifact(artifact))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind artifact object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&artifact.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert artifact query failed")
+	}
+
+	return nil
+}
+
+// FindByName finds the artifact of an execution by its name.
+func (s *ArtifactStore) FindByName(ctx context.Context, executionID int64, name string) (*types.Artifact, error) {
+	const sqlQuery = `
+	SELECT` + artifactColumns + `
+	FROM execution_artifacts
+	WHERE artifact_execution_id = $1 AND artifact_name = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &artifact{}
+	if err := db.GetContext(ctx, dst, sqlQuery, executionID, name); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find artifact")
+	}
+
+	return mapArtifact(dst), nil
+}
+
+// List returns all artifacts of an execution ordered by name.
+func (s *ArtifactStore) List(ctx context.Context, executionID int64) ([]*types.Artifact, error) {
+	const sqlQuery = `
+	SELECT` + artifactColumns + `
+	FROM execution_artifacts
+	WHERE artifact_execution_id = $1
+	ORDER BY artifact_name ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*artifact, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, executionID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list artifacts")
+	}
+
+	return mapArtifacts(dst), nil
+}
+
+// ListExpired returns artifacts that were created before the provided time (unix millis)
+// or whose execution no longer exists.
+func (s *ArtifactStore) ListExpired(ctx context.Context, createdBefore int64, limit int) ([]*types.Artifact, error) {
+	const sqlQuery = `
+	SELECT` + artifactColumns + `
+	FROM execution_artifacts
+	WHERE artifact_created < $1
+		OR NOT EXISTS (SELECT 1 FROM executions WHERE execution_id = artifact_execution_id)
+	ORDER BY artifact_id ASC
+	LIMIT $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*artifact, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, createdBefore, limit); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list expired artifacts")
+	}
+
+	return mapArtifacts(dst), nil
+}
+
+// ListReposExceedingSize returns IDs of repositories whose artifacts take up more than maxSize bytes.
+func (s *ArtifactStore) ListReposExceedingSize(ctx context.Context, maxSize int64) ([]int64, error) {
+	const sqlQuery = `
+	SELECT artifact_repo_id
+	FROM execution_artifacts
+	GROUP BY artifact_repo_id
+	HAVING SUM(artifact_size) > $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	repoIDs := make([]int64, 0)
+	if err := db.SelectContext(ctx, &repoIDs, sqlQuery, maxSize); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list repositories exceeding artifact size")
+	}
+
+	return repoIDs, nil
+}
+
+// SumSizes returns the total size (bytes) of the artifacts per repository.
+func (s *ArtifactStore) SumSizes(ctx context.Context) (map[int64]int64, error) {
+	const sqlQuery = `
+	SELECT artifact_repo_id, SUM(artifact_size) AS size
+	FROM execution_artifacts
+	GROUP BY artifact_repo_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]struct {
+		RepoID int64 `db:"artifact_repo_id"`
+		Size   int64 `db:"size"`
+	}, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to sum artifact sizes")
+	}
+
+	sizes := make(map[int64]int64, len(dst))
+	for _, d := range dst {
+		sizes[d.RepoID] = d.Size
+	}
+
+	return sizes, nil
+}
+
+// ListForRepo returns all artifacts of a repository, newest first.
+func (s *ArtifactStore) ListForRepo(ctx context.Context, repoID int64) ([]*types.Artifact, error) {
+	const sqlQuery = `
+	SELECT` + artifactColumns + `
+	FROM execution_artifacts
+	WHERE artifact_repo_id = $1
+	ORDER BY artifact_created DESC, artifact_id DESC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*artifact, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, repoID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list repository artifacts")
+	}
+
+	return mapArtifacts(dst), nil
+}
+
+// Delete deletes an artifact.
+func (s *ArtifactStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM execution_artifacts
+	WHERE artifact_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete artifact")
+	}
+
+	return nil
+}
+
+func mapInternalArtifact(a *types.Artifact) *artifact {
+	return &artifact{
+		ID:          a.ID,
+		ExecutionID: a.ExecutionID,
+		RepoID:      a.RepoID,
+		Name:        a.Name,
+		Size:        a.Size,
+		Checksum:    a.Checksum,
+		ContentType: a.ContentType,
+		BlobPath:    a.BlobPath,
+		CreatedBy:   a.CreatedBy,
+		Created:     a.Created,
+	}
+}
+
+func mapArtifact(a *artifact) *types.Artifact {
+	return &types.Artifact{
+		ID:          a.ID,
+		ExecutionID: a.ExecutionID,
+		RepoID:      a.RepoID,
+		Name:        a.Name,
+		Size:        a.Size,
+		Checksum:    a.Checksum,
+		ContentType: a.ContentType,
+		BlobPath:    a.BlobPath,
+		CreatedBy:   a.CreatedBy,
+		Created:     a.Created,
+	}
+}
+
+func mapArtifacts(artifacts []*artifact) []*types.Artifact {
+	m := make([]*types.Artifact, len(artifacts))
+	for i, a := range artifacts {
+		m[i] = mapArtifact(a)
+	}
+	return m
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.AvatarStore = (*AvatarStore)(nil)
+
+// NewAvatarStore returns a new AvatarStore.
+func NewAvatarStore(db *sqlx.DB) *AvatarStore {
+	return &AvatarStore{
+		db: db,
+	}
+}
+
+// AvatarStore implements store.AvatarStore backed by a relational database.
+type AvatarStore struct {
+	db *sqlx.DB
+}
+
+const avatarColumns = `
+	 avatar_principal_id
+	,avatar_hash
+	,avatar_created
+	,avatar_updated`
+
+// Find returns the avatar of the principal.
+func (s *AvatarStore) Find(ctx context.Context, principalID int64) (*types.Avatar, error) {
+	const sqlQuery = `
+		SELECT` + avatarColumns + `
+		FROM principal_avatars
+		WHERE avatar_principal_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &types.Avatar{}
+	if err := db.GetContext(ctx, dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find avatar")
+	}
+
+	return dst, nil
+}
+
+// Upsert creates or replaces the avatar of the principal.
+func (s *AvatarStore) Upsert(ctx context.Context, avatar *types.Avatar) error {
+	const sqlQuery = `
+		INSERT INTO principal_avatars (` + avatarColumns + `
+		) VALUES (
+			 :avatar_principal_id
+			,:avatar_hash
+			,:avatar_created
+			,:avatar_updated
+		)
+		ON CONFLICT (avatar_principal_id) DO UPDATE
+		SET
+			 avatar_hash = EXCLUDED.avatar_hash
+			,avatar_updated = EXCLUDED.avatar_updated
+		RETURNING avatar_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, avatar)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind avatar object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&avatar.Created); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to upsert avatar")
+	}
+
+	return nil
+}
+
+// Delete deletes the avatar of the principal.
+func (s *AvatarStore) Delete(ctx context.Context, principalID int64) error {
+	const sqlQuery = `
+		DELETE FROM principal_avatars
+		WHERE avatar_principal_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, principalID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete avatar")
+	}
+
+	return nil
+}
@@ -0,0 +1,256 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.CounterStore = (*CounterStore)(nil)
+
+// NewCounterStore returns a new CounterStore.
+func NewCounterStore(db *sqlx.DB) *CounterStore {
+	return &CounterStore{
+		db: db,
+	}
+}
+
+// CounterStore implements store.CounterStore backed by a relational database.
+type CounterStore struct {
+	db *sqlx.DB
+}
+
+// counterColumn describes where a counter column is stored.
+type counterColumn struct {
+	table    string
+	idColumn string
+}
+
+// counterColumns contains all counter columns that can be corrected.
+var counterColumns = map[string]counterColumn{
+	"repo_num_stars":        {table: "repositories", idColumn: "repo_id"},
+	"repo_num_pulls":        {table: "repositories", idColumn: "repo_id"},
+	"repo_num_open_pulls":   {table: "repositories", idColumn: "repo_id"},
+	"repo_num_closed_pulls": {table: "repositories", idColumn: "repo_id"},
+	"repo_num_merged_pulls": {table: "repositories", idColumn: "repo_id"},
+	"repo_stats_pushes_7d":  {table: "repo_stats", idColumn: "repo_stats_repo_id"},
+	"repo_stats_pushes_30d": {table: "repo_stats", idColumn: "repo_stats_repo_id"},
+}
+
+// MaxRepoID returns the highest repository id.
+func (s *CounterStore) MaxRepoID(ctx context.Context) (int64, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var maxID int64
+	if err := db.QueryRowContext(ctx, "SELECT COALESCE(MAX(repo_id), 0) FROM repositories").Scan(&maxID); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing max repo id query")
+	}
+
+	return maxID, nil
+}
+
+// ListRepoStars returns the star counters of the repositories with an id greater than afterID.
+func (s *CounterStore) ListRepoStars(ctx context.Context, afterID int64, limit int) ([]types.CounterValue, error) {
+	stmt := database.Builder.
+		Select(
+			"repo_id",
+			"repo_num_stars",
+			"(SELECT COUNT(*) FROM repo_stars WHERE repo_star_repo_id = repo_id)",
+		).
+		From("repositories").
+		Where("repo_id > ?", afterID).
+		OrderBy("repo_id").
+		Limit(uint64(limit))
+
+	return s.list(ctx, stmt, "repo_num_stars")
+}
+
+// ListRepoPullReqs returns the pull request counters of the repositories with an id greater than afterID.
+func (s *CounterStore) ListRepoPullReqs(ctx context.Context, afterID int64, limit int) ([]types.CounterValue, error) {
+	countByState := func(state enum.PullReqState) string {
+		return "(SELECT COUNT(*) FROM pullreqs WHERE pullreq_target_repo_id = repo_id AND pullreq_state = '" +
+			string(state) + "')"
+	}
+
+	stmt := database.Builder.
+		Select(
+			"repo_id",
+			"repo_num_pulls",
+			"(SELECT COUNT(*) FROM pullreqs WHERE pullreq_target_repo_id = repo_id)",
+			"repo_num_open_pulls",
+			countByState(enum.PullReqStateOpen),
+			"repo_num_closed_pulls",
+			countByState(enum.PullReqStateClosed),
+			"repo_num_merged_pulls",
+			countByState(enum.PullReqStateMerged),
+		).
+		From("repositories").
+		Where("repo_id > ?", afterID).
+		OrderBy("repo_id").
+		Limit(uint64(limit))
+
+	return s.list(ctx, stmt,
+		"repo_num_pulls", "repo_num_open_pulls", "repo_num_closed_pulls", "repo_num_merged_pulls")
+}
+
+// ListRepoPushes returns the rolling push counters of the repositories with an id greater than afterID.
+func (s *CounterStore) ListRepoPushes(
+	ctx context.Context,
+	afterID int64,
+	limit int,
+	since7Days int64,
+	since30Days int64,
+) ([]types.CounterValue, error) {
+	countSince := "(SELECT COUNT(*) FROM repo_pushes" +
+		" WHERE repo_push_repo_id = repo_stats_repo_id AND repo_push_created >= ?)"
+
+	stmt := database.Builder.
+		Select("repo_stats_repo_id", "repo_stats_pushes_7d").
+		Column(countSince, since7Days).
+		Column("repo_stats_pushes_30d").
+		Column(countSince, since30Days).
+		From("repo_stats").
+		Where("repo_stats_repo_id > ?", afterID).
+		OrderBy("repo_stats_repo_id").
+		Limit(uint64(limit))
+
+	return s.list(ctx, stmt, "repo_stats_pushes_7d", "repo_stats_pushes_30d")
+}
+
+// ListRepoSizes returns the size infos of the repositories with an id greater than afterID.
+func (s *CounterStore) ListRepoSizes(
+	ctx context.Context,
+	afterID int64,
+	limit int,
+) ([]*types.RepositorySizeInfo, error) {
+	stmt := database.Builder.
+		Select("repo_id", "repo_git_uid", "repo_size", "repo_size_updated").
+		From("repositories").
+		Where("repo_id > ?", afterID).
+		Where("repo_deleted IS NULL").
+		OrderBy("repo_id").
+		Limit(uint64(limit))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list repo sizes query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*repoSize{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list repo sizes query")
+	}
+
+	result := make([]*types.RepositorySizeInfo, len(dst))
+	for i, size := range dst {
+		result[i] = &types.RepositorySizeInfo{
+			ID:          size.ID,
+			GitUID:      size.GitUID,
+			Size:        size.Size,
+			SizeUpdated: size.SizeUpdated,
+		}
+	}
+
+	return result, nil
+}
+
+// Correct adjusts the counter by the difference between its recomputed and its stored value.
+func (s *CounterStore) Correct(ctx context.Context, value types.CounterValue) error {
+	column, ok := counterColumns[value.Column]
+	if !ok {
+		return fmt.Errorf("unknown counter column %q", value.Column)
+	}
+
+	stmt := database.Builder.
+		Update(column.table).
+		Set(value.Column, squirrel.Expr(value.Column+" + ?", value.Delta())).
+		Where(column.idColumn+" = ?", value.ID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert correct counter query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed executing correct counter query")
+	}
+
+	return nil
+}
+
+// list executes a query that returns the row id followed by pairs of stored and recomputed values
+// of the provided counter columns.
+func (s *CounterStore) list(ctx context.Context, stmt squirrel.SelectBuilder, columns ...string) (
+	[]types.CounterValue,
+	error,
+) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list counters query to sql: %w", err)
+	}
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	rows, err := db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list counters query")
+	}
+	defer rows.Close()
+
+	var result []types.CounterValue
+	for rows.Next() {
+		var id int64
+		values := make([]int64, 2*len(columns))
+
+		dest := make([]any, 0, len(values)+1)
+		dest = append(dest, &id)
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+
+		if err = rows.Scan(dest...); err != nil {
+			return nil, database.ProcessSQLErrorf(ctx, err, "Failed scanning counters")
+		}
+
+		for i, column := range columns {
+			result = append(result, types.CounterValue{
+				ID:     id,
+				Column: column,
+				Stored: values[2*i],
+				Actual: values[2*i+1],
+			})
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed listing counters")
+	}
+
+	return result, nil
+}
@@ -0,0 +1,384 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.DeployKeyStore = DeployKeyStore{}
+
+// NewDeployKeyStore returns a new DeployKeyStore.
+func NewDeployKeyStore(db *sqlx.DB) DeployKeyStore {
+	return DeployKeyStore{
+		db: db,
+	}
+}
+
+// DeployKeyStore implements a store.DeployKeyStore backed by a relational database.
+type DeployKeyStore struct {
+	db *sqlx.DB
+}
+
+type deployKey struct {
+	ID int64 `db:"deploy_key_id"`
+
+	RepoID    int64 `db:"deploy_key_repo_id"`
+	CreatedBy int64 `db:"deploy_key_created_by"`
+
+	Created  int64    `db:"deploy_key_created"`
+	Verified null.Int `db:"deploy_key_verified"`
+	LastUsed null.Int `db:"deploy_key_last_used"`
+
+	Identifier        string `db:"deploy_key_identifier"`
+	IdentifierSortKey string `db:"deploy_key_identifier_sort_key"`
+	Title             string `db:"deploy_key_title"`
+	ReadOnly          bool   `db:"deploy_key_read_only"`
+
+	Fingerprint string `db:"deploy_key_fingerprint"`
+	Content     string `db:"deploy_key_content"`
+	Comment     string `db:"deploy_key_comment"`
+	Type        string `db:"deploy_key_type"`
+}
+
+const (
+	deployKeyColumns = `
+		 deploy_key_id
+		,deploy_key_repo_id
+		,deploy_key_created_by
+		,deploy_key_created
+		,deploy_key_verified
+		,deploy_key_last_used
+		,deploy_key_identifier
+		,deploy_key_title
+		,deploy_key_read_only
+		,deploy_key_fingerprint
+		,deploy_key_content
+		,deploy_key_comment
+		,deploy_key_type`
+
+	deployKeySelectBase = `
+		SELECT` + deployKeyColumns + `
+		FROM deploy_keys`
+)
+
+// Find returns a deploy key given its ID.
+func (s DeployKeyStore) Find(ctx context.Context, id int64) (*types.DeployKey, error) {
+	const sqlQuery = deployKeySelectBase + `
+	WHERE deploy_key_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result := &deployKey{}
+	if err := db.GetContext(ctx, result, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find deploy key by id")
+	}
+
+	key := mapToDeployKey(result)
+
+	return &key, nil
+}
+
+// FindByIdentifier returns a deploy key given a repo ID and an identifier.
+func (s DeployKeyStore) FindByIdentifier(
+	ctx context.Context,
+	repoID int64,
+	identifier string,
+) (*types.DeployKey, error) {
+	const sqlQuery = deployKeySelectBase + `
+	WHERE deploy_key_repo_id = $1 and LOWER(deploy_key_identifier) = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result := &deployKey{}
+	if err := db.GetContext(ctx, result, sqlQuery, repoID, strings.ToLower(identifier)); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find deploy key by repo and identifier")
+	}
+
+	key := mapToDeployKey(result)
+
+	return &key, nil
+}
+
+// Create creates a new deploy key.
+func (s DeployKeyStore) Create(ctx context.Context, key *types.DeployKey) error {
+	const sqlQuery = `
+		INSERT INTO deploy_keys (
+			 deploy_key_repo_id
+			,deploy_key_created_by
+			,deploy_key_created
+			,deploy_key_verified
+			,deploy_key_last_used
+			,deploy_key_identifier
+			,deploy_key_identifier_sort_key
+			,deploy_key_title
+			,deploy_key_read_only
+			,deploy_key_fingerprint
+			,deploy_key_content
+			,deploy_key_comment
+			,deploy_key_type
+		) values (
+			 :deploy_key_repo_id
+			,:deploy_key_created_by
+			,:deploy_key_created
+			,:deploy_key_verified
+			,:deploy_key_last_used
+			,:deploy_key_identifier
+			,:deploy_key_identifier_sort_key
+			,:deploy_key_title
+			,:deploy_key_read_only
+			,:deploy_key_fingerprint
+			,:deploy_key_content
+			,:deploy_key_comment
+			,:deploy_key_type
+		) RETURNING deploy_key_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbKey := mapToInternalDeployKey(key)
+
+	query, arg, err := db.BindNamed(sqlQuery, &dbKey)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind deploy key object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&dbKey.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert deploy key query failed")
+	}
+
+	key.ID = dbKey.ID
+
+	return nil
+}
+
+// DeleteByIdentifier deletes a deploy key.
+func (s DeployKeyStore) DeleteByIdentifier(ctx context.Context, repoID int64, identifier string) error {
+	const sqlQuery = `DELETE FROM deploy_keys WHERE deploy_key_repo_id = $1 and LOWER(deploy_key_identifier) = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, repoID, strings.ToLower(identifier))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Delete deploy key query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "RowsAffected after delete of deploy key failed")
+	}
+
+	if count == 0 {
+		return errors.NotFound("Key not found")
+	}
+
+	return nil
+}
+
+// MarkAsUsed updates the last used timestamp of the deploy key.
+// The verified timestamp is set as well, if the key has never been used before.
+func (s DeployKeyStore) MarkAsUsed(ctx context.Context, id int64, used int64) error {
+	const sqlQuery = `
+		UPDATE deploy_keys
+		SET
+			 deploy_key_verified = COALESCE(deploy_key_verified, $1)
+			,deploy_key_last_used = $1
+		WHERE deploy_key_id = $2`
+
+	if _, err := dbtx.GetAccessor(ctx, s.db).ExecContext(ctx, sqlQuery, used, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to mark deploy key as used")
+	}
+
+	return nil
+}
+
+// Count returns the number of deploy keys of the repository that match the provided filter.
+func (s DeployKeyStore) Count(
+	ctx context.Context,
+	repoID int64,
+	filter *types.DeployKeyFilter,
+) (int, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("deploy_keys").
+		Where("deploy_key_repo_id = ?", repoID)
+
+	stmt = s.applyQueryFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int
+
+	if err := db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "failed to execute count deploy keys query")
+	}
+
+	return count, nil
+}
+
+// List returns the deploy keys of the repository.
+func (s DeployKeyStore) List(
+	ctx context.Context,
+	repoID int64,
+	filter *types.DeployKeyFilter,
+) ([]types.DeployKey, error) {
+	stmt := database.Builder.
+		Select(deployKeyColumns).
+		From("deploy_keys").
+		Where("deploy_key_repo_id = ?", repoID)
+
+	stmt = s.applyQueryFilter(stmt, filter)
+	stmt = s.applySortFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	keys := make([]deployKey, 0)
+	if err = db.SelectContext(ctx, &keys, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "failed to execute list deploy keys query")
+	}
+
+	return mapToDeployKeys(keys), nil
+}
+
+// ListByFingerprint returns deploy keys given a fingerprint.
+func (s DeployKeyStore) ListByFingerprint(
+	ctx context.Context,
+	fingerprint string,
+) ([]types.DeployKey, error) {
+	stmt := database.Builder.
+		Select(deployKeyColumns).
+		From("deploy_keys").
+		Where("deploy_key_fingerprint = ?", fingerprint).
+		OrderBy("deploy_key_created ASC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	keys := make([]deployKey, 0)
+	if err = db.SelectContext(ctx, &keys, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "failed to execute deploy keys by fingerprint query")
+	}
+
+	return mapToDeployKeys(keys), nil
+}
+
+func (DeployKeyStore) applyQueryFilter(
+	stmt squirrel.SelectBuilder,
+	filter *types.DeployKeyFilter,
+) squirrel.SelectBuilder {
+	if filter.Query != "" {
+		stmt = stmt.Where("LOWER(deploy_key_identifier) LIKE ?",
+			fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
+	}
+
+	return stmt
+}
+
+func (DeployKeyStore) applySortFilter(
+	stmt squirrel.SelectBuilder,
+	filter *types.DeployKeyFilter,
+) squirrel.SelectBuilder {
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	order := filter.Order
+	if order == enum.OrderDefault {
+		order = enum.OrderAsc
+	}
+
+	switch filter.Sort {
+	case enum.PublicKeySortIdentifier:
+		stmt = stmt.OrderBy("deploy_key_identifier_sort_key " + order.String())
+	case enum.PublicKeySortCreated:
+		stmt = stmt.OrderBy("deploy_key_created " + order.String())
+	}
+
+	stmt = stmt.OrderBy("deploy_key_id " + order.String())
+
+	return stmt
+}
+
+func mapToInternalDeployKey(in *types.DeployKey) deployKey {
+	return deployKey{
+		ID:                in.ID,
+		RepoID:            in.RepoID,
+		CreatedBy:         in.CreatedBy,
+		Created:           in.Created,
+		Verified:          null.IntFromPtr(in.Verified),
+		LastUsed:          null.IntFromPtr(in.LastUsed),
+		Identifier:        in.Identifier,
+		IdentifierSortKey: database.SortKey(in.Identifier),
+		Title:             in.Title,
+		ReadOnly:          in.ReadOnly,
+		Fingerprint:       in.Fingerprint,
+		Content:           in.Content,
+		Comment:           in.Comment,
+		Type:              in.Type,
+	}
+}
+
+func mapToDeployKey(in *deployKey) types.DeployKey {
+	return types.DeployKey{
+		ID:          in.ID,
+		RepoID:      in.RepoID,
+		CreatedBy:   in.CreatedBy,
+		Created:     in.Created,
+		Verified:    in.Verified.Ptr(),
+		LastUsed:    in.LastUsed.Ptr(),
+		Identifier:  in.Identifier,
+		Title:       in.Title,
+		ReadOnly:    in.ReadOnly,
+		Fingerprint: in.Fingerprint,
+		Content:     in.Content,
+		Comment:     in.Comment,
+		Type:        in.Type,
+	}
+}
+
+func mapToDeployKeys(
+	keys []deployKey,
+) []types.DeployKey {
+	res := make([]types.DeployKey, len(keys))
+	for i := 0; i < len(keys); i++ {
+		res[i] = mapToDeployKey(&keys[i])
+	}
+	return res
+}
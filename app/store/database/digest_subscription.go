@@ -0,0 +1,237 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
+)
+
+var _ store.DigestSubscriptionStore = (*DigestSubscriptionStore)(nil)
+
+// NewDigestSubscriptionStore returns a new DigestSubscriptionStore.
+func NewDigestSubscriptionStore(db *sqlx.DB) *DigestSubscriptionStore {
+	return &DigestSubscriptionStore{
+		db: db,
+	}
+}
+
+// DigestSubscriptionStore implements store.DigestSubscriptionStore backed by a relational database.
+type DigestSubscriptionStore struct {
+	db *sqlx.DB
+}
+
+type digestSubscription struct {
+	PrincipalID int64                `db:"digest_subscription_principal_id"`
+	Frequency   enum.DigestFrequency `db:"digest_subscription_frequency"`
+	SpaceIDs    sqlxtypes.JSONText   `db:"digest_subscription_space_ids"`
+	RepoIDs     sqlxtypes.JSONText   `db:"digest_subscription_repo_ids"`
+	LastSent    int64                `db:"digest_subscription_last_sent"`
+	Created     int64                `db:"digest_subscription_created"`
+	Updated     int64                `db:"digest_subscription_updated"`
+}
+
+const (
+	digestSubscriptionColumns = `
+		 digest_subscription_principal_id
+		,digest_subscription_frequency
+		,digest_subscription_space_ids
+		,digest_subscription_repo_ids
+		,digest_subscription_last_sent
+		,digest_subscription_created
+		,digest_subscription_updated`
+
+	digestSubscriptionSelectBase = `
+		SELECT` + digestSubscriptionColumns + `
+		FROM digest_subscriptions`
+)
+
+// Find returns the digest subscription of the principal.
+func (s *DigestSubscriptionStore) Find(ctx context.Context, principalID int64) (*types.DigestSubscription, error) {
+	const sqlQuery = digestSubscriptionSelectBase + `
+		WHERE digest_subscription_principal_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &digestSubscription{}
+	if err := db.GetContext(ctx, dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find digest subscription")
+	}
+
+	return mapToDigestSubscription(dst)
+}
+
+// Upsert creates or updates the digest subscription of the principal.
+func (s *DigestSubscriptionStore) Upsert(ctx context.Context, sub *types.DigestSubscription) error {
+	const sqlQuery = `
+		INSERT INTO digest_subscriptions (
+			 digest_subscription_principal_id
+			,digest_subscription_frequency
+			,digest_subscription_space_ids
+			,digest_subscription_repo_ids
+			,digest_subscription_last_sent
+			,digest_subscription_created
+			,digest_subscription_updated
+		) values (
+			 :digest_subscription_principal_id
+			,:digest_subscription_frequency
+			,:digest_subscription_space_ids
+			,:digest_subscription_repo_ids
+			,:digest_subscription_last_sent
+			,:digest_subscription_created
+			,:digest_subscription_updated
+		)
+		ON CONFLICT (digest_subscription_principal_id) DO
+		UPDATE SET
+			 digest_subscription_frequency = :digest_subscription_frequency
+			,digest_subscription_space_ids = :digest_subscription_space_ids
+			,digest_subscription_repo_ids = :digest_subscription_repo_ids
+			,digest_subscription_updated = :digest_subscription_updated
+		RETURNING digest_subscription_last_sent, digest_subscription_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapToInternalDigestSubscription(sub))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind digest subscription object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&sub.LastSent, &sub.Created); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Upsert digest subscription query failed")
+	}
+
+	return nil
+}
+
+// Delete removes the digest subscription of the principal.
+func (s *DigestSubscriptionStore) Delete(ctx context.Context, principalID int64) error {
+	const sqlQuery = `
+		DELETE FROM digest_subscriptions
+		WHERE digest_subscription_principal_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, principalID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete digest subscription")
+	}
+
+	return nil
+}
+
+// ListDue returns up to limit subscriptions with the provided frequency that haven't been sent since sentBefore.
+// Subscriptions are ordered by principal ID, only principals with an ID greater than afterPrincipalID are returned.
+func (s *DigestSubscriptionStore) ListDue(
+	ctx context.Context,
+	frequency enum.DigestFrequency,
+	sentBefore int64,
+	afterPrincipalID int64,
+	limit int,
+) ([]*types.DigestSubscription, error) {
+	stmt := database.Builder.
+		Select(digestSubscriptionColumns).
+		From("digest_subscriptions").
+		Where("digest_subscription_frequency = ?", frequency).
+		Where("digest_subscription_last_sent < ?", sentBefore).
+		Where("digest_subscription_principal_id > ?", afterPrincipalID).
+		OrderBy("digest_subscription_principal_id ASC").
+		Limit(uint64(limit))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*digestSubscription, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list due digest subscriptions")
+	}
+
+	result := make([]*types.DigestSubscription, len(dst))
+	for i, sub := range dst {
+		if result[i], err = mapToDigestSubscription(sub); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// UpdateLastSent updates the time the last digest was sent to the principal.
+func (s *DigestSubscriptionStore) UpdateLastSent(ctx context.Context, principalID int64, lastSent int64) error {
+	const sqlQuery = `
+		UPDATE digest_subscriptions
+		SET digest_subscription_last_sent = $1
+		WHERE digest_subscription_principal_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, lastSent, principalID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update digest subscription last sent time")
+	}
+
+	return nil
+}
+
+func mapToInternalDigestSubscription(in *types.DigestSubscription) *digestSubscription {
+	return &digestSubscription{
+		PrincipalID: in.PrincipalID,
+		Frequency:   in.Frequency,
+		SpaceIDs:    EncodeToSQLXJSON(nonNilIDs(in.SpaceIDs)),
+		RepoIDs:     EncodeToSQLXJSON(nonNilIDs(in.RepoIDs)),
+		LastSent:    in.LastSent,
+		Created:     in.Created,
+		Updated:     in.Updated,
+	}
+}
+
+func mapToDigestSubscription(in *digestSubscription) (*types.DigestSubscription, error) {
+	sub := &types.DigestSubscription{
+		PrincipalID: in.PrincipalID,
+		Frequency:   in.Frequency,
+		LastSent:    in.LastSent,
+		Created:     in.Created,
+		Updated:     in.Updated,
+	}
+
+	if err := json.Unmarshal(in.SpaceIDs, &sub.SpaceIDs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal digest subscription space IDs: %w", err)
+	}
+
+	if err := json.Unmarshal(in.RepoIDs, &sub.RepoIDs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal digest subscription repo IDs: %w", err)
+	}
+
+	return sub, nil
+}
+
+// nonNilIDs ensures empty ID lists are stored as an empty json array.
+func nonNilIDs(ids []int64) []int64 {
+	if ids == nil {
+		return []int64{}
+	}
+	return ids
+}
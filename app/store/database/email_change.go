@@ -0,0 +1,113 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.EmailChangeStore = (*EmailChangeStore)(nil)
+
+// NewEmailChangeStore returns a new EmailChangeStore.
+func NewEmailChangeStore(db *sqlx.DB) *EmailChangeStore {
+	return &EmailChangeStore{
+		db: db,
+	}
+}
+
+// EmailChangeStore implements store.EmailChangeStore backed by a relational database.
+type EmailChangeStore struct {
+	db *sqlx.DB
+}
+
+const (
+	emailChangeColumns = `
+		 email_change_principal_id
+		,email_change_email
+		,email_change_token_hash
+		,email_change_expires
+		,email_change_created`
+)
+
+// FindByTokenHash finds the pending email change by the hash of its verification token.
+func (s *EmailChangeStore) FindByTokenHash(ctx context.Context, tokenHash string) (*types.EmailChange, error) {
+	const sqlQuery = `
+		SELECT` + emailChangeColumns + `
+		FROM email_changes
+		WHERE email_change_token_hash = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &types.EmailChange{}
+	if err := db.GetContext(ctx, dst, sqlQuery, tokenHash); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find pending email change")
+	}
+
+	return dst, nil
+}
+
+// Upsert creates the pending email change of the principal or replaces the existing one.
+func (s *EmailChangeStore) Upsert(ctx context.Context, change *types.EmailChange) error {
+	const sqlQuery = `
+		INSERT INTO email_changes (` + emailChangeColumns + `
+		) values (
+			 :email_change_principal_id
+			,:email_change_email
+			,:email_change_token_hash
+			,:email_change_expires
+			,:email_change_created
+		)
+		ON CONFLICT (email_change_principal_id) DO
+		UPDATE SET
+			 email_change_email = EXCLUDED.email_change_email
+			,email_change_token_hash = EXCLUDED.email_change_token_hash
+			,email_change_expires = EXCLUDED.email_change_expires
+			,email_change_created = EXCLUDED.email_change_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, change)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind pending email change object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to upsert pending email change")
+	}
+
+	return nil
+}
+
+// Delete deletes the pending email change of the principal.
+func (s *EmailChangeStore) Delete(ctx context.Context, principalID int64) error {
+	const sqlQuery = `
+		DELETE FROM email_changes
+		WHERE email_change_principal_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, principalID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete pending email change")
+	}
+
+	return nil
+}
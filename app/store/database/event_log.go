@@ -0,0 +1,307 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/stream"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ stream.LogStore = (*EventLogStore)(nil)
+
+// NewEventLogStore returns a new EventLogStore.
+func NewEventLogStore(db *sqlx.DB) *EventLogStore {
+	return &EventLogStore{
+		db: db,
+	}
+}
+
+// EventLogStore implements stream.LogStore backed by a relational database.
+type EventLogStore struct {
+	db *sqlx.DB
+}
+
+type eventLogMessage struct {
+	ID       int64  `db:"event_log_id"`
+	StreamID string `db:"event_log_stream_id"`
+	Payload  []byte `db:"event_log_payload"`
+	Created  int64  `db:"event_log_created"`
+}
+
+type eventConsumerLag struct {
+	GroupName    string `db:"event_consumer_offset_group_name"`
+	StreamID     string `db:"event_consumer_offset_stream_id"`
+	Offset       int64  `db:"event_consumer_offset_position"`
+	Head         int64  `db:"head"`
+	Lag          int64  `db:"lag"`
+	LeaseOwner   string `db:"event_consumer_offset_lease_owner"`
+	LeaseExpires int64  `db:"event_consumer_offset_lease_expires"`
+	Updated      int64  `db:"event_consumer_offset_updated"`
+}
+
+// Append appends a message to the stream and returns its ID.
+func (s *EventLogStore) Append(ctx context.Context, streamID string, payload map[string][]byte) (int64, error) {
+	const sqlQuery = `
+	INSERT INTO event_log (
+		 event_log_stream_id
+		,event_log_payload
+		,event_log_created
+	) VALUES ($1, $2, $3)
+	RETURNING event_log_id`
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal event payload: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var id int64
+	err = db.QueryRowContext(ctx, sqlQuery, streamID, data, time.Now().UnixMilli()).Scan(&id)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Insert event log query failed")
+	}
+
+	return id, nil
+}
+
+// ListAfter returns up to limit messages of the stream with an ID greater than the offset, ordered by ID.
+func (s *EventLogStore) ListAfter(
+	ctx context.Context,
+	streamID string,
+	offset int64,
+	limit int,
+) ([]stream.LogMessage, error) {
+	const sqlQuery = `
+	SELECT
+		 event_log_id
+		,event_log_stream_id
+		,event_log_payload
+		,event_log_created
+	FROM event_log
+	WHERE event_log_stream_id = $1 AND event_log_id > $2
+	ORDER BY event_log_id
+	LIMIT $3`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*eventLogMessage, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, streamID, offset, limit); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list event log query")
+	}
+
+	result := make([]stream.LogMessage, len(dst))
+	for i, m := range dst {
+		payload := make(map[string][]byte)
+		if err := json.Unmarshal(m.Payload, &payload); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal payload of event %d: %w", m.ID, err)
+		}
+
+		result[i] = stream.LogMessage{
+			ID:       m.ID,
+			StreamID: m.StreamID,
+			Payload:  payload,
+			Created:  m.Created,
+		}
+	}
+
+	return result, nil
+}
+
+// Trim deletes all but the newest maxLength messages of the stream.
+func (s *EventLogStore) Trim(ctx context.Context, streamID string, maxLength int64) error {
+	const sqlQuery = `
+	DELETE FROM event_log
+	WHERE event_log_stream_id = $1 AND event_log_id <= (
+		SELECT event_log_id
+		FROM event_log
+		WHERE event_log_stream_id = $1
+		ORDER BY event_log_id DESC
+		LIMIT 1 OFFSET $2
+	)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, streamID, maxLength); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed executing trim event log query")
+	}
+
+	return nil
+}
+
+// Lease acquires or renews the lease of the consumer group on the stream for the owner.
+func (s *EventLogStore) Lease(
+	ctx context.Context,
+	groupName, streamID, owner string,
+	expires int64,
+) (int64, bool, error) {
+	const sqlQuery = `
+	INSERT INTO event_consumer_offsets (
+		 event_consumer_offset_group_name
+		,event_consumer_offset_stream_id
+		,event_consumer_offset_position
+		,event_consumer_offset_lease_owner
+		,event_consumer_offset_lease_expires
+		,event_consumer_offset_updated
+	) VALUES ($1, $2, 0, $3, $4, $5)
+	ON CONFLICT (event_consumer_offset_group_name, event_consumer_offset_stream_id) DO UPDATE
+	SET
+		 event_consumer_offset_lease_owner = EXCLUDED.event_consumer_offset_lease_owner
+		,event_consumer_offset_lease_expires = EXCLUDED.event_consumer_offset_lease_expires
+	WHERE event_consumer_offsets.event_consumer_offset_lease_owner = EXCLUDED.event_consumer_offset_lease_owner
+		OR event_consumer_offsets.event_consumer_offset_lease_expires < $5
+	RETURNING event_consumer_offset_position`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var offset int64
+	err := db.QueryRowContext(ctx, sqlQuery, groupName, streamID, owner, expires, time.Now().UnixMilli()).
+		Scan(&offset)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, database.ProcessSQLErrorf(ctx, err, "Failed executing lease event stream query")
+	}
+
+	return offset, true, nil
+}
+
+// Renew extends the lease of the consumer group on the stream.
+func (s *EventLogStore) Renew(ctx context.Context, groupName, streamID, owner string, expires int64) error {
+	const sqlQuery = `
+	UPDATE event_consumer_offsets
+	SET event_consumer_offset_lease_expires = $4
+	WHERE event_consumer_offset_group_name = $1
+		AND event_consumer_offset_stream_id = $2
+		AND event_consumer_offset_lease_owner = $3`
+
+	return s.updateLeased(ctx, sqlQuery, groupName, streamID, owner, expires)
+}
+
+// Commit stores the offset of the consumer group on the stream and renews the lease.
+func (s *EventLogStore) Commit(
+	ctx context.Context,
+	groupName, streamID, owner string,
+	offset, expires int64,
+) error {
+	const sqlQuery = `
+	UPDATE event_consumer_offsets
+	SET
+		 event_consumer_offset_lease_expires = $4
+		,event_consumer_offset_position = $5
+		,event_consumer_offset_updated = $6
+	WHERE event_consumer_offset_group_name = $1
+		AND event_consumer_offset_stream_id = $2
+		AND event_consumer_offset_lease_owner = $3`
+
+	return s.updateLeased(ctx, sqlQuery, groupName, streamID, owner, expires, offset, time.Now().UnixMilli())
+}
+
+func (s *EventLogStore) updateLeased(ctx context.Context, sqlQuery string, args ...any) error {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed executing update event consumer offset query")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated event consumer offsets")
+	}
+
+	if count == 0 {
+		return stream.ErrLeaseLost
+	}
+
+	return nil
+}
+
+// Reset sets the offset of the consumer group on the stream and releases the lease.
+func (s *EventLogStore) Reset(ctx context.Context, groupName, streamID string, offset int64) error {
+	const sqlQuery = `
+	INSERT INTO event_consumer_offsets (
+		 event_consumer_offset_group_name
+		,event_consumer_offset_stream_id
+		,event_consumer_offset_position
+		,event_consumer_offset_lease_owner
+		,event_consumer_offset_lease_expires
+		,event_consumer_offset_updated
+	) VALUES ($1, $2, $3, '', 0, $4)
+	ON CONFLICT (event_consumer_offset_group_name, event_consumer_offset_stream_id) DO UPDATE
+	SET
+		 event_consumer_offset_position = EXCLUDED.event_consumer_offset_position
+		,event_consumer_offset_lease_owner = EXCLUDED.event_consumer_offset_lease_owner
+		,event_consumer_offset_lease_expires = EXCLUDED.event_consumer_offset_lease_expires
+		,event_consumer_offset_updated = EXCLUDED.event_consumer_offset_updated`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, groupName, streamID, offset, time.Now().UnixMilli()); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed executing reset event consumer offset query")
+	}
+
+	return nil
+}
+
+// ListLag returns the lag of all consumer groups on all streams.
+func (s *EventLogStore) ListLag(ctx context.Context) ([]stream.ConsumerLag, error) {
+	const sqlQuery = `
+	SELECT
+		 event_consumer_offset_group_name
+		,event_consumer_offset_stream_id
+		,event_consumer_offset_position
+		,COALESCE((
+			SELECT MAX(event_log_id)
+			FROM event_log
+			WHERE event_log_stream_id = event_consumer_offset_stream_id
+		), 0) AS head
+		,(
+			SELECT COUNT(*)
+			FROM event_log
+			WHERE event_log_stream_id = event_consumer_offset_stream_id
+				AND event_log_id > event_consumer_offset_position
+		) AS lag
+		,event_consumer_offset_lease_owner
+		,event_consumer_offset_lease_expires
+		,event_consumer_offset_updated
+	FROM event_consumer_offsets
+	ORDER BY event_consumer_offset_group_name, event_consumer_offset_stream_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*eventConsumerLag, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list event consumer lag query")
+	}
+
+	result := make([]stream.ConsumerLag, len(dst))
+	for i, l := range dst {
+		result[i] = stream.ConsumerLag(*l)
+	}
+
+	return result, nil
+}
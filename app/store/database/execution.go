@@ -25,6 +25,7 @@ import (
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+	"github.com/harness/gitness/types/listfilter"
 
 	"github.com/jmoiron/sqlx"
 	sqlxtypes "github.com/jmoiron/sqlx/types"
@@ -33,6 +34,18 @@ import (
 
 var _ store.ExecutionStore = (*executionStore)(nil)
 
+// executionListColumns maps the fields of execution list filters to their columns.
+var executionListColumns = listFilterColumns{
+	id: "execution_id",
+	sort: map[listfilter.Field]string{
+		types.ExecutionFieldNumber:  "execution_number",
+		types.ExecutionFieldCreated: "execution_created",
+	},
+	filter: map[listfilter.Field]string{
+		types.ExecutionFieldStatus: "execution_status",
+	},
+}
+
 // NewExecutionStore returns a new ExecutionStore.
 func NewExecutionStore(db *sqlx.DB) store.ExecutionStore {
 	return &executionStore{
@@ -77,6 +90,10 @@ type execution struct {
 	Deploy       string             `db:"execution_deploy"`
 	DeployID     int64              `db:"execution_deploy_id"`
 	Debug        bool               `db:"execution_debug"`
+	RetriedFrom  int64              `db:"execution_retried_from"`
+	QueueReason  string             `db:"execution_queue_reason"`
+	SupersededBy int64              `db:"execution_superseded_by"`
+	Timeout      int64              `db:"execution_timeout"`
 	Started      int64              `db:"execution_started"`
 	Finished     int64              `db:"execution_finished"`
 	Created      int64              `db:"execution_created"`
@@ -117,6 +134,10 @@ const (
 		,execution_deploy
 		,execution_deploy_id
 		,execution_debug
+		,execution_retried_from
+		,execution_queue_reason
+		,execution_superseded_by
+		,execution_timeout
 		,execution_started
 		,execution_finished
 		,execution_created
@@ -194,6 +215,10 @@ func (s *executionStore) Create(ctx context.Context, execution *types.Execution)
 		,execution_deploy
 		,execution_deploy_id
 		,execution_debug
+		,execution_retried_from
+		,execution_queue_reason
+		,execution_superseded_by
+		,execution_timeout
 		,execution_started
 		,execution_finished
 		,execution_created
@@ -230,6 +255,10 @@ func (s *executionStore) Create(ctx context.Context, execution *types.Execution)
 		,:execution_deploy
 		,:execution_deploy_id
 		,:execution_debug
+		,:execution_retried_from
+		,:execution_queue_reason
+		,:execution_superseded_by
+		,:execution_timeout
 		,:execution_started
 		,:execution_finished
 		,:execution_created
@@ -260,6 +289,8 @@ func (s *executionStore) Update(ctx context.Context, e *types.Execution) error {
 		,execution_event = :execution_event
 		,execution_started = :execution_started
 		,execution_finished = :execution_finished
+		,execution_superseded_by = :execution_superseded_by
+		,execution_timeout = :execution_timeout
 		,execution_updated = :execution_updated
 		,execution_version = :execution_version
 	WHERE execution_id = :execution_id AND execution_version = :execution_version - 1`
@@ -303,21 +334,56 @@ func (s *executionStore) Update(ctx context.Context, e *types.Execution) error {
 	return nil
 }
 
+// ListIncomplete returns the executions that are pending or running,
+// ordered by execution ID.
+func (s *executionStore) ListIncomplete(ctx context.Context) ([]*types.Execution, error) {
+	const queryListIncomplete = `
+	SELECT` + executionColumns + `
+	FROM executions
+	WHERE execution_status IN ('pending','running')
+	ORDER BY execution_id ASC
+	`
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*execution{}
+	if err := db.SelectContext(ctx, &dst, queryListIncomplete); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find incomplete executions")
+	}
+
+	return mapInternalToExecutionList(dst)
+}
+
+// UpdateQueueReason sets the queue reason of an execution.
+// The reason is informational only, hence the execution version is not updated.
+func (s *executionStore) UpdateQueueReason(ctx context.Context, executionID int64, reason string) error {
+	const executionUpdateQueueReasonStmt = `
+	UPDATE executions
+	SET execution_queue_reason = $1
+	WHERE execution_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, executionUpdateQueueReasonStmt, reason, executionID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update execution queue reason")
+	}
+
+	return nil
+}
+
 // List lists the executions for a given pipeline ID.
 // It orders them in descending order of execution number.
 func (s *executionStore) List(
 	ctx context.Context,
 	pipelineID int64,
-	pagination types.Pagination,
+	filter *listfilter.Filter,
 ) ([]*types.Execution, error) {
 	stmt := database.Builder.
 		Select(executionColumns).
 		From("executions").
-		Where("execution_pipeline_id = ?", fmt.Sprint(pipelineID)).
-		OrderBy("execution_number " + enum.OrderDesc.String())
+		Where("execution_pipeline_id = ?", fmt.Sprint(pipelineID))
 
-	stmt = stmt.Limit(database.Limit(pagination.Size))
-	stmt = stmt.Offset(database.Offset(pagination.Page, pagination.Size))
+	stmt = applyListFilterConditions(stmt, filter, executionListColumns)
+	stmt = applyListFilterOrder(stmt, filter, executionListColumns)
 
 	sql, args, err := stmt.ToSql()
 	if err != nil {
@@ -335,7 +401,8 @@ func (s *executionStore) List(
 }
 
 // Count of executions in a pipeline, if pipelineID is 0 then return total number of executions.
-func (s *executionStore) Count(ctx context.Context, pipelineID int64) (int64, error) {
+// The optional filter limits the count to the executions matching its conditions.
+func (s *executionStore) Count(ctx context.Context, pipelineID int64, filter *listfilter.Filter) (int64, error) {
 	stmt := database.Builder.
 		Select("count(*)").
 		From("executions")
@@ -344,6 +411,10 @@ func (s *executionStore) Count(ctx context.Context, pipelineID int64) (int64, er
 		stmt = stmt.Where("execution_pipeline_id = ?", pipelineID)
 	}
 
+	if filter != nil {
+		stmt = applyListFilterConditions(stmt, filter, executionListColumns)
+	}
+
 	sql, args, err := stmt.ToSql()
 	if err != nil {
 		return 0, errors.Wrap(err, "Failed to convert query to sql")
@@ -56,6 +56,10 @@ func mapInternalToExecution(in *execution) (*types.Execution, error) {
 		Deploy:       in.Deploy,
 		DeployID:     in.DeployID,
 		Debug:        in.Debug,
+		RetriedFrom:  in.RetriedFrom,
+		QueueReason:  in.QueueReason,
+		SupersededBy: in.SupersededBy,
+		Timeout:      in.Timeout,
 		Started:      in.Started,
 		Finished:     in.Finished,
 		Created:      in.Created,
@@ -97,6 +101,10 @@ func mapExecutionToInternal(in *types.Execution) *execution {
 		Deploy:       in.Deploy,
 		DeployID:     in.DeployID,
 		Debug:        in.Debug,
+		RetriedFrom:  in.RetriedFrom,
+		QueueReason:  in.QueueReason,
+		SupersededBy: in.SupersededBy,
+		Timeout:      in.Timeout,
 		Started:      in.Started,
 		Finished:     in.Finished,
 		Created:      in.Created,
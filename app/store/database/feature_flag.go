@@ -0,0 +1,136 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.FeatureFlagStore = (*FeatureFlagStore)(nil)
+
+// NewFeatureFlagStore returns a new FeatureFlagStore.
+func NewFeatureFlagStore(db *sqlx.DB) *FeatureFlagStore {
+	return &FeatureFlagStore{
+		db: db,
+	}
+}
+
+// FeatureFlagStore implements store.FeatureFlagStore backed by a relational database.
+type FeatureFlagStore struct {
+	db *sqlx.DB
+}
+
+const (
+	featureFlagColumns = `
+		 feature_flag_id
+		,feature_flag_name
+		,feature_flag_space_id
+		,feature_flag_enabled
+		,feature_flag_updated_by
+		,feature_flag_updated`
+
+	featureFlagInsert = `
+		INSERT INTO feature_flags (
+			 feature_flag_name
+			,feature_flag_space_id
+			,feature_flag_enabled
+			,feature_flag_updated_by
+			,feature_flag_updated
+		) values (
+			 :feature_flag_name
+			,:feature_flag_space_id
+			,:feature_flag_enabled
+			,:feature_flag_updated_by
+			,:feature_flag_updated
+		)`
+
+	featureFlagUpdateOnConflict = `
+		DO UPDATE SET
+			 feature_flag_enabled = EXCLUDED.feature_flag_enabled
+			,feature_flag_updated_by = EXCLUDED.feature_flag_updated_by
+			,feature_flag_updated = EXCLUDED.feature_flag_updated
+		RETURNING feature_flag_id`
+)
+
+// List returns all feature flag overrides.
+func (s *FeatureFlagStore) List(ctx context.Context) ([]*types.FeatureFlagOverride, error) {
+	const sqlQuery = `
+		SELECT` + featureFlagColumns + `
+		FROM feature_flags
+		ORDER BY feature_flag_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*types.FeatureFlagOverride{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list feature flags")
+	}
+
+	return dst, nil
+}
+
+// Upsert creates or updates the override of the feature flag for the instance or the space.
+func (s *FeatureFlagStore) Upsert(ctx context.Context, override *types.FeatureFlagOverride) error {
+	// the conflict targets have to match the partial unique indexes.
+	sqlQuery := featureFlagInsert + `
+		ON CONFLICT (feature_flag_name) WHERE feature_flag_space_id IS NULL` + featureFlagUpdateOnConflict
+	if override.SpaceID != nil {
+		sqlQuery = featureFlagInsert + `
+		ON CONFLICT (feature_flag_name, feature_flag_space_id) WHERE feature_flag_space_id IS NOT NULL` +
+			featureFlagUpdateOnConflict
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, override)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind feature flag object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&override.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to upsert feature flag")
+	}
+
+	return nil
+}
+
+// Delete deletes the override of the feature flag for the instance (spaceID is nil) or the space.
+func (s *FeatureFlagStore) Delete(ctx context.Context, name string, spaceID *int64) error {
+	sqlQuery := `
+		DELETE FROM feature_flags
+		WHERE feature_flag_name = $1 AND feature_flag_space_id IS NULL`
+	args := []any{name}
+	if spaceID != nil {
+		sqlQuery = `
+		DELETE FROM feature_flags
+		WHERE feature_flag_name = $1 AND feature_flag_space_id = $2`
+		args = append(args, *spaceID)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete feature flag")
+	}
+
+	return nil
+}
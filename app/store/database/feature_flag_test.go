@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeatureFlagStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+
+	principalStore, spaceStore, spacePathStore, _ := setupStores(t, db)
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+
+	flagStore := database.NewFeatureFlagStore(db)
+
+	spaceID := int64(1)
+	require.NoError(t, flagStore.Upsert(ctx, &types.FeatureFlagOverride{
+		Name: "flag", Enabled: true, UpdatedBy: userID, Updated: 1,
+	}))
+	require.NoError(t, flagStore.Upsert(ctx, &types.FeatureFlagOverride{
+		Name: "flag", SpaceID: &spaceID, Enabled: true, UpdatedBy: userID, Updated: 1,
+	}))
+
+	// the instance-wide and the space override are updated independently.
+	require.NoError(t, flagStore.Upsert(ctx, &types.FeatureFlagOverride{
+		Name: "flag", Enabled: false, UpdatedBy: userID, Updated: 2,
+	}))
+
+	overrides, err := flagStore.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, overrides, 2)
+	assert.Nil(t, overrides[0].SpaceID)
+	assert.False(t, overrides[0].Enabled)
+	assert.Equal(t, int64(2), overrides[0].Updated)
+	require.NotNil(t, overrides[1].SpaceID)
+	assert.Equal(t, spaceID, *overrides[1].SpaceID)
+	assert.True(t, overrides[1].Enabled)
+
+	require.NoError(t, flagStore.Delete(ctx, "flag", nil))
+
+	overrides, err = flagStore.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, overrides, 1)
+	assert.NotNil(t, overrides[0].SpaceID)
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.GitOperationLimitStore = (*GitOperationLimitStore)(nil)
+
+// NewGitOperationLimitStore returns a new GitOperationLimitStore.
+func NewGitOperationLimitStore(db *sqlx.DB) *GitOperationLimitStore {
+	return &GitOperationLimitStore{
+		db: db,
+	}
+}
+
+// GitOperationLimitStore implements store.GitOperationLimitStore backed by a relational database.
+type GitOperationLimitStore struct {
+	db *sqlx.DB
+}
+
+// List returns the git operation limits of all principals.
+func (s *GitOperationLimitStore) List(ctx context.Context) ([]*types.GitOperationLimit, error) {
+	const sqlQuery = `
+		SELECT
+			 git_operation_limit_principal_id
+			,git_operation_limit_max_concurrent
+			,git_operation_limit_updated_by
+			,git_operation_limit_updated
+		FROM git_operation_limits
+		ORDER BY git_operation_limit_principal_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*types.GitOperationLimit{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list git operation limits")
+	}
+
+	return dst, nil
+}
+
+// Upsert creates or updates the git operation limit of the principal.
+func (s *GitOperationLimitStore) Upsert(ctx context.Context, limit *types.GitOperationLimit) error {
+	const sqlQuery = `
+		INSERT INTO git_operation_limits (
+			 git_operation_limit_principal_id
+			,git_operation_limit_max_concurrent
+			,git_operation_limit_updated_by
+			,git_operation_limit_updated
+		) values (
+			 :git_operation_limit_principal_id
+			,:git_operation_limit_max_concurrent
+			,:git_operation_limit_updated_by
+			,:git_operation_limit_updated
+		)
+		ON CONFLICT (git_operation_limit_principal_id) DO UPDATE SET
+			 git_operation_limit_max_concurrent = EXCLUDED.git_operation_limit_max_concurrent
+			,git_operation_limit_updated_by = EXCLUDED.git_operation_limit_updated_by
+			,git_operation_limit_updated = EXCLUDED.git_operation_limit_updated`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, limit)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind git operation limit object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to upsert git operation limit")
+	}
+
+	return nil
+}
+
+// Delete deletes the git operation limit of the principal.
+func (s *GitOperationLimitStore) Delete(ctx context.Context, principalID int64) error {
+	const sqlQuery = `
+		DELETE FROM git_operation_limits
+		WHERE git_operation_limit_principal_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, principalID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete git operation limit")
+	}
+
+	return nil
+}
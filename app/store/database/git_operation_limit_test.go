@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitOperationLimitStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+
+	principalStore, _, _, _ := setupStores(t, db)
+	createUser(ctx, t, principalStore)
+
+	limitStore := database.NewGitOperationLimitStore(db)
+
+	require.NoError(t, limitStore.Upsert(ctx, &types.GitOperationLimit{
+		PrincipalID: userID, MaxConcurrent: 5, UpdatedBy: userID, Updated: 1,
+	}))
+	require.NoError(t, limitStore.Upsert(ctx, &types.GitOperationLimit{
+		PrincipalID: userID, MaxConcurrent: 10, UpdatedBy: userID, Updated: 2,
+	}))
+
+	limits, err := limitStore.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, limits, 1)
+	assert.Equal(t, userID, limits[0].PrincipalID)
+	assert.Equal(t, 10, limits[0].MaxConcurrent)
+	assert.Equal(t, int64(2), limits[0].Updated)
+
+	require.NoError(t, limitStore.Delete(ctx, userID))
+
+	limits, err = limitStore.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, limits)
+}
@@ -0,0 +1,194 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.IdempotencyKeyStore = (*IdempotencyKeyStore)(nil)
+
+// NewIdempotencyKeyStore returns a new IdempotencyKeyStore.
+func NewIdempotencyKeyStore(db *sqlx.DB) *IdempotencyKeyStore {
+	return &IdempotencyKeyStore{
+		db: db,
+	}
+}
+
+// IdempotencyKeyStore implements store.IdempotencyKeyStore backed by a relational database.
+type IdempotencyKeyStore struct {
+	db *sqlx.DB
+}
+
+const (
+	idempotencyKeyColumns = `
+		 idempotency_key_principal_id
+		,idempotency_key_key
+		,idempotency_key_fingerprint
+		,idempotency_key_response_status
+		,idempotency_key_response_content_type
+		,idempotency_key_response_body
+		,idempotency_key_created
+		,idempotency_key_expires`
+)
+
+// Create reserves the key for the principal. Keys that expired before now are replaced.
+// The primary key of the table decides which of two concurrent requests with the same key wins,
+// the other one gets store.ErrDuplicate.
+func (s *IdempotencyKeyStore) Create(ctx context.Context, key *types.IdempotencyKey, now int64) error {
+	const sqlQuery = `
+		INSERT INTO idempotency_keys (` + idempotencyKeyColumns + `
+		) values ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (idempotency_key_principal_id, idempotency_key_key) DO
+		UPDATE SET
+			 idempotency_key_fingerprint = EXCLUDED.idempotency_key_fingerprint
+			,idempotency_key_response_status = EXCLUDED.idempotency_key_response_status
+			,idempotency_key_response_content_type = EXCLUDED.idempotency_key_response_content_type
+			,idempotency_key_response_body = EXCLUDED.idempotency_key_response_body
+			,idempotency_key_created = EXCLUDED.idempotency_key_created
+			,idempotency_key_expires = EXCLUDED.idempotency_key_expires
+		WHERE idempotency_keys.idempotency_key_expires <= $9`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery,
+		key.PrincipalID,
+		key.Key,
+		key.Fingerprint,
+		key.ResponseStatus,
+		key.ResponseContentType,
+		key.ResponseBody,
+		key.Created,
+		key.Expires,
+		now,
+	)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert idempotency key")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of inserted idempotency keys")
+	}
+
+	if n == 0 {
+		return gitness_store.ErrDuplicate
+	}
+
+	return nil
+}
+
+// Find finds the key of the principal.
+func (s *IdempotencyKeyStore) Find(
+	ctx context.Context,
+	principalID int64,
+	key string,
+) (*types.IdempotencyKey, error) {
+	const sqlQuery = `
+		SELECT` + idempotencyKeyColumns + `
+		FROM idempotency_keys
+		WHERE idempotency_key_principal_id = $1 AND idempotency_key_key = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &types.IdempotencyKey{}
+	if err := db.GetContext(ctx, dst, sqlQuery, principalID, key); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find idempotency key")
+	}
+
+	return dst, nil
+}
+
+// Complete stores the response with the key and extends the key's expiry.
+func (s *IdempotencyKeyStore) Complete(ctx context.Context, key *types.IdempotencyKey) error {
+	const sqlQuery = `
+		UPDATE idempotency_keys
+		SET
+			 idempotency_key_response_status = $1
+			,idempotency_key_response_content_type = $2
+			,idempotency_key_response_body = $3
+			,idempotency_key_expires = $4
+		WHERE idempotency_key_principal_id = $5 AND idempotency_key_key = $6`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery,
+		key.ResponseStatus,
+		key.ResponseContentType,
+		key.ResponseBody,
+		key.Expires,
+		key.PrincipalID,
+		key.Key,
+	)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update idempotency key")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated idempotency keys")
+	}
+
+	if n == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// DeletePending deletes the key of the principal unless a response is stored with it.
+func (s *IdempotencyKeyStore) DeletePending(ctx context.Context, principalID int64, key string) error {
+	const sqlQuery = `
+		DELETE FROM idempotency_keys
+		WHERE idempotency_key_principal_id = $1 AND idempotency_key_key = $2
+			AND idempotency_key_response_status = 0`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, principalID, key); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete pending idempotency key")
+	}
+
+	return nil
+}
+
+// Purge deletes all keys that expired before the provided time.
+func (s *IdempotencyKeyStore) Purge(ctx context.Context, before int64) (int64, error) {
+	const sqlQuery = `
+		DELETE FROM idempotency_keys
+		WHERE idempotency_key_expires <= $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, before)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to purge idempotency keys")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to get number of purged idempotency keys")
+	}
+
+	return n, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyKeyStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+
+	principalStore, _, _, _ := setupStores(t, db)
+	createUser(ctx, t, principalStore)
+
+	keyStore := database.NewIdempotencyKeyStore(db)
+
+	key := &types.IdempotencyKey{
+		PrincipalID: userID,
+		Key:         "key",
+		Fingerprint: "fp1",
+		Created:     100,
+		Expires:     200,
+	}
+	require.NoError(t, keyStore.Create(ctx, key, 100))
+
+	// the key is reserved until it expires.
+	other := *key
+	other.Fingerprint = "fp2"
+	err := keyStore.Create(ctx, &other, 150)
+	require.ErrorIs(t, err, gitness_store.ErrDuplicate)
+
+	// pending keys can be deleted.
+	require.NoError(t, keyStore.DeletePending(ctx, userID, "key"))
+	_, err = keyStore.Find(ctx, userID, "key")
+	require.ErrorIs(t, err, gitness_store.ErrResourceNotFound)
+
+	require.NoError(t, keyStore.Create(ctx, key, 100))
+
+	completed := *key
+	completed.ResponseStatus = 201
+	completed.ResponseContentType = "application/json"
+	completed.ResponseBody = []byte(`{"id":1}`)
+	completed.Expires = 1000
+	require.NoError(t, keyStore.Complete(ctx, &completed))
+
+	found, err := keyStore.Find(ctx, userID, "key")
+	require.NoError(t, err)
+	assert.Equal(t, &completed, found)
+	assert.True(t, found.IsCompleted())
+
+	// completed keys are kept.
+	require.NoError(t, keyStore.DeletePending(ctx, userID, "key"))
+	_, err = keyStore.Find(ctx, userID, "key")
+	require.NoError(t, err)
+
+	// expired keys are replaced.
+	other.Created = 1000
+	other.Expires = 2000
+	require.NoError(t, keyStore.Create(ctx, &other, 1000))
+
+	found, err = keyStore.Find(ctx, userID, "key")
+	require.NoError(t, err)
+	assert.Equal(t, "fp2", found.Fingerprint)
+	assert.False(t, found.IsCompleted())
+
+	n, err := keyStore.Purge(ctx, 2000)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+}
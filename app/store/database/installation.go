@@ -0,0 +1,115 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.InstallationStore = (*InstallationStore)(nil)
+
+// NewInstallationStore returns a new InstallationStore.
+func NewInstallationStore(db *sqlx.DB) *InstallationStore {
+	return &InstallationStore{
+		db: db,
+	}
+}
+
+// InstallationStore implements store.InstallationStore backed by a relational database.
+type InstallationStore struct {
+	db *sqlx.DB
+}
+
+// installationID is the id of the only row of the installation table.
+const installationID = 1
+
+// Find returns the installation record.
+func (s *InstallationStore) Find(ctx context.Context) (*types.Installation, error) {
+	const sqlQuery = `
+		SELECT
+			 installation_uuid
+			,installation_installed
+			,installation_installed_by
+			,installation_installer_email
+		FROM installation
+		WHERE installation_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &types.Installation{}
+	if err := db.GetContext(ctx, dst, sqlQuery, installationID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find installation")
+	}
+
+	return dst, nil
+}
+
+// Create creates the installation record.
+func (s *InstallationStore) Create(ctx context.Context, installation *types.Installation) error {
+	const sqlQuery = `
+		INSERT INTO installation (
+			 installation_id
+			,installation_uuid
+			,installation_installed
+			,installation_installed_by
+			,installation_installer_email
+		) VALUES (
+			 $1
+			,$2
+			,$3
+			,$4
+			,$5
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	_, err := db.ExecContext(ctx, sqlQuery,
+		installationID,
+		installation.UUID,
+		installation.Installed,
+		installation.InstalledBy,
+		installation.InstallerEmail,
+	)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert installation")
+	}
+
+	return nil
+}
+
+// SetInstaller sets the installer of the instance, unless it is known already.
+func (s *InstallationStore) SetInstaller(ctx context.Context, principalID int64, email string) error {
+	const sqlQuery = `
+		UPDATE installation
+		SET
+			 installation_installed_by = $1
+			,installation_installer_email = $2
+		WHERE installation_id = $3 AND installation_installed_by IS NULL`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, principalID, email, installationID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to set installer")
+	}
+
+	return nil
+}
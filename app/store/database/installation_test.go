@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstallationStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+
+	installationStore := database.NewInstallationStore(db)
+
+	// no users exist, so the migration doesn't backfill the record.
+	_, err := installationStore.Find(ctx)
+	require.ErrorIs(t, err, gitness_store.ErrResourceNotFound)
+
+	installation := &types.Installation{
+		UUID:      "5c8a3e0e-6f5e-4c4b-9d8e-1e0f2a3b4c5d",
+		Installed: 100,
+	}
+	require.NoError(t, installationStore.Create(ctx, installation))
+
+	// there is only ever one installation record.
+	other := *installation
+	other.UUID = "other"
+	err = installationStore.Create(ctx, &other)
+	require.ErrorIs(t, err, gitness_store.ErrDuplicate)
+
+	require.NoError(t, installationStore.SetInstaller(ctx, 1, "admin@example.com"))
+
+	// the installer is only set once.
+	require.NoError(t, installationStore.SetInstaller(ctx, 2, "user@example.com"))
+
+	found, err := installationStore.Find(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, installation.UUID, found.UUID)
+	assert.Equal(t, int64(100), found.Installed)
+	require.NotNil(t, found.InstalledBy)
+	assert.Equal(t, int64(1), *found.InstalledBy)
+	assert.Equal(t, "admin@example.com", found.InstallerEmail)
+}
@@ -52,6 +52,7 @@ type label struct {
 	RepoID      null.Int        `db:"label_repo_id"`
 	Scope       int64           `db:"label_scope"`
 	Key         string          `db:"label_key"`
+	KeySortKey  string          `db:"label_key_sort_key"`
 	Description string          `db:"label_description"`
 	Type        enum.LabelType  `db:"label_type"`
 	Color       enum.LabelColor `db:"label_color"`
@@ -88,7 +89,9 @@ var _ store.LabelStore = (*labelStore)(nil)
 
 func (s *labelStore) Define(ctx context.Context, lbl *types.Label) error {
 	const sqlQuery = `
-		INSERT INTO labels (` + labelColumns + `)` + `
+		INSERT INTO labels (` + labelColumns + `
+			,label_key_sort_key
+		)` + `
 		values (
 			:label_space_id
 			,:label_repo_id
@@ -100,7 +103,8 @@ func (s *labelStore) Define(ctx context.Context, lbl *types.Label) error {
 			,:label_created
 			,:label_updated 
 			,:label_created_by
-			,:label_updated_by 
+			,:label_updated_by
+			,:label_key_sort_key
 		) 
 		RETURNING label_id`
 
@@ -121,6 +125,7 @@ func (s *labelStore) Update(ctx context.Context, lbl *types.Label) error {
 	const sqlQuery = `
 		UPDATE labels SET
 			 label_key = :label_key
+			,label_key_sort_key = :label_key_sort_key
 			,label_description = :label_description
 			,label_type = :label_type
 			,label_color = :label_color
@@ -218,7 +223,8 @@ func (s *labelStore) List(
 	stmt := database.Builder.
 		Select(`label_id, ` + labelColumns + `, label_value_count`).
 		From("labels").
-		OrderBy("label_key")
+		OrderBy("label_key_sort_key").
+		OrderBy("label_id")
 
 	stmt = stmt.Where("(label_space_id = ? OR label_repo_id = ?)", spaceID, repoID)
 	stmt = stmt.Limit(database.Limit(filter.Size))
@@ -257,8 +263,9 @@ func (s *labelStore) ListInScopes(
 		squirrel.Eq{"label_space_id": spaceIDs},
 		squirrel.Eq{"label_repo_id": repoID},
 	}).
-		OrderBy("label_key").
-		OrderBy("label_scope")
+		OrderBy("label_key_sort_key").
+		OrderBy("label_scope").
+		OrderBy("label_id")
 
 	stmt = stmt.Limit(database.Limit(filter.Size))
 	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
@@ -302,8 +309,9 @@ func (s *labelStore) ListInfosInScopes(
 			squirrel.Eq{"label_space_id": spaceIDs},
 			squirrel.Eq{"label_repo_id": repoID},
 		}).
-		OrderBy("label_key").
-		OrderBy("label_scope")
+		OrderBy("label_key_sort_key").
+		OrderBy("label_scope").
+		OrderBy("label_id")
 
 	stmt = stmt.Limit(database.Limit(filter.Size))
 	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
@@ -429,6 +437,7 @@ func mapInternalLabel(lbl *types.Label) *label {
 		RepoID:      null.IntFromPtr(lbl.RepoID),
 		Scope:       lbl.Scope,
 		Key:         lbl.Key,
+		KeySortKey:  database.SortKey(lbl.Key),
 		Description: lbl.Description,
 		Type:        lbl.Type,
 		Color:       lbl.Color,
@@ -0,0 +1,114 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/types/listfilter"
+
+	"github.com/Masterminds/squirrel"
+)
+
+// listFilterColumns maps the fields of a list filter to the columns of a table.
+// Fields without a column are ignored, so only columns defined by the store end up in queries.
+type listFilterColumns struct {
+	id string
+
+	// sort maps the sort fields to the columns used for ordering.
+	sort map[listfilter.Field]string
+
+	// sortPrefix contains fixed order terms that are placed before the column of a sort field.
+	sortPrefix map[listfilter.Field]string
+
+	// filter maps the search and enum fields to the columns they are matched against.
+	filter map[listfilter.Field]string
+}
+
+// applyListFilterConditions adds the search and the enum conditions of the list filter to the query.
+func applyListFilterConditions(
+	stmt squirrel.SelectBuilder,
+	filter *listfilter.Filter,
+	columns listFilterColumns,
+) squirrel.SelectBuilder {
+	if filter.Query != "" {
+		search := squirrel.Or{}
+		for _, field := range filter.Search {
+			column, ok := columns.filter[field]
+			if !ok {
+				continue
+			}
+
+			search = append(search, squirrel.Expr("LOWER("+column+") LIKE ?", fmt.Sprintf("%%%s%%", filter.Query)))
+		}
+
+		if len(search) > 0 {
+			stmt = stmt.Where(search)
+		}
+	}
+
+	// the fields are sorted to generate the same query for the same filter.
+	fields := make([]listfilter.Field, 0, len(filter.Enums))
+	for field := range filter.Enums {
+		fields = append(fields, field)
+	}
+	slices.Sort(fields)
+
+	for _, field := range fields {
+		column, ok := columns.filter[field]
+		if !ok {
+			continue
+		}
+
+		stmt = stmt.Where(squirrel.Eq{column: filter.Enums[field]})
+	}
+
+	return stmt
+}
+
+// applyListFilterOrder adds the order, ending with the ID tiebreaker, and the pagination
+// of the list filter to the query.
+func applyListFilterOrder(
+	stmt squirrel.SelectBuilder,
+	filter *listfilter.Filter,
+	columns listFilterColumns,
+) squirrel.SelectBuilder {
+	if !filter.Unpaginated {
+		stmt = stmt.Limit(database.Limit(filter.Size))
+		stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+	}
+
+	for _, term := range filter.OrderBy() {
+		column, ok := columns.sort[term.Field]
+		if term.Field == listfilter.FieldID {
+			column, ok = columns.id, true
+		}
+		if !ok {
+			continue
+		}
+
+		if prefix, hasPrefix := columns.sortPrefix[term.Field]; hasPrefix {
+			stmt = stmt.OrderBy(prefix)
+		}
+
+		// NOTE: string concatenation is safe because the column is defined by the store
+		// and the order is an enum, neither is subject to injection attacks.
+		stmt = stmt.OrderBy(column + " " + term.Order.String())
+	}
+
+	return stmt
+}
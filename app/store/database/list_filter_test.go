@@ -0,0 +1,178 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+	"github.com/harness/gitness/types/listfilter"
+
+	"github.com/Masterminds/squirrel"
+)
+
+// legacyRepoSortFilter is the repository sorting as implemented before the list filters were introduced.
+func legacyRepoSortFilter(stmt squirrel.SelectBuilder, filter *types.RepoFilter) squirrel.SelectBuilder {
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	switch filter.Sort {
+	case enum.RepoAttrUID, enum.RepoAttrIdentifier, enum.RepoAttrNone:
+		stmt = stmt.OrderBy("repo_state desc, repo_uid_sort_key " + filter.Order.String())
+	case enum.RepoAttrCreated:
+		stmt = stmt.OrderBy("repo_created " + filter.Order.String())
+	case enum.RepoAttrUpdated:
+		stmt = stmt.OrderBy("repo_updated " + filter.Order.String())
+	case enum.RepoAttrDeleted:
+		stmt = stmt.OrderBy("repo_deleted " + filter.Order.String())
+	case enum.RepoAttrLastActivity:
+		stmt = stmt.OrderBy("repo_last_activity " + filter.Order.String())
+	case enum.RepoAttrStars:
+		stmt = stmt.OrderBy("repo_num_stars " + filter.Order.String())
+	}
+
+	return stmt.OrderBy("repo_id " + filter.Order.String())
+}
+
+func toSQL(t *testing.T, stmt squirrel.SelectBuilder) (string, []any) {
+	t.Helper()
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		t.Fatalf("failed to convert query to sql: %v", err)
+	}
+
+	return sql, args
+}
+
+func TestApplySortFilter_MatchesLegacyRepoOrder(t *testing.T) {
+	attrs := []enum.RepoAttr{
+		enum.RepoAttrNone, enum.RepoAttrUID, enum.RepoAttrIdentifier, enum.RepoAttrCreated,
+		enum.RepoAttrUpdated, enum.RepoAttrDeleted, enum.RepoAttrLastActivity, enum.RepoAttrStars,
+	}
+	orders := []enum.Order{enum.OrderDefault, enum.OrderAsc, enum.OrderDesc}
+	pages := []types.Pagination{{}, {Page: 1, Size: 10}, {Page: 3, Size: 25}}
+
+	for _, attr := range attrs {
+		for _, order := range orders {
+			for _, page := range pages {
+				filter := &types.RepoFilter{Sort: attr, Order: order, Page: page.Page, Size: page.Size}
+				stmt := squirrel.Select("repo_id").From("repositories")
+
+				wantSQL, wantArgs := toSQL(t, legacyRepoSortFilter(stmt, filter))
+				gotSQL, gotArgs := toSQL(t, applySortFilter(stmt, filter))
+
+				if gotSQL != wantSQL || fmt.Sprint(gotArgs) != fmt.Sprint(wantArgs) {
+					t.Errorf("sort=%q order=%s page=%v: got %q %v, want %q %v",
+						attr, order, page, gotSQL, gotArgs, wantSQL, wantArgs)
+				}
+			}
+		}
+	}
+}
+
+func TestApplyQueryFilter_RepoSearch(t *testing.T) {
+	stmt := squirrel.Select("repo_id").From("repositories")
+
+	sql, args := toSQL(t, applyQueryFilter(stmt, &types.RepoFilter{Query: "My-Repo"}))
+
+	if !strings.Contains(sql, "WHERE (LOWER(repo_uid) LIKE ?) AND repo_deleted IS NULL") {
+		t.Errorf("unexpected query: %q", sql)
+	}
+	if len(args) != 1 || args[0] != "%my-repo%" {
+		t.Errorf("unexpected args: %v", args)
+	}
+}
+
+func TestApplyListFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   *listfilter.Filter
+		columns  listFilterColumns
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			name: "execution default",
+			filter: &listfilter.Filter{
+				Page: 2, Size: 30, Sort: types.ExecutionFieldNumber, Order: enum.OrderDesc,
+			},
+			columns: executionListColumns,
+			wantSQL: "SELECT * FROM t ORDER BY execution_number desc, execution_id desc LIMIT 30 OFFSET 30",
+		},
+		{
+			name: "execution status",
+			filter: &listfilter.Filter{
+				Page: 1, Size: 10, Sort: types.ExecutionFieldCreated, Order: enum.OrderAsc,
+				Enums: map[listfilter.Field][]string{
+					types.ExecutionFieldStatus: {string(enum.CIStatusRunning), string(enum.CIStatusPending)},
+				},
+			},
+			columns: executionListColumns,
+			wantSQL: "SELECT * FROM t WHERE execution_status IN (?,?) " +
+				"ORDER BY execution_created asc, execution_id asc LIMIT 10 OFFSET 0",
+			wantArgs: []any{string(enum.CIStatusRunning), string(enum.CIStatusPending)},
+		},
+		{
+			name: "service accounts unpaginated",
+			filter: &listfilter.Filter{
+				Unpaginated: true, Sort: types.ServiceAccountFieldUID, Order: enum.OrderAsc,
+			},
+			columns: serviceAccountListColumns,
+			wantSQL: "SELECT * FROM t ORDER BY principal_uid_sort_key asc, principal_id asc",
+		},
+		{
+			name: "service accounts search",
+			filter: &listfilter.Filter{
+				Unpaginated: true, Sort: types.ServiceAccountFieldCreated, Order: enum.OrderDesc, Query: "bot",
+				Search: []listfilter.Field{types.ServiceAccountFieldUID, types.ServiceAccountFieldDisplayName},
+			},
+			columns: serviceAccountListColumns,
+			wantSQL: "SELECT * FROM t WHERE (LOWER(principal_uid) LIKE ? OR LOWER(principal_display_name) LIKE ?) " +
+				"ORDER BY principal_created desc, principal_id desc",
+			wantArgs: []any{"%bot%", "%bot%"},
+		},
+		{
+			name: "unmapped fields are ignored",
+			filter: &listfilter.Filter{
+				Unpaginated: true, Sort: "principal_id; DROP TABLE principals", Order: enum.OrderAsc, Query: "x",
+				Search: []listfilter.Field{"unknown"},
+				Enums:  map[listfilter.Field][]string{"unknown": {"a"}},
+			},
+			columns: serviceAccountListColumns,
+			wantSQL: "SELECT * FROM t ORDER BY principal_id asc",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stmt := squirrel.Select("*").From("t")
+			stmt = applyListFilterConditions(stmt, test.filter, test.columns)
+			stmt = applyListFilterOrder(stmt, test.filter, test.columns)
+
+			sql, args := toSQL(t, stmt)
+			if sql != test.wantSQL {
+				t.Errorf("got sql %q, want %q", sql, test.wantSQL)
+			}
+			if fmt.Sprint(args) != fmt.Sprint(test.wantArgs) {
+				t.Errorf("got args %v, want %v", args, test.wantArgs)
+			}
+		})
+	}
+}
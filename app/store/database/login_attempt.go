@@ -0,0 +1,206 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.LoginAttemptStore = (*LoginAttemptStore)(nil)
+
+// NewLoginAttemptStore returns a new LoginAttemptStore.
+func NewLoginAttemptStore(db *sqlx.DB) *LoginAttemptStore {
+	return &LoginAttemptStore{
+		db: db,
+	}
+}
+
+// LoginAttemptStore implements store.LoginAttemptStore backed by a relational database.
+type LoginAttemptStore struct {
+	db *sqlx.DB
+}
+
+// RecordFailure stores a failed login for the key.
+func (s *LoginAttemptStore) RecordFailure(ctx context.Context, key string, created int64) error {
+	const sqlQuery = `
+	INSERT INTO login_failures (
+		 login_failure_key
+		,login_failure_created
+	) VALUES ($1, $2)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, key, created); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert login failure")
+	}
+
+	return nil
+}
+
+// CountFailures returns the number of failed logins for the key since the provided time.
+func (s *LoginAttemptStore) CountFailures(ctx context.Context, key string, since int64) (int64, error) {
+	const sqlQuery = `
+	SELECT count(*)
+	FROM login_failures
+	WHERE login_failure_key = $1 AND login_failure_created > $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err := db.QueryRowContext(ctx, sqlQuery, key, since).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing count login failures query")
+	}
+
+	return count, nil
+}
+
+// DeleteFailures deletes all failed logins of the keys.
+func (s *LoginAttemptStore) DeleteFailures(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	stmt := database.Builder.
+		Delete("login_failures").
+		Where(squirrel.Eq{"login_failure_key": keys})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert delete login failures query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete login failures")
+	}
+
+	return nil
+}
+
+// PurgeFailures deletes all failed logins older than the provided time.
+func (s *LoginAttemptStore) PurgeFailures(ctx context.Context, before int64) (int64, error) {
+	const sqlQuery = `
+	DELETE FROM login_failures
+	WHERE login_failure_created <= $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, before)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to purge login failures")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to get number of purged login failures")
+	}
+
+	return n, nil
+}
+
+// Lock locks the key until the provided time. An existing lockout of the key is replaced.
+func (s *LoginAttemptStore) Lock(ctx context.Context, key string, until int64) error {
+	const sqlQuery = `
+	INSERT INTO login_lockouts (
+		 login_lockout_key
+		,login_lockout_until
+		,login_lockout_created
+	) VALUES ($1, $2, $3)
+	ON CONFLICT (login_lockout_key) DO UPDATE
+	SET
+		 login_lockout_until = EXCLUDED.login_lockout_until
+		,login_lockout_created = EXCLUDED.login_lockout_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, key, until, time.Now().UnixMilli()); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to upsert login lockout")
+	}
+
+	return nil
+}
+
+// LockedUntil returns the latest time any of the keys is locked until, or 0 if none is locked.
+func (s *LoginAttemptStore) LockedUntil(ctx context.Context, now int64, keys ...string) (int64, error) {
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	stmt := database.Builder.
+		Select("COALESCE(MAX(login_lockout_until), 0)").
+		From("login_lockouts").
+		Where(squirrel.Eq{"login_lockout_key": keys}).
+		Where("login_lockout_until > ?", now)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert find login lockout query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var until int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&until); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing find login lockout query")
+	}
+
+	return until, nil
+}
+
+// Unlock removes the lockout of the key, if any.
+func (s *LoginAttemptStore) Unlock(ctx context.Context, key string) error {
+	const sqlQuery = `
+	DELETE FROM login_lockouts
+	WHERE login_lockout_key = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, key); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete login lockout")
+	}
+
+	return nil
+}
+
+// PurgeLockouts deletes all lockouts that ended before the provided time.
+func (s *LoginAttemptStore) PurgeLockouts(ctx context.Context, before int64) (int64, error) {
+	const sqlQuery = `
+	DELETE FROM login_lockouts
+	WHERE login_lockout_until <= $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, before)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to purge login lockouts")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to get number of purged login lockouts")
+	}
+
+	return n, nil
+}
@@ -0,0 +1,200 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.MailFailureStore = (*MailFailureStore)(nil)
+
+// NewMailFailureStore returns a new MailFailureStore.
+func NewMailFailureStore(db *sqlx.DB) *MailFailureStore {
+	return &MailFailureStore{
+		db: db,
+	}
+}
+
+// MailFailureStore implements store.MailFailureStore backed by a relational database.
+type MailFailureStore struct {
+	db *sqlx.DB
+}
+
+const (
+	mailFailureColumns = `
+		 mail_failure_id
+		,mail_failure_job_uid
+		,mail_failure_recipient
+		,mail_failure_subject
+		,mail_failure_error
+		,mail_failure_attempts
+		,mail_failure_created
+		,mail_failure_updated`
+)
+
+type mailFailure struct {
+	ID        int64  `db:"mail_failure_id"`
+	JobUID    string `db:"mail_failure_job_uid"`
+	Recipient string `db:"mail_failure_recipient"`
+	Subject   string `db:"mail_failure_subject"`
+	Error     string `db:"mail_failure_error"`
+	Attempts  int    `db:"mail_failure_attempts"`
+	Created   int64  `db:"mail_failure_created"`
+	Updated   int64  `db:"mail_failure_updated"`
+}
+
+// Record stores a failed delivery attempt. Repeated failures of the same job
+// update the existing record and increase its number of attempts.
+func (s *MailFailureStore) Record(ctx context.Context, failure *types.MailFailure) error {
+	const sqlQuery = `
+	INSERT INTO mail_failures (
+		 mail_failure_job_uid
+		,mail_failure_recipient
+		,mail_failure_subject
+		,mail_failure_error
+		,mail_failure_attempts
+		,mail_failure_created
+		,mail_failure_updated
+	) VALUES (
+		 :mail_failure_job_uid
+		,:mail_failure_recipient
+		,:mail_failure_subject
+		,:mail_failure_error
+		,1
+		,:mail_failure_created
+		,:mail_failure_updated
+	)
+	ON CONFLICT (mail_failure_job_uid) DO UPDATE
+	SET
+		 mail_failure_error = EXCLUDED.mail_failure_error
+		,mail_failure_attempts = mail_failures.mail_failure_attempts + 1
+		,mail_failure_updated = EXCLUDED.mail_failure_updated
+	RETURNING mail_failure_id, mail_failure_attempts`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalMailFailure(failure))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind mail failure object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&failure.ID, &failure.Attempts); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Upsert mail failure query failed")
+	}
+
+	return nil
+}
+
+// Count returns the number of mail failures matching the filter.
+func (s *MailFailureStore) Count(ctx context.Context, filter *types.MailFailureFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("mail_failures")
+
+	stmt = applyMailFailureFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert count mail failures query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing count mail failures query")
+	}
+
+	return count, nil
+}
+
+// List returns the mail failures matching the filter, most recently updated first.
+func (s *MailFailureStore) List(ctx context.Context, filter *types.MailFailureFilter) ([]*types.MailFailure, error) {
+	stmt := database.Builder.
+		Select(mailFailureColumns).
+		From("mail_failures")
+
+	stmt = applyMailFailureFilter(stmt, filter)
+
+	stmt = stmt.
+		Limit(database.Limit(filter.Size)).
+		Offset(database.Offset(filter.Page, filter.Size)).
+		OrderBy("mail_failure_updated DESC, mail_failure_id DESC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list mail failures query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*mailFailure, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list mail failures query")
+	}
+
+	result := make([]*types.MailFailure, len(dst))
+	for i, f := range dst {
+		result[i] = mapMailFailure(f)
+	}
+
+	return result, nil
+}
+
+func applyMailFailureFilter(
+	stmt squirrel.SelectBuilder,
+	filter *types.MailFailureFilter,
+) squirrel.SelectBuilder {
+	if filter.Recipient != "" {
+		stmt = stmt.Where("LOWER(mail_failure_recipient) = LOWER(?)", filter.Recipient)
+	}
+
+	return stmt
+}
+
+func mapInternalMailFailure(f *types.MailFailure) *mailFailure {
+	return &mailFailure{
+		ID:        f.ID,
+		JobUID:    f.JobUID,
+		Recipient: f.Recipient,
+		Subject:   f.Subject,
+		Error:     f.Error,
+		Attempts:  f.Attempts,
+		Created:   f.Created,
+		Updated:   f.Updated,
+	}
+}
+
+func mapMailFailure(f *mailFailure) *types.MailFailure {
+	return &types.MailFailure{
+		ID:        f.ID,
+		JobUID:    f.JobUID,
+		Recipient: f.Recipient,
+		Subject:   f.Subject,
+		Error:     f.Error,
+		Attempts:  f.Attempts,
+		Created:   f.Created,
+		Updated:   f.Updated,
+	}
+}
@@ -251,11 +251,13 @@ func (s *MembershipStore) ListUsers(ctx context.Context,
 
 	switch filter.Sort {
 	case enum.MembershipUserSortName:
-		stmt = stmt.OrderBy("principal_display_name " + order.String())
+		stmt = stmt.OrderBy("principal_display_name_sort_key " + order.String())
 	case enum.MembershipUserSortCreated:
 		stmt = stmt.OrderBy("membership_created " + order.String())
 	}
 
+	stmt = stmt.OrderBy("principal_id " + order.String())
+
 	sql, args, err := stmt.ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert membership users list query to sql: %w", err)
@@ -341,11 +343,13 @@ func (s *MembershipStore) ListSpaces(ctx context.Context,
 	switch filter.Sort {
 	// TODO [CODE-1363]: remove after identifier migration.
 	case enum.MembershipSpaceSortUID, enum.MembershipSpaceSortIdentifier:
-		stmt = stmt.OrderBy("space_uid " + order.String())
+		stmt = stmt.OrderBy("space_uid_sort_key " + order.String())
 	case enum.MembershipSpaceSortCreated:
 		stmt = stmt.OrderBy("membership_created " + order.String())
 	}
 
+	stmt = stmt.OrderBy("space_id " + order.String())
+
 	sql, args, err := stmt.ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert membership spaces list query to sql: %w", err)
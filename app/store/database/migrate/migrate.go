@@ -18,8 +18,14 @@ import (
 	"context"
 	"database/sql"
 	"embed"
+	"errors"
 	"fmt"
+	"io"
 	"io/fs"
+	"sort"
+	"strings"
+
+	"github.com/harness/gitness/types"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/maragudk/migrate"
@@ -40,10 +46,29 @@ const (
 
 	sqliteDriverName = "sqlite3"
 	sqliteSourceDir  = "sqlite"
+
+	upSuffix = ".up.sql"
 )
 
+// ErrSchemaTooNew is returned if the database schema was migrated by a newer version of the binary.
+var ErrSchemaTooNew = errors.New("database schema is newer than supported by this binary")
+
+// migrationsAfter contains the data migrations implemented in code, keyed by the version they run after.
+var migrationsAfter = map[string]func(ctx context.Context, dbtx *sql.Tx) error{
+	"0039_alter_table_webhooks_uid":        migrateAfter_0039_alter_table_webhooks_uid,
+	"0042_alter_table_rules":               migrateAfter_0042_alter_table_rules,
+	"0072_alter_tables_add_uid_sort_key":   migrateAfter_0072_alter_tables_add_uid_sort_key,
+	"0106_create_table_installation":       migrateAfter_0106_create_table_installation,
+	"0110_alter_tables_add_name_sort_keys": migrateAfter_0110_alter_tables_add_name_sort_keys,
+}
+
 // Migrate performs the database migration.
+// It refuses to run if the database schema is newer than the latest migration known by the binary.
 func Migrate(ctx context.Context, db *sqlx.DB) error {
+	if err := CheckCompatible(ctx, db); err != nil {
+		return err
+	}
+
 	opts, err := getMigrator(db)
 	if err != nil {
 		return fmt.Errorf("failed to get migrator: %w", err)
@@ -51,6 +76,139 @@ func Migrate(ctx context.Context, db *sqlx.DB) error {
 	return migrate.New(opts).MigrateUp(ctx)
 }
 
+// CheckCompatible returns ErrSchemaTooNew if the binary can't run against the current database schema.
+func CheckCompatible(ctx context.Context, db *sqlx.DB) error {
+	status, err := Status(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	return checkStatus(status)
+}
+
+func checkStatus(status *types.MigrationStatus) error {
+	if !status.Compatible {
+		return fmt.Errorf("%w: database is at version %q, latest version known by the binary is %q; "+
+			"upgrade to a newer release to use this database",
+			ErrSchemaTooNew, status.CurrentVersion, status.LatestVersion)
+	}
+
+	return nil
+}
+
+// Status returns the migration status of the database.
+func Status(ctx context.Context, db *sqlx.DB) (*types.MigrationStatus, error) {
+	fsys, err := getFS(db.DriverName())
+	if err != nil {
+		return nil, err
+	}
+
+	all, err := versions(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := Current(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	return getStatus(db.DriverName(), current, all), nil
+}
+
+// DryRun writes the SQL of all pending migrations to w without applying any of them.
+func DryRun(ctx context.Context, db *sqlx.DB, w io.Writer) error {
+	status, err := Status(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	if err = checkStatus(status); err != nil {
+		return err
+	}
+
+	fsys, err := getFS(db.DriverName())
+	if err != nil {
+		return err
+	}
+
+	if len(status.Pending) == 0 {
+		_, err = fmt.Fprintf(w, "-- database is up to date (version %s)\n", status.CurrentVersion)
+		return err
+	}
+
+	for _, version := range status.Pending {
+		data, err := fs.ReadFile(fsys, version+upSuffix)
+		if err != nil {
+			return fmt.Errorf("failed to read migration %q: %w", version, err)
+		}
+
+		if _, err = fmt.Fprintf(w, "-- migration: %s\n%s\n", version, strings.TrimSpace(string(data))); err != nil {
+			return err
+		}
+
+		if _, ok := migrationsAfter[version]; ok {
+			_, err = fmt.Fprintf(w, "-- migration %s is followed by a data migration implemented in code\n", version)
+			if err != nil {
+				return err
+			}
+		}
+
+		if _, err = fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getStatus computes the migration status from the current database version
+// and the sorted list of all versions known by the binary.
+func getStatus(driver string, current string, all []string) *types.MigrationStatus {
+	status := &types.MigrationStatus{
+		Driver:         driver,
+		CurrentVersion: current,
+		Pending:        []string{},
+		Compatible:     true,
+	}
+
+	if len(all) > 0 {
+		status.LatestVersion = all[len(all)-1]
+	}
+
+	for _, version := range all {
+		if version > current {
+			status.Pending = append(status.Pending, version)
+		}
+	}
+
+	if current > status.LatestVersion {
+		status.Compatible = false
+	}
+
+	return status
+}
+
+// versions returns the sorted versions of all up migrations in the provided file system.
+func versions(fsys fs.FS) ([]string, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+
+	result := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), upSuffix) {
+			continue
+		}
+		result = append(result, strings.TrimSuffix(entry.Name(), upSuffix))
+	}
+
+	sort.Strings(result)
+
+	return result, nil
+}
+
 // To performs the database migration to the specific version.
 func To(ctx context.Context, db *sqlx.DB, version string) error {
 	opts, err := getMigrator(db)
@@ -77,7 +235,7 @@ func Current(ctx context.Context, db *sqlx.DB) (string, error) {
 		query = `
 			SELECT count(*)
 			FROM information_schema.tables
-			WHERE table_name = ? and table_schema = 'public'`
+			WHERE table_name = $1 and table_schema = current_schema()`
 	default:
 		return "", fmt.Errorf("unsupported driver '%s'", db.DriverName())
 	}
@@ -124,35 +282,38 @@ func getMigrator(db *sqlx.DB) (migrate.Options, error) {
 		log.Info().Msg("[START]")
 		defer log.Info().Msg("[DONE]")
 
-		switch version {
-		case "0039_alter_table_webhooks_uid":
-			return migrateAfter_0039_alter_table_webhooks_uid(ctx, dbtx)
-		case "0042_alter_table_rules":
-			return migrateAfter_0042_alter_table_rules(ctx, dbtx)
-		default:
-			return nil
+		if fn, ok := migrationsAfter[version]; ok {
+			return fn(ctx, dbtx)
 		}
+
+		return nil
+	}
+
+	folder, err := getFS(db.DriverName())
+	if err != nil {
+		return migrate.Options{}, err
 	}
 
 	opts := migrate.Options{
 		After:  after,
 		Before: before,
 		DB:     db.DB,
-		FS:     sqlite,
+		FS:     folder,
 		Table:  tableName,
 	}
 
-	switch db.DriverName() {
+	return opts, nil
+}
+
+func getFS(driver string) (fs.FS, error) {
+	switch driver {
 	case sqliteDriverName:
 		folder, _ := fs.Sub(sqlite, sqliteSourceDir)
-		opts.FS = folder
+		return folder, nil
 	case postgresDriverName:
 		folder, _ := fs.Sub(postgres, postgresSourceDir)
-		opts.FS = folder
-
+		return folder, nil
 	default:
-		return migrate.Options{}, fmt.Errorf("unsupported driver '%s'", db.DriverName())
+		return nil, fmt.Errorf("unsupported driver '%s'", driver)
 	}
-
-	return opts, nil
 }
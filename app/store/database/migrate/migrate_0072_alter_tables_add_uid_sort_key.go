@@ -0,0 +1,134 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/harness/gitness/store/database"
+
+	"github.com/rs/zerolog/log"
+)
+
+//nolint:stylecheck,revive // have naming match migration version
+func migrateAfter_0072_alter_tables_add_uid_sort_key(
+	ctx context.Context,
+	dbtx *sql.Tx,
+) error {
+	if err := backfillUIDSortKey(ctx, dbtx, "repositories", "repo_id", "repo_uid", "repo_uid_sort_key"); err != nil {
+		return fmt.Errorf("failed to backfill repository sort keys: %w", err)
+	}
+
+	if err := backfillUIDSortKey(ctx, dbtx, "spaces", "space_id", "space_uid", "space_uid_sort_key"); err != nil {
+		return fmt.Errorf("failed to backfill space sort keys: %w", err)
+	}
+
+	return nil
+}
+
+// backfillUIDSortKey computes the sort key of all rows of the provided table.
+// NOTE: table and column names are constants provided by the migration and not user input.
+func backfillUIDSortKey(
+	ctx context.Context,
+	dbtx *sql.Tx,
+	table string,
+	idColumn string,
+	uidColumn string,
+	sortKeyColumn string,
+) error {
+	log := log.Ctx(ctx)
+
+	log.Info().Msgf("backfill %s of all %s", sortKeyColumn, table)
+
+	// Same as in 0042, we have to process page by page in memory and can't update as we read.
+	type row struct {
+		id  int64
+		uid string
+	}
+
+	const pageSize = 1000
+	buffer := make([]row, pageSize)
+	page := 0
+	nextPage := func() (int, error) {
+		// nullable columns (e.g. principal display names) are backfilled with the sort key of an empty string.
+		selectQuery := fmt.Sprintf(`
+			SELECT %[1]s, COALESCE(%[2]s, '')
+			FROM %[3]s
+			ORDER BY %[1]s
+			LIMIT $1
+			OFFSET $2
+			`, idColumn, uidColumn, table)
+		rows, err := dbtx.QueryContext(ctx, selectQuery, pageSize, page*pageSize)
+		if rows != nil {
+			defer func() {
+				err := rows.Close()
+				if err != nil {
+					log.Warn().Err(err).Msg("failed to close result rows")
+				}
+			}()
+		}
+		if err != nil {
+			return 0, database.ProcessSQLErrorf(ctx, err, "failed batch select query")
+		}
+
+		c := 0
+		for rows.Next() {
+			err = rows.Scan(&buffer[c].id, &buffer[c].uid)
+			if err != nil {
+				return 0, database.ProcessSQLErrorf(ctx, err, "failed scanning next row")
+			}
+			c++
+		}
+
+		if rows.Err() != nil {
+			return 0, database.ProcessSQLErrorf(ctx, err, "failed reading all rows")
+		}
+
+		page++
+
+		return c, nil
+	}
+
+	updateQuery := fmt.Sprintf(`
+		UPDATE %s
+		SET
+			%s = $1
+		WHERE
+			%s = $2`, table, sortKeyColumn, idColumn)
+
+	for {
+		n, err := nextPage()
+		if err != nil {
+			return fmt.Errorf("failed to read next batch of %s: %w", table, err)
+		}
+
+		if n == 0 {
+			break
+		}
+
+		for i := 0; i < n; i++ {
+			r := buffer[i]
+
+			_, err = dbtx.ExecContext(ctx, updateQuery, database.SortKey(r.uid), r.id)
+			if err != nil {
+				return database.ProcessSQLErrorf(ctx, err, "failed to update sort key")
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// migrateAfter_0106_create_table_installation backfills the installation record of existing instances
+// from the first user ever created, which used to be treated as the installer.
+// Instances without any users get their record created during the bootstrap of the system.
+//
+//nolint:stylecheck,revive // have naming match migration version
+func migrateAfter_0106_create_table_installation(
+	ctx context.Context,
+	dbtx *sql.Tx,
+) error {
+	const selectQuery = `
+		SELECT principal_id, principal_email, principal_created
+		FROM principals
+		WHERE principal_type = 'user'
+		ORDER BY principal_created, principal_id
+		LIMIT 1`
+
+	var (
+		principalID int64
+		email       string
+		created     int64
+	)
+	err := dbtx.QueryRowContext(ctx, selectQuery).Scan(&principalID, &email, &created)
+	if errors.Is(err, sql.ErrNoRows) {
+		log.Ctx(ctx).Info().Msg("no users found, installation record will be created during bootstrap")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find first user: %w", err)
+	}
+
+	const insertQuery = `
+		INSERT INTO installation (
+			 installation_id
+			,installation_uuid
+			,installation_installed
+			,installation_installed_by
+			,installation_installer_email
+		) VALUES (1, $1, $2, $3, $4)`
+
+	_, err = dbtx.ExecContext(ctx, insertQuery, uuid.NewString(), created, principalID, email)
+	if err != nil {
+		return fmt.Errorf("failed to insert installation record: %w", err)
+	}
+
+	log.Ctx(ctx).Info().Msgf("backfilled installation record from user with id %d", principalID)
+
+	return nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+//nolint:stylecheck,revive // have naming match migration version
+func migrateAfter_0110_alter_tables_add_name_sort_keys(
+	ctx context.Context,
+	dbtx *sql.Tx,
+) error {
+	sortKeys := []struct {
+		table         string
+		idColumn      string
+		nameColumn    string
+		sortKeyColumn string
+	}{
+		{"principals", "principal_id", "principal_uid", "principal_uid_sort_key"},
+		{"principals", "principal_id", "principal_display_name", "principal_display_name_sort_key"},
+		{"rules", "rule_id", "rule_uid", "rule_uid_sort_key"},
+		{"webhooks", "webhook_id", "webhook_uid", "webhook_uid_sort_key"},
+		{"webhooks", "webhook_id", "webhook_display_name", "webhook_display_name_sort_key"},
+		{"public_keys", "public_key_id", "public_key_identifier", "public_key_identifier_sort_key"},
+		{"deploy_keys", "deploy_key_id", "deploy_key_identifier", "deploy_key_identifier_sort_key"},
+		{"labels", "label_id", "label_key", "label_key_sort_key"},
+	}
+
+	for _, k := range sortKeys {
+		err := backfillUIDSortKey(ctx, dbtx, k.table, k.idColumn, k.nameColumn, k.sortKeyColumn)
+		if err != nil {
+			return fmt.Errorf("failed to backfill %s of %s: %w", k.sortKeyColumn, k.table, err)
+		}
+	}
+
+	return nil
+}
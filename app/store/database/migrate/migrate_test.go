@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersions(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0002_b.up.sql":   {},
+		"0002_b.down.sql": {},
+		"0001_a.up.sql":   {},
+		"0001_a.down.sql": {},
+		"readme.md":       {},
+	}
+
+	result, err := versions(fsys)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0001_a", "0002_b"}, result)
+}
+
+func TestGetStatus(t *testing.T) {
+	all := []string{"0001_a", "0002_b", "0003_c"}
+
+	tests := []struct {
+		name       string
+		current    string
+		pending    []string
+		compatible bool
+	}{
+		{name: "empty database", current: "", pending: all, compatible: true},
+		{name: "pending", current: "0001_a", pending: []string{"0002_b", "0003_c"}, compatible: true},
+		{name: "up to date", current: "0003_c", pending: []string{}, compatible: true},
+		{name: "newer schema", current: "0004_d", pending: []string{}, compatible: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			status := getStatus(postgresDriverName, test.current, all)
+			assert.Equal(t, "0003_c", status.LatestVersion)
+			assert.Equal(t, test.pending, status.Pending)
+			assert.Equal(t, test.compatible, status.Compatible)
+
+			err := checkStatus(status)
+			if test.compatible {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrSchemaTooNew)
+			}
+		})
+	}
+}
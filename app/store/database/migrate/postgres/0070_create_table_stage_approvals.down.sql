@@ -0,0 +1,3 @@
+DROP TABLE stage_approvals;
+DROP INDEX ix_stage_blocked;
+ALTER TABLE stages DROP COLUMN stage_approval;
@@ -0,0 +1,16 @@
+CREATE TABLE digest_subscriptions (
+ digest_subscription_principal_id INTEGER PRIMARY KEY
+,digest_subscription_frequency TEXT NOT NULL
+,digest_subscription_space_ids JSONB NOT NULL
+,digest_subscription_repo_ids JSONB NOT NULL
+,digest_subscription_last_sent BIGINT NOT NULL
+,digest_subscription_created BIGINT NOT NULL
+,digest_subscription_updated BIGINT NOT NULL
+,CONSTRAINT fk_digest_subscription_principal_id FOREIGN KEY (digest_subscription_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX digest_subscriptions_frequency_last_sent
+    ON digest_subscriptions(digest_subscription_frequency, digest_subscription_last_sent);
@@ -0,0 +1 @@
+ALTER TABLE repositories DROP COLUMN repo_state_until;
@@ -0,0 +1,14 @@
+CREATE TABLE password_resets (
+ password_reset_principal_id INTEGER PRIMARY KEY
+,password_reset_token_hash TEXT NOT NULL
+,password_reset_expires BIGINT NOT NULL
+,password_reset_created_by INTEGER NOT NULL
+,password_reset_created BIGINT NOT NULL
+,CONSTRAINT fk_password_reset_principal_id FOREIGN KEY (password_reset_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX password_resets_token_hash
+    ON password_resets(password_reset_token_hash);
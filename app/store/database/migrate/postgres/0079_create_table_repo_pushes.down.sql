@@ -0,0 +1,2 @@
+DROP TABLE repo_stats;
+DROP TABLE repo_pushes;
@@ -0,0 +1,2 @@
+ALTER TABLE repositories DROP COLUMN repo_num_stars;
+DROP TABLE repo_stars;
@@ -0,0 +1,16 @@
+CREATE TABLE mail_failures (
+ mail_failure_id SERIAL PRIMARY KEY
+,mail_failure_job_uid TEXT NOT NULL
+,mail_failure_recipient TEXT NOT NULL
+,mail_failure_subject TEXT NOT NULL
+,mail_failure_error TEXT NOT NULL
+,mail_failure_attempts INTEGER NOT NULL
+,mail_failure_created BIGINT NOT NULL
+,mail_failure_updated BIGINT NOT NULL
+);
+
+CREATE UNIQUE INDEX mail_failures_job_uid
+    ON mail_failures(mail_failure_job_uid);
+
+CREATE INDEX mail_failures_recipient
+    ON mail_failures(LOWER(mail_failure_recipient));
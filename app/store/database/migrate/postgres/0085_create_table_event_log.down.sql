@@ -0,0 +1,2 @@
+DROP TABLE event_consumer_offsets;
+DROP TABLE event_log;
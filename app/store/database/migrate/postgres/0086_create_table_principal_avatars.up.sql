@@ -0,0 +1,10 @@
+CREATE TABLE principal_avatars (
+ avatar_principal_id INTEGER PRIMARY KEY
+,avatar_hash TEXT NOT NULL
+,avatar_created BIGINT NOT NULL
+,avatar_updated BIGINT NOT NULL
+,CONSTRAINT fk_avatar_principal_id FOREIGN KEY (avatar_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
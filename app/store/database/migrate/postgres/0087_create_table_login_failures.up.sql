@@ -0,0 +1,14 @@
+CREATE TABLE login_failures (
+ login_failure_id SERIAL PRIMARY KEY
+,login_failure_key TEXT NOT NULL
+,login_failure_created BIGINT NOT NULL
+);
+
+CREATE INDEX login_failures_key_created
+    ON login_failures(login_failure_key, login_failure_created);
+
+CREATE TABLE login_lockouts (
+ login_lockout_key TEXT PRIMARY KEY
+,login_lockout_until BIGINT NOT NULL
+,login_lockout_created BIGINT NOT NULL
+);
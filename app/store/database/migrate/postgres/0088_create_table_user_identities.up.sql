@@ -0,0 +1,17 @@
+CREATE TABLE user_identities (
+ user_identity_id SERIAL PRIMARY KEY
+,user_identity_principal_id INTEGER NOT NULL
+,user_identity_provider TEXT NOT NULL
+,user_identity_subject TEXT NOT NULL
+,user_identity_created BIGINT NOT NULL
+,CONSTRAINT fk_user_identity_principal_id FOREIGN KEY (user_identity_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX user_identities_provider_subject
+    ON user_identities(user_identity_provider, user_identity_subject);
+
+CREATE INDEX user_identities_principal_id
+    ON user_identities(user_identity_principal_id);
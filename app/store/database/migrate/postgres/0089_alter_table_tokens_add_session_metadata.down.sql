@@ -0,0 +1,3 @@
+ALTER TABLE tokens DROP COLUMN token_last_used_at;
+ALTER TABLE tokens DROP COLUMN token_ip_address;
+ALTER TABLE tokens DROP COLUMN token_user_agent;
@@ -0,0 +1,3 @@
+ALTER TABLE tokens ADD COLUMN token_user_agent TEXT NOT NULL DEFAULT '';
+ALTER TABLE tokens ADD COLUMN token_ip_address TEXT NOT NULL DEFAULT '';
+ALTER TABLE tokens ADD COLUMN token_last_used_at BIGINT;
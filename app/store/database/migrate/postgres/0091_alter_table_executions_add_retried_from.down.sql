@@ -0,0 +1 @@
+ALTER TABLE executions DROP COLUMN execution_retried_from;
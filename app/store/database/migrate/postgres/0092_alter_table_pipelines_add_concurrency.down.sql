@@ -0,0 +1,2 @@
+ALTER TABLE pipelines DROP COLUMN pipeline_cancel_superseded;
+ALTER TABLE pipelines DROP COLUMN pipeline_concurrency_limit;
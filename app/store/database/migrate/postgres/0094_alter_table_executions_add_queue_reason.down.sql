@@ -0,0 +1,2 @@
+ALTER TABLE executions DROP COLUMN execution_superseded_by;
+ALTER TABLE executions DROP COLUMN execution_queue_reason;
@@ -0,0 +1,2 @@
+ALTER TABLE executions ADD COLUMN execution_queue_reason TEXT NOT NULL DEFAULT '';
+ALTER TABLE executions ADD COLUMN execution_superseded_by INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+ALTER TABLE stages ADD COLUMN stage_step_timeouts TEXT NOT NULL DEFAULT 'null';
+ALTER TABLE steps ADD COLUMN step_timeout BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE steps ADD COLUMN step_timed_out BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,17 @@
+CREATE TABLE usage_rollups (
+ usage_id SERIAL PRIMARY KEY
+,usage_repo_id INTEGER NOT NULL
+,usage_day BIGINT NOT NULL
+,usage_ci_duration BIGINT NOT NULL
+,usage_git_size BIGINT NOT NULL
+,usage_artifacts_size BIGINT NOT NULL
+,usage_updated BIGINT NOT NULL
+,CONSTRAINT fk_usage_repo_id FOREIGN KEY (usage_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX usage_rollups_repo_id_day ON usage_rollups(usage_repo_id, usage_day);
+
+CREATE INDEX usage_rollups_day ON usage_rollups(usage_day);
@@ -0,0 +1,23 @@
+CREATE TABLE space_pinned_repos (
+ space_pinned_repo_space_id INTEGER NOT NULL
+,space_pinned_repo_repo_id INTEGER NOT NULL
+,space_pinned_repo_order INTEGER NOT NULL
+,space_pinned_repo_created BIGINT NOT NULL
+,space_pinned_repo_created_by INTEGER NOT NULL
+,CONSTRAINT pk_space_pinned_repos PRIMARY KEY (space_pinned_repo_space_id, space_pinned_repo_repo_id)
+,CONSTRAINT fk_space_pinned_repo_space_id FOREIGN KEY (space_pinned_repo_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_space_pinned_repo_repo_id FOREIGN KEY (space_pinned_repo_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_space_pinned_repo_created_by FOREIGN KEY (space_pinned_repo_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX space_pinned_repos_repo_id
+    ON space_pinned_repos(space_pinned_repo_repo_id);
@@ -0,0 +1,3 @@
+ALTER TABLE repositories DROP COLUMN repo_show_readme;
+ALTER TABLE repositories DROP COLUMN repo_contact_id;
+ALTER TABLE repositories DROP COLUMN repo_website;
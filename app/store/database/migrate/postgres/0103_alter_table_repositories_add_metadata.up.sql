@@ -0,0 +1,4 @@
+ALTER TABLE repositories ADD COLUMN repo_website TEXT NOT NULL DEFAULT '';
+ALTER TABLE repositories ADD COLUMN repo_contact_id INTEGER
+    REFERENCES principals (principal_id) ON DELETE SET NULL;
+ALTER TABLE repositories ADD COLUMN repo_show_readme BOOLEAN NOT NULL DEFAULT TRUE;
@@ -0,0 +1,7 @@
+CREATE TABLE installation (
+ installation_id INTEGER PRIMARY KEY CHECK (installation_id = 1)
+,installation_uuid TEXT NOT NULL
+,installation_installed BIGINT NOT NULL
+,installation_installed_by INTEGER
+,installation_installer_email TEXT NOT NULL DEFAULT ''
+);
@@ -0,0 +1,20 @@
+CREATE TABLE feature_flags (
+ feature_flag_id SERIAL PRIMARY KEY
+,feature_flag_name TEXT NOT NULL
+,feature_flag_space_id INTEGER
+,feature_flag_enabled BOOLEAN NOT NULL
+,feature_flag_updated_by INTEGER NOT NULL
+,feature_flag_updated BIGINT NOT NULL
+,CONSTRAINT fk_feature_flag_space_id FOREIGN KEY (feature_flag_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX feature_flags_name_instance
+    ON feature_flags(feature_flag_name)
+    WHERE feature_flag_space_id IS NULL;
+
+CREATE UNIQUE INDEX feature_flags_name_space_id
+    ON feature_flags(feature_flag_name, feature_flag_space_id)
+    WHERE feature_flag_space_id IS NOT NULL;
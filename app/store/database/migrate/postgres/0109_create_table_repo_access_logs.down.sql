@@ -0,0 +1 @@
+DROP TABLE repo_access_logs;
@@ -0,0 +1,22 @@
+CREATE TABLE repo_access_logs (
+ repo_access_log_id SERIAL PRIMARY KEY
+,repo_access_log_repo_id INTEGER NOT NULL
+,repo_access_log_principal_id INTEGER
+,repo_access_log_operation TEXT NOT NULL
+,repo_access_log_client_ip TEXT NOT NULL
+,repo_access_log_bytes_sent BIGINT NOT NULL
+,repo_access_log_bytes_received BIGINT NOT NULL
+,repo_access_log_duration BIGINT NOT NULL
+,repo_access_log_success BOOLEAN NOT NULL
+,repo_access_log_created BIGINT NOT NULL
+,CONSTRAINT fk_repo_access_log_repo_id FOREIGN KEY (repo_access_log_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX repo_access_logs_repo_id_created
+    ON repo_access_logs(repo_access_log_repo_id, repo_access_log_created);
+
+CREATE INDEX repo_access_logs_created
+    ON repo_access_logs(repo_access_log_created);
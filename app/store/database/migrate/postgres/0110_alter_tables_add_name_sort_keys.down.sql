@@ -0,0 +1,8 @@
+ALTER TABLE labels DROP COLUMN label_key_sort_key;
+ALTER TABLE deploy_keys DROP COLUMN deploy_key_identifier_sort_key;
+ALTER TABLE public_keys DROP COLUMN public_key_identifier_sort_key;
+ALTER TABLE webhooks DROP COLUMN webhook_display_name_sort_key;
+ALTER TABLE webhooks DROP COLUMN webhook_uid_sort_key;
+ALTER TABLE rules DROP COLUMN rule_uid_sort_key;
+ALTER TABLE principals DROP COLUMN principal_display_name_sort_key;
+ALTER TABLE principals DROP COLUMN principal_uid_sort_key;
@@ -0,0 +1,31 @@
+ALTER TABLE stages ADD COLUMN stage_approval TEXT NOT NULL DEFAULT 'null';
+
+CREATE INDEX ix_stage_blocked ON stages (stage_status)
+WHERE stage_status = 'blocked';
+
+CREATE TABLE stage_approvals (
+    stage_approval_id INTEGER PRIMARY KEY AUTOINCREMENT
+    ,stage_approval_execution_id INTEGER NOT NULL
+    ,stage_approval_stage_id INTEGER NOT NULL
+    ,stage_approval_stage_number INTEGER NOT NULL
+    ,stage_approval_repo_id INTEGER NOT NULL
+    ,stage_approval_decision TEXT NOT NULL
+    ,stage_approval_comment TEXT NOT NULL
+    ,stage_approval_created_by INTEGER NOT NULL
+    ,stage_approval_created BIGINT NOT NULL
+    ,CONSTRAINT fk_stage_approval_execution_id FOREIGN KEY (stage_approval_execution_id)
+        REFERENCES executions (execution_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+    ,CONSTRAINT fk_stage_approval_stage_id FOREIGN KEY (stage_approval_stage_id)
+        REFERENCES stages (stage_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+    ,CONSTRAINT fk_stage_approval_created_by FOREIGN KEY (stage_approval_created_by)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE NO ACTION
+);
+
+CREATE INDEX stage_approvals_execution_id
+    ON stage_approvals(stage_approval_execution_id);
@@ -0,0 +1,2 @@
+DROP TABLE repo_activities;
+ALTER TABLE repositories DROP COLUMN repo_last_activity;
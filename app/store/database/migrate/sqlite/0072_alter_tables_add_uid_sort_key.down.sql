@@ -0,0 +1,5 @@
+DROP INDEX spaces_parent_id_uid_sort_key;
+DROP INDEX repositories_parent_id_uid_sort_key;
+
+ALTER TABLE spaces DROP COLUMN space_uid_sort_key;
+ALTER TABLE repositories DROP COLUMN repo_uid_sort_key;
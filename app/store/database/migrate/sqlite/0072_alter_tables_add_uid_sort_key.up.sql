@@ -0,0 +1,8 @@
+ALTER TABLE repositories ADD COLUMN repo_uid_sort_key TEXT NOT NULL DEFAULT '';
+ALTER TABLE spaces ADD COLUMN space_uid_sort_key TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX repositories_parent_id_uid_sort_key
+    ON repositories(repo_parent_id, repo_uid_sort_key);
+
+CREATE INDEX spaces_parent_id_uid_sort_key
+    ON spaces(space_parent_id, space_uid_sort_key);
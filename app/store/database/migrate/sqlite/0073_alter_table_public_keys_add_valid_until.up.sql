@@ -0,0 +1,2 @@
+ALTER TABLE public_keys ADD COLUMN public_key_valid_until BIGINT;
+ALTER TABLE public_keys ADD COLUMN public_key_last_used BIGINT;
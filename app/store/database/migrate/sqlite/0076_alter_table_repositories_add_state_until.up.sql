@@ -0,0 +1 @@
+ALTER TABLE repositories ADD COLUMN repo_state_until BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,14 @@
+CREATE TABLE email_changes (
+ email_change_principal_id INTEGER PRIMARY KEY
+,email_change_email TEXT NOT NULL
+,email_change_token_hash TEXT NOT NULL
+,email_change_expires BIGINT NOT NULL
+,email_change_created BIGINT NOT NULL
+,CONSTRAINT fk_email_change_principal_id FOREIGN KEY (email_change_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX email_changes_token_hash
+    ON email_changes(email_change_token_hash);
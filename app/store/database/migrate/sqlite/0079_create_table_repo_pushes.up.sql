@@ -0,0 +1,33 @@
+CREATE TABLE repo_pushes (
+ repo_push_id INTEGER PRIMARY KEY AUTOINCREMENT
+,repo_push_repo_id INTEGER NOT NULL
+,repo_push_principal_id INTEGER NOT NULL
+,repo_push_ref TEXT NOT NULL
+,repo_push_commit_count INTEGER NOT NULL
+,repo_push_created BIGINT NOT NULL
+,CONSTRAINT fk_repo_push_repo_id FOREIGN KEY (repo_push_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX repo_pushes_repo_id_created
+    ON repo_pushes(repo_push_repo_id, repo_push_created);
+
+CREATE INDEX repo_pushes_created
+    ON repo_pushes(repo_push_created);
+
+CREATE TABLE repo_stats (
+ repo_stats_repo_id INTEGER PRIMARY KEY
+,repo_stats_pushes_7d INTEGER NOT NULL
+,repo_stats_pushes_30d INTEGER NOT NULL
+,repo_stats_branches INTEGER NOT NULL
+,repo_stats_tags INTEGER NOT NULL
+,repo_stats_push_buckets TEXT NOT NULL
+,repo_stats_contributors TEXT NOT NULL
+,repo_stats_updated BIGINT NOT NULL
+,CONSTRAINT fk_repo_stats_repo_id FOREIGN KEY (repo_stats_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
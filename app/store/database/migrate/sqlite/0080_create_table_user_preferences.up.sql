@@ -0,0 +1,12 @@
+CREATE TABLE user_preferences (
+ user_preference_principal_id INTEGER NOT NULL
+,user_preference_view TEXT NOT NULL
+,user_preference_params TEXT NOT NULL
+,user_preference_created BIGINT NOT NULL
+,user_preference_updated BIGINT NOT NULL
+,CONSTRAINT pk_user_preferences PRIMARY KEY (user_preference_principal_id, user_preference_view)
+,CONSTRAINT fk_user_preference_principal_id FOREIGN KEY (user_preference_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
@@ -0,0 +1 @@
+DROP TABLE recent_visits;
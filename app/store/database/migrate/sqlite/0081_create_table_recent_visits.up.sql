@@ -0,0 +1,14 @@
+CREATE TABLE recent_visits (
+ recent_visit_principal_id INTEGER NOT NULL
+,recent_visit_type TEXT NOT NULL
+,recent_visit_resource_id INTEGER NOT NULL
+,recent_visit_visited BIGINT NOT NULL
+,CONSTRAINT pk_recent_visits PRIMARY KEY (recent_visit_principal_id, recent_visit_type, recent_visit_resource_id)
+,CONSTRAINT fk_recent_visit_principal_id FOREIGN KEY (recent_visit_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX recent_visits_principal_id_visited
+    ON recent_visits(recent_visit_principal_id, recent_visit_visited);
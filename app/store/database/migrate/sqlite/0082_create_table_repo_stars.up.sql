@@ -0,0 +1,19 @@
+CREATE TABLE repo_stars (
+ repo_star_principal_id INTEGER NOT NULL
+,repo_star_repo_id INTEGER NOT NULL
+,repo_star_created BIGINT NOT NULL
+,CONSTRAINT pk_repo_stars PRIMARY KEY (repo_star_principal_id, repo_star_repo_id)
+,CONSTRAINT fk_repo_star_principal_id FOREIGN KEY (repo_star_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_repo_star_repo_id FOREIGN KEY (repo_star_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX repo_stars_repo_id
+    ON repo_stars(repo_star_repo_id);
+
+ALTER TABLE repositories ADD COLUMN repo_num_stars INTEGER NOT NULL DEFAULT 0;
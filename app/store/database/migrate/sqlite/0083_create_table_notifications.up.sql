@@ -0,0 +1,43 @@
+CREATE TABLE notification_subscriptions (
+ notification_subscription_principal_id INTEGER NOT NULL
+,notification_subscription_resource_type TEXT NOT NULL
+,notification_subscription_resource_id INTEGER NOT NULL
+,notification_subscription_subscribed BOOLEAN NOT NULL
+,notification_subscription_created BIGINT NOT NULL
+,notification_subscription_updated BIGINT NOT NULL
+,CONSTRAINT pk_notification_subscriptions PRIMARY KEY (notification_subscription_principal_id,
+    notification_subscription_resource_type, notification_subscription_resource_id)
+,CONSTRAINT fk_notification_subscription_principal_id FOREIGN KEY (notification_subscription_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX notification_subscriptions_resource
+    ON notification_subscriptions(notification_subscription_resource_type, notification_subscription_resource_id);
+
+CREATE TABLE notifications (
+ notification_id INTEGER PRIMARY KEY AUTOINCREMENT
+,notification_principal_id INTEGER NOT NULL
+,notification_type TEXT NOT NULL
+,notification_repo_id INTEGER NOT NULL
+,notification_resource_id INTEGER NOT NULL
+,notification_actor_id INTEGER
+,notification_payload TEXT NOT NULL
+,notification_read BOOLEAN NOT NULL
+,notification_created BIGINT NOT NULL
+,CONSTRAINT fk_notification_principal_id FOREIGN KEY (notification_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_notification_repo_id FOREIGN KEY (notification_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX notifications_principal_id_created
+    ON notifications(notification_principal_id, notification_created);
+
+CREATE INDEX notifications_principal_id_read
+    ON notifications(notification_principal_id, notification_read);
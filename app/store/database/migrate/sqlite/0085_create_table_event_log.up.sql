@@ -0,0 +1,20 @@
+CREATE TABLE event_log (
+ event_log_id INTEGER PRIMARY KEY AUTOINCREMENT
+,event_log_stream_id TEXT NOT NULL
+,event_log_payload TEXT NOT NULL
+,event_log_created BIGINT NOT NULL
+);
+
+CREATE INDEX event_log_stream_id_id
+    ON event_log(event_log_stream_id, event_log_id);
+
+CREATE TABLE event_consumer_offsets (
+ event_consumer_offset_group_name TEXT NOT NULL
+,event_consumer_offset_stream_id TEXT NOT NULL
+,event_consumer_offset_position BIGINT NOT NULL
+,event_consumer_offset_lease_owner TEXT NOT NULL
+,event_consumer_offset_lease_expires BIGINT NOT NULL
+,event_consumer_offset_updated BIGINT NOT NULL
+,CONSTRAINT pk_event_consumer_offsets PRIMARY KEY (event_consumer_offset_group_name,
+    event_consumer_offset_stream_id)
+);
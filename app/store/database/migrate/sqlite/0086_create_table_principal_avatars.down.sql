@@ -0,0 +1 @@
+DROP TABLE principal_avatars;
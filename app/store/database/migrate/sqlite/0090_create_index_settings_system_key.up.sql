@@ -0,0 +1,3 @@
+CREATE UNIQUE INDEX settings_system_key
+	ON settings(LOWER(setting_key))
+	WHERE setting_space_id IS NULL AND setting_repo_id IS NULL;
@@ -0,0 +1 @@
+ALTER TABLE executions ADD COLUMN execution_retried_from INTEGER NOT NULL DEFAULT 0;
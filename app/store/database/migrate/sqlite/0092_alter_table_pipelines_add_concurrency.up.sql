@@ -0,0 +1,2 @@
+ALTER TABLE pipelines ADD COLUMN pipeline_concurrency_limit INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE pipelines ADD COLUMN pipeline_cancel_superseded BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1 @@
+ALTER TABLE spaces DROP COLUMN space_execution_limit;
@@ -0,0 +1 @@
+ALTER TABLE spaces ADD COLUMN space_execution_limit INTEGER NOT NULL DEFAULT 0;
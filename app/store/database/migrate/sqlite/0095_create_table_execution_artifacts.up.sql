@@ -0,0 +1,22 @@
+-- no foreign keys on purpose: artifacts of deleted executions are kept until the cleanup job removed their blobs.
+CREATE TABLE execution_artifacts (
+ artifact_id INTEGER PRIMARY KEY AUTOINCREMENT
+,artifact_execution_id INTEGER NOT NULL
+,artifact_repo_id INTEGER NOT NULL
+,artifact_name TEXT NOT NULL
+,artifact_size BIGINT NOT NULL
+,artifact_checksum TEXT NOT NULL
+,artifact_content_type TEXT NOT NULL
+,artifact_blob_path TEXT NOT NULL
+,artifact_created_by INTEGER NOT NULL
+,artifact_created BIGINT NOT NULL
+);
+
+CREATE UNIQUE INDEX execution_artifacts_execution_id_name
+    ON execution_artifacts(artifact_execution_id, artifact_name);
+
+CREATE INDEX execution_artifacts_repo_id_created
+    ON execution_artifacts(artifact_repo_id, artifact_created);
+
+CREATE INDEX execution_artifacts_created
+    ON execution_artifacts(artifact_created);
@@ -0,0 +1,2 @@
+ALTER TABLE executions DROP COLUMN execution_timeout;
+ALTER TABLE pipelines DROP COLUMN pipeline_timeout;
@@ -0,0 +1,2 @@
+ALTER TABLE pipelines ADD COLUMN pipeline_timeout BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE executions ADD COLUMN execution_timeout BIGINT NOT NULL DEFAULT 0;
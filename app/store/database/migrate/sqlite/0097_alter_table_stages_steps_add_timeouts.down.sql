@@ -0,0 +1,3 @@
+ALTER TABLE steps DROP COLUMN step_timed_out;
+ALTER TABLE steps DROP COLUMN step_timeout;
+ALTER TABLE stages DROP COLUMN stage_step_timeouts;
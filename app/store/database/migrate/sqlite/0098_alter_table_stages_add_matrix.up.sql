@@ -0,0 +1 @@
+ALTER TABLE stages ADD COLUMN stage_matrix TEXT NOT NULL DEFAULT 'null';
@@ -0,0 +1,25 @@
+CREATE TABLE runners (
+ runner_id INTEGER PRIMARY KEY AUTOINCREMENT
+,runner_space_id INTEGER
+,runner_uid TEXT NOT NULL
+,runner_description TEXT NOT NULL
+,runner_labels TEXT NOT NULL
+,runner_capacity INTEGER NOT NULL
+,runner_os TEXT NOT NULL
+,runner_arch TEXT NOT NULL
+,runner_embedded BOOLEAN NOT NULL
+,runner_token_hash TEXT NOT NULL
+,runner_draining BOOLEAN NOT NULL
+,runner_last_heartbeat BIGINT NOT NULL
+,runner_created BIGINT NOT NULL
+,runner_updated BIGINT NOT NULL
+,runner_version INTEGER NOT NULL
+,CONSTRAINT fk_runner_space_id FOREIGN KEY (runner_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX runners_uid ON runners(LOWER(runner_uid));
+
+CREATE INDEX runners_space_id ON runners(runner_space_id);
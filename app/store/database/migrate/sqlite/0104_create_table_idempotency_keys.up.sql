@@ -0,0 +1,18 @@
+CREATE TABLE idempotency_keys (
+ idempotency_key_principal_id INTEGER NOT NULL
+,idempotency_key_key TEXT NOT NULL
+,idempotency_key_fingerprint TEXT NOT NULL
+,idempotency_key_response_status INTEGER NOT NULL
+,idempotency_key_response_content_type TEXT NOT NULL
+,idempotency_key_response_body BLOB
+,idempotency_key_created BIGINT NOT NULL
+,idempotency_key_expires BIGINT NOT NULL
+,CONSTRAINT pk_idempotency_keys PRIMARY KEY (idempotency_key_principal_id, idempotency_key_key)
+,CONSTRAINT fk_idempotency_key_principal_id FOREIGN KEY (idempotency_key_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX idempotency_keys_expires
+    ON idempotency_keys(idempotency_key_expires);
@@ -0,0 +1,24 @@
+CREATE TABLE operations (
+ operation_id INTEGER PRIMARY KEY AUTOINCREMENT
+,operation_uid TEXT NOT NULL
+,operation_type TEXT NOT NULL
+,operation_resource_type TEXT NOT NULL
+,operation_resource_id INTEGER NOT NULL
+,operation_job_uid TEXT NOT NULL
+,operation_job_group_id TEXT NOT NULL
+,operation_created_by INTEGER NOT NULL
+,operation_created BIGINT NOT NULL
+,CONSTRAINT fk_operation_created_by FOREIGN KEY (operation_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX operations_uid
+    ON operations(operation_uid);
+
+CREATE UNIQUE INDEX operations_job_uid_job_group_id
+    ON operations(operation_job_uid, operation_job_group_id);
+
+CREATE INDEX operations_created
+    ON operations(operation_created);
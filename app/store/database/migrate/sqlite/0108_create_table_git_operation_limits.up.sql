@@ -0,0 +1,10 @@
+CREATE TABLE git_operation_limits (
+ git_operation_limit_principal_id INTEGER PRIMARY KEY
+,git_operation_limit_max_concurrent INTEGER NOT NULL
+,git_operation_limit_updated_by INTEGER NOT NULL
+,git_operation_limit_updated BIGINT NOT NULL
+,CONSTRAINT fk_git_operation_limit_principal_id FOREIGN KEY (git_operation_limit_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
@@ -0,0 +1,8 @@
+ALTER TABLE principals ADD COLUMN principal_uid_sort_key TEXT NOT NULL DEFAULT '';
+ALTER TABLE principals ADD COLUMN principal_display_name_sort_key TEXT NOT NULL DEFAULT '';
+ALTER TABLE rules ADD COLUMN rule_uid_sort_key TEXT NOT NULL DEFAULT '';
+ALTER TABLE webhooks ADD COLUMN webhook_uid_sort_key TEXT NOT NULL DEFAULT '';
+ALTER TABLE webhooks ADD COLUMN webhook_display_name_sort_key TEXT NOT NULL DEFAULT '';
+ALTER TABLE public_keys ADD COLUMN public_key_identifier_sort_key TEXT NOT NULL DEFAULT '';
+ALTER TABLE deploy_keys ADD COLUMN deploy_key_identifier_sort_key TEXT NOT NULL DEFAULT '';
+ALTER TABLE labels ADD COLUMN label_key_sort_key TEXT NOT NULL DEFAULT '';
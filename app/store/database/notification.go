@@ -0,0 +1,298 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.NotificationStore = (*NotificationStore)(nil)
+
+// NewNotificationStore returns a new NotificationStore.
+func NewNotificationStore(
+	db *sqlx.DB,
+	pCache store.PrincipalInfoCache,
+) *NotificationStore {
+	return &NotificationStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// NotificationStore implements store.NotificationStore backed by a relational database.
+type NotificationStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+const (
+	notificationColumns = `
+		 notification_id
+		,notification_principal_id
+		,notification_type
+		,notification_repo_id
+		,notification_resource_id
+		,notification_actor_id
+		,notification_payload
+		,notification_read
+		,notification_created`
+)
+
+type notification struct {
+	ID          int64                 `db:"notification_id"`
+	PrincipalID int64                 `db:"notification_principal_id"`
+	Type        enum.NotificationType `db:"notification_type"`
+	RepoID      int64                 `db:"notification_repo_id"`
+	ResourceID  int64                 `db:"notification_resource_id"`
+	ActorID     null.Int              `db:"notification_actor_id"`
+	Payload     json.RawMessage       `db:"notification_payload"`
+	Read        bool                  `db:"notification_read"`
+	Created     int64                 `db:"notification_created"`
+}
+
+// Create creates a new notification.
+func (s *NotificationStore) Create(ctx context.Context, n *types.Notification) error {
+	const sqlQuery = `
+	INSERT INTO notifications (
+		 notification_principal_id
+		,notification_type
+		,notification_repo_id
+		,notification_resource_id
+		,notification_actor_id
+		,notification_payload
+		,notification_read
+		,notification_created
+	) VALUES (
+		 :notification_principal_id
+		,:notification_type
+		,:notification_repo_id
+		,:notification_resource_id
+		,:notification_actor_id
+		,:notification_payload
+		,:notification_read
+		,:notification_created
+	) RETURNING notification_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalNotification(n))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&n.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert notification query failed")
+	}
+
+	return nil
+}
+
+// ExistsSince returns true if the principal received a notification
+// of the same type about the same resource since the provided time.
+func (s *NotificationStore) ExistsSince(
+	ctx context.Context,
+	principalID int64,
+	notificationType enum.NotificationType,
+	resourceID int64,
+	since int64,
+) (bool, error) {
+	const sqlQuery = `
+		SELECT EXISTS(
+			SELECT 1
+			FROM notifications
+			WHERE notification_principal_id = $1
+				AND notification_type = $2
+				AND notification_resource_id = $3
+				AND notification_created >= $4
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var exists bool
+	err := db.QueryRowContext(ctx, sqlQuery, principalID, notificationType, resourceID, since).Scan(&exists)
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to check for recent notification")
+	}
+
+	return exists, nil
+}
+
+// Count returns the number of notifications of the principal matching the filter.
+func (s *NotificationStore) Count(
+	ctx context.Context,
+	principalID int64,
+	filter *types.NotificationFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("notifications").
+		Where("notification_principal_id = ?", principalID)
+
+	if filter.OnlyUnread {
+		stmt = stmt.Where("notification_read = ?", false)
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert count notifications query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing count notifications query")
+	}
+
+	return count, nil
+}
+
+// List returns the notifications of the principal matching the filter, newest first.
+func (s *NotificationStore) List(
+	ctx context.Context,
+	principalID int64,
+	filter *types.NotificationFilter,
+) ([]*types.Notification, error) {
+	stmt := database.Builder.
+		Select(notificationColumns).
+		From("notifications").
+		Where("notification_principal_id = ?", principalID)
+
+	if filter.OnlyUnread {
+		stmt = stmt.Where("notification_read = ?", false)
+	}
+
+	stmt = stmt.
+		Limit(database.Limit(filter.Size)).
+		Offset(database.Offset(filter.Page, filter.Size)).
+		OrderBy("notification_created DESC, notification_id DESC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list notifications query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*notification, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list notifications query")
+	}
+
+	return s.mapSliceNotification(ctx, dst)
+}
+
+// MarkRead marks the provided notifications of the principal as read.
+// If no notification IDs are provided, all notifications of the principal are marked as read.
+func (s *NotificationStore) MarkRead(ctx context.Context, principalID int64, ids []int64) (int64, error) {
+	stmt := database.Builder.
+		Update("notifications").
+		Set("notification_read", true).
+		Where("notification_principal_id = ?", principalID).
+		Where("notification_read = ?", false)
+
+	if len(ids) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"notification_id": ids})
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert mark notifications read query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing mark notifications read query")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated notifications")
+	}
+
+	return n, nil
+}
+
+func mapInternalNotification(n *types.Notification) *notification {
+	return &notification{
+		ID:          n.ID,
+		PrincipalID: n.PrincipalID,
+		Type:        n.Type,
+		RepoID:      n.RepoID,
+		ResourceID:  n.ResourceID,
+		ActorID:     null.IntFromPtr(n.ActorID),
+		Payload:     n.Payload,
+		Read:        n.Read,
+		Created:     n.Created,
+	}
+}
+
+func mapNotification(n *notification) *types.Notification {
+	return &types.Notification{
+		ID:          n.ID,
+		PrincipalID: n.PrincipalID,
+		Type:        n.Type,
+		RepoID:      n.RepoID,
+		ResourceID:  n.ResourceID,
+		ActorID:     n.ActorID.Ptr(),
+		Payload:     n.Payload,
+		Read:        n.Read,
+		Created:     n.Created,
+	}
+}
+
+func (s *NotificationStore) mapSliceNotification(
+	ctx context.Context,
+	notifications []*notification,
+) ([]*types.Notification, error) {
+	// collect all actor IDs
+	ids := make([]int64, 0, len(notifications))
+	for _, n := range notifications {
+		if n.ActorID.Valid {
+			ids = append(ids, n.ActorID.Int64)
+		}
+	}
+
+	// pull principal infos from cache
+	infoMap, err := s.pCache.Map(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load notification actors: %w", err)
+	}
+
+	// attach the principal infos back to the slice items
+	m := make([]*types.Notification, len(notifications))
+	for i, n := range notifications {
+		m[i] = mapNotification(n)
+		if principal, ok := infoMap[n.ActorID.Int64]; ok && n.ActorID.Valid {
+			m[i].Actor = principal
+		}
+	}
+
+	return m, nil
+}
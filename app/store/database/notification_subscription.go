@@ -0,0 +1,198 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.NotificationSubscriptionStore = (*NotificationSubscriptionStore)(nil)
+
+// NewNotificationSubscriptionStore returns a new NotificationSubscriptionStore.
+func NewNotificationSubscriptionStore(db *sqlx.DB) *NotificationSubscriptionStore {
+	return &NotificationSubscriptionStore{
+		db: db,
+	}
+}
+
+// NotificationSubscriptionStore implements store.NotificationSubscriptionStore backed by a relational database.
+type NotificationSubscriptionStore struct {
+	db *sqlx.DB
+}
+
+const (
+	notificationSubscriptionColumns = `
+		 notification_subscription_principal_id
+		,notification_subscription_resource_type
+		,notification_subscription_resource_id
+		,notification_subscription_subscribed
+		,notification_subscription_created
+		,notification_subscription_updated`
+)
+
+type notificationSubscription struct {
+	PrincipalID  int64                         `db:"notification_subscription_principal_id"`
+	ResourceType enum.SubscriptionResourceType `db:"notification_subscription_resource_type"`
+	ResourceID   int64                         `db:"notification_subscription_resource_id"`
+	Subscribed   bool                          `db:"notification_subscription_subscribed"`
+	Created      int64                         `db:"notification_subscription_created"`
+	Updated      int64                         `db:"notification_subscription_updated"`
+}
+
+// Find returns the subscription of the principal to the resource.
+func (s *NotificationSubscriptionStore) Find(
+	ctx context.Context,
+	principalID int64,
+	resourceType enum.SubscriptionResourceType,
+	resourceID int64,
+) (*types.NotificationSubscription, error) {
+	const sqlQuery = `
+		SELECT` + notificationSubscriptionColumns + `
+		FROM notification_subscriptions
+		WHERE notification_subscription_principal_id = $1
+			AND notification_subscription_resource_type = $2
+			AND notification_subscription_resource_id = $3`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &notificationSubscription{}
+	if err := db.GetContext(ctx, dst, sqlQuery, principalID, resourceType, resourceID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find notification subscription")
+	}
+
+	return mapNotificationSubscription(dst), nil
+}
+
+// Upsert creates the subscription or updates the subscribed flag of the existing one.
+func (s *NotificationSubscriptionStore) Upsert(ctx context.Context, sub *types.NotificationSubscription) error {
+	const sqlQuery = `
+		INSERT INTO notification_subscriptions (` + notificationSubscriptionColumns + `
+		) VALUES (
+			 :notification_subscription_principal_id
+			,:notification_subscription_resource_type
+			,:notification_subscription_resource_id
+			,:notification_subscription_subscribed
+			,:notification_subscription_created
+			,:notification_subscription_updated
+		)
+		ON CONFLICT (notification_subscription_principal_id,
+			notification_subscription_resource_type, notification_subscription_resource_id) DO
+		UPDATE SET
+			 notification_subscription_subscribed = EXCLUDED.notification_subscription_subscribed
+			,notification_subscription_updated = EXCLUDED.notification_subscription_updated
+		RETURNING notification_subscription_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapInternalNotificationSubscription(sub))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification subscription object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&sub.Created); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to upsert notification subscription")
+	}
+
+	return nil
+}
+
+// CreateIfNotExists creates the subscription unless the principal already has one for the resource.
+// It's used for automatic subscriptions, which must not override an explicit unsubscribe.
+func (s *NotificationSubscriptionStore) CreateIfNotExists(
+	ctx context.Context,
+	sub *types.NotificationSubscription,
+) error {
+	const sqlQuery = `
+		INSERT INTO notification_subscriptions (` + notificationSubscriptionColumns + `
+		) VALUES (
+			 :notification_subscription_principal_id
+			,:notification_subscription_resource_type
+			,:notification_subscription_resource_id
+			,:notification_subscription_subscribed
+			,:notification_subscription_created
+			,:notification_subscription_updated
+		)
+		ON CONFLICT DO NOTHING`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapInternalNotificationSubscription(sub))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification subscription object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to create notification subscription")
+	}
+
+	return nil
+}
+
+// ListByResource returns all subscriptions to the resource, including explicit unsubscribes.
+func (s *NotificationSubscriptionStore) ListByResource(
+	ctx context.Context,
+	resourceType enum.SubscriptionResourceType,
+	resourceID int64,
+) ([]*types.NotificationSubscription, error) {
+	const sqlQuery = `
+		SELECT` + notificationSubscriptionColumns + `
+		FROM notification_subscriptions
+		WHERE notification_subscription_resource_type = $1
+			AND notification_subscription_resource_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*notificationSubscription, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, resourceType, resourceID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list notification subscriptions")
+	}
+
+	result := make([]*types.NotificationSubscription, len(dst))
+	for i, sub := range dst {
+		result[i] = mapNotificationSubscription(sub)
+	}
+
+	return result, nil
+}
+
+func mapInternalNotificationSubscription(sub *types.NotificationSubscription) *notificationSubscription {
+	return &notificationSubscription{
+		PrincipalID:  sub.PrincipalID,
+		ResourceType: sub.ResourceType,
+		ResourceID:   sub.ResourceID,
+		Subscribed:   sub.Subscribed,
+		Created:      sub.Created,
+		Updated:      sub.Updated,
+	}
+}
+
+func mapNotificationSubscription(sub *notificationSubscription) *types.NotificationSubscription {
+	return &types.NotificationSubscription{
+		PrincipalID:  sub.PrincipalID,
+		ResourceType: sub.ResourceType,
+		ResourceID:   sub.ResourceID,
+		Subscribed:   sub.Subscribed,
+		Created:      sub.Created,
+		Updated:      sub.Updated,
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.OperationStore = (*OperationStore)(nil)
+
+// NewOperationStore returns a new OperationStore.
+func NewOperationStore(db *sqlx.DB) *OperationStore {
+	return &OperationStore{
+		db: db,
+	}
+}
+
+// OperationStore implements store.OperationStore backed by a relational database.
+type OperationStore struct {
+	db *sqlx.DB
+}
+
+const (
+	operationColumns = `
+		 operation_id
+		,operation_uid
+		,operation_type
+		,operation_resource_type
+		,operation_resource_id
+		,operation_job_uid
+		,operation_job_group_id
+		,operation_created_by
+		,operation_created`
+)
+
+// Create creates the operation. An existing operation that references the same jobs is replaced.
+func (s *OperationStore) Create(ctx context.Context, op *types.Operation) error {
+	const sqlQuery = `
+		INSERT INTO operations (
+			 operation_uid
+			,operation_type
+			,operation_resource_type
+			,operation_resource_id
+			,operation_job_uid
+			,operation_job_group_id
+			,operation_created_by
+			,operation_created
+		) values (
+			 :operation_uid
+			,:operation_type
+			,:operation_resource_type
+			,:operation_resource_id
+			,:operation_job_uid
+			,:operation_job_group_id
+			,:operation_created_by
+			,:operation_created
+		)
+		ON CONFLICT (operation_job_uid, operation_job_group_id) DO
+		UPDATE SET
+			 operation_uid = EXCLUDED.operation_uid
+			,operation_type = EXCLUDED.operation_type
+			,operation_resource_type = EXCLUDED.operation_resource_type
+			,operation_resource_id = EXCLUDED.operation_resource_id
+			,operation_created_by = EXCLUDED.operation_created_by
+			,operation_created = EXCLUDED.operation_created
+		RETURNING operation_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, op)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind operation object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&op.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert operation")
+	}
+
+	return nil
+}
+
+// FindByUID finds the operation by its UID.
+func (s *OperationStore) FindByUID(ctx context.Context, uid string) (*types.Operation, error) {
+	const sqlQuery = `
+		SELECT` + operationColumns + `
+		FROM operations
+		WHERE operation_uid = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &types.Operation{}
+	if err := db.GetContext(ctx, dst, sqlQuery, uid); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find operation")
+	}
+
+	return dst, nil
+}
+
+// Purge deletes all operations created before the provided time.
+func (s *OperationStore) Purge(ctx context.Context, before int64) (int64, error) {
+	const sqlQuery = `
+		DELETE FROM operations
+		WHERE operation_created < $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, before)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to purge operations")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to get number of purged operations")
+	}
+
+	return n, nil
+}
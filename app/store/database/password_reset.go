@@ -0,0 +1,113 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PasswordResetStore = (*PasswordResetStore)(nil)
+
+// NewPasswordResetStore returns a new PasswordResetStore.
+func NewPasswordResetStore(db *sqlx.DB) *PasswordResetStore {
+	return &PasswordResetStore{
+		db: db,
+	}
+}
+
+// PasswordResetStore implements store.PasswordResetStore backed by a relational database.
+type PasswordResetStore struct {
+	db *sqlx.DB
+}
+
+const (
+	passwordResetColumns = `
+		 password_reset_principal_id
+		,password_reset_token_hash
+		,password_reset_expires
+		,password_reset_created_by
+		,password_reset_created`
+)
+
+// FindByTokenHash finds the password reset by the hash of its token.
+func (s *PasswordResetStore) FindByTokenHash(ctx context.Context, tokenHash string) (*types.PasswordReset, error) {
+	const sqlQuery = `
+		SELECT` + passwordResetColumns + `
+		FROM password_resets
+		WHERE password_reset_token_hash = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &types.PasswordReset{}
+	if err := db.GetContext(ctx, dst, sqlQuery, tokenHash); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find password reset")
+	}
+
+	return dst, nil
+}
+
+// Upsert creates the password reset of the principal or replaces the existing one.
+func (s *PasswordResetStore) Upsert(ctx context.Context, reset *types.PasswordReset) error {
+	const sqlQuery = `
+		INSERT INTO password_resets (` + passwordResetColumns + `
+		) values (
+			 :password_reset_principal_id
+			,:password_reset_token_hash
+			,:password_reset_expires
+			,:password_reset_created_by
+			,:password_reset_created
+		)
+		ON CONFLICT (password_reset_principal_id) DO
+		UPDATE SET
+			 password_reset_token_hash = EXCLUDED.password_reset_token_hash
+			,password_reset_expires = EXCLUDED.password_reset_expires
+			,password_reset_created_by = EXCLUDED.password_reset_created_by
+			,password_reset_created = EXCLUDED.password_reset_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, reset)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind password reset object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to upsert password reset")
+	}
+
+	return nil
+}
+
+// Delete deletes the password reset of the principal.
+func (s *PasswordResetStore) Delete(ctx context.Context, principalID int64) error {
+	const sqlQuery = `
+		DELETE FROM password_resets
+		WHERE password_reset_principal_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, principalID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete password reset")
+	}
+
+	return nil
+}
@@ -48,6 +48,9 @@ const (
 	,pipeline_repo_id
 	,pipeline_default_branch
 	,pipeline_config_path
+	,pipeline_concurrency_limit
+	,pipeline_cancel_superseded
+	,pipeline_timeout
 	,pipeline_created
 	,pipeline_updated
 	,pipeline_version
@@ -95,6 +98,28 @@ func (s *pipelineStore) FindByIdentifier(
 	return dst, nil
 }
 
+// IdentifierExists returns true if a pipeline of the repo, other than the pipeline with the excluded ID,
+// has the provided identifier (compared case-insensitively).
+func (s *pipelineStore) IdentifierExists(
+	ctx context.Context,
+	repoID int64,
+	identifier string,
+	excludeID int64,
+) (bool, error) {
+	const existsQueryStmt = `
+		SELECT EXISTS (
+			SELECT 1 FROM pipelines
+			WHERE pipeline_repo_id = $1 AND LOWER(pipeline_uid) = LOWER($2) AND pipeline_id <> $3
+		)`
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var exists bool
+	if err := db.GetContext(ctx, &exists, existsQueryStmt, repoID, identifier, excludeID); err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to check if pipeline identifier exists")
+	}
+	return exists, nil
+}
+
 // Create creates a pipeline.
 func (s *pipelineStore) Create(ctx context.Context, pipeline *types.Pipeline) error {
 	const pipelineInsertStmt = `
@@ -107,6 +132,9 @@ func (s *pipelineStore) Create(ctx context.Context, pipeline *types.Pipeline) er
 		,pipeline_created_by
 		,pipeline_default_branch
 		,pipeline_config_path
+		,pipeline_concurrency_limit
+		,pipeline_cancel_superseded
+		,pipeline_timeout
 		,pipeline_created
 		,pipeline_updated
 		,pipeline_version
@@ -119,6 +147,9 @@ func (s *pipelineStore) Create(ctx context.Context, pipeline *types.Pipeline) er
 		:pipeline_created_by,
 		:pipeline_default_branch,
 		:pipeline_config_path,
+		:pipeline_concurrency_limit,
+		:pipeline_cancel_superseded,
+		:pipeline_timeout,
 		:pipeline_created,
 		:pipeline_updated,
 		:pipeline_version
@@ -148,6 +179,9 @@ func (s *pipelineStore) Update(ctx context.Context, p *types.Pipeline) error {
 		pipeline_disabled = :pipeline_disabled,
 		pipeline_default_branch = :pipeline_default_branch,
 		pipeline_config_path = :pipeline_config_path,
+		pipeline_concurrency_limit = :pipeline_concurrency_limit,
+		pipeline_cancel_superseded = :pipeline_cancel_superseded,
+		pipeline_timeout = :pipeline_timeout,
 		pipeline_updated = :pipeline_updated,
 		pipeline_version = :pipeline_version
 	WHERE pipeline_id = :pipeline_id AND pipeline_version = :pipeline_version - 1`
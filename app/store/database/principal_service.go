@@ -30,7 +30,9 @@ import (
 // It is required to allow storing transformed UIDs used for uniquness constraints and searching.
 type service struct {
 	types.Service
-	UIDUnique string `db:"principal_uid_unique"`
+	UIDUnique          string `db:"principal_uid_unique"`
+	UIDSortKey         string `db:"principal_uid_sort_key"`
+	DisplayNameSortKey string `db:"principal_display_name_sort_key"`
 }
 
 // service doesn't have any extra columns.
@@ -85,8 +87,10 @@ func (s *PrincipalStore) CreateService(ctx context.Context, svc *types.Service)
 			principal_type
 			,principal_uid
 			,principal_uid_unique
+			,principal_uid_sort_key
 			,principal_email
 			,principal_display_name
+			,principal_display_name_sort_key
 			,principal_admin
 			,principal_blocked
 			,principal_salt
@@ -96,8 +100,10 @@ func (s *PrincipalStore) CreateService(ctx context.Context, svc *types.Service)
 			'service'
 			,:principal_uid
 			,:principal_uid_unique
+			,:principal_uid_sort_key
 			,:principal_email
 			,:principal_display_name
+			,:principal_display_name_sort_key
 			,:principal_admin
 			,:principal_blocked
 			,:principal_salt
@@ -131,8 +137,10 @@ func (s *PrincipalStore) UpdateService(ctx context.Context, svc *types.Service)
 		SET
 			 principal_uid	          = :principal_uid
 			,principal_uid_unique     = :principal_uid_unique
+			,principal_uid_sort_key   = :principal_uid_sort_key
 			,principal_email          = :principal_email
 			,principal_display_name   = :principal_display_name
+			,principal_display_name_sort_key = :principal_display_name_sort_key
 			,principal_admin          = :principal_admin
 			,principal_blocked        = :principal_blocked
 			,principal_updated        = :principal_updated
@@ -177,7 +185,7 @@ func (s *PrincipalStore) DeleteService(ctx context.Context, id int64) error {
 func (s *PrincipalStore) ListServices(ctx context.Context) ([]*types.Service, error) {
 	const sqlQuery = serviceSelectBase + `
 		WHERE principal_type = 'service'
-		ORDER BY principal_uid ASC`
+		ORDER BY principal_uid_sort_key ASC, principal_id ASC`
 
 	db := dbtx.GetAccessor(ctx, s.db)
 
@@ -232,8 +240,10 @@ func (s *PrincipalStore) mapToDBservice(svc *types.Service) (*service, error) {
 		return nil, fmt.Errorf("failed to transform service UID: %w", err)
 	}
 	dbService := &service{
-		Service:   *svc,
-		UIDUnique: uidUnique,
+		Service:            *svc,
+		UIDUnique:          uidUnique,
+		UIDSortKey:         database.SortKey(svc.UID),
+		DisplayNameSortKey: database.SortKey(svc.DisplayName),
 	}
 
 	return dbService, nil
@@ -23,7 +23,9 @@ import (
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+	"github.com/harness/gitness/types/listfilter"
 
+	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 )
 
@@ -31,13 +33,28 @@ import (
 // It is required to allow storing transformed UIDs used for uniquness constraints and searching.
 type serviceAccount struct {
 	types.ServiceAccount
-	UIDUnique string `db:"principal_uid_unique"`
+	UIDUnique          string `db:"principal_uid_unique"`
+	UIDSortKey         string `db:"principal_uid_sort_key"`
+	DisplayNameSortKey string `db:"principal_display_name_sort_key"`
 }
 
 const serviceAccountColumns = principalCommonColumns + `
 	,principal_sa_parent_type
 	,principal_sa_parent_id`
 
+// serviceAccountListColumns maps the fields of service account list filters to their columns.
+var serviceAccountListColumns = listFilterColumns{
+	id: "principal_id",
+	sort: map[listfilter.Field]string{
+		types.ServiceAccountFieldUID:     "principal_uid_sort_key",
+		types.ServiceAccountFieldCreated: "principal_created",
+	},
+	filter: map[listfilter.Field]string{
+		types.ServiceAccountFieldUID:         "principal_uid",
+		types.ServiceAccountFieldDisplayName: "principal_display_name",
+	},
+}
+
 const serviceAccountSelectBase = `
 	SELECT` + serviceAccountColumns + `
 	FROM principals`
@@ -86,8 +103,10 @@ func (s *PrincipalStore) CreateServiceAccount(ctx context.Context, sa *types.Ser
 			principal_type
 			,principal_uid
 			,principal_uid_unique
+			,principal_uid_sort_key
 			,principal_email
 			,principal_display_name
+			,principal_display_name_sort_key
 			,principal_admin
 			,principal_blocked
 			,principal_salt
@@ -99,8 +118,10 @@ func (s *PrincipalStore) CreateServiceAccount(ctx context.Context, sa *types.Ser
 			'serviceaccount'
 			,:principal_uid
 			,:principal_uid_unique
+			,:principal_uid_sort_key
 			,:principal_email
 			,:principal_display_name
+			,:principal_display_name_sort_key
 			,false
 			,:principal_blocked
 			,:principal_salt
@@ -136,8 +157,10 @@ func (s *PrincipalStore) UpdateServiceAccount(ctx context.Context, sa *types.Ser
 		SET
 			 principal_uid	          = :principal_uid
 			,principal_uid_unique     = :principal_uid_unique
+			,principal_uid_sort_key   = :principal_uid_sort_key
 			,principal_email          = :principal_email
 			,principal_display_name   = :principal_display_name
+			,principal_display_name_sort_key = :principal_display_name_sort_key
 			,principal_blocked        = :principal_blocked
 			,principal_salt           = :principal_salt
 			,principal_updated        = :principal_updated
@@ -178,16 +201,31 @@ func (s *PrincipalStore) DeleteServiceAccount(ctx context.Context, id int64) err
 }
 
 // ListServiceAccounts returns a list of service accounts for a specific parent.
-func (s *PrincipalStore) ListServiceAccounts(ctx context.Context, parentType enum.ParentResourceType,
-	parentID int64) ([]*types.ServiceAccount, error) {
-	const sqlQuery = serviceAccountSelectBase + `
-		WHERE principal_type = 'serviceaccount' AND principal_sa_parent_type = $1 AND principal_sa_parent_id = $2
-		ORDER BY principal_uid ASC`
+func (s *PrincipalStore) ListServiceAccounts(
+	ctx context.Context,
+	parentType enum.ParentResourceType,
+	parentID int64,
+	filter *listfilter.Filter,
+) ([]*types.ServiceAccount, error) {
+	stmt := database.Builder.
+		Select(serviceAccountColumns).
+		From("principals").
+		Where("principal_type = 'serviceaccount'").
+		Where("principal_sa_parent_type = ?", parentType).
+		Where("principal_sa_parent_id = ?", parentID)
+
+	stmt = applyListFilterConditions(stmt, filter, serviceAccountListColumns)
+	stmt = applyListFilterOrder(stmt, filter, serviceAccountListColumns)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
 
 	db := dbtx.GetAccessor(ctx, s.db)
 
 	dst := []*serviceAccount{}
-	err := db.SelectContext(ctx, &dst, sqlQuery, parentType, parentID)
+	err = db.SelectContext(ctx, &dst, sql, args...)
 	if err != nil {
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing default list query")
 	}
@@ -237,8 +275,10 @@ func (s *PrincipalStore) mapToDBserviceAccount(sa *types.ServiceAccount) (*servi
 		return nil, fmt.Errorf("failed to transform service account UID: %w", err)
 	}
 	dbSA := &serviceAccount{
-		ServiceAccount: *sa,
-		UIDUnique:      uidUnique,
+		ServiceAccount:     *sa,
+		UIDUnique:          uidUnique,
+		UIDSortKey:         database.SortKey(sa.UID),
+		DisplayNameSortKey: database.SortKey(sa.DisplayName),
 	}
 
 	return dbSA, nil
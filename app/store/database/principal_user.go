@@ -33,7 +33,9 @@ import (
 // It is required to allow storing transformed UIDs used for uniquness constraints and searching.
 type user struct {
 	types.User
-	UIDUnique string `db:"principal_uid_unique"`
+	UIDUnique          string `db:"principal_uid_unique"`
+	UIDSortKey         string `db:"principal_uid_sort_key"`
+	DisplayNameSortKey string `db:"principal_display_name_sort_key"`
 }
 
 const userColumns = principalCommonColumns + `
@@ -103,8 +105,10 @@ func (s *PrincipalStore) CreateUser(ctx context.Context, user *types.User) error
 			principal_type
 			,principal_uid
 			,principal_uid_unique
+			,principal_uid_sort_key
 			,principal_email
 			,principal_display_name
+			,principal_display_name_sort_key
 			,principal_admin
 			,principal_blocked
 			,principal_salt
@@ -115,8 +119,10 @@ func (s *PrincipalStore) CreateUser(ctx context.Context, user *types.User) error
 			'user'
 			,:principal_uid
 			,:principal_uid_unique
+			,:principal_uid_sort_key
 			,:principal_email
 			,:principal_display_name
+			,:principal_display_name_sort_key
 			,:principal_admin
 			,:principal_blocked
 			,:principal_salt
@@ -151,8 +157,10 @@ func (s *PrincipalStore) UpdateUser(ctx context.Context, user *types.User) error
 		SET
 			 principal_uid	          = :principal_uid
 			,principal_uid_unique     = :principal_uid_unique
+			,principal_uid_sort_key   = :principal_uid_sort_key
 			,principal_email          = :principal_email
 			,principal_display_name   = :principal_display_name
+			,principal_display_name_sort_key = :principal_display_name_sort_key
 			,principal_admin          = :principal_admin
 			,principal_blocked        = :principal_blocked
 			,principal_salt           = :principal_salt
@@ -196,13 +204,18 @@ func (s *PrincipalStore) DeleteUser(ctx context.Context, id int64) error {
 
 // ListUsers returns a list of users.
 func (s *PrincipalStore) ListUsers(ctx context.Context, opts *types.UserFilter) ([]*types.User, error) {
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 	dst := []*user{}
 
 	stmt := database.Builder.
 		Select(userColumns).
 		From("principals").
 		Where("principal_type = 'user'")
+
+	if opts.Blocked != nil {
+		stmt = stmt.Where("principal_blocked = ?", *opts.Blocked)
+	}
+
 	stmt = stmt.Limit(database.Limit(opts.Size))
 	stmt = stmt.Offset(database.Offset(opts.Page, opts.Size))
 
@@ -216,7 +229,7 @@ func (s *PrincipalStore) ListUsers(ctx context.Context, opts *types.UserFilter)
 		// NOTE: string concatenation is safe because the
 		// order attribute is an enum and is not user-defined,
 		// and is therefore not subject to injection attacks.
-		stmt = stmt.OrderBy("principal_display_name " + order.String())
+		stmt = stmt.OrderBy("principal_display_name_sort_key " + order.String())
 	case enum.UserAttrCreated:
 		stmt = stmt.OrderBy("principal_created " + order.String())
 	case enum.UserAttrUpdated:
@@ -224,17 +237,20 @@ func (s *PrincipalStore) ListUsers(ctx context.Context, opts *types.UserFilter)
 	case enum.UserAttrEmail:
 		stmt = stmt.OrderBy("LOWER(principal_email) " + order.String())
 	case enum.UserAttrUID:
-		stmt = stmt.OrderBy("principal_uid " + order.String())
+		stmt = stmt.OrderBy("principal_uid_sort_key " + order.String())
 	case enum.UserAttrAdmin:
 		stmt = stmt.OrderBy("principal_admin " + order.String())
 	}
 
-	sql, _, err := stmt.ToSql()
+	// principal ID as tiebreaker guarantees a stable order across pages.
+	stmt = stmt.OrderBy("principal_id " + order.String())
+
+	sql, args, err := stmt.ToSql()
 	if err != nil {
 		return nil, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	if err = db.SelectContext(ctx, &dst, sql); err != nil {
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing custom list query")
 	}
 
@@ -252,6 +268,10 @@ func (s *PrincipalStore) CountUsers(ctx context.Context, opts *types.UserFilter)
 		stmt = stmt.Where("principal_admin = ?", opts.Admin)
 	}
 
+	if opts.Blocked != nil {
+		stmt = stmt.Where("principal_blocked = ?", *opts.Blocked)
+	}
+
 	sql, args, err := stmt.ToSql()
 	if err != nil {
 		return 0, errors.Wrap(err, "Failed to convert query to sql")
@@ -291,8 +311,10 @@ func (s *PrincipalStore) mapToDBUser(usr *types.User) (*user, error) {
 		return nil, fmt.Errorf("failed to transform user UID: %w", err)
 	}
 	dbUser := &user{
-		User:      *usr,
-		UIDUnique: uidUnique,
+		User:               *usr,
+		UIDUnique:          uidUnique,
+		UIDSortKey:         database.SortKey(usr.UID),
+		DisplayNameSortKey: database.SortKey(usr.DisplayName),
 	}
 
 	return dbUser, nil
@@ -0,0 +1,107 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_ListOnlyPublic(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+	publicAccessStore := database.NewPublicAccessStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 2, 1)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 3, 1)
+	for repoID := int64(1); repoID <= 4; repoID++ {
+		createRepo(ctx, t, repoStore, repoID, 1, 0)
+	}
+
+	for _, repoID := range []int64{2, 4} {
+		if err := publicAccessStore.Create(ctx, enum.PublicResourceTypeRepo, repoID); err != nil {
+			t.Fatalf("failed to make repo %d public: %v", repoID, err)
+		}
+	}
+	if err := publicAccessStore.Create(ctx, enum.PublicResourceTypeSpace, 3); err != nil {
+		t.Fatalf("failed to make space public: %v", err)
+	}
+
+	repoFilter := &types.RepoFilter{Page: 1, Size: 10, OnlyPublic: true}
+	repos, err := repoStore.List(ctx, 1, repoFilter)
+	if err != nil {
+		t.Fatalf("failed to list repos: %v", err)
+	}
+	repoIDs := make([]int64, len(repos))
+	for i, repo := range repos {
+		repoIDs[i] = repo.ID
+	}
+	slices.Sort(repoIDs)
+	if !slices.Equal(repoIDs, []int64{2, 4}) {
+		t.Errorf("expected only public repos [2 4], got %v", repoIDs)
+	}
+
+	repoCount, err := repoStore.Count(ctx, 1, repoFilter)
+	if err != nil {
+		t.Fatalf("failed to count repos: %v", err)
+	}
+	if repoCount != 2 {
+		t.Errorf("expected 2 public repos, got %d", repoCount)
+	}
+
+	repoCount, err = repoStore.Count(ctx, 1, &types.RepoFilter{})
+	if err != nil {
+		t.Fatalf("failed to count repos: %v", err)
+	}
+	if repoCount != 4 {
+		t.Errorf("expected 4 repos without filter, got %d", repoCount)
+	}
+
+	spaceFilter := &types.SpaceFilter{Page: 1, Size: 10, OnlyPublic: true}
+	spaces, err := spaceStore.List(ctx, 1, spaceFilter)
+	if err != nil {
+		t.Fatalf("failed to list spaces: %v", err)
+	}
+	if len(spaces) != 1 || spaces[0].ID != 3 {
+		t.Errorf("expected only public space 3, got %d spaces", len(spaces))
+	}
+
+	spaceCount, err := spaceStore.Count(ctx, 1, spaceFilter)
+	if err != nil {
+		t.Fatalf("failed to count spaces: %v", err)
+	}
+	if spaceCount != 1 {
+		t.Errorf("expected 1 public space, got %d", spaceCount)
+	}
+
+	spaceCount, err = spaceStore.Count(ctx, 1, &types.SpaceFilter{})
+	if err != nil {
+		t.Fatalf("failed to count spaces: %v", err)
+	}
+	if spaceCount != 2 {
+		t.Errorf("expected 2 spaces without filter, got %d", spaceCount)
+	}
+}
@@ -50,11 +50,14 @@ type publicKey struct {
 
 	PrincipalID int64 `db:"public_key_principal_id"`
 
-	Created  int64    `db:"public_key_created"`
-	Verified null.Int `db:"public_key_verified"`
+	Created    int64    `db:"public_key_created"`
+	Verified   null.Int `db:"public_key_verified"`
+	LastUsed   null.Int `db:"public_key_last_used"`
+	ValidUntil null.Int `db:"public_key_valid_until"`
 
-	Identifier string `db:"public_key_identifier"`
-	Usage      string `db:"public_key_usage"`
+	Identifier        string `db:"public_key_identifier"`
+	IdentifierSortKey string `db:"public_key_identifier_sort_key"`
+	Usage             string `db:"public_key_usage"`
 
 	Fingerprint string `db:"public_key_fingerprint"`
 	Content     string `db:"public_key_content"`
@@ -68,6 +71,8 @@ const (
 		,public_key_principal_id
 		,public_key_created
 		,public_key_verified
+		,public_key_last_used
+		,public_key_valid_until
 		,public_key_identifier
 		,public_key_usage
 		,public_key_fingerprint
@@ -125,7 +130,10 @@ func (s PublicKeyStore) Create(ctx context.Context, key *types.PublicKey) error
 			 public_key_principal_id
 			,public_key_created
 			,public_key_verified
+			,public_key_last_used
+			,public_key_valid_until
 			,public_key_identifier
+			,public_key_identifier_sort_key
 			,public_key_usage
 			,public_key_fingerprint
 			,public_key_content
@@ -135,7 +143,10 @@ func (s PublicKeyStore) Create(ctx context.Context, key *types.PublicKey) error
 			 :public_key_principal_id
 			,:public_key_created
 			,:public_key_verified
+			,:public_key_last_used
+			,:public_key_valid_until
 			,:public_key_identifier
+			,:public_key_identifier_sort_key
 			,:public_key_usage
 			,:public_key_fingerprint
 			,:public_key_content
@@ -184,15 +195,18 @@ func (s PublicKeyStore) DeleteByIdentifier(ctx context.Context, principalID int6
 	return nil
 }
 
-// MarkAsVerified updates the public key to mark it as verified.
-func (s PublicKeyStore) MarkAsVerified(ctx context.Context, id int64, verified int64) error {
+// MarkAsUsed updates the last used timestamp of the public key.
+// The verified timestamp is set as well, if the key has never been used before.
+func (s PublicKeyStore) MarkAsUsed(ctx context.Context, id int64, used int64) error {
 	const sqlQuery = `
 		UPDATE public_keys
-		SET public_key_verified = $1
+		SET
+			 public_key_verified = COALESCE(public_key_verified, $1)
+			,public_key_last_used = $1
 		WHERE public_key_id = $2`
 
-	if _, err := dbtx.GetAccessor(ctx, s.db).ExecContext(ctx, sqlQuery, verified, id); err != nil {
-		return database.ProcessSQLErrorf(ctx, err, "Failed to mark public key as varified")
+	if _, err := dbtx.GetAccessor(ctx, s.db).ExecContext(ctx, sqlQuery, used, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to mark public key as used")
 	}
 
 	return nil
@@ -307,26 +321,32 @@ func (PublicKeyStore) applySortFilter(
 
 	switch filter.Sort {
 	case enum.PublicKeySortIdentifier:
-		stmt = stmt.OrderBy("public_key_identifier " + order.String())
+		stmt = stmt.OrderBy("public_key_identifier_sort_key " + order.String())
 	case enum.PublicKeySortCreated:
 		stmt = stmt.OrderBy("public_key_created " + order.String())
 	}
 
+	// public key ID as tiebreaker guarantees a stable order across pages.
+	stmt = stmt.OrderBy("public_key_id " + order.String())
+
 	return stmt
 }
 
 func mapToInternalPublicKey(in *types.PublicKey) publicKey {
 	return publicKey{
-		ID:          in.ID,
-		PrincipalID: in.PrincipalID,
-		Created:     in.Created,
-		Verified:    null.IntFromPtr(in.Verified),
-		Identifier:  in.Identifier,
-		Usage:       string(in.Usage),
-		Fingerprint: in.Fingerprint,
-		Content:     in.Content,
-		Comment:     in.Comment,
-		Type:        in.Type,
+		ID:                in.ID,
+		PrincipalID:       in.PrincipalID,
+		Created:           in.Created,
+		Verified:          null.IntFromPtr(in.Verified),
+		LastUsed:          null.IntFromPtr(in.LastUsed),
+		ValidUntil:        null.IntFromPtr(in.ValidUntil),
+		Identifier:        in.Identifier,
+		IdentifierSortKey: database.SortKey(in.Identifier),
+		Usage:             string(in.Usage),
+		Fingerprint:       in.Fingerprint,
+		Content:           in.Content,
+		Comment:           in.Comment,
+		Type:              in.Type,
 	}
 }
 
@@ -336,6 +356,8 @@ func mapToPublicKey(in *publicKey) types.PublicKey {
 		PrincipalID: in.PrincipalID,
 		Created:     in.Created,
 		Verified:    in.Verified.Ptr(),
+		LastUsed:    in.LastUsed.Ptr(),
+		ValidUntil:  in.ValidUntil.Ptr(),
 		Identifier:  in.Identifier,
 		Usage:       enum.PublicKeyUsage(in.Usage),
 		Fingerprint: in.Fingerprint,
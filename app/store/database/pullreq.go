@@ -446,7 +446,7 @@ func (s *PullReqStore) Count(ctx context.Context, opts *types.PullReqFilter) (in
 		return 0, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	var count int64
 	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
@@ -477,7 +477,7 @@ func (s *PullReqStore) List(ctx context.Context, opts *types.PullReqFilter) ([]*
 
 	dst := make([]*pullReq, 0)
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing custom list query")
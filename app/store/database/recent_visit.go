@@ -0,0 +1,184 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RecentVisitStore = (*RecentVisitStore)(nil)
+
+// NewRecentVisitStore returns a new RecentVisitStore.
+func NewRecentVisitStore(db *sqlx.DB) *RecentVisitStore {
+	return &RecentVisitStore{
+		db: db,
+	}
+}
+
+// RecentVisitStore implements store.RecentVisitStore backed by a relational database.
+type RecentVisitStore struct {
+	db *sqlx.DB
+}
+
+const (
+	recentVisitColumns = `
+		 recent_visit_principal_id
+		,recent_visit_type
+		,recent_visit_resource_id
+		,recent_visit_visited`
+)
+
+type recentVisit struct {
+	PrincipalID int64                `db:"recent_visit_principal_id"`
+	Type        enum.RecentVisitType `db:"recent_visit_type"`
+	ResourceID  int64                `db:"recent_visit_resource_id"`
+	Visited     int64                `db:"recent_visit_visited"`
+}
+
+// UpsertMany creates the provided visits or updates the visited timestamp of the existing ones.
+func (s *RecentVisitStore) UpsertMany(ctx context.Context, visits []types.RecentVisit) error {
+	if len(visits) == 0 {
+		return nil
+	}
+
+	stmt := database.Builder.
+		Insert("").
+		Into("recent_visits").
+		Columns(
+			"recent_visit_principal_id",
+			"recent_visit_type",
+			"recent_visit_resource_id",
+			"recent_visit_visited",
+		)
+
+	for _, visit := range visits {
+		stmt = stmt.Values(visit.PrincipalID, visit.Type, visit.ResourceID, visit.Visited)
+	}
+
+	stmt = stmt.Suffix(`
+		ON CONFLICT (recent_visit_principal_id, recent_visit_type, recent_visit_resource_id) DO
+		UPDATE SET
+			recent_visit_visited = EXCLUDED.recent_visit_visited`)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to upsert recent visits")
+	}
+
+	return nil
+}
+
+// List returns the most recent visits of the principal, optionally filtered by type.
+func (s *RecentVisitStore) List(
+	ctx context.Context,
+	principalID int64,
+	filter *types.RecentVisitFilter,
+	limit int,
+) ([]types.RecentVisit, error) {
+	stmt := database.Builder.
+		Select(recentVisitColumns).
+		From("recent_visits").
+		Where("recent_visit_principal_id = ?", principalID).
+		OrderBy("recent_visit_visited DESC").
+		Limit(uint64(limit))
+
+	if filter.Type != "" {
+		stmt = stmt.Where("recent_visit_type = ?", filter.Type)
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*recentVisit, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list recent visits")
+	}
+
+	return mapRecentVisits(dst), nil
+}
+
+// Delete removes the visit of the resource by the principal.
+func (s *RecentVisitStore) Delete(
+	ctx context.Context,
+	principalID int64,
+	visitType enum.RecentVisitType,
+	resourceID int64,
+) error {
+	const sqlQuery = `
+		DELETE FROM recent_visits
+		WHERE recent_visit_principal_id = $1 AND recent_visit_type = $2 AND recent_visit_resource_id = $3`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, principalID, visitType, resourceID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete recent visit")
+	}
+
+	return nil
+}
+
+// Trim removes all but the provided number of most recent visits of the principal.
+func (s *RecentVisitStore) Trim(ctx context.Context, principalID int64, keep int) error {
+	const sqlQuery = `
+		DELETE FROM recent_visits
+		WHERE recent_visit_principal_id = $1 AND recent_visit_visited < (
+			SELECT recent_visit_visited
+			FROM recent_visits
+			WHERE recent_visit_principal_id = $1
+			ORDER BY recent_visit_visited DESC
+			LIMIT 1 OFFSET $2
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, principalID, keep-1); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to trim recent visits")
+	}
+
+	return nil
+}
+
+func mapRecentVisits(visits []*recentVisit) []types.RecentVisit {
+	result := make([]types.RecentVisit, len(visits))
+	for i, visit := range visits {
+		result[i] = types.RecentVisit{
+			PrincipalID: visit.PrincipalID,
+			Type:        visit.Type,
+			ResourceID:  visit.ResourceID,
+			Visited:     visit.Visited,
+		}
+	}
+
+	return result
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+	"github.com/harness/gitness/types/listfilter"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/guregu/null"
@@ -66,12 +67,18 @@ type repository struct {
 	Version     int64    `db:"repo_version"`
 	ParentID    int64    `db:"repo_parent_id"`
 	Identifier  string   `db:"repo_uid"`
+	UIDSortKey  string   `db:"repo_uid_sort_key"`
 	Description string   `db:"repo_description"`
+	Website     string   `db:"repo_website"`
+	ContactID   null.Int `db:"repo_contact_id"`
+	ShowReadme  bool     `db:"repo_show_readme"`
 	CreatedBy   int64    `db:"repo_created_by"`
 	Created     int64    `db:"repo_created"`
 	Updated     int64    `db:"repo_updated"`
 	Deleted     null.Int `db:"repo_deleted"`
 
+	LastActivity int64 `db:"repo_last_activity"`
+
 	Size        int64 `db:"repo_size"`
 	SizeUpdated int64 `db:"repo_size_updated"`
 
@@ -85,9 +92,11 @@ type repository struct {
 	NumClosedPulls int `db:"repo_num_closed_pulls"`
 	NumOpenPulls   int `db:"repo_num_open_pulls"`
 	NumMergedPulls int `db:"repo_num_merged_pulls"`
+	NumStars       int `db:"repo_num_stars"`
 
-	State   enum.RepoState `db:"repo_state"`
-	IsEmpty bool           `db:"repo_is_empty"`
+	State      enum.RepoState `db:"repo_state"`
+	StateUntil int64          `db:"repo_state_until"`
+	IsEmpty    bool           `db:"repo_is_empty"`
 }
 
 const (
@@ -97,10 +106,14 @@ const (
 		,repo_parent_id
 		,repo_uid
 		,repo_description
+		,repo_website
+		,repo_contact_id
+		,repo_show_readme
 		,repo_created_by
 		,repo_created
 		,repo_updated
 		,repo_deleted
+		,repo_last_activity
 		,repo_size
 		,repo_size_updated
 		,repo_git_uid
@@ -112,7 +125,9 @@ const (
 		,repo_num_closed_pulls
 		,repo_num_open_pulls
 		,repo_num_merged_pulls
+		,repo_num_stars
 		,repo_state
+		,repo_state_until
 		,repo_is_empty`
 )
 
@@ -220,11 +235,16 @@ func (s *RepoStore) Create(ctx context.Context, repo *types.Repository) error {
 			repo_version                      
 			,repo_parent_id
 			,repo_uid
+			,repo_uid_sort_key
 			,repo_description
+			,repo_website
+			,repo_contact_id
+			,repo_show_readme
 			,repo_created_by
 			,repo_created
 			,repo_updated
 			,repo_deleted
+			,repo_last_activity
 			,repo_size
 			,repo_size_updated	
 			,repo_git_uid
@@ -237,16 +257,22 @@ func (s *RepoStore) Create(ctx context.Context, repo *types.Repository) error {
 			,repo_num_open_pulls
 			,repo_num_merged_pulls
 			,repo_state
+			,repo_state_until
 			,repo_is_empty
 		) values (
 			:repo_version
 			,:repo_parent_id
 			,:repo_uid
+			,:repo_uid_sort_key
 			,:repo_description
+			,:repo_website
+			,:repo_contact_id
+			,:repo_show_readme
 			,:repo_created_by
 			,:repo_created
 			,:repo_updated
 			,:repo_deleted
+			,:repo_last_activity
 			,:repo_size
 			,:repo_size_updated
 			,:repo_git_uid
@@ -259,9 +285,15 @@ func (s *RepoStore) Create(ctx context.Context, repo *types.Repository) error {
 			,:repo_num_open_pulls
 			,:repo_num_merged_pulls
 			,:repo_state
+			,:repo_state_until
 			,:repo_is_empty
 		) RETURNING repo_id`
 
+	// a new repository counts as activity
+	if repo.LastActivity == 0 {
+		repo.LastActivity = repo.Created
+	}
+
 	db := dbtx.GetAccessor(ctx, s.db)
 
 	// insert repo first so we get id
@@ -292,8 +324,12 @@ func (s *RepoStore) Update(ctx context.Context, repo *types.Repository) error {
 			,repo_deleted = :repo_deleted
 			,repo_parent_id = :repo_parent_id
 			,repo_uid = :repo_uid
+			,repo_uid_sort_key = :repo_uid_sort_key
 			,repo_git_uid = :repo_git_uid
 			,repo_description = :repo_description
+			,repo_website = :repo_website
+			,repo_contact_id = :repo_contact_id
+			,repo_show_readme = :repo_show_readme
 			,repo_default_branch = :repo_default_branch
 			,repo_pullreq_seq = :repo_pullreq_seq
 			,repo_num_forks = :repo_num_forks
@@ -301,7 +337,6 @@ func (s *RepoStore) Update(ctx context.Context, repo *types.Repository) error {
 			,repo_num_closed_pulls = :repo_num_closed_pulls
 			,repo_num_open_pulls = :repo_num_open_pulls
 			,repo_num_merged_pulls = :repo_num_merged_pulls
-			,repo_state = :repo_state
 			,repo_is_empty = :repo_is_empty
 		WHERE repo_id = :repo_id AND repo_version = :repo_version - 1`
 
@@ -376,6 +411,100 @@ func (s *RepoStore) UpdateSize(ctx context.Context, id int64, sizeInKiB int64) e
 	return nil
 }
 
+// UpdateState moves the repository from one state to another.
+// It returns ErrVersionConflict if the repository isn't in the expected state anymore.
+func (s *RepoStore) UpdateState(
+	ctx context.Context,
+	repo *types.Repository,
+	from enum.RepoState,
+	to enum.RepoState,
+	until int64,
+) error {
+	const sqlQuery = `
+		UPDATE repositories
+		SET
+			 repo_state = $1
+			,repo_state_until = $2
+			,repo_updated = $3
+			,repo_version = repo_version + 1
+		WHERE repo_id = $4 AND repo_state = $5
+		RETURNING repo_version, repo_updated`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var version, updated int64
+	err := db.QueryRowContext(ctx, sqlQuery, to, until, time.Now().UnixMilli(), repo.ID, from).
+		Scan(&version, &updated)
+	if err != nil {
+		err = database.ProcessSQLErrorf(ctx, err, "Failed to update repository state")
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			// the repository isn't in the expected state anymore.
+			return gitness_store.ErrVersionConflict
+		}
+		return err
+	}
+
+	repo.State = to
+	repo.StateUntil = until
+	repo.Version = version
+	repo.Updated = updated
+
+	return nil
+}
+
+// UpdateLastActivity moves the last activity time of the repository forward
+// (never backwards, as activity events can be processed out of order).
+func (s *RepoStore) UpdateLastActivity(ctx context.Context, id int64, lastActivity int64) error {
+	stmt := database.Builder.
+		Update("repositories").
+		Set("repo_last_activity", lastActivity).
+		Where("repo_id = ? AND repo_last_activity < ?", id, lastActivity)
+
+	sqlQuery, args, err := stmt.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "Failed to create sql query")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sqlQuery, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update repo last activity")
+	}
+
+	return nil
+}
+
+// UpdateNumStars changes the star counter of the repository by the provided delta.
+func (s *RepoStore) UpdateNumStars(ctx context.Context, id int64, delta int) error {
+	stmt := database.Builder.
+		Update("repositories").
+		Set("repo_num_stars", squirrel.Expr("repo_num_stars + ?", delta)).
+		Where("repo_id = ?", id)
+
+	sqlQuery, args, err := stmt.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "Failed to create sql query")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update repo star count")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return fmt.Errorf("repo %d star count not updated: %w", id, gitness_store.ErrResourceNotFound)
+	}
+
+	return nil
+}
+
 // GetSize returns the repo size.
 func (s *RepoStore) GetSize(ctx context.Context, id int64) (int64, error) {
 	query := "SELECT repo_size FROM repositories WHERE repo_id = $1 AND repo_deleted IS NULL;"
@@ -547,7 +676,7 @@ func (s *RepoStore) count(
 		return 0, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	var count int64
 	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
@@ -566,7 +695,7 @@ func (s *RepoStore) countAll(
 		SELECT space_descendant_id
 		FROM space_descendants`
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	var spaceIDs []int64
 	if err := db.SelectContext(ctx, &spaceIDs, query, parentID); err != nil {
@@ -676,7 +805,7 @@ func (s *RepoStore) list(
 		return nil, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	dst := []*repository{}
 	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
@@ -695,7 +824,7 @@ func (s *RepoStore) listAll(
 		SELECT space_descendant_id
 		FROM space_descendants`
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	var spaceIDs []int64
 	if err := db.SelectContext(ctx, &spaceIDs, query, parentID); err != nil {
@@ -722,6 +851,61 @@ func (s *RepoStore) listAll(
 	return s.mapToRepos(ctx, repos)
 }
 
+// CountStarred returns the number of active repos starred by the principal.
+func (s *RepoStore) CountStarred(ctx context.Context, principalID int64) (int64, error) {
+	stmt := database.Builder.
+		Select("COUNT(*)").
+		From("repo_stars").
+		InnerJoin("repositories ON repo_id = repo_star_repo_id").
+		Where("repo_star_principal_id = ?", principalID).
+		Where("repo_deleted IS NULL")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing count starred repos query")
+	}
+
+	return count, nil
+}
+
+// ListStarred returns the active repos starred by the principal, most recently starred first.
+func (s *RepoStore) ListStarred(
+	ctx context.Context,
+	principalID int64,
+	pagination types.Pagination,
+) ([]*types.Repository, error) {
+	stmt := database.Builder.
+		Select(repoColumnsForJoin).
+		From("repo_stars").
+		InnerJoin("repositories ON repo_id = repo_star_repo_id").
+		Where("repo_star_principal_id = ?", principalID).
+		Where("repo_deleted IS NULL").
+		OrderBy("repo_star_created DESC", "repo_id DESC").
+		Limit(database.Limit(pagination.Size)).
+		Offset(database.Offset(pagination.Page, pagination.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	dst := []*repository{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list starred repos query")
+	}
+
+	return s.mapToRepos(ctx, dst)
+}
+
 type repoSize struct {
 	ID          int64  `db:"repo_id"`
 	GitUID      string `db:"repo_git_uid"`
@@ -761,10 +945,14 @@ func (s *RepoStore) mapToRepo(
 		ParentID:       in.ParentID,
 		Identifier:     in.Identifier,
 		Description:    in.Description,
+		Website:        in.Website,
+		ContactID:      in.ContactID.Ptr(),
+		ShowReadme:     in.ShowReadme,
 		Created:        in.Created,
 		CreatedBy:      in.CreatedBy,
 		Updated:        in.Updated,
 		Deleted:        in.Deleted.Ptr(),
+		LastActivity:   in.LastActivity,
 		Size:           in.Size,
 		SizeUpdated:    in.SizeUpdated,
 		GitUID:         in.GitUID,
@@ -776,7 +964,9 @@ func (s *RepoStore) mapToRepo(
 		NumClosedPulls: in.NumClosedPulls,
 		NumOpenPulls:   in.NumOpenPulls,
 		NumMergedPulls: in.NumMergedPulls,
+		NumStars:       in.NumStars,
 		State:          in.State,
+		StateUntil:     in.StateUntil,
 		IsEmpty:        in.IsEmpty,
 		// Path: is set below
 	}
@@ -843,11 +1033,16 @@ func mapToInternalRepo(in *types.Repository) *repository {
 		Version:        in.Version,
 		ParentID:       in.ParentID,
 		Identifier:     in.Identifier,
+		UIDSortKey:     database.SortKey(in.Identifier),
 		Description:    in.Description,
+		Website:        in.Website,
+		ContactID:      null.IntFromPtr(in.ContactID),
+		ShowReadme:     in.ShowReadme,
 		Created:        in.Created,
 		CreatedBy:      in.CreatedBy,
 		Updated:        in.Updated,
 		Deleted:        null.IntFromPtr(in.Deleted),
+		LastActivity:   in.LastActivity,
 		Size:           in.Size,
 		SizeUpdated:    in.SizeUpdated,
 		GitUID:         in.GitUID,
@@ -859,15 +1054,35 @@ func mapToInternalRepo(in *types.Repository) *repository {
 		NumClosedPulls: in.NumClosedPulls,
 		NumOpenPulls:   in.NumOpenPulls,
 		NumMergedPulls: in.NumMergedPulls,
+		NumStars:       in.NumStars,
 		State:          in.State,
+		StateUntil:     in.StateUntil,
 		IsEmpty:        in.IsEmpty,
 	}
 }
 
+// repoListColumns maps the fields of repository list filters to their columns.
+var repoListColumns = listFilterColumns{
+	id: "repo_id",
+	sort: map[listfilter.Field]string{
+		types.RepoFieldIdentifier:   "repo_uid_sort_key",
+		types.RepoFieldCreated:      "repo_created",
+		types.RepoFieldUpdated:      "repo_updated",
+		types.RepoFieldDeleted:      "repo_deleted",
+		types.RepoFieldLastActivity: "repo_last_activity",
+		types.RepoFieldStars:        "repo_num_stars",
+	},
+	sortPrefix: map[listfilter.Field]string{
+		types.RepoFieldIdentifier: "repo_state desc",
+	},
+	filter: map[listfilter.Field]string{
+		types.RepoFieldIdentifier: "repo_uid",
+	},
+}
+
 func applyQueryFilter(stmt squirrel.SelectBuilder, filter *types.RepoFilter) squirrel.SelectBuilder {
-	if filter.Query != "" {
-		stmt = stmt.Where("LOWER(repo_uid) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
-	}
+	stmt = applyListFilterConditions(stmt, filter.ListFilter(), repoListColumns)
+
 	//nolint:gocritic
 	if filter.DeletedAt != nil {
 		stmt = stmt.Where("repo_deleted = ?", filter.DeletedAt)
@@ -876,27 +1091,38 @@ func applyQueryFilter(stmt squirrel.SelectBuilder, filter *types.RepoFilter) squ
 	} else {
 		stmt = stmt.Where("repo_deleted IS NULL")
 	}
-	return stmt
-}
 
-func applySortFilter(stmt squirrel.SelectBuilder, filter *types.RepoFilter) squirrel.SelectBuilder {
-	stmt = stmt.Limit(database.Limit(filter.Size))
-	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
-
-	switch filter.Sort {
-	// TODO [CODE-1363]: remove after identifier migration.
-	case enum.RepoAttrUID, enum.RepoAttrIdentifier, enum.RepoAttrNone:
-		// NOTE: string concatenation is safe because the
-		// order attribute is an enum and is not user-defined,
-		// and is therefore not subject to injection attacks.
-		stmt = stmt.OrderBy("repo_state desc, repo_uid " + filter.Order.String())
-	case enum.RepoAttrCreated:
-		stmt = stmt.OrderBy("repo_created " + filter.Order.String())
-	case enum.RepoAttrUpdated:
-		stmt = stmt.OrderBy("repo_updated " + filter.Order.String())
-	case enum.RepoAttrDeleted:
-		stmt = stmt.OrderBy("repo_deleted " + filter.Order.String())
+	if filter.OnlyPublic {
+		stmt = stmt.Where("repo_id IN (SELECT public_access_repo_id FROM public_access_repo)")
+	}
+
+	if len(filter.Topics) > 0 {
+		// the nested query keeps the default placeholders, they are replaced as part of the outer query.
+		topicStmt := squirrel.
+			Select("repo_topic_repo_id").
+			From("repo_topics").
+			Where(squirrel.Eq{"repo_topic_topic": filter.Topics})
+
+		if filter.TopicsMatchAll {
+			topicStmt = topicStmt.
+				GroupBy("repo_topic_repo_id").
+				Having("COUNT(*) = ?", len(filter.Topics))
+		}
+
+		stmt = stmt.Where(squirrel.Expr("repo_id IN (?)", topicStmt))
+	}
+
+	if filter.RepoIDs != nil {
+		stmt = stmt.Where(squirrel.Eq{"repo_id": filter.RepoIDs})
+	}
+
+	if len(filter.ExcludedRepoIDs) > 0 {
+		stmt = stmt.Where(squirrel.NotEq{"repo_id": filter.ExcludedRepoIDs})
 	}
 
 	return stmt
 }
+
+func applySortFilter(stmt squirrel.SelectBuilder, filter *types.RepoFilter) squirrel.SelectBuilder {
+	return applyListFilterOrder(stmt, filter.ListFilter(), repoListColumns)
+}
@@ -0,0 +1,279 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RepoAccessLogStore = (*RepoAccessLogStore)(nil)
+
+// NewRepoAccessLogStore returns a new RepoAccessLogStore.
+func NewRepoAccessLogStore(
+	db *sqlx.DB,
+	pCache store.PrincipalInfoCache,
+) *RepoAccessLogStore {
+	return &RepoAccessLogStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// RepoAccessLogStore implements store.RepoAccessLogStore backed by a relational database.
+type RepoAccessLogStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+const (
+	repoAccessLogColumns = `
+		 repo_access_log_id
+		,repo_access_log_repo_id
+		,repo_access_log_principal_id
+		,repo_access_log_operation
+		,repo_access_log_client_ip
+		,repo_access_log_bytes_sent
+		,repo_access_log_bytes_received
+		,repo_access_log_duration
+		,repo_access_log_success
+		,repo_access_log_created`
+)
+
+type repoAccessLog struct {
+	ID            int64               `db:"repo_access_log_id"`
+	RepoID        int64               `db:"repo_access_log_repo_id"`
+	PrincipalID   *int64              `db:"repo_access_log_principal_id"`
+	Operation     enum.GitServiceType `db:"repo_access_log_operation"`
+	ClientIP      string              `db:"repo_access_log_client_ip"`
+	BytesSent     int64               `db:"repo_access_log_bytes_sent"`
+	BytesReceived int64               `db:"repo_access_log_bytes_received"`
+	Duration      int64               `db:"repo_access_log_duration"`
+	Success       bool                `db:"repo_access_log_success"`
+	Created       int64               `db:"repo_access_log_created"`
+}
+
+// CreateMany records the provided access log entries with a single statement.
+func (s *RepoAccessLogStore) CreateMany(ctx context.Context, entries []*types.RepoAccessLog) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	stmt := database.Builder.
+		Insert("").
+		Into("repo_access_logs").
+		Columns(
+			"repo_access_log_repo_id",
+			"repo_access_log_principal_id",
+			"repo_access_log_operation",
+			"repo_access_log_client_ip",
+			"repo_access_log_bytes_sent",
+			"repo_access_log_bytes_received",
+			"repo_access_log_duration",
+			"repo_access_log_success",
+			"repo_access_log_created",
+		)
+
+	for _, entry := range entries {
+		stmt = stmt.Values(
+			entry.RepoID,
+			entry.PrincipalID,
+			entry.Operation,
+			entry.ClientIP,
+			entry.BytesSent,
+			entry.BytesReceived,
+			entry.Duration,
+			entry.Success,
+			entry.Created,
+		)
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert insert repo access logs query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert repo access logs")
+	}
+
+	return nil
+}
+
+// Count returns the number of access log entries of a repository matching the filter.
+func (s *RepoAccessLogStore) Count(
+	ctx context.Context,
+	repoID int64,
+	filter *types.RepoAccessLogFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("repo_access_logs").
+		Where("repo_access_log_repo_id = ?", repoID)
+
+	stmt = applyRepoAccessLogFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert count repo access logs query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing count repo access logs query")
+	}
+
+	return count, nil
+}
+
+// List returns the access log entries of a repository matching the filter, most recent first.
+func (s *RepoAccessLogStore) List(
+	ctx context.Context,
+	repoID int64,
+	filter *types.RepoAccessLogFilter,
+) ([]*types.RepoAccessLog, error) {
+	stmt := database.Builder.
+		Select(repoAccessLogColumns).
+		From("repo_access_logs").
+		Where("repo_access_log_repo_id = ?", repoID)
+
+	stmt = applyRepoAccessLogFilter(stmt, filter)
+
+	stmt = stmt.
+		Limit(database.Limit(filter.Size)).
+		Offset(database.Offset(filter.Page, filter.Size)).
+		OrderBy("repo_access_log_created DESC, repo_access_log_id DESC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list repo access logs query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*repoAccessLog, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list repo access logs query")
+	}
+
+	return s.mapSliceRepoAccessLog(ctx, dst)
+}
+
+// DeleteOld removes all access log entries that are older than the provided time.
+func (s *RepoAccessLogStore) DeleteOld(ctx context.Context, olderThan time.Time) (int64, error) {
+	stmt := database.Builder.
+		Delete("repo_access_logs").
+		Where("repo_access_log_created < ?", olderThan.UnixMilli())
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert delete repo access logs query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "failed to execute delete repo access logs query")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "failed to get number of deleted repo access logs")
+	}
+
+	return n, nil
+}
+
+func applyRepoAccessLogFilter(
+	stmt squirrel.SelectBuilder,
+	filter *types.RepoAccessLogFilter,
+) squirrel.SelectBuilder {
+	if filter.PrincipalID > 0 {
+		stmt = stmt.Where("repo_access_log_principal_id = ?", filter.PrincipalID)
+	}
+
+	if filter.Since > 0 {
+		stmt = stmt.Where("repo_access_log_created >= ?", filter.Since)
+	}
+
+	if filter.Until > 0 {
+		stmt = stmt.Where("repo_access_log_created < ?", filter.Until)
+	}
+
+	return stmt
+}
+
+func mapRepoAccessLog(entry *repoAccessLog) *types.RepoAccessLog {
+	return &types.RepoAccessLog{
+		ID:            entry.ID,
+		RepoID:        entry.RepoID,
+		PrincipalID:   entry.PrincipalID,
+		Operation:     entry.Operation,
+		ClientIP:      entry.ClientIP,
+		BytesSent:     entry.BytesSent,
+		BytesReceived: entry.BytesReceived,
+		Duration:      entry.Duration,
+		Success:       entry.Success,
+		Created:       entry.Created,
+	}
+}
+
+func (s *RepoAccessLogStore) mapSliceRepoAccessLog(
+	ctx context.Context,
+	entries []*repoAccessLog,
+) ([]*types.RepoAccessLog, error) {
+	// collect the IDs of all principals, anonymous access doesn't have one.
+	ids := make([]int64, 0, len(entries))
+	for _, entry := range entries {
+		if entry.PrincipalID != nil {
+			ids = append(ids, *entry.PrincipalID)
+		}
+	}
+
+	// pull principal infos from cache
+	infoMap, err := s.pCache.Map(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load repo access log principals: %w", err)
+	}
+
+	// attach the principal infos back to the slice items
+	m := make([]*types.RepoAccessLog, len(entries))
+	for i, entry := range entries {
+		m[i] = mapRepoAccessLog(entry)
+		if entry.PrincipalID == nil {
+			continue
+		}
+		if principal, ok := infoMap[*entry.PrincipalID]; ok {
+			m[i].Principal = principal
+		}
+	}
+
+	return m, nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepoAccessLogStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	ctx := context.Background()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+
+	repoID := int64(1)
+	createRepo(ctx, t, repoStore, repoID, 1, 0)
+
+	pCache := cache.ProvidePrincipalInfoCache(database.ProvidePrincipalInfoView(db))
+	accessLogStore := database.NewRepoAccessLogStore(db, pCache)
+
+	principalID := userID
+	require.NoError(t, accessLogStore.CreateMany(ctx, []*types.RepoAccessLog{
+		{RepoID: repoID, PrincipalID: &principalID, Operation: enum.GitServiceTypeUploadPack,
+			ClientIP: "10.0.0.1", BytesSent: 100, Success: true, Created: 1000},
+		{RepoID: repoID, Operation: enum.GitServiceTypeUploadPack,
+			ClientIP: "10.0.0.2", BytesSent: 200, Success: true, Created: 2000},
+		{RepoID: repoID, PrincipalID: &principalID, Operation: enum.GitServiceTypeReceivePack,
+			ClientIP: "10.0.0.1", BytesReceived: 300, Success: false, Created: 3000},
+	}))
+
+	filter := &types.RepoAccessLogFilter{Page: 1, Size: 10}
+	count, err := accessLogStore.Count(ctx, repoID, filter)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+
+	entries, err := accessLogStore.List(ctx, repoID, filter)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, int64(3000), entries[0].Created)
+	assert.Equal(t, enum.GitServiceTypeReceivePack, entries[0].Operation)
+	require.NotNil(t, entries[0].Principal)
+	assert.Equal(t, userID, entries[0].Principal.ID)
+	// anonymous access is recorded without a principal.
+	assert.Nil(t, entries[1].PrincipalID)
+	assert.Nil(t, entries[1].Principal)
+
+	entries, err = accessLogStore.List(ctx, repoID,
+		&types.RepoAccessLogFilter{Page: 1, Size: 10, PrincipalID: userID, Since: 1000, Until: 3000})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, int64(1000), entries[0].Created)
+
+	n, err := accessLogStore.DeleteOld(ctx, time.UnixMilli(2500))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	count, err = accessLogStore.Count(ctx, repoID, filter)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
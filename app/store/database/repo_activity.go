@@ -0,0 +1,301 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RepoActivityStore = (*RepoActivityStore)(nil)
+
+// NewRepoActivityStore returns a new RepoActivityStore.
+func NewRepoActivityStore(
+	db *sqlx.DB,
+	pCache store.PrincipalInfoCache,
+) *RepoActivityStore {
+	return &RepoActivityStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// RepoActivityStore implements store.RepoActivityStore backed by a relational database.
+type RepoActivityStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+const (
+	repoActivityColumns = `
+		 repo_activity_id
+		,repo_activity_repo_id
+		,repo_activity_type
+		,repo_activity_principal_id
+		,repo_activity_payload
+		,repo_activity_created`
+)
+
+type repoActivity struct {
+	ID          int64                 `db:"repo_activity_id"`
+	RepoID      int64                 `db:"repo_activity_repo_id"`
+	Type        enum.RepoActivityType `db:"repo_activity_type"`
+	PrincipalID int64                 `db:"repo_activity_principal_id"`
+	Payload     json.RawMessage       `db:"repo_activity_payload"`
+	Created     int64                 `db:"repo_activity_created"`
+}
+
+// Create records a new repository activity.
+func (s *RepoActivityStore) Create(ctx context.Context, act *types.RepoActivity) error {
+	const sqlQuery = `
+	INSERT INTO repo_activities (
+		 repo_activity_repo_id
+		,repo_activity_type
+		,repo_activity_principal_id
+		,repo_activity_payload
+		,repo_activity_created
+	) VALUES (
+		 :repo_activity_repo_id
+		,:repo_activity_type
+		,:repo_activity_principal_id
+		,:repo_activity_payload
+		,:repo_activity_created
+	) RETURNING repo_activity_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalRepoActivity(act))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind repo activity object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&act.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert repo activity query failed")
+	}
+
+	return nil
+}
+
+// Count returns the number of activities of a repository matching the filter.
+func (s *RepoActivityStore) Count(
+	ctx context.Context,
+	repoID int64,
+	filter *types.RepoActivityFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("repo_activities").
+		Where("repo_activity_repo_id = ?", repoID)
+
+	stmt = applyRepoActivityFilter(stmt, filter, s.db.DriverName())
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert count repo activities query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing count repo activities query")
+	}
+
+	return count, nil
+}
+
+// List returns the activities of a repository matching the filter, most recent first.
+func (s *RepoActivityStore) List(
+	ctx context.Context,
+	repoID int64,
+	filter *types.RepoActivityFilter,
+) ([]*types.RepoActivity, error) {
+	stmt := database.Builder.
+		Select(repoActivityColumns).
+		From("repo_activities").
+		Where("repo_activity_repo_id = ?", repoID)
+
+	stmt = applyRepoActivityFilter(stmt, filter, s.db.DriverName())
+
+	stmt = stmt.
+		Limit(database.Limit(filter.Size)).
+		Offset(database.Offset(filter.Page, filter.Size)).
+		OrderBy("repo_activity_created DESC, repo_activity_id DESC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list repo activities query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*repoActivity, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list repo activities query")
+	}
+
+	return s.mapSliceRepoActivity(ctx, dst)
+}
+
+// ListPrincipalsActiveBefore returns those of the provided principals
+// that have any activity in the repository before the provided time.
+func (s *RepoActivityStore) ListPrincipalsActiveBefore(
+	ctx context.Context,
+	repoID int64,
+	principalIDs []int64,
+	before int64,
+) ([]int64, error) {
+	if len(principalIDs) == 0 {
+		return []int64{}, nil
+	}
+
+	stmt := database.Builder.
+		Select("DISTINCT repo_activity_principal_id").
+		From("repo_activities").
+		Where("repo_activity_repo_id = ?", repoID).
+		Where(squirrel.Eq{"repo_activity_principal_id": principalIDs}).
+		Where("repo_activity_created < ?", before)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list active principals query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]int64, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list active principals query")
+	}
+
+	return dst, nil
+}
+
+// DeleteOld removes all repository activities that are older than the provided time.
+func (s *RepoActivityStore) DeleteOld(ctx context.Context, olderThan time.Time) (int64, error) {
+	stmt := database.Builder.
+		Delete("repo_activities").
+		Where("repo_activity_created < ?", olderThan.UnixMilli())
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert delete repo activities query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "failed to execute delete repo activities query")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "failed to get number of deleted repo activities")
+	}
+
+	return n, nil
+}
+
+func applyRepoActivityFilter(
+	stmt squirrel.SelectBuilder,
+	filter *types.RepoActivityFilter,
+	driverName string,
+) squirrel.SelectBuilder {
+	if len(filter.Types) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"repo_activity_type": filter.Types})
+	}
+
+	if filter.Since > 0 {
+		stmt = stmt.Where("repo_activity_created >= ?", filter.Since)
+	}
+
+	if filter.Until > 0 {
+		stmt = stmt.Where("repo_activity_created < ?", filter.Until)
+	}
+
+	if filter.Ref != "" {
+		if strings.HasPrefix(driverName, "sqlite") {
+			// the payload is stored as blob, which sqlite would interpret as binary json.
+			stmt = stmt.Where("json_extract(CAST(repo_activity_payload AS TEXT), '$.ref') = ?", filter.Ref)
+		} else {
+			stmt = stmt.Where("repo_activity_payload->>'ref' = ?", filter.Ref)
+		}
+	}
+
+	return stmt
+}
+
+func mapInternalRepoActivity(act *types.RepoActivity) *repoActivity {
+	return &repoActivity{
+		ID:          act.ID,
+		RepoID:      act.RepoID,
+		Type:        act.Type,
+		PrincipalID: act.PrincipalID,
+		Payload:     act.Payload,
+		Created:     act.Created,
+	}
+}
+
+func mapRepoActivity(act *repoActivity) *types.RepoActivity {
+	return &types.RepoActivity{
+		ID:          act.ID,
+		RepoID:      act.RepoID,
+		Type:        act.Type,
+		PrincipalID: act.PrincipalID,
+		Payload:     act.Payload,
+		Created:     act.Created,
+	}
+}
+
+func (s *RepoActivityStore) mapSliceRepoActivity(
+	ctx context.Context,
+	activities []*repoActivity,
+) ([]*types.RepoActivity, error) {
+	// collect all principal IDs
+	ids := make([]int64, len(activities))
+	for i, act := range activities {
+		ids[i] = act.PrincipalID
+	}
+
+	// pull principal infos from cache
+	infoMap, err := s.pCache.Map(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load repo activity principals: %w", err)
+	}
+
+	// attach the principal infos back to the slice items
+	m := make([]*types.RepoActivity, len(activities))
+	for i, act := range activities {
+		m[i] = mapRepoActivity(act)
+		if principal, ok := infoMap[act.PrincipalID]; ok {
+			m[i].Principal = principal
+		}
+	}
+
+	return m, nil
+}
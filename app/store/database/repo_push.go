@@ -0,0 +1,184 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RepoPushStore = (*RepoPushStore)(nil)
+
+// dayMillis is the length of a day in milliseconds, used to group pushes into daily buckets.
+const dayMillis = int64(24 * time.Hour / time.Millisecond)
+
+// NewRepoPushStore returns a new RepoPushStore.
+func NewRepoPushStore(db *sqlx.DB) *RepoPushStore {
+	return &RepoPushStore{
+		db: db,
+	}
+}
+
+// RepoPushStore implements store.RepoPushStore backed by a relational database.
+type RepoPushStore struct {
+	db *sqlx.DB
+}
+
+// Create records a new push to a repository.
+func (s *RepoPushStore) Create(ctx context.Context, push *types.RepoPush) error {
+	const sqlQuery = `
+	INSERT INTO repo_pushes (
+		 repo_push_repo_id
+		,repo_push_principal_id
+		,repo_push_ref
+		,repo_push_commit_count
+		,repo_push_created
+	) VALUES (
+		 :repo_push_repo_id
+		,:repo_push_principal_id
+		,:repo_push_ref
+		,:repo_push_commit_count
+		,:repo_push_created
+	) RETURNING repo_push_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, push)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind repo push object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&push.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert repo push query failed")
+	}
+
+	return nil
+}
+
+// Count returns the number of pushes to the repository since the provided time.
+func (s *RepoPushStore) Count(ctx context.Context, repoID int64, since int64) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("repo_pushes").
+		Where("repo_push_repo_id = ?", repoID).
+		Where("repo_push_created >= ?", since)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert count repo pushes query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing count repo pushes query")
+	}
+
+	return count, nil
+}
+
+// ListBuckets returns the number of pushes to the repository per day since the provided time.
+// Days without any pushes are omitted.
+func (s *RepoPushStore) ListBuckets(ctx context.Context, repoID int64, since int64) ([]types.RepoPushBucket, error) {
+	stmt := database.Builder.
+		Select(fmt.Sprintf("repo_push_created / %[1]d * %[1]d AS day, count(*) AS pushes", dayMillis)).
+		From("repo_pushes").
+		Where("repo_push_repo_id = ?", repoID).
+		Where("repo_push_created >= ?", since).
+		GroupBy("day").
+		OrderBy("day")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list repo push buckets query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]types.RepoPushBucket, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list repo push buckets query")
+	}
+
+	return dst, nil
+}
+
+// ListTopContributors returns up to limit principals that pushed the most commits
+// to the repository since the provided time.
+func (s *RepoPushStore) ListTopContributors(
+	ctx context.Context,
+	repoID int64,
+	since int64,
+	limit int,
+) ([]types.RepoContributor, error) {
+	stmt := database.Builder.
+		Select("repo_push_principal_id AS principal_id, sum(repo_push_commit_count) AS commits").
+		From("repo_pushes").
+		Where("repo_push_repo_id = ?", repoID).
+		Where("repo_push_created >= ?", since).
+		GroupBy("repo_push_principal_id").
+		Having("sum(repo_push_commit_count) > 0").
+		OrderBy("commits DESC", "principal_id").
+		Limit(uint64(limit))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list repo top contributors query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]types.RepoContributor, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing list repo top contributors query")
+	}
+
+	return dst, nil
+}
+
+// DeleteOld removes all pushes that are older than the provided time.
+func (s *RepoPushStore) DeleteOld(ctx context.Context, olderThan time.Time) (int64, error) {
+	stmt := database.Builder.
+		Delete("repo_pushes").
+		Where("repo_push_created < ?", olderThan.UnixMilli())
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert delete repo pushes query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "failed to execute delete repo pushes query")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "failed to get number of deleted repo pushes")
+	}
+
+	return n, nil
+}
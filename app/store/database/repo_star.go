@@ -0,0 +1,86 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RepoStarStore = (*RepoStarStore)(nil)
+
+// NewRepoStarStore returns a new RepoStarStore.
+func NewRepoStarStore(db *sqlx.DB) *RepoStarStore {
+	return &RepoStarStore{
+		db: db,
+	}
+}
+
+// RepoStarStore implements store.RepoStarStore backed by a relational database.
+type RepoStarStore struct {
+	db *sqlx.DB
+}
+
+// Create stars the repo for the principal. It returns false if the repo was already starred.
+func (s *RepoStarStore) Create(ctx context.Context, principalID, repoID int64) (bool, error) {
+	const sqlQuery = `
+		INSERT INTO repo_stars (
+			 repo_star_principal_id
+			,repo_star_repo_id
+			,repo_star_created
+		) VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, principalID, repoID, time.Now().UnixMilli())
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to star repo")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to get number of inserted rows")
+	}
+
+	return count > 0, nil
+}
+
+// Delete removes the star of the principal from the repo. It returns false if the repo wasn't starred.
+func (s *RepoStarStore) Delete(ctx context.Context, principalID, repoID int64) (bool, error) {
+	const sqlQuery = `
+		DELETE FROM repo_stars
+		WHERE repo_star_principal_id = $1 AND repo_star_repo_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, principalID, repoID)
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to unstar repo")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted rows")
+	}
+
+	return count > 0, nil
+}
@@ -0,0 +1,202 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RepoStatsStore = (*RepoStatsStore)(nil)
+
+// NewRepoStatsStore returns a new RepoStatsStore.
+func NewRepoStatsStore(db *sqlx.DB) *RepoStatsStore {
+	return &RepoStatsStore{
+		db: db,
+	}
+}
+
+// RepoStatsStore implements store.RepoStatsStore backed by a relational database.
+type RepoStatsStore struct {
+	db *sqlx.DB
+}
+
+const (
+	repoStatsColumns = `
+		 repo_stats_repo_id
+		,repo_stats_pushes_7d
+		,repo_stats_pushes_30d
+		,repo_stats_branches
+		,repo_stats_tags
+		,repo_stats_push_buckets
+		,repo_stats_contributors
+		,repo_stats_updated`
+)
+
+type repoStats struct {
+	RepoID       int64           `db:"repo_stats_repo_id"`
+	Pushes7Days  int64           `db:"repo_stats_pushes_7d"`
+	Pushes30Days int64           `db:"repo_stats_pushes_30d"`
+	Branches     int64           `db:"repo_stats_branches"`
+	Tags         int64           `db:"repo_stats_tags"`
+	PushBuckets  json.RawMessage `db:"repo_stats_push_buckets"`
+	Contributors json.RawMessage `db:"repo_stats_contributors"`
+	Updated      int64           `db:"repo_stats_updated"`
+}
+
+// Find returns the statistics of the repository.
+func (s *RepoStatsStore) Find(ctx context.Context, repoID int64) (*types.RepoStats, error) {
+	const sqlQuery = `
+		SELECT` + repoStatsColumns + `
+		FROM repo_stats
+		WHERE repo_stats_repo_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &repoStats{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find repo stats")
+	}
+
+	return mapRepoStats(dst)
+}
+
+// Upsert creates or replaces the statistics of the repository.
+func (s *RepoStatsStore) Upsert(ctx context.Context, stats *types.RepoStats) error {
+	const sqlQuery = `
+		INSERT INTO repo_stats (` + repoStatsColumns + `
+		) values (
+			 :repo_stats_repo_id
+			,:repo_stats_pushes_7d
+			,:repo_stats_pushes_30d
+			,:repo_stats_branches
+			,:repo_stats_tags
+			,:repo_stats_push_buckets
+			,:repo_stats_contributors
+			,:repo_stats_updated
+		)
+		ON CONFLICT (repo_stats_repo_id) DO
+		UPDATE SET
+			 repo_stats_pushes_7d = EXCLUDED.repo_stats_pushes_7d
+			,repo_stats_pushes_30d = EXCLUDED.repo_stats_pushes_30d
+			,repo_stats_branches = EXCLUDED.repo_stats_branches
+			,repo_stats_tags = EXCLUDED.repo_stats_tags
+			,repo_stats_push_buckets = EXCLUDED.repo_stats_push_buckets
+			,repo_stats_contributors = EXCLUDED.repo_stats_contributors
+			,repo_stats_updated = EXCLUDED.repo_stats_updated`
+
+	dbStats, err := mapInternalRepoStats(stats)
+	if err != nil {
+		return err
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, dbStats)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind repo stats object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to upsert repo stats")
+	}
+
+	return nil
+}
+
+// Totals returns the statistics summed up over all repositories.
+func (s *RepoStatsStore) Totals(ctx context.Context) (*types.RepoStatsTotals, error) {
+	const sqlQuery = `
+		SELECT
+			 COALESCE(SUM(repo_stats_pushes_7d), 0)
+			,COALESCE(SUM(repo_stats_pushes_30d), 0)
+			,COALESCE(SUM(repo_stats_branches), 0)
+			,COALESCE(SUM(repo_stats_tags), 0)
+		FROM repo_stats`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	totals := &types.RepoStatsTotals{}
+	err := db.QueryRowContext(ctx, sqlQuery).
+		Scan(&totals.Pushes7Days, &totals.Pushes30Days, &totals.Branches, &totals.Tags)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to sum up repo stats")
+	}
+
+	return totals, nil
+}
+
+func mapInternalRepoStats(stats *types.RepoStats) (*repoStats, error) {
+	buckets := stats.PushBuckets
+	if buckets == nil {
+		buckets = []types.RepoPushBucket{}
+	}
+	rawBuckets, err := json.Marshal(buckets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal repo push buckets: %w", err)
+	}
+
+	contributors := stats.Contributors
+	if contributors == nil {
+		contributors = map[enum.RepoStatsWindow][]types.RepoContributor{}
+	}
+	rawContributors, err := json.Marshal(contributors)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal repo contributors: %w", err)
+	}
+
+	return &repoStats{
+		RepoID:       stats.RepoID,
+		Pushes7Days:  stats.Pushes7Days,
+		Pushes30Days: stats.Pushes30Days,
+		Branches:     stats.Branches,
+		Tags:         stats.Tags,
+		PushBuckets:  rawBuckets,
+		Contributors: rawContributors,
+		Updated:      stats.Updated,
+	}, nil
+}
+
+func mapRepoStats(stats *repoStats) (*types.RepoStats, error) {
+	var buckets []types.RepoPushBucket
+	if err := json.Unmarshal(stats.PushBuckets, &buckets); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal repo push buckets: %w", err)
+	}
+
+	var contributors map[enum.RepoStatsWindow][]types.RepoContributor
+	if err := json.Unmarshal(stats.Contributors, &contributors); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal repo contributors: %w", err)
+	}
+
+	return &types.RepoStats{
+		RepoID:       stats.RepoID,
+		Pushes7Days:  stats.Pushes7Days,
+		Pushes30Days: stats.Pushes30Days,
+		Branches:     stats.Branches,
+		Tags:         stats.Tags,
+		PushBuckets:  buckets,
+		Contributors: contributors,
+		Updated:      stats.Updated,
+	}, nil
+}
@@ -0,0 +1,287 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RepoTopicStore = (*RepoTopicStore)(nil)
+
+// NewRepoTopicStore returns a new RepoTopicStore.
+func NewRepoTopicStore(db *sqlx.DB) *RepoTopicStore {
+	return &RepoTopicStore{
+		db: db,
+	}
+}
+
+// RepoTopicStore implements store.RepoTopicStore backed by a relational database.
+type RepoTopicStore struct {
+	db *sqlx.DB
+}
+
+type repoTopic struct {
+	RepoID int64  `db:"repo_topic_repo_id"`
+	Topic  string `db:"repo_topic_topic"`
+}
+
+// List returns the topics of the repo, sorted alphabetically.
+func (s *RepoTopicStore) List(ctx context.Context, repoID int64) ([]string, error) {
+	const sqlQuery = `
+		SELECT repo_topic_topic
+		FROM repo_topics
+		WHERE repo_topic_repo_id = $1
+		ORDER BY repo_topic_topic`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	topics := []string{}
+	if err := db.SelectContext(ctx, &topics, sqlQuery, repoID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list repo topics")
+	}
+
+	return topics, nil
+}
+
+// Map returns the topics of the repos, sorted alphabetically, indexed by the repo ID.
+func (s *RepoTopicStore) Map(ctx context.Context, repoIDs []int64) (map[int64][]string, error) {
+	result := make(map[int64][]string, len(repoIDs))
+	if len(repoIDs) == 0 {
+		return result, nil
+	}
+
+	stmt := database.Builder.
+		Select("repo_topic_repo_id", "repo_topic_topic").
+		From("repo_topics").
+		Where(squirrel.Eq{"repo_topic_repo_id": repoIDs}).
+		OrderBy("repo_topic_repo_id", "repo_topic_topic")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	var dst []repoTopic
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list topics of repos")
+	}
+
+	for _, t := range dst {
+		result[t.RepoID] = append(result[t.RepoID], t.Topic)
+	}
+
+	return result, nil
+}
+
+// Set replaces the topics of the repo.
+func (s *RepoTopicStore) Set(ctx context.Context, repoID int64, topics []string) error {
+	const sqlQueryDelete = `
+		DELETE FROM repo_topics
+		WHERE repo_topic_repo_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQueryDelete, repoID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete repo topics")
+	}
+
+	if len(topics) == 0 {
+		return nil
+	}
+
+	stmt := database.Builder.
+		Insert("").
+		Into("repo_topics").
+		Columns(
+			"repo_topic_repo_id",
+			"repo_topic_topic",
+		)
+
+	for _, topic := range topics {
+		stmt = stmt.Values(repoID, topic)
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert repo topics")
+	}
+
+	return nil
+}
+
+// Count returns the number of active repos per topic in the space, most used topics first.
+func (s *RepoTopicStore) Count(
+	ctx context.Context,
+	spaceID int64,
+	filter *types.RepoTopicFilter,
+) ([]types.RepoTopicCount, error) {
+	spaceIDs, err := s.getSpaceIDs(ctx, spaceID, filter.Recursive)
+	if err != nil {
+		return nil, err
+	}
+
+	stmt := database.Builder.
+		Select("repo_topic_topic", "COUNT(*) AS topic_count").
+		From("repo_topics").
+		InnerJoin("repositories ON repo_id = repo_topic_repo_id").
+		Where(squirrel.Eq{"repo_parent_id": spaceIDs}).
+		Where("repo_deleted IS NULL").
+		GroupBy("repo_topic_topic").
+		OrderBy("topic_count DESC", "repo_topic_topic")
+
+	if filter.Query != "" {
+		stmt = stmt.Where("repo_topic_topic LIKE ?", strings.ToLower(filter.Query)+"%")
+	}
+
+	if filter.OnlyPublic {
+		stmt = stmt.Where("repo_id IN (SELECT public_access_repo_id FROM public_access_repo)")
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	rows, err := db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to count repo topics")
+	}
+	defer rows.Close()
+
+	result := []types.RepoTopicCount{}
+	for rows.Next() {
+		var count types.RepoTopicCount
+		if err = rows.Scan(&count.Topic, &count.Count); err != nil {
+			return nil, database.ProcessSQLErrorf(ctx, err, "Failed to scan repo topic count")
+		}
+
+		result = append(result, count)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to count repo topics")
+	}
+
+	return result, nil
+}
+
+// Rename renames the topic of all repos in the space and its subspaces.
+// It returns the IDs of the repos that had the topic.
+// Repos that already have the new topic keep it, the old one is removed.
+func (s *RepoTopicStore) Rename(
+	ctx context.Context,
+	spaceID int64,
+	oldTopic, newTopic string,
+) ([]int64, error) {
+	spaceIDs, err := s.getSpaceIDs(ctx, spaceID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	stmt := database.Builder.
+		Select("repo_topic_repo_id").
+		From("repo_topics").
+		InnerJoin("repositories ON repo_id = repo_topic_repo_id").
+		Where(squirrel.Eq{"repo_parent_id": spaceIDs}).
+		Where("repo_topic_topic = ?", oldTopic).
+		OrderBy("repo_topic_repo_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var repoIDs []int64
+	if err = db.SelectContext(ctx, &repoIDs, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find repos with topic")
+	}
+
+	if len(repoIDs) == 0 {
+		return repoIDs, nil
+	}
+
+	deleteStmt := database.Builder.
+		Delete("repo_topics").
+		Where(squirrel.Eq{"repo_topic_repo_id": repoIDs}).
+		Where("repo_topic_topic = ?", oldTopic).
+		Where("repo_topic_repo_id IN (SELECT repo_topic_repo_id FROM repo_topics WHERE repo_topic_topic = ?)",
+			newTopic)
+
+	sql, args, err = deleteStmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to delete duplicate repo topics")
+	}
+
+	updateStmt := database.Builder.
+		Update("repo_topics").
+		Set("repo_topic_topic", newTopic).
+		Where(squirrel.Eq{"repo_topic_repo_id": repoIDs}).
+		Where("repo_topic_topic = ?", oldTopic)
+
+	sql, args, err = updateStmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to rename repo topic")
+	}
+
+	return repoIDs, nil
+}
+
+// getSpaceIDs returns the ID of the space and, if recursive, the IDs of all its subspaces.
+func (s *RepoTopicStore) getSpaceIDs(ctx context.Context, spaceID int64, recursive bool) ([]int64, error) {
+	if !recursive {
+		return []int64{spaceID}, nil
+	}
+
+	query := spaceDescendantsQuery + `
+		SELECT space_descendant_id
+		FROM space_descendants`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	var spaceIDs []int64
+	if err := db.SelectContext(ctx, &spaceIDs, query, spaceID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "failed to retrieve spaces")
+	}
+
+	return spaceIDs, nil
+}
@@ -65,6 +65,7 @@ type rule struct {
 	RepoID  null.Int `db:"rule_repo_id"`
 
 	Identifier  string `db:"rule_uid"`
+	UIDSortKey  string `db:"rule_uid_sort_key"`
 	Description string `db:"rule_description"`
 
 	Type  types.RuleType `db:"rule_type"`
@@ -152,6 +153,7 @@ func (s *RuleStore) Create(ctx context.Context, rule *types.Rule) error {
 			,rule_space_id
 			,rule_repo_id
 			,rule_uid
+			,rule_uid_sort_key
 			,rule_description
 			,rule_type
 			,rule_state
@@ -165,6 +167,7 @@ func (s *RuleStore) Create(ctx context.Context, rule *types.Rule) error {
 			,:rule_space_id
 			,:rule_repo_id
 			,:rule_uid
+			,:rule_uid_sort_key
 			,:rule_description
 			,:rule_type
 			,:rule_state
@@ -200,6 +203,7 @@ func (s *RuleStore) Update(ctx context.Context, rule *types.Rule) error {
 			 rule_version = :rule_version
 			,rule_updated = :rule_updated
 			,rule_uid = :rule_uid
+			,rule_uid_sort_key = :rule_uid_sort_key
 			,rule_description = :rule_description
 			,rule_state = :rule_state
 			,rule_pattern = :rule_pattern
@@ -329,9 +333,12 @@ func (s *RuleStore) List(ctx context.Context, spaceID, repoID *int64, filter *ty
 		stmt = stmt.OrderBy("rule_updated " + order.String())
 		// TODO [CODE-1363]: remove after identifier migration.
 	case enum.RuleSortUID, enum.RuleSortIdentifier:
-		stmt = stmt.OrderBy("LOWER(rule_uid) " + order.String())
+		stmt = stmt.OrderBy("rule_uid_sort_key " + order.String())
 	}
 
+	// rule ID as tiebreaker guarantees a stable order across pages.
+	stmt = stmt.OrderBy("rule_id " + order.String())
+
 	sql, args, err := stmt.ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
@@ -452,6 +459,10 @@ func (*RuleStore) applyFilter(
 		stmt = stmt.Where(squirrel.Eq{"rule_state": filter.States})
 	}
 
+	if len(filter.Types) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"rule_type": filter.Types})
+	}
+
 	if filter.Query != "" {
 		stmt = stmt.Where("LOWER(rule_uid) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
 	}
@@ -512,6 +523,7 @@ func mapToInternalRule(in *types.Rule) rule {
 		SpaceID:     null.IntFromPtr(in.SpaceID),
 		RepoID:      null.IntFromPtr(in.RepoID),
 		Identifier:  in.Identifier,
+		UIDSortKey:  database.SortKey(in.Identifier),
 		Description: in.Description,
 		Type:        in.Type,
 		State:       in.State,
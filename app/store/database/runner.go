@@ -0,0 +1,327 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
+)
+
+var _ store.RunnerStore = (*RunnerStore)(nil)
+
+// NewRunnerStore returns a new RunnerStore.
+func NewRunnerStore(db *sqlx.DB) *RunnerStore {
+	return &RunnerStore{
+		db: db,
+	}
+}
+
+// RunnerStore implements store.RunnerStore backed by a relational database.
+type RunnerStore struct {
+	db *sqlx.DB
+}
+
+const (
+	runnerColumns = `
+		 runner_id
+		,runner_space_id
+		,runner_uid
+		,runner_description
+		,runner_labels
+		,runner_capacity
+		,runner_os
+		,runner_arch
+		,runner_embedded
+		,runner_token_hash
+		,runner_draining
+		,runner_last_heartbeat
+		,runner_created
+		,runner_updated
+		,runner_version`
+)
+
+type runner struct {
+	ID            int64              `db:"runner_id"`
+	SpaceID       null.Int           `db:"runner_space_id"`
+	Identifier    string             `db:"runner_uid"`
+	Description   string             `db:"runner_description"`
+	Labels        sqlxtypes.JSONText `db:"runner_labels"`
+	Capacity      int                `db:"runner_capacity"`
+	OS            string             `db:"runner_os"`
+	Arch          string             `db:"runner_arch"`
+	Embedded      bool               `db:"runner_embedded"`
+	TokenHash     string             `db:"runner_token_hash"`
+	Draining      bool               `db:"runner_draining"`
+	LastHeartbeat int64              `db:"runner_last_heartbeat"`
+	Created       int64              `db:"runner_created"`
+	Updated       int64              `db:"runner_updated"`
+	Version       int64              `db:"runner_version"`
+}
+
+// Create creates a new runner.
+func (s *RunnerStore) Create(ctx context.Context, runner *types.Runner) error {
+	const sqlQuery = `
+	INSERT INTO runners (
+		 runner_space_id
+		,runner_uid
+		,runner_description
+		,runner_labels
+		,runner_capacity
+		,runner_os
+		,runner_arch
+		,runner_embedded
+		,runner_token_hash
+		,runner_draining
+		,runner_last_heartbeat
+		,runner_created
+		,runner_updated
+		,runner_version
+	) VALUES (
+		 :runner_space_id
+		,:runner_uid
+		,:runner_description
+		,:runner_labels
+		,:runner_capacity
+		,:runner_os
+		,:runner_arch
+		,:runner_embedded
+		,:runner_token_hash
+		,:runner_draining
+		,:runner_last_heartbeat
+		,:runner_created
+		,:runner_updated
+		,:runner_version
+	) RETURNING runner_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalRunner(runner))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind runner object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&runner.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert runner query failed")
+	}
+
+	return nil
+}
+
+// FindByIdentifier finds the runner by its identifier.
+func (s *RunnerStore) FindByIdentifier(ctx context.Context, identifier string) (*types.Runner, error) {
+	const sqlQuery = `
+	SELECT` + runnerColumns + `
+	FROM runners
+	WHERE LOWER(runner_uid) = LOWER($1)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &runner{}
+	if err := db.GetContext(ctx, dst, sqlQuery, identifier); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find runner")
+	}
+
+	return mapRunner(dst)
+}
+
+// List returns all runners ordered by identifier.
+func (s *RunnerStore) List(ctx context.Context) ([]*types.Runner, error) {
+	const sqlQuery = `
+	SELECT` + runnerColumns + `
+	FROM runners
+	ORDER BY runner_uid ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*runner, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list runners")
+	}
+
+	return mapRunners(dst)
+}
+
+// ListBySpace returns the runners registered for a space ordered by identifier.
+func (s *RunnerStore) ListBySpace(ctx context.Context, spaceID int64) ([]*types.Runner, error) {
+	const sqlQuery = `
+	SELECT` + runnerColumns + `
+	FROM runners
+	WHERE runner_space_id = $1
+	ORDER BY runner_uid ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*runner, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, spaceID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list space runners")
+	}
+
+	return mapRunners(dst)
+}
+
+// Update tries to update a runner and returns an optimistic locking error if it was
+// unable to do so.
+func (s *RunnerStore) Update(ctx context.Context, runner *types.Runner) error {
+	const sqlQuery = `
+	UPDATE runners
+	SET
+		 runner_description = :runner_description
+		,runner_labels = :runner_labels
+		,runner_capacity = :runner_capacity
+		,runner_os = :runner_os
+		,runner_arch = :runner_arch
+		,runner_token_hash = :runner_token_hash
+		,runner_draining = :runner_draining
+		,runner_last_heartbeat = :runner_last_heartbeat
+		,runner_updated = :runner_updated
+		,runner_version = :runner_version
+	WHERE runner_id = :runner_id AND runner_version = :runner_version - 1`
+
+	dbRunner := mapInternalRunner(runner)
+	dbRunner.Version++
+	dbRunner.Updated = time.Now().UnixMilli()
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, dbRunner)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind runner object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update runner")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	runner.Version = dbRunner.Version
+	runner.Updated = dbRunner.Updated
+
+	return nil
+}
+
+// UpdateHeartbeat records a heartbeat of the runner.
+// It doesn't bump the version, as heartbeats must not conflict with other updates of the runner.
+func (s *RunnerStore) UpdateHeartbeat(ctx context.Context, id int64, heartbeat int64) error {
+	const sqlQuery = `
+	UPDATE runners
+	SET runner_last_heartbeat = $1
+	WHERE runner_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, heartbeat, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update runner heartbeat")
+	}
+
+	return nil
+}
+
+// Delete deletes a runner.
+func (s *RunnerStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM runners
+	WHERE runner_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete runner")
+	}
+
+	return nil
+}
+
+func mapInternalRunner(r *types.Runner) *runner {
+	var spaceID null.Int
+	if r.SpaceID != 0 {
+		spaceID = null.IntFrom(r.SpaceID)
+	}
+
+	return &runner{
+		ID:            r.ID,
+		SpaceID:       spaceID,
+		Identifier:    r.Identifier,
+		Description:   r.Description,
+		Labels:        EncodeToSQLXJSON(r.Labels),
+		Capacity:      r.Capacity,
+		OS:            r.OS,
+		Arch:          r.Arch,
+		Embedded:      r.Embedded,
+		TokenHash:     r.TokenHash,
+		Draining:      r.Draining,
+		LastHeartbeat: r.LastHeartbeat,
+		Created:       r.Created,
+		Updated:       r.Updated,
+		Version:       r.Version,
+	}
+}
+
+func mapRunner(r *runner) (*types.Runner, error) {
+	var labels map[string]string
+	if err := json.Unmarshal(r.Labels, &labels); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal labels of runner %d: %w", r.ID, err)
+	}
+
+	return &types.Runner{
+		ID:            r.ID,
+		SpaceID:       r.SpaceID.Int64,
+		Identifier:    r.Identifier,
+		Description:   r.Description,
+		Labels:        labels,
+		Capacity:      r.Capacity,
+		OS:            r.OS,
+		Arch:          r.Arch,
+		Embedded:      r.Embedded,
+		TokenHash:     r.TokenHash,
+		Draining:      r.Draining,
+		LastHeartbeat: r.LastHeartbeat,
+		Created:       r.Created,
+		Updated:       r.Updated,
+		Version:       r.Version,
+	}, nil
+}
+
+func mapRunners(runners []*runner) ([]*types.Runner, error) {
+	m := make([]*types.Runner, len(runners))
+	for i, r := range runners {
+		var err error
+		m[i], err = mapRunner(r)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/store/database/migrate"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.SchemaStore = (*SchemaStore)(nil)
+
+// NewSchemaStore returns a new SchemaStore.
+func NewSchemaStore(db *sqlx.DB) *SchemaStore {
+	return &SchemaStore{
+		db: db,
+	}
+}
+
+// SchemaStore implements store.SchemaStore backed by a relational database.
+type SchemaStore struct {
+	db *sqlx.DB
+}
+
+// MigrationStatus returns the migration status of the database schema.
+func (s *SchemaStore) MigrationStatus(ctx context.Context) (*types.MigrationStatus, error) {
+	return migrate.Status(ctx, s.db)
+}
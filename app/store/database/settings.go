@@ -73,15 +73,13 @@ func (s *SettingsStore) Find(
 		From("settings").
 		Where("LOWER(setting_key) = ?", strings.ToLower(key))
 
-	switch scope {
-	case enum.SettingsScopeSpace:
-		stmt = stmt.Where("setting_space_id = ?", scopeID)
-	case enum.SettingsScopeRepo:
-		stmt = stmt.Where("setting_repo_id = ?", scopeID)
-	default:
-		return nil, fmt.Errorf("setting scope %q is not supported", scope)
+	scopeFilter, err := settingsScopeFilter(scope, scopeID)
+	if err != nil {
+		return nil, err
 	}
 
+	stmt = stmt.Where(scopeFilter)
+
 	sql, args, err := stmt.ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
@@ -90,7 +88,7 @@ func (s *SettingsStore) Find(
 	db := dbtx.GetAccessor(ctx, s.db)
 
 	dst := &setting{}
-	if err := db.GetContext(ctx, dst, sql, args...); err != nil {
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
 		return nil, database.ProcessSQLErrorf(ctx, err, "Select query failed")
 	}
 
@@ -117,15 +115,13 @@ func (s *SettingsStore) FindMany(
 		From("settings").
 		Where(squirrel.Eq{"LOWER(setting_key)": keysLower})
 
-	switch scope {
-	case enum.SettingsScopeSpace:
-		stmt = stmt.Where("setting_space_id = ?", scopeID)
-	case enum.SettingsScopeRepo:
-		stmt = stmt.Where("setting_repo_id = ?", scopeID)
-	default:
-		return nil, fmt.Errorf("setting scope %q is not supported", scope)
+	scopeFilter, err := settingsScopeFilter(scope, scopeID)
+	if err != nil {
+		return nil, err
 	}
 
+	stmt = stmt.Where(scopeFilter)
+
 	sql, args, err := stmt.ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
@@ -134,7 +130,7 @@ func (s *SettingsStore) FindMany(
 	db := dbtx.GetAccessor(ctx, s.db)
 
 	dst := []*setting{}
-	if err := db.SelectContext(ctx, &dst, sql, args...); err != nil {
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
 		return nil, database.ProcessSQLErrorf(ctx, err, "Select query failed")
 	}
 
@@ -169,6 +165,9 @@ func (s *SettingsStore) Upsert(ctx context.Context,
 	case enum.SettingsScopeRepo:
 		stmt = stmt.Values(null.Int{}, null.IntFrom(scopeID), key, value)
 		stmt = stmt.Suffix(`ON CONFLICT (setting_repo_id, LOWER(setting_key)) WHERE setting_repo_id IS NOT NULL DO`)
+	case enum.SettingsScopeSystem:
+		stmt = stmt.Values(null.Int{}, null.Int{}, key, value)
+		stmt = stmt.Suffix(`ON CONFLICT (LOWER(setting_key)) WHERE setting_space_id IS NULL AND setting_repo_id IS NULL DO`)
 	default:
 		return fmt.Errorf("setting scope %q is not supported", scope)
 	}
@@ -197,3 +196,47 @@ func (s *SettingsStore) Upsert(ctx context.Context,
 
 	return nil
 }
+
+func (s *SettingsStore) Delete(
+	ctx context.Context,
+	scope enum.SettingsScope,
+	scopeID int64,
+	key string,
+) error {
+	scopeFilter, err := settingsScopeFilter(scope, scopeID)
+	if err != nil {
+		return err
+	}
+
+	stmt := database.Builder.
+		Delete("settings").
+		Where("LOWER(setting_key) = ?", strings.ToLower(key)).
+		Where(scopeFilter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Delete query failed")
+	}
+
+	return nil
+}
+
+// settingsScopeFilter returns the condition selecting the settings of the provided scope.
+func settingsScopeFilter(scope enum.SettingsScope, scopeID int64) (squirrel.Sqlizer, error) {
+	switch scope {
+	case enum.SettingsScopeSpace:
+		return squirrel.Eq{"setting_space_id": scopeID}, nil
+	case enum.SettingsScopeRepo:
+		return squirrel.Eq{"setting_repo_id": scopeID}, nil
+	case enum.SettingsScopeSystem:
+		return squirrel.Eq{"setting_space_id": nil, "setting_repo_id": nil}, nil
+	default:
+		return nil, fmt.Errorf("setting scope %q is not supported", scope)
+	}
+}
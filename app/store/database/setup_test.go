@@ -17,6 +17,7 @@ package database_test
 import (
 	"context"
 	"fmt"
+	"os"
 	"strconv"
 	"testing"
 
@@ -27,6 +28,7 @@ import (
 	"github.com/harness/gitness/types"
 
 	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
 	"github.com/rs/xid"
 )
 
@@ -63,6 +65,51 @@ func setupDB(t *testing.T) (*sqlx.DB, func()) {
 	}
 }
 
+// setupPostgresDB creates an isolated, migrated schema in the postgres database provided via
+// GITNESS_TEST_POSTGRES_DSN (key/value format). The test is skipped if the variable isn't set.
+func setupPostgresDB(t *testing.T) (*sqlx.DB, func()) {
+	t.Helper()
+	db, teardown := setupEmptyPostgresDB(t)
+
+	if err := migrate.Migrate(context.Background(), db); err != nil {
+		teardown()
+		t.Fatalf("Error migrating db, err: %v", err)
+	}
+
+	return db, teardown
+}
+
+// setupEmptyPostgresDB creates an isolated, empty schema in the postgres database provided via
+// GITNESS_TEST_POSTGRES_DSN (key/value format). The test is skipped if the variable isn't set.
+func setupEmptyPostgresDB(t *testing.T) (*sqlx.DB, func()) {
+	t.Helper()
+	dsn := os.Getenv("GITNESS_TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("GITNESS_TEST_POSTGRES_DSN not set")
+	}
+
+	admin, err := sqlx.Connect("postgres", dsn)
+	if err != nil {
+		t.Fatalf("Error opening postgres db, err: %v", err)
+	}
+
+	schema := "test_" + xid.New().String()
+	if _, err = admin.Exec("CREATE SCHEMA " + schema); err != nil {
+		t.Fatalf("Error creating schema, err: %v", err)
+	}
+
+	db, err := sqlx.Connect("postgres", dsn+" search_path="+schema)
+	if err != nil {
+		t.Fatalf("Error opening postgres db, err: %v", err)
+	}
+
+	return db, func() {
+		db.Close()
+		_, _ = admin.Exec("DROP SCHEMA " + schema + " CASCADE")
+		admin.Close()
+	}
+}
+
 func setupStores(t *testing.T, db *sqlx.DB) (
 	*database.PrincipalStore,
 	*database.SpaceStore,
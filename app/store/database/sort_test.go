@@ -0,0 +1,204 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	gitness_database "github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// trickyNames are unique when lower cased, but some of them share the same sort key.
+var trickyNames = []string{
+	"Äpfel", "apfel", "Zebra", "zebra_1", "über", "Ubuntu", "uber-2", "10-repo", "9-repo",
+	"Straße", "strasse-1", "ÉCLAIR", "eclair", "Mixed_Case", "mixed_case_2", "a", "B", "ä",
+}
+
+func TestDatabase_ListPagination(t *testing.T) {
+	backends := map[string]func(t *testing.T) (*sqlx.DB, func()){
+		"sqlite":   setupDB,
+		"postgres": setupPostgresDB,
+	}
+
+	for name, setup := range backends {
+		t.Run(name, func(t *testing.T) {
+			db, teardown := setup(t)
+			defer teardown()
+
+			testListPagination(t, db)
+		})
+	}
+}
+
+func testListPagination(t *testing.T, db *sqlx.DB) {
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+	labelStore := database.NewLabelStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+
+	for i, name := range trickyNames {
+		space := types.Space{Identifier: name, CreatedBy: userID, ParentID: 1}
+		if err := spaceStore.Create(ctx, &space); err != nil {
+			t.Fatalf("failed to create space %q: %v", name, err)
+		}
+
+		if err := spacePathStore.InsertSegment(ctx, &types.SpacePathSegment{
+			Identifier: name, CreatedBy: userID, SpaceID: space.ID, ParentID: 1, IsPrimary: true,
+		}); err != nil {
+			t.Fatalf("failed to insert segment %q: %v", name, err)
+		}
+
+		repo := types.Repository{Identifier: name, ParentID: 1, GitUID: "git_" + strconv.Itoa(i)}
+		if err := repoStore.Create(ctx, &repo); err != nil {
+			t.Fatalf("failed to create repo %q: %v", name, err)
+		}
+
+		user := types.User{UID: name, DisplayName: name, Email: "user_" + strconv.Itoa(i) + "@example.com"}
+		if err := principalStore.CreateUser(ctx, &user); err != nil {
+			t.Fatalf("failed to create user %q: %v", name, err)
+		}
+
+		spaceID := int64(1)
+		label := types.Label{
+			SpaceID: &spaceID, Key: name, Type: enum.LabelTypeStatic, Color: enum.LabelColorBlue,
+			CreatedBy: userID, UpdatedBy: userID,
+		}
+		if err := labelStore.Define(ctx, &label); err != nil {
+			t.Fatalf("failed to define label %q: %v", name, err)
+		}
+	}
+
+	for _, order := range []enum.Order{enum.OrderAsc, enum.OrderDesc} {
+		for _, sort := range []enum.RepoAttr{enum.RepoAttrIdentifier, enum.RepoAttrCreated} {
+			var identifiers []string
+			for page := 1; ; page++ {
+				repos, err := repoStore.List(ctx, 1, &types.RepoFilter{Page: page, Size: 4, Sort: sort, Order: order})
+				if err != nil {
+					t.Fatalf("failed to list repos: %v", err)
+				}
+				if len(repos) == 0 {
+					break
+				}
+				for _, repo := range repos {
+					identifiers = append(identifiers, repo.Identifier)
+				}
+			}
+
+			checkPages(t, fmt.Sprintf("repos by %s %s", sort, order), identifiers, sort == enum.RepoAttrIdentifier, order)
+		}
+
+		for _, sort := range []enum.SpaceAttr{enum.SpaceAttrIdentifier, enum.SpaceAttrCreated} {
+			var identifiers []string
+			for page := 1; ; page++ {
+				spaces, err := spaceStore.List(ctx, 1, &types.SpaceFilter{Page: page, Size: 4, Sort: sort, Order: order})
+				if err != nil {
+					t.Fatalf("failed to list spaces: %v", err)
+				}
+				if len(spaces) == 0 {
+					break
+				}
+				for _, space := range spaces {
+					identifiers = append(identifiers, space.Identifier)
+				}
+			}
+
+			checkPages(t, fmt.Sprintf("spaces by %s %s", sort, order), identifiers, sort == enum.SpaceAttrIdentifier, order)
+		}
+
+		for _, sort := range []enum.UserAttr{enum.UserAttrName, enum.UserAttrUID, enum.UserAttrCreated} {
+			var identifiers []string
+			for page := 1; ; page++ {
+				users, err := principalStore.ListUsers(ctx, &types.UserFilter{Page: page, Size: 4, Sort: sort, Order: order})
+				if err != nil {
+					t.Fatalf("failed to list users: %v", err)
+				}
+				if len(users) == 0 {
+					break
+				}
+				for _, user := range users {
+					// skip the owner of the test space, its display name is empty.
+					if user.ID == userID {
+						continue
+					}
+					identifiers = append(identifiers, user.UID)
+				}
+			}
+
+			checkPages(t, fmt.Sprintf("users by %v %s", sort, order), identifiers, sort != enum.UserAttrCreated, order)
+		}
+	}
+
+	var keys []string
+	spaceID := int64(1)
+	for page := 1; ; page++ {
+		labels, err := labelStore.List(ctx, &spaceID, nil,
+			&types.LabelFilter{ListQueryFilter: types.ListQueryFilter{Pagination: types.Pagination{Page: page, Size: 4}}})
+		if err != nil {
+			t.Fatalf("failed to list labels: %v", err)
+		}
+		if len(labels) == 0 {
+			break
+		}
+		for _, label := range labels {
+			keys = append(keys, label.Key)
+		}
+	}
+
+	checkPages(t, "labels by key", keys, true, enum.OrderAsc)
+}
+
+// checkPages verifies that all pages together contain every name exactly once.
+func checkPages(t *testing.T, name string, identifiers []string, sorted bool, order enum.Order) {
+	t.Helper()
+
+	seen := make(map[string]struct{}, len(identifiers))
+	for _, identifier := range identifiers {
+		if _, ok := seen[identifier]; ok {
+			t.Errorf("%s: %q is listed more than once", name, identifier)
+		}
+		seen[identifier] = struct{}{}
+	}
+
+	for _, identifier := range trickyNames {
+		if _, ok := seen[identifier]; !ok {
+			t.Errorf("%s: %q is missing", name, identifier)
+		}
+	}
+
+	if !sorted {
+		return
+	}
+
+	for i := 1; i < len(identifiers); i++ {
+		prev, curr := gitness_database.SortKey(identifiers[i-1]), gitness_database.SortKey(identifiers[i])
+		if order == enum.OrderDesc {
+			prev, curr = curr, prev
+		}
+		if prev > curr {
+			t.Errorf("%s: %q is listed before %q", name, identifiers[i-1], identifiers[i])
+		}
+	}
+}
@@ -62,13 +62,15 @@ type space struct {
 	ID      int64 `db:"space_id"`
 	Version int64 `db:"space_version"`
 	// IMPORTANT: We need to make parentID optional for spaces to allow it to be a foreign key.
-	ParentID    null.Int `db:"space_parent_id"`
-	Identifier  string   `db:"space_uid"`
-	Description string   `db:"space_description"`
-	CreatedBy   int64    `db:"space_created_by"`
-	Created     int64    `db:"space_created"`
-	Updated     int64    `db:"space_updated"`
-	Deleted     null.Int `db:"space_deleted"`
+	ParentID       null.Int `db:"space_parent_id"`
+	Identifier     string   `db:"space_uid"`
+	UIDSortKey     string   `db:"space_uid_sort_key"`
+	Description    string   `db:"space_description"`
+	ExecutionLimit int      `db:"space_execution_limit"`
+	CreatedBy      int64    `db:"space_created_by"`
+	Created        int64    `db:"space_created"`
+	Updated        int64    `db:"space_updated"`
+	Deleted        null.Int `db:"space_deleted"`
 }
 
 const (
@@ -78,6 +80,7 @@ const (
 		,space_parent_id
 		,space_uid
 		,space_description
+		,space_execution_limit
 		,space_created_by
 		,space_created
 		,space_updated
@@ -337,7 +340,9 @@ func (s *SpaceStore) Create(ctx context.Context, space *types.Space) error {
 			space_version
 			,space_parent_id
 			,space_uid
+			,space_uid_sort_key
 			,space_description
+			,space_execution_limit
 			,space_created_by
 			,space_created
 			,space_updated
@@ -346,7 +351,9 @@ func (s *SpaceStore) Create(ctx context.Context, space *types.Space) error {
 			:space_version
 			,:space_parent_id
 			,:space_uid
+			,:space_uid_sort_key
 			,:space_description
+			,:space_execution_limit
 			,:space_created_by
 			,:space_created
 			,:space_updated
@@ -380,7 +387,9 @@ func (s *SpaceStore) Update(ctx context.Context, space *types.Space) error {
 			,space_updated		= :space_updated
 			,space_parent_id	= :space_parent_id
 			,space_uid			= :space_uid
+			,space_uid_sort_key	= :space_uid_sort_key
 			,space_description	= :space_description
+			,space_execution_limit	= :space_execution_limit
 			,space_deleted 		= :space_deleted
 		WHERE space_id = :space_id AND space_version = :space_version - 1`
 
@@ -610,7 +619,7 @@ func (s *SpaceStore) count(
 		return 0, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	var count int64
 	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
@@ -638,7 +647,7 @@ func (s *SpaceStore) countAll(
 		JOIN SpaceHierarchy h ON s.space_parent_id = h.space_id
 	)`
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	stmt := database.Builder.
 		Select("COUNT(*)").
@@ -691,7 +700,7 @@ func (s *SpaceStore) list(
 		return nil, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	var dst []*space
 	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
@@ -717,7 +726,7 @@ func (s *SpaceStore) listAll(ctx context.Context,
 		JOIN SpaceHierarchy h ON s.space_parent_id = h.space_id
 	)`
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	db := dbtx.GetReadAccessor(ctx, s.db)
 
 	stmt := database.Builder.
 		Select(spaceColumns).
@@ -757,6 +766,10 @@ func (s *SpaceStore) applyQueryFilter(
 		stmt = stmt.Where("space_deleted IS NULL")
 	}
 
+	if opts.OnlyPublic {
+		stmt = stmt.Where("space_id IN (SELECT public_access_space_id FROM public_access_space)")
+	}
+
 	return stmt
 }
 
@@ -799,9 +812,7 @@ func (s *SpaceStore) applySortFilter(
 		// NOTE: string concatenation is safe because the
 		// order attribute is an enum and is not user-defined,
 		// and is therefore not subject to injection attacks.
-		stmt = stmt.OrderBy("space_uid " + opts.Order.String())
-		//TODO: Postgres does not support COLLATE NOCASE for UTF8
-		// stmt = stmt.OrderBy("space_uid COLLATE NOCASE " + opts.Order.String())
+		stmt = stmt.OrderBy("space_uid_sort_key " + opts.Order.String())
 	case enum.SpaceAttrCreated:
 		stmt = stmt.OrderBy("space_created " + opts.Order.String())
 	case enum.SpaceAttrUpdated:
@@ -809,6 +820,10 @@ func (s *SpaceStore) applySortFilter(
 	case enum.SpaceAttrDeleted:
 		stmt = stmt.OrderBy("space_deleted " + opts.Order.String())
 	}
+
+	// space ID as tiebreaker guarantees a stable order across pages.
+	stmt = stmt.OrderBy("space_id " + opts.Order.String())
+
 	return stmt
 }
 
@@ -820,14 +835,15 @@ func mapToSpace(
 ) (*types.Space, error) {
 	var err error
 	res := &types.Space{
-		ID:          in.ID,
-		Version:     in.Version,
-		Identifier:  in.Identifier,
-		Description: in.Description,
-		Created:     in.Created,
-		CreatedBy:   in.CreatedBy,
-		Updated:     in.Updated,
-		Deleted:     in.Deleted.Ptr(),
+		ID:             in.ID,
+		Version:        in.Version,
+		Identifier:     in.Identifier,
+		Description:    in.Description,
+		ExecutionLimit: in.ExecutionLimit,
+		Created:        in.Created,
+		CreatedBy:      in.CreatedBy,
+		Updated:        in.Updated,
+		Deleted:        in.Deleted.Ptr(),
 	}
 
 	// Only overwrite ParentID if it's not a root space
@@ -880,14 +896,16 @@ func (s *SpaceStore) mapToSpaces(
 
 func mapToInternalSpace(s *types.Space) *space {
 	res := &space{
-		ID:          s.ID,
-		Version:     s.Version,
-		Identifier:  s.Identifier,
-		Description: s.Description,
-		Created:     s.Created,
-		CreatedBy:   s.CreatedBy,
-		Updated:     s.Updated,
-		Deleted:     null.IntFromPtr(s.Deleted),
+		ID:             s.ID,
+		Version:        s.Version,
+		Identifier:     s.Identifier,
+		UIDSortKey:     database.SortKey(s.Identifier),
+		Description:    s.Description,
+		ExecutionLimit: s.ExecutionLimit,
+		Created:        s.Created,
+		CreatedBy:      s.CreatedBy,
+		Updated:        s.Updated,
+		Deleted:        null.IntFromPtr(s.Deleted),
 	}
 
 	// Only overwrite ParentID if it's not a root space
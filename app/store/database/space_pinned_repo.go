@@ -0,0 +1,154 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.SpacePinnedRepoStore = (*SpacePinnedRepoStore)(nil)
+
+// NewSpacePinnedRepoStore returns a new SpacePinnedRepoStore.
+func NewSpacePinnedRepoStore(db *sqlx.DB) *SpacePinnedRepoStore {
+	return &SpacePinnedRepoStore{
+		db: db,
+	}
+}
+
+// SpacePinnedRepoStore implements store.SpacePinnedRepoStore backed by a relational database.
+type SpacePinnedRepoStore struct {
+	db *sqlx.DB
+}
+
+type spacePinnedRepo struct {
+	SpaceID   int64 `db:"space_pinned_repo_space_id"`
+	RepoID    int64 `db:"space_pinned_repo_repo_id"`
+	Order     int   `db:"space_pinned_repo_order"`
+	Created   int64 `db:"space_pinned_repo_created"`
+	CreatedBy int64 `db:"space_pinned_repo_created_by"`
+}
+
+const spacePinnedRepoColumns = `
+	 space_pinned_repo_space_id
+	,space_pinned_repo_repo_id
+	,space_pinned_repo_order
+	,space_pinned_repo_created
+	,space_pinned_repo_created_by`
+
+// List returns the pins of the space in their order.
+// Pins of repos that were deleted or aren't in the space anymore are omitted.
+func (s *SpacePinnedRepoStore) List(ctx context.Context, spaceID int64) ([]types.SpacePinnedRepo, error) {
+	const sqlQuery = `
+		SELECT` + spacePinnedRepoColumns + `
+		FROM space_pinned_repos
+		INNER JOIN repositories ON repo_id = space_pinned_repo_repo_id
+		WHERE space_pinned_repo_space_id = $1 AND
+			repo_parent_id = space_pinned_repo_space_id AND
+			repo_deleted IS NULL
+		ORDER BY space_pinned_repo_order, space_pinned_repo_repo_id`
+
+	db := dbtx.GetReadAccessor(ctx, s.db)
+
+	var dst []spacePinnedRepo
+	if err := db.SelectContext(ctx, &dst, sqlQuery, spaceID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list pinned repos")
+	}
+
+	pins := make([]types.SpacePinnedRepo, len(dst))
+	for i := range dst {
+		pins[i] = types.SpacePinnedRepo(dst[i])
+	}
+
+	return pins, nil
+}
+
+// Replace replaces all pins of the space with the provided ones, ordered as provided.
+// It should be called within a transaction.
+func (s *SpacePinnedRepoStore) Replace(ctx context.Context, spaceID int64, pins []types.SpacePinnedRepo) error {
+	const sqlQueryDelete = `
+		DELETE FROM space_pinned_repos
+		WHERE space_pinned_repo_space_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQueryDelete, spaceID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete pinned repos")
+	}
+
+	if len(pins) == 0 {
+		return nil
+	}
+
+	stmt := database.Builder.
+		Insert("").
+		Into("space_pinned_repos").
+		Columns(
+			"space_pinned_repo_space_id",
+			"space_pinned_repo_repo_id",
+			"space_pinned_repo_order",
+			"space_pinned_repo_created",
+			"space_pinned_repo_created_by",
+		)
+
+	for i, pin := range pins {
+		stmt = stmt.Values(spaceID, pin.RepoID, i, pin.Created, pin.CreatedBy)
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert pinned repos")
+	}
+
+	return nil
+}
+
+// DeleteStale deletes the pins of repos that were deleted or aren't in the space anymore.
+func (s *SpacePinnedRepoStore) DeleteStale(ctx context.Context) (int64, error) {
+	const sqlQuery = `
+		DELETE FROM space_pinned_repos
+		WHERE NOT EXISTS (
+			SELECT 1
+			FROM repositories
+			WHERE repo_id = space_pinned_repo_repo_id AND
+				repo_parent_id = space_pinned_repo_space_id AND
+				repo_deleted IS NULL
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to delete stale pinned repos")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted pinned repos")
+	}
+
+	return n, nil
+}
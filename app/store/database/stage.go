@@ -61,6 +61,9 @@ const (
 	,stage_on_failure
 	,stage_depends_on
 	,stage_labels
+	,stage_approval
+	,stage_step_timeouts
+	,stage_matrix
 	`
 )
 
@@ -93,6 +96,9 @@ type stage struct {
 	OnFailure     bool               `db:"stage_on_failure"`
 	DependsOn     sqlxtypes.JSONText `db:"stage_depends_on"`
 	Labels        sqlxtypes.JSONText `db:"stage_labels"`
+	Approval      sqlxtypes.JSONText `db:"stage_approval"`
+	StepTimeouts  sqlxtypes.JSONText `db:"stage_step_timeouts"`
+	Matrix        sqlxtypes.JSONText `db:"stage_matrix"`
 }
 
 // NewStageStore returns a new StageStore.
@@ -152,6 +158,9 @@ func (s *stageStore) Create(ctx context.Context, st *types.Stage) error {
 			,stage_on_failure
 			,stage_depends_on
 			,stage_labels
+			,stage_approval
+			,stage_step_timeouts
+			,stage_matrix
 		) VALUES (
 			:stage_execution_id
 			,:stage_repo_id
@@ -180,7 +189,9 @@ func (s *stageStore) Create(ctx context.Context, st *types.Stage) error {
 			,:stage_on_failure
 			,:stage_depends_on
 			,:stage_labels
-
+			,:stage_approval
+			,:stage_step_timeouts
+			,:stage_matrix
 		) RETURNING stage_id`
 	db := dbtx.GetAccessor(ctx, s.db)
 
@@ -250,6 +261,24 @@ func (s *stageStore) ListIncomplete(ctx context.Context) ([]*types.Stage, error)
 	return mapInternalToStageList(dst)
 }
 
+// ListBlocked returns a list of stages that are waiting for approval.
+func (s *stageStore) ListBlocked(ctx context.Context) ([]*types.Stage, error) {
+	const queryListBlocked = `
+	SELECT` + stageColumns + `
+	FROM stages
+	WHERE stage_status = 'blocked'
+	ORDER BY stage_id ASC
+	`
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*stage{}
+	if err := db.SelectContext(ctx, &dst, queryListBlocked); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find blocked stages")
+	}
+	// map stages list
+	return mapInternalToStageList(dst)
+}
+
 // List returns a list of stages corresponding to an execution ID.
 func (s *stageStore) List(ctx context.Context, executionID int64) ([]*types.Stage, error) {
 	const queryList = `
@@ -287,6 +316,7 @@ func (s *stageStore) Update(ctx context.Context, st *types.Stage) error {
 		,stage_errignore = :stage_errignore
 		,stage_depends_on = :stage_depends_on
 		,stage_labels = :stage_labels
+		,stage_approval = :stage_approval
 	WHERE stage_id = :stage_id AND stage_version = :stage_version - 1`
 	updatedAt := time.Now()
 	steps := st.Steps
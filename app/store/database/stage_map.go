@@ -43,6 +43,8 @@ type nullstep struct {
 	Image         sql.NullString     `db:"step_image"`
 	Detached      sql.NullBool       `db:"step_detached"`
 	Schema        sql.NullString     `db:"step_schema"`
+	Timeout       sql.NullInt64      `db:"step_timeout"`
+	TimedOut      sql.NullBool       `db:"step_timed_out"`
 }
 
 // used for join operations where fields may be null.
@@ -68,6 +70,8 @@ func convertFromNullStep(nullstep *nullstep) (*types.Step, error) {
 		Image:     nullstep.Image.String,
 		Detached:  nullstep.Detached.Bool,
 		Schema:    nullstep.Schema.String,
+		Timeout:   nullstep.Timeout.Int64,
+		TimedOut:  nullstep.TimedOut.Bool,
 	}, nil
 }
 
@@ -82,66 +86,87 @@ func mapInternalToStage(in *stage) (*types.Stage, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "could not unmarshal stage.labels")
 	}
+	var approval *types.StageApprovalGate
+	err = json.Unmarshal(in.Approval, &approval)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal stage.approval")
+	}
+	var stepTimeouts map[string]int64
+	err = json.Unmarshal(in.StepTimeouts, &stepTimeouts)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal stage.step_timeouts")
+	}
+	var matrix *types.StageMatrix
+	err = json.Unmarshal(in.Matrix, &matrix)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal stage.matrix")
+	}
 	return &types.Stage{
-		ID:          in.ID,
-		ExecutionID: in.ExecutionID,
-		RepoID:      in.RepoID,
-		Number:      in.Number,
-		Name:        in.Name,
-		Kind:        in.Kind,
-		Type:        in.Type,
-		Status:      in.Status,
-		Error:       in.Error,
-		ErrIgnore:   in.ErrIgnore,
-		ExitCode:    in.ExitCode,
-		Machine:     in.Machine,
-		OS:          in.OS,
-		Arch:        in.Arch,
-		Variant:     in.Variant,
-		Kernel:      in.Kernel,
-		Limit:       in.Limit,
-		LimitRepo:   in.LimitRepo,
-		Started:     in.Started,
-		Stopped:     in.Stopped,
-		Created:     in.Created,
-		Updated:     in.Updated,
-		Version:     in.Version,
-		OnSuccess:   in.OnSuccess,
-		OnFailure:   in.OnFailure,
-		DependsOn:   dependsOn,
-		Labels:      labels,
+		ID:           in.ID,
+		ExecutionID:  in.ExecutionID,
+		RepoID:       in.RepoID,
+		Number:       in.Number,
+		Name:         in.Name,
+		Kind:         in.Kind,
+		Type:         in.Type,
+		Status:       in.Status,
+		Error:        in.Error,
+		ErrIgnore:    in.ErrIgnore,
+		ExitCode:     in.ExitCode,
+		Machine:      in.Machine,
+		OS:           in.OS,
+		Arch:         in.Arch,
+		Variant:      in.Variant,
+		Kernel:       in.Kernel,
+		Limit:        in.Limit,
+		LimitRepo:    in.LimitRepo,
+		Started:      in.Started,
+		Stopped:      in.Stopped,
+		Created:      in.Created,
+		Updated:      in.Updated,
+		Version:      in.Version,
+		OnSuccess:    in.OnSuccess,
+		OnFailure:    in.OnFailure,
+		DependsOn:    dependsOn,
+		Labels:       labels,
+		Approval:     approval,
+		StepTimeouts: stepTimeouts,
+		Matrix:       matrix,
 	}, nil
 }
 
 func mapStageToInternal(in *types.Stage) *stage {
 	return &stage{
-		ID:          in.ID,
-		ExecutionID: in.ExecutionID,
-		RepoID:      in.RepoID,
-		Number:      in.Number,
-		Name:        in.Name,
-		Kind:        in.Kind,
-		Type:        in.Type,
-		Status:      in.Status,
-		Error:       in.Error,
-		ErrIgnore:   in.ErrIgnore,
-		ExitCode:    in.ExitCode,
-		Machine:     in.Machine,
-		OS:          in.OS,
-		Arch:        in.Arch,
-		Variant:     in.Variant,
-		Kernel:      in.Kernel,
-		Limit:       in.Limit,
-		LimitRepo:   in.LimitRepo,
-		Started:     in.Started,
-		Stopped:     in.Stopped,
-		Created:     in.Created,
-		Updated:     in.Updated,
-		Version:     in.Version,
-		OnSuccess:   in.OnSuccess,
-		OnFailure:   in.OnFailure,
-		DependsOn:   EncodeToSQLXJSON(in.DependsOn),
-		Labels:      EncodeToSQLXJSON(in.Labels),
+		ID:           in.ID,
+		ExecutionID:  in.ExecutionID,
+		RepoID:       in.RepoID,
+		Number:       in.Number,
+		Name:         in.Name,
+		Kind:         in.Kind,
+		Type:         in.Type,
+		Status:       in.Status,
+		Error:        in.Error,
+		ErrIgnore:    in.ErrIgnore,
+		ExitCode:     in.ExitCode,
+		Machine:      in.Machine,
+		OS:           in.OS,
+		Arch:         in.Arch,
+		Variant:      in.Variant,
+		Kernel:       in.Kernel,
+		Limit:        in.Limit,
+		LimitRepo:    in.LimitRepo,
+		Started:      in.Started,
+		Stopped:      in.Stopped,
+		Created:      in.Created,
+		Updated:      in.Updated,
+		Version:      in.Version,
+		OnSuccess:    in.OnSuccess,
+		OnFailure:    in.OnFailure,
+		DependsOn:    EncodeToSQLXJSON(in.DependsOn),
+		Labels:       EncodeToSQLXJSON(in.Labels),
+		Approval:     EncodeToSQLXJSON(in.Approval),
+		StepTimeouts: EncodeToSQLXJSON(in.StepTimeouts),
+		Matrix:       EncodeToSQLXJSON(in.Matrix),
 	}
 }
 
@@ -191,6 +216,9 @@ func scanRowsWithSteps(rows *sql.Rows) ([]*types.Stage, error) {
 func scanRowStep(rows *sql.Rows, stage *types.Stage, step *nullstep) error {
 	depJSON := sqlxtypes.JSONText{}
 	labJSON := sqlxtypes.JSONText{}
+	approvalJSON := sqlxtypes.JSONText{}
+	stepTimeoutsJSON := sqlxtypes.JSONText{}
+	matrixJSON := sqlxtypes.JSONText{}
 	stepDepJSON := sqlxtypes.JSONText{}
 	err := rows.Scan(
 		&stage.ID,
@@ -220,6 +248,9 @@ func scanRowStep(rows *sql.Rows, stage *types.Stage, step *nullstep) error {
 		&stage.OnFailure,
 		&depJSON,
 		&labJSON,
+		&approvalJSON,
+		&stepTimeoutsJSON,
+		&matrixJSON,
 		&step.ID,
 		&step.StageID,
 		&step.Number,
@@ -235,6 +266,8 @@ func scanRowStep(rows *sql.Rows, stage *types.Stage, step *nullstep) error {
 		&step.Image,
 		&step.Detached,
 		&step.Schema,
+		&step.Timeout,
+		&step.TimedOut,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to scan row: %w", err)
@@ -247,6 +280,18 @@ func scanRowStep(rows *sql.Rows, stage *types.Stage, step *nullstep) error {
 	if err != nil {
 		return fmt.Errorf("failed to unmarshal labJSON: %w", err)
 	}
+	err = json.Unmarshal(approvalJSON, &stage.Approval)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal approvalJSON: %w", err)
+	}
+	err = json.Unmarshal(stepTimeoutsJSON, &stage.StepTimeouts)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal stepTimeoutsJSON: %w", err)
+	}
+	err = json.Unmarshal(matrixJSON, &stage.Matrix)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal matrixJSON: %w", err)
+	}
 	if step.ID.Valid {
 		// try to unmarshal step dependencies if step exists
 		err = json.Unmarshal(stepDepJSON, &step.DependsOn)
@@ -48,6 +48,8 @@ const (
 	,step_image
 	,step_detached
 	,step_schema
+	,step_timeout
+	,step_timed_out
 	`
 )
 
@@ -68,6 +70,8 @@ type step struct {
 	Image         string             `db:"step_image"`
 	Detached      bool               `db:"step_detached"`
 	Schema        string             `db:"step_schema"`
+	Timeout       int64              `db:"step_timeout"`
+	TimedOut      bool               `db:"step_timed_out"`
 }
 
 // NewStepStore returns a new StepStore.
@@ -115,6 +119,8 @@ func (s *stepStore) Create(ctx context.Context, step *types.Step) error {
 		,step_image
 		,step_detached
 		,step_schema
+		,step_timeout
+		,step_timed_out
 	) VALUES (
 		:step_stage_id
 		,:step_number
@@ -131,6 +137,8 @@ func (s *stepStore) Create(ctx context.Context, step *types.Step) error {
 		,:step_image
 		,:step_detached
 		,:step_schema
+		,:step_timeout
+		,:step_timed_out
 	) RETURNING step_id`
 	db := dbtx.GetAccessor(ctx, s.db)
 
@@ -163,6 +171,7 @@ func (s *stepStore) Update(ctx context.Context, e *types.Step) error {
 		,step_image = :step_image
 		,step_detached = :step_detached
 		,step_schema = :step_schema
+		,step_timed_out = :step_timed_out
 		,step_version = :step_version
 	WHERE step_id = :step_id AND step_version = :step_version - 1`
 	step := mapStepToInternal(e)
@@ -198,3 +207,18 @@ func (s *stepStore) Update(ctx context.Context, e *types.Step) error {
 	e.Version = step.Version
 	return nil
 }
+
+// DeleteByStageID deletes all steps of a stage, their logs are deleted by the foreign key cascade.
+func (s *stepStore) DeleteByStageID(ctx context.Context, stageID int64) error {
+	const stepDeleteStmt = `
+	DELETE FROM steps
+	WHERE step_stage_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, stepDeleteStmt, stageID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete steps of stage")
+	}
+
+	return nil
+}
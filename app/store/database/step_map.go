@@ -43,6 +43,8 @@ func mapInternalToStep(in *step) (*types.Step, error) {
 		Image:     in.Image,
 		Detached:  in.Detached,
 		Schema:    in.Schema,
+		Timeout:   in.Timeout,
+		TimedOut:  in.TimedOut,
 	}, nil
 }
 
@@ -63,5 +65,7 @@ func mapStepToInternal(in *types.Step) *step {
 		Image:     in.Image,
 		Detached:  in.Detached,
 		Schema:    in.Schema,
+		Timeout:   in.Timeout,
+		TimedOut:  in.TimedOut,
 	}
 }
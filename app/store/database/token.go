@@ -99,6 +99,86 @@ func (s *TokenStore) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
+// DeleteForPrincipal deletes all tokens of the principal and returns the number of deleted tokens.
+// If tokenTypes are provided, then only tokens of that type are deleted.
+func (s *TokenStore) DeleteForPrincipal(
+	ctx context.Context,
+	principalID int64,
+	tknTypes []enum.TokenType,
+) (int64, error) {
+	stmt := database.Builder.
+		Delete("tokens").
+		Where("token_principal_id = ?", principalID)
+
+	if len(tknTypes) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"token_type": tknTypes})
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert delete token query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "failed to execute delete token query")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "failed to get number of deleted tokens")
+	}
+
+	return n, nil
+}
+
+// DeleteForPrincipalExcept deletes all tokens of the given type of the principal except the token with the provided id
+// and returns the number of deleted tokens.
+func (s *TokenStore) DeleteForPrincipalExcept(
+	ctx context.Context,
+	principalID int64,
+	tknType enum.TokenType,
+	exceptID int64,
+) (int64, error) {
+	stmt := database.Builder.
+		Delete("tokens").
+		Where("token_principal_id = ?", principalID).
+		Where("token_type = ?", tknType).
+		Where("token_id <> ?", exceptID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert delete token query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "failed to execute delete token query")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "failed to get number of deleted tokens")
+	}
+
+	return n, nil
+}
+
+// UpdateLastUsed updates the time the token was last used.
+func (s *TokenStore) UpdateLastUsed(ctx context.Context, id int64, lastUsedAt int64) error {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, tokenUpdateLastUsedAt, lastUsedAt, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update last used time of token")
+	}
+
+	return nil
+}
+
 // DeleteExpiredBefore deletes all tokens that expired before the provided time.
 // If tokenTypes are provided, then only tokens of that type are deleted.
 func (s *TokenStore) DeleteExpiredBefore(
@@ -164,6 +244,19 @@ func (s *TokenStore) List(ctx context.Context,
 	return dst, nil
 }
 
+// ListForPrincipal returns all tokens of the principal.
+func (s *TokenStore) ListForPrincipal(ctx context.Context, principalID int64) ([]*types.Token, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*types.Token{}
+
+	err := db.SelectContext(ctx, &dst, tokenSelectForPrincipalID, principalID)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing token list query")
+	}
+	return dst, nil
+}
+
 const tokenSelectBase = `
 SELECT
 token_id
@@ -173,6 +266,9 @@ token_id
 ,token_expires_at
 ,token_issued_at
 ,token_created_by
+,token_user_agent
+,token_ip_address
+,token_last_used_at
 FROM tokens
 ` //#nosec G101
 
@@ -181,6 +277,10 @@ WHERE token_principal_id = $1 AND token_type = $2
 ORDER BY token_issued_at DESC
 ` //#nosec G101
 
+const tokenSelectForPrincipalID = tokenSelectBase + `
+WHERE token_principal_id = $1
+` //#nosec G101
+
 const tokenCountForPrincipalIDOfType = `
 SELECT count(*)
 FROM tokens
@@ -200,6 +300,12 @@ DELETE FROM tokens
 WHERE token_id = $1
 `
 
+const tokenUpdateLastUsedAt = `
+UPDATE tokens
+SET token_last_used_at = $1
+WHERE token_id = $2
+`
+
 const tokenInsert = `
 INSERT INTO tokens (
 	token_type
@@ -208,6 +314,8 @@ INSERT INTO tokens (
 	,token_expires_at
 	,token_issued_at
 	,token_created_by
+	,token_user_agent
+	,token_ip_address
 ) values (
 	:token_type
 	,:token_uid
@@ -215,5 +323,7 @@ INSERT INTO tokens (
 	,:token_expires_at
 	,:token_issued_at
 	,:token_created_by
+	,:token_user_agent
+	,:token_ip_address
 ) RETURNING token_id
 `
@@ -0,0 +1,186 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transfer
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const nullMarker = "\x00NULL"
+
+// timeLayouts are the layouts sqlite uses for timestamps stored as text.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// convert converts a value read from sqlite to a value accepted by the target postgres column.
+func convert(value any, dataType string) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	switch dataType {
+	case "boolean":
+		return toBool(value)
+	case "timestamp without time zone", "timestamp with time zone", "date":
+		return toTime(value)
+	case "smallint", "integer", "bigint":
+		if b, ok := value.(bool); ok {
+			if b {
+				return int64(1), nil
+			}
+			return int64(0), nil
+		}
+		return value, nil
+	case "bytea":
+		if s, ok := value.(string); ok {
+			return []byte(s), nil
+		}
+		return value, nil
+	default:
+		// sqlite returns values of columns with blob affinity as bytes,
+		// which would be sent to postgres as bytea otherwise.
+		if b, ok := value.([]byte); ok {
+			return string(b), nil
+		}
+		return value, nil
+	}
+}
+
+func toBool(value any) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case int64:
+		return v != 0, nil
+	case float64:
+		return v != 0, nil
+	case []byte:
+		return toBool(string(v))
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "1", "t", "true":
+			return true, nil
+		case "0", "f", "false":
+			return false, nil
+		}
+	}
+
+	return false, fmt.Errorf("can't convert %T value %v to boolean", value, value)
+}
+
+func toTime(value any) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v.UTC(), nil
+	case int64:
+		return time.Unix(v, 0).UTC(), nil
+	case []byte:
+		return toTime(string(v))
+	case string:
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t.UTC(), nil
+			}
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("can't convert %T value %v to timestamp", value, value)
+}
+
+// normalize returns a textual representation of a value that is identical
+// for equal values read from sqlite (after conversion) and from postgres.
+func normalize(value any, dataType string) string {
+	switch v := value.(type) {
+	case nil:
+		// postgres doesn't allow NUL characters in text, so the marker can't collide with a value.
+		return nullMarker
+	case time.Time:
+		// postgres stores timestamps with microsecond precision.
+		return v.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano)
+	case []byte:
+		if dataType == "bytea" {
+			return hex.EncodeToString(v)
+		}
+		return normalizeString(string(v), dataType)
+	case string:
+		return normalizeString(v, dataType)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func normalizeString(s string, dataType string) string {
+	if dataType != "json" && dataType != "jsonb" {
+		return s
+	}
+
+	// postgres doesn't preserve the formatting and key order of jsonb values.
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return s
+	}
+
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return s
+	}
+
+	return strings.TrimSpace(buf.String())
+}
+
+// normalizeRow returns the normalized representation of a row.
+func normalizeRow(values []any, columns []column) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = strconv.Quote(normalize(v, columns[i].dataType))
+	}
+	return strings.Join(parts, ",")
+}
+
+// hashRows returns a hash of the provided normalized rows that doesn't depend on their order.
+func hashRows(rows []string) string {
+	sorted := make([]string, len(rows))
+	copy(sorted, rows)
+	sort.Strings(sorted)
+
+	h := sha256.New()
+	for _, row := range sorted {
+		h.Write([]byte(row))
+		h.Write([]byte{'\n'})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,253 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transfer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// migrationsTable is managed by the schema migrations and therefore never copied.
+const migrationsTable = "migrations"
+
+// table describes a table that exists in both the source and the target database.
+type table struct {
+	name    string
+	columns []column
+	// pk contains the names of the primary key columns in the order of the key.
+	pk []string
+}
+
+// column describes a column that exists in both the source and the target database.
+type column struct {
+	name string
+	// dataType is the data type of the column in the target database.
+	dataType string
+	// sequence is true if the target column takes its default value from a sequence.
+	sequence bool
+}
+
+func quote(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func (t *table) columnList() string {
+	names := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		names[i] = quote(c.name)
+	}
+	return strings.Join(names, ", ")
+}
+
+func (t *table) pkColumns() []column {
+	result := make([]column, 0, len(t.pk))
+	for _, name := range t.pk {
+		for _, c := range t.columns {
+			if c.name == name {
+				result = append(result, c)
+			}
+		}
+	}
+	return result
+}
+
+// listTables returns all tables that exist in both databases in the order they have to be copied
+// so that rows referenced by foreign keys are always copied before the rows referencing them.
+func listTables(ctx context.Context, src *sqlx.DB, dst *sqlx.DB) ([]*table, error) {
+	var srcNames []string
+	err := src.SelectContext(ctx, &srcNames, `
+		SELECT name
+		FROM sqlite_master
+		WHERE type = 'table' AND name NOT LIKE 'sqlite_%'`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list source tables: %w", err)
+	}
+
+	var dstNames []string
+	err = dst.SelectContext(ctx, &dstNames, `
+		SELECT table_name
+		FROM information_schema.tables
+		WHERE table_schema = current_schema() AND table_type = 'BASE TABLE'`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list target tables: %w", err)
+	}
+
+	dstSet := make(map[string]struct{}, len(dstNames))
+	for _, name := range dstNames {
+		dstSet[name] = struct{}{}
+	}
+
+	tables := make(map[string]*table)
+	for _, name := range srcNames {
+		if name == migrationsTable {
+			continue
+		}
+		if _, ok := dstSet[name]; !ok {
+			return nil, fmt.Errorf("source table %q doesn't exist in the target database", name)
+		}
+
+		tables[name], err = describeTable(ctx, src, dst, name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	deps, err := listDependencies(ctx, dst)
+	if err != nil {
+		return nil, err
+	}
+
+	return sortTables(tables, deps), nil
+}
+
+func describeTable(ctx context.Context, src *sqlx.DB, dst *sqlx.DB, name string) (*table, error) {
+	type srcColumn struct {
+		CID          int     `db:"cid"`
+		Name         string  `db:"name"`
+		Type         string  `db:"type"`
+		NotNull      bool    `db:"notnull"`
+		DefaultValue *string `db:"dflt_value"`
+		PK           int     `db:"pk"`
+	}
+
+	var srcColumns []srcColumn
+	if err := src.SelectContext(ctx, &srcColumns, "PRAGMA table_info("+quote(name)+")"); err != nil {
+		return nil, fmt.Errorf("failed to describe source table %q: %w", name, err)
+	}
+
+	type dstColumn struct {
+		Name       string  `db:"column_name"`
+		DataType   string  `db:"data_type"`
+		Default    *string `db:"column_default"`
+		IsIdentity string  `db:"is_identity"`
+	}
+
+	var dstColumns []dstColumn
+	err := dst.SelectContext(ctx, &dstColumns, `
+		SELECT column_name, data_type, column_default, is_identity
+		FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = $1
+		ORDER BY ordinal_position`, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe target table %q: %w", name, err)
+	}
+
+	dstByName := make(map[string]dstColumn, len(dstColumns))
+	for _, c := range dstColumns {
+		dstByName[c.Name] = c
+	}
+
+	t := &table{name: name}
+	pkPositions := make(map[string]int)
+
+	for _, c := range srcColumns {
+		d, ok := dstByName[c.Name]
+		if !ok {
+			return nil, fmt.Errorf("source column %q.%q doesn't exist in the target database", name, c.Name)
+		}
+
+		t.columns = append(t.columns, column{
+			name:     c.Name,
+			dataType: d.DataType,
+			sequence: d.IsIdentity == "YES" || (d.Default != nil && strings.HasPrefix(*d.Default, "nextval(")),
+		})
+
+		if c.PK > 0 {
+			t.pk = append(t.pk, c.Name)
+			pkPositions[c.Name] = c.PK
+		}
+	}
+
+	sort.Slice(t.pk, func(i, j int) bool { return pkPositions[t.pk[i]] < pkPositions[t.pk[j]] })
+
+	return t, nil
+}
+
+// listDependencies returns for each table of the target database the tables it references with foreign keys.
+func listDependencies(ctx context.Context, dst *sqlx.DB) (map[string][]string, error) {
+	type dependency struct {
+		Table      string `db:"table_name"`
+		Referenced string `db:"referenced_table_name"`
+	}
+
+	var dependencies []dependency
+	err := dst.SelectContext(ctx, &dependencies, `
+		SELECT t.relname AS table_name, r.relname AS referenced_table_name
+		FROM pg_constraint c
+		JOIN pg_class t ON t.oid = c.conrelid
+		JOIN pg_class r ON r.oid = c.confrelid
+		JOIN pg_namespace n ON n.oid = t.relnamespace
+		WHERE c.contype = 'f' AND n.nspname = current_schema()`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list foreign keys of the target database: %w", err)
+	}
+
+	deps := make(map[string][]string)
+	for _, d := range dependencies {
+		deps[d.Table] = append(deps[d.Table], d.Referenced)
+	}
+
+	return deps, nil
+}
+
+// sortTables orders the tables topologically by their dependencies. Self references are ignored,
+// tables with the same dependency depth are sorted by name. Tables that are part of a dependency
+// cycle are appended at the end in alphabetical order.
+func sortTables(tables map[string]*table, deps map[string][]string) []*table {
+	pending := make(map[string]map[string]struct{}, len(tables))
+	for name := range tables {
+		pending[name] = make(map[string]struct{})
+		for _, dep := range deps[name] {
+			if _, ok := tables[dep]; ok && dep != name {
+				pending[name][dep] = struct{}{}
+			}
+		}
+	}
+
+	result := make([]*table, 0, len(tables))
+	for len(pending) > 0 {
+		var ready []string
+		for name, waitingFor := range pending {
+			if len(waitingFor) == 0 {
+				ready = append(ready, name)
+			}
+		}
+
+		if len(ready) == 0 {
+			for name := range pending {
+				ready = append(ready, name)
+			}
+		}
+
+		sort.Strings(ready)
+
+		for _, name := range ready {
+			result = append(result, tables[name])
+			delete(pending, name)
+		}
+
+		for _, waitingFor := range pending {
+			for _, name := range ready {
+				delete(waitingFor, name)
+			}
+		}
+	}
+
+	return result
+}
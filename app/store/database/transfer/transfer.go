@@ -0,0 +1,489 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transfer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store/database/migrate"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	sqliteDriverName   = "sqlite3"
+	postgresDriverName = "postgres"
+
+	// maxParameters is the maximum number of parameters postgres accepts in a single statement.
+	maxParameters = 65535
+
+	defaultBatchSize  = 500
+	defaultSampleSize = 100
+)
+
+var (
+	// ErrTargetNotEmpty is returned if the target database already contains tables
+	// and the transfer wasn't started in resume mode.
+	ErrTargetNotEmpty = errors.New("target database is not empty")
+
+	// ErrVerificationFailed is returned if the data in the target database doesn't match the source.
+	ErrVerificationFailed = errors.New("verification of copied data failed")
+)
+
+// Options configures the transfer.
+type Options struct {
+	// Resume continues an interrupted transfer. Tables that were already copied completely are skipped.
+	Resume bool
+
+	// BatchSize is the maximum number of rows inserted with a single statement.
+	BatchSize int
+
+	// SampleSize is the number of rows per table that are compared after the table was copied.
+	SampleSize int
+}
+
+// Run copies all data from the sqlite database src into the postgres database dst.
+//
+// The target database has to be empty, it is migrated to the schema version of the source before any data
+// is copied. Each table is copied in its own transaction in foreign key dependency order and verified
+// afterwards by comparing row counts and hashes of sampled rows. If the transfer fails, it can be resumed
+// with Options.Resume, which skips all tables that were copied and verified successfully.
+func Run(ctx context.Context, src *sqlx.DB, dst *sqlx.DB, opts Options) error {
+	log := log.Ctx(ctx)
+
+	if src.DriverName() != sqliteDriverName {
+		return fmt.Errorf("source database has to be %s, got %s", sqliteDriverName, src.DriverName())
+	}
+	if dst.DriverName() != postgresDriverName {
+		return fmt.Errorf("target database has to be %s, got %s", postgresDriverName, dst.DriverName())
+	}
+
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultBatchSize
+	}
+	if opts.SampleSize <= 0 {
+		opts.SampleSize = defaultSampleSize
+	}
+
+	if err := migrate.CheckCompatible(ctx, src); err != nil {
+		return err
+	}
+
+	version, err := migrate.Current(ctx, src)
+	if err != nil {
+		return fmt.Errorf("failed to get version of source database: %w", err)
+	}
+	if version == "" {
+		return errors.New("source database has no schema")
+	}
+
+	if err = prepareTarget(ctx, dst, version, opts.Resume); err != nil {
+		return err
+	}
+
+	tables, err := listTables(ctx, src, dst)
+	if err != nil {
+		return err
+	}
+
+	for i, t := range tables {
+		tableLog := log.With().
+			Str("table", t.name).
+			Str("progress", fmt.Sprintf("%d/%d", i+1, len(tables))).
+			Logger()
+
+		if opts.Resume {
+			if err = verifyTable(ctx, src, dst, t, opts.SampleSize); err == nil {
+				tableLog.Info().Msg("table was already copied, skipping")
+				continue
+			}
+		}
+
+		start := time.Now()
+
+		var count int64
+		count, err = copyTable(ctx, src, dst, t, opts.BatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to copy table %q (rerun in resume mode to continue): %w", t.name, err)
+		}
+
+		if err = verifyTable(ctx, src, dst, t, opts.SampleSize); err != nil {
+			return fmt.Errorf("failed to verify table %q: %w", t.name, err)
+		}
+
+		tableLog.Info().
+			Int64("rows", count).
+			Dur("duration", time.Since(start)).
+			Msg("copied table")
+	}
+
+	if err = resetSequences(ctx, dst, tables); err != nil {
+		return err
+	}
+
+	log.Info().Int("tables", len(tables)).Msg("transfer completed")
+
+	return nil
+}
+
+// prepareTarget migrates an empty target database to the provided version.
+// In resume mode, a non-empty target database is accepted if it has the provided version.
+func prepareTarget(ctx context.Context, dst *sqlx.DB, version string, resume bool) error {
+	var tableCount int
+	err := dst.GetContext(ctx, &tableCount, `
+		SELECT count(*)
+		FROM information_schema.tables
+		WHERE table_schema = current_schema()`)
+	if err != nil {
+		return fmt.Errorf("failed to check if target database is empty: %w", err)
+	}
+
+	if tableCount == 0 {
+		log.Ctx(ctx).Info().Str("version", version).Msg("migrating target database")
+
+		if err = migrate.To(ctx, dst, version); err != nil {
+			return fmt.Errorf("failed to migrate target database: %w", err)
+		}
+
+		return nil
+	}
+
+	if !resume {
+		return fmt.Errorf("%w: found %d tables, the target has to be a newly created database", ErrTargetNotEmpty, tableCount)
+	}
+
+	dstVersion, err := migrate.Current(ctx, dst)
+	if err != nil {
+		return fmt.Errorf("failed to get version of target database: %w", err)
+	}
+
+	if dstVersion != version {
+		return fmt.Errorf("can't resume: target database is at version %q, source database at version %q",
+			dstVersion, version)
+	}
+
+	return nil
+}
+
+// copyTable replaces all rows of the table in the target database with the rows of the source database.
+func copyTable(ctx context.Context, src *sqlx.DB, dst *sqlx.DB, t *table, batchSize int) (int64, error) {
+	tx, err := dst.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	// remove rows inserted by the schema migrations or by triggers of previously copied tables.
+	if _, err = tx.ExecContext(ctx, "DELETE FROM "+quote(t.name)); err != nil {
+		return 0, fmt.Errorf("failed to clear target table: %w", err)
+	}
+
+	query := "SELECT " + t.columnList() + " FROM " + quote(t.name) + t.orderBy()
+	rows, err := src.QueryContext(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read source table: %w", err)
+	}
+	defer rows.Close()
+
+	batchSize = min(batchSize, maxParameters/len(t.columns))
+	batch := make([]any, 0, batchSize*len(t.columns))
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		if _, execErr := tx.ExecContext(ctx, t.insertQuery(len(batch)/len(t.columns)), batch...); err != nil {
+			return fmt.Errorf("failed to insert rows: %w", execErr)
+		}
+
+		batch = batch[:0]
+
+		return nil
+	}
+
+	var count int64
+	for rows.Next() {
+		var values []any
+		if values, err = scanRow(rows, len(t.columns)); err != nil {
+			return 0, err
+		}
+
+		for i, c := range t.columns {
+			var v any
+			if v, err = convert(values[i], c.dataType); err != nil {
+				return 0, fmt.Errorf("failed to convert column %q: %w", c.name, err)
+			}
+			batch = append(batch, v)
+		}
+
+		count++
+
+		if len(batch) == cap(batch) {
+			if err = flush(); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read source table: %w", err)
+	}
+
+	if err = flush(); err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return count, nil
+}
+
+// verifyTable compares the row counts of the table in both databases and the content of sampled rows.
+// Tables with at most sampleSize rows are compared completely. Rows of bigger tables are sampled
+// from the beginning and the end of the table ordered by primary key, tables without primary key
+// are only compared by row count.
+func verifyTable(ctx context.Context, src *sqlx.DB, dst *sqlx.DB, t *table, sampleSize int) error {
+	var srcCount, dstCount int64
+	if err := src.GetContext(ctx, &srcCount, "SELECT count(*) FROM "+quote(t.name)); err != nil {
+		return fmt.Errorf("failed to count source rows: %w", err)
+	}
+	if err := dst.GetContext(ctx, &dstCount, "SELECT count(*) FROM "+quote(t.name)); err != nil {
+		return fmt.Errorf("failed to count target rows: %w", err)
+	}
+
+	if srcCount != dstCount {
+		return fmt.Errorf("%w: source has %d rows, target has %d rows", ErrVerificationFailed, srcCount, dstCount)
+	}
+
+	var (
+		srcRows [][]any
+		dstRows [][]any
+		err     error
+	)
+
+	selectAll := "SELECT " + t.columnList() + " FROM " + quote(t.name)
+
+	switch {
+	case srcCount <= int64(sampleSize):
+		if srcRows, err = queryRows(ctx, src, len(t.columns), selectAll); err != nil {
+			return err
+		}
+		if dstRows, err = queryRows(ctx, dst, len(t.columns), selectAll); err != nil {
+			return err
+		}
+	case len(t.pk) > 0:
+		var first, last [][]any
+		first, err = queryRows(ctx, src, len(t.columns),
+			selectAll+t.orderBy()+" LIMIT "+strconv.Itoa(sampleSize/2))
+		if err != nil {
+			return err
+		}
+		last, err = queryRows(ctx, src, len(t.columns),
+			selectAll+t.orderByDesc()+" LIMIT "+strconv.Itoa(sampleSize-sampleSize/2))
+		if err != nil {
+			return err
+		}
+		srcRows = append(first, last...)
+
+		var (
+			query string
+			args  []any
+		)
+		if query, args, err = t.selectByPK(srcRows); err != nil {
+			return err
+		}
+		if dstRows, err = queryRows(ctx, dst, len(t.columns), query, args...); err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+
+	srcNormalized := make([]string, len(srcRows))
+	for i, row := range srcRows {
+		for j, c := range t.columns {
+			if row[j], err = convert(row[j], c.dataType); err != nil {
+				return fmt.Errorf("failed to convert column %q: %w", c.name, err)
+			}
+		}
+		srcNormalized[i] = normalizeRow(row, t.columns)
+	}
+
+	dstNormalized := make([]string, len(dstRows))
+	for i, row := range dstRows {
+		dstNormalized[i] = normalizeRow(row, t.columns)
+	}
+
+	if hashRows(srcNormalized) != hashRows(dstNormalized) {
+		return fmt.Errorf("%w: sampled rows differ between source and target", ErrVerificationFailed)
+	}
+
+	return nil
+}
+
+// resetSequences sets all sequences of the copied tables past the highest copied value
+// so that rows inserted later don't collide with the copied rows.
+func resetSequences(ctx context.Context, dst *sqlx.DB, tables []*table) error {
+	for _, t := range tables {
+		for _, c := range t.columns {
+			if !c.sequence {
+				continue
+			}
+
+			_, err := dst.ExecContext(ctx, `
+				SELECT setval(pg_get_serial_sequence($1, $2), COALESCE((SELECT MAX(`+quote(c.name)+`)
+				FROM `+quote(t.name)+`), 0) + 1, false)`,
+				quote(t.name), c.name)
+			if err != nil {
+				return fmt.Errorf("failed to reset sequence of %q.%q: %w", t.name, c.name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (t *table) orderBy() string {
+	if len(t.pk) == 0 {
+		return ""
+	}
+
+	parts := make([]string, len(t.pk))
+	for i, name := range t.pk {
+		parts[i] = quote(name)
+	}
+
+	return " ORDER BY " + strings.Join(parts, ", ")
+}
+
+func (t *table) orderByDesc() string {
+	parts := make([]string, len(t.pk))
+	for i, name := range t.pk {
+		parts[i] = quote(name) + " DESC"
+	}
+
+	return " ORDER BY " + strings.Join(parts, ", ")
+}
+
+func (t *table) insertQuery(rowCount int) string {
+	sb := strings.Builder{}
+	sb.WriteString("INSERT INTO " + quote(t.name) + " (" + t.columnList() + ") VALUES ")
+
+	n := 1
+	for r := 0; r < rowCount; r++ {
+		if r > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString("(")
+		for c := range t.columns {
+			if c > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString("$" + strconv.Itoa(n))
+			n++
+		}
+		sb.WriteString(")")
+	}
+
+	return sb.String()
+}
+
+// selectByPK returns a query selecting the rows of the table with the same primary key as the provided rows.
+func (t *table) selectByPK(rows [][]any) (string, []any, error) {
+	pkColumns := t.pkColumns()
+
+	pkIndexes := make([]int, len(pkColumns))
+	for i, pk := range pkColumns {
+		for j, c := range t.columns {
+			if c.name == pk.name {
+				pkIndexes[i] = j
+			}
+		}
+	}
+
+	names := make([]string, len(pkColumns))
+	for i, c := range pkColumns {
+		names[i] = quote(c.name)
+	}
+
+	args := make([]any, 0, len(rows)*len(pkColumns))
+	tuples := make([]string, len(rows))
+	for i, row := range rows {
+		placeholders := make([]string, len(pkColumns))
+		for j, c := range pkColumns {
+			v, err := convert(row[pkIndexes[j]], c.dataType)
+			if err != nil {
+				return "", nil, fmt.Errorf("failed to convert column %q: %w", c.name, err)
+			}
+			args = append(args, v)
+			placeholders[j] = "$" + strconv.Itoa(len(args))
+		}
+		tuples[i] = "(" + strings.Join(placeholders, ", ") + ")"
+	}
+
+	query := "SELECT " + t.columnList() + " FROM " + quote(t.name) +
+		" WHERE (" + strings.Join(names, ", ") + ") IN (" + strings.Join(tuples, ", ") + ")"
+
+	return query, args, nil
+}
+
+func queryRows(ctx context.Context, db *sqlx.DB, columnCount int, query string, args ...any) ([][]any, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rows: %w", err)
+	}
+	defer rows.Close()
+
+	var result [][]any
+	for rows.Next() {
+		var values []any
+		if values, err = scanRow(rows, columnCount); err != nil {
+			return nil, err
+		}
+		result = append(result, values)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query rows: %w", err)
+	}
+
+	return result, nil
+}
+
+func scanRow(rows *sql.Rows, columnCount int) ([]any, error) {
+	values := make([]any, columnCount)
+	pointers := make([]any, columnCount)
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+
+	if err := rows.Scan(pointers...); err != nil {
+		return nil, fmt.Errorf("failed to scan row: %w", err)
+	}
+
+	return values, nil
+}
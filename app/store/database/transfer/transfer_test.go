@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transfer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortTables(t *testing.T) {
+	tables := map[string]*table{}
+	for _, name := range []string{"repositories", "principals", "spaces", "pullreqs", "a", "b"} {
+		tables[name] = &table{name: name}
+	}
+
+	deps := map[string][]string{
+		"spaces":       {"spaces", "principals"},
+		"repositories": {"spaces", "principals"},
+		"pullreqs":     {"repositories", "principals", "unknown"},
+		"a":            {"b"},
+		"b":            {"a"},
+	}
+
+	ordered := sortTables(tables, deps)
+
+	names := make([]string, len(ordered))
+	for i, tbl := range ordered {
+		names[i] = tbl.name
+	}
+
+	assert.Equal(t, []string{"principals", "spaces", "repositories", "pullreqs", "a", "b"}, names)
+}
+
+func TestConvert(t *testing.T) {
+	ts := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    any
+		dataType string
+		expected any
+	}{
+		{name: "nil", value: nil, dataType: "boolean", expected: nil},
+		{name: "bool from integer", value: int64(1), dataType: "boolean", expected: true},
+		{name: "bool from text", value: "false", dataType: "boolean", expected: false},
+		{name: "integer from bool", value: true, dataType: "bigint", expected: int64(1)},
+		{name: "timestamp from text", value: "2024-05-06 07:08:09", dataType: "timestamp without time zone", expected: ts},
+		{name: "timestamp from unix", value: ts.Unix(), dataType: "timestamp with time zone", expected: ts},
+		{name: "text from blob", value: []byte("abc"), dataType: "text", expected: "abc"},
+		{name: "bytea from text", value: "abc", dataType: "bytea", expected: []byte("abc")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := convert(test.value, test.dataType)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+
+	_, err := convert("maybe", "boolean")
+	assert.Error(t, err)
+}
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, normalize(`{"b":1, "a":[1,2]}`, "jsonb"), normalize([]byte(`{"a":[1,2],"b":1}`), "jsonb"))
+	assert.Equal(t, normalize(int64(5), "integer"), normalize(int64(5), "bigint"))
+	assert.Equal(t, nullMarker, normalize(nil, "text"))
+	assert.NotEqual(t, normalize("NULL", "text"), normalize(nil, "text"))
+
+	tsSource := time.Date(2024, 5, 6, 7, 8, 9, 123456789, time.UTC)
+	tsTarget := time.Date(2024, 5, 6, 9, 8, 9, 123456000, time.FixedZone("", 2*60*60))
+	assert.Equal(t, normalize(tsSource, "timestamp with time zone"), normalize(tsTarget, "timestamp with time zone"))
+}
+
+func TestHashRows(t *testing.T) {
+	assert.Equal(t, hashRows([]string{"a", "b"}), hashRows([]string{"b", "a"}))
+	assert.NotEqual(t, hashRows([]string{"a", "b"}), hashRows([]string{"a", "c"}))
+}
+
+func TestQueries(t *testing.T) {
+	tbl := &table{
+		name: "labels",
+		columns: []column{
+			{name: "label_space_id", dataType: "integer"},
+			{name: "label_key", dataType: "text"},
+			{name: "label_enabled", dataType: "boolean"},
+		},
+		pk: []string{"label_space_id", "label_key"},
+	}
+
+	assert.Equal(t,
+		`INSERT INTO "labels" ("label_space_id", "label_key", "label_enabled") VALUES ($1, $2, $3), ($4, $5, $6)`,
+		tbl.insertQuery(2))
+
+	query, args, err := tbl.selectByPK([][]any{{int64(1), "a", int64(1)}, {int64(2), []byte("b"), int64(0)}})
+	require.NoError(t, err)
+	assert.Equal(t,
+		`SELECT "label_space_id", "label_key", "label_enabled" FROM "labels" `+
+			`WHERE ("label_space_id", "label_key") IN (($1, $2), ($3, $4))`,
+		query)
+	assert.Equal(t, []any{int64(1), "a", int64(2), "b"}, args)
+
+	assert.Equal(t, ` ORDER BY "label_space_id", "label_key"`, tbl.orderBy())
+	assert.Equal(t, ` ORDER BY "label_space_id" DESC, "label_key" DESC`, tbl.orderByDesc())
+}
@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database/transfer"
+	"github.com/harness/gitness/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransfer_SQLiteToPostgres(t *testing.T) {
+	dst, teardownDst := setupEmptyPostgresDB(t)
+	defer teardownDst()
+
+	src, teardownSrc := setupDB(t)
+	defer teardownSrc()
+
+	ctx := context.Background()
+
+	srcPrincipalStore, srcSpaceStore, srcSpacePathStore, srcRepoStore := setupStores(t, src)
+
+	createUser(ctx, t, srcPrincipalStore)
+	numSpaces := createNestedSpaces(ctx, t, srcSpaceStore, srcSpacePathStore)
+	var numRepos int64
+	for i := 1; i <= numSpaces; i++ {
+		numRepos += createRepos(ctx, t, srcRepoStore, numRepos, 3, int64(i))
+	}
+
+	// a small sample size ensures rows are sampled by primary key instead of compared completely.
+	err := transfer.Run(ctx, src, dst, transfer.Options{BatchSize: 7, SampleSize: 4})
+	require.NoError(t, err)
+
+	dstPrincipalStore, dstSpaceStore, _, dstRepoStore := setupStores(t, dst)
+
+	srcUser, err := srcPrincipalStore.FindUser(ctx, userID)
+	require.NoError(t, err)
+	dstUser, err := dstPrincipalStore.FindUser(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, srcUser, dstUser)
+
+	for id := int64(1); id <= int64(numSpaces); id++ {
+		var srcSpace, dstSpace *types.Space
+		srcSpace, err = srcSpaceStore.Find(ctx, id)
+		require.NoError(t, err)
+		dstSpace, err = dstSpaceStore.Find(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, srcSpace, dstSpace)
+	}
+
+	filter := &types.RepoFilter{Size: int(numRepos), Recursive: true}
+	srcRepos, err := srcRepoStore.List(ctx, 1, filter)
+	require.NoError(t, err)
+	dstRepos, err := dstRepoStore.List(ctx, 1, filter)
+	require.NoError(t, err)
+	assert.Len(t, dstRepos, int(numRepos))
+	assert.Equal(t, srcRepos, dstRepos)
+
+	// a completed transfer can't be started again, but resuming it skips all tables.
+	err = transfer.Run(ctx, src, dst, transfer.Options{})
+	assert.True(t, errors.Is(err, transfer.ErrTargetNotEmpty), "expected ErrTargetNotEmpty, got %v", err)
+
+	err = transfer.Run(ctx, src, dst, transfer.Options{Resume: true})
+	require.NoError(t, err)
+
+	// sequences are reset, new rows don't collide with the copied ones.
+	var maxRepoID int64
+	for _, repo := range srcRepos {
+		maxRepoID = max(maxRepoID, repo.ID)
+	}
+
+	repo := types.Repository{Identifier: "new_repo", ParentID: 1, GitUID: "new_repo"}
+	require.NoError(t, dstRepoStore.Create(ctx, &repo))
+	assert.Greater(t, repo.ID, maxRepoID)
+}
@@ -0,0 +1,195 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.UsageStore = (*UsageStore)(nil)
+
+// NewUsageStore returns a new UsageStore.
+func NewUsageStore(db *sqlx.DB) *UsageStore {
+	return &UsageStore{
+		db: db,
+	}
+}
+
+// UsageStore implements store.UsageStore backed by a relational database.
+type UsageStore struct {
+	db *sqlx.DB
+}
+
+const (
+	usageColumns = `
+		 usage_repo_id
+		,usage_day
+		,usage_ci_duration
+		,usage_git_size
+		,usage_artifacts_size
+		,usage_updated`
+)
+
+type usageRollup struct {
+	RepoID        int64 `db:"usage_repo_id"`
+	Day           int64 `db:"usage_day"`
+	CIDuration    int64 `db:"usage_ci_duration"`
+	GitSize       int64 `db:"usage_git_size"`
+	ArtifactsSize int64 `db:"usage_artifacts_size"`
+	Updated       int64 `db:"usage_updated"`
+}
+
+// Upsert creates or updates the rollup of a repository for a day.
+func (s *UsageStore) Upsert(ctx context.Context, rollup *types.UsageRollup) error {
+	const sqlQuery = `
+	INSERT INTO usage_rollups (
+		 usage_repo_id
+		,usage_day
+		,usage_ci_duration
+		,usage_git_size
+		,usage_artifacts_size
+		,usage_updated
+	) VALUES (
+		 :usage_repo_id
+		,:usage_day
+		,:usage_ci_duration
+		,:usage_git_size
+		,:usage_artifacts_size
+		,:usage_updated
+	)
+	ON CONFLICT (usage_repo_id, usage_day) DO
+	UPDATE SET
+		 usage_ci_duration = :usage_ci_duration
+		,usage_git_size = CASE WHEN usage_rollups.usage_git_size > :usage_git_size
+			THEN usage_rollups.usage_git_size ELSE :usage_git_size END
+		,usage_artifacts_size = CASE WHEN usage_rollups.usage_artifacts_size > :usage_artifacts_size
+			THEN usage_rollups.usage_artifacts_size ELSE :usage_artifacts_size END
+		,usage_updated = :usage_updated`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalUsageRollup(rollup))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind usage rollup object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Upsert usage rollup query failed")
+	}
+
+	return nil
+}
+
+// ListByRepos returns the rollups of the repositories for the days within [from, to] (unix millis).
+func (s *UsageStore) ListByRepos(
+	ctx context.Context,
+	repoIDs []int64,
+	from int64,
+	to int64,
+) ([]*types.UsageRollup, error) {
+	if len(repoIDs) == 0 {
+		return []*types.UsageRollup{}, nil
+	}
+
+	stmt := database.Builder.
+		Select(usageColumns).
+		From("usage_rollups").
+		Where(squirrel.Eq{"usage_repo_id": repoIDs}).
+		Where("usage_day >= ?", from).
+		Where("usage_day <= ?", to).
+		OrderBy("usage_day ASC", "usage_repo_id ASC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*usageRollup, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list usage rollups")
+	}
+
+	rollups := make([]*types.UsageRollup, len(dst))
+	for i, r := range dst {
+		rollups[i] = mapUsageRollup(r)
+	}
+
+	return rollups, nil
+}
+
+// SumCIDurations returns the sum of the runtimes (millis) of the steps that finished
+// within [from, to) (unix millis), per repository.
+func (s *UsageStore) SumCIDurations(ctx context.Context, from int64, to int64) (map[int64]int64, error) {
+	const sqlQuery = `
+	SELECT
+		 execution_repo_id
+		,SUM(step_stopped - step_started) AS duration
+	FROM steps
+	INNER JOIN stages ON step_stage_id = stage_id
+	INNER JOIN executions ON stage_execution_id = execution_id
+	WHERE step_started > 0 AND step_stopped >= step_started
+		AND step_stopped >= $1 AND step_stopped < $2
+	GROUP BY execution_repo_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]struct {
+		RepoID   int64 `db:"execution_repo_id"`
+		Duration int64 `db:"duration"`
+	}, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, from, to); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to sum step durations")
+	}
+
+	durations := make(map[int64]int64, len(dst))
+	for _, d := range dst {
+		durations[d.RepoID] = d.Duration
+	}
+
+	return durations, nil
+}
+
+func mapInternalUsageRollup(r *types.UsageRollup) *usageRollup {
+	return &usageRollup{
+		RepoID:        r.RepoID,
+		Day:           r.Day,
+		CIDuration:    r.CIDuration,
+		GitSize:       r.GitSize,
+		ArtifactsSize: r.ArtifactsSize,
+		Updated:       r.Updated,
+	}
+}
+
+func mapUsageRollup(r *usageRollup) *types.UsageRollup {
+	return &types.UsageRollup{
+		RepoID:        r.RepoID,
+		Day:           r.Day,
+		CIDuration:    r.CIDuration,
+		GitSize:       r.GitSize,
+		ArtifactsSize: r.ArtifactsSize,
+		Updated:       r.Updated,
+	}
+}
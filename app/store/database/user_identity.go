@@ -0,0 +1,93 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.UserIdentityStore = (*UserIdentityStore)(nil)
+
+// NewUserIdentityStore returns a new UserIdentityStore.
+func NewUserIdentityStore(db *sqlx.DB) *UserIdentityStore {
+	return &UserIdentityStore{
+		db: db,
+	}
+}
+
+// UserIdentityStore implements store.UserIdentityStore backed by a relational database.
+type UserIdentityStore struct {
+	db *sqlx.DB
+}
+
+const userIdentityColumns = `
+	 user_identity_id
+	,user_identity_principal_id
+	,user_identity_provider
+	,user_identity_subject
+	,user_identity_created`
+
+// Find finds the identity of the provider with the subject.
+func (s *UserIdentityStore) Find(ctx context.Context, provider, subject string) (*types.UserIdentity, error) {
+	const sqlQuery = `
+		SELECT` + userIdentityColumns + `
+		FROM user_identities
+		WHERE user_identity_provider = $1 AND user_identity_subject = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &types.UserIdentity{}
+	if err := db.GetContext(ctx, dst, sqlQuery, provider, subject); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find user identity")
+	}
+
+	return dst, nil
+}
+
+// Create links a user to an external identity.
+func (s *UserIdentityStore) Create(ctx context.Context, identity *types.UserIdentity) error {
+	const sqlQuery = `
+		INSERT INTO user_identities (
+			 user_identity_principal_id
+			,user_identity_provider
+			,user_identity_subject
+			,user_identity_created
+		) VALUES (
+			 :user_identity_principal_id
+			,:user_identity_provider
+			,:user_identity_subject
+			,:user_identity_created
+		) RETURNING user_identity_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, identity)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind user identity object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&identity.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert user identity")
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.UserPreferenceStore = (*UserPreferenceStore)(nil)
+
+// NewUserPreferenceStore returns a new UserPreferenceStore.
+func NewUserPreferenceStore(db *sqlx.DB) *UserPreferenceStore {
+	return &UserPreferenceStore{
+		db: db,
+	}
+}
+
+// UserPreferenceStore implements store.UserPreferenceStore backed by a relational database.
+type UserPreferenceStore struct {
+	db *sqlx.DB
+}
+
+const (
+	userPreferenceColumns = `
+		 user_preference_principal_id
+		,user_preference_view
+		,user_preference_params
+		,user_preference_created
+		,user_preference_updated`
+)
+
+type userPreference struct {
+	PrincipalID int64                   `db:"user_preference_principal_id"`
+	View        enum.UserPreferenceView `db:"user_preference_view"`
+	Params      json.RawMessage         `db:"user_preference_params"`
+	Created     int64                   `db:"user_preference_created"`
+	Updated     int64                   `db:"user_preference_updated"`
+}
+
+// Find returns the preference of the principal for the provided view.
+func (s *UserPreferenceStore) Find(
+	ctx context.Context,
+	principalID int64,
+	view enum.UserPreferenceView,
+) (*types.UserPreference, error) {
+	const sqlQuery = `
+		SELECT` + userPreferenceColumns + `
+		FROM user_preferences
+		WHERE user_preference_principal_id = $1 AND user_preference_view = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &userPreference{}
+	if err := db.GetContext(ctx, dst, sqlQuery, principalID, view); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find user preference")
+	}
+
+	return mapUserPreference(dst)
+}
+
+// Upsert creates or replaces the preference of the principal for the view.
+func (s *UserPreferenceStore) Upsert(ctx context.Context, pref *types.UserPreference) error {
+	const sqlQuery = `
+		INSERT INTO user_preferences (` + userPreferenceColumns + `
+		) values (
+			 :user_preference_principal_id
+			,:user_preference_view
+			,:user_preference_params
+			,:user_preference_created
+			,:user_preference_updated
+		)
+		ON CONFLICT (user_preference_principal_id, user_preference_view) DO
+		UPDATE SET
+			 user_preference_params = EXCLUDED.user_preference_params
+			,user_preference_updated = EXCLUDED.user_preference_updated
+		RETURNING user_preference_created`
+
+	dbPref, err := mapInternalUserPreference(pref)
+	if err != nil {
+		return err
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, dbPref)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind user preference object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&pref.Created); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to upsert user preference")
+	}
+
+	return nil
+}
+
+func mapInternalUserPreference(pref *types.UserPreference) (*userPreference, error) {
+	params := pref.Params
+	if params == nil {
+		params = map[string][]string{}
+	}
+	rawParams, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal user preference params: %w", err)
+	}
+
+	return &userPreference{
+		PrincipalID: pref.PrincipalID,
+		View:        pref.View,
+		Params:      rawParams,
+		Created:     pref.Created,
+		Updated:     pref.Updated,
+	}, nil
+}
+
+func mapUserPreference(pref *userPreference) (*types.UserPreference, error) {
+	var params map[string][]string
+	if err := json.Unmarshal(pref.Params, &params); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal user preference params: %w", err)
+	}
+
+	return &types.UserPreference{
+		PrincipalID: pref.PrincipalID,
+		View:        pref.View,
+		Params:      params,
+		Created:     pref.Created,
+		Updated:     pref.Updated,
+	}, nil
+}
@@ -58,8 +58,10 @@ type webhook struct {
 	Internal  bool     `db:"webhook_internal"`
 
 	Identifier string `db:"webhook_uid"`
+	UIDSortKey string `db:"webhook_uid_sort_key"`
 	// TODO [CODE-1364]: Remove once UID/Identifier migration is completed.
 	DisplayName           string      `db:"webhook_display_name"`
+	DisplayNameSortKey    string      `db:"webhook_display_name_sort_key"`
 	Description           string      `db:"webhook_description"`
 	URL                   string      `db:"webhook_url"`
 	Secret                string      `db:"webhook_secret"`
@@ -165,7 +167,9 @@ func (s *WebhookStore) Create(ctx context.Context, hook *types.Webhook) error {
 			,webhook_created
 			,webhook_updated
 			,webhook_uid
+			,webhook_uid_sort_key
 			,webhook_display_name
+			,webhook_display_name_sort_key
 			,webhook_description
 			,webhook_url
 			,webhook_secret
@@ -181,7 +185,9 @@ func (s *WebhookStore) Create(ctx context.Context, hook *types.Webhook) error {
 			,:webhook_created
 			,:webhook_updated
 			,:webhook_uid
+			,:webhook_uid_sort_key
 			,:webhook_display_name
+			,:webhook_display_name_sort_key
 			,:webhook_description
 			,:webhook_url
 			,:webhook_secret
@@ -219,7 +225,9 @@ func (s *WebhookStore) Update(ctx context.Context, hook *types.Webhook) error {
 			 webhook_version = :webhook_version
 			,webhook_updated = :webhook_updated
 			,webhook_uid = :webhook_uid
+			,webhook_uid_sort_key = :webhook_uid_sort_key
 			,webhook_display_name = :webhook_display_name
+			,webhook_display_name_sort_key = :webhook_display_name_sort_key
 			,webhook_description = :webhook_description
 			,webhook_url = :webhook_url
 			,webhook_secret = :webhook_secret
@@ -414,17 +422,21 @@ func (s *WebhookStore) List(ctx context.Context, parentType enum.WebhookParent,
 
 		// TODO [CODE-1363]: remove after identifier migration.
 	case enum.WebhookAttrUID, enum.WebhookAttrIdentifier:
-		stmt = stmt.OrderBy("LOWER(webhook_uid) " + opts.Order.String())
+		stmt = stmt.OrderBy("webhook_uid_sort_key " + opts.Order.String())
 		// TODO [CODE-1364]: Remove once UID/Identifier migration is completed
 	case enum.WebhookAttrDisplayName:
-		stmt = stmt.OrderBy("webhook_display_name " + opts.Order.String())
-		//TODO: Postgres does not support COLLATE NOCASE for UTF8
+		stmt = stmt.OrderBy("webhook_display_name_sort_key " + opts.Order.String())
 	case enum.WebhookAttrCreated:
 		stmt = stmt.OrderBy("webhook_created " + opts.Order.String())
 	case enum.WebhookAttrUpdated:
 		stmt = stmt.OrderBy("webhook_updated " + opts.Order.String())
 	}
 
+	if opts.Sort != enum.WebhookAttrID && opts.Sort != enum.WebhookAttrNone {
+		// webhook ID as tiebreaker guarantees a stable order across pages.
+		stmt = stmt.OrderBy("webhook_id " + opts.Order.String())
+	}
+
 	sql, args, err := stmt.ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
@@ -489,8 +501,10 @@ func mapToInternalWebhook(hook *types.Webhook) (*webhook, error) {
 		Created:    hook.Created,
 		Updated:    hook.Updated,
 		Identifier: hook.Identifier,
+		UIDSortKey: database.SortKey(hook.Identifier),
 		// TODO [CODE-1364]: Remove once UID/Identifier migration is completed
 		DisplayName:           hook.DisplayName,
+		DisplayNameSortKey:    database.SortKey(hook.DisplayName),
 		Description:           hook.Description,
 		URL:                   hook.URL,
 		Secret:                hook.Secret,
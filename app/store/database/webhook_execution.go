@@ -25,6 +25,7 @@ import (
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/guregu/null"
 	"github.com/jmoiron/sqlx"
 )
@@ -192,6 +193,10 @@ func (s *WebhookExecutionStore) ListForWebhook(ctx context.Context, webhookID in
 		From("webhook_executions").
 		Where("webhook_execution_webhook_id = ?", webhookID)
 
+	if len(opts.Triggers) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"webhook_execution_trigger_type": opts.Triggers})
+	}
+
 	stmt = stmt.Limit(database.Limit(opts.Size))
 	stmt = stmt.Offset(database.Offset(opts.Page, opts.Size))
 
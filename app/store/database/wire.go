@@ -16,11 +16,14 @@ package database
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/store/database/migrate"
 	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/stream"
 
 	"github.com/google/wire"
 	"github.com/jmoiron/sqlx"
@@ -36,11 +39,19 @@ var WireSet = wire.NewSet(
 	ProvideSpacePathStore,
 	ProvideSpaceStore,
 	ProvideRepoStore,
+	ProvideRepoActivityStore,
+	ProvideRepoPushStore,
+	ProvideRepoStatsStore,
 	ProvideRuleStore,
 	ProvideJobStore,
+	ProvideEventLogStore,
 	ProvideExecutionStore,
 	ProvidePipelineStore,
 	ProvideStageStore,
+	ProvideStageApprovalStore,
+	ProvideArtifactStore,
+	ProvideRunnerStore,
+	ProvideUsageStore,
 	ProvideStepStore,
 	ProvideSecretStore,
 	ProvideRepoGitInfoView,
@@ -56,12 +67,35 @@ var WireSet = wire.NewSet(
 	ProvideWebhookExecutionStore,
 	ProvideSettingsStore,
 	ProvidePublicAccessStore,
+	ProvideSchemaStore,
+	ProvideCounterStore,
 	ProvideCheckStore,
 	ProvideConnectorStore,
 	ProvideTemplateStore,
 	ProvideTriggerStore,
 	ProvidePluginStore,
 	ProvidePublicKeyStore,
+	ProvideDeployKeyStore,
+	ProvideDigestSubscriptionStore,
+	ProvideUserPreferenceStore,
+	ProvideRecentVisitStore,
+	ProvideRepoStarStore,
+	ProvideRepoTopicStore,
+	ProvideSpacePinnedRepoStore,
+	ProvideAvatarStore,
+	ProvideNotificationStore,
+	ProvideNotificationSubscriptionStore,
+	ProvideMailFailureStore,
+	ProvideLoginAttemptStore,
+	ProvideIdempotencyKeyStore,
+	ProvideOperationStore,
+	ProvideInstallationStore,
+	ProvideFeatureFlagStore,
+	ProvideGitOperationLimitStore,
+	ProvideRepoAccessLogStore,
+	ProvideUserIdentityStore,
+	ProvidePasswordResetStore,
+	ProvideEmailChangeStore,
 	ProvideInfraProviderConfigStore,
 	ProvideInfraProviderResourceStore,
 	ProvideGitspaceConfigStore,
@@ -81,13 +115,35 @@ func migrator(ctx context.Context, db *sqlx.DB) error {
 }
 
 // ProvideDatabase provides a database connection.
+// If a replica datasource is configured, the replica is registered for read-only operations.
 func ProvideDatabase(ctx context.Context, config database.Config) (*sqlx.DB, error) {
-	return database.ConnectAndMigrate(
+	db, err := database.ConnectAndMigrate(
 		ctx,
 		config.Driver,
 		config.Datasource,
 		migrator,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.ReplicaDatasource == "" {
+		return db, nil
+	}
+
+	if config.Driver != "postgres" {
+		return nil, fmt.Errorf("database replica isn't supported for driver %q", config.Driver)
+	}
+
+	// the replica isn't pinged, read-only operations fall back to the primary while it's unreachable.
+	replica, err := database.Open(config.Driver, config.ReplicaDatasource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database replica: %w", err)
+	}
+
+	dbtx.RegisterReplica(db, replica)
+
+	return db, nil
 }
 
 // ProvidePrincipalStore provides a principal store.
@@ -141,6 +197,24 @@ func ProvideRepoStore(
 	return NewRepoStore(db, spacePathCache, spacePathStore, spaceStore)
 }
 
+// ProvideRepoActivityStore provides a repo activity store.
+func ProvideRepoActivityStore(
+	db *sqlx.DB,
+	principalInfoCache store.PrincipalInfoCache,
+) store.RepoActivityStore {
+	return NewRepoActivityStore(db, principalInfoCache)
+}
+
+// ProvideRepoPushStore provides a repo push store.
+func ProvideRepoPushStore(db *sqlx.DB) store.RepoPushStore {
+	return NewRepoPushStore(db)
+}
+
+// ProvideRepoStatsStore provides a repo stats store.
+func ProvideRepoStatsStore(db *sqlx.DB) store.RepoStatsStore {
+	return NewRepoStatsStore(db)
+}
+
 // ProvideRuleStore provides a rule store.
 func ProvideRuleStore(
 	db *sqlx.DB,
@@ -184,6 +258,29 @@ func ProvideStageStore(db *sqlx.DB) store.StageStore {
 	return NewStageStore(db)
 }
 
+// ProvideStageApprovalStore provides a stage approval store.
+func ProvideStageApprovalStore(
+	db *sqlx.DB,
+	principalInfoCache store.PrincipalInfoCache,
+) store.StageApprovalStore {
+	return NewStageApprovalStore(db, principalInfoCache)
+}
+
+// ProvideArtifactStore provides an execution artifact store.
+func ProvideArtifactStore(db *sqlx.DB) store.ArtifactStore {
+	return NewArtifactStore(db)
+}
+
+// ProvideRunnerStore provides a pipeline runner store.
+func ProvideRunnerStore(db *sqlx.DB) store.RunnerStore {
+	return NewRunnerStore(db)
+}
+
+// ProvideUsageStore provides a usage rollup store.
+func ProvideUsageStore(db *sqlx.DB) store.UsageStore {
+	return NewUsageStore(db)
+}
+
 // ProvideStepStore provides a step store.
 func ProvideStepStore(db *sqlx.DB) store.StepStore {
 	return NewStepStore(db)
@@ -209,6 +306,11 @@ func ProvideTriggerStore(db *sqlx.DB) store.TriggerStore {
 	return NewTriggerStore(db)
 }
 
+// ProvideEventLogStore provides the store of the durable event log.
+func ProvideEventLogStore(db *sqlx.DB) stream.LogStore {
+	return NewEventLogStore(db)
+}
+
 // ProvideExecutionStore provides an execution store.
 func ProvideExecutionStore(db *sqlx.DB) store.ExecutionStore {
 	return NewExecutionStore(db)
@@ -300,6 +402,16 @@ func ProvideSettingsStore(db *sqlx.DB) store.SettingsStore {
 	return NewSettingsStore(db)
 }
 
+// ProvideSchemaStore provides a schema store.
+func ProvideSchemaStore(db *sqlx.DB) store.SchemaStore {
+	return NewSchemaStore(db)
+}
+
+// ProvideCounterStore provides a counter store.
+func ProvideCounterStore(db *sqlx.DB) store.CounterStore {
+	return NewCounterStore(db)
+}
+
 // ProvidePublicAccessStore provides a public access store.
 func ProvidePublicAccessStore(db *sqlx.DB) store.PublicAccessStore {
 	return NewPublicAccessStore(db)
@@ -310,6 +422,11 @@ func ProvidePublicKeyStore(db *sqlx.DB) store.PublicKeyStore {
 	return NewPublicKeyStore(db)
 }
 
+// ProvideDeployKeyStore provides a deploy key store.
+func ProvideDeployKeyStore(db *sqlx.DB) store.DeployKeyStore {
+	return NewDeployKeyStore(db)
+}
+
 // ProvideGitspaceEventStore provides a gitspace event store.
 func ProvideGitspaceEventStore(db *sqlx.DB) store.GitspaceEventStore {
 	return NewGitspaceEventStore(db)
@@ -339,3 +456,103 @@ func ProvideInfraProviderTemplateStore(db *sqlx.DB) store.InfraProviderTemplateS
 func ProvideInfraProvisionedStore(db *sqlx.DB) store.InfraProvisionedStore {
 	return NewInfraProvisionedStore(db)
 }
+
+// ProvideUserPreferenceStore provides a user preference store.
+func ProvideUserPreferenceStore(db *sqlx.DB) store.UserPreferenceStore {
+	return NewUserPreferenceStore(db)
+}
+
+// ProvideRecentVisitStore provides a recent visit store.
+func ProvideRecentVisitStore(db *sqlx.DB) store.RecentVisitStore {
+	return NewRecentVisitStore(db)
+}
+
+// ProvideRepoStarStore provides a repo star store.
+func ProvideRepoStarStore(db *sqlx.DB) store.RepoStarStore {
+	return NewRepoStarStore(db)
+}
+
+// ProvideRepoTopicStore provides a repo topic store.
+func ProvideRepoTopicStore(db *sqlx.DB) store.RepoTopicStore {
+	return NewRepoTopicStore(db)
+}
+
+// ProvideSpacePinnedRepoStore provides a space pinned repo store.
+func ProvideSpacePinnedRepoStore(db *sqlx.DB) store.SpacePinnedRepoStore {
+	return NewSpacePinnedRepoStore(db)
+}
+
+// ProvideAvatarStore provides an avatar store.
+func ProvideAvatarStore(db *sqlx.DB) store.AvatarStore {
+	return NewAvatarStore(db)
+}
+
+// ProvideNotificationStore provides a notification store.
+func ProvideNotificationStore(db *sqlx.DB, pCache store.PrincipalInfoCache) store.NotificationStore {
+	return NewNotificationStore(db, pCache)
+}
+
+// ProvideNotificationSubscriptionStore provides a notification subscription store.
+func ProvideNotificationSubscriptionStore(db *sqlx.DB) store.NotificationSubscriptionStore {
+	return NewNotificationSubscriptionStore(db)
+}
+
+// ProvideMailFailureStore provides a mail failure store.
+func ProvideMailFailureStore(db *sqlx.DB) store.MailFailureStore {
+	return NewMailFailureStore(db)
+}
+
+// ProvideLoginAttemptStore provides a login attempt store.
+func ProvideLoginAttemptStore(db *sqlx.DB) store.LoginAttemptStore {
+	return NewLoginAttemptStore(db)
+}
+
+// ProvideUserIdentityStore provides a user identity store.
+func ProvideUserIdentityStore(db *sqlx.DB) store.UserIdentityStore {
+	return NewUserIdentityStore(db)
+}
+
+// ProvideDigestSubscriptionStore provides a digest subscription store.
+func ProvideDigestSubscriptionStore(db *sqlx.DB) store.DigestSubscriptionStore {
+	return NewDigestSubscriptionStore(db)
+}
+
+// ProvidePasswordResetStore provides a password reset store.
+func ProvidePasswordResetStore(db *sqlx.DB) store.PasswordResetStore {
+	return NewPasswordResetStore(db)
+}
+
+// ProvideEmailChangeStore provides a pending email change store.
+func ProvideEmailChangeStore(db *sqlx.DB) store.EmailChangeStore {
+	return NewEmailChangeStore(db)
+}
+
+// ProvideIdempotencyKeyStore provides an idempotency key store.
+func ProvideIdempotencyKeyStore(db *sqlx.DB) store.IdempotencyKeyStore {
+	return NewIdempotencyKeyStore(db)
+}
+
+// ProvideOperationStore provides an operation store.
+func ProvideOperationStore(db *sqlx.DB) store.OperationStore {
+	return NewOperationStore(db)
+}
+
+// ProvideInstallationStore provides an installation store.
+func ProvideInstallationStore(db *sqlx.DB) store.InstallationStore {
+	return NewInstallationStore(db)
+}
+
+// ProvideFeatureFlagStore provides a feature flag store.
+func ProvideFeatureFlagStore(db *sqlx.DB) store.FeatureFlagStore {
+	return NewFeatureFlagStore(db)
+}
+
+// ProvideGitOperationLimitStore provides a git operation limit store.
+func ProvideGitOperationLimitStore(db *sqlx.DB) store.GitOperationLimitStore {
+	return NewGitOperationLimitStore(db)
+}
+
+// ProvideRepoAccessLogStore provides a repo access log store.
+func ProvideRepoAccessLogStore(db *sqlx.DB, pCache store.PrincipalInfoCache) store.RepoAccessLogStore {
+	return NewRepoAccessLogStore(db, pCache)
+}
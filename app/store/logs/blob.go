@@ -0,0 +1,66 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+)
+
+// NewBlobLogStore returns a new log store that keeps the logs in the blob store.
+func NewBlobLogStore(blobStore blob.Store) store.LogStore {
+	return &blobLogStore{
+		blobStore: blobStore,
+	}
+}
+
+type blobLogStore struct {
+	blobStore blob.Store
+}
+
+func (s *blobLogStore) Find(ctx context.Context, step int64) (io.ReadCloser, error) {
+	return s.blobStore.Download(ctx, blobLogPath(step))
+}
+
+func (s *blobLogStore) Create(ctx context.Context, step int64, r io.Reader) error {
+	return s.blobStore.Upload(ctx, r, blobLogPath(step))
+}
+
+func (s *blobLogStore) Update(ctx context.Context, step int64, r io.Reader) error {
+	return s.Create(ctx, step, r)
+}
+
+// Delete deletes the logs of the step. It fails with blob.ErrNotFound if there are no logs of the step,
+// to allow the combined log store to fall back to the secondary store.
+func (s *blobLogStore) Delete(ctx context.Context, step int64) error {
+	exists, err := s.blobStore.Exists(ctx, blobLogPath(step))
+	if err != nil {
+		return fmt.Errorf("failed to check if logs of step %d exist: %w", step, err)
+	}
+	if !exists {
+		return blob.ErrNotFound
+	}
+
+	return s.blobStore.Delete(ctx, blobLogPath(step))
+}
+
+func blobLogPath(step int64) string {
+	return path.Join("logs", "steps", fmt.Sprint(step))
+}
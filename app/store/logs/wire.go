@@ -16,6 +16,7 @@ package logs
 
 import (
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
@@ -27,8 +28,11 @@ var WireSet = wire.NewSet(
 	ProvideLogStore,
 )
 
-func ProvideLogStore(db *sqlx.DB, config *types.Config) store.LogStore {
+func ProvideLogStore(db *sqlx.DB, config *types.Config, blobStore blob.Store) store.LogStore {
 	s := NewDatabaseLogStore(db)
+	if config.Logs.BlobStore {
+		return NewCombined(NewBlobLogStore(blobStore), s)
+	}
 	if config.Logs.S3.Bucket != "" {
 		p := NewS3LogStore(
 			config.Logs.S3.Bucket,
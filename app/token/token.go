@@ -17,10 +17,12 @@ package token
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/harness/gitness/app/jwt"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -28,10 +30,10 @@ import (
 )
 
 const (
-	// userSessionTokenLifeTime is the duration a login / register token is valid.
-	// NOTE: Users can list / delete session tokens via rest API if they want to cleanup earlier.
-	userSessionTokenLifeTime                  time.Duration = 30 * 24 * time.Hour // 30 days.
-	sessionTokenWithAccessPermissionsLifeTime time.Duration = 24 * time.Hour      // 24 hours.
+	sessionTokenWithAccessPermissionsLifeTime time.Duration = 24 * time.Hour // 24 hours.
+
+	// maxUserAgentLength is the maximum length of the user agent stored with a token.
+	maxUserAgentLength = 512
 )
 
 func CreateUserWithAccessPermissions(
@@ -46,11 +48,14 @@ func CreateUserWithAccessPermissions(
 	)
 }
 
+// CreateUserSession creates a login session token that is valid for the provided lifetime.
+// NOTE: Users can list / revoke their sessions via rest API if they want to cleanup earlier.
 func CreateUserSession(
 	ctx context.Context,
 	tokenStore store.TokenStore,
 	user *types.User,
 	identifier string,
+	lifetime time.Duration,
 ) (*types.Token, string, error) {
 	principal := user.ToPrincipal()
 	return create(
@@ -60,7 +65,7 @@ func CreateUserSession(
 		principal,
 		principal,
 		identifier,
-		ptr.Duration(userSessionTokenLifeTime),
+		ptr.Duration(lifetime),
 	)
 }
 
@@ -118,6 +123,13 @@ func create(
 		expiresAt = ptr.Int64(issuedAt.Add(*lifetime).UnixMilli())
 	}
 
+	userAgent := audit.GetUserAgent(ctx)
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+	// headers aren't guaranteed to be valid utf-8, which the db would reject.
+	userAgent = strings.ToValidUTF8(userAgent, "")
+
 	// create db entry first so we get the id.
 	token := types.Token{
 		Type:        tokenType,
@@ -126,6 +138,8 @@ func create(
 		IssuedAt:    issuedAt.UnixMilli(),
 		ExpiresAt:   expiresAt,
 		CreatedBy:   createdBy.ID,
+		UserAgent:   userAgent,
+		IPAddress:   audit.GetRealIP(ctx),
 	}
 
 	err := tokenStore.Create(ctx, &token)
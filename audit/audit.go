@@ -37,14 +37,16 @@ const (
 type Action string
 
 const (
-	ActionCreated Action = "created"
-	ActionUpdated Action = "updated" // update default branch, switching default branch, updating description
-	ActionDeleted Action = "deleted"
+	ActionCreated  Action = "created"
+	ActionUpdated  Action = "updated" // update default branch, switching default branch, updating description
+	ActionDeleted  Action = "deleted"
+	ActionApproved Action = "approved" // approving a pipeline stage waiting on an approval gate
+	ActionRejected Action = "rejected"
 )
 
 func (a Action) Validate() error {
 	switch a {
-	case ActionCreated, ActionUpdated, ActionDeleted:
+	case ActionCreated, ActionUpdated, ActionDeleted, ActionApproved, ActionRejected:
 		return nil
 	default:
 		return ErrActionUndefined
@@ -59,6 +61,7 @@ const (
 	ResourceTypeRepositorySettings    ResourceType = "repository_settings"
 	ResourceTypeRegistry              ResourceType = "registry"
 	ResourceTypeRegistryUpstreamProxy ResourceType = "registry_upstream_proxy"
+	ResourceTypePipelineStage         ResourceType = "pipeline_stage"
 )
 
 func (a ResourceType) Validate() error {
@@ -67,7 +70,8 @@ func (a ResourceType) Validate() error {
 		ResourceTypeBranchRule,
 		ResourceTypeRepositorySettings,
 		ResourceTypeRegistry,
-		ResourceTypeRegistryUpstreamProxy:
+		ResourceTypeRegistryUpstreamProxy,
+		ResourceTypePipelineStage:
 		return nil
 
 	default:
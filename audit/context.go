@@ -22,8 +22,15 @@ const (
 	realIPKey key = iota
 	requestID
 	requestMethod
+	userAgent
 )
 
+// WithRealIP returns a copy of the context with the IP address of the client,
+// for requests that aren't processed by the Middleware (e.g. git over ssh).
+func WithRealIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, realIPKey, ip)
+}
+
 // GetRealIP returns IP address from context.
 func GetRealIP(ctx context.Context) string {
 	ip, ok := ctx.Value(realIPKey).(string)
@@ -53,3 +60,13 @@ func GetRequestMethod(ctx context.Context) string {
 
 	return method
 }
+
+// GetUserAgent returns the user agent of the request from context.
+func GetUserAgent(ctx context.Context) string {
+	ua, ok := ctx.Value(userAgent).(string)
+	if !ok {
+		return ""
+	}
+
+	return ua
+}
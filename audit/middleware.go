@@ -38,6 +38,7 @@ func Middleware() func(next http.Handler) http.Handler {
 
 			ctx = context.WithValue(ctx, requestMethod, r.Method)
 			ctx = context.WithValue(ctx, requestID, w.Header().Get("X-Request-Id"))
+			ctx = context.WithValue(ctx, userAgent, r.UserAgent())
 
 			r = r.WithContext(ctx)
 			next.ServeHTTP(w, r)
@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestFileSystemStore_DeleteAndExists(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewFileSystemStore(Config{Bucket: t.TempDir()})
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+
+	const filePath = "avatars/1/avatar.png"
+
+	if err := s.Upload(ctx, strings.NewReader("content"), filePath); err != nil {
+		t.Fatalf("failed to upload file: %v", err)
+	}
+
+	exists, err := s.Exists(ctx, filePath)
+	if err != nil {
+		t.Fatalf("failed to check uploaded file: %v", err)
+	}
+	if !exists {
+		t.Fatal("expected uploaded file to exist")
+	}
+
+	rc, err := s.Download(ctx, filePath)
+	if err != nil {
+		t.Fatalf("failed to download file: %v", err)
+	}
+	content, err := io.ReadAll(rc)
+	_ = rc.Close()
+	if err != nil || string(content) != "content" {
+		t.Fatalf("unexpected file content %q: %v", content, err)
+	}
+
+	if err := s.Delete(ctx, filePath); err != nil {
+		t.Fatalf("failed to delete file: %v", err)
+	}
+
+	exists, err = s.Exists(ctx, filePath)
+	if err != nil {
+		t.Fatalf("failed to check deleted file: %v", err)
+	}
+	if exists {
+		t.Error("expected deleted file to not exist")
+	}
+
+	if _, err := s.Download(ctx, filePath); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound when downloading deleted file, got %v", err)
+	}
+}
+
+func TestFileSystemStore_MissingFile(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewFileSystemStore(Config{Bucket: t.TempDir()})
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+
+	exists, err := s.Exists(ctx, "missing/file.txt")
+	if err != nil {
+		t.Fatalf("failed to check missing file: %v", err)
+	}
+	if exists {
+		t.Error("expected missing file to not exist")
+	}
+
+	// deleting a file that doesn't exist isn't an error.
+	if err := s.Delete(ctx, "missing/file.txt"); err != nil {
+		t.Errorf("expected no error when deleting missing file, got %v", err)
+	}
+}
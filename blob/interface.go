@@ -34,4 +34,10 @@ type Store interface {
 
 	// Download returns a reader for a file in the blob store.
 	Download(ctx context.Context, filePath string) (io.ReadCloser, error)
+
+	// Delete deletes a file from the blob store. Deleting a file that doesn't exist isn't an error.
+	Delete(ctx context.Context, filePath string) error
+
+	// Exists returns true if the file exists in the blob store.
+	Exists(ctx context.Context, filePath string) (bool, error)
 }
@@ -0,0 +1,179 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+// defaultSignedURLExpiry is the validity of signed URLs if none is configured.
+const defaultSignedURLExpiry = 1 * time.Hour
+
+// S3Store stores files in an S3 compatible object storage.
+type S3Store struct {
+	config   Config
+	client   *s3.S3
+	uploader *s3manager.Uploader
+}
+
+func NewS3Store(cfg Config) (Store, error) {
+	if err := validateS3Config(cfg); err != nil {
+		return nil, err
+	}
+
+	awsConfig := &aws.Config{
+		S3ForcePathStyle: aws.Bool(cfg.PathStyle),
+	}
+	if cfg.Region != "" {
+		awsConfig.Region = aws.String(cfg.Region)
+	}
+	if cfg.Endpoint != "" {
+		awsConfig.Endpoint = aws.String(cfg.Endpoint)
+		awsConfig.DisableSSL = aws.Bool(!strings.HasPrefix(cfg.Endpoint, "https://"))
+	}
+	// without static credentials the default credential chain (env, shared config, instance role) is used.
+	if cfg.AccessKey != "" {
+		awsConfig.Credentials = credentials.NewStaticCredentials(cfg.AccessKey, cfg.SecretKey, "")
+	}
+
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create s3 session: %w", err)
+	}
+
+	return &S3Store{
+		config:   cfg,
+		client:   s3.New(sess),
+		uploader: s3manager.NewUploader(sess),
+	}, nil
+}
+
+func (c *S3Store) Upload(ctx context.Context, file io.Reader, filePath string) error {
+	_, err := c.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		ACL:    aws.String("private"),
+		Bucket: aws.String(c.config.Bucket),
+		Key:    aws.String(objectKey(filePath)),
+		Body:   file,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload file to s3: %w", err)
+	}
+
+	return nil
+}
+
+func (c *S3Store) GetSignedURL(_ context.Context, filePath string) (string, error) {
+	req, _ := c.client.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(c.config.Bucket),
+		Key:    aws.String(objectKey(filePath)),
+	})
+
+	signedURL, err := req.Presign(signedURLExpiry(c.config))
+	if err != nil {
+		return "", fmt.Errorf("failed to create signed URL for file: %s %w", filePath, err)
+	}
+	return signedURL, nil
+}
+
+func (c *S3Store) Download(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	out, err := c.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(c.config.Bucket),
+		Key:    aws.String(objectKey(filePath)),
+	})
+	if isS3NotFound(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file: %s %w", filePath, err)
+	}
+	return out.Body, nil
+}
+
+func (c *S3Store) Delete(ctx context.Context, filePath string) error {
+	// S3 doesn't fail when deleting objects that don't exist.
+	_, err := c.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(c.config.Bucket),
+		Key:    aws.String(objectKey(filePath)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete file: %s %w", filePath, err)
+	}
+	return nil
+}
+
+func (c *S3Store) Exists(ctx context.Context, filePath string) (bool, error) {
+	_, err := c.client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(c.config.Bucket),
+		Key:    aws.String(objectKey(filePath)),
+	})
+	if isS3NotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get head of file: %s %w", filePath, err)
+	}
+	return true, nil
+}
+
+// validateS3Config ensures the config contains everything required to access the bucket.
+func validateS3Config(cfg Config) error {
+	if cfg.Bucket == "" {
+		return errors.New("bucket is required for the s3 blob store")
+	}
+	if (cfg.AccessKey == "") != (cfg.SecretKey == "") {
+		return errors.New("access key and secret key of the s3 blob store have to be provided together")
+	}
+	return nil
+}
+
+// objectKey returns the key of the object that stores the file.
+// Keys are relative to the bucket, so leading slashes would end up as empty path segments.
+func objectKey(filePath string) string {
+	return strings.TrimLeft(path.Clean("/"+filePath), "/")
+}
+
+// isS3NotFound returns true if the error is returned by S3 for objects that don't exist.
+// HEAD requests have no body, hence only the status code indicates a missing object.
+func isS3NotFound(err error) bool {
+	var reqErr awserr.RequestFailure
+	if errors.As(err, &reqErr) && reqErr.StatusCode() == http.StatusNotFound {
+		return true
+	}
+
+	var awsErr awserr.Error
+	return errors.As(err, &awsErr) && awsErr.Code() == s3.ErrCodeNoSuchKey
+}
+
+// signedURLExpiry returns the configured validity of signed URLs.
+func signedURLExpiry(cfg Config) time.Duration {
+	if cfg.SignedURLExpiry <= 0 {
+		return defaultSignedURLExpiry
+	}
+	return cfg.SignedURLExpiry
+}
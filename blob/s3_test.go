@@ -0,0 +1,130 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestValidateS3Config(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{
+			name: "bucket only",
+			cfg:  Config{Bucket: "gitness"},
+		},
+		{
+			name: "static credentials",
+			cfg:  Config{Bucket: "gitness", AccessKey: "key", SecretKey: "secret"},
+		},
+		{
+			name:    "missing bucket",
+			cfg:     Config{AccessKey: "key", SecretKey: "secret"},
+			wantErr: true,
+		},
+		{
+			name:    "access key without secret key",
+			cfg:     Config{Bucket: "gitness", AccessKey: "key"},
+			wantErr: true,
+		},
+		{
+			name:    "secret key without access key",
+			cfg:     Config{Bucket: "gitness", SecretKey: "secret"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateS3Config(test.cfg)
+			if test.wantErr && err == nil {
+				t.Error("expected an error")
+			}
+			if !test.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			_, err = NewS3Store(test.cfg)
+			if test.wantErr != (err != nil) {
+				t.Errorf("NewS3Store: expected error=%t, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestObjectKey(t *testing.T) {
+	tests := []struct {
+		filePath string
+		want     string
+	}{
+		{filePath: "avatars/1/avatar.png", want: "avatars/1/avatar.png"},
+		{filePath: "/avatars/1/avatar.png", want: "avatars/1/avatar.png"},
+		{filePath: "//avatars//1/avatar.png", want: "avatars/1/avatar.png"},
+		{filePath: "avatars/1/", want: "avatars/1"},
+		{filePath: "../avatars/1/avatar.png", want: "avatars/1/avatar.png"},
+	}
+
+	for _, test := range tests {
+		if got := objectKey(test.filePath); got != test.want {
+			t.Errorf("objectKey(%q): want=%q got=%q", test.filePath, test.want, got)
+		}
+	}
+}
+
+func TestS3Store_GetSignedURL(t *testing.T) {
+	s, err := NewS3Store(Config{
+		Bucket:          "gitness",
+		Endpoint:        "https://s3.example.com",
+		Region:          "us-east-1",
+		AccessKey:       "key",
+		SecretKey:       "secret",
+		PathStyle:       true,
+		SignedURLExpiry: 10 * time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+
+	signedURL, err := s.GetSignedURL(context.Background(), "/avatars/1/avatar.png")
+	if err != nil {
+		t.Fatalf("failed to get signed url: %v", err)
+	}
+
+	u, err := url.Parse(signedURL)
+	if err != nil {
+		t.Fatalf("failed to parse signed url %q: %v", signedURL, err)
+	}
+	if want := "/gitness/avatars/1/avatar.png"; u.Path != want {
+		t.Errorf("path mismatch: want=%q got=%q", want, u.Path)
+	}
+	if want := "600"; u.Query().Get("X-Amz-Expires") != want {
+		t.Errorf("expiry mismatch: want=%s got=%s", want, u.Query().Get("X-Amz-Expires"))
+	}
+}
+
+func TestSignedURLExpiry(t *testing.T) {
+	if got := signedURLExpiry(Config{}); got != defaultSignedURLExpiry {
+		t.Errorf("expected default expiry %s, got %s", defaultSignedURLExpiry, got)
+	}
+	if got := signedURLExpiry(Config{SignedURLExpiry: time.Minute}); got != time.Minute {
+		t.Errorf("expected configured expiry %s, got %s", time.Minute, got)
+	}
+}
@@ -100,3 +100,9 @@ func (c *Redis[K, V]) Get(ctx context.Context, key K) (V, error) {
 
 	return item, nil
 }
+
+// Evict implements the cache.Cache interface.
+// A failed eviction leaves the object in the cache until it expires.
+func (c *Redis[K, V]) Evict(ctx context.Context, key K) {
+	_ = c.client.Del(ctx, c.keyEncoder(key)).Err()
+}
@@ -211,6 +211,13 @@ func (c *TTLCache[K, V]) Get(ctx context.Context, key K) (V, error) {
 	return item, nil
 }
 
+// Evict removes the object from the cache.
+func (c *TTLCache[K, V]) Evict(_ context.Context, key K) {
+	c.mx.Lock()
+	delete(c.cache, key)
+	c.mx.Unlock()
+}
+
 // deduplicate is a utility function that removes duplicates from slice.
 func deduplicate[V constraints.Ordered](slice []V) []V {
 	if len(slice) <= 1 {
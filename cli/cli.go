@@ -18,7 +18,10 @@ import (
 	"os"
 
 	"github.com/harness/gitness/cli/operations/hooks"
+	"github.com/harness/gitness/client"
 	"github.com/harness/gitness/git/hook"
+
+	"gopkg.in/alecthomas/kingpin.v2"
 )
 
 func GetArguments() []string {
@@ -32,3 +35,19 @@ func GetArguments() []string {
 
 	return args
 }
+
+// MustParse is a replacement of kingpin.MustParse that terminates the application with a non-zero exit code
+// if parsing the command line or running the selected command failed.
+// Errors returned by the remote API are reported with their error code (e.g. "not_found").
+func MustParse(command string, err error) string {
+	if err == nil {
+		return command
+	}
+
+	if code, ok := client.ErrorCode(err); ok {
+		kingpin.Fatalf("%s: %s", code, err)
+	}
+
+	kingpin.Fatalf("%s, try --help", err)
+	return command
+}
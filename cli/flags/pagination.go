@@ -0,0 +1,33 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flags
+
+import (
+	"github.com/harness/gitness/client"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// RegisterPagination registers the pagination flags of list commands.
+func RegisterPagination(cmd *kingpin.CmdClause, opts *client.ListOptions) {
+	cmd.Flag("page", "page number").
+		IntVar(&opts.Page)
+
+	cmd.Flag("limit", "page size").
+		IntVar(&opts.Limit)
+
+	cmd.Flag("all", "list all pages, starting with the requested page").
+		BoolVar(&opts.All)
+}
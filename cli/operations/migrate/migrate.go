@@ -33,6 +33,7 @@ func Register(app *kingpin.Application) {
 	cmd := app.Command("migrate", "database migration tool")
 	registerCurrent(cmd)
 	registerTo(cmd)
+	registerSQLiteToPostgres(cmd)
 }
 
 func getDB(ctx context.Context, envfile string) (*sqlx.DB, error) {
@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"os/signal"
+	"syscall"
+
+	"github.com/harness/gitness/app/store/database/transfer"
+	"github.com/harness/gitness/store/database"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type commandSQLiteToPostgres struct {
+	source     string
+	target     string
+	resume     bool
+	batchSize  int
+	sampleSize int
+}
+
+func (c *commandSQLiteToPostgres) run(*kingpin.ParseContext) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	ctx = setupLoggingContext(ctx)
+
+	src, err := database.Connect(ctx, "sqlite3", c.source)
+	if err != nil {
+		return fmt.Errorf("failed to open source database: %w", err)
+	}
+
+	dst, err := database.Connect(ctx, "postgres", c.target)
+	if err != nil {
+		return fmt.Errorf("failed to open target database: %w", err)
+	}
+
+	return transfer.Run(ctx, src, dst, transfer.Options{
+		Resume:     c.resume,
+		BatchSize:  c.batchSize,
+		SampleSize: c.sampleSize,
+	})
+}
+
+func registerSQLiteToPostgres(app *kingpin.CmdClause) {
+	c := &commandSQLiteToPostgres{}
+
+	cmd := app.Command("sqlite-to-postgres", "copies all data from a sqlite database into an empty postgres database").
+		Action(c.run)
+
+	cmd.Arg("source", "datasource of the sqlite database").
+		Required().
+		StringVar(&c.source)
+
+	cmd.Arg("target", "datasource of the postgres database").
+		Required().
+		StringVar(&c.target)
+
+	cmd.Flag("resume", "continue an interrupted transfer, skipping tables that were copied completely").
+		Default("false").
+		BoolVar(&c.resume)
+
+	cmd.Flag("batch-size", "maximum number of rows inserted with a single statement").
+		Default("500").
+		IntVar(&c.batchSize)
+
+	cmd.Flag("sample-size", "number of rows per table that are compared after copying").
+		Default("100").
+		IntVar(&c.sampleSize)
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repos
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/cli/provide"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type createCommand struct {
+	in   repo.CreateInput
+	json bool
+}
+
+func (c *createCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	out, err := provide.Client().RepoCreate(ctx, &c.in)
+	if err != nil {
+		return err
+	}
+
+	return printRepos([]repo.RepositoryOutput{*out}, c.json)
+}
+
+// helper function registers the repository create command.
+func registerCreate(app *kingpin.CmdClause) {
+	c := &createCommand{}
+
+	cmd := app.Command("create", "create a repository").
+		Action(c.run)
+
+	cmd.Arg("space", "path of the space the repository is created in").
+		Required().
+		StringVar(&c.in.ParentRef)
+
+	cmd.Arg("identifier", "identifier of the repository").
+		Required().
+		StringVar(&c.in.Identifier)
+
+	cmd.Flag("default-branch", "default branch of the repository").
+		StringVar(&c.in.DefaultBranch)
+
+	cmd.Flag("description", "description of the repository").
+		StringVar(&c.in.Description)
+
+	cmd.Flag("public", "make the repository public").
+		BoolVar(&c.in.IsPublic)
+
+	cmd.Flag("readme", "create an initial README file").
+		BoolVar(&c.in.Readme)
+
+	cmd.Flag("license", "create an initial LICENSE file of the given type").
+		StringVar(&c.in.License)
+
+	cmd.Flag("gitignore", "create an initial .gitignore file of the given type").
+		StringVar(&c.in.GitIgnore)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+}
@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repos
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/harness/gitness/cli/provide"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type exportCommand struct {
+	repo    string
+	gitRef  string
+	format  string
+	output  string
+	timeout time.Duration
+}
+
+func (c *exportCommand) run(*kingpin.ParseContext) (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	out := os.Stdout
+	if c.output != "-" {
+		out, err = os.Create(c.output)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer func() {
+			if cErr := out.Close(); cErr != nil && err == nil {
+				err = fmt.Errorf("failed to close output file: %w", cErr)
+			}
+		}()
+	}
+
+	return provide.Client().RepoArchive(ctx, c.repo, c.gitRef, c.format, out)
+}
+
+// helper function registers the repository export command.
+func registerExport(app *kingpin.CmdClause) {
+	c := &exportCommand{}
+
+	cmd := app.Command("export", "export the content of a repository as an archive").
+		Action(c.run)
+
+	cmd.Arg("repo", "path of the repository").
+		Required().
+		StringVar(&c.repo)
+
+	cmd.Arg("output", "file the archive is written to, - for stdout").
+		Required().
+		StringVar(&c.output)
+
+	cmd.Flag("ref", "branch, tag or commit to export").
+		Default("HEAD").
+		StringVar(&c.gitRef)
+
+	cmd.Flag("format", "format of the archive").
+		Default("zip").
+		EnumVar(&c.format, "zip", "tar", "tar.gz", "tgz")
+
+	cmd.Flag("timeout", "maximum duration of the export").
+		Default("10m").
+		DurationVar(&c.timeout)
+}
@@ -0,0 +1,100 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repos
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/services/importer"
+	"github.com/harness/gitness/cli/provide"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type importCommand struct {
+	in        repo.ImportInput
+	provider  string
+	pipelines string
+	json      bool
+}
+
+func (c *importCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	c.in.Provider.Type = importer.ProviderType(c.provider)
+	c.in.Pipelines = importer.PipelineOption(c.pipelines)
+
+	out, err := provide.Client().RepoImport(ctx, &c.in)
+	if err != nil {
+		return err
+	}
+
+	return printRepos([]repo.RepositoryOutput{*out}, c.json)
+}
+
+// helper function registers the repository import command.
+func registerImport(app *kingpin.CmdClause) {
+	c := &importCommand{}
+
+	cmd := app.Command("import", "import a repository from a git provider").
+		Action(c.run)
+
+	cmd.Arg("space", "path of the space the repository is imported into").
+		Required().
+		StringVar(&c.in.ParentRef)
+
+	cmd.Arg("identifier", "identifier of the repository").
+		Required().
+		StringVar(&c.in.Identifier)
+
+	cmd.Arg("provider-repo", "repository at the provider (e.g. owner/name)").
+		Required().
+		StringVar(&c.in.ProviderRepo)
+
+	cmd.Flag("provider", "type of the git provider").
+		Default(string(importer.ProviderTypeGitHub)).
+		EnumVar(&c.provider,
+			string(importer.ProviderTypeGitHub),
+			string(importer.ProviderTypeGitLab),
+			string(importer.ProviderTypeBitbucket),
+			string(importer.ProviderTypeStash),
+			string(importer.ProviderTypeGitea),
+			string(importer.ProviderTypeGogs),
+			string(importer.ProviderTypeAzure),
+		)
+
+	cmd.Flag("host", "host of the git provider, if it's self-hosted").
+		StringVar(&c.in.Provider.Host)
+
+	cmd.Flag("username", "username used to authenticate with the git provider").
+		StringVar(&c.in.Provider.Username)
+
+	cmd.Flag("password", "password or token used to authenticate with the git provider").
+		Envar("GITNESS_CLI_IMPORT_PASSWORD").
+		StringVar(&c.in.Provider.Password)
+
+	cmd.Flag("pipelines", "whether pipelines of the repository are converted").
+		Default(string(importer.PipelineOptionIgnore)).
+		EnumVar(&c.pipelines, string(importer.PipelineOptionConvert), string(importer.PipelineOptionIgnore))
+
+	cmd.Flag("description", "description of the repository").
+		StringVar(&c.in.Description)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+}
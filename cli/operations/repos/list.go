@@ -0,0 +1,61 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repos
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/cli/flags"
+	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/client"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type listCommand struct {
+	space string
+	opts  client.ListOptions
+	json  bool
+}
+
+func (c *listCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	repos, err := provide.Client().SpaceRepos(ctx, c.space, c.opts)
+	if err != nil {
+		return err
+	}
+
+	return printRepos(repos, c.json)
+}
+
+// helper function registers the repository list command.
+func registerList(app *kingpin.CmdClause) {
+	c := &listCommand{}
+
+	cmd := app.Command("ls", "display a list of repositories").
+		Action(c.run)
+
+	cmd.Arg("space", "path of the space").
+		Required().
+		StringVar(&c.space)
+
+	flags.RegisterPagination(cmd, &c.opts)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+}
@@ -0,0 +1,48 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repos
+
+import (
+	"strconv"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/cli/textui"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// Register the command.
+func Register(app *kingpin.Application) {
+	cmd := app.Command("repos", "manage repositories")
+	registerCreate(cmd)
+	registerImport(cmd)
+	registerExport(cmd)
+	registerDelete(cmd)
+	registerList(cmd)
+}
+
+// helper function prints the repositories either as json or as a table.
+func printRepos(repos []repo.RepositoryOutput, asJSON bool) error {
+	if asJSON {
+		return textui.JSON(repos)
+	}
+
+	rows := make([][]string, len(repos))
+	for i, r := range repos {
+		rows[i] = []string{r.Path, r.DefaultBranch, strconv.FormatBool(r.IsPublic)}
+	}
+
+	return textui.Table([]string{"PATH", "DEFAULT BRANCH", "PUBLIC"}, rows)
+}
@@ -22,19 +22,31 @@ import (
 	"strings"
 	"unicode"
 
+	"github.com/harness/gitness/app/auth/oidc"
 	"github.com/harness/gitness/app/gitspace/infrastructure"
 	"github.com/harness/gitness/app/gitspace/orchestrator"
 	"github.com/harness/gitness/app/gitspace/orchestrator/ide"
+	"github.com/harness/gitness/app/services/accesslog"
+	"github.com/harness/gitness/app/services/avatar"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/digest"
 	"github.com/harness/gitness/app/services/gitspaceevent"
+	"github.com/harness/gitness/app/services/idempotency"
+	"github.com/harness/gitness/app/services/inbox"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/loginprotection"
 	"github.com/harness/gitness/app/services/notification"
+	"github.com/harness/gitness/app/services/recentvisit"
+	"github.com/harness/gitness/app/services/repoactivity"
+	"github.com/harness/gitness/app/services/repodocs"
+	"github.com/harness/gitness/app/services/spacefeed"
 	"github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/events"
 	gittypes "github.com/harness/gitness/git/types"
+	"github.com/harness/gitness/http/outbound"
 	"github.com/harness/gitness/infraprovider"
 	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/lock"
@@ -271,14 +283,15 @@ func getSanitizedMachineName() (string, error) {
 // ProvideDatabaseConfig loads the database config from the main config.
 func ProvideDatabaseConfig(config *types.Config) database.Config {
 	return database.Config{
-		Driver:     config.Database.Driver,
-		Datasource: config.Database.Datasource,
+		Driver:            config.Database.Driver,
+		Datasource:        config.Database.Datasource,
+		ReplicaDatasource: config.Database.ReplicaDatasource,
 	}
 }
 
 // ProvideBlobStoreConfig loads the blob store config from the main config.
 func ProvideBlobStoreConfig(config *types.Config) (blob.Config, error) {
-	// Prefix home directory in case of filesystem blobstore
+	// Store files next to the git repositories in case of filesystem blobstore
 	if config.BlobStore.Provider == blob.ProviderFileSystem && config.BlobStore.Bucket == "" {
 		var homedir string
 		homedir, err := os.UserHomeDir()
@@ -286,7 +299,13 @@ func ProvideBlobStoreConfig(config *types.Config) (blob.Config, error) {
 			return blob.Config{}, err
 		}
 
-		config.BlobStore.Bucket = filepath.Join(homedir, gitnessHomeDir, blobDir)
+		// keep using the legacy location in the home directory if it's in use already.
+		legacyPath := filepath.Join(homedir, gitnessHomeDir, blobDir)
+		if _, err = os.Stat(legacyPath); err == nil || config.Git.Root == "" {
+			config.BlobStore.Bucket = legacyPath
+		} else {
+			config.BlobStore.Bucket = filepath.Join(config.Git.Root, blobDir)
+		}
 	}
 	return blob.Config{
 		Provider:              config.BlobStore.Provider,
@@ -294,20 +313,37 @@ func ProvideBlobStoreConfig(config *types.Config) (blob.Config, error) {
 		KeyPath:               config.BlobStore.KeyPath,
 		TargetPrincipal:       config.BlobStore.TargetPrincipal,
 		ImpersonationLifetime: config.BlobStore.ImpersonationLifetime,
+		Endpoint:              config.BlobStore.S3.Endpoint,
+		Region:                config.BlobStore.S3.Region,
+		AccessKey:             config.BlobStore.S3.AccessKey,
+		SecretKey:             config.BlobStore.S3.SecretKey,
+		PathStyle:             config.BlobStore.S3.PathStyle,
+		SignedURLExpiry:       config.BlobStore.SignedURLExpiry,
 	}, nil
 }
 
 // ProvideGitConfig loads the git config from the main config.
 func ProvideGitConfig(config *types.Config) gittypes.Config {
 	return gittypes.Config{
-		Trace:    config.Git.Trace,
-		Root:     config.Git.Root,
-		TmpDir:   config.Git.TmpDir,
-		HookPath: config.Git.HookPath,
+		Trace:                config.Git.Trace,
+		Root:                 config.Git.Root,
+		TmpDir:               config.Git.TmpDir,
+		HookPath:             config.Git.HookPath,
+		PartialClone:         config.Git.PartialClone,
+		AdvertisePushOptions: config.Git.AdvertisePushOptions,
+		AdvertiseAtomic:      config.Git.AdvertiseAtomic,
+		HiddenRefs:           config.Git.HiddenRefs,
+		AncestryBatchWindow:  config.Git.AncestryBatchWindow,
 		LastCommitCache: gittypes.LastCommitCacheConfig{
 			Mode:     config.Git.LastCommitCache.Mode,
 			Duration: config.Git.LastCommitCache.Duration,
 		},
+		Timeouts: gittypes.TimeoutsConfig{
+			InfoRefs:    config.Git.Timeouts.InfoRefs,
+			UploadPack:  config.Git.Timeouts.UploadPack,
+			ReceivePack: config.Git.Timeouts.ReceivePack,
+			Archive:     config.Git.Timeouts.Archive,
+		},
 	}
 }
 
@@ -321,16 +357,26 @@ func ProvideEventsConfig(config *types.Config) events.Config {
 	}
 }
 
+// ProvideOutboundConfig loads the config shared by all outbound http clients from the main config.
+func ProvideOutboundConfig(config *types.Config) outbound.Config {
+	return outbound.Config{
+		Timeout:         config.Outbound.Timeout,
+		ProxyURL:        config.Outbound.ProxyURL,
+		MaxResponseSize: config.Outbound.MaxResponseSize,
+		AllowedNetworks: config.Outbound.AllowedNetworks,
+		LogRequests:     config.Outbound.LogRequests,
+	}
+}
+
 // ProvideWebhookConfig loads the webhook service config from the main config.
-func ProvideWebhookConfig(config *types.Config) webhook.Config {
+func ProvideWebhookConfig(config *types.Config, outboundConfig outbound.Config) webhook.Config {
 	return webhook.Config{
-		UserAgentIdentity:   config.Webhook.UserAgentIdentity,
-		HeaderIdentity:      config.Webhook.HeaderIdentity,
-		EventReaderName:     config.InstanceID,
-		Concurrency:         config.Webhook.Concurrency,
-		MaxRetries:          config.Webhook.MaxRetries,
-		AllowPrivateNetwork: config.Webhook.AllowPrivateNetwork,
-		AllowLoopback:       config.Webhook.AllowLoopback,
+		UserAgentIdentity: config.Webhook.UserAgentIdentity,
+		HeaderIdentity:    config.Webhook.HeaderIdentity,
+		EventReaderName:   config.InstanceID,
+		Concurrency:       config.Webhook.Concurrency,
+		MaxRetries:        config.Webhook.MaxRetries,
+		Outbound:          outboundConfig,
 	}
 }
 
@@ -382,6 +428,126 @@ func ProvideCleanupConfig(config *types.Config) cleanup.Config {
 	return cleanup.Config{
 		WebhookExecutionsRetentionTime:   config.Webhook.RetentionTime,
 		DeletedRepositoriesRetentionTime: config.Repos.DeletedRetentionTime,
+		RepoActivitiesRetentionTime:      config.RepoActivity.RetentionTime,
+		RepoAccessLogsRetentionTime:      config.RepoAccessLog.RetentionTime,
+		PullReqClosedRefsRetentionTime:   config.PullReq.ClosedRefsRetentionTime,
+		LoginFailuresRetentionTime:       config.Login.FailureWindow,
+		ArtifactsRetentionTime:           config.CI.Artifacts.RetentionTime,
+		OperationsRetentionTime:          config.BackgroundJobs.RetentionTime,
+		ArtifactsMaxRepoSize:             config.CI.Artifacts.MaxRepoSize,
+	}
+}
+
+// ProvideOIDCConfig loads the oidc provider config from the main config.
+func ProvideOIDCConfig(config *types.Config) oidc.Config {
+	redirectURL := config.OIDC.RedirectURL
+	if redirectURL == "" {
+		redirectURL = strings.TrimSuffix(config.URL.API, "/") + "/v1/auth/oidc/callback"
+	}
+
+	return oidc.Config{
+		Enabled:             config.OIDC.Enabled,
+		DisplayName:         config.OIDC.DisplayName,
+		IssuerURL:           config.OIDC.IssuerURL,
+		ClientID:            config.OIDC.ClientID,
+		ClientSecret:        config.OIDC.ClientSecret,
+		Scopes:              config.OIDC.Scopes,
+		RedirectURL:         redirectURL,
+		ClaimEmail:          config.OIDC.ClaimEmail,
+		ClaimName:           config.OIDC.ClaimName,
+		ClaimUsername:       config.OIDC.ClaimUsername,
+		AutoProvision:       config.OIDC.AutoProvision,
+		LinkExistingByEmail: config.OIDC.LinkExistingByEmail,
+		DiscoveryTimeout:    config.OIDC.DiscoveryTimeout,
+		DiscoveryCacheTTL:   config.OIDC.DiscoveryCacheTTL,
+	}
+}
+
+// ProvideLoginProtectionConfig loads the login protection service config from the main config.
+func ProvideLoginProtectionConfig(config *types.Config) loginprotection.Config {
+	return loginprotection.Config{
+		Enabled:            config.Login.ProtectionEnabled,
+		FailureWindow:      config.Login.FailureWindow,
+		DelayAfter:         config.Login.DelayAfter,
+		DelayStep:          config.Login.DelayStep,
+		MaxDelay:           config.Login.MaxDelay,
+		LockoutThreshold:   config.Login.LockoutThreshold,
+		IPLockoutThreshold: config.Login.IPLockoutThreshold,
+		LockoutDuration:    config.Login.LockoutDuration,
+	}
+}
+
+// ProvideRepoActivityConfig loads the repo activity service config from the main config.
+func ProvideRepoActivityConfig(config *types.Config) repoactivity.Config {
+	return repoactivity.Config{
+		EventReaderName: config.InstanceID,
+		Concurrency:     config.RepoActivity.Concurrency,
+		MaxRetries:      config.RepoActivity.MaxRetries,
+	}
+}
+
+// ProvideInboxConfig loads the in-app notification service config from the main config.
+func ProvideInboxConfig(config *types.Config) inbox.Config {
+	return inbox.Config{
+		EventReaderName: config.InstanceID,
+		Concurrency:     config.Inbox.Concurrency,
+		MaxRetries:      config.Inbox.MaxRetries,
+		DedupWindow:     config.Inbox.DedupWindow,
+	}
+}
+
+// ProvideAvatarConfig loads the avatar service config from the main config.
+func ProvideAvatarConfig(config *types.Config) avatar.Config {
+	return avatar.Config{
+		MaxFileSize:  config.Avatar.MaxFileSize,
+		MaxDimension: config.Avatar.MaxDimension,
+	}
+}
+
+// ProvideSpaceFeedConfig loads the space live update feed service config from the main config.
+func ProvideSpaceFeedConfig(config *types.Config) spacefeed.Config {
+	return spacefeed.Config{
+		EventReaderName: config.InstanceID,
+		Concurrency:     config.SpaceFeed.Concurrency,
+		MaxRetries:      config.SpaceFeed.MaxRetries,
+		MaxLength:       config.SpaceFeed.MaxLength,
+		ReplayLimit:     config.SpaceFeed.ReplayLimit,
+	}
+}
+
+// ProvideDigestConfig loads the activity digest service config from the main config.
+func ProvideDigestConfig(config *types.Config) digest.Config {
+	return digest.Config{
+		Enabled:      config.SMTP.Host != "",
+		BatchSize:    config.Digest.BatchSize,
+		MaxRepos:     config.Digest.MaxRepos,
+		SendInterval: config.Digest.SendInterval,
+	}
+}
+
+// ProvideRecentVisitConfig loads the recent visit service config from the main config.
+func ProvideRecentVisitConfig(config *types.Config) recentvisit.Config {
+	return recentvisit.Config{
+		Debounce:      config.RecentVisits.Debounce,
+		FlushInterval: config.RecentVisits.FlushInterval,
+		MaxEntries:    config.RecentVisits.MaxEntries,
+	}
+}
+
+// ProvideRepoAccessLogConfig loads the repo access log service config from the main config.
+func ProvideRepoAccessLogConfig(config *types.Config) accesslog.Config {
+	return accesslog.Config{
+		FlushInterval: config.RepoAccessLog.FlushInterval,
+		BatchSize:     config.RepoAccessLog.BatchSize,
+	}
+}
+
+// ProvideIdempotencyConfig loads the idempotency service config from the main config.
+func ProvideIdempotencyConfig(config *types.Config) idempotency.Config {
+	return idempotency.Config{
+		TTL:         config.Idempotency.TTL,
+		LockTimeout: config.Idempotency.LockTimeout,
+		WaitTimeout: config.Idempotency.WaitTimeout,
 	}
 }
 
@@ -401,6 +567,15 @@ func ProvideKeywordSearchConfig(config *types.Config) keywordsearch.Config {
 	}
 }
 
+// ProvideRepoDocsConfig loads the repo docs service config from the main config.
+func ProvideRepoDocsConfig(config *types.Config) repodocs.Config {
+	return repodocs.Config{
+		EventReaderName: config.InstanceID,
+		Concurrency:     config.RepoDocs.Concurrency,
+		MaxRetries:      config.RepoDocs.MaxRetries,
+	}
+}
+
 func ProvideJobsConfig(config *types.Config) job.Config {
 	return job.Config{
 		InstanceID:                  config.InstanceID,
@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/harness/gitness/app/store/database/migrate"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// MigrateOnly migrates the database to the latest version known by the binary without starting the server.
+// If dryRun is true, the SQL of all pending migrations is printed to stdout instead of being applied.
+func MigrateOnly(ctx context.Context, config *types.Config, dryRun bool) error {
+	log := log.Logger.With().Logger()
+	ctx = log.WithContext(ctx)
+
+	db, err := database.Connect(ctx, config.Database.Driver, config.Database.Datasource)
+	if err != nil {
+		return fmt.Errorf("failed to create database handle: %w", err)
+	}
+
+	if dryRun {
+		return migrate.DryRun(ctx, db, os.Stdout)
+	}
+
+	if err = migrate.Migrate(ctx, db); err != nil {
+		return fmt.Errorf("failed to migrate the database: %w", err)
+	}
+
+	version, err := migrate.Current(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to get current database version: %w", err)
+	}
+
+	log.Info().Str("version", version).Msg("database migrated")
+
+	return nil
+}
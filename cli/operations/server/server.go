@@ -25,6 +25,7 @@ import (
 	"github.com/harness/gitness/app/pipeline/logger"
 	"github.com/harness/gitness/profiler"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/version"
 
 	"github.com/joho/godotenv"
@@ -38,6 +39,8 @@ import (
 type command struct {
 	envfile     string
 	enableCI    bool
+	migrateOnly bool
+	dryRun      bool
 	initializer func(context.Context, *types.Config) (*System, error)
 }
 
@@ -63,12 +66,37 @@ func (c *command) run(*kingpin.ParseContext) error {
 	// configure profiler
 	SetupProfiler(config)
 
+	// restore default behavior on the interrupt signal once the first one was received,
+	// allowing the user to force the shutdown.
+	context.AfterFunc(ctx, stop)
+
+	if c.migrateOnly || c.dryRun {
+		return MigrateOnly(ctx, config, c.dryRun)
+	}
+
+	return Serve(ctx, config, c.initializer, c.enableCI)
+}
+
+// Serve initializes the system using the provided initializer and runs it until
+// the context is canceled or any of the servers or background services fails.
+// The system is shut down gracefully before Serve returns.
+func Serve(
+	ctx context.Context,
+	config *types.Config,
+	initializer func(context.Context, *types.Config) (*System, error),
+	enableCI bool,
+) error {
 	// add logger to context
 	log := log.Logger.With().Logger()
 	ctx = log.WithContext(ctx)
 
+	// configure the validation of new UIDs
+	check.SetUIDPolicy(check.UIDPolicy{
+		AllowLeadingDigit: config.UID.AllowLeadingDigit,
+	})
+
 	// initialize system
-	system, err := c.initializer(ctx, config)
+	system, err := initializer(ctx, config)
 	if err != nil {
 		return fmt.Errorf("encountered an error while wiring the system: %w", err)
 	}
@@ -100,18 +128,83 @@ func (c *command) run(*kingpin.ParseContext) error {
 			}
 		}
 
+		if err := system.services.RepoReflogEnabler.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register repo reflog enabler")
+			return err
+		}
+
+		if err := system.services.RepoMaintenance.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register repo maintenance")
+			return err
+		}
+
+		if err := system.services.RepoStatsCalculator.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register repo stats calculator")
+			return err
+		}
+
+		if err := system.services.CounterReconciler.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register counter reconciler")
+			return err
+		}
+
 		if err := system.services.Cleanup.Register(gCtx); err != nil {
 			log.Error().Err(err).Msg("failed to register cleanup service")
 			return err
 		}
 
+		if err := system.services.StageApprovalExpirer.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register stage approval expirer")
+			return err
+		}
+
+		if err := system.services.TimeoutEnforcer.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register execution timeout enforcer")
+			return err
+		}
+
+		if err := system.services.RunnerMonitor.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register runner monitor")
+			return err
+		}
+
+		if err := system.services.Usage.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register usage rollup job")
+			return err
+		}
+
+		if err := system.services.Digest.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register activity digest job")
+			return err
+		}
+
 		return system.services.JobScheduler.Run(gCtx)
 	})
 
+	g.Go(func() error {
+		return system.services.RecentVisit.Run(gCtx)
+	})
+
+	g.Go(func() error {
+		return system.services.InstanceSettings.Run(gCtx)
+	})
+
+	g.Go(func() error {
+		return system.services.FeatureFlags.Run(gCtx)
+	})
+
+	g.Go(func() error {
+		return system.services.GitLimit.Run(gCtx)
+	})
+
+	g.Go(func() error {
+		return system.services.AccessLog.Run(gCtx)
+	})
+
 	// start server
 	gHTTP, shutdownHTTP := system.server.ListenAndServe()
 	g.Go(gHTTP.Wait)
-	if c.enableCI {
+	if enableCI {
 		// start populating plugins
 		g.Go(func() error {
 			err := system.resolverManager.Populate(ctx)
@@ -120,6 +213,11 @@ func (c *command) run(*kingpin.ParseContext) error {
 			}
 			return nil
 		})
+		// keep the embedded runner registered, stages are only dispatched to online runners.
+		g.Go(func() error {
+			system.runnerHeartbeat.Run(gCtx)
+			return nil
+		})
 		// start poller for CI build executions.
 		g.Go(func() error {
 			system.poller.Poll(
@@ -148,14 +246,20 @@ func (c *command) run(*kingpin.ParseContext) error {
 	// wait until the error group context is done
 	<-gCtx.Done()
 
-	// restore default behavior on the interrupt signal and notify user of shutdown.
-	stop()
+	// notify user of shutdown.
 	log.Info().Msg("shutting down gracefully (press Ctrl+C again to force)")
 
-	// shutdown servers gracefully
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), config.GracefulShutdownTime)
+	// shutdown servers gracefully, keeping the logger of the canceled context for the shutdown logs.
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), config.GracefulShutdownTime)
 	defer cancel()
 
+	// drain in-flight git operations before shutting down the servers, as git hooks of in-flight pushes
+	// call the internal api of the http server. New git operations are rejected while draining and
+	// git operations exceeding the drain timeout get canceled.
+	drainCtx, drainCancel := context.WithTimeout(shutdownCtx, config.Git.DrainTimeout)
+	system.git.Drain(drainCtx)
+	drainCancel()
+
 	if sErr := shutdownHTTP(shutdownCtx); sErr != nil {
 		log.Err(sErr).Msg("failed to shutdown http server gracefully")
 	}
@@ -235,4 +339,12 @@ func Register(app *kingpin.Application, initializer func(context.Context, *types
 		Default("true").
 		Envar("ENABLE_CI").
 		BoolVar(&c.enableCI)
+
+	cmd.Flag("migrate-only", "migrate the database and exit without starting the server").
+		Default("false").
+		BoolVar(&c.migrateOnly)
+
+	cmd.Flag("dry-run", "print the SQL of pending database migrations and exit without applying them").
+		Default("false").
+		BoolVar(&c.dryRun)
 }
@@ -17,8 +17,10 @@ package server
 import (
 	"github.com/harness/gitness/app/bootstrap"
 	"github.com/harness/gitness/app/pipeline/resolver"
+	"github.com/harness/gitness/app/pipeline/runner"
 	"github.com/harness/gitness/app/server"
 	"github.com/harness/gitness/app/services"
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/ssh"
 
 	"github.com/drone/runner-go/poller"
@@ -31,7 +33,9 @@ type System struct {
 	sshServer       *ssh.Server
 	resolverManager *resolver.Manager
 	poller          *poller.Poller
+	runnerHeartbeat *runner.Heartbeat
 	services        services.Services
+	git             git.Interface
 }
 
 // NewSystem returns a new system structure.
@@ -40,15 +44,19 @@ func NewSystem(
 	server *server.Server,
 	sshServer *ssh.Server,
 	poller *poller.Poller,
+	runnerHeartbeat *runner.Heartbeat,
 	resolverManager *resolver.Manager,
 	services services.Services,
+	git git.Interface,
 ) *System {
 	return &System{
 		bootstrap:       bootstrap,
 		server:          server,
 		sshServer:       sshServer,
 		poller:          poller,
+		runnerHeartbeat: runnerHeartbeat,
 		resolverManager: resolverManager,
 		services:        services,
+		git:             git,
 	}
 }
@@ -0,0 +1,66 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/space"
+	"github.com/harness/gitness/cli/provide"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type createCommand struct {
+	in   space.CreateInput
+	json bool
+}
+
+func (c *createCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	out, err := provide.Client().SpaceCreate(ctx, &c.in)
+	if err != nil {
+		return err
+	}
+
+	return printSpaces([]space.SpaceOutput{*out}, c.json)
+}
+
+// helper function registers the space create command.
+func registerCreate(app *kingpin.CmdClause) {
+	c := &createCommand{}
+
+	cmd := app.Command("create", "create a space").
+		Action(c.run)
+
+	cmd.Arg("identifier", "identifier of the space").
+		Required().
+		StringVar(&c.in.Identifier)
+
+	cmd.Flag("parent", "path of the parent space").
+		StringVar(&c.in.ParentRef)
+
+	cmd.Flag("description", "description of the space").
+		StringVar(&c.in.Description)
+
+	cmd.Flag("public", "make the space public").
+		BoolVar(&c.in.IsPublic)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/space"
+	"github.com/harness/gitness/cli/flags"
+	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/client"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type listCommand struct {
+	parent string
+	opts   client.ListOptions
+	json   bool
+}
+
+func (c *listCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	if c.parent != "" {
+		spaces, err := provide.Client().SpaceSpaces(ctx, c.parent, c.opts)
+		if err != nil {
+			return err
+		}
+		return printSpaces(spaces, c.json)
+	}
+
+	// without a parent, list the spaces the user is a member of.
+	memberships, err := provide.Client().UserMemberships(ctx, c.opts)
+	if err != nil {
+		return err
+	}
+
+	spaces := make([]space.SpaceOutput, len(memberships))
+	for i, m := range memberships {
+		spaces[i] = space.SpaceOutput{Space: m.Space}
+	}
+
+	return printSpaces(spaces, c.json)
+}
+
+// helper function registers the space list command.
+func registerList(app *kingpin.CmdClause) {
+	c := &listCommand{}
+
+	cmd := app.Command("ls", "display a list of spaces").
+		Action(c.run)
+
+	cmd.Arg("parent", "path of the parent space, lists the spaces of the user if omitted").
+		StringVar(&c.parent)
+
+	flags.RegisterPagination(cmd, &c.opts)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+}
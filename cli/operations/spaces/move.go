@@ -0,0 +1,62 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/space"
+	"github.com/harness/gitness/cli/provide"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type moveCommand struct {
+	space      string
+	identifier string
+	json       bool
+}
+
+func (c *moveCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	out, err := provide.Client().SpaceMove(ctx, c.space, &space.MoveInput{Identifier: &c.identifier})
+	if err != nil {
+		return err
+	}
+
+	return printSpaces([]space.SpaceOutput{*out}, c.json)
+}
+
+// helper function registers the space move command.
+func registerMove(app *kingpin.CmdClause) {
+	c := &moveCommand{}
+
+	cmd := app.Command("move", "change the identifier of a space").
+		Action(c.run)
+
+	cmd.Arg("space", "path of the space").
+		Required().
+		StringVar(&c.space)
+
+	cmd.Arg("identifier", "new identifier of the space").
+		Required().
+		StringVar(&c.identifier)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+}
@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spaces
+
+import (
+	"github.com/harness/gitness/app/api/controller/space"
+	"github.com/harness/gitness/cli/textui"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// Register the command.
+func Register(app *kingpin.Application) {
+	cmd := app.Command("spaces", "manage spaces")
+	registerCreate(cmd)
+	registerList(cmd)
+	registerMove(cmd)
+}
+
+// helper function prints the spaces either as json or as a table.
+func printSpaces(spaces []space.SpaceOutput, asJSON bool) error {
+	if asJSON {
+		return textui.JSON(spaces)
+	}
+
+	rows := make([][]string, len(spaces))
+	for i, s := range spaces {
+		rows[i] = []string{s.Path, s.Description}
+	}
+
+	return textui.Table([]string{"PATH", "DESCRIPTION"}, rows)
+}
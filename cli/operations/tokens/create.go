@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokens
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/cli/textui"
+	"github.com/harness/gitness/types"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type createCommand struct {
+	identifier string
+	lifetime   time.Duration
+	json       bool
+}
+
+func (c *createCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	in := user.CreateTokenInput{
+		Identifier: c.identifier,
+	}
+	if c.lifetime > 0 {
+		in.Lifetime = &c.lifetime
+	}
+
+	out, err := provide.Client().UserCreatePAT(ctx, in)
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return textui.JSON(out)
+	}
+
+	if err = printTokens([]types.Token{out.Token}, false); err != nil {
+		return err
+	}
+
+	return textui.Table([]string{"TOKEN"}, [][]string{{out.AccessToken}})
+}
+
+// helper function registers the token create command.
+func registerCreate(app *kingpin.CmdClause) {
+	c := &createCommand{}
+
+	cmd := app.Command("create", "create a personal access token").
+		Action(c.run)
+
+	cmd.Arg("identifier", "identifier of the token").
+		Required().
+		StringVar(&c.identifier)
+
+	cmd.Flag("lifetime", "lifetime of the token (e.g. 720h), the token doesn't expire if omitted").
+		DurationVar(&c.lifetime)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+}
@@ -0,0 +1,52 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokens
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/harness/gitness/cli/textui"
+	"github.com/harness/gitness/types"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// Register the command.
+func Register(app *kingpin.Application) {
+	cmd := app.Command("tokens", "manage personal access tokens of the currently logged-in user")
+	registerCreate(cmd)
+	registerList(cmd)
+	registerDelete(cmd)
+}
+
+// helper function prints the tokens either as json or as a table.
+func printTokens(tokens []types.Token, asJSON bool) error {
+	if asJSON {
+		return textui.JSON(tokens)
+	}
+
+	rows := make([][]string, len(tokens))
+	for i, t := range tokens {
+		expiresAt := "never"
+		if t.ExpiresAt != nil {
+			expiresAt = time.UnixMilli(*t.ExpiresAt).Format(time.RFC3339)
+		}
+		rows[i] = []string{t.Identifier, strconv.FormatInt(t.PrincipalID, 10),
+			time.UnixMilli(t.IssuedAt).Format(time.RFC3339), expiresAt}
+	}
+
+	return textui.Table([]string{"IDENTIFIER", "PRINCIPAL", "ISSUED", "EXPIRES"}, rows)
+}
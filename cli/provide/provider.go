@@ -29,6 +29,15 @@ import (
 
 const DefaultServerURI = "http://localhost:3000"
 
+const (
+	// EnvServerURI overrides the server url of the stored session.
+	EnvServerURI = "GITNESS_CLI_URI"
+
+	// EnvAccessToken provides the token used to authenticate with the server,
+	// removing the need of a stored session.
+	EnvAccessToken = "GITNESS_CLI_TOKEN" //#nosec G101
+)
+
 func NewSession() session.Session {
 	ss, err := newSession()
 	if err != nil {
@@ -50,7 +59,20 @@ func Session() session.Session {
 }
 
 func Client() client.Client {
-	return newClient(Session())
+	if token := os.Getenv(EnvAccessToken); token != "" {
+		uri := os.Getenv(EnvServerURI)
+		if uri == "" {
+			uri = DefaultServerURI
+		}
+		return newClient(session.Session{URI: uri, AccessToken: token})
+	}
+
+	ss := Session()
+	if uri := os.Getenv(EnvServerURI); uri != "" {
+		ss = ss.SetURI(uri)
+	}
+
+	return newClient(ss)
 }
 
 func OpenClient(uri string) client.Client {
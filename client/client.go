@@ -23,6 +23,9 @@ import (
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/harness/gitness/app/api/controller/user"
 	"github.com/harness/gitness/types"
@@ -31,16 +34,30 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+const (
+	// defaultMaxRetries is the default number of times a request is retried
+	// when the server responds with 429 Too Many Requests or 503 Service Unavailable.
+	defaultMaxRetries = 3
+
+	// retryBaseDelay is the delay before the first retry in case the server
+	// didn't provide a Retry-After header. It doubles with every further attempt.
+	retryBaseDelay = 500 * time.Millisecond
+
+	// retryMaxDelay caps the delay between two retries.
+	retryMaxDelay = 30 * time.Second
+)
+
 // ensure HTTPClient implements Client interface.
 var _ Client = (*HTTPClient)(nil)
 
 // HTTPClient provides an HTTP client for interacting
 // with the remote API.
 type HTTPClient struct {
-	client *http.Client
-	base   string
-	token  string
-	debug  bool
+	client     *http.Client
+	base       string
+	token      string
+	debug      bool
+	maxRetries int
 }
 
 // New returns a client at the specified url.
@@ -51,7 +68,7 @@ func New(uri string) *HTTPClient {
 // NewToken returns a client at the specified url that
 // authenticates all outbound requests with the given token.
 func NewToken(uri, token string) *HTTPClient {
-	return &HTTPClient{http.DefaultClient, uri, token, false}
+	return &HTTPClient{http.DefaultClient, strings.TrimSuffix(uri, "/"), token, false, defaultMaxRetries}
 }
 
 // SetClient sets the default http client. This can be
@@ -68,6 +85,13 @@ func (c *HTTPClient) SetDebug(debug bool) {
 	c.debug = debug
 }
 
+// SetMaxRetries sets the number of times a request is retried when
+// the server is rate limiting or temporarily unavailable.
+// A value of zero disables retries.
+func (c *HTTPClient) SetMaxRetries(maxRetries int) {
+	c.maxRetries = maxRetries
+}
+
 // Login authenticates the user and returns a JWT token.
 func (c *HTTPClient) Login(ctx context.Context, input *user.LoginInput) (*types.TokenResponse, error) {
 	out := new(types.TokenResponse)
@@ -104,6 +128,26 @@ func (c *HTTPClient) UserCreatePAT(ctx context.Context, in user.CreateTokenInput
 	return out, err
 }
 
+// UserMemberships returns the spaces the user is a member of.
+func (c *HTTPClient) UserMemberships(ctx context.Context, opts ListOptions) ([]types.MembershipSpace, error) {
+	uri := fmt.Sprintf("%s/api/v1/user/memberships", c.base)
+	return list[types.MembershipSpace](ctx, c, uri, opts)
+}
+
+// UserTokens returns all PATs of the user.
+func (c *HTTPClient) UserTokens(ctx context.Context) ([]types.Token, error) {
+	out := []types.Token{}
+	uri := fmt.Sprintf("%s/api/v1/user/tokens", c.base)
+	err := c.get(ctx, uri, &out)
+	return out, err
+}
+
+// UserTokenDelete deletes a PAT of the user.
+func (c *HTTPClient) UserTokenDelete(ctx context.Context, identifier string) error {
+	uri := fmt.Sprintf("%s/api/v1/user/tokens/%s", c.base, url.PathEscape(identifier))
+	return c.delete(ctx, uri)
+}
+
 // User returns a user by ID or email.
 func (c *HTTPClient) User(ctx context.Context, key string) (*types.User, error) {
 	out := new(types.User)
@@ -189,9 +233,94 @@ func (c *HTTPClient) do(ctx context.Context, rawurl, method string, noToken bool
 	return nil
 }
 
+// helper function for making an http GET request to a list endpoint.
+func list[T any](ctx context.Context, c *HTTPClient, rawurl string, opts ListOptions) ([]T, error) {
+	return listFunc(ctx, c, rawurl, opts, func(body io.Reader) ([]T, error) {
+		var page []T
+		err := json.NewDecoder(body).Decode(&page)
+		return page, err
+	})
+}
+
+// helper function for making an http GET request to a list endpoint
+// whose items have to be extracted from the response body by decode.
+// If all pages are requested, the "next" links of the responses are followed until the last page is read.
+func listFunc[T any](ctx context.Context, c *HTTPClient, rawurl string, opts ListOptions,
+	decode func(body io.Reader) ([]T, error)) ([]T, error) {
+	rawurl, err := opts.apply(rawurl)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []T{}
+	for rawurl != "" {
+		resp, err := c.send(ctx, rawurl, http.MethodGet, false, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		page, err := decode(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, page...)
+
+		if !opts.All {
+			break
+		}
+
+		rawurl, err = c.nextPage(rawurl, resp.Header)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+// nextPage returns the absolute url of the next page referenced by the Link header,
+// or an empty string in case there is no next page.
+func (c *HTTPClient) nextPage(rawurl string, header http.Header) (string, error) {
+	for _, link := range header.Values("Link") {
+		for _, value := range strings.Split(link, ",") {
+			uri, params, ok := strings.Cut(strings.TrimSpace(value), ";")
+			if !ok || !strings.Contains(params, `rel="next"`) {
+				continue
+			}
+
+			current, err := url.Parse(rawurl)
+			if err != nil {
+				return "", err
+			}
+
+			next, err := current.Parse(strings.Trim(strings.TrimSpace(uri), "<>"))
+			if err != nil {
+				return "", fmt.Errorf("failed to parse next page link: %w", err)
+			}
+
+			return next.String(), nil
+		}
+	}
+
+	return "", nil
+}
+
 // helper function to stream a http request.
 func (c *HTTPClient) stream(ctx context.Context, rawurl, method string, noToken bool,
 	in, _ interface{}) (io.ReadCloser, error) {
+	resp, err := c.send(ctx, rawurl, method, noToken, in)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// helper function to send a http request. Requests that are rejected with
+// 429 Too Many Requests or 503 Service Unavailable are retried, honoring the Retry-After header.
+func (c *HTTPClient) send(ctx context.Context, rawurl, method string, noToken bool,
+	in interface{}) (*http.Response, error) {
 	uri, err := url.Parse(rawurl)
 	if err != nil {
 		return nil, err
@@ -199,52 +328,113 @@ func (c *HTTPClient) stream(ctx context.Context, rawurl, method string, noToken
 
 	// if we are posting or putting data, we need to
 	// write it to the body of the request.
-	var buf io.ReadWriter
+	var payload []byte
 	if in != nil {
-		buf = &bytes.Buffer{}
+		buf := &bytes.Buffer{}
 		if err = json.NewEncoder(buf).Encode(in); err != nil {
 			return nil, err
 		}
+		payload = buf.Bytes()
 	}
 
-	// creates a new http request.
-	req, err := http.NewRequestWithContext(ctx, method, uri.String(), buf)
-	if err != nil {
-		return nil, err
+	for attempt := 0; ; attempt++ {
+		// the body has to be recreated for every attempt as it's consumed by the previous one.
+		var body io.Reader
+		if in != nil {
+			body = bytes.NewReader(payload)
+		}
+
+		// creates a new http request.
+		var req *http.Request
+		req, err = http.NewRequestWithContext(ctx, method, uri.String(), body)
+		if err != nil {
+			return nil, err
+		}
+		if in != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		if !noToken && c.token != "" {
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		}
+		if _, ok := in.(*url.Values); ok {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+
+		// include the client version information in the
+		// http accept header for debugging purposes.
+		req.Header.Set("Accept", "application/json;version="+version.Version.String())
+
+		// send the http request.
+		var resp *http.Response
+		resp, err = c.client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if c.debug {
+			dump, _ := httputil.DumpResponse(resp, true)
+			log.Debug().Msgf("method %s, url %s", method, rawurl)
+			log.Debug().Msg(string(dump))
+		}
+
+		if attempt < c.maxRetries && isRetryable(resp.StatusCode) {
+			delay := retryDelay(resp.Header.Get("Retry-After"), attempt)
+			_ = resp.Body.Close()
+
+			if err = sleep(ctx, delay); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if resp.StatusCode >= http.StatusMultipleChoices {
+			defer func(Body io.ReadCloser) {
+				_ = Body.Close()
+			}(resp.Body)
+			err = &remoteError{Status: resp.StatusCode}
+			if decodeErr := json.NewDecoder(resp.Body).Decode(err); decodeErr != nil {
+				return nil, decodeErr
+			}
+			return nil, err
+		}
+		return resp, nil
 	}
-	if in != nil {
-		req.Header.Set("Content-Type", "application/json")
+}
+
+// isRetryable returns true if a request that failed with the provided status code can be retried.
+func isRetryable(status int) bool {
+	return status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable
+}
+
+// retryDelay returns how long to wait before the next attempt.
+// The Retry-After header can either contain the delay in seconds or an http date.
+func retryDelay(retryAfter string, attempt int) time.Duration {
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(retryAfter); err == nil {
+		delay = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(retryAfter); err == nil {
+		delay = time.Until(date)
+	} else {
+		delay = retryBaseDelay << attempt
 	}
-	if !noToken && c.token != "" {
-		req.Header.Set("Authorization", "Bearer "+c.token)
+
+	if delay < 0 {
+		return 0
 	}
-	if _, ok := in.(*url.Values); ok {
-		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if delay > retryMaxDelay {
+		return retryMaxDelay
 	}
+	return delay
+}
 
-	// include the client version information in the
-	// http accept header for debugging purposes.
-	req.Header.Set("Accept", "application/json;version="+version.Version.String())
+// sleep waits for the provided duration or until the context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
 
-	// send the http request.
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, err
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
 	}
-	if c.debug {
-		dump, _ := httputil.DumpResponse(resp, true)
-		log.Debug().Msgf("method %s, url %s", method, rawurl)
-		log.Debug().Msg(string(dump))
-	}
-	if resp.StatusCode >= http.StatusMultipleChoices {
-		defer func(Body io.ReadCloser) {
-			_ = Body.Close()
-		}(resp.Body)
-		err = &remoteError{}
-		if decodeErr := json.NewDecoder(resp.Body).Decode(err); decodeErr != nil {
-			return nil, decodeErr
-		}
-		return nil, err
-	}
-	return resp.Body, nil
 }
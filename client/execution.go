@@ -0,0 +1,48 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/harness/gitness/types"
+)
+
+//
+// Execution Endpoints
+//
+
+// Executions returns all executions of a pipeline.
+func (c *HTTPClient) Executions(ctx context.Context, repoRef, pipeline string) ([]types.Execution, error) {
+	uri := fmt.Sprintf("%s/api/v1/repos/%s/pipelines/%s/executions",
+		c.base, escapeRef(repoRef), url.PathEscape(pipeline))
+	return list[types.Execution](ctx, c, uri, allPages)
+}
+
+// Execution returns an execution of a pipeline by its number.
+func (c *HTTPClient) Execution(
+	ctx context.Context,
+	repoRef string,
+	pipeline string,
+	number int64,
+) (*types.Execution, error) {
+	out := new(types.Execution)
+	uri := fmt.Sprintf("%s/api/v1/repos/%s/pipelines/%s/executions/%d",
+		c.base, escapeRef(repoRef), url.PathEscape(pipeline), number)
+	err := c.get(ctx, uri, out)
+	return out, err
+}
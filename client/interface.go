@@ -16,9 +16,19 @@ package client
 
 import (
 	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/controller/space"
 	"github.com/harness/gitness/app/api/controller/user"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 // Client to access the remote APIs.
@@ -49,11 +59,135 @@ type Client interface {
 
 	// UserCreatePAT creates a new PAT for the user.
 	UserCreatePAT(ctx context.Context, in user.CreateTokenInput) (*types.TokenResponse, error)
+
+	// UserMemberships returns the spaces the user is a member of.
+	UserMemberships(ctx context.Context, opts ListOptions) ([]types.MembershipSpace, error)
+
+	// UserTokens returns all PATs of the user.
+	UserTokens(ctx context.Context) ([]types.Token, error)
+
+	// UserTokenDelete deletes a PAT of the user.
+	UserTokenDelete(ctx context.Context, identifier string) error
+
+	// SpaceCreate creates a new space.
+	SpaceCreate(ctx context.Context, input *space.CreateInput) (*space.SpaceOutput, error)
+
+	// Space returns a space by path or ID.
+	Space(ctx context.Context, spaceRef string) (*space.SpaceOutput, error)
+
+	// SpaceMove moves (renames) a space.
+	SpaceMove(ctx context.Context, spaceRef string, input *space.MoveInput) (*space.SpaceOutput, error)
+
+	// SpaceSpaces returns the child spaces of a space.
+	SpaceSpaces(ctx context.Context, spaceRef string, opts ListOptions) ([]space.SpaceOutput, error)
+
+	// SpaceRepos returns the repositories of a space.
+	SpaceRepos(ctx context.Context, spaceRef string, opts ListOptions) ([]repo.RepositoryOutput, error)
+
+	// RepoCreate creates a new repository.
+	RepoCreate(ctx context.Context, input *repo.CreateInput) (*repo.RepositoryOutput, error)
+
+	// Repo returns a repository by path or ID.
+	Repo(ctx context.Context, repoRef string) (*repo.RepositoryOutput, error)
+
+	// RepoImport imports a repository from a remote provider.
+	RepoImport(ctx context.Context, input *repo.ImportInput) (*repo.RepositoryOutput, error)
+
+	// RepoDelete soft deletes a repository.
+	RepoDelete(ctx context.Context, repoRef string) error
+
+	// RepoArchive writes an archive of the repository at the provided git reference to w.
+	RepoArchive(ctx context.Context, repoRef, gitRef, format string, w io.Writer) error
+
+	// Content returns the content of a path in the repository at the provided git reference.
+	Content(ctx context.Context, repoRef, gitRef, path string) (*ContentOutput, error)
+
+	// CommitFiles commits the provided file actions to the repository.
+	CommitFiles(ctx context.Context, repoRef string, input *repo.CommitFilesOptions) (*types.CommitFilesResponse, error)
+
+	// Commits returns all commits reachable from the provided git reference.
+	Commits(ctx context.Context, repoRef, gitRef string) ([]types.Commit, error)
+
+	// Branches returns all branches of the repository.
+	Branches(ctx context.Context, repoRef string) ([]repo.Branch, error)
+
+	// BranchCreate creates a new branch in the repository.
+	BranchCreate(ctx context.Context, repoRef string, input *repo.CreateBranchInput) (*repo.Branch, error)
+
+	// BranchDelete deletes a branch of the repository.
+	BranchDelete(ctx context.Context, repoRef, branch string) error
+
+	// Tags returns all tags of the repository.
+	Tags(ctx context.Context, repoRef string) ([]repo.CommitTag, error)
+
+	// TagCreate creates a new tag in the repository.
+	TagCreate(ctx context.Context, repoRef string, input *repo.CreateCommitTagInput) (*repo.CommitTag, error)
+
+	// TagDelete deletes a tag of the repository.
+	TagDelete(ctx context.Context, repoRef, tag string) error
+
+	// PullReqCreate creates a new pull request.
+	PullReqCreate(ctx context.Context, repoRef string, input *pullreq.CreateInput) (*types.PullReq, error)
+
+	// PullReq returns a pull request by its number.
+	PullReq(ctx context.Context, repoRef string, number int64) (*types.PullReq, error)
+
+	// PullReqs returns all pull requests of the repository that are in one of the provided states.
+	PullReqs(ctx context.Context, repoRef string, states ...enum.PullReqState) ([]types.PullReq, error)
+
+	// PullReqMerge merges a pull request.
+	PullReqMerge(
+		ctx context.Context,
+		repoRef string,
+		number int64,
+		input *pullreq.MergeInput,
+	) (*types.MergeResponse, error)
+
+	// Executions returns all executions of a pipeline.
+	Executions(ctx context.Context, repoRef, pipeline string) ([]types.Execution, error)
+
+	// Execution returns an execution of a pipeline by its number.
+	Execution(ctx context.Context, repoRef, pipeline string, number int64) (*types.Execution, error)
+}
+
+// ListOptions specifies which page of a list endpoint is requested.
+type ListOptions struct {
+	// Page is the page to start with. If zero, the server default is used.
+	Page int
+
+	// Limit is the number of items per page. If zero, the server default is used.
+	Limit int
+
+	// All requests all pages starting with Page until the list is exhausted.
+	All bool
+}
+
+// allPages requests all pages of a list endpoint using the server defaults.
+var allPages = ListOptions{All: true}
+
+// apply adds the pagination query parameters to the url.
+func (o ListOptions) apply(rawurl string) (string, error) {
+	uri, err := url.Parse(rawurl)
+	if err != nil {
+		return "", err
+	}
+
+	params := uri.Query()
+	if o.Page > 0 {
+		params.Set("page", strconv.Itoa(o.Page))
+	}
+	if o.Limit > 0 {
+		params.Set("limit", strconv.Itoa(o.Limit))
+	}
+	uri.RawQuery = params.Encode()
+
+	return uri.String(), nil
 }
 
 // remoteError store the error payload returned
 // fro the remote API.
 type remoteError struct {
+	Status  int    `json:"-"`
 	Message string `json:"message"`
 }
 
@@ -61,3 +195,19 @@ type remoteError struct {
 func (e *remoteError) Error() string {
 	return e.Message
 }
+
+// ErrorCode returns the error code of an error returned by the remote API
+// (e.g. "not_found" for 404 Not Found). It returns false if err isn't an error of the remote API.
+func ErrorCode(err error) (string, bool) {
+	var rErr *remoteError
+	if !errors.As(err, &rErr) {
+		return "", false
+	}
+
+	code := strings.ToLower(strings.ReplaceAll(http.StatusText(rErr.Status), " ", "_"))
+	if code == "" {
+		code = strconv.Itoa(rErr.Status)
+	}
+
+	return code, true
+}
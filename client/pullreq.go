@@ -0,0 +1,76 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+//
+// Pull Request Endpoints
+//
+
+// PullReqCreate creates a new pull request.
+func (c *HTTPClient) PullReqCreate(
+	ctx context.Context,
+	repoRef string,
+	input *pullreq.CreateInput,
+) (*types.PullReq, error) {
+	out := new(types.PullReq)
+	uri := fmt.Sprintf("%s/api/v1/repos/%s/pullreq", c.base, escapeRef(repoRef))
+	err := c.post(ctx, uri, false, input, out)
+	return out, err
+}
+
+// PullReq returns a pull request by its number.
+func (c *HTTPClient) PullReq(ctx context.Context, repoRef string, number int64) (*types.PullReq, error) {
+	out := new(types.PullReq)
+	uri := fmt.Sprintf("%s/api/v1/repos/%s/pullreq/%d", c.base, escapeRef(repoRef), number)
+	err := c.get(ctx, uri, out)
+	return out, err
+}
+
+// PullReqs returns all pull requests of the repository that are in one of the provided states.
+func (c *HTTPClient) PullReqs(
+	ctx context.Context,
+	repoRef string,
+	states ...enum.PullReqState,
+) ([]types.PullReq, error) {
+	params := url.Values{}
+	for _, state := range states {
+		params.Add("state", string(state))
+	}
+	uri := fmt.Sprintf("%s/api/v1/repos/%s/pullreq?%s", c.base, escapeRef(repoRef), params.Encode())
+	return list[types.PullReq](ctx, c, uri, allPages)
+}
+
+// PullReqMerge merges a pull request.
+func (c *HTTPClient) PullReqMerge(
+	ctx context.Context,
+	repoRef string,
+	number int64,
+	input *pullreq.MergeInput,
+) (*types.MergeResponse, error) {
+	out := new(types.MergeResponse)
+	uri := fmt.Sprintf("%s/api/v1/repos/%s/pullreq/%d/merge", c.base, escapeRef(repoRef), number)
+	err := c.post(ctx, uri, false, input, out)
+	return out, err
+}
@@ -0,0 +1,196 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/types"
+)
+
+// ContentOutput is the client side representation of repo.GetContentOutput.
+// The content is kept in its raw form as its structure depends on the content type.
+type ContentOutput struct {
+	repo.ContentInfo
+	Content json.RawMessage `json:"content"`
+}
+
+// File returns the content of a file.
+func (o *ContentOutput) File() (*repo.FileContent, error) {
+	return decodeContent[repo.FileContent](o, repo.ContentTypeFile)
+}
+
+// Dir returns the content of a directory.
+func (o *ContentOutput) Dir() (*repo.DirContent, error) {
+	return decodeContent[repo.DirContent](o, repo.ContentTypeDir)
+}
+
+func decodeContent[T any](o *ContentOutput, contentType repo.ContentType) (*T, error) {
+	if o.Type != contentType {
+		return nil, fmt.Errorf("content is of type %q, not %q", o.Type, contentType)
+	}
+
+	out := new(T)
+	if err := json.Unmarshal(o.Content, out); err != nil {
+		return nil, fmt.Errorf("failed to decode %s content: %w", contentType, err)
+	}
+	return out, nil
+}
+
+//
+// Repository Endpoints
+//
+
+// RepoCreate creates a new repository.
+func (c *HTTPClient) RepoCreate(ctx context.Context, input *repo.CreateInput) (*repo.RepositoryOutput, error) {
+	out := new(repo.RepositoryOutput)
+	uri := fmt.Sprintf("%s/api/v1/repos", c.base)
+	err := c.post(ctx, uri, false, input, out)
+	return out, err
+}
+
+// Repo returns a repository by path or ID.
+func (c *HTTPClient) Repo(ctx context.Context, repoRef string) (*repo.RepositoryOutput, error) {
+	out := new(repo.RepositoryOutput)
+	uri := fmt.Sprintf("%s/api/v1/repos/%s", c.base, escapeRef(repoRef))
+	err := c.get(ctx, uri, out)
+	return out, err
+}
+
+// RepoImport imports a repository from a remote provider.
+func (c *HTTPClient) RepoImport(ctx context.Context, input *repo.ImportInput) (*repo.RepositoryOutput, error) {
+	out := new(repo.RepositoryOutput)
+	uri := fmt.Sprintf("%s/api/v1/repos/import", c.base)
+	err := c.post(ctx, uri, false, input, out)
+	return out, err
+}
+
+// RepoDelete soft deletes a repository.
+func (c *HTTPClient) RepoDelete(ctx context.Context, repoRef string) error {
+	uri := fmt.Sprintf("%s/api/v1/repos/%s", c.base, escapeRef(repoRef))
+	return c.delete(ctx, uri)
+}
+
+// RepoArchive writes an archive of the repository at the provided git reference to w.
+// The format is one of the archive formats supported by the server (e.g. "zip" or "tar.gz").
+func (c *HTTPClient) RepoArchive(ctx context.Context, repoRef, gitRef, format string, w io.Writer) error {
+	uri := fmt.Sprintf("%s/api/v1/repos/%s/archive/%s.%s", c.base, escapeRef(repoRef), escapePath(gitRef), format)
+	body, err := c.stream(ctx, uri, http.MethodGet, false, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	_, err = io.Copy(w, body)
+	return err
+}
+
+// Content returns the content of a path in the repository at the provided git reference.
+// If no git reference is provided, the content is read from the default branch.
+func (c *HTTPClient) Content(ctx context.Context, repoRef, gitRef, path string) (*ContentOutput, error) {
+	out := new(ContentOutput)
+	uri := fmt.Sprintf("%s/api/v1/repos/%s/content/%s?git_ref=%s",
+		c.base, escapeRef(repoRef), escapePath(path), url.QueryEscape(gitRef))
+	err := c.get(ctx, uri, out)
+	return out, err
+}
+
+// CommitFiles commits the provided file actions to the repository.
+func (c *HTTPClient) CommitFiles(
+	ctx context.Context,
+	repoRef string,
+	input *repo.CommitFilesOptions,
+) (*types.CommitFilesResponse, error) {
+	out := new(types.CommitFilesResponse)
+	uri := fmt.Sprintf("%s/api/v1/repos/%s/commits", c.base, escapeRef(repoRef))
+	err := c.post(ctx, uri, false, input, out)
+	return out, err
+}
+
+// Commits returns all commits reachable from the provided git reference.
+// If no git reference is provided, the commits of the default branch are returned.
+func (c *HTTPClient) Commits(ctx context.Context, repoRef, gitRef string) ([]types.Commit, error) {
+	uri := fmt.Sprintf("%s/api/v1/repos/%s/commits?git_ref=%s",
+		c.base, escapeRef(repoRef), url.QueryEscape(gitRef))
+	return listFunc(ctx, c, uri, allPages, func(body io.Reader) ([]types.Commit, error) {
+		out := types.ListCommitResponse{}
+		err := json.NewDecoder(body).Decode(&out)
+		return out.Commits, err
+	})
+}
+
+// Branches returns all branches of the repository.
+func (c *HTTPClient) Branches(ctx context.Context, repoRef string) ([]repo.Branch, error) {
+	uri := fmt.Sprintf("%s/api/v1/repos/%s/branches", c.base, escapeRef(repoRef))
+	return list[repo.Branch](ctx, c, uri, allPages)
+}
+
+// BranchCreate creates a new branch in the repository.
+func (c *HTTPClient) BranchCreate(
+	ctx context.Context,
+	repoRef string,
+	input *repo.CreateBranchInput,
+) (*repo.Branch, error) {
+	out := new(repo.Branch)
+	uri := fmt.Sprintf("%s/api/v1/repos/%s/branches", c.base, escapeRef(repoRef))
+	err := c.post(ctx, uri, false, input, out)
+	return out, err
+}
+
+// BranchDelete deletes a branch of the repository.
+func (c *HTTPClient) BranchDelete(ctx context.Context, repoRef, branch string) error {
+	uri := fmt.Sprintf("%s/api/v1/repos/%s/branches/%s", c.base, escapeRef(repoRef), escapePath(branch))
+	return c.delete(ctx, uri)
+}
+
+// Tags returns all tags of the repository.
+func (c *HTTPClient) Tags(ctx context.Context, repoRef string) ([]repo.CommitTag, error) {
+	uri := fmt.Sprintf("%s/api/v1/repos/%s/tags", c.base, escapeRef(repoRef))
+	return list[repo.CommitTag](ctx, c, uri, allPages)
+}
+
+// TagCreate creates a new tag in the repository.
+func (c *HTTPClient) TagCreate(
+	ctx context.Context,
+	repoRef string,
+	input *repo.CreateCommitTagInput,
+) (*repo.CommitTag, error) {
+	out := new(repo.CommitTag)
+	uri := fmt.Sprintf("%s/api/v1/repos/%s/tags", c.base, escapeRef(repoRef))
+	err := c.post(ctx, uri, false, input, out)
+	return out, err
+}
+
+// TagDelete deletes a tag of the repository.
+func (c *HTTPClient) TagDelete(ctx context.Context, repoRef, tag string) error {
+	uri := fmt.Sprintf("%s/api/v1/repos/%s/tags/%s", c.base, escapeRef(repoRef), escapePath(tag))
+	return c.delete(ctx, uri)
+}
+
+// escapeRef escapes a space or repository reference so it can be used as a single path segment.
+func escapeRef(ref string) string {
+	return url.PathEscape(ref)
+}
+
+// escapePath escapes a path while keeping its separators.
+func escapePath(path string) string {
+	return (&url.URL{Path: path}).EscapedPath()
+}
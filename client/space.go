@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/controller/space"
+)
+
+//
+// Space Endpoints
+//
+
+// SpaceCreate creates a new space.
+func (c *HTTPClient) SpaceCreate(ctx context.Context, input *space.CreateInput) (*space.SpaceOutput, error) {
+	out := new(space.SpaceOutput)
+	uri := fmt.Sprintf("%s/api/v1/spaces", c.base)
+	err := c.post(ctx, uri, false, input, out)
+	return out, err
+}
+
+// Space returns a space by path or ID.
+func (c *HTTPClient) Space(ctx context.Context, spaceRef string) (*space.SpaceOutput, error) {
+	out := new(space.SpaceOutput)
+	uri := fmt.Sprintf("%s/api/v1/spaces/%s", c.base, escapeRef(spaceRef))
+	err := c.get(ctx, uri, out)
+	return out, err
+}
+
+// SpaceMove moves (renames) a space.
+func (c *HTTPClient) SpaceMove(
+	ctx context.Context,
+	spaceRef string,
+	input *space.MoveInput,
+) (*space.SpaceOutput, error) {
+	out := new(space.SpaceOutput)
+	uri := fmt.Sprintf("%s/api/v1/spaces/%s/move", c.base, escapeRef(spaceRef))
+	err := c.post(ctx, uri, false, input, out)
+	return out, err
+}
+
+// SpaceSpaces returns the child spaces of a space.
+func (c *HTTPClient) SpaceSpaces(
+	ctx context.Context,
+	spaceRef string,
+	opts ListOptions,
+) ([]space.SpaceOutput, error) {
+	uri := fmt.Sprintf("%s/api/v1/spaces/%s/spaces", c.base, escapeRef(spaceRef))
+	return list[space.SpaceOutput](ctx, c, uri, opts)
+}
+
+// SpaceRepos returns the repositories of a space.
+func (c *HTTPClient) SpaceRepos(
+	ctx context.Context,
+	spaceRef string,
+	opts ListOptions,
+) ([]repo.RepositoryOutput, error) {
+	uri := fmt.Sprintf("%s/api/v1/spaces/%s/repos", c.base, escapeRef(spaceRef))
+	return list[repo.RepositoryOutput](ctx, c, uri, opts)
+}
@@ -0,0 +1,202 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build integration
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/controller/space"
+	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/cli/operations/server"
+	"github.com/harness/gitness/client"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	adminEmail    = "admin@example.com"
+	adminPassword = "integration-test-password"
+)
+
+// TestIntegrationCommitFlow runs the server in-process and uses the client to
+// create a repository, commit a file to it and read the commits back.
+func TestIntegrationCommitFlow(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	base := startServer(ctx, t)
+
+	token, err := client.New(base).Login(ctx, &user.LoginInput{
+		LoginIdentifier: adminEmail,
+		Password:        adminPassword,
+	})
+	if err != nil {
+		t.Fatalf("failed to login: %s", err)
+	}
+
+	c := client.NewToken(base, token.AccessToken)
+
+	if _, err = c.SpaceCreate(ctx, &space.CreateInput{Identifier: "integration"}); err != nil {
+		t.Fatalf("failed to create space: %s", err)
+	}
+
+	r, err := c.RepoCreate(ctx, &repo.CreateInput{
+		ParentRef:     "integration",
+		Identifier:    "repo",
+		DefaultBranch: "main",
+		Readme:        true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create repository: %s", err)
+	}
+
+	commit, err := c.CommitFiles(ctx, r.Path, &repo.CommitFilesOptions{
+		Title:  "add hello.txt",
+		Branch: "main",
+		Actions: []repo.CommitFileAction{{
+			Action:   git.CreateAction,
+			Path:     "docs/hello.txt",
+			Payload:  "hello world",
+			Encoding: enum.ContentEncodingTypeUTF8,
+		}},
+	})
+	if err != nil {
+		t.Fatalf("failed to commit file: %s", err)
+	}
+
+	commits, err := c.Commits(ctx, r.Path, "main")
+	if err != nil {
+		t.Fatalf("failed to list commits: %s", err)
+	}
+	if len(commits) != 2 {
+		t.Fatalf("expected 2 commits, got %d", len(commits))
+	}
+	if got := commits[0].SHA; got != commit.CommitID {
+		t.Errorf("expected latest commit %s, got %s", commit.CommitID, got)
+	}
+	if got := commits[0].Title; got != "add hello.txt" {
+		t.Errorf("expected latest commit title %q, got %q", "add hello.txt", got)
+	}
+
+	content, err := c.Content(ctx, r.Path, "main", "docs/hello.txt")
+	if err != nil {
+		t.Fatalf("failed to get content: %s", err)
+	}
+	file, err := content.File()
+	if err != nil {
+		t.Fatalf("failed to decode file content: %s", err)
+	}
+	if file.Size != int64(len("hello world")) {
+		t.Errorf("expected file size %d, got %d", len("hello world"), file.Size)
+	}
+}
+
+// startServer starts the server with an isolated configuration and
+// returns its base url once it's ready to accept requests.
+func startServer(ctx context.Context, t *testing.T) string {
+	t.Helper()
+
+	port, err := freePort()
+	if err != nil {
+		t.Fatalf("failed to find free port: %s", err)
+	}
+	base := fmt.Sprintf("http://127.0.0.1:%d", port)
+	dir := t.TempDir()
+
+	t.Setenv("HOME", dir)
+	t.Setenv("GITNESS_GIT_ROOT", filepath.Join(dir, "git"))
+	t.Setenv("GITNESS_DATABASE_DRIVER", "sqlite3")
+	t.Setenv("GITNESS_DATABASE_DATASOURCE", filepath.Join(dir, "database.sqlite3"))
+	t.Setenv("GITNESS_HTTP_HOST", "127.0.0.1")
+	t.Setenv("GITNESS_HTTP_PORT", fmt.Sprint(port))
+	t.Setenv("GITNESS_URL_BASE", base)
+	t.Setenv("GITNESS_METRIC_ENABLED", "false")
+	t.Setenv("GITNESS_PRINCIPAL_ADMIN_EMAIL", adminEmail)
+	t.Setenv("GITNESS_PRINCIPAL_ADMIN_PASSWORD", adminPassword)
+
+	config, err := server.LoadConfig()
+	if err != nil {
+		t.Fatalf("failed to load config: %s", err)
+	}
+
+	serverCtx, stop := context.WithCancel(ctx)
+	done := make(chan error, 1)
+	go func() {
+		done <- server.Serve(serverCtx, config, initSystem, false)
+	}()
+
+	t.Cleanup(func() {
+		stop()
+		if err := <-done; err != nil && !errors.Is(err, context.Canceled) {
+			t.Errorf("server failed: %s", err)
+		}
+	})
+
+	if err := waitForServer(ctx, base, done); err != nil {
+		t.Fatalf("server didn't become ready: %s", err)
+	}
+
+	return base
+}
+
+// waitForServer polls the health endpoint until the server responds successfully.
+func waitForServer(ctx context.Context, base string, done <-chan error) error {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, base+"/api/v1/system/health", nil)
+		if err != nil {
+			return err
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err == nil {
+			_ = resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-done:
+			return fmt.Errorf("server stopped: %w", err)
+		case <-ticker.C:
+		}
+	}
+}
+
+// freePort returns a tcp port that's currently not in use.
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
@@ -20,8 +20,11 @@ import (
 	"github.com/harness/gitness/cli/operations/account"
 	"github.com/harness/gitness/cli/operations/hooks"
 	"github.com/harness/gitness/cli/operations/migrate"
+	"github.com/harness/gitness/cli/operations/repos"
 	"github.com/harness/gitness/cli/operations/server"
+	"github.com/harness/gitness/cli/operations/spaces"
 	"github.com/harness/gitness/cli/operations/swagger"
+	"github.com/harness/gitness/cli/operations/tokens"
 	"github.com/harness/gitness/cli/operations/user"
 	"github.com/harness/gitness/cli/operations/users"
 	"github.com/harness/gitness/version"
@@ -44,6 +47,10 @@ func main() {
 
 	user.Register(app)
 	users.Register(app)
+	tokens.Register(app)
+
+	spaces.Register(app)
+	repos.Register(app)
 
 	account.RegisterLogin(app)
 	account.RegisterRegister(app)
@@ -54,5 +61,5 @@ func main() {
 	swagger.Register(app, openapi.NewOpenAPIService())
 
 	kingpin.Version(version.Version.String())
-	kingpin.MustParse(app.Parse(args))
+	cli.MustParse(app.Parse(args))
 }
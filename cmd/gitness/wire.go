@@ -24,20 +24,27 @@ import (
 	"github.com/harness/gitness/app/api/controller/capabilities"
 	checkcontroller "github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/controller/connector"
+	controllerdigest "github.com/harness/gitness/app/api/controller/digest"
+	controllereventlog "github.com/harness/gitness/app/api/controller/eventlog"
 	"github.com/harness/gitness/app/api/controller/execution"
 	githookCtrl "github.com/harness/gitness/app/api/controller/githook"
 	gitspaceCtrl "github.com/harness/gitness/app/api/controller/gitspace"
+	controllerinbox "github.com/harness/gitness/app/api/controller/inbox"
 	infraproviderCtrl "github.com/harness/gitness/app/api/controller/infraprovider"
 	controllerkeywordsearch "github.com/harness/gitness/app/api/controller/keywordsearch"
 	"github.com/harness/gitness/app/api/controller/limiter"
 	controllerlogs "github.com/harness/gitness/app/api/controller/logs"
+	controllermail "github.com/harness/gitness/app/api/controller/mail"
 	"github.com/harness/gitness/app/api/controller/migrate"
+	controlleroperation "github.com/harness/gitness/app/api/controller/operation"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
 	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/controller/reposettings"
+	controllerrunner "github.com/harness/gitness/app/api/controller/runner"
+	"github.com/harness/gitness/app/api/controller/scim"
 	"github.com/harness/gitness/app/api/controller/secret"
 	"github.com/harness/gitness/app/api/controller/service"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
@@ -52,6 +59,7 @@ import (
 	"github.com/harness/gitness/app/api/openapi"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/auth/oidc"
 	"github.com/harness/gitness/app/bootstrap"
 	gitevents "github.com/harness/gitness/app/events/git"
 	gitspaceevents "github.com/harness/gitness/app/events/gitspace"
@@ -68,6 +76,7 @@ import (
 	containerUser "github.com/harness/gitness/app/gitspace/orchestrator/user"
 	"github.com/harness/gitness/app/gitspace/scm"
 	gitspacesecret "github.com/harness/gitness/app/gitspace/secret"
+	"github.com/harness/gitness/app/pipeline/approver"
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/converter"
@@ -77,34 +86,57 @@ import (
 	"github.com/harness/gitness/app/pipeline/runner"
 	"github.com/harness/gitness/app/pipeline/scheduler"
 	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/app/pipeline/validator"
 	"github.com/harness/gitness/app/router"
 	"github.com/harness/gitness/app/server"
 	"github.com/harness/gitness/app/services"
+	"github.com/harness/gitness/app/services/accesslog"
 	aiagentservice "github.com/harness/gitness/app/services/aiagent"
+	"github.com/harness/gitness/app/services/avatar"
 	capabilitiesservice "github.com/harness/gitness/app/services/capabilities"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/commitpolicy"
+	"github.com/harness/gitness/app/services/contributorstats"
+	"github.com/harness/gitness/app/services/counters"
+	"github.com/harness/gitness/app/services/digest"
 	"github.com/harness/gitness/app/services/exporter"
+	"github.com/harness/gitness/app/services/featureflag"
+	"github.com/harness/gitness/app/services/gitlimit"
 	"github.com/harness/gitness/app/services/gitspaceevent"
 	"github.com/harness/gitness/app/services/gitspaceservice"
+	"github.com/harness/gitness/app/services/idempotency"
 	"github.com/harness/gitness/app/services/importer"
+	"github.com/harness/gitness/app/services/inbox"
+	"github.com/harness/gitness/app/services/instancesettings"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/keywordsearch"
 	svclabel "github.com/harness/gitness/app/services/label"
 	locker "github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/loginprotection"
+	"github.com/harness/gitness/app/services/maintenance"
 	"github.com/harness/gitness/app/services/metric"
 	migrateservice "github.com/harness/gitness/app/services/migrate"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/app/services/operation"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/services/publickey"
 	pullreqservice "github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/recentvisit"
 	reposervice "github.com/harness/gitness/app/services/repo"
+	"github.com/harness/gitness/app/services/repoactivity"
+	"github.com/harness/gitness/app/services/repobulk"
+	"github.com/harness/gitness/app/services/repodocs"
+	"github.com/harness/gitness/app/services/repostate"
 	secretservice "github.com/harness/gitness/app/services/secret"
 	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/services/spacearchive"
+	"github.com/harness/gitness/app/services/spacefeed"
 	"github.com/harness/gitness/app/services/trigger"
+	"github.com/harness/gitness/app/services/usage"
 	usergroupservice "github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/app/sse"
@@ -158,6 +190,8 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		publicaccess.WireSet,
 		repo.WireSet,
 		reposettings.WireSet,
+		scim.WireSet,
+		controllerrunner.WireSet,
 		pullreq.WireSet,
 		controllerwebhook.WireSet,
 		svclabel.WireSet,
@@ -170,6 +204,8 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		system.WireSet,
 		authn.WireSet,
 		authz.WireSet,
+		cliserver.ProvideOIDCConfig,
+		oidc.WireSet,
 		infrastructure.WireSet,
 		infraproviderpkg.WireSet,
 		gitspaceevents.WireSet,
@@ -188,6 +224,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		encrypt.WireSet,
 		cliserver.ProvideEventsConfig,
 		events.WireSet,
+		cliserver.ProvideOutboundConfig,
 		cliserver.ProvideWebhookConfig,
 		cliserver.ProvideNotificationConfig,
 		webhook.WireSet,
@@ -204,6 +241,32 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		job.WireSet,
 		cliserver.ProvideCleanupConfig,
 		cleanup.WireSet,
+		cliserver.ProvideRepoActivityConfig,
+		repoactivity.WireSet,
+		repostate.WireSet,
+		cliserver.ProvideDigestConfig,
+		digest.WireSet,
+		cliserver.ProvideRecentVisitConfig,
+		cliserver.ProvideRepoAccessLogConfig,
+		recentvisit.WireSet,
+		cliserver.ProvideIdempotencyConfig,
+		idempotency.WireSet,
+		operation.WireSet,
+		controlleroperation.WireSet,
+		cliserver.ProvideLoginProtectionConfig,
+		loginprotection.WireSet,
+		cliserver.ProvideInboxConfig,
+		inbox.WireSet,
+		cliserver.ProvideSpaceFeedConfig,
+		spacefeed.WireSet,
+		cliserver.ProvideAvatarConfig,
+		avatar.WireSet,
+		cliserver.ProvideRepoDocsConfig,
+		repodocs.WireSet,
+		controllerdigest.WireSet,
+		controllerinbox.WireSet,
+		controllermail.WireSet,
+		controllereventlog.WireSet,
 		codecomments.WireSet,
 		protection.WireSet,
 		checkcontroller.WireSet,
@@ -219,6 +282,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		triggerer.WireSet,
 		file.WireSet,
 		converter.WireSet,
+		validator.WireSet,
 		runner.WireSet,
 		sse.WireSet,
 		scheduler.WireSet,
@@ -229,7 +293,13 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		importer.WireSet,
 		migrateservice.WireSet,
 		canceler.WireSet,
+		approver.WireSet,
 		exporter.WireSet,
+		spacearchive.WireSet,
+		repobulk.WireSet,
+		contributorstats.WireSet,
+		counters.WireSet,
+		usage.WireSet,
 		metric.WireSet,
 		reposervice.WireSet,
 		cliserver.ProvideCodeOwnerConfig,
@@ -239,6 +309,12 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		keywordsearch.WireSet,
 		controllerkeywordsearch.WireSet,
 		settings.WireSet,
+		commitpolicy.WireSet,
+		instancesettings.WireSet,
+		featureflag.WireSet,
+		gitlimit.WireSet,
+		accesslog.WireSet,
+		maintenance.WireSet,
 		usergroup.WireSet,
 		openapi.WireSet,
 		repo.ProvideRepoCheck,
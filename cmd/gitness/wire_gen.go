@@ -13,20 +13,27 @@ import (
 	capabilities2 "github.com/harness/gitness/app/api/controller/capabilities"
 	check2 "github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/controller/connector"
+	digest2 "github.com/harness/gitness/app/api/controller/digest"
+	"github.com/harness/gitness/app/api/controller/eventlog"
 	"github.com/harness/gitness/app/api/controller/execution"
 	"github.com/harness/gitness/app/api/controller/githook"
 	gitspace2 "github.com/harness/gitness/app/api/controller/gitspace"
+	inbox2 "github.com/harness/gitness/app/api/controller/inbox"
 	infraprovider3 "github.com/harness/gitness/app/api/controller/infraprovider"
 	keywordsearch2 "github.com/harness/gitness/app/api/controller/keywordsearch"
 	"github.com/harness/gitness/app/api/controller/limiter"
 	logs2 "github.com/harness/gitness/app/api/controller/logs"
+	"github.com/harness/gitness/app/api/controller/mail"
 	migrate2 "github.com/harness/gitness/app/api/controller/migrate"
+	operation2 "github.com/harness/gitness/app/api/controller/operation"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
 	pullreq2 "github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/controller/reposettings"
+	runner2 "github.com/harness/gitness/app/api/controller/runner"
+	"github.com/harness/gitness/app/api/controller/scim"
 	"github.com/harness/gitness/app/api/controller/secret"
 	"github.com/harness/gitness/app/api/controller/service"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
@@ -41,6 +48,7 @@ import (
 	"github.com/harness/gitness/app/api/openapi"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/auth/oidc"
 	"github.com/harness/gitness/app/bootstrap"
 	events6 "github.com/harness/gitness/app/events/git"
 	events7 "github.com/harness/gitness/app/events/gitspace"
@@ -57,6 +65,7 @@ import (
 	user2 "github.com/harness/gitness/app/gitspace/orchestrator/user"
 	"github.com/harness/gitness/app/gitspace/scm"
 	secret2 "github.com/harness/gitness/app/gitspace/secret"
+	"github.com/harness/gitness/app/pipeline/approver"
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/converter"
@@ -66,36 +75,59 @@ import (
 	"github.com/harness/gitness/app/pipeline/runner"
 	"github.com/harness/gitness/app/pipeline/scheduler"
 	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/app/pipeline/validator"
 	router2 "github.com/harness/gitness/app/router"
 	server2 "github.com/harness/gitness/app/server"
 	"github.com/harness/gitness/app/services"
+	"github.com/harness/gitness/app/services/accesslog"
 	"github.com/harness/gitness/app/services/aiagent"
+	"github.com/harness/gitness/app/services/avatar"
 	"github.com/harness/gitness/app/services/capabilities"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/commitpolicy"
+	"github.com/harness/gitness/app/services/contributorstats"
+	"github.com/harness/gitness/app/services/counters"
+	"github.com/harness/gitness/app/services/digest"
 	"github.com/harness/gitness/app/services/exporter"
+	"github.com/harness/gitness/app/services/featureflag"
+	"github.com/harness/gitness/app/services/gitlimit"
 	"github.com/harness/gitness/app/services/gitspace"
 	"github.com/harness/gitness/app/services/gitspaceevent"
 	"github.com/harness/gitness/app/services/gitspaceinfraevent"
+	"github.com/harness/gitness/app/services/idempotency"
 	"github.com/harness/gitness/app/services/importer"
+	"github.com/harness/gitness/app/services/inbox"
 	infraprovider2 "github.com/harness/gitness/app/services/infraprovider"
+	"github.com/harness/gitness/app/services/instancesettings"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/label"
 	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/loginprotection"
+	"github.com/harness/gitness/app/services/maintenance"
 	"github.com/harness/gitness/app/services/metric"
 	"github.com/harness/gitness/app/services/migrate"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/app/services/operation"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/recentvisit"
 	repo2 "github.com/harness/gitness/app/services/repo"
+	"github.com/harness/gitness/app/services/repoactivity"
+	"github.com/harness/gitness/app/services/repobulk"
+	"github.com/harness/gitness/app/services/repodocs"
+	"github.com/harness/gitness/app/services/repostate"
 	secret3 "github.com/harness/gitness/app/services/secret"
 	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/services/spacearchive"
+	"github.com/harness/gitness/app/services/spacefeed"
 	trigger2 "github.com/harness/gitness/app/services/trigger"
+	"github.com/harness/gitness/app/services/usage"
 	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/app/sse"
@@ -154,33 +186,108 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	permissionCache := authz.ProvidePermissionCache(spaceStore, membershipStore)
 	publicAccessStore := database.ProvidePublicAccessStore(db)
 	repoStore := database.ProvideRepoStore(db, spacePathCache, spacePathStore, spaceStore)
-	publicaccessService := publicaccess.ProvidePublicAccess(config, publicAccessStore, repoStore, spaceStore)
-	authorizer := authz.ProvideAuthorizer(permissionCache, spaceStore, publicaccessService)
+	settingsStore := database.ProvideSettingsStore(db)
+	settingsService := settings.ProvideService(settingsStore)
+	commitpolicyService := commitpolicy.ProvideService(settingsService, spaceStore)
+	universalClient, err := server.ProvideRedis(config)
+	if err != nil {
+		return nil, err
+	}
+	pubsubConfig := server.ProvidePubsubConfig(config)
+	pubSub := pubsub.ProvidePubSub(pubsubConfig, universalClient)
+	instancesettingsService, err := instancesettings.ProvideService(ctx, config, settingsService, pubSub)
+	if err != nil {
+		return nil, err
+	}
+	featureFlagStore := database.ProvideFeatureFlagStore(db)
+	featureflagService, err := featureflag.ProvideService(ctx, featureFlagStore, spacePathStore, spacePathTransformation, pubSub)
+	if err != nil {
+		return nil, err
+	}
+	maintenanceService := maintenance.ProvideService(featureflagService, instancesettingsService, spaceStore, repoStore)
+	gitOperationLimitStore := database.ProvideGitOperationLimitStore(db)
+	gitlimitService, err := gitlimit.ProvideService(ctx, gitOperationLimitStore, principalInfoCache, instancesettingsService, pubSub)
+	if err != nil {
+		return nil, err
+	}
+	accesslogConfig := server.ProvideRepoAccessLogConfig(config)
+	repoAccessLogStore := database.ProvideRepoAccessLogStore(db, principalInfoCache)
+	accesslogService, err := accesslog.ProvideService(accesslogConfig, repoAccessLogStore)
+	if err != nil {
+		return nil, err
+	}
+	publicaccessService := publicaccess.ProvidePublicAccess(config, instancesettingsService, publicAccessStore, repoStore, spaceStore)
+	authorizer := authz.ProvideAuthorizer(permissionCache, spaceStore, repoStore, publicaccessService)
 	principalUIDTransformation := store.ProvidePrincipalUIDTransformation()
 	principalStore := database.ProvidePrincipalStore(db, principalUIDTransformation)
 	tokenStore := database.ProvideTokenStore(db)
+	principalTokenCache := cache.ProvidePrincipalTokenCache(tokenStore)
 	publicKeyStore := database.ProvidePublicKeyStore(db)
-	controller := user.ProvideController(transactor, principalUID, authorizer, principalStore, tokenStore, membershipStore, publicKeyStore)
+	deployKeyStore := database.ProvideDeployKeyStore(db)
+	publickeyService := publickey.ProvidePublicKey(publicKeyStore, deployKeyStore, principalStore, principalInfoCache)
+	passwordResetStore := database.ProvidePasswordResetStore(db)
+	emailChangeStore := database.ProvideEmailChangeStore(db)
+	jobStore := database.ProvideJobStore(db)
+	executor := job.ProvideExecutor(jobStore, pubSub)
+	lockConfig := server.ProvideLockConfig(config)
+	mutexManager := lock.ProvideMutexManager(lockConfig, universalClient)
+	jobConfig := server.ProvideJobsConfig(config)
+	jobScheduler, err := job.ProvideScheduler(jobStore, executor, mutexManager, pubSub, jobConfig)
+	if err != nil {
+		return nil, err
+	}
+	transport := mailer.ProvideMailClient(config)
+	mailFailureStore := database.ProvideMailFailureStore(db)
+	mailerMailer, err := mailer.ProvideMailer(config, transport, jobScheduler, executor, mailFailureStore)
+	if err != nil {
+		return nil, err
+	}
+	userPreferenceStore := database.ProvideUserPreferenceStore(db)
+	recentVisitStore := database.ProvideRecentVisitStore(db)
+	recentvisitConfig := server.ProvideRecentVisitConfig(config)
+	recentvisitService, err := recentvisit.ProvideService(recentvisitConfig, recentVisitStore, repoStore, spaceStore, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	blobConfig, err := server.ProvideBlobStoreConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	blobStore, err := blob.ProvideStore(ctx, blobConfig)
+	if err != nil {
+		return nil, err
+	}
+	avatarConfig := server.ProvideAvatarConfig(config)
+	avatarStore := database.ProvideAvatarStore(db)
+	avatarService := avatar.ProvideService(avatarConfig, avatarStore, blobStore)
+	loginprotectionConfig := server.ProvideLoginProtectionConfig(config)
+	loginAttemptStore := database.ProvideLoginAttemptStore(db)
+	loginprotectionService, err := loginprotection.ProvideService(loginprotectionConfig, loginAttemptStore, instancesettingsService)
+	if err != nil {
+		return nil, err
+	}
+	oidcConfig := server.ProvideOIDCConfig(config)
+	oidcProvider, err := oidc.ProvideProvider(oidcConfig)
+	if err != nil {
+		return nil, err
+	}
+	userIdentityStore := database.ProvideUserIdentityStore(db)
+	installationStore := database.ProvideInstallationStore(db)
+	controller := user.ProvideController(config, transactor, principalUID, authorizer, principalStore, tokenStore, principalTokenCache, membershipStore, publicKeyStore, publickeyService, passwordResetStore, emailChangeStore, mailerMailer, userPreferenceStore, recentvisitService, avatarService, loginprotectionService, oidcProvider, userIdentityStore, installationStore)
 	serviceController := service.NewController(principalUID, authorizer, principalStore)
-	bootstrapBootstrap := bootstrap.ProvideBootstrap(config, controller, serviceController)
-	authenticator := authn.ProvideAuthenticator(config, principalStore, tokenStore)
+	bootstrapBootstrap := bootstrap.ProvideBootstrap(config, controller, serviceController, installationStore)
+	authenticator := authn.ProvideAuthenticator(config, principalStore, tokenStore, principalTokenCache, deployKeyStore)
 	provider, err := url.ProvideURLProvider(config)
 	if err != nil {
 		return nil, err
 	}
 	pipelineStore := database.ProvidePipelineStore(db)
 	ruleStore := database.ProvideRuleStore(db, principalInfoCache)
-	settingsStore := database.ProvideSettingsStore(db)
-	settingsService := settings.ProvideService(settingsStore)
 	protectionManager, err := protection.ProvideManager(ruleStore)
 	if err != nil {
 		return nil, err
 	}
 	typesConfig := server.ProvideGitConfig(config)
-	universalClient, err := server.ProvideRedis(config)
-	if err != nil {
-		return nil, err
-	}
 	cacheCache, err := api.ProvideLastCommitCache(typesConfig, universalClient)
 	if err != nil {
 		return nil, err
@@ -200,22 +307,21 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	jobStore := database.ProvideJobStore(db)
-	pubsubConfig := server.ProvidePubsubConfig(config)
-	pubSub := pubsub.ProvidePubSub(pubsubConfig, universalClient)
-	executor := job.ProvideExecutor(jobStore, pubSub)
-	lockConfig := server.ProvideLockConfig(config)
-	mutexManager := lock.ProvideMutexManager(lockConfig, universalClient)
-	jobConfig := server.ProvideJobsConfig(config)
-	jobScheduler, err := job.ProvideScheduler(jobStore, executor, mutexManager, pubSub, jobConfig)
-	if err != nil {
-		return nil, err
-	}
 	streamer := sse.ProvideEventsStreaming(pubSub)
 	localIndexSearcher := keywordsearch.ProvideLocalIndexSearcher()
 	indexer := keywordsearch.ProvideIndexer(localIndexSearcher)
 	auditService := audit.ProvideAuditService()
-	repository, err := importer.ProvideRepoImporter(config, provider, gitInterface, transactor, repoStore, pipelineStore, triggerStore, encrypter, jobScheduler, executor, streamer, indexer, publicaccessService, auditService)
+	repostateService := repostate.ProvideService(repoStore, auditService)
+	webhookStore := database.ProvideWebhookStore(db)
+	spacearchiveService, err := spacearchive.ProvideService(config, gitInterface, spaceStore, repoStore, webhookStore, principalStore, principalInfoCache, publicaccessService, blobStore, jobScheduler, executor)
+	if err != nil {
+		return nil, err
+	}
+	contributorstatsService, err := contributorstats.ProvideService(gitInterface, repoStore, jobScheduler, executor)
+	if err != nil {
+		return nil, err
+	}
+	repository, err := importer.ProvideRepoImporter(config, provider, gitInterface, transactor, repoStore, pipelineStore, triggerStore, encrypter, jobScheduler, executor, streamer, indexer, publicaccessService, auditService, repostateService, spacearchiveService)
 	if err != nil {
 		return nil, err
 	}
@@ -223,7 +329,8 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	usergroupResolver := usergroup.ProvideUserGroupResolver()
 	codeownersService := codeowners.ProvideCodeOwners(gitInterface, repoStore, codeownersConfig, principalStore, usergroupResolver)
 	eventsConfig := server.ProvideEventsConfig(config)
-	eventsSystem, err := events.ProvideSystem(eventsConfig, universalClient)
+	eventLogStore := database.ProvideEventLogStore(db)
+	eventsSystem, err := events.ProvideSystem(eventsConfig, universalClient, eventLogStore)
 	if err != nil {
 		return nil, err
 	}
@@ -235,6 +342,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
+	quotaWarner := limiter.ProvideQuotaWarner(resourceLimiter, config)
 	lockerLocker := locker.ProvideLocker(mutexManager)
 	repoIdentifier := check.ProvideRepoIdentifierCheck()
 	repoCheck := repo.ProvideRepoCheck()
@@ -243,25 +351,47 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	pullReqLabelAssignmentStore := database.ProvidePullReqLabelStore(db)
 	labelService := label.ProvideLabel(transactor, spaceStore, labelStore, labelValueStore, pullReqLabelAssignmentStore)
 	instrumentService := instrument.ProvideService()
-	repoController := repo.ProvideController(config, transactor, provider, authorizer, repoStore, spaceStore, pipelineStore, principalStore, ruleStore, settingsService, principalInfoCache, protectionManager, gitInterface, repository, codeownersService, reporter, indexer, resourceLimiter, lockerLocker, auditService, mutexManager, repoIdentifier, repoCheck, publicaccessService, labelService, instrumentService)
-	reposettingsController := reposettings.ProvideController(authorizer, repoStore, settingsService, auditService)
+	repoActivityStore := database.ProvideRepoActivityStore(db, principalInfoCache)
+	repoPushStore := database.ProvideRepoPushStore(db)
+	repoStatsStore := database.ProvideRepoStatsStore(db)
+	pullReqStore := database.ProvidePullReqStore(db, principalInfoCache)
+	repoStarStore := database.ProvideRepoStarStore(db)
+	repoTopicStore := database.ProvideRepoTopicStore(db)
+	spacePinnedRepoStore := database.ProvideSpacePinnedRepoStore(db)
+	readerFactory, err := events6.ProvideReaderFactory(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	repodocsConfig := server.ProvideRepoDocsConfig(config)
+	repodocsService, err := repodocs.ProvideService(ctx, repodocsConfig, gitInterface, repoStore, jobScheduler, executor, readerFactory)
+	if err != nil {
+		return nil, err
+	}
+	operationStore := database.ProvideOperationStore(db)
+	operationService := operation.ProvideService(operationStore, jobScheduler)
+	repoController := repo.ProvideController(config, transactor, provider, authorizer, repoStore, spaceStore, pipelineStore, principalStore, ruleStore, settingsService, principalInfoCache, protectionManager, gitInterface, repository, codeownersService, reporter, indexer, resourceLimiter, quotaWarner, lockerLocker, auditService, mutexManager, repoIdentifier, repoCheck, publicaccessService, labelService, instrumentService, repoActivityStore, deployKeyStore, publickeyService, repostateService, pullReqStore, repoStatsStore, contributorstatsService, recentvisitService, repoStarStore, repodocsService, repoTopicStore, operationService, maintenanceService, gitlimitService, repoAccessLogStore, accesslogService)
+	reposettingsController := reposettings.ProvideController(authorizer, repoStore, settingsService, commitpolicyService, auditService)
 	executionStore := database.ProvideExecutionStore(db)
 	checkStore := database.ProvideCheckStore(db, principalInfoCache)
 	stageStore := database.ProvideStageStore(db)
-	schedulerScheduler, err := scheduler.ProvideScheduler(stageStore, mutexManager)
+	runnerStore := database.ProvideRunnerStore(db)
+	schedulerScheduler, err := scheduler.ProvideScheduler(stageStore, executionStore, pipelineStore, repoStore, spaceStore, runnerStore, mutexManager, config)
 	if err != nil {
 		return nil, err
 	}
 	stepStore := database.ProvideStepStore(db)
-	cancelerCanceler := canceler.ProvideCanceler(executionStore, streamer, repoStore, schedulerScheduler, stageStore, stepStore)
+	reporter2, err := events4.ProvideReporter(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	cancelerCanceler := canceler.ProvideCanceler(executionStore, streamer, repoStore, schedulerScheduler, stageStore, stepStore, reporter2)
 	commitService := commit.ProvideService(gitInterface)
 	fileService := file.ProvideService(gitInterface)
 	converterService := converter.ProvideService(fileService, publicaccessService)
 	templateStore := database.ProvideTemplateStore(db)
 	pluginStore := database.ProvidePluginStore(db)
-	triggererTriggerer := triggerer.ProvideTriggerer(executionStore, checkStore, stageStore, transactor, pipelineStore, fileService, converterService, schedulerScheduler, repoStore, provider, templateStore, pluginStore, publicaccessService)
-	executionController := execution.ProvideController(transactor, authorizer, executionStore, checkStore, cancelerCanceler, commitService, triggererTriggerer, repoStore, stageStore, pipelineStore)
-	logStore := logs.ProvideLogStore(db, config)
+	triggererTriggerer := triggerer.ProvideTriggerer(executionStore, checkStore, stageStore, transactor, pipelineStore, fileService, converterService, schedulerScheduler, repoStore, provider, templateStore, pluginStore, publicaccessService, streamer, cancelerCanceler)
+	logStore := logs.ProvideLogStore(db, config, blobStore)
 	logStream := livelog.ProvideLogStream()
 	logsController := logs2.ProvideController(authorizer, executionStore, repoStore, pipelineStore, stageStore, stepStore, logStore, logStream)
 	spaceIdentifier := check.ProvideSpaceIdentifierCheck()
@@ -269,7 +399,6 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	connectorStore := database.ProvideConnectorStore(db)
 	repoGitInfoView := database.ProvideRepoGitInfoView(db)
 	repoGitInfoCache := cache.ProvideRepoGitInfoCache(repoGitInfoView)
-	pullReqStore := database.ProvidePullReqStore(db, principalInfoCache)
 	listService := pullreq.ProvideListService(transactor, gitInterface, authorizer, spaceStore, repoStore, repoGitInfoCache, pullReqStore, labelService)
 	exporterRepository, err := exporter.ProvideSpaceExporter(provider, gitInterface, repoStore, jobScheduler, executor, encrypter, streamer)
 	if err != nil {
@@ -293,12 +422,35 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	factory := infraprovider.ProvideFactory(dockerProvider)
 	infraproviderService := infraprovider2.ProvideInfraProvider(transactor, infraProviderResourceStore, infraProviderConfigStore, infraProviderTemplateStore, factory, spaceStore)
 	gitspaceService := gitspace.ProvideGitspace(transactor, gitspaceConfigStore, gitspaceInstanceStore, spaceStore, infraproviderService)
-	spaceController := space.ProvideController(config, transactor, provider, streamer, spaceIdentifier, authorizer, spacePathStore, pipelineStore, secretStore, connectorStore, templateStore, spaceStore, repoStore, principalStore, repoController, membershipStore, listService, repository, exporterRepository, resourceLimiter, publicaccessService, auditService, gitspaceService, labelService, instrumentService)
-	reporter2, err := events4.ProvideReporter(eventsSystem)
+	eventsReaderFactory, err := events5.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	pipelineController := pipeline.ProvideController(repoStore, triggerStore, authorizer, pipelineStore, reporter2)
+	readerFactory5, err := events4.ProvideReaderFactory(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	readerFactory2, err := events2.ProvideReaderFactory(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	spacefeedConfig := server.ProvideSpaceFeedConfig(config)
+	spacefeedService, err := spacefeed.ProvideService(ctx, spacefeedConfig, eventLogStore, repoStore, spaceStore, streamer, readerFactory2, eventsReaderFactory, readerFactory5)
+	if err != nil {
+		return nil, err
+	}
+	artifactStore := database.ProvideArtifactStore(db)
+	usageStore := database.ProvideUsageStore(db)
+	usageService, err := usage.ProvideService(config, repoStore, artifactStore, usageStore, jobScheduler, executor)
+	if err != nil {
+		return nil, err
+	}
+	executionManager := manager.ProvideExecutionManager(config, executionStore, pipelineStore, provider, streamer, fileService, converterService, logStore, logStream, checkStore, repoStore, schedulerScheduler, secretStore, stageStore, stepStore, principalStore, publicaccessService, reporter2)
+	stageApprovalStore := database.ProvideStageApprovalStore(db, principalInfoCache)
+	approverApprover := approver.ProvideApprover(stageStore, stageApprovalStore, spaceStore, membershipStore, schedulerScheduler, executionManager, streamer, auditService)
+	executionController := execution.ProvideController(transactor, authorizer, executionStore, checkStore, cancelerCanceler, commitService, triggererTriggerer, repoStore, stageStore, pipelineStore, approverApprover, stageApprovalStore, artifactStore, blobStore, config, quotaWarner)
+	validatorService := validator.ProvideService(secretStore, connectorStore, templateStore, pluginStore)
+	pipelineController := pipeline.ProvideController(repoStore, triggerStore, authorizer, pipelineStore, reporter2, fileService, validatorService)
 	secretController := secret.ProvideController(encrypter, secretStore, authorizer, spaceStore)
 	triggerController := trigger.ProvideController(authorizer, triggerStore, pipelineStore, repoStore)
 	connectorController := connector.ProvideController(connectorStore, authorizer, spaceStore)
@@ -316,29 +468,26 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	migrator := codecomments.ProvideMigrator(gitInterface)
-	readerFactory, err := events6.ProvideReaderFactory(eventsSystem)
-	if err != nil {
-		return nil, err
-	}
-	eventsReaderFactory, err := events5.ProvideReaderFactory(eventsSystem)
-	if err != nil {
-		return nil, err
-	}
 	pullreqService, err := pullreq.ProvideService(ctx, config, readerFactory, eventsReaderFactory, reporter3, gitInterface, repoGitInfoCache, repoStore, pullReqStore, pullReqActivityStore, principalInfoCache, codeCommentView, migrator, pullReqFileViewStore, pubSub, provider, streamer)
 	if err != nil {
 		return nil, err
 	}
 	pullReq := migrate.ProvidePullReqImporter(provider, gitInterface, principalStore, repoStore, pullReqStore, pullReqActivityStore, transactor)
 	searchService := usergroup.ProvideSearchService()
-	pullreqController := pullreq2.ProvideController(transactor, provider, authorizer, pullReqStore, pullReqActivityStore, codeCommentView, pullReqReviewStore, pullReqReviewerStore, repoStore, principalStore, userGroupStore, userGroupReviewersStore, principalInfoCache, pullReqFileViewStore, membershipStore, checkStore, gitInterface, reporter3, migrator, pullreqService, listService, protectionManager, streamer, codeownersService, lockerLocker, pullReq, labelService, instrumentService, searchService)
-	webhookConfig := server.ProvideWebhookConfig(config)
-	webhookStore := database.ProvideWebhookStore(db)
+	pullreqController := pullreq2.ProvideController(transactor, provider, authorizer, pullReqStore, pullReqActivityStore, codeCommentView, pullReqReviewStore, pullReqReviewerStore, repoStore, principalStore, userGroupStore, userGroupReviewersStore, principalInfoCache, pullReqFileViewStore, membershipStore, checkStore, gitInterface, reporter3, migrator, pullreqService, listService, protectionManager, streamer, codeownersService, lockerLocker, pullReq, labelService, instrumentService, searchService, settingsService, commitpolicyService)
+	outboundConfig := server.ProvideOutboundConfig(config)
+	webhookConfig := server.ProvideWebhookConfig(config, outboundConfig)
 	webhookExecutionStore := database.ProvideWebhookExecutionStore(db)
-	webhookService, err := webhook.ProvideService(ctx, webhookConfig, readerFactory, eventsReaderFactory, webhookStore, webhookExecutionStore, repoStore, pullReqStore, pullReqActivityStore, provider, principalStore, gitInterface, encrypter)
+	webhookService, err := webhook.ProvideService(ctx, webhookConfig, readerFactory, eventsReaderFactory, readerFactory5, webhookStore, webhookExecutionStore, repoStore, pullReqStore, pullReqActivityStore, provider, principalStore, gitInterface, encrypter, instancesettingsService, pipelineStore, executionStore)
+	if err != nil {
+		return nil, err
+	}
+	webhookController := webhook2.ProvideController(instancesettingsService, authorizer, webhookStore, webhookExecutionStore, repoStore, webhookService, encrypter)
+	repobulkService, err := repobulk.ProvideService(authorizer, principalStore, spaceStore, repoStore, ruleStore, webhookStore, publicaccessService, repoController, webhookController, encrypter, jobScheduler, executor)
 	if err != nil {
 		return nil, err
 	}
-	webhookController := webhook2.ProvideController(webhookConfig, authorizer, webhookStore, webhookExecutionStore, repoStore, webhookService, encrypter)
+	spaceController := space.ProvideController(config, transactor, provider, streamer, spaceIdentifier, authorizer, spacePathStore, pipelineStore, secretStore, connectorStore, templateStore, spaceStore, repoStore, principalStore, repoController, membershipStore, listService, repository, exporterRepository, spacearchiveService, resourceLimiter, publicaccessService, auditService, gitspaceService, labelService, instrumentService, recentvisitService, spacefeedService, usageService, commitpolicyService, repoTopicStore, spacePinnedRepoStore, lockerLocker, repobulkService, operationService)
 	reporter4, err := events6.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
@@ -355,24 +504,22 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	githookController := githook.ProvideController(authorizer, principalStore, repoStore, reporter4, reporter, gitInterface, pullReqStore, provider, protectionManager, clientFactory, resourceLimiter, settingsService, preReceiveExtender, updateExtender, postReceiveExtender)
-	serviceaccountController := serviceaccount.NewController(principalUID, authorizer, principalStore, spaceStore, repoStore, tokenStore)
-	principalController := principal.ProvideController(principalStore, authorizer)
+	githookController := githook.ProvideController(authorizer, principalStore, repoStore, reporter4, reporter, gitInterface, pullReqStore, repoPushStore, provider, protectionManager, clientFactory, resourceLimiter, quotaWarner, settingsService, commitpolicyService, featureflagService, config, preReceiveExtender, updateExtender, postReceiveExtender)
+	serviceaccountController := serviceaccount.NewController(principalUID, authorizer, principalStore, spaceStore, repoStore, tokenStore, principalTokenCache, transactor)
+	principalController := principal.ProvideController(principalStore, authorizer, avatarService)
 	usergroupController := usergroup2.ProvideController(userGroupStore, spaceStore, authorizer, searchService)
 	v := check2.ProvideCheckSanitizers()
-	checkController := check2.ProvideController(transactor, authorizer, repoStore, checkStore, gitInterface, v)
-	systemController := system.NewController(principalStore, config)
-	blobConfig, err := server.ProvideBlobStoreConfig(config)
-	if err != nil {
-		return nil, err
-	}
-	blobStore, err := blob.ProvideStore(ctx, blobConfig)
+	checkController := check2.ProvideController(transactor, authorizer, repoStore, checkStore, gitInterface, v, protectionManager)
+	schemaStore := database.ProvideSchemaStore(db)
+	counterStore := database.ProvideCounterStore(db)
+	reconciler, err := counters.ProvideReconciler(config, gitInterface, repoStore, counterStore, settingsService, jobScheduler, executor)
 	if err != nil {
 		return nil, err
 	}
-	uploadController := upload.ProvideController(authorizer, repoStore, blobStore)
+	systemController := system.NewController(principalStore, config, instancesettingsService, schemaStore, reconciler, installationStore, spaceStore, featureflagService, gitlimitService)
+	uploadController := upload.ProvideController(authorizer, repoStore, blobStore, instancesettingsService)
 	searcher := keywordsearch.ProvideSearcher(localIndexSearcher)
-	keywordsearchController := keywordsearch2.ProvideController(authorizer, searcher, repoController, spaceController)
+	keywordsearchController := keywordsearch2.ProvideController(authorizer, searcher, repoController, spaceController, repoTopicStore)
 	infraproviderController := infraprovider3.ProvideController(authorizer, spaceStore, infraproviderService)
 	reporter5, err := events7.ProvideReporter(eventsSystem)
 	if err != nil {
@@ -400,8 +547,8 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	gitspaceEventStore := database.ProvideGitspaceEventStore(db)
 	gitspaceController := gitspace2.ProvideController(transactor, authorizer, infraproviderService, gitspaceConfigStore, gitspaceInstanceStore, spaceStore, reporter5, orchestratorOrchestrator, gitspaceEventStore, statefulLogger, scmSCM, repoStore, gitspaceService)
 	rule := migrate.ProvideRuleImporter(ruleStore, transactor, principalStore)
-	migrateWebhook := migrate.ProvideWebhookImporter(webhookConfig, transactor, webhookStore)
-	migrateController := migrate2.ProvideController(authorizer, publicaccessService, gitInterface, provider, pullReq, rule, migrateWebhook, resourceLimiter, auditService, repoIdentifier, transactor, spaceStore, repoStore)
+	migrateWebhook := migrate.ProvideWebhookImporter(instancesettingsService, transactor, webhookStore)
+	migrateController := migrate2.ProvideController(authorizer, publicaccessService, gitInterface, provider, pullReq, rule, migrateWebhook, resourceLimiter, auditService, repoIdentifier, transactor, spaceStore, repoStore, repostateService)
 	registry, err := capabilities.ProvideCapabilities(repoStore, gitInterface)
 	if err != nil {
 		return nil, err
@@ -443,11 +590,35 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	cleanupPolicyRepository := database2.ProvideCleanupPolicyDao(db, transactor)
 	apiHandler := router.APIHandlerProvider(registryRepository, upstreamProxyConfigRepository, tagRepository, manifestRepository, cleanupPolicyRepository, artifactRepository, storageDriver, spaceStore, transactor, authenticator, provider, authorizer, auditService)
 	appRouter := router.AppRouterProvider(registryOCIHandler, apiHandler)
-	routerRouter := router2.ProvideRouter(ctx, config, authenticator, repoController, reposettingsController, executionController, logsController, spaceController, pipelineController, secretController, triggerController, connectorController, templateController, pluginController, pullreqController, webhookController, githookController, gitInterface, serviceaccountController, controller, principalController, usergroupController, checkController, systemController, uploadController, keywordsearchController, infraproviderController, gitspaceController, migrateController, aiagentController, capabilitiesController, provider, openapiService, appRouter)
+	digestConfig := server.ProvideDigestConfig(config)
+	digestSubscriptionStore := database.ProvideDigestSubscriptionStore(db)
+	digestService, err := digest.ProvideService(digestConfig, jobScheduler, executor, digestSubscriptionStore, repoActivityStore, repoStore, pipelineStore, principalStore, principalInfoCache, authorizer, gitInterface, provider, mailerMailer)
+	if err != nil {
+		return nil, err
+	}
+	digestController := digest2.ProvideController(authorizer, digestSubscriptionStore, repoStore, spaceStore, digestService)
+	inboxConfig := server.ProvideInboxConfig(config)
+	notificationStore := database.ProvideNotificationStore(db, principalInfoCache)
+	notificationSubscriptionStore := database.ProvideNotificationSubscriptionStore(db)
+	inboxService, err := inbox.ProvideService(ctx, inboxConfig, notificationStore, notificationSubscriptionStore, pullReqStore, executionStore, principalInfoCache, eventsReaderFactory, readerFactory5)
+	if err != nil {
+		return nil, err
+	}
+	inboxController := inbox2.ProvideController(authorizer, notificationStore, repoStore, pullReqStore, inboxService)
+	mailController := mail.ProvideController(config, transport, mailFailureStore)
+	eventlogController := eventlog.ProvideController(config, eventLogStore)
+	scimController := scim.ProvideController(principalUID, authorizer, principalStore)
+	runnerController := runner2.ProvideController(config, authorizer, runnerStore, stageStore, spaceStore)
+	idempotencyConfig := server.ProvideIdempotencyConfig(config)
+	idempotencyKeyStore := database.ProvideIdempotencyKeyStore(db)
+	idempotencyService, err := idempotency.ProvideService(idempotencyConfig, idempotencyKeyStore, encrypter)
+	if err != nil {
+		return nil, err
+	}
+	operationController := operation2.ProvideController(authorizer, spaceStore, repoStore, publicaccessService, operationService)
+	routerRouter := router2.ProvideRouter(ctx, config, authenticator, repoController, reposettingsController, executionController, logsController, spaceController, pipelineController, secretController, triggerController, connectorController, templateController, pluginController, pullreqController, webhookController, githookController, gitInterface, serviceaccountController, controller, principalController, usergroupController, checkController, systemController, uploadController, keywordsearchController, infraproviderController, gitspaceController, migrateController, aiagentController, capabilitiesController, digestController, inboxController, mailController, eventlogController, scimController, runnerController, provider, openapiService, appRouter, idempotencyService, operationController, maintenanceService)
 	serverServer := server2.ProvideServer(config, routerRouter)
-	publickeyService := publickey.ProvidePublicKey(publicKeyStore, principalInfoCache)
-	sshServer := ssh.ProvideServer(config, publickeyService, repoController)
-	executionManager := manager.ProvideExecutionManager(config, executionStore, pipelineStore, provider, streamer, fileService, converterService, logStore, logStream, checkStore, repoStore, schedulerScheduler, secretStore, stageStore, stepStore, principalStore, publicaccessService, reporter2)
+	sshServer := ssh.ProvideServer(config, publickeyService, repoController, featureflagService)
 	client := manager.ProvideExecutionClient(executionManager, provider, config)
 	resolverManager := resolver.ProvideResolver(config, pluginStore, templateStore, executionStore, repoStore)
 	runtimeRunner, err := runner.ProvideExecutionRunner(config, client, resolverManager)
@@ -455,12 +626,13 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	poller := runner.ProvideExecutionPoller(runtimeRunner, client)
+	heartbeat := runner.ProvideHeartbeat(runnerStore, config)
 	triggerConfig := server.ProvideTriggerConfig(config)
 	triggerService, err := trigger2.ProvideService(ctx, triggerConfig, triggerStore, commitService, pullReqStore, repoStore, pipelineStore, triggererTriggerer, readerFactory, eventsReaderFactory)
 	if err != nil {
 		return nil, err
 	}
-	collector, err := metric.ProvideCollector(config, principalStore, repoStore, pipelineStore, executionStore, jobScheduler, executor, gitspaceConfigStore)
+	collector, err := metric.ProvideCollector(config, outboundConfig, installationStore, principalStore, repoStore, pipelineStore, executionStore, jobScheduler, executor, gitspaceConfigStore, repoStatsStore)
 	if err != nil {
 		return nil, err
 	}
@@ -468,7 +640,15 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	readerFactory2, err := events2.ProvideReaderFactory(eventsSystem)
+	reflogEnabler, err := repo2.ProvideReflogEnabler(gitInterface, repoStore, jobScheduler, executor)
+	if err != nil {
+		return nil, err
+	}
+	maintenance2, err := repo2.ProvideMaintenance(config, gitInterface, repoStore, jobScheduler, executor)
+	if err != nil {
+		return nil, err
+	}
+	statsCalculator, err := repo2.ProvideStatsCalculator(config, gitInterface, repoStore, repoPushStore, repoStatsStore, jobScheduler, executor)
 	if err != nil {
 		return nil, err
 	}
@@ -477,11 +657,10 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	cleanupConfig := server.ProvideCleanupConfig(config)
-	cleanupService, err := cleanup.ProvideService(cleanupConfig, jobScheduler, executor, webhookExecutionStore, tokenStore, repoStore, repoController)
+	cleanupService, err := cleanup.ProvideService(cleanupConfig, jobScheduler, executor, webhookExecutionStore, tokenStore, repoStore, repoActivityStore, repoAccessLogStore, pullReqStore, loginAttemptStore, artifactStore, spacePinnedRepoStore, idempotencyKeyStore, operationStore, blobStore, gitInterface, repoController, pullreqService)
 	if err != nil {
 		return nil, err
 	}
-	mailerMailer := mailer.ProvideMailClient(config)
 	notificationClient := notification.ProvideMailClient(mailerMailer)
 	notificationConfig := server.ProvideNotificationConfig(config)
 	notificationService, err := notification.ProvideNotificationService(ctx, notificationClient, notificationConfig, eventsReaderFactory, pullReqStore, repoStore, principalInfoView, principalInfoCache, pullReqReviewerStore, pullReqActivityStore, spacePathStore, provider)
@@ -519,7 +698,15 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	servicesServices := services.ProvideServices(webhookService, pullreqService, triggerService, jobScheduler, collector, sizeCalculator, repoService, cleanupService, notificationService, keywordsearchService, gitspaceServices, instrumentService, consumer, repositoryCount)
-	serverSystem := server.NewSystem(bootstrapBootstrap, serverServer, sshServer, poller, resolverManager, servicesServices)
+	repoactivityConfig := server.ProvideRepoActivityConfig(config)
+	repoactivityService, err := repoactivity.ProvideService(ctx, repoactivityConfig, repoActivityStore, repoStore, executionStore, readerFactory, eventsReaderFactory, readerFactory5, readerFactory2)
+	if err != nil {
+		return nil, err
+	}
+	expirer := approver.ProvideExpirer(jobScheduler, executor, approverApprover, stageStore, repoStore)
+	timeoutEnforcer := canceler.ProvideTimeoutEnforcer(jobScheduler, executor, executionStore, stageStore, stepStore, repoStore, schedulerScheduler, streamer, reporter2, config)
+	runnerMonitor := canceler.ProvideRunnerMonitor(jobScheduler, executor, transactor, executionStore, stageStore, stepStore, repoStore, runnerStore, schedulerScheduler, streamer, reporter2, config)
+	servicesServices := services.ProvideServices(webhookService, pullreqService, triggerService, jobScheduler, collector, sizeCalculator, reflogEnabler, maintenance2, statsCalculator, reconciler, repoService, cleanupService, notificationService, keywordsearchService, gitspaceServices, instrumentService, consumer, repositoryCount, expirer, timeoutEnforcer, runnerMonitor, repoactivityService, digestService, recentvisitService, inboxService, spacefeedService, instancesettingsService, featureflagService, gitlimitService, accesslogService, usageService)
+	serverSystem := server.NewSystem(bootstrapBootstrap, serverServer, sshServer, poller, heartbeat, resolverManager, servicesServices, gitInterface)
 	return serverSystem, nil
 }
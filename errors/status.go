@@ -31,6 +31,7 @@ const (
 	StatusFailed             Status = "failed"
 	StatusPreconditionFailed Status = "precondition_failed"
 	StatusAborted            Status = "aborted"
+	StatusUnavailable        Status = "unavailable"
 )
 
 type Error struct {
@@ -166,6 +167,11 @@ func Aborted(format string, args ...interface{}) *Error {
 	return Format(StatusAborted, format, args...)
 }
 
+// Unavailable is a helper function to return unavailable error status.
+func Unavailable(format string, args ...interface{}) *Error {
+	return Format(StatusUnavailable, format, args...)
+}
+
 // IsNotFound checks if err is not found error.
 func IsNotFound(err error) bool {
 	return AsStatus(err) == StatusNotFound
@@ -195,3 +201,8 @@ func IsPreconditionFailed(err error) bool {
 func IsAborted(err error) bool {
 	return AsStatus(err) == StatusAborted
 }
+
+// IsUnavailable checks if err is unavailable error.
+func IsUnavailable(err error) bool {
+	return AsStatus(err) == StatusUnavailable
+}
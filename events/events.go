@@ -45,6 +45,9 @@ type Mode string
 const (
 	ModeRedis    Mode = "redis"
 	ModeInMemory Mode = "inmemory"
+	// ModeDatabase stores events in a durable log in the database,
+	// consumer groups track their offsets and can be replayed.
+	ModeDatabase Mode = "database"
 )
 
 // Config defines the config of the events system.
@@ -59,7 +62,7 @@ func (c *Config) Validate() error {
 	if c == nil {
 		return errors.New("config is required")
 	}
-	if c.Mode != ModeRedis && c.Mode != ModeInMemory {
+	if c.Mode != ModeRedis && c.Mode != ModeInMemory && c.Mode != ModeDatabase {
 		return fmt.Errorf("config.Mode '%s' is not supported", c.Mode)
 	}
 	if c.MaxStreamLength < 1 {
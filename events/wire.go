@@ -29,7 +29,7 @@ var WireSet = wire.NewSet(
 	ProvideSystem,
 )
 
-func ProvideSystem(config Config, redisClient redis.UniversalClient) (*System, error) {
+func ProvideSystem(config Config, redisClient redis.UniversalClient, logStore stream.LogStore) (*System, error) {
 	if err := config.Validate(); err != nil {
 		return nil, fmt.Errorf("provided config is invalid: %w", err)
 	}
@@ -41,6 +41,8 @@ func ProvideSystem(config Config, redisClient redis.UniversalClient) (*System, e
 		system, err = provideSystemInMemory(config)
 	case ModeRedis:
 		system, err = provideSystemRedis(config, redisClient)
+	case ModeDatabase:
+		system, err = provideSystemDatabase(config, logStore)
 	default:
 		return nil, fmt.Errorf("events system mode '%s' is not supported", config.Mode)
 	}
@@ -76,6 +78,17 @@ func provideSystemRedis(config Config, redisClient redis.UniversalClient) (*Syst
 	)
 }
 
+func provideSystemDatabase(config Config, logStore stream.LogStore) (*System, error) {
+	if logStore == nil {
+		return nil, errors.New("log store required")
+	}
+
+	return NewSystem(
+		newDatabaseStreamConsumerFactoryMethod(logStore, config.Namespace),
+		newDatabaseStreamProducer(logStore, config.Namespace, config.MaxStreamLength),
+	)
+}
+
 func newMemoryStreamConsumerFactoryMethod(broker *stream.MemoryBroker, namespace string) StreamConsumerFactoryFunc {
 	return func(groupName string, _ string) (StreamConsumer, error) {
 		return stream.NewMemoryConsumer(broker, namespace, groupName)
@@ -99,3 +112,13 @@ func newRedisStreamProducer(redisClient redis.UniversalClient, namespace string,
 	maxStreamLength int64, approxMaxStreamLength bool) StreamProducer {
 	return stream.NewRedisProducer(redisClient, namespace, maxStreamLength, approxMaxStreamLength)
 }
+
+func newDatabaseStreamConsumerFactoryMethod(logStore stream.LogStore, namespace string) StreamConsumerFactoryFunc {
+	return func(groupName string, consumerName string) (StreamConsumer, error) {
+		return stream.NewDatabaseConsumer(logStore, namespace, groupName, consumerName)
+	}
+}
+
+func newDatabaseStreamProducer(logStore stream.LogStore, namespace string, maxStreamLength int64) StreamProducer {
+	return stream.NewDatabaseProducer(logStore, namespace, maxStreamLength)
+}
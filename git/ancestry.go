@@ -0,0 +1,138 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/git/api"
+)
+
+const (
+	// ancestryBatchMaxSize is the max number of checks in a batch, bigger batches are executed immediately.
+	ancestryBatchMaxSize = 100
+	// ancestryBatchTimeout is the max duration of the execution of a batch.
+	ancestryBatchTimeout = time.Minute
+)
+
+// ancestryBatcher coalesces the ancestry checks of a repository that arrive within a short window,
+// so they get answered by a single git process instead of one process per check.
+type ancestryBatcher struct {
+	git    *api.Git
+	window time.Duration
+
+	mx      sync.Mutex
+	batches map[string]*ancestryBatch
+}
+
+type ancestryBatch struct {
+	ctx        context.Context
+	repoPath   string
+	alternates []string
+	checks     []api.AncestryCheck
+	timer      *time.Timer
+
+	// results and errs are only safe to read once done is closed.
+	done    chan struct{}
+	results []bool
+	errs    []error
+}
+
+func newAncestryBatcher(git *api.Git, window time.Duration) *ancestryBatcher {
+	return &ancestryBatcher{
+		git:     git,
+		window:  window,
+		batches: map[string]*ancestryBatch{},
+	}
+}
+
+// IsAncestor adds the check to the pending batch of the repository and waits for its result.
+// Without a batching window the check is executed right away.
+func (b *ancestryBatcher) IsAncestor(
+	ctx context.Context,
+	repoPath string,
+	alternates []string,
+	check api.AncestryCheck,
+) (bool, error) {
+	if b.window <= 0 {
+		return b.git.IsAncestor(ctx, repoPath, alternates, check.Ancestor, check.Descendant)
+	}
+
+	key := repoPath + "\x00" + strings.Join(alternates, "\x00")
+
+	b.mx.Lock()
+	batch, ok := b.batches[key]
+	if !ok {
+		batch = &ancestryBatch{
+			// the batch is shared by all callers, it must not be canceled together with the first of them.
+			ctx:        context.WithoutCancel(ctx),
+			repoPath:   repoPath,
+			alternates: alternates,
+			done:       make(chan struct{}),
+		}
+		b.batches[key] = batch
+		batch.timer = time.AfterFunc(b.window, func() { b.flush(key, batch) })
+	}
+
+	idx := len(batch.checks)
+	batch.checks = append(batch.checks, check)
+
+	if len(batch.checks) >= ancestryBatchMaxSize {
+		batch.timer.Stop()
+		go b.flush(key, batch)
+	}
+	b.mx.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return false, ctx.Err()
+	case <-batch.done:
+		return batch.results[idx], batch.errs[idx]
+	}
+}
+
+// flush executes the batch, unless it got executed already.
+func (b *ancestryBatcher) flush(key string, batch *ancestryBatch) {
+	b.mx.Lock()
+	if b.batches[key] != batch {
+		b.mx.Unlock()
+		return
+	}
+	delete(b.batches, key)
+	b.mx.Unlock()
+
+	ctx, cancel := context.WithTimeout(batch.ctx, ancestryBatchTimeout)
+	defer cancel()
+
+	batch.errs = make([]error, len(batch.checks))
+
+	results, err := b.git.AreAncestors(ctx, batch.repoPath, batch.alternates, batch.checks)
+	if err == nil {
+		batch.results = results
+		close(batch.done)
+		return
+	}
+
+	// a single invalid check fails the whole batch, so fall back to individual checks to isolate the error.
+	batch.results = make([]bool, len(batch.checks))
+	for i, check := range batch.checks {
+		batch.results[i], batch.errs[i] = b.git.IsAncestor(ctx,
+			batch.repoPath, batch.alternates, check.Ancestor, check.Descendant)
+	}
+	close(batch.done)
+}
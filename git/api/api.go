@@ -21,9 +21,13 @@ import (
 )
 
 type Git struct {
-	traceGit        bool
-	lastCommitCache cache.Cache[CommitEntryKey, *Commit]
-	githookFactory  hook.ClientFactory
+	traceGit             bool
+	allowPartialClone    bool
+	advertisePushOptions bool
+	advertiseAtomic      bool
+	hiddenRefs           []string
+	lastCommitCache      cache.Cache[CommitEntryKey, *Commit]
+	githookFactory       hook.ClientFactory
 }
 
 func New(
@@ -32,8 +36,12 @@ func New(
 	githookFactory hook.ClientFactory,
 ) (*Git, error) {
 	return &Git{
-		traceGit:        config.Trace,
-		lastCommitCache: lastCommitCache,
-		githookFactory:  githookFactory,
+		traceGit:             config.Trace,
+		allowPartialClone:    config.PartialClone,
+		advertisePushOptions: config.AdvertisePushOptions,
+		advertiseAtomic:      config.AdvertiseAtomic,
+		hiddenRefs:           config.HiddenRefs,
+		lastCommitCache:      lastCommitCache,
+		githookFactory:       githookFactory,
 	}, nil
 }
@@ -39,6 +39,12 @@ var (
 type BlamePart struct {
 	Commit *Commit  `json:"commit"`
 	Lines  []string `json:"lines"`
+	// Path is the path of the file in the commit, it differs from the blamed path if the file was renamed since.
+	Path string `json:"path"`
+	// LineNumber is the line number of the first line in the blamed revision.
+	LineNumber int `json:"line_number"`
+	// OrigLineNumber is the line number of the first line in the commit.
+	OrigLineNumber int `json:"orig_line_number"`
 }
 
 type BlameNextReader interface {
@@ -94,6 +100,7 @@ func (g *Git) Blame(
 	return &BlameReader{
 		scanner:     bufio.NewScanner(pipeRead),
 		commitCache: make(map[string]*Commit),
+		pathCache:   make(map[string]string),
 		errReader:   stderr, // Any stderr output will cause the BlameReader to fail.
 	}
 }
@@ -102,6 +109,7 @@ type BlameReader struct {
 	scanner     *bufio.Scanner
 	lastLine    string
 	commitCache map[string]*Commit
+	pathCache   map[string]string
 	errReader   io.Reader
 }
 
@@ -133,6 +141,8 @@ func (r *BlameReader) unreadLine(line string) {
 func (r *BlameReader) NextPart() (*BlamePart, error) {
 	var commit *Commit
 	var lines []string
+	var path string
+	var lineNumber, origLineNumber int
 	var err error
 
 	for {
@@ -151,6 +161,10 @@ func (r *BlameReader) NextPart() (*BlamePart, error) {
 					commit = &Commit{SHA: commitSHA}
 				}
 
+				path = r.pathCache[commitSHA.String()]
+				origLineNumber, _ = strconv.Atoi(matches[2])
+				lineNumber, _ = strconv.Atoi(matches[3])
+
 				if matches[5] != "" {
 					// At index 5 there's number of lines in this section. However, the resulting
 					// BlamePart might contain more than this because we join consecutive sections
@@ -167,8 +181,11 @@ func (r *BlameReader) NextPart() (*BlamePart, error) {
 				r.commitCache[commit.SHA.String()] = commit
 
 				return &BlamePart{
-					Commit: commit,
-					Lines:  lines,
+					Commit:         commit,
+					Lines:          lines,
+					Path:           path,
+					LineNumber:     lineNumber,
+					OrigLineNumber: origLineNumber,
 				}, nil
 			}
 
@@ -187,6 +204,13 @@ func (r *BlameReader) NextPart() (*BlamePart, error) {
 			continue
 		}
 
+		// the filename header is output only for the first section of a commit.
+		if filename, ok := strings.CutPrefix(line, "filename "); ok {
+			path = unquoteBlameFilename(filename)
+			r.pathCache[commit.SHA.String()] = path
+			continue
+		}
+
 		parseBlameHeaders(line, commit)
 	}
 
@@ -223,8 +247,11 @@ func (r *BlameReader) NextPart() (*BlamePart, error) {
 
 	if commit != nil && len(lines) > 0 {
 		part = &BlamePart{
-			Commit: commit,
-			Lines:  lines,
+			Commit:         commit,
+			Lines:          lines,
+			Path:           path,
+			LineNumber:     lineNumber,
+			OrigLineNumber: origLineNumber,
 		}
 	}
 
@@ -261,6 +288,21 @@ func parseBlameHeaders(line string, commit *Commit) {
 	}
 }
 
+// unquoteBlameFilename returns the filename from git blame output.
+// Filenames with special characters are quoted by git using C-style escapes.
+func unquoteBlameFilename(s string) string {
+	if len(s) < 2 || s[0] != '"' {
+		return s
+	}
+
+	unquoted, err := strconv.Unquote(s)
+	if err != nil {
+		return s
+	}
+
+	return unquoted
+}
+
 func extractName(s string) string {
 	return s
 }
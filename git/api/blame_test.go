@@ -103,22 +103,32 @@ filename file_name.go
 
 	want := []*BlamePart{
 		{
-			Commit: commit1,
-			Lines:  []string{"Line 10", "Line 11"},
+			Commit:         commit1,
+			Lines:          []string{"Line 10", "Line 11"},
+			Path:           "file_name_before_rename.go",
+			LineNumber:     10,
+			OrigLineNumber: 9,
 		},
 		{
-			Commit: commit2,
-			Lines:  []string{"Line 12"},
+			Commit:         commit2,
+			Lines:          []string{"Line 12"},
+			Path:           "file_name.go",
+			LineNumber:     12,
+			OrigLineNumber: 12,
 		},
 		{
-			Commit: commit1,
-			Lines:  []string{"Line 13", "Line 14"},
+			Commit:         commit1,
+			Lines:          []string{"Line 13", "Line 14"},
+			Path:           "file_name_before_rename.go",
+			LineNumber:     13,
+			OrigLineNumber: 13,
 		},
 	}
 
 	reader := BlameReader{
 		scanner:     bufio.NewScanner(strings.NewReader(blameOut)),
 		commitCache: make(map[string]*Commit),
+		pathCache:   make(map[string]string),
 		errReader:   strings.NewReader(""),
 	}
 
@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"io"
+
+	"github.com/harness/gitness/git/command"
+)
+
+// CreateBundle writes a git bundle containing all references of the repository to the provided writer.
+// NOTE: git refuses to create a bundle for a repository without any references.
+func (g *Git) CreateBundle(
+	ctx context.Context,
+	repoPath string,
+	w io.Writer,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+
+	// the bundle is written to stdout ("-") and contains all refs of the repository.
+	cmd := command.New("bundle",
+		command.WithAction("create"),
+		command.WithFlag("-", "--all"),
+	)
+
+	if err := cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(w)); err != nil {
+		return processGitErrorf(err, "failed to create bundle")
+	}
+
+	return nil
+}
+
+// VerifyBundle verifies that the bundle file is valid and all its prerequisites exist in the repository.
+func (g *Git) VerifyBundle(
+	ctx context.Context,
+	repoPath string,
+	bundlePath string,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+
+	cmd := command.New("bundle",
+		command.WithAction("verify"),
+		command.WithFlag("--quiet"),
+		command.WithArg(bundlePath),
+	)
+
+	if err := cmd.Run(ctx, command.WithDir(repoPath)); err != nil {
+		return processGitErrorf(err, "failed to verify bundle")
+	}
+
+	return nil
+}
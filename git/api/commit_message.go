@@ -0,0 +1,114 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/git/sha"
+)
+
+// CommitMessage is the raw message of a commit.
+type CommitMessage struct {
+	SHA        sha.SHA
+	ParentSHAs []sha.SHA
+	Message    string
+}
+
+// ListNewCommitMessages returns the messages of up to limit commits reachable from rev
+// that aren't reachable from any reference of the repository.
+// It's meant to be used in pre-receive, before the pushed references are updated,
+// to get the commits introduced by the push.
+func (g *Git) ListNewCommitMessages(
+	ctx context.Context,
+	repoPath string,
+	alternates []string,
+	rev string,
+	limit int,
+) ([]CommitMessage, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+
+	cmd := command.New("rev-list",
+		command.WithArg(rev),
+		command.WithArg("--not", "--all"),
+		command.WithAlternateObjectDirs(alternates...),
+	)
+	if limit > 0 {
+		cmd.Add(command.WithFlag("--max-count", strconv.Itoa(limit)))
+	}
+
+	commitSHAs := &bytes.Buffer{}
+	err := cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(commitSHAs))
+	if err != nil {
+		return nil, processGitErrorf(err, "failed to list new commits of %q", rev)
+	}
+
+	if commitSHAs.Len() == 0 {
+		return []CommitMessage{}, nil
+	}
+
+	cmd = command.New("log",
+		command.WithFlag("--no-walk=unsorted"),
+		command.WithFlag("--stdin"),
+		command.WithFlag("--format="+fmtCommitHash+fmtZero+fmtParentHashes+fmtZero+fmtBody+fmtZero),
+		command.WithAlternateObjectDirs(alternates...),
+	)
+
+	stdout := &bytes.Buffer{}
+	err = cmd.Run(ctx,
+		command.WithDir(repoPath),
+		command.WithStdin(commitSHAs),
+		command.WithStdout(stdout),
+	)
+	if err != nil {
+		return nil, processGitErrorf(err, "failed to read commit messages")
+	}
+
+	const columnCount = 3
+
+	fields := strings.Split(stdout.String(), "\x00")
+	messages := make([]CommitMessage, 0, len(fields)/columnCount)
+	for i := 0; i+columnCount <= len(fields); i += columnCount {
+		var commitSHA sha.SHA
+		commitSHA, err = sha.New(strings.TrimSpace(fields[i]))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse commit sha: %w", err)
+		}
+
+		parentFields := strings.Fields(fields[i+1])
+		parentSHAs := make([]sha.SHA, len(parentFields))
+		for j, parent := range parentFields {
+			parentSHAs[j], err = sha.New(parent)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse parent sha: %w", err)
+			}
+		}
+
+		messages = append(messages, CommitMessage{
+			SHA:        commitSHA,
+			ParentSHAs: parentSHAs,
+			Message:    strings.TrimRight(fields[i+2], "\n"),
+		})
+	}
+
+	return messages, nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/git/sha"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestListNewCommitMessages(t *testing.T) {
+	ctx := context.Background()
+	repo := setupResolveRefsRepo(t, 0)
+	base := sha.Must(revParse(t, repo, "HEAD"))
+
+	commit := func(args ...string) {
+		runGit(t, repo, append([]string{"-c", "user.name=test", "-c", "user.email=test@gitness.io",
+			"commit", "--allow-empty"}, args...)...)
+	}
+
+	// create commits that aren't referenced by any reference, same as pushed commits in pre-receive.
+	runGit(t, repo, "checkout", "--detach")
+	commit("-m", "feat: first")
+	first := sha.Must(revParse(t, repo, "HEAD"))
+	commit("-m", "second", "-m", "body", "-m", "Signed-off-by: test <test@gitness.io>")
+	second := sha.Must(revParse(t, repo, "HEAD"))
+	runGit(t, repo, "checkout", "main")
+
+	messages, err := (&Git{}).ListNewCommitMessages(ctx, repo, nil, second.String(), 0)
+	require.NoError(t, err)
+	require.Equal(t, []CommitMessage{
+		{
+			SHA:        second,
+			ParentSHAs: []sha.SHA{first},
+			Message:    "second\n\nbody\n\nSigned-off-by: test <test@gitness.io>",
+		},
+		{
+			SHA:        first,
+			ParentSHAs: []sha.SHA{base},
+			Message:    "feat: first",
+		},
+	}, messages)
+
+	messages, err = (&Git{}).ListNewCommitMessages(ctx, repo, nil, second.String(), 1)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+
+	messages, err = (&Git{}).ListNewCommitMessages(ctx, repo, nil, base.String(), 0)
+	require.NoError(t, err)
+	require.Empty(t, messages)
+}
@@ -15,12 +15,15 @@
 package api
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"fmt"
 	"strings"
 
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/git/parser"
 )
 
 // Config set local git key and value configuration.
@@ -51,3 +54,70 @@ func (g *Git) Config(
 	}
 	return nil
 }
+
+// ListConfig returns all local git configuration of the repository.
+// Keys are returned in their canonical form (section and variable name lowercased).
+// In case a key is set multiple times, the last value wins.
+func (g *Git) ListConfig(
+	ctx context.Context,
+	repoPath string,
+) (map[string]string, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+
+	output := &bytes.Buffer{}
+	cmd := command.New("config",
+		command.WithFlag("--local"),
+		command.WithFlag("--list"),
+		command.WithFlag("--null"),
+	)
+	if err := cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(output)); err != nil {
+		return nil, processGitErrorf(err, "failed to list git config")
+	}
+
+	// with --null, each entry is terminated by NUL and the key is separated from the value by a newline.
+	config := map[string]string{}
+	scanner := bufio.NewScanner(output)
+	scanner.Split(parser.ScanZeroSeparated)
+	for scanner.Scan() {
+		key, value, _ := strings.Cut(scanner.Text(), "\n")
+		config[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read git config: %w", err)
+	}
+
+	return config, nil
+}
+
+// UnsetConfig removes all values of the local git configuration key.
+// Unsetting a key that isn't set is not considered an error.
+func (g *Git) UnsetConfig(
+	ctx context.Context,
+	repoPath string,
+	key string,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+	if key == "" {
+		return errors.InvalidArgument("key cannot be empty")
+	}
+
+	cmd := command.New("config",
+		command.WithFlag("--local"),
+		command.WithFlag("--unset-all"),
+		command.WithArg(key),
+	)
+	err := cmd.Run(ctx, command.WithDir(repoPath))
+	// git config exits with code 5 in case the key isn't set.
+	if cmdErr := command.AsError(err); cmdErr != nil && cmdErr.IsExitCode(5) {
+		return nil
+	}
+	if err != nil {
+		return processGitErrorf(err, "failed to unset git config %q", key)
+	}
+
+	return nil
+}
@@ -0,0 +1,242 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/git/command"
+)
+
+const (
+	fmtAuthorNameMailmap  = "%aN" // author name respecting .mailmap
+	fmtAuthorEmailMailmap = "%aE" // author email respecting .mailmap
+
+	secondsPerDay  = int64(24 * time.Hour / time.Second)
+	secondsPerWeek = 7 * secondsPerDay
+)
+
+// ContributorWeekStats contains the contributions of an author during a single week.
+type ContributorWeekStats struct {
+	// Week is the start of the week (Sunday 00:00 UTC) as unix timestamp.
+	Week      int64
+	Commits   int64
+	Additions int64
+	Deletions int64
+}
+
+// ContributorStats contains the contributions of an author, in total and bucketed by week.
+type ContributorStats struct {
+	Identity  Identity
+	Commits   int64
+	Additions int64
+	Deletions int64
+	// Weeks contains the weekly contributions, ordered by week. Weeks without any commits are omitted.
+	Weeks []ContributorWeekStats
+}
+
+// ContributorStats walks the (non-merge) history of the provided revision and returns the number of commits,
+// additions and deletions per author. Authors are merged using the .mailmap file of the revision, if it exists.
+// If provided, only commits committed in the time range [since, until] are taken into account.
+func (g *Git) ContributorStats(
+	ctx context.Context,
+	repoPath string,
+	rev string,
+	since time.Time,
+	until time.Time,
+) ([]ContributorStats, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+
+	cmd := command.New("log",
+		command.WithConfig("mailmap.blob", rev+":.mailmap"),
+		command.WithFlag("--use-mailmap"),
+		command.WithFlag("--no-merges"),
+		command.WithFlag("--numstat"),
+		command.WithFlag("--no-renames"),
+		command.WithFlag("--format="+fmtZero+fmtAuthorNameMailmap+
+			fmtZero+fmtAuthorEmailMailmap+
+			fmtZero+fmtAuthorUnix),
+	)
+	if !since.IsZero() {
+		cmd.Add(command.WithFlag("--since", "@"+strconv.FormatInt(since.Unix(), 10)))
+	}
+	if !until.IsZero() {
+		cmd.Add(command.WithFlag("--until", "@"+strconv.FormatInt(until.Unix(), 10)))
+	}
+	cmd.Add(command.WithArg(rev))
+
+	pipeRead, pipeWrite := io.Pipe()
+	stderr := &bytes.Buffer{}
+	go func() {
+		var err error
+
+		defer func() {
+			// If running of the command below fails, make the pipe reader also fail with the same error.
+			_ = pipeWrite.CloseWithError(err)
+		}()
+
+		err = cmd.Run(ctx,
+			command.WithDir(repoPath),
+			command.WithStdout(pipeWrite),
+			command.WithStderr(stderr),
+		)
+	}()
+
+	stats, err := parseContributorStats(pipeRead)
+	if err != nil {
+		// drain the pipe to unblock the command in case the parsing failed.
+		_, _ = io.Copy(io.Discard, pipeRead)
+		return nil, processGitErrorf(err, "failed to get contributor stats of %q: %s", rev, stderr.String())
+	}
+
+	return stats, nil
+}
+
+// parseContributorStats parses the output of git log with the contributor stats format and numstat.
+func parseContributorStats(r io.Reader) ([]ContributorStats, error) {
+	type contributor struct {
+		stats ContributorStats
+		weeks map[int64]*ContributorWeekStats
+	}
+
+	contributors := map[Identity]*contributor{}
+	var current *contributor
+	var currentWeek *ContributorWeekStats
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		if header, ok := strings.CutPrefix(line, "\x00"); ok {
+			parts := strings.Split(header, "\x00")
+			if len(parts) != 3 {
+				return nil, fmt.Errorf("unexpected commit header %q", header)
+			}
+
+			authored, err := strconv.ParseInt(parts[2], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse author time %q: %w", parts[2], err)
+			}
+
+			identity := Identity{Name: parts[0], Email: parts[1]}
+			current = contributors[identity]
+			if current == nil {
+				current = &contributor{
+					stats: ContributorStats{Identity: identity},
+					weeks: map[int64]*ContributorWeekStats{},
+				}
+				contributors[identity] = current
+			}
+
+			week := weekStart(authored)
+			currentWeek = current.weeks[week]
+			if currentWeek == nil {
+				currentWeek = &ContributorWeekStats{Week: week}
+				current.weeks[week] = currentWeek
+			}
+
+			current.stats.Commits++
+			currentWeek.Commits++
+
+			continue
+		}
+
+		if current == nil {
+			return nil, fmt.Errorf("unexpected numstat line %q before first commit", line)
+		}
+
+		// numstat line: "<additions>\t<deletions>\t<path>", binary files are reported as "-\t-\t<path>".
+		rawAdditions, rest, ok := strings.Cut(line, "\t")
+		if !ok {
+			return nil, fmt.Errorf("unexpected numstat line %q", line)
+		}
+		rawDeletions, _, ok := strings.Cut(rest, "\t")
+		if !ok {
+			return nil, fmt.Errorf("unexpected numstat line %q", line)
+		}
+		if rawAdditions == "-" || rawDeletions == "-" {
+			continue
+		}
+
+		additions, err := strconv.ParseInt(rawAdditions, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse additions of numstat line %q: %w", line, err)
+		}
+		deletions, err := strconv.ParseInt(rawDeletions, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse deletions of numstat line %q: %w", line, err)
+		}
+
+		current.stats.Additions += additions
+		current.stats.Deletions += deletions
+		currentWeek.Additions += additions
+		currentWeek.Deletions += deletions
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	result := make([]ContributorStats, 0, len(contributors))
+	for _, c := range contributors {
+		c.stats.Weeks = make([]ContributorWeekStats, 0, len(c.weeks))
+		for _, week := range c.weeks {
+			c.stats.Weeks = append(c.stats.Weeks, *week)
+		}
+		sort.Slice(c.stats.Weeks, func(i, j int) bool {
+			return c.stats.Weeks[i].Week < c.stats.Weeks[j].Week
+		})
+
+		result = append(result, c.stats)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Commits != result[j].Commits {
+			return result[i].Commits > result[j].Commits
+		}
+		return result[i].Identity.Email < result[j].Identity.Email
+	})
+
+	return result, nil
+}
+
+// weekStart returns the start of the week (Sunday 00:00 UTC) of the provided unix timestamp.
+func weekStart(unix int64) int64 {
+	days := unix / secondsPerDay
+	if unix < 0 && unix%secondsPerDay != 0 {
+		days--
+	}
+
+	// 1970-01-01 was a Thursday, which is four days after the start of its week.
+	weekday := (days + 4) % 7
+	if weekday < 0 {
+		weekday += 7
+	}
+
+	return (days - weekday) * secondsPerDay
+}
@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContributorStats(t *testing.T) {
+	dir := t.TempDir()
+	repoPath := filepath.Join(dir, "repo.git")
+	local := filepath.Join(dir, "local")
+
+	// use a bare repository like gitness does, otherwise git would read the .mailmap of the work tree.
+	runGit(t, dir, "init", "--bare", repoPath)
+	runGit(t, dir, "init", "-b", "main", local)
+
+	// 2024-01-07 is a Sunday.
+	week1 := time.Date(2024, 1, 8, 12, 0, 0, 0, time.UTC)
+	week2 := week1.AddDate(0, 0, 7)
+
+	commitFile(t, local, "Jane", "jane@old.io", week1, "a.txt", "1\n2\n3\n")
+	commitFile(t, local, "Jane Doe", "jane@new.io", week2, "a.txt", "1\n")
+	commitFile(t, local, "John", "john@gitness.io", week2, "b.bin", "\x00\x01")
+	runGit(t, local, "push", repoPath, "main")
+
+	g := &Git{}
+
+	stats, err := g.ContributorStats(context.Background(), repoPath, "main", time.Time{}, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, stats, 3)
+
+	// without a mailmap, both identities of jane are reported separately (same commit count, ordered by email).
+	require.Equal(t, Identity{Name: "Jane Doe", Email: "jane@new.io"}, stats[0].Identity)
+	require.Equal(t, Identity{Name: "Jane", Email: "jane@old.io"}, stats[1].Identity)
+	require.Equal(t, []ContributorWeekStats{
+		{Week: weekStart(week1.Unix()), Commits: 1, Additions: 3},
+	}, stats[1].Weeks)
+
+	commitFile(t, local, "Jane Doe", "jane@new.io", week2, ".mailmap",
+		"Jane Doe <jane@new.io> <jane@old.io>\n")
+	runGit(t, local, "push", repoPath, "main")
+
+	stats, err = g.ContributorStats(context.Background(), repoPath, "main", time.Time{}, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+
+	require.Equal(t, ContributorStats{
+		Identity:  Identity{Name: "Jane Doe", Email: "jane@new.io"},
+		Commits:   3,
+		Additions: 4,
+		Deletions: 2,
+		Weeks: []ContributorWeekStats{
+			{Week: weekStart(week1.Unix()), Commits: 1, Additions: 3},
+			{Week: weekStart(week2.Unix()), Commits: 2, Additions: 1, Deletions: 2},
+		},
+	}, stats[0])
+	require.Equal(t, Identity{Name: "John", Email: "john@gitness.io"}, stats[1].Identity)
+	require.Equal(t, int64(1), stats[1].Commits)
+	require.Equal(t, int64(0), stats[1].Additions, "binary files don't count as additions")
+
+	// the mailmap is read from the provided revision.
+	stats, err = g.ContributorStats(context.Background(), repoPath, "main~1", time.Time{}, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, stats, 3)
+
+	stats, err = g.ContributorStats(context.Background(), repoPath, "main", week2, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+	require.Equal(t, int64(2), stats[0].Commits)
+}
+
+func TestWeekStart(t *testing.T) {
+	sunday := time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC).Unix()
+
+	for _, ts := range []time.Time{
+		time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 10, 15, 4, 5, 0, time.UTC),
+		time.Date(2024, 1, 13, 23, 59, 59, 0, time.UTC),
+	} {
+		require.Equal(t, sunday, weekStart(ts.Unix()), ts.String())
+	}
+
+	require.Equal(t, time.Date(1969, 12, 28, 0, 0, 0, 0, time.UTC).Unix(), weekStart(-1))
+}
+
+func commitFile(t *testing.T, dir, name, email string, when time.Time, file, content string) {
+	t.Helper()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(content), 0o600))
+	runGit(t, dir, "add", file)
+
+	cmd := exec.Command("git", "-c", "user.name="+name, "-c", "user.email="+email,
+		"commit", "-m", "update "+file)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_DATE="+when.Format(time.RFC3339),
+		"GIT_COMMITTER_DATE="+when.Format(time.RFC3339),
+	)
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
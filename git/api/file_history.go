@@ -0,0 +1,205 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/git/parser"
+)
+
+// FileHistoryFilter contains the filters for listing the history of a file.
+type FileHistoryFilter struct {
+	// Path is the path of the file at the starting revision.
+	Path string
+	// After is the SHA of the commit after which the listing continues (exclusive),
+	// e.g. the last commit of the previous page. Optional, the listing starts at the beginning if empty.
+	After     string
+	Since     int64
+	Until     int64
+	Committer string
+}
+
+// FileHistoryEntry is a commit that changed a file, together with the path the file had at that commit.
+type FileHistoryEntry struct {
+	Commit *Commit
+	Path   string
+}
+
+// FileHistory lists the commits reachable from rev that changed the file, following renames of the file.
+// As git can't skip efficiently over the output of --follow, pagination is done with a commit SHA cursor:
+// the log is streamed until the cursor commit is found and stopped as soon as enough entries are collected.
+// At most limit entries are returned, the returned flag indicates whether there are more entries after them.
+// An empty list is returned if the file never existed.
+func (g *Git) FileHistory(
+	ctx context.Context,
+	repoPath string,
+	rev string,
+	limit int,
+	filter FileHistoryFilter,
+) ([]FileHistoryEntry, bool, error) {
+	if repoPath == "" {
+		return nil, false, ErrRepositoryPathEmpty
+	}
+
+	// resolve the revision first, to return a proper error if it doesn't exist.
+	head, err := getCommit(ctx, repoPath, rev, "")
+	if err != nil {
+		return nil, false, err
+	}
+
+	// stop the git command once enough entries are read.
+	cmdCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	cmd := command.New("log",
+		command.WithFlag("--follow"),
+		command.WithFlag("--name-status"),
+		command.WithFlag("-z"),
+		command.WithFlag("--format=%H"),
+	)
+	if filter.Since > 0 {
+		cmd.Add(command.WithFlag("--since", "@"+strconv.FormatInt(filter.Since, 10)))
+	}
+	if filter.Until > 0 {
+		cmd.Add(command.WithFlag("--until", "@"+strconv.FormatInt(filter.Until, 10)))
+	}
+	if filter.Committer != "" {
+		cmd.Add(command.WithFlag("--committer", filter.Committer))
+	}
+	cmd.Add(command.WithArg(head.SHA.String()))
+	cmd.Add(command.WithPostSepArg(filter.Path))
+
+	pipeRead, pipeWrite := io.Pipe()
+	stderr := &bytes.Buffer{}
+	go func() {
+		var runErr error
+
+		defer func() {
+			// If running of the command below fails, make the pipe reader also fail with the same error.
+			_ = pipeWrite.CloseWithError(runErr)
+		}()
+
+		runErr = cmd.Run(cmdCtx,
+			command.WithDir(repoPath),
+			command.WithStdout(pipeWrite),
+			command.WithStderr(stderr),
+		)
+	}()
+
+	// read one entry more than requested to know whether there are more entries.
+	entries, err := parseFileHistory(pipeRead, filter.Path, filter.After, limit+1)
+
+	// stop the command and drain the pipe to unblock it.
+	cancel()
+	_, _ = io.Copy(io.Discard, pipeRead)
+
+	if err != nil {
+		return nil, false, processGitErrorf(err, "failed to get history of file %q: %s", filter.Path, stderr.String())
+	}
+
+	hasMore := len(entries) > limit
+	if hasMore {
+		entries = entries[:limit]
+	}
+
+	result := make([]FileHistoryEntry, len(entries))
+	for i, entry := range entries {
+		result[i].Path = entry.path
+		result[i].Commit, err = getCommit(ctx, repoPath, entry.sha, "")
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to get commit %q: %w", entry.sha, err)
+		}
+	}
+
+	return result, hasMore, nil
+}
+
+type fileHistoryEntry struct {
+	sha  string
+	path string
+}
+
+// parseFileHistory parses the output of git log --follow --name-status -z --format=%H.
+// Each commit is a SHA token followed by its status token (prefixed with a new line) and one or two paths.
+// Commits without changes (e.g. merge commits) have no status token.
+// Only entries after the commit with the SHA 'after' are returned (all if it's empty), at most 'count' of them.
+func parseFileHistory(r io.Reader, path string, after string, count int) ([]fileHistoryEntry, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	scanner.Split(parser.ScanZeroSeparated)
+
+	next := func() (string, bool) {
+		if !scanner.Scan() {
+			return "", false
+		}
+		return scanner.Text(), true
+	}
+
+	entries := make([]fileHistoryEntry, 0)
+	skipping := after != ""
+
+	// the current path of the file, renames are reported when walking back in history.
+	currentPath := path
+
+	token, ok := next()
+	for ok && len(entries) < count {
+		commitSHA := strings.TrimSpace(token)
+		entry := fileHistoryEntry{sha: commitSHA, path: currentPath}
+
+		token, ok = next()
+		if status, isStatus := strings.CutPrefix(token, "\n"); ok && isStatus {
+			paths := 1
+			if strings.HasPrefix(status, "R") || strings.HasPrefix(status, "C") {
+				paths = 2
+			}
+
+			names := make([]string, 0, paths)
+			for range paths {
+				name, found := next()
+				if !found {
+					return nil, fmt.Errorf("unexpected end of output for commit %s", commitSHA)
+				}
+				names = append(names, name)
+			}
+
+			// the file has the last path at this commit, before this commit it had the first one.
+			entry.path = names[len(names)-1]
+			currentPath = names[0]
+
+			token, ok = next()
+		}
+
+		if skipping {
+			skipping = commitSHA != after
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileHistory(t *testing.T) {
+	dir := t.TempDir()
+	repoPath := filepath.Join(dir, "repo.git")
+	local := filepath.Join(dir, "local")
+
+	runGit(t, dir, "init", "--bare", repoPath)
+	runGit(t, dir, "init", "-b", "main", local)
+
+	when := time.Date(2024, 1, 8, 12, 0, 0, 0, time.UTC)
+	commitFile(t, local, "Jane", "jane@gitness.io", when, "a.txt", "1\n2\n3\n4\n")
+	commitFile(t, local, "Jane", "jane@gitness.io", when, "a.txt", "1\n2\n3\n4\n5\n")
+	runGit(t, local, "mv", "a.txt", "b c.txt")
+	runGit(t, local, "-c", "user.name=Jane", "-c", "user.email=jane@gitness.io", "commit", "-m", "rename")
+	commitFile(t, local, "Jane", "jane@gitness.io", when, "b c.txt", "1\n2\n3\n4\n5\n6\n")
+	commitFile(t, local, "Jane", "jane@gitness.io", when, "other.txt", "x\n")
+	runGit(t, local, "push", repoPath, "main")
+
+	g := &Git{}
+	ctx := context.Background()
+
+	entries, hasMore, err := g.FileHistory(ctx, repoPath, "main", 10, FileHistoryFilter{Path: "b c.txt"})
+	require.NoError(t, err)
+	require.False(t, hasMore)
+	require.Equal(t, []string{"b c.txt", "b c.txt", "a.txt", "a.txt"}, historyPaths(entries))
+	require.Equal(t, "rename", entries[1].Commit.Title)
+
+	// paginate with the SHA of the last commit of the previous page as cursor.
+	page1, hasMore, err := g.FileHistory(ctx, repoPath, "main", 2, FileHistoryFilter{Path: "b c.txt"})
+	require.NoError(t, err)
+	require.True(t, hasMore)
+	require.Len(t, page1, 2)
+
+	page2, hasMore, err := g.FileHistory(ctx, repoPath, "main", 2, FileHistoryFilter{
+		Path:  "b c.txt",
+		After: page1[1].Commit.SHA.String(),
+	})
+	require.NoError(t, err)
+	require.False(t, hasMore)
+	require.Equal(t, []string{"a.txt", "a.txt"}, historyPaths(page2))
+	require.Equal(t, entries[2].Commit.SHA, page2[0].Commit.SHA)
+
+	// a path that never existed returns an empty list.
+	entries, hasMore, err = g.FileHistory(ctx, repoPath, "main", 10, FileHistoryFilter{Path: "missing.txt"})
+	require.NoError(t, err)
+	require.False(t, hasMore)
+	require.Empty(t, entries)
+}
+
+func TestParseFileHistory(t *testing.T) {
+	const (
+		sha1 = "1111111111111111111111111111111111111111"
+		sha2 = "2222222222222222222222222222222222222222"
+		sha3 = "3333333333333333333333333333333333333333"
+		sha4 = "4444444444444444444444444444444444444444"
+	)
+
+	// sha2 is a merge commit without changes.
+	output := strings.Join([]string{
+		sha1, "\nM", "new.txt",
+		sha2,
+		sha3, "\nR087", "old.txt", "new.txt",
+		sha4, "\nA", "old.txt",
+	}, "\x00") + "\x00"
+
+	entries, err := parseFileHistory(strings.NewReader(output), "new.txt", "", 10)
+	require.NoError(t, err)
+	require.Equal(t, []fileHistoryEntry{
+		{sha: sha1, path: "new.txt"},
+		{sha: sha2, path: "new.txt"},
+		{sha: sha3, path: "new.txt"},
+		{sha: sha4, path: "old.txt"},
+	}, entries)
+
+	entries, err = parseFileHistory(strings.NewReader(output), "new.txt", sha2, 1)
+	require.NoError(t, err)
+	require.Equal(t, []fileHistoryEntry{{sha: sha3, path: "new.txt"}}, entries)
+
+	entries, err = parseFileHistory(strings.NewReader(output), "new.txt", "unknown", 10)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func historyPaths(entries []FileHistoryEntry) []string {
+	paths := make([]string, len(entries))
+	for i, entry := range entries {
+		paths[i] = entry.Path
+	}
+	return paths
+}
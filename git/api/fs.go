@@ -120,7 +120,7 @@ func (f *FS) openBlob(path string, treeNode *TreeNode) *fsFile {
 func (f *FS) openSubmodule(path string, treeNode *TreeNode) *fsFile {
 	content := treeNode.SHA.String() + "\n" // content of a submodule is the commit SHA plus end-of-line character.
 	return &fsFile{
-		ctx:      context.Background(),
+		ctx:      f.ctx,
 		cancelFn: func() {},
 		path:     path,
 		blobSHA:  treeNode.SHA,
@@ -132,7 +132,7 @@ func (f *FS) openSubmodule(path string, treeNode *TreeNode) *fsFile {
 
 func (f *FS) openTree(path string, treeNode *TreeNode) *fsDir {
 	return &fsDir{
-		ctx:     context.Background(),
+		ctx:     f.ctx,
 		path:    path,
 		treeSHA: treeNode.SHA,
 		dir:     f.dir,
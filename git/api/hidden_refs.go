@@ -0,0 +1,298 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/command"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// pktMaxLen is the maximum length of a pkt-line (including the 4 bytes of the length prefix).
+	pktMaxLen = 65520
+	// maxWantsValidated is the maximum number of wanted objects of a single upload-pack request
+	// that are checked for being reachable from the advertised references.
+	maxWantsValidated = 10000
+)
+
+// NormalizeHiddenRef returns the reference prefix the way git matches it for transfer.hideRefs.
+// git hides all references below the prefix, globs and trailing slashes would prevent a match
+// (e.g. "refs/pullreq/*" has to be configured as "refs/pullreq").
+func NormalizeHiddenRef(ref string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(strings.TrimSpace(ref), "*"), "/")
+}
+
+// MatchHiddenRef returns the (normalized) hidden reference prefix the provided reference belongs to.
+func MatchHiddenRef(ref string, hiddenRefs []string) (string, bool) {
+	for _, hiddenRef := range hiddenRefs {
+		hiddenRef = NormalizeHiddenRef(hiddenRef)
+		if hiddenRef == "" {
+			continue
+		}
+
+		if ref == hiddenRef || strings.HasPrefix(ref, hiddenRef+"/") {
+			return hiddenRef, true
+		}
+	}
+
+	return "", false
+}
+
+// allHiddenRefs returns the normalized instance wide hidden reference prefixes combined with the provided ones.
+func (g *Git) allHiddenRefs(hiddenRefs []string) []string {
+	all := make([]string, 0, len(g.hiddenRefs)+len(hiddenRefs))
+	for _, refs := range [][]string{g.hiddenRefs, hiddenRefs} {
+		for _, ref := range refs {
+			if ref = NormalizeHiddenRef(ref); ref != "" {
+				all = append(all, ref)
+			}
+		}
+	}
+
+	return all
+}
+
+func (g *Git) hasHiddenRefs(hiddenRefs []string) bool {
+	return len(g.allHiddenRefs(hiddenRefs)) > 0
+}
+
+// wantsValidator wraps the input of upload-pack and ensures that all wanted commits are reachable
+// from the advertised references. Hiding references only removes them from the reference advertisement,
+// protocol v2 (and uploadpack.allowAnySHA1InWant) still allow clients to fetch any object by its id,
+// which would expose the content of hidden references to anyone knowing (or guessing) a commit sha.
+//
+// The input is forwarded to upload-pack one request (everything up to a flush packet) at a time,
+// once all wants of the request have been validated.
+// NOTE: Only commits and tags are validated - blobs and trees requested directly (e.g. the on-demand fetches
+// of a partial clone) are served without reachability check, as computing it would require an object walk.
+type wantsValidator struct {
+	ctx        context.Context
+	git        *Git
+	repoPath   string
+	hiddenRefs []string
+	in         *bufio.Reader
+	out        io.Writer
+
+	validated bytes.Buffer
+	err       error
+}
+
+func (g *Git) newWantsValidator(
+	ctx context.Context,
+	repoPath string,
+	hiddenRefs []string,
+	in io.Reader,
+	out io.Writer,
+) *wantsValidator {
+	return &wantsValidator{
+		ctx:        ctx,
+		git:        g,
+		repoPath:   repoPath,
+		hiddenRefs: hiddenRefs,
+		in:         bufio.NewReaderSize(in, pktMaxLen),
+		out:        out,
+	}
+}
+
+func (v *wantsValidator) Read(p []byte) (int, error) {
+	for v.validated.Len() == 0 {
+		if v.err != nil {
+			return 0, v.err
+		}
+		v.err = v.readRequest()
+	}
+
+	return v.validated.Read(p)
+}
+
+// readRequest reads all packets up to the next flush packet (or the end of the input)
+// and makes them available to upload-pack in case all wanted objects are allowed to be fetched.
+func (v *wantsValidator) readRequest() error {
+	var request bytes.Buffer
+	var wants []string
+	var errRead error
+	for {
+		var raw, payload []byte
+		raw, payload, errRead = readPacket(v.in)
+		if errRead != nil {
+			break
+		}
+
+		request.Write(raw)
+
+		if string(raw) == "0000" {
+			break
+		}
+
+		if oid, ok := parseWant(payload); ok {
+			wants = append(wants, oid)
+		}
+	}
+	if errRead != nil && !errors.Is(errRead, io.EOF) {
+		return errRead
+	}
+
+	if err := v.validate(wants); err != nil {
+		// report rejections to the client the same way upload-pack does for unadvertised objects.
+		if errors.IsInvalidArgument(err) {
+			if errWrite := WriteErrorPacket(v.out, errors.Message(err)); errWrite != nil {
+				log.Ctx(v.ctx).Warn().Err(errWrite).Msg("failed to write upload-pack error to client")
+			}
+		}
+		return err
+	}
+
+	request.WriteTo(&v.validated) //nolint:errcheck // writing to a bytes.Buffer never fails.
+
+	return errRead
+}
+
+// validate returns an error in case any of the wanted commits or tags is only reachable from hidden references.
+func (v *wantsValidator) validate(wants []string) error {
+	if len(wants) == 0 {
+		return nil
+	}
+	if len(wants) > maxWantsValidated {
+		return errors.InvalidArgument("too many objects wanted (max %d)", maxWantsValidated)
+	}
+
+	objects, err := v.git.getObjectTypes(v.ctx, v.repoPath, wants)
+	if err != nil {
+		return fmt.Errorf("failed to get types of wanted objects: %w", err)
+	}
+
+	cmd := command.New("rev-list",
+		v.git.withHiddenRefsConfig(v.hiddenRefs...),
+		command.WithFlag("--max-count=1"),
+	)
+	hasCommits := false
+	for _, oid := range wants {
+		// unknown objects are left to upload-pack, which rejects them.
+		if t := objects[oid]; t == GitObjectTypeCommit || t == GitObjectTypeTag {
+			cmd.Add(command.WithArg(oid))
+			hasCommits = true
+		}
+	}
+	if !hasCommits {
+		return nil
+	}
+
+	cmd.Add(command.WithArg("--not", "--exclude-hidden=uploadpack", "--all"))
+
+	stdout := &bytes.Buffer{}
+	err = cmd.Run(v.ctx, command.WithDir(v.repoPath), command.WithStdout(stdout))
+	if err != nil {
+		return fmt.Errorf("failed to check reachability of wanted objects: %w", err)
+	}
+
+	if unreachable := strings.TrimSpace(stdout.String()); unreachable != "" {
+		return errors.InvalidArgument("upload-pack: not our ref %s", unreachable)
+	}
+
+	return nil
+}
+
+// getObjectTypes returns the types of the provided objects, objects that don't exist aren't part of the result.
+func (g *Git) getObjectTypes(
+	ctx context.Context,
+	repoPath string,
+	oids []string,
+) (map[string]GitObjectType, error) {
+	stdin := &bytes.Buffer{}
+	for _, oid := range oids {
+		stdin.WriteString(oid)
+		stdin.WriteByte('\n')
+	}
+
+	stdout := &bytes.Buffer{}
+	cmd := command.New("cat-file",
+		command.WithFlag("--batch-check=%(objectname) %(objecttype)"),
+	)
+	err := cmd.Run(ctx,
+		command.WithDir(repoPath),
+		command.WithStdin(stdin),
+		command.WithStdout(stdout),
+	)
+	if err != nil {
+		return nil, processGitErrorf(err, "failed to check object types")
+	}
+
+	objectTypes := make(map[string]GitObjectType, len(oids))
+	for _, line := range strings.Split(strings.TrimSpace(stdout.String()), "\n") {
+		oid, objectType, ok := strings.Cut(line, " ")
+		if !ok || objectType == "missing" || objectType == "ambiguous" {
+			continue
+		}
+
+		objectTypes[oid] = GitObjectType(objectType)
+	}
+
+	return objectTypes, nil
+}
+
+// readPacket reads a single pkt-line and returns its raw bytes and its payload.
+// Special packets (flush, delimiter and response end) have no payload.
+func readPacket(r *bufio.Reader) ([]byte, []byte, error) {
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, nil, err
+	}
+
+	length, err := strconv.ParseUint(string(header), 16, 16)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid pkt-line length %q: %w", header, err)
+	}
+
+	switch {
+	case length < 3:
+		return header, nil, nil
+	case length == 3 || length > pktMaxLen:
+		return nil, nil, fmt.Errorf("invalid pkt-line length %d", length)
+	}
+
+	raw := make([]byte, length)
+	copy(raw, header)
+	if _, err = io.ReadFull(r, raw[4:]); err != nil {
+		return nil, nil, fmt.Errorf("failed to read pkt-line payload: %w", err)
+	}
+
+	return raw, raw[4:], nil
+}
+
+// parseWant returns the object id of a want line ("want <oid>[ <capabilities>]").
+func parseWant(payload []byte) (string, bool) {
+	line, ok := strings.CutPrefix(string(payload), "want ")
+	if !ok {
+		return "", false
+	}
+
+	// protocol v0 sends the capabilities as part of the first want line.
+	oid, _, _ := strings.Cut(strings.TrimRight(line, "\n"), " ")
+	if oid == "" {
+		return "", false
+	}
+
+	return oid, true
+}
@@ -0,0 +1,90 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchHiddenRef(t *testing.T) {
+	hiddenRefs := []string{"refs/pullreq/*", "refs/internal/", " ", "refs/keep"}
+
+	tests := []struct {
+		ref      string
+		expected string
+	}{
+		{ref: "refs/pullreq/1/head", expected: "refs/pullreq"},
+		{ref: "refs/internal/a/b", expected: "refs/internal"},
+		{ref: "refs/keep", expected: "refs/keep"},
+		{ref: "refs/keeper"},
+		{ref: "refs/heads/main"},
+	}
+	for _, test := range tests {
+		t.Run(test.ref, func(t *testing.T) {
+			prefix, ok := MatchHiddenRef(test.ref, hiddenRefs)
+			require.Equal(t, test.expected != "", ok)
+			require.Equal(t, test.expected, prefix)
+		})
+	}
+}
+
+func TestUploadPackHiddenRefWants(t *testing.T) {
+	dir := t.TempDir()
+	repoPath := filepath.Join(dir, "repo.git")
+	runGit(t, dir, "init", "--bare", repoPath)
+	importLinearHistory(t, repoPath, 10)
+
+	// a commit that is only reachable from a hidden reference.
+	hiddenSHA := gitOutput(t, repoPath, "-c", "user.name=test", "-c", "user.email=test@example.com",
+		"commit-tree", "main^{tree}", "-p", "main", "-m", "hidden")
+	runGit(t, repoPath, "update-ref", "refs/internal/1", hiddenSHA)
+	visibleSHA := gitOutput(t, repoPath, "rev-parse", "main~1")
+
+	for _, version := range []string{"0", "2"} {
+		t.Run("protocol v"+version, func(t *testing.T) {
+			// allowAnySHA1InWant (enabled with partial clones) lets protocol v0 clients want unadvertised objects.
+			g := &Git{allowPartialClone: true, hiddenRefs: []string{"refs/internal"}}
+			server := newSmartHTTPServer(t, g, repoPath)
+
+			local := filepath.Join(t.TempDir(), "local.git")
+			runGit(t, dir, "init", "--bare", local)
+
+			runGit(t, local, "-c", "protocol.version="+version, "fetch", "--quiet", server.URL, visibleSHA)
+			require.Equal(t, visibleSHA, gitOutput(t, local, "rev-parse", "FETCH_HEAD"))
+
+			out, err := gitCombinedOutput(local, "-c", "protocol.version="+version,
+				"fetch", "--quiet", server.URL, hiddenSHA)
+			require.Error(t, err)
+			require.Contains(t, out, "not our ref "+hiddenSHA)
+
+			// without hidden references the commit can be fetched by its sha.
+			server = newSmartHTTPServer(t, &Git{allowPartialClone: true}, repoPath)
+			runGit(t, local, "-c", "protocol.version="+version, "fetch", "--quiet", server.URL, hiddenSHA)
+			require.Equal(t, hiddenSHA, gitOutput(t, local, "rev-parse", "FETCH_HEAD"))
+		})
+	}
+}
+
+func gitCombinedOutput(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+
+	return string(out), err
+}
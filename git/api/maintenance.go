@@ -0,0 +1,80 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/command"
+)
+
+// optimizationConfig is the git config that makes git write commit-graph files (with changed-path
+// Bloom filters) and pack bitmaps during gc, and use them when counting commits and serving fetches.
+var optimizationConfig = [][2]string{
+	{"core.commitGraph", "true"},
+	{"commitGraph.readChangedPaths", "true"},
+	{"gc.writeCommitGraph", "true"},
+	{"pack.useBitmaps", "true"},
+	{"pack.writeBitmapHashCache", "true"},
+	{"repack.writeBitmaps", "true"},
+}
+
+// withOptimizationConfig applies the optimization config to a git command.
+// The config is inherited by child processes, e.g. by the automatic gc after a push.
+func withOptimizationConfig() command.CmdOptionFunc {
+	return func(c *command.Command) {
+		for _, kv := range optimizationConfig {
+			command.WithConfig(kv[0], kv[1])(c)
+		}
+	}
+}
+
+// OptimizeRepository runs gc on the repository, which writes pack bitmaps,
+// and writes the commit-graph with changed-path Bloom filters.
+//
+// It's safe to run concurrently with fetches and pushes:
+// gc doesn't run if another gc holds the gc.pid lock of the repository,
+// unreachable objects are kept loose (and only pruned after the gc.pruneExpire grace period),
+// and the commit-graph chain is replaced atomically.
+func (g *Git) OptimizeRepository(ctx context.Context, repoPath string) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+
+	cmd := command.New("gc",
+		withOptimizationConfig(),
+		// the commit-graph is written below with changed-path Bloom filters.
+		command.WithConfig("gc.writeCommitGraph", "false"),
+		command.WithFlag("--quiet"),
+	)
+	if err := cmd.Run(ctx, command.WithDir(repoPath)); err != nil {
+		return errors.Internal(err, "failed to run gc")
+	}
+
+	cmd = command.New("commit-graph",
+		withOptimizationConfig(),
+		command.WithAction("write"),
+		command.WithFlag("--reachable"),
+		command.WithFlag("--changed-paths"),
+		command.WithFlag("--split"),
+		command.WithFlag("--no-progress"),
+	)
+	if err := cmd.Run(ctx, command.WithDir(repoPath)); err != nil {
+		return errors.Internal(err, "failed to write commit-graph")
+	}
+
+	return nil
+}
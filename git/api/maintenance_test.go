@@ -0,0 +1,125 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestOptimizeRepositorySpeedsUpCommitDivergence verifies that the ahead/behind count of branches
+// is significantly faster once the maintenance wrote the commit-graph of the repository.
+func TestOptimizeRepositorySpeedsUpCommitDivergence(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping creation of a repository with 10k commits in short mode")
+	}
+
+	const commitCount = 10000
+
+	ctx := context.Background()
+	repoPath := filepath.Join(t.TempDir(), "repo.git")
+	runGit(t, "", "init", "--bare", repoPath)
+	importLinearHistory(t, repoPath, commitCount)
+
+	g := &Git{}
+	requests := []CommitDivergenceRequest{{From: "refs/heads/old", To: "refs/heads/main"}}
+
+	measure := func() time.Duration {
+		best := time.Duration(0)
+		for i := 0; i < 5; i++ {
+			start := time.Now()
+			divergences, err := g.GetCommitDivergences(ctx, repoPath, requests, 0)
+			elapsed := time.Since(start)
+
+			require.NoError(t, err)
+			require.Equal(t, []CommitDivergence{{Ahead: 0, Behind: commitCount - 100}}, divergences)
+
+			if best == 0 || elapsed < best {
+				best = elapsed
+			}
+		}
+		return best
+	}
+
+	withoutGraph := measure()
+
+	require.NoError(t, g.OptimizeRepository(ctx, repoPath))
+	require.FileExists(t, filepath.Join(repoPath, "objects", "info", "commit-graphs", "commit-graph-chain"))
+	bitmaps, err := filepath.Glob(filepath.Join(repoPath, "objects", "pack", "*.bitmap"))
+	require.NoError(t, err)
+	require.NotEmpty(t, bitmaps)
+
+	withGraph := measure()
+
+	t.Logf("ahead/behind of %d commits: %s without commit-graph, %s with commit-graph",
+		commitCount, withoutGraph, withGraph)
+	require.Less(t, 2*withGraph, withoutGraph, "commit-graph should at least halve the ahead/behind duration")
+}
+
+func TestOptimizeRepositoryConcurrentWithFetch(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	repoPath := filepath.Join(dir, "repo.git")
+	runGit(t, dir, "init", "--bare", repoPath)
+	importLinearHistory(t, repoPath, 500)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- (&Git{}).OptimizeRepository(ctx, repoPath)
+	}()
+
+	for i := 0; i < 3; i++ {
+		runGit(t, dir, "clone", "--quiet", "--bare", repoPath, filepath.Join(dir, fmt.Sprintf("clone-%d.git", i)))
+	}
+
+	require.NoError(t, <-errCh)
+
+	runGit(t, dir, "clone", "--quiet", "--bare", repoPath, filepath.Join(dir, "clone-after.git"))
+	runGit(t, repoPath, "fsck", "--connectivity-only")
+}
+
+// importLinearHistory creates refs/heads/main with a linear history of the provided number of commits
+// and refs/heads/old pointing to its 100th commit (or the last one for shorter histories).
+func importLinearHistory(t *testing.T, repoPath string, commitCount int) {
+	t.Helper()
+
+	stream := &bytes.Buffer{}
+	for i := 1; i <= commitCount; i++ {
+		msg := fmt.Sprintf("commit %d", i)
+		content := fmt.Sprintf("%d", i)
+		fmt.Fprintf(stream, "commit refs/heads/main\nmark :%d\n", i)
+		fmt.Fprintf(stream, "committer test <test@gitness.io> %d +0000\n", 1700000000+i)
+		fmt.Fprintf(stream, "data %d\n%s\n", len(msg), msg)
+		if i > 1 {
+			fmt.Fprintf(stream, "from :%d\n", i-1)
+		}
+		fmt.Fprintf(stream, "M 644 inline file-%d.txt\ndata %d\n%s\n\n", i%100, len(content), content)
+	}
+	fmt.Fprintf(stream, "reset refs/heads/old\nfrom :%d\n\n", min(100, commitCount))
+
+	cmd := exec.Command("git", "fast-import", "--quiet")
+	cmd.Dir = repoPath
+	cmd.Stdin = stream
+	cmd.Stderr = os.Stderr
+	require.NoError(t, cmd.Run())
+}
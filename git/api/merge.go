@@ -17,7 +17,9 @@ package api
 import (
 	"bytes"
 	"context"
+	"fmt"
 
+	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git/command"
 	"github.com/harness/gitness/git/sha"
 )
@@ -25,6 +27,9 @@ import (
 const (
 	// RemotePrefix is the base directory of the remotes information of git.
 	RemotePrefix = "refs/remotes/"
+
+	// ErrCodeUnrelatedHistories is the error code of errors caused by commits without a common ancestor.
+	ErrCodeUnrelatedHistories = "unrelated_histories"
 )
 
 // GetMergeBase checks and returns merge base of two branches and the reference used as base.
@@ -65,6 +70,14 @@ func (g *Git) GetMergeBase(
 		command.WithStdout(stdout),
 	)
 	if err != nil {
+		cmdErr := command.AsError(err)
+		if cmdErr != nil && cmdErr.IsExitCode(1) && len(cmdErr.StdErr) == 0 {
+			return sha.None, "", errors.NotFound("no merge base found for %q and %q, the histories are unrelated",
+				base, head).SetDetails(map[string]any{"code": ErrCodeUnrelatedHistories})
+		}
+		if cmdErr != nil && cmdErr.IsExitCode(128) && bytes.Contains(cmdErr.StdErr, []byte("Not a valid object name")) {
+			return sha.None, "", errors.NotFound("failed to resolve %q or %q", base, head)
+		}
 		return sha.None, "", processGitErrorf(err, "failed to get merge-base [%s, %s]", base, head)
 	}
 
@@ -105,3 +118,143 @@ func (g *Git) IsAncestor(
 
 	return true, nil
 }
+
+// AncestryCheck is a single request of a batched ancestry check.
+type AncestryCheck struct {
+	Ancestor   sha.SHA
+	Descendant sha.SHA
+}
+
+// maxAncestryWalk is the max number of commits read per descendant by AreAncestors
+// before the remaining checks of the descendant fall back to git merge-base.
+const maxAncestryWalk = 1000
+
+// AreAncestors returns for each of the provided checks whether the ancestor commit is an ancestor of the descendant.
+// The commit graph is walked using a single git cat-file process shared by all checks.
+// Checks that can't be decided within a bounded walk fall back to IsAncestor.
+func (g *Git) AreAncestors(
+	ctx context.Context,
+	repoPath string,
+	alternates []string,
+	checks []AncestryCheck,
+) ([]bool, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+
+	results := make([]bool, len(checks))
+	if len(checks) == 0 {
+		return results, nil
+	}
+
+	writer, reader, cancel := CatFileBatch(ctx, repoPath, alternates)
+	defer func() {
+		cancel()
+		_ = writer.Close()
+	}()
+
+	// parents caches the parents of all commits read during the walks.
+	parents := map[string][]sha.SHA{}
+	readParents := func(commitSHA sha.SHA) ([]sha.SHA, error) {
+		if p, ok := parents[commitSHA.String()]; ok {
+			return p, nil
+		}
+		if _, err := writer.Write([]byte(commitSHA.String() + "\n")); err != nil {
+			return nil, fmt.Errorf("failed to write to cat-file: %w", err)
+		}
+		commit, err := getCommitFromBatchReader(ctx, repoPath, reader, commitSHA.String())
+		if err != nil {
+			return nil, err
+		}
+		parents[commitSHA.String()] = commit.ParentSHAs
+		return commit.ParentSHAs, nil
+	}
+
+	// group the checks by descendant, so each descendant is walked only once.
+	byDescendant := map[string][]int{}
+	descendants := make([]sha.SHA, 0, len(checks))
+	for i, check := range checks {
+		key := check.Descendant.String()
+		if _, ok := byDescendant[key]; !ok {
+			descendants = append(descendants, check.Descendant)
+		}
+		byDescendant[key] = append(byDescendant[key], i)
+	}
+
+	for _, descendant := range descendants {
+		pending := map[string][]int{}
+		for _, idx := range byDescendant[descendant.String()] {
+			ancestor := checks[idx].Ancestor.String()
+			pending[ancestor] = append(pending[ancestor], idx)
+		}
+
+		complete, err := walkAncestors(descendant, maxAncestryWalk, readParents, func(commitSHA sha.SHA) bool {
+			for _, idx := range pending[commitSHA.String()] {
+				results[idx] = true
+			}
+			delete(pending, commitSHA.String())
+			return len(pending) == 0
+		})
+		if err != nil {
+			return nil, err
+		}
+		if complete {
+			continue
+		}
+
+		// the walk was stopped before the whole history was visited, let git decide the remaining checks.
+		for _, indices := range pending {
+			check := checks[indices[0]]
+			var isAncestor bool
+			isAncestor, err = g.IsAncestor(ctx, repoPath, alternates, check.Ancestor, check.Descendant)
+			if err != nil {
+				return nil, err
+			}
+			for _, idx := range indices {
+				results[idx] = isAncestor
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// walkAncestors visits the commit and its ancestors in breadth-first order until the visitor returns true,
+// all ancestors got visited, or the max number of commits has been read.
+// It returns true if the walk wasn't stopped because of the max number of commits.
+func walkAncestors(
+	commitSHA sha.SHA,
+	maxCommits int,
+	readParents func(sha.SHA) ([]sha.SHA, error),
+	visit func(sha.SHA) bool,
+) (bool, error) {
+	visited := map[string]struct{}{commitSHA.String(): {}}
+	queue := []sha.SHA{commitSHA}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if visit(current) {
+			return true, nil
+		}
+
+		if len(visited) > maxCommits {
+			return false, nil
+		}
+
+		parentSHAs, err := readParents(current)
+		if err != nil {
+			return false, fmt.Errorf("failed to read parents of commit %s: %w", current, err)
+		}
+
+		for _, parentSHA := range parentSHAs {
+			if _, ok := visited[parentSHA.String()]; ok {
+				continue
+			}
+			visited[parentSHA.String()] = struct{}{}
+			queue = append(queue, parentSHA)
+		}
+	}
+
+	return true, nil
+}
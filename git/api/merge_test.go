@@ -0,0 +1,80 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/sha"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAreAncestors(t *testing.T) {
+	ctx := context.Background()
+	repo := setupResolveRefsRepo(t, 0)
+	commit := func(message string) {
+		runGit(t, repo, "-c", "user.name=test", "-c", "user.email=test@gitness.io",
+			"commit", "--allow-empty", "-m", message)
+	}
+
+	first := sha.Must(revParse(t, repo, "HEAD"))
+	commit("second")
+	runGit(t, repo, "checkout", "-b", "feature")
+	commit("feature")
+	feature := sha.Must(revParse(t, repo, "HEAD"))
+	runGit(t, repo, "checkout", "main")
+	commit("third")
+	main := sha.Must(revParse(t, repo, "HEAD"))
+
+	checks := []AncestryCheck{
+		{Ancestor: first, Descendant: main},
+		{Ancestor: first, Descendant: feature},
+		{Ancestor: feature, Descendant: main},
+		{Ancestor: main, Descendant: first},
+		{Ancestor: main, Descendant: main},
+	}
+	expected := []bool{true, true, false, false, true}
+
+	results, err := (&Git{}).AreAncestors(ctx, repo, nil, checks)
+	require.NoError(t, err)
+	require.Equal(t, expected, results)
+
+	// the batched results have to match the ones of git merge-base --is-ancestor.
+	for i, check := range checks {
+		isAncestor, errAncestor := (&Git{}).IsAncestor(ctx, repo, nil, check.Ancestor, check.Descendant)
+		require.NoError(t, errAncestor)
+		require.Equal(t, expected[i], isAncestor)
+	}
+}
+
+func TestGetMergeBaseUnrelatedHistories(t *testing.T) {
+	ctx := context.Background()
+	repo := setupResolveRefsRepo(t, 0)
+
+	runGit(t, repo, "checkout", "--orphan", "unrelated")
+	runGit(t, repo, "-c", "user.name=test", "-c", "user.email=test@gitness.io",
+		"commit", "--allow-empty", "-m", "unrelated")
+
+	_, _, err := (&Git{}).GetMergeBase(ctx, repo, "", "main", "unrelated")
+	require.True(t, errors.IsNotFound(err))
+	require.Equal(t, ErrCodeUnrelatedHistories, errors.Details(err)["code"])
+
+	_, _, err = (&Git{}).GetMergeBase(ctx, repo, "", "main", "missing")
+	require.True(t, errors.IsNotFound(err))
+	require.Nil(t, errors.Details(err)["code"])
+}
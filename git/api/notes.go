@@ -0,0 +1,101 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/git/sha"
+)
+
+// DefaultNotesRef is the reference git stores commit notes in by default.
+const DefaultNotesRef = "refs/notes/commits"
+
+// NotesRefPrefix is the prefix of all references containing git notes.
+const NotesRefPrefix = "refs/notes/"
+
+// GetNotes returns the notes attached to the provided commits in the provided notes reference.
+// Commits without a note aren't part of the result.
+func (g *Git) GetNotes(
+	ctx context.Context,
+	repoPath string,
+	notesRef string,
+	commitSHAs []sha.SHA,
+) (map[sha.SHA]string, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+
+	notes := make(map[sha.SHA]string, len(commitSHAs))
+	if len(commitSHAs) == 0 {
+		return notes, nil
+	}
+
+	// git log reads the notes independent of the fanout of the notes tree.
+	cmd := command.New("log",
+		command.WithFlag("--no-walk=unsorted"),
+		command.WithFlag("--notes="+notesRef),
+		command.WithFlag("--format=%H%x00%N%x00"),
+	)
+	for _, commitSHA := range commitSHAs {
+		cmd.Add(command.WithArg(commitSHA.String()))
+	}
+
+	stdout := &bytes.Buffer{}
+	if err := cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(stdout)); err != nil {
+		return nil, processGitErrorf(err, "failed to read notes from %q", notesRef)
+	}
+
+	fields := strings.Split(stdout.String(), "\x00")
+	for i := 0; i+1 < len(fields); i += 2 {
+		note := fields[i+1]
+		if note == "" {
+			continue
+		}
+
+		commitSHA, err := sha.New(strings.TrimSpace(fields[i]))
+		if err != nil {
+			return nil, err
+		}
+
+		notes[commitSHA] = strings.TrimSuffix(note, "\n")
+	}
+
+	return notes, nil
+}
+
+// NotePaths returns the possible paths of the note of a commit within the notes tree.
+// git fans out the notes tree into subdirectories (e.g. "ab/cdef...") once it contains many notes.
+func NotePaths(commitSHA sha.SHA) []string {
+	const maxFanout = 2
+
+	s := commitSHA.String()
+	paths := make([]string, 0, maxFanout+1)
+	for fanout := 0; fanout <= maxFanout; fanout++ {
+		var path strings.Builder
+		for i := 0; i < fanout; i++ {
+			path.WriteString(s[2*i : 2*i+2])
+			path.WriteByte('/')
+		}
+		path.WriteString(s[2*fanout:])
+
+		paths = append(paths, path.String())
+	}
+
+	return paths
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/harness/gitness/git/sha"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetNotes(t *testing.T) {
+	repoPath := filepath.Join(t.TempDir(), "repo.git")
+	runGit(t, "", "init", "--bare", repoPath)
+	importLinearHistory(t, repoPath, 3)
+
+	first := sha.Must(gitOutput(t, repoPath, "rev-parse", "main~2"))
+	second := sha.Must(gitOutput(t, repoPath, "rev-parse", "main~1"))
+	third := sha.Must(gitOutput(t, repoPath, "rev-parse", "main"))
+
+	notes := func(args ...string) {
+		runGit(t, repoPath, append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com",
+			"notes"}, args...)...)
+	}
+	notes("add", "-m", "build: passed", first.String())
+	notes("add", "-m", "line 1\n\nline 2", third.String())
+	notes("--ref=refs/notes/review", "add", "-m", "approved", second.String())
+
+	g := &Git{}
+
+	out, err := g.GetNotes(context.Background(), repoPath, DefaultNotesRef, []sha.SHA{first, second, third})
+	require.NoError(t, err)
+	require.Equal(t, map[sha.SHA]string{
+		first: "build: passed",
+		third: "line 1\n\nline 2",
+	}, out)
+
+	out, err = g.GetNotes(context.Background(), repoPath, "refs/notes/review", []sha.SHA{first, second})
+	require.NoError(t, err)
+	require.Equal(t, map[sha.SHA]string{second: "approved"}, out)
+
+	out, err = g.GetNotes(context.Background(), repoPath, "refs/notes/missing", []sha.SHA{first})
+	require.NoError(t, err)
+	require.Empty(t, out)
+}
+
+func TestNotePaths(t *testing.T) {
+	commitSHA := sha.Must("0123456789abcdef0123456789abcdef01234567")
+
+	require.Equal(t, []string{
+		"0123456789abcdef0123456789abcdef01234567",
+		"01/23456789abcdef0123456789abcdef01234567",
+		"01/23/456789abcdef0123456789abcdef01234567",
+	}, NotePaths(commitSHA))
+}
@@ -72,3 +72,72 @@ func (g *Git) HashObject(ctx context.Context, repoPath string, reader io.Reader)
 	}
 	return sha.New(stdout.String())
 }
+
+// HashBlob computes the SHA of the blob content read from the reader without writing it to the object database.
+func (g *Git) HashBlob(ctx context.Context, repoPath string, reader io.Reader) (sha.SHA, error) {
+	if repoPath == "" {
+		return sha.None, ErrRepositoryPathEmpty
+	}
+	cmd := command.New("hash-object",
+		command.WithFlag("-t", "blob"),
+		command.WithFlag("--stdin"),
+	)
+	stdout := new(bytes.Buffer)
+	err := cmd.Run(ctx,
+		command.WithDir(repoPath),
+		command.WithStdin(reader),
+		command.WithStdout(stdout),
+	)
+	if err != nil {
+		return sha.None, processGitErrorf(err, "failed to hash blob")
+	}
+	return sha.New(stdout.String())
+}
+
+// BlobsExist returns for each of the provided SHAs whether a blob with that SHA exists in the repository.
+// All SHAs are checked with a single git cat-file invocation.
+func (g *Git) BlobsExist(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	shas []sha.SHA,
+) ([]bool, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+	if len(shas) == 0 {
+		return []bool{}, nil
+	}
+
+	stdin := &bytes.Buffer{}
+	for _, objectSHA := range shas {
+		stdin.WriteString(objectSHA.String())
+		stdin.WriteByte('\n')
+	}
+
+	cmd := command.New("cat-file",
+		command.WithFlag("--batch-check=%(objectname) %(objecttype)"),
+		command.WithAlternateObjectDirs(alternateObjectDirs...),
+	)
+	stdout := &bytes.Buffer{}
+	err := cmd.Run(ctx,
+		command.WithDir(repoPath),
+		command.WithStdin(stdin),
+		command.WithStdout(stdout),
+	)
+	if err != nil {
+		return nil, processGitErrorf(err, "failed to check existence of blobs")
+	}
+
+	objects, err := parseResolveOutput(stdout, len(shas))
+	if err != nil {
+		return nil, err
+	}
+
+	exist := make([]bool, len(shas))
+	for i, object := range objects {
+		exist[i] = object != nil && object.objType == string(GitObjectTypeBlob)
+	}
+
+	return exist, nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/git/sha"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashBlobAndBlobsExist(t *testing.T) {
+	ctx := context.Background()
+	repo := setupResolveRefsRepo(t, 0)
+
+	// file.txt with "content" is part of the repository, "other content" isn't.
+	existingSHA, err := (&Git{}).HashBlob(ctx, repo, strings.NewReader("content"))
+	require.NoError(t, err)
+	require.Equal(t, revParse(t, repo, "HEAD:file.txt"), existingSHA.String())
+
+	newSHA, err := (&Git{}).HashBlob(ctx, repo, strings.NewReader("other content"))
+	require.NoError(t, err)
+
+	exist, err := (&Git{}).BlobsExist(ctx, repo, nil, []sha.SHA{
+		existingSHA,
+		newSHA,
+		sha.Must(revParse(t, repo, "HEAD")),
+	})
+	require.NoError(t, err)
+	require.Equal(t, []bool{true, false, false}, exist, "hashing must not write the blob, commits aren't blobs")
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/git/sha"
+)
+
+// ResolveCommitSHA resolves a full or abbreviated commit SHA to the full SHA of the commit.
+// An abbreviated SHA that matches multiple commits is rejected as invalid argument.
+func (g *Git) ResolveCommitSHA(
+	ctx context.Context,
+	repoPath string,
+	commitSHA sha.SHA,
+) (sha.SHA, error) {
+	if repoPath == "" {
+		return sha.None, ErrRepositoryPathEmpty
+	}
+
+	cmd := command.New("rev-parse",
+		command.WithFlag("--verify"),
+		command.WithArg(commitSHA.String()+"^{commit}"),
+	)
+	output := &bytes.Buffer{}
+	err := cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(output))
+	if err != nil {
+		if strings.Contains(err.Error(), "is ambiguous") {
+			return sha.None, errors.InvalidArgument("the commit sha '%s' is ambiguous", commitSHA)
+		}
+		if cmdErr := command.AsError(err); cmdErr != nil && cmdErr.IsExitCode(128) {
+			return sha.None, errors.NotFound("commit '%s' not found", commitSHA)
+		}
+		return sha.None, processGitErrorf(err, "failed to resolve commit sha")
+	}
+
+	return sha.New(output.String())
+}
+
+// FormatPatch streams the commits in the range (baseRef, headRef] in mailbox format (git format-patch),
+// oldest commit first. If baseRef is empty, only the headRef commit is formatted.
+func (g *Git) FormatPatch(
+	ctx context.Context,
+	repoPath string,
+	alternates []string,
+	baseRef string,
+	headRef string,
+	w io.Writer,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+	if headRef == "" {
+		return errors.InvalidArgument("git revision cannot be empty")
+	}
+
+	cmd := command.New("format-patch",
+		command.WithFlag("--stdout"),
+		command.WithFlag("--full-index"),
+		command.WithFlag("--no-signature"),
+		command.WithAlternateObjectDirs(alternates...),
+	)
+	if baseRef == "" {
+		cmd.Add(command.WithFlag("-1"), command.WithArg(headRef))
+	} else {
+		cmd.Add(command.WithArg(baseRef + ".." + headRef))
+	}
+
+	if err := cmd.Run(ctx,
+		command.WithDir(repoPath),
+		command.WithStdout(w),
+	); err != nil {
+		return processGitErrorf(err, "format patch error")
+	}
+
+	return nil
+}
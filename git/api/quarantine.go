@@ -0,0 +1,127 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// quarantineDirPrefix is the prefix of the temporary object directories git receive-pack
+// uses to quarantine the objects of a push until all pre-receive checks passed.
+const quarantineDirPrefix = "incoming-"
+
+// ListQuarantineDirs returns the names of the quarantine directories of the repository.
+func (g *Git) ListQuarantineDirs(repoPath string) ([]string, error) {
+	dirs, err := listQuarantineDirs(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(dirs))
+	for i := range dirs {
+		names[i] = dirs[i].name
+	}
+
+	return names, nil
+}
+
+// RemoveStaleQuarantineDirs removes quarantine directories of pushes that didn't complete.
+// Git removes the quarantine directory of a rejected push on its own, but not if the
+// receive-pack process got killed (e.g. on timeout or shutdown).
+// Only directories last modified before the provided time are removed, to not interfere with in-flight pushes.
+func (g *Git) RemoveStaleQuarantineDirs(ctx context.Context, repoPath string, before time.Time) error {
+	return removeQuarantineDirs(ctx, repoPath, func(dir quarantineDir) bool {
+		return dir.modTime.Before(before)
+	})
+}
+
+// RemoveNewQuarantineDirs removes the quarantine directories that aren't part of the provided existing
+// directories and weren't modified after the provided time. It's used to remove the quarantine directory
+// of a failed push right away, using the quarantine directories that existed before the push started.
+func (g *Git) RemoveNewQuarantineDirs(
+	ctx context.Context,
+	repoPath string,
+	existing []string,
+	notAfter time.Time,
+) error {
+	existingSet := make(map[string]struct{}, len(existing))
+	for _, name := range existing {
+		existingSet[name] = struct{}{}
+	}
+
+	return removeQuarantineDirs(ctx, repoPath, func(dir quarantineDir) bool {
+		_, ok := existingSet[dir.name]
+		return !ok && !dir.modTime.After(notAfter)
+	})
+}
+
+type quarantineDir struct {
+	name    string
+	modTime time.Time
+}
+
+func listQuarantineDirs(repoPath string) ([]quarantineDir, error) {
+	entries, err := os.ReadDir(filepath.Join(repoPath, "objects"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read objects directory: %w", err)
+	}
+
+	var dirs []quarantineDir
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), quarantineDirPrefix) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if os.IsNotExist(err) {
+			continue // removed by git in the meantime
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get info of quarantine directory %q: %w", entry.Name(), err)
+		}
+
+		dirs = append(dirs, quarantineDir{name: entry.Name(), modTime: info.ModTime()})
+	}
+
+	return dirs, nil
+}
+
+func removeQuarantineDirs(ctx context.Context, repoPath string, shouldRemove func(quarantineDir) bool) error {
+	dirs, err := listQuarantineDirs(repoPath)
+	if err != nil {
+		return err
+	}
+
+	for _, dir := range dirs {
+		if !shouldRemove(dir) {
+			continue
+		}
+
+		if err = os.RemoveAll(filepath.Join(repoPath, "objects", dir.name)); err != nil {
+			return fmt.Errorf("failed to remove quarantine directory %q: %w", dir.name, err)
+		}
+
+		log.Ctx(ctx).Info().Msgf("removed quarantine directory %q", dir.name)
+	}
+
+	return nil
+}
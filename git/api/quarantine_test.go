@@ -0,0 +1,134 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRejectedPushLeavesObjectCountUnchanged(t *testing.T) {
+	dir := t.TempDir()
+	remote := filepath.Join(dir, "remote.git")
+	local := filepath.Join(dir, "local")
+
+	runGit(t, dir, "init", "--bare", remote)
+	err := os.WriteFile(filepath.Join(remote, "hooks", "pre-receive"), []byte("#!/bin/sh\nexit 1\n"), 0o700)
+	require.NoError(t, err)
+
+	countBefore := countObjects(t, remote)
+
+	runGit(t, dir, "init", local)
+	require.NoError(t, os.WriteFile(filepath.Join(local, "file.txt"), []byte("content"), 0o600))
+	runGit(t, local, "add", "file.txt")
+	runGit(t, local, "-c", "user.name=test", "-c", "user.email=test@gitness.io", "commit", "-m", "test")
+
+	push := exec.Command("git", "push", remote, "HEAD:refs/heads/main")
+	push.Dir = local
+	require.Error(t, push.Run(), "push should be rejected by the pre-receive hook")
+
+	require.Equal(t, countBefore, countObjects(t, remote))
+
+	entries, err := os.ReadDir(filepath.Join(remote, "objects"))
+	require.NoError(t, err)
+	for _, entry := range entries {
+		require.False(t, strings.HasPrefix(entry.Name(), quarantineDirPrefix), "quarantine dir %q left", entry.Name())
+	}
+}
+
+func TestRemoveStaleQuarantineDirs(t *testing.T) {
+	repoPath := t.TempDir()
+	stale := filepath.Join(repoPath, "objects", quarantineDirPrefix+"stale")
+	active := filepath.Join(repoPath, "objects", quarantineDirPrefix+"active")
+	pack := filepath.Join(repoPath, "objects", "pack")
+
+	for _, path := range []string{stale, active, pack} {
+		require.NoError(t, os.MkdirAll(path, 0o700))
+	}
+
+	old := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, os.Chtimes(stale, old, old))
+	require.NoError(t, os.Chtimes(pack, old, old))
+
+	err := (&Git{}).RemoveStaleQuarantineDirs(context.Background(), repoPath, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+
+	require.NoDirExists(t, stale)
+	require.DirExists(t, active)
+	require.DirExists(t, pack)
+}
+
+func TestRemoveNewQuarantineDirs(t *testing.T) {
+	repoPath := t.TempDir()
+	existing := filepath.Join(repoPath, "objects", quarantineDirPrefix+"existing")
+	require.NoError(t, os.MkdirAll(existing, 0o700))
+
+	g := &Git{}
+	existingDirs, err := g.ListQuarantineDirs(repoPath)
+	require.NoError(t, err)
+	require.Equal(t, []string{quarantineDirPrefix + "existing"}, existingDirs)
+
+	// the quarantine dir of the failed push and one of a push that started after the failure.
+	failed := filepath.Join(repoPath, "objects", quarantineDirPrefix+"failed")
+	later := filepath.Join(repoPath, "objects", quarantineDirPrefix+"later")
+	require.NoError(t, os.MkdirAll(failed, 0o700))
+	require.NoError(t, os.MkdirAll(later, 0o700))
+
+	failedAt := time.Now()
+	require.NoError(t, os.Chtimes(failed, failedAt.Add(-time.Second), failedAt.Add(-time.Second)))
+	require.NoError(t, os.Chtimes(later, failedAt.Add(time.Second), failedAt.Add(time.Second)))
+
+	err = g.RemoveNewQuarantineDirs(context.Background(), repoPath, existingDirs, failedAt)
+	require.NoError(t, err)
+
+	require.NoDirExists(t, failed)
+	require.DirExists(t, existing)
+	require.DirExists(t, later)
+}
+
+func runGit(t testing.TB, dir string, args ...string) {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+// countObjects returns the number of loose and packed objects of the repository.
+func countObjects(t *testing.T, repoPath string) string {
+	t.Helper()
+
+	cmd := exec.Command("git", "count-objects", "-v")
+	cmd.Dir = repoPath
+	out, err := cmd.Output()
+	require.NoError(t, err)
+
+	var counts []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if strings.HasPrefix(line, "count:") || strings.HasPrefix(line, "in-pack:") {
+			counts = append(counts, line)
+		}
+	}
+
+	return strings.Join(counts, ", ")
+}
@@ -0,0 +1,218 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/git/sha"
+)
+
+// RefKind is the kind of a resolved reference.
+type RefKind string
+
+const (
+	RefKindBranch RefKind = "branch"
+	RefKindTag    RefKind = "tag"
+	RefKindCommit RefKind = "commit"
+)
+
+// ResolvedRef contains the resolution result of a single ref name or commit SHA.
+type ResolvedRef struct {
+	// Input is the ref name or commit SHA as it was provided.
+	Input  string
+	Exists bool
+	Kind   RefKind
+	// Ref is the full name of the reference (empty for commits).
+	Ref string
+	// SHA is the object the reference points to (the tag object in case of annotated tags).
+	SHA sha.SHA
+	// PeeledSHA is the object an annotated tag points to (empty for everything else).
+	PeeledSHA sha.SHA
+}
+
+// resolveQueries contains the indices of the cat-file queries issued for a single input (-1 if not issued).
+type resolveQueries struct {
+	branch int
+	tag    int
+	peeled int
+	commit int
+}
+
+// ResolveRefs resolves a list of branch names, tag names, full references and commit SHAs
+// using a single git cat-file invocation. The results are returned in the order of the inputs.
+// In case a name matches multiple kinds, branches take precedence over tags and tags over commits.
+func (g *Git) ResolveRefs(
+	ctx context.Context,
+	repoPath string,
+	inputs []string,
+) ([]ResolvedRef, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+	if len(inputs) == 0 {
+		return []ResolvedRef{}, nil
+	}
+
+	stdin := &bytes.Buffer{}
+	queryCount := 0
+	query := func(rev string) int {
+		stdin.WriteString(rev)
+		stdin.WriteByte('\n')
+		queryCount++
+		return queryCount - 1
+	}
+
+	queries := make([]resolveQueries, len(inputs))
+	for i, input := range inputs {
+		if input == "" || strings.ContainsAny(input, " \t\r\n:^~") {
+			return nil, errors.InvalidArgument("invalid ref or commit SHA %q", input)
+		}
+
+		queries[i] = resolveQueries{branch: -1, tag: -1, peeled: -1, commit: -1}
+
+		switch {
+		case strings.HasPrefix(input, BranchPrefix):
+			queries[i].branch = query(input)
+		case strings.HasPrefix(input, TagPrefix):
+			queries[i].tag = query(input)
+			queries[i].peeled = query(input + "^{}")
+		default:
+			queries[i].branch = query(BranchPrefix + input)
+			queries[i].tag = query(TagPrefix + input)
+			queries[i].peeled = query(TagPrefix + input + "^{}")
+			if _, err := sha.New(input); err == nil {
+				queries[i].commit = query(input + "^{commit}")
+			}
+		}
+	}
+
+	cmd := command.New("cat-file",
+		command.WithFlag("--batch-check=%(objectname) %(objecttype)"),
+	)
+	stdout := &bytes.Buffer{}
+	err := cmd.Run(ctx,
+		command.WithDir(repoPath),
+		command.WithStdin(stdin),
+		command.WithStdout(stdout),
+	)
+	if err != nil {
+		return nil, processGitErrorf(err, "failed to resolve refs")
+	}
+
+	objects, err := parseResolveOutput(stdout, queryCount)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]ResolvedRef, len(inputs))
+	for i, input := range inputs {
+		results[i] = resolveRef(input, queries[i], objects)
+	}
+
+	return results, nil
+}
+
+type resolvedObject struct {
+	sha     sha.SHA
+	objType string
+}
+
+// parseResolveOutput parses the output of cat-file --batch-check with "%(objectname) %(objecttype)" format.
+// Objects that couldn't be found are returned as nil.
+func parseResolveOutput(stdout *bytes.Buffer, expected int) ([]*resolvedObject, error) {
+	objects := make([]*resolvedObject, 0, expected)
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		line := scanner.Text()
+		idx := strings.LastIndexByte(line, ' ')
+		if idx < 0 {
+			return nil, fmt.Errorf("unexpected cat-file output line: %q", line)
+		}
+
+		value, objType := line[:idx], line[idx+1:]
+		if objType == "missing" || objType == "ambiguous" {
+			objects = append(objects, nil)
+			continue
+		}
+
+		objectSHA, err := sha.New(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse cat-file object sha: %w", err)
+		}
+
+		objects = append(objects, &resolvedObject{sha: objectSHA, objType: objType})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read cat-file output: %w", err)
+	}
+
+	if len(objects) != expected {
+		return nil, fmt.Errorf("cat-file returned %d objects, expected %d", len(objects), expected)
+	}
+
+	return objects, nil
+}
+
+func resolveRef(input string, q resolveQueries, objects []*resolvedObject) ResolvedRef {
+	object := func(idx int) *resolvedObject {
+		if idx < 0 {
+			return nil
+		}
+		return objects[idx]
+	}
+
+	if branch := object(q.branch); branch != nil {
+		return ResolvedRef{
+			Input:  input,
+			Exists: true,
+			Kind:   RefKindBranch,
+			Ref:    BranchPrefix + strings.TrimPrefix(input, BranchPrefix),
+			SHA:    branch.sha,
+		}
+	}
+
+	if tag := object(q.tag); tag != nil {
+		res := ResolvedRef{
+			Input:  input,
+			Exists: true,
+			Kind:   RefKindTag,
+			Ref:    TagPrefix + strings.TrimPrefix(input, TagPrefix),
+			SHA:    tag.sha,
+		}
+		if peeled := object(q.peeled); tag.objType == string(GitObjectTypeTag) && peeled != nil {
+			res.PeeledSHA = peeled.sha
+		}
+		return res
+	}
+
+	// only accept the commit if it was resolved by its SHA (and not e.g. via some other reference).
+	if commit := object(q.commit); commit != nil && strings.HasPrefix(commit.sha.String(), strings.ToLower(input)) {
+		return ResolvedRef{
+			Input:  input,
+			Exists: true,
+			Kind:   RefKindCommit,
+			SHA:    commit.sha,
+		}
+	}
+
+	return ResolvedRef{Input: input}
+}
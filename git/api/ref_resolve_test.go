@@ -0,0 +1,131 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveRefs(t *testing.T) {
+	ctx := context.Background()
+	repo := setupResolveRefsRepo(t, 0)
+	commitSHA := revParse(t, repo, "HEAD")
+
+	runGit(t, repo, "tag", "light")
+	runGit(t, repo, "-c", "user.name=test", "-c", "user.email=test@gitness.io", "tag", "-a", "annotated", "-m", "tag")
+	tagSHA := revParse(t, repo, "refs/tags/annotated")
+
+	refs, err := (&Git{}).ResolveRefs(ctx, repo, []string{
+		"main",
+		"refs/heads/main",
+		"light",
+		"annotated",
+		commitSHA[:10],
+		"missing",
+		strings.Repeat("0", 40),
+	})
+	require.NoError(t, err)
+	require.Len(t, refs, 7)
+
+	require.Equal(t, RefKindBranch, refs[0].Kind)
+	require.Equal(t, "refs/heads/main", refs[0].Ref)
+	require.Equal(t, commitSHA, refs[0].SHA.String())
+
+	require.Equal(t, RefKindBranch, refs[1].Kind)
+	require.Equal(t, commitSHA, refs[1].SHA.String())
+
+	require.Equal(t, RefKindTag, refs[2].Kind)
+	require.Equal(t, commitSHA, refs[2].SHA.String())
+	require.True(t, refs[2].PeeledSHA.IsEmpty(), "lightweight tags aren't peeled")
+
+	require.Equal(t, RefKindTag, refs[3].Kind)
+	require.Equal(t, tagSHA, refs[3].SHA.String())
+	require.Equal(t, commitSHA, refs[3].PeeledSHA.String())
+
+	require.Equal(t, RefKindCommit, refs[4].Kind)
+	require.Equal(t, commitSHA, refs[4].SHA.String())
+
+	require.False(t, refs[5].Exists)
+	require.Equal(t, "missing", refs[5].Input)
+	require.False(t, refs[6].Exists)
+
+	_, err = (&Git{}).ResolveRefs(ctx, repo, []string{"main:file.txt"})
+	require.Error(t, err)
+}
+
+func BenchmarkResolveRefs(b *testing.B) {
+	const n = 100
+	ctx := context.Background()
+	repo := setupResolveRefsRepo(b, n)
+
+	refs := make([]string, n)
+	for i := range refs {
+		refs[i] = fmt.Sprintf("refs/heads/branch-%d", i)
+	}
+
+	b.Run("sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, ref := range refs {
+				if _, err := (&Git{}).GetRef(ctx, repo, ref); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+
+	b.Run("batched", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := (&Git{}).ResolveRefs(ctx, repo, refs); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// setupResolveRefsRepo creates a repository with a single commit on main and the provided number of branches.
+func setupResolveRefsRepo(t testing.TB, branches int) string {
+	t.Helper()
+
+	repo := t.TempDir()
+	runGit(t, repo, "init", "--initial-branch", "main")
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "file.txt"), []byte("content"), 0o600))
+	runGit(t, repo, "add", "file.txt")
+	runGit(t, repo, "-c", "user.name=test", "-c", "user.email=test@gitness.io", "commit", "-m", "test")
+
+	for i := 0; i < branches; i++ {
+		runGit(t, repo, "branch", fmt.Sprintf("branch-%d", i))
+	}
+
+	return repo
+}
+
+func revParse(t testing.TB, repo string, rev string) string {
+	t.Helper()
+
+	cmd := exec.Command("git", "rev-parse", rev)
+	cmd.Dir = repo
+	out, err := cmd.Output()
+	require.NoError(t, err)
+
+	return strings.TrimSpace(string(out))
+}
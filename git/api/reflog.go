@@ -0,0 +1,117 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/sha"
+)
+
+// ReflogEntry is a single update of a reference recorded in the reflog.
+type ReflogEntry struct {
+	OldSHA  sha.SHA
+	NewSHA  sha.SHA
+	Pusher  Signature
+	Message string
+}
+
+// ListReflog returns the reflog entries of the reference, most recent first.
+// In case the reference doesn't have a reflog (e.g. it was deleted), an empty list is returned.
+func (g *Git) ListReflog(
+	_ context.Context,
+	repoPath string,
+	ref string,
+	skip int,
+	limit int,
+) ([]ReflogEntry, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+
+	// reflogs are stored in the logs folder using the reference as path - protect against path traversal.
+	if !strings.HasPrefix(ref, "refs/") || filepath.Clean(ref) != ref || strings.Contains(ref, "..") {
+		return nil, errors.InvalidArgument("invalid reference %q", ref)
+	}
+
+	data, err := os.ReadFile(filepath.Join(repoPath, "logs", ref))
+	if errors.Is(err, os.ErrNotExist) {
+		return []ReflogEntry{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reflog of %q: %w", ref, err)
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan reflog of %q: %w", ref, err)
+	}
+
+	entries := make([]ReflogEntry, 0, limit)
+	for i := len(lines) - 1 - skip; i >= 0 && len(entries) < limit; i-- {
+		entry, err := parseReflogLine(lines[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse reflog of %q: %w", ref, err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// parseReflogLine parses a reflog line of the format "<old-sha> <new-sha> <name> <<email>> <time> <tz>\t<message>".
+func parseReflogLine(line string) (ReflogEntry, error) {
+	header, message, _ := strings.Cut(line, "\t")
+
+	oldSHA, rest, ok := strings.Cut(header, " ")
+	if !ok {
+		return ReflogEntry{}, fmt.Errorf("reflog line is missing new sha ('%s')", line)
+	}
+
+	newSHA, signature, ok := strings.Cut(rest, " ")
+	if !ok {
+		return ReflogEntry{}, fmt.Errorf("reflog line is missing signature ('%s')", line)
+	}
+
+	entry := ReflogEntry{
+		Message: message,
+	}
+
+	var err error
+	if entry.OldSHA, err = sha.New(oldSHA); err != nil {
+		return ReflogEntry{}, fmt.Errorf("invalid old sha in reflog line ('%s'): %w", line, err)
+	}
+	if entry.NewSHA, err = sha.New(newSHA); err != nil {
+		return ReflogEntry{}, fmt.Errorf("invalid new sha in reflog line ('%s'): %w", line, err)
+	}
+	if entry.Pusher, err = parseSignatureFromCatFileLine(signature); err != nil {
+		return ReflogEntry{}, fmt.Errorf("invalid signature in reflog line: %w", err)
+	}
+
+	return entry, nil
+}
@@ -150,6 +150,48 @@ func (g *Git) GetDefaultBranch(
 	return output.String(), nil
 }
 
+// IsRepositoryEmpty returns true iff the repository doesn't contain any references and its HEAD is unborn.
+func (g *Git) IsRepositoryEmpty(
+	ctx context.Context,
+	repoPath string,
+) (bool, error) {
+	if repoPath == "" {
+		return false, ErrRepositoryPathEmpty
+	}
+
+	cmd := command.New("for-each-ref",
+		command.WithFlag("--count", "1"),
+		command.WithFlag("--format", "%(refname)"),
+	)
+	output := &bytes.Buffer{}
+	err := cmd.Run(ctx,
+		command.WithDir(repoPath),
+		command.WithStdout(output))
+	if err != nil {
+		return false, processGitErrorf(err, "failed to list references")
+	}
+
+	if strings.TrimSpace(output.String()) != "" {
+		return false, nil
+	}
+
+	// a detached HEAD can point to a commit even if there are no references.
+	cmd = command.New("rev-parse",
+		command.WithFlag("--verify"),
+		command.WithFlag("--quiet"),
+		command.WithArg("HEAD"),
+	)
+	err = cmd.Run(ctx, command.WithDir(repoPath))
+	if err == nil {
+		return false, nil
+	}
+	if cmdErr := command.AsError(err); cmdErr != nil && cmdErr.IsExitCode(1) {
+		return true, nil
+	}
+
+	return false, processGitErrorf(err, "failed to resolve HEAD")
+}
+
 // GetRemoteDefaultBranch retrieves the default branch of a remote repository.
 // If the repo doesn't have a default branch, types.ErrNoDefaultBranch is returned.
 func (g *Git) GetRemoteDefaultBranch(
@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRepositoryEmpty(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	remote := filepath.Join(dir, "remote.git")
+	local := filepath.Join(dir, "local")
+
+	runGit(t, dir, "init", "--bare", remote)
+
+	isEmpty, err := (&Git{}).IsRepositoryEmpty(ctx, remote)
+	require.NoError(t, err)
+	require.True(t, isEmpty, "freshly initialized repository should be empty")
+
+	runGit(t, dir, "init", local)
+	require.NoError(t, os.WriteFile(filepath.Join(local, "file.txt"), []byte("content"), 0o600))
+	runGit(t, local, "add", "file.txt")
+	runGit(t, local, "-c", "user.name=test", "-c", "user.email=test@gitness.io", "commit", "-m", "test")
+	runGit(t, local, "push", remote, "HEAD:refs/heads/feature")
+
+	isEmpty, err = (&Git{}).IsRepositoryEmpty(ctx, remote)
+	require.NoError(t, err)
+	require.False(t, isEmpty, "repository with a pushed branch shouldn't be empty, even with an unborn HEAD")
+}
@@ -35,11 +35,16 @@ func (g *Git) InfoRefs(
 	ctx context.Context,
 	repoPath string,
 	service string,
+	hiddenRefs []string,
 	w io.Writer,
 	env ...string,
 ) error {
 	stdout := &bytes.Buffer{}
 	cmd := command.New(service,
+		withOptimizationConfig(),
+		g.withPartialCloneConfig(enum.GitServiceType(service)),
+		g.withReceivePackConfig(enum.GitServiceType(service)),
+		g.withHiddenRefsConfig(hiddenRefs...),
 		command.WithFlag("--stateless-rpc"),
 		command.WithFlag("--advertise-refs"),
 		command.WithArg("."),
@@ -74,6 +79,8 @@ type ServicePackOptions struct {
 	Stderr       io.Writer
 	Env          []string
 	Protocol     string
+	// HiddenRefs are the reference prefixes hidden in addition to the ones configured for the instance.
+	HiddenRefs []string
 }
 
 func (g *Git) ServicePack(
@@ -82,6 +89,10 @@ func (g *Git) ServicePack(
 	options ServicePackOptions,
 ) error {
 	cmd := command.New(string(options.Service),
+		withOptimizationConfig(),
+		g.withPartialCloneConfig(options.Service),
+		g.withReceivePackConfig(options.Service),
+		g.withHiddenRefsConfig(options.HiddenRefs...),
 		command.WithArg(repoPath),
 		command.WithEnv("SSH_ORIGINAL_COMMAND", string(options.Service)),
 	)
@@ -94,16 +105,70 @@ func (g *Git) ServicePack(
 		cmd.Add(command.WithEnv("GIT_PROTOCOL", options.Protocol))
 	}
 
+	stdin := options.Stdin
+	if options.Service == enum.GitServiceTypeUploadPack && g.hasHiddenRefs(options.HiddenRefs) {
+		stdin = g.newWantsValidator(ctx, repoPath, options.HiddenRefs, stdin, options.Stdout)
+	}
+
 	err := cmd.Run(ctx,
 		command.WithDir(repoPath),
 		command.WithStdout(options.Stdout),
-		command.WithStdin(options.Stdin),
+		command.WithStdin(stdin),
 		command.WithStderr(options.Stderr),
 		command.WithEnvs(options.Env...),
 	)
 	if err != nil && err.Error() != "signal: killed" {
-		log.Ctx(ctx).Err(err).Msgf("Fail to serve RPC(%s) in %s: %v", options.Service, repoPath, err)
+		// the caller's logging context carries the request id, repo uid and rpc name to correlate the failure.
+		log.Ctx(ctx).Err(err).
+			Str("repo_dir", repoPath).
+			Msg("failed to serve git rpc")
+	}
+	return err
+}
+
+// withPartialCloneConfig allows upload-pack to serve partial clones (e.g. --filter=blob:none)
+// and the on-demand fetches of missing objects by the promisor remote of a partial clone.
+// The config has to be applied to the reference advertisement as well, as it contains the capabilities.
+func (g *Git) withPartialCloneConfig(service enum.GitServiceType) command.CmdOptionFunc {
+	return func(c *command.Command) {
+		if !g.allowPartialClone || service != enum.GitServiceTypeUploadPack {
+			return
+		}
+
+		command.WithConfig("uploadpack.allowFilter", "true")(c)
+		command.WithConfig("uploadpack.allowAnySHA1InWant", "true")(c)
+	}
+}
+
+// withReceivePackConfig configures the capabilities receive-pack advertises for push options and atomic pushes.
+// As with partial clones, the config has to be applied to the reference advertisement as well.
+// NOTE: Atomic pushes don't need any special handling in the pre-receive hook, as a rejection by the hook
+// always rejects all references of the push.
+func (g *Git) withReceivePackConfig(service enum.GitServiceType) command.CmdOptionFunc {
+	return func(c *command.Command) {
+		if service != enum.GitServiceTypeReceivePack {
+			return
+		}
+
+		command.WithConfig("receive.advertisePushOptions", strconv.FormatBool(g.advertisePushOptions))(c)
+		command.WithConfig("receive.advertiseAtomic", strconv.FormatBool(g.advertiseAtomic))(c)
+	}
+}
+
+// withHiddenRefsConfig hides the instance wide and the provided reference prefixes from the reference
+// advertisement (including protocol v2 ls-refs), which also prevents clients from updating them via push.
+func (g *Git) withHiddenRefsConfig(hiddenRefs ...string) command.CmdOptionFunc {
+	return func(c *command.Command) {
+		for _, ref := range g.allHiddenRefs(hiddenRefs) {
+			command.WithConfig("transfer.hideRefs", ref)(c)
+		}
 	}
+}
+
+// WriteErrorPacket reports the message to the git client via the error channel of the pack protocol
+// (an ERR pkt-line), which git clients show as "remote error: <message>".
+func WriteErrorPacket(w io.Writer, message string) error {
+	_, err := w.Write(packetWrite("ERR " + message + "\n"))
 	return err
 }
 
@@ -0,0 +1,363 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartialClone(t *testing.T) {
+	for _, version := range []string{"0", "2"} {
+		t.Run("protocol v"+version, func(t *testing.T) {
+			dir := t.TempDir()
+			repoPath := filepath.Join(dir, "repo.git")
+			runGit(t, dir, "init", "--bare", repoPath)
+			importLinearHistory(t, repoPath, 200)
+
+			server := newSmartHTTPServer(t, &Git{allowPartialClone: true}, repoPath)
+
+			local := filepath.Join(dir, "local")
+			runGit(t, dir, "-c", "protocol.version="+version,
+				"clone", "--quiet", "--filter=blob:none", "--no-checkout", server.URL, local)
+
+			require.Equal(t, "true", gitOutput(t, local, "config", "remote.origin.promisor"))
+			missingBefore := countMissingObjects(t, local)
+			require.NotZero(t, missingBefore, "blobs shouldn't be part of the clone")
+
+			// the checkout fetches the blobs of the checked out tree on demand.
+			runGit(t, local, "-c", "protocol.version="+version, "checkout", "--quiet", "main")
+
+			require.Equal(t, "200", gitOutput(t, local, "show", "HEAD:file-0.txt"))
+			require.Less(t, countMissingObjects(t, local), missingBefore)
+		})
+	}
+}
+
+func TestPartialCloneDisabled(t *testing.T) {
+	dir := t.TempDir()
+	repoPath := filepath.Join(dir, "repo.git")
+	runGit(t, dir, "init", "--bare", repoPath)
+	importLinearHistory(t, repoPath, 10)
+
+	server := newSmartHTTPServer(t, &Git{allowPartialClone: false}, repoPath)
+
+	// the server ignores the filter and sends all objects.
+	local := filepath.Join(dir, "local")
+	runGit(t, dir, "-c", "protocol.version=2",
+		"clone", "--quiet", "--filter=blob:none", "--bare", server.URL, local)
+
+	require.Zero(t, countMissingObjects(t, local))
+}
+
+func TestInfoRefsPartialCloneCapability(t *testing.T) {
+	repoPath := filepath.Join(t.TempDir(), "repo.git")
+	runGit(t, "", "init", "--bare", repoPath)
+	importLinearHistory(t, repoPath, 1)
+
+	tests := []struct {
+		name    string
+		allow   bool
+		env     []string
+		expect  string
+		service string
+	}{
+		{name: "v0 enabled", allow: true, expect: " filter", service: "upload-pack"},
+		{name: "v2 enabled", allow: true, env: []string{"GIT_PROTOCOL=version=2"},
+			expect: "fetch=shallow wait-for-done filter", service: "upload-pack"},
+		{name: "v0 disabled", allow: false, service: "upload-pack"},
+		{name: "v2 disabled", allow: false, env: []string{"GIT_PROTOCOL=version=2"}, service: "upload-pack"},
+		{name: "receive-pack", allow: true, service: "receive-pack"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			g := &Git{allowPartialClone: test.allow}
+			err := g.InfoRefs(context.Background(), repoPath, test.service, nil, buf, test.env...)
+			require.NoError(t, err)
+
+			if test.expect != "" {
+				require.Contains(t, buf.String(), test.expect)
+			} else {
+				require.NotContains(t, buf.String(), "filter")
+			}
+		})
+	}
+}
+
+func TestInfoRefsReceivePackCapabilities(t *testing.T) {
+	repoPath := filepath.Join(t.TempDir(), "repo.git")
+	runGit(t, "", "init", "--bare", repoPath)
+	importLinearHistory(t, repoPath, 1)
+
+	for _, enabled := range []bool{true, false} {
+		t.Run(strconv.FormatBool(enabled), func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			g := &Git{advertisePushOptions: enabled, advertiseAtomic: enabled}
+			err := g.InfoRefs(context.Background(), repoPath, "receive-pack", nil, buf)
+			require.NoError(t, err)
+
+			if enabled {
+				require.Contains(t, buf.String(), " push-options")
+				require.Contains(t, buf.String(), " atomic")
+			} else {
+				require.NotContains(t, buf.String(), "push-options")
+				require.NotContains(t, buf.String(), "atomic")
+			}
+		})
+	}
+}
+
+func TestAtomicPushRejectedByPreReceive(t *testing.T) {
+	dir := t.TempDir()
+	repoPath := filepath.Join(dir, "repo.git")
+	runGit(t, dir, "init", "--bare", repoPath)
+	importLinearHistory(t, repoPath, 1)
+	runGit(t, repoPath, "symbolic-ref", "HEAD", "refs/heads/main")
+
+	// the hook rejects the update of the protected branch, same as the pre-receive hook for protection rules.
+	hook := "#!/bin/sh\n" +
+		"while read old new ref; do\n" +
+		"  if [ \"$ref\" = refs/heads/protected ]; then echo \"rule violation: $ref\" >&2; exit 1; fi\n" +
+		"done\n"
+	err := os.WriteFile(filepath.Join(repoPath, "hooks", "pre-receive"), []byte(hook), 0o700)
+	require.NoError(t, err)
+
+	server := newSmartHTTPServer(t, &Git{advertisePushOptions: true, advertiseAtomic: true}, repoPath)
+
+	local := filepath.Join(dir, "local")
+	runGit(t, dir, "clone", "--quiet", server.URL, local)
+	runGit(t, local, "-c", "user.name=test", "-c", "user.email=test@gitness.io",
+		"commit", "--quiet", "--allow-empty", "-m", "change")
+
+	mainBefore := gitOutput(t, repoPath, "rev-parse", "refs/heads/main")
+
+	output, err := gitCombinedOutput(local, "push", "--atomic", "origin", "main", "main:protected")
+	require.Error(t, err, "push should be rejected")
+	require.Contains(t, output, "rule violation: refs/heads/protected")
+
+	// none of the references is updated, including the one that doesn't violate any rule.
+	require.Equal(t, mainBefore, gitOutput(t, repoPath, "rev-parse", "refs/heads/main"))
+	_, err = gitCombinedOutput(repoPath, "rev-parse", "--verify", "--quiet", "refs/heads/protected")
+	require.Error(t, err)
+
+	// without the protected branch the push succeeds, including push options.
+	runGit(t, local, "push", "--quiet", "--atomic", "-o", "ci.skip", "origin", "main")
+	require.Equal(t, gitOutput(t, local, "rev-parse", "HEAD"), gitOutput(t, repoPath, "rev-parse", "refs/heads/main"))
+}
+
+func TestPushOptionsDisabled(t *testing.T) {
+	dir := t.TempDir()
+	repoPath := filepath.Join(dir, "repo.git")
+	runGit(t, dir, "init", "--bare", repoPath)
+	importLinearHistory(t, repoPath, 1)
+	runGit(t, repoPath, "symbolic-ref", "HEAD", "refs/heads/main")
+
+	server := newSmartHTTPServer(t, &Git{}, repoPath)
+
+	local := filepath.Join(dir, "local")
+	runGit(t, dir, "clone", "--quiet", server.URL, local)
+
+	output, err := gitCombinedOutput(local, "push", "-o", "ci.skip", "origin", "main:other")
+	require.Error(t, err)
+	require.Contains(t, output, "push options")
+}
+
+// newSmartHTTPServer serves the repository via git's smart http protocol.
+func newSmartHTTPServer(t *testing.T, g *Git, repoPath string) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/info/refs", func(w http.ResponseWriter, r *http.Request) {
+		service := strings.TrimPrefix(r.URL.Query().Get("service"), "git-")
+		var env []string
+		if protocol := r.Header.Get("Git-Protocol"); protocol != "" {
+			env = append(env, "GIT_PROTOCOL="+protocol)
+		}
+
+		w.Header().Set("Content-Type", "application/x-git-"+service+"-advertisement")
+		if err := g.InfoRefs(r.Context(), repoPath, service, nil, w, env...); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+	for _, service := range []enum.GitServiceType{enum.GitServiceTypeUploadPack, enum.GitServiceTypeReceivePack} {
+		mux.HandleFunc("/git-"+string(service), func(w http.ResponseWriter, r *http.Request) {
+			var body io.Reader = r.Body
+			if r.Header.Get("Content-Encoding") == "gzip" {
+				gzipReader, err := gzip.NewReader(r.Body)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				defer gzipReader.Close()
+				body = gzipReader
+			}
+
+			w.Header().Set("Content-Type", "application/x-git-"+string(service)+"-result")
+			err := g.ServicePack(r.Context(), repoPath, ServicePackOptions{
+				Service:      service,
+				StatelessRPC: true,
+				Stdout:       w,
+				Stdin:        body,
+				Protocol:     r.Header.Get("Git-Protocol"),
+			})
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+		})
+	}
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func gitOutput(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	require.NoError(t, err)
+
+	return strings.TrimSpace(string(out))
+}
+
+// countMissingObjects returns the number of objects that are referenced but missing in a partial clone.
+func countMissingObjects(t *testing.T, repoPath string) int {
+	t.Helper()
+
+	out := gitOutput(t, repoPath, "rev-list", "--objects", "--all", "--missing=print")
+
+	var count int
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasPrefix(line, "?") {
+			count++
+		}
+	}
+
+	return count
+}
+
+func TestProtocolV2LsRefs(t *testing.T) {
+	dir := t.TempDir()
+	repoPath := filepath.Join(dir, "repo.git")
+	runGit(t, dir, "init", "--bare", repoPath)
+	importLinearHistory(t, repoPath, 100)
+	runGit(t, repoPath, "update-ref", "refs/pullreq/1/head", "refs/heads/old")
+	runGit(t, repoPath, "update-ref", "refs/pullreq/1/merge", "refs/heads/main")
+	runGit(t, repoPath, "symbolic-ref", "HEAD", "refs/heads/main")
+
+	g := &Git{hiddenRefs: []string{"refs/pullreq/*"}}
+
+	tests := []struct {
+		name     string
+		prefixes []string
+		expected []string
+	}{
+		{
+			name:     "ref prefix",
+			prefixes: []string{"refs/heads/main"},
+			expected: []string{"refs/heads/main"},
+		},
+		{
+			name:     "hidden ref prefix",
+			prefixes: []string{"refs/pullreq/"},
+			expected: nil,
+		},
+		{
+			name:     "no ref prefix",
+			expected: []string{"HEAD", "refs/heads/main", "refs/heads/old"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := &bytes.Buffer{}
+			request.Write(packetWrite("command=ls-refs\n"))
+			request.WriteString("0001")
+			for _, prefix := range test.prefixes {
+				request.Write(packetWrite("ref-prefix " + prefix + "\n"))
+			}
+			request.WriteString("0000")
+
+			response := &bytes.Buffer{}
+			err := g.ServicePack(context.Background(), repoPath, ServicePackOptions{
+				Service:      enum.GitServiceTypeUploadPack,
+				StatelessRPC: true,
+				Stdout:       response,
+				Stdin:        request,
+				Protocol:     "version=2",
+			})
+			require.NoError(t, err)
+
+			require.Equal(t, test.expected, parseLsRefsResponse(t, response.String()))
+		})
+	}
+
+	// a protocol v2 fetch of a single branch only lists the requested branch and never any hidden refs.
+	server := newSmartHTTPServer(t, g, repoPath)
+
+	refs := gitOutput(t, dir, "-c", "protocol.version=2", "ls-remote", server.URL, "refs/heads/main")
+	require.Equal(t, 1, strings.Count(refs, "\n")+1)
+	require.True(t, strings.HasSuffix(refs, "\trefs/heads/main"))
+
+	refs = gitOutput(t, dir, "-c", "protocol.version=2", "ls-remote", server.URL)
+	require.NotContains(t, refs, "refs/pullreq")
+
+	local := filepath.Join(dir, "local")
+	runGit(t, dir, "init", "--bare", local)
+	runGit(t, local, "-c", "protocol.version=2", "fetch", "--quiet", server.URL, "main")
+	require.Equal(t, gitOutput(t, repoPath, "rev-parse", "refs/heads/main"), gitOutput(t, local, "rev-parse", "FETCH_HEAD"))
+}
+
+// parseLsRefsResponse returns the reference names of a protocol v2 ls-refs response.
+func parseLsRefsResponse(t *testing.T, response string) []string {
+	t.Helper()
+
+	var refs []string
+	for response != "" {
+		require.GreaterOrEqual(t, len(response), 4)
+		length, err := strconv.ParseInt(response[:4], 16, 32)
+		require.NoError(t, err)
+
+		if length == 0 {
+			break
+		}
+
+		line := strings.TrimSuffix(response[4:length], "\n")
+		response = response[length:]
+
+		_, ref, ok := strings.Cut(line, " ")
+		require.True(t, ok, "unexpected ls-refs line %q", line)
+		refs = append(refs, ref)
+	}
+
+	return refs
+}
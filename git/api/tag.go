@@ -423,3 +423,78 @@ func (g *Git) GetTagCount(
 
 	return countLines(pipeOut), nil
 }
+
+// TagTarget describes the object a tag reference points to.
+type TagTarget struct {
+	// Annotated is true if the tag reference points to an annotated tag object.
+	Annotated bool
+	// CommitSHA is the commit the tag peels to. It's empty if the tag doesn't point to a commit.
+	CommitSHA sha.SHA
+}
+
+// GetTagTargets returns for each of the provided tag reference targets whether it's an annotated tag object
+// and the commit it peels to. All objects are resolved using a single git cat-file process.
+func (g *Git) GetTagTargets(
+	ctx context.Context,
+	repoPath string,
+	alternates []string,
+	targets []sha.SHA,
+) ([]TagTarget, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+
+	result := make([]TagTarget, len(targets))
+	if len(targets) == 0 {
+		return result, nil
+	}
+
+	// for every target we ask for the type of the object itself and for the commit it peels to.
+	stdin := &bytes.Buffer{}
+	for _, target := range targets {
+		stdin.WriteString(target.String())
+		stdin.WriteByte('\n')
+		stdin.WriteString(target.String())
+		stdin.WriteString("^{commit}\n")
+	}
+
+	stdout := &bytes.Buffer{}
+	cmd := command.New("cat-file",
+		command.WithFlag("--batch-check=%(objectname) %(objecttype)"),
+		command.WithAlternateObjectDirs(alternates...),
+	)
+	err := cmd.Run(ctx,
+		command.WithDir(repoPath),
+		command.WithStdin(stdin),
+		command.WithStdout(stdout),
+	)
+	if err != nil {
+		return nil, processGitErrorf(err, "failed to get tag targets")
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 2*len(targets) {
+		return nil, fmt.Errorf("unexpected number of lines in git cat-file output: %d", len(lines))
+	}
+
+	for i := range targets {
+		_, objectType, _ := strings.Cut(lines[2*i], " ")
+		if objectType == "missing" {
+			return nil, errors.NotFound("object %s not found", targets[i])
+		}
+
+		result[i].Annotated = GitObjectType(objectType) == GitObjectTypeTag
+
+		peeledSHA, peeledType, _ := strings.Cut(lines[2*i+1], " ")
+		if GitObjectType(peeledType) != GitObjectTypeCommit {
+			continue
+		}
+
+		result[i].CommitSHA, err = sha.New(peeledSHA)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse peeled commit sha: %w", err)
+		}
+	}
+
+	return result, nil
+}
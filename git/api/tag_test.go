@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/git/sha"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTagTargets(t *testing.T) {
+	ctx := context.Background()
+	repo := setupResolveRefsRepo(t, 0)
+
+	commit := sha.Must(revParse(t, repo, "HEAD"))
+	runGit(t, repo, "tag", "lightweight")
+	runGit(t, repo, "-c", "user.name=test", "-c", "user.email=test@gitness.io",
+		"tag", "-a", "annotated", "-m", "annotated")
+	runGit(t, repo, "tag", "tree", "HEAD^{tree}")
+
+	targets := []sha.SHA{
+		sha.Must(revParse(t, repo, "lightweight")),
+		sha.Must(revParse(t, repo, "annotated")),
+		sha.Must(revParse(t, repo, "tree")),
+	}
+
+	results, err := (&Git{}).GetTagTargets(ctx, repo, nil, targets)
+	require.NoError(t, err)
+	require.Equal(t, []TagTarget{
+		{Annotated: false, CommitSHA: commit},
+		{Annotated: true, CommitSHA: commit},
+		{Annotated: false, CommitSHA: sha.None},
+	}, results)
+}
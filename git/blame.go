@@ -67,6 +67,12 @@ func (params *BlameParams) Validate() error {
 type BlamePart struct {
 	Commit *Commit  `json:"commit"`
 	Lines  []string `json:"lines"`
+	// Path is the path of the file in the commit, it differs from the blamed path if the file was renamed since.
+	Path string `json:"path"`
+	// LineNumber is the line number of the first line in the blamed revision.
+	LineNumber int `json:"line_number"`
+	// OrigLineNumber is the line number of the first line in the commit.
+	OrigLineNumber int `json:"orig_line_number"`
 }
 
 // Blame processes and streams the git blame output data.
@@ -96,6 +102,9 @@ func (s *Service) Blame(ctx context.Context, params *BlameParams) (<-chan *Blame
 			part, errRead := reader.NextPart()
 
 			if part == nil {
+				if errRead != nil && !errors.Is(errRead, io.EOF) {
+					chErr <- s.processEmptyRepoErr(ctx, repoPath, errRead)
+				}
 				return
 			}
 
@@ -108,7 +117,13 @@ func (s *Service) Blame(ctx context.Context, params *BlameParams) (<-chan *Blame
 			lines := make([]string, len(part.Lines))
 			copy(lines, part.Lines)
 
-			ch <- &BlamePart{Commit: commit, Lines: lines}
+			ch <- &BlamePart{
+				Commit:         commit,
+				Lines:          lines,
+				Path:           part.Path,
+				LineNumber:     part.LineNumber,
+				OrigLineNumber: part.OrigLineNumber,
+			}
 
 			if errRead != nil && errors.Is(errRead, io.EOF) {
 				return
@@ -18,6 +18,7 @@ import (
 	"context"
 	"io"
 
+	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git/api"
 	"github.com/harness/gitness/git/sha"
 )
@@ -64,3 +65,64 @@ func (s *Service) GetBlob(ctx context.Context, params *GetBlobParams) (*GetBlobO
 		Content:     reader.Content,
 	}, nil
 }
+
+type HashBlobParams struct {
+	ReadParams
+	// Content is the streamed content of the blob.
+	Content io.Reader
+}
+
+type HashBlobOutput struct {
+	SHA sha.SHA
+}
+
+// HashBlob returns the SHA the provided content would have as a blob, without writing it to the repository.
+func (s *Service) HashBlob(ctx context.Context, params *HashBlobParams) (*HashBlobOutput, error) {
+	if params == nil {
+		return nil, ErrNoParamsProvided
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	if params.Content == nil {
+		return nil, errors.InvalidArgument("blob content is required")
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	blobSHA, err := s.git.HashBlob(ctx, repoPath, params.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HashBlobOutput{SHA: blobSHA}, nil
+}
+
+type ExistsBlobsParams struct {
+	ReadParams
+	SHAs []sha.SHA
+}
+
+type ExistsBlobsOutput struct {
+	// Exists contains for each of the requested SHAs whether the blob exists in the repository.
+	Exists []bool
+}
+
+// ExistsBlobs checks in a single batch which of the provided blobs already exist in the repository.
+func (s *Service) ExistsBlobs(ctx context.Context, params *ExistsBlobsParams) (*ExistsBlobsOutput, error) {
+	if params == nil {
+		return nil, ErrNoParamsProvided
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	exists, err := s.git.BlobsExist(ctx, repoPath, params.AlternateObjectDirs, params.SHAs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExistsBlobsOutput{Exists: exists}, nil
+}
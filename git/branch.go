@@ -142,7 +142,7 @@ func (s *Service) GetBranch(ctx context.Context, params *GetBranchParams) (*GetB
 
 	gitBranch, err := s.git.GetBranch(ctx, repoPath, sanitizedBranchName)
 	if err != nil {
-		return nil, err
+		return nil, s.processEmptyRepoErr(ctx, repoPath, err)
 	}
 
 	branch, err := mapBranch(gitBranch)
@@ -0,0 +1,153 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/harness/gitness/errors"
+
+	"github.com/rs/zerolog/log"
+)
+
+type CreateBundleParams struct {
+	ReadParams
+}
+
+func (p *CreateBundleParams) Validate() error {
+	return p.ReadParams.Validate()
+}
+
+// CreateBundle streams a git bundle containing all references of the repository to the provided writer.
+func (s *Service) CreateBundle(ctx context.Context, params *CreateBundleParams, w io.Writer) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	err := s.git.CreateBundle(ctx, repoPath, w)
+	if err != nil {
+		return fmt.Errorf("failed to create git bundle: %w", err)
+	}
+
+	return nil
+}
+
+type RestoreBundleParams struct {
+	WriteParams
+	// Force allows to restore the bundle into a repository that already contains branches.
+	// All references of the repository are replaced with the references of the bundle.
+	Force bool
+}
+
+func (p *RestoreBundleParams) Validate() error {
+	return p.WriteParams.Validate()
+}
+
+type RestoreBundleOutput struct {
+	DefaultBranch string
+}
+
+// RestoreBundle restores the repository from the git bundle provided by the reader.
+// The repository is created if it doesn't exist yet. The bundle is stored in a temporary location
+// and verified against an empty repository before the repository itself is touched.
+func (s *Service) RestoreBundle(
+	ctx context.Context,
+	params *RestoreBundleParams,
+	r io.Reader,
+) (*RestoreBundleOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	tmpDir, err := os.MkdirTemp(s.tmpDir, "bundle-restore-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer func() {
+		if errRemove := os.RemoveAll(tmpDir); errRemove != nil {
+			log.Ctx(ctx).Warn().Err(errRemove).Msgf("failed to remove temporary directory %s", tmpDir)
+		}
+	}()
+
+	bundlePath := filepath.Join(tmpDir, "restore.bundle")
+	if err = writeBundleFile(bundlePath, r); err != nil {
+		return nil, err
+	}
+
+	// verify the bundle against an empty repository - only complete bundles (without prerequisites) are accepted.
+	verifyRepoPath := filepath.Join(tmpDir, "verify.git")
+	if err = s.git.InitRepository(ctx, verifyRepoPath, true); err != nil {
+		return nil, fmt.Errorf("failed to create repository for bundle verification: %w", err)
+	}
+
+	if err = s.git.VerifyBundle(ctx, verifyRepoPath, bundlePath); err != nil {
+		return nil, errors.InvalidArgument("The provided bundle is invalid or incomplete.")
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	_, err = os.Stat(repoPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to check repository directory: %w", err)
+	}
+
+	if err == nil && !params.Force {
+		// NOTE: HasBranches returns true in case the repo has no branches (see its usage in operations.go).
+		isEmpty, err := s.git.HasBranches(ctx, repoPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check if repository is empty: %w", err)
+		}
+
+		if !isEmpty {
+			return nil, errors.Conflict("The repository isn't empty, restoring it requires the force flag.")
+		}
+	}
+
+	syncOut, err := s.SyncRepository(ctx, &SyncRepositoryParams{
+		WriteParams:       params.WriteParams,
+		Source:            bundlePath,
+		CreateIfNotExists: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore repository from bundle: %w", err)
+	}
+
+	return &RestoreBundleOutput{
+		DefaultBranch: syncOut.DefaultBranch,
+	}, nil
+}
+
+func writeBundleFile(filePath string, r io.Reader) error {
+	f, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle file: %w", err)
+	}
+
+	_, err = io.Copy(f, r)
+	if errClose := f.Close(); err == nil && errClose != nil {
+		err = errClose
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write bundle file: %w", err)
+	}
+
+	return nil
+}
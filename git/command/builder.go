@@ -210,10 +210,10 @@ var descriptions = map[string]builder{
 				// intermingled with normal positional arguments. Given that these
 				// pseudo-revisions have leading dashes, normal validation would
 				// refuse them as positional arguments. We thus override validation
-				// for two of these which we are using in our codebase. There are
+				// for the few of these which we are using in our codebase. There are
 				// more, but we can add them at a later point if they're ever
 				// required.
-				if arg == "--all" || arg == "--not" {
+				if arg == "--all" || arg == "--not" || arg == "--exclude-hidden=uploadpack" {
 					continue
 				}
 				if err := validatePositionalArg(arg); err != nil {
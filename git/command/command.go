@@ -20,13 +20,19 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
 	"regexp"
+	"time"
 )
 
 var (
 	GitExecutable = "git"
 
+	// WaitDelay is the max time we wait for the output pipes of a killed git process to be closed.
+	// Without it, a canceled command keeps waiting for sub processes (e.g. pack-objects) holding the pipes open.
+	WaitDelay = 5 * time.Second
+
 	actionRegex = regexp.MustCompile(`^[[:alnum:]]+[-[:alnum:]]*$`)
 )
 
@@ -113,12 +119,21 @@ func (c *Command) Add(options ...CmdOptionFunc) *Command {
 }
 
 // Run executes the git command with optional configuration using WithXxx functions.
-func (c *Command) Run(ctx context.Context, opts ...RunOptionFunc) (err error) {
+// All git processes are started here, which makes it the central place to observe them.
+func (c *Command) Run(ctx context.Context, opts ...RunOptionFunc) error {
 	options := &RunOption{}
 	for _, f := range opts {
 		f(options)
 	}
 
+	start := time.Now()
+	err := c.run(ctx, options)
+	c.observe(ctx, options, start, err)
+
+	return err
+}
+
+func (c *Command) run(ctx context.Context, options *RunOption) (err error) {
 	if options.Stdout == nil {
 		options.Stdout = io.Discard
 	}
@@ -138,13 +153,31 @@ func (c *Command) Run(ctx context.Context, opts ...RunOptionFunc) (err error) {
 	}
 	cmd.Env = append(cmd.Env, options.Envs...)
 	cmd.Dir = options.Dir
-	cmd.Stdin = options.Stdin
 	cmd.Stdout = options.Stdout
 	cmd.Stderr = options.Stderr
+	cmd.WaitDelay = WaitDelay
+
+	// Stdin that isn't a file is copied by us instead of the exec package, as the exec package waits
+	// for the copy to complete - a stalled stdin reader would prevent the process from being reaped.
+	var stdin io.WriteCloser
+	if _, ok := options.Stdin.(*os.File); ok || options.Stdin == nil {
+		cmd.Stdin = options.Stdin
+	} else if stdin, err = cmd.StdinPipe(); err != nil {
+		return fmt.Errorf("failed to create stdin pipe: %w", err)
+	}
+
 	if err = cmd.Start(); err != nil {
 		return err
 	}
 
+	if stdin != nil {
+		go func() {
+			// the copy fails once the process exited, the process reports truncated input on its own.
+			_, _ = io.Copy(stdin, options.Stdin)
+			_ = stdin.Close()
+		}()
+	}
+
 	result := make(chan error)
 	go func() {
 		result <- cmd.Wait()
@@ -154,7 +187,8 @@ func (c *Command) Run(ctx context.Context, opts ...RunOptionFunc) (err error) {
 	case <-ctx.Done():
 		<-result
 		if cmd.Process != nil && cmd.ProcessState != nil && !cmd.ProcessState.Exited() {
-			if err := cmd.Process.Kill(); err != nil && !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			if err := cmd.Process.Kill(); err != nil && !errors.Is(err, os.ErrProcessDone) &&
+				!errors.Is(ctx.Err(), context.DeadlineExceeded) {
 				return fmt.Errorf("kill process: %w", err)
 			}
 		}
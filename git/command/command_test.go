@@ -112,3 +112,45 @@ func TestCommandContextTimeout(t *testing.T) {
 		t.Errorf("expected: %v error, got: %v", context.DeadlineExceeded, err)
 	}
 }
+
+func TestCommandContextCancelWithBlockedStdin(t *testing.T) {
+	cmd := New("init", WithFlag("--bare"), WithArg("samplerepo"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := cmd.Run(ctx)
+	defer os.RemoveAll("samplerepo")
+	if err != nil {
+		t.Errorf("expected: %v error, got: %v", nil, err)
+		return
+	}
+
+	// the stdin never receives any data nor gets closed, like a stalled client mid-transfer.
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	defer pw.Close()
+
+	runCtx, runCancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(500 * time.Millisecond)
+		runCancel()
+	}()
+
+	result := make(chan error, 1)
+	go func() {
+		result <- New("hash-object", WithFlag("--stdin")).Run(runCtx,
+			WithDir("./samplerepo"),
+			WithStdin(pr),
+		)
+	}()
+
+	select {
+	case err = <-result:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected: %v error, got: %v", context.Canceled, err)
+		}
+	case <-time.After(WaitDelay + 5*time.Second):
+		t.Errorf("git process wasn't reaped after the context got canceled")
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog/log"
+)
+
+// maxLoggedArgs is the max number of arguments of a slow git process that are logged.
+const maxLoggedArgs = 32
+
+var (
+	// slowThreshold is the duration (in nanoseconds) after which a git process is considered slow.
+	slowThreshold atomic.Int64
+
+	slowOperations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gitness",
+		Subsystem: "git",
+		Name:      "slow_operations_total",
+		Help:      "Number of git processes that exceeded the slow operation threshold by git command.",
+	}, []string{"operation"})
+)
+
+// SetSlowThreshold sets the duration after which git processes are logged as slow.
+// A zero duration disables the slow operation log.
+func SetSlowThreshold(threshold time.Duration) {
+	slowThreshold.Store(int64(threshold))
+}
+
+// observe logs and counts the git process in case it exceeded the slow threshold.
+func (c *Command) observe(ctx context.Context, options *RunOption, start time.Time, err error) {
+	threshold := time.Duration(slowThreshold.Load())
+	if threshold <= 0 {
+		return
+	}
+
+	duration := time.Since(start)
+	if duration < threshold {
+		return
+	}
+
+	slowOperations.WithLabelValues(c.Name).Inc()
+
+	args, errArgs := c.makeArgs()
+	if errArgs != nil {
+		args = []string{c.Name}
+	}
+
+	log.Ctx(ctx).Warn().
+		Str("git.repo_uid", repoUIDFromDir(options.Dir)).
+		Str("git.dir", options.Dir).
+		Strs("git.args", sanitizeArgs(args)).
+		Dur("git.duration", duration).
+		Dur("git.threshold", threshold).
+		Int("git.exit_code", exitCode(err)).
+		Msg("slow git operation")
+}
+
+// repoUIDFromDir returns the uid of the repository located in the provided directory,
+// or an empty string if the directory isn't a repository of the git service (e.g. a temporary clone).
+// Repositories are stored as "<root>/<uid[0:2]>/<uid[2:4]>/<uid[4:]>.git".
+func repoUIDFromDir(dir string) string {
+	remainder, ok := strings.CutSuffix(filepath.Base(dir), ".git")
+	if !ok || remainder == "" {
+		return ""
+	}
+
+	second := filepath.Base(filepath.Dir(dir))
+	first := filepath.Base(filepath.Dir(filepath.Dir(dir)))
+	if len(first) != 2 || len(second) != 2 {
+		return ""
+	}
+
+	return first + second + remainder
+}
+
+// sanitizeArgs removes credentials from url arguments and limits the number of arguments.
+func sanitizeArgs(args []string) []string {
+	if len(args) > maxLoggedArgs {
+		args = append(args[:maxLoggedArgs:maxLoggedArgs], "...")
+	}
+
+	sanitized := make([]string, len(args))
+	for i, arg := range args {
+		sanitized[i] = arg
+		if !strings.Contains(arg, "://") {
+			continue
+		}
+
+		u, err := url.Parse(arg)
+		if err != nil {
+			sanitized[i] = "<invalid url>"
+			continue
+		}
+
+		if u.User != nil {
+			u.User = url.User("redacted")
+			sanitized[i] = u.String()
+		}
+	}
+
+	return sanitized
+}
+
+// exitCode returns the exit code of the git process, or -1 if it didn't exit on its own.
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+
+	return -1
+}
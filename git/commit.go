@@ -42,6 +42,8 @@ type Commit struct {
 	Author     Signature         `json:"author"`
 	Committer  Signature         `json:"committer"`
 	FileStats  []CommitFileStats `json:"file_stats,omitempty"`
+	// Note is the git note attached to the commit (only populated if requested).
+	Note *string `json:"note,omitempty"`
 }
 
 type GetCommitOutput struct {
@@ -77,7 +79,7 @@ func (s *Service) GetCommit(ctx context.Context, params *GetCommitParams) (*GetC
 	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
 	result, err := s.git.GetCommit(ctx, repoPath, params.Revision)
 	if err != nil {
-		return nil, err
+		return nil, s.processEmptyRepoErr(ctx, repoPath, err)
 	}
 
 	commit, err := mapCommit(result)
@@ -112,6 +114,11 @@ type ListCommitsParams struct {
 
 	// IncludeStats allows to include information about inserted, deletions and status for changed files.
 	IncludeStats bool
+
+	// IncludeNotes allows to include the git notes attached to the commits.
+	IncludeNotes bool
+	// NotesRef is the reference the notes are read from, defaults to api.DefaultNotesRef.
+	NotesRef string
 }
 
 type RenameDetails struct {
@@ -139,6 +146,9 @@ func (s *Service) ListCommits(ctx context.Context, params *ListCommitsParams) (*
 	if params == nil {
 		return nil, ErrNoParamsProvided
 	}
+	if err := validateNotesRef(params.NotesRef); err != nil {
+		return nil, err
+	}
 
 	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
 
@@ -158,7 +168,7 @@ func (s *Service) ListCommits(ctx context.Context, params *ListCommitsParams) (*
 		},
 	)
 	if err != nil {
-		return nil, err
+		return nil, s.processEmptyRepoErr(ctx, repoPath, err)
 	}
 
 	// try to get total commits between gitref and After refs
@@ -187,6 +197,13 @@ func (s *Service) ListCommits(ctx context.Context, params *ListCommitsParams) (*
 		commits[i] = *commit
 	}
 
+	if params.IncludeNotes {
+		err = s.addNotes(ctx, repoPath, notesRefOrDefault(params.NotesRef), commits)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &ListCommitsOutput{
 		Commits:       commits,
 		RenameDetails: mapRenameDetails(renameDetails),
@@ -194,6 +211,53 @@ func (s *Service) ListCommits(ctx context.Context, params *ListCommitsParams) (*
 	}, nil
 }
 
+type ListNewCommitMessagesParams struct {
+	ReadParams
+	// Rev is the revision from which the commits are listed.
+	Rev string
+	// Limit is the maximum number of returned commits, zero means no limit.
+	Limit int
+}
+
+type CommitMessage struct {
+	SHA        sha.SHA
+	ParentSHAs []sha.SHA
+	Message    string
+}
+
+type ListNewCommitMessagesOutput struct {
+	Commits []CommitMessage
+}
+
+// ListNewCommitMessages returns the messages of the commits reachable from the revision
+// that aren't reachable from any reference of the repository (e.g. commits pushed to the quarantine area).
+func (s *Service) ListNewCommitMessages(
+	ctx context.Context,
+	params *ListNewCommitMessagesParams,
+) (*ListNewCommitMessagesOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	messages, err := s.git.ListNewCommitMessages(ctx, repoPath, params.AlternateObjectDirs, params.Rev, params.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	commits := make([]CommitMessage, len(messages))
+	for i, message := range messages {
+		commits[i] = CommitMessage{
+			SHA:        message.SHA,
+			ParentSHAs: message.ParentSHAs,
+			Message:    message.Message,
+		}
+	}
+
+	return &ListNewCommitMessagesOutput{Commits: commits}, nil
+}
+
 type GetCommitDivergencesParams struct {
 	ReadParams
 	MaxCount int32
@@ -14,7 +14,10 @@
 
 package git
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 const (
 	RequestIDNone string = "git_none"
@@ -38,3 +41,13 @@ func RequestIDFrom(ctx context.Context) string {
 func WithRequestID(parent context.Context, v string) context.Context {
 	return context.WithValue(parent, requestIDKey{}, v)
 }
+
+// withTimeout returns a copy of parent that is canceled after the provided timeout.
+// The parent's deadline and cancelation still apply, a non-positive timeout doesn't limit the operation further.
+func withTimeout(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(parent)
+	}
+
+	return context.WithTimeout(parent, timeout)
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/sha"
+)
+
+type ContributorStatsParams struct {
+	ReadParams
+	// SHA is the commit whose history is walked.
+	SHA sha.SHA
+	// Since allows to filter for commits since the provided UNIX timestamp - Optional, ignored if value is 0.
+	Since int64
+	// Until allows to filter for commits until the provided UNIX timestamp - Optional, ignored if value is 0.
+	Until int64
+}
+
+func (p *ContributorStatsParams) Validate() error {
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.SHA.IsEmpty() {
+		return errors.InvalidArgument("commit SHA is mandatory")
+	}
+
+	return nil
+}
+
+type ContributorWeekStats struct {
+	// Week is the start of the week (Sunday 00:00 UTC) as unix timestamp.
+	Week      int64 `json:"week"`
+	Commits   int64 `json:"commits"`
+	Additions int64 `json:"additions"`
+	Deletions int64 `json:"deletions"`
+}
+
+type ContributorStats struct {
+	Identity  Identity               `json:"identity"`
+	Commits   int64                  `json:"commits"`
+	Additions int64                  `json:"additions"`
+	Deletions int64                  `json:"deletions"`
+	Weeks     []ContributorWeekStats `json:"weeks"`
+}
+
+type ContributorStatsOutput struct {
+	Contributors []ContributorStats
+}
+
+// ContributorStats returns the number of commits, additions and deletions per author, bucketed by week.
+// Authors with multiple identities are merged if a .mailmap file exists at the provided commit.
+func (s *Service) ContributorStats(
+	ctx context.Context,
+	params *ContributorStatsParams,
+) (*ContributorStatsOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	var since, until time.Time
+	if params.Since > 0 {
+		since = time.Unix(params.Since, 0)
+	}
+	if params.Until > 0 {
+		until = time.Unix(params.Until, 0)
+	}
+
+	stats, err := s.git.ContributorStats(ctx, repoPath, params.SHA.String(), since, until)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contributor stats: %w", err)
+	}
+
+	contributors := make([]ContributorStats, len(stats))
+	for i, contributor := range stats {
+		contributors[i] = mapContributorStats(contributor)
+	}
+
+	return &ContributorStatsOutput{
+		Contributors: contributors,
+	}, nil
+}
+
+func mapContributorStats(stats api.ContributorStats) ContributorStats {
+	weeks := make([]ContributorWeekStats, len(stats.Weeks))
+	for i, week := range stats.Weeks {
+		weeks[i] = ContributorWeekStats(week)
+	}
+
+	return ContributorStats{
+		Identity:  Identity(stats.Identity),
+		Commits:   stats.Commits,
+		Additions: stats.Additions,
+		Deletions: stats.Deletions,
+		Weeks:     weeks,
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"sync"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/logging"
+
+	"github.com/rs/zerolog/log"
+)
+
+// operation is a single in-flight git operation that has to be completed before the service can shut down.
+type operation struct {
+	repoUID string
+	service string
+	cancel  context.CancelFunc
+}
+
+// operationTracker keeps track of in-flight git operations (upload-pack, receive-pack, ...)
+// to allow draining them on shutdown.
+type operationTracker struct {
+	mx       sync.Mutex
+	wg       sync.WaitGroup
+	draining bool
+	nextID   uint64
+	ops      map[uint64]operation
+}
+
+func newOperationTracker() *operationTracker {
+	return &operationTracker{
+		ops: map[uint64]operation{},
+	}
+}
+
+// start registers a new in-flight operation. It returns a context that gets canceled
+// in case the operation doesn't complete before the drain deadline and whose logs are
+// annotated with the repo uid and the rpc name, and a function
+// that has to be called once the operation completed.
+// Once draining started, no new operations are accepted.
+func (t *operationTracker) start(
+	ctx context.Context,
+	repoUID string,
+	service string,
+) (context.Context, func(), error) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	if t.draining {
+		return nil, nil, errors.Unavailable("git service is shutting down, please retry later")
+	}
+
+	// annotate all logs of the operation with the repo and rpc, the request id is inherited from the caller.
+	ctx = logging.NewContext(ctx, logging.WithRepoUID(repoUID), logging.WithGitRPC(service))
+	ctx, cancel := context.WithCancel(ctx)
+
+	id := t.nextID
+	t.nextID++
+	t.ops[id] = operation{
+		repoUID: repoUID,
+		service: service,
+		cancel:  cancel,
+	}
+	t.wg.Add(1)
+
+	done := func() {
+		t.mx.Lock()
+		delete(t.ops, id)
+		t.mx.Unlock()
+
+		cancel()
+		t.wg.Done()
+	}
+
+	return ctx, done, nil
+}
+
+// count returns the number of in-flight operations of the provided service for the repository.
+func (t *operationTracker) count(repoUID string, service string) int {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	n := 0
+	for _, op := range t.ops {
+		if op.repoUID == repoUID && op.service == service {
+			n++
+		}
+	}
+
+	return n
+}
+
+// drain stops accepting new operations and waits for all in-flight operations to complete.
+// Operations still running once the context is done get canceled.
+func (t *operationTracker) drain(ctx context.Context) {
+	t.mx.Lock()
+	t.draining = true
+	t.mx.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		t.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return
+	case <-ctx.Done():
+	}
+
+	t.mx.Lock()
+	for _, op := range t.ops {
+		log.Ctx(ctx).Warn().
+			Str("repo_uid", op.repoUID).
+			Str("service", op.service).
+			Msg("canceling git operation that didn't complete before the drain deadline")
+		op.cancel()
+	}
+	t.mx.Unlock()
+
+	<-done
+}
+
+// Drain stops accepting new git operations and waits for all in-flight git operations to complete.
+// Operations still running once the context is done get canceled.
+func (s *Service) Drain(ctx context.Context) {
+	s.ops.drain(ctx)
+}
@@ -0,0 +1,80 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/errors"
+)
+
+func TestOperationTracker_Drain(t *testing.T) {
+	tracker := newOperationTracker()
+
+	finishedCtx, finished, err := tracker.start(context.Background(), "repo1", "receive-pack")
+	if err != nil {
+		t.Fatalf("failed to start operation: %v", err)
+	}
+
+	stuckCtx, stuck, err := tracker.start(context.Background(), "repo2", "upload-pack")
+	if err != nil {
+		t.Fatalf("failed to start operation: %v", err)
+	}
+
+	drainCtx, drainCancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer drainCancel()
+
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		tracker.drain(drainCtx)
+	}()
+
+	// wait for the drain to start, after which new operations are rejected.
+	for {
+		tracker.mx.Lock()
+		draining := tracker.draining
+		tracker.mx.Unlock()
+		if draining {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if _, _, err = tracker.start(context.Background(), "repo1", "upload-pack"); !errors.IsUnavailable(err) {
+		t.Errorf("expected new operation to be rejected as unavailable, got: %v", err)
+	}
+
+	finished()
+	if finishedCtx.Err() == nil {
+		t.Errorf("expected context of the finished operation to be released")
+	}
+
+	// the stuck operation gets canceled at the drain deadline and completes.
+	<-stuckCtx.Done()
+	stuck()
+
+	select {
+	case <-drained:
+	case <-time.After(time.Second):
+		t.Fatalf("drain didn't return after all operations completed")
+	}
+
+	if n := tracker.count("repo2", "upload-pack"); n != 0 {
+		t.Errorf("expected no in-flight operations after the drain, got %d", n)
+	}
+}
@@ -17,6 +17,8 @@ package git
 import (
 	"context"
 	"fmt"
+
+	"github.com/harness/gitness/git/command"
 )
 
 const (
@@ -47,3 +49,12 @@ func CreateEnvironmentForPush(ctx context.Context, writeRequest WriteParams) []s
 
 	return environ
 }
+
+// CreateEnvironmentForReflog returns the environment variables git uses to attribute reference updates
+// recorded in the reflog to the pusher.
+func CreateEnvironmentForReflog(pusher Identity) map[string]string {
+	return map[string]string{
+		command.GitCommitterName:  pusher.Name,
+		command.GitCommitterEmail: pusher.Email,
+	}
+}
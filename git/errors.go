@@ -15,9 +15,50 @@
 package git
 
 import (
+	"context"
+
 	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/api"
+
+	"github.com/rs/zerolog/log"
 )
 
+// ErrCodeRepositoryEmpty is the error code of errors caused by reading from a repository without commits.
+const ErrCodeRepositoryEmpty = "repo_empty"
+
 var (
 	ErrNoParamsProvided = errors.InvalidArgument("params not provided")
 )
+
+// ErrRepositoryEmpty returns the error returned by read operations on a repository without commits.
+func ErrRepositoryEmpty() *errors.Error {
+	return errors.NotFound("repository is empty").
+		SetDetails(map[string]any{"code": ErrCodeRepositoryEmpty})
+}
+
+// IsErrRepositoryEmpty returns true iff the error was caused by reading from a repository without commits.
+func IsErrRepositoryEmpty(err error) bool {
+	e := errors.AsError(err)
+	return e != nil && e.Details["code"] == ErrCodeRepositoryEmpty
+}
+
+// IsErrUnrelatedHistories returns true iff the error was caused by commits without a common ancestor.
+func IsErrUnrelatedHistories(err error) bool {
+	e := errors.AsError(err)
+	return e != nil && e.Details["code"] == api.ErrCodeUnrelatedHistories
+}
+
+// processEmptyRepoErr replaces the error of a read operation with ErrRepositoryEmpty
+// if the repository is empty, as the operation then failed because there is nothing to read.
+func (s *Service) processEmptyRepoErr(ctx context.Context, repoPath string, err error) error {
+	isEmpty, errEmpty := s.git.IsRepositoryEmpty(ctx, repoPath)
+	if errEmpty != nil {
+		log.Ctx(ctx).Warn().Err(errEmpty).Msg("failed to check if repository is empty")
+		return err
+	}
+	if !isEmpty {
+		return err
+	}
+
+	return ErrRepositoryEmpty()
+}
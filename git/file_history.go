@@ -0,0 +1,136 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/sha"
+)
+
+type ListFileHistoryParams struct {
+	ReadParams
+	// GitREF is a git reference (branch / tag / commit SHA) from which the history is walked.
+	GitREF string
+	// Path is the path of the file at GitREF.
+	Path string
+	// After is the SHA of the last commit of the previous page - Optional, the first page is returned if empty.
+	After string
+	Limit int
+
+	// Since allows to filter for commits since the provided UNIX timestamp - Optional, ignored if value is 0.
+	Since int64
+	// Until allows to filter for commits until the provided UNIX timestamp - Optional, ignored if value is 0.
+	Until int64
+	// Committer allows to filter for commits based on the committer - Optional, ignored if string is empty.
+	Committer string
+
+	// IncludeNotes allows to include the git notes attached to the commits.
+	IncludeNotes bool
+	// NotesRef is the reference the notes are read from, defaults to api.DefaultNotesRef.
+	NotesRef string
+}
+
+func (p *ListFileHistoryParams) Validate() error {
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.GitREF == "" {
+		return errors.InvalidArgument("git reference is mandatory")
+	}
+
+	if p.Path == "" {
+		return errors.InvalidArgument("file path is mandatory")
+	}
+
+	if p.Limit <= 0 {
+		return errors.InvalidArgument("limit has to be positive")
+	}
+
+	if p.After != "" {
+		if _, err := sha.New(p.After); err != nil {
+			return errors.InvalidArgument("after has to be a commit SHA")
+		}
+	}
+
+	return validateNotesRef(p.NotesRef)
+}
+
+type ListFileHistoryOutput struct {
+	Commits []Commit
+	// Paths contains the path the file had at each of the commits.
+	Paths []string
+	// HasMore is true if there are more commits after the returned ones.
+	HasMore bool
+}
+
+// ListFileHistory lists the commits that changed a file, following renames of the file.
+func (s *Service) ListFileHistory(
+	ctx context.Context,
+	params *ListFileHistoryParams,
+) (*ListFileHistoryOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	var after string
+	if params.After != "" {
+		// normalize the SHA as it's compared to the output of git.
+		after = sha.Must(params.After).String()
+	}
+
+	entries, hasMore, err := s.git.FileHistory(ctx, repoPath, params.GitREF, params.Limit, api.FileHistoryFilter{
+		Path:      params.Path,
+		After:     after,
+		Since:     params.Since,
+		Until:     params.Until,
+		Committer: params.Committer,
+	})
+	if err != nil {
+		return nil, s.processEmptyRepoErr(ctx, repoPath, err)
+	}
+
+	commits := make([]Commit, len(entries))
+	paths := make([]string, len(entries))
+	for i, entry := range entries {
+		var commit *Commit
+		commit, err = mapCommit(entry.Commit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map rpc commit: %w", err)
+		}
+
+		commits[i] = *commit
+		paths[i] = entry.Path
+	}
+
+	if params.IncludeNotes {
+		err = s.addNotes(ctx, repoPath, notesRefOrDefault(params.NotesRef), commits)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &ListFileHistoryOutput{
+		Commits: commits,
+		Paths:   paths,
+		HasMore: hasMore,
+	}, nil
+}
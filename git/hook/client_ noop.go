@@ -38,3 +38,15 @@ func (c *NoopClient) Update(_ context.Context, _ UpdateInput) (Output, error) {
 func (c *NoopClient) PostReceive(_ context.Context, _ PostReceiveInput) (Output, error) {
 	return Output{Messages: c.messages}, nil
 }
+
+// NoopClientFactory creates clients that skip the git hooks.
+// It's used for references that are maintained exclusively by the server.
+type NoopClientFactory struct{}
+
+func NewNoopClientFactory() ClientFactory {
+	return &NoopClientFactory{}
+}
+
+func (f *NoopClientFactory) NewClient(_ map[string]string) (Client, error) {
+	return NewNoopClient(nil), nil
+}
@@ -229,11 +229,21 @@ func (u *RefUpdater) UpdateRef(ctx context.Context) error {
 
 	cmd.Add(command.WithArg(u.oldValue.String()))
 
+	// attribute the reference update recorded in the reflog to the pusher.
+	for _, key := range []string{command.GitCommitterName, command.GitCommitterEmail} {
+		if value, ok := u.envVars[key]; ok {
+			cmd.Add(command.WithEnv(key, value))
+		}
+	}
+
 	if err := cmd.Run(ctx, command.WithDir(u.repoPath)); err != nil {
 		msg := err.Error()
 		if strings.Contains(msg, "reference already exists") {
 			return errors.Conflict("reference already exists")
 		}
+		if strings.Contains(msg, "but expected") {
+			return errors.Conflict("reference %q was updated concurrently", u.ref)
+		}
 
 		return fmt.Errorf("update of ref %q from %q to %q failed: %w", u.ref, u.oldValue, u.newValue, err)
 	}
@@ -29,19 +29,32 @@ type Interface interface {
 	ListPaths(ctx context.Context, params *ListPathsParams) (*ListPathsOutput, error)
 	GetSubmodule(ctx context.Context, params *GetSubmoduleParams) (*GetSubmoduleOutput, error)
 	GetBlob(ctx context.Context, params *GetBlobParams) (*GetBlobOutput, error)
+	// HashBlob returns the SHA of the streamed blob content without writing it to the repository.
+	HashBlob(ctx context.Context, params *HashBlobParams) (*HashBlobOutput, error)
+	ExistsBlobs(ctx context.Context, params *ExistsBlobsParams) (*ExistsBlobsOutput, error)
 	CreateBranch(ctx context.Context, params *CreateBranchParams) (*CreateBranchOutput, error)
 	CreateCommitTag(ctx context.Context, params *CreateCommitTagParams) (*CreateCommitTagOutput, error)
 	DeleteTag(ctx context.Context, params *DeleteTagParams) error
+	GetTagTargets(ctx context.Context, params *GetTagTargetsParams) (*GetTagTargetsOutput, error)
 	GetBranch(ctx context.Context, params *GetBranchParams) (*GetBranchOutput, error)
 	DeleteBranch(ctx context.Context, params *DeleteBranchParams) error
 	ListBranches(ctx context.Context, params *ListBranchesParams) (*ListBranchesOutput, error)
 	UpdateDefaultBranch(ctx context.Context, params *UpdateDefaultBranchParams) error
+	GetRepoConfig(ctx context.Context, params *GetRepoConfigParams) (*GetRepoConfigOutput, error)
+	ListReflog(ctx context.Context, params *ListReflogParams) (*ListReflogOutput, error)
+	UpdateRepoConfig(ctx context.Context, params *UpdateRepoConfigParams) error
 	GetRef(ctx context.Context, params GetRefParams) (GetRefResponse, error)
+	// GetRefs resolves a list of ref names and commit SHAs with a single git invocation.
+	GetRefs(ctx context.Context, params *GetRefsParams) (*GetRefsOutput, error)
 	PathsDetails(ctx context.Context, params PathsDetailsParams) (PathsDetailsOutput, error)
 	Summary(ctx context.Context, params SummaryParams) (SummaryOutput, error)
 
 	// GetRepositorySize calculates the size of a repo in KiB.
 	GetRepositorySize(ctx context.Context, params *GetRepositorySizeParams) (*GetRepositorySizeOutput, error)
+	// OptimizeRepository writes pack bitmaps and the commit-graph of a repo.
+	OptimizeRepository(ctx context.Context, params *OptimizeRepositoryParams) error
+	// RemoveStaleQuarantineDirs removes the quarantine directories of pushes that didn't complete.
+	RemoveStaleQuarantineDirs(ctx context.Context, params *RemoveStaleQuarantineDirsParams) error
 	// UpdateRef creates, updates or deletes a git ref. If the OldValue is defined it must match the reference value
 	// prior to the call. To remove a ref use the zero ref as the NewValue. To require the creation of a new one and
 	// not update of an exiting one, set the zero ref as the OldValue.
@@ -55,12 +68,19 @@ type Interface interface {
 	 * Commits service
 	 */
 	GetCommit(ctx context.Context, params *GetCommitParams) (*GetCommitOutput, error)
+	ResolveCommitSHA(ctx context.Context, params *ResolveCommitSHAParams) (ResolveCommitSHAOutput, error)
 	ListCommits(ctx context.Context, params *ListCommitsParams) (*ListCommitsOutput, error)
+	ListFileHistory(ctx context.Context, params *ListFileHistoryParams) (*ListFileHistoryOutput, error)
+	ListNewCommitMessages(ctx context.Context, params *ListNewCommitMessagesParams) (*ListNewCommitMessagesOutput, error)
 	ListCommitTags(ctx context.Context, params *ListCommitTagsParams) (*ListCommitTagsOutput, error)
+	GetNote(ctx context.Context, params *GetNoteParams) (*GetNoteOutput, error)
+	SetNote(ctx context.Context, params *SetNoteParams) (*SetNoteOutput, error)
 	GetCommitDivergences(ctx context.Context, params *GetCommitDivergencesParams) (*GetCommitDivergencesOutput, error)
+	ContributorStats(ctx context.Context, params *ContributorStatsParams) (*ContributorStatsOutput, error)
 	CommitFiles(ctx context.Context, params *CommitFilesParams) (CommitFilesResponse, error)
 	MergeBase(ctx context.Context, params MergeBaseParams) (MergeBaseOutput, error)
 	IsAncestor(ctx context.Context, params IsAncestorParams) (IsAncestorOutput, error)
+	AreAncestors(ctx context.Context, params AreAncestorsParams) (AreAncestorsOutput, error)
 	FindOversizeFiles(
 		ctx context.Context,
 		params *FindOversizeFilesParams,
@@ -71,6 +91,8 @@ type Interface interface {
 	 */
 	GetInfoRefs(ctx context.Context, w io.Writer, params *InfoRefsParams) error
 	ServicePack(ctx context.Context, params *ServicePackParams) error
+	// Drain stops accepting new git cli operations and waits for in-flight operations to complete.
+	Drain(ctx context.Context)
 
 	/*
 	 * Diff services
@@ -79,6 +101,7 @@ type Interface interface {
 	Diff(ctx context.Context, in *DiffParams, files ...api.FileDiffRequest) (<-chan *FileDiff, <-chan error)
 	DiffFileNames(ctx context.Context, in *DiffParams) (DiffFileNamesOutput, error)
 	CommitDiff(ctx context.Context, params *GetCommitParams, w io.Writer) error
+	FormatPatch(ctx context.Context, w io.Writer, params *FormatPatchParams) error
 	DiffShortStat(ctx context.Context, params *DiffParams) (DiffShortStatOutput, error)
 	DiffStats(ctx context.Context, params *DiffParams) (DiffStatsOutput, error)
 
@@ -103,4 +126,6 @@ type Interface interface {
 	 */
 	ScanSecrets(ctx context.Context, param *ScanSecretsParams) (*ScanSecretsOutput, error)
 	Archive(ctx context.Context, params ArchiveParams, w io.Writer) error
+	CreateBundle(ctx context.Context, params *CreateBundleParams, w io.Writer) error
+	RestoreBundle(ctx context.Context, params *RestoreBundleParams, r io.Reader) (*RestoreBundleOutput, error)
 }
@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+type OptimizeRepositoryParams struct {
+	ReadParams
+}
+
+// OptimizeRepository runs the housekeeping of the repository
+// (gc with pack bitmaps and the commit-graph with changed-path Bloom filters).
+func (s *Service) OptimizeRepository(ctx context.Context, params *OptimizeRepositoryParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	if err := s.git.OptimizeRepository(ctx, repoPath); err != nil {
+		return fmt.Errorf("failed to optimize repository: %w", err)
+	}
+
+	return nil
+}
+
+type RemoveStaleQuarantineDirsParams struct {
+	ReadParams
+}
+
+// defaultStaleQuarantineAge is the age after which a quarantine directory is considered stale
+// in case no receive-pack timeout is configured.
+const defaultStaleQuarantineAge = 24 * time.Hour
+
+// RemoveStaleQuarantineDirs removes the quarantine directories of pushes that exceeded the max receive-pack duration.
+func (s *Service) RemoveStaleQuarantineDirs(ctx context.Context, params *RemoveStaleQuarantineDirsParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	maxAge := s.timeouts.ReceivePack
+	if maxAge <= 0 {
+		maxAge = defaultStaleQuarantineAge
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	if err := s.git.RemoveStaleQuarantineDirs(ctx, repoPath, time.Now().Add(-maxAge)); err != nil {
+		return fmt.Errorf("failed to remove stale quarantine directories: %w", err)
+	}
+
+	return nil
+}
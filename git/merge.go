@@ -279,7 +279,7 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 
 	// merge
 
-	refUpdater, err := hook.CreateRefUpdater(s.hookClientFactory, params.EnvVars, repoPath, refPath)
+	refUpdater, err := hook.CreateRefUpdater(s.hookClientFactoryForRef(refPath), params.EnvVars, repoPath, refPath)
 	if err != nil {
 		return MergeOutput{}, errors.Internal(err, "failed to create ref updater object")
 	}
@@ -390,12 +390,14 @@ func (s *Service) IsAncestor(
 ) (IsAncestorOutput, error) {
 	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
 
-	result, err := s.git.IsAncestor(
+	result, err := s.ancestry.IsAncestor(
 		ctx,
 		repoPath,
 		params.AlternateObjectDirs,
-		params.AncestorCommitSHA,
-		params.DescendantCommitSHA,
+		api.AncestryCheck{
+			Ancestor:   params.AncestorCommitSHA,
+			Descendant: params.DescendantCommitSHA,
+		},
 	)
 	if err != nil {
 		return IsAncestorOutput{}, err
@@ -405,3 +407,47 @@ func (s *Service) IsAncestor(
 		Ancestor: result,
 	}, nil
 }
+
+type AncestorCheck struct {
+	AncestorCommitSHA   sha.SHA
+	DescendantCommitSHA sha.SHA
+}
+
+type AreAncestorsParams struct {
+	ReadParams
+	Checks []AncestorCheck
+}
+
+type AreAncestorsOutput struct {
+	// Ancestors contains for each of the checks if the ancestor commit is an ancestor of the descendant commit.
+	Ancestors []bool
+}
+
+// AreAncestors runs all provided ancestry checks using a single walk of the commit graph.
+func (s *Service) AreAncestors(
+	ctx context.Context,
+	params AreAncestorsParams,
+) (AreAncestorsOutput, error) {
+	if err := params.Validate(); err != nil {
+		return AreAncestorsOutput{}, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	checks := make([]api.AncestryCheck, len(params.Checks))
+	for i, check := range params.Checks {
+		checks[i] = api.AncestryCheck{
+			Ancestor:   check.AncestorCommitSHA,
+			Descendant: check.DescendantCommitSHA,
+		}
+	}
+
+	result, err := s.git.AreAncestors(ctx, repoPath, params.AlternateObjectDirs, checks)
+	if err != nil {
+		return AreAncestorsOutput{}, err
+	}
+
+	return AreAncestorsOutput{
+		Ancestors: result,
+	}, nil
+}
@@ -0,0 +1,268 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/check"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/git/sharedrepo"
+
+	"github.com/rs/zerolog/log"
+)
+
+// maxSetNoteAttempts is the number of times writing a note is attempted
+// in case the notes reference is updated concurrently.
+const maxSetNoteAttempts = 5
+
+type GetNoteParams struct {
+	ReadParams
+	// NotesRef is the reference the notes are stored in, defaults to api.DefaultNotesRef.
+	NotesRef  string
+	CommitSHA sha.SHA
+}
+
+func (p *GetNoteParams) Validate() error {
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.CommitSHA.IsEmpty() {
+		return errors.InvalidArgument("commit sha is mandatory")
+	}
+
+	return validateNotesRef(p.NotesRef)
+}
+
+type GetNoteOutput struct {
+	Note string
+}
+
+// GetNote returns the note attached to the commit.
+func (s *Service) GetNote(ctx context.Context, params *GetNoteParams) (*GetNoteOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	notes, err := s.git.GetNotes(ctx, repoPath, notesRefOrDefault(params.NotesRef), []sha.SHA{params.CommitSHA})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get note: %w", err)
+	}
+
+	note, ok := notes[params.CommitSHA]
+	if !ok {
+		return nil, errors.NotFound("commit %s has no note", params.CommitSHA)
+	}
+
+	return &GetNoteOutput{Note: note}, nil
+}
+
+type SetNoteParams struct {
+	WriteParams
+	// NotesRef is the reference the notes are stored in, defaults to api.DefaultNotesRef.
+	NotesRef  string
+	CommitSHA sha.SHA
+	Note      string
+}
+
+func (p *SetNoteParams) Validate() error {
+	if err := p.WriteParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.CommitSHA.IsEmpty() {
+		return errors.InvalidArgument("commit sha is mandatory")
+	}
+
+	if strings.TrimSpace(p.Note) == "" {
+		return errors.InvalidArgument("note can't be empty")
+	}
+
+	return validateNotesRef(p.NotesRef)
+}
+
+type SetNoteOutput struct {
+	// NotesCommitSHA is the commit of the notes reference that contains the note.
+	NotesCommitSHA sha.SHA
+}
+
+// SetNote creates or overwrites the note attached to the commit.
+// The note is written as a new commit on the notes reference, authored by the actor.
+func (s *Service) SetNote(ctx context.Context, params *SetNoteParams) (*SetNoteOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	notesRef := notesRefOrDefault(params.NotesRef)
+
+	if _, err := s.git.GetCommit(ctx, repoPath, params.CommitSHA.String()); err != nil {
+		return nil, fmt.Errorf("failed to get commit: %w", err)
+	}
+
+	for attempt := 1; ; attempt++ {
+		notesCommitSHA, err := s.setNote(ctx, repoPath, notesRef, params)
+		if errors.IsConflict(err) && attempt < maxSetNoteAttempts {
+			log.Ctx(ctx).Debug().Err(err).Msgf("notes reference %q was updated concurrently, retrying", notesRef)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to set note: %w", err)
+		}
+
+		return &SetNoteOutput{NotesCommitSHA: notesCommitSHA}, nil
+	}
+}
+
+// setNote commits the note on top of the current value of the notes reference.
+// A conflict error is returned in case the notes reference got updated in the meantime.
+func (s *Service) setNote(
+	ctx context.Context,
+	repoPath string,
+	notesRef string,
+	params *SetNoteParams,
+) (sha.SHA, error) {
+	oldSHA, err := s.git.GetRef(ctx, repoPath, notesRef)
+	if errors.IsNotFound(err) {
+		oldSHA = sha.Nil
+	} else if err != nil {
+		return sha.None, fmt.Errorf("failed to get notes reference: %w", err)
+	}
+
+	refUpdater, err := hook.CreateRefUpdater(s.hookClientFactory, params.EnvVars, repoPath, notesRef)
+	if err != nil {
+		return sha.None, fmt.Errorf("failed to create ref updater: %w", err)
+	}
+
+	var newSHA sha.SHA
+	err = sharedrepo.Run(ctx, refUpdater, s.tmpDir, repoPath, func(r *sharedrepo.SharedRepo) error {
+		var errCommit error
+		newSHA, errCommit = commitNote(ctx, r, oldSHA, params)
+		if errCommit != nil {
+			return errCommit
+		}
+
+		return refUpdater.Init(ctx, oldSHA, newSHA)
+	})
+	if err != nil {
+		return sha.None, err
+	}
+
+	return newSHA, nil
+}
+
+// commitNote creates a commit on top of the provided notes commit (sha.Nil if there's none)
+// with the note of the commit replaced.
+func commitNote(
+	ctx context.Context,
+	r *sharedrepo.SharedRepo,
+	parentSHA sha.SHA,
+	params *SetNoteParams,
+) (sha.SHA, error) {
+	var parents []sha.SHA
+	if !parentSHA.IsNil() {
+		if err := r.SetIndex(ctx, parentSHA); err != nil {
+			return sha.None, fmt.Errorf("failed to set index to notes tree: %w", err)
+		}
+		parents = append(parents, parentSHA)
+	}
+
+	// remove an existing note, independent of the fanout of the notes tree.
+	if err := r.RemoveFilesFromIndex(ctx, api.NotePaths(params.CommitSHA)...); err != nil {
+		return sha.None, fmt.Errorf("failed to remove existing note: %w", err)
+	}
+
+	blobSHA, err := r.WriteGitObject(ctx, strings.NewReader(strings.TrimSuffix(params.Note, "\n")+"\n"))
+	if err != nil {
+		return sha.None, fmt.Errorf("failed to write note: %w", err)
+	}
+
+	err = r.AddObjectToIndex(ctx, "100644", blobSHA, params.CommitSHA.String())
+	if err != nil {
+		return sha.None, fmt.Errorf("failed to add note to index: %w", err)
+	}
+
+	treeSHA, err := r.WriteTree(ctx)
+	if err != nil {
+		return sha.None, fmt.Errorf("failed to write notes tree: %w", err)
+	}
+
+	actor := &api.Signature{Identity: api.Identity(params.Actor), When: time.Now().UTC()}
+	message := fmt.Sprintf("Notes added for commit %s", params.CommitSHA)
+
+	commitSHA, err := r.CommitTree(ctx, actor, actor, treeSHA, message, false, parents...)
+	if err != nil {
+		return sha.None, fmt.Errorf("failed to commit notes tree: %w", err)
+	}
+
+	return commitSHA, nil
+}
+
+// addNotes attaches the notes stored in the notes reference to the commits.
+func (s *Service) addNotes(ctx context.Context, repoPath string, notesRef string, commits []Commit) error {
+	commitSHAs := make([]sha.SHA, len(commits))
+	for i := range commits {
+		commitSHAs[i] = commits[i].SHA
+	}
+
+	notes, err := s.git.GetNotes(ctx, repoPath, notesRef, commitSHAs)
+	if err != nil {
+		return fmt.Errorf("failed to get notes of commits: %w", err)
+	}
+
+	for i := range commits {
+		if note, ok := notes[commits[i].SHA]; ok {
+			commits[i].Note = &note
+		}
+	}
+
+	return nil
+}
+
+func notesRefOrDefault(notesRef string) string {
+	if notesRef == "" {
+		return api.DefaultNotesRef
+	}
+
+	return notesRef
+}
+
+// validateNotesRef ensures the notes reference is part of the notes namespace,
+// which keeps notes out of branch and tag listings.
+func validateNotesRef(notesRef string) error {
+	if notesRef == "" {
+		return nil
+	}
+
+	name, ok := strings.CutPrefix(notesRef, api.NotesRefPrefix)
+	if !ok {
+		return errors.InvalidArgument("notes reference has to start with %q", api.NotesRefPrefix)
+	}
+
+	if err := check.BranchName(name); err != nil {
+		return errors.InvalidArgument("invalid notes reference %q", notesRef)
+	}
+
+	return nil
+}
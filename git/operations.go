@@ -61,6 +61,9 @@ type CommitFilesParams struct {
 	Branch    string
 	NewBranch string
 	Actions   []CommitFileAction
+	// Patch is applied to the tree after the actions (optional).
+	// It can be a unified diff or a mailbox created by git format-patch.
+	Patch []byte
 
 	// Committer overwrites the git committer used for committing the files
 	// (optional, default: actor)
@@ -181,6 +184,12 @@ func (s *Service) CommitFiles(ctx context.Context, params *CommitFilesParams) (C
 			}
 		}
 
+		if len(params.Patch) > 0 {
+			if err = s.applyPatch(ctx, r, params.Patch); err != nil {
+				return err
+			}
+		}
+
 		treeSHA, err := r.WriteTree(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to write tree object: %w", err)
@@ -21,4 +21,5 @@ var (
 	ErrHunkNotFound       = errors.NotFound("hunk not found")
 	ErrBinaryFile         = errors.InvalidArgument("can't handle a binary file")
 	ErrPeekedMoreThanOnce = errors.PreconditionFailed("peeking more than once in a row is not supported")
+	ErrCorruptPatch       = errors.InvalidArgument("corrupt patch")
 )
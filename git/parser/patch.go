@@ -0,0 +1,172 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+)
+
+// PatchFile holds the part of a patch that changes a single file.
+type PatchFile struct {
+	// Path is the path of the file after the change, or before the change if the file is deleted.
+	Path string
+	// Header holds the lines of the file diff preceding the first hunk,
+	// including extended headers and binary patch data.
+	Header []string
+	Hunks  []Hunk
+}
+
+// HunkPatch returns a patch that applies only the i-th hunk of the file.
+func (f *PatchFile) HunkPatch(i int) string {
+	sb := strings.Builder{}
+
+	for _, line := range f.Header {
+		sb.WriteString(line)
+		sb.WriteByte('\n')
+	}
+
+	sb.WriteString(f.Hunks[i].HunkHeader.String())
+	sb.WriteByte('\n')
+
+	for _, line := range f.Hunks[i].Lines {
+		sb.WriteString(line)
+		sb.WriteByte('\n')
+	}
+
+	return sb.String()
+}
+
+// ParsePatch splits a patch in unified diff format, with or without git headers, into files and hunks.
+// Content outside of file diffs, like the commit message of a mailbox created by git format-patch, is ignored.
+func ParsePatch(r io.Reader) ([]*PatchFile, error) {
+	br := bufio.NewReader(r)
+
+	var files []*PatchFile
+	var file *PatchFile
+	var hunk *Hunk
+	var oldLeft, newLeft int
+	var oldFileLine string
+
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+
+		if line == "" && err != nil {
+			break
+		}
+
+		line = strings.TrimSuffix(line, "\n")
+
+		if hunk != nil {
+			if oldLeft > 0 || newLeft > 0 {
+				switch {
+				case line == "", line[0] == ' ':
+					oldLeft--
+					newLeft--
+				case line[0] == '-':
+					oldLeft--
+				case line[0] == '+':
+					newLeft--
+				case line[0] == '\\':
+				default:
+					return nil, ErrCorruptPatch
+				}
+
+				hunk.Lines = append(hunk.Lines, line)
+				continue
+			}
+
+			// the "\ No newline at end of file" marker follows the last line of a hunk.
+			if strings.HasPrefix(line, `\`) {
+				hunk.Lines = append(hunk.Lines, line)
+				continue
+			}
+
+			hunk = nil
+		}
+
+		if h, ok := ParseDiffFileHeader(line); ok {
+			file = &PatchFile{Path: h.NewFileName, Header: []string{line}}
+			files = append(files, file)
+			oldFileLine = ""
+			continue
+		}
+
+		if file != nil {
+			if h, ok := ParseDiffHunkHeader(line); ok {
+				file.Hunks = append(file.Hunks, Hunk{HunkHeader: h})
+				hunk = &file.Hunks[len(file.Hunks)-1]
+				oldLeft, newLeft = h.OldSpan, h.NewSpan
+				continue
+			}
+
+			if len(file.Hunks) == 0 {
+				file.Header = append(file.Header, line)
+				continue
+			}
+		}
+
+		// diffs without git headers start with the "---" and "+++" lines.
+		if strings.HasPrefix(line, "--- ") {
+			oldFileLine = line
+			continue
+		}
+
+		if oldFileLine != "" && strings.HasPrefix(line, "+++ ") {
+			file = &PatchFile{
+				Path:   unifiedDiffPath(oldFileLine, line),
+				Header: []string{oldFileLine, line},
+			}
+			files = append(files, file)
+		}
+
+		oldFileLine = ""
+	}
+
+	if hunk != nil && (oldLeft > 0 || newLeft > 0) {
+		return nil, ErrCorruptPatch
+	}
+
+	return files, nil
+}
+
+// unifiedDiffPath returns the path of the changed file from the "---" and "+++" lines of a unified diff.
+func unifiedDiffPath(oldFileLine, newFileLine string) string {
+	name := func(line string) string {
+		name := line[4:]
+		if i := strings.IndexByte(name, '\t'); i >= 0 {
+			name = name[:i]
+		}
+		if name == "/dev/null" {
+			return ""
+		}
+		// strip the first path component (like "a/" and "b/"), as git apply does by default.
+		if i := strings.IndexByte(name, '/'); i >= 0 {
+			name = name[i+1:]
+		}
+		return name
+	}
+
+	if path := name(newFileLine); path != "" {
+		return path
+	}
+
+	return name(oldFileLine)
+}
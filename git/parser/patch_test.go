@@ -0,0 +1,178 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParsePatch(t *testing.T) {
+	input := `From 5e1f2c3 Mon Sep 17 00:00:00 2001
+From: Jane Doe <jane@example.com>
+Subject: [PATCH] Change files
+
+--- a/message line that looks like a file header
+---
+ file.txt | 3 ++-
+ 2 files changed
+
+diff --git a/file.txt b/file.txt
+index 0123456..789abcd 100644
+--- a/file.txt
++++ b/file.txt
+@@ -1,3 +1,3 @@ func main() {
+ a
+-b
++B
+
+@@ -10,2 +10,3 @@
+ x
++y
+ z
+\ No newline at end of file
+--- old.txt	2024-01-01 00:00:00
++++ new.txt	2024-01-02 00:00:00
+@@ -1 +1 @@
+--- removed
++++ added
+diff --git a/image.png b/image.png
+new file mode 100644
+index 0000000..fb0c863
+GIT binary patch
+literal 3
+KcmZ?wbN~PV00031
+
+literal 0
+HcmV?d00001
+
+-- 
+2.39.5
+`
+
+	files, err := ParsePatch(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []*PatchFile{
+		{
+			Path: "file.txt",
+			Header: []string{
+				"diff --git a/file.txt b/file.txt",
+				"index 0123456..789abcd 100644",
+				"--- a/file.txt",
+				"+++ b/file.txt",
+			},
+			Hunks: []Hunk{
+				{
+					HunkHeader: HunkHeader{OldLine: 1, OldSpan: 3, NewLine: 1, NewSpan: 3, Text: "func main() {"},
+					Lines:      []string{" a", "-b", "+B", ""},
+				},
+				{
+					HunkHeader: HunkHeader{OldLine: 10, OldSpan: 2, NewLine: 10, NewSpan: 3},
+					Lines:      []string{" x", "+y", " z", `\ No newline at end of file`},
+				},
+			},
+		},
+		{
+			Path:   "new.txt",
+			Header: []string{"--- old.txt\t2024-01-01 00:00:00", "+++ new.txt\t2024-01-02 00:00:00"},
+			Hunks: []Hunk{
+				{
+					HunkHeader: HunkHeader{OldLine: 1, OldSpan: 1, NewLine: 1, NewSpan: 1},
+					Lines:      []string{"--- removed", "+++ added"},
+				},
+			},
+		},
+		{
+			Path: "image.png",
+			Header: []string{
+				"diff --git a/image.png b/image.png",
+				"new file mode 100644",
+				"index 0000000..fb0c863",
+				"GIT binary patch",
+				"literal 3",
+				"KcmZ?wbN~PV00031",
+				"",
+				"literal 0",
+				"HcmV?d00001",
+				"",
+				"-- ",
+				"2.39.5",
+			},
+		},
+	}
+
+	if diff := cmp.Diff(want, files); diff != "" {
+		t.Errorf("unexpected result (-want +got):\n%s", diff)
+	}
+
+	wantHunkPatch := "diff --git a/file.txt b/file.txt\n" +
+		"index 0123456..789abcd 100644\n" +
+		"--- a/file.txt\n" +
+		"+++ b/file.txt\n" +
+		"@@ -10,2 +10,3 @@\n" +
+		" x\n" +
+		"+y\n" +
+		" z\n" +
+		"\\ No newline at end of file\n"
+	if got := files[0].HunkPatch(1); got != wantHunkPatch {
+		t.Errorf("unexpected hunk patch:\n%s", got)
+	}
+}
+
+func TestParsePatch_Unified(t *testing.T) {
+	input := `--- a/docs/old.txt	2024-01-01 00:00:00
++++ /dev/null	2024-01-02 00:00:00
+@@ -1 +0,0 @@
+-removed
+--- a/src/main.go
++++ b/src/main.go
+@@ -1 +1 @@
+-old
++new
+`
+
+	files, err := ParsePatch(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	paths := make([]string, len(files))
+	for i, f := range files {
+		paths[i] = f.Path
+	}
+
+	if diff := cmp.Diff([]string{"docs/old.txt", "src/main.go"}, paths); diff != "" {
+		t.Errorf("unexpected paths (-want +got):\n%s", diff)
+	}
+}
+
+func TestParsePatch_Corrupt(t *testing.T) {
+	input := `diff --git a/file.txt b/file.txt
+--- a/file.txt
++++ b/file.txt
+@@ -1,3 +1,3 @@
+ a
+?b
+`
+
+	if _, err := ParsePatch(strings.NewReader(input)); err == nil {
+		t.Error("expected an error for a corrupt patch")
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/git/parser"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/git/sharedrepo"
+
+	"golang.org/x/exp/slices"
+)
+
+// ErrCodePatchConflict is the error code of errors caused by patches that don't apply to the branch.
+const ErrCodePatchConflict = "patch_conflict"
+
+// PatchConflictFile describes a file of a patch that couldn't be applied.
+type PatchConflictFile struct {
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+	// Hunks holds the rejected hunks of the file, empty if the file was rejected as a whole.
+	Hunks []PatchConflictHunk `json:"hunks,omitempty"`
+}
+
+// PatchConflictHunk is a rejected hunk of a patch, including its context lines.
+type PatchConflictHunk struct {
+	Header string   `json:"header"`
+	Lines  []string `json:"lines"`
+}
+
+type ResolveCommitSHAParams struct {
+	ReadParams
+	// SHA is the full or abbreviated commit SHA.
+	SHA string
+}
+
+func (p *ResolveCommitSHAParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	return p.ReadParams.Validate()
+}
+
+type ResolveCommitSHAOutput struct {
+	SHA sha.SHA
+}
+
+// ResolveCommitSHA resolves a full or abbreviated commit SHA to the full SHA of the commit.
+// Abbreviated SHAs matching more than one commit are rejected as invalid argument.
+func (s *Service) ResolveCommitSHA(
+	ctx context.Context,
+	params *ResolveCommitSHAParams,
+) (ResolveCommitSHAOutput, error) {
+	if err := params.Validate(); err != nil {
+		return ResolveCommitSHAOutput{}, err
+	}
+
+	commitSHA, err := sha.New(params.SHA)
+	if err != nil {
+		return ResolveCommitSHAOutput{}, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	result, err := s.git.ResolveCommitSHA(ctx, repoPath, commitSHA)
+	if err != nil {
+		return ResolveCommitSHAOutput{}, fmt.Errorf("failed to resolve commit sha: %w", err)
+	}
+
+	return ResolveCommitSHAOutput{SHA: result}, nil
+}
+
+type FormatPatchParams struct {
+	ReadParams
+	// BaseRef is the exclusive start of the formatted commit range.
+	// If empty, only the HeadRef commit is formatted.
+	BaseRef string
+	HeadRef string
+}
+
+func (p *FormatPatchParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.HeadRef == "" {
+		return errors.InvalidArgument("head ref cannot be empty")
+	}
+
+	return nil
+}
+
+// FormatPatch streams the commits of the range in mailbox format (git format-patch), oldest commit first.
+func (s *Service) FormatPatch(ctx context.Context, w io.Writer, params *FormatPatchParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	return s.git.FormatPatch(ctx, repoPath, params.AlternateObjectDirs, params.BaseRef, params.HeadRef, w)
+}
+
+// applyPatch applies the patch to the index of the shared repository.
+// If the patch doesn't apply, the returned conflict error lists the rejected hunks of each file.
+func (s *Service) applyPatch(ctx context.Context, r *sharedrepo.SharedRepo, patch []byte) error {
+	treeSHA, err := r.WriteTree(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to write tree object before applying the patch: %w", err)
+	}
+
+	err = r.ApplyPatch(ctx, patch)
+	if err == nil {
+		return nil
+	}
+
+	cErr := command.AsError(err)
+	if cErr == nil {
+		return err
+	}
+	if cErr.IsExitCode(128) {
+		return errors.InvalidArgument("Invalid patch: %s", patchErrorMessage(cErr.StdErr))
+	}
+	if !cErr.IsExitCode(1) {
+		return err
+	}
+
+	unmerged, err := r.UnmergedFiles(ctx)
+	if err != nil {
+		return err
+	}
+
+	files, err := parser.ParsePatch(bytes.NewReader(patch))
+	if err != nil {
+		return errors.InvalidArgument("Invalid patch: %s", err)
+	}
+
+	// find the rejected hunks by checking each hunk on its own against the original index.
+	if err = r.SetIndex(ctx, treeSHA); err != nil {
+		return err
+	}
+
+	var conflicts []PatchConflictFile
+	for _, file := range files {
+		reason := patchFileErrorReason(cErr.StdErr, file.Path)
+		if reason == "" {
+			if !slices.Contains(unmerged, file.Path) {
+				continue
+			}
+			reason = "conflicts with changes on the branch"
+		}
+
+		conflict := PatchConflictFile{Path: file.Path, Reason: reason}
+		for i, hunk := range file.Hunks {
+			errCheck := r.CheckPatch(ctx, []byte(file.HunkPatch(i)))
+			if errCheck == nil {
+				continue
+			}
+			if cErrCheck := command.AsError(errCheck); cErrCheck == nil || !cErrCheck.IsExitCode(1) {
+				return errCheck
+			}
+
+			conflict.Hunks = append(conflict.Hunks, PatchConflictHunk{
+				Header: hunk.HunkHeader.String(),
+				Lines:  hunk.Lines,
+			})
+		}
+
+		conflicts = append(conflicts, conflict)
+	}
+
+	return errors.Conflict("The patch does not apply to the branch.").
+		SetDetails(map[string]any{"code": ErrCodePatchConflict, "files": conflicts})
+}
+
+// patchErrorMessage returns the first error message git apply printed.
+func patchErrorMessage(stderr []byte) string {
+	for _, line := range strings.Split(string(stderr), "\n") {
+		if msg, ok := strings.CutPrefix(line, "error: "); ok {
+			return msg
+		}
+	}
+
+	return strings.TrimSpace(string(stderr))
+}
+
+// patchFileErrorReason returns the reason git apply printed for rejecting the file, like "patch does not apply".
+func patchFileErrorReason(stderr []byte, path string) string {
+	for _, line := range strings.Split(string(stderr), "\n") {
+		msg, ok := strings.CutPrefix(line, "error: ")
+		if !ok {
+			continue
+		}
+
+		if reason, found := strings.CutPrefix(msg, path+": "); found {
+			return reason
+		}
+	}
+
+	return ""
+}
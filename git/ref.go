@@ -69,6 +69,46 @@ func (s *Service) GetRef(ctx context.Context, params GetRefParams) (GetRefRespon
 	return GetRefResponse{SHA: refSHA}, nil
 }
 
+type GetRefsParams struct {
+	ReadParams
+	// Refs contains branch names, tag names, full references or commit SHAs.
+	Refs []string
+}
+
+func (p *GetRefsParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+	if len(p.Refs) == 0 {
+		return errors.InvalidArgument("at least one ref has to be provided")
+	}
+	return nil
+}
+
+type GetRefsOutput struct {
+	// Refs contains the resolved refs in the same order as the input.
+	Refs []api.ResolvedRef
+}
+
+// GetRefs resolves the provided refs and returns for each of them whether it exists,
+// its kind (branch, tag or commit), the SHA it points to and, for annotated tags, the peeled SHA.
+func (s *Service) GetRefs(ctx context.Context, params *GetRefsParams) (*GetRefsOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	refs, err := s.git.ResolveRefs(ctx, repoPath, params.Refs)
+	if err != nil {
+		return nil, fmt.Errorf("GetRefs: failed to resolve refs: %w", err)
+	}
+
+	return &GetRefsOutput{Refs: refs}, nil
+}
+
 type UpdateRefParams struct {
 	WriteParams
 	Type enum.RefType
@@ -92,7 +132,7 @@ func (s *Service) UpdateRef(ctx context.Context, params UpdateRefParams) error {
 		return fmt.Errorf("UpdateRef: failed to fetch reference '%s': %w", params.Name, err)
 	}
 
-	refUpdater, err := hook.CreateRefUpdater(s.hookClientFactory, params.EnvVars, repoPath, reference)
+	refUpdater, err := hook.CreateRefUpdater(s.hookClientFactoryForRef(reference), params.EnvVars, repoPath, reference)
 	if err != nil {
 		return fmt.Errorf("UpdateRef: failed to create ref updater: %w", err)
 	}
@@ -104,9 +144,26 @@ func (s *Service) UpdateRef(ctx context.Context, params UpdateRefParams) error {
 	return nil
 }
 
+const refPullReqPrefix = "refs/pullreq/"
+
+// internalRefPrefixes are the reference namespaces that are maintained exclusively by the server.
+var internalRefPrefixes = []string{refPullReqPrefix}
+
+// hookClientFactoryForRef returns the git hook client factory used for updating the reference.
+// Updates of internal references skip the git hooks, as there's nothing to verify or report for them
+// and the pre-receive hook rejects updates of references in hidden namespaces, which they are usually part of.
+func (s *Service) hookClientFactoryForRef(ref string) hook.ClientFactory {
+	for _, prefix := range internalRefPrefixes {
+		if strings.HasPrefix(ref, prefix) {
+			return hook.NewNoopClientFactory()
+		}
+	}
+
+	return s.hookClientFactory
+}
+
 func GetRefPath(refName string, refType enum.RefType) (string, error) {
 	const (
-		refPullReqPrefix      = "refs/pullreq/"
 		refPullReqHeadSuffix  = "/head"
 		refPullReqMergeSuffix = "/merge"
 	)
@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/sha"
+)
+
+type ListReflogParams struct {
+	ReadParams
+	// Ref is the full name of the reference (e.g. refs/heads/main).
+	Ref   string
+	Page  int32
+	Limit int32
+}
+
+func (p *ListReflogParams) Validate() error {
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.Ref == "" {
+		return errors.InvalidArgument("reference is mandatory")
+	}
+
+	return nil
+}
+
+type ReflogEntry struct {
+	OldSHA  sha.SHA
+	NewSHA  sha.SHA
+	Pusher  Signature
+	Message string
+}
+
+type ListReflogOutput struct {
+	Entries []ReflogEntry
+}
+
+// ListReflog returns the updates of a reference recorded in its reflog, most recent first.
+// The reflog of a reference is removed together with the reference.
+func (s *Service) ListReflog(ctx context.Context, params *ListReflogParams) (*ListReflogOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	page, limit := params.Page, params.Limit
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 1
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	entries, err := s.git.ListReflog(ctx, repoPath, params.Ref, int((page-1)*limit), int(limit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reflog: %w", err)
+	}
+
+	out := &ListReflogOutput{
+		Entries: make([]ReflogEntry, len(entries)),
+	}
+	for i := range entries {
+		pusher, err := mapSignature(&entries[i].Pusher)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map pusher: %w", err)
+		}
+
+		out.Entries[i] = ReflogEntry{
+			OldSHA:  entries[i].OldSHA,
+			NewSHA:  entries[i].NewSHA,
+			Pusher:  *pusher,
+			Message: entries[i].Message,
+		}
+	}
+
+	return out, nil
+}
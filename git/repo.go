@@ -416,6 +416,12 @@ func (s *Service) createRepositoryInternal(
 		return fmt.Errorf("createRepositoryInternal: failed to initialize the repository: %w", err)
 	}
 
+	// bare repositories don't record reference updates by default - enable it to keep the history of references.
+	err = s.git.Config(ctx, repoPath, RepoConfigKeyLogAllRefUpdates, "true")
+	if err != nil {
+		return fmt.Errorf("createRepositoryInternal: failed to enable the reflog: %w", err)
+	}
+
 	// update default branch (currently set to non-existent branch)
 	err = s.git.SetDefaultBranch(ctx, repoPath, defaultBranch, true)
 	if err != nil {
@@ -557,6 +563,9 @@ func (s *Service) Archive(ctx context.Context, params ArchiveParams, w io.Writer
 	if err := params.Validate(); err != nil {
 		return err
 	}
+	ctx, cancel := withTimeout(ctx, s.timeouts.Archive)
+	defer cancel()
+
 	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
 	err := s.git.Archive(ctx, repoPath, params.ArchiveParams, w)
 	if err != nil {
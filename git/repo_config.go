@@ -0,0 +1,140 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+)
+
+// RepoConfigKeyLogAllRefUpdates is the git config key that enables the reflog of the repository.
+const RepoConfigKeyLogAllRefUpdates = "core.logAllRefUpdates"
+
+// repoConfigKeys is the curated list of git config keys that can be managed per repository.
+// Keys are in canonical form (lower case).
+var repoConfigKeys = map[string]struct{}{
+	"receive.denydeletes":         {},
+	"receive.denynonfastforwards": {},
+	"core.logallrefupdates":       {},
+}
+
+// repoConfigKeyPrefixes are the prefixes of git config keys that can be managed per repository.
+var repoConfigKeyPrefixes = []string{
+	"gc.",
+}
+
+// IsRepoConfigKeyAllowed returns true in case the git config key can be managed per repository.
+func IsRepoConfigKeyAllowed(key string) bool {
+	key = strings.ToLower(key)
+
+	if _, ok := repoConfigKeys[key]; ok {
+		return true
+	}
+
+	for _, prefix := range repoConfigKeyPrefixes {
+		if strings.HasPrefix(key, prefix) && len(key) > len(prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type GetRepoConfigParams struct {
+	ReadParams
+}
+
+type GetRepoConfigOutput struct {
+	// Values contains the values of all set git config keys that can be managed per repository.
+	Values map[string]string
+}
+
+// GetRepoConfig returns the git config of the repository that can be managed per repository.
+func (s *Service) GetRepoConfig(
+	ctx context.Context,
+	params *GetRepoConfigParams,
+) (*GetRepoConfigOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	config, err := s.git.ListConfig(ctx, repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list git config: %w", err)
+	}
+
+	values := map[string]string{}
+	for key, value := range config {
+		if IsRepoConfigKeyAllowed(key) {
+			values[key] = value
+		}
+	}
+
+	return &GetRepoConfigOutput{
+		Values: values,
+	}, nil
+}
+
+type UpdateRepoConfigParams struct {
+	WriteParams
+
+	// Values contains the new values of the git config keys. A nil value unsets the key.
+	Values map[string]*string
+}
+
+func (p *UpdateRepoConfigParams) Validate() error {
+	if err := p.WriteParams.Validate(); err != nil {
+		return err
+	}
+
+	for key := range p.Values {
+		if !IsRepoConfigKeyAllowed(key) {
+			return errors.InvalidArgument("git config key %q can't be managed per repository", key)
+		}
+	}
+
+	return nil
+}
+
+// UpdateRepoConfig sets or unsets the provided git config keys of the repository.
+func (s *Service) UpdateRepoConfig(
+	ctx context.Context,
+	params *UpdateRepoConfigParams,
+) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	for key, value := range params.Values {
+		var err error
+		if value == nil {
+			err = s.git.UnsetConfig(ctx, repoPath, key)
+		} else {
+			err = s.git.Config(ctx, repoPath, key, *value)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update git config %q: %w", key, err)
+		}
+	}
+
+	return nil
+}
@@ -38,6 +38,9 @@ type Service struct {
 	store             storage.Store
 	gitHookPath       string
 	reposGraveyard    string
+	ops               *operationTracker
+	timeouts          types.TimeoutsConfig
+	ancestry          *ancestryBatcher
 }
 
 func New(
@@ -70,5 +73,8 @@ func New(
 		hookClientFactory: hookClientFactory,
 		store:             storage,
 		gitHookPath:       config.HookPath,
+		ops:               newOperationTracker(),
+		timeouts:          config.Timeouts,
+		ancestry:          newAncestryBatcher(adapter, config.AncestryBatchWindow),
 	}, nil
 }
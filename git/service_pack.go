@@ -18,10 +18,13 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git/api"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 type InfoRefsParams struct {
@@ -29,6 +32,8 @@ type InfoRefsParams struct {
 	Service     string
 	Options     []string // (key, value) pair
 	GitProtocol string
+	// HiddenRefs are the reference prefixes hidden in addition to the ones configured for the instance.
+	HiddenRefs []string
 }
 
 func (s *Service) GetInfoRefs(ctx context.Context, w io.Writer, params *InfoRefsParams) error {
@@ -41,8 +46,17 @@ func (s *Service) GetInfoRefs(ctx context.Context, w io.Writer, params *InfoRefs
 		environ = append(environ, "GIT_PROTOCOL="+params.GitProtocol)
 	}
 
+	ctx, done, err := s.ops.start(ctx, params.RepoUID, params.Service)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	ctx, cancel := withTimeout(ctx, s.timeouts.InfoRefs)
+	defer cancel()
+
 	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
-	err := s.git.InfoRefs(ctx, repoPath, params.Service, w, environ...)
+	err = s.git.InfoRefs(ctx, repoPath, params.Service, params.HiddenRefs, w, environ...)
 	if err != nil {
 		return fmt.Errorf("failed to fetch info references: %w", err)
 	}
@@ -66,27 +80,73 @@ func (s *Service) ServicePack(ctx context.Context, params *ServicePackParams) er
 	if err := params.Validate(); err != nil {
 		return err
 	}
-	var repoPath string
+	var repoUID string
+	var timeout time.Duration
 	switch params.Service {
 	case enum.GitServiceTypeUploadPack:
 		if err := params.ReadParams.Validate(); err != nil {
 			return errors.InvalidArgument("upload-pack requires ReadParams")
 		}
-		repoPath = getFullPathForRepo(s.reposRoot, params.ReadParams.RepoUID)
+		repoUID = params.ReadParams.RepoUID
+		timeout = s.timeouts.UploadPack
 	case enum.GitServiceTypeReceivePack:
 		if err := params.WriteParams.Validate(); err != nil {
 			return errors.InvalidArgument("receive-pack requires WriteParams")
 		}
 		params.Env = append(params.Env, CreateEnvironmentForPush(ctx, *params.WriteParams)...)
-		repoPath = getFullPathForRepo(s.reposRoot, params.WriteParams.RepoUID)
+		repoUID = params.WriteParams.RepoUID
+		timeout = s.timeouts.ReceivePack
 	default:
 		return errors.InvalidArgument("unsupported service provided: %s", params.Service)
 	}
 
-	err := s.git.ServicePack(ctx, repoPath, params.ServicePackOptions)
+	ctx, done, err := s.ops.start(ctx, repoUID, string(params.Service))
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	ctx, cancel := withTimeout(ctx, timeout)
+	defer cancel()
+
+	repoPath := getFullPathForRepo(s.reposRoot, repoUID)
+
+	// remember the existing quarantine directories to be able to remove the one of the push in case it fails.
+	var quarantineDirs []string
+	var quarantineDirsErr error
+	if params.Service == enum.GitServiceTypeReceivePack {
+		quarantineDirs, quarantineDirsErr = s.git.ListQuarantineDirs(repoPath)
+	}
+
+	err = s.git.ServicePack(ctx, repoPath, params.ServicePackOptions)
 	if err != nil {
+		if params.Service == enum.GitServiceTypeReceivePack && quarantineDirsErr == nil {
+			s.removeFailedPushQuarantineDir(context.WithoutCancel(ctx), repoUID, repoPath, quarantineDirs)
+		}
 		return fmt.Errorf("failed to execute git %s: %w", params.Service, err)
 	}
 
 	return nil
 }
+
+// removeFailedPushQuarantineDir removes the quarantine directory of a failed push right away,
+// as git can't clean it up if the receive-pack process got killed (e.g. on timeout or shutdown).
+// The quarantine directories of concurrent pushes to the same repository can't be told apart,
+// so in that case the directory is left to the periodic removal of stale quarantine directories.
+func (s *Service) removeFailedPushQuarantineDir(
+	ctx context.Context,
+	repoUID string,
+	repoPath string,
+	existingDirs []string,
+) {
+	failedAt := time.Now()
+
+	if s.ops.count(repoUID, string(enum.GitServiceTypeReceivePack)) > 1 {
+		return
+	}
+
+	err := s.git.RemoveNewQuarantineDirs(ctx, repoPath, existingDirs, failedAt)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to remove quarantine directory of failed push")
+	}
+}
@@ -359,6 +359,71 @@ func CleanupMergeConflicts(conflicts []string) []string {
 	return out
 }
 
+// ApplyPatch applies the patch to the git index. Changes of files whose original content
+// is available in the repository are applied using a three-way merge, files with conflicting
+// changes are left unmerged in the index.
+func (r *SharedRepo) ApplyPatch(ctx context.Context, patch []byte) error {
+	cmd := command.New("apply",
+		command.WithFlag("--cached"),
+		command.WithFlag("--3way"),
+		command.WithFlag("--whitespace=nowarn"))
+
+	err := cmd.Run(ctx,
+		command.WithDir(r.repoPath),
+		command.WithStdin(bytes.NewReader(patch)))
+	if err != nil {
+		return fmt.Errorf("failed to apply patch in shared repo: %w", err)
+	}
+
+	return nil
+}
+
+// CheckPatch checks whether the patch applies to the git index without a three-way merge.
+// The index is not modified.
+func (r *SharedRepo) CheckPatch(ctx context.Context, patch []byte) error {
+	cmd := command.New("apply",
+		command.WithFlag("--cached"),
+		command.WithFlag("--check"),
+		command.WithFlag("--whitespace=nowarn"))
+
+	err := cmd.Run(ctx,
+		command.WithDir(r.repoPath),
+		command.WithStdin(bytes.NewReader(patch)))
+	if err != nil {
+		return fmt.Errorf("failed to check patch in shared repo: %w", err)
+	}
+
+	return nil
+}
+
+// UnmergedFiles returns the paths of the files with unresolved conflicts in the git index.
+func (r *SharedRepo) UnmergedFiles(ctx context.Context) ([]string, error) {
+	cmd := command.New("ls-files",
+		command.WithFlag("--unmerged"),
+		command.WithFlag("-z"))
+
+	stdout := bytes.NewBuffer(nil)
+
+	err := cmd.Run(ctx, command.WithDir(r.repoPath), command.WithStdout(stdout))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unmerged files in shared repository's git index: %w", err)
+	}
+
+	// each unmerged file is listed once per stage: "<mode> <sha> <stage>\t<path>".
+	var files []string
+	for _, entry := range bytes.Split(stdout.Bytes(), []byte{'\000'}) {
+		_, name, ok := bytes.Cut(entry, []byte{'\t'})
+		if !ok {
+			continue
+		}
+		if file := string(name); !slices.Contains(files, file) {
+			files = append(files, file)
+		}
+	}
+
+	return files, nil
+}
+
 // CommitTree creates a commit from a given tree for the user with provided message.
 func (r *SharedRepo) CommitTree(
 	ctx context.Context,
@@ -46,30 +46,31 @@ func (s *Service) Summary(
 	}
 	defaultBranch = strings.TrimSpace(defaultBranch)
 
-	g, ctx := errgroup.WithContext(ctx)
+	g, gCtx := errgroup.WithContext(ctx)
 
 	var commitCount, branchCount, tagCount int
 
 	g.Go(func() error {
 		var err error
-		commitCount, err = merge.CommitCount(ctx, repoPath, "", defaultBranch)
+		commitCount, err = merge.CommitCount(gCtx, repoPath, "", defaultBranch)
 		return err
 	})
 
 	g.Go(func() error {
 		var err error
-		branchCount, err = s.git.GetBranchCount(ctx, repoPath)
+		branchCount, err = s.git.GetBranchCount(gCtx, repoPath)
 		return err
 	})
 
 	g.Go(func() error {
 		var err error
-		tagCount, err = s.git.GetTagCount(ctx, repoPath)
+		tagCount, err = s.git.GetTagCount(gCtx, repoPath)
 		return err
 	})
 
 	if err := g.Wait(); err != nil {
-		return SummaryOutput{}, fmt.Errorf("failed to get repo summary: %w", err)
+		return SummaryOutput{}, fmt.Errorf("failed to get repo summary: %w",
+			s.processEmptyRepoErr(ctx, repoPath, err))
 	}
 
 	return SummaryOutput{
@@ -355,6 +355,47 @@ func (s *Service) DeleteTag(ctx context.Context, params *DeleteTagParams) error
 	return nil
 }
 
+type GetTagTargetsParams struct {
+	ReadParams
+	// SHAs are the objects tag references are pointing to.
+	SHAs []sha.SHA
+}
+
+type TagTarget struct {
+	// Annotated is true if the object is an annotated tag.
+	Annotated bool
+	// CommitSHA is the commit the object peels to. It's empty if the object doesn't point to a commit.
+	CommitSHA sha.SHA
+}
+
+type GetTagTargetsOutput struct {
+	Targets []TagTarget
+}
+
+// GetTagTargets returns for each of the provided objects whether it's an annotated tag and the commit it peels to.
+func (s *Service) GetTagTargets(ctx context.Context, params *GetTagTargetsParams) (*GetTagTargetsOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	targets, err := s.git.GetTagTargets(ctx, repoPath, params.AlternateObjectDirs, params.SHAs)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]TagTarget, len(targets))
+	for i, target := range targets {
+		out[i] = TagTarget{
+			Annotated: target.Annotated,
+			CommitSHA: target.CommitSHA,
+		}
+	}
+
+	return &GetTagTargetsOutput{Targets: out}, nil
+}
+
 func (s *Service) listCommitTagsLoadReferenceData(
 	ctx context.Context,
 	repoPath string,
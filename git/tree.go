@@ -83,7 +83,8 @@ func (s *Service) GetTreeNode(ctx context.Context, params *GetTreeNodeParams) (*
 
 	gitNode, err := s.git.GetTreeNode(ctx, repoPath, params.GitREF, params.Path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find node '%s' in '%s': %w", params.Path, params.GitREF, err)
+		return nil, fmt.Errorf("failed to find node '%s' in '%s': %w", params.Path, params.GitREF,
+			s.processEmptyRepoErr(ctx, repoPath, err))
 	}
 
 	node, err := mapTreeNode(gitNode)
@@ -132,7 +133,7 @@ func (s *Service) ListTreeNodes(ctx context.Context, params *ListTreeNodeParams)
 		params.GitREF,
 		params.Path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list tree nodes: %w", err)
+		return nil, fmt.Errorf("failed to list tree nodes: %w", s.processEmptyRepoErr(ctx, repoPath, err))
 	}
 
 	nodes := make([]TreeNode, len(res))
@@ -176,7 +177,7 @@ func (s *Service) ListPaths(ctx context.Context, params *ListPathsParams) (*List
 		params.IncludeDirectories,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list paths: %w", err)
+		return nil, fmt.Errorf("failed to list paths: %w", s.processEmptyRepoErr(ctx, repoPath, err))
 	}
 
 	return &ListPathsOutput{
@@ -214,7 +215,8 @@ func (s *Service) PathsDetails(ctx context.Context, params PathsDetailsParams) (
 		params.GitREF,
 		params.Paths)
 	if err != nil {
-		return PathsDetailsOutput{}, fmt.Errorf("failed to get path details in '%s': %w", params.GitREF, err)
+		return PathsDetailsOutput{}, fmt.Errorf("failed to get path details in '%s': %w", params.GitREF,
+			s.processEmptyRepoErr(ctx, repoPath, err))
 	}
 
 	details := make([]PathDetails, len(pathsDetails))
@@ -31,9 +31,42 @@ type Config struct {
 	TmpDir string
 	// HookPath points to the binary used as git server hook.
 	HookPath string
+	// PartialClone specifies whether clients can request a partial clone (e.g. --filter=blob:none)
+	// and fetch the missing objects on demand.
+	PartialClone bool
+	// AdvertisePushOptions specifies whether receive-pack accepts push options (receive.advertisePushOptions).
+	AdvertisePushOptions bool
+	// AdvertiseAtomic specifies whether receive-pack supports atomic pushes (receive.advertiseAtomic).
+	AdvertiseAtomic bool
+	// HiddenRefs specifies the reference prefixes that are hidden from the reference advertisement
+	// of fetches and pushes (transfer.hideRefs).
+	HiddenRefs []string
+
+	// AncestryBatchWindow (optional) specifies how long ancestry checks of a repository are collected
+	// to be executed as a single batch. A zero value disables batching.
+	AncestryBatchWindow time.Duration
 
 	// LastCommitCache holds configuration options for the last commit cache.
 	LastCommitCache LastCommitCacheConfig
+
+	// Timeouts holds the max duration of long running git operations.
+	Timeouts TimeoutsConfig
+}
+
+// TimeoutsConfig holds the max duration of long running git operations.
+// The operations are bound by the context of the caller, a zero value means no additional limit.
+type TimeoutsConfig struct {
+	// InfoRefs defines the max duration of the reference advertisement (info/refs).
+	InfoRefs time.Duration
+
+	// UploadPack defines the max duration of git-upload-pack (clone, fetch).
+	UploadPack time.Duration
+
+	// ReceivePack defines the max duration of git-receive-pack (push).
+	ReceivePack time.Duration
+
+	// Archive defines the max duration of creating a repository archive.
+	Archive time.Duration
 }
 
 // LastCommitCacheConfig holds configuration options for the last commit cache.
@@ -0,0 +1,347 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package outbound
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/net/http/httpproxy"
+)
+
+const (
+	dialTimeout         = 30 * time.Second
+	dialKeepAlive       = 30 * time.Second
+	tlsHandshakeTimeout = 10 * time.Second
+)
+
+var (
+	ErrLoopbackNotAllowed       = errors.New("loopback not allowed")
+	ErrLinkLocalNotAllowed      = errors.New("link-local address not allowed")
+	ErrPrivateNetworkNotAllowed = errors.New("private network not allowed")
+	ErrResponseTooLarge         = errors.New("response exceeds the size limit")
+)
+
+// AllowAlways can be used to allow a type of destination independent of any settings.
+func AllowAlways() bool {
+	return true
+}
+
+// Config defines the configuration of an outbound http client.
+type Config struct {
+	// Name identifies the client in the logs (e.g. "webhook").
+	Name string
+
+	// Timeout is the max duration of a request, including reading the response body. Zero means no limit.
+	Timeout time.Duration
+
+	// ProxyURL is the proxy used for all requests. If empty, the proxy is taken from the environment
+	// (HTTP_PROXY, HTTPS_PROXY and NO_PROXY).
+	ProxyURL string
+
+	// MaxResponseSize is the max number of bytes read from a response body. Zero means no limit.
+	MaxResponseSize int64
+
+	// AllowLoopback and AllowPrivateNetwork allow sending requests to loopback addresses or private networks.
+	// They are evaluated per connection, which allows changing the restrictions at runtime.
+	// Nil functions deny the destinations.
+	AllowLoopback       func() bool
+	AllowPrivateNetwork func() bool
+
+	// AllowedNetworks contains networks (CIDR notation) or IPs that can always be targeted,
+	// even though they are loopback, link-local or private addresses.
+	AllowedNetworks []string
+
+	// LogRequests enables the logging of destination, status and duration of every request.
+	LogRequests bool
+}
+
+// Client is an http client for requests to destinations outside the application.
+// It guards against requests to internal destinations (SSRF), and limits the duration
+// and response size of the requests.
+type Client struct {
+	config          Config
+	allowedNetworks []*net.IPNet
+	// proxies contains the addresses of the configured proxies, which are exempt from the destination checks.
+	proxies map[string]struct{}
+
+	secure   *http.Client
+	insecure *http.Client
+}
+
+// New creates a new outbound http client.
+func New(config Config) (*Client, error) {
+	allowedNetworks, err := parseNetworks(config.AllowedNetworks)
+	if err != nil {
+		return nil, err
+	}
+
+	proxy, proxies, err := setupProxy(config.ProxyURL)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		config:          config,
+		allowedNetworks: allowedNetworks,
+		proxies:         proxies,
+	}
+
+	c.secure = c.newHTTPClient(proxy, false)
+	c.insecure = c.newHTTPClient(proxy, true)
+
+	return c, nil
+}
+
+// RequestOption configures a single request of the client.
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	skipTLSVerify bool
+}
+
+// SkipTLSVerify disables the verification of the TLS certificate of the destination.
+func SkipTLSVerify(skip bool) RequestOption {
+	return func(o *requestOptions) {
+		o.skipTLSVerify = skip
+	}
+}
+
+// Do sends the request. The body of the response has to be closed by the caller.
+// Reading more than the max response size from the body fails with ErrResponseTooLarge.
+func (c *Client) Do(req *http.Request, opts ...RequestOption) (*http.Response, error) {
+	options := requestOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	client := c.secure
+	if options.skipTLSVerify {
+		client = c.insecure
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	c.logRequest(req, resp, err, time.Since(start))
+	if err != nil {
+		return nil, err
+	}
+
+	if c.config.MaxResponseSize > 0 && resp.Body != nil {
+		resp.Body = &limitedBody{ReadCloser: resp.Body, remaining: c.config.MaxResponseSize}
+	}
+
+	return resp, nil
+}
+
+// logRequest logs the destination, status and duration of the request.
+// The path and query of the url aren't logged, as they might contain credentials.
+func (c *Client) logRequest(req *http.Request, resp *http.Response, err error, duration time.Duration) {
+	if !c.config.LogRequests {
+		return
+	}
+
+	ctx := req.Context()
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Str("outbound.client", c.config.Name).
+			Str("http.method", req.Method).
+			Str("http.host", req.URL.Host).
+			Dur("http.elapsed_ms", duration).
+			Msg("outbound http request failed")
+		return
+	}
+
+	log.Ctx(ctx).Info().
+		Str("outbound.client", c.config.Name).
+		Str("http.method", req.Method).
+		Str("http.host", req.URL.Host).
+		Int("http.status_code", resp.StatusCode).
+		Int64("http.response_size_bytes", resp.ContentLength).
+		Dur("http.elapsed_ms", duration).
+		Msg("outbound http request completed")
+}
+
+func (c *Client) newHTTPClient(proxy func(*http.Request) (*url.URL, error), skipTLSVerify bool) *http.Client {
+	// Clone http.DefaultTransport (used by http.DefaultClient)
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+
+	tr.Proxy = proxy
+	tr.TLSHandshakeTimeout = tlsHandshakeTimeout
+	if tr.TLSClientConfig == nil {
+		tr.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	tr.TLSClientConfig.InsecureSkipVerify = skipTLSVerify // #nosec G402 (insecure TLS configuration)
+
+	// destinations are checked before the connection is established (after name resolution),
+	// which prevents scanning of internal addresses and DNS rebinding.
+	guardedDialer := &net.Dialer{
+		Timeout:   dialTimeout,
+		KeepAlive: dialKeepAlive,
+		Control:   c.checkDestination,
+	}
+	proxyDialer := &net.Dialer{
+		Timeout:   dialTimeout,
+		KeepAlive: dialKeepAlive,
+	}
+
+	tr.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if _, ok := c.proxies[addr]; ok {
+			return proxyDialer.DialContext(ctx, network, addr)
+		}
+
+		return guardedDialer.DialContext(ctx, network, addr)
+	}
+
+	return &http.Client{
+		Transport: tr,
+		Timeout:   c.config.Timeout,
+	}
+}
+
+// checkDestination verifies that the resolved address the client is about to connect to is allowed.
+func (c *Client) checkDestination(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("invalid destination address %q: %w", address, err)
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("destination address %q isn't an IP", address)
+	}
+
+	for _, network := range c.allowedNetworks {
+		if network.Contains(ip) {
+			return nil
+		}
+	}
+
+	switch {
+	case ip.IsLoopback():
+		if !allowed(c.config.AllowLoopback) {
+			return ErrLoopbackNotAllowed
+		}
+	case ip.IsLinkLocalUnicast(), ip.IsLinkLocalMulticast(), ip.IsUnspecified():
+		return ErrLinkLocalNotAllowed
+	case ip.IsPrivate():
+		if !allowed(c.config.AllowPrivateNetwork) {
+			return ErrPrivateNetworkNotAllowed
+		}
+	}
+
+	return nil
+}
+
+func allowed(fn func() bool) bool {
+	return fn != nil && fn()
+}
+
+// parseNetworks parses networks in CIDR notation or single IPs.
+func parseNetworks(networks []string) ([]*net.IPNet, error) {
+	out := make([]*net.IPNet, 0, len(networks))
+	for _, network := range networks {
+		if ip := net.ParseIP(network); ip != nil {
+			bits := 8 * len(ip.To16())
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 8 * net.IPv4len
+			}
+			out = append(out, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, ipNet, err := net.ParseCIDR(network)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowed network %q: %w", network, err)
+		}
+		out = append(out, ipNet)
+	}
+
+	return out, nil
+}
+
+// setupProxy returns the proxy function of the client and the addresses of all proxies it might use.
+func setupProxy(proxyURL string) (func(*http.Request) (*url.URL, error), map[string]struct{}, error) {
+	proxies := map[string]struct{}{}
+
+	if proxyURL == "" {
+		env := httpproxy.FromEnvironment()
+		for _, raw := range []string{env.HTTPProxy, env.HTTPSProxy} {
+			if u, err := url.Parse(raw); err == nil && u.Host != "" {
+				proxies[hostPort(u)] = struct{}{}
+			}
+		}
+
+		return http.ProxyFromEnvironment, proxies, nil
+	}
+
+	u, err := url.Parse(proxyURL)
+	if err != nil || u.Host == "" {
+		return nil, nil, fmt.Errorf("invalid proxy url %q", proxyURL)
+	}
+	proxies[hostPort(u)] = struct{}{}
+
+	return http.ProxyURL(u), proxies, nil
+}
+
+// hostPort returns the address of the url including the default port of its scheme.
+func hostPort(u *url.URL) string {
+	if u.Port() != "" {
+		return u.Host
+	}
+
+	if u.Scheme == "https" {
+		return net.JoinHostPort(u.Hostname(), "443")
+	}
+
+	return net.JoinHostPort(u.Hostname(), "80")
+}
+
+// limitedBody fails reads once more than the remaining number of bytes are read.
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining <= 0 {
+		// check whether there's more data, reading exactly up to the limit is fine.
+		var probe [1]byte
+		n, err := b.ReadCloser.Read(probe[:])
+		if n > 0 {
+			return 0, ErrResponseTooLarge
+		}
+		return 0, err
+	}
+
+	if int64(len(p)) > b.remaining {
+		p = p[:b.remaining]
+	}
+
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+
+	return n, err
+}
@@ -0,0 +1,133 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package outbound
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClientDestinations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name   string
+		config Config
+		expErr error
+	}{
+		{
+			name:   "loopback-denied-by-default",
+			config: Config{},
+			expErr: ErrLoopbackNotAllowed,
+		},
+		{
+			name:   "loopback-allowed",
+			config: Config{AllowLoopback: AllowAlways},
+		},
+		{
+			name:   "loopback-allow-listed",
+			config: Config{AllowedNetworks: []string{"127.0.0.0/8"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client, err := New(test.config)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+
+			resp, err := client.Do(req)
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+
+			if !errors.Is(err, test.expErr) {
+				t.Errorf("expected error %v, got %v", test.expErr, err)
+			}
+		})
+	}
+}
+
+func TestCheckDestination(t *testing.T) {
+	client, err := New(Config{AllowedNetworks: []string{"10.1.2.3"}})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	tests := []struct {
+		address string
+		expErr  error
+	}{
+		{address: "93.184.216.34:443"},
+		{address: "10.1.2.3:443"},
+		{address: "10.1.2.4:443", expErr: ErrPrivateNetworkNotAllowed},
+		{address: "192.168.1.1:80", expErr: ErrPrivateNetworkNotAllowed},
+		{address: "169.254.169.254:80", expErr: ErrLinkLocalNotAllowed},
+		{address: "[::1]:80", expErr: ErrLoopbackNotAllowed},
+	}
+
+	for _, test := range tests {
+		if err := client.checkDestination("tcp", test.address, nil); !errors.Is(err, test.expErr) {
+			t.Errorf("%s: expected error %v, got %v", test.address, test.expErr, err)
+		}
+	}
+}
+
+func TestClientMaxResponseSize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(strings.Repeat("x", 100)))
+	}))
+	defer server.Close()
+
+	for _, limit := range []int64{100, 99} {
+		client, err := New(Config{AllowLoopback: AllowAlways, MaxResponseSize: limit})
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		if err != nil {
+			t.Fatalf("failed to create request: %v", err)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		_, err = io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+
+		if limit == 100 && err != nil {
+			t.Errorf("expected response of exactly the max size to be read, got %v", err)
+		}
+		if limit == 99 && !errors.Is(err, ErrResponseTooLarge) {
+			t.Errorf("expected error %v, got %v", ErrResponseTooLarge, err)
+		}
+	}
+}
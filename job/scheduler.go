@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"runtime/debug"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/harness/gitness/lock"
@@ -49,8 +50,13 @@ type Scheduler struct {
 	wgRunning    sync.WaitGroup
 	cancelJobMx  sync.Mutex
 	cancelJobMap map[string]context.CancelFunc
+	interrupted  atomic.Bool
 }
 
+// interruptGracePeriod is the time the running jobs get to return and store their state
+// after they got interrupted because they didn't finish before the shutdown deadline.
+const interruptGracePeriod = 5 * time.Second
+
 func NewScheduler(
 	store Store,
 	executor *Executor,
@@ -169,6 +175,8 @@ func (s *Scheduler) Run(ctx context.Context) error {
 
 // WaitJobsDone waits until execution of all jobs has finished.
 // It is intended to be used for graceful shutdown, after the Run method has finished.
+// Jobs still running once the context is done get canceled and are rescheduled without
+// counting as failed execution, keeping the progress they reported so far.
 func (s *Scheduler) WaitJobsDone(ctx context.Context) {
 	log.Ctx(ctx).Debug().Msg("job scheduler: stopping... waiting for the currently running jobs to finish")
 
@@ -179,10 +187,27 @@ func (s *Scheduler) WaitJobsDone(ctx context.Context) {
 	}()
 
 	select {
-	case <-ctx.Done():
-		log.Ctx(ctx).Warn().Msg("job scheduler: stop interrupted")
 	case <-ch:
 		log.Ctx(ctx).Info().Msg("job scheduler: gracefully stopped")
+		return
+	case <-ctx.Done():
+	}
+
+	log.Ctx(ctx).Warn().Msg("job scheduler: shutdown deadline exceeded... interrupting the currently running jobs")
+
+	s.interrupted.Store(true)
+
+	s.cancelJobMx.Lock()
+	for _, cancelFn := range s.cancelJobMap {
+		cancelFn()
+	}
+	s.cancelJobMx.Unlock()
+
+	select {
+	case <-ch:
+		log.Ctx(ctx).Info().Msg("job scheduler: stopped, interrupted jobs are rescheduled")
+	case <-time.After(interruptGracePeriod):
+		log.Ctx(ctx).Warn().Msg("job scheduler: stop interrupted")
 	}
 }
 
@@ -239,6 +264,26 @@ func (s *Scheduler) CancelJob(ctx context.Context, jobUID string) error {
 	return s.pubsubService.Publish(ctx, PubSubTopicCancelJob, []byte(jobUID))
 }
 
+// CancelJobsByGroupID cancels all scheduled or running jobs of a job group.
+func (s *Scheduler) CancelJobsByGroupID(ctx context.Context, jobGroupID string) error {
+	jobs, err := s.store.ListByGroupID(ctx, jobGroupID)
+	if err != nil {
+		return fmt.Errorf("failed to list jobs to cancel by group id=%s: %w", jobGroupID, err)
+	}
+
+	for _, job := range jobs {
+		if job.State.IsCompleted() {
+			continue
+		}
+
+		if err = s.CancelJob(ctx, job.UID); err != nil {
+			return fmt.Errorf("failed to cancel job with id=%s: %w", job.UID, err)
+		}
+	}
+
+	return nil
+}
+
 func (s *Scheduler) handleCancelJob(payload []byte) error {
 	jobUID := string(payload)
 	if jobUID == "" {
@@ -419,6 +464,9 @@ func (s *Scheduler) availableSlots(ctx context.Context) (int, error) {
 // The function will also log the execution.
 func (s *Scheduler) runJob(ctx context.Context, j *Job) {
 	s.wgRunning.Add(1)
+
+	// The job doesn't get canceled together with the scheduler,
+	// on shutdown it has time to finish until the WaitJobsDone deadline.
 	go func(ctx context.Context,
 		jobUID, jobType, jobData string,
 		jobRunDeadline int64,
@@ -431,6 +479,7 @@ func (s *Scheduler) runJob(ctx context.Context, j *Job) {
 
 		// Run the job
 		execResult, execFailure := s.doExec(ctx, jobUID, jobType, jobData, jobRunDeadline)
+		interrupted := execFailure != "" && s.interrupted.Load()
 
 		// Use the context.Background() because we want to update the job even if the job's context is done.
 		// The context can be done because the job exceeded its deadline or the server is shutting down.
@@ -454,7 +503,11 @@ func (s *Scheduler) runJob(ctx context.Context, j *Job) {
 		}
 
 		// Update the job fields, reschedule if necessary.
-		postExec(job, execResult, execFailure)
+		if interrupted {
+			rescheduleInterrupted(job)
+		} else {
+			postExec(job, execResult, execFailure)
+		}
 
 		err = s.store.UpdateExecution(backgroundCtx, job)
 		if err != nil {
@@ -488,6 +541,11 @@ func (s *Scheduler) runJob(ctx context.Context, j *Job) {
 			s.scheduleIfHaveMoreJobs()
 
 		case JobStateScheduled:
+			if interrupted {
+				logInfo.Msg("job interrupted by shutdown and rescheduled")
+				break
+			}
+
 			scheduledTime := time.UnixMilli(job.Scheduled)
 			logInfo.
 				Str("job.Scheduled", scheduledTime.Format(time.RFC3339Nano)).
@@ -503,7 +561,7 @@ func (s *Scheduler) runJob(ctx context.Context, j *Job) {
 		if err := publishStateChange(backgroundCtx, s.pubsubService, job); err != nil {
 			log.Ctx(ctx).Err(err).Msg("failed to publish job state change")
 		}
-	}(ctx, j.UID, j.Type, j.Data, j.RunDeadline)
+	}(context.WithoutCancel(ctx), j.UID, j.Type, j.Data, j.RunDeadline)
 }
 
 // preExec updates the provided Job before execution.
@@ -623,6 +681,21 @@ func postExec(job *Job, resultData, resultErr string) {
 	}
 }
 
+// rescheduleInterrupted updates the provided Job after its execution got interrupted by the shutdown.
+// The job is scheduled to run again right away, the interruption doesn't count as failure.
+func rescheduleInterrupted(job *Job) {
+	if job.State != JobStateRunning {
+		return
+	}
+
+	nowMilli := time.Now().UnixMilli()
+
+	job.Updated = nowMilli
+	job.State = JobStateScheduled
+	job.Scheduled = nowMilli
+	job.RunBy = ""
+}
+
 func (s *Scheduler) GetJobProgress(ctx context.Context, jobUID string) (Progress, error) {
 	job, err := s.store.Find(ctx, jobUID)
 	if err != nil {
@@ -0,0 +1,144 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/lock"
+	"github.com/harness/gitness/pubsub"
+)
+
+type memJobStore struct {
+	Store
+	mx   sync.Mutex
+	jobs map[string]Job
+}
+
+func (s *memJobStore) Find(_ context.Context, uid string) (*Job, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	job := s.jobs[uid]
+	return &job, nil
+}
+
+func (s *memJobStore) UpdateExecution(_ context.Context, job *Job) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.jobs[job.UID] = *job
+	return nil
+}
+
+func (s *memJobStore) UpdateProgress(_ context.Context, job *Job) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	stored := s.jobs[job.UID]
+	stored.RunProgress = job.RunProgress
+	stored.Result = job.Result
+	s.jobs[job.UID] = stored
+	return nil
+}
+
+// handlerFunc adapts a function to the job Handler interface.
+type handlerFunc func(ctx context.Context, input string, fn ProgressReporter) (string, error)
+
+func (f handlerFunc) Handle(ctx context.Context, input string, fn ProgressReporter) (string, error) {
+	return f(ctx, input, fn)
+}
+
+func TestScheduler_WaitJobsDone(t *testing.T) {
+	tests := []struct {
+		name     string
+		finishIn time.Duration
+		expState State
+	}{
+		{
+			name:     "finished before deadline",
+			finishIn: 10 * time.Millisecond,
+			expState: JobStateFinished,
+		},
+		{
+			name:     "interrupted at deadline",
+			finishIn: time.Hour,
+			expState: JobStateScheduled,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store := &memJobStore{jobs: map[string]Job{}}
+			publisher := pubsub.NewInMemory()
+			executor := NewExecutor(store, publisher)
+			err := executor.Register("test", handlerFunc(
+				func(ctx context.Context, _ string, fn ProgressReporter) (string, error) {
+					if err := fn(50, "half way"); err != nil {
+						return "", err
+					}
+
+					select {
+					case <-time.After(test.finishIn):
+						return "done", nil
+					case <-ctx.Done():
+						return "", ctx.Err()
+					}
+				}))
+			if err != nil {
+				t.Fatalf("failed to register handler: %v", err)
+			}
+
+			mxManager := lock.NewInMemory(lock.Config{
+				Expiry:     time.Minute,
+				Tries:      10,
+				RetryDelay: time.Millisecond,
+			})
+
+			scheduler, err := NewScheduler(store, executor, mxManager, publisher, "test", 1, time.Hour)
+			if err != nil {
+				t.Fatalf("failed to create scheduler: %v", err)
+			}
+
+			job := &Job{UID: "job", Type: "test", MaxDurationSeconds: 3600, MaxRetries: 0}
+			scheduler.preExec(job)
+			store.jobs[job.UID] = *job
+
+			// the scheduler context is canceled on shutdown, the job has to keep running regardless.
+			runCtx, runCancel := context.WithCancel(context.Background())
+			scheduler.runJob(runCtx, job)
+			runCancel()
+
+			waitCtx, waitCancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer waitCancel()
+
+			scheduler.WaitJobsDone(waitCtx)
+
+			stored, _ := store.Find(context.Background(), job.UID)
+			if stored.State != test.expState {
+				t.Fatalf("expected job state %q, got %q (failure: %q)", test.expState, stored.State, stored.LastFailureError)
+			}
+			if stored.ConsecutiveFailures != 0 {
+				t.Errorf("expected no failures, got %d", stored.ConsecutiveFailures)
+			}
+			if stored.State == JobStateScheduled && stored.RunProgress != 50 {
+				t.Errorf("expected interrupted job to keep its progress, got %d", stored.RunProgress)
+			}
+		})
+	}
+}
@@ -59,3 +59,60 @@ func WithRequestID(reqID string) Option {
 		return c.Str("request_id", reqID)
 	}
 }
+
+// WithPrincipalID can be used to annotate logs with the id of the principal executing the request.
+func WithPrincipalID(principalID int64) Option {
+	return func(c zerolog.Context) zerolog.Context {
+		return c.Int64("principal_id", principalID)
+	}
+}
+
+// WithPrincipalUID can be used to annotate logs with the uid of the principal executing the request.
+func WithPrincipalUID(principalUID string) Option {
+	return func(c zerolog.Context) zerolog.Context {
+		return c.Str("principal_uid", principalUID)
+	}
+}
+
+// WithRepoRef can be used to annotate logs with the (not yet resolved) repo reference of the request.
+func WithRepoRef(repoRef string) Option {
+	return func(c zerolog.Context) zerolog.Context {
+		return c.Str("repo_ref", repoRef)
+	}
+}
+
+// WithRepoID can be used to annotate logs with the id of a repository.
+func WithRepoID(repoID int64) Option {
+	return func(c zerolog.Context) zerolog.Context {
+		return c.Int64("repo_id", repoID)
+	}
+}
+
+// WithRepoPath can be used to annotate logs with the path of a repository.
+func WithRepoPath(repoPath string) Option {
+	return func(c zerolog.Context) zerolog.Context {
+		return c.Str("repo_path", repoPath)
+	}
+}
+
+// WithRepoUID can be used to annotate logs with the git uid of a repository.
+// It's the field shared by the logs of the API and the git layer.
+func WithRepoUID(repoUID string) Option {
+	return func(c zerolog.Context) zerolog.Context {
+		return c.Str("repo_uid", repoUID)
+	}
+}
+
+// WithSpaceRef can be used to annotate logs with the (not yet resolved) space reference of the request.
+func WithSpaceRef(spaceRef string) Option {
+	return func(c zerolog.Context) zerolog.Context {
+		return c.Str("space_ref", spaceRef)
+	}
+}
+
+// WithGitRPC can be used to annotate logs with the name of the git rpc (e.g. git-receive-pack).
+func WithGitRPC(rpc string) Option {
+	return func(c zerolog.Context) zerolog.Context {
+		return c.Str("git_rpc", rpc)
+	}
+}
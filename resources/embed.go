@@ -17,6 +17,7 @@ package resources
 import (
 	"embed"
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -42,6 +43,41 @@ func ReadLicense(name string) ([]byte, error) {
 	return content, err
 }
 
+// LicenseExists returns true iff a license with the provided name exists in license folder.
+func LicenseExists(name string) bool {
+	_, err := licence.Open(fmt.Sprintf("license/%s.txt", name))
+	return err == nil
+}
+
+// LicenseData contains the values of the placeholders in a license.
+type LicenseData struct {
+	Year    int
+	Owner   string
+	Program string
+}
+
+// RenderLicense reads licence from license folder and replaces its placeholders with the provided data.
+func RenderLicense(name string, data LicenseData) ([]byte, error) {
+	content, err := ReadLicense(name)
+	if err != nil {
+		return nil, err
+	}
+
+	year := strconv.Itoa(data.Year)
+	replacer := strings.NewReplacer(
+		"[year]", year,
+		"[yyyy]", year,
+		"<year>", year,
+		"[fullname]", data.Owner,
+		"[name of copyright owner]", data.Owner,
+		"<owner>", data.Owner,
+		"<name of author>", data.Owner,
+		"<program>", data.Program,
+	)
+
+	return []byte(replacer.Replace(string(content))), nil
+}
+
 // GitIgnores lists all files in gitignore folder and return file names.
 func GitIgnores() ([]string, error) {
 	entries, err := gitignore.ReadDir("gitignore")
@@ -55,6 +91,23 @@ func GitIgnores() ([]string, error) {
 	return files, nil
 }
 
+// FindGitIgnore returns the name of the gitignore file in gitignore folder
+// that matches the provided name case-insensitively.
+func FindGitIgnore(name string) (string, bool) {
+	files, err := GitIgnores()
+	if err != nil {
+		return "", false
+	}
+
+	for _, file := range files {
+		if strings.EqualFold(file, name) {
+			return file, true
+		}
+	}
+
+	return "", false
+}
+
 // ReadGitIgnore reads gitignore file from license folder.
 func ReadGitIgnore(name string) ([]byte, error) {
 	return gitignore.ReadFile(fmt.Sprintf("gitignore/%s.gitignore", name))
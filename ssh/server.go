@@ -31,7 +31,11 @@ import (
 
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/featureflag"
+	"github.com/harness/gitness/app/services/gitlimit"
 	"github.com/harness/gitness/app/services/publickey"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git/api"
 	"github.com/harness/gitness/types"
@@ -45,7 +49,10 @@ import (
 
 type contextKey string
 
-const principalKey = contextKey("principalKey")
+const (
+	principalKey = contextKey("principalKey")
+	metadataKey  = contextKey("metadataKey")
+)
 
 var (
 	allowedCommands = []string{
@@ -94,8 +101,9 @@ type Server struct {
 	HostKeys                []string
 	KeepAliveInterval       time.Duration
 
-	Verifier publickey.Service
-	RepoCtrl *repo.Controller
+	Verifier     publickey.Service
+	RepoCtrl     *repo.Controller
+	FeatureFlags *featureflag.Service
 }
 
 func (s *Server) sanitize() error {
@@ -122,6 +130,10 @@ func (s *Server) sanitize() error {
 	if s.RepoCtrl == nil {
 		return errors.InvalidArgument("repository controller is needed to run git service pack commands")
 	}
+
+	if s.FeatureFlags == nil {
+		return errors.InvalidArgument("feature flag service is needed to check whether ssh access is enabled")
+	}
 	return nil
 }
 
@@ -208,6 +220,11 @@ func (s *Server) sessionHandler(session ssh.Session) {
 		return
 	}
 
+	var metadata auth.Metadata
+	if deployKeyMetadata, ok := session.Context().Value(metadataKey).(*auth.DeployKeyMetadata); ok {
+		metadata = deployKeyMetadata
+	}
+
 	parts := strings.Fields(command)
 	if len(parts) < 2 {
 		_, _ = fmt.Fprintf(session.Stderr(), "command %q must have an argument\n", command)
@@ -237,6 +254,11 @@ func (s *Server) sessionHandler(session ssh.Session) {
 	// remove .git suffix
 	repoRef = strings.TrimSuffix(repoRef, ".git")
 
+	if !s.FeatureFlags.IsEnabledForRepoPath(featureflag.FlagSSH, repoRef) {
+		_, _ = fmt.Fprintf(session.Stderr(), "git over ssh is disabled for repository %q\n", repoRef)
+		return
+	}
+
 	gitProtocol := ""
 	for _, key := range session.Environ() {
 		if strings.HasPrefix(key, "GIT_PROTOCOL=") {
@@ -252,6 +274,10 @@ func (s *Server) sessionHandler(session ssh.Session) {
 		go sendKeepAliveMsg(ctx, session, s.KeepAliveInterval)
 	}
 
+	if host, _, errSplit := net.SplitHostPort(session.RemoteAddr().String()); errSplit == nil {
+		ctx = audit.WithRealIP(ctx, host)
+	}
+
 	err = s.RepoCtrl.GitServicePack(
 		ctx,
 		&auth.Session{
@@ -264,6 +290,7 @@ func (s *Server) sessionHandler(session ssh.Session) {
 				Created:     principal.Created,
 				Updated:     principal.Updated,
 			},
+			Metadata: metadata,
 		},
 		repoRef,
 		api.ServicePackOptions{
@@ -274,6 +301,10 @@ func (s *Server) sessionHandler(session ssh.Session) {
 			Protocol: gitProtocol,
 		},
 	)
+	if errors.Is(err, gitlimit.ErrTooManyOperations) {
+		// the rejection was already reported to the client via git's error channel.
+		return
+	}
 	if err != nil {
 		log.Error().Err(err).Msg("git service pack failed")
 		_, err = io.Copy(session.Stderr(), strings.NewReader(err.Error()))
@@ -317,8 +348,7 @@ func (s *Server) publicKeyHandler(ctx ssh.Context, key ssh.PublicKey) bool {
 
 	principal, err := s.Verifier.ValidateKey(ctx, key, enum.PublicKeyUsageAuth)
 	if errors.IsNotFound(err) {
-		log.Debug().Err(err).Msg("public key is unknown")
-		return false
+		return s.deployKeyHandler(ctx, key)
 	}
 	if err != nil {
 		log.Warn().Err(err).Msg("failed to validate public key")
@@ -349,6 +379,32 @@ func (s *Server) publicKeyHandler(ctx ssh.Context, key ssh.PublicKey) bool {
 	return true
 }
 
+// deployKeyHandler authenticates the session using one of the repository deploy keys.
+// The session is restricted to git operations on the repository the deploy key belongs to.
+func (s *Server) deployKeyHandler(ctx ssh.Context, key ssh.PublicKey) bool {
+	if _, ok := key.(*gossh.Certificate); ok {
+		log.Debug().Msg("certificates aren't supported for deploy keys")
+		return false
+	}
+
+	deployKey, err := s.Verifier.ValidateDeployKey(ctx, key)
+	if errors.IsNotFound(err) {
+		log.Debug().Err(err).Msg("public key is unknown")
+		return false
+	}
+	if err != nil {
+		log.Warn().Err(err).Msg("failed to validate deploy key")
+		return false
+	}
+
+	// the session acts as the deploy key service, never as the creator of the key.
+	session := bootstrap.NewDeployKeyServiceSession(deployKey)
+
+	ctx.SetValue(principalKey, session.Principal.ToPrincipalInfo())
+	ctx.SetValue(metadataKey, session.Metadata)
+	return true
+}
+
 func sshConnectionFailed(conn net.Conn, err error) {
 	log.Err(err).Msgf("failed connection from %s with error: %v", conn.RemoteAddr(), err)
 }
@@ -18,4 +18,6 @@ package database
 type Config struct {
 	Driver     string
 	Datasource string
+	// ReplicaDatasource is the optional datasource of a read replica used for read-only operations.
+	ReplicaDatasource string
 }
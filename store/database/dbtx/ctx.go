@@ -23,13 +23,51 @@ import (
 // ctxKeyTx is context key for storing and retrieving TransactionAccessor to and from a context.
 type ctxKeyTx struct{}
 
+// ctxKeyPrimary is context key for forcing read-only operations to the primary database.
+type ctxKeyPrimary struct{}
+
 // GetAccessor returns Accessor interface from the context if it exists or creates a new one from the provided *sql.DB.
 // It is intended to be used in data layer functions that might or might not be running inside a transaction.
+// Queries exceeding the slow query threshold (see SetSlowQueryThreshold) are logged.
 func GetAccessor(ctx context.Context, db *sqlx.DB) Accessor {
 	if a, ok := ctx.Value(ctxKeyTx{}).(Accessor); ok {
-		return a
+		return withSlowQueryLog(a)
+	}
+	return withSlowQueryLog(New(db))
+}
+
+// GetReadAccessor returns Accessor interface for read-only operations (list/count/find).
+// Inside a transaction, the transaction is returned. Otherwise, the queries are executed on the read replica
+// registered for the provided *sql.DB, falling back to the primary if the replica is unreachable.
+// Operations that need to read their own writes can be forced to the primary with WithPrimary.
+func GetReadAccessor(ctx context.Context, db *sqlx.DB) Accessor {
+	if a, ok := ctx.Value(ctxKeyTx{}).(Accessor); ok {
+		return withSlowQueryLog(a)
+	}
+
+	v, ok := replicas.Load(db)
+	if !ok {
+		return withSlowQueryLog(New(db))
 	}
-	return New(db)
+
+	a := readAccessor{primary: New(db)}
+	if r, isReplica := v.(*replica); isReplica && r.available() && !IsPrimary(ctx) {
+		a.replica = r
+	}
+
+	return withSlowQueryLog(a)
+}
+
+// WithPrimary returns a context that forces all read-only operations to the primary database.
+// It is intended for requests that read data they have written themselves.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxKeyPrimary{}, true)
+}
+
+// IsPrimary returns true if read-only operations are forced to the primary database.
+func IsPrimary(ctx context.Context) bool {
+	primary, _ := ctx.Value(ctxKeyPrimary{}).(bool)
+	return primary
 }
 
 // GetTransaction returns Transaction interface from the context if it exists or return nil.
@@ -0,0 +1,200 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtx
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	targetPrimary = "primary"
+	targetReplica = "replica"
+
+	// replicaRetryInterval is the time a replica isn't used after it was found unreachable.
+	replicaRetryInterval = 30 * time.Second
+)
+
+var (
+	readQueries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gitness",
+		Subsystem: "database",
+		Name:      "read_queries_total",
+		Help:      "Number of read-only store queries by the database they were executed on.",
+	}, []string{"target"})
+
+	replicaFallbacks = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "gitness",
+		Subsystem: "database",
+		Name:      "replica_fallbacks_total",
+		Help:      "Number of read-only store queries that fell back to the primary because the replica was unreachable.",
+	})
+)
+
+// replicas contains the registered read replicas keyed by their primary database.
+var replicas sync.Map
+
+type replica struct {
+	accessor Accessor
+	// downUntil is the time (unix nano) until which the replica isn't used.
+	downUntil atomic.Int64
+}
+
+// RegisterReplica registers a read replica of the primary database.
+// Read-only operations of the stores (see GetReadAccessor) are executed on the replica from then on.
+func RegisterReplica(primary *sqlx.DB, replicaDB *sqlx.DB) {
+	replicas.Store(primary, &replica{accessor: New(replicaDB)})
+}
+
+func (r *replica) available() bool {
+	return time.Now().UnixNano() >= r.downUntil.Load()
+}
+
+func (r *replica) markDown(ctx context.Context, err error) {
+	r.downUntil.Store(time.Now().Add(replicaRetryInterval).UnixNano())
+	log.Ctx(ctx).Warn().Err(err).
+		Dur("retry_interval", replicaRetryInterval).
+		Msg("database replica is unreachable, falling back to primary")
+}
+
+// isConnectionError returns true if the error indicates that the database couldn't be reached.
+func isConnectionError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var netErr net.Error
+	return errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.As(err, &netErr)
+}
+
+// readAccessor executes read-only queries on the replica, if there is one available,
+// and falls back to the primary if the replica is unreachable.
+// Statements that might modify data are always executed on the primary.
+type readAccessor struct {
+	primary Accessor
+	replica *replica
+}
+
+var _ Accessor = readAccessor{}
+
+func (a readAccessor) read(ctx context.Context, fn func(Accessor) error) error {
+	if a.replica != nil {
+		err := fn(a.replica.accessor)
+		if !isConnectionError(err) {
+			readQueries.WithLabelValues(targetReplica).Inc()
+			return err
+		}
+
+		a.replica.markDown(ctx, err)
+		replicaFallbacks.Inc()
+	}
+
+	readQueries.WithLabelValues(targetPrimary).Inc()
+
+	return fn(a.primary)
+}
+
+// rowTarget returns the accessor for calls that can't fall back because their error is only known after Scan.
+func (a readAccessor) rowTarget() Accessor {
+	if a.replica != nil {
+		readQueries.WithLabelValues(targetReplica).Inc()
+		return a.replica.accessor
+	}
+
+	readQueries.WithLabelValues(targetPrimary).Inc()
+
+	return a.primary
+}
+
+func (a readAccessor) DriverName() string {
+	return a.primary.DriverName()
+}
+
+func (a readAccessor) Rebind(query string) string {
+	return a.primary.Rebind(query)
+}
+
+func (a readAccessor) BindNamed(query string, arg interface{}) (string, []interface{}, error) {
+	return a.primary.BindNamed(query, arg)
+}
+
+func (a readAccessor) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	var rows *sql.Rows
+	err := a.read(ctx, func(acc Accessor) (err error) {
+		rows, err = acc.QueryContext(ctx, query, args...)
+		return err
+	})
+	return rows, err
+}
+
+func (a readAccessor) QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error) {
+	var rows *sqlx.Rows
+	err := a.read(ctx, func(acc Accessor) (err error) {
+		rows, err = acc.QueryxContext(ctx, query, args...)
+		return err
+	})
+	return rows, err
+}
+
+func (a readAccessor) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
+	return a.rowTarget().QueryRowxContext(ctx, query, args...)
+}
+
+func (a readAccessor) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return a.rowTarget().QueryRowContext(ctx, query, args...)
+}
+
+func (a readAccessor) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return a.read(ctx, func(acc Accessor) error {
+		return acc.GetContext(ctx, dest, query, args...)
+	})
+}
+
+func (a readAccessor) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return a.read(ctx, func(acc Accessor) error {
+		return acc.SelectContext(ctx, dest, query, args...)
+	})
+}
+
+func (a readAccessor) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return a.primary.ExecContext(ctx, query, args...)
+}
+
+func (a readAccessor) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return a.primary.PrepareContext(ctx, query)
+}
+
+func (a readAccessor) PreparexContext(ctx context.Context, query string) (*sqlx.Stmt, error) {
+	return a.primary.PreparexContext(ctx, query)
+}
+
+func (a readAccessor) PrepareNamedContext(ctx context.Context, query string) (*sqlx.NamedStmt, error) {
+	return a.primary.PrepareNamedContext(ctx, query)
+}
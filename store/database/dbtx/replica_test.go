@@ -0,0 +1,106 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtx
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadAccessor(t *testing.T) {
+	errTest := errors.New("dummy error")
+
+	tests := []struct {
+		name          string
+		errReplica    error
+		expectErr     error
+		expectPrimary int
+		expectDown    bool
+	}{
+		{
+			name: "replica",
+		},
+		{
+			name:       "replica-query-error",
+			errReplica: errTest,
+			expectErr:  errTest,
+		},
+		{
+			name:          "replica-unreachable",
+			errReplica:    driver.ErrBadConn,
+			expectPrimary: 1,
+			expectDown:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			primary := &accessorMock{}
+			r := &replica{accessor: &accessorMock{err: test.errReplica}}
+
+			a := readAccessor{primary: primary, replica: r}
+
+			err := a.SelectContext(context.Background(), nil, "")
+			if want, got := test.expectErr, err; !errors.Is(got, want) {
+				t.Errorf("expected error %v, but got %v", want, got)
+			}
+
+			assert.Equal(t, test.expectPrimary, primary.calls)
+			assert.Equal(t, test.expectDown, !r.available())
+		})
+	}
+}
+
+func TestGetReadAccessor(t *testing.T) {
+	primaryDB := &sqlx.DB{}
+	r := &replica{accessor: &accessorMock{}}
+	replicas.Store(primaryDB, r)
+	defer replicas.Delete(primaryDB)
+
+	ctx := context.Background()
+
+	a, ok := GetReadAccessor(ctx, primaryDB).(readAccessor)
+	require.True(t, ok)
+	assert.Equal(t, r, a.replica)
+
+	a, ok = GetReadAccessor(WithPrimary(ctx), primaryDB).(readAccessor)
+	require.True(t, ok)
+	assert.Nil(t, a.replica)
+
+	r.markDown(ctx, driver.ErrBadConn)
+	a, ok = GetReadAccessor(ctx, primaryDB).(readAccessor)
+	require.True(t, ok)
+	assert.Nil(t, a.replica)
+
+	_, ok = GetReadAccessor(ctx, &sqlx.DB{}).(readAccessor)
+	assert.False(t, ok)
+}
+
+type accessorMock struct {
+	*sqlx.DB // only to fulfill the Accessor interface, will be nil
+	err      error
+	calls    int
+}
+
+func (a *accessorMock) SelectContext(context.Context, interface{}, string, ...interface{}) error {
+	a.calls++
+	return a.err
+}
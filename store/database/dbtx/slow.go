@@ -0,0 +1,145 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtx
+
+import (
+	"context"
+	"database/sql"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// slowQueryThreshold is the duration (in nanoseconds) after which a store query is considered slow.
+	slowQueryThreshold atomic.Int64
+
+	slowQueries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gitness",
+		Subsystem: "database",
+		Name:      "slow_queries_total",
+		Help:      "Number of store queries that exceeded the slow query threshold by the store method executing them.",
+	}, []string{"query"})
+)
+
+// SetSlowQueryThreshold sets the duration after which store queries are logged as slow.
+// A zero duration disables the slow query log.
+func SetSlowQueryThreshold(threshold time.Duration) {
+	slowQueryThreshold.Store(int64(threshold))
+}
+
+// withSlowQueryLog wraps the accessor to log and count the queries exceeding the slow query threshold.
+func withSlowQueryLog(a Accessor) Accessor {
+	threshold := time.Duration(slowQueryThreshold.Load())
+	if threshold <= 0 {
+		return a
+	}
+
+	return slowQueryAccessor{Accessor: a, threshold: threshold}
+}
+
+// slowQueryAccessor logs the queries exceeding the threshold. Queries are identified by the store method
+// executing them, the raw SQL isn't logged as the arguments might contain sensitive data.
+type slowQueryAccessor struct {
+	Accessor
+	threshold time.Duration
+}
+
+// observe has to be called directly by the accessor methods, as the query name is taken from the call stack.
+func (a slowQueryAccessor) observe(ctx context.Context, start time.Time) {
+	duration := time.Since(start)
+	if duration < a.threshold {
+		return
+	}
+
+	// skip observe and the accessor method to get to the store method executing the query.
+	name := queryName(2)
+
+	slowQueries.WithLabelValues(name).Inc()
+
+	log.Ctx(ctx).Warn().
+		Str("db.query", name).
+		Dur("db.duration", duration).
+		Dur("db.threshold", a.threshold).
+		Msg("slow store query")
+}
+
+// queryName returns the name of the function the given number of frames above the caller, e.g. "RepoStore.Find".
+func queryName(skip int) string {
+	pc, _, _, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return "unknown"
+	}
+
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return "unknown"
+	}
+
+	// github.com/harness/gitness/app/store/database.(*RepoStore).Find -> RepoStore.Find
+	name := fn.Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	if i := strings.Index(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+
+	return strings.NewReplacer("(*", "", "(", "", ")", "").Replace(name)
+}
+
+func (a slowQueryAccessor) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	defer a.observe(ctx, time.Now())
+	return a.Accessor.QueryContext(ctx, query, args...)
+}
+
+func (a slowQueryAccessor) QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error) {
+	defer a.observe(ctx, time.Now())
+	return a.Accessor.QueryxContext(ctx, query, args...)
+}
+
+func (a slowQueryAccessor) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
+	defer a.observe(ctx, time.Now())
+	return a.Accessor.QueryRowxContext(ctx, query, args...)
+}
+
+func (a slowQueryAccessor) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	defer a.observe(ctx, time.Now())
+	return a.Accessor.QueryRowContext(ctx, query, args...)
+}
+
+func (a slowQueryAccessor) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	defer a.observe(ctx, time.Now())
+	return a.Accessor.ExecContext(ctx, query, args...)
+}
+
+func (a slowQueryAccessor) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	defer a.observe(ctx, time.Now())
+	return a.Accessor.GetContext(ctx, dest, query, args...)
+}
+
+func (a slowQueryAccessor) SelectContext(
+	ctx context.Context,
+	dest interface{},
+	query string,
+	args ...interface{},
+) error {
+	defer a.observe(ctx, time.Now())
+	return a.Accessor.SelectContext(ctx, dest, query, args...)
+}
@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtx
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rs/zerolog"
+)
+
+type slowQueryTestStore struct {
+	db Accessor
+}
+
+func (s *slowQueryTestStore) List(ctx context.Context) error {
+	return s.db.SelectContext(ctx, nil, "SELECT secret FROM table WHERE value = 'sensitive'")
+}
+
+func TestSlowQueryLog(t *testing.T) {
+	mock := &accessorMock{}
+
+	if _, ok := withSlowQueryLog(mock).(slowQueryAccessor); ok {
+		t.Fatal("expected the accessor not to be wrapped without slow query threshold")
+	}
+
+	SetSlowQueryThreshold(time.Nanosecond)
+	defer SetSlowQueryThreshold(0)
+
+	buf := &bytes.Buffer{}
+	ctx := zerolog.New(buf).WithContext(context.Background())
+
+	countBefore := testutil.ToFloat64(slowQueries.WithLabelValues("slowQueryTestStore.List"))
+
+	store := &slowQueryTestStore{db: withSlowQueryLog(mock)}
+	if err := store.List(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if mock.calls != 1 {
+		t.Errorf("expected the query to be executed once, but it was executed %d times", mock.calls)
+	}
+
+	logs := buf.String()
+	if !strings.Contains(logs, `"db.query":"slowQueryTestStore.List"`) {
+		t.Errorf("expected the slow query to be logged with the store method, got: %s", logs)
+	}
+	if strings.Contains(logs, "sensitive") {
+		t.Errorf("expected the raw query not to be logged, got: %s", logs)
+	}
+
+	countAfter := testutil.ToFloat64(slowQueries.WithLabelValues("slowQueryTestStore.List"))
+	if countAfter != countBefore+1 {
+		t.Errorf("expected slow query counter to be incremented, got %v -> %v", countBefore, countAfter)
+	}
+}
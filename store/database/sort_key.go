@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// SortKey returns the normalized form of a name that's stored next to it and used for ordering.
+// Ordering by the sort key (and the ID as tiebreaker) results in the same order on all databases,
+// as it doesn't rely on the case sensitivity and unicode handling of the database collation.
+//
+// The sort key is case folded and stripped of diacritics, e.g. "Äpfel" and "apfel" share a sort key.
+func SortKey(s string) string {
+	t := transform.Chain(norm.NFKD, runes.Remove(runes.In(unicode.Mn)), cases.Fold(), norm.NFC)
+
+	key, _, err := transform.String(t, s)
+	if err != nil {
+		// transformation only fails on invalid input - fall back to simple lower casing.
+		return strings.ToLower(s)
+	}
+
+	return key
+}
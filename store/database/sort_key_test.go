@@ -0,0 +1,39 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import "testing"
+
+func TestSortKey(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "", want: ""},
+		{in: "repo", want: "repo"},
+		{in: "Repo_1", want: "repo_1"},
+		{in: "Äpfel", want: "apfel"},
+		{in: "ÖKONOMIE", want: "okonomie"},
+		{in: "Straße", want: "strasse"},
+		{in: "éclair", want: "eclair"},
+		{in: "ﬁle", want: "file"},
+	}
+
+	for _, test := range tests {
+		if got := SortKey(test.in); got != test.want {
+			t.Errorf("SortKey(%q) = %q, want %q", test.in, got, test.want)
+		}
+	}
+}
@@ -42,6 +42,20 @@ var Builder = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
 
 // Connect to a database and verify with a ping.
 func Connect(ctx context.Context, driver string, datasource string) (*sqlx.DB, error) {
+	dbx, err := Open(driver, datasource)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = pingDatabase(ctx, dbx); err != nil {
+		return nil, fmt.Errorf("failed to ping the db: %w", err)
+	}
+
+	return dbx, nil
+}
+
+// Open creates the database handle without verifying that the database is reachable.
+func Open(driver string, datasource string) (*sqlx.DB, error) {
 	datasource, err := prepareDatasourceForDriver(driver, datasource)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare datasource: %w", err)
@@ -52,12 +66,7 @@ func Connect(ctx context.Context, driver string, datasource string) (*sqlx.DB, e
 		return nil, fmt.Errorf("failed to open the db: %w", err)
 	}
 
-	dbx := sqlx.NewDb(db, driver)
-	if err = pingDatabase(ctx, dbx); err != nil {
-		return nil, fmt.Errorf("failed to ping the db: %w", err)
-	}
-
-	return dbx, nil
+	return sqlx.NewDb(db, driver), nil
 }
 
 // ConnectAndMigrate creates the database handle and migrates the database.
@@ -0,0 +1,336 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	databaseConsumerBatchSize     = 100
+	databaseConsumerPollInterval  = time.Second
+	databaseConsumerLeaseDuration = 30 * time.Second
+)
+
+// DatabaseConsumer consumes streams from the durable stream log.
+// Every consumer group tracks its own offset per stream and processes messages at-least-once:
+// the offset is only committed after all messages of a batch have been handled.
+// Message IDs are stable, so handlers can use them as idempotency keys when messages are replayed.
+// Only one consumer of a group processes a stream at a time, which is ensured using a lease.
+type DatabaseConsumer struct {
+	store LogStore
+	// namespace specifies the namespace of the keys - any stream key will be prefixed with it
+	namespace string
+	// groupName specifies the name of the consumer group.
+	groupName string
+	// consumerName specifies the name of the consumer, used as owner of the leases.
+	consumerName string
+
+	// Config is the generic consumer configuration.
+	Config ConsumerConfig
+
+	// streams is a map of all registered streams and their handlers.
+	streams map[string]handler
+
+	isStarted bool
+	// workers limits the number of messages handled concurrently across all streams.
+	workers chan struct{}
+	errorCh chan error
+	infoCh  chan string
+}
+
+func NewDatabaseConsumer(
+	store LogStore,
+	namespace string,
+	groupName string,
+	consumerName string,
+) (*DatabaseConsumer, error) {
+	if groupName == "" {
+		return nil, errors.New("groupName can't be empty")
+	}
+	if consumerName == "" {
+		return nil, errors.New("consumerName can't be empty")
+	}
+
+	const errorChCapacity = 64
+	const infoChCapacity = 64
+
+	return &DatabaseConsumer{
+		store:        store,
+		namespace:    namespace,
+		groupName:    groupName,
+		consumerName: consumerName,
+		streams:      map[string]handler{},
+		Config:       defaultConfig,
+		isStarted:    false,
+		errorCh:      make(chan error, errorChCapacity),
+		infoCh:       make(chan string, infoChCapacity),
+	}, nil
+}
+
+func (c *DatabaseConsumer) Configure(opts ...ConsumerOption) {
+	if c.isStarted {
+		return
+	}
+
+	for _, opt := range opts {
+		opt.apply(&c.Config)
+	}
+}
+
+func (c *DatabaseConsumer) Register(streamID string, fn HandlerFunc, opts ...HandlerOption) error {
+	if c.isStarted {
+		return ErrAlreadyStarted
+	}
+	if streamID == "" {
+		return errors.New("streamID can't be empty")
+	}
+	if fn == nil {
+		return errors.New("fn can't be empty")
+	}
+
+	// transpose streamID to key namespace - no need to keep inner streamID
+	transposedStreamID := transposeStreamID(c.namespace, streamID)
+	if _, ok := c.streams[transposedStreamID]; ok {
+		return fmt.Errorf("consumer is already registered for '%s' (full stream '%s')", streamID, transposedStreamID)
+	}
+
+	config := c.Config.DefaultHandlerConfig
+	for _, opt := range opts {
+		opt.apply(&config)
+	}
+
+	c.streams[transposedStreamID] = handler{
+		handle: fn,
+		config: config,
+	}
+	return nil
+}
+
+func (c *DatabaseConsumer) Start(ctx context.Context) error {
+	if c.isStarted {
+		return ErrAlreadyStarted
+	}
+
+	if len(c.streams) == 0 {
+		return errors.New("no streams registered")
+	}
+
+	// mark as started before starting go routines (can't error out from here)
+	c.isStarted = true
+	c.workers = make(chan struct{}, c.Config.Concurrency)
+
+	wg := &sync.WaitGroup{}
+
+	// every stream is read independently, so a stuck stream doesn't block the others.
+	for streamID := range c.streams {
+		wg.Add(1)
+		go func(stream string) {
+			defer wg.Done()
+			c.reader(ctx, stream)
+		}(streamID)
+	}
+
+	// start cleanup routing
+	go func() {
+		// wait for all go routines to complete
+		wg.Wait()
+
+		close(c.infoCh)
+		close(c.errorCh)
+	}()
+
+	return nil
+}
+
+// reader processes the messages of a single stream in batches while holding the lease of the stream.
+func (c *DatabaseConsumer) reader(ctx context.Context, streamID string) {
+	handler := c.streams[streamID]
+
+	for {
+		processed, err := c.processBatch(ctx, streamID, handler)
+		if err != nil {
+			c.pushError(fmt.Errorf("failed to process batch of stream '%s': %w", streamID, err))
+		}
+
+		// only wait if there's nothing left to process.
+		if processed > 0 && err == nil {
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(databaseConsumerPollInterval):
+		}
+	}
+}
+
+// processBatch handles the next batch of messages of the stream and commits the offset of the group.
+// It returns the number of processed messages.
+func (c *DatabaseConsumer) processBatch(ctx context.Context, streamID string, handler handler) (int, error) {
+	offset, acquired, err := c.store.Lease(ctx, c.groupName, streamID, c.consumerName, c.leaseExpiry())
+	if err != nil {
+		return 0, fmt.Errorf("failed to acquire lease: %w", err)
+	}
+	if !acquired {
+		// another consumer of the group is processing the stream.
+		return 0, nil
+	}
+
+	msgs, err := c.store.ListAfter(ctx, streamID, offset, databaseConsumerBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list messages after offset %d: %w", offset, err)
+	}
+	if len(msgs) == 0 {
+		return 0, nil
+	}
+
+	// keep renewing the lease while the batch is processed, retries can take a while.
+	leaseCtx, cancelLease := context.WithCancel(ctx)
+	defer cancelLease()
+	go c.renewLease(leaseCtx, streamID)
+
+	wg := &sync.WaitGroup{}
+	for _, msg := range msgs {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return 0, ctx.Err()
+		case c.workers <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(msg LogMessage) {
+			defer func() {
+				<-c.workers
+				wg.Done()
+			}()
+			c.handle(ctx, streamID, handler, msg)
+		}(msg)
+	}
+	wg.Wait()
+
+	cancelLease()
+
+	if ctx.Err() != nil {
+		// the batch might not have been completely processed, it's picked up again after restart.
+		return 0, ctx.Err()
+	}
+
+	lastID := msgs[len(msgs)-1].ID
+	err = c.store.Commit(ctx, c.groupName, streamID, c.consumerName, lastID, c.leaseExpiry())
+	if errors.Is(err, ErrLeaseLost) {
+		c.pushInfo(fmt.Sprintf("lease of stream '%s' was lost, batch up to '%d' will be processed again",
+			streamID, lastID))
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to commit offset %d: %w", lastID, err)
+	}
+
+	return len(msgs), nil
+}
+
+// handle runs the handler for a single message, retrying it up to the configured max retries.
+func (c *DatabaseConsumer) handle(ctx context.Context, streamID string, handler handler, msg LogMessage) {
+	msgID := strconv.FormatInt(msg.ID, 10)
+	payload := fromLogPayload(msg.Payload)
+
+	for retries := 0; ; retries++ {
+		err := func() (err error) {
+			// Ensure that handlers don't cause panic.
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("PANIC when processing message '%s' in stream '%s':\n%s",
+						msgID, streamID, debug.Stack())
+				}
+			}()
+
+			return handler.handle(ctx, msgID, payload)
+		}()
+		if err == nil {
+			return
+		}
+
+		c.pushError(fmt.Errorf("failed to process message with id '%s' in stream '%s' (retries: %d): %w",
+			msgID, streamID, retries, err))
+
+		if retries >= handler.config.maxRetries {
+			c.pushError(fmt.Errorf("discard message with id '%s' from stream '%s' - failed %d retries",
+				msgID, streamID, retries))
+			return
+		}
+
+		// TODO: linear/exponential backoff relative to retry count might be good
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(handler.config.idleTimeout):
+		}
+	}
+}
+
+func (c *DatabaseConsumer) renewLease(ctx context.Context, streamID string) {
+	ticker := time.NewTicker(databaseConsumerLeaseDuration / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := c.store.Renew(ctx, c.groupName, streamID, c.consumerName, c.leaseExpiry())
+			if errors.Is(err, ErrLeaseLost) {
+				// the batch is completed anyway, the commit of the offset will fail.
+				c.pushInfo(fmt.Sprintf("lease of stream '%s' was lost while processing a batch", streamID))
+				return
+			}
+			if err != nil && ctx.Err() == nil {
+				c.pushError(fmt.Errorf("failed to renew lease of stream '%s': %w", streamID, err))
+			}
+		}
+	}
+}
+
+func (c *DatabaseConsumer) leaseExpiry() int64 {
+	return time.Now().Add(databaseConsumerLeaseDuration).UnixMilli()
+}
+
+func (c *DatabaseConsumer) Errors() <-chan error { return c.errorCh }
+func (c *DatabaseConsumer) Infos() <-chan string { return c.infoCh }
+
+func (c *DatabaseConsumer) pushError(err error) {
+	select {
+	case c.errorCh <- err:
+	default:
+	}
+}
+
+func (c *DatabaseConsumer) pushInfo(s string) {
+	select {
+	case c.infoCh <- s:
+	default:
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// trimInterval is the number of messages sent to a stream after which the stream is trimmed.
+const trimInterval = 100
+
+// DatabaseProducer appends messages to the durable stream log.
+// Producers never wait for consumers, a slow consumer only increases its lag.
+type DatabaseProducer struct {
+	store LogStore
+	// namespace defines the namespace of the stream keys - any stream key will be prefixed with it.
+	namespace string
+	// maxStreamLength defines the approximate maximum number of messages kept in each stream.
+	maxStreamLength int64
+
+	mx   sync.Mutex
+	sent map[string]int
+}
+
+func NewDatabaseProducer(store LogStore, namespace string, maxStreamLength int64) *DatabaseProducer {
+	return &DatabaseProducer{
+		store:           store,
+		namespace:       namespace,
+		maxStreamLength: maxStreamLength,
+		sent:            make(map[string]int),
+	}
+}
+
+// Send appends the message to the stream log.
+// Returns the message ID in case of success.
+func (p *DatabaseProducer) Send(ctx context.Context, streamID string, payload map[string]interface{}) (string, error) {
+	// ensure we transpose streamID using the key namespace
+	transposedStreamID := transposeStreamID(p.namespace, streamID)
+
+	id, err := p.store.Append(ctx, transposedStreamID, toLogPayload(payload))
+	if err != nil {
+		return "", fmt.Errorf("failed to write to stream '%s' (full stream '%s'). Error: %w",
+			streamID, transposedStreamID, err)
+	}
+
+	if p.shouldTrim(transposedStreamID) {
+		if err = p.store.Trim(ctx, transposedStreamID, p.maxStreamLength); err != nil {
+			return "", fmt.Errorf("failed to trim stream '%s': %w", transposedStreamID, err)
+		}
+	}
+
+	return strconv.FormatInt(id, 10), nil
+}
+
+// shouldTrim returns true every trimInterval messages sent to the stream.
+func (p *DatabaseProducer) shouldTrim(streamID string) bool {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	p.sent[streamID]++
+	if p.sent[streamID] < trimInterval {
+		return false
+	}
+
+	p.sent[streamID] = 0
+	return true
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrLeaseLost is returned by the LogStore if the consumer no longer holds the lease of a stream.
+var ErrLeaseLost = errors.New("lease of the stream was lost")
+
+// LogMessage is a single message of the durable stream log.
+type LogMessage struct {
+	ID       int64
+	StreamID string
+	Payload  map[string][]byte
+	Created  int64
+}
+
+// ConsumerLag describes how far a consumer group is behind the head of a stream.
+type ConsumerLag struct {
+	GroupName    string `json:"group_name"`
+	StreamID     string `json:"stream_id"`
+	Offset       int64  `json:"offset"`
+	Head         int64  `json:"head"`
+	Lag          int64  `json:"lag"`
+	LeaseOwner   string `json:"lease_owner"`
+	LeaseExpires int64  `json:"lease_expires"`
+	Updated      int64  `json:"updated"`
+}
+
+// LogStore is the storage of the durable stream log and the offsets of its consumer groups.
+// Messages of a stream have monotonically increasing IDs.
+type LogStore interface {
+	// Append appends a message to the stream and returns its ID.
+	Append(ctx context.Context, streamID string, payload map[string][]byte) (int64, error)
+
+	// ListAfter returns up to limit messages of the stream with an ID greater than the offset, ordered by ID.
+	ListAfter(ctx context.Context, streamID string, offset int64, limit int) ([]LogMessage, error)
+
+	// Trim deletes all but the newest maxLength messages of the stream.
+	Trim(ctx context.Context, streamID string, maxLength int64) error
+
+	// Lease acquires or renews the lease of the consumer group on the stream for the owner.
+	// It returns the current offset of the group and false if another owner holds an unexpired lease.
+	Lease(ctx context.Context, groupName, streamID, owner string, expires int64) (int64, bool, error)
+
+	// Renew extends the lease of the consumer group on the stream.
+	// It fails with ErrLeaseLost if the owner doesn't hold the lease anymore.
+	Renew(ctx context.Context, groupName, streamID, owner string, expires int64) error
+
+	// Commit stores the offset of the consumer group on the stream and renews the lease.
+	// It fails with ErrLeaseLost if the owner doesn't hold the lease anymore.
+	Commit(ctx context.Context, groupName, streamID, owner string, offset, expires int64) error
+
+	// Reset sets the offset of the consumer group on the stream and releases the lease,
+	// which causes the messages after the offset to be processed again.
+	Reset(ctx context.Context, groupName, streamID string, offset int64) error
+
+	// ListLag returns the lag of all consumer groups on all streams.
+	ListLag(ctx context.Context) ([]ConsumerLag, error)
+}
+
+// toLogPayload converts a stream payload into its stored representation.
+// Same as with redis, all values are stored as raw bytes.
+func toLogPayload(payload map[string]interface{}) map[string][]byte {
+	res := make(map[string][]byte, len(payload))
+	for k, v := range payload {
+		switch val := v.(type) {
+		case []byte:
+			res[k] = val
+		case string:
+			res[k] = []byte(val)
+		default:
+			res[k] = []byte(fmt.Sprint(val))
+		}
+	}
+
+	return res
+}
+
+// fromLogPayload converts a stored payload back into a stream payload.
+func fromLogPayload(payload map[string][]byte) map[string]interface{} {
+	res := make(map[string]interface{}, len(payload))
+	for k, v := range payload {
+		res[k] = v
+	}
+
+	return res
+}
@@ -0,0 +1,30 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// Artifact is a file produced by a pipeline execution.
+type Artifact struct {
+	ID          int64  `json:"id"`
+	ExecutionID int64  `json:"execution_id"`
+	RepoID      int64  `json:"repo_id"`
+	Name        string `json:"name"`
+	Size        int64  `json:"size"`
+	// Checksum is the hex encoded SHA-256 checksum of the artifact content.
+	Checksum    string `json:"checksum"`
+	ContentType string `json:"content_type"`
+	BlobPath    string `json:"-"`
+	CreatedBy   int64  `json:"created_by"`
+	Created     int64  `json:"created"`
+}
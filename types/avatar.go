@@ -0,0 +1,24 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// Avatar is the uploaded avatar image of a principal.
+type Avatar struct {
+	PrincipalID int64 `db:"avatar_principal_id" json:"-"`
+	// Hash is the SHA-256 hash of the stored avatar image.
+	Hash    string `db:"avatar_hash"    json:"hash"`
+	Created int64  `db:"avatar_created" json:"created"`
+	Updated int64  `db:"avatar_updated" json:"updated"`
+}
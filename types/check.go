@@ -109,3 +109,28 @@ type PullReqCheck struct {
 	Bypassable bool  `json:"bypassable"`
 	Check      Check `json:"check"`
 }
+
+// CombinedCheckStatus is the rollup of the latest status check results of a commit.
+type CombinedCheckStatus struct {
+	CommitSHA string `json:"commit_sha"`
+	Ref       string `json:"ref,omitempty"`
+	// Status is success if all non-optional checks succeeded, pending if any of them is still pending
+	// and failure otherwise.
+	Status enum.CheckStatus  `json:"status"`
+	Counts CheckStatusCounts `json:"counts"`
+	Checks []CombinedCheck   `json:"checks"`
+}
+
+// CheckStatusCounts holds the number of status checks per status.
+type CheckStatusCounts struct {
+	Pending int `json:"pending"`
+	Running int `json:"running"`
+	Success int `json:"success"`
+	Failure int `json:"failure"`
+	Error   int `json:"error"`
+}
+
+type CombinedCheck struct {
+	Optional bool  `json:"optional"`
+	Check    Check `json:"check"`
+}
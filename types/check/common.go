@@ -39,8 +39,12 @@ const (
 
 var (
 	// illegalRootSpaceIdentifiers is the list of space identifier we are blocking for root spaces
-	// as they might cause issues with routing.
-	illegalRootSpaceIdentifiers = []string{"api", "git"}
+	// as they might cause issues with routing (router mounts and static web routes).
+	illegalRootSpaceIdentifiers = []string{"api", "git", "openapi.yaml", "registry", "swagger", "v2"}
+
+	// illegalSpaceIdentifiers is the list of space identifiers we are blocking for all spaces,
+	// as space paths ending with them are routed to the artifact registry.
+	illegalSpaceIdentifiers = []string{"artifacts", "registries"}
 )
 
 var (
@@ -73,6 +77,10 @@ var (
 		fmt.Sprintf("The following identifiers are not allowed for a root space: %v", illegalRootSpaceIdentifiers),
 	}
 
+	ErrIllegalSpaceIdentifier = &ValidationError{
+		fmt.Sprintf("The following identifiers are not allowed for a space: %v", illegalSpaceIdentifiers),
+	}
+
 	ErrIllegalRepoSpaceIdentifierSuffix = &ValidationError{
 		fmt.Sprintf("Space and repository identifiers cannot end with %q.", illegalRepoSpaceIdentifierSuffix),
 	}
@@ -129,9 +137,9 @@ func Identifier(identifier string) error {
 
 type RepoIdentifier func(identifier string) error
 
-// RepoIdentifierDefault performs the default Identifier check and also blocks illegal repo identifiers.
+// RepoIdentifierDefault performs the default UID check and also blocks illegal repo identifiers.
 func RepoIdentifierDefault(identifier string) error {
-	if err := Identifier(identifier); err != nil {
+	if err := UID(identifier); err != nil {
 		return err
 	}
 
@@ -165,9 +173,9 @@ func PrincipalUIDDefault(uid string) error {
 // NOTE: Enables support for different path formats.
 type SpaceIdentifier func(identifier string, isRoot bool) error
 
-// SpaceIdentifierDefault performs the default Identifier check and also blocks illegal root space Identifiers.
+// SpaceIdentifierDefault performs the default UID check and also blocks illegal root space Identifiers.
 func SpaceIdentifierDefault(identifier string, isRoot bool) error {
-	if err := Identifier(identifier); err != nil {
+	if err := UID(identifier); err != nil {
 		return err
 	}
 
@@ -176,6 +184,12 @@ func SpaceIdentifierDefault(identifier string, isRoot bool) error {
 		return ErrIllegalRepoSpaceIdentifierSuffix
 	}
 
+	for _, p := range illegalSpaceIdentifiers {
+		if p == identifierLower {
+			return ErrIllegalSpaceIdentifier
+		}
+	}
+
 	if isRoot {
 		for _, p := range illegalRootSpaceIdentifiers {
 			if p == identifierLower {
@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"fmt"
+	"regexp"
+)
+
+const (
+	// MaxRepoTopics is the maximum number of topics of a repository.
+	MaxRepoTopics = 20
+
+	maxRepoTopicLength = 50
+)
+
+var repoTopicRegex = regexp.MustCompile("^[a-z0-9][a-z0-9-]*$")
+
+var (
+	ErrRepoTopicLength = &ValidationError{
+		fmt.Sprintf("Topic has to be between 1 and %d in length.", maxRepoTopicLength),
+	}
+	ErrRepoTopicRegex = &ValidationError{
+		"Topic can only contain lowercase letters, numbers and hyphens, and has to start with a letter or number.",
+	}
+	ErrRepoTopicsCount = &ValidationError{
+		fmt.Sprintf("A repository can have at most %d topics.", MaxRepoTopics),
+	}
+)
+
+// RepoTopic checks the provided repository topic and returns an error if it isn't valid.
+func RepoTopic(topic string) error {
+	l := len(topic)
+	if l < 1 || l > maxRepoTopicLength {
+		return ErrRepoTopicLength
+	}
+
+	if !repoTopicRegex.MatchString(topic) {
+		return ErrRepoTopicRegex
+	}
+
+	return nil
+}
+
+// RepoTopics checks the provided repository topics and returns an error if any of them isn't valid.
+func RepoTopics(topics []string) error {
+	if len(topics) > MaxRepoTopics {
+		return ErrRepoTopicsCount
+	}
+
+	for _, topic := range topics {
+		if err := RepoTopic(topic); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRepoTopics(t *testing.T) {
+	tests := []struct {
+		name    string
+		topics  []string
+		wantErr error
+	}{
+		{name: "valid", topics: []string{"go", "web-framework", "3d"}},
+		{name: "none", topics: nil},
+		{name: "empty", topics: []string{""}, wantErr: ErrRepoTopicLength},
+		{name: "too long", topics: []string{strings.Repeat("a", maxRepoTopicLength+1)}, wantErr: ErrRepoTopicLength},
+		{name: "uppercase", topics: []string{"Go"}, wantErr: ErrRepoTopicRegex},
+		{name: "invalid character", topics: []string{"web_framework"}, wantErr: ErrRepoTopicRegex},
+		{name: "leading dash", topics: []string{"-go"}, wantErr: ErrRepoTopicRegex},
+		{name: "too many", topics: make([]string, MaxRepoTopics+1), wantErr: ErrRepoTopicsCount},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := RepoTopics(test.topics)
+			if test.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if test.wantErr != nil && !errors.Is(err, test.wantErr) {
+				t.Fatalf("expected error %q, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
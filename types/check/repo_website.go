@@ -0,0 +1,52 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"fmt"
+	"net/url"
+)
+
+const (
+	maxRepoWebsiteLength = 2048
+)
+
+var (
+	ErrRepoWebsiteLength = &ValidationError{
+		fmt.Sprintf("Website can be at most %d in length.", maxRepoWebsiteLength),
+	}
+	ErrRepoWebsiteInvalid = &ValidationError{
+		"Website has to be an absolute http or https URL.",
+	}
+)
+
+// RepoWebsite checks the provided repository website and returns an error if it isn't valid.
+// An empty website is valid and means that the repository has no website.
+func RepoWebsite(website string) error {
+	if website == "" {
+		return nil
+	}
+
+	if len(website) > maxRepoWebsiteLength {
+		return ErrRepoWebsiteLength
+	}
+
+	u, err := url.Parse(website)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.User != nil {
+		return ErrRepoWebsiteInvalid
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import "regexp"
+
+const (
+	minUIDLength = 1
+	maxUIDLength = MaxIdentifierLength
+)
+
+var (
+	// uidRegex is the charset policy of UIDs. UIDs can't start with '.' or '-' to avoid
+	// confusion with relative paths and command line flags.
+	uidRegex = regexp.MustCompile("^[a-zA-Z0-9_][a-zA-Z0-9_.-]*$")
+
+	// uidPolicy is the policy used by UID.
+	uidPolicy = UIDPolicy{
+		AllowLeadingDigit: true,
+	}
+)
+
+var (
+	ErrUIDRegex = &ValidationError{
+		"Identifier can only contain the following characters [a-zA-Z0-9-_.] and can't start with '.' or '-'.",
+	}
+
+	ErrUIDLeadingDigit = &ValidationError{
+		"Identifier can't start with a digit.",
+	}
+)
+
+// UIDPolicy defines the rules UIDs of spaces, repositories, pipelines and tokens have to follow
+// in addition to the length and charset restrictions.
+type UIDPolicy struct {
+	// AllowLeadingDigit allows UIDs to start with a digit.
+	AllowLeadingDigit bool
+}
+
+// SetUIDPolicy sets the policy used by UID.
+// NOTE: It's expected to be called once during startup, before any request is handled.
+func SetUIDPolicy(policy UIDPolicy) {
+	uidPolicy = policy
+}
+
+// UID checks the provided UID of a space, repository, pipeline or token and returns an error if it isn't valid.
+// UIDs are unique per parent in a case-insensitive way.
+// NOTE: The check is only applied when resources are created or renamed,
+// existing resources that violate the policy still resolve.
+func UID(uid string) error {
+	l := len(uid)
+	if l < minUIDLength || l > maxUIDLength {
+		return ErrIdentifierLength
+	}
+
+	if !uidRegex.MatchString(uid) {
+		return ErrUIDRegex
+	}
+
+	if !uidPolicy.AllowLeadingDigit && uid[0] >= '0' && uid[0] <= '9' {
+		return ErrUIDLeadingDigit
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestUID(t *testing.T) {
+	tests := []struct {
+		name              string
+		uid               string
+		allowLeadingDigit bool
+		wantErr           error
+	}{
+		{name: "valid", uid: "my-repo_1.0", allowLeadingDigit: true},
+		{name: "empty", uid: "", allowLeadingDigit: true, wantErr: ErrIdentifierLength},
+		{name: "too long", uid: strings.Repeat("a", maxUIDLength+1), allowLeadingDigit: true,
+			wantErr: ErrIdentifierLength},
+		{name: "invalid character", uid: "my/repo", allowLeadingDigit: true, wantErr: ErrUIDRegex},
+		{name: "leading dot", uid: ".repo", allowLeadingDigit: true, wantErr: ErrUIDRegex},
+		{name: "leading dash", uid: "-repo", allowLeadingDigit: true, wantErr: ErrUIDRegex},
+		{name: "leading digit allowed", uid: "1repo", allowLeadingDigit: true},
+		{name: "leading digit disallowed", uid: "1repo", allowLeadingDigit: false, wantErr: ErrUIDLeadingDigit},
+		{name: "route segment", uid: "settings", allowLeadingDigit: true},
+	}
+
+	defer SetUIDPolicy(uidPolicy)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			SetUIDPolicy(UIDPolicy{AllowLeadingDigit: test.allowLeadingDigit})
+
+			err := UID(test.uid)
+			if test.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if test.wantErr != nil && !errors.Is(err, test.wantErr) {
+				t.Fatalf("expected error %q, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRepoAndSpaceIdentifierUseUIDPolicy(t *testing.T) {
+	if err := RepoIdentifierDefault(".repo"); !errors.Is(err, ErrUIDRegex) {
+		t.Errorf("expected repo identifier to be checked against the UID policy, got %v", err)
+	}
+	if err := SpaceIdentifierDefault("-space", false); !errors.Is(err, ErrUIDRegex) {
+		t.Errorf("expected space identifier to be checked against the UID policy, got %v", err)
+	}
+}
+
+func TestSpaceIdentifierDefault(t *testing.T) {
+	tests := []struct {
+		identifier string
+		isRoot     bool
+		wantErr    error
+	}{
+		{identifier: "my-space", isRoot: true},
+		{identifier: "settings", isRoot: true},
+		{identifier: "api", isRoot: false},
+		{identifier: "API", isRoot: true, wantErr: ErrIllegalRootSpaceIdentifier},
+		{identifier: "swagger", isRoot: true, wantErr: ErrIllegalRootSpaceIdentifier},
+		{identifier: "artifacts", isRoot: false, wantErr: ErrIllegalSpaceIdentifier},
+		{identifier: "Registries", isRoot: true, wantErr: ErrIllegalSpaceIdentifier},
+		{identifier: "space.git", isRoot: false, wantErr: ErrIllegalRepoSpaceIdentifierSuffix},
+	}
+
+	for _, test := range tests {
+		err := SpaceIdentifierDefault(test.identifier, test.isRoot)
+		if test.wantErr == nil && err != nil {
+			t.Errorf("%q (root=%t): unexpected error: %s", test.identifier, test.isRoot, err)
+		}
+		if test.wantErr != nil && !errors.Is(err, test.wantErr) {
+			t.Errorf("%q (root=%t): expected error %q, got %v", test.identifier, test.isRoot, test.wantErr, err)
+		}
+	}
+}
@@ -28,6 +28,7 @@ type CodeOwnerEvaluation struct {
 type CodeOwnerEvaluationEntry struct {
 	LineNumber                int64                      `json:"line_number"`
 	Pattern                   string                     `json:"pattern"`
+	Paths                     []string                   `json:"paths"`
 	OwnerEvaluations          []OwnerEvaluation          `json:"owner_evaluations"`
 	UserGroupOwnerEvaluations []UserGroupOwnerEvaluation `json:"user_group_owner_evaluations"`
 }
@@ -49,9 +50,10 @@ type CodeOwnersValidation struct {
 }
 
 type CodeOwnersViolation struct {
-	Code    enum.CodeOwnerViolationCode `json:"code"`
-	Message string                      `json:"message"`
-	Params  []any                       `json:"params"`
+	Code       enum.CodeOwnerViolationCode `json:"code"`
+	Message    string                      `json:"message"`
+	Params     []any                       `json:"params"`
+	LineNumber int64                       `json:"line_number,omitempty"`
 }
 
 func (violations *CodeOwnersValidation) Add(code enum.CodeOwnerViolationCode, message string) {
@@ -69,3 +71,37 @@ func (violations *CodeOwnersValidation) Addf(code enum.CodeOwnerViolationCode, f
 		Params:  params,
 	})
 }
+
+func (violations *CodeOwnersValidation) AddfAtLine(
+	lineNumber int64,
+	code enum.CodeOwnerViolationCode,
+	format string,
+	params ...any,
+) {
+	violations.Violations = append(violations.Violations, CodeOwnersViolation{
+		Code:       code,
+		Message:    fmt.Sprintf(format, params...),
+		Params:     params,
+		LineNumber: lineNumber,
+	})
+}
+
+// CodeOwnersPathOwners contains the owners of a single path,
+// as defined by the last matching entry of the codeowners file.
+type CodeOwnersPathOwners struct {
+	Path       string          `json:"path"`
+	LineNumber int64           `json:"line_number"`
+	Pattern    string          `json:"pattern"`
+	Owners     []PrincipalInfo `json:"owners"`
+	UserGroups []UserGroupInfo `json:"user_groups"`
+}
+
+// CodeOwnersOwnership contains the owners of a set of paths.
+type CodeOwnersOwnership struct {
+	FileSHA string                 `json:"file_sha"`
+	Paths   []CodeOwnersPathOwners `json:"paths"`
+
+	// Owners and UserGroups contain the union of all owners of all paths.
+	Owners     []PrincipalInfo `json:"owners"`
+	UserGroups []UserGroupInfo `json:"user_groups"`
+}
@@ -16,7 +16,14 @@ package types
 
 // CommitFilesResponse holds commit id.
 type CommitFilesResponse struct {
-	DryRunRules    bool             `json:"dry_run_rules,omitempty"`
-	CommitID       string           `json:"commit_id"`
-	RuleViolations []RuleViolations `json:"rule_violations,omitempty"`
+	DryRunRules    bool               `json:"dry_run_rules,omitempty"`
+	CommitID       string             `json:"commit_id"`
+	RuleViolations []RuleViolations   `json:"rule_violations,omitempty"`
+	Files          []CommitFileStatus `json:"files,omitempty"`
+}
+
+// CommitFileStatus reports whether the action on a file changed its content.
+type CommitFileStatus struct {
+	Path    string `json:"path"`
+	Changed bool   `json:"changed"`
 }
@@ -0,0 +1,48 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// CommitMessagePolicy defines the conventions the messages of commits pushed to a repository have to follow.
+// A repository without a policy of its own inherits the policy of its closest ancestor space that has one.
+type CommitMessagePolicy struct {
+	// SubjectPattern is a regular expression the subject (first line) of the commit message has to match.
+	SubjectPattern string `json:"subject_pattern,omitempty"`
+
+	// MaxSubjectLength is the maximum number of characters of the subject. Zero means unlimited.
+	MaxSubjectLength int `json:"max_subject_length,omitempty"`
+
+	// RequiredTrailer is the token of a trailer that must be present in the message, e.g. "Signed-off-by".
+	RequiredTrailer string `json:"required_trailer,omitempty"`
+
+	// SkipMergeCommits excludes commits with more than one parent from the policy.
+	SkipMergeCommits bool `json:"skip_merge_commits,omitempty"`
+
+	// ExcludedBranches lists glob patterns of branches the policy doesn't apply to, e.g. "wip/*".
+	ExcludedBranches []string `json:"excluded_branches,omitempty"`
+}
+
+// IsEmpty returns true if the policy doesn't place any restriction on commit messages.
+func (p CommitMessagePolicy) IsEmpty() bool {
+	return p.SubjectPattern == "" && p.MaxSubjectLength == 0 && p.RequiredTrailer == ""
+}
+
+// CommitMessagePolicyOutput is the commit message policy in effect for a repository or a space.
+type CommitMessagePolicyOutput struct {
+	CommitMessagePolicy
+
+	// InheritedFrom is the path of the space the policy is defined in,
+	// empty if it's defined for the resource itself or if no policy is in effect.
+	InheritedFrom string `json:"inherited_from,omitempty"`
+}
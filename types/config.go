@@ -22,6 +22,7 @@ import (
 	gitenum "github.com/harness/gitness/git/enum"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/pubsub"
+	"github.com/harness/gitness/types/enum"
 
 	gossh "golang.org/x/crypto/ssh"
 )
@@ -45,6 +46,13 @@ type Config struct {
 	// PublicResourceCreationEnabled specifies whether a user can create publicly accessible resources.
 	PublicResourceCreationEnabled bool `envconfig:"GITNESS_PUBLIC_RESOURCE_CREATION_ENABLED" default:"true"`
 
+	// PublicAccessEnabled specifies whether public resources are accessible without permissions.
+	// If disabled, all resources are treated as private, regardless of their public access mode.
+	PublicAccessEnabled bool `envconfig:"GITNESS_PUBLIC_ACCESS_ENABLED" default:"true"`
+
+	// MaxPinnedRepos is the maximum number of repositories that can be pinned in a space.
+	MaxPinnedRepos int `envconfig:"GITNESS_MAX_PINNED_REPOS" default:"10"`
+
 	Profiler struct {
 		Type        string `envconfig:"GITNESS_PROFILER_TYPE"`
 		ServiceName string `envconfig:"GITNESS_PROFILER_SERVICE_NAME" default:"gitness"`
@@ -108,6 +116,41 @@ type Config struct {
 		TmpDir string `envconfig:"GITNESS_GIT_TMP_DIR"`
 		// HookPath points to the binary used as git server hook.
 		HookPath string `envconfig:"GITNESS_GIT_HOOK_PATH"`
+		// DrainTimeout defines the max time we wait for in-flight git operations (clone, fetch, push)
+		// to complete on shutdown before they get canceled.
+		DrainTimeout time.Duration `envconfig:"GITNESS_GIT_DRAIN_TIMEOUT" default:"60s"`
+		// PartialClone specifies whether clients can request a partial clone (e.g. --filter=blob:none)
+		// and fetch the missing objects on demand.
+		PartialClone bool `envconfig:"GITNESS_GIT_PARTIAL_CLONE_ENABLED" default:"true"`
+		// AdvertisePushOptions specifies whether receive-pack accepts push options (git push -o).
+		// The push options are passed on to the git hooks.
+		AdvertisePushOptions bool `envconfig:"GITNESS_GIT_ADVERTISE_PUSH_OPTIONS" default:"true"`
+		// AdvertiseAtomic specifies whether receive-pack supports atomic pushes (git push --atomic),
+		// where either all references are updated or none of them.
+		AdvertiseAtomic bool `envconfig:"GITNESS_GIT_ADVERTISE_ATOMIC" default:"true"`
+		// HiddenRefs specifies the reference prefixes that are hidden from the reference advertisement
+		// of fetches and pushes (transfer.hideRefs), e.g. internal namespaces like "refs/pullreq".
+		// Commits only reachable from hidden references can't be fetched and the references can't be updated
+		// via git. Repositories can hide additional namespaces via their security settings.
+		HiddenRefs []string `envconfig:"GITNESS_GIT_HIDDEN_REFS"`
+		// AncestryBatchWindow specifies how long ancestry checks of a repository are collected
+		// to be executed by a single git process. A zero value disables batching.
+		AncestryBatchWindow time.Duration `envconfig:"GITNESS_GIT_ANCESTRY_BATCH_WINDOW" default:"5ms"`
+		// PatchMaxSize is the maximum size (in bytes) of downloadable .patch and .diff files.
+		// Larger patches are truncated.
+		PatchMaxSize int64 `envconfig:"GITNESS_GIT_PATCH_MAX_SIZE" default:"20971520"`
+		// PatchUploadMaxSize is the maximum size (in bytes) of requests applying a patch to a branch.
+		PatchUploadMaxSize int64 `envconfig:"GITNESS_GIT_PATCH_UPLOAD_MAX_SIZE" default:"10485760"`
+		// SlowOperationThreshold is the duration after which git processes are logged as slow
+		// (zero disables the log). It can be changed at runtime via the instance settings.
+		SlowOperationThreshold time.Duration `envconfig:"GITNESS_GIT_SLOW_OPERATION_THRESHOLD" default:"10s"`
+		// MaxConcurrentOperations is the maximum number of concurrent git operations (clone, fetch, push)
+		// per principal, or per client IP for anonymous access (zero disables the limit).
+		// It can be changed at runtime via the instance settings and overridden for individual principals.
+		MaxConcurrentOperations int `envconfig:"GITNESS_GIT_MAX_CONCURRENT_OPERATIONS" default:"20"`
+		// ConcurrentOperationsWait is the duration git operations exceeding the limit wait for a free slot
+		// before they're rejected. It can be changed at runtime via the instance settings.
+		ConcurrentOperationsWait time.Duration `envconfig:"GITNESS_GIT_CONCURRENT_OPERATIONS_WAIT" default:"10s"`
 
 		// LastCommitCache holds configuration options for the last commit cache.
 		LastCommitCache struct {
@@ -117,6 +160,14 @@ type Config struct {
 			// Duration defines cache duration of last commit.
 			Duration time.Duration `envconfig:"GITNESS_GIT_LAST_COMMIT_CACHE_DURATION" default:"12h"`
 		}
+
+		// Timeouts defines the max duration of long running git operations.
+		Timeouts struct {
+			InfoRefs    time.Duration `envconfig:"GITNESS_GIT_TIMEOUT_INFO_REFS" default:"30s"`
+			UploadPack  time.Duration `envconfig:"GITNESS_GIT_TIMEOUT_UPLOAD_PACK" default:"1h"`
+			ReceivePack time.Duration `envconfig:"GITNESS_GIT_TIMEOUT_RECEIVE_PACK" default:"1h"`
+			Archive     time.Duration `envconfig:"GITNESS_GIT_TIMEOUT_ARCHIVE" default:"10m"`
+		}
 	}
 
 	// Encrypter defines the parameters for the encrypter
@@ -130,6 +181,17 @@ type Config struct {
 		Port  int    `envconfig:"GITNESS_HTTP_PORT" default:"3000"`
 		Host  string `envconfig:"GITNESS_HTTP_HOST"`
 		Proto string `envconfig:"GITNESS_HTTP_PROTO" default:"http"`
+
+		// Timeouts defines the max duration of api requests, after which the request is canceled.
+		// Streaming endpoints (events, logs, archives, uploads and git hooks) aren't limited.
+		// Zero disables the timeout.
+		Timeouts struct {
+			// Default is the timeout of api requests without a route specific timeout.
+			Default time.Duration `envconfig:"GITNESS_HTTP_TIMEOUT_DEFAULT" default:"1m"`
+
+			// Long is the timeout of expensive api requests like diffs, blame, contributor stats and search.
+			Long time.Duration `envconfig:"GITNESS_HTTP_TIMEOUT_LONG" default:"5m"`
+		}
 	}
 
 	// Acme defines Acme configuration parameters.
@@ -173,12 +235,50 @@ type Config struct {
 		// In that case, GITNESS_URL_CONTAINER should also be changed
 		// (eg to http://<gitness_container_name>:<port>).
 		ContainerNetworks []string `envconfig:"GITNESS_CI_CONTAINER_NETWORKS"`
+
+		// Artifacts defines the limits of files uploaded by pipeline executions.
+		Artifacts struct {
+			// MaxFileSize is the maximum size of a single artifact in bytes.
+			MaxFileSize int64 `envconfig:"GITNESS_CI_ARTIFACTS_MAX_FILE_SIZE" default:"104857600"` // 100 MiB
+			// RetentionTime is the duration after which artifacts are purged.
+			RetentionTime time.Duration `envconfig:"GITNESS_CI_ARTIFACTS_RETENTION_TIME" default:"720h"` // 30 days
+			// MaxRepoSize is the maximum total size of artifacts kept per repository in bytes,
+			// the oldest artifacts are purged first. 0 means unlimited.
+			MaxRepoSize int64 `envconfig:"GITNESS_CI_ARTIFACTS_MAX_REPO_SIZE"`
+		}
+
+		// MaxExecutionTimeout is the maximum duration an execution may run, measured from its start.
+		// It's used for pipelines that don't configure a timeout and caps the ones that do.
+		MaxExecutionTimeout time.Duration `envconfig:"GITNESS_CI_MAX_EXECUTION_TIMEOUT" default:"10h"`
+		// MaxStepTimeout is the maximum duration a single step may run, measured from its start.
+		// It's used for steps that don't declare a timeout and caps the ones that do.
+		MaxStepTimeout time.Duration `envconfig:"GITNESS_CI_MAX_STEP_TIMEOUT" default:"10h"`
+
+		// Runners defines how runners register with the server and how stages of offline runners are handled.
+		Runners struct {
+			// RegistrationToken is the shared secret runners present to register, registration is disabled if empty.
+			RegistrationToken string `envconfig:"GITNESS_CI_RUNNERS_REGISTRATION_TOKEN"`
+			// HeartbeatTimeout is the age of the last heartbeat after which a runner is considered offline.
+			HeartbeatTimeout time.Duration `envconfig:"GITNESS_CI_RUNNERS_HEARTBEAT_TIMEOUT" default:"1m"`
+			// StalePolicy defines whether stages of runners that went offline are re-queued or failed.
+			StalePolicy enum.RunnerStalePolicy `envconfig:"GITNESS_CI_RUNNERS_STALE_POLICY" default:"requeue"`
+			// EmbeddedLabels are the labels of the runner that is part of the server (e.g. "gpu:true,zone:eu").
+			EmbeddedLabels map[string]string `envconfig:"GITNESS_CI_RUNNERS_EMBEDDED_LABELS"`
+		}
 	}
 
 	// Database defines the database configuration parameters.
 	Database struct {
 		Driver     string `envconfig:"GITNESS_DATABASE_DRIVER" default:"sqlite3"`
 		Datasource string `envconfig:"GITNESS_DATABASE_DATASOURCE" default:"database.sqlite3"`
+
+		// ReplicaDatasource is the datasource of a read-only replica of the database (postgres only).
+		// Heavy read-only queries are sent to the replica, falling back to the primary if it's unreachable.
+		ReplicaDatasource string `envconfig:"GITNESS_DATABASE_REPLICA_DATASOURCE"`
+
+		// SlowQueryThreshold is the duration after which store queries are logged as slow (zero disables the log).
+		// It can be changed at runtime via the instance settings.
+		SlowQueryThreshold time.Duration `envconfig:"GITNESS_DATABASE_SLOW_QUERY_THRESHOLD" default:"1s"`
 	}
 
 	// BlobStore defines the blob storage configuration parameters.
@@ -196,6 +296,18 @@ type Config struct {
 		TargetPrincipal string `envconfig:"GITNESS_BLOBSTORE_TARGET_PRINCIPAL" default:""`
 
 		ImpersonationLifetime time.Duration `envconfig:"GITNESS_BLOBSTORE_IMPERSONATION_LIFETIME" default:"12h"`
+
+		// S3 configures the s3 provider, which works with any S3 compatible object storage.
+		S3 struct {
+			Endpoint  string `envconfig:"GITNESS_BLOBSTORE_S3_ENDPOINT"`
+			Region    string `envconfig:"GITNESS_BLOBSTORE_S3_REGION"`
+			AccessKey string `envconfig:"GITNESS_BLOBSTORE_S3_ACCESS_KEY"`
+			SecretKey string `envconfig:"GITNESS_BLOBSTORE_S3_SECRET_KEY"`
+			PathStyle bool   `envconfig:"GITNESS_BLOBSTORE_S3_PATH_STYLE"`
+		}
+
+		// SignedURLExpiry is the time for which signed download URLs are valid.
+		SignedURLExpiry time.Duration `envconfig:"GITNESS_BLOBSTORE_SIGNED_URL_EXPIRY" default:"1h"`
 	}
 
 	// Token defines token configuration parameters.
@@ -204,8 +316,97 @@ type Config struct {
 		Expire     time.Duration `envconfig:"GITNESS_TOKEN_EXPIRE" default:"720h"`
 	}
 
+	// OIDC defines the single sign-on via an OpenID Connect identity provider.
+	OIDC struct {
+		Enabled bool `envconfig:"GITNESS_OIDC_ENABLED" default:"false"`
+
+		// DisplayName is the name of the identity provider shown to users.
+		DisplayName string `envconfig:"GITNESS_OIDC_DISPLAY_NAME" default:"SSO"`
+
+		// IssuerURL is the issuer of the identity provider, used to fetch its discovery document.
+		IssuerURL    string   `envconfig:"GITNESS_OIDC_ISSUER_URL"`
+		ClientID     string   `envconfig:"GITNESS_OIDC_CLIENT_ID"`
+		ClientSecret string   `envconfig:"GITNESS_OIDC_CLIENT_SECRET"`
+		Scopes       []string `envconfig:"GITNESS_OIDC_SCOPES" default:"openid,profile,email"`
+
+		// RedirectURL is the callback URL registered with the identity provider.
+		// Value is derived from the API URL unless explicitly specified.
+		RedirectURL string `envconfig:"GITNESS_OIDC_REDIRECT_URL"`
+
+		// ClaimEmail, ClaimName and ClaimUsername are the ID token claims the user details are taken from.
+		ClaimEmail    string `envconfig:"GITNESS_OIDC_CLAIM_EMAIL" default:"email"`
+		ClaimName     string `envconfig:"GITNESS_OIDC_CLAIM_NAME" default:"name"`
+		ClaimUsername string `envconfig:"GITNESS_OIDC_CLAIM_USERNAME" default:"preferred_username"`
+
+		// AutoProvision creates users on their first login.
+		AutoProvision bool `envconfig:"GITNESS_OIDC_AUTO_PROVISION" default:"true"`
+
+		// LinkExistingByEmail links the first login to an existing local user with the same (verified) email.
+		// If disabled, such logins are rejected as conflicting with the existing user.
+		LinkExistingByEmail bool `envconfig:"GITNESS_OIDC_LINK_EXISTING_BY_EMAIL" default:"false"`
+
+		// DiscoveryTimeout is the timeout of requests to the identity provider.
+		DiscoveryTimeout time.Duration `envconfig:"GITNESS_OIDC_DISCOVERY_TIMEOUT" default:"10s"`
+
+		// DiscoveryCacheTTL is the duration for which the discovery document and signing keys are cached.
+		DiscoveryCacheTTL time.Duration `envconfig:"GITNESS_OIDC_DISCOVERY_CACHE_TTL" default:"1h"`
+	}
+
+	// Login defines the brute-force protection of password logins.
+	Login struct {
+		// ProtectionEnabled enables tracking of failed logins, delayed responses and temporary lockouts.
+		ProtectionEnabled bool `envconfig:"GITNESS_LOGIN_PROTECTION_ENABLED" default:"true"`
+
+		// FailureWindow is the sliding window in which failed logins are counted.
+		FailureWindow time.Duration `envconfig:"GITNESS_LOGIN_FAILURE_WINDOW" default:"15m"`
+
+		// DelayAfter is the number of failed logins after which responses to failed logins are delayed.
+		DelayAfter int `envconfig:"GITNESS_LOGIN_DELAY_AFTER" default:"3"`
+
+		// DelayStep is the delay added for every failed login beyond DelayAfter.
+		DelayStep time.Duration `envconfig:"GITNESS_LOGIN_DELAY_STEP" default:"500ms"`
+
+		// MaxDelay is the maximum delay of a response to a failed login.
+		MaxDelay time.Duration `envconfig:"GITNESS_LOGIN_MAX_DELAY" default:"5s"`
+
+		// LockoutThreshold is the number of failed logins for an account after which the account is locked.
+		LockoutThreshold int `envconfig:"GITNESS_LOGIN_LOCKOUT_THRESHOLD" default:"10"`
+
+		// IPLockoutThreshold is the number of failed logins from a source IP after which the IP is locked.
+		IPLockoutThreshold int `envconfig:"GITNESS_LOGIN_IP_LOCKOUT_THRESHOLD" default:"50"`
+
+		// LockoutDuration is the duration for which an account or source IP stays locked.
+		LockoutDuration time.Duration `envconfig:"GITNESS_LOGIN_LOCKOUT_DURATION" default:"15m"`
+	}
+
+	// Maintenance defines the responses to writes that are blocked while maintenance mode is enabled.
+	// Maintenance mode itself is toggled at runtime via the maintenance_mode feature flag.
+	Maintenance struct {
+		// Message is the message returned for blocked writes.
+		Message string `envconfig:"GITNESS_MAINTENANCE_MESSAGE" default:"The system is undergoing maintenance, writes are temporarily disabled."`
+
+		// RetryAfter is the delay clients are asked to wait before retrying blocked writes.
+		RetryAfter time.Duration `envconfig:"GITNESS_MAINTENANCE_RETRY_AFTER" default:"5m"`
+	}
+
+	// Idempotency defines the handling of the Idempotency-Key header of resource creating requests.
+	Idempotency struct {
+		// TTL is the duration for which the response of a request is stored with its idempotency key.
+		TTL time.Duration `envconfig:"GITNESS_IDEMPOTENCY_TTL" default:"24h"`
+
+		// LockTimeout is the duration after which a key of a request that never completed can be reused.
+		LockTimeout time.Duration `envconfig:"GITNESS_IDEMPOTENCY_LOCK_TIMEOUT" default:"10m"`
+
+		// WaitTimeout is the maximum time a request waits for a concurrent request with the same key.
+		WaitTimeout time.Duration `envconfig:"GITNESS_IDEMPOTENCY_WAIT_TIMEOUT" default:"30s"`
+	}
+
 	Logs struct {
+		// BlobStore stores the logs of completed steps in the blob store instead of the database.
+		BlobStore bool `envconfig:"GITNESS_LOGS_BLOBSTORE"`
+
 		// S3 provides optional storage option for logs.
+		// Deprecated: use GITNESS_LOGS_BLOBSTORE with the s3 blob store provider instead.
 		S3 struct {
 			Bucket    string `envconfig:"GITNESS_LOGS_S3_BUCKET"`
 			Prefix    string `envconfig:"GITNESS_LOGS_S3_PREFIX"`
@@ -218,8 +419,8 @@ type Config struct {
 	Cors struct {
 		AllowedOrigins   []string `envconfig:"GITNESS_CORS_ALLOWED_ORIGINS"   default:"*"`
 		AllowedMethods   []string `envconfig:"GITNESS_CORS_ALLOWED_METHODS"   default:"GET,POST,PATCH,PUT,DELETE,OPTIONS"`
-		AllowedHeaders   []string `envconfig:"GITNESS_CORS_ALLOWED_HEADERS"   default:"Origin,Accept,Accept-Language,Authorization,Content-Type,Content-Language,X-Requested-With,X-Request-Id"` //nolint:lll // struct tags can't be multiline
-		ExposedHeaders   []string `envconfig:"GITNESS_CORS_EXPOSED_HEADERS"   default:"Link"`
+		AllowedHeaders   []string `envconfig:"GITNESS_CORS_ALLOWED_HEADERS"   default:"Origin,Accept,Accept-Language,Authorization,Content-Type,Content-Language,X-Requested-With,X-Request-Id,X-CSRF-Token,Idempotency-Key"` //nolint:lll // struct tags can't be multiline
+		ExposedHeaders   []string `envconfig:"GITNESS_CORS_EXPOSED_HEADERS"   default:"Link,X-Total,X-Total-Pages,X-Page,X-Per-Page,X-Next-Page,X-Prev-Page,X-Request-Id,Idempotent-Replayed"`                                //nolint:lll // struct tags can't be multiline
 		AllowCredentials bool     `envconfig:"GITNESS_CORS_ALLOW_CREDENTIALS" default:"true"`
 		MaxAge           int      `envconfig:"GITNESS_CORS_MAX_AGE"           default:"300"`
 	}
@@ -264,6 +465,13 @@ type Config struct {
 			Email       string `envconfig:"GITNESS_PRINCIPAL_GITSPACE_EMAIL"        default:"gitspace@gitness.io"`
 		}
 
+		// DeployKey defines the principal information used to create the deploy key service.
+		DeployKey struct {
+			UID         string `envconfig:"GITNESS_PRINCIPAL_DEPLOY_KEY_UID"          default:"deploy-key"`
+			DisplayName string `envconfig:"GITNESS_PRINCIPAL_DEPLOY_KEY_DISPLAY_NAME" default:"Gitness Deploy Key"`
+			Email       string `envconfig:"GITNESS_PRINCIPAL_DEPLOY_KEY_EMAIL"        default:"deploy-key@gitness.io"`
+		}
+
 		// Admin defines the principal information used to create the admin user.
 		// NOTE: The admin user is only auto-created in case a password and an email is provided.
 		Admin struct {
@@ -346,6 +554,23 @@ type Config struct {
 		MaxRetries  int `envconfig:"GITNESS_TRIGGER_MAX_RETRIES" default:"3"`
 	}
 
+	// Outbound defines the configuration of http requests to destinations outside of the application
+	// (e.g. webhooks and the metric collector).
+	Outbound struct {
+		// Timeout is the max duration of an outbound request, including reading the response.
+		Timeout time.Duration `envconfig:"GITNESS_OUTBOUND_TIMEOUT" default:"1m"`
+		// ProxyURL is the proxy used for outbound requests.
+		// If empty, the proxy is taken from the environment (HTTP_PROXY, HTTPS_PROXY and NO_PROXY).
+		ProxyURL string `envconfig:"GITNESS_OUTBOUND_PROXY_URL"`
+		// MaxResponseSize is the max number of bytes read from the response of an outbound request.
+		MaxResponseSize int64 `envconfig:"GITNESS_OUTBOUND_MAX_RESPONSE_SIZE" default:"10485760"`
+		// AllowedNetworks contains networks (CIDR notation) or IPs that can always be targeted,
+		// even though they are loopback, link-local or private addresses.
+		AllowedNetworks []string `envconfig:"GITNESS_OUTBOUND_ALLOWED_NETWORKS"`
+		// LogRequests specifies whether destination, status and duration of outbound requests are logged.
+		LogRequests bool `envconfig:"GITNESS_OUTBOUND_LOG_REQUESTS" default:"true"`
+	}
+
 	Metric struct {
 		Enabled  bool   `envconfig:"GITNESS_METRIC_ENABLED" default:"true"`
 		Endpoint string `envconfig:"GITNESS_METRIC_ENDPOINT" default:"https://stats.drone.ci/api/v1/gitness"`
@@ -359,6 +584,46 @@ type Config struct {
 		NumWorkers  int           `envconfig:"GITNESS_REPO_SIZE_NUM_WORKERS" default:"5"`
 	}
 
+	RepoMaintenance struct {
+		Enabled     bool          `envconfig:"GITNESS_REPO_MAINTENANCE_ENABLED" default:"true"`
+		CRON        string        `envconfig:"GITNESS_REPO_MAINTENANCE_CRON" default:"0 2 * * *"`
+		MaxDuration time.Duration `envconfig:"GITNESS_REPO_MAINTENANCE_MAX_DURATION" default:"2h"`
+		NumWorkers  int           `envconfig:"GITNESS_REPO_MAINTENANCE_NUM_WORKERS" default:"2"`
+	}
+
+	RepoStats struct {
+		Enabled     bool          `envconfig:"GITNESS_REPO_STATS_ENABLED" default:"true"`
+		CRON        string        `envconfig:"GITNESS_REPO_STATS_CRON" default:"15 * * * *"`
+		MaxDuration time.Duration `envconfig:"GITNESS_REPO_STATS_MAX_DURATION" default:"30m"`
+		NumWorkers  int           `envconfig:"GITNESS_REPO_STATS_NUM_WORKERS" default:"5"`
+		// TopContributors is the number of top contributors precomputed per time window.
+		TopContributors int `envconfig:"GITNESS_REPO_STATS_TOP_CONTRIBUTORS" default:"10"`
+	}
+
+	CounterReconciler struct {
+		Enabled     bool          `envconfig:"GITNESS_COUNTER_RECONCILER_ENABLED" default:"true"`
+		CRON        string        `envconfig:"GITNESS_COUNTER_RECONCILER_CRON" default:"30 3 * * *"`
+		MaxDuration time.Duration `envconfig:"GITNESS_COUNTER_RECONCILER_MAX_DURATION" default:"1h"`
+		// BatchSize is the number of rows that are recomputed at once.
+		BatchSize int `envconfig:"GITNESS_COUNTER_RECONCILER_BATCH_SIZE" default:"100"`
+		// BatchInterval is the pause between two batches, to limit the load on the database.
+		BatchInterval time.Duration `envconfig:"GITNESS_COUNTER_RECONCILER_BATCH_INTERVAL" default:"100ms"`
+	}
+
+	Usage struct {
+		Enabled     bool          `envconfig:"GITNESS_USAGE_ENABLED" default:"true"`
+		CRON        string        `envconfig:"GITNESS_USAGE_CRON" default:"15 * * * *"`
+		MaxDuration time.Duration `envconfig:"GITNESS_USAGE_MAX_DURATION" default:"10m"`
+		// MaxReportDays is the maximum number of days a usage report can span.
+		MaxReportDays int `envconfig:"GITNESS_USAGE_MAX_REPORT_DAYS" default:"366"`
+	}
+
+	Quota struct {
+		// WarningThreshold is the usage of a space quota, in percent of its limit, above which
+		// responses and pushes warn about the remaining quota. 0 disables the warnings.
+		WarningThreshold int `envconfig:"GITNESS_QUOTA_WARNING_THRESHOLD" default:"80"`
+	}
+
 	CodeOwners struct {
 		FilePaths []string `envconfig:"GITNESS_CODEOWNERS_FILEPATH" default:"CODEOWNERS,.harness/CODEOWNERS"`
 	}
@@ -370,6 +635,11 @@ type Config struct {
 		Password string `envconfig:"GITNESS_SMTP_PASSWORD"`
 		FromMail string `envconfig:"GITNESS_SMTP_FROM_MAIL"`
 		Insecure bool   `envconfig:"GITNESS_SMTP_INSECURE"`
+
+		// MaxRetries is the number of times the delivery of an email to a recipient is retried.
+		MaxRetries int `envconfig:"GITNESS_SMTP_MAX_RETRIES" default:"3"`
+		// SendTimeout is the max duration of a single delivery attempt.
+		SendTimeout time.Duration `envconfig:"GITNESS_SMTP_SEND_TIMEOUT" default:"1m"`
 	}
 
 	Notification struct {
@@ -382,11 +652,93 @@ type Config struct {
 		MaxRetries  int `envconfig:"GITNESS_KEYWORD_SEARCH_MAX_RETRIES" default:"3"`
 	}
 
+	RepoDocs struct {
+		Concurrency int `envconfig:"GITNESS_REPO_DOCS_CONCURRENCY" default:"4"`
+		MaxRetries  int `envconfig:"GITNESS_REPO_DOCS_MAX_RETRIES" default:"3"`
+	}
+
 	Repos struct {
 		// DeletedRetentionTime is the duration after which deleted repositories will be purged.
 		DeletedRetentionTime time.Duration `envconfig:"GITNESS_REPOS_DELETED_RETENTION_TIME" default:"2160h"` // 90 days
 	}
 
+	// UID defines the policy for the UIDs of spaces, repositories, pipelines and tokens.
+	UID struct {
+		// AllowLeadingDigit allows UIDs to start with a digit.
+		AllowLeadingDigit bool `envconfig:"GITNESS_UID_ALLOW_LEADING_DIGIT" default:"true"`
+	}
+
+	PullReq struct {
+		// ClosedRefsRetentionTime is the duration after which the head git references
+		// of closed pull requests (refs/pullreq/{number}/head) will be removed.
+		ClosedRefsRetentionTime time.Duration `envconfig:"GITNESS_PULLREQ_CLOSED_REFS_RETENTION_TIME" default:"2160h"` // 90 days
+	}
+
+	RepoActivity struct {
+		Concurrency int `envconfig:"GITNESS_REPO_ACTIVITY_CONCURRENCY" default:"4"`
+		MaxRetries  int `envconfig:"GITNESS_REPO_ACTIVITY_MAX_RETRIES" default:"3"`
+		// RetentionTime is the duration after which repository activities will be purged from the DB.
+		RetentionTime time.Duration `envconfig:"GITNESS_REPO_ACTIVITY_RETENTION_TIME" default:"2160h"` // 90 days
+	}
+
+	RepoAccessLog struct {
+		// FlushInterval is the interval in which recorded git operations are written to the database.
+		FlushInterval time.Duration `envconfig:"GITNESS_REPO_ACCESS_LOG_FLUSH_INTERVAL" default:"5s"`
+		// BatchSize is the maximum number of access log entries written to the database at once.
+		BatchSize int `envconfig:"GITNESS_REPO_ACCESS_LOG_BATCH_SIZE" default:"500"`
+		// RetentionTime is the duration after which repository access log entries will be purged from the DB.
+		RetentionTime time.Duration `envconfig:"GITNESS_REPO_ACCESS_LOG_RETENTION_TIME" default:"2160h"` // 90 days
+	}
+
+	Digest struct {
+		// BatchSize is the number of digest subscriptions processed at once.
+		BatchSize int `envconfig:"GITNESS_DIGEST_BATCH_SIZE" default:"100"`
+		// MaxRepos is the maximum number of repositories included in a single digest.
+		MaxRepos int `envconfig:"GITNESS_DIGEST_MAX_REPOS" default:"50"`
+		// SendInterval is the minimum time between two digest emails, used to stay within SMTP rate limits.
+		SendInterval time.Duration `envconfig:"GITNESS_DIGEST_SEND_INTERVAL" default:"200ms"`
+	}
+
+	Inbox struct {
+		Concurrency int `envconfig:"GITNESS_INBOX_CONCURRENCY" default:"4"`
+		MaxRetries  int `envconfig:"GITNESS_INBOX_MAX_RETRIES" default:"3"`
+		// DedupWindow is the time in which repeated notifications about the same resource are dropped.
+		DedupWindow time.Duration `envconfig:"GITNESS_INBOX_DEDUP_WINDOW" default:"5m"`
+	}
+
+	Avatar struct {
+		// MaxFileSize is the maximum size of uploaded avatar images in bytes.
+		MaxFileSize int64 `envconfig:"GITNESS_AVATAR_MAX_FILE_SIZE" default:"2097152"`
+		// MaxDimension is the maximum width and height of stored avatars, bigger images are downscaled.
+		MaxDimension int `envconfig:"GITNESS_AVATAR_MAX_DIMENSION" default:"512"`
+	}
+
+	Upload struct {
+		// MaxFileSize is the maximum size of files uploaded via the API in bytes.
+		// NOTE: The value can be overridden at runtime via the instance settings.
+		MaxFileSize int64 `envconfig:"GITNESS_UPLOAD_MAX_FILE_SIZE" default:"10485760"`
+	}
+
+	SpaceFeed struct {
+		Concurrency int `envconfig:"GITNESS_SPACE_FEED_CONCURRENCY" default:"4"`
+		MaxRetries  int `envconfig:"GITNESS_SPACE_FEED_MAX_RETRIES" default:"3"`
+		// MaxLength is the number of live update events kept for resuming event streams.
+		MaxLength int64 `envconfig:"GITNESS_SPACE_FEED_MAX_LENGTH" default:"10000"`
+		// ReplayLimit is the maximum number of events replayed when an event stream is resumed.
+		ReplayLimit int `envconfig:"GITNESS_SPACE_FEED_REPLAY_LIMIT" default:"500"`
+		// MaxStreamsPerUser is the maximum number of concurrent space event streams of a single principal.
+		MaxStreamsPerUser int `envconfig:"GITNESS_SPACE_FEED_MAX_STREAMS_PER_USER" default:"10"`
+	}
+
+	RecentVisits struct {
+		// Debounce is the minimum time between two recorded visits of the same resource by the same user.
+		Debounce time.Duration `envconfig:"GITNESS_RECENT_VISITS_DEBOUNCE" default:"10m"`
+		// FlushInterval is the interval in which recorded visits are written to the database.
+		FlushInterval time.Duration `envconfig:"GITNESS_RECENT_VISITS_FLUSH_INTERVAL" default:"30s"`
+		// MaxEntries is the number of most recent visits kept per user.
+		MaxEntries int `envconfig:"GITNESS_RECENT_VISITS_MAX_ENTRIES" default:"50"`
+	}
+
 	Docker struct {
 		// Host sets the url to the docker server.
 		Host string `envconfig:"GITNESS_DOCKER_HOST"`
@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// CounterValue is the stored value of a denormalized counter together with its value
+// recomputed from the source of truth.
+type CounterValue struct {
+	// ID is the id of the row holding the counter.
+	ID int64
+	// Column is the name of the column holding the counter.
+	Column string
+	Stored int64
+	Actual int64
+}
+
+// Delta returns the difference between the recomputed and the stored value.
+func (v CounterValue) Delta() int64 {
+	return v.Actual - v.Stored
+}
+
+// CounterDrift contains the statistics of the reconciliation of a counter.
+type CounterDrift struct {
+	Counter enum.CounterType `json:"counter"`
+	// Checked is the number of counter values that were compared.
+	Checked int64 `json:"checked"`
+	// Corrected is the number of counter values that were fixed.
+	Corrected int64 `json:"corrected"`
+	// MaxDelta is the largest absolute difference between a stored and a recomputed value.
+	MaxDelta int64 `json:"max_delta"`
+}
@@ -0,0 +1,40 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// DeployKey is an SSH public key that grants git access to a single repository.
+type DeployKey struct {
+	ID          int64  `json:"-"`
+	RepoID      int64  `json:"-"` // API always returns keys for the same repository
+	CreatedBy   int64  `json:"created_by"`
+	Created     int64  `json:"created"`
+	Verified    *int64 `json:"verified"`
+	LastUsed    *int64 `json:"last_used"`
+	Identifier  string `json:"identifier"`
+	Title       string `json:"title"`
+	ReadOnly    bool   `json:"read_only"`
+	Fingerprint string `json:"fingerprint"`
+	Content     string `json:"-"`
+	Comment     string `json:"comment"`
+	Type        string `json:"type"`
+}
+
+type DeployKeyFilter struct {
+	ListQueryFilter
+	Sort  enum.PublicKeySort
+	Order enum.Order
+}
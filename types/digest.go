@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// DigestSubscription stores which activity digests a user wants to receive.
+type DigestSubscription struct {
+	PrincipalID int64                `json:"-"`
+	Frequency   enum.DigestFrequency `json:"frequency"`
+	SpaceIDs    []int64              `json:"space_ids"`
+	RepoIDs     []int64              `json:"repo_ids"`
+	LastSent    int64                `json:"last_sent"`
+	Created     int64                `json:"created"`
+	Updated     int64                `json:"updated"`
+}
+
+// Digest is the summary of the activity of a set of repositories in a period of time.
+type Digest struct {
+	Recipient *PrincipalInfo `json:"recipient"`
+	From      int64          `json:"from"`
+	To        int64          `json:"to"`
+	Repos     []DigestRepo   `json:"repos"`
+}
+
+// IsEmpty returns true if there's nothing to report.
+func (d *Digest) IsEmpty() bool {
+	return len(d.Repos) == 0
+}
+
+// DigestRepo is the summary of the activity of a single repository.
+type DigestRepo struct {
+	Path             string                    `json:"path"`
+	URL              string                    `json:"url"`
+	Pushes           int                       `json:"pushes"`
+	CommitsPushed    int                       `json:"commits_pushed"`
+	PullReqsOpened   []DigestPullReq           `json:"pullreqs_opened"`
+	PullReqsMerged   []DigestPullReq           `json:"pullreqs_merged"`
+	NewContributors  []*PrincipalInfo          `json:"new_contributors"`
+	FailedExecutions []DigestPipelineExecution `json:"failed_executions"`
+}
+
+// IsEmpty returns true if there's nothing to report for the repository.
+func (r *DigestRepo) IsEmpty() bool {
+	return r.Pushes == 0 &&
+		len(r.PullReqsOpened) == 0 &&
+		len(r.PullReqsMerged) == 0 &&
+		len(r.FailedExecutions) == 0
+}
+
+// DigestPullReq is a pull request listed in a digest.
+type DigestPullReq struct {
+	Number int64  `json:"number"`
+	URL    string `json:"url"`
+}
+
+// DigestPipelineExecution is a pipeline execution listed in a digest.
+type DigestPipelineExecution struct {
+	Pipeline string `json:"pipeline"`
+	Number   int64  `json:"number"`
+	URL      string `json:"url"`
+}
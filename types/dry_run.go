@@ -0,0 +1,32 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// AffectedResource is a resource that is changed by a destructive operation.
+type AffectedResource struct {
+	Type enum.AffectedResourceType `json:"type"`
+	ID   int64                     `json:"id"`
+	// Name is the path of a space or repository, or the key or value of a label.
+	Name string `json:"name"`
+}
+
+// DryRunOutput is returned by destructive operations that were requested with dry_run=true.
+// It contains the resources the operation would change, but nothing has been changed.
+type DryRunOutput struct {
+	DryRun   bool               `json:"dry_run"`
+	Affected []AffectedResource `json:"affected"`
+}
@@ -0,0 +1,25 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// EmailChange represents a pending change of the email address of a user.
+// Only the hash of the verification token is stored.
+type EmailChange struct {
+	PrincipalID int64  `db:"email_change_principal_id" json:"-"`
+	Email       string `db:"email_change_email"        json:"email"`
+	TokenHash   string `db:"email_change_token_hash"   json:"-"`
+	Expires     int64  `db:"email_change_expires"      json:"expires"`
+	Created     int64  `db:"email_change_created"      json:"created"`
+}
@@ -23,6 +23,10 @@ const (
 	CodeOwnerViolationCodePatternInvalid CodeOwnerViolationCode = "pattern_invalid"
 	// CodeOwnerViolationCodePatternEmpty occurs when a pattern in codeowners file is empty.
 	CodeOwnerViolationCodePatternEmpty CodeOwnerViolationCode = "pattern_empty"
+	// CodeOwnerViolationCodeSyntaxError occurs when a line in codeowners file can't be parsed.
+	CodeOwnerViolationCodeSyntaxError CodeOwnerViolationCode = "syntax_error"
+	// CodeOwnerViolationCodeUserGroupNotFound occurs when user group in codeowners file is not present.
+	CodeOwnerViolationCodeUserGroupNotFound CodeOwnerViolationCode = "user_group_not_found"
 )
 
 func (CodeOwnerViolationCode) Enum() []interface{} { return toInterfaceSlice(codeOwnerViolationCodes) }
@@ -31,4 +35,6 @@ var codeOwnerViolationCodes = sortEnum([]CodeOwnerViolationCode{
 	CodeOwnerViolationCodeUserNotFound,
 	CodeOwnerViolationCodePatternInvalid,
 	CodeOwnerViolationCodePatternEmpty,
+	CodeOwnerViolationCodeSyntaxError,
+	CodeOwnerViolationCodeUserGroupNotFound,
 })
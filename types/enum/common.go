@@ -51,6 +51,8 @@ const (
 	deleted       = "deleted"
 	deletedAt     = "deleted_at"
 	displayName   = "display_name"
+	lastActivity  = "last_activity"
+	stars         = "stars"
 	date          = "date"
 	defaultString = "default"
 	undefined     = "undefined"
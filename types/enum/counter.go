@@ -0,0 +1,45 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// CounterType defines a denormalized counter that can be reconciled with its source of truth.
+type CounterType string
+
+func (CounterType) Enum() []interface{} { return toInterfaceSlice(counterTypes) }
+func (t CounterType) Sanitize() (CounterType, bool) {
+	return Sanitize(t, GetAllCounterTypes)
+}
+func GetAllCounterTypes() ([]CounterType, CounterType) {
+	return counterTypes, ""
+}
+
+// CounterType enumeration.
+const (
+	// CounterTypeRepoStars is the number of stars of a repository.
+	CounterTypeRepoStars CounterType = "repo_stars"
+	// CounterTypeRepoPullReqs are the total, open, closed and merged pull request counters of a repository.
+	CounterTypeRepoPullReqs CounterType = "repo_pullreqs"
+	// CounterTypeRepoPushes are the rolling push counters (last 7 and 30 days) of a repository.
+	CounterTypeRepoPushes CounterType = "repo_pushes"
+	// CounterTypeRepoSize is the size of a repository as reported by git.
+	CounterTypeRepoSize CounterType = "repo_size"
+)
+
+var counterTypes = sortEnum([]CounterType{
+	CounterTypeRepoStars,
+	CounterTypeRepoPullReqs,
+	CounterTypeRepoPushes,
+	CounterTypeRepoSize,
+})
@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+import "time"
+
+// DigestFrequency defines how often an activity digest is sent.
+type DigestFrequency string
+
+func (DigestFrequency) Enum() []interface{} { return toInterfaceSlice(digestFrequencies) }
+func (f DigestFrequency) Sanitize() (DigestFrequency, bool) {
+	return Sanitize(f, GetAllDigestFrequencies)
+}
+func GetAllDigestFrequencies() ([]DigestFrequency, DigestFrequency) {
+	return digestFrequencies, DigestFrequencyWeekly
+}
+
+// DigestFrequency enumeration.
+const (
+	DigestFrequencyDaily  DigestFrequency = "daily"
+	DigestFrequencyWeekly DigestFrequency = "weekly"
+)
+
+var digestFrequencies = sortEnum([]DigestFrequency{
+	DigestFrequencyDaily,
+	DigestFrequencyWeekly,
+})
+
+// Period returns the duration covered by a single digest.
+func (f DigestFrequency) Period() time.Duration {
+	if f == DigestFrequencyDaily {
+		return 24 * time.Hour
+	}
+	return 7 * 24 * time.Hour
+}
@@ -114,3 +114,13 @@ func ParseGitServiceType(s string) (GitServiceType, error) {
 		return "", fmt.Errorf("unknown git service type provided: %q", s)
 	}
 }
+
+// PatchFormat defines the format of downloadable patch files.
+type PatchFormat string
+
+const (
+	// PatchFormatPatch is a mailbox of the commits, as produced by git format-patch.
+	PatchFormatPatch PatchFormat = "patch"
+	// PatchFormatDiff is the plain diff of the changes.
+	PatchFormatDiff PatchFormat = "diff"
+)
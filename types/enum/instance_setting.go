@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// InstanceSettingType defines the type of the value of an instance setting.
+type InstanceSettingType string
+
+func (InstanceSettingType) Enum() []interface{} { return toInterfaceSlice(instanceSettingTypes) }
+
+// InstanceSettingType enumeration.
+const (
+	// InstanceSettingTypeBool is a boolean value.
+	InstanceSettingTypeBool InstanceSettingType = "bool"
+	// InstanceSettingTypeInt is an integer value.
+	InstanceSettingTypeInt InstanceSettingType = "int"
+	// InstanceSettingTypeBytes is a byte size, provided as number of bytes or as string (e.g. "100MB").
+	InstanceSettingTypeBytes InstanceSettingType = "bytes"
+	// InstanceSettingTypeDuration is a duration, provided as string (e.g. "15m").
+	InstanceSettingTypeDuration InstanceSettingType = "duration"
+	// InstanceSettingTypeString is a text value.
+	InstanceSettingTypeString InstanceSettingType = "string"
+)
+
+var instanceSettingTypes = sortEnum([]InstanceSettingType{
+	InstanceSettingTypeBool,
+	InstanceSettingTypeInt,
+	InstanceSettingTypeBytes,
+	InstanceSettingTypeDuration,
+	InstanceSettingTypeString,
+})
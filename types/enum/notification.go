@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// NotificationType defines the type of an in-app notification.
+type NotificationType string
+
+func (NotificationType) Enum() []interface{} { return toInterfaceSlice(notificationTypes) }
+func (t NotificationType) Sanitize() (NotificationType, bool) {
+	return Sanitize(t, GetAllNotificationTypes)
+}
+func GetAllNotificationTypes() ([]NotificationType, NotificationType) {
+	return notificationTypes, ""
+}
+
+// NotificationType enumeration.
+const (
+	NotificationTypePullReqCreated         NotificationType = "pullreq_created"
+	NotificationTypePullReqMerged          NotificationType = "pullreq_merged"
+	NotificationTypePullReqClosed          NotificationType = "pullreq_closed"
+	NotificationTypePullReqReopened        NotificationType = "pullreq_reopened"
+	NotificationTypePullReqReviewSubmitted NotificationType = "pullreq_review_submitted"
+	NotificationTypeExecutionFailed        NotificationType = "execution_failed"
+)
+
+var notificationTypes = sortEnum([]NotificationType{
+	NotificationTypePullReqCreated,
+	NotificationTypePullReqMerged,
+	NotificationTypePullReqClosed,
+	NotificationTypePullReqReopened,
+	NotificationTypePullReqReviewSubmitted,
+	NotificationTypeExecutionFailed,
+})
+
+// SubscriptionResourceType defines the type of resource a notification subscription refers to.
+type SubscriptionResourceType string
+
+func (SubscriptionResourceType) Enum() []interface{} {
+	return toInterfaceSlice(subscriptionResourceTypes)
+}
+func (t SubscriptionResourceType) Sanitize() (SubscriptionResourceType, bool) {
+	return Sanitize(t, GetAllSubscriptionResourceTypes)
+}
+func GetAllSubscriptionResourceTypes() ([]SubscriptionResourceType, SubscriptionResourceType) {
+	return subscriptionResourceTypes, ""
+}
+
+// SubscriptionResourceType enumeration.
+const (
+	SubscriptionResourceTypeRepo    SubscriptionResourceType = "repo"
+	SubscriptionResourceTypePullReq SubscriptionResourceType = "pullreq"
+)
+
+var subscriptionResourceTypes = sortEnum([]SubscriptionResourceType{
+	SubscriptionResourceTypeRepo,
+	SubscriptionResourceTypePullReq,
+})
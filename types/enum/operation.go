@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// OperationType defines the type of long-running operation.
+type OperationType string
+
+func (OperationType) Enum() []interface{} { return toInterfaceSlice(operationTypes) }
+func (t OperationType) Sanitize() (OperationType, bool) {
+	return Sanitize(t, GetAllOperationTypes)
+}
+func GetAllOperationTypes() ([]OperationType, OperationType) {
+	return operationTypes, ""
+}
+
+// OperationType enumeration.
+const (
+	OperationTypeRepoImport         OperationType = "repo_import"
+	OperationTypeSpaceExport        OperationType = "space_export"
+	OperationTypeSpaceArchiveExport OperationType = "space_archive_export"
+)
+
+var operationTypes = sortEnum([]OperationType{
+	OperationTypeRepoImport,
+	OperationTypeSpaceExport,
+	OperationTypeSpaceArchiveExport,
+})
+
+// OperationState defines the state of a long-running operation.
+type OperationState string
+
+func (OperationState) Enum() []interface{} { return toInterfaceSlice(operationStates) }
+func (s OperationState) Sanitize() (OperationState, bool) {
+	return Sanitize(s, GetAllOperationStates)
+}
+func GetAllOperationStates() ([]OperationState, OperationState) {
+	return operationStates, ""
+}
+
+// OperationState enumeration.
+const (
+	OperationStatePending   OperationState = "pending"
+	OperationStateRunning   OperationState = "running"
+	OperationStateSucceeded OperationState = "succeeded"
+	OperationStateFailed    OperationState = "failed"
+	OperationStateCancelled OperationState = "cancelled"
+)
+
+var operationStates = sortEnum([]OperationState{
+	OperationStatePending,
+	OperationStateRunning,
+	OperationStateSucceeded,
+	OperationStateFailed,
+	OperationStateCancelled,
+})
+
+// IsCompleted returns true if the operation won't change its state anymore.
+func (s OperationState) IsCompleted() bool {
+	return s == OperationStateSucceeded || s == OperationStateFailed || s == OperationStateCancelled
+}
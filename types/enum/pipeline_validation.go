@@ -0,0 +1,41 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// PipelineProblemSeverity defines how severe a problem found while validating a pipeline yaml is.
+type PipelineProblemSeverity string
+
+func (PipelineProblemSeverity) Enum() []interface{} {
+	return toInterfaceSlice(pipelineProblemSeverities)
+}
+func (s PipelineProblemSeverity) Sanitize() (PipelineProblemSeverity, bool) {
+	return Sanitize(s, GetAllPipelineProblemSeverities)
+}
+func GetAllPipelineProblemSeverities() ([]PipelineProblemSeverity, PipelineProblemSeverity) {
+	return pipelineProblemSeverities, PipelineProblemSeverityError
+}
+
+// PipelineProblemSeverity enumeration.
+const (
+	// PipelineProblemSeverityError marks problems that prevent the pipeline from being executed.
+	PipelineProblemSeverityError PipelineProblemSeverity = "error"
+	// PipelineProblemSeverityWarning marks problems that are likely mistakes but don't prevent execution.
+	PipelineProblemSeverityWarning PipelineProblemSeverity = "warning"
+)
+
+var pipelineProblemSeverities = sortEnum([]PipelineProblemSeverity{
+	PipelineProblemSeverityError,
+	PipelineProblemSeverityWarning,
+})
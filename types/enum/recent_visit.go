@@ -0,0 +1,37 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// RecentVisitType defines the type of resource a recent visit refers to.
+type RecentVisitType string
+
+func (RecentVisitType) Enum() []interface{} { return toInterfaceSlice(recentVisitTypes) }
+func (t RecentVisitType) Sanitize() (RecentVisitType, bool) {
+	return Sanitize(t, GetAllRecentVisitTypes)
+}
+func GetAllRecentVisitTypes() ([]RecentVisitType, RecentVisitType) {
+	return recentVisitTypes, ""
+}
+
+// RecentVisitType enumeration.
+const (
+	RecentVisitTypeRepo  RecentVisitType = "repo"
+	RecentVisitTypeSpace RecentVisitType = "space"
+)
+
+var recentVisitTypes = sortEnum([]RecentVisitType{
+	RecentVisitTypeRepo,
+	RecentVisitTypeSpace,
+})
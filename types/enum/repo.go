@@ -30,6 +30,8 @@ const (
 	RepoAttrCreated
 	RepoAttrUpdated
 	RepoAttrDeleted
+	RepoAttrLastActivity
+	RepoAttrStars
 )
 
 // ParseRepoAttr parses the repo attribute string
@@ -47,6 +49,10 @@ func ParseRepoAttr(s string) RepoAttr {
 		return RepoAttrUpdated
 	case deleted, deletedAt:
 		return RepoAttrDeleted
+	case lastActivity:
+		return RepoAttrLastActivity
+	case stars:
+		return RepoAttrStars
 	default:
 		return RepoAttrNone
 	}
@@ -66,6 +72,10 @@ func (a RepoAttr) String() string {
 		return updated
 	case RepoAttrDeleted:
 		return deleted
+	case RepoAttrLastActivity:
+		return lastActivity
+	case RepoAttrStars:
+		return stars
 	case RepoAttrNone:
 		return ""
 	default:
@@ -74,16 +84,44 @@ func (a RepoAttr) String() string {
 }
 
 // RepoState defines repo state.
+//
+// The state determines which operations are allowed on the repository:
+//   - active: all operations.
+//   - git-import: none, the repository is being imported by the system.
+//   - migrate-git-push: git access only, used for pushing the git data of a migrated repository.
+//   - migrate-data-import: git access only, the metadata of a migrated repository is being imported.
+//   - maintenance: reads and git fetches, the repository is temporarily read-only (e.g. during GC).
+//   - locked: reads and git fetches, the repository was made read-only by an administrator.
+//   - quarantined: none, the repository was flagged by the integrity checker.
+//   - purging: none, the repository is being removed permanently.
+//
+// Git pushes are additionally restricted by the pre-receive hook.
 type RepoState int
 
 // RepoState enumeration.
+// NOTE: the values are stored in the DB, new states have to be appended.
 const (
 	RepoStateActive RepoState = iota
 	RepoStateGitImport
 	RepoStateMigrateGitPush
 	RepoStateMigrateDataImport
+	RepoStateMaintenance
+	RepoStateLocked
+	RepoStateQuarantined
+	RepoStatePurging
 )
 
+var repoStates = []RepoState{
+	RepoStateActive,
+	RepoStateGitImport,
+	RepoStateMigrateGitPush,
+	RepoStateMigrateDataImport,
+	RepoStateMaintenance,
+	RepoStateLocked,
+	RepoStateQuarantined,
+	RepoStatePurging,
+}
+
 // String returns the string representation of the RepoState.
 func (state RepoState) String() string {
 	switch state {
@@ -95,7 +133,112 @@ func (state RepoState) String() string {
 		return "migrate-git-push"
 	case RepoStateMigrateDataImport:
 		return "migrate-data-import"
+	case RepoStateMaintenance:
+		return "maintenance"
+	case RepoStateLocked:
+		return "locked"
+	case RepoStateQuarantined:
+		return "quarantined"
+	case RepoStatePurging:
+		return "purging"
 	default:
 		return undefined
 	}
 }
+
+// ParseRepoState parses the string representation of the RepoState.
+func ParseRepoState(s string) (RepoState, bool) {
+	for _, state := range repoStates {
+		if state.String() == s {
+			return state, true
+		}
+	}
+
+	return RepoStateActive, false
+}
+
+// IsImporting returns true if the repository is being imported or migrated.
+func (state RepoState) IsImporting() bool {
+	return state == RepoStateGitImport ||
+		state == RepoStateMigrateGitPush ||
+		state == RepoStateMigrateDataImport
+}
+
+// RepoOperation defines a kind of operation performed on a repository.
+type RepoOperation int
+
+// RepoOperation enumeration.
+const (
+	// RepoOperationRead covers reading the repository content and metadata via the API.
+	RepoOperationRead RepoOperation = iota
+	// RepoOperationWrite covers all changes of the repository content and metadata via the API.
+	RepoOperationWrite
+	// RepoOperationGit covers access to the repository via the git protocols.
+	RepoOperationGit
+)
+
+// Allows returns true if the operation is allowed on a repository in the state.
+func (state RepoState) Allows(op RepoOperation) bool {
+	switch state {
+	case RepoStateActive:
+		return true
+	case RepoStateMigrateGitPush, RepoStateMigrateDataImport:
+		return op == RepoOperationGit
+	case RepoStateMaintenance, RepoStateLocked:
+		return op == RepoOperationRead || op == RepoOperationGit
+	case RepoStateGitImport, RepoStateQuarantined, RepoStatePurging:
+		return false
+	default:
+		return false
+	}
+}
+
+// RepoStatesAllowing returns all states in which the operation is allowed.
+func RepoStatesAllowing(op RepoOperation) []RepoState {
+	states := make([]RepoState, 0, len(repoStates))
+	for _, state := range repoStates {
+		if state.Allows(op) {
+			states = append(states, state)
+		}
+	}
+
+	return states
+}
+
+// repoStateTransitions contains all allowed state transitions.
+// Any state can transition to purging, purging is final.
+var repoStateTransitions = map[RepoState][]RepoState{
+	RepoStateActive: {
+		RepoStateMigrateDataImport, RepoStateMaintenance, RepoStateLocked, RepoStateQuarantined},
+	RepoStateGitImport: {
+		RepoStateActive},
+	RepoStateMigrateGitPush: {
+		RepoStateActive, RepoStateMigrateDataImport},
+	RepoStateMigrateDataImport: {
+		RepoStateActive},
+	RepoStateMaintenance: {
+		RepoStateActive, RepoStateLocked, RepoStateQuarantined},
+	RepoStateLocked: {
+		RepoStateActive, RepoStateMaintenance, RepoStateQuarantined},
+	RepoStateQuarantined: {
+		RepoStateActive, RepoStateLocked},
+}
+
+// CanTransitionTo returns true if a repository in the state is allowed to move to the provided state.
+func (state RepoState) CanTransitionTo(to RepoState) bool {
+	if state == RepoStatePurging {
+		return false
+	}
+
+	if to == RepoStatePurging {
+		return true
+	}
+
+	for _, allowed := range repoStateTransitions[state] {
+		if allowed == to {
+			return true
+		}
+	}
+
+	return false
+}
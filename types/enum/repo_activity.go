@@ -0,0 +1,43 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// RepoActivityType defines the type of repository activity.
+type RepoActivityType string
+
+func (RepoActivityType) Enum() []interface{} { return toInterfaceSlice(repoActivityTypes) }
+func (t RepoActivityType) Sanitize() (RepoActivityType, bool) {
+	return Sanitize(t, GetAllRepoActivityTypes)
+}
+func GetAllRepoActivityTypes() ([]RepoActivityType, RepoActivityType) {
+	return repoActivityTypes, ""
+}
+
+// RepoActivityType enumeration.
+const (
+	RepoActivityTypePush              RepoActivityType = "push"
+	RepoActivityTypePullReqOpened     RepoActivityType = "pullreq_opened"
+	RepoActivityTypePullReqMerged     RepoActivityType = "pullreq_merged"
+	RepoActivityTypePipelineCompleted RepoActivityType = "pipeline_completed"
+	RepoActivityTypeDefaultBranch     RepoActivityType = "default_branch_updated"
+)
+
+var repoActivityTypes = sortEnum([]RepoActivityType{
+	RepoActivityTypePush,
+	RepoActivityTypePullReqOpened,
+	RepoActivityTypePullReqMerged,
+	RepoActivityTypePipelineCompleted,
+	RepoActivityTypeDefaultBranch,
+})
@@ -0,0 +1,53 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// RepoStatsWindow defines the time window over which repository statistics are aggregated.
+type RepoStatsWindow string
+
+func (RepoStatsWindow) Enum() []interface{} { return toInterfaceSlice(repoStatsWindows) }
+func (w RepoStatsWindow) Sanitize() (RepoStatsWindow, bool) {
+	return Sanitize(w, GetAllRepoStatsWindows)
+}
+func GetAllRepoStatsWindows() ([]RepoStatsWindow, RepoStatsWindow) {
+	return repoStatsWindows, RepoStatsWindow30Days
+}
+
+// Days returns the number of days covered by the window.
+func (w RepoStatsWindow) Days() int {
+	switch w {
+	case RepoStatsWindow7Days:
+		return 7
+	case RepoStatsWindow30Days:
+		return 30
+	case RepoStatsWindow90Days:
+		return 90
+	default:
+		return 0
+	}
+}
+
+// RepoStatsWindow enumeration.
+const (
+	RepoStatsWindow7Days  RepoStatsWindow = "7d"
+	RepoStatsWindow30Days RepoStatsWindow = "30d"
+	RepoStatsWindow90Days RepoStatsWindow = "90d"
+)
+
+var repoStatsWindows = sortEnum([]RepoStatsWindow{
+	RepoStatsWindow7Days,
+	RepoStatsWindow30Days,
+	RepoStatsWindow90Days,
+})
@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+import "testing"
+
+func TestRepoStateCanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from RepoState
+		to   RepoState
+		want bool
+	}{
+		{RepoStateActive, RepoStateMaintenance, true},
+		{RepoStateActive, RepoStateLocked, true},
+		{RepoStateActive, RepoStateQuarantined, true},
+		{RepoStateActive, RepoStatePurging, true},
+		{RepoStateGitImport, RepoStateActive, true},
+		{RepoStateGitImport, RepoStatePurging, true},
+		{RepoStateMigrateGitPush, RepoStateMigrateDataImport, true},
+		{RepoStateMaintenance, RepoStateActive, true},
+		{RepoStateQuarantined, RepoStateActive, true},
+
+		// illegal transitions
+		{RepoStateActive, RepoStateActive, false},
+		{RepoStateActive, RepoStateGitImport, false},
+		{RepoStateActive, RepoStateMigrateGitPush, false},
+		{RepoStateGitImport, RepoStateMaintenance, false},
+		{RepoStateGitImport, RepoStateLocked, false},
+		{RepoStateMigrateDataImport, RepoStateMigrateGitPush, false},
+		{RepoStateQuarantined, RepoStateMaintenance, false},
+		{RepoStatePurging, RepoStateActive, false},
+		{RepoStatePurging, RepoStatePurging, false},
+		{RepoState(-1), RepoStateActive, false},
+	}
+
+	for _, test := range tests {
+		if got := test.from.CanTransitionTo(test.to); got != test.want {
+			t.Errorf("transition from %q to %q: want %t, got %t", test.from, test.to, test.want, got)
+		}
+	}
+}
+
+func TestRepoStateAllows(t *testing.T) {
+	tests := []struct {
+		state RepoState
+		read  bool
+		write bool
+		git   bool
+	}{
+		{RepoStateActive, true, true, true},
+		{RepoStateGitImport, false, false, false},
+		{RepoStateMigrateGitPush, false, false, true},
+		{RepoStateMigrateDataImport, false, false, true},
+		{RepoStateMaintenance, true, false, true},
+		{RepoStateLocked, true, false, true},
+		{RepoStateQuarantined, false, false, false},
+		{RepoStatePurging, false, false, false},
+	}
+
+	for _, test := range tests {
+		if got := test.state.Allows(RepoOperationRead); got != test.read {
+			t.Errorf("state %q allows read: want %t, got %t", test.state, test.read, got)
+		}
+		if got := test.state.Allows(RepoOperationWrite); got != test.write {
+			t.Errorf("state %q allows write: want %t, got %t", test.state, test.write, got)
+		}
+		if got := test.state.Allows(RepoOperationGit); got != test.git {
+			t.Errorf("state %q allows git: want %t, got %t", test.state, test.git, got)
+		}
+	}
+}
+
+func TestParseRepoState(t *testing.T) {
+	for _, state := range repoStates {
+		got, ok := ParseRepoState(state.String())
+		if !ok || got != state {
+			t.Errorf("parse %q: want %d, got %d (ok=%t)", state, state, got, ok)
+		}
+	}
+
+	if _, ok := ParseRepoState("unknown"); ok {
+		t.Errorf("parse of unknown state should fail")
+	}
+}
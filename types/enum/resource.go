@@ -32,3 +32,26 @@ func GetAllParentResourceTypes() []ParentResourceType {
 		ParentResourceTypeRepo,
 	}
 }
+
+// AffectedResourceType defines the type of a resource affected by a destructive operation.
+type AffectedResourceType string
+
+func (AffectedResourceType) Enum() []interface{} {
+	return toInterfaceSlice(GetAllAffectedResourceTypes())
+}
+
+const (
+	AffectedResourceTypeSpace      AffectedResourceType = "space"
+	AffectedResourceTypeRepo       AffectedResourceType = "repository"
+	AffectedResourceTypeLabel      AffectedResourceType = "label"
+	AffectedResourceTypeLabelValue AffectedResourceType = "label_value"
+)
+
+func GetAllAffectedResourceTypes() []AffectedResourceType {
+	return []AffectedResourceType{
+		AffectedResourceTypeSpace,
+		AffectedResourceTypeRepo,
+		AffectedResourceTypeLabel,
+		AffectedResourceTypeLabelValue,
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// RunnerStalePolicy defines what happens to stages assigned to a runner that went offline.
+type RunnerStalePolicy string
+
+func (RunnerStalePolicy) Enum() []interface{} { return toInterfaceSlice(runnerStalePolicies) }
+func (p RunnerStalePolicy) Sanitize() (RunnerStalePolicy, bool) {
+	return Sanitize(p, GetAllRunnerStalePolicies)
+}
+func GetAllRunnerStalePolicies() ([]RunnerStalePolicy, RunnerStalePolicy) {
+	return runnerStalePolicies, RunnerStalePolicyRequeue
+}
+
+// RunnerStalePolicy enumeration.
+const (
+	// RunnerStalePolicyRequeue puts the stage back into the queue so another runner picks it up.
+	RunnerStalePolicyRequeue RunnerStalePolicy = "requeue"
+	// RunnerStalePolicyFail fails the execution of the stage.
+	RunnerStalePolicyFail RunnerStalePolicy = "fail"
+)
+
+var runnerStalePolicies = sortEnum([]RunnerStalePolicy{
+	RunnerStalePolicyRequeue,
+	RunnerStalePolicyFail,
+})
@@ -27,11 +27,16 @@ var (
 
 	// SettingsScopeRepo defines settings stored on a repo level.
 	SettingsScopeRepo SettingsScope = "repo"
+
+	// SettingsScopeSystem defines settings stored on the instance level.
+	// The scope ID is ignored for system settings.
+	SettingsScopeSystem SettingsScope = "system"
 )
 
 func GetAllSettingsScopes() []SettingsScope {
 	return []SettingsScope{
 		SettingsScopeSpace,
 		SettingsScopeRepo,
+		SettingsScopeSystem,
 	}
 }
@@ -24,10 +24,18 @@ const (
 	SSETypeExecutionCompleted SSEType = "execution_completed"
 	SSETypeExecutionCanceled  SSEType = "execution_canceled"
 
+	SSETypeStageApprovalRequested SSEType = "stage_approval_requested"
+	SSETypeStageApprovalDecided   SSEType = "stage_approval_decided"
+
 	SSETypeRepositoryImportCompleted SSEType = "repository_import_completed"
 	SSETypeRepositoryExportCompleted SSEType = "repository_export_completed"
 
 	SSETypePullRequestUpdated SSEType = "pullreq_updated"
 
 	SSETypeLogLineAppended SSEType = "log_line_appended"
+
+	SSETypeRepositoryCreated     SSEType = "repository_created"
+	SSETypeRepositoryUpdated     SSEType = "repository_updated"
+	SSETypeExecutionStateChanged SSEType = "execution_state_changed"
+	SSETypePullReqChanged        SSEType = "pullreq_changed"
 )
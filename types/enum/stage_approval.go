@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// StageApprovalDecision defines the outcome of a stage approval gate.
+type StageApprovalDecision string
+
+func (StageApprovalDecision) Enum() []interface{} { return toInterfaceSlice(stageApprovalDecisions) }
+func (d StageApprovalDecision) Sanitize() (StageApprovalDecision, bool) {
+	return Sanitize(d, GetAllStageApprovalDecisions)
+}
+func GetAllStageApprovalDecisions() ([]StageApprovalDecision, StageApprovalDecision) {
+	return stageApprovalDecisions, ""
+}
+
+// StageApprovalDecision enumeration.
+const (
+	StageApprovalDecisionApproved StageApprovalDecision = "approved"
+	StageApprovalDecisionRejected StageApprovalDecision = "rejected"
+	StageApprovalDecisionTimedOut StageApprovalDecision = "timed_out"
+)
+
+var stageApprovalDecisions = sortEnum([]StageApprovalDecision{
+	StageApprovalDecisionApproved,
+	StageApprovalDecisionRejected,
+	StageApprovalDecisionTimedOut,
+})
+
+// StageApprovalTimeoutAction defines what happens to a stage if its approval gate times out.
+type StageApprovalTimeoutAction string
+
+func (StageApprovalTimeoutAction) Enum() []interface{} {
+	return toInterfaceSlice(stageApprovalTimeoutActions)
+}
+func (a StageApprovalTimeoutAction) Sanitize() (StageApprovalTimeoutAction, bool) {
+	return Sanitize(a, GetAllStageApprovalTimeoutActions)
+}
+func GetAllStageApprovalTimeoutActions() ([]StageApprovalTimeoutAction, StageApprovalTimeoutAction) {
+	return stageApprovalTimeoutActions, StageApprovalTimeoutActionFail
+}
+
+// StageApprovalTimeoutAction enumeration.
+const (
+	StageApprovalTimeoutActionFail StageApprovalTimeoutAction = "fail"
+	StageApprovalTimeoutActionSkip StageApprovalTimeoutAction = "skip"
+)
+
+var stageApprovalTimeoutActions = sortEnum([]StageApprovalTimeoutAction{
+	StageApprovalTimeoutActionFail,
+	StageApprovalTimeoutActionSkip,
+})
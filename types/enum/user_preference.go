@@ -0,0 +1,37 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// UserPreferenceView defines the list view a user preference is stored for.
+type UserPreferenceView string
+
+func (UserPreferenceView) Enum() []interface{} { return toInterfaceSlice(userPreferenceViews) }
+func (v UserPreferenceView) Sanitize() (UserPreferenceView, bool) {
+	return Sanitize(v, GetAllUserPreferenceViews)
+}
+func GetAllUserPreferenceViews() ([]UserPreferenceView, UserPreferenceView) {
+	return userPreferenceViews, ""
+}
+
+// UserPreferenceView enumeration.
+const (
+	UserPreferenceViewRepoList    UserPreferenceView = "repo_list"
+	UserPreferenceViewPullReqList UserPreferenceView = "pullreq_list"
+)
+
+var userPreferenceViews = sortEnum([]UserPreferenceView{
+	UserPreferenceViewRepoList,
+	UserPreferenceViewPullReqList,
+})
@@ -160,6 +160,13 @@ const (
 	WebhookTriggerPullReqMerged WebhookTrigger = "pullreq_merged"
 	// WebhookTriggerPullReqUpdated gets triggered when a pull request gets updated.
 	WebhookTriggerPullReqUpdated WebhookTrigger = "pullreq_updated"
+
+	// WebhookTriggerExecutionStarted gets triggered when a pipeline execution starts running.
+	WebhookTriggerExecutionStarted WebhookTrigger = "execution_started"
+	// WebhookTriggerExecutionCompleted gets triggered when a pipeline execution reaches its final status.
+	WebhookTriggerExecutionCompleted WebhookTrigger = "execution_completed"
+	// WebhookTriggerExecutionFailed gets triggered when a pipeline execution fails, errors or gets killed.
+	WebhookTriggerExecutionFailed WebhookTrigger = "execution_failed"
 )
 
 var webhookTriggers = sortEnum([]WebhookTrigger{
@@ -176,4 +183,22 @@ var webhookTriggers = sortEnum([]WebhookTrigger{
 	WebhookTriggerPullReqClosed,
 	WebhookTriggerPullReqCommentCreated,
 	WebhookTriggerPullReqMerged,
+	WebhookTriggerExecutionStarted,
+	WebhookTriggerExecutionCompleted,
+	WebhookTriggerExecutionFailed,
 })
+
+// IsOptIn returns true if the trigger is only sent to webhooks that explicitly registered for it.
+// Webhooks without any triggers are registered for all triggers that aren't opt-in,
+// which keeps the deliveries of existing webhooks unchanged when new kinds of triggers are added.
+func (s WebhookTrigger) IsOptIn() bool {
+	//nolint:exhaustive
+	switch s {
+	case WebhookTriggerExecutionStarted,
+		WebhookTriggerExecutionCompleted,
+		WebhookTriggerExecutionFailed:
+		return true
+	default:
+		return false
+	}
+}
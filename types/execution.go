@@ -14,7 +14,10 @@
 
 package types
 
-import "github.com/harness/gitness/types/enum"
+import (
+	"github.com/harness/gitness/types/enum"
+	"github.com/harness/gitness/types/listfilter"
+)
 
 // Execution represents an instance of a pipeline execution.
 type Execution struct {
@@ -49,10 +52,25 @@ type Execution struct {
 	Deploy       string             `json:"deploy_to,omitempty"`
 	DeployID     int64              `json:"deploy_id,omitempty"`
 	Debug        bool               `json:"debug,omitempty"`
-	Started      int64              `json:"started,omitempty"`
-	Finished     int64              `json:"finished,omitempty"`
-	Created      int64              `json:"created"`
-	Updated      int64              `json:"updated"`
-	Version      int64              `json:"-"`
-	Stages       []*Stage           `json:"stages,omitempty"`
+	RetriedFrom  int64              `json:"retried_from,omitempty"`
+	Timeout      int64              `json:"timeout,omitempty"`
+	// QueueReason explains why a pending execution is not yet being scheduled.
+	QueueReason string `json:"queue_reason,omitempty"`
+	// SupersededBy is the number of the newer execution that canceled this one.
+	SupersededBy int64    `json:"superseded_by,omitempty"`
+	Started      int64    `json:"started,omitempty"`
+	Finished     int64    `json:"finished,omitempty"`
+	Created      int64    `json:"created"`
+	Updated      int64    `json:"updated"`
+	Version      int64    `json:"-"`
+	Stages       []*Stage `json:"stages,omitempty"`
+	// Matrices groups the stages expanded from a matrix, it's only populated for the execution details.
+	Matrices []*ExecutionMatrix `json:"matrices,omitempty"`
 }
+
+// Execution fields supported by list filters.
+const (
+	ExecutionFieldNumber  listfilter.Field = "number"
+	ExecutionFieldCreated listfilter.Field = "created"
+	ExecutionFieldStatus  listfilter.Field = "status"
+)
@@ -0,0 +1,40 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// FeatureFlag describes a feature flag and its state.
+type FeatureFlag struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Default     bool   `json:"default"`
+
+	// Enabled is the instance-wide state of the flag, which applies to all spaces without an override.
+	Enabled    bool `json:"enabled"`
+	Overridden bool `json:"overridden"`
+
+	SpaceOverrides []*FeatureFlagOverride `json:"space_overrides"`
+}
+
+// FeatureFlagOverride is the state of a feature flag set at runtime, either instance-wide or for a space.
+// The override of a space applies to all its subspaces and repositories, unless they have an override themselves.
+type FeatureFlagOverride struct {
+	ID        int64  `db:"feature_flag_id"         json:"-"`
+	Name      string `db:"feature_flag_name"       json:"-"`
+	SpaceID   *int64 `db:"feature_flag_space_id"   json:"space_id,omitempty"`
+	SpacePath string `db:"-"                       json:"space_path,omitempty"`
+	Enabled   bool   `db:"feature_flag_enabled"    json:"enabled"`
+	UpdatedBy int64  `db:"feature_flag_updated_by" json:"updated_by"`
+	Updated   int64  `db:"feature_flag_updated"    json:"updated"`
+}
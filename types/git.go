@@ -38,6 +38,12 @@ type CommitFilter struct {
 	Until        int64  `json:"until"`
 	Committer    string `json:"committer"`
 	IncludeStats bool   `json:"include_stats"`
+	IncludeNotes bool   `json:"include_notes"`
+	NotesRef     string `json:"notes_ref"`
+
+	// Follow lists the history of the file at Path across renames.
+	// Pagination is done with the After commit SHA cursor instead of pages.
+	Follow bool `json:"follow"`
 }
 
 // BranchFilter stores branch query parameters.
@@ -49,6 +55,15 @@ type BranchFilter struct {
 	Size  int                   `json:"size"`
 }
 
+// StaleBranchFilter stores stale branch query parameters.
+type StaleBranchFilter struct {
+	Query string `json:"query"`
+	// InactiveDays is the number of days without commits after which a branch is considered inactive.
+	InactiveDays int `json:"inactive_days"`
+	Page         int `json:"page"`
+	Size         int `json:"size"`
+}
+
 // TagFilter stores commit tag query parameters.
 type TagFilter struct {
 	Query string             `json:"query"`
@@ -84,6 +99,10 @@ type Commit struct {
 	Author     Signature    `json:"author"`
 	Committer  Signature    `json:"committer"`
 	Stats      *CommitStats `json:"stats,omitempty"`
+	Note       *string      `json:"note,omitempty"`
+
+	// Path is the path the file had at the commit, only set when the history of a file is listed following renames.
+	Path string `json:"path,omitempty"`
 }
 
 type Signature struct {
@@ -107,4 +126,7 @@ type ListCommitResponse struct {
 	Commits       []Commit        `json:"commits"`
 	RenameDetails []RenameDetails `json:"rename_details"`
 	TotalCommits  int             `json:"total_commits,omitempty"`
+
+	// NextAfter is the cursor for the next page when following renames, empty if there are no more commits.
+	NextAfter string `json:"next_after,omitempty"`
 }
@@ -0,0 +1,37 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// GitOperationLimit overrides the maximum number of concurrent git operations (clone, fetch, push) of a principal.
+type GitOperationLimit struct {
+	PrincipalID int64          `db:"git_operation_limit_principal_id"   json:"principal_id"`
+	Principal   *PrincipalInfo `db:"-"                                  json:"principal,omitempty"`
+	// MaxConcurrent is the maximum number of concurrent git operations, zero means unlimited.
+	MaxConcurrent int   `db:"git_operation_limit_max_concurrent" json:"max_concurrent"`
+	UpdatedBy     int64 `db:"git_operation_limit_updated_by"     json:"updated_by"`
+	Updated       int64 `db:"git_operation_limit_updated"        json:"updated"`
+}
+
+// GitOperationsInFlight describes the git operations currently executed for a principal
+// or, in case of anonymous access, for a client IP.
+type GitOperationsInFlight struct {
+	Principal *PrincipalInfo `json:"principal,omitempty"`
+	ClientIP  string         `json:"client_ip,omitempty"`
+	InFlight  int            `json:"in_flight"`
+	// Waiting is the number of operations waiting for one of the in-flight operations to complete.
+	Waiting int `json:"waiting"`
+	// Limit is the maximum number of concurrent git operations, zero means unlimited.
+	Limit int `json:"limit"`
+}
@@ -0,0 +1,34 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// IdempotencyKey represents a key provided by a principal to safely retry a request.
+// The key stores the fingerprint of the request and, once the request completed, the produced response.
+type IdempotencyKey struct {
+	PrincipalID         int64  `db:"idempotency_key_principal_id"`
+	Key                 string `db:"idempotency_key_key"`
+	Fingerprint         string `db:"idempotency_key_fingerprint"`
+	ResponseStatus      int    `db:"idempotency_key_response_status"`
+	ResponseContentType string `db:"idempotency_key_response_content_type"`
+	ResponseBody        []byte `db:"idempotency_key_response_body"`
+	Created             int64  `db:"idempotency_key_created"`
+	Expires             int64  `db:"idempotency_key_expires"`
+}
+
+// IsCompleted returns true if the response of the request is stored with the key.
+// A key without a response is still reserved by the request that is in progress.
+func (k *IdempotencyKey) IsCompleted() bool {
+	return k.ResponseStatus != 0
+}
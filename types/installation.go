@@ -0,0 +1,35 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// Installation is the record of the installation of the instance.
+type Installation struct {
+	// UUID uniquely identifies the instance, independent of its hostname or any of its users.
+	UUID      string `db:"installation_uuid"      json:"uuid"`
+	Installed int64  `db:"installation_installed" json:"installed"`
+
+	// InstalledBy is the id of the admin that installed the instance, nil until the first user was created.
+	InstalledBy    *int64 `db:"installation_installed_by"    json:"installed_by,omitempty"`
+	InstallerEmail string `db:"installation_installer_email" json:"installer_email,omitempty"`
+}
+
+// SystemInfo describes the running instance.
+type SystemInfo struct {
+	Installation   *Installation   `json:"installation"`
+	Version        string          `json:"version"`
+	DatabaseDriver string          `json:"database_driver"`
+	Features       map[string]bool `json:"features"`
+	FeatureFlags   []*FeatureFlag  `json:"feature_flags"`
+}
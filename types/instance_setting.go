@@ -0,0 +1,28 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// InstanceSetting is an instance-level setting that can be changed by admins at runtime.
+// The environment configuration provides the default value, which is overridden by a value set via the API.
+type InstanceSetting struct {
+	Key         string                   `json:"key"`
+	Type        enum.InstanceSettingType `json:"type"`
+	Description string                   `json:"description"`
+	Value       any                      `json:"value"`
+	Default     any                      `json:"default"`
+	Overridden  bool                     `json:"overridden"`
+}
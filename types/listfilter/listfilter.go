@@ -0,0 +1,97 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package listfilter provides a declarative description of the parameters a list endpoint accepts
+// and the typed filter parsed from them, shared between the API handlers and the stores.
+package listfilter
+
+import (
+	"slices"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// Field identifies an attribute of a listed resource that can be sorted, filtered or searched on.
+// Fields are mapped to columns by the stores, they are never used in queries directly.
+type Field string
+
+// FieldID is the unique ID of a listed resource. It is always the last sort term,
+// which guarantees a deterministic order across pages.
+const FieldID Field = "id"
+
+// EnumField is a field that can be filtered on a fixed set of values.
+type EnumField struct {
+	Field  Field
+	Values []string
+}
+
+// Spec declares the sort, enum and search fields a list endpoint supports.
+type Spec struct {
+	// Sort maps the accepted values of the sort parameter (lower case) to fields.
+	Sort map[string]Field
+
+	// DefaultSort is used if the sort parameter is missing or not supported.
+	DefaultSort Field
+
+	// DefaultOrder is used if the order parameter is missing or not supported.
+	DefaultOrder enum.Order
+
+	// Enums maps query parameters to the enum fields they filter on.
+	Enums map[string]EnumField
+
+	// Search contains the fields that are matched against the query parameter.
+	Search []Field
+
+	// Unpaginated lists all matching entries, the page and limit parameters are ignored.
+	Unpaginated bool
+}
+
+// SortTerm is a single term of the order of a list.
+type SortTerm struct {
+	Field Field
+	Order enum.Order
+}
+
+// Filter is the typed list filter parsed according to a Spec.
+type Filter struct {
+	// Page and Size are ignored if the list is Unpaginated.
+	Page        int
+	Size        int
+	Unpaginated bool
+
+	// Query is matched case-insensitively against the Search fields.
+	Query  string
+	Search []Field
+
+	Sort  Field
+	Order enum.Order
+
+	// Enums contains the accepted values of the filtered enum fields.
+	Enums map[Field][]string
+}
+
+// OrderBy returns the sort terms of the filter, always ending with the ID tiebreaker.
+func (f *Filter) OrderBy() []SortTerm {
+	terms := make([]SortTerm, 0, 2)
+	if f.Sort != "" && f.Sort != FieldID {
+		terms = append(terms, SortTerm{Field: f.Sort, Order: f.Order})
+	}
+
+	return append(terms, SortTerm{Field: FieldID, Order: f.Order})
+}
+
+// Supports returns true if the enum field accepts the value.
+func (e EnumField) Supports(value string) bool {
+	return slices.Contains(e.Values, value)
+}
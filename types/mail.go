@@ -0,0 +1,34 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// MailFailure holds the failed delivery of an email to a single recipient.
+// Retries of the same delivery are tracked in the same record.
+type MailFailure struct {
+	ID        int64  `json:"id"`
+	JobUID    string `json:"job_uid"`
+	Recipient string `json:"recipient"`
+	Subject   string `json:"subject"`
+	Error     string `json:"error"`
+	Attempts  int    `json:"attempts"`
+	Created   int64  `json:"created"`
+	Updated   int64  `json:"updated"`
+}
+
+// MailFailureFilter stores mail failure query parameters.
+type MailFailureFilter struct {
+	Pagination
+	Recipient string `json:"recipient"`
+}
@@ -0,0 +1,26 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// MigrationStatus describes the state of the database schema relative to the migrations known by the binary.
+type MigrationStatus struct {
+	Driver         string   `json:"driver"`
+	CurrentVersion string   `json:"current_version"`
+	LatestVersion  string   `json:"latest_version"`
+	Pending        []string `json:"pending"`
+
+	// Compatible is false if the database schema is newer than the latest migration known by the binary.
+	Compatible bool `json:"compatible"`
+}
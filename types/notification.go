@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// Notification is an in-app notification of a user.
+type Notification struct {
+	ID          int64                 `json:"id"`
+	PrincipalID int64                 `json:"-"`
+	Type        enum.NotificationType `json:"type"`
+	RepoID      int64                 `json:"repo_id"`
+	// ResourceID is the ID of the pull request or the execution the notification is about.
+	ResourceID int64           `json:"resource_id"`
+	ActorID    *int64          `json:"-"`
+	Payload    json.RawMessage `json:"payload"`
+	Read       bool            `json:"read"`
+	Created    int64           `json:"created"`
+
+	Actor *PrincipalInfo `json:"actor,omitempty"`
+}
+
+// NotificationPullReqPayload is the payload of a pull request notification.
+type NotificationPullReqPayload struct {
+	Number   int64                      `json:"number"`
+	Title    string                     `json:"title"`
+	Decision enum.PullReqReviewDecision `json:"decision,omitempty"`
+}
+
+// NotificationExecutionPayload is the payload of a pipeline execution notification.
+type NotificationExecutionPayload struct {
+	PipelineID      int64         `json:"pipeline_id"`
+	ExecutionNumber int64         `json:"execution_number"`
+	Ref             string        `json:"ref"`
+	Status          enum.CIStatus `json:"status"`
+}
+
+// NotificationFilter stores notification query parameters.
+type NotificationFilter struct {
+	Pagination
+	OnlyUnread bool `json:"only_unread"`
+}
+
+// NotificationCount contains the number of notifications of a user.
+type NotificationCount struct {
+	Unread int64 `json:"unread"`
+}
+
+// NotificationSubscription defines if a user wants to be notified about a repository or a pull request.
+// Authors and reviewers of pull requests are subscribed automatically,
+// an explicit unsubscribe is kept to prevent the automatic subscription from taking effect again.
+type NotificationSubscription struct {
+	PrincipalID  int64                         `json:"-"`
+	ResourceType enum.SubscriptionResourceType `json:"resource_type"`
+	ResourceID   int64                         `json:"resource_id"`
+	Subscribed   bool                          `json:"subscribed"`
+	Created      int64                         `json:"created"`
+	Updated      int64                         `json:"updated"`
+}
@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Nullable is a JSON input field that distinguishes between a field that is absent,
+// a field that is explicitly set to null and a field that is set to a value.
+// It's intended to be used in partial update (PATCH) input structs.
+type Nullable[T any] struct {
+	// Set is true if the field was present in the input (including an explicit null).
+	Set bool
+	// Value is the provided value, nil if the field was absent or explicitly set to null.
+	Value *T
+}
+
+// IsNull returns true if the field was present in the input and explicitly set to null.
+func (n Nullable[T]) IsNull() bool {
+	return n.Set && n.Value == nil
+}
+
+// UnmarshalJSON is only called by the decoder for fields that are present in the input.
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	n.Set = true
+
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		n.Value = nil
+		return nil
+	}
+
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	n.Value = &v
+
+	return nil
+}
+
+// MarshalJSON outputs null if no value is provided.
+func (n Nullable[T]) MarshalJSON() ([]byte, error) {
+	if n.Value == nil {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(*n.Value)
+}
@@ -0,0 +1,111 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNullable_UnmarshalJSON(t *testing.T) {
+	type input struct {
+		Text Nullable[string] `json:"text"`
+		Num  Nullable[int64]  `json:"num"`
+	}
+
+	tests := []struct {
+		name     string
+		data     string
+		wantSet  bool
+		wantNull bool
+		wantText string
+	}{
+		{
+			name:    "absent",
+			data:    `{}`,
+			wantSet: false,
+		},
+		{
+			name:     "null",
+			data:     `{"text":null}`,
+			wantSet:  true,
+			wantNull: true,
+		},
+		{
+			name:     "empty",
+			data:     `{"text":""}`,
+			wantSet:  true,
+			wantText: "",
+		},
+		{
+			name:     "value",
+			data:     `{"text":"abc"}`,
+			wantSet:  true,
+			wantText: "abc",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var in input
+			if err := json.Unmarshal([]byte(test.data), &in); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if in.Text.Set != test.wantSet {
+				t.Errorf("set: want=%t got=%t", test.wantSet, in.Text.Set)
+			}
+			if in.Text.IsNull() != test.wantNull {
+				t.Errorf("null: want=%t got=%t", test.wantNull, in.Text.IsNull())
+			}
+			if test.wantSet && !test.wantNull && (in.Text.Value == nil || *in.Text.Value != test.wantText) {
+				t.Errorf("value: want=%q got=%v", test.wantText, in.Text.Value)
+			}
+			if in.Num.Set {
+				t.Errorf("unrelated field should not be set")
+			}
+		})
+	}
+}
+
+func TestNullable_UnmarshalJSONInvalid(t *testing.T) {
+	var n Nullable[int64]
+	if err := json.Unmarshal([]byte(`"abc"`), &n); err == nil {
+		t.Errorf("expected an error for an invalid value")
+	}
+}
+
+func TestNullable_MarshalJSON(t *testing.T) {
+	v := "abc"
+	tests := []struct {
+		name string
+		in   Nullable[string]
+		want string
+	}{
+		{name: "unset", in: Nullable[string]{}, want: `null`},
+		{name: "null", in: Nullable[string]{Set: true}, want: `null`},
+		{name: "value", in: Nullable[string]{Set: true, Value: &v}, want: `"abc"`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := json.Marshal(test.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if string(data) != test.want {
+				t.Errorf("want=%s got=%s", test.want, data)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// Operation is a long-running operation that is executed in the background by one or more jobs.
+// Operations are referenced by their UID, which is returned by the request that started the operation.
+type Operation struct {
+	ID           int64                   `db:"operation_id"`
+	UID          string                  `db:"operation_uid"`
+	Type         enum.OperationType      `db:"operation_type"`
+	ResourceType enum.ParentResourceType `db:"operation_resource_type"`
+	ResourceID   int64                   `db:"operation_resource_id"`
+	// JobUID is set if the operation is executed by a single job.
+	JobUID string `db:"operation_job_uid"`
+	// JobGroupID is set if the operation is executed by a group of jobs.
+	JobGroupID string `db:"operation_job_group_id"`
+	CreatedBy  int64  `db:"operation_created_by"`
+	Created    int64  `db:"operation_created"`
+}
+
+// OperationError describes why an operation failed.
+type OperationError struct {
+	Message string `json:"message"`
+}
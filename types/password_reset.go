@@ -0,0 +1,25 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// PasswordReset represents an admin initiated password reset of a user.
+// Only the hash of the one-time reset token is stored.
+type PasswordReset struct {
+	PrincipalID int64  `db:"password_reset_principal_id" json:"-"`
+	TokenHash   string `db:"password_reset_token_hash"   json:"-"`
+	Expires     int64  `db:"password_reset_expires"      json:"expires"`
+	CreatedBy   int64  `db:"password_reset_created_by"   json:"created_by"`
+	Created     int64  `db:"password_reset_created"      json:"created"`
+}
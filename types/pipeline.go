@@ -27,7 +27,13 @@ type Pipeline struct {
 	RepoID        int64  `db:"pipeline_repo_id"         json:"repo_id"`
 	DefaultBranch string `db:"pipeline_default_branch"  json:"default_branch"`
 	ConfigPath    string `db:"pipeline_config_path"     json:"config_path"`
-	Created       int64  `db:"pipeline_created"         json:"created"`
+	// ConcurrencyLimit is the max number of executions running at once, 0 means unlimited.
+	ConcurrencyLimit int `db:"pipeline_concurrency_limit" json:"concurrency_limit"`
+	// CancelSuperseded cancels queued or running executions of the same ref once a newer one is triggered.
+	CancelSuperseded bool `db:"pipeline_cancel_superseded" json:"cancel_superseded"`
+	// Timeout is the max duration (in ms) an execution may run, 0 means the instance wide maximum.
+	Timeout int64 `db:"pipeline_timeout"         json:"timeout"`
+	Created int64 `db:"pipeline_created"         json:"created"`
 	// Execution contains information about the latest execution if available
 	Execution *Execution `db:"-"                        json:"execution,omitempty"`
 	Updated   int64      `db:"pipeline_updated"         json:"updated"`
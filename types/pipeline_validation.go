@@ -0,0 +1,33 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// PipelineProblem is a single problem found while validating a pipeline yaml.
+// Line and Column are 1-based and omitted if the problem can't be attributed to a position in the yaml.
+type PipelineProblem struct {
+	Severity enum.PipelineProblemSeverity `json:"severity"`
+	Message  string                       `json:"message"`
+	Line     int                          `json:"line,omitempty"`
+	Column   int                          `json:"column,omitempty"`
+}
+
+// PipelineValidation is the result of validating a pipeline yaml.
+// The yaml is valid if none of the problems is an error, warnings are allowed.
+type PipelineValidation struct {
+	Valid    bool              `json:"valid"`
+	Problems []PipelineProblem `json:"problems"`
+}
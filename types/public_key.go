@@ -21,6 +21,8 @@ type PublicKey struct {
 	PrincipalID int64               `json:"-"` // API always returns keys for the same user
 	Created     int64               `json:"created"`
 	Verified    *int64              `json:"verified"`
+	LastUsed    *int64              `json:"last_used"`
+	ValidUntil  *int64              `json:"valid_until"`
 	Identifier  string              `json:"identifier"`
 	Usage       enum.PublicKeyUsage `json:"usage"`
 	Fingerprint string              `json:"fingerprint"`
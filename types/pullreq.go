@@ -62,6 +62,9 @@ type PullReq struct {
 	Stats  PullReqStats   `json:"stats"`
 
 	Labels []*LabelPullReqAssignmentInfo `json:"labels,omitempty"`
+
+	// CodeOwners contains the owners of the changed files, it's only populated for a single pull request.
+	CodeOwners *CodeOwnersOwnership `json:"code_owners,omitempty"`
 }
 
 // DiffStats shows total number of commits and modified files.
@@ -196,6 +199,7 @@ type MergeResponse struct {
 	SHA            string           `json:"sha,omitempty"`
 	BranchDeleted  bool             `json:"branch_deleted,omitempty"`
 	RuleViolations []RuleViolations `json:"rule_violations,omitempty"`
+	Warnings       []string         `json:"warnings,omitempty"`
 
 	// values only returned on dryrun
 	DryRun                              bool               `json:"dry_run,omitempty"`
@@ -0,0 +1,37 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// RecentVisit is the last visit of a repository or space by a user.
+type RecentVisit struct {
+	PrincipalID int64                `json:"-"`
+	Type        enum.RecentVisitType `json:"type"`
+	ResourceID  int64                `json:"resource_id"`
+	Visited     int64                `json:"visited"`
+}
+
+// RecentVisitFilter stores recent visit query parameters.
+type RecentVisitFilter struct {
+	Type enum.RecentVisitType `json:"type"`
+}
+
+// RecentVisitOutput is a recently visited repository or space.
+type RecentVisitOutput struct {
+	RecentVisit
+	Repo  *Repository `json:"repo,omitempty"`
+	Space *Space      `json:"space,omitempty"`
+}
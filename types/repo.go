@@ -15,7 +15,11 @@
 package types
 
 import (
+	"slices"
+	"strings"
+
 	"github.com/harness/gitness/types/enum"
+	"github.com/harness/gitness/types/listfilter"
 )
 
 // Repository represents a code repository.
@@ -32,6 +36,9 @@ type Repository struct {
 	Updated     int64  `json:"updated" yaml:"updated"`
 	Deleted     *int64 `json:"deleted,omitempty" yaml:"deleted"`
 
+	// LastActivity is the time of the most recent activity (push, pull request, pipeline) in the repository.
+	LastActivity int64 `json:"last_activity" yaml:"last_activity"`
+
 	// Size of the repository in KiB.
 	Size int64 `json:"size" yaml:"size"`
 	// SizeUpdated is the time when the Size was last updated.
@@ -47,9 +54,22 @@ type Repository struct {
 	NumClosedPulls int `json:"num_closed_pulls" yaml:"num_closed_pulls"`
 	NumOpenPulls   int `json:"num_open_pulls" yaml:"num_open_pulls"`
 	NumMergedPulls int `json:"num_merged_pulls" yaml:"num_merged_pulls"`
+	NumStars       int `json:"num_stars" yaml:"num_stars"`
+
+	State enum.RepoState `json:"state" yaml:"-"`
+	// StateUntil is the time the repository is expected to leave its current state (0 if unknown).
+	StateUntil int64 `json:"state_until,omitempty" yaml:"-"`
+	IsEmpty    bool  `json:"is_empty,omitempty" yaml:"is_empty"`
 
-	State   enum.RepoState `json:"state" yaml:"-"`
-	IsEmpty bool           `json:"is_empty,omitempty" yaml:"is_empty"`
+	// Website is an optional homepage of the repository.
+	Website string `json:"website,omitempty" yaml:"website"`
+	// ContactID is the principal that is the primary contact for the repository (nil if not set).
+	ContactID *int64 `json:"contact_id,omitempty" yaml:"contact_id"`
+	// ShowReadme indicates whether the README is rendered on the repository overview page.
+	ShowReadme bool `json:"show_readme" yaml:"show_readme"`
+
+	// Topics are stored separately from the repository and are backfilled by the API layer.
+	Topics []string `json:"topics,omitempty" yaml:"-"`
 
 	// git urls
 	GitURL    string `json:"git_url" yaml:"-"`
@@ -64,6 +84,11 @@ func (r Repository) Clone() Repository {
 		deleted = &id
 	}
 	r.Deleted = deleted
+	if r.ContactID != nil {
+		contactID := *r.ContactID
+		r.ContactID = &contactID
+	}
+	r.Topics = slices.Clone(r.Topics)
 
 	return r
 }
@@ -77,6 +102,14 @@ type RepositorySizeInfo struct {
 	SizeUpdated int64 `json:"size_updated"`
 }
 
+// RepoLicense is the license detected in the license file of a repository.
+type RepoLicense struct {
+	// SPDXID is the SPDX identifier of the license.
+	SPDXID string `json:"spdx_id"`
+	// Confidence is the similarity of the license file to the license template, between 0 and 1.
+	Confidence float64 `json:"confidence"`
+}
+
 func (r Repository) GetGitUID() string {
 	return r.GitUID
 }
@@ -90,7 +123,58 @@ type RepoFilter struct {
 	Order             enum.Order    `json:"order"`
 	DeletedAt         *int64        `json:"deleted_at,omitempty"`
 	DeletedBeforeOrAt *int64        `json:"deleted_before_or_at,omitempty"`
+	OnlyPublic        bool          `json:"only_public"`
 	Recursive         bool
+	// Topics limits the repos to the ones with the topics - with any of them, or all of them if TopicsMatchAll is set.
+	Topics         []string `json:"topics"`
+	TopicsMatchAll bool     `json:"topics_match_all"`
+	// IncludePinned returns the pinned repos of the space before the other repos.
+	IncludePinned bool `json:"include_pinned"`
+	// RepoIDs limits the repos to the ones with the IDs, ExcludedRepoIDs excludes the repos with the IDs.
+	RepoIDs         []int64 `json:"-"`
+	ExcludedRepoIDs []int64 `json:"-"`
+}
+
+// Repository fields supported by list filters.
+const (
+	RepoFieldIdentifier   listfilter.Field = "identifier"
+	RepoFieldCreated      listfilter.Field = "created"
+	RepoFieldUpdated      listfilter.Field = "updated"
+	RepoFieldDeleted      listfilter.Field = "deleted"
+	RepoFieldLastActivity listfilter.Field = "last_activity"
+	RepoFieldStars        listfilter.Field = "stars"
+)
+
+// ListFilter returns the pagination, search and sort part of the repo filter as a list filter.
+func (f *RepoFilter) ListFilter() *listfilter.Filter {
+	sort := listfilter.Field(f.Sort.String())
+
+	// TODO [CODE-1363]: remove after identifier migration.
+	if f.Sort == enum.RepoAttrUID || f.Sort == enum.RepoAttrNone {
+		sort = RepoFieldIdentifier
+	}
+
+	return &listfilter.Filter{
+		Page:   f.Page,
+		Size:   f.Size,
+		Query:  strings.ToLower(f.Query),
+		Search: []listfilter.Field{RepoFieldIdentifier},
+		Sort:   sort,
+		Order:  f.Order,
+	}
+}
+
+// RepoTopicFilter stores repo topic query parameters.
+type RepoTopicFilter struct {
+	Query      string `json:"query"`
+	Recursive  bool   `json:"recursive"`
+	OnlyPublic bool   `json:"only_public"`
+}
+
+// RepoTopicCount is the number of repositories with the topic.
+type RepoTopicCount struct {
+	Topic string `json:"topic"`
+	Count int64  `json:"count"`
 }
 
 // RepositoryGitInfo holds git info for a repository.
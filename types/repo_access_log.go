@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// RepoAccessLog is an entry of the access log of a repository, recorded for every git operation (clone, fetch, push).
+type RepoAccessLog struct {
+	ID     int64 `json:"id"`
+	RepoID int64 `json:"repo_id"`
+	// PrincipalID is nil for anonymous access to public repositories.
+	PrincipalID   *int64              `json:"-"`
+	Operation     enum.GitServiceType `json:"operation"`
+	ClientIP      string              `json:"client_ip"`
+	BytesSent     int64               `json:"bytes_sent"`
+	BytesReceived int64               `json:"bytes_received"`
+	// Duration is the duration of the git operation in milliseconds.
+	Duration int64 `json:"duration"`
+	Success  bool  `json:"success"`
+	Created  int64 `json:"created"`
+
+	Principal *PrincipalInfo `json:"principal,omitempty"`
+}
+
+// RepoAccessLogFilter stores repository access log query parameters.
+type RepoAccessLogFilter struct {
+	Page        int   `json:"page"`
+	Size        int   `json:"size"`
+	PrincipalID int64 `json:"principal_id"`
+	Since       int64 `json:"since"`
+	Until       int64 `json:"until"`
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// RepoActivity represents a single event in the activity feed of a repository.
+type RepoActivity struct {
+	ID          int64                 `json:"id"`
+	RepoID      int64                 `json:"repo_id"`
+	Type        enum.RepoActivityType `json:"type"`
+	PrincipalID int64                 `json:"-"`
+	Payload     json.RawMessage       `json:"payload"`
+	Created     int64                 `json:"created"`
+
+	Principal *PrincipalInfo `json:"principal,omitempty"`
+}
+
+// RepoActivityPushPayload is the payload of a push activity.
+type RepoActivityPushPayload struct {
+	Ref    string `json:"ref"`
+	OldSHA string `json:"old_sha,omitempty"`
+	NewSHA string `json:"new_sha,omitempty"`
+	Forced bool   `json:"forced,omitempty"`
+}
+
+// RepoActivityPullReqPayload is the payload of a pull request activity.
+type RepoActivityPullReqPayload struct {
+	PullReqID     int64  `json:"pullreq_id"`
+	PullReqNumber int64  `json:"pullreq_number"`
+	SHA           string `json:"sha,omitempty"`
+}
+
+// RepoActivityPipelinePayload is the payload of a pipeline activity.
+type RepoActivityPipelinePayload struct {
+	PipelineID      int64         `json:"pipeline_id"`
+	ExecutionNumber int64         `json:"execution_number"`
+	Ref             string        `json:"ref,omitempty"`
+	Status          enum.CIStatus `json:"status"`
+}
+
+// RepoActivityDefaultBranchPayload is the payload of a default branch update activity.
+type RepoActivityDefaultBranchPayload struct {
+	OldName string `json:"old_name"`
+	NewName string `json:"new_name"`
+	// Automatic is true if the default branch was detected from the first push to the repository.
+	Automatic bool `json:"automatic,omitempty"`
+}
+
+// RepoActivityFilter stores repository activity query parameters.
+type RepoActivityFilter struct {
+	Page  int                     `json:"page"`
+	Size  int                     `json:"size"`
+	Types []enum.RepoActivityType `json:"types"`
+	Since int64                   `json:"since"`
+	Until int64                   `json:"until"`
+
+	// internal use only
+	Ref string `json:"-"`
+}
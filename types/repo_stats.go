@@ -0,0 +1,103 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// RepoPush represents a single reference update pushed to a repository.
+type RepoPush struct {
+	ID          int64  `db:"repo_push_id"`
+	RepoID      int64  `db:"repo_push_repo_id"`
+	PrincipalID int64  `db:"repo_push_principal_id"`
+	Ref         string `db:"repo_push_ref"`
+	CommitCount int64  `db:"repo_push_commit_count"`
+	Created     int64  `db:"repo_push_created"`
+}
+
+// RepoPushBucket contains the number of pushes to a repository during a single day.
+type RepoPushBucket struct {
+	Day    int64 `db:"day" json:"day"` // start of the day (UTC) as unix milliseconds
+	Pushes int64 `db:"pushes" json:"pushes"`
+}
+
+// RepoContributor contains the number of commits a principal pushed to a repository.
+type RepoContributor struct {
+	PrincipalID int64 `db:"principal_id" json:"principal_id"`
+	Commits     int64 `db:"commits"      json:"commits"`
+}
+
+// RepoStats contains the precomputed statistics of a repository.
+type RepoStats struct {
+	RepoID       int64
+	Pushes7Days  int64
+	Pushes30Days int64
+	Branches     int64
+	Tags         int64
+	PushBuckets  []RepoPushBucket
+	Contributors map[enum.RepoStatsWindow][]RepoContributor
+	Updated      int64
+}
+
+// RepoStatsTotals contains the statistics summed up over all repositories.
+type RepoStatsTotals struct {
+	Pushes7Days  int64
+	Pushes30Days int64
+	Branches     int64
+	Tags         int64
+}
+
+// RepoContributorInfo is a top contributor of a repository as returned by the API.
+type RepoContributorInfo struct {
+	Principal *PrincipalInfo `json:"principal"`
+	Commits   int64          `json:"commits"`
+}
+
+// RepoStatsOutput is the repository statistics as returned by the API.
+type RepoStatsOutput struct {
+	Pushes7Days     int64                 `json:"pushes_7d"`
+	Pushes30Days    int64                 `json:"pushes_30d"`
+	Branches        int64                 `json:"branches"`
+	Tags            int64                 `json:"tags"`
+	PushBuckets     []RepoPushBucket      `json:"push_buckets"`
+	Window          enum.RepoStatsWindow  `json:"window"`
+	TopContributors []RepoContributorInfo `json:"top_contributors"`
+	Updated         int64                 `json:"updated"`
+}
+
+// ContributorWeekStats contains the contributions of an author during a single week.
+type ContributorWeekStats struct {
+	Week      int64 `json:"week"` // start of the week (Sunday 00:00 UTC) as unix timestamp
+	Commits   int64 `json:"commits"`
+	Additions int64 `json:"additions"`
+	Deletions int64 `json:"deletions"`
+}
+
+// ContributorStats contains the contributions of an author computed from the git history.
+type ContributorStats struct {
+	Identity  Identity               `json:"identity"`
+	Commits   int64                  `json:"commits"`
+	Additions int64                  `json:"additions"`
+	Deletions int64                  `json:"deletions"`
+	Weeks     []ContributorWeekStats `json:"weeks"`
+}
+
+// RepoContributorStats contains the contributor statistics of a repository at a commit.
+// Since and Until are unix timestamps, extended to full weeks.
+type RepoContributorStats struct {
+	SHA          string             `json:"sha"`
+	Since        int64              `json:"since,omitempty"`
+	Until        int64              `json:"until,omitempty"`
+	Contributors []ContributorStats `json:"contributors"`
+}
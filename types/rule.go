@@ -128,6 +128,7 @@ type RuleType string
 type RuleFilter struct {
 	ListQueryFilter
 	States []enum.RuleState
+	Types  []RuleType
 	Sort   enum.RuleSort `json:"sort"`
 	Order  enum.Order    `json:"order"`
 }
@@ -163,6 +164,15 @@ func (violations *RuleViolations) Addf(code, format string, params ...any) {
 	})
 }
 
+// AddWithParams adds a violation with params that provide additional details, which aren't part of the message.
+func (violations *RuleViolations) AddWithParams(code, message string, params ...any) {
+	violations.Violations = append(violations.Violations, Violation{
+		Code:    code,
+		Message: message,
+		Params:  params,
+	})
+}
+
 func (violations *RuleViolations) IsCritical() bool {
 	return violations.Rule.State == enum.RuleStateActive && len(violations.Violations) > 0 && !violations.Bypassed
 }
@@ -0,0 +1,51 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "time"
+
+// Runner is a machine registered with the server to execute pipeline stages.
+type Runner struct {
+	ID int64 `json:"-"`
+	// SpaceID is the space the runner executes stages for, 0 if the runner serves the whole instance.
+	SpaceID     int64             `json:"space_id,omitempty"`
+	Identifier  string            `json:"identifier"`
+	Description string            `json:"description"`
+	Labels      map[string]string `json:"labels"`
+	// Capacity is the maximum number of stages the runner executes concurrently.
+	Capacity int    `json:"capacity"`
+	OS       string `json:"os"`
+	Arch     string `json:"arch"`
+	// Embedded is set for the runner that is part of the server itself.
+	Embedded  bool   `json:"embedded"`
+	TokenHash string `json:"-"`
+	// Draining is set once the runner is deleted while it still has stages in flight.
+	// A draining runner doesn't receive new stages and is removed once its stages completed.
+	Draining      bool  `json:"draining"`
+	LastHeartbeat int64 `json:"last_heartbeat"`
+	Created       int64 `json:"created"`
+	Updated       int64 `json:"updated"`
+	Version       int64 `json:"-"`
+
+	// Online and Running are derived from the age of the last heartbeat
+	// and the stages currently assigned to the runner.
+	Online  bool `json:"online"`
+	Running int  `json:"running"`
+}
+
+// IsOnline returns true if the runner sent a heartbeat within the timeout.
+func (r *Runner) IsOnline(now int64, heartbeatTimeout time.Duration) bool {
+	return r.LastHeartbeat > 0 && now-r.LastHeartbeat <= heartbeatTimeout.Milliseconds()
+}
@@ -15,7 +15,17 @@
 // Package types defines common data structures.
 package types
 
-import "github.com/harness/gitness/types/enum"
+import (
+	"github.com/harness/gitness/types/enum"
+	"github.com/harness/gitness/types/listfilter"
+)
+
+// Service account fields supported by list filters.
+const (
+	ServiceAccountFieldUID         listfilter.Field = "uid"
+	ServiceAccountFieldDisplayName listfilter.Field = "display_name"
+	ServiceAccountFieldCreated     listfilter.Field = "created"
+)
 
 type (
 	// ServiceAccount is a principal representing a service account.
@@ -37,10 +37,13 @@ type Space struct {
 	Path        string `json:"path"`
 	Identifier  string `json:"identifier"`
 	Description string `json:"description"`
-	CreatedBy   int64  `json:"created_by"`
-	Created     int64  `json:"created"`
-	Updated     int64  `json:"updated"`
-	Deleted     *int64 `json:"deleted,omitempty"`
+	// ExecutionLimit caps the number of pipeline executions running at once
+	// across all repositories in the space (and its subspaces); 0 means unlimited.
+	ExecutionLimit int    `json:"execution_limit"`
+	CreatedBy      int64  `json:"created_by"`
+	Created        int64  `json:"created"`
+	Updated        int64  `json:"updated"`
+	Deleted        *int64 `json:"deleted,omitempty"`
 }
 
 type SpaceParentData struct {
@@ -59,4 +62,5 @@ type SpaceFilter struct {
 	DeletedAt         *int64         `json:"deleted_at,omitempty"`
 	DeletedBeforeOrAt *int64         `json:"deleted_before_or_at,omitempty"`
 	Recursive         bool           `json:"recursive"`
+	OnlyPublic        bool           `json:"only_public"`
 }
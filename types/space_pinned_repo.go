@@ -0,0 +1,25 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// SpacePinnedRepo is a repository pinned in a space to highlight it.
+type SpacePinnedRepo struct {
+	SpaceID int64 `json:"space_id"`
+	RepoID  int64 `json:"repo_id"`
+	// Order is the position of the repository among the pinned repositories of the space, starting at 0.
+	Order     int   `json:"order"`
+	Created   int64 `json:"created"`
+	CreatedBy int64 `json:"created_by"`
+}
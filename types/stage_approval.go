@@ -0,0 +1,54 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"github.com/harness/gitness/types/enum"
+)
+
+// StageApprovalGate is the approval requirement declared for a pipeline stage.
+// A stage with a gate doesn't get scheduled until an eligible approver approves it.
+type StageApprovalGate struct {
+	// Roles lists the space membership roles whose members are allowed to approve the stage.
+	Roles []enum.MembershipRole `json:"roles,omitempty"`
+
+	// Approvers lists the UIDs of principals that are allowed to approve the stage.
+	Approvers []string `json:"approvers,omitempty"`
+
+	// Timeout is the maximum time in milliseconds the stage is waiting for approval.
+	Timeout int64 `json:"timeout"`
+
+	// OnTimeout defines whether the stage is failed or skipped after the timeout.
+	OnTimeout enum.StageApprovalTimeoutAction `json:"on_timeout"`
+
+	// Deadline is the time (unix millis) at which the gate times out.
+	// It's set once the stage starts waiting for approval.
+	Deadline int64 `json:"deadline,omitempty"`
+}
+
+// StageApproval is a decision made on a stage approval gate.
+type StageApproval struct {
+	ID          int64                      `json:"id"`
+	ExecutionID int64                      `json:"execution_id"`
+	StageID     int64                      `json:"-"`
+	StageNumber int64                      `json:"stage_number"`
+	RepoID      int64                      `json:"repo_id"`
+	Decision    enum.StageApprovalDecision `json:"decision"`
+	Comment     string                     `json:"comment,omitempty"`
+	CreatedBy   int64                      `json:"-"`
+	Created     int64                      `json:"created"`
+
+	Author *PrincipalInfo `json:"author,omitempty"`
+}
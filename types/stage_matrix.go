@@ -0,0 +1,45 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// StageMatrix is the matrix cell a stage was expanded from.
+type StageMatrix struct {
+	// Group is the name of the pipeline that declared the matrix.
+	Group string `json:"group"`
+
+	// Axes contains the axis values of the cell.
+	Axes map[string]string `json:"axes"`
+
+	// AllowFailure indicates that a failure of the cell doesn't fail the execution.
+	AllowFailure bool `json:"allow_failure,omitempty"`
+}
+
+// ExecutionMatrix groups the stages of an execution that were expanded from the same matrix.
+type ExecutionMatrix struct {
+	// Group is the name of the pipeline that declared the matrix.
+	Group string `json:"group"`
+
+	// Axes contains all values of each axis of the matrix.
+	Axes map[string][]string `json:"axes"`
+
+	// Status is the aggregated status of all cells,
+	// failed cells that are allowed to fail don't fail the matrix.
+	Status enum.CIStatus `json:"status"`
+
+	// Stages contains the stage numbers of the cells.
+	Stages []int64 `json:"stages"`
+}
@@ -37,6 +37,9 @@ type Step struct {
 	Image     string        `json:"image,omitempty"`
 	Detached  bool          `json:"detached"`
 	Schema    string        `json:"schema,omitempty"`
+	// Timeout is the effective max duration (in ms) of the step, measured from its start.
+	Timeout  int64 `json:"timeout,omitempty"`
+	TimedOut bool  `json:"timed_out,omitempty"`
 }
 
 // Pretty print a step.
@@ -32,6 +32,11 @@ type Token struct {
 	// IssuedAt is the unix time at which the token was issued.
 	IssuedAt  int64 `db:"token_issued_at"          json:"issued_at"`
 	CreatedBy int64 `db:"token_created_by"         json:"created_by"`
+	// UserAgent and IPAddress are the user agent and ip address of the request that created the token.
+	UserAgent string `db:"token_user_agent"         json:"user_agent,omitempty"`
+	IPAddress string `db:"token_ip_address"         json:"ip_address,omitempty"`
+	// LastUsedAt is the unix time at which the token was last used (tracked for session tokens only).
+	LastUsedAt *int64 `db:"token_last_used_at"       json:"last_used_at,omitempty"`
 }
 
 // TODO [CODE-1363]: remove after identifier migration.
@@ -47,6 +52,24 @@ func (t Token) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// PrincipalTokens contains all tokens of a principal that haven't been revoked, indexed by token id.
+type PrincipalTokens struct {
+	PrincipalID int64
+	Tokens      map[int64]*Token
+}
+
+// UserSession is an active login session of a user.
+type UserSession struct {
+	Identifier string `json:"identifier"`
+	IssuedAt   int64  `json:"issued_at"`
+	ExpiresAt  *int64 `json:"expires_at,omitempty"`
+	LastUsedAt *int64 `json:"last_used_at,omitempty"`
+	UserAgent  string `json:"user_agent"`
+	IPAddress  string `json:"ip_address"`
+	// Current is true for the session that was used to make the request.
+	Current bool `json:"current"`
+}
+
 // TokenResponse is returned as part of token creation for PAT / SAT / User Session.
 type TokenResponse struct {
 	AccessToken string `json:"access_token"`
@@ -0,0 +1,62 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// UsageRollup is the daily resource usage of a repository.
+type UsageRollup struct {
+	RepoID int64 `json:"repo_id"`
+	// Day is the start of the UTC day (unix millis).
+	Day int64 `json:"day"`
+	// CIDuration is the sum of the step runtimes of the day (millis).
+	CIDuration int64 `json:"ci_duration"`
+	// GitSize and ArtifactsSize are the highest storage sizes observed during the day (bytes).
+	GitSize       int64 `json:"git_size"`
+	ArtifactsSize int64 `json:"artifacts_size"`
+	Updated       int64 `json:"updated"`
+}
+
+// UsageBucket is the resource usage of a single UTC day.
+type UsageBucket struct {
+	Day           int64   `json:"day"`
+	CIMinutes     float64 `json:"ci_minutes"`
+	GitSize       int64   `json:"git_size"`
+	ArtifactsSize int64   `json:"artifacts_size"`
+}
+
+// UsageEntry is the resource usage of a child space (including all its descendants) or a repository of a space.
+type UsageEntry struct {
+	Type       enum.ResourceType `json:"type"`
+	Identifier string            `json:"identifier"`
+	Path       string            `json:"path"`
+
+	// CIMinutes is the total of the CI minutes of the buckets,
+	// GitSize and ArtifactsSize are the high-water marks of the buckets.
+	CIMinutes     float64 `json:"ci_minutes"`
+	GitSize       int64   `json:"git_size"`
+	ArtifactsSize int64   `json:"artifacts_size"`
+
+	Buckets []UsageBucket `json:"buckets"`
+}
+
+// UsageReport is the daily resource usage of the children of a space.
+type UsageReport struct {
+	SpacePath string `json:"space_path"`
+	// From and To are the start of the first and last UTC day of the report (unix millis).
+	From    int64        `json:"from"`
+	To      int64        `json:"to"`
+	Entries []UsageEntry `json:"entries"`
+}
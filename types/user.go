@@ -53,6 +53,8 @@ type (
 		Sort  enum.UserAttr `json:"sort"`
 		Order enum.Order    `json:"order"`
 		Admin bool          `json:"admin"`
+		// Blocked restricts the users to blocked (true) or unblocked (false) users if set.
+		Blocked *bool `json:"blocked"`
 	}
 )
 
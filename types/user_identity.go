@@ -0,0 +1,24 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// UserIdentity links a user to its identity at an external identity provider.
+type UserIdentity struct {
+	ID          int64  `db:"user_identity_id"           json:"-"`
+	PrincipalID int64  `db:"user_identity_principal_id" json:"principal_id"`
+	Provider    string `db:"user_identity_provider"     json:"provider"`
+	Subject     string `db:"user_identity_subject"      json:"subject"`
+	Created     int64  `db:"user_identity_created"      json:"created"`
+}
@@ -0,0 +1,28 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// UserPreference stores the default sort order and filters a user has saved for a list view.
+// Params holds query parameters that are applied to the list requests of the view,
+// unless the request provides them explicitly.
+type UserPreference struct {
+	PrincipalID int64                   `json:"-"`
+	View        enum.UserPreferenceView `json:"view"`
+	Params      map[string][]string     `json:"params"`
+	Created     int64                   `json:"created"`
+	Updated     int64                   `json:"updated"`
+}
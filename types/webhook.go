@@ -109,6 +109,7 @@ type WebhookFilter struct {
 
 // WebhookExecutionFilter stores WebhookExecution query parameters for listing.
 type WebhookExecutionFilter struct {
-	Page int `json:"page"`
-	Size int `json:"size"`
+	Page     int                   `json:"page"`
+	Size     int                   `json:"size"`
+	Triggers []enum.WebhookTrigger `json:"triggers"`
 }